@@ -17,6 +17,7 @@ import (
 	"github.com/skobkin/meshgo/internal/domain"
 	"github.com/skobkin/meshgo/internal/logging"
 	"github.com/skobkin/meshgo/internal/persistence"
+	"github.com/skobkin/meshgo/internal/platform"
 	"github.com/skobkin/meshgo/internal/projections"
 	"github.com/skobkin/meshgo/internal/radio"
 	"github.com/skobkin/meshgo/internal/radio/busmsg"
@@ -43,6 +44,7 @@ func run() error {
 	bluetoothAdapter := flag.String("bluetooth-adapter", "", "bluetooth adapter id (example: hci0)")
 	noSubscribe := flag.Bool("no-subscribe", false, "exit after initial config download completes")
 	listenFor := flag.Duration("listen-for", 0, "listen duration, e.g. 30s")
+	mute := flag.String("mute", "", "mute node events, e.g. '!1234abcd:position,telemetry;!00000001:core'; adds to config")
 	flag.Parse()
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
@@ -78,6 +80,14 @@ func run() error {
 		cfg.Connection.BluetoothAdapter = strings.TrimSpace(*bluetoothAdapter)
 	}
 
+	if strings.TrimSpace(*mute) != "" {
+		muted, err := config.ParseMutedNodeEvents(*mute)
+		if err != nil {
+			return fmt.Errorf("parse -mute: %w", err)
+		}
+		cfg.Logging.MutedNodeEvents = mergeMutedNodeEvents(cfg.Logging.MutedNodeEvents, muted)
+	}
+
 	if err := cfg.Validate(); err != nil {
 		return fmt.Errorf("invalid connection config: %w", err)
 	}
@@ -95,22 +105,39 @@ func run() error {
 	logger := logMgr.Logger("cli")
 	logger.Info("starting meshgo debug", "version", app.BuildVersion(), "build_date", app.BuildDateYMD())
 
-	db, err := persistence.Open(ctx, paths.DBFile)
+	database, err := app.OpenDatabase(ctx, paths, cfg.Persistence.EncryptDatabase, platform.NewSecretStore())
 	if err != nil {
 		return fmt.Errorf("open sqlite: %w", err)
 	}
 	defer func() {
-		if closeErr := db.Close(); closeErr != nil {
+		if closeErr := database.Close(); closeErr != nil {
 			logger.Warn("close sqlite", "error", closeErr)
 		}
 	}()
+	db := database.DB
 
 	nodeCoreRepo := persistence.NewNodeCoreRepo(db)
 	nodePositionRepo := persistence.NewNodePositionRepo(db)
 	nodeTelemetryRepo := persistence.NewNodeTelemetryRepo(db)
+	nodeSignalHistory := persistence.NewNodeSignalHistoryRepo(db)
 	chatRepo := persistence.NewChatRepo(db)
 	msgRepo := persistence.NewMessageRepo(db)
 	tracerouteRepo := persistence.NewTracerouteRepo(db)
+	statisticsRepo := persistence.NewStatisticsRepo(db)
+	deviceNamespaces := persistence.NewDeviceNamespaceRepo(db)
+	lastDeviceID, _, err := deviceNamespaces.LastConnected(ctx)
+	if err != nil {
+		return fmt.Errorf("read last connected device: %w", err)
+	}
+	deviceScope := persistence.NewDeviceScope(lastDeviceID)
+	nodeCoreRepo.UseDeviceScope(deviceScope)
+	nodePositionRepo.UseDeviceScope(deviceScope)
+	nodeTelemetryRepo.UseDeviceScope(deviceScope)
+	nodeSignalHistory.UseDeviceScope(deviceScope)
+	chatRepo.UseDeviceScope(deviceScope)
+	msgRepo.UseDeviceScope(deviceScope)
+	tracerouteRepo.UseDeviceScope(deviceScope)
+	statisticsRepo.UseDeviceScope(deviceScope)
 
 	nodesCore, err := nodeCoreRepo.ListSortedByLastHeard(ctx)
 	if err != nil {
@@ -143,7 +170,19 @@ func run() error {
 	chatStore.Start(ctx, b)
 
 	writer := persistence.NewWriterQueue(logMgr.Logger("persistence"), 256)
+	writer.EnableBatching(db, 250*time.Millisecond)
 	writer.Start(ctx)
+	defer func() {
+		stats := writer.Stats()
+		logger.Info(
+			"persistence writer summary",
+			"batches", stats.Batches,
+			"commands", stats.Commands,
+			"coalesced", stats.Coalesced,
+			"avg_batch_size", stats.AverageBatchSize(),
+			"max_latency", stats.MaxLatency,
+		)
+	}()
 	projections.StartPersistenceProjection(
 		ctx,
 		b,
@@ -151,10 +190,12 @@ func run() error {
 		nodeCoreRepo,
 		nodePositionRepo,
 		nodeTelemetryRepo,
+		nodeSignalHistory,
 		debugHistoryLimitsProvider{},
 		chatRepo,
 		msgRepo,
 		tracerouteRepo,
+		statisticsRepo,
 	)
 
 	codec, err := radio.NewMeshtasticCodec()
@@ -167,6 +208,12 @@ func run() error {
 		return fmt.Errorf("create transport: %w", err)
 	}
 	radioService := radio.NewService(logMgr.Logger("radio"), b, connTransport, codec)
+	radioService.OnLocalNodeChange(func(nodeID string) {
+		if _, err := deviceNamespaces.Activate(ctx, nodeID, time.Now()); err != nil {
+			logger.Warn("record device namespace", "device_id", nodeID, "error", err)
+		}
+		deviceScope.Set(nodeID)
+	})
 	initialDecodedSub := b.Subscribe(bus.TopicRadioFrom)
 	initialConnSub := b.Subscribe(bus.TopicConnStatus)
 	initialRawInSub := b.Subscribe(bus.TopicRawFrameIn)
@@ -176,6 +223,9 @@ func run() error {
 	defer b.Unsubscribe(initialRawInSub, bus.TopicRawFrameIn)
 	defer b.Unsubscribe(initialRawOutSub, bus.TopicRawFrameOut)
 	radioService.Start(ctx)
+	defer func() {
+		logger.Info("radio session summary", "duplicate_packets_dropped", radioService.DuplicatePacketsDropped())
+	}()
 
 	logger.Info(
 		"waiting for initial config completion",
@@ -198,7 +248,7 @@ func run() error {
 		return nil
 	}
 
-	watch(ctx, b, logger)
+	watch(ctx, b, logger, cfg.Logging)
 
 	if *listenFor > 0 {
 		logger.Info("listen mode", "duration", *listenFor)
@@ -286,7 +336,7 @@ func waitForInitialConfig(ctx context.Context, logger *slog.Logger, decodedSub,
 	}
 }
 
-func watch(ctx context.Context, b bus.MessageBus, logger *slog.Logger) {
+func watch(ctx context.Context, b bus.MessageBus, logger *slog.Logger, logging config.LoggingConfig) {
 	connSub := b.Subscribe(bus.TopicConnStatus)
 	channelSub := b.Subscribe(bus.TopicChannels)
 	nodeCoreSub := b.Subscribe(bus.TopicNodeCore)
@@ -323,7 +373,7 @@ func watch(ctx context.Context, b bus.MessageBus, logger *slog.Logger) {
 					logger.Info("channels", "count", len(channels.Items))
 				}
 			case raw := <-nodeCoreSub:
-				if node, ok := raw.(domain.NodeCoreUpdate); ok {
+				if node, ok := raw.(domain.NodeCoreUpdate); ok && !logging.IsNodeEventMuted(node.Core.NodeID, config.NodeEventCore) {
 					logger.Info("node-core", "id", node.Core.NodeID, "name", domain.NodeDisplayName(domain.Node{
 						NodeID:    node.Core.NodeID,
 						LongName:  node.Core.LongName,
@@ -331,11 +381,11 @@ func watch(ctx context.Context, b bus.MessageBus, logger *slog.Logger) {
 					}))
 				}
 			case raw := <-nodePositionSub:
-				if node, ok := raw.(domain.NodePositionUpdate); ok {
+				if node, ok := raw.(domain.NodePositionUpdate); ok && !logging.IsNodeEventMuted(node.Position.NodeID, config.NodeEventPosition) {
 					logger.Info("node-position", "id", node.Position.NodeID, "lat", node.Position.Latitude, "lon", node.Position.Longitude)
 				}
 			case raw := <-nodeTelemetrySub:
-				if node, ok := raw.(domain.NodeTelemetryUpdate); ok {
+				if node, ok := raw.(domain.NodeTelemetryUpdate); ok && !logging.IsNodeEventMuted(node.Telemetry.NodeID, config.NodeEventTelemetry) {
 					logger.Info("node-telemetry", "id", node.Telemetry.NodeID, "battery", node.Telemetry.BatteryLevel)
 				}
 			case raw := <-textSub:
@@ -452,6 +502,22 @@ type debugHistoryLimitsProvider struct{}
 func (debugHistoryLimitsProvider) PositionHistoryLimit() int  { return 100 }
 func (debugHistoryLimitsProvider) TelemetryHistoryLimit() int { return 250 }
 func (debugHistoryLimitsProvider) IdentityHistoryLimit() int  { return 50 }
+func (debugHistoryLimitsProvider) SignalHistoryLimit() int    { return 500 }
+
+// mergeMutedNodeEvents adds the extra muted events on top of the configured ones.
+func mergeMutedNodeEvents(base, extra map[string][]config.NodeEventType) map[string][]config.NodeEventType {
+	out := make(map[string][]config.NodeEventType, len(base)+len(extra))
+	for nodeID, events := range base {
+		out[nodeID] = append(out[nodeID], events...)
+	}
+	for nodeID, events := range extra {
+		out[nodeID] = append(out[nodeID], events...)
+	}
+	merged := config.AppConfig{Logging: config.LoggingConfig{MutedNodeEvents: out}}
+	merged.FillMissingDefaults()
+
+	return merged.Logging.MutedNodeEvents
+}
 
 func previewHex(hex string) string {
 	hex = strings.TrimSpace(hex)
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/skobkin/meshgo/internal/config"
@@ -24,3 +25,25 @@ func TestConnectionTarget(t *testing.T) {
 		}
 	}
 }
+
+func TestMergeMutedNodeEvents(t *testing.T) {
+	base := map[string][]config.NodeEventType{
+		"!1234abcd": {config.NodeEventTelemetry},
+	}
+	extra := map[string][]config.NodeEventType{
+		"!1234abcd": {config.NodeEventPosition, config.NodeEventTelemetry},
+		"!00000001": {config.NodeEventCore},
+	}
+
+	got := mergeMutedNodeEvents(base, extra)
+	want := map[string][]config.NodeEventType{
+		"!1234abcd": {config.NodeEventPosition, config.NodeEventTelemetry},
+		"!00000001": {config.NodeEventCore},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if len(base["!1234abcd"]) != 1 {
+		t.Fatalf("expected base map to stay untouched, got %v", base)
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/persistence"
+	"github.com/skobkin/meshgo/internal/platform"
+)
+
+const dbCommandName = "db"
+
+type dbQueryFormat string
+
+const (
+	dbQueryFormatTable dbQueryFormat = "table"
+	dbQueryFormatCSV   dbQueryFormat = "csv"
+	dbQueryFormatJSON  dbQueryFormat = "json"
+)
+
+type dbQueryOptions struct {
+	Format dbQueryFormat
+	Output string
+	List   bool
+	// Statement is SQL or a canned query name; empty starts the interactive prompt.
+	Statement string
+}
+
+type dbQueryFunc func(ctx context.Context, statement string) (persistence.QueryResult, error)
+
+// runDBCommand handles "meshgo db ..." without starting the GUI.
+func runDBCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 || args[0] != "query" {
+		_, _ = fmt.Fprintln(stderr, "usage: meshgo db query [-format table|csv|json] [-output file] [-list] [SQL | canned query]")
+
+		return fmt.Errorf("unknown db subcommand")
+	}
+	opts, err := parseDBQueryOptions(args[1:], stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+
+		return fmt.Errorf("parse db query options: %w", err)
+	}
+	if opts.List {
+		return writeCannedQueryList(stdout)
+	}
+
+	// Migration and startup chatter would mix with query output.
+	slog.SetDefault(slog.New(slog.NewTextHandler(stderr, &slog.HandlerOptions{Level: slog.LevelWarn})))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	paths, err := app.ResolvePaths()
+	if err != nil {
+		return fmt.Errorf("resolve paths: %w", err)
+	}
+	cfg, err := config.Load(paths.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	// Read-only, so the query tool can run while the app has the database open.
+	database, err := app.OpenDatabaseReadOnly(ctx, paths, cfg.Persistence.EncryptDatabase, platform.NewSecretStore())
+	if err != nil {
+		return fmt.Errorf("open sqlite: %w", err)
+	}
+	defer func() {
+		if closeErr := database.Close(); closeErr != nil {
+			slog.Warn("close sqlite", "error", closeErr)
+		}
+	}()
+
+	out := stdout
+	if opts.Output != "" {
+		file, err := os.Create(opts.Output)
+		if err != nil {
+			return fmt.Errorf("create output file: %w", err)
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		out = file
+	}
+	query := func(ctx context.Context, statement string) (persistence.QueryResult, error) {
+		return persistence.ReadOnlyQuery(ctx, database.DB, statement)
+	}
+
+	return runDBQuery(ctx, opts, query, stdin, out, stderr)
+}
+
+func parseDBQueryOptions(args []string, stderr io.Writer) (dbQueryOptions, error) {
+	fs := flag.NewFlagSet("meshgo db query", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", string(dbQueryFormatTable), "output format: table, csv or json")
+	output := fs.String("output", "", "write results to this file instead of stdout")
+	list := fs.Bool("list", false, "list canned queries and exit")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "usage: meshgo db query [flags] [SQL | canned query]")
+		_, _ = fmt.Fprintln(stderr, "Runs a read-only query against the app database. Without a query, reads statements from stdin.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return dbQueryOptions{}, err
+	}
+
+	opts := dbQueryOptions{
+		Format:    dbQueryFormat(strings.ToLower(strings.TrimSpace(*format))),
+		Output:    strings.TrimSpace(*output),
+		List:      *list,
+		Statement: strings.TrimSpace(strings.Join(fs.Args(), " ")),
+	}
+	switch opts.Format {
+	case dbQueryFormatTable, dbQueryFormatCSV, dbQueryFormatJSON:
+	default:
+		return dbQueryOptions{}, fmt.Errorf("unsupported format %q", *format)
+	}
+
+	return opts, nil
+}
+
+// runDBQuery runs the statement from opts, or reads statements from stdin until EOF.
+func runDBQuery(ctx context.Context, opts dbQueryOptions, query dbQueryFunc, stdin io.Reader, out, stderr io.Writer) error {
+	if opts.Statement != "" {
+		result, err := query(ctx, resolveDBStatement(opts.Statement))
+		if err != nil {
+			return err
+		}
+
+		return writeQueryResult(out, opts.Format, result)
+	}
+
+	_, _ = fmt.Fprintln(stderr, "Read-only SQL; end statements with ';'. Type a canned query name, .queries to list them, or .quit to leave.")
+	scanner := bufio.NewScanner(stdin)
+	var pending strings.Builder
+	run := func(statement string) {
+		result, err := query(ctx, resolveDBStatement(statement))
+		if err == nil {
+			err = writeQueryResult(out, opts.Format, result)
+		}
+		if err != nil {
+			_, _ = fmt.Fprintf(stderr, "error: %v\n", err)
+		}
+	}
+	for {
+		if pending.Len() == 0 {
+			_, _ = fmt.Fprint(stderr, "db> ")
+		} else {
+			_, _ = fmt.Fprint(stderr, "..> ")
+		}
+		if !scanner.Scan() {
+			break
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if pending.Len() == 0 {
+			switch {
+			case line == "":
+				continue
+			case line == ".quit" || line == ".exit":
+				return nil
+			case line == ".queries":
+				_ = writeCannedQueryList(stderr)
+
+				continue
+			}
+			if _, ok := persistence.LookupCannedQuery(strings.TrimRight(line, ";")); ok {
+				run(strings.TrimRight(line, ";"))
+
+				continue
+			}
+		}
+		pending.WriteString(line)
+		pending.WriteString("\n")
+		if strings.HasSuffix(line, ";") {
+			run(pending.String())
+			pending.Reset()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read statements: %w", err)
+	}
+	if strings.TrimSpace(pending.String()) != "" {
+		run(pending.String())
+	}
+
+	return nil
+}
+
+func resolveDBStatement(statement string) string {
+	if canned, ok := persistence.LookupCannedQuery(statement); ok {
+		return canned.SQL
+	}
+
+	return statement
+}
+
+func writeCannedQueryList(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, canned := range persistence.CannedQueries() {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\n", canned.Name, canned.Description)
+	}
+
+	return tw.Flush()
+}
+
+func writeQueryResult(w io.Writer, format dbQueryFormat, result persistence.QueryResult) error {
+	switch format {
+	case dbQueryFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(result.Columns); err != nil {
+			return fmt.Errorf("write csv header: %w", err)
+		}
+		for _, row := range result.Rows {
+			record := make([]string, len(row))
+			for i, value := range row {
+				record[i] = formatQueryValue(value, "")
+			}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("write csv row: %w", err)
+			}
+		}
+		writer.Flush()
+
+		return writer.Error()
+	case dbQueryFormatJSON:
+		items := make([]map[string]any, 0, len(result.Rows))
+		for _, row := range result.Rows {
+			item := make(map[string]any, len(row))
+			for i, value := range row {
+				item[result.Columns[i]] = value
+			}
+			items = append(items, item)
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(items)
+	default:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(tw, strings.Join(result.Columns, "\t"))
+		for _, row := range result.Rows {
+			cells := make([]string, len(row))
+			for i, value := range row {
+				cells[i] = formatQueryValue(value, "NULL")
+			}
+			_, _ = fmt.Fprintln(tw, strings.Join(cells, "\t"))
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintf(w, "(%d rows)\n", len(result.Rows))
+
+		return err
+	}
+}
+
+func formatQueryValue(value any, null string) string {
+	switch v := value.(type) {
+	case nil:
+		return null
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
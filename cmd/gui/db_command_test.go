@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/persistence"
+)
+
+func TestParseDBQueryOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    dbQueryOptions
+		wantErr bool
+	}{
+		{name: "defaults", args: nil, want: dbQueryOptions{Format: dbQueryFormatTable}},
+		{name: "canned csv", args: []string{"-format", "CSV", "top-senders"}, want: dbQueryOptions{Format: dbQueryFormatCSV, Statement: "top-senders"}},
+		{name: "sql words", args: []string{"-output", "out.json", "-format", "json", "SELECT", "1"}, want: dbQueryOptions{Format: dbQueryFormatJSON, Output: "out.json", Statement: "SELECT 1"}},
+		{name: "list", args: []string{"-list"}, want: dbQueryOptions{Format: dbQueryFormatTable, List: true}},
+		{name: "unknown format", args: []string{"-format", "xml"}, wantErr: true},
+		{name: "unknown flag", args: []string{"--nope"}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := parseDBQueryOptions(tc.args, io.Discard)
+		if tc.wantErr {
+			if err == nil {
+				t.Fatalf("%s: expected error, got nil", tc.name)
+			}
+
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: expected %+v, got %+v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestWriteQueryResult(t *testing.T) {
+	result := persistence.QueryResult{
+		Columns: []string{"node_id", "messages", "snr"},
+		Rows: [][]any{
+			{"!00000001", int64(3), 5.5},
+			{"!00000002", int64(1), nil},
+		},
+	}
+	tests := []struct {
+		format dbQueryFormat
+		want   string
+	}{
+		{format: dbQueryFormatCSV, want: "node_id,messages,snr\n!00000001,3,5.5\n!00000002,1,\n"},
+		{format: dbQueryFormatJSON, want: "[\n  {\n    \"messages\": 3,\n    \"node_id\": \"!00000001\",\n    \"snr\": 5.5\n  },\n  {\n    \"messages\": 1,\n    \"node_id\": \"!00000002\",\n    \"snr\": null\n  }\n]\n"},
+		{format: dbQueryFormatTable, want: "node_id    messages  snr\n!00000001  3         5.5\n!00000002  1         NULL\n(2 rows)\n"},
+	}
+
+	for _, tc := range tests {
+		var out bytes.Buffer
+		if err := writeQueryResult(&out, tc.format, result); err != nil {
+			t.Fatalf("%s: write result: %v", tc.format, err)
+		}
+		if out.String() != tc.want {
+			t.Fatalf("%s: unexpected output:\n%s", tc.format, out.String())
+		}
+	}
+}
+
+func TestRunDBQueryPrompt(t *testing.T) {
+	var statements []string
+	query := func(_ context.Context, statement string) (persistence.QueryResult, error) {
+		statements = append(statements, strings.TrimSpace(statement))
+
+		return persistence.QueryResult{Columns: []string{"n"}, Rows: [][]any{{int64(1)}}}, nil
+	}
+	stdin := strings.NewReader("SELECT 1\n  AS n;\nmessages-per-day\n.queries\nSELECT 2\n")
+	var out, stderr bytes.Buffer
+
+	if err := runDBQuery(context.Background(), dbQueryOptions{Format: dbQueryFormatCSV}, query, stdin, &out, &stderr); err != nil {
+		t.Fatalf("run prompt: %v", err)
+	}
+	canned, _ := persistence.LookupCannedQuery("messages-per-day")
+	want := []string{"SELECT 1\nAS n;", canned.SQL, "SELECT 2"}
+	if len(statements) != len(want) {
+		t.Fatalf("unexpected statements: %q", statements)
+	}
+	for i := range want {
+		if statements[i] != want[i] {
+			t.Fatalf("statement %d: expected %q, got %q", i, want[i], statements[i])
+		}
+	}
+	if strings.Count(out.String(), "n\n1\n") != 3 {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+	if !strings.Contains(stderr.String(), "top-senders") {
+		t.Fatalf("expected canned query list on stderr: %q", stderr.String())
+	}
+}
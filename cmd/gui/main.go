@@ -32,6 +32,10 @@ func main() {
 }
 
 func run() error {
+	if len(os.Args) > 1 && os.Args[1] == dbCommandName {
+		return runDBCommand(os.Args[2:], os.Stdin, os.Stdout, os.Stderr)
+	}
+
 	opts, err := parseLaunchOptions(os.Args[1:])
 	if err != nil {
 		return fmt.Errorf("parse launch options: %w", err)
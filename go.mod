@@ -4,6 +4,7 @@ go 1.25.0
 
 require (
 	fyne.io/fyne/v2 v2.7.4
+	fyne.io/systray v1.12.2
 	fyne.io/x/fyne v0.0.0-20260607194840-16ad916d90e0
 	github.com/cskr/pubsub v1.0.2
 	github.com/godbus/dbus/v5 v5.2.2
@@ -17,7 +18,6 @@ require (
 )
 
 require (
-	fyne.io/systray v1.12.2 // indirect
 	github.com/BurntSushi/toml v1.6.0 // indirect
 	github.com/clipperhouse/uax29/v2 v2.7.0 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
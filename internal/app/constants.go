@@ -1,12 +1,16 @@
 package app
 
 const (
-	Name           = "meshgo"
-	SourceURL      = "https://git.skobk.in/skobkin/meshgo"
-	MeshtasticURL  = "https://meshtastic.org"
-	ConfigFilename = "config.json"
-	DBFilename     = "app.db"
-	LogFilename    = "app.log"
-	MapTilesDir    = "tiles"
-	DefaultIPPort  = 4403
+	Name                       = "meshgo"
+	SourceURL                  = "https://git.skobk.in/skobkin/meshgo"
+	MeshtasticURL              = "https://meshtastic.org"
+	ConfigFilename             = "config.json"
+	DBFilename                 = "app.db"
+	EncryptedDBFilename        = "app.db.enc"
+	LogFilename                = "app.log"
+	WindowStateFilename        = "window_state.json"
+	EmergencyModeStateFilename = "emergency_mode.json"
+	MapTilesDir                = "tiles"
+	TranslationsDir            = "translations"
+	DefaultIPPort              = 4403
 )
@@ -0,0 +1,333 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/persistence"
+	"github.com/skobkin/meshgo/internal/platform"
+)
+
+const (
+	databaseKeySecretName = "database-key"
+	// encryptedDatabaseFlushInterval bounds how long changes made outside of the writer
+	// queue, such as settings and deletions, wait to reach the disk while the database
+	// is encrypted at rest.
+	encryptedDatabaseFlushInterval = 30 * time.Second
+	// encryptedDatabaseWriteFlushDelay is how long writer queue batches are gathered
+	// before the encrypted database is written out. Each flush rewrites the whole file.
+	encryptedDatabaseWriteFlushDelay = 5 * time.Second
+	// databaseLockFilename sits next to the database and is held by the process that owns
+	// it, i.e. the one allowed to repair, convert and maintain the file.
+	databaseLockFilename = "database.lock"
+)
+
+// ErrDatabaseInUse means another process owns the database and it cannot be shared in
+// its current form.
+var ErrDatabaseInUse = errors.New("database is in use by another meshgo process")
+
+// Database is the opened app database, either a plain SQLite file or an in-memory
+// database persisted as an encrypted file.
+type Database struct {
+	DB *sql.DB
+	// RepairReport is set when a corrupted plain database was rebuilt on open.
+	RepairReport *persistence.DatabaseRepairReport
+	// Shared is set when another process owns the database. Both processes read and write
+	// it, but only the owner repairs, converts or vacuums the file.
+	Shared bool
+	// ReadOnly is set for databases opened by OpenDatabaseReadOnly.
+	ReadOnly bool
+
+	encrypted *persistence.EncryptedDatabase
+	lock      platform.InstanceLock
+}
+
+// Encrypted reports whether the database is encrypted at rest.
+func (d *Database) Encrypted() bool {
+	return d.encrypted != nil
+}
+
+// Flush writes pending changes of an encrypted database to disk. Plain databases
+// are always up to date, so it does nothing for them.
+func (d *Database) Flush(ctx context.Context) error {
+	if d.encrypted == nil {
+		return nil
+	}
+
+	return d.encrypted.Flush(ctx)
+}
+
+func (d *Database) Close() error {
+	var closeErr error
+	if d.encrypted != nil {
+		closeErr = d.encrypted.Close()
+	} else {
+		closeErr = d.DB.Close()
+	}
+	if d.lock != nil {
+		closeErr = errors.Join(closeErr, d.lock.Release())
+		d.lock = nil
+	}
+
+	return closeErr
+}
+
+// OpenDatabase opens the database in the form selected by encrypt. When the file on disk
+// is in the other form it is converted first, so toggling the setting takes effect on the
+// next start without losing history. The encryption key lives in the OS keyring.
+//
+// The first process to open the database owns it until Close. A second process, such as
+// the debug tool next to a running GUI, opens a plain database in shared mode instead:
+// SQLite's busy timeout serializes the writes and the owner keeps doing maintenance.
+func OpenDatabase(ctx context.Context, paths Paths, encrypt bool, secrets platform.SecretStore) (*Database, error) {
+	lock, err := platform.AcquireFileLock(filepath.Join(filepath.Dir(paths.DBFile), databaseLockFilename))
+	switch {
+	case errors.Is(err, platform.ErrFileLocked):
+		return openSharedDatabase(ctx, paths, encrypt)
+	case errors.Is(err, platform.ErrInstanceLockUnsupported):
+		lock = nil
+	case err != nil:
+		return nil, fmt.Errorf("lock database: %w", err)
+	}
+
+	database, err := openOwnedDatabase(ctx, paths, encrypt, secrets)
+	if err != nil {
+		if lock != nil {
+			_ = lock.Release()
+		}
+
+		return nil, err
+	}
+	database.lock = lock
+
+	return database, nil
+}
+
+func openOwnedDatabase(ctx context.Context, paths Paths, encrypt bool, secrets platform.SecretStore) (*Database, error) {
+	plainExists, err := fileExists(paths.DBFile)
+	if err != nil {
+		return nil, err
+	}
+	encryptedExists, err := fileExists(paths.EncryptedDBFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if encrypt {
+		// A new key is only safe to create while no file depends on the old one.
+		key, err := loadDatabaseKey(secrets, !encryptedExists)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case plainExists && !encryptedExists:
+			slog.Info("encrypting database at rest", "from", paths.DBFile, "to", paths.EncryptedDBFile)
+			if err := persistence.EncryptDatabaseFile(ctx, paths.DBFile, paths.EncryptedDBFile, key); err != nil {
+				return nil, fmt.Errorf("encrypt existing database: %w", err)
+			}
+		case plainExists:
+			slog.Warn("plain database file ignored while encryption is enabled", "path", paths.DBFile)
+		}
+		encrypted, err := persistence.OpenEncrypted(ctx, paths.EncryptedDBFile, key)
+		if err != nil {
+			return nil, fmt.Errorf("open encrypted database: %w", err)
+		}
+
+		return &Database{DB: encrypted.DB(), encrypted: encrypted}, nil
+	}
+
+	switch {
+	case encryptedExists && !plainExists:
+		key, err := loadDatabaseKey(secrets, false)
+		if err != nil {
+			return nil, err
+		}
+		slog.Info("decrypting database", "from", paths.EncryptedDBFile, "to", paths.DBFile)
+		if err := persistence.DecryptDatabaseFile(paths.EncryptedDBFile, paths.DBFile, key); err != nil {
+			return nil, fmt.Errorf("decrypt existing database: %w", err)
+		}
+		if err := secrets.Delete(databaseKeySecretName); err != nil {
+			slog.Warn("remove unused database key from keyring", "error", err)
+		}
+	case encryptedExists:
+		slog.Warn("encrypted database file ignored while encryption is disabled", "path", paths.EncryptedDBFile)
+	}
+
+	db, repairReport, err := persistence.OpenWithIntegrityCheck(ctx, paths.DBFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Database{DB: db, RepairReport: repairReport}, nil
+}
+
+// openSharedDatabase opens the plain database owned by another process. Encrypted
+// databases live in the owner's memory, and a pending conversion can only be done by the
+// owner, so both are refused. The owner may have encryption on whatever this process is
+// configured with, so an encrypted file next to the plain one is refused too.
+func openSharedDatabase(ctx context.Context, paths Paths, encrypt bool) (*Database, error) {
+	if encrypt {
+		return nil, fmt.Errorf("%w: encrypted databases cannot be shared", ErrDatabaseInUse)
+	}
+	encryptedExists, err := fileExists(paths.EncryptedDBFile)
+	if err != nil {
+		return nil, err
+	}
+	if encryptedExists {
+		return nil, fmt.Errorf("%w: the database may be encrypted by its owner", ErrDatabaseInUse)
+	}
+
+	slog.Info("database is owned by another process, opening it shared", "path", paths.DBFile)
+	db, err := persistence.Open(ctx, paths.DBFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Database{DB: db, Shared: true}, nil
+}
+
+// OpenDatabaseReadOnly opens the existing database for reading without converting,
+// migrating or locking it, so it works next to a running app. An encrypted database is
+// read from its last flushed snapshot.
+func OpenDatabaseReadOnly(ctx context.Context, paths Paths, encrypt bool, secrets platform.SecretStore) (*Database, error) {
+	plainExists, err := fileExists(paths.DBFile)
+	if err != nil {
+		return nil, err
+	}
+	encryptedExists, err := fileExists(paths.EncryptedDBFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var db *sql.DB
+	switch {
+	// Until the next start converts it, the file on disk is still in its old form.
+	case encryptedExists && (encrypt || !plainExists):
+		key, err := loadDatabaseKey(secrets, false)
+		if err != nil {
+			return nil, err
+		}
+		db, err = persistence.OpenEncryptedReadOnly(ctx, paths.EncryptedDBFile, key)
+		if err != nil {
+			return nil, fmt.Errorf("open encrypted database: %w", err)
+		}
+	case plainExists:
+		db, err = persistence.OpenReadOnly(ctx, paths.DBFile)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("no database found in %s", filepath.Dir(paths.DBFile))
+	}
+
+	return &Database{DB: db, ReadOnly: true}, nil
+}
+
+func loadDatabaseKey(secrets platform.SecretStore, create bool) ([]byte, error) {
+	if secrets == nil {
+		return nil, fmt.Errorf("database encryption needs an OS keyring")
+	}
+	encoded, err := secrets.Get(databaseKeySecretName)
+	switch {
+	case errors.Is(err, platform.ErrSecretNotFound) && create:
+		key, err := persistence.NewDatabaseKey()
+		if err != nil {
+			return nil, err
+		}
+		if err := secrets.Set(databaseKeySecretName, base64.StdEncoding.EncodeToString(key)); err != nil {
+			return nil, fmt.Errorf("save database key: %w", err)
+		}
+		slog.Info("database key created in OS keyring")
+
+		return key, nil
+	case errors.Is(err, platform.ErrSecretNotFound):
+		return nil, fmt.Errorf("database key is missing from the OS keyring, the encrypted database cannot be opened")
+	case err != nil:
+		return nil, fmt.Errorf("load database key: %w", err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode database key from keyring: %w", err)
+	}
+
+	return key, nil
+}
+
+// encryptedDatabaseFlusher persists an encrypted database in the background. Every
+// flush serializes and rewrites the whole database, so writes only mark it dirty and are
+// flushed together a few seconds later. Close flushes whatever is left on shutdown.
+type encryptedDatabaseFlusher struct {
+	flush    func(context.Context) error
+	delay    time.Duration
+	interval time.Duration
+	dirty    chan struct{}
+}
+
+func newEncryptedDatabaseFlusher(database *Database) *encryptedDatabaseFlusher {
+	return &encryptedDatabaseFlusher{
+		flush:    database.Flush,
+		delay:    encryptedDatabaseWriteFlushDelay,
+		interval: encryptedDatabaseFlushInterval,
+		dirty:    make(chan struct{}, 1),
+	}
+}
+
+// MarkDirty schedules a flush after the write delay unless one is already scheduled.
+func (f *encryptedDatabaseFlusher) MarkDirty() {
+	select {
+	case f.dirty <- struct{}{}:
+	default:
+	}
+}
+
+// Run flushes scheduled writes and, periodically, changes made outside of the writer
+// queue until ctx is done.
+func (f *encryptedDatabaseFlusher) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	var scheduled <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-f.dirty:
+			if scheduled == nil {
+				scheduled = time.After(f.delay)
+			}
+		case <-scheduled:
+			scheduled = nil
+			f.flushNow(ctx)
+		case <-ticker.C:
+			f.flushNow(ctx)
+		}
+	}
+}
+
+func (f *encryptedDatabaseFlusher) flushNow(ctx context.Context) {
+	flushCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := f.flush(flushCtx); err != nil {
+		slog.Warn("flush encrypted database", "error", err)
+	}
+}
+
+func fileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, os.ErrNotExist):
+		return false, nil
+	default:
+		return false, fmt.Errorf("check %s: %w", path, err)
+	}
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/persistence"
+)
+
+const (
+	databaseMaintenanceInterval = 24 * time.Hour
+	// databaseMaintenanceFirstDelay lets startup traffic settle before the first pass.
+	databaseMaintenanceFirstDelay = 10 * time.Minute
+	databaseMaintenanceTimeout    = 5 * time.Minute
+)
+
+// DatabaseMaintenanceStatus is the outcome of the latest maintenance pass.
+type DatabaseMaintenanceStatus struct {
+	Report persistence.MaintenanceReport
+	Err    error
+}
+
+// DatabaseMaintainer runs persistence.RunMaintenance daily and remembers the last result.
+type DatabaseMaintainer struct {
+	db     *sql.DB
+	logger *slog.Logger
+
+	runMu sync.Mutex
+	mu    sync.RWMutex
+	last  DatabaseMaintenanceStatus
+	known bool
+}
+
+func NewDatabaseMaintainer(db *sql.DB, logger *slog.Logger) *DatabaseMaintainer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &DatabaseMaintainer{db: db, logger: logger}
+}
+
+// Start schedules maintenance passes until ctx is done.
+func (m *DatabaseMaintainer) Start(ctx context.Context) {
+	if m == nil {
+		return
+	}
+
+	go func() {
+		timer := time.NewTimer(databaseMaintenanceFirstDelay)
+		defer timer.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				_, _ = m.Run(ctx)
+				timer.Reset(databaseMaintenanceInterval)
+			}
+		}
+	}()
+}
+
+// Run performs one maintenance pass now. Concurrent calls wait for each other.
+func (m *DatabaseMaintainer) Run(ctx context.Context) (DatabaseMaintenanceStatus, error) {
+	if m == nil {
+		return DatabaseMaintenanceStatus{}, nil
+	}
+	m.runMu.Lock()
+	defer m.runMu.Unlock()
+
+	runCtx, cancel := context.WithTimeout(ctx, databaseMaintenanceTimeout)
+	defer cancel()
+	report, err := persistence.RunMaintenance(runCtx, m.db, persistence.DefaultVacuumFreeRatio)
+	status := DatabaseMaintenanceStatus{Report: report, Err: err}
+	if err != nil {
+		m.logger.Warn("database maintenance failed", "error", err)
+	} else {
+		m.logger.Info(
+			"database maintenance finished",
+			"duration", report.FinishedAt.Sub(report.StartedAt),
+			"checkpointed", report.Checkpointed,
+			"vacuumed", report.Vacuumed,
+			"pages", report.PageCount,
+			"free_pages", report.FreePages,
+		)
+	}
+
+	m.mu.Lock()
+	m.last = status
+	m.known = true
+	m.mu.Unlock()
+
+	return status, err
+}
+
+// LastStatus returns the latest maintenance result, if a pass has run.
+func (m *DatabaseMaintainer) LastStatus() (DatabaseMaintenanceStatus, bool) {
+	if m == nil {
+		return DatabaseMaintenanceStatus{}, false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.last, m.known
+}
@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/persistence"
+)
+
+func TestDatabaseMaintainerRunRecordsStatus(t *testing.T) {
+	ctx := context.Background()
+	db, err := persistence.Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	maintainer := NewDatabaseMaintainer(db, nil)
+	if _, known := maintainer.LastStatus(); known {
+		t.Fatalf("expected no status before the first run")
+	}
+	if _, err := maintainer.Run(ctx); err != nil {
+		t.Fatalf("run maintenance: %v", err)
+	}
+	status, known := maintainer.LastStatus()
+	if !known || status.Err != nil || !status.Report.Analyzed {
+		t.Fatalf("unexpected status: %+v known=%v", status, known)
+	}
+
+	_ = db.Close()
+	if _, err := maintainer.Run(ctx); err == nil {
+		t.Fatalf("expected maintenance on a closed database to fail")
+	}
+	if status, _ := maintainer.LastStatus(); status.Err == nil {
+		t.Fatalf("expected the failure to be recorded")
+	}
+}
@@ -0,0 +1,291 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/persistence"
+	"github.com/skobkin/meshgo/internal/platform"
+)
+
+type memorySecretStore map[string]string
+
+func (s memorySecretStore) Get(name string) (string, error) {
+	value, ok := s[name]
+	if !ok {
+		return "", platform.ErrSecretNotFound
+	}
+
+	return value, nil
+}
+
+func (s memorySecretStore) Set(name, value string) error {
+	s[name] = value
+
+	return nil
+}
+
+func (s memorySecretStore) Delete(name string) error {
+	delete(s, name)
+
+	return nil
+}
+
+func testDatabasePaths(t *testing.T) Paths {
+	t.Helper()
+	dir := t.TempDir()
+
+	return Paths{
+		DBFile:          filepath.Join(dir, DBFilename),
+		EncryptedDBFile: filepath.Join(dir, EncryptedDBFilename),
+	}
+}
+
+func TestOpenDatabase_TogglingEncryptionConvertsHistory(t *testing.T) {
+	ctx := context.Background()
+	paths := testDatabasePaths(t)
+	secrets := memorySecretStore{}
+
+	plain, err := OpenDatabase(ctx, paths, false, secrets)
+	if err != nil {
+		t.Fatalf("open plain db: %v", err)
+	}
+	if plain.Encrypted() {
+		t.Fatalf("expected plain database")
+	}
+	if _, err := persistence.NewMessageRepo(plain.DB).Insert(ctx, domain.ChatMessage{
+		ChatKey:   "channel:0",
+		Direction: domain.MessageDirectionIn,
+		Body:      "kept across conversions",
+		At:        time.Now(),
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+	if err := plain.Close(); err != nil {
+		t.Fatalf("close plain db: %v", err)
+	}
+
+	encrypted, err := OpenDatabase(ctx, paths, true, secrets)
+	if err != nil {
+		t.Fatalf("open encrypted db: %v", err)
+	}
+	if !encrypted.Encrypted() || secrets[databaseKeySecretName] == "" {
+		t.Fatalf("expected encrypted database with a key in the keyring")
+	}
+	if _, err := os.Stat(paths.DBFile); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected plain database file to be removed, got %v", err)
+	}
+	assertMessageBodies(t, encrypted, "kept across conversions")
+	if err := encrypted.Close(); err != nil {
+		t.Fatalf("close encrypted db: %v", err)
+	}
+
+	decrypted, err := OpenDatabase(ctx, paths, false, secrets)
+	if err != nil {
+		t.Fatalf("open decrypted db: %v", err)
+	}
+	defer func() { _ = decrypted.Close() }()
+	if _, err := os.Stat(paths.EncryptedDBFile); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected encrypted file to be removed, got %v", err)
+	}
+	if _, ok := secrets[databaseKeySecretName]; ok {
+		t.Fatalf("expected unused key to be removed from keyring")
+	}
+	assertMessageBodies(t, decrypted, "kept across conversions")
+}
+
+func TestOpenDatabase_RefusesToReplaceMissingKey(t *testing.T) {
+	ctx := context.Background()
+	paths := testDatabasePaths(t)
+	secrets := memorySecretStore{}
+
+	database, err := OpenDatabase(ctx, paths, true, secrets)
+	if err != nil {
+		t.Fatalf("open encrypted db: %v", err)
+	}
+	if err := database.Close(); err != nil {
+		t.Fatalf("close encrypted db: %v", err)
+	}
+	delete(secrets, databaseKeySecretName)
+
+	for _, encrypt := range []bool{true, false} {
+		if _, err := OpenDatabase(ctx, paths, encrypt, secrets); err == nil || !strings.Contains(err.Error(), "missing from the OS keyring") {
+			t.Fatalf("encrypt=%v: expected missing key error, got %v", encrypt, err)
+		}
+	}
+	if _, ok := secrets[databaseKeySecretName]; ok {
+		t.Fatalf("expected no new key to be created for an existing encrypted file")
+	}
+}
+
+func TestOpenDatabase_SecondProcessSharesPlainDatabase(t *testing.T) {
+	ctx := context.Background()
+	paths := testDatabasePaths(t)
+	secrets := memorySecretStore{}
+
+	owner, err := OpenDatabase(ctx, paths, false, secrets)
+	if err != nil {
+		t.Fatalf("open owner db: %v", err)
+	}
+	if owner.Shared {
+		t.Fatalf("expected first open to own the database")
+	}
+
+	shared, err := OpenDatabase(ctx, paths, false, secrets)
+	if err != nil {
+		t.Fatalf("open shared db: %v", err)
+	}
+	if !shared.Shared {
+		t.Fatalf("expected second open to be shared")
+	}
+	if _, err := persistence.NewMessageRepo(shared.DB).Insert(ctx, domain.ChatMessage{
+		ChatKey:   "channel:0",
+		Direction: domain.MessageDirectionIn,
+		Body:      "from the second process",
+		At:        time.Now(),
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+	assertMessageBodies(t, owner, "from the second process")
+	if _, err := OpenDatabase(ctx, paths, true, secrets); !errors.Is(err, ErrDatabaseInUse) {
+		t.Fatalf("expected encrypted open of a shared database to fail, got %v", err)
+	}
+	if err := shared.Close(); err != nil {
+		t.Fatalf("close shared db: %v", err)
+	}
+	if err := owner.Close(); err != nil {
+		t.Fatalf("close owner db: %v", err)
+	}
+
+	reopened, err := OpenDatabase(ctx, paths, false, secrets)
+	if err != nil {
+		t.Fatalf("reopen db: %v", err)
+	}
+	defer func() { _ = reopened.Close() }()
+	if reopened.Shared {
+		t.Fatalf("expected ownership to be released on close")
+	}
+}
+
+func TestOpenDatabase_SecondProcessRefusesEncryptedDatabase(t *testing.T) {
+	ctx := context.Background()
+	paths := testDatabasePaths(t)
+	secrets := memorySecretStore{}
+
+	owner, err := OpenDatabase(ctx, paths, true, secrets)
+	if err != nil {
+		t.Fatalf("open owner db: %v", err)
+	}
+	defer func() { _ = owner.Close() }()
+	// A plain file left from before encryption must not be shared in its place.
+	stale, err := persistence.Open(ctx, paths.DBFile)
+	if err != nil {
+		t.Fatalf("create stale plain db: %v", err)
+	}
+	_ = stale.Close()
+
+	for _, encrypt := range []bool{false, true} {
+		if _, err := OpenDatabase(ctx, paths, encrypt, secrets); !errors.Is(err, ErrDatabaseInUse) {
+			t.Fatalf("expected sharing of an encrypted database to fail (encrypt=%v), got %v", encrypt, err)
+		}
+	}
+}
+
+func TestOpenDatabaseReadOnly(t *testing.T) {
+	ctx := context.Background()
+	secrets := memorySecretStore{}
+
+	for _, encrypt := range []bool{false, true} {
+		paths := testDatabasePaths(t)
+		if _, err := OpenDatabaseReadOnly(ctx, paths, encrypt, secrets); err == nil {
+			t.Fatalf("encrypt=%v: expected error without a database", encrypt)
+		}
+
+		owner, err := OpenDatabase(ctx, paths, encrypt, secrets)
+		if err != nil {
+			t.Fatalf("encrypt=%v: open owner db: %v", encrypt, err)
+		}
+		if _, err := persistence.NewMessageRepo(owner.DB).Insert(ctx, domain.ChatMessage{
+			ChatKey:   "channel:0",
+			Direction: domain.MessageDirectionIn,
+			Body:      "visible to readers",
+			At:        time.Now(),
+		}); err != nil {
+			t.Fatalf("encrypt=%v: insert message: %v", encrypt, err)
+		}
+		if err := owner.Flush(ctx); err != nil {
+			t.Fatalf("encrypt=%v: flush: %v", encrypt, err)
+		}
+
+		reader, err := OpenDatabaseReadOnly(ctx, paths, encrypt, secrets)
+		if err != nil {
+			t.Fatalf("encrypt=%v: open read-only db: %v", encrypt, err)
+		}
+		assertMessageBodies(t, reader, "visible to readers")
+		if _, err := reader.DB.ExecContext(ctx, `DELETE FROM messages`); !persistence.IsReadOnlyError(err) {
+			t.Fatalf("encrypt=%v: expected read-only error, got %v", encrypt, err)
+		}
+		_ = reader.Close()
+		_ = owner.Close()
+	}
+}
+
+func assertMessageBodies(t *testing.T, database *Database, want ...string) {
+	t.Helper()
+	messages, err := persistence.NewMessageRepo(database.DB).ListRecentByChat(context.Background(), "channel:0", 10)
+	if err != nil {
+		t.Fatalf("list messages: %v", err)
+	}
+	if len(messages) != len(want) {
+		t.Fatalf("expected %d messages, got %d", len(want), len(messages))
+	}
+	for i, body := range want {
+		if messages[i].Body != body {
+			t.Fatalf("message %d: expected %q, got %q", i, body, messages[i].Body)
+		}
+	}
+}
+
+func TestEncryptedDatabaseFlusherGathersWrites(t *testing.T) {
+	flushes := make(chan struct{}, 10)
+	flusher := &encryptedDatabaseFlusher{
+		flush: func(context.Context) error {
+			flushes <- struct{}{}
+
+			return nil
+		},
+		delay:    50 * time.Millisecond,
+		interval: time.Hour,
+		dirty:    make(chan struct{}, 1),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go flusher.Run(ctx)
+
+	for range 20 {
+		flusher.MarkDirty()
+	}
+	select {
+	case <-flushes:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected a flush after the write delay")
+	}
+	select {
+	case <-flushes:
+		t.Fatalf("expected the writes to be flushed together")
+	case <-time.After(150 * time.Millisecond):
+	}
+
+	flusher.MarkDirty()
+	select {
+	case <-flushes:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected later writes to be flushed again")
+	}
+}
@@ -0,0 +1,193 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/config"
+)
+
+const (
+	diagnosticsUploadTimeout = 2 * time.Minute
+	// diagnosticsLogTailBytes caps how much of the log file goes into a bundle.
+	diagnosticsLogTailBytes = 4 << 20
+	diagnosticsRedacted     = "[redacted]"
+)
+
+// DiagnosticsUpload describes a diagnostics bundle sent to the support endpoint.
+type DiagnosticsUpload struct {
+	Name     string
+	Size     int
+	Endpoint string
+}
+
+// UploadDiagnostics builds a diagnostics bundle and posts it to the configured support
+// upload URL. The UI asks for confirmation before calling it.
+func (r *Runtime) UploadDiagnostics(ctx context.Context) (DiagnosticsUpload, error) {
+	cfg := r.CurrentConfig()
+	endpoint := strings.TrimSpace(cfg.Logging.SupportUploadURL)
+	if endpoint == "" {
+		return DiagnosticsUpload{}, fmt.Errorf("support upload URL is not configured")
+	}
+
+	now := time.Now()
+	var bundle bytes.Buffer
+	if err := writeDiagnosticsBundle(&bundle, cfg, r.Core.Paths.LogFile, now); err != nil {
+		return DiagnosticsUpload{}, err
+	}
+	upload := DiagnosticsUpload{
+		Name:     diagnosticsBundleName(now),
+		Size:     bundle.Len(),
+		Endpoint: endpoint,
+	}
+	client := &http.Client{Timeout: diagnosticsUploadTimeout}
+	if err := uploadDiagnosticsBundle(ctx, client, endpoint, upload.Name, bundle.Bytes()); err != nil {
+		return DiagnosticsUpload{}, err
+	}
+	slog.Info("diagnostics bundle uploaded", "endpoint", endpoint, "name", upload.Name, "bytes", upload.Size)
+
+	return upload, nil
+}
+
+func diagnosticsBundleName(now time.Time) string {
+	return fmt.Sprintf("%s-diagnostics-%s.zip", Name, now.UTC().Format("20060102-150405"))
+}
+
+// writeDiagnosticsBundle zips build and system info, the settings without connection
+// addresses and the tail of the log file when file logging produced one.
+func writeDiagnosticsBundle(w io.Writer, cfg config.AppConfig, logFile string, now time.Time) error {
+	archive := zip.NewWriter(w)
+
+	info := strings.Join([]string{
+		"version: " + BuildVersionWithDate(),
+		"os: " + runtime.GOOS + "/" + runtime.GOARCH,
+		"go: " + runtime.Version(),
+		"created_at: " + now.UTC().Format(time.RFC3339),
+		"",
+	}, "\n")
+	if err := writeDiagnosticsFile(archive, "info.txt", now, []byte(info)); err != nil {
+		return err
+	}
+
+	redacted := redactDiagnosticsConfig(cfg)
+	rawConfig, err := json.MarshalIndent(redacted, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode diagnostics config: %w", err)
+	}
+	if err := writeDiagnosticsFile(archive, "config.json", now, rawConfig); err != nil {
+		return err
+	}
+
+	logTail, err := readFileTail(logFile, diagnosticsLogTailBytes)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("read log file: %w", err)
+	default:
+		if err := writeDiagnosticsFile(archive, filepath.Base(logFile), now, logTail); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("finish diagnostics bundle: %w", err)
+	}
+
+	return nil
+}
+
+func writeDiagnosticsFile(archive *zip.Writer, name string, modified time.Time, content []byte) error {
+	file, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return fmt.Errorf("add %s to diagnostics bundle: %w", name, err)
+	}
+	if _, err := file.Write(content); err != nil {
+		return fmt.Errorf("write %s to diagnostics bundle: %w", name, err)
+	}
+
+	return nil
+}
+
+// redactDiagnosticsConfig drops values that locate the user's radio or identify them.
+func redactDiagnosticsConfig(cfg config.AppConfig) config.AppConfig {
+	redact := func(value string) string {
+		if strings.TrimSpace(value) == "" {
+			return value
+		}
+
+		return diagnosticsRedacted
+	}
+	cfg.Connection.Host = redact(cfg.Connection.Host)
+	cfg.Connection.BluetoothAddress = redact(cfg.Connection.BluetoothAddress)
+	cfg.UI.LastSelectedChat = redact(cfg.UI.LastSelectedChat)
+	cfg.UI.MapViewport = config.MapViewportConfig{}
+
+	return cfg
+}
+
+func readFileTail(path string, limit int64) ([]byte, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, fs.ErrNotExist
+	}
+	// #nosec G304 -- path is the app log file resolved by the runtime.
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if stat.Size() > limit {
+		if _, err := file.Seek(stat.Size()-limit, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+
+	return io.ReadAll(io.LimitReader(file, limit))
+}
+
+func uploadDiagnosticsBundle(ctx context.Context, client *http.Client, endpoint, name string, bundle []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(bundle))
+	if err != nil {
+		return fmt.Errorf("create diagnostics upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/zip")
+	req.Header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	req.Header.Set("User-Agent", Name+"/"+BuildVersion())
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("upload diagnostics bundle: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		message := strings.TrimSpace(string(body))
+		if message == "" {
+			return fmt.Errorf("upload diagnostics bundle: server responded %s", resp.Status)
+		}
+
+		return fmt.Errorf("upload diagnostics bundle: server responded %s: %s", resp.Status, message)
+	}
+
+	return nil
+}
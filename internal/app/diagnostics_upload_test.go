@@ -0,0 +1,114 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/config"
+)
+
+func TestWriteDiagnosticsBundle(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "meshgo.log")
+	if err := os.WriteFile(logFile, []byte("first line\nlast line\n"), 0o600); err != nil {
+		t.Fatalf("write log file: %v", err)
+	}
+	cfg := config.Default()
+	cfg.Connection.Host = "192.168.1.10"
+	cfg.UI.LastSelectedChat = "dm:!00000001"
+
+	var bundle bytes.Buffer
+	if err := writeDiagnosticsBundle(&bundle, cfg, logFile, time.Now()); err != nil {
+		t.Fatalf("write bundle: %v", err)
+	}
+	files := readZipFiles(t, bundle.Bytes())
+	if !strings.Contains(files["info.txt"], "version: ") {
+		t.Fatalf("unexpected info: %q", files["info.txt"])
+	}
+	if strings.Contains(files["config.json"], "192.168.1.10") || strings.Contains(files["config.json"], "!00000001") {
+		t.Fatalf("expected connection details to be redacted: %s", files["config.json"])
+	}
+	if files["meshgo.log"] != "first line\nlast line\n" {
+		t.Fatalf("unexpected log content: %q", files["meshgo.log"])
+	}
+
+	// A missing log file only leaves the log out.
+	bundle.Reset()
+	if err := writeDiagnosticsBundle(&bundle, cfg, filepath.Join(t.TempDir(), "missing.log"), time.Now()); err != nil {
+		t.Fatalf("write bundle without log: %v", err)
+	}
+	if files := readZipFiles(t, bundle.Bytes()); len(files) != 2 {
+		t.Fatalf("expected info and config only, got %d files", len(files))
+	}
+}
+
+func TestReadFileTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("0123456789"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	tail, err := readFileTail(path, 4)
+	if err != nil || string(tail) != "6789" {
+		t.Fatalf("unexpected tail %q, err %v", tail, err)
+	}
+}
+
+func TestUploadDiagnosticsBundle(t *testing.T) {
+	var received []byte
+	var contentType, disposition string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/full" {
+			http.Error(w, "storage is full", http.StatusInsufficientStorage)
+
+			return
+		}
+		contentType = r.Header.Get("Content-Type")
+		disposition = r.Header.Get("Content-Disposition")
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	if err := uploadDiagnosticsBundle(context.Background(), server.Client(), server.URL+"/upload", "bundle.zip", []byte("zip")); err != nil {
+		t.Fatalf("upload bundle: %v", err)
+	}
+	if string(received) != "zip" || contentType != "application/zip" || disposition != `attachment; filename="bundle.zip"` {
+		t.Fatalf("unexpected upload: body=%q type=%q disposition=%q", received, contentType, disposition)
+	}
+
+	err := uploadDiagnosticsBundle(context.Background(), server.Client(), server.URL+"/full", "bundle.zip", []byte("zip"))
+	if err == nil || !strings.Contains(err.Error(), "storage is full") {
+		t.Fatalf("expected server error message, got %v", err)
+	}
+}
+
+func readZipFiles(t *testing.T, raw []byte) map[string]string {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		t.Fatalf("open zip: %v", err)
+	}
+	files := make(map[string]string, len(reader.File))
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", file.Name, err)
+		}
+		files[file.Name] = string(content)
+	}
+
+	return files
+}
@@ -0,0 +1,439 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/config"
+	generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
+)
+
+// EmergencyPositionBroadcastSecs is the position beacon interval used by the default emergency profile.
+const EmergencyPositionBroadcastSecs = 120
+
+// emergencyScreenAlwaysOnSecs is treated by firmware as "never turn the screen off".
+const emergencyScreenAlwaysOnSecs = math.MaxUint32
+
+type emergencyModeNodeSettings interface {
+	LoadPositionSettings(ctx context.Context, target NodeSettingsTarget) (NodePositionSettings, error)
+	SavePositionSettings(ctx context.Context, target NodeSettingsTarget, settings NodePositionSettings) error
+	LoadDisplaySettings(ctx context.Context, target NodeSettingsTarget) (NodeDisplaySettings, error)
+	SaveDisplaySettings(ctx context.Context, target NodeSettingsTarget, settings NodeDisplaySettings) error
+	LoadDeviceSettings(ctx context.Context, target NodeSettingsTarget) (NodeDeviceSettings, error)
+	SaveDeviceSettings(ctx context.Context, target NodeSettingsTarget, settings NodeDeviceSettings) error
+	LoadChannelSettings(ctx context.Context, target NodeSettingsTarget) (NodeChannelSettingsList, error)
+	SaveChannelSettings(ctx context.Context, target NodeSettingsTarget, settings NodeChannelSettingsList) error
+}
+
+// EmergencyModeProfile describes what emergency mode changes on the node and in the app.
+type EmergencyModeProfile struct {
+	// PositionBroadcastSecs is the fixed position beacon interval. Smart broadcast is disabled.
+	PositionBroadcastSecs uint32
+	ScreenAlwaysOn        bool
+	// BuzzerMode overrides the device buzzer mode while active. Nil keeps the current mode.
+	BuzzerMode *int32
+	// Channels replaces the node channel set while active. Nil keeps current channels.
+	Channels      []NodeChannelSettings
+	Notifications EmergencyNotifications
+}
+
+// EmergencyNotifications lists the app alerts emergency mode turns on. It only adds
+// alerts: every other notification setting stays as the user left it.
+type EmergencyNotifications struct {
+	NotifyWhenFocused bool
+	// Unmute lifts a notification mute and the do-not-disturb schedule.
+	Unmute           bool
+	IncomingMessage  bool
+	NodeDiscovered   bool
+	ConnectionStatus bool
+	LowBattery       bool
+	NodePresence     bool
+}
+
+// apply turns on the alerts listed in e.
+func (e EmergencyNotifications) apply(notifications *config.NotificationConfig) {
+	notifications.NotifyWhenFocused = notifications.NotifyWhenFocused || e.NotifyWhenFocused
+	if e.Unmute {
+		notifications.Muted = false
+		notifications.MutedUntil = time.Time{}
+		notifications.DoNotDisturb.Enabled = false
+	}
+	events := &notifications.Events
+	events.IncomingMessage = events.IncomingMessage || e.IncomingMessage
+	events.NodeDiscovered = events.NodeDiscovered || e.NodeDiscovered
+	events.ConnectionStatus = events.ConnectionStatus || e.ConnectionStatus
+	events.LowBattery = events.LowBattery || e.LowBattery
+	events.NodePresence = events.NodePresence || e.NodePresence
+}
+
+// restore puts back the settings apply may have changed, as they were in previous.
+func (e EmergencyNotifications) restore(notifications *config.NotificationConfig, previous config.NotificationConfig) {
+	if e.NotifyWhenFocused {
+		notifications.NotifyWhenFocused = previous.NotifyWhenFocused
+	}
+	if e.Unmute {
+		notifications.Muted = previous.Muted
+		notifications.MutedUntil = previous.MutedUntil
+		notifications.DoNotDisturb.Enabled = previous.DoNotDisturb.Enabled
+	}
+	events := &notifications.Events
+	if e.IncomingMessage {
+		events.IncomingMessage = previous.Events.IncomingMessage
+	}
+	if e.NodeDiscovered {
+		events.NodeDiscovered = previous.Events.NodeDiscovered
+	}
+	if e.ConnectionStatus {
+		events.ConnectionStatus = previous.Events.ConnectionStatus
+	}
+	if e.LowBattery {
+		events.LowBattery = previous.Events.LowBattery
+	}
+	if e.NodePresence {
+		events.NodePresence = previous.Events.NodePresence
+	}
+}
+
+// DefaultEmergencyModeProfile returns the built-in emergency preset.
+func DefaultEmergencyModeProfile() EmergencyModeProfile {
+	buzzerMode := int32(generated.Config_DeviceConfig_ALL_ENABLED)
+
+	return EmergencyModeProfile{
+		PositionBroadcastSecs: EmergencyPositionBroadcastSecs,
+		ScreenAlwaysOn:        true,
+		BuzzerMode:            &buzzerMode,
+		Notifications: EmergencyNotifications{
+			NotifyWhenFocused: true,
+			Unmute:            true,
+			IncomingMessage:   true,
+			NodeDiscovered:    true,
+			ConnectionStatus:  true,
+			LowBattery:        true,
+			NodePresence:      true,
+		},
+	}
+}
+
+// emergencyModeSnapshot keeps pre-activation state so it can be restored. It is saved
+// to the state file while emergency mode is on, so a restart does not lose it.
+type emergencyModeSnapshot struct {
+	Target        NodeSettingsTarget        `json:"target"`
+	Position      NodePositionSettings      `json:"position"`
+	Display       NodeDisplaySettings       `json:"display"`
+	Device        *NodeDeviceSettings       `json:"device,omitempty"`
+	Channels      *NodeChannelSettingsList  `json:"channels,omitempty"`
+	Notifications config.NotificationConfig `json:"notifications"`
+}
+
+// EmergencyModeService applies the emergency profile to the local node and app config
+// and restores the previous state on deactivation. While emergency mode is on, the
+// previous state is kept in a state file and loaded again on the next start.
+type EmergencyModeService struct {
+	settings      emergencyModeNodeSettings
+	currentConfig func() config.AppConfig
+	saveConfig    func(config.AppConfig) error
+	profile       EmergencyModeProfile
+	statePath     string
+	logger        *slog.Logger
+
+	mu       sync.Mutex
+	active   bool
+	snapshot emergencyModeSnapshot
+}
+
+func NewEmergencyModeService(
+	settings emergencyModeNodeSettings,
+	currentConfig func() config.AppConfig,
+	saveConfig func(config.AppConfig) error,
+	profile EmergencyModeProfile,
+	statePath string,
+	logger *slog.Logger,
+) *EmergencyModeService {
+	if logger == nil {
+		logger = slog.Default().With("component", "app.emergency_mode")
+	}
+
+	s := &EmergencyModeService{
+		settings:      settings,
+		currentConfig: currentConfig,
+		saveConfig:    saveConfig,
+		profile:       profile,
+		statePath:     strings.TrimSpace(statePath),
+		logger:        logger,
+	}
+	snapshot, ok, err := loadEmergencyModeState(s.statePath)
+	switch {
+	case err != nil:
+		logger.Warn("load emergency mode state failed", "path", s.statePath, "error", err)
+	case ok:
+		s.active = true
+		s.snapshot = snapshot
+		logger.Info("emergency mode is still active from the previous run", "node_id", strings.TrimSpace(snapshot.Target.NodeID))
+	}
+
+	return s
+}
+
+func (s *EmergencyModeService) Active() bool {
+	if s == nil {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.active
+}
+
+// Activate snapshots current node/app state and applies the emergency profile.
+// Steps already applied are rolled back when a later step fails.
+func (s *EmergencyModeService) Activate(ctx context.Context, target NodeSettingsTarget) error {
+	if s == nil || s.settings == nil || s.currentConfig == nil || s.saveConfig == nil {
+		return fmt.Errorf("emergency mode service is not initialized")
+	}
+	if strings.TrimSpace(target.NodeID) == "" {
+		return fmt.Errorf("target node id is empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active {
+		return fmt.Errorf("emergency mode is already active")
+	}
+	s.logger.Info("activating emergency mode", "trigger", "user_action", "node_id", strings.TrimSpace(target.NodeID))
+
+	snapshot := emergencyModeSnapshot{Target: target}
+	var err error
+	if snapshot.Position, err = s.settings.LoadPositionSettings(ctx, target); err != nil {
+		return fmt.Errorf("load position settings: %w", err)
+	}
+	if snapshot.Display, err = s.settings.LoadDisplaySettings(ctx, target); err != nil {
+		return fmt.Errorf("load display settings: %w", err)
+	}
+	if s.profile.BuzzerMode != nil {
+		device, err := s.settings.LoadDeviceSettings(ctx, target)
+		if err != nil {
+			return fmt.Errorf("load device settings: %w", err)
+		}
+		snapshot.Device = &device
+	}
+	if s.profile.Channels != nil {
+		channels, err := s.settings.LoadChannelSettings(ctx, target)
+		if err != nil {
+			return fmt.Errorf("load channel settings: %w", err)
+		}
+		snapshot.Channels = &channels
+	}
+	snapshot.Notifications = s.currentConfig().UI.Notifications
+
+	steps := []SettingsStep{{
+		Name: "apply position settings",
+		Apply: func(ctx context.Context) error {
+			position := snapshot.Position
+			position.PositionBroadcastSecs = s.profile.PositionBroadcastSecs
+			position.PositionBroadcastSmartEnabled = false
+			position.RemoveFixedPosition = false
+
+			return s.settings.SavePositionSettings(ctx, target, position)
+		},
+		Revert: func(ctx context.Context) error {
+			return s.settings.SavePositionSettings(ctx, target, snapshot.Position)
+		},
+	}}
+	if s.profile.ScreenAlwaysOn {
+		steps = append(steps, SettingsStep{
+			Name: "apply display settings",
+			Apply: func(ctx context.Context) error {
+				display := snapshot.Display
+				display.ScreenOnSecs = emergencyScreenAlwaysOnSecs
+
+				return s.settings.SaveDisplaySettings(ctx, target, display)
+			},
+			Revert: func(ctx context.Context) error {
+				return s.settings.SaveDisplaySettings(ctx, target, snapshot.Display)
+			},
+		})
+	}
+	if snapshot.Device != nil {
+		steps = append(steps, SettingsStep{
+			Name: "apply device buzzer mode",
+			Apply: func(ctx context.Context) error {
+				device := *snapshot.Device
+				device.BuzzerMode = *s.profile.BuzzerMode
+
+				return s.settings.SaveDeviceSettings(ctx, target, device)
+			},
+			Revert: func(ctx context.Context) error {
+				return s.settings.SaveDeviceSettings(ctx, target, *snapshot.Device)
+			},
+		})
+	}
+	if snapshot.Channels != nil {
+		steps = append(steps, SettingsStep{
+			Name: "apply channel settings",
+			Apply: func(ctx context.Context) error {
+				return s.settings.SaveChannelSettings(ctx, target, NodeChannelSettingsList{
+					NodeID:   snapshot.Channels.NodeID,
+					MaxSlots: snapshot.Channels.MaxSlots,
+					Channels: s.profile.Channels,
+				})
+			},
+			Revert: func(ctx context.Context) error {
+				return s.settings.SaveChannelSettings(ctx, target, *snapshot.Channels)
+			},
+		})
+	}
+	// App config goes last, so a device failure never needs an app config rollback.
+	steps = append(steps, AppConfigSettingsStep(
+		"apply notification overrides",
+		s.currentConfig,
+		s.saveConfig,
+		func(cfg *config.AppConfig) { s.profile.Notifications.apply(&cfg.UI.Notifications) },
+	))
+
+	// The state is saved before anything changes, so a crash halfway through still
+	// leaves the previous settings to restore from.
+	if err := saveEmergencyModeState(s.statePath, snapshot); err != nil {
+		return err
+	}
+	if err := RunSettingsTransaction(ctx, s.logger, steps...); err != nil {
+		s.removeState()
+
+		return err
+	}
+
+	s.snapshot = snapshot
+	s.active = true
+	s.logger.Info("emergency mode activated", "node_id", strings.TrimSpace(target.NodeID))
+
+	return nil
+}
+
+// Deactivate restores the state captured on activation. Every step is attempted even
+// when an earlier one fails, so as much of the previous state as possible comes back.
+func (s *EmergencyModeService) Deactivate(ctx context.Context) error {
+	if s == nil || s.settings == nil || s.saveConfig == nil {
+		return fmt.Errorf("emergency mode service is not initialized")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.active {
+		return fmt.Errorf("emergency mode is not active")
+	}
+	snapshot := s.snapshot
+	s.logger.Info("deactivating emergency mode", "trigger", "user_action", "node_id", strings.TrimSpace(snapshot.Target.NodeID))
+
+	var errs []error
+	if err := s.restoreNotifications(snapshot.Notifications); err != nil {
+		errs = append(errs, fmt.Errorf("restore notification settings: %w", err))
+	}
+	if snapshot.Channels != nil {
+		if err := s.settings.SaveChannelSettings(ctx, snapshot.Target, *snapshot.Channels); err != nil {
+			errs = append(errs, fmt.Errorf("restore channel settings: %w", err))
+		}
+	}
+	if snapshot.Device != nil {
+		if err := s.settings.SaveDeviceSettings(ctx, snapshot.Target, *snapshot.Device); err != nil {
+			errs = append(errs, fmt.Errorf("restore device settings: %w", err))
+		}
+	}
+	if s.profile.ScreenAlwaysOn {
+		if err := s.settings.SaveDisplaySettings(ctx, snapshot.Target, snapshot.Display); err != nil {
+			errs = append(errs, fmt.Errorf("restore display settings: %w", err))
+		}
+	}
+	if err := s.settings.SavePositionSettings(ctx, snapshot.Target, snapshot.Position); err != nil {
+		errs = append(errs, fmt.Errorf("restore position settings: %w", err))
+	}
+	if len(errs) > 0 {
+		err := errors.Join(errs...)
+		s.logger.Warn("emergency mode restore incomplete", "error", err)
+
+		return err
+	}
+
+	s.active = false
+	s.snapshot = emergencyModeSnapshot{}
+	s.removeState()
+	s.logger.Info("emergency mode deactivated", "node_id", strings.TrimSpace(snapshot.Target.NodeID))
+
+	return nil
+}
+
+func (s *EmergencyModeService) restoreNotifications(previous config.NotificationConfig) error {
+	cfg := s.currentConfig()
+	s.profile.Notifications.restore(&cfg.UI.Notifications, previous)
+
+	return ignoreAutostartSyncWarning(s.saveConfig(cfg))
+}
+
+func (s *EmergencyModeService) removeState() {
+	if err := removeEmergencyModeState(s.statePath); err != nil {
+		s.logger.Warn("remove emergency mode state failed", "path", s.statePath, "error", err)
+	}
+}
+
+// loadEmergencyModeState reads the snapshot saved at path. ok is false when emergency
+// mode was off, which is when the file is missing. An empty path keeps no state.
+func loadEmergencyModeState(path string) (snapshot emergencyModeSnapshot, ok bool, err error) {
+	if path == "" {
+		return emergencyModeSnapshot{}, false, nil
+	}
+	// #nosec G304 -- path is resolved by app runtime and points to user config dir.
+	raw, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return emergencyModeSnapshot{}, false, nil
+		}
+
+		return emergencyModeSnapshot{}, false, fmt.Errorf("read emergency mode state: %w", err)
+	}
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return emergencyModeSnapshot{}, false, fmt.Errorf("decode emergency mode state: %w", err)
+	}
+
+	return snapshot, true, nil
+}
+
+// saveEmergencyModeState writes snapshot to path, replacing the previous state at once.
+func saveEmergencyModeState(path string, snapshot emergencyModeSnapshot) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("create emergency mode state dir: %w", err)
+	}
+	raw, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode emergency mode state: %w", err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, raw, 0o600); err != nil {
+		return fmt.Errorf("write temp emergency mode state: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("rename temp emergency mode state: %w", err)
+	}
+
+	return nil
+}
+
+func removeEmergencyModeState(path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove emergency mode state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/config"
+)
+
+type emergencyModeSettingsSpy struct {
+	position      NodePositionSettings
+	display       NodeDisplaySettings
+	device        NodeDeviceSettings
+	channels      NodeChannelSettingsList
+	savedPosition []NodePositionSettings
+	savedDisplay  []NodeDisplaySettings
+	savedDevice   []NodeDeviceSettings
+	savedChannels []NodeChannelSettingsList
+	displayErr    error
+}
+
+func (s *emergencyModeSettingsSpy) LoadPositionSettings(context.Context, NodeSettingsTarget) (NodePositionSettings, error) {
+	return s.position, nil
+}
+
+func (s *emergencyModeSettingsSpy) SavePositionSettings(_ context.Context, _ NodeSettingsTarget, settings NodePositionSettings) error {
+	s.savedPosition = append(s.savedPosition, settings)
+
+	return nil
+}
+
+func (s *emergencyModeSettingsSpy) LoadDisplaySettings(context.Context, NodeSettingsTarget) (NodeDisplaySettings, error) {
+	return s.display, nil
+}
+
+func (s *emergencyModeSettingsSpy) SaveDisplaySettings(_ context.Context, _ NodeSettingsTarget, settings NodeDisplaySettings) error {
+	if s.displayErr != nil {
+		return s.displayErr
+	}
+	s.savedDisplay = append(s.savedDisplay, settings)
+
+	return nil
+}
+
+func (s *emergencyModeSettingsSpy) LoadDeviceSettings(context.Context, NodeSettingsTarget) (NodeDeviceSettings, error) {
+	return s.device, nil
+}
+
+func (s *emergencyModeSettingsSpy) SaveDeviceSettings(_ context.Context, _ NodeSettingsTarget, settings NodeDeviceSettings) error {
+	s.savedDevice = append(s.savedDevice, settings)
+
+	return nil
+}
+
+func (s *emergencyModeSettingsSpy) LoadChannelSettings(context.Context, NodeSettingsTarget) (NodeChannelSettingsList, error) {
+	return s.channels, nil
+}
+
+func (s *emergencyModeSettingsSpy) SaveChannelSettings(_ context.Context, _ NodeSettingsTarget, settings NodeChannelSettingsList) error {
+	s.savedChannels = append(s.savedChannels, settings)
+
+	return nil
+}
+
+type emergencyModeConfigStore struct {
+	cfg   config.AppConfig
+	saves int
+}
+
+func (s *emergencyModeConfigStore) current() config.AppConfig {
+	return s.cfg
+}
+
+func (s *emergencyModeConfigStore) save(cfg config.AppConfig) error {
+	s.cfg = cfg
+	s.saves++
+
+	return nil
+}
+
+func TestEmergencyModeServiceActivateAndRestore(t *testing.T) {
+	settings := &emergencyModeSettingsSpy{
+		position: NodePositionSettings{NodeID: "!00000001", PositionBroadcastSecs: 900, PositionBroadcastSmartEnabled: true},
+		display:  NodeDisplaySettings{NodeID: "!00000001", ScreenOnSecs: 30},
+		device:   NodeDeviceSettings{NodeID: "!00000001", BuzzerMode: 1},
+		channels: NodeChannelSettingsList{NodeID: "!00000001", MaxSlots: 8, Channels: []NodeChannelSettings{{Name: "Home"}}},
+	}
+	cfg := config.Default()
+	cfg.UI.Notifications.Events.IncomingMessage = false
+	store := &emergencyModeConfigStore{cfg: cfg}
+	profile := DefaultEmergencyModeProfile()
+	profile.Channels = []NodeChannelSettings{{Name: "SOS"}}
+	service := NewEmergencyModeService(settings, store.current, store.save, profile, "", discardLogger())
+	target := NodeSettingsTarget{NodeID: "!00000001", IsLocal: true}
+
+	if err := service.Activate(context.Background(), target); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+	if !service.Active() {
+		t.Fatalf("expected emergency mode to be active")
+	}
+	if got := settings.savedPosition[0]; got.PositionBroadcastSecs != EmergencyPositionBroadcastSecs || got.PositionBroadcastSmartEnabled {
+		t.Fatalf("unexpected emergency position settings: %+v", got)
+	}
+	if got := settings.savedDisplay[0].ScreenOnSecs; got != math.MaxUint32 {
+		t.Fatalf("expected screen always on, got %d", got)
+	}
+	if got := settings.savedDevice[0].BuzzerMode; got != 0 {
+		t.Fatalf("expected buzzer to be enabled, got mode %d", got)
+	}
+	if got := settings.savedChannels[0]; len(got.Channels) != 1 || got.Channels[0].Name != "SOS" || got.MaxSlots != 8 {
+		t.Fatalf("unexpected emergency channel set: %+v", got)
+	}
+	if !store.cfg.UI.Notifications.Events.IncomingMessage || !store.cfg.UI.Notifications.NotifyWhenFocused {
+		t.Fatalf("expected notification overrides to be applied, got %+v", store.cfg.UI.Notifications)
+	}
+	if err := service.Activate(context.Background(), target); err == nil {
+		t.Fatalf("expected second activation to fail")
+	}
+
+	if err := service.Deactivate(context.Background()); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+	if service.Active() {
+		t.Fatalf("expected emergency mode to be inactive")
+	}
+	if got := settings.savedPosition[len(settings.savedPosition)-1]; got != settings.position {
+		t.Fatalf("expected position settings to be restored, got %+v", got)
+	}
+	if got := settings.savedDisplay[len(settings.savedDisplay)-1]; got != settings.display {
+		t.Fatalf("expected display settings to be restored, got %+v", got)
+	}
+	if got := settings.savedDevice[len(settings.savedDevice)-1]; got.BuzzerMode != settings.device.BuzzerMode {
+		t.Fatalf("expected device settings to be restored, got %+v", got)
+	}
+	if got := settings.savedChannels[len(settings.savedChannels)-1]; got.Channels[0].Name != "Home" {
+		t.Fatalf("expected channels to be restored, got %+v", got)
+	}
+	if store.cfg.UI.Notifications.Events.IncomingMessage {
+		t.Fatalf("expected notification settings to be restored")
+	}
+}
+
+func TestEmergencyModeServiceActivateRollsBackOnFailure(t *testing.T) {
+	settings := &emergencyModeSettingsSpy{
+		position:   NodePositionSettings{NodeID: "!00000001", PositionBroadcastSecs: 900},
+		display:    NodeDisplaySettings{NodeID: "!00000001", ScreenOnSecs: 30},
+		displayErr: errors.New("display rejected"),
+	}
+	store := &emergencyModeConfigStore{cfg: config.Default()}
+	statePath := filepath.Join(t.TempDir(), EmergencyModeStateFilename)
+	service := NewEmergencyModeService(settings, store.current, store.save, DefaultEmergencyModeProfile(), statePath, discardLogger())
+
+	err := service.Activate(context.Background(), NodeSettingsTarget{NodeID: "!00000001", IsLocal: true})
+	var txErr *SettingsTransactionError
+	if !errors.As(err, &txErr) || txErr.Step != "apply display settings" || !txErr.RolledBack() {
+		t.Fatalf("expected rolled back display step failure, got %v", err)
+	}
+	if service.Active() {
+		t.Fatalf("expected emergency mode to stay inactive after failure")
+	}
+	if len(settings.savedPosition) != 2 || settings.savedPosition[1] != settings.position {
+		t.Fatalf("expected position settings to be rolled back, got %+v", settings.savedPosition)
+	}
+	if store.saves != 0 {
+		t.Fatalf("expected app config to stay untouched, got %d saves", store.saves)
+	}
+	if _, err := os.Stat(statePath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected no emergency mode state after failure, got %v", err)
+	}
+}
+
+func TestEmergencyModeServiceOnlyAddsAlerts(t *testing.T) {
+	settings := &emergencyModeSettingsSpy{
+		position: NodePositionSettings{NodeID: "!00000001"},
+		display:  NodeDisplaySettings{NodeID: "!00000001"},
+	}
+	cfg := config.Default()
+	cfg.UI.Notifications.Events.UpdateAvailable = true
+	cfg.UI.Notifications.Events.LowBattery = false
+	cfg.UI.Notifications.Muted = true
+	cfg.UI.Notifications.MutedNodes = []string{"!00000002"}
+	cfg.UI.Notifications.LowBatteryPercent = 30
+	cfg.UI.Notifications.ClickAction = config.NotificationClickShowWindow
+	cfg.UI.Notifications.NodePresence = map[string]config.NodePresenceAlert{"!00000003": {SilentMinutes: 60}}
+	store := &emergencyModeConfigStore{cfg: cfg}
+	service := NewEmergencyModeService(settings, store.current, store.save, DefaultEmergencyModeProfile(), "", discardLogger())
+
+	if err := service.Activate(context.Background(), NodeSettingsTarget{NodeID: "!00000001", IsLocal: true}); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+	got := store.cfg.UI.Notifications
+	if !got.Events.LowBattery || !got.Events.NodePresence || got.Muted {
+		t.Fatalf("expected emergency alerts to be on, got %+v", got)
+	}
+	if !got.Events.UpdateAvailable || got.LowBatteryPercent != 30 || got.ClickAction != config.NotificationClickShowWindow ||
+		len(got.MutedNodes) != 1 || got.NodePresence["!00000003"].SilentMinutes != 60 {
+		t.Fatalf("expected settings outside the profile to be kept, got %+v", got)
+	}
+
+	store.cfg.UI.Notifications.LowBatteryPercent = 25
+	if err := service.Deactivate(context.Background()); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+	got = store.cfg.UI.Notifications
+	if got.Events.LowBattery || !got.Muted {
+		t.Fatalf("expected alerts to be restored, got %+v", got)
+	}
+	if got.LowBatteryPercent != 25 {
+		t.Fatalf("expected changes made while active to be kept, got %d", got.LowBatteryPercent)
+	}
+}
+
+func TestEmergencyModeServiceRestoresAfterRestart(t *testing.T) {
+	settings := &emergencyModeSettingsSpy{
+		position: NodePositionSettings{NodeID: "!00000001", PositionBroadcastSecs: 900},
+		display:  NodeDisplaySettings{NodeID: "!00000001", ScreenOnSecs: 30},
+		device:   NodeDeviceSettings{NodeID: "!00000001", BuzzerMode: 1},
+	}
+	cfg := config.Default()
+	cfg.UI.Notifications.Events.IncomingMessage = false
+	store := &emergencyModeConfigStore{cfg: cfg}
+	statePath := filepath.Join(t.TempDir(), EmergencyModeStateFilename)
+	target := NodeSettingsTarget{NodeID: "!00000001", IsLocal: true}
+
+	first := NewEmergencyModeService(settings, store.current, store.save, DefaultEmergencyModeProfile(), statePath, discardLogger())
+	if err := first.Activate(context.Background(), target); err != nil {
+		t.Fatalf("activate: %v", err)
+	}
+
+	restarted := NewEmergencyModeService(settings, store.current, store.save, DefaultEmergencyModeProfile(), statePath, discardLogger())
+	if !restarted.Active() {
+		t.Fatalf("expected emergency mode to stay active after restart")
+	}
+	if err := restarted.Deactivate(context.Background()); err != nil {
+		t.Fatalf("deactivate: %v", err)
+	}
+	if got := settings.savedPosition[len(settings.savedPosition)-1]; got != settings.position {
+		t.Fatalf("expected position settings to be restored, got %+v", got)
+	}
+	if got := settings.savedDisplay[len(settings.savedDisplay)-1]; got != settings.display {
+		t.Fatalf("expected display settings to be restored, got %+v", got)
+	}
+	if got := settings.savedDevice[len(settings.savedDevice)-1]; got.BuzzerMode != settings.device.BuzzerMode {
+		t.Fatalf("expected device settings to be restored, got %+v", got)
+	}
+	if store.cfg.UI.Notifications.Events.IncomingMessage {
+		t.Fatalf("expected notification settings to be restored")
+	}
+	if _, err := os.Stat(statePath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected emergency mode state to be removed, got %v", err)
+	}
+	if NewEmergencyModeService(settings, store.current, store.save, DefaultEmergencyModeProfile(), statePath, discardLogger()).Active() {
+		t.Fatalf("expected emergency mode to be off after the next restart")
+	}
+}
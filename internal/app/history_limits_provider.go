@@ -31,6 +31,13 @@ func (p historyLimitsProvider) IdentityHistoryLimit() int {
 	)
 }
 
+func (p historyLimitsProvider) SignalHistoryLimit() int {
+	return p.limitOrDefault(
+		func(cfg config.AppConfig) *int { return cfg.Persistence.HistoryLimits.Signal },
+		config.DefaultSignalHistoryLimit,
+	)
+}
+
 func (p historyLimitsProvider) limitOrDefault(selectLimit func(config.AppConfig) *int, fallback int) int {
 	if p.currentConfig == nil {
 		return fallback
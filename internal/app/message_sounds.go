@@ -0,0 +1,192 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/platform"
+	"github.com/skobkin/meshgo/internal/sounds"
+)
+
+// messageSoundMinInterval keeps bursts of messages, e.g. history arriving after a
+// reconnect, from playing the same sound over and over.
+const messageSoundMinInterval = time.Second
+
+// messageSoundKind is the event whose sound a message plays.
+type messageSoundKind string
+
+const (
+	messageSoundSend    messageSoundKind = "send"
+	messageSoundChannel messageSoundKind = "channel"
+	messageSoundDirect  messageSoundKind = "direct"
+	messageSoundAlert   messageSoundKind = "alert"
+)
+
+// MessageSoundService plays the sound effects of sent and received chat messages.
+type MessageSoundService struct {
+	bus           bus.MessageBus
+	chatStore     *domain.ChatStore
+	currentConfig func() config.AppConfig
+	player        platform.SoundPlayer
+	cacheDir      string
+	logger        *slog.Logger
+	now           func() time.Time
+
+	mu       sync.Mutex
+	lastPlay map[messageSoundKind]time.Time
+}
+
+// NewMessageSoundService prepares the sound files in cacheDir and plays them with player.
+func NewMessageSoundService(
+	messageBus bus.MessageBus,
+	chatStore *domain.ChatStore,
+	currentConfig func() config.AppConfig,
+	player platform.SoundPlayer,
+	cacheDir string,
+	logger *slog.Logger,
+) *MessageSoundService {
+	if logger == nil {
+		logger = slog.Default().With("component", "app.sounds")
+	}
+
+	return &MessageSoundService{
+		bus:           messageBus,
+		chatStore:     chatStore,
+		currentConfig: currentConfig,
+		player:        player,
+		cacheDir:      cacheDir,
+		logger:        logger,
+		now:           time.Now,
+		lastPlay:      make(map[messageSoundKind]time.Time),
+	}
+}
+
+func (s *MessageSoundService) Start(ctx context.Context) {
+	if s == nil || s.bus == nil || s.player == nil {
+		return
+	}
+
+	textSub := s.bus.Subscribe(bus.TopicTextMessage)
+	go func() {
+		defer s.bus.Unsubscribe(textSub, bus.TopicTextMessage)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case raw, ok := <-textSub:
+				if !ok {
+					return
+				}
+				msg, ok := raw.(domain.ChatMessage)
+				if !ok {
+					continue
+				}
+				s.handleMessage(msg)
+			}
+		}
+	}()
+}
+
+// Preview plays the sound of an event regardless of the mute and enabled settings, so
+// it can be tried out in settings.
+func (s *MessageSoundService) Preview(event config.SoundEventConfig) error {
+	if s == nil || s.player == nil {
+		return fmt.Errorf("sound playback is not available")
+	}
+
+	return s.play(event)
+}
+
+func (s *MessageSoundService) handleMessage(msg domain.ChatMessage) {
+	cfg := config.Default()
+	if s.currentConfig != nil {
+		cfg = s.currentConfig()
+		cfg.FillMissingDefaults()
+	}
+	prefs := cfg.UI.Sounds
+	if !prefs.Enabled || cfg.UI.Notifications.MutedAt(s.now()) {
+		return
+	}
+	// Reactions are shown on the message they react to, not as messages of their own.
+	if strings.TrimSpace(msg.ReplyToDeviceMessageID) != "" && msg.Emoji != 0 {
+		return
+	}
+
+	var (
+		event config.SoundEventConfig
+		kind  messageSoundKind
+	)
+	switch msg.Direction {
+	case domain.MessageDirectionOut:
+		// Queued messages are published again once they leave the outbox.
+		if strings.TrimSpace(msg.QueuedMessageID) != "" {
+			return
+		}
+		event, kind = prefs.Send, messageSoundSend
+	case domain.MessageDirectionIn:
+		if cfg.UI.Notifications.MutesNode(senderNodeIDForMessage(msg)) || s.chatMuted(msg.ChatKey) {
+			return
+		}
+		bell := domain.MessageRingsBell(msg.Body)
+		if cfg.UI.Notifications.DoNotDisturb.SilencesAt(s.now(), bell) {
+			return
+		}
+		switch {
+		case bell:
+			event, kind = prefs.Alert, messageSoundAlert
+		case domain.IsDMKey(msg.ChatKey):
+			event, kind = prefs.Direct, messageSoundDirect
+		default:
+			event, kind = prefs.Receive, messageSoundChannel
+		}
+	default:
+		return
+	}
+	if !event.Enabled || !s.claimPlay(kind) {
+		return
+	}
+
+	if err := s.play(event); err != nil {
+		s.logger.Warn("failed to play message sound", "event", kind, "sound", event.Sound, "error", err)
+	}
+}
+
+func (s *MessageSoundService) chatMuted(chatKey string) bool {
+	if s.chatStore == nil {
+		return false
+	}
+	chat, ok := s.chatStore.ChatByKey(chatKey)
+
+	return ok && chat.Notifications.MutedAt(s.now())
+}
+
+// claimPlay reports whether the sound of the event may play now and records it.
+func (s *MessageSoundService) claimPlay(kind messageSoundKind) bool {
+	now := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if last, ok := s.lastPlay[kind]; ok && now.Sub(last) < messageSoundMinInterval {
+		return false
+	}
+	s.lastPlay[kind] = now
+
+	return true
+}
+
+func (s *MessageSoundService) play(event config.SoundEventConfig) error {
+	path, err := sounds.Prepare(s.cacheDir, event.Sound, event.Volume)
+	if err != nil {
+		return err
+	}
+
+	return s.player.Play(path)
+}
@@ -0,0 +1,220 @@
+package app
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/sounds"
+)
+
+func TestMessageSoundServicePlaysEventSounds(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mutedChat := domain.ChatKeyForChannel(1)
+	chatStore := domain.NewChatStore()
+	chatStore.UpsertChat(domain.Chat{Key: mutedChat, Title: "Noisy", Type: domain.ChatTypeChannel, Notifications: domain.ChatNotificationPrefs{Muted: true}})
+	doNotDisturb := func(cfg *config.AppConfig) {
+		cfg.UI.Notifications.DoNotDisturb = config.DoNotDisturbConfig{
+			Enabled:    true,
+			Start:      now.Local().Format("15:04"),
+			End:        now.Local().Add(time.Hour).Format("15:04"),
+			AllowBells: true,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		prepare func(cfg *config.AppConfig)
+		msg     domain.ChatMessage
+		want    int
+	}{
+		{
+			name: "received",
+			msg:  domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionIn, Body: "hi"},
+			want: 1,
+		},
+		{
+			name: "sent",
+			msg:  domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionOut, Body: "hi"},
+			want: 1,
+		},
+		{
+			name:    "sounds disabled",
+			prepare: func(cfg *config.AppConfig) { cfg.UI.Sounds.Enabled = false },
+			msg:     domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionIn, Body: "hi"},
+		},
+		{
+			name:    "event disabled",
+			prepare: func(cfg *config.AppConfig) { cfg.UI.Sounds.Send.Enabled = false },
+			msg:     domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionOut, Body: "hi"},
+		},
+		{
+			name:    "notifications muted",
+			prepare: func(cfg *config.AppConfig) { cfg.UI.Notifications.Muted = true },
+			msg:     domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionIn, Body: "hi"},
+		},
+		{
+			name:    "node muted",
+			prepare: func(cfg *config.AppConfig) { cfg.UI.Notifications.SetNodeMuted("!87654321", true) },
+			msg:     domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionIn, Body: "hi", MetaJSON: `{"from":"!87654321"}`},
+		},
+		{
+			name: "chat muted",
+			msg:  domain.ChatMessage{ChatKey: mutedChat, Direction: domain.MessageDirectionIn, Body: "hi"},
+		},
+		{
+			name:    "do not disturb",
+			prepare: doNotDisturb,
+			msg:     domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionIn, Body: "hi"},
+		},
+		{
+			name:    "bell during do not disturb",
+			prepare: doNotDisturb,
+			msg:     domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionIn, Body: "\agate open"},
+			want:    1,
+		},
+		{
+			name:    "sent during do not disturb",
+			prepare: doNotDisturb,
+			msg:     domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionOut, Body: "hi"},
+			want:    1,
+		},
+		{
+			name:    "alert silenced",
+			prepare: func(cfg *config.AppConfig) { cfg.UI.Sounds.Alert.Enabled = false },
+			msg:     domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionIn, Body: "\agate open"},
+		},
+		{
+			name: "flushed from outbox",
+			msg:  domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionOut, Body: "hi", QueuedMessageID: "q1"},
+		},
+		{
+			name: "reaction",
+			msg:  domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionIn, Body: "👍", ReplyToDeviceMessageID: "7", Emoji: 1},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.UI.Sounds.Enabled = true
+			if tc.prepare != nil {
+				tc.prepare(&cfg)
+			}
+			player := &collectingSoundPlayer{}
+			service := NewMessageSoundService(nil, chatStore, func() config.AppConfig { return cfg }, player, t.TempDir(), nil)
+			service.now = func() time.Time { return now }
+
+			service.handleMessage(tc.msg)
+
+			if got := len(player.played()); got != tc.want {
+				t.Fatalf("expected %d sounds, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMessageSoundServicePlaysSoundOfMessageKind(t *testing.T) {
+	cfg := config.Default()
+	cfg.UI.Sounds.Enabled = true
+	cfg.UI.Sounds.Receive = config.SoundEventConfig{Enabled: true, Sound: config.SoundChime, Volume: 10}
+	cfg.UI.Sounds.Direct = config.SoundEventConfig{Enabled: true, Sound: config.SoundPop, Volume: 20}
+	cfg.UI.Sounds.Alert = config.SoundEventConfig{Enabled: true, Sound: config.SoundBell, Volume: 30}
+
+	tests := []struct {
+		name string
+		msg  domain.ChatMessage
+		want config.SoundEventConfig
+	}{
+		{
+			name: "channel",
+			msg:  domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionIn, Body: "hi"},
+			want: cfg.UI.Sounds.Receive,
+		},
+		{
+			name: "direct",
+			msg:  domain.ChatMessage{ChatKey: domain.ChatKeyForDM("!87654321"), Direction: domain.MessageDirectionIn, Body: "hi"},
+			want: cfg.UI.Sounds.Direct,
+		},
+		{
+			name: "alert",
+			msg:  domain.ChatMessage{ChatKey: domain.ChatKeyForDM("!87654321"), Direction: domain.MessageDirectionIn, Body: "\agate open"},
+			want: cfg.UI.Sounds.Alert,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			player := &collectingSoundPlayer{}
+			service := NewMessageSoundService(nil, domain.NewChatStore(), func() config.AppConfig { return cfg }, player, dir, nil)
+
+			service.handleMessage(tc.msg)
+
+			want, err := sounds.Prepare(dir, tc.want.Sound, tc.want.Volume)
+			if err != nil {
+				t.Fatalf("prepare sound: %v", err)
+			}
+			if got := player.played(); len(got) != 1 || got[0] != want {
+				t.Fatalf("expected %v, got %v", []string{want}, got)
+			}
+		})
+	}
+}
+
+func TestMessageSoundServiceThrottlesBursts(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cfg := config.Default()
+	cfg.UI.Sounds.Enabled = true
+	player := &collectingSoundPlayer{}
+	service := NewMessageSoundService(nil, domain.NewChatStore(), func() config.AppConfig { return cfg }, player, t.TempDir(), nil)
+	service.now = func() time.Time { return now }
+	incoming := domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionIn, Body: "hi"}
+
+	service.handleMessage(incoming)
+	service.handleMessage(incoming)
+	service.handleMessage(domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionOut, Body: "hi"})
+	now = now.Add(messageSoundMinInterval)
+	service.handleMessage(incoming)
+
+	if got := len(player.played()); got != 3 {
+		t.Fatalf("expected 3 sounds, got %d", got)
+	}
+}
+
+func TestMessageSoundServicePreviewIgnoresMute(t *testing.T) {
+	cfg := config.Default()
+	cfg.UI.Notifications.Muted = true
+	player := &collectingSoundPlayer{}
+	service := NewMessageSoundService(nil, nil, func() config.AppConfig { return cfg }, player, t.TempDir(), nil)
+
+	if err := service.Preview(config.SoundEventConfig{Sound: config.SoundBell, Volume: 30}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := len(player.played()); got != 1 {
+		t.Fatalf("expected 1 sound, got %d", got)
+	}
+	if err := service.Preview(config.SoundEventConfig{Sound: "/missing/sound.wav", Volume: 30}); err == nil {
+		t.Fatalf("expected an error for a missing sound file")
+	}
+}
+
+type collectingSoundPlayer struct {
+	mu    sync.Mutex
+	paths []string
+}
+
+func (p *collectingSoundPlayer) Play(path string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.paths = append(p.paths, path)
+
+	return nil
+}
+
+func (p *collectingSoundPlayer) played() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]string(nil), p.paths...)
+}
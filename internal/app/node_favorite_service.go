@@ -19,7 +19,8 @@ type nodeFavoriteRadioSender interface {
 	SendAdmin(to uint32, channel uint32, wantResponse bool, payload *generated.AdminMessage) (string, error)
 }
 
-// NodeFavoriteService toggles Meshtastic node favorites and keeps local state in sync.
+// NodeFavoriteService toggles Meshtastic node favorites and ignores and keeps local
+// state in sync.
 type NodeFavoriteService struct {
 	radio       nodeFavoriteRadioSender
 	nodeStore   *domain.NodeStore
@@ -52,52 +53,93 @@ func NewNodeFavoriteService(
 }
 
 func (s *NodeFavoriteService) SetFavorite(ctx context.Context, targetNodeID string, favorite bool) error {
+	targetNodeNum, err := s.sendNodeListAdmin(ctx, targetNodeID, func(nodeNum uint32) *generated.AdminMessage {
+		if favorite {
+			return &generated.AdminMessage{PayloadVariant: &generated.AdminMessage_SetFavoriteNode{SetFavoriteNode: nodeNum}}
+		}
+
+		return &generated.AdminMessage{PayloadVariant: &generated.AdminMessage_RemoveFavoriteNode{RemoveFavoriteNode: nodeNum}}
+	})
+	if err != nil {
+		return err
+	}
+
+	targetNodeID = strings.TrimSpace(targetNodeID)
+	s.publishLocalNodeUpdate(domain.NodeCore{NodeID: targetNodeID, IsFavorite: boolPtr(favorite)})
+	s.logger.Info(
+		"node favorite toggled",
+		"target_node_id", targetNodeID,
+		"target_node_num", targetNodeNum,
+		"value", favorite,
+	)
+
+	return nil
+}
+
+// SetIgnored adds the node to the ignore list of the local node, which then drops its
+// packets, or removes it from there.
+func (s *NodeFavoriteService) SetIgnored(ctx context.Context, targetNodeID string, ignored bool) error {
+	targetNodeNum, err := s.sendNodeListAdmin(ctx, targetNodeID, func(nodeNum uint32) *generated.AdminMessage {
+		if ignored {
+			return &generated.AdminMessage{PayloadVariant: &generated.AdminMessage_SetIgnoredNode{SetIgnoredNode: nodeNum}}
+		}
+
+		return &generated.AdminMessage{PayloadVariant: &generated.AdminMessage_RemoveIgnoredNode{RemoveIgnoredNode: nodeNum}}
+	})
+	if err != nil {
+		return err
+	}
+
+	targetNodeID = strings.TrimSpace(targetNodeID)
+	s.publishLocalNodeUpdate(domain.NodeCore{NodeID: targetNodeID, IsIgnored: boolPtr(ignored)})
+	s.logger.Info(
+		"node ignore toggled",
+		"target_node_id", targetNodeID,
+		"target_node_num", targetNodeNum,
+		"value", ignored,
+	)
+
+	return nil
+}
+
+// sendNodeListAdmin sends the admin message built for the target node to the local node.
+func (s *NodeFavoriteService) sendNodeListAdmin(
+	ctx context.Context,
+	targetNodeID string,
+	payload func(nodeNum uint32) *generated.AdminMessage,
+) (uint32, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 	if err := ctx.Err(); err != nil {
-		return err
+		return 0, err
 	}
 	if s == nil || s.radio == nil {
-		return fmt.Errorf("node favorite service is not initialized")
+		return 0, fmt.Errorf("node favorite service is not initialized")
 	}
 	if !s.isConnected() {
-		return fmt.Errorf("device is not connected")
+		return 0, fmt.Errorf("device is not connected")
 	}
 
 	targetNodeID = strings.TrimSpace(targetNodeID)
 	if targetNodeID == "" {
-		return fmt.Errorf("target node id is empty")
+		return 0, fmt.Errorf("target node id is empty")
 	}
 	targetNodeNum, err := parseNodeID(targetNodeID)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	localNodeNum, err := s.resolveLocalNodeNum()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	adminPayload := &generated.AdminMessage{}
-	if favorite {
-		adminPayload.PayloadVariant = &generated.AdminMessage_SetFavoriteNode{SetFavoriteNode: targetNodeNum}
-	} else {
-		adminPayload.PayloadVariant = &generated.AdminMessage_RemoveFavoriteNode{RemoveFavoriteNode: targetNodeNum}
+	if _, err := s.radio.SendAdmin(localNodeNum, nodeFavoriteAdminChannel, false, payload(targetNodeNum)); err != nil {
+		return 0, err
 	}
-	if _, err := s.radio.SendAdmin(localNodeNum, nodeFavoriteAdminChannel, false, adminPayload); err != nil {
-		return err
-	}
-
-	s.publishLocalFavoriteUpdate(targetNodeID, favorite)
-	s.logger.Info(
-		"node favorite toggled",
-		"target_node_id", targetNodeID,
-		"target_node_num", targetNodeNum,
-		"value", favorite,
-	)
 
-	return nil
+	return targetNodeNum, nil
 }
 
 func (s *NodeFavoriteService) isConnected() bool {
@@ -121,13 +163,12 @@ func (s *NodeFavoriteService) resolveLocalNodeNum() (uint32, error) {
 	return parseNodeID(localNodeID)
 }
 
-func (s *NodeFavoriteService) publishLocalFavoriteUpdate(nodeID string, favorite bool) {
+// publishLocalNodeUpdate applies the favorite or ignore flag set in core right away,
+// without waiting for the node info of the device.
+func (s *NodeFavoriteService) publishLocalNodeUpdate(core domain.NodeCore) {
+	core.UpdatedAt = time.Now()
 	update := domain.NodeCoreUpdate{
-		Core: domain.NodeCore{
-			NodeID:     strings.TrimSpace(nodeID),
-			IsFavorite: boolPtr(favorite),
-			UpdatedAt:  time.Now(),
-		},
+		Core:       core,
 		FromPacket: false,
 		Type:       domain.NodeUpdateTypeUnknown,
 	}
@@ -139,9 +180,10 @@ func (s *NodeFavoriteService) publishLocalFavoriteUpdate(nodeID string, favorite
 	}
 	if s.nodeStore != nil {
 		s.nodeStore.Upsert(domain.Node{
-			NodeID:     update.Core.NodeID,
-			IsFavorite: update.Core.IsFavorite,
-			UpdatedAt:  update.Core.UpdatedAt,
+			NodeID:     core.NodeID,
+			IsFavorite: core.IsFavorite,
+			IsIgnored:  core.IsIgnored,
+			UpdatedAt:  core.UpdatedAt,
 		})
 	}
 }
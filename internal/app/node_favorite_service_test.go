@@ -115,6 +115,48 @@ func TestNodeFavoriteServiceUnsetFavoriteWithoutBusUpdatesStore(t *testing.T) {
 	}
 }
 
+func TestNodeFavoriteServiceSetIgnored(t *testing.T) {
+	radio := &nodeFavoriteRadioSpy{}
+	store := domain.NewNodeStore()
+	store.Upsert(domain.Node{NodeID: "!0000002a"})
+
+	service := NewNodeFavoriteService(
+		radio,
+		store,
+		nil,
+		func() string { return "!00000001" },
+		func() (busmsg.ConnectionStatus, bool) {
+			return busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnected}, true
+		},
+		discardLogger(),
+	)
+
+	if err := service.SetIgnored(context.Background(), "!0000002a", true); err != nil {
+		t.Fatalf("set ignored: %v", err)
+	}
+	if radio.to != 0x1 {
+		t.Fatalf("unexpected admin target node: %d", radio.to)
+	}
+	if radio.payload == nil || radio.payload.GetSetIgnoredNode() != 0x2a {
+		t.Fatalf("expected set_ignored_node payload, got %+v", radio.payload)
+	}
+	node, ok := store.Get("!0000002a")
+	if !ok || node.IsIgnored == nil || !*node.IsIgnored {
+		t.Fatalf("expected ignored=true in store, got %v", node.IsIgnored)
+	}
+
+	if err := service.SetIgnored(context.Background(), "!0000002a", false); err != nil {
+		t.Fatalf("unset ignored: %v", err)
+	}
+	if radio.payload == nil || radio.payload.GetRemoveIgnoredNode() != 0x2a {
+		t.Fatalf("expected remove_ignored_node payload, got %+v", radio.payload)
+	}
+	node, _ = store.Get("!0000002a")
+	if node.IsIgnored == nil || *node.IsIgnored {
+		t.Fatalf("expected ignored=false in store, got %v", node.IsIgnored)
+	}
+}
+
 func TestNodeFavoriteServiceSetFavoriteValidation(t *testing.T) {
 	t.Run("fails when disconnected", func(t *testing.T) {
 		service := NewNodeFavoriteService(
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/skobkin/meshgo/internal/config"
 	"github.com/skobkin/meshgo/internal/domain"
@@ -21,13 +23,15 @@ type nodeOverviewRadioSender interface {
 
 // NodeOverviewService provides request and history actions used by node overview UI.
 type NodeOverviewService struct {
-	radio         nodeOverviewRadioSender
-	nodeStore     *domain.NodeStore
-	telemetryRepo domain.NodeTelemetryRepository
-	positionRepo  domain.NodePositionRepository
-	identityRepo  domain.NodeIdentityHistoryRepository
-	connStatus    func() (busmsg.ConnectionStatus, bool)
-	logger        *slog.Logger
+	radio          nodeOverviewRadioSender
+	nodeStore      *domain.NodeStore
+	telemetryRepo  domain.NodeTelemetryRepository
+	positionRepo   domain.NodePositionRepository
+	identityRepo   domain.NodeIdentityHistoryRepository
+	tracerouteRepo domain.TracerouteRepository
+	signalRepo     domain.NodeSignalHistoryRepository
+	connStatus     func() (busmsg.ConnectionStatus, bool)
+	logger         *slog.Logger
 }
 
 func NewNodeOverviewService(
@@ -36,6 +40,8 @@ func NewNodeOverviewService(
 	telemetryRepo domain.NodeTelemetryRepository,
 	positionRepo domain.NodePositionRepository,
 	identityRepo domain.NodeIdentityHistoryRepository,
+	tracerouteRepo domain.TracerouteRepository,
+	signalRepo domain.NodeSignalHistoryRepository,
 	connStatus func() (busmsg.ConnectionStatus, bool),
 	logger *slog.Logger,
 ) *NodeOverviewService {
@@ -44,13 +50,15 @@ func NewNodeOverviewService(
 	}
 
 	return &NodeOverviewService{
-		radio:         radio,
-		nodeStore:     nodeStore,
-		telemetryRepo: telemetryRepo,
-		positionRepo:  positionRepo,
-		identityRepo:  identityRepo,
-		connStatus:    connStatus,
-		logger:        logger,
+		radio:          radio,
+		nodeStore:      nodeStore,
+		telemetryRepo:  telemetryRepo,
+		positionRepo:   positionRepo,
+		identityRepo:   identityRepo,
+		tracerouteRepo: tracerouteRepo,
+		signalRepo:     signalRepo,
+		connStatus:     connStatus,
+		logger:         logger,
 	}
 }
 
@@ -151,6 +159,27 @@ func (s *NodeOverviewService) ListPositionHistory(ctx context.Context, nodeID st
 	})
 }
 
+// ListPositionTrack returns every position received from the node between from and to,
+// oldest first. Zero bounds leave that side of the range open.
+func (s *NodeOverviewService) ListPositionTrack(ctx context.Context, nodeID string, from, to time.Time) ([]domain.NodeTrackPoint, error) {
+	if s == nil || s.positionRepo == nil {
+		return nil, fmt.Errorf("node overview position repository is not initialized")
+	}
+	nodeID = strings.TrimSpace(nodeID)
+	if nodeID == "" {
+		return nil, fmt.Errorf("node id is required")
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, fmt.Errorf("track range ends before it starts")
+	}
+
+	return s.positionRepo.ListTrack(ctx, domain.NodeTrackQuery{
+		NodeID: nodeID,
+		From:   from,
+		To:     to,
+	})
+}
+
 func (s *NodeOverviewService) ListIdentityHistory(ctx context.Context, nodeID string, limit int) ([]domain.NodeIdentityHistoryEntry, error) {
 	if s == nil || s.identityRepo == nil {
 		return nil, fmt.Errorf("node overview identity repository is not initialized")
@@ -170,6 +199,58 @@ func (s *NodeOverviewService) ListIdentityHistory(ctx context.Context, nodeID st
 	})
 }
 
+// ListTracerouteHistory returns past traceroutes toward the node, newest first.
+func (s *NodeOverviewService) ListTracerouteHistory(ctx context.Context, nodeID string, limit int) ([]domain.TracerouteRecord, error) {
+	if s == nil || s.tracerouteRepo == nil {
+		return nil, fmt.Errorf("node overview traceroute repository is not initialized")
+	}
+	nodeID = strings.TrimSpace(nodeID)
+	if nodeID == "" {
+		return nil, fmt.Errorf("node id is required")
+	}
+	if limit <= 0 {
+		limit = config.DefaultTracerouteHistoryLimit
+	}
+
+	return s.tracerouteRepo.ListByTargetNodeID(ctx, nodeID, limit)
+}
+
+// ListSignalHistory returns the node's recorded RSSI/SNR readings, oldest first.
+func (s *NodeOverviewService) ListSignalHistory(ctx context.Context, nodeID string, limit int) ([]domain.NodeSignalHistoryEntry, error) {
+	if s == nil || s.signalRepo == nil {
+		return nil, fmt.Errorf("node overview signal repository is not initialized")
+	}
+	nodeID = strings.TrimSpace(nodeID)
+	if nodeID == "" {
+		return nil, fmt.Errorf("node id is required")
+	}
+	if limit <= 0 {
+		limit = config.DefaultSignalHistoryLimit
+	}
+	items, err := s.signalRepo.ListHistoryByNodeID(ctx, domain.NodeHistoryQuery{
+		NodeID: nodeID,
+		Limit:  limit,
+		Order:  domain.SortDescending,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The newest readings are selected first so the limit drops the oldest ones.
+	slices.Reverse(items)
+
+	return items, nil
+}
+
+// EstimateBatteryRuntime extrapolates stored battery telemetry of the node. It reports
+// false when the node is on external power or its history shows no clear trend yet.
+func (s *NodeOverviewService) EstimateBatteryRuntime(ctx context.Context, nodeID string) (domain.BatteryEstimate, bool, error) {
+	if s == nil || s.telemetryRepo == nil {
+		return domain.BatteryEstimate{}, false, fmt.Errorf("node overview telemetry repository is not initialized")
+	}
+
+	return estimateNodeBatteryRuntime(ctx, s.telemetryRepo, nodeID, time.Now())
+}
+
 func (s *NodeOverviewService) isConnected() bool {
 	if s == nil || s.connStatus == nil {
 		return false
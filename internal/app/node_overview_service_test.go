@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/skobkin/meshgo/internal/config"
 	"github.com/skobkin/meshgo/internal/domain"
@@ -74,9 +75,11 @@ func (s *telemetryRepoSpy) ListHistoryByNodeID(_ context.Context, query domain.N
 }
 
 type positionRepoSpy struct {
-	items     []domain.NodePositionHistoryEntry
-	err       error
-	lastQuery domain.NodeHistoryQuery
+	items          []domain.NodePositionHistoryEntry
+	track          []domain.NodeTrackPoint
+	err            error
+	lastQuery      domain.NodeHistoryQuery
+	lastTrackQuery domain.NodeTrackQuery
 }
 
 func (s *positionRepoSpy) Upsert(context.Context, domain.NodePositionUpdate, int) error {
@@ -100,6 +103,15 @@ func (s *positionRepoSpy) ListHistoryByNodeID(_ context.Context, query domain.No
 	return s.items, nil
 }
 
+func (s *positionRepoSpy) ListTrack(_ context.Context, query domain.NodeTrackQuery) ([]domain.NodeTrackPoint, error) {
+	s.lastTrackQuery = query
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return s.track, nil
+}
+
 type identityRepoSpy struct {
 	items     []domain.NodeIdentityHistoryEntry
 	err       error
@@ -115,6 +127,38 @@ func (s *identityRepoSpy) ListHistoryByNodeID(_ context.Context, query domain.No
 	return s.items, nil
 }
 
+type tracerouteRepoSpy struct {
+	items     []domain.TracerouteRecord
+	lastNode  string
+	lastLimit int
+}
+
+func (s *tracerouteRepoSpy) Upsert(context.Context, domain.TracerouteRecord) error {
+	return nil
+}
+
+func (s *tracerouteRepoSpy) ListByTargetNodeID(_ context.Context, nodeID string, limit int) ([]domain.TracerouteRecord, error) {
+	s.lastNode = nodeID
+	s.lastLimit = limit
+
+	return s.items, nil
+}
+
+type signalRepoSpy struct {
+	items     []domain.NodeSignalHistoryEntry
+	lastQuery domain.NodeHistoryQuery
+}
+
+func (s *signalRepoSpy) Record(context.Context, domain.NodeSignalHistoryEntry, int) error {
+	return nil
+}
+
+func (s *signalRepoSpy) ListHistoryByNodeID(_ context.Context, query domain.NodeHistoryQuery) ([]domain.NodeSignalHistoryEntry, error) {
+	s.lastQuery = query
+
+	return append([]domain.NodeSignalHistoryEntry(nil), s.items...), nil
+}
+
 func TestNodeOverviewServiceRequestUserInfo(t *testing.T) {
 	store := domain.NewNodeStore()
 	channel := uint32(5)
@@ -126,6 +170,8 @@ func TestNodeOverviewServiceRequestUserInfo(t *testing.T) {
 		&telemetryRepoSpy{},
 		&positionRepoSpy{},
 		&identityRepoSpy{},
+		&tracerouteRepoSpy{},
+		&signalRepoSpy{},
 		func() (busmsg.ConnectionStatus, bool) {
 			return busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnected}, true
 		},
@@ -164,6 +210,8 @@ func TestNodeOverviewServiceRequestTelemetry(t *testing.T) {
 		&telemetryRepoSpy{},
 		&positionRepoSpy{},
 		&identityRepoSpy{},
+		&tracerouteRepoSpy{},
+		&signalRepoSpy{},
 		func() (busmsg.ConnectionStatus, bool) {
 			return busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnected}, true
 		},
@@ -192,6 +240,8 @@ func TestNodeOverviewServiceRequestFailsWhenDisconnected(t *testing.T) {
 		&telemetryRepoSpy{},
 		&positionRepoSpy{},
 		&identityRepoSpy{},
+		&tracerouteRepoSpy{},
+		&signalRepoSpy{},
 		func() (busmsg.ConnectionStatus, bool) {
 			return busmsg.ConnectionStatus{State: busmsg.ConnectionStateDisconnected}, true
 		},
@@ -215,6 +265,8 @@ func TestNodeOverviewServiceRequestTelemetryPropagatesRadioError(t *testing.T) {
 		&telemetryRepoSpy{},
 		&positionRepoSpy{},
 		&identityRepoSpy{},
+		&tracerouteRepoSpy{},
+		&signalRepoSpy{},
 		func() (busmsg.ConnectionStatus, bool) {
 			return busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnected}, true
 		},
@@ -239,6 +291,8 @@ func TestNodeOverviewServiceListTelemetryHistory(t *testing.T) {
 		repo,
 		&positionRepoSpy{},
 		&identityRepoSpy{},
+		&tracerouteRepoSpy{},
+		&signalRepoSpy{},
 		func() (busmsg.ConnectionStatus, bool) { return busmsg.ConnectionStatus{}, false },
 		nil,
 	)
@@ -273,6 +327,8 @@ func TestNodeOverviewServiceListPositionHistory(t *testing.T) {
 		&telemetryRepoSpy{},
 		repo,
 		&identityRepoSpy{},
+		&tracerouteRepoSpy{},
+		&signalRepoSpy{},
 		func() (busmsg.ConnectionStatus, bool) { return busmsg.ConnectionStatus{}, false },
 		nil,
 	)
@@ -307,6 +363,8 @@ func TestNodeOverviewServiceListIdentityHistory(t *testing.T) {
 		&telemetryRepoSpy{},
 		&positionRepoSpy{},
 		repo,
+		&tracerouteRepoSpy{},
+		&signalRepoSpy{},
 		func() (busmsg.ConnectionStatus, bool) { return busmsg.ConnectionStatus{}, false },
 		nil,
 	)
@@ -328,3 +386,104 @@ func TestNodeOverviewServiceListIdentityHistory(t *testing.T) {
 		t.Fatalf("unexpected query order: %q", repo.lastQuery.Order)
 	}
 }
+
+func TestNodeOverviewServiceListTracerouteHistory(t *testing.T) {
+	repo := &tracerouteRepoSpy{
+		items: []domain.TracerouteRecord{{RequestID: "7", TargetNodeID: "!0000002a"}},
+	}
+	service := NewNodeOverviewService(
+		&nodeOverviewRadioSpy{},
+		domain.NewNodeStore(),
+		&telemetryRepoSpy{},
+		&positionRepoSpy{},
+		&identityRepoSpy{},
+		repo,
+		&signalRepoSpy{},
+		func() (busmsg.ConnectionStatus, bool) { return busmsg.ConnectionStatus{}, false },
+		nil,
+	)
+
+	items, err := service.ListTracerouteHistory(context.Background(), " !0000002a ", 0)
+	if err != nil {
+		t.Fatalf("list traceroute history: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected one traceroute, got %d", len(items))
+	}
+	if repo.lastNode != "!0000002a" {
+		t.Fatalf("unexpected query node id: %q", repo.lastNode)
+	}
+	if repo.lastLimit != config.DefaultTracerouteHistoryLimit {
+		t.Fatalf("unexpected default query limit: %d", repo.lastLimit)
+	}
+	if _, err := service.ListTracerouteHistory(context.Background(), " ", 0); err == nil {
+		t.Fatalf("expected empty node id to be rejected")
+	}
+}
+
+func TestNodeOverviewServiceListSignalHistory(t *testing.T) {
+	repo := &signalRepoSpy{
+		items: []domain.NodeSignalHistoryEntry{
+			{RowID: 2, NodeID: "!0000002a"},
+			{RowID: 1, NodeID: "!0000002a"},
+		},
+	}
+	service := NewNodeOverviewService(
+		&nodeOverviewRadioSpy{},
+		domain.NewNodeStore(),
+		&telemetryRepoSpy{},
+		&positionRepoSpy{},
+		&identityRepoSpy{},
+		&tracerouteRepoSpy{},
+		repo,
+		func() (busmsg.ConnectionStatus, bool) { return busmsg.ConnectionStatus{}, false },
+		nil,
+	)
+
+	items, err := service.ListSignalHistory(context.Background(), "!0000002a", 0)
+	if err != nil {
+		t.Fatalf("list signal history: %v", err)
+	}
+	if len(items) != 2 || items[0].RowID != 1 || items[1].RowID != 2 {
+		t.Fatalf("expected readings oldest first, got %+v", items)
+	}
+	if repo.lastQuery.Limit != config.DefaultSignalHistoryLimit {
+		t.Fatalf("unexpected default query limit: %d", repo.lastQuery.Limit)
+	}
+	if repo.lastQuery.Order != domain.SortDescending {
+		t.Fatalf("unexpected query order: %q", repo.lastQuery.Order)
+	}
+}
+
+func TestNodeOverviewServiceListPositionTrack(t *testing.T) {
+	repo := &positionRepoSpy{
+		track: []domain.NodeTrackPoint{{NodeID: "!0000002a", Latitude: 1, Longitude: 2}},
+	}
+	service := NewNodeOverviewService(
+		&nodeOverviewRadioSpy{},
+		domain.NewNodeStore(),
+		&telemetryRepoSpy{},
+		repo,
+		&identityRepoSpy{},
+		&tracerouteRepoSpy{},
+		&signalRepoSpy{},
+		func() (busmsg.ConnectionStatus, bool) { return busmsg.ConnectionStatus{}, false },
+		nil,
+	)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	points, err := service.ListPositionTrack(context.Background(), " !0000002a ", from, to)
+	if err != nil {
+		t.Fatalf("list position track: %v", err)
+	}
+	if len(points) != 1 {
+		t.Fatalf("expected one track point, got %d", len(points))
+	}
+	if repo.lastTrackQuery.NodeID != "!0000002a" || !repo.lastTrackQuery.From.Equal(from) || !repo.lastTrackQuery.To.Equal(to) {
+		t.Fatalf("unexpected track query: %+v", repo.lastTrackQuery)
+	}
+	if _, err := service.ListPositionTrack(context.Background(), "!0000002a", to, from); err == nil {
+		t.Fatalf("expected reversed range to be rejected")
+	}
+}
@@ -285,8 +285,11 @@ func TestNodeSettingsServiceLoadNewModuleSettings(t *testing.T) {
 								GetModuleConfigResponse: &generated.ModuleConfig{
 									PayloadVariant: &generated.ModuleConfig_RemoteHardware{
 										RemoteHardware: &generated.ModuleConfig_RemoteHardwareConfig{
-											Enabled:       true,
-											AvailablePins: []*generated.RemoteHardwarePin{{GpioPin: 3}, {GpioPin: 4}},
+											Enabled: true,
+											AvailablePins: []*generated.RemoteHardwarePin{
+												{GpioPin: 3},
+												{GpioPin: 4, Name: "Gate", Type: generated.RemoteHardwarePinType_DIGITAL_WRITE},
+											},
 										},
 									},
 								},
@@ -304,7 +307,8 @@ func TestNodeSettingsServiceLoadNewModuleSettings(t *testing.T) {
 				if err != nil {
 					t.Fatalf("load remote hardware settings: %v", err)
 				}
-				if !settings.Enabled || len(settings.AvailablePins) != 2 || settings.AvailablePins[1] != 4 {
+				want := NodeRemoteHardwarePin{GPIOPin: 4, Name: "Gate", Type: int32(generated.RemoteHardwarePinType_DIGITAL_WRITE)}
+				if !settings.Enabled || len(settings.AvailablePins) != 2 || settings.AvailablePins[1] != want {
 					t.Fatalf("unexpected remote hardware settings: %+v", settings)
 				}
 			},
@@ -616,11 +620,13 @@ func TestNodeSettingsServiceSaveNewModuleSettings_ImmediateStatusEvents(t *testi
 			run: func(t *testing.T) {
 				runSimpleModuleSaveTest(t, func(service *NodeSettingsService, ctx context.Context) error {
 					return service.SaveRemoteHardwareSettings(ctx, mustLocalNodeTarget(), NodeRemoteHardwareSettings{
-						NodeID: "!00000001", Enabled: true, AvailablePins: []uint32{3, 4},
+						NodeID: "!00000001", Enabled: true, AvailablePins: []NodeRemoteHardwarePin{{GPIOPin: 3}, {GPIOPin: 4, Name: "Gate", Type: 1}},
 					})
 				}, func(payload *generated.AdminMessage) {
 					hardware := payload.GetSetModuleConfig().GetRemoteHardware()
-					if hardware == nil || len(hardware.GetAvailablePins()) != 2 || hardware.GetAvailablePins()[1].GetGpioPin() != 4 {
+					if hardware == nil || len(hardware.GetAvailablePins()) != 2 || hardware.GetAvailablePins()[1].GetGpioPin() != 4 ||
+						hardware.GetAvailablePins()[1].GetName() != "Gate" ||
+						hardware.GetAvailablePins()[1].GetType() != generated.RemoteHardwarePinType_DIGITAL_READ {
 						t.Fatalf("unexpected remote hardware payload: %+v", hardware)
 					}
 				})
@@ -22,14 +22,18 @@ func (s *NodeSettingsService) LoadRemoteHardwareSettings(ctx context.Context, ta
 		NodeID:                  strings.TrimSpace(target.NodeID),
 		Enabled:                 hardware.GetEnabled(),
 		AllowUndefinedPinAccess: hardware.GetAllowUndefinedPinAccess(),
-		AvailablePins: func() []uint32 {
+		AvailablePins: func() []NodeRemoteHardwarePin {
 			pins := hardware.GetAvailablePins()
-			out := make([]uint32, 0, len(pins))
+			out := make([]NodeRemoteHardwarePin, 0, len(pins))
 			for _, pin := range pins {
 				if pin == nil {
 					continue
 				}
-				out = append(out, pin.GetGpioPin())
+				out = append(out, NodeRemoteHardwarePin{
+					GPIOPin: pin.GetGpioPin(),
+					Name:    pin.GetName(),
+					Type:    int32(pin.GetType()),
+				})
 			}
 
 			return out
@@ -40,7 +44,11 @@ func (s *NodeSettingsService) LoadRemoteHardwareSettings(ctx context.Context, ta
 func (s *NodeSettingsService) SaveRemoteHardwareSettings(ctx context.Context, target NodeSettingsTarget, settings NodeRemoteHardwareSettings) error {
 	availablePins := make([]*generated.RemoteHardwarePin, 0, len(settings.AvailablePins))
 	for _, pin := range settings.AvailablePins {
-		availablePins = append(availablePins, &generated.RemoteHardwarePin{GpioPin: pin})
+		availablePins = append(availablePins, &generated.RemoteHardwarePin{
+			GpioPin: pin.GPIOPin,
+			Name:    strings.TrimSpace(pin.Name),
+			Type:    generated.RemoteHardwarePinType(pin.Type),
+		})
 	}
 
 	return s.saveModuleConfig(ctx, target, "set_module_config.remote_hardware", &generated.ModuleConfig{
@@ -276,7 +276,14 @@ type NodeRemoteHardwareSettings struct {
 	NodeID                  string
 	Enabled                 bool
 	AllowUndefinedPinAccess bool
-	AvailablePins           []uint32
+	AvailablePins           []NodeRemoteHardwarePin
+}
+
+// NodeRemoteHardwarePin describes a GPIO pin exposed to the mesh by the Remote Hardware module.
+type NodeRemoteHardwarePin struct {
+	GPIOPin uint32
+	Name    string
+	Type    int32
 }
 
 // NodeNeighborInfoSettings contains editable Neighbor Info module settings.
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+	"github.com/skobkin/meshgo/internal/notifications"
+)
+
+const (
+	notificationTitleLowBatteryFormat = "Low battery: %s"
+	// lowBatteryAlertHysteresis is how far above the alert threshold the level must rise
+	// before the same node alerts again, so a level jittering around the threshold does
+	// not repeat the alert.
+	lowBatteryAlertHysteresis = 5
+	// batteryEstimateHistoryLimit bounds the telemetry rows read for one estimate.
+	batteryEstimateHistoryLimit = 1000
+	batteryEstimateTimeout      = 5 * time.Second
+)
+
+// BatteryRuntimeEstimator estimates time to empty or full for a node from stored telemetry.
+type BatteryRuntimeEstimator func(ctx context.Context, nodeID string) (domain.BatteryEstimate, bool, error)
+
+// SetBatteryAlertSources enables low battery alerts for the local node and favorites.
+// It must be called before Start; estimate may be nil to alert without a runtime estimate.
+func (s *NotificationService) SetBatteryAlertSources(localNodeID func() string, estimate BatteryRuntimeEstimator) {
+	s.localNodeID = localNodeID
+	s.estimateBattery = estimate
+}
+
+func (s *NotificationService) handleNodeTelemetry(update domain.NodeTelemetryUpdate) {
+	level := update.Telemetry.BatteryLevel
+	nodeID := strings.TrimSpace(update.Telemetry.NodeID)
+	if level == nil || nodeID == "" || !s.watchesBattery(nodeID) {
+		return
+	}
+
+	prefs := s.notificationPrefs()
+	threshold := int64(prefs.LowBatteryThreshold())
+
+	s.batteryMu.Lock()
+	if int64(*level) > threshold+lowBatteryAlertHysteresis {
+		// Also covers 101, which firmware reports while on external power.
+		delete(s.lowBatteryNotified, nodeID)
+		s.batteryMu.Unlock()
+
+		return
+	}
+	if int64(*level) > threshold || s.lowBatteryNotified[nodeID] || !prefs.Events.LowBattery {
+		s.batteryMu.Unlock()
+
+		return
+	}
+	if s.lowBatteryNotified == nil {
+		s.lowBatteryNotified = make(map[string]bool)
+	}
+	s.lowBatteryNotified[nodeID] = true
+	s.batteryMu.Unlock()
+
+	s.notify(prefs, notifications.Payload{
+		Title:   i18n.Tf(notificationTitleLowBatteryFormat, domain.NodeDisplayNameByID(s.nodeStore, nodeID)),
+		Content: s.lowBatteryContent(nodeID, *level),
+		NodeID:  nodeID,
+	})
+}
+
+// watchesBattery limits alerts to nodes the user cares about, not every node on the mesh.
+func (s *NotificationService) watchesBattery(nodeID string) bool {
+	if s.localNodeID != nil && strings.TrimSpace(s.localNodeID()) == nodeID {
+		return true
+	}
+	if s.nodeStore == nil {
+		return false
+	}
+	node, ok := s.nodeStore.Get(nodeID)
+
+	return ok && node.IsFavorite != nil && *node.IsFavorite
+}
+
+func (s *NotificationService) lowBatteryContent(nodeID string, level uint32) string {
+	content := i18n.Tf("Battery at %d%%", level)
+	if s.estimateBattery == nil {
+		return content
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), batteryEstimateTimeout)
+	defer cancel()
+	estimate, ok, err := s.estimateBattery(ctx, nodeID)
+	if err != nil {
+		s.logger.Debug("battery runtime estimate failed", "node_id", nodeID, "error", err)
+
+		return content
+	}
+	if !ok || estimate.Charging() {
+		return content
+	}
+
+	return content + ", " + estimate.Summary()
+}
+
+func estimateNodeBatteryRuntime(
+	ctx context.Context,
+	repo domain.NodeTelemetryRepository,
+	nodeID string,
+	now time.Time,
+) (domain.BatteryEstimate, bool, error) {
+	nodeID = strings.TrimSpace(nodeID)
+	if nodeID == "" {
+		return domain.BatteryEstimate{}, false, fmt.Errorf("node id is required")
+	}
+	history, err := repo.ListHistoryByNodeID(ctx, domain.NodeHistoryQuery{
+		NodeID: nodeID,
+		Limit:  batteryEstimateHistoryLimit,
+		Order:  domain.SortDescending,
+	})
+	if err != nil {
+		return domain.BatteryEstimate{}, false, err
+	}
+	estimate, ok := domain.EstimateBatteryRuntime(history, now)
+
+	return estimate, ok, nil
+}
@@ -0,0 +1,183 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestNotificationServiceLowBatteryAlerts(t *testing.T) {
+	messageBus := newTestMessageBus(t)
+	nodeStore := domain.NewNodeStore()
+	favorite := true
+	nodeStore.Upsert(domain.Node{NodeID: "!00000001", LongName: "Base"})
+	nodeStore.Upsert(domain.Node{NodeID: "!00000002", LongName: "Hiker", IsFavorite: &favorite})
+	nodeStore.Upsert(domain.Node{NodeID: "!00000003", LongName: "Stranger"})
+	cfg := config.Default()
+	sender := newCollectingNotificationSender()
+	service := NewNotificationService(
+		messageBus,
+		domain.NewChatStore(),
+		nodeStore,
+		func() config.AppConfig { return cfg },
+		func() bool { return false },
+		sender,
+		nil,
+	)
+	service.SetBatteryAlertSources(
+		func() string { return "!00000001" },
+		func(_ context.Context, nodeID string) (domain.BatteryEstimate, bool, error) {
+			if nodeID != "!00000001" {
+				return domain.BatteryEstimate{}, false, nil
+			}
+
+			return domain.BatteryEstimate{RatePerHour: -6, TimeToEmpty: 3*time.Hour + 10*time.Minute}, true, nil
+		},
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service.Start(ctx)
+
+	publish := func(nodeID string, level uint32) {
+		messageBus.Publish(bus.TopicNodeTelemetry, domain.NodeTelemetryUpdate{
+			Telemetry: domain.NodeTelemetry{NodeID: nodeID, BatteryLevel: &level},
+		})
+	}
+
+	publish("!00000001", 30)
+	publish("!00000003", 10)
+	publish("!00000001", 19)
+	got := sender.waitForCount(t, 1)
+	if got[0].Title != "Low battery: Base" || got[0].Content != "Battery at 19%, about 3h 10m to empty" {
+		t.Fatalf("unexpected local node alert: %+v", got[0])
+	}
+
+	// Further drops do not repeat the alert until the level recovers past the rearm level.
+	publish("!00000001", 18)
+	publish("!00000001", 24)
+	publish("!00000002", 20)
+	got = sender.waitForCount(t, 2)
+	if got[1].Title != "Low battery: Hiker" || got[1].Content != "Battery at 20%" {
+		t.Fatalf("unexpected favorite alert: %+v", got[1])
+	}
+
+	publish("!00000001", 101)
+	publish("!00000001", 15)
+	got = sender.waitForCount(t, 3)
+	if got[2].Content != "Battery at 15%, about 3h 10m to empty" {
+		t.Fatalf("unexpected repeated alert: %+v", got[2])
+	}
+	sender.assertCount(t, 3)
+}
+
+func TestNotificationServiceLowBatteryRespectsSettings(t *testing.T) {
+	messageBus := newTestMessageBus(t)
+	cfg := config.Default()
+	cfg.UI.Notifications.Events.LowBattery = false
+	sender := newCollectingNotificationSender()
+	service := NewNotificationService(
+		messageBus,
+		domain.NewChatStore(),
+		domain.NewNodeStore(),
+		func() config.AppConfig { return cfg },
+		func() bool { return false },
+		sender,
+		nil,
+	)
+	service.SetBatteryAlertSources(func() string { return "!00000001" }, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service.Start(ctx)
+
+	level := uint32(5)
+	messageBus.Publish(bus.TopicNodeTelemetry, domain.NodeTelemetryUpdate{
+		Telemetry: domain.NodeTelemetry{NodeID: "!00000001", BatteryLevel: &level},
+	})
+	messageBus.Publish(bus.TopicNodeDiscovered, domain.NodeDiscovered{NodeID: "!00000009"})
+	sender.waitForCount(t, 1)
+	sender.assertCount(t, 1)
+}
+
+func TestNotificationServiceLowBatteryUsesThreshold(t *testing.T) {
+	messageBus := newTestMessageBus(t)
+	cfg := config.Default()
+	cfg.UI.Notifications.LowBatteryPercent = 40
+	sender := newCollectingNotificationSender()
+	service := NewNotificationService(
+		messageBus,
+		domain.NewChatStore(),
+		domain.NewNodeStore(),
+		func() config.AppConfig { return cfg },
+		func() bool { return false },
+		sender,
+		nil,
+	)
+	service.SetBatteryAlertSources(func() string { return "!00000001" }, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service.Start(ctx)
+
+	publish := func(level uint32) {
+		messageBus.Publish(bus.TopicNodeTelemetry, domain.NodeTelemetryUpdate{
+			Telemetry: domain.NodeTelemetry{NodeID: "!00000001", BatteryLevel: &level},
+		})
+	}
+
+	publish(41)
+	publish(40)
+	got := sender.waitForCount(t, 1)
+	if got[0].Content != "Battery at 40%" {
+		t.Fatalf("unexpected alert: %+v", got[0])
+	}
+
+	// Recovering within the hysteresis does not rearm the alert, passing it does.
+	publish(45)
+	publish(38)
+	publish(46)
+	publish(39)
+	got = sender.waitForCount(t, 2)
+	if got[1].Content != "Battery at 39%" {
+		t.Fatalf("unexpected repeated alert: %+v", got[1])
+	}
+	sender.assertCount(t, 2)
+}
+
+func TestEstimateNodeBatteryRuntime(t *testing.T) {
+	now := time.Now()
+	history := make([]domain.NodeTelemetryHistoryEntry, 0, 4)
+	for i, level := range []uint32{50, 52, 54, 56} {
+		history = append(history, domain.NodeTelemetryHistoryEntry{
+			NodeID:       "!00000001",
+			BatteryLevel: &level,
+			ObservedAt:   now.Add(-time.Duration(i) * time.Hour),
+		})
+	}
+	repo := &batteryHistoryRepo{history: history}
+
+	estimate, ok, err := estimateNodeBatteryRuntime(context.Background(), repo, " !00000001 ", now)
+	if err != nil || !ok {
+		t.Fatalf("expected estimate, got ok=%v err=%v", ok, err)
+	}
+	if estimate.TimeToEmpty != 25*time.Hour || repo.query.NodeID != "!00000001" || repo.query.Order != domain.SortDescending {
+		t.Fatalf("unexpected estimate %+v for query %+v", estimate, repo.query)
+	}
+	if _, _, err := estimateNodeBatteryRuntime(context.Background(), repo, " ", now); err == nil {
+		t.Fatalf("expected empty node id to be rejected")
+	}
+}
+
+type batteryHistoryRepo struct {
+	domain.NodeTelemetryRepository
+	history []domain.NodeTelemetryHistoryEntry
+	query   domain.NodeHistoryQuery
+}
+
+func (r *batteryHistoryRepo) ListHistoryByNodeID(_ context.Context, query domain.NodeHistoryQuery) ([]domain.NodeTelemetryHistoryEntry, error) {
+	r.query = query
+
+	return r.history, nil
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+	"github.com/skobkin/meshgo/internal/notifications"
+)
+
+const (
+	notificationTitleNodeSilentFormat     = "Silent: %s"
+	notificationTitleNodeHeardAgainFormat = "Heard again: %s"
+	// nodePresenceCheckInterval is how often favorites are checked for going silent or
+	// being heard again.
+	nodePresenceCheckInterval = 30 * time.Second
+)
+
+// nodePresenceState is what the presence alerts know about a node: when it was last heard
+// and whether it was already reported silent since.
+type nodePresenceState struct {
+	lastHeard      time.Time
+	silentNotified bool
+}
+
+// checkNodePresence alerts about favorites with presence alerts that went silent or were
+// heard again since the last check. Nodes are only compared with earlier checks, so the
+// first check after the start alerts about nothing.
+func (s *NotificationService) checkNodePresence(now time.Time) {
+	if s.nodeStore == nil {
+		return
+	}
+	prefs := s.notificationPrefs()
+
+	s.presenceMu.Lock()
+	if s.nodePresence == nil {
+		s.nodePresence = make(map[string]nodePresenceState)
+	}
+	var alerts []notifications.Payload
+	for nodeID := range s.nodePresence {
+		if !prefs.PresenceAlert(nodeID).Enabled() {
+			delete(s.nodePresence, nodeID)
+		}
+	}
+	for nodeID, alert := range prefs.NodePresence {
+		node, ok := s.nodeStore.Get(nodeID)
+		if !ok || node.IsFavorite == nil || !*node.IsFavorite || node.LastHeardAt.IsZero() {
+			delete(s.nodePresence, nodeID)
+
+			continue
+		}
+		silentAfter := time.Duration(alert.SilentMinutes) * time.Minute
+		heardAgainAfter := time.Duration(alert.HeardAgainMinutes) * time.Minute
+		state, known := s.nodePresence[nodeID]
+		switch {
+		case !known:
+			state = nodePresenceState{
+				lastHeard:      node.LastHeardAt,
+				silentNotified: silentAfter > 0 && now.Sub(node.LastHeardAt) >= silentAfter,
+			}
+		case node.LastHeardAt.After(state.lastHeard):
+			if silence := node.LastHeardAt.Sub(state.lastHeard); heardAgainAfter > 0 && silence >= heardAgainAfter {
+				alerts = append(alerts, notifications.Payload{
+					Title:   i18n.Tf(notificationTitleNodeHeardAgainFormat, domain.NodeDisplayNameByID(s.nodeStore, nodeID)),
+					Content: i18n.Tf("Silent for %s", domain.FormatRoughDuration(silence)),
+					NodeID:  nodeID,
+				})
+			}
+			state = nodePresenceState{lastHeard: node.LastHeardAt}
+		case silentAfter > 0 && !state.silentNotified && now.Sub(state.lastHeard) >= silentAfter:
+			alerts = append(alerts, notifications.Payload{
+				Title:   i18n.Tf(notificationTitleNodeSilentFormat, domain.NodeDisplayNameByID(s.nodeStore, nodeID)),
+				Content: i18n.Tf("Not heard for %s", domain.FormatRoughDuration(now.Sub(state.lastHeard))),
+				NodeID:  nodeID,
+			})
+			state.silentNotified = true
+		}
+		s.nodePresence[nodeID] = state
+	}
+	s.presenceMu.Unlock()
+
+	if !prefs.Events.NodePresence {
+		return
+	}
+	for _, alert := range alerts {
+		s.notify(prefs, alert)
+	}
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestNotificationServiceNodePresenceAlerts(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	favorite := true
+	nodeStore := domain.NewNodeStore()
+	nodeStore.Upsert(domain.Node{NodeID: "!00000001", LongName: "Hiker", IsFavorite: &favorite, LastHeardAt: start})
+	nodeStore.Upsert(domain.Node{NodeID: "!00000002", LongName: "Stranger", LastHeardAt: start})
+	cfg := config.Default()
+	cfg.UI.Notifications.SetPresenceAlert("!00000001", config.NodePresenceAlert{SilentMinutes: 60, HeardAgainMinutes: 120})
+	cfg.UI.Notifications.SetPresenceAlert("!00000002", config.NodePresenceAlert{SilentMinutes: 60})
+	sender := newCollectingNotificationSender()
+	service := NewNotificationService(
+		nil,
+		domain.NewChatStore(),
+		nodeStore,
+		func() config.AppConfig { return cfg },
+		func() bool { return false },
+		sender,
+		nil,
+	)
+
+	service.checkNodePresence(start.Add(time.Minute))
+	service.checkNodePresence(start.Add(59 * time.Minute))
+	sender.assertCount(t, 0)
+
+	service.checkNodePresence(start.Add(90 * time.Minute))
+	service.checkNodePresence(start.Add(100 * time.Minute))
+	got := sender.snapshot()
+	if len(got) != 1 || got[0].Title != "Silent: Hiker" || got[0].Content != "Not heard for 1h 30m" {
+		t.Fatalf("expected one silent alert for the favorite, got %+v", got)
+	}
+
+	// Heard again after less silence than the heard again alert needs.
+	nodeStore.Upsert(domain.Node{NodeID: "!00000001", LastHeardAt: start.Add(110 * time.Minute)})
+	service.checkNodePresence(start.Add(111 * time.Minute))
+	sender.assertCount(t, 1)
+	service.checkNodePresence(start.Add(3 * time.Hour))
+
+	nodeStore.Upsert(domain.Node{NodeID: "!00000001", LastHeardAt: start.Add(5 * time.Hour)})
+	service.checkNodePresence(start.Add(5*time.Hour + time.Minute))
+	got = sender.snapshot()
+	if len(got) != 3 {
+		t.Fatalf("expected silent and heard again alerts, got %+v", got)
+	}
+	if got[1].Content != "Not heard for 1h 10m" || got[2].Title != "Heard again: Hiker" || got[2].Content != "Silent for 3h 10m" {
+		t.Fatalf("unexpected alerts: %+v", got[1:])
+	}
+}
+
+func TestNotificationServiceNodePresenceRespectsSettings(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	favorite := true
+	nodeStore := domain.NewNodeStore()
+	nodeStore.Upsert(domain.Node{NodeID: "!00000001", LongName: "Hiker", IsFavorite: &favorite, LastHeardAt: start})
+	cfg := config.Default()
+	cfg.UI.Notifications.Events.NodePresence = false
+	cfg.UI.Notifications.SetPresenceAlert("!00000001", config.NodePresenceAlert{SilentMinutes: 60})
+	sender := newCollectingNotificationSender()
+	service := NewNotificationService(
+		nil,
+		domain.NewChatStore(),
+		nodeStore,
+		func() config.AppConfig { return cfg },
+		func() bool { return false },
+		sender,
+		nil,
+	)
+
+	service.checkNodePresence(start)
+	service.checkNodePresence(start.Add(2 * time.Hour))
+	sender.assertCount(t, 0)
+}
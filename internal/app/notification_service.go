@@ -7,18 +7,21 @@ import (
 	"log/slog"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/skobkin/meshgo/internal/bus"
 	"github.com/skobkin/meshgo/internal/config"
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 	"github.com/skobkin/meshgo/internal/notifications"
 	"github.com/skobkin/meshgo/internal/radio/busmsg"
 )
 
 const (
-	notificationTitleNodeDiscovered = "New node discovered"
-	notificationTitleUpdatePrefix   = "Update available: "
-	notificationCurrentVersionLabel = "Current version: "
+	notificationTitleNodeDiscovered  = "New node discovered"
+	notificationTitleUpdateFormat    = "Update available: %s"
+	notificationCurrentVersionFormat = "Current version: %s"
+	notificationTitleMessagesGroup   = "New messages"
 )
 
 // NotificationService listens to bus events and emits user-facing notifications.
@@ -29,7 +32,9 @@ type NotificationService struct {
 	currentConfig func() config.AppConfig
 	isForeground  func() bool
 	sender        notifications.Sender
+	history       *notifications.History
 	logger        *slog.Logger
+	now           func() time.Time
 
 	connStatusMu     sync.Mutex
 	lastConnState    busmsg.ConnectionState
@@ -37,6 +42,14 @@ type NotificationService struct {
 
 	updateMu            sync.Mutex
 	lastNotifiedVersion string
+
+	localNodeID        func() string
+	estimateBattery    BatteryRuntimeEstimator
+	batteryMu          sync.Mutex
+	lowBatteryNotified map[string]bool
+
+	presenceMu   sync.Mutex
+	nodePresence map[string]nodePresenceState
 }
 
 type messageMeta struct {
@@ -64,9 +77,22 @@ func NewNotificationService(
 		isForeground:  isForeground,
 		sender:        sender,
 		logger:        logger,
+		now:           time.Now,
 	}
 }
 
+// SetLocalNodeID sets the source of the local node ID used to find mentions in chats
+// that notify only on mentions. It must be called before Start.
+func (s *NotificationService) SetLocalNodeID(localNodeID func() string) {
+	s.localNodeID = localNodeID
+}
+
+// SetHistory records notifications in history, including the ones not shown because
+// the app was focused. It must be called before Start.
+func (s *NotificationService) SetHistory(history *notifications.History) {
+	s.history = history
+}
+
 func (s *NotificationService) Start(ctx context.Context) {
 	if s == nil || s.bus == nil || s.sender == nil {
 		return
@@ -76,12 +102,16 @@ func (s *NotificationService) Start(ctx context.Context) {
 	nodeSub := s.bus.Subscribe(bus.TopicNodeDiscovered)
 	connSub := s.bus.Subscribe(bus.TopicConnStatus)
 	updateSub := s.bus.Subscribe(bus.TopicUpdateSnapshot)
+	telemetrySub := s.bus.Subscribe(bus.TopicNodeTelemetry)
+	presenceTicker := time.NewTicker(nodePresenceCheckInterval)
 
 	go func() {
+		defer presenceTicker.Stop()
 		defer s.bus.Unsubscribe(textSub, bus.TopicTextMessage)
 		defer s.bus.Unsubscribe(nodeSub, bus.TopicNodeDiscovered)
 		defer s.bus.Unsubscribe(connSub, bus.TopicConnStatus)
 		defer s.bus.Unsubscribe(updateSub, bus.TopicUpdateSnapshot)
+		defer s.bus.Unsubscribe(telemetrySub, bus.TopicNodeTelemetry)
 
 		for {
 			select {
@@ -123,6 +153,17 @@ func (s *NotificationService) Start(ctx context.Context) {
 					continue
 				}
 				s.handleUpdateSnapshot(snapshot)
+			case raw, ok := <-telemetrySub:
+				if !ok {
+					return
+				}
+				update, ok := raw.(domain.NodeTelemetryUpdate)
+				if !ok {
+					continue
+				}
+				s.handleNodeTelemetry(update)
+			case <-presenceTicker.C:
+				s.checkNodePresence(s.now())
 			}
 		}
 	}()
@@ -133,7 +174,13 @@ func (s *NotificationService) handleIncomingMessage(msg domain.ChatMessage) {
 	if msg.Direction != domain.MessageDirectionIn {
 		return
 	}
-	if !s.shouldNotify(prefs, prefs.Events.IncomingMessage) {
+	if !prefs.Events.IncomingMessage {
+		return
+	}
+	if prefs.MutesNode(senderNodeIDForMessage(msg)) {
+		return
+	}
+	if !s.chatAllowsNotification(msg) {
 		return
 	}
 
@@ -141,10 +188,14 @@ func (s *NotificationService) handleIncomingMessage(msg domain.ChatMessage) {
 	if senderName == "" {
 		senderName = "unknown"
 	}
-	body := strings.TrimSpace(msg.Body)
+	bell := domain.MessageRingsBell(msg.Body)
+	body := strings.TrimSpace(strings.ReplaceAll(msg.Body, string(domain.MessageBell), ""))
 	if body == "" {
 		body = "(empty)"
 	}
+	if bell {
+		body = "🔔 " + body
+	}
 
 	titlePrefix := "#"
 	titleSubject := s.chatTitle(msg.ChatKey)
@@ -159,15 +210,75 @@ func (s *NotificationService) handleIncomingMessage(msg domain.ChatMessage) {
 		titleSubject = "unknown"
 	}
 
-	s.send(notifications.Payload{
-		Title:   titlePrefix + titleSubject,
-		Content: fmt.Sprintf("%s: %s", senderName, body),
+	title := titlePrefix + titleSubject
+	groupKey, groupTitle := s.messageGroup(prefs.MessageGrouping, msg, title, senderName)
+	s.notify(prefs, notifications.Payload{
+		Title:      title,
+		Content:    fmt.Sprintf("%s: %s", senderName, body),
+		GroupKey:   groupKey,
+		GroupTitle: groupTitle,
+		ChatKey:    strings.TrimSpace(msg.ChatKey),
+		Bell:       bell,
 	})
 }
 
+// chatAllowsNotification applies the chat's mute and mention preferences. Direct
+// messages always count as mentions.
+func (s *NotificationService) chatAllowsNotification(msg domain.ChatMessage) bool {
+	chat, ok := s.chatStore.ChatByKey(msg.ChatKey)
+	if !ok {
+		return true
+	}
+	if chat.Notifications.MutedAt(s.now()) {
+		return false
+	}
+	if !chat.Notifications.MentionsOnly || chatTypeForNotification(msg.ChatKey) == domain.ChatTypeDM {
+		return true
+	}
+
+	return s.mentionsLocalNode(msg.Body)
+}
+
+func (s *NotificationService) mentionsLocalNode(text string) bool {
+	if s.localNodeID == nil {
+		return false
+	}
+	nodeID := strings.TrimSpace(s.localNodeID())
+	if nodeID == "" {
+		return false
+	}
+	node := domain.Node{NodeID: nodeID}
+	if s.nodeStore != nil {
+		if known, ok := s.nodeStore.Get(nodeID); ok {
+			node = known
+		}
+	}
+
+	return domain.MessageMentionsNode(text, node)
+}
+
+// messageGroup returns the notification group key and summary title for an incoming message.
+func (s *NotificationService) messageGroup(
+	grouping config.NotificationGrouping,
+	msg domain.ChatMessage,
+	title string,
+	senderName string,
+) (string, string) {
+	switch grouping {
+	case config.NotificationGroupingGlobal:
+		return "messages", i18n.T(notificationTitleMessagesGroup)
+	case config.NotificationGroupingSender:
+		if nodeID := senderNodeIDForMessage(msg); nodeID != "" {
+			return "sender:" + nodeID, "@" + senderName
+		}
+	}
+
+	return "chat:" + strings.TrimSpace(msg.ChatKey), title
+}
+
 func (s *NotificationService) handleNodeDiscovered(event domain.NodeDiscovered) {
 	prefs := s.notificationPrefs()
-	if !s.shouldNotify(prefs, prefs.Events.NodeDiscovered) {
+	if !prefs.Events.NodeDiscovered {
 		return
 	}
 
@@ -175,9 +286,10 @@ func (s *NotificationService) handleNodeDiscovered(event domain.NodeDiscovered)
 	if content == "" {
 		return
 	}
-	s.send(notifications.Payload{
-		Title:   notificationTitleNodeDiscovered,
+	s.notify(prefs, notifications.Payload{
+		Title:   i18n.T(notificationTitleNodeDiscovered),
 		Content: content,
+		NodeID:  normalizeNotificationNodeID(firstNonEmpty(event.NodeID, event.Node.NodeID)),
 	})
 }
 
@@ -201,25 +313,25 @@ func (s *NotificationService) handleConnectionStatus(status busmsg.ConnectionSta
 		status.State != busmsg.ConnectionStateDisconnected {
 		return
 	}
-	if !s.shouldNotify(prefs, prefs.Events.ConnectionStatus) {
+	if !prefs.Events.ConnectionStatus {
 		return
 	}
 
 	transport := notificationTransportName(status.TransportName)
 	if transport == "" {
-		transport = "Unknown"
+		transport = i18n.T("Unknown")
 	}
 	details := strings.TrimSpace(status.Target)
 	if details == "" {
-		details = "No connection details"
+		details = i18n.T("No connection details")
 	}
 	if status.State == busmsg.ConnectionStateDisconnected {
 		if errText := strings.TrimSpace(status.Err); errText != "" {
-			details = fmt.Sprintf("%s (error: %s)", details, errText)
+			details = i18n.Tf("%s (error: %s)", details, errText)
 		}
 	}
 
-	s.send(notifications.Payload{
+	s.notify(prefs, notifications.Payload{
 		Title:   fmt.Sprintf("%s - %s", transport, status.State),
 		Content: details,
 	})
@@ -230,7 +342,7 @@ func (s *NotificationService) handleUpdateSnapshot(snapshot UpdateSnapshot) {
 	if !snapshot.UpdateAvailable {
 		return
 	}
-	if !s.shouldNotify(prefs, prefs.Events.UpdateAvailable) {
+	if !prefs.Events.UpdateAvailable {
 		return
 	}
 
@@ -252,14 +364,14 @@ func (s *NotificationService) handleUpdateSnapshot(snapshot UpdateSnapshot) {
 	if currentVersion == "" {
 		currentVersion = "unknown"
 	}
-	s.send(notifications.Payload{
-		Title:   notificationTitleUpdatePrefix + latestVersion,
-		Content: notificationCurrentVersionLabel + currentVersion,
+	s.notify(prefs, notifications.Payload{
+		Title:   i18n.Tf(notificationTitleUpdateFormat, latestVersion),
+		Content: i18n.Tf(notificationCurrentVersionFormat, currentVersion),
 	})
 }
 
-func (s *NotificationService) shouldNotify(prefs config.NotificationConfig, kindEnabled bool) bool {
-	if !kindEnabled {
+func (s *NotificationService) shouldNotify(prefs config.NotificationConfig, notification notifications.Payload) bool {
+	if prefs.MutedAt(s.now()) || prefs.DoNotDisturb.SilencesAt(s.now(), notification.Bell) {
 		return false
 	}
 	if prefs.NotifyWhenFocused {
@@ -283,33 +395,55 @@ func (s *NotificationService) notificationPrefs() config.NotificationConfig {
 }
 
 func (s *NotificationService) senderNameForMessage(msg domain.ChatMessage) string {
-	meta, ok := parseMessageMeta(msg.MetaJSON)
-	if ok {
-		if nodeID := normalizeNotificationNodeID(meta.From); nodeID != "" {
-			return domain.NodeDisplayNameByID(s.nodeStore, nodeID)
-		}
-	}
-	if nodeID := normalizeNotificationNodeID(domain.NodeIDFromDMChatKey(msg.ChatKey)); nodeID != "" {
+	if nodeID := senderNodeIDForMessage(msg); nodeID != "" {
 		return domain.NodeDisplayNameByID(s.nodeStore, nodeID)
 	}
 
 	return ""
 }
 
+func senderNodeIDForMessage(msg domain.ChatMessage) string {
+	if meta, ok := parseMessageMeta(msg.MetaJSON); ok {
+		if nodeID := normalizeNotificationNodeID(meta.From); nodeID != "" {
+			return nodeID
+		}
+	}
+
+	return normalizeNotificationNodeID(domain.NodeIDFromDMChatKey(msg.ChatKey))
+}
+
 func (s *NotificationService) chatTitle(chatKey string) string {
 	return domain.ChatTitleByKey(s.chatStore, chatKey)
 }
 
+// notify records notification in the history and sends it unless notifications are
+// muted or held back by do-not-disturb, or the app is focused and the user does not
+// want notifications then.
+func (s *NotificationService) notify(prefs config.NotificationConfig, notification notifications.Payload) {
+	shown := s.shouldNotify(prefs, notification)
+	if s.history != nil {
+		s.history.Add(notifications.HistoryEntry{At: s.now(), Payload: notification, Shown: shown})
+	}
+	if shown {
+		s.send(notification)
+	}
+}
+
 func (s *NotificationService) send(notification notifications.Payload) {
 	title := strings.TrimSpace(notification.Title)
 	content := strings.TrimSpace(notification.Content)
 	if title == "" && content == "" {
 		return
 	}
-	s.logger.Debug("sending notification", "title", title)
+	s.logger.Debug("sending notification", "title", title, "group_key", notification.GroupKey)
 	s.sender.Send(notifications.Payload{
-		Title:   title,
-		Content: content,
+		Title:      title,
+		Content:    content,
+		GroupKey:   notification.GroupKey,
+		GroupTitle: notification.GroupTitle,
+		ChatKey:    notification.ChatKey,
+		NodeID:     notification.NodeID,
+		Bell:       notification.Bell,
 	})
 }
 
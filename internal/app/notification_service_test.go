@@ -103,6 +103,155 @@ func TestNotificationServiceIncomingChannelMessage(t *testing.T) {
 	}
 }
 
+func TestNotificationServiceIncomingMessageRespectsChatPreferences(t *testing.T) {
+	chatKey := domain.ChatKeyForChannel(0)
+	chatStore := domain.NewChatStore()
+	chatStore.UpsertChat(domain.Chat{Key: chatKey, Title: "General", Type: domain.ChatTypeChannel, UpdatedAt: time.Now()})
+	nodeStore := domain.NewNodeStore()
+	nodeStore.Upsert(domain.Node{NodeID: "!11111111", LongName: "Base Camp", ShortName: "BC"})
+	cfg := config.Default()
+	sender := newCollectingNotificationSender()
+	service := NewNotificationService(
+		newTestMessageBus(t),
+		chatStore,
+		nodeStore,
+		func() config.AppConfig { return cfg },
+		func() bool { return false },
+		sender,
+		nil,
+	)
+	service.SetLocalNodeID(func() string { return "!11111111" })
+	incoming := func(body string) domain.ChatMessage {
+		return domain.ChatMessage{ChatKey: chatKey, Direction: domain.MessageDirectionIn, Body: body, MetaJSON: `{"from":"!87654321"}`}
+	}
+
+	chatStore.SetChatNotifications(chatKey, domain.ChatNotificationPrefs{Muted: true})
+	service.handleIncomingMessage(incoming("@BC while muted"))
+	chatStore.SetChatNotifications(chatKey, domain.ChatNotificationPrefs{Muted: true, MutedUntil: time.Now().Add(-time.Minute), MentionsOnly: true})
+	service.handleIncomingMessage(incoming("no mention here"))
+	service.handleIncomingMessage(incoming("@BC are you there?"))
+
+	got := sender.snapshot()
+	if len(got) != 1 || got[0].Content != "!87654321: @BC are you there?" {
+		t.Fatalf("expected only the mention after the mute expired, got %+v", got)
+	}
+}
+
+func TestNotificationServiceIncomingMessageSkipsMutedNodes(t *testing.T) {
+	chatStore := domain.NewChatStore()
+	cfg := config.Default()
+	cfg.UI.Notifications.SetNodeMuted("!87654321", true)
+	sender := newCollectingNotificationSender()
+	service := NewNotificationService(
+		newTestMessageBus(t),
+		chatStore,
+		domain.NewNodeStore(),
+		func() config.AppConfig { return cfg },
+		func() bool { return false },
+		sender,
+		nil,
+	)
+
+	service.handleIncomingMessage(domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionIn, Body: "channel", MetaJSON: `{"from":"!87654321"}`})
+	service.handleIncomingMessage(domain.ChatMessage{ChatKey: domain.ChatKeyForDM("!87654321"), Direction: domain.MessageDirectionIn, Body: "direct"})
+	service.handleIncomingMessage(domain.ChatMessage{ChatKey: domain.ChatKeyForChannel(0), Direction: domain.MessageDirectionIn, Body: "other", MetaJSON: `{"from":"!11111111"}`})
+
+	got := sender.snapshot()
+	if len(got) != 1 || got[0].Content != "!11111111: other" {
+		t.Fatalf("expected only the message from the unmuted node, got %+v", got)
+	}
+}
+
+func TestNotificationServiceIncomingMessageGrouping(t *testing.T) {
+	tests := []struct {
+		name           string
+		grouping       config.NotificationGrouping
+		chatKey        string
+		wantGroupKey   string
+		wantGroupTitle string
+	}{
+		{
+			name:           "per chat",
+			grouping:       config.NotificationGroupingChat,
+			chatKey:        domain.ChatKeyForChannel(0),
+			wantGroupKey:   "chat:" + domain.ChatKeyForChannel(0),
+			wantGroupTitle: "#General",
+		},
+		{
+			name:           "per sender",
+			grouping:       config.NotificationGroupingSender,
+			chatKey:        domain.ChatKeyForChannel(0),
+			wantGroupKey:   "sender:!87654321",
+			wantGroupTitle: "@B0B",
+		},
+		{
+			name:           "per sender in direct chat",
+			grouping:       config.NotificationGroupingSender,
+			chatKey:        domain.ChatKeyForDM("!87654321"),
+			wantGroupKey:   "sender:!87654321",
+			wantGroupTitle: "@B0B",
+		},
+		{
+			name:           "global",
+			grouping:       config.NotificationGroupingGlobal,
+			chatKey:        domain.ChatKeyForChannel(0),
+			wantGroupKey:   "messages",
+			wantGroupTitle: "New messages",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			messageBus := newTestMessageBus(t)
+			chatStore := domain.NewChatStore()
+			chatStore.UpsertChat(domain.Chat{
+				Key:       domain.ChatKeyForChannel(0),
+				Title:     "General",
+				Type:      domain.ChatTypeChannel,
+				UpdatedAt: time.Now(),
+			})
+			nodeStore := domain.NewNodeStore()
+			nodeStore.Upsert(domain.Node{
+				NodeID:    "!87654321",
+				ShortName: "B0B",
+			})
+			cfg := config.Default()
+			cfg.UI.Notifications.MessageGrouping = tc.grouping
+			sender := newCollectingNotificationSender()
+			service := NewNotificationService(
+				messageBus,
+				chatStore,
+				nodeStore,
+				func() config.AppConfig { return cfg },
+				func() bool { return false },
+				sender,
+				nil,
+			)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			service.Start(ctx)
+
+			messageBus.Publish(bus.TopicTextMessage, domain.ChatMessage{
+				ChatKey:   tc.chatKey,
+				Direction: domain.MessageDirectionIn,
+				Body:      "Hi",
+				MetaJSON:  `{"from":"!87654321"}`,
+			})
+
+			got := sender.waitForCount(t, 1)[0]
+			if got.GroupKey != tc.wantGroupKey {
+				t.Fatalf("expected group key %q, got %q", tc.wantGroupKey, got.GroupKey)
+			}
+			if got.GroupTitle != tc.wantGroupTitle {
+				t.Fatalf("expected group title %q, got %q", tc.wantGroupTitle, got.GroupTitle)
+			}
+			if got.ChatKey != tc.chatKey {
+				t.Fatalf("expected chat key %q, got %q", tc.chatKey, got.ChatKey)
+			}
+		})
+	}
+}
+
 func TestNotificationServiceSkipsOutgoingMessages(t *testing.T) {
 	messageBus := newTestMessageBus(t)
 	cfg := config.Default()
@@ -290,6 +439,157 @@ func TestNotificationServiceForegroundAndPerTypeSettings(t *testing.T) {
 	sender.assertCount(t, 1)
 }
 
+func TestNotificationServiceRecordsHistoryWhileFocused(t *testing.T) {
+	messageBus := newTestMessageBus(t)
+	cfg := config.Default()
+	sender := newCollectingNotificationSender()
+	history := notifications.NewHistory(0)
+	service := NewNotificationService(
+		messageBus,
+		domain.NewChatStore(),
+		domain.NewNodeStore(),
+		func() config.AppConfig { return cfg },
+		func() bool { return true },
+		sender,
+		nil,
+	)
+	service.SetHistory(history)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	service.Start(ctx)
+
+	messageBus.Publish(bus.TopicTextMessage, domain.ChatMessage{
+		ChatKey:   domain.ChatKeyForDM("!12345678"),
+		Direction: domain.MessageDirectionIn,
+		Body:      "hello",
+		MetaJSON:  `{"from":"!12345678"}`,
+	})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(history.Entries()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for the history entry")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	entry := history.Entries()[0]
+	if entry.Shown {
+		t.Fatalf("expected the entry to be recorded as not shown while focused")
+	}
+	if entry.Payload.ChatKey != domain.ChatKeyForDM("!12345678") {
+		t.Fatalf("unexpected entry chat key: expected %q, got %q", domain.ChatKeyForDM("!12345678"), entry.Payload.ChatKey)
+	}
+	sender.assertCount(t, 0)
+}
+
+func TestNotificationServiceMutedRecordsHistoryOnly(t *testing.T) {
+	cfg := config.Default()
+	cfg.UI.Notifications.Muted = true
+	sender := newCollectingNotificationSender()
+	history := notifications.NewHistory(0)
+	service := NewNotificationService(
+		newTestMessageBus(t),
+		domain.NewChatStore(),
+		domain.NewNodeStore(),
+		func() config.AppConfig { return cfg },
+		func() bool { return false },
+		sender,
+		nil,
+	)
+	service.SetHistory(history)
+
+	service.handleIncomingMessage(domain.ChatMessage{
+		ChatKey:   domain.ChatKeyForDM("!12345678"),
+		Direction: domain.MessageDirectionIn,
+		Body:      "hello",
+		MetaJSON:  `{"from":"!12345678"}`,
+	})
+
+	entries := history.Entries()
+	if len(entries) != 1 || entries[0].Shown {
+		t.Fatalf("expected one entry recorded as not shown, got %+v", entries)
+	}
+	sender.assertCount(t, 0)
+}
+
+func TestNotificationServiceMuteExpires(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	cfg := config.Default()
+	cfg.UI.Notifications.Muted = true
+	cfg.UI.Notifications.MutedUntil = now.Add(30 * time.Minute)
+	sender := newCollectingNotificationSender()
+	service := NewNotificationService(
+		newTestMessageBus(t),
+		domain.NewChatStore(),
+		domain.NewNodeStore(),
+		func() config.AppConfig { return cfg },
+		func() bool { return false },
+		sender,
+		nil,
+	)
+	service.now = func() time.Time { return now }
+	message := domain.ChatMessage{
+		ChatKey:   domain.ChatKeyForDM("!12345678"),
+		Direction: domain.MessageDirectionIn,
+		Body:      "hello",
+		MetaJSON:  `{"from":"!12345678"}`,
+	}
+
+	service.handleIncomingMessage(message)
+	sender.assertCount(t, 0)
+
+	now = now.Add(30 * time.Minute)
+	service.handleIncomingMessage(message)
+	sender.assertCount(t, 1)
+}
+
+func TestNotificationServiceDoNotDisturbLetsBellsThrough(t *testing.T) {
+	now := time.Date(2026, 3, 10, 2, 30, 0, 0, time.Local)
+	cfg := config.Default()
+	cfg.UI.Notifications.DoNotDisturb.Enabled = true
+	sender := newCollectingNotificationSender()
+	history := notifications.NewHistory(0)
+	service := NewNotificationService(
+		newTestMessageBus(t),
+		domain.NewChatStore(),
+		domain.NewNodeStore(),
+		func() config.AppConfig { return cfg },
+		func() bool { return false },
+		sender,
+		nil,
+	)
+	service.SetHistory(history)
+	service.now = func() time.Time { return now }
+
+	message := func(body string) domain.ChatMessage {
+		return domain.ChatMessage{
+			ChatKey:   domain.ChatKeyForChannel(0),
+			Direction: domain.MessageDirectionIn,
+			Body:      body,
+			MetaJSON:  `{"from":"!12345678"}`,
+		}
+	}
+	service.handleIncomingMessage(message("night chatter"))
+	sender.assertCount(t, 0)
+	if entries := history.Entries(); len(entries) != 1 || entries[0].Shown {
+		t.Fatalf("expected the message recorded as not shown, got %+v", entries)
+	}
+
+	service.handleIncomingMessage(message("\aGate opened"))
+	got := sender.waitForCount(t, 1)
+	if got[0].Content != "!12345678: 🔔 Gate opened" || !got[0].Bell {
+		t.Fatalf("unexpected bell notification: %+v", got[0])
+	}
+
+	cfg.UI.Notifications.DoNotDisturb.AllowBells = false
+	service.handleIncomingMessage(message("\aGate closed"))
+	sender.assertCount(t, 1)
+
+	now = time.Date(2026, 3, 10, 8, 0, 0, 0, time.Local)
+	service.handleIncomingMessage(message("good morning"))
+	sender.waitForCount(t, 2)
+}
+
 func TestNotificationServiceUpdateAvailableOnLaterSnapshot(t *testing.T) {
 	messageBus := newTestMessageBus(t)
 	cfg := config.Default()
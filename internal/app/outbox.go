@@ -0,0 +1,363 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/radio"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+const (
+	// outboxFlushDelay lets the radio finish its config download after a reconnect
+	// before queued messages are sent.
+	outboxFlushDelay = 5 * time.Second
+	// outboxSendInterval spaces queued messages so a flush does not flood the mesh.
+	outboxSendInterval = 3 * time.Second
+	outboxSendTimeout  = 15 * time.Second
+	// outboxMaxAttempts is how many failed sends give a queued message up.
+	outboxMaxAttempts = 3
+)
+
+type outboxSender interface {
+	SendText(chatKey, text string, opts radio.TextSendOptions) <-chan radio.SendResult
+}
+
+// outboxWaypointSender is implemented by radios that can send waypoint packets.
+type outboxWaypointSender interface {
+	SendWaypoint(chatKey string, waypoint domain.Waypoint) <-chan radio.SendResult
+}
+
+type outboxStore interface {
+	Add(ctx context.Context, m domain.OutboxMessage) (int64, error)
+	ListQueued(ctx context.Context) ([]domain.OutboxMessage, error)
+	MarkAttempt(ctx context.Context, id int64, sendErr string) error
+	MarkFailed(ctx context.Context, id int64, sendErr string) error
+	Delete(ctx context.Context, id int64) error
+}
+
+// OutboxService sends chat messages through the radio and keeps the ones written while
+// it is disconnected until it comes back. Queued messages show up in their chat as
+// pending and are flushed one by one after a reconnect.
+type OutboxService struct {
+	bus        bus.MessageBus
+	radio      outboxSender
+	store      outboxStore
+	connStatus func() (busmsg.ConnectionStatus, bool)
+	logger     *slog.Logger
+
+	flushDelay   time.Duration
+	sendInterval time.Duration
+
+	mu       sync.Mutex
+	ctx      context.Context
+	flushing bool
+	// flushAgain makes a running flush look at the outbox once more before it stops.
+	flushAgain bool
+}
+
+func NewOutboxService(
+	messageBus bus.MessageBus,
+	sender outboxSender,
+	store outboxStore,
+	connStatus func() (busmsg.ConnectionStatus, bool),
+	logger *slog.Logger,
+) *OutboxService {
+	if logger == nil {
+		logger = slog.Default().With("component", "app.outbox")
+	}
+
+	return &OutboxService{
+		bus:          messageBus,
+		radio:        sender,
+		store:        store,
+		connStatus:   connStatus,
+		logger:       logger,
+		flushDelay:   outboxFlushDelay,
+		sendInterval: outboxSendInterval,
+	}
+}
+
+// Start flushes the outbox whenever the radio connects until ctx is done.
+func (s *OutboxService) Start(ctx context.Context) {
+	if s == nil || s.bus == nil {
+		return
+	}
+	s.mu.Lock()
+	s.ctx = ctx
+	s.mu.Unlock()
+
+	connSub := s.bus.Subscribe(bus.TopicConnStatus)
+	go func() {
+		defer s.bus.Unsubscribe(connSub, bus.TopicConnStatus)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case raw, ok := <-connSub:
+				if !ok {
+					return
+				}
+				status, ok := raw.(busmsg.ConnectionStatus)
+				if !ok || status.State != busmsg.ConnectionStateConnected {
+					continue
+				}
+				s.scheduleFlush(s.flushDelay)
+			}
+		}
+	}()
+	if s.connected() {
+		s.scheduleFlush(0)
+	}
+}
+
+// SendText sends the message right away when the radio is connected and the outbox is
+// empty. Otherwise the message is queued and its pending chat entry is the result.
+// Loopback chat messages never reach the radio and are not queued.
+func (s *OutboxService) SendText(chatKey, text string, opts radio.TextSendOptions) <-chan radio.SendResult {
+	if domain.IsLoopbackKey(chatKey) || (s.connected() && !s.isFlushing()) {
+		return s.radio.SendText(chatKey, text, opts)
+	}
+
+	resCh := make(chan radio.SendResult, 1)
+	msg, err := s.enqueue(chatKey, text, opts)
+	resCh <- radio.SendResult{Message: msg, Err: err}
+	close(resCh)
+
+	return resCh
+}
+
+// QueueText always queues the message, so messages queued together go out one by one,
+// spaced like a flush. Loopback chat messages are sent right away.
+func (s *OutboxService) QueueText(chatKey, text string, opts radio.TextSendOptions) <-chan radio.SendResult {
+	if domain.IsLoopbackKey(chatKey) {
+		return s.radio.SendText(chatKey, text, opts)
+	}
+
+	resCh := make(chan radio.SendResult, 1)
+	msg, err := s.enqueue(chatKey, text, opts)
+	resCh <- radio.SendResult{Message: msg, Err: err}
+	close(resCh)
+
+	return resCh
+}
+
+// SendWaypoint shares a waypoint as a waypoint packet when the radio is connected and
+// can send one. Otherwise the waypoint goes out, or is queued, as its compact text.
+func (s *OutboxService) SendWaypoint(chatKey string, waypoint domain.Waypoint) <-chan radio.SendResult {
+	if err := waypoint.Validate(); err != nil {
+		resCh := make(chan radio.SendResult, 1)
+		resCh <- radio.SendResult{Err: err}
+		close(resCh)
+
+		return resCh
+	}
+	if sender, ok := s.radio.(outboxWaypointSender); ok && (domain.IsLoopbackKey(chatKey) || (s.connected() && !s.isFlushing())) {
+		return sender.SendWaypoint(chatKey, waypoint)
+	}
+
+	return s.SendText(chatKey, waypoint.ReferenceText(), radio.TextSendOptions{})
+}
+
+func (s *OutboxService) enqueue(chatKey, text string, opts radio.TextSendOptions) (domain.ChatMessage, error) {
+	chatKey = strings.TrimSpace(chatKey)
+	if err := radio.ValidateText(chatKey, text); err != nil {
+		return domain.ChatMessage{}, err
+	}
+	if s.store == nil {
+		return domain.ChatMessage{}, fmt.Errorf("radio is not connected")
+	}
+
+	now := time.Now()
+	entry := domain.OutboxMessage{
+		ChatKey:                chatKey,
+		Body:                   text,
+		ReplyToDeviceMessageID: strings.TrimSpace(opts.ReplyToDeviceMessageID),
+		Emoji:                  opts.Emoji,
+		Status:                 domain.OutboxStatusQueued,
+		CreatedAt:              now,
+	}
+	ctx, cancel := context.WithTimeout(s.baseContext(), outboxSendTimeout)
+	defer cancel()
+	id, err := s.store.Add(ctx, entry)
+	if err != nil {
+		return domain.ChatMessage{}, fmt.Errorf("queue outgoing message: %w", err)
+	}
+	entry.ID = id
+
+	msg := domain.ChatMessage{
+		DeviceMessageID:        entry.QueuedMessageID(),
+		ReplyToDeviceMessageID: entry.ReplyToDeviceMessageID,
+		Emoji:                  entry.Emoji,
+		ChatKey:                chatKey,
+		Direction:              domain.MessageDirectionOut,
+		Body:                   text,
+		Status:                 domain.MessageStatusPending,
+		At:                     now,
+	}
+	if s.bus != nil {
+		s.bus.Publish(bus.TopicTextMessage, msg)
+	}
+	s.logger.Info("message queued until the radio reconnects", "chat_key", chatKey, "outbox_id", id)
+	if s.connected() {
+		s.scheduleFlush(0)
+	}
+
+	return msg, nil
+}
+
+func (s *OutboxService) scheduleFlush(delay time.Duration) {
+	s.mu.Lock()
+	if s.ctx == nil {
+		s.mu.Unlock()
+
+		return
+	}
+	if s.flushing {
+		s.flushAgain = true
+		s.mu.Unlock()
+
+		return
+	}
+	s.flushing = true
+	ctx := s.ctx
+	s.mu.Unlock()
+
+	go func() {
+		ok := sleepContext(ctx, delay)
+		for {
+			if ok {
+				s.flush(ctx)
+			}
+			s.mu.Lock()
+			if !s.flushAgain || ctx.Err() != nil {
+				s.flushing = false
+				s.flushAgain = false
+				s.mu.Unlock()
+
+				return
+			}
+			s.flushAgain = false
+			s.mu.Unlock()
+		}
+	}()
+}
+
+// flush sends queued messages oldest first until the outbox is empty, the radio drops
+// or a send fails.
+func (s *OutboxService) flush(ctx context.Context) {
+	for {
+		queued, err := s.store.ListQueued(ctx)
+		if err != nil {
+			s.logger.Warn("list queued messages", "error", err)
+
+			return
+		}
+		if len(queued) == 0 {
+			return
+		}
+		for _, entry := range queued {
+			if !s.connected() {
+				return
+			}
+			if !s.sendQueued(ctx, entry) {
+				return
+			}
+			if !sleepContext(ctx, s.sendInterval) {
+				return
+			}
+		}
+	}
+}
+
+func (s *OutboxService) sendQueued(ctx context.Context, entry domain.OutboxMessage) bool {
+	opts := radio.TextSendOptions{
+		ReplyToDeviceMessageID: entry.ReplyToDeviceMessageID,
+		Emoji:                  entry.Emoji,
+		QueuedMessageID:        entry.QueuedMessageID(),
+	}
+	var res radio.SendResult
+	select {
+	case <-ctx.Done():
+		return false
+	case res = <-s.radio.SendText(entry.ChatKey, entry.Body, opts):
+	}
+
+	if res.Err == nil {
+		if err := s.store.Delete(ctx, entry.ID); err != nil {
+			s.logger.Warn("delete sent message from outbox", "outbox_id", entry.ID, "error", err)
+		}
+		s.logger.Info("queued message sent", "chat_key", entry.ChatKey, "outbox_id", entry.ID, "device_message_id", res.Message.DeviceMessageID)
+
+		return true
+	}
+
+	reason := res.Err.Error()
+	if entry.Attempts+1 < outboxMaxAttempts {
+		if err := s.store.MarkAttempt(ctx, entry.ID, reason); err != nil {
+			s.logger.Warn("record outbox send attempt", "outbox_id", entry.ID, "error", err)
+		}
+		s.logger.Warn("send queued message, will retry on reconnect", "outbox_id", entry.ID, "attempt", entry.Attempts+1, "error", res.Err)
+
+		return false
+	}
+
+	if err := s.store.MarkFailed(ctx, entry.ID, reason); err != nil {
+		s.logger.Warn("mark outbox message failed", "outbox_id", entry.ID, "error", err)
+	}
+	s.bus.Publish(bus.TopicMessageStatus, domain.MessageStatusUpdate{
+		DeviceMessageID: entry.QueuedMessageID(),
+		Status:          domain.MessageStatusFailed,
+		Reason:          reason,
+	})
+	s.logger.Warn("queued message given up", "outbox_id", entry.ID, "attempts", entry.Attempts+1, "error", res.Err)
+
+	// A message that keeps failing must not hold back the ones queued after it.
+	return true
+}
+
+func (s *OutboxService) connected() bool {
+	if s == nil || s.connStatus == nil {
+		return false
+	}
+	status, known := s.connStatus()
+
+	return known && status.State == busmsg.ConnectionStateConnected
+}
+
+func (s *OutboxService) isFlushing() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.flushing
+}
+
+func (s *OutboxService) baseContext() context.Context {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ctx == nil {
+		return context.Background()
+	}
+
+	return s.ctx
+}
+
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,314 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/radio"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+type stubOutboxSender struct {
+	mu    sync.Mutex
+	calls []radio.TextSendOptions
+	err   error
+}
+
+func (s *stubOutboxSender) SendText(chatKey, text string, opts radio.TextSendOptions) <-chan radio.SendResult {
+	s.mu.Lock()
+	s.calls = append(s.calls, opts)
+	n := len(s.calls)
+	s.mu.Unlock()
+
+	resCh := make(chan radio.SendResult, 1)
+	if s.err != nil {
+		resCh <- radio.SendResult{Err: s.err}
+	} else {
+		resCh <- radio.SendResult{Message: domain.ChatMessage{DeviceMessageID: strconv.Itoa(n), ChatKey: chatKey, Body: text}}
+	}
+	close(resCh)
+
+	return resCh
+}
+
+func (s *stubOutboxSender) sent() []radio.TextSendOptions {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]radio.TextSendOptions(nil), s.calls...)
+}
+
+type memoryOutboxStore struct {
+	mu      sync.Mutex
+	nextID  int64
+	entries map[int64]domain.OutboxMessage
+}
+
+func newMemoryOutboxStore() *memoryOutboxStore {
+	return &memoryOutboxStore{entries: make(map[int64]domain.OutboxMessage)}
+}
+
+func (s *memoryOutboxStore) Add(_ context.Context, m domain.OutboxMessage) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	m.ID = s.nextID
+	s.entries[m.ID] = m
+
+	return m.ID, nil
+}
+
+func (s *memoryOutboxStore) ListQueued(_ context.Context) ([]domain.OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []domain.OutboxMessage
+	for id := int64(1); id <= s.nextID; id++ {
+		if m, ok := s.entries[id]; ok && m.Status == domain.OutboxStatusQueued {
+			out = append(out, m)
+		}
+	}
+
+	return out, nil
+}
+
+func (s *memoryOutboxStore) MarkAttempt(_ context.Context, id int64, sendErr string) error {
+	return s.update(id, domain.OutboxStatusQueued, sendErr)
+}
+
+func (s *memoryOutboxStore) MarkFailed(_ context.Context, id int64, sendErr string) error {
+	return s.update(id, domain.OutboxStatusFailed, sendErr)
+}
+
+func (s *memoryOutboxStore) update(id int64, status domain.OutboxStatus, sendErr string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := s.entries[id]
+	m.Status = status
+	m.Attempts++
+	m.LastError = sendErr
+	s.entries[id] = m
+
+	return nil
+}
+
+func (s *memoryOutboxStore) Delete(_ context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, id)
+
+	return nil
+}
+
+func (s *memoryOutboxStore) get(id int64) (domain.OutboxMessage, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.entries[id]
+
+	return m, ok
+}
+
+func newTestOutboxService(t *testing.T, sender outboxSender, store outboxStore, connected *atomic.Bool) (*OutboxService, *bus.PubSubBus) {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	messageBus := bus.New(logger)
+	t.Cleanup(messageBus.Close)
+
+	service := NewOutboxService(messageBus, sender, store, func() (busmsg.ConnectionStatus, bool) {
+		if connected.Load() {
+			return busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnected}, true
+		}
+
+		return busmsg.ConnectionStatus{State: busmsg.ConnectionStateDisconnected}, true
+	}, logger)
+	service.flushDelay = 0
+	service.sendInterval = 0
+
+	return service, messageBus
+}
+
+func TestOutboxServiceSendText_QueuesWhileDisconnected(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sender := &stubOutboxSender{}
+	store := newMemoryOutboxStore()
+	var connected atomic.Bool
+	service, messageBus := newTestOutboxService(t, sender, store, &connected)
+	textSub := messageBus.Subscribe(bus.TopicTextMessage)
+	service.Start(ctx)
+
+	res := <-service.SendText("channel:0", "hello", radio.TextSendOptions{ReplyToDeviceMessageID: "99"})
+	if res.Err != nil {
+		t.Fatalf("send while disconnected: %v", res.Err)
+	}
+	if res.Message.DeviceMessageID != "outbox:1" || res.Message.Status != domain.MessageStatusPending {
+		t.Fatalf("expected a pending queued message, got %+v", res.Message)
+	}
+	if len(sender.sent()) != 0 {
+		t.Fatalf("expected nothing to reach the radio while disconnected")
+	}
+	select {
+	case raw := <-textSub:
+		msg, ok := raw.(domain.ChatMessage)
+		if !ok || msg.DeviceMessageID != "outbox:1" || msg.ReplyToDeviceMessageID != "99" {
+			t.Fatalf("unexpected published message: %+v", raw)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the queued message to be shown in its chat")
+	}
+
+	connected.Store(true)
+	messageBus.Publish(bus.TopicConnStatus, busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnected})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, ok := store.get(1); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the outbox to be flushed after reconnect")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	sent := sender.sent()
+	if len(sent) != 1 || sent[0].QueuedMessageID != "outbox:1" || sent[0].ReplyToDeviceMessageID != "99" {
+		t.Fatalf("unexpected flushed sends: %+v", sent)
+	}
+}
+
+func TestOutboxServiceSendText_SendsDirectlyWhileConnected(t *testing.T) {
+	sender := &stubOutboxSender{}
+	store := newMemoryOutboxStore()
+	var connected atomic.Bool
+	connected.Store(true)
+	service, _ := newTestOutboxService(t, sender, store, &connected)
+
+	res := <-service.SendText("channel:0", "hello", radio.TextSendOptions{})
+	if res.Err != nil {
+		t.Fatalf("send while connected: %v", res.Err)
+	}
+	if len(sender.sent()) != 1 {
+		t.Fatalf("expected the message to go straight to the radio")
+	}
+	if queued, _ := store.ListQueued(context.Background()); len(queued) != 0 {
+		t.Fatalf("expected nothing to be queued, got %+v", queued)
+	}
+}
+
+func TestOutboxServiceSendText_LoopbackBypassesQueueWhileDisconnected(t *testing.T) {
+	sender := &stubOutboxSender{}
+	store := newMemoryOutboxStore()
+	var connected atomic.Bool
+	service, _ := newTestOutboxService(t, sender, store, &connected)
+
+	res := <-service.SendText(domain.ChatKeyLoopback, "ping", radio.TextSendOptions{})
+	if res.Err != nil {
+		t.Fatalf("loopback send while disconnected: %v", res.Err)
+	}
+	if len(sender.sent()) != 1 {
+		t.Fatalf("expected the loopback message to go straight to the radio service")
+	}
+	if queued, _ := store.ListQueued(context.Background()); len(queued) != 0 {
+		t.Fatalf("expected nothing to be queued, got %+v", queued)
+	}
+}
+
+func TestOutboxServiceQueueText_QueuesWhileConnected(t *testing.T) {
+	sender := &stubOutboxSender{}
+	store := newMemoryOutboxStore()
+	var connected atomic.Bool
+	connected.Store(true)
+	service, _ := newTestOutboxService(t, sender, store, &connected)
+
+	for _, part := range []string{"1/2 hello ", "2/2 world"} {
+		res := <-service.QueueText("channel:0", part, radio.TextSendOptions{})
+		if res.Err != nil {
+			t.Fatalf("queue part %q: %v", part, res.Err)
+		}
+		if res.Message.Status != domain.MessageStatusPending || !domain.IsQueuedMessageID(res.Message.DeviceMessageID) {
+			t.Fatalf("expected a pending queued message, got %+v", res.Message)
+		}
+	}
+	if len(sender.sent()) != 0 {
+		t.Fatalf("expected queued parts not to go straight to the radio")
+	}
+	if queued, _ := store.ListQueued(context.Background()); len(queued) != 2 || queued[0].Body != "1/2 hello " {
+		t.Fatalf("expected both parts queued in order, got %+v", queued)
+	}
+}
+
+func TestOutboxServiceSendText_RejectsInvalidMessageWithoutQueueing(t *testing.T) {
+	store := newMemoryOutboxStore()
+	var connected atomic.Bool
+	service, _ := newTestOutboxService(t, &stubOutboxSender{}, store, &connected)
+
+	res := <-service.SendText("channel:0", "", radio.TextSendOptions{})
+	if res.Err == nil {
+		t.Fatalf("expected an empty message to be rejected")
+	}
+	if queued, _ := store.ListQueued(context.Background()); len(queued) != 0 {
+		t.Fatalf("expected nothing to be queued, got %+v", queued)
+	}
+}
+
+func TestOutboxServiceSendWaypoint_FallsBackToTextWithoutWaypointSupport(t *testing.T) {
+	sender := &stubOutboxSender{}
+	store := newMemoryOutboxStore()
+	var connected atomic.Bool
+	connected.Store(true)
+	service, _ := newTestOutboxService(t, sender, store, &connected)
+
+	waypoint := domain.Waypoint{Name: "Camp", Latitude: 1.5, Longitude: -2.25}
+	res := <-service.SendWaypoint("channel:0", waypoint)
+	if res.Err != nil {
+		t.Fatalf("send waypoint: %v", res.Err)
+	}
+	if res.Message.Body != waypoint.ReferenceText() {
+		t.Fatalf("expected waypoint text %q, got %q", waypoint.ReferenceText(), res.Message.Body)
+	}
+
+	res = <-service.SendWaypoint("channel:0", domain.Waypoint{Latitude: 1, Longitude: 2})
+	if res.Err == nil {
+		t.Fatalf("expected a waypoint without name to be rejected")
+	}
+	if len(sender.sent()) != 1 {
+		t.Fatalf("expected only the valid waypoint to reach the radio")
+	}
+}
+
+func TestOutboxServiceFlush_GivesUpAfterRepeatedFailures(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sender := &stubOutboxSender{err: errors.New("send outgoing frame: broken pipe")}
+	store := newMemoryOutboxStore()
+	id, _ := store.Add(ctx, domain.OutboxMessage{ChatKey: "channel:0", Body: "hello", Status: domain.OutboxStatusQueued, Attempts: outboxMaxAttempts - 1})
+	var connected atomic.Bool
+	connected.Store(true)
+	service, messageBus := newTestOutboxService(t, sender, store, &connected)
+	statusSub := messageBus.Subscribe(bus.TopicMessageStatus)
+	service.Start(ctx)
+
+	select {
+	case raw := <-statusSub:
+		update, ok := raw.(domain.MessageStatusUpdate)
+		if !ok || update.DeviceMessageID != "outbox:1" || update.Status != domain.MessageStatusFailed {
+			t.Fatalf("unexpected status update: %+v", raw)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected the queued message to be marked failed")
+	}
+	entry, ok := store.get(id)
+	if !ok || entry.Status != domain.OutboxStatusFailed || entry.LastError == "" {
+		t.Fatalf("expected the outbox entry to be failed, got %+v", entry)
+	}
+}
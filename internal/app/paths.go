@@ -8,12 +8,18 @@ import (
 
 // Paths stores resolved runtime file locations for user config, logs, and cache.
 type Paths struct {
-	RootDir     string
-	ConfigFile  string
-	DBFile      string
-	LogFile     string
-	CacheDir    string
-	MapTilesDir string
+	RootDir         string
+	ConfigFile      string
+	WindowStateFile string
+	// EmergencyModeStateFile exists only while emergency mode is on.
+	EmergencyModeStateFile string
+	DBFile                 string
+	EncryptedDBFile        string
+	LogFile                string
+	CacheDir               string
+	MapTilesDir            string
+	// TranslationsDir holds optional user message catalogs. It is not created.
+	TranslationsDir string
 }
 
 func ResolvePaths() (Paths, error) {
@@ -40,11 +46,15 @@ func ResolvePaths() (Paths, error) {
 	}
 
 	return Paths{
-		RootDir:     root,
-		ConfigFile:  filepath.Join(root, ConfigFilename),
-		DBFile:      filepath.Join(root, DBFilename),
-		LogFile:     filepath.Join(root, LogFilename),
-		CacheDir:    cache,
-		MapTilesDir: mapTiles,
+		RootDir:                root,
+		ConfigFile:             filepath.Join(root, ConfigFilename),
+		WindowStateFile:        filepath.Join(root, WindowStateFilename),
+		EmergencyModeStateFile: filepath.Join(root, EmergencyModeStateFilename),
+		DBFile:                 filepath.Join(root, DBFilename),
+		EncryptedDBFile:        filepath.Join(root, EncryptedDBFilename),
+		LogFile:                filepath.Join(root, LogFilename),
+		CacheDir:               cache,
+		MapTilesDir:            mapTiles,
+		TranslationsDir:        filepath.Join(root, TranslationsDir),
 	}, nil
 }
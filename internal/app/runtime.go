@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -23,6 +24,9 @@ import (
 	"github.com/skobkin/meshgo/internal/radio/busmsg"
 )
 
+// writerBatchWindow is how long persistence writes are gathered into one transaction.
+const writerBatchWindow = 250 * time.Millisecond
+
 // Runtime wires app services, persistence, transport, and UI-facing stores together.
 type Runtime struct {
 	mu sync.RWMutex
@@ -38,6 +42,7 @@ type Runtime struct {
 	connStatusMu    sync.RWMutex
 	connStatus      busmsg.ConnectionStatus
 	connStatusKnown bool
+	connectedSince  time.Time
 }
 
 // RuntimeCore contains app-level configuration, paths, logging, and startup integrations.
@@ -51,15 +56,29 @@ type RuntimeCore struct {
 
 // RuntimePersistence contains database handles, repositories, and write projection queue.
 type RuntimePersistence struct {
+	// Database owns DB and, when encrypted at rest, persists it on flush and close.
+	Database            *Database
 	DB                  *sql.DB
 	NodeCoreRepo        *persistence.NodeCoreRepo
 	NodePositionRepo    *persistence.NodePositionRepo
 	NodeTelemetryRepo   *persistence.NodeTelemetryRepo
 	NodeIdentityHistory *persistence.NodeIdentityHistoryRepo
+	NodeSignalHistory   *persistence.NodeSignalHistoryRepo
 	ChatRepo            *persistence.ChatRepo
 	MessageRepo         *persistence.MessageRepo
 	TracerouteRepo      *persistence.TracerouteRepo
+	RawPacketLog        *persistence.RawPacketLogRepo
+	Statistics          *persistence.StatisticsRepo
+	OutboxRepo          *persistence.OutboxRepo
+	DeletedItemsRepo    *persistence.DeletedItemsRepo
+	MessageAnnotations  *persistence.MessageAnnotationRepo
+	MessagePins         *persistence.MessagePinRepo
+	DeviceNamespaces    *persistence.DeviceNamespaceRepo
+	DeviceScope         *persistence.DeviceScope
 	WriterQueue         *persistence.WriterQueue
+	Maintainer          *DatabaseMaintainer
+	// RepairReport is set when a corrupted database was rebuilt on startup.
+	RepairReport *persistence.DatabaseRepairReport
 }
 
 // RuntimeDomain contains in-memory stores and message bus projections used by the app/UI.
@@ -69,6 +88,7 @@ type RuntimeDomain struct {
 	ChatStore     *domain.ChatStore
 	NodeDiscovery *projections.NodeDiscoveryProjection
 	NodeMetadata  *projections.NodeMetadataProjection
+	ChatTitles    *projections.ChatTitleBackfillProjection
 }
 
 // RuntimeConnectivity contains transport and radio services used for device communication.
@@ -76,6 +96,7 @@ type RuntimeConnectivity struct {
 	ConnectionTransport *SwitchableTransport
 	Radio               *radio.Service
 	Traceroute          *TracerouteService
+	Outbox              *OutboxService
 }
 
 func Initialize(parent context.Context) (*Runtime, error) {
@@ -83,6 +104,8 @@ func Initialize(parent context.Context) (*Runtime, error) {
 	if err != nil {
 		return nil, err
 	}
+	// Logging is not configured yet, so the outcome is logged below.
+	restored, restoreErr := applyPendingRestore(paths)
 	cfg, err := config.Load(paths.ConfigFile)
 	if err != nil {
 		return nil, err
@@ -108,25 +131,58 @@ func Initialize(parent context.Context) (*Runtime, error) {
 	}
 	rt.Core.LogManager = logMgr
 	slog.Info("starting meshgo runtime", "version", BuildVersion(), "build_date", BuildDateYMD())
+	switch {
+	case restoreErr != nil:
+		slog.Warn("apply staged app data restore", "error", restoreErr)
+	case restored != nil:
+		slog.Info(
+			"app data restored from backup",
+			"backup_app_version", restored.AppVersion,
+			"backup_schema_version", restored.SchemaVersion,
+			"backup_created_at", restored.CreatedAt,
+		)
+	}
 	if err := rt.syncAutostart(cfg, "startup"); err != nil {
 		slog.Warn("sync autostart on startup", "error", err)
 	}
 
-	db, err := persistence.Open(ctx, paths.DBFile)
+	database, err := OpenDatabase(ctx, paths, cfg.Persistence.EncryptDatabase, platform.NewSecretStore())
 	if err != nil {
 		_ = rt.Close()
 
 		return nil, err
 	}
+	db := database.DB
+	rt.Persistence.Database = database
 	rt.Persistence.DB = db
+	rt.Persistence.RepairReport = database.RepairReport
+	var databaseFlusher *encryptedDatabaseFlusher
+	if database.Encrypted() {
+		databaseFlusher = newEncryptedDatabaseFlusher(database)
+		go databaseFlusher.Run(ctx)
+	}
 
 	rt.Persistence.NodeCoreRepo = persistence.NewNodeCoreRepo(db)
 	rt.Persistence.NodePositionRepo = persistence.NewNodePositionRepo(db)
 	rt.Persistence.NodeTelemetryRepo = persistence.NewNodeTelemetryRepo(db)
 	rt.Persistence.NodeIdentityHistory = persistence.NewNodeIdentityHistoryRepo(db)
+	rt.Persistence.NodeSignalHistory = persistence.NewNodeSignalHistoryRepo(db)
 	rt.Persistence.ChatRepo = persistence.NewChatRepo(db)
 	rt.Persistence.MessageRepo = persistence.NewMessageRepo(db)
 	rt.Persistence.TracerouteRepo = persistence.NewTracerouteRepo(db)
+	rt.Persistence.RawPacketLog = persistence.NewRawPacketLogRepo(db)
+	rt.Persistence.Statistics = persistence.NewStatisticsRepo(db)
+	rt.Persistence.OutboxRepo = persistence.NewOutboxRepo(db)
+	rt.Persistence.DeletedItemsRepo = persistence.NewDeletedItemsRepo(db)
+	rt.Persistence.MessageAnnotations = persistence.NewMessageAnnotationRepo(db)
+	rt.Persistence.MessagePins = persistence.NewMessagePinRepo(db)
+	rt.Persistence.DeviceNamespaces = persistence.NewDeviceNamespaceRepo(db)
+	rt.Persistence.useDeviceScope(openDeviceScope(ctx, rt.Persistence.DeviceNamespaces))
+	rt.purgeExpiredDeletedItems(ctx, cfg.Persistence.DeletedRetentionDays)
+	if !database.Shared {
+		rt.Persistence.Maintainer = NewDatabaseMaintainer(db, logMgr.Logger("db_maintenance"))
+		rt.Persistence.Maintainer.Start(ctx)
+	}
 
 	nodeStore := domain.NewNodeStore()
 	chatStore := domain.NewChatStore()
@@ -144,6 +200,7 @@ func Initialize(parent context.Context) (*Runtime, error) {
 
 		return nil, err
 	}
+	rt.hideDeletedNodes(ctx, nodeStore)
 	rt.Domain.NodeStore = nodeStore
 	rt.Domain.ChatStore = chatStore
 
@@ -161,6 +218,13 @@ func Initialize(parent context.Context) (*Runtime, error) {
 	rt.Domain.NodeMetadata = nodeMetadata
 
 	writerQueue := persistence.NewWriterQueue(logMgr.Logger("persistence"), 512)
+	writerQueue.EnableBatching(db, writerBatchWindow)
+	writerQueue.UseDeviceScope(rt.Persistence.DeviceScope)
+	if databaseFlusher != nil {
+		// The encrypted database lives in memory, so batches are written to disk soon
+		// after instead of waiting for the periodic flush.
+		writerQueue.OnBatchWritten(func(context.Context) { databaseFlusher.MarkDirty() })
+	}
 	writerQueue.Start(ctx)
 	rt.Persistence.WriterQueue = writerQueue
 	projections.StartPersistenceProjection(
@@ -170,11 +234,29 @@ func Initialize(parent context.Context) (*Runtime, error) {
 		rt.Persistence.NodeCoreRepo,
 		rt.Persistence.NodePositionRepo,
 		rt.Persistence.NodeTelemetryRepo,
+		rt.Persistence.NodeSignalHistory,
 		newHistoryLimitsProvider(rt.CurrentConfig),
 		rt.Persistence.ChatRepo,
 		rt.Persistence.MessageRepo,
 		rt.Persistence.TracerouteRepo,
+		rt.Persistence.Statistics,
 	)
+	projections.StartRawPacketLogProjection(
+		ctx,
+		b,
+		writerQueue,
+		rt.Persistence.RawPacketLog,
+		newRawPacketLogSettings(rt.CurrentConfig),
+	)
+	chatTitles := projections.NewChatTitleBackfillProjection(
+		chatStore,
+		writerQueue,
+		rt.Persistence.ChatRepo,
+		logMgr.Logger("chat_title_backfill"),
+	)
+	chatTitles.BackfillFromStore(nodeStore)
+	chatTitles.Start(ctx, b)
+	rt.Domain.ChatTitles = chatTitles
 
 	codec, err := radio.NewMeshtasticCodec()
 	if err != nil {
@@ -192,6 +274,7 @@ func Initialize(parent context.Context) (*Runtime, error) {
 	rt.Connectivity.ConnectionTransport = connTransport
 
 	rt.Connectivity.Radio = radio.NewService(logMgr.Logger("radio"), b, rt.Connectivity.ConnectionTransport, codec)
+	rt.Connectivity.Radio.OnLocalNodeChange(rt.switchDeviceNamespace)
 	rt.Connectivity.Radio.Start(ctx)
 	rt.Connectivity.Traceroute = NewTracerouteService(
 		b,
@@ -202,6 +285,14 @@ func Initialize(parent context.Context) (*Runtime, error) {
 		DefaultTracerouteRequestTimeout,
 	)
 	rt.Connectivity.Traceroute.Start(ctx)
+	rt.Connectivity.Outbox = NewOutboxService(
+		b,
+		rt.Connectivity.Radio,
+		rt.Persistence.OutboxRepo,
+		rt.CurrentConnStatus,
+		logMgr.Logger("outbox"),
+	)
+	rt.Connectivity.Outbox.Start(ctx)
 
 	rt.Core.UpdateChecker = NewUpdateChecker(UpdateCheckerDependencies{
 		CurrentVersion: BuildVersion(),
@@ -226,12 +317,42 @@ func (r *Runtime) captureConnStatus(ctx context.Context, sub bus.Subscription) {
 				continue
 			}
 			r.setConnStatus(status)
+			if status.State == busmsg.ConnectionStateConnected {
+				r.rememberConnection(status)
+			}
 		}
 	}
 }
 
+// rememberConnection adds the configured endpoint to the recent connections once the
+// transport reports it connected.
+func (r *Runtime) rememberConnection(status busmsg.ConnectionStatus) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cfg := r.Core.Config
+	if status.TransportName != TransportNameFromType(cfg.Connection.Transport) {
+		return
+	}
+	cfg.RecentConnections = slices.Clone(cfg.RecentConnections)
+	if !cfg.RememberConnection(cfg.Connection) {
+		return
+	}
+	if err := config.Save(r.Core.Paths.ConfigFile, cfg); err != nil {
+		slog.Warn("save recent connections", "error", err)
+
+		return
+	}
+	r.Core.Config = cfg
+}
+
 func (r *Runtime) setConnStatus(status busmsg.ConnectionStatus) {
 	r.connStatusMu.Lock()
+	switch {
+	case status.State != busmsg.ConnectionStateConnected:
+		r.connectedSince = time.Time{}
+	case r.connectedSince.IsZero():
+		r.connectedSince = time.Now()
+	}
 	r.connStatus = status
 	r.connStatusKnown = true
 	r.connStatusMu.Unlock()
@@ -277,6 +398,16 @@ func (r *Runtime) SaveAndApplyConfig(cfg config.AppConfig) error {
 	prevConnection := r.Core.Config.Connection
 	cfg.UI.LastSelectedChat = r.Core.Config.UI.LastSelectedChat
 	cfg.UI.MapViewport = r.Core.Config.UI.MapViewport
+	cfg.UI.TaskbarFlash.Chats = r.Core.Config.UI.TaskbarFlash.Chats
+	cfg.UI.Notifications.MutedNodes = r.Core.Config.UI.Notifications.MutedNodes
+	cfg.UI.Notifications.NodePresence = r.Core.Config.UI.Notifications.NodePresence
+	cfg.UI.Notifications.Muted = r.Core.Config.UI.Notifications.Muted
+	cfg.UI.Notifications.MutedUntil = r.Core.Config.UI.Notifications.MutedUntil
+	cfg.UI.ChannelColors = r.Core.Config.UI.ChannelColors
+	cfg.UI.CloseButton = r.Core.Config.UI.CloseButton
+	cfg.UI.ChatList = r.Core.Config.UI.ChatList
+	cfg.UI.NodeList = r.Core.Config.UI.NodeList
+	cfg.RecentConnections = r.Core.Config.RecentConnections
 	if err := config.Save(r.Core.Paths.ConfigFile, cfg); err != nil {
 		r.mu.Unlock()
 
@@ -374,6 +505,159 @@ func (r *Runtime) RememberMapViewport(zoom, x, y int) {
 	r.mu.Unlock()
 }
 
+// TaskbarFlashChatDefault reports whether a chat without an override flashes the
+// taskbar: direct messages do, channels do not.
+func TaskbarFlashChatDefault(chatKey string) bool {
+	return domain.IsDMKey(chatKey)
+}
+
+// SetChatTaskbarFlash records whether new messages in the chat flash the taskbar.
+func (r *Runtime) SetChatTaskbarFlash(chatKey string, enabled bool) error {
+	chatKey = strings.TrimSpace(chatKey)
+	if chatKey == "" {
+		return fmt.Errorf("chat key is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cfg := r.Core.Config
+	cfg.UI.TaskbarFlash.SetChat(chatKey, enabled, TaskbarFlashChatDefault(chatKey))
+	if err := config.Save(r.Core.Paths.ConfigFile, cfg); err != nil {
+		return fmt.Errorf("save chat taskbar flash: %w", err)
+	}
+	r.Core.Config = cfg
+
+	return nil
+}
+
+// SetNodeNotificationsMuted records whether messages from the node are notified about.
+// Muted messages are still stored and counted as unread.
+func (r *Runtime) SetNodeNotificationsMuted(nodeID string, muted bool) error {
+	nodeID = strings.TrimSpace(nodeID)
+	if nodeID == "" {
+		return fmt.Errorf("node id is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cfg := r.Core.Config
+	cfg.UI.Notifications.SetNodeMuted(nodeID, muted)
+	if err := config.Save(r.Core.Paths.ConfigFile, cfg); err != nil {
+		return fmt.Errorf("save node notification mute: %w", err)
+	}
+	r.Core.Config = cfg
+
+	return nil
+}
+
+// SetNodePresenceAlert records when the favorite node alerts about going silent and
+// being heard again.
+func (r *Runtime) SetNodePresenceAlert(nodeID string, alert config.NodePresenceAlert) error {
+	nodeID = strings.TrimSpace(nodeID)
+	if nodeID == "" {
+		return fmt.Errorf("node id is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cfg := r.Core.Config
+	cfg.UI.Notifications.SetPresenceAlert(nodeID, alert)
+	if err := config.Save(r.Core.Paths.ConfigFile, cfg); err != nil {
+		return fmt.Errorf("save node presence alert: %w", err)
+	}
+	r.Core.Config = cfg
+
+	return nil
+}
+
+// SetChannelColor records the accent color of the channel chat. An empty color restores
+// the automatic one.
+func (r *Runtime) SetChannelColor(chatKey, color string) error {
+	chatKey = strings.TrimSpace(chatKey)
+	if chatKey == "" {
+		return fmt.Errorf("chat key is required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cfg := r.Core.Config
+	cfg.UI.SetChannelColor(chatKey, color)
+	if err := config.Save(r.Core.Paths.ConfigFile, cfg); err != nil {
+		return fmt.Errorf("save channel color: %w", err)
+	}
+	r.Core.Config = cfg
+
+	return nil
+}
+
+// SetNotificationsMuted silences or restores desktop notifications and message sounds.
+// A non-zero until ends the mute on its own.
+func (r *Runtime) SetNotificationsMuted(muted bool, until time.Time) error {
+	if !muted {
+		until = time.Time{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := r.Core.Config.UI.Notifications
+	if current.Muted == muted && current.MutedUntil.Equal(until) {
+		return nil
+	}
+	cfg := r.Core.Config
+	cfg.UI.Notifications.Muted = muted
+	cfg.UI.Notifications.MutedUntil = until
+	if err := config.Save(r.Core.Paths.ConfigFile, cfg); err != nil {
+		return fmt.Errorf("save notifications mute: %w", err)
+	}
+	r.Core.Config = cfg
+
+	return nil
+}
+
+// SetCloseButtonAction records what the close button of the main window does.
+func (r *Runtime) SetCloseButtonAction(action config.CloseButtonAction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cfg := r.Core.Config
+	cfg.UI.CloseButton = action
+	cfg.FillMissingDefaults()
+	if err := config.Save(r.Core.Paths.ConfigFile, cfg); err != nil {
+		return fmt.Errorf("save close button action: %w", err)
+	}
+	r.Core.Config = cfg
+
+	return nil
+}
+
+// SetChatListPrefs records the sorting and filter of the chat list.
+func (r *Runtime) SetChatListPrefs(prefs config.ChatListConfig) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cfg := r.Core.Config
+	cfg.UI.ChatList = prefs
+	cfg.FillMissingDefaults()
+	if err := config.Save(r.Core.Paths.ConfigFile, cfg); err != nil {
+		return fmt.Errorf("save chat list preferences: %w", err)
+	}
+	r.Core.Config = cfg
+
+	return nil
+}
+
+// SetNodeListPrefs records the columns and sorting of the node list.
+func (r *Runtime) SetNodeListPrefs(prefs config.NodeListConfig) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cfg := r.Core.Config
+	cfg.UI.NodeList = prefs
+	cfg.FillMissingDefaults()
+	if err := config.Save(r.Core.Paths.ConfigFile, cfg); err != nil {
+		return fmt.Errorf("save node list preferences: %w", err)
+	}
+	r.Core.Config = cfg
+
+	return nil
+}
+
 func (r *Runtime) DeleteDMChat(chatKey string) error {
 	chatKey = strings.TrimSpace(chatKey)
 	if chatKey == "" {
@@ -382,34 +666,16 @@ func (r *Runtime) DeleteDMChat(chatKey string) error {
 	if !domain.IsDMKey(chatKey) {
 		return fmt.Errorf("delete dm chat: chat %q is not a DM", chatKey)
 	}
-	if r.Persistence.DB == nil {
+	if r.Persistence.DeletedItemsRepo == nil {
 		return fmt.Errorf("database is not initialized")
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	tx, err := r.Persistence.DB.BeginTx(ctx, nil)
-	if err != nil {
-		return fmt.Errorf("begin delete dm chat transaction: %w", err)
-	}
-	committed := false
-	defer func() {
-		if !committed {
-			_ = tx.Rollback()
-		}
-	}()
-
-	if _, err := tx.ExecContext(ctx, `DELETE FROM messages WHERE chat_key = ?`, chatKey); err != nil {
-		return fmt.Errorf("delete dm chat messages: %w", err)
-	}
-	if _, err := tx.ExecContext(ctx, `DELETE FROM chats WHERE chat_key = ?`, chatKey); err != nil {
-		return fmt.Errorf("delete dm chat metadata: %w", err)
-	}
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("commit delete dm chat: %w", err)
+	if err := r.Persistence.DeletedItemsRepo.DeleteChat(ctx, chatKey, time.Now()); err != nil {
+		return fmt.Errorf("delete dm chat: %w", err)
 	}
-	committed = true
 
 	if r.Domain.ChatStore != nil {
 		r.Domain.ChatStore.DeleteChat(chatKey)
@@ -570,7 +836,25 @@ func (r *Runtime) Close() error {
 	if r.Connectivity.ConnectionTransport != nil {
 		_ = r.Connectivity.ConnectionTransport.Close()
 	}
-	if r.Persistence.DB != nil {
+	if r.Persistence.WriterQueue != nil {
+		stats := r.Persistence.WriterQueue.Stats()
+		slog.Info(
+			"persistence writer summary",
+			"batches", stats.Batches,
+			"commands", stats.Commands,
+			"coalesced", stats.Coalesced,
+			"failed", stats.Failed,
+			"avg_batch_size", stats.AverageBatchSize(),
+			"max_batch_size", stats.MaxBatchSize,
+			"avg_latency", stats.AverageLatency(),
+			"max_latency", stats.MaxLatency,
+		)
+	}
+	if r.Persistence.Database != nil {
+		if err := r.Persistence.Database.Close(); err != nil {
+			slog.Warn("close database", "error", err)
+		}
+	} else if r.Persistence.DB != nil {
 		_ = r.Persistence.DB.Close()
 	}
 	if r.Core.LogManager != nil {
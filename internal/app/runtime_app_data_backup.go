@@ -0,0 +1,511 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/persistence"
+	"github.com/skobkin/meshgo/internal/platform"
+)
+
+const (
+	appDataBackupFormatVersion = 1
+	appDataBackupManifestName  = "manifest.json"
+	// appDataBackupManifestLimit and appDataBackupConfigLimit cap how much of a
+	// user-picked archive is read into memory.
+	appDataBackupManifestLimit = 64 << 10
+	appDataBackupConfigLimit   = 4 << 20
+	// pendingRestoreDirname holds a checked backup until the next start swaps it in,
+	// while no open connection can write to the database it replaces.
+	pendingRestoreDirname = "restore-pending"
+)
+
+// ErrIncompatibleBackup means an app data backup cannot be restored by this build.
+var ErrIncompatibleBackup = errors.New("backup is not compatible with this meshgo version")
+
+// ErrBackupKeyUnavailable means an encrypted backup cannot be restored because the
+// database key it was made with is not in use.
+var ErrBackupKeyUnavailable = errors.New("backup database is encrypted with a key this install does not have")
+
+// AppDataBackup is the manifest stored in an app data backup archive.
+type AppDataBackup struct {
+	FormatVersion int       `json:"format_version"`
+	AppVersion    string    `json:"app_version"`
+	SchemaVersion int       `json:"schema_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	// Encrypted is set when the database entry is sealed with the database key, which
+	// happens when the database is encrypted at rest.
+	Encrypted bool `json:"encrypted,omitempty"`
+}
+
+// databaseEntryName returns the archive entry holding the database.
+func (b AppDataBackup) databaseEntryName() string {
+	if b.Encrypted {
+		return EncryptedDBFilename
+	}
+
+	return DBFilename
+}
+
+// AppDataBackupName suggests a file name for a backup made at now.
+func AppDataBackupName(now time.Time) string {
+	return fmt.Sprintf("%s-backup-%s.zip", Name, now.Format("20060102-150405"))
+}
+
+// BackupAppData writes the database and settings into a zip archive at path. A database
+// encrypted at rest is stored sealed with its key, so only an install holding that key
+// can restore it.
+func (r *Runtime) BackupAppData(ctx context.Context, path string) (AppDataBackup, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return AppDataBackup{}, fmt.Errorf("backup file path is required")
+	}
+	if r.Persistence.DB == nil {
+		return AppDataBackup{}, fmt.Errorf("database is not initialized")
+	}
+
+	started := time.Now()
+	workDir, err := os.MkdirTemp("", Name+"-backup-*")
+	if err != nil {
+		return AppDataBackup{}, fmt.Errorf("create backup work dir: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(workDir)
+	}()
+
+	encrypted := r.Persistence.Database != nil && r.Persistence.Database.Encrypted()
+	manifest := AppDataBackup{
+		FormatVersion: appDataBackupFormatVersion,
+		AppVersion:    BuildVersion(),
+		CreatedAt:     started.UTC(),
+		Encrypted:     encrypted,
+	}
+	snapshotPath := filepath.Join(workDir, manifest.databaseEntryName())
+	if encrypted {
+		manifest.SchemaVersion, err = snapshotEncryptedDatabase(ctx, r.Persistence.Database, snapshotPath)
+	} else {
+		manifest.SchemaVersion, err = snapshotPlainDatabase(ctx, r.Persistence.DB, snapshotPath)
+	}
+	if err != nil {
+		return AppDataBackup{}, err
+	}
+	rawConfig, err := json.MarshalIndent(r.CurrentConfig(), "", "  ")
+	if err != nil {
+		return AppDataBackup{}, fmt.Errorf("encode config: %w", err)
+	}
+	if err := writeAppDataBackup(path, manifest, snapshotPath, rawConfig); err != nil {
+		return AppDataBackup{}, err
+	}
+
+	slog.Info(
+		"app data backup written",
+		"path", path,
+		"schema_version", manifest.SchemaVersion,
+		"encrypted", manifest.Encrypted,
+		"duration", time.Since(started),
+	)
+
+	return manifest, nil
+}
+
+func snapshotPlainDatabase(ctx context.Context, db *sql.DB, path string) (int, error) {
+	if err := persistence.SnapshotDatabase(ctx, db, path); err != nil {
+		return 0, err
+	}
+
+	return persistence.InspectDatabaseFile(ctx, path)
+}
+
+// snapshotEncryptedDatabase seals the copy with the database key and reads its schema
+// version back, which also proves the copy opens with that key.
+func snapshotEncryptedDatabase(ctx context.Context, database *Database, path string) (int, error) {
+	if err := database.encrypted.SnapshotTo(ctx, path); err != nil {
+		return 0, err
+	}
+	db, err := database.encrypted.OpenSnapshotReadOnly(ctx, path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	return persistence.SchemaVersion(ctx, db)
+}
+
+func writeAppDataBackup(path string, manifest AppDataBackup, snapshotPath string, rawConfig []byte) error {
+	rawManifest, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode backup manifest: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	// #nosec G304 -- path is picked by the user in a save dialog.
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("create backup file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmpPath)
+	}()
+
+	archive := zip.NewWriter(file)
+	writeErr := writeAppDataBackupEntry(archive, appDataBackupManifestName, manifest.CreatedAt, bytes.NewReader(rawManifest))
+	if writeErr == nil {
+		writeErr = writeAppDataBackupFile(archive, manifest.databaseEntryName(), manifest.CreatedAt, snapshotPath)
+	}
+	if writeErr == nil {
+		writeErr = writeAppDataBackupEntry(archive, ConfigFilename, manifest.CreatedAt, bytes.NewReader(rawConfig))
+	}
+	if writeErr == nil {
+		if err := archive.Close(); err != nil {
+			writeErr = fmt.Errorf("finish backup archive: %w", err)
+		}
+	}
+	if closeErr := file.Close(); writeErr == nil && closeErr != nil {
+		writeErr = fmt.Errorf("close backup file: %w", closeErr)
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("rename backup file: %w", err)
+	}
+
+	return nil
+}
+
+func writeAppDataBackupFile(archive *zip.Writer, name string, modified time.Time, path string) error {
+	// #nosec G304 -- path is the database snapshot made by BackupAppData.
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open %s for backup: %w", name, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return writeAppDataBackupEntry(archive, name, modified, file)
+}
+
+func writeAppDataBackupEntry(archive *zip.Writer, name string, modified time.Time, content io.Reader) error {
+	entry, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return fmt.Errorf("add %s to backup: %w", name, err)
+	}
+	if _, err := io.Copy(entry, content); err != nil {
+		return fmt.Errorf("write %s to backup: %w", name, err)
+	}
+
+	return nil
+}
+
+// RestoreAppData checks a backup made by BackupAppData and stages it to replace the
+// database and settings on the next start. The running app keeps its current data, so
+// the user has to restart it to finish the restore.
+func (r *Runtime) RestoreAppData(ctx context.Context, path string) (AppDataBackup, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return AppDataBackup{}, fmt.Errorf("backup file path is required")
+	}
+	if r.Core.Paths.RootDir == "" {
+		return AppDataBackup{}, fmt.Errorf("app data dir is not configured")
+	}
+
+	manifest, err := stageAppDataRestore(ctx, path, r.Core.Paths.RootDir, r.Persistence.Database)
+	if err != nil {
+		return AppDataBackup{}, err
+	}
+	slog.Info(
+		"app data restore staged for next start",
+		"path", path,
+		"backup_app_version", manifest.AppVersion,
+		"backup_schema_version", manifest.SchemaVersion,
+		"backup_created_at", manifest.CreatedAt,
+	)
+
+	return manifest, nil
+}
+
+// stageAppDataRestore checks the backup at path and moves it into the pending restore
+// dir. An encrypted backup is checked with the key of database, the running one.
+func stageAppDataRestore(ctx context.Context, path, rootDir string, database *Database) (AppDataBackup, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return AppDataBackup{}, fmt.Errorf("open backup archive: %w", err)
+	}
+	defer func() {
+		_ = archive.Close()
+	}()
+
+	entries := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		entries[file.Name] = file
+	}
+	for _, name := range []string{appDataBackupManifestName, ConfigFilename} {
+		if entries[name] == nil {
+			return AppDataBackup{}, fmt.Errorf("not a %s backup: %s is missing", Name, name)
+		}
+	}
+
+	rawManifest, err := readAppDataBackupEntry(entries[appDataBackupManifestName], appDataBackupManifestLimit)
+	if err != nil {
+		return AppDataBackup{}, err
+	}
+	var manifest AppDataBackup
+	if err := json.Unmarshal(rawManifest, &manifest); err != nil {
+		return AppDataBackup{}, fmt.Errorf("decode backup manifest: %w", err)
+	}
+	if err := checkAppDataBackupCompatibility(manifest); err != nil {
+		return AppDataBackup{}, err
+	}
+	dbEntry := entries[manifest.databaseEntryName()]
+	if dbEntry == nil {
+		return AppDataBackup{}, fmt.Errorf("not a %s backup: %s is missing", Name, manifest.databaseEntryName())
+	}
+	if manifest.Encrypted && (database == nil || !database.Encrypted()) {
+		return AppDataBackup{}, fmt.Errorf("%w: turn on database encryption with the key the backup was made with", ErrBackupKeyUnavailable)
+	}
+
+	rawConfig, err := readAppDataBackupEntry(entries[ConfigFilename], appDataBackupConfigLimit)
+	if err != nil {
+		return AppDataBackup{}, err
+	}
+	var cfg config.AppConfig
+	if err := json.Unmarshal(rawConfig, &cfg); err != nil {
+		return AppDataBackup{}, fmt.Errorf("decode backup config: %w", err)
+	}
+
+	stagingDir, err := os.MkdirTemp(rootDir, pendingRestoreDirname+".tmp-*")
+	if err != nil {
+		return AppDataBackup{}, fmt.Errorf("create restore staging dir: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(stagingDir)
+	}()
+
+	stagedDB := filepath.Join(stagingDir, manifest.databaseEntryName())
+	if err := extractAppDataBackupEntry(dbEntry, stagedDB); err != nil {
+		return AppDataBackup{}, err
+	}
+	var schemaVersion int
+	if manifest.Encrypted {
+		schemaVersion, err = inspectEncryptedBackupDatabase(ctx, database, stagedDB)
+	} else {
+		schemaVersion, err = persistence.InspectDatabaseFile(ctx, stagedDB)
+	}
+	if err != nil {
+		return AppDataBackup{}, fmt.Errorf("check backup database: %w", err)
+	}
+	if schemaVersion != manifest.SchemaVersion {
+		return AppDataBackup{}, fmt.Errorf(
+			"backup database has schema version %d, but its manifest says %d",
+			schemaVersion,
+			manifest.SchemaVersion,
+		)
+	}
+	if err := os.WriteFile(filepath.Join(stagingDir, ConfigFilename), rawConfig, 0o600); err != nil {
+		return AppDataBackup{}, fmt.Errorf("stage config: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(stagingDir, appDataBackupManifestName), rawManifest, 0o600); err != nil {
+		return AppDataBackup{}, fmt.Errorf("stage backup manifest: %w", err)
+	}
+
+	pendingDir := filepath.Join(rootDir, pendingRestoreDirname)
+	if err := os.RemoveAll(pendingDir); err != nil {
+		return AppDataBackup{}, fmt.Errorf("remove previously staged restore: %w", err)
+	}
+	if err := os.Rename(stagingDir, pendingDir); err != nil {
+		return AppDataBackup{}, fmt.Errorf("stage restore: %w", err)
+	}
+
+	return manifest, nil
+}
+
+func inspectEncryptedBackupDatabase(ctx context.Context, database *Database, path string) (int, error) {
+	db, err := database.encrypted.OpenSnapshotReadOnly(ctx, path)
+	if errors.Is(err, persistence.ErrDatabaseKeyRejected) {
+		return 0, fmt.Errorf("%w: %v", ErrBackupKeyUnavailable, err)
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	return persistence.SchemaVersion(ctx, db)
+}
+
+// checkAppDataBackupCompatibility rejects archives this build cannot read. Backups with
+// an older database schema are fine: the usual migrations upgrade them on open.
+func checkAppDataBackupCompatibility(manifest AppDataBackup) error {
+	if manifest.FormatVersion != appDataBackupFormatVersion {
+		return fmt.Errorf("%w: unsupported backup format %d", ErrIncompatibleBackup, manifest.FormatVersion)
+	}
+	if latest := persistence.LatestSchemaVersion(); manifest.SchemaVersion > latest {
+		return fmt.Errorf(
+			"%w: it was made by %s %s with database schema %d, this version supports up to %d",
+			ErrIncompatibleBackup,
+			Name,
+			manifest.AppVersion,
+			manifest.SchemaVersion,
+			latest,
+		)
+	}
+
+	return nil
+}
+
+func readAppDataBackupEntry(file *zip.File, limit int64) ([]byte, error) {
+	if file.UncompressedSize64 > uint64(limit) {
+		return nil, fmt.Errorf("backup entry %s is too large", file.Name)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("open backup entry %s: %w", file.Name, err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	raw, err := io.ReadAll(io.LimitReader(reader, limit))
+	if err != nil {
+		return nil, fmt.Errorf("read backup entry %s: %w", file.Name, err)
+	}
+
+	return raw, nil
+}
+
+func extractAppDataBackupEntry(file *zip.File, path string) error {
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("open backup entry %s: %w", file.Name, err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	// #nosec G304 -- path is inside the restore staging dir.
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+	if _, err := io.Copy(out, reader); err != nil {
+		_ = out.Close()
+
+		return fmt.Errorf("extract backup entry %s: %w", file.Name, err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// applyPendingRestore swaps a staged backup in before the database and settings are
+// opened. It returns nil when no restore is pending. A restore is postponed while
+// another process owns the database.
+func applyPendingRestore(paths Paths) (*AppDataBackup, error) {
+	pendingDir := filepath.Join(paths.RootDir, pendingRestoreDirname)
+	rawManifest, err := os.ReadFile(filepath.Join(pendingDir, appDataBackupManifestName))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("read staged restore: %w", err)
+	}
+	var manifest AppDataBackup
+	if err := json.Unmarshal(rawManifest, &manifest); err != nil {
+		return nil, fmt.Errorf("decode staged restore manifest: %w", err)
+	}
+
+	lock, err := platform.AcquireFileLock(filepath.Join(filepath.Dir(paths.DBFile), databaseLockFilename))
+	switch {
+	case errors.Is(err, platform.ErrFileLocked):
+		return nil, ErrDatabaseInUse
+	case errors.Is(err, platform.ErrInstanceLockUnsupported):
+		lock = nil
+	case err != nil:
+		return nil, fmt.Errorf("lock database: %w", err)
+	}
+	if lock != nil {
+		defer func() {
+			_ = lock.Release()
+		}()
+	}
+
+	// Each step is safe to repeat, so a restore interrupted by a crash finishes on the
+	// following start.
+	if manifest.Encrypted {
+		err = restoreEncryptedDatabase(filepath.Join(pendingDir, EncryptedDBFilename), paths)
+	} else {
+		err = restorePlainDatabase(filepath.Join(pendingDir, DBFilename), paths)
+	}
+	if err != nil {
+		return nil, err
+	}
+	stagedConfig := filepath.Join(pendingDir, ConfigFilename)
+	if err := os.Rename(stagedConfig, paths.ConfigFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("restore config: %w", err)
+	}
+	if err := os.RemoveAll(pendingDir); err != nil {
+		return nil, fmt.Errorf("remove staged restore: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+func restorePlainDatabase(stagedDB string, paths Paths) error {
+	if _, err := os.Stat(stagedDB); err == nil {
+		// A leftover WAL would be replayed into the restored file, so it goes first.
+		if err := removePlainDatabaseFiles(paths.DBFile, "-wal", "-shm"); err != nil {
+			return err
+		}
+		if err := os.Rename(stagedDB, paths.DBFile); err != nil {
+			return fmt.Errorf("restore database: %w", err)
+		}
+	}
+	// The next open encrypts the restored plain file again when encryption is enabled.
+	if err := os.Remove(paths.EncryptedDBFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove encrypted database: %w", err)
+	}
+
+	return nil
+}
+
+// restoreEncryptedDatabase puts the encrypted file back without ever writing it out
+// decrypted. The next open decrypts it again when encryption has been turned off.
+func restoreEncryptedDatabase(stagedDB string, paths Paths) error {
+	if _, err := os.Stat(stagedDB); err == nil {
+		if err := os.Rename(stagedDB, paths.EncryptedDBFile); err != nil {
+			return fmt.Errorf("restore database: %w", err)
+		}
+	}
+
+	// A plain file would otherwise be encrypted over the restored one.
+	return removePlainDatabaseFiles(paths.DBFile, "", "-wal", "-shm")
+}
+
+func removePlainDatabaseFiles(path string, suffixes ...string) error {
+	for _, suffix := range suffixes {
+		if err := os.Remove(path + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove database%s file: %w", suffix, err)
+		}
+	}
+
+	return nil
+}
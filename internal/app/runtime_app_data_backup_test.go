@@ -0,0 +1,273 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/persistence"
+)
+
+func testAppDataPaths(t *testing.T) Paths {
+	t.Helper()
+	paths := testDatabasePaths(t)
+	paths.RootDir = filepath.Dir(paths.DBFile)
+	paths.ConfigFile = filepath.Join(paths.RootDir, ConfigFilename)
+
+	return paths
+}
+
+func insertTestMessage(t *testing.T, database *Database, body string) {
+	t.Helper()
+	if _, err := persistence.NewMessageRepo(database.DB).Insert(context.Background(), domain.ChatMessage{
+		ChatKey:   "channel:0",
+		Direction: domain.MessageDirectionIn,
+		Body:      body,
+		At:        time.Now(),
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+}
+
+func countTestMessages(t *testing.T, database *Database) int {
+	t.Helper()
+	var count int
+	if err := database.DB.QueryRow(`SELECT COUNT(*) FROM messages`).Scan(&count); err != nil {
+		t.Fatalf("count messages: %v", err)
+	}
+
+	return count
+}
+
+func TestRuntimeAppDataBackup_RestoresOnNextStart(t *testing.T) {
+	ctx := context.Background()
+	paths := testAppDataPaths(t)
+	secrets := memorySecretStore{}
+
+	database, err := OpenDatabase(ctx, paths, true, secrets)
+	if err != nil {
+		t.Fatalf("open encrypted db: %v", err)
+	}
+	insertTestMessage(t, database, "before backup")
+	cfg := config.Default()
+	cfg.Persistence.EncryptDatabase = true
+	cfg.UI.LastSelectedChat = "channel:0"
+	rt := &Runtime{
+		Core:        RuntimeCore{Paths: paths, Config: cfg},
+		Persistence: RuntimePersistence{Database: database, DB: database.DB},
+	}
+
+	backupPath := filepath.Join(t.TempDir(), AppDataBackupName(time.Now()))
+	manifest, err := rt.BackupAppData(ctx, backupPath)
+	if err != nil {
+		t.Fatalf("backup app data: %v", err)
+	}
+	if manifest.SchemaVersion != persistence.LatestSchemaVersion() {
+		t.Fatalf("expected schema version %d, got %d", persistence.LatestSchemaVersion(), manifest.SchemaVersion)
+	}
+	assertBackupDatabaseEncrypted(t, backupPath)
+
+	insertTestMessage(t, database, "after backup")
+	if _, err := rt.RestoreAppData(ctx, backupPath); err != nil {
+		t.Fatalf("restore app data: %v", err)
+	}
+	if got := countTestMessages(t, database); got != 2 {
+		t.Fatalf("expected the running app to keep its data until restart, got %d messages", got)
+	}
+	if err := database.Close(); err != nil {
+		t.Fatalf("close db: %v", err)
+	}
+
+	restored, err := applyPendingRestore(paths)
+	if err != nil {
+		t.Fatalf("apply pending restore: %v", err)
+	}
+	if restored == nil || restored.CreatedAt.IsZero() {
+		t.Fatalf("expected restored backup manifest, got %+v", restored)
+	}
+	if _, err := os.Stat(filepath.Join(paths.RootDir, pendingRestoreDirname)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected staged restore to be removed, stat err: %v", err)
+	}
+	loaded, err := config.Load(paths.ConfigFile)
+	if err != nil {
+		t.Fatalf("load restored config: %v", err)
+	}
+	if loaded.UI.LastSelectedChat != "channel:0" || !loaded.Persistence.EncryptDatabase {
+		t.Fatalf("unexpected restored config: %+v", loaded.UI)
+	}
+
+	reopened, err := OpenDatabase(ctx, paths, true, secrets)
+	if err != nil {
+		t.Fatalf("reopen restored db: %v", err)
+	}
+	defer func() { _ = reopened.Close() }()
+	if !reopened.Encrypted() {
+		t.Fatalf("expected restored database to be encrypted again")
+	}
+	if got := countTestMessages(t, reopened); got != 1 {
+		t.Fatalf("expected 1 restored message, got %d", got)
+	}
+
+	again, err := applyPendingRestore(paths)
+	if err != nil || again != nil {
+		t.Fatalf("expected no pending restore after applying it, got %+v, %v", again, err)
+	}
+}
+
+// assertBackupDatabaseEncrypted fails unless the archive holds the database only in its
+// encrypted form.
+func assertBackupDatabaseEncrypted(t *testing.T, backupPath string) {
+	t.Helper()
+	archive, err := zip.OpenReader(backupPath)
+	if err != nil {
+		t.Fatalf("open backup archive: %v", err)
+	}
+	defer func() { _ = archive.Close() }()
+
+	var sealed []byte
+	for _, file := range archive.File {
+		if file.Name == DBFilename {
+			t.Fatalf("expected no plain database in the backup of an encrypted database")
+		}
+		if file.Name == EncryptedDBFilename {
+			sealed, err = readAppDataBackupEntry(file, 64<<20)
+			if err != nil {
+				t.Fatalf("read encrypted database entry: %v", err)
+			}
+		}
+	}
+	if len(sealed) == 0 {
+		t.Fatalf("expected an encrypted database entry in the backup")
+	}
+	if bytes.HasPrefix(sealed, []byte("SQLite format 3")) || bytes.Contains(sealed, []byte("before backup")) {
+		t.Fatalf("expected the database entry to be encrypted")
+	}
+}
+
+func TestRuntimeRestoreAppData_RejectsEncryptedBackupWithOtherKey(t *testing.T) {
+	ctx := context.Background()
+	paths := testAppDataPaths(t)
+	database, err := OpenDatabase(ctx, paths, true, memorySecretStore{})
+	if err != nil {
+		t.Fatalf("open encrypted db: %v", err)
+	}
+	defer func() { _ = database.Close() }()
+	insertTestMessage(t, database, "before backup")
+	rt := &Runtime{
+		Core:        RuntimeCore{Paths: paths, Config: config.Default()},
+		Persistence: RuntimePersistence{Database: database, DB: database.DB},
+	}
+	backupPath := filepath.Join(t.TempDir(), "backup.zip")
+	if _, err := rt.BackupAppData(ctx, backupPath); err != nil {
+		t.Fatalf("backup app data: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		encrypt bool
+	}{
+		{name: "plain database"},
+		{name: "database with another key", encrypt: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			otherPaths := testAppDataPaths(t)
+			other, err := OpenDatabase(ctx, otherPaths, tt.encrypt, memorySecretStore{})
+			if err != nil {
+				t.Fatalf("open other db: %v", err)
+			}
+			defer func() { _ = other.Close() }()
+			otherRT := &Runtime{
+				Core:        RuntimeCore{Paths: otherPaths},
+				Persistence: RuntimePersistence{Database: other, DB: other.DB},
+			}
+
+			if _, err := otherRT.RestoreAppData(ctx, backupPath); !errors.Is(err, ErrBackupKeyUnavailable) {
+				t.Fatalf("expected the missing key to be reported, got %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(otherPaths.RootDir, pendingRestoreDirname)); !errors.Is(err, os.ErrNotExist) {
+				t.Fatalf("expected nothing to be staged, stat err: %v", err)
+			}
+		})
+	}
+}
+
+func TestRuntimeRestoreAppData_RejectsIncompatibleBackups(t *testing.T) {
+	ctx := context.Background()
+	paths := testAppDataPaths(t)
+	database, err := OpenDatabase(ctx, paths, false, memorySecretStore{})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = database.Close() }()
+	snapshotPath := filepath.Join(t.TempDir(), DBFilename)
+	if err := persistence.SnapshotDatabase(ctx, database.DB, snapshotPath); err != nil {
+		t.Fatalf("snapshot db: %v", err)
+	}
+	rt := &Runtime{Core: RuntimeCore{Paths: paths}}
+	valid := AppDataBackup{
+		FormatVersion: appDataBackupFormatVersion,
+		AppVersion:    "v9.9.9",
+		SchemaVersion: persistence.LatestSchemaVersion(),
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	tests := []struct {
+		name         string
+		manifest     func(AppDataBackup) AppDataBackup
+		incompatible bool
+	}{
+		{
+			name: "newer schema",
+			manifest: func(m AppDataBackup) AppDataBackup {
+				m.SchemaVersion++
+
+				return m
+			},
+			incompatible: true,
+		},
+		{
+			name: "unknown format",
+			manifest: func(m AppDataBackup) AppDataBackup {
+				m.FormatVersion++
+
+				return m
+			},
+			incompatible: true,
+		},
+		{
+			name: "manifest does not match database",
+			manifest: func(m AppDataBackup) AppDataBackup {
+				m.SchemaVersion--
+
+				return m
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backupPath := filepath.Join(t.TempDir(), "backup.zip")
+			if err := writeAppDataBackup(backupPath, tt.manifest(valid), snapshotPath, []byte(`{}`)); err != nil {
+				t.Fatalf("write backup: %v", err)
+			}
+
+			_, err := rt.RestoreAppData(ctx, backupPath)
+			if err == nil {
+				t.Fatalf("expected restore to be rejected")
+			}
+			if got := errors.Is(err, ErrIncompatibleBackup); got != tt.incompatible {
+				t.Fatalf("expected incompatible=%v, got error %v", tt.incompatible, err)
+			}
+			if _, err := os.Stat(filepath.Join(paths.RootDir, pendingRestoreDirname)); !errors.Is(err, os.ErrNotExist) {
+				t.Fatalf("expected nothing to be staged, stat err: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/chatexport"
+)
+
+// ExportChats writes the history of the selected chats to w. Unlike other
+// database calls it has no fixed timeout: large exports are bounded by ctx.
+func (r *Runtime) ExportChats(ctx context.Context, w io.Writer, opts chatexport.Options) error {
+	if r.Persistence.MessageRepo == nil {
+		return fmt.Errorf("database is not initialized")
+	}
+
+	started := time.Now()
+	exported := 0
+	progress := opts.Progress
+	opts.Progress = func(done, total int) {
+		exported = done
+		if progress != nil {
+			progress(done, total)
+		}
+	}
+	if err := chatexport.Export(ctx, w, r.Persistence.MessageRepo, opts); err != nil {
+		return fmt.Errorf("export chats: %w", err)
+	}
+
+	slog.Info(
+		"chat history exported",
+		"format", opts.Format,
+		"chats", len(opts.Chats),
+		"messages", exported,
+		"duration", time.Since(started),
+	)
+
+	return nil
+}
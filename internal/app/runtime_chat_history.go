@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+const (
+	// chatSearchResultLimit caps how many matches one in-chat search returns.
+	chatSearchResultLimit = 500
+	// chatHistoryPageSize is how many older messages one scroll to the top loads.
+	chatHistoryPageSize = 100
+)
+
+// SearchChatMessages returns the newest stored messages of a chat whose text contains
+// the query, oldest first. It searches the whole history, not only the loaded messages.
+func (r *Runtime) SearchChatMessages(chatKey, query string) ([]domain.ChatMessage, error) {
+	chatKey = strings.TrimSpace(chatKey)
+	if chatKey == "" {
+		return nil, fmt.Errorf("chat key is required")
+	}
+	if r.Persistence.MessageRepo == nil {
+		return nil, fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	matches, err := r.Persistence.MessageRepo.SearchByChat(ctx, chatKey, query, chatSearchResultLimit)
+	if err != nil {
+		return nil, fmt.Errorf("search chat messages: %w", err)
+	}
+
+	return matches, nil
+}
+
+// LoadOlderChatMessages adds the page of history before the loaded messages of a chat
+// to the chat store. It returns how many messages were added; zero means the whole
+// history is loaded.
+func (r *Runtime) LoadOlderChatMessages(chatKey string) (int, error) {
+	chatKey = strings.TrimSpace(chatKey)
+	if chatKey == "" {
+		return 0, fmt.Errorf("chat key is required")
+	}
+	if r.Persistence.MessageRepo == nil || r.Domain.ChatStore == nil {
+		return 0, fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	added, err := domain.LoadOlderChatMessages(ctx, r.Domain.ChatStore, r.Persistence.MessageRepo, chatKey, chatHistoryPageSize)
+	if err != nil {
+		return 0, err
+	}
+	slog.Debug("older chat messages loaded", "chat_key", chatKey, "count", added)
+
+	return added, nil
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// DeleteChatMessage removes a message from this desktop app only; the mesh is not told.
+// A message still waiting in the outbox is dropped from it, so it is never sent.
+func (r *Runtime) DeleteChatMessage(message domain.ChatMessage) error {
+	if strings.TrimSpace(message.ChatKey) == "" {
+		return fmt.Errorf("chat key is required")
+	}
+	if r.Persistence.MessageRepo == nil {
+		return fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if outboxID, ok := domain.ParseQueuedMessageID(message.DeviceMessageID); ok && r.Persistence.OutboxRepo != nil {
+		if err := r.Persistence.OutboxRepo.Delete(ctx, outboxID); err != nil {
+			return fmt.Errorf("delete queued message: %w", err)
+		}
+	}
+	if err := r.Persistence.MessageRepo.Delete(ctx, message); err != nil {
+		return fmt.Errorf("delete chat message: %w", err)
+	}
+	if r.Domain.ChatStore != nil {
+		r.Domain.ChatStore.DeleteMessage(message)
+	}
+	slog.Info("chat message deleted", "trigger", "user_action", "chat_key", message.ChatKey, "device_message_id", message.DeviceMessageID)
+
+	return nil
+}
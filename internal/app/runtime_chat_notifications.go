@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// SetChatNotifications stores per-chat mute and mention preferences.
+func (r *Runtime) SetChatNotifications(chatKey string, prefs domain.ChatNotificationPrefs) error {
+	chatKey = strings.TrimSpace(chatKey)
+	if chatKey == "" {
+		return fmt.Errorf("chat key is required")
+	}
+	if r.Persistence.ChatRepo == nil {
+		return fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := r.Persistence.ChatRepo.SetNotifications(ctx, chatKey, prefs); err != nil {
+		return err
+	}
+	if r.Domain.ChatStore != nil {
+		r.Domain.ChatStore.SetChatNotifications(chatKey, prefs)
+	}
+
+	slog.Debug(
+		"chat notification preferences saved",
+		"chat_key", chatKey,
+		"muted", prefs.Muted,
+		"muted_until", prefs.MutedUntil,
+		"mentions_only", prefs.MentionsOnly,
+	)
+
+	return nil
+}
+
+// MarkChatRead records that incoming messages of the chat up to at were seen. The
+// watermark is written through the writer queue, so marking rows read as they scroll by
+// does not wait for the database.
+func (r *Runtime) MarkChatRead(chatKey string, at time.Time) {
+	chatKey = strings.TrimSpace(chatKey)
+	if chatKey == "" || at.IsZero() {
+		return
+	}
+	if r.Domain.ChatStore != nil && !r.Domain.ChatStore.MarkChatRead(chatKey, at) {
+		return
+	}
+	if r.Persistence.WriterQueue == nil || r.Persistence.ChatRepo == nil {
+		return
+	}
+	repo := r.Persistence.ChatRepo
+	// Only the newest watermark matters, so a queued older one is dropped.
+	r.Persistence.WriterQueue.EnqueueCoalesced("mark_chat_read", "chat_read:"+chatKey, func(ctx context.Context) error {
+		return repo.SetReadUpTo(ctx, chatKey, at)
+	})
+}
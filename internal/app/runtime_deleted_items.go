@@ -0,0 +1,125 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// DeleteNode soft-deletes a node. It stays hidden until restored, purged,
+// or heard from again.
+func (r *Runtime) DeleteNode(nodeID string) error {
+	nodeID = domain.NormalizeNodeID(nodeID)
+	if nodeID == "" {
+		return fmt.Errorf("node id is required")
+	}
+	if r.Persistence.DeletedItemsRepo == nil {
+		return fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	deletedAt := time.Now()
+	if err := r.Persistence.DeletedItemsRepo.DeleteNode(ctx, nodeID, deletedAt); err != nil {
+		return fmt.Errorf("delete node: %w", err)
+	}
+	if r.Domain.NodeStore != nil {
+		r.Domain.NodeStore.Hide(nodeID, deletedAt)
+	}
+
+	slog.Info("node deleted", "trigger", "user_action", "node_id", nodeID)
+
+	return nil
+}
+
+// ListRecentlyDeleted returns soft-deleted chats and nodes that can still be restored.
+func (r *Runtime) ListRecentlyDeleted() ([]domain.DeletedItem, error) {
+	if r.Persistence.DeletedItemsRepo == nil {
+		return nil, fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return r.Persistence.DeletedItemsRepo.ListDeleted(ctx)
+}
+
+// RestoreDeletedItem undoes a soft delete and puts the item back into the in-memory stores.
+func (r *Runtime) RestoreDeletedItem(item domain.DeletedItem) error {
+	key := strings.TrimSpace(item.Key)
+	if key == "" {
+		return fmt.Errorf("deleted item key is required")
+	}
+	if r.Persistence.DeletedItemsRepo == nil {
+		return fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	switch item.Kind {
+	case domain.DeletedItemChat:
+		if err := r.Persistence.DeletedItemsRepo.RestoreChat(ctx, key); err != nil {
+			return fmt.Errorf("restore chat: %w", err)
+		}
+		if r.Domain.ChatStore != nil {
+			if err := domain.RestoreChatFromRepositories(ctx, r.Domain.ChatStore, r.Persistence.ChatRepo, r.Persistence.MessageRepo, key); err != nil {
+				return fmt.Errorf("reload restored chat: %w", err)
+			}
+		}
+	case domain.DeletedItemNode:
+		if err := r.Persistence.DeletedItemsRepo.RestoreNode(ctx, key); err != nil {
+			return fmt.Errorf("restore node: %w", err)
+		}
+		if r.Domain.NodeStore != nil {
+			if err := domain.RestoreNodeFromRepositories(
+				ctx,
+				r.Domain.NodeStore,
+				r.Persistence.NodeCoreRepo,
+				r.Persistence.NodePositionRepo,
+				r.Persistence.NodeTelemetryRepo,
+				key,
+			); err != nil {
+				return fmt.Errorf("reload restored node: %w", err)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown deleted item kind: %q", item.Kind)
+	}
+
+	slog.Info("deleted item restored", "trigger", "user_action", "kind", item.Kind, "key", key)
+
+	return nil
+}
+
+// hideDeletedNodes keeps soft-deleted nodes hidden when the radio reports them again
+// without new activity (for example in the node DB sent on connect).
+func (r *Runtime) hideDeletedNodes(ctx context.Context, nodeStore *domain.NodeStore) {
+	deleted, err := r.Persistence.DeletedItemsRepo.ListDeletedNodeIDs(ctx)
+	if err != nil {
+		slog.Warn("load deleted nodes", "error", err)
+
+		return
+	}
+	for nodeID, deletedAt := range deleted {
+		nodeStore.Hide(nodeID, deletedAt)
+	}
+}
+
+func (r *Runtime) purgeExpiredDeletedItems(ctx context.Context, retentionDays int) {
+	cutoff := time.Now().AddDate(0, 0, -retentionDays)
+	purged, err := r.Persistence.DeletedItemsRepo.PurgeDeletedBefore(ctx, cutoff)
+	if err != nil {
+		slog.Warn("purge expired deleted items", "retention_days", retentionDays, "error", err)
+
+		return
+	}
+	if purged > 0 {
+		slog.Info("purged expired deleted items", "count", purged, "retention_days", retentionDays)
+	}
+}
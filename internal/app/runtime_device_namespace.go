@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/persistence"
+)
+
+const deviceNamespaceSwitchTimeout = 10 * time.Second
+
+// openDeviceScope selects the namespace of the radio connected last, so its history is
+// shown before the radio reports itself again.
+func openDeviceScope(ctx context.Context, namespaces *persistence.DeviceNamespaceRepo) *persistence.DeviceScope {
+	deviceID, ok, err := namespaces.LastConnected(ctx)
+	if err != nil {
+		slog.Warn("read last connected device", "error", err)
+	}
+	if ok {
+		slog.Info("device namespace selected", "device_id", deviceID, "trigger", "startup")
+	}
+
+	return persistence.NewDeviceScope(deviceID)
+}
+
+// useDeviceScope points every namespaced repository at scope.
+func (p *RuntimePersistence) useDeviceScope(scope *persistence.DeviceScope) {
+	p.DeviceScope = scope
+	p.NodeCoreRepo.UseDeviceScope(scope)
+	p.NodePositionRepo.UseDeviceScope(scope)
+	p.NodeTelemetryRepo.UseDeviceScope(scope)
+	p.NodeIdentityHistory.UseDeviceScope(scope)
+	p.NodeSignalHistory.UseDeviceScope(scope)
+	p.ChatRepo.UseDeviceScope(scope)
+	p.MessageRepo.UseDeviceScope(scope)
+	p.TracerouteRepo.UseDeviceScope(scope)
+	p.OutboxRepo.UseDeviceScope(scope)
+	p.DeletedItemsRepo.UseDeviceScope(scope)
+	p.MessageAnnotations.UseDeviceScope(scope)
+	p.MessagePins.UseDeviceScope(scope)
+	p.Statistics.UseDeviceScope(scope)
+}
+
+// switchDeviceNamespace records the connected radio and, when it differs from the one
+// whose data is loaded, switches repositories to its namespace and reloads the stores.
+// It runs on the radio reader loop before the radio's own data is published.
+func (r *Runtime) switchDeviceNamespace(deviceID string) {
+	deviceID = strings.TrimSpace(deviceID)
+	if deviceID == "" || r.Persistence.DeviceNamespaces == nil || r.Persistence.DeviceScope == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Ctx, deviceNamespaceSwitchTimeout)
+	defer cancel()
+
+	adopted, err := r.Persistence.DeviceNamespaces.Activate(ctx, deviceID, time.Now())
+	if err != nil {
+		slog.Warn("record device namespace", "device_id", deviceID, "error", err)
+	}
+	previous := r.Persistence.DeviceScope.DeviceID()
+	if previous == deviceID {
+		return
+	}
+
+	r.Persistence.DeviceScope.Set(deviceID)
+	r.resetInMemoryStores()
+	if err := r.reloadStores(ctx); err != nil {
+		slog.Warn("reload stores for device namespace", "device_id", deviceID, "error", err)
+	}
+	if r.Domain.NodeDiscovery != nil {
+		r.Domain.NodeDiscovery.ResetFromStore(r.Domain.NodeStore)
+	}
+	if r.Domain.ChatTitles != nil {
+		r.Domain.ChatTitles.BackfillFromStore(r.Domain.NodeStore)
+	}
+	slog.Info(
+		"device namespace switched",
+		"device_id", deviceID,
+		"previous_device_id", previous,
+		"adopted_legacy_data", adopted,
+	)
+}
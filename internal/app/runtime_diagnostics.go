@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/persistence"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+// processStartedAt approximates the process start for the app uptime.
+var processStartedAt = time.Now()
+
+// RuntimeDiagnostics is a snapshot of the app's resource use and connection.
+type RuntimeDiagnostics struct {
+	At                time.Time
+	StartedAt         time.Time
+	DatabaseBytes     int64
+	DatabaseEncrypted bool
+	Nodes             int
+	Chats             int
+	Messages          int
+	// HeapBytes is the memory held by live objects and SystemBytes all memory taken
+	// from the OS by the Go runtime.
+	HeapBytes   uint64
+	SystemBytes uint64
+	GCCycles    uint32
+	Goroutines  int
+	Connection  busmsg.ConnectionStatus
+	// ConnectedSince is zero while not connected.
+	ConnectedSince time.Time
+	Writer         persistence.WriterQueueStats
+}
+
+// ConnectionUptime returns how long the current connection has lasted, or zero when
+// not connected.
+func (d RuntimeDiagnostics) ConnectionUptime() time.Duration {
+	if d.ConnectedSince.IsZero() {
+		return 0
+	}
+
+	return d.At.Sub(d.ConnectedSince)
+}
+
+// Diagnostics collects the current diagnostics. Values that can't be read stay zero and
+// their errors are returned together with the rest of the snapshot.
+func (r *Runtime) Diagnostics(ctx context.Context) (RuntimeDiagnostics, error) {
+	diag := RuntimeDiagnostics{At: time.Now(), StartedAt: processStartedAt}
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	diag.HeapBytes = mem.HeapAlloc
+	diag.SystemBytes = mem.Sys
+	diag.GCCycles = mem.NumGC
+	diag.Goroutines = runtime.NumGoroutine()
+
+	r.connStatusMu.RLock()
+	diag.Connection = r.connStatus
+	diag.ConnectedSince = r.connectedSince
+	r.connStatusMu.RUnlock()
+
+	if r.Domain.NodeStore != nil {
+		diag.Nodes = r.Domain.NodeStore.Len()
+	}
+	if r.Domain.ChatStore != nil {
+		diag.Chats = len(r.Domain.ChatStore.ChatListSorted())
+	}
+	if r.Persistence.WriterQueue != nil {
+		diag.Writer = r.Persistence.WriterQueue.Stats()
+	}
+	if r.Persistence.Database != nil {
+		diag.DatabaseEncrypted = r.Persistence.Database.Encrypted()
+	}
+
+	var errs []error
+	if r.Persistence.DB != nil {
+		size, err := persistence.DatabaseSize(ctx, r.Persistence.DB)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("database size: %w", err))
+		}
+		diag.DatabaseBytes = size
+	}
+	if r.Persistence.MessageRepo != nil {
+		count, err := r.Persistence.MessageRepo.Count(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		diag.Messages = count
+	}
+
+	return diag, errors.Join(errs...)
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/persistence"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+func TestRuntimeDiagnosticsCollectsCountsAndSizes(t *testing.T) {
+	ctx := context.Background()
+	db, err := persistence.Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	messages := persistence.NewMessageRepo(db)
+	if _, err := messages.Insert(ctx, domain.ChatMessage{
+		ChatKey:   domain.ChatKeyForChannel(0),
+		Direction: domain.MessageDirectionIn,
+		Body:      "hello",
+		Status:    domain.MessageStatusSent,
+		At:        time.Now(),
+	}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+	nodeStore := domain.NewNodeStore()
+	nodeStore.Upsert(domain.Node{NodeID: "!00000001"})
+	nodeStore.Upsert(domain.Node{NodeID: "!00000002"})
+
+	rt := &Runtime{
+		Persistence: RuntimePersistence{DB: db, MessageRepo: messages},
+		Domain:      RuntimeDomain{NodeStore: nodeStore, ChatStore: domain.NewChatStore()},
+	}
+	diag, err := rt.Diagnostics(ctx)
+	if err != nil {
+		t.Fatalf("diagnostics: %v", err)
+	}
+	if diag.Nodes != 2 || diag.Messages != 1 {
+		t.Fatalf("expected 2 nodes and 1 message, got %d and %d", diag.Nodes, diag.Messages)
+	}
+	if diag.DatabaseBytes <= 0 || diag.HeapBytes == 0 || diag.Goroutines == 0 {
+		t.Fatalf("expected database and memory sizes, got %+v", diag)
+	}
+	if diag.ConnectionUptime() != 0 {
+		t.Fatalf("expected no connection uptime while disconnected, got %v", diag.ConnectionUptime())
+	}
+}
+
+func TestRuntimeDiagnosticsTracksConnectionUptime(t *testing.T) {
+	rt := &Runtime{}
+	rt.setConnStatus(busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnecting})
+	rt.setConnStatus(busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnected})
+	diag, err := rt.Diagnostics(context.Background())
+	if err != nil {
+		t.Fatalf("diagnostics: %v", err)
+	}
+	since := diag.ConnectedSince
+	if since.IsZero() {
+		t.Fatalf("expected the connection start to be recorded")
+	}
+
+	rt.setConnStatus(busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnected})
+	diag, _ = rt.Diagnostics(context.Background())
+	if !diag.ConnectedSince.Equal(since) {
+		t.Fatalf("expected a repeated connected status to keep the start: expected %v, got %v", since, diag.ConnectedSince)
+	}
+
+	rt.setConnStatus(busmsg.ConnectionStatus{State: busmsg.ConnectionStateDisconnected})
+	diag, _ = rt.Diagnostics(context.Background())
+	if !diag.ConnectedSince.IsZero() {
+		t.Fatalf("expected the connection start to reset on disconnect, got %v", diag.ConnectedSince)
+	}
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/historyimport"
+)
+
+// ImportHistory merges messages and nodes exported by another Meshtastic client
+// into the database and reloads the in-memory stores. Like ExportChats it is
+// bounded by ctx rather than a fixed timeout.
+func (r *Runtime) ImportHistory(ctx context.Context, path string) (historyimport.Report, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return historyimport.Report{}, fmt.Errorf("import file path is required")
+	}
+	if r.Persistence.MessageRepo == nil {
+		return historyimport.Report{}, fmt.Errorf("database is not initialized")
+	}
+
+	started := time.Now()
+	snapshot, err := historyimport.Read(ctx, path)
+	if err != nil {
+		return historyimport.Report{}, err
+	}
+	report, err := historyimport.Merge(ctx, snapshot, historyImportTarget{
+		persistence: r.Persistence,
+		limits:      newHistoryLimitsProvider(r.CurrentConfig),
+	})
+	if err != nil {
+		return report, fmt.Errorf("import history: %w", err)
+	}
+	if err := r.reloadStores(ctx); err != nil {
+		return report, fmt.Errorf("reload imported history: %w", err)
+	}
+
+	slog.Info(
+		"history imported",
+		"source", report.Source,
+		"messages_read", report.MessagesRead,
+		"messages_imported", report.MessagesImported,
+		"chats_updated", report.ChatsUpdated,
+		"nodes_read", report.NodesRead,
+		"nodes_imported", report.NodesImported,
+		"duration", time.Since(started),
+	)
+
+	return report, nil
+}
+
+func (r *Runtime) reloadStores(ctx context.Context) error {
+	if r.Domain.NodeStore == nil || r.Domain.ChatStore == nil {
+		return nil
+	}
+	if err := domain.LoadStoresFromRepositories(
+		ctx,
+		r.Domain.NodeStore,
+		r.Domain.ChatStore,
+		r.Persistence.NodeCoreRepo,
+		r.Persistence.NodePositionRepo,
+		r.Persistence.NodeTelemetryRepo,
+		r.Persistence.ChatRepo,
+		r.Persistence.MessageRepo,
+	); err != nil {
+		return err
+	}
+	r.hideDeletedNodes(ctx, r.Domain.NodeStore)
+
+	return nil
+}
+
+// historyImportTarget writes imported data through the regular repositories.
+type historyImportTarget struct {
+	persistence RuntimePersistence
+	limits      historyLimitsProvider
+}
+
+func (t historyImportTarget) InsertMessage(ctx context.Context, message domain.ChatMessage) (bool, error) {
+	// Messages without a packet id cannot hit the unique index, so compare their content instead.
+	if message.DeviceMessageID == "" {
+		exists, err := t.persistence.MessageRepo.ExistsByContent(ctx, message)
+		if err != nil || exists {
+			return false, err
+		}
+	}
+	id, err := t.persistence.MessageRepo.Insert(ctx, message)
+	if err != nil {
+		return false, err
+	}
+
+	return id != 0, nil
+}
+
+func (t historyImportTarget) UpsertChat(ctx context.Context, chat domain.Chat) error {
+	return t.persistence.ChatRepo.Upsert(ctx, chat)
+}
+
+func (t historyImportTarget) NodeLastHeard(ctx context.Context, nodeID string) (time.Time, bool, error) {
+	core, found, err := t.persistence.NodeCoreRepo.GetByNodeID(ctx, nodeID)
+	if err != nil || !found {
+		return time.Time{}, false, err
+	}
+
+	return core.LastHeardAt, true, nil
+}
+
+func (t historyImportTarget) UpsertNode(ctx context.Context, node historyimport.Node) error {
+	if err := t.persistence.NodeCoreRepo.Upsert(ctx, domain.NodeCoreUpdate{
+		Core: node.Core,
+		Type: domain.NodeUpdateTypeNodeInfoSnapshot,
+	}, t.limits.IdentityHistoryLimit()); err != nil {
+		return err
+	}
+	if node.Position == nil {
+		return nil
+	}
+
+	return t.persistence.NodePositionRepo.Upsert(ctx, domain.NodePositionUpdate{
+		Position: *node.Position,
+		Type:     domain.NodeUpdateTypePositionPacket,
+	}, t.limits.PositionHistoryLimit())
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// SaveMessageAnnotation stores local stars and tags for a message. An annotation
+// without marks removes the stored one.
+func (r *Runtime) SaveMessageAnnotation(annotation domain.MessageAnnotation) error {
+	if r.Persistence.MessageAnnotations == nil {
+		return fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	annotation.UpdatedAt = time.Now()
+	if err := r.Persistence.MessageAnnotations.Save(ctx, annotation); err != nil {
+		return fmt.Errorf("save message annotation: %w", err)
+	}
+
+	slog.Debug(
+		"message annotation saved",
+		"chat_key", annotation.ChatKey,
+		"device_message_id", annotation.DeviceMessageID,
+		"starred", annotation.Starred,
+		"tags", len(annotation.Tags),
+	)
+
+	return nil
+}
+
+// ListMessageAnnotations returns all stored message annotations.
+func (r *Runtime) ListMessageAnnotations() ([]domain.MessageAnnotation, error) {
+	if r.Persistence.MessageAnnotations == nil {
+		return nil, fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return r.Persistence.MessageAnnotations.ListAll(ctx)
+}
+
+// ListAnnotatedMessages returns starred or tagged messages across all chats, newest first.
+func (r *Runtime) ListAnnotatedMessages() ([]domain.AnnotatedMessage, error) {
+	if r.Persistence.MessageAnnotations == nil {
+		return nil, fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return r.Persistence.MessageAnnotations.ListAnnotatedMessages(ctx)
+}
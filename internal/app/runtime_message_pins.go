@@ -0,0 +1,57 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// PinMessage pins a message to the top of its chat.
+func (r *Runtime) PinMessage(chatKey, deviceMessageID string) error {
+	if r.Persistence.MessagePins == nil {
+		return fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pin := domain.MessagePin{ChatKey: chatKey, DeviceMessageID: deviceMessageID, PinnedAt: time.Now()}
+	if err := r.Persistence.MessagePins.Pin(ctx, pin); err != nil {
+		return fmt.Errorf("pin message: %w", err)
+	}
+	slog.Debug("message pinned", "chat_key", chatKey, "device_message_id", deviceMessageID)
+
+	return nil
+}
+
+// UnpinMessage removes a message pin.
+func (r *Runtime) UnpinMessage(chatKey, deviceMessageID string) error {
+	if r.Persistence.MessagePins == nil {
+		return fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := r.Persistence.MessagePins.Unpin(ctx, chatKey, deviceMessageID); err != nil {
+		return fmt.Errorf("unpin message: %w", err)
+	}
+	slog.Debug("message unpinned", "chat_key", chatKey, "device_message_id", deviceMessageID)
+
+	return nil
+}
+
+// ListMessagePins returns all stored message pins, oldest first.
+func (r *Runtime) ListMessagePins() ([]domain.MessagePin, error) {
+	if r.Persistence.MessagePins == nil {
+		return nil, fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return r.Persistence.MessagePins.ListAll(ctx)
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// SetNodeNotes stores the local alias and note of a node.
+func (r *Runtime) SetNodeNotes(nodeID, alias, note string) error {
+	nodeID = strings.TrimSpace(nodeID)
+	if nodeID == "" {
+		return fmt.Errorf("node id is required")
+	}
+	alias, note, err := domain.NormalizeNodeNotes(alias, note)
+	if err != nil {
+		return err
+	}
+	if r.Persistence.NodeCoreRepo == nil {
+		return fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := r.Persistence.NodeCoreRepo.SetLocalNotes(ctx, nodeID, alias, note); err != nil {
+		return err
+	}
+	if r.Domain.NodeStore != nil {
+		r.Domain.NodeStore.SetNotes(nodeID, alias, note)
+	}
+
+	slog.Debug("node notes saved", "node_id", nodeID, "has_alias", alias != "", "has_note", note != "")
+
+	return nil
+}
+
+// SetNodeTags stores the local tags of a node.
+func (r *Runtime) SetNodeTags(nodeID string, tags []string) error {
+	nodeID = strings.TrimSpace(nodeID)
+	if nodeID == "" {
+		return fmt.Errorf("node id is required")
+	}
+	tags, err := domain.NormalizeNodeTags(tags)
+	if err != nil {
+		return err
+	}
+	if r.Persistence.NodeCoreRepo == nil {
+		return fmt.Errorf("database is not initialized")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := r.Persistence.NodeCoreRepo.SetLocalTags(ctx, nodeID, tags); err != nil {
+		return err
+	}
+	if r.Domain.NodeStore != nil {
+		r.Domain.NodeStore.SetTags(nodeID, tags)
+	}
+
+	slog.Debug("node tags saved", "node_id", nodeID, "tags", len(tags))
+
+	return nil
+}
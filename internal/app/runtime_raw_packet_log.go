@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/config"
+)
+
+type rawPacketLogSettings struct {
+	currentConfig func() config.AppConfig
+}
+
+func newRawPacketLogSettings(currentConfig func() config.AppConfig) rawPacketLogSettings {
+	return rawPacketLogSettings{currentConfig: currentConfig}
+}
+
+func (s rawPacketLogSettings) RawPacketLogEnabled() bool {
+	return s.currentConfig != nil && s.currentConfig().Logging.RawPacketLog.Enabled
+}
+
+func (s rawPacketLogSettings) RawPacketLogMaxBytes() int64 {
+	if s.currentConfig == nil {
+		return int64(config.DefaultRawPacketLogMaxSizeMB) * 1024 * 1024
+	}
+
+	return s.currentConfig().Logging.RawPacketLog.MaxBytes()
+}
+
+// rawPacketLogLine is one exported frame.
+type rawPacketLogLine struct {
+	At        string `json:"at"`
+	Direction string `json:"direction"`
+	Len       int    `json:"len"`
+	Hex       string `json:"hex"`
+}
+
+// ExportRawPacketLog writes the logged raw frames to w as JSON lines, oldest first, and
+// returns how many frames were written.
+func (r *Runtime) ExportRawPacketLog(ctx context.Context, w io.Writer) (int, error) {
+	if r.Persistence.RawPacketLog == nil {
+		return 0, fmt.Errorf("database is not initialized")
+	}
+
+	entries, err := r.Persistence.RawPacketLog.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("export raw packet log: %w", err)
+	}
+	encoder := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := encoder.Encode(rawPacketLogLine{
+			At:        entry.LoggedAt.UTC().Format(time.RFC3339Nano),
+			Direction: string(entry.Direction),
+			Len:       len(entry.Payload),
+			Hex:       strings.ToUpper(hex.EncodeToString(entry.Payload)),
+		}); err != nil {
+			return 0, fmt.Errorf("write raw packet log: %w", err)
+		}
+	}
+	slog.Info("raw packet log exported", "frames", len(entries))
+
+	return len(entries), nil
+}
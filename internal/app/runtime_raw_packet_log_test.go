@@ -0,0 +1,61 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/persistence"
+)
+
+func TestExportRawPacketLogWritesJSONLines(t *testing.T) {
+	ctx := context.Background()
+	db, err := persistence.Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := persistence.NewRawPacketLogRepo(db)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, entry := range []domain.RawPacketLogEntry{
+		{Direction: domain.RawPacketToRadio, Payload: []byte{0x1a, 0x02}, LoggedAt: at},
+		{Direction: domain.RawPacketFromRadio, Payload: []byte{0xff}, LoggedAt: at.Add(time.Second)},
+	} {
+		if err := repo.Append(ctx, entry, 0); err != nil {
+			t.Fatalf("append frame: %v", err)
+		}
+	}
+
+	rt := &Runtime{Persistence: RuntimePersistence{RawPacketLog: repo}}
+	var out bytes.Buffer
+	count, err := rt.ExportRawPacketLog(ctx, &out)
+	if err != nil {
+		t.Fatalf("export raw packet log: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 exported frames, got %d", count)
+	}
+	want := `{"at":"2026-03-01T12:00:00Z","direction":"to_radio","len":2,"hex":"1A02"}` + "\n" +
+		`{"at":"2026-03-01T12:00:01Z","direction":"from_radio","len":1,"hex":"FF"}` + "\n"
+	if out.String() != want {
+		t.Fatalf("unexpected export:\n%s", out.String())
+	}
+}
+
+func TestRawPacketLogSettingsFollowCurrentConfig(t *testing.T) {
+	cfg := config.Default()
+	settings := newRawPacketLogSettings(func() config.AppConfig { return cfg })
+	if settings.RawPacketLogEnabled() {
+		t.Fatalf("expected raw packet log to be disabled by default")
+	}
+	cfg.Logging.RawPacketLog.Enabled = true
+	cfg.Logging.RawPacketLog.MaxSizeMB = 2
+	if !settings.RawPacketLogEnabled() || settings.RawPacketLogMaxBytes() != 2*1024*1024 {
+		t.Fatalf("expected settings to follow config changes")
+	}
+}
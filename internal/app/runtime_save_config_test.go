@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/skobkin/meshgo/internal/domain"
 	"github.com/skobkin/meshgo/internal/logging"
 	"github.com/skobkin/meshgo/internal/persistence"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
 )
 
 func TestRuntimeSaveAndApplyConfig_TransportSwitchResetsInMemoryStores(t *testing.T) {
@@ -84,6 +86,216 @@ func TestRuntimeSaveAndApplyConfig_ChangedConnectionReappliesTransport(t *testin
 	}
 }
 
+func TestRuntimeSetChatTaskbarFlash_SurvivesSettingsSave(t *testing.T) {
+	rt := newRuntimeForSaveConfigTests(t)
+	stale := rt.Core.Config
+
+	if err := rt.SetChatTaskbarFlash("channel:0", true); err != nil {
+		t.Fatalf("enable channel taskbar flash: %v", err)
+	}
+	if err := rt.SetChatTaskbarFlash("dm:!0000002a", true); err != nil {
+		t.Fatalf("keep dm taskbar flash default: %v", err)
+	}
+
+	stale.UI.TaskbarFlash.Enabled = true
+	if err := rt.SaveAndApplyConfig(stale); err != nil {
+		t.Fatalf("save and apply config: %v", err)
+	}
+
+	loaded, err := config.Load(rt.Core.Paths.ConfigFile)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	flash := loaded.UI.TaskbarFlash
+	if !flash.Enabled || !flash.FlashesChat("channel:0", false) {
+		t.Fatalf("expected the chat override to survive a settings save, got %+v", flash)
+	}
+	if _, ok := flash.Chats["dm:!0000002a"]; ok {
+		t.Fatalf("expected no override for a chat left at its default, got %+v", flash.Chats)
+	}
+}
+
+func TestRuntimeSetNodeNotificationsMuted_SurvivesSettingsSave(t *testing.T) {
+	rt := newRuntimeForSaveConfigTests(t)
+	stale := rt.Core.Config
+
+	if err := rt.SetNodeNotificationsMuted("!0000002a", true); err != nil {
+		t.Fatalf("mute node: %v", err)
+	}
+	if err := rt.SaveAndApplyConfig(stale); err != nil {
+		t.Fatalf("save and apply config: %v", err)
+	}
+
+	loaded, err := config.Load(rt.Core.Paths.ConfigFile)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if !loaded.UI.Notifications.MutesNode("!0000002a") {
+		t.Fatalf("expected the node mute to survive a settings save, got %v", loaded.UI.Notifications.MutedNodes)
+	}
+	if err := rt.SetNodeNotificationsMuted(" ", true); err == nil {
+		t.Fatalf("expected an error without node id")
+	}
+}
+
+func TestRuntimeSetChannelColor_SurvivesSettingsSave(t *testing.T) {
+	rt := newRuntimeForSaveConfigTests(t)
+	stale := rt.Core.Config
+
+	if err := rt.SetChannelColor("channel:1", "orange"); err != nil {
+		t.Fatalf("set channel color: %v", err)
+	}
+	if err := rt.SaveAndApplyConfig(stale); err != nil {
+		t.Fatalf("save and apply config: %v", err)
+	}
+
+	loaded, err := config.Load(rt.Core.Paths.ConfigFile)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if got := loaded.UI.ChannelColor("channel:1"); got != "orange" {
+		t.Fatalf("expected the channel color to survive a settings save, got %q", got)
+	}
+	if err := rt.SetChannelColor(" ", "orange"); err == nil {
+		t.Fatalf("expected an error without chat key")
+	}
+}
+
+func TestRuntimeSetNodePresenceAlert_SurvivesSettingsSave(t *testing.T) {
+	rt := newRuntimeForSaveConfigTests(t)
+	stale := rt.Core.Config
+
+	want := config.NodePresenceAlert{SilentMinutes: 120, HeardAgainMinutes: 360}
+	if err := rt.SetNodePresenceAlert("!0000002a", want); err != nil {
+		t.Fatalf("set node presence alert: %v", err)
+	}
+	if err := rt.SaveAndApplyConfig(stale); err != nil {
+		t.Fatalf("save and apply config: %v", err)
+	}
+
+	loaded, err := config.Load(rt.Core.Paths.ConfigFile)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if got := loaded.UI.Notifications.PresenceAlert("!0000002a"); got != want {
+		t.Fatalf("expected the presence alert to survive a settings save, got %+v", got)
+	}
+	if err := rt.SetNodePresenceAlert(" ", want); err == nil {
+		t.Fatalf("expected an error without node id")
+	}
+}
+
+func TestRuntimeSetNotificationsMuted_SurvivesSettingsSave(t *testing.T) {
+	rt := newRuntimeForSaveConfigTests(t)
+	stale := rt.Core.Config
+
+	until := time.Date(2026, 3, 1, 13, 0, 0, 0, time.UTC)
+	if err := rt.SetNotificationsMuted(true, until); err != nil {
+		t.Fatalf("set notifications muted: %v", err)
+	}
+	if err := rt.SaveAndApplyConfig(stale); err != nil {
+		t.Fatalf("save and apply config: %v", err)
+	}
+
+	loaded, err := config.Load(rt.Core.Paths.ConfigFile)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if !loaded.UI.Notifications.Muted || !loaded.UI.Notifications.MutedUntil.Equal(until) {
+		t.Fatalf("expected the notifications mute to survive a settings save, got %v until %v", loaded.UI.Notifications.Muted, loaded.UI.Notifications.MutedUntil)
+	}
+}
+
+func TestRuntimeSetCloseButtonAction_SurvivesSettingsSave(t *testing.T) {
+	rt := newRuntimeForSaveConfigTests(t)
+	stale := rt.Core.Config
+
+	if err := rt.SetCloseButtonAction(config.CloseButtonQuit); err != nil {
+		t.Fatalf("set close button action: %v", err)
+	}
+	if err := rt.SaveAndApplyConfig(stale); err != nil {
+		t.Fatalf("save and apply config: %v", err)
+	}
+
+	loaded, err := config.Load(rt.Core.Paths.ConfigFile)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if loaded.UI.CloseButton != config.CloseButtonQuit {
+		t.Fatalf("expected the close button action to survive a settings save, got %q", loaded.UI.CloseButton)
+	}
+}
+
+func TestRuntimeSetChatListPrefs_SurvivesSettingsSave(t *testing.T) {
+	rt := newRuntimeForSaveConfigTests(t)
+	stale := rt.Core.Config
+
+	want := config.ChatListConfig{Sort: config.ChatListSortUnreadFirst, Filter: config.ChatListFilterDMs}
+	if err := rt.SetChatListPrefs(want); err != nil {
+		t.Fatalf("set chat list preferences: %v", err)
+	}
+	if err := rt.SaveAndApplyConfig(stale); err != nil {
+		t.Fatalf("save and apply config: %v", err)
+	}
+
+	loaded, err := config.Load(rt.Core.Paths.ConfigFile)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	if loaded.UI.ChatList != want {
+		t.Fatalf("expected chat list preferences to survive a settings save: expected %+v, got %+v", want, loaded.UI.ChatList)
+	}
+}
+
+func TestRuntimeSetNodeListPrefs_SurvivesSettingsSave(t *testing.T) {
+	rt := newRuntimeForSaveConfigTests(t)
+	stale := rt.Core.Config
+
+	want := config.NodeListConfig{
+		Columns: []config.NodeListColumn{config.NodeListColumnShortName, config.NodeListColumnSNR},
+		SortBy:  config.NodeListColumnSNR,
+	}
+	if err := rt.SetNodeListPrefs(want); err != nil {
+		t.Fatalf("set node list preferences: %v", err)
+	}
+	if err := rt.SaveAndApplyConfig(stale); err != nil {
+		t.Fatalf("save and apply config: %v", err)
+	}
+
+	loaded, err := config.Load(rt.Core.Paths.ConfigFile)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	got := loaded.UI.NodeList
+	if !slices.Equal(got.Columns, want.Columns) || got.SortBy != want.SortBy || got.SortDescending {
+		t.Fatalf("expected node list preferences to survive a settings save: expected %+v, got %+v", want, got)
+	}
+}
+
+func TestRuntimeRememberConnection_SurvivesSettingsSave(t *testing.T) {
+	rt := newRuntimeForSaveConfigTests(t)
+	rt.Core.Config.Connection = config.ConnectionConfig{Transport: config.TransportIP, Host: "192.168.1.20"}
+	stale := rt.Core.Config
+
+	rt.rememberConnection(busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnected, TransportName: "serial"})
+	if len(rt.Core.Config.RecentConnections) != 0 {
+		t.Fatalf("expected a status of another transport to be ignored, got %+v", rt.Core.Config.RecentConnections)
+	}
+	rt.rememberConnection(busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnected, TransportName: "ip"})
+	if err := rt.SaveAndApplyConfig(stale); err != nil {
+		t.Fatalf("save and apply config: %v", err)
+	}
+
+	loaded, err := config.Load(rt.Core.Paths.ConfigFile)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	want := []config.ConnectionConfig{{Transport: config.TransportIP, Host: "192.168.1.20"}}
+	if !slices.Equal(loaded.RecentConnections, want) {
+		t.Fatalf("expected recent connections to survive a settings save: expected %+v, got %+v", want, loaded.RecentConnections)
+	}
+}
+
 func TestRuntimeClearDatabase_ClearsAllTables(t *testing.T) {
 	ctx := context.Background()
 	db, err := persistence.Open(ctx, filepath.Join(t.TempDir(), "app.db"))
@@ -198,7 +410,7 @@ func TestRuntimeCurrentConfigReturnsCopy(t *testing.T) {
 	}
 }
 
-func TestRuntimeDeleteDMChat_RemovesPersistenceAndState(t *testing.T) {
+func TestRuntimeDeleteDMChat_SoftDeletesPersistenceAndRemovesState(t *testing.T) {
 	ctx := context.Background()
 	db, err := persistence.Open(ctx, filepath.Join(t.TempDir(), "app.db"))
 	if err != nil {
@@ -262,7 +474,10 @@ func TestRuntimeDeleteDMChat_RemovesPersistenceAndState(t *testing.T) {
 			},
 		},
 		Persistence: RuntimePersistence{
-			DB: db,
+			DB:               db,
+			ChatRepo:         persistence.NewChatRepo(db),
+			MessageRepo:      persistence.NewMessageRepo(db),
+			DeletedItemsRepo: persistence.NewDeletedItemsRepo(db),
 		},
 		Domain: RuntimeDomain{
 			ChatStore: chatStore,
@@ -273,19 +488,19 @@ func TestRuntimeDeleteDMChat_RemovesPersistenceAndState(t *testing.T) {
 		t.Fatalf("delete dm chat: %v", err)
 	}
 
-	var chatCount int
-	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM chats WHERE chat_key = ?`, domain.ChatKeyForDM("!12345678")).Scan(&chatCount); err != nil {
-		t.Fatalf("count dm chats: %v", err)
+	var deletedAt *int64
+	if err := db.QueryRowContext(ctx, `SELECT deleted_at FROM chats WHERE chat_key = ?`, domain.ChatKeyForDM("!12345678")).Scan(&deletedAt); err != nil {
+		t.Fatalf("load dm chat deleted_at: %v", err)
 	}
-	if chatCount != 0 {
-		t.Fatalf("expected dm chat row to be removed, got %d", chatCount)
+	if deletedAt == nil {
+		t.Fatalf("expected dm chat row to be marked deleted")
 	}
 	var messageCount int
 	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM messages WHERE chat_key = ?`, domain.ChatKeyForDM("!12345678")).Scan(&messageCount); err != nil {
 		t.Fatalf("count dm messages: %v", err)
 	}
-	if messageCount != 0 {
-		t.Fatalf("expected dm messages to be removed, got %d", messageCount)
+	if messageCount != 1 {
+		t.Fatalf("expected dm messages to be kept until purge, got %d", messageCount)
 	}
 	if _, ok := chatStore.ChatByKey(domain.ChatKeyForDM("!12345678")); ok {
 		t.Fatalf("expected dm chat to be removed from store")
@@ -303,6 +518,23 @@ func TestRuntimeDeleteDMChat_RemovesPersistenceAndState(t *testing.T) {
 	if loadedCfg.UI.LastSelectedChat != "" {
 		t.Fatalf("expected persisted last selected chat to be cleared, got %q", loadedCfg.UI.LastSelectedChat)
 	}
+
+	deleted, err := rt.ListRecentlyDeleted()
+	if err != nil {
+		t.Fatalf("list recently deleted: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].Kind != domain.DeletedItemChat || deleted[0].Title != "Alice" {
+		t.Fatalf("expected deleted dm chat to be listed, got %+v", deleted)
+	}
+	if err := rt.RestoreDeletedItem(deleted[0]); err != nil {
+		t.Fatalf("restore dm chat: %v", err)
+	}
+	if _, ok := chatStore.ChatByKey(domain.ChatKeyForDM("!12345678")); !ok {
+		t.Fatalf("expected restored dm chat in store")
+	}
+	if got := len(chatStore.Messages(domain.ChatKeyForDM("!12345678"))); got != 1 {
+		t.Fatalf("expected restored dm chat history, got %d messages", got)
+	}
 }
 
 func TestRuntimeDeleteDMChat_RejectsNonDMChat(t *testing.T) {
@@ -376,3 +608,53 @@ func newRuntimeForSaveConfigTests(t *testing.T) *Runtime {
 
 	return rt
 }
+
+func TestRuntimeDeleteNode_HidesUntilRestored(t *testing.T) {
+	ctx := context.Background()
+	db, err := persistence.Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open test db: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	now := time.Now()
+	coreRepo := persistence.NewNodeCoreRepo(db)
+	if err := coreRepo.Upsert(ctx, domain.NodeCoreUpdate{
+		Core: domain.NodeCore{NodeID: "!12345678", LongName: "Alice", LastHeardAt: now, UpdatedAt: now},
+		Type: domain.NodeUpdateTypeNodeInfoSnapshot,
+	}, 0); err != nil {
+		t.Fatalf("seed node: %v", err)
+	}
+	nodeStore := domain.NewNodeStore()
+	nodeStore.Upsert(domain.Node{NodeID: "!12345678", LongName: "Alice", LastHeardAt: now})
+
+	rt := &Runtime{
+		Persistence: RuntimePersistence{
+			DB:                db,
+			NodeCoreRepo:      coreRepo,
+			NodePositionRepo:  persistence.NewNodePositionRepo(db),
+			NodeTelemetryRepo: persistence.NewNodeTelemetryRepo(db),
+			DeletedItemsRepo:  persistence.NewDeletedItemsRepo(db),
+		},
+		Domain: RuntimeDomain{
+			NodeStore: nodeStore,
+		},
+	}
+
+	if err := rt.DeleteNode("!12345678"); err != nil {
+		t.Fatalf("delete node: %v", err)
+	}
+	if _, ok := nodeStore.Get("!12345678"); ok {
+		t.Fatalf("expected deleted node to be hidden from store")
+	}
+
+	if err := rt.RestoreDeletedItem(domain.DeletedItem{Kind: domain.DeletedItemNode, Key: "!12345678"}); err != nil {
+		t.Fatalf("restore node: %v", err)
+	}
+	node, ok := nodeStore.Get("!12345678")
+	if !ok || node.LongName != "Alice" {
+		t.Fatalf("expected restored node in store, got %+v (ok=%v)", node, ok)
+	}
+}
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// LoadStatistics returns daily message counts since the given day, the nodeLimit
+// busiest nodes and the activity by hour of the day.
+func (r *Runtime) LoadStatistics(ctx context.Context, since time.Time, nodeLimit int) (domain.MeshStatistics, error) {
+	if r.Persistence.Statistics == nil {
+		return domain.MeshStatistics{}, fmt.Errorf("database is not initialized")
+	}
+
+	stats, err := r.Persistence.Statistics.Load(ctx, since, nodeLimit)
+	if err != nil {
+		return domain.MeshStatistics{}, fmt.Errorf("load statistics: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/skobkin/meshgo/internal/config"
+)
+
+// SettingsStep is a single write of a change that spans app config and device settings.
+//
+// Only actions built from steps are all-or-nothing. Emergency mode activation is
+// currently the one action that writes both app config and device settings; the
+// app settings and node settings pages each save a single side and need no steps.
+// A new action that writes both must go through RunSettingsTransaction.
+type SettingsStep struct {
+	Name  string
+	Apply func(ctx context.Context) error
+	// Revert undoes Apply. Nil means the step has nothing to undo.
+	Revert func(ctx context.Context) error
+}
+
+// SettingsTransactionError reports which step of a combined settings change failed
+// and whether the already applied steps were reverted.
+type SettingsTransactionError struct {
+	Step        string
+	Err         error
+	RollbackErr error
+}
+
+func (e *SettingsTransactionError) Error() string {
+	if e.RollbackErr != nil {
+		return fmt.Sprintf("%s: %v (rollback failed: %v)", e.Step, e.Err, e.RollbackErr)
+	}
+
+	return fmt.Sprintf("%s: %v (previous settings restored)", e.Step, e.Err)
+}
+
+func (e *SettingsTransactionError) Unwrap() error {
+	return e.Err
+}
+
+// RolledBack reports whether every applied step was reverted successfully.
+func (e *SettingsTransactionError) RolledBack() bool {
+	return e.RollbackErr == nil
+}
+
+// RunSettingsTransaction applies steps in order. When a step fails, steps applied before it
+// are reverted in reverse order and a *SettingsTransactionError is returned.
+func RunSettingsTransaction(ctx context.Context, logger *slog.Logger, steps ...SettingsStep) error {
+	if logger == nil {
+		logger = slog.Default().With("component", "app.settings_transaction")
+	}
+
+	for i, step := range steps {
+		if err := step.Apply(ctx); err != nil {
+			logger.Warn("settings step failed, rolling back", "step", step.Name, "applied_steps", i, "error", err)
+			rollbackErr := revertSettingsSteps(ctx, logger, steps[:i])
+
+			return &SettingsTransactionError{Step: step.Name, Err: err, RollbackErr: rollbackErr}
+		}
+		logger.Debug("settings step applied", "step", step.Name)
+	}
+
+	return nil
+}
+
+// AppConfigSettingsStep returns a step that mutates the app config and restores the
+// config captured right before the mutation on revert.
+func AppConfigSettingsStep(
+	name string,
+	currentConfig func() config.AppConfig,
+	saveConfig func(config.AppConfig) error,
+	mutate func(cfg *config.AppConfig),
+) SettingsStep {
+	var previous config.AppConfig
+
+	return SettingsStep{
+		Name: name,
+		Apply: func(context.Context) error {
+			previous = currentConfig()
+			next := previous
+			mutate(&next)
+
+			return ignoreAutostartSyncWarning(saveConfig(next))
+		},
+		Revert: func(context.Context) error {
+			return ignoreAutostartSyncWarning(saveConfig(previous))
+		},
+	}
+}
+
+func revertSettingsSteps(ctx context.Context, logger *slog.Logger, applied []SettingsStep) error {
+	var errs []error
+	for i := len(applied) - 1; i >= 0; i-- {
+		step := applied[i]
+		if step.Revert == nil {
+			continue
+		}
+		if err := step.Revert(ctx); err != nil {
+			logger.Warn("settings step rollback failed", "step", step.Name, "error", err)
+			errs = append(errs, fmt.Errorf("revert %s: %w", step.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ignoreAutostartSyncWarning treats autostart sync warnings as success: the config itself was saved.
+func ignoreAutostartSyncWarning(err error) error {
+	var autostartWarning *AutostartSyncWarning
+	var devBuildWarning *AutostartDevBuildSkipWarning
+	if errors.As(err, &autostartWarning) || errors.As(err, &devBuildWarning) {
+		return nil
+	}
+
+	return err
+}
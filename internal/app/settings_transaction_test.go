@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/config"
+)
+
+func TestRunSettingsTransaction(t *testing.T) {
+	errDevice := errors.New("device rejected")
+	errRevert := errors.New("revert rejected")
+
+	tests := []struct {
+		name         string
+		failStep     int
+		failRevert   int
+		wantErr      bool
+		wantRollback bool
+		wantLog      []string
+	}{
+		{
+			name:     "all steps applied",
+			failStep: -1,
+			wantLog:  []string{"apply a", "apply b", "apply c"},
+		},
+		{
+			name:         "failure reverts applied steps in reverse order",
+			failStep:     2,
+			failRevert:   -1,
+			wantErr:      true,
+			wantRollback: true,
+			wantLog:      []string{"apply a", "apply b", "apply c", "revert b", "revert a"},
+		},
+		{
+			name:       "revert failure is reported and other steps still revert",
+			failStep:   2,
+			failRevert: 1,
+			wantErr:    true,
+			wantLog:    []string{"apply a", "apply b", "apply c", "revert b", "revert a"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var log []string
+			steps := make([]SettingsStep, 0, 3)
+			for i, name := range []string{"a", "b", "c"} {
+				steps = append(steps, SettingsStep{
+					Name: name,
+					Apply: func(context.Context) error {
+						log = append(log, "apply "+name)
+						if i == tc.failStep {
+							return errDevice
+						}
+
+						return nil
+					},
+					Revert: func(context.Context) error {
+						log = append(log, "revert "+name)
+						if i == tc.failRevert {
+							return errRevert
+						}
+
+						return nil
+					},
+				})
+			}
+
+			err := RunSettingsTransaction(context.Background(), discardLogger(), steps...)
+			if strings.Join(log, ",") != strings.Join(tc.wantLog, ",") {
+				t.Fatalf("unexpected step order: got %v want %v", log, tc.wantLog)
+			}
+			if !tc.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+			var txErr *SettingsTransactionError
+			if !errors.As(err, &txErr) {
+				t.Fatalf("expected settings transaction error, got %v", err)
+			}
+			if txErr.Step != "c" || !errors.Is(err, errDevice) {
+				t.Fatalf("unexpected failed step: %+v", txErr)
+			}
+			if txErr.RolledBack() != tc.wantRollback {
+				t.Fatalf("expected rolled back %v, got %v (%v)", tc.wantRollback, txErr.RolledBack(), txErr.RollbackErr)
+			}
+		})
+	}
+}
+
+func TestAppConfigSettingsStepRevertsToPreviousConfig(t *testing.T) {
+	store := &emergencyModeConfigStore{cfg: config.Default()}
+	step := AppConfigSettingsStep("notifications", store.current, store.save, func(cfg *config.AppConfig) {
+		cfg.UI.Notifications.NotifyWhenFocused = true
+	})
+	failing := SettingsStep{
+		Name:  "device",
+		Apply: func(context.Context) error { return errors.New("device rejected") },
+	}
+
+	if err := RunSettingsTransaction(context.Background(), discardLogger(), step, failing); err == nil {
+		t.Fatalf("expected transaction error")
+	}
+	if store.cfg.UI.Notifications.NotifyWhenFocused {
+		t.Fatalf("expected app config change to be reverted")
+	}
+	if store.saves != 2 {
+		t.Fatalf("expected apply and revert saves, got %d", store.saves)
+	}
+}
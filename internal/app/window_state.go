@@ -0,0 +1,65 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/skobkin/meshgo/internal/platform"
+)
+
+// WindowState is the main window geometry and selected tab saved between launches.
+type WindowState struct {
+	// Width and Height are the window content size in Fyne units.
+	Width  float32 `json:"width"`
+	Height float32 `json:"height"`
+	// Bounds is the window position and size in screen pixels when not maximized. It is
+	// nil where the window system does not report it.
+	Bounds      *platform.WindowRect `json:"bounds,omitempty"`
+	Maximized   bool                 `json:"maximized"`
+	SelectedTab string               `json:"selected_tab,omitempty"`
+}
+
+// LoadWindowState reads the window state saved at path. A missing file is an empty state.
+func LoadWindowState(path string) (WindowState, error) {
+	// #nosec G304 -- path is resolved by app runtime and points to user config dir.
+	raw, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return WindowState{}, nil
+		}
+
+		return WindowState{}, fmt.Errorf("read window state: %w", err)
+	}
+	var state WindowState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return WindowState{}, fmt.Errorf("decode window state: %w", err)
+	}
+	if state.Width < 0 || state.Height < 0 {
+		state.Width, state.Height = 0, 0
+	}
+
+	return state, nil
+}
+
+// SaveWindowState writes state to path, replacing the previous state at once.
+func SaveWindowState(path string, state WindowState) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("create window state dir: %w", err)
+	}
+	raw, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode window state: %w", err)
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, raw, 0o600); err != nil {
+		return fmt.Errorf("write temp window state: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("rename temp window state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/platform"
+)
+
+func TestWindowStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), WindowStateFilename)
+
+	empty, err := LoadWindowState(path)
+	if err != nil {
+		t.Fatalf("load missing window state: %v", err)
+	}
+	if empty != (WindowState{}) {
+		t.Fatalf("expected empty state for a missing file, got %+v", empty)
+	}
+
+	want := WindowState{
+		Width:       1200,
+		Height:      800,
+		Bounds:      &platform.WindowRect{X: 1930, Y: 40, Width: 1500, Height: 1000},
+		Maximized:   true,
+		SelectedTab: "Map",
+	}
+	if err := SaveWindowState(path, want); err != nil {
+		t.Fatalf("save window state: %v", err)
+	}
+	got, err := LoadWindowState(path)
+	if err != nil {
+		t.Fatalf("load window state: %v", err)
+	}
+	if got.Width != want.Width || got.Height != want.Height || got.Maximized != want.Maximized || got.SelectedTab != want.SelectedTab {
+		t.Fatalf("unexpected window state: expected %+v, got %+v", want, got)
+	}
+	if got.Bounds == nil || *got.Bounds != *want.Bounds {
+		t.Fatalf("unexpected window bounds: expected %+v, got %+v", *want.Bounds, got.Bounds)
+	}
+}
+
+func TestLoadWindowStateRejectsInvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), WindowStateFilename)
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("write window state: %v", err)
+	}
+	if _, err := LoadWindowState(path); err == nil {
+		t.Fatalf("expected an error for invalid window state")
+	}
+}
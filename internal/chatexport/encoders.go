@@ -0,0 +1,304 @@
+package chatexport
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+	"time"
+)
+
+// encoder writes an export incrementally, one chat and one message at a time.
+type encoder interface {
+	begin(exportedAt time.Time) error
+	beginChat(chat Chat) error
+	message(chat Chat, rec record) error
+	endChat() error
+	end() error
+}
+
+func newEncoder(format Format, w io.Writer) (encoder, error) {
+	switch format {
+	case FormatJSON:
+		return &jsonEncoder{w: bufio.NewWriter(w)}, nil
+	case FormatCSV:
+		return &csvEncoder{w: csv.NewWriter(w)}, nil
+	case FormatHTML:
+		return &htmlEncoder{w: bufio.NewWriter(w)}, nil
+	case FormatText:
+		return &textEncoder{w: bufio.NewWriter(w)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// jsonEncoder writes {"exported_at": ..., "chats": [{..., "messages": [...]}]}
+// with one message object per line.
+type jsonEncoder struct {
+	w            *bufio.Writer
+	chats        int
+	chatMessages int
+}
+
+func (e *jsonEncoder) begin(exportedAt time.Time) error {
+	at, err := json.Marshal(exportedAt)
+	if err != nil {
+		return fmt.Errorf("encode export time: %w", err)
+	}
+	_, err = fmt.Fprintf(e.w, "{\n\"exported_at\": %s,\n\"chats\": [", at)
+
+	return err
+}
+
+func (e *jsonEncoder) beginChat(chat Chat) error {
+	header, err := json.Marshal(struct {
+		Key   string `json:"key"`
+		Title string `json:"title"`
+		Type  string `json:"type"`
+	}{Key: chat.Key, Title: chat.Title, Type: chatTypeName(chat.Type)})
+	if err != nil {
+		return fmt.Errorf("encode chat %s: %w", chat.Key, err)
+	}
+	separator := "\n"
+	if e.chats > 0 {
+		separator = ",\n"
+	}
+	e.chats++
+	e.chatMessages = 0
+	// Reopen the marshaled header object to append the messages array.
+	_, err = fmt.Fprintf(e.w, "%s%s, \"messages\": [", separator, header[:len(header)-1])
+
+	return err
+}
+
+func (e *jsonEncoder) message(_ Chat, rec record) error {
+	raw, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("encode message: %w", err)
+	}
+	separator := "\n"
+	if e.chatMessages > 0 {
+		separator = ",\n"
+	}
+	e.chatMessages++
+	_, err = fmt.Fprintf(e.w, "%s%s", separator, raw)
+
+	return err
+}
+
+func (e *jsonEncoder) endChat() error {
+	_, err := e.w.WriteString("\n]}")
+
+	return err
+}
+
+func (e *jsonEncoder) end() error {
+	if _, err := e.w.WriteString("\n]\n}\n"); err != nil {
+		return err
+	}
+
+	return e.w.Flush()
+}
+
+// csvEncoder writes one row per message with the chat repeated on every row.
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func (e *csvEncoder) begin(time.Time) error {
+	return e.w.Write([]string{
+		"chat_key", "chat_title", "chat_type", "at", "direction", "sender", "body",
+		"status", "device_message_id", "reply_to_device_message_id", "reaction",
+	})
+}
+
+func (e *csvEncoder) beginChat(Chat) error {
+	return nil
+}
+
+func (e *csvEncoder) message(chat Chat, rec record) error {
+	reaction := ""
+	if rec.Reaction {
+		reaction = "1"
+	}
+
+	return e.w.Write([]string{
+		chat.Key, chat.Title, chatTypeName(chat.Type), formatRecordTime(rec.At), rec.Direction, rec.Sender, rec.Body,
+		rec.Status, rec.DeviceMessageID, rec.ReplyTo, reaction,
+	})
+}
+
+func (e *csvEncoder) endChat() error {
+	return nil
+}
+
+func (e *csvEncoder) end() error {
+	e.w.Flush()
+
+	return e.w.Error()
+}
+
+// htmlEncoder writes a self-contained transcript with inline styles and no external resources.
+type htmlEncoder struct {
+	w            *bufio.Writer
+	chatMessages int
+}
+
+const htmlHead = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>MeshGo chat export</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2em auto; max-width: 52em; padding: 0 1em; color: #222; background: #fafafa; }
+header p, .meta { color: #777; font-size: 0.85em; }
+section { margin-top: 2.5em; }
+h2 { border-bottom: 1px solid #ddd; padding-bottom: 0.3em; }
+.msg { margin: 0.6em 0; padding: 0.5em 0.8em; border-radius: 0.5em; background: #fff; border: 1px solid #e4e4e4; max-width: 80%; }
+.msg.out { margin-left: auto; background: #e8f1ff; border-color: #c9dcf7; }
+.sender { font-weight: 600; }
+.body { white-space: pre-wrap; overflow-wrap: anywhere; margin: 0.2em 0; }
+.empty { color: #999; font-style: italic; }
+</style>
+</head>
+<body>
+`
+
+func (e *htmlEncoder) begin(exportedAt time.Time) error {
+	_, err := fmt.Fprintf(
+		e.w,
+		"%s<header><h1>MeshGo chat export</h1><p>Exported %s</p></header>\n",
+		htmlHead,
+		html.EscapeString(formatRecordTime(exportedAt)),
+	)
+
+	return err
+}
+
+func (e *htmlEncoder) beginChat(chat Chat) error {
+	e.chatMessages = 0
+	_, err := fmt.Fprintf(
+		e.w,
+		"<section>\n<h2>%s</h2>\n<p class=\"meta\">%s · %s</p>\n",
+		html.EscapeString(chat.Title),
+		html.EscapeString(chatTypeName(chat.Type)),
+		html.EscapeString(chat.Key),
+	)
+
+	return err
+}
+
+func (e *htmlEncoder) message(_ Chat, rec record) error {
+	e.chatMessages++
+	sender := ""
+	if rec.Sender != "" {
+		sender = fmt.Sprintf("<span class=\"sender\">%s</span> · ", html.EscapeString(rec.Sender))
+	}
+	status := ""
+	if rec.Direction == "out" && rec.Status != "" {
+		status = " · " + html.EscapeString(rec.Status)
+	}
+	_, err := fmt.Fprintf(
+		e.w,
+		"<div class=\"msg %s\"><div class=\"meta\">%s<time datetime=\"%s\">%s</time>%s</div><div class=\"body\">%s</div></div>\n",
+		rec.Direction,
+		sender,
+		html.EscapeString(formatRecordTime(rec.At)),
+		html.EscapeString(rec.At.Local().Format("2006-01-02 15:04:05")),
+		status,
+		html.EscapeString(rec.Body),
+	)
+
+	return err
+}
+
+func (e *htmlEncoder) endChat() error {
+	if e.chatMessages == 0 {
+		if _, err := e.w.WriteString("<p class=\"empty\">No messages.</p>\n"); err != nil {
+			return err
+		}
+	}
+	_, err := e.w.WriteString("</section>\n")
+
+	return err
+}
+
+func (e *htmlEncoder) end() error {
+	if _, err := e.w.WriteString("</body>\n</html>\n"); err != nil {
+		return err
+	}
+
+	return e.w.Flush()
+}
+
+// textEncoder writes a plain transcript with one "[time] sender: body" entry per message.
+type textEncoder struct {
+	w            *bufio.Writer
+	chatMessages int
+}
+
+func (e *textEncoder) begin(exportedAt time.Time) error {
+	_, err := fmt.Fprintf(e.w, "MeshGo chat export\nExported %s\n", formatRecordTime(exportedAt))
+
+	return err
+}
+
+func (e *textEncoder) beginChat(chat Chat) error {
+	e.chatMessages = 0
+	_, err := fmt.Fprintf(e.w, "\n== %s (%s · %s) ==\n\n", chat.Title, chatTypeName(chat.Type), chat.Key)
+
+	return err
+}
+
+func (e *textEncoder) message(_ Chat, rec record) error {
+	e.chatMessages++
+	sender := rec.Sender
+	if sender == "" {
+		sender = "Unknown"
+	}
+	suffix := ""
+	if rec.Reaction {
+		suffix = " (reaction)"
+	}
+	if rec.Direction == "out" && rec.Status != "" {
+		suffix += " [" + rec.Status + "]"
+	}
+	// Continuation lines are indented so every entry still starts with its timestamp.
+	body := strings.ReplaceAll(rec.Body, "\n", "\n    ")
+	_, err := fmt.Fprintf(
+		e.w,
+		"[%s] %s: %s%s\n",
+		rec.At.Local().Format("2006-01-02 15:04:05"),
+		sender,
+		body,
+		suffix,
+	)
+
+	return err
+}
+
+func (e *textEncoder) endChat() error {
+	if e.chatMessages == 0 {
+		_, err := e.w.WriteString("No messages.\n")
+
+		return err
+	}
+
+	return nil
+}
+
+func (e *textEncoder) end() error {
+	return e.w.Flush()
+}
+
+func formatRecordTime(at time.Time) string {
+	if at.IsZero() {
+		return ""
+	}
+
+	return at.Format(time.RFC3339)
+}
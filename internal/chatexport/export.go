@@ -0,0 +1,240 @@
+package chatexport
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// Format identifies the output file format of a chat export.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatHTML Format = "html"
+	FormatText Format = "txt"
+)
+
+const defaultPageSize = 500
+
+// Formats lists supported export formats in the order they are offered to users.
+func Formats() []Format {
+	return []Format{FormatHTML, FormatText, FormatJSON, FormatCSV}
+}
+
+// Extension returns the file name extension for the format, including the dot.
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// ParseFormat resolves a format name case-insensitively.
+func ParseFormat(raw string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(raw)))
+	switch format {
+	case FormatJSON, FormatCSV, FormatHTML, FormatText:
+		return format, nil
+	case "text":
+		return FormatText, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q", raw)
+	}
+}
+
+// Chat is a chat to export with the title it should carry in the output.
+type Chat struct {
+	Key   string
+	Title string
+	Type  domain.ChatType
+}
+
+// MessageSource pages through stored chat messages in chronological order.
+type MessageSource interface {
+	CountByChat(ctx context.Context, chatKey string) (int, error)
+	ListPageByChat(ctx context.Context, chatKey string, after domain.ChatMessage, limit int) ([]domain.ChatMessage, error)
+}
+
+// Options controls what is exported and how progress is reported.
+type Options struct {
+	Format Format
+	Chats  []Chat
+	// SenderName resolves a display name for a message sender. Empty names are omitted.
+	SenderName func(message domain.ChatMessage) string
+	// Progress is called after every written page with the number of exported and total messages.
+	Progress   func(done, total int)
+	ExportedAt time.Time
+	PageSize   int
+}
+
+// Export streams the selected chats from source to w in the requested format.
+// Messages are read page by page so large histories are never loaded at once.
+func Export(ctx context.Context, w io.Writer, source MessageSource, opts Options) error {
+	if source == nil {
+		return fmt.Errorf("message source is not configured")
+	}
+	if len(opts.Chats) == 0 {
+		return fmt.Errorf("no chats selected for export")
+	}
+	enc, err := newEncoder(opts.Format, w)
+	if err != nil {
+		return err
+	}
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	exportedAt := opts.ExportedAt
+	if exportedAt.IsZero() {
+		exportedAt = time.Now()
+	}
+
+	total := 0
+	for _, chat := range opts.Chats {
+		count, err := source.CountByChat(ctx, chat.Key)
+		if err != nil {
+			return fmt.Errorf("count messages in %s: %w", chat.Key, err)
+		}
+		total += count
+	}
+	reportProgress(opts.Progress, 0, total)
+
+	if err := enc.begin(exportedAt); err != nil {
+		return err
+	}
+	done := 0
+	for _, chat := range opts.Chats {
+		if err := enc.beginChat(chat); err != nil {
+			return err
+		}
+		var after domain.ChatMessage
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			page, err := source.ListPageByChat(ctx, chat.Key, after, pageSize)
+			if err != nil {
+				return fmt.Errorf("list messages in %s: %w", chat.Key, err)
+			}
+			if len(page) == 0 {
+				break
+			}
+			for _, message := range page {
+				if err := enc.message(chat, newRecord(message, opts.SenderName)); err != nil {
+					return err
+				}
+			}
+			done += len(page)
+			reportProgress(opts.Progress, done, max(total, done))
+			after = page[len(page)-1]
+			if len(page) < pageSize {
+				break
+			}
+		}
+		if err := enc.endChat(); err != nil {
+			return err
+		}
+	}
+
+	return enc.end()
+}
+
+// DefaultFileName suggests an export file name like "meshgo-chats-20260224-1530.html".
+func DefaultFileName(format Format, chats []Chat, at time.Time) string {
+	name := "chats"
+	if len(chats) == 1 {
+		if slug := fileNameSlug(chats[0].Title); slug != "" {
+			name = "chat-" + slug
+		} else {
+			name = "chat"
+		}
+	}
+
+	return fmt.Sprintf("meshgo-%s-%s%s", name, at.Format("20060102-1504"), format.Extension())
+}
+
+func fileNameSlug(raw string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(raw)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case b.Len() > 0 && !dash:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+func reportProgress(progress func(done, total int), done, total int) {
+	if progress != nil {
+		progress(done, total)
+	}
+}
+
+// record is the format-independent view of an exported message.
+type record struct {
+	At              time.Time `json:"at"`
+	Direction       string    `json:"direction"`
+	Sender          string    `json:"sender,omitempty"`
+	Body            string    `json:"body"`
+	Status          string    `json:"status,omitempty"`
+	DeviceMessageID string    `json:"device_message_id,omitempty"`
+	ReplyTo         string    `json:"reply_to_device_message_id,omitempty"`
+	Reaction        bool      `json:"reaction,omitempty"`
+}
+
+func newRecord(message domain.ChatMessage, senderName func(domain.ChatMessage) string) record {
+	rec := record{
+		At:              message.At,
+		Direction:       directionName(message.Direction),
+		Body:            message.Body,
+		Status:          statusName(message.Status),
+		DeviceMessageID: message.DeviceMessageID,
+		ReplyTo:         message.ReplyToDeviceMessageID,
+		Reaction:        message.Emoji != 0,
+	}
+	if senderName != nil {
+		rec.Sender = strings.TrimSpace(senderName(message))
+	}
+
+	return rec
+}
+
+func directionName(direction domain.MessageDirection) string {
+	if direction == domain.MessageDirectionOut {
+		return "out"
+	}
+
+	return "in"
+}
+
+func statusName(status domain.MessageStatus) string {
+	switch status {
+	case domain.MessageStatusPending:
+		return "pending"
+	case domain.MessageStatusSent:
+		return "sent"
+	case domain.MessageStatusAcked:
+		return "acked"
+	case domain.MessageStatusFailed:
+		return "failed"
+	default:
+		return ""
+	}
+}
+
+func chatTypeName(chatType domain.ChatType) string {
+	if chatType == domain.ChatTypeDM {
+		return "dm"
+	}
+
+	return "channel"
+}
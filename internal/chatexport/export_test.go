@@ -0,0 +1,253 @@
+package chatexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+type fakeMessageSource struct {
+	byChat map[string][]domain.ChatMessage
+	pages  int
+}
+
+func (s *fakeMessageSource) CountByChat(_ context.Context, chatKey string) (int, error) {
+	return len(s.byChat[chatKey]), nil
+}
+
+func (s *fakeMessageSource) ListPageByChat(_ context.Context, chatKey string, after domain.ChatMessage, limit int) ([]domain.ChatMessage, error) {
+	s.pages++
+	var out []domain.ChatMessage
+	for _, m := range s.byChat[chatKey] {
+		if m.LocalID <= after.LocalID {
+			continue
+		}
+		out = append(out, m)
+		if len(out) == limit {
+			break
+		}
+	}
+
+	return out, nil
+}
+
+func testSource() *fakeMessageSource {
+	at := time.Date(2026, 2, 24, 15, 30, 0, 0, time.UTC)
+
+	return &fakeMessageSource{byChat: map[string][]domain.ChatMessage{
+		"channel:0": {
+			{LocalID: 1, ChatKey: "channel:0", DeviceMessageID: "10", Direction: domain.MessageDirectionIn, Body: "hello <b>mesh</b>", Status: domain.MessageStatusSent, At: at, MetaJSON: `{"from":"!1234abcd"}`},
+			{LocalID: 2, ChatKey: "channel:0", DeviceMessageID: "11", Direction: domain.MessageDirectionOut, Body: "reply, \"quoted\"", Status: domain.MessageStatusAcked, At: at.Add(time.Minute)},
+			{LocalID: 3, ChatKey: "channel:0", DeviceMessageID: "12", ReplyToDeviceMessageID: "10", Emoji: 1, Direction: domain.MessageDirectionIn, Body: "👍", Status: domain.MessageStatusSent, At: at.Add(2 * time.Minute)},
+		},
+		"dm:!1234abcd": {
+			{LocalID: 4, ChatKey: "dm:!1234abcd", Direction: domain.MessageDirectionIn, Body: "direct", Status: domain.MessageStatusSent, At: at},
+		},
+	}}
+}
+
+func testChats() []Chat {
+	return []Chat{
+		{Key: "channel:0", Title: "Primary", Type: domain.ChatTypeChannel},
+		{Key: "dm:!1234abcd", Title: "Alice", Type: domain.ChatTypeDM},
+	}
+}
+
+func testSenderName(m domain.ChatMessage) string {
+	if m.Direction == domain.MessageDirectionOut {
+		return "Me"
+	}
+
+	return "Alice"
+}
+
+func TestExportJSON(t *testing.T) {
+	var out bytes.Buffer
+	source := testSource()
+	var progress [][2]int
+	err := Export(context.Background(), &out, source, Options{
+		Format:     FormatJSON,
+		Chats:      testChats(),
+		SenderName: testSenderName,
+		Progress:   func(done, total int) { progress = append(progress, [2]int{done, total}) },
+		PageSize:   2,
+	})
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	var decoded struct {
+		ExportedAt time.Time `json:"exported_at"`
+		Chats      []struct {
+			Key      string   `json:"key"`
+			Title    string   `json:"title"`
+			Type     string   `json:"type"`
+			Messages []record `json:"messages"`
+		} `json:"chats"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode export: %v\n%s", err, out.String())
+	}
+	if len(decoded.Chats) != 2 || decoded.Chats[0].Title != "Primary" || decoded.Chats[1].Type != "dm" {
+		t.Fatalf("unexpected chats: %+v", decoded.Chats)
+	}
+	messages := decoded.Chats[0].Messages
+	if len(messages) != 3 {
+		t.Fatalf("expected three channel messages, got %d", len(messages))
+	}
+	if messages[1].Direction != "out" || messages[1].Sender != "Me" || messages[1].Status != "acked" {
+		t.Fatalf("unexpected outgoing record: %+v", messages[1])
+	}
+	if !messages[2].Reaction || messages[2].ReplyTo != "10" {
+		t.Fatalf("expected reaction reply to roundtrip, got %+v", messages[2])
+	}
+	if decoded.ExportedAt.IsZero() {
+		t.Fatalf("expected export time to be set")
+	}
+
+	want := [][2]int{{0, 4}, {2, 4}, {3, 4}, {4, 4}}
+	if len(progress) != len(want) {
+		t.Fatalf("unexpected progress calls: %v", progress)
+	}
+	for i := range want {
+		if progress[i] != want[i] {
+			t.Fatalf("unexpected progress calls: got %v, want %v", progress, want)
+		}
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	var out bytes.Buffer
+	if err := Export(context.Background(), &out, testSource(), Options{Format: FormatCSV, Chats: testChats(), SenderName: testSenderName}); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("expected header and four rows, got %d", len(rows))
+	}
+	if rows[0][0] != "chat_key" || rows[2][6] != "reply, \"quoted\"" || rows[2][3] != "2026-02-24T15:31:00Z" {
+		t.Fatalf("unexpected csv rows: %v", rows)
+	}
+	if rows[4][1] != "Alice" || rows[4][2] != "dm" {
+		t.Fatalf("unexpected dm row: %v", rows[4])
+	}
+}
+
+func TestExportHTMLEscapesContent(t *testing.T) {
+	var out bytes.Buffer
+	chats := append(testChats(), Chat{Key: "channel:1", Title: "<Empty>", Type: domain.ChatTypeChannel})
+	if err := Export(context.Background(), &out, testSource(), Options{Format: FormatHTML, Chats: chats, SenderName: testSenderName}); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	page := out.String()
+	for _, want := range []string{"<!DOCTYPE html>", "hello &lt;b&gt;mesh&lt;/b&gt;", "<h2>&lt;Empty&gt;</h2>", "No messages.", `class="msg out"`, "</html>"} {
+		if !strings.Contains(page, want) {
+			t.Fatalf("expected html to contain %q:\n%s", want, page)
+		}
+	}
+	if strings.Contains(page, "<b>mesh</b>") {
+		t.Fatalf("expected message body to be escaped")
+	}
+}
+
+func TestExportText(t *testing.T) {
+	var out bytes.Buffer
+	chats := append(testChats(), Chat{Key: "channel:1", Title: "Empty", Type: domain.ChatTypeChannel})
+	source := testSource()
+	source.byChat["dm:!1234abcd"][0].Body = "first\nsecond"
+	if err := Export(context.Background(), &out, source, Options{Format: FormatText, Chats: chats, SenderName: testSenderName}); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+
+	at := time.Date(2026, 2, 24, 15, 30, 0, 0, time.UTC).Local()
+	transcript := out.String()
+	for _, want := range []string{
+		"== Primary (channel · channel:0) ==",
+		"[" + at.Format("2006-01-02 15:04:05") + "] Alice: hello <b>mesh</b>\n",
+		"[" + at.Add(time.Minute).Format("2006-01-02 15:04:05") + "] Me: reply, \"quoted\" [acked]\n",
+		"Alice: 👍 (reaction)\n",
+		"Alice: first\n    second\n",
+		"== Empty (channel · channel:1) ==\n\nNo messages.\n",
+	} {
+		if !strings.Contains(transcript, want) {
+			t.Fatalf("expected transcript to contain %q:\n%s", want, transcript)
+		}
+	}
+}
+
+func TestExportStopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	source := testSource()
+
+	err := Export(ctx, &bytes.Buffer{}, source, Options{Format: FormatJSON, Chats: testChats()})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context cancellation, got %v", err)
+	}
+	if source.pages != 0 {
+		t.Fatalf("expected no pages to be read, got %d", source.pages)
+	}
+}
+
+func TestExportRejectsInvalidOptions(t *testing.T) {
+	if err := Export(context.Background(), &bytes.Buffer{}, testSource(), Options{Format: FormatJSON}); err == nil {
+		t.Fatalf("expected error without chats")
+	}
+	if err := Export(context.Background(), &bytes.Buffer{}, testSource(), Options{Format: "xml", Chats: testChats()}); err == nil {
+		t.Fatalf("expected error for unsupported format")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    Format
+		wantErr bool
+	}{
+		{raw: "json", want: FormatJSON},
+		{raw: " CSV ", want: FormatCSV},
+		{raw: "Html", want: FormatHTML},
+		{raw: "txt", want: FormatText},
+		{raw: "text", want: FormatText},
+		{raw: "pdf", wantErr: true},
+	}
+	for _, tc := range tests {
+		got, err := ParseFormat(tc.raw)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Fatalf("ParseFormat(%q) = %q, %v", tc.raw, got, err)
+		}
+	}
+}
+
+func TestDefaultFileName(t *testing.T) {
+	at := time.Date(2026, 2, 24, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		format Format
+		chats  []Chat
+		want   string
+	}{
+		{name: "all chats", format: FormatHTML, chats: testChats(), want: "meshgo-chats-20260224-1530.html"},
+		{name: "single chat", format: FormatCSV, chats: []Chat{{Title: "Long Fast #1"}}, want: "meshgo-chat-long-fast-1-20260224-1530.csv"},
+		{name: "untitled chat", format: FormatJSON, chats: []Chat{{Title: "Привет"}}, want: "meshgo-chat-20260224-1530.json"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DefaultFileName(tc.format, tc.chats, at); got != tc.want {
+				t.Fatalf("unexpected file name: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
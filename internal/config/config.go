@@ -4,9 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 )
 
 // TransportType identifies which transport backend should be used.
@@ -18,15 +23,48 @@ type AutostartMode string
 // MapLinkProvider identifies which external map provider is used for location links.
 type MapLinkProvider string
 
+// NotificationGrouping controls which message notifications are stacked together.
+type NotificationGrouping string
+
+// NotificationClickAction controls what clicking a message notification does.
+type NotificationClickAction string
+
+// CloseButtonAction controls what the close button of the main window does.
+type CloseButtonAction string
+
+// NodeEventType identifies a kind of per-node event written to the event log.
+type NodeEventType string
+
+// ChatListSort controls the order of the chat list.
+type ChatListSort string
+
+// ChatListFilter controls which chats the chat list shows.
+type ChatListFilter string
+
+// NodeListColumn identifies a column of the node list.
+type NodeListColumn string
+
+// NodeHopGroup identifies a section of the node list grouped by hops away.
+type NodeHopGroup string
+
 const (
 	TransportIP        TransportType = "ip"
 	TransportBluetooth TransportType = "bluetooth"
 	TransportSerial    TransportType = "serial"
 	DefaultSerialBaud                = 115200
 
-	DefaultPositionHistoryLimit  = 100
-	DefaultTelemetryHistoryLimit = 250
-	DefaultIdentityHistoryLimit  = 50
+	DefaultPositionHistoryLimit   = 100
+	DefaultTelemetryHistoryLimit  = 250
+	DefaultIdentityHistoryLimit   = 50
+	DefaultSignalHistoryLimit     = 500
+	DefaultTracerouteHistoryLimit = 100
+	DefaultDeletedRetentionDays   = 30
+	DefaultRawPacketLogMaxSizeMB  = 8
+	DefaultMapTileCacheSizeMB     = 200
+
+	NodeEventCore      NodeEventType = "core"
+	NodeEventPosition  NodeEventType = "position"
+	NodeEventTelemetry NodeEventType = "telemetry"
 
 	AutostartModeNormal     AutostartMode = "normal"
 	AutostartModeBackground AutostartMode = "background"
@@ -35,12 +73,101 @@ const (
 	MapLinkProviderKagi          MapLinkProvider = "kagi"
 	MapLinkProviderGoogle        MapLinkProvider = "google"
 	MapLinkProviderYandex        MapLinkProvider = "yandex"
+
+	NotificationGroupingChat   NotificationGrouping = "chat"
+	NotificationGroupingSender NotificationGrouping = "sender"
+	NotificationGroupingGlobal NotificationGrouping = "global"
+
+	NotificationClickOpenChat   NotificationClickAction = "open_chat"
+	NotificationClickShowWindow NotificationClickAction = "show_window"
+
+	// CloseButtonAsk asks on the next close and remembers the answer.
+	CloseButtonAsk        CloseButtonAction = "ask"
+	CloseButtonHideToTray CloseButtonAction = "hide_to_tray"
+	CloseButtonQuit       CloseButtonAction = "quit"
+
+	ChatListSortRecent       ChatListSort = "recent"
+	ChatListSortUnreadFirst  ChatListSort = "unread_first"
+	ChatListSortAlphabetical ChatListSort = "alphabetical"
+
+	ChatListFilterAll      ChatListFilter = "all"
+	ChatListFilterChannels ChatListFilter = "channels"
+	ChatListFilterDMs      ChatListFilter = "dms"
+	ChatListFilterUnread   ChatListFilter = "unread"
+
+	NodeListColumnShortName NodeListColumn = "short_name"
+	NodeListColumnLongName  NodeListColumn = "long_name"
+	NodeListColumnBattery   NodeListColumn = "battery"
+	NodeListColumnSNR       NodeListColumn = "snr"
+	NodeListColumnHops      NodeListColumn = "hops"
+	NodeListColumnLastHeard NodeListColumn = "last_heard"
+	NodeListColumnDistance  NodeListColumn = "distance"
+
+	NodeHopGroupDirect   NodeHopGroup = "direct"
+	NodeHopGroupOneHop   NodeHopGroup = "one_hop"
+	NodeHopGroupMultiHop NodeHopGroup = "multi_hop"
+	NodeHopGroupMQTT     NodeHopGroup = "mqtt"
+	NodeHopGroupUnknown  NodeHopGroup = "unknown"
 )
 
+// NodeListColumns lists the node list columns in their display order.
+var NodeListColumns = []NodeListColumn{
+	NodeListColumnShortName,
+	NodeListColumnLongName,
+	NodeListColumnBattery,
+	NodeListColumnSNR,
+	NodeListColumnHops,
+	NodeListColumnLastHeard,
+	NodeListColumnDistance,
+}
+
+// NodeHopGroups lists the node list sections in their display order.
+var NodeHopGroups = []NodeHopGroup{
+	NodeHopGroupDirect,
+	NodeHopGroupOneHop,
+	NodeHopGroupMultiHop,
+	NodeHopGroupMQTT,
+	NodeHopGroupUnknown,
+}
+
 // LoggingConfig defines runtime logging behavior.
 type LoggingConfig struct {
 	Level     string `json:"level"`
 	LogToFile bool   `json:"log_to_file"`
+	// MutedNodeEvents maps node IDs to event types left out of the event log.
+	MutedNodeEvents map[string][]NodeEventType `json:"muted_node_events,omitempty"`
+	// SupportUploadURL is an HTTPS endpoint diagnostics bundles are uploaded to on request.
+	SupportUploadURL string `json:"support_upload_url,omitempty"`
+	// RawPacketLog keeps raw radio frames in the database for protocol debugging.
+	RawPacketLog RawPacketLogConfig `json:"raw_packet_log"`
+}
+
+// RawPacketLogConfig controls the log of raw FromRadio/ToRadio frames.
+type RawPacketLogConfig struct {
+	Enabled bool `json:"enabled"`
+	// MaxSizeMB caps the stored frames; the oldest frames are dropped first.
+	MaxSizeMB int `json:"max_size_mb"`
+}
+
+// MaxBytes returns the size cap of the log in bytes.
+func (c RawPacketLogConfig) MaxBytes() int64 {
+	return int64(c.MaxSizeMB) * 1024 * 1024
+}
+
+// NodeEventTypes lists every node event type that can be muted.
+func NodeEventTypes() []NodeEventType {
+	return []NodeEventType{NodeEventCore, NodeEventPosition, NodeEventTelemetry}
+}
+
+// IsNodeEventMuted reports whether events of the given type from the node are muted.
+func (c LoggingConfig) IsNodeEventMuted(nodeID string, event NodeEventType) bool {
+	for _, muted := range c.MutedNodeEvents[normalizeMutedNodeID(nodeID)] {
+		if muted == event {
+			return true
+		}
+	}
+
+	return false
 }
 
 // ConnectionConfig contains transport-specific connection parameters.
@@ -64,6 +191,107 @@ type UIConfig struct {
 	MapViewport      MapViewportConfig  `json:"map_viewport"`
 	MapDisplay       MapDisplayConfig   `json:"map_display"`
 	Notifications    NotificationConfig `json:"notifications"`
+	TaskbarFlash     TaskbarFlashConfig `json:"taskbar_flash"`
+	Sounds           SoundsConfig       `json:"sounds"`
+	Formats          FormatsConfig      `json:"formats"`
+	Display          DisplayConfig      `json:"display"`
+	ChatList         ChatListConfig     `json:"chat_list"`
+	NodeList         NodeListConfig     `json:"node_list"`
+	CloseButton      CloseButtonAction  `json:"close_button"`
+	// Language is the UI language code. Empty follows the system locale.
+	Language string `json:"language,omitempty"`
+	// Shortcuts overrides keyboard shortcuts by action name, e.g. "search": "Ctrl+F".
+	// An empty value or "none" disables the shortcut.
+	Shortcuts map[string]string `json:"shortcuts,omitempty"`
+	// ChannelColors overrides the automatic color of channel chats by chat key. Values
+	// are AccentColors.
+	ChannelColors map[string]string `json:"channel_colors,omitempty"`
+}
+
+// ChannelColor returns the color picked for the channel chat, or "" when the chat uses
+// the automatic one.
+func (c UIConfig) ChannelColor(chatKey string) string {
+	return c.ChannelColors[strings.TrimSpace(chatKey)]
+}
+
+// SetChannelColor records the color of the channel chat. An empty or unknown color
+// restores the automatic one.
+func (c *UIConfig) SetChannelColor(chatKey, color string) {
+	chatKey = strings.TrimSpace(chatKey)
+	if chatKey == "" {
+		return
+	}
+	colors := maps.Clone(c.ChannelColors)
+	if colors == nil {
+		colors = make(map[string]string, 1)
+	}
+	if color = normalizeAccentColor(color); color == "" {
+		delete(colors, chatKey)
+	} else {
+		colors[chatKey] = color
+	}
+	if len(colors) == 0 {
+		colors = nil
+	}
+	c.ChannelColors = colors
+}
+
+// TaskbarFlashConfig controls highlighting the taskbar entry when messages arrive while
+// the window is unfocused. It works independently of notifications.
+type TaskbarFlashConfig struct {
+	Enabled bool `json:"enabled"`
+	// Chats overrides the per-chat default by chat key.
+	Chats map[string]bool `json:"chats,omitempty"`
+}
+
+// FlashesChat reports whether messages in the chat flash the taskbar. byDefault is used
+// for chats without an override.
+func (c TaskbarFlashConfig) FlashesChat(chatKey string, byDefault bool) bool {
+	if enabled, ok := c.Chats[strings.TrimSpace(chatKey)]; ok {
+		return enabled
+	}
+
+	return byDefault
+}
+
+// SetChat records whether the chat flashes the taskbar, dropping overrides that match
+// the default.
+func (c *TaskbarFlashConfig) SetChat(chatKey string, enabled, byDefault bool) {
+	chatKey = strings.TrimSpace(chatKey)
+	if chatKey == "" {
+		return
+	}
+	chats := maps.Clone(c.Chats)
+	if chats == nil {
+		chats = make(map[string]bool, 1)
+	}
+	if enabled == byDefault {
+		delete(chats, chatKey)
+	} else {
+		chats[chatKey] = enabled
+	}
+	if len(chats) == 0 {
+		chats = nil
+	}
+	c.Chats = chats
+}
+
+// ChatListConfig stores the sorting and filter chosen for the chat list.
+type ChatListConfig struct {
+	Sort   ChatListSort   `json:"sort"`
+	Filter ChatListFilter `json:"filter"`
+}
+
+// NodeListConfig stores the columns, sorting and grouping of the node list. Without
+// columns the list shows node cards.
+type NodeListConfig struct {
+	Columns        []NodeListColumn `json:"columns,omitempty"`
+	SortBy         NodeListColumn   `json:"sort_by"`
+	SortDescending bool             `json:"sort_descending"`
+	// GroupByHops sections the list by how many hops away the nodes are. Collapsed
+	// sections only show their header.
+	GroupByHops     bool           `json:"group_by_hops,omitempty"`
+	CollapsedGroups []NodeHopGroup `json:"collapsed_groups,omitempty"`
 }
 
 // MessagingConfig stores outgoing-message UI preferences.
@@ -90,12 +318,118 @@ type MapDisplayConfig struct {
 	ShowPrecisionCircles            bool            `json:"show_precision_circles"`
 	ShowPrecisionCirclesOnlyOnHover bool            `json:"show_precision_circles_only_on_hover"`
 	MapLinkProvider                 MapLinkProvider `json:"map_link_provider"`
+	// TileCacheSizeMB caps the on-disk map tile cache; the least recently used tiles are dropped first.
+	TileCacheSizeMB int `json:"tile_cache_size_mb"`
+}
+
+// TileCacheMaxBytes returns the size cap of the map tile cache in bytes.
+func (c MapDisplayConfig) TileCacheMaxBytes() int64 {
+	return int64(c.TileCacheSizeMB) * 1024 * 1024
 }
 
 // NotificationConfig stores desktop notification preferences.
 type NotificationConfig struct {
-	NotifyWhenFocused bool                     `json:"notify_when_focused"`
-	Events            NotificationEventsConfig `json:"events"`
+	NotifyWhenFocused bool                 `json:"notify_when_focused"`
+	MessageGrouping   NotificationGrouping `json:"message_grouping"`
+	// ClickAction is applied where the desktop reports notification clicks.
+	ClickAction NotificationClickAction  `json:"click_action"`
+	Events      NotificationEventsConfig `json:"events"`
+	// MutedNodes lists the nodes whose messages are stored but never notified about.
+	MutedNodes []string `json:"muted_nodes,omitempty"`
+	// Muted silences desktop notifications and sounds until unmuted. Notifications are
+	// still listed in the notification center.
+	Muted bool `json:"muted,omitempty"`
+	// MutedUntil ends a temporary mute. Zero keeps notifications muted until unmuted.
+	MutedUntil   time.Time          `json:"muted_until,omitzero"`
+	DoNotDisturb DoNotDisturbConfig `json:"do_not_disturb"`
+	// LowBatteryPercent is the battery level at or below which the local node or a
+	// favorite alerts. Zero means DefaultLowBatteryPercent.
+	LowBatteryPercent int `json:"low_battery_percent,omitempty"`
+	// NodePresence sets when favorite nodes alert about going silent and being heard
+	// again, by node ID.
+	NodePresence map[string]NodePresenceAlert `json:"node_presence,omitempty"`
+}
+
+// NodePresenceAlert sets when a favorite node alerts about going silent or being heard
+// again. Zero disables an alert.
+type NodePresenceAlert struct {
+	// SilentMinutes alerts once the node has not been heard for this long.
+	SilentMinutes int `json:"silent_minutes,omitempty"`
+	// HeardAgainMinutes alerts when the node is heard after being silent this long.
+	HeardAgainMinutes int `json:"heard_again_minutes,omitempty"`
+}
+
+// Enabled reports whether any of the alerts is on.
+func (a NodePresenceAlert) Enabled() bool {
+	return a.SilentMinutes > 0 || a.HeardAgainMinutes > 0
+}
+
+// PresenceAlert returns the presence alerts of the node.
+func (c NotificationConfig) PresenceAlert(nodeID string) NodePresenceAlert {
+	return c.NodePresence[strings.TrimSpace(nodeID)]
+}
+
+// SetPresenceAlert records the presence alerts of the node. Disabled alerts are dropped.
+func (c *NotificationConfig) SetPresenceAlert(nodeID string, alert NodePresenceAlert) {
+	nodeID = strings.TrimSpace(nodeID)
+	if nodeID == "" {
+		return
+	}
+	nodes := maps.Clone(c.NodePresence)
+	if nodes == nil {
+		nodes = make(map[string]NodePresenceAlert, 1)
+	}
+	if alert = normalizeNodePresenceAlert(alert); alert.Enabled() {
+		nodes[nodeID] = alert
+	} else {
+		delete(nodes, nodeID)
+	}
+	if len(nodes) == 0 {
+		nodes = nil
+	}
+	c.NodePresence = nodes
+}
+
+// MutedAt reports whether notifications and sounds are muted at now.
+func (c NotificationConfig) MutedAt(now time.Time) bool {
+	if !c.Muted {
+		return false
+	}
+
+	return c.MutedUntil.IsZero() || now.Before(c.MutedUntil)
+}
+
+// LowBatteryThreshold returns the battery percentage at or below which a low battery
+// alert is sent.
+func (c NotificationConfig) LowBatteryThreshold() int {
+	if c.LowBatteryPercent <= 0 || c.LowBatteryPercent >= 100 {
+		return DefaultLowBatteryPercent
+	}
+
+	return c.LowBatteryPercent
+}
+
+// MutesNode reports whether notifications about messages from the node are muted.
+func (c NotificationConfig) MutesNode(nodeID string) bool {
+	nodeID = strings.TrimSpace(nodeID)
+
+	return nodeID != "" && slices.Contains(c.MutedNodes, nodeID)
+}
+
+// SetNodeMuted mutes or unmutes notifications about messages from the node.
+func (c *NotificationConfig) SetNodeMuted(nodeID string, muted bool) {
+	nodeID = strings.TrimSpace(nodeID)
+	if nodeID == "" || c.MutesNode(nodeID) == muted {
+		return
+	}
+	nodes := slices.DeleteFunc(slices.Clone(c.MutedNodes), func(mutedID string) bool { return mutedID == nodeID })
+	if muted {
+		nodes = append(nodes, nodeID)
+	}
+	if len(nodes) == 0 {
+		nodes = nil
+	}
+	c.MutedNodes = nodes
 }
 
 // NotificationEventsConfig stores per-event notification toggles.
@@ -104,11 +438,21 @@ type NotificationEventsConfig struct {
 	NodeDiscovered   bool `json:"node_discovered"`
 	ConnectionStatus bool `json:"connection_status"`
 	UpdateAvailable  bool `json:"update_available"`
+	// LowBattery alerts when the local node or a favorite runs low on battery.
+	LowBattery bool `json:"low_battery"`
+	// NodePresence alerts when a favorite goes silent or is heard again, as set for
+	// the node in NotificationConfig.NodePresence.
+	NodePresence bool `json:"node_presence"`
 }
 
 // PersistenceConfig stores persistence behavior and retention settings.
 type PersistenceConfig struct {
 	HistoryLimits HistoryLimitsConfig `json:"history_limits"`
+	// DeletedRetentionDays is how long deleted chats and nodes stay restorable before purge.
+	DeletedRetentionDays int `json:"deleted_retention_days"`
+	// EncryptDatabase stores the database encrypted with a key kept in the OS keyring.
+	// Changing it converts the database file on the next start.
+	EncryptDatabase bool `json:"encrypt_database"`
 }
 
 // HistoryLimitsConfig stores per-table node history row caps.
@@ -117,14 +461,19 @@ type HistoryLimitsConfig struct {
 	Position  *int `json:"position"`
 	Telemetry *int `json:"telemetry"`
 	Identity  *int `json:"identity"`
+	Signal    *int `json:"signal"`
 }
 
-// AppConfig is the root persisted application configuration.
+// AppConfig is the root persisted application configuration. Configs are passed around
+// by value, so copies share their maps and slices: setters replace them with an edited
+// clone and never change them in place.
 type AppConfig struct {
 	Connection  ConnectionConfig  `json:"connection"`
 	Logging     LoggingConfig     `json:"logging"`
 	Persistence PersistenceConfig `json:"persistence"`
 	UI          UIConfig          `json:"ui"`
+	// RecentConnections are the endpoints that were connected to, most recent first.
+	RecentConnections []ConnectionConfig `json:"recent_connections,omitempty"`
 }
 
 func Default() AppConfig {
@@ -141,9 +490,13 @@ func Default() AppConfig {
 		Logging: LoggingConfig{
 			Level:     "info",
 			LogToFile: false,
+			RawPacketLog: RawPacketLogConfig{
+				MaxSizeMB: DefaultRawPacketLogMaxSizeMB,
+			},
 		},
 		Persistence: PersistenceConfig{
-			HistoryLimits: defaultHistoryLimitsConfig(),
+			HistoryLimits:        defaultHistoryLimitsConfig(),
+			DeletedRetentionDays: DefaultDeletedRetentionDays,
 		},
 		UI: UIConfig{
 			LastSelectedChat: "",
@@ -155,16 +508,24 @@ func Default() AppConfig {
 				CompactCyrillicEncoding: false,
 			},
 			MapViewport: MapViewportConfig{},
-			MapDisplay:  MapDisplayConfig{},
+			MapDisplay:  MapDisplayConfig{TileCacheSizeMB: DefaultMapTileCacheSizeMB},
 			Notifications: NotificationConfig{
 				NotifyWhenFocused: false,
+				MessageGrouping:   NotificationGroupingChat,
+				ClickAction:       NotificationClickOpenChat,
 				Events: NotificationEventsConfig{
 					IncomingMessage:  true,
 					NodeDiscovered:   true,
 					ConnectionStatus: true,
 					UpdateAvailable:  true,
+					LowBattery:       true,
+					NodePresence:     true,
 				},
+				DoNotDisturb: defaultDoNotDisturbConfig(),
 			},
+			Formats:     defaultFormatsConfig(),
+			Sounds:      defaultSoundsConfig(),
+			CloseButton: CloseButtonAsk,
 		},
 	}
 }
@@ -189,6 +550,7 @@ func Load(path string) (AppConfig, error) {
 
 	// Check for deprecated "connector" field and migrate if needed.
 	cfg = migrateDeprecatedConnector(raw, cfg)
+	cfg = migrateDirectMessageSound(raw, cfg)
 
 	cfg.FillMissingDefaults()
 
@@ -213,7 +575,70 @@ func (c *AppConfig) FillMissingDefaults() {
 	c.UI.Autostart.Mode = normalizeAutostartMode(c.UI.Autostart.Mode)
 	c.UI.MapViewport = normalizeMapViewport(c.UI.MapViewport)
 	c.UI.MapDisplay = normalizeMapDisplay(c.UI.MapDisplay)
+	c.UI.Notifications.MessageGrouping = normalizeNotificationGrouping(c.UI.Notifications.MessageGrouping)
+	c.UI.Notifications.ClickAction = normalizeNotificationClickAction(c.UI.Notifications.ClickAction)
+	c.UI.CloseButton = normalizeCloseButtonAction(c.UI.CloseButton)
+	c.UI.Notifications.DoNotDisturb = normalizeDoNotDisturbConfig(c.UI.Notifications.DoNotDisturb)
+	c.UI.Notifications.NodePresence = normalizeNodePresence(c.UI.Notifications.NodePresence)
+	if !c.UI.Notifications.Muted {
+		c.UI.Notifications.MutedUntil = time.Time{}
+	}
+	c.UI.Formats = normalizeFormatsConfig(c.UI.Formats)
+	c.UI.Display = normalizeDisplayConfig(c.UI.Display)
+	c.UI.ChatList = normalizeChatListConfig(c.UI.ChatList)
+	c.UI.NodeList = normalizeNodeListConfig(c.UI.NodeList)
+	c.RecentConnections = normalizeRecentConnections(c.RecentConnections)
+	c.UI.Language = strings.ToLower(strings.TrimSpace(c.UI.Language))
+	c.UI.Shortcuts = normalizeShortcutOverrides(c.UI.Shortcuts)
+	c.UI.ChannelColors = normalizeChannelColors(c.UI.ChannelColors)
+	c.UI.Sounds = normalizeSoundsConfig(c.UI.Sounds)
+	c.Logging.MutedNodeEvents = normalizeMutedNodeEvents(c.Logging.MutedNodeEvents)
+	if c.Logging.RawPacketLog.MaxSizeMB <= 0 {
+		c.Logging.RawPacketLog.MaxSizeMB = DefaultRawPacketLogMaxSizeMB
+	}
 	c.Persistence.HistoryLimits = normalizeHistoryLimitsConfig(c.Persistence.HistoryLimits)
+	if c.Persistence.DeletedRetentionDays <= 0 {
+		c.Persistence.DeletedRetentionDays = DefaultDeletedRetentionDays
+	}
+}
+
+func normalizeShortcutOverrides(overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(overrides))
+	for action, key := range overrides {
+		action = strings.ToLower(strings.TrimSpace(action))
+		if action == "" {
+			continue
+		}
+		out[action] = strings.TrimSpace(key)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+
+	return out
+}
+
+func normalizeChannelColors(colors map[string]string) map[string]string {
+	if len(colors) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(colors))
+	for chatKey, color := range colors {
+		chatKey = strings.TrimSpace(chatKey)
+		color = normalizeAccentColor(color)
+		if chatKey == "" || color == "" {
+			continue
+		}
+		out[chatKey] = color
+	}
+	if len(out) == 0 {
+		return nil
+	}
+
+	return out
 }
 
 func normalizeAutostartMode(mode AutostartMode) AutostartMode {
@@ -251,15 +676,200 @@ func normalizeMapDisplay(display MapDisplayConfig) MapDisplayConfig {
 	default:
 		display.MapLinkProvider = MapLinkProviderOpenStreetMap
 	}
+	if display.TileCacheSizeMB <= 0 {
+		display.TileCacheSizeMB = DefaultMapTileCacheSizeMB
+	}
 
 	return display
 }
 
+func normalizeNotificationGrouping(grouping NotificationGrouping) NotificationGrouping {
+	switch grouping {
+	case NotificationGroupingSender, NotificationGroupingGlobal:
+		return grouping
+	default:
+		return NotificationGroupingChat
+	}
+}
+
+func normalizeChatListConfig(list ChatListConfig) ChatListConfig {
+	switch list.Sort {
+	case ChatListSortUnreadFirst, ChatListSortAlphabetical:
+	default:
+		list.Sort = ChatListSortRecent
+	}
+	switch list.Filter {
+	case ChatListFilterChannels, ChatListFilterDMs, ChatListFilterUnread:
+	default:
+		list.Filter = ChatListFilterAll
+	}
+
+	return list
+}
+
+// normalizeNodeListConfig drops unknown and repeated columns and groups and falls back
+// to the most recently heard nodes first.
+func normalizeNodeListConfig(list NodeListConfig) NodeListConfig {
+	var columns []NodeListColumn
+	for _, column := range list.Columns {
+		if slices.Contains(NodeListColumns, column) && !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+	list.Columns = columns
+	var collapsed []NodeHopGroup
+	for _, group := range list.CollapsedGroups {
+		if slices.Contains(NodeHopGroups, group) && !slices.Contains(collapsed, group) {
+			collapsed = append(collapsed, group)
+		}
+	}
+	list.CollapsedGroups = collapsed
+	if !slices.Contains(NodeListColumns, list.SortBy) {
+		list.SortBy = NodeListColumnLastHeard
+		list.SortDescending = true
+	}
+
+	return list
+}
+
+func normalizeNotificationClickAction(action NotificationClickAction) NotificationClickAction {
+	switch action {
+	case NotificationClickShowWindow:
+		return NotificationClickShowWindow
+	default:
+		return NotificationClickOpenChat
+	}
+}
+
+func normalizeNodePresenceAlert(alert NodePresenceAlert) NodePresenceAlert {
+	alert.SilentMinutes = max(alert.SilentMinutes, 0)
+	alert.HeardAgainMinutes = max(alert.HeardAgainMinutes, 0)
+
+	return alert
+}
+
+func normalizeNodePresence(nodes map[string]NodePresenceAlert) map[string]NodePresenceAlert {
+	if len(nodes) == 0 {
+		return nil
+	}
+	out := make(map[string]NodePresenceAlert, len(nodes))
+	for nodeID, alert := range nodes {
+		nodeID = strings.TrimSpace(nodeID)
+		if alert = normalizeNodePresenceAlert(alert); nodeID != "" && alert.Enabled() {
+			out[nodeID] = alert
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+
+	return out
+}
+
+func normalizeCloseButtonAction(action CloseButtonAction) CloseButtonAction {
+	switch action {
+	case CloseButtonHideToTray, CloseButtonQuit:
+		return action
+	default:
+		return CloseButtonAsk
+	}
+}
+
+// ParseMutedNodeEvents parses entries like "!1234abcd: position, telemetry".
+// Entries are separated by newlines or semicolons.
+func ParseMutedNodeEvents(spec string) (map[string][]NodeEventType, error) {
+	out := make(map[string][]NodeEventType)
+	entries := strings.FieldsFunc(spec, func(r rune) bool { return r == '\n' || r == ';' })
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		nodeID, events, ok := strings.Cut(entry, ":")
+		nodeID = normalizeMutedNodeID(nodeID)
+		if !ok || nodeID == "" {
+			return nil, fmt.Errorf("muted node events entry %q must look like node_id:event[,event]", entry)
+		}
+		for _, raw := range strings.Split(events, ",") {
+			event := NodeEventType(strings.ToLower(strings.TrimSpace(raw)))
+			if event == "" {
+				continue
+			}
+			if !containsNodeEventType(NodeEventTypes(), event) {
+				return nil, fmt.Errorf("unknown node event type %q for %s", event, nodeID)
+			}
+			out[nodeID] = append(out[nodeID], event)
+		}
+	}
+
+	return normalizeMutedNodeEvents(out), nil
+}
+
+// FormatMutedNodeEvents renders muted events one node per line, sorted by node ID.
+func FormatMutedNodeEvents(muted map[string][]NodeEventType) string {
+	nodeIDs := make([]string, 0, len(muted))
+	for nodeID := range muted {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+
+	lines := make([]string, 0, len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		events := make([]string, 0, len(muted[nodeID]))
+		for _, event := range muted[nodeID] {
+			events = append(events, string(event))
+		}
+		lines = append(lines, nodeID+": "+strings.Join(events, ", "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func normalizeMutedNodeEvents(muted map[string][]NodeEventType) map[string][]NodeEventType {
+	if len(muted) == 0 {
+		return nil
+	}
+
+	out := make(map[string][]NodeEventType, len(muted))
+	for nodeID, events := range muted {
+		nodeID = normalizeMutedNodeID(nodeID)
+		if nodeID == "" {
+			continue
+		}
+		// Keep the canonical order and drop unknown or repeated types.
+		for _, known := range NodeEventTypes() {
+			if containsNodeEventType(events, known) && !containsNodeEventType(out[nodeID], known) {
+				out[nodeID] = append(out[nodeID], known)
+			}
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+
+	return out
+}
+
+func normalizeMutedNodeID(nodeID string) string {
+	return strings.ToLower(strings.TrimSpace(nodeID))
+}
+
+func containsNodeEventType(events []NodeEventType, event NodeEventType) bool {
+	for _, item := range events {
+		if item == event {
+			return true
+		}
+	}
+
+	return false
+}
+
 func defaultHistoryLimitsConfig() HistoryLimitsConfig {
 	return HistoryLimitsConfig{
 		Position:  intPtr(DefaultPositionHistoryLimit),
 		Telemetry: intPtr(DefaultTelemetryHistoryLimit),
 		Identity:  intPtr(DefaultIdentityHistoryLimit),
+		Signal:    intPtr(DefaultSignalHistoryLimit),
 	}
 }
 
@@ -274,6 +884,9 @@ func normalizeHistoryLimitsConfig(limits HistoryLimitsConfig) HistoryLimitsConfi
 	if limits.Identity == nil {
 		limits.Identity = intPtr(*defaults.Identity)
 	}
+	if limits.Signal == nil {
+		limits.Signal = intPtr(*defaults.Signal)
+	}
 
 	return limits
 }
@@ -284,6 +897,21 @@ func intPtr(v int) *int {
 	return &value
 }
 
+// validateSupportUploadURL accepts an empty value or an absolute HTTPS URL: bundles carry
+// logs and settings, so they must not travel in plain text.
+func validateSupportUploadURL(raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return errors.New("support upload URL must be an https:// URL")
+	}
+
+	return nil
+}
+
 func (c AppConfig) Validate() error {
 	switch c.Connection.Transport {
 	case TransportIP:
@@ -304,6 +932,9 @@ func (c AppConfig) Validate() error {
 	default:
 		return fmt.Errorf("unknown transport: %s", c.Connection.Transport)
 	}
+	if err := validateSupportUploadURL(c.Logging.SupportUploadURL); err != nil {
+		return err
+	}
 	if c.Persistence.HistoryLimits.Position != nil && *c.Persistence.HistoryLimits.Position < 0 {
 		return errors.New("position history limit must be non-negative")
 	}
@@ -38,3 +38,23 @@ func migrateDeprecatedConnector(raw []byte, cfg AppConfig) AppConfig {
 
 	return cfg
 }
+
+// migrateDirectMessageSound keeps direct messages playing the received message sound in
+// configs written before direct messages got a sound of their own.
+func migrateDirectMessageSound(raw []byte, cfg AppConfig) AppConfig {
+	var rawMap struct {
+		UI struct {
+			Sounds map[string]json.RawMessage `json:"sounds"`
+		} `json:"ui"`
+	}
+	if err := json.Unmarshal(raw, &rawMap); err != nil {
+		return cfg
+	}
+	_, hasReceive := rawMap.UI.Sounds["receive"]
+	_, hasDirect := rawMap.UI.Sounds["direct"]
+	if hasReceive && !hasDirect {
+		cfg.UI.Sounds.Direct = cfg.UI.Sounds.Receive
+	}
+
+	return cfg
+}
@@ -169,3 +169,32 @@ func containsSubstringHelper(s, substr string) bool {
 
 	return false
 }
+
+func TestLoadKeepsReceiveSoundForDirectMessages(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.json")
+	oldConfig := `{
+  "ui": {
+    "sounds": {
+      "enabled": true,
+      "receive": {"enabled": false, "sound": "bell", "volume": 40}
+    }
+  }
+}`
+	if err := os.WriteFile(configPath, []byte(oldConfig), 0o600); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	want := SoundEventConfig{Sound: SoundBell, Volume: 40}
+	if cfg.UI.Sounds.Direct != want {
+		t.Fatalf("expected the direct message sound %+v, got %+v", want, cfg.UI.Sounds.Direct)
+	}
+	if cfg.UI.Sounds.Alert != defaultSoundsConfig().Alert {
+		t.Fatalf("expected the default alert sound, got %+v", cfg.UI.Sounds.Alert)
+	}
+}
@@ -3,7 +3,10 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestAppConfigFillMissingDefaults(t *testing.T) {
@@ -49,6 +52,18 @@ func TestAppConfigFillMissingDefaults(t *testing.T) {
 	if cfg.Persistence.HistoryLimits.Identity == nil || *cfg.Persistence.HistoryLimits.Identity != DefaultIdentityHistoryLimit {
 		t.Fatalf("expected default identity history limit %d, got %v", DefaultIdentityHistoryLimit, cfg.Persistence.HistoryLimits.Identity)
 	}
+	if cfg.Persistence.HistoryLimits.Signal == nil || *cfg.Persistence.HistoryLimits.Signal != DefaultSignalHistoryLimit {
+		t.Fatalf("expected default signal history limit %d, got %v", DefaultSignalHistoryLimit, cfg.Persistence.HistoryLimits.Signal)
+	}
+	if cfg.Persistence.DeletedRetentionDays != DefaultDeletedRetentionDays {
+		t.Fatalf("expected default deleted retention %d days, got %d", DefaultDeletedRetentionDays, cfg.Persistence.DeletedRetentionDays)
+	}
+	if cfg.Logging.RawPacketLog.Enabled {
+		t.Fatalf("expected raw packet log to be disabled by default")
+	}
+	if cfg.Logging.RawPacketLog.MaxBytes() != DefaultRawPacketLogMaxSizeMB*1024*1024 {
+		t.Fatalf("expected default raw packet log cap %d MB, got %d bytes", DefaultRawPacketLogMaxSizeMB, cfg.Logging.RawPacketLog.MaxBytes())
+	}
 }
 
 func TestCompactCyrillicEncodingPersistence(t *testing.T) {
@@ -309,6 +324,9 @@ func TestAppConfigFillMissingDefaultsNormalizesMapDisplay(t *testing.T) {
 	if cfg.UI.MapDisplay.MapLinkProvider != MapLinkProviderOpenStreetMap {
 		t.Fatalf("expected invalid map link provider to normalize to %q, got %q", MapLinkProviderOpenStreetMap, cfg.UI.MapDisplay.MapLinkProvider)
 	}
+	if cfg.UI.MapDisplay.TileCacheSizeMB != DefaultMapTileCacheSizeMB {
+		t.Fatalf("expected missing tile cache size to default to %d MB, got %d", DefaultMapTileCacheSizeMB, cfg.UI.MapDisplay.TileCacheSizeMB)
+	}
 }
 
 func TestAppConfigFillMissingDefaultsKeepsKnownMapLinkProvider(t *testing.T) {
@@ -339,6 +357,152 @@ func TestAppConfigFillMissingDefaultsKeepsKnownMapLinkProvider(t *testing.T) {
 	}
 }
 
+func TestAppConfigFillMissingDefaultsNormalizesNotificationGrouping(t *testing.T) {
+	tests := []struct {
+		in   NotificationGrouping
+		want NotificationGrouping
+	}{
+		{in: "", want: NotificationGroupingChat},
+		{in: NotificationGrouping("invalid"), want: NotificationGroupingChat},
+		{in: NotificationGroupingChat, want: NotificationGroupingChat},
+		{in: NotificationGroupingSender, want: NotificationGroupingSender},
+		{in: NotificationGroupingGlobal, want: NotificationGroupingGlobal},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.in), func(t *testing.T) {
+			cfg := AppConfig{UI: UIConfig{Notifications: NotificationConfig{MessageGrouping: tc.in}}}
+
+			cfg.FillMissingDefaults()
+			if cfg.UI.Notifications.MessageGrouping != tc.want {
+				t.Fatalf("expected message grouping %q, got %q", tc.want, cfg.UI.Notifications.MessageGrouping)
+			}
+		})
+	}
+}
+
+func TestAppConfigFillMissingDefaultsNormalizesChatList(t *testing.T) {
+	tests := []struct {
+		name string
+		in   ChatListConfig
+		want ChatListConfig
+	}{
+		{name: "empty", in: ChatListConfig{}, want: ChatListConfig{Sort: ChatListSortRecent, Filter: ChatListFilterAll}},
+		{name: "invalid", in: ChatListConfig{Sort: "size", Filter: "muted"}, want: ChatListConfig{Sort: ChatListSortRecent, Filter: ChatListFilterAll}},
+		{name: "valid", in: ChatListConfig{Sort: ChatListSortAlphabetical, Filter: ChatListFilterDMs}, want: ChatListConfig{Sort: ChatListSortAlphabetical, Filter: ChatListFilterDMs}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := AppConfig{UI: UIConfig{ChatList: tc.in}}
+
+			cfg.FillMissingDefaults()
+			if cfg.UI.ChatList != tc.want {
+				t.Fatalf("expected chat list %+v, got %+v", tc.want, cfg.UI.ChatList)
+			}
+		})
+	}
+}
+
+func TestAppConfigFillMissingDefaultsNormalizesNodeList(t *testing.T) {
+	tests := []struct {
+		name string
+		in   NodeListConfig
+		want NodeListConfig
+	}{
+		{
+			name: "empty sorts by last heard",
+			in:   NodeListConfig{},
+			want: NodeListConfig{SortBy: NodeListColumnLastHeard, SortDescending: true},
+		},
+		{
+			name: "drops unknown and repeated columns",
+			in: NodeListConfig{
+				Columns: []NodeListColumn{NodeListColumnSNR, "color", NodeListColumnSNR, NodeListColumnHops},
+				SortBy:  NodeListColumnHops,
+			},
+			want: NodeListConfig{Columns: []NodeListColumn{NodeListColumnSNR, NodeListColumnHops}, SortBy: NodeListColumnHops},
+		},
+		{
+			name: "unknown sort column",
+			in:   NodeListConfig{SortBy: "color"},
+			want: NodeListConfig{SortBy: NodeListColumnLastHeard, SortDescending: true},
+		},
+		{
+			name: "drops unknown and repeated collapsed groups",
+			in: NodeListConfig{
+				SortBy:          NodeListColumnHops,
+				GroupByHops:     true,
+				CollapsedGroups: []NodeHopGroup{NodeHopGroupMQTT, "far", NodeHopGroupMQTT, NodeHopGroupDirect},
+			},
+			want: NodeListConfig{
+				SortBy:          NodeListColumnHops,
+				GroupByHops:     true,
+				CollapsedGroups: []NodeHopGroup{NodeHopGroupMQTT, NodeHopGroupDirect},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := AppConfig{UI: UIConfig{NodeList: tc.in}}
+
+			cfg.FillMissingDefaults()
+			got := cfg.UI.NodeList
+			if !slices.Equal(got.Columns, tc.want.Columns) || got.SortBy != tc.want.SortBy || got.SortDescending != tc.want.SortDescending ||
+				got.GroupByHops != tc.want.GroupByHops || !slices.Equal(got.CollapsedGroups, tc.want.CollapsedGroups) {
+				t.Fatalf("expected node list %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAppConfigFillMissingDefaultsNormalizesNotificationClickAction(t *testing.T) {
+	tests := []struct {
+		in   NotificationClickAction
+		want NotificationClickAction
+	}{
+		{in: "", want: NotificationClickOpenChat},
+		{in: NotificationClickAction("invalid"), want: NotificationClickOpenChat},
+		{in: NotificationClickOpenChat, want: NotificationClickOpenChat},
+		{in: NotificationClickShowWindow, want: NotificationClickShowWindow},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.in), func(t *testing.T) {
+			cfg := AppConfig{UI: UIConfig{Notifications: NotificationConfig{ClickAction: tc.in}}}
+
+			cfg.FillMissingDefaults()
+			if cfg.UI.Notifications.ClickAction != tc.want {
+				t.Fatalf("expected click action %q, got %q", tc.want, cfg.UI.Notifications.ClickAction)
+			}
+		})
+	}
+}
+
+func TestAppConfigFillMissingDefaultsNormalizesCloseButtonAction(t *testing.T) {
+	tests := []struct {
+		in   CloseButtonAction
+		want CloseButtonAction
+	}{
+		{in: "", want: CloseButtonAsk},
+		{in: CloseButtonAction("minimize"), want: CloseButtonAsk},
+		{in: CloseButtonHideToTray, want: CloseButtonHideToTray},
+		{in: CloseButtonQuit, want: CloseButtonQuit},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.in), func(t *testing.T) {
+			cfg := AppConfig{UI: UIConfig{CloseButton: tc.in}}
+
+			cfg.FillMissingDefaults()
+			if cfg.UI.CloseButton != tc.want {
+				t.Fatalf("expected close button action %q, got %q", tc.want, cfg.UI.CloseButton)
+			}
+		})
+	}
+}
+
 func TestAppConfigFillMissingDefaultsEnablesBluetoothTestingForBluetoothTransport(t *testing.T) {
 	cfg := AppConfig{
 		Connection: ConnectionConfig{
@@ -428,6 +592,21 @@ func TestAppConfigValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid support upload url",
+			cfg: AppConfig{
+				Connection: ConnectionConfig{Transport: TransportIP, Host: "192.168.1.10"},
+				Logging:    LoggingConfig{SupportUploadURL: "https://support.example.org/upload"},
+			},
+		},
+		{
+			name: "plain http support upload url",
+			cfg: AppConfig{
+				Connection: ConnectionConfig{Transport: TransportIP, Host: "192.168.1.10"},
+				Logging:    LoggingConfig{SupportUploadURL: "http://support.example.org/upload"},
+			},
+			wantErr: true,
+		},
 		{
 			name: "unknown transport",
 			cfg: AppConfig{
@@ -495,3 +674,362 @@ func TestLoadWithEmptyConnectionSection(t *testing.T) {
 		t.Fatalf("expected default transport %q, got %q", TransportIP, cfg.Connection.Transport)
 	}
 }
+
+func TestParseMutedNodeEvents(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[string][]NodeEventType
+		wantErr bool
+	}{
+		{name: "empty", spec: "  ", want: nil},
+		{
+			name: "lines and semicolons",
+			spec: "!1234ABCD: telemetry, position, position\n!00000001:core;!00000002: position",
+			want: map[string][]NodeEventType{
+				"!1234abcd": {NodeEventPosition, NodeEventTelemetry},
+				"!00000001": {NodeEventCore},
+				"!00000002": {NodeEventPosition},
+			},
+		},
+		{name: "node without events is dropped", spec: "!1234abcd:", want: nil},
+		{name: "missing separator", spec: "!1234abcd position", wantErr: true},
+		{name: "unknown event", spec: "!1234abcd: weather", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseMutedNodeEvents(tc.spec)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMutedNodeEventsRoundTripAndLookup(t *testing.T) {
+	cfg := AppConfig{Logging: LoggingConfig{MutedNodeEvents: map[string][]NodeEventType{
+		" !1234ABCD ": {NodeEventTelemetry, "bogus", NodeEventPosition},
+		"!00000001":   {"bogus"},
+	}}}
+	cfg.FillMissingDefaults()
+
+	if got, want := FormatMutedNodeEvents(cfg.Logging.MutedNodeEvents), "!1234abcd: position, telemetry"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if !cfg.Logging.IsNodeEventMuted("!1234ABCD", NodeEventPosition) {
+		t.Fatalf("expected position events to be muted")
+	}
+	if cfg.Logging.IsNodeEventMuted("!1234abcd", NodeEventCore) {
+		t.Fatalf("expected core events to stay unmuted")
+	}
+	parsed, err := ParseMutedNodeEvents(FormatMutedNodeEvents(cfg.Logging.MutedNodeEvents))
+	if err != nil {
+		t.Fatalf("parse formatted value: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, cfg.Logging.MutedNodeEvents) {
+		t.Fatalf("expected %v after round trip, got %v", cfg.Logging.MutedNodeEvents, parsed)
+	}
+}
+
+func TestAppConfigFillMissingDefaultsNormalizesFormats(t *testing.T) {
+	cfg := AppConfig{UI: UIConfig{Formats: FormatsConfig{
+		TimeFormat:       TimeFormat12h,
+		DateFormat:       DateFormat("yyyy"),
+		DecimalSeparator: DecimalSeparator(""),
+		TemperatureUnit:  TemperatureUnitFahrenheit,
+		CoordinateFormat: CoordinateFormat("utm"),
+		MessageTimestamp: MessageTimestamp("ago"),
+	}}}
+
+	cfg.FillMissingDefaults()
+	want := FormatsConfig{
+		TimeFormat:       TimeFormat12h,
+		DateFormat:       DateFormatAuto,
+		DecimalSeparator: DecimalSeparatorAuto,
+		TemperatureUnit:  TemperatureUnitFahrenheit,
+		CoordinateFormat: CoordinateFormatDecimal,
+		MessageTimestamp: MessageTimestampAbsolute,
+	}
+	if cfg.UI.Formats != want {
+		t.Fatalf("expected formats %+v, got %+v", want, cfg.UI.Formats)
+	}
+
+	for _, format := range []CoordinateFormat{CoordinateFormatDMS, CoordinateFormatMGRS, CoordinateFormatMaidenhead} {
+		cfg := AppConfig{UI: UIConfig{Formats: FormatsConfig{CoordinateFormat: format}}}
+		cfg.FillMissingDefaults()
+		if cfg.UI.Formats.CoordinateFormat != format {
+			t.Fatalf("expected coordinate format %q to be kept, got %q", format, cfg.UI.Formats.CoordinateFormat)
+		}
+	}
+
+	relative := AppConfig{UI: UIConfig{Formats: FormatsConfig{MessageTimestamp: MessageTimestampRelative, ChatDaySeparators: true}}}
+	relative.FillMissingDefaults()
+	if relative.UI.Formats.MessageTimestamp != MessageTimestampRelative || !relative.UI.Formats.ChatDaySeparators {
+		t.Fatalf("expected relative timestamps with day separators to be kept, got %+v", relative.UI.Formats)
+	}
+}
+
+func TestTaskbarFlashConfigChatOverrides(t *testing.T) {
+	var cfg TaskbarFlashConfig
+	if !cfg.FlashesChat("dm:!1234abcd", true) || cfg.FlashesChat("channel:0", false) {
+		t.Fatalf("expected chats without overrides to follow the default")
+	}
+
+	cfg.SetChat("channel:0", true, false)
+	saved := cfg
+	cfg.SetChat("dm:!1234abcd", false, true)
+	if !cfg.FlashesChat("channel:0", false) || cfg.FlashesChat("dm:!1234abcd", true) {
+		t.Fatalf("expected overrides to apply, got %+v", cfg.Chats)
+	}
+	if _, ok := saved.Chats["dm:!1234abcd"]; ok {
+		t.Fatalf("expected earlier copies to keep their overrides")
+	}
+
+	cfg.SetChat("channel:0", false, false)
+	cfg.SetChat("dm:!1234abcd", true, true)
+	if cfg.Chats != nil {
+		t.Fatalf("expected overrides matching the default to be dropped, got %+v", cfg.Chats)
+	}
+}
+
+func TestNotificationConfigMutedNodes(t *testing.T) {
+	var cfg NotificationConfig
+	if cfg.MutesNode("!1234abcd") {
+		t.Fatalf("expected nodes to be unmuted by default")
+	}
+
+	cfg.SetNodeMuted("!1234abcd", true)
+	saved := cfg
+	cfg.SetNodeMuted(" !00000001 ", true)
+	cfg.SetNodeMuted("!1234abcd", true)
+	if !cfg.MutesNode("!1234abcd") || !cfg.MutesNode("!00000001") || len(cfg.MutedNodes) != 2 {
+		t.Fatalf("expected both nodes muted once, got %v", cfg.MutedNodes)
+	}
+	if saved.MutesNode("!00000001") {
+		t.Fatalf("expected earlier copies to keep their muted nodes")
+	}
+
+	cfg.SetNodeMuted("!1234abcd", false)
+	cfg.SetNodeMuted("!00000001", false)
+	if cfg.MutedNodes != nil {
+		t.Fatalf("expected no muted nodes left, got %v", cfg.MutedNodes)
+	}
+}
+
+func TestNotificationConfigPresenceAlerts(t *testing.T) {
+	var cfg NotificationConfig
+	if cfg.PresenceAlert("!1234abcd").Enabled() {
+		t.Fatalf("expected nodes to have no presence alerts by default")
+	}
+
+	cfg.SetPresenceAlert(" !1234abcd ", NodePresenceAlert{SilentMinutes: 120})
+	saved := cfg
+	cfg.SetPresenceAlert("!00000001", NodePresenceAlert{SilentMinutes: -5, HeardAgainMinutes: 60})
+	if got := cfg.PresenceAlert("!1234abcd"); got != (NodePresenceAlert{SilentMinutes: 120}) {
+		t.Fatalf("expected the silent alert to be kept, got %+v", got)
+	}
+	if got := cfg.PresenceAlert("!00000001"); got != (NodePresenceAlert{HeardAgainMinutes: 60}) {
+		t.Fatalf("expected negative minutes to be dropped, got %+v", got)
+	}
+	if saved.PresenceAlert("!00000001").Enabled() {
+		t.Fatalf("expected earlier copies to keep their presence alerts")
+	}
+
+	cfg.SetPresenceAlert("!1234abcd", NodePresenceAlert{})
+	cfg.SetPresenceAlert("!00000001", NodePresenceAlert{})
+	if cfg.NodePresence != nil {
+		t.Fatalf("expected no presence alerts left, got %v", cfg.NodePresence)
+	}
+}
+
+func TestNotificationConfigMutedAt(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		cfg  NotificationConfig
+		want bool
+	}{
+		{name: "not muted", cfg: NotificationConfig{MutedUntil: now.Add(time.Hour)}, want: false},
+		{name: "muted until unmuted", cfg: NotificationConfig{Muted: true}, want: true},
+		{name: "temporary mute active", cfg: NotificationConfig{Muted: true, MutedUntil: now.Add(time.Minute)}, want: true},
+		{name: "temporary mute expired", cfg: NotificationConfig{Muted: true, MutedUntil: now}, want: false},
+	}
+	for _, tt := range tests {
+		if got := tt.cfg.MutedAt(now); got != tt.want {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	cfg := AppConfig{UI: UIConfig{Notifications: NotificationConfig{MutedUntil: now}}}
+	cfg.FillMissingDefaults()
+	if !cfg.UI.Notifications.MutedUntil.IsZero() {
+		t.Fatalf("expected the mute end to be dropped when not muted, got %v", cfg.UI.Notifications.MutedUntil)
+	}
+}
+
+func TestUIConfigChannelColors(t *testing.T) {
+	var cfg UIConfig
+	if got := cfg.ChannelColor("channel:0"); got != "" {
+		t.Fatalf("expected channels to use the automatic color, got %q", got)
+	}
+
+	cfg.SetChannelColor("channel:0", " Green ")
+	saved := cfg
+	cfg.SetChannelColor("channel:1", "purple")
+	if got := cfg.ChannelColor("channel:0"); got != "green" {
+		t.Fatalf("unexpected channel color: expected %q, got %q", "green", got)
+	}
+	if saved.ChannelColor("channel:1") != "" {
+		t.Fatalf("expected earlier copies to keep their colors")
+	}
+
+	cfg.SetChannelColor("channel:0", "")
+	cfg.SetChannelColor("channel:1", "teal")
+	if cfg.ChannelColors != nil {
+		t.Fatalf("expected empty and unknown colors to restore the automatic one, got %v", cfg.ChannelColors)
+	}
+
+	full := AppConfig{UI: UIConfig{ChannelColors: map[string]string{"channel:0": "RED", "channel:1": "teal", " ": "blue"}}}
+	full.FillMissingDefaults()
+	if len(full.UI.ChannelColors) != 1 || full.UI.ChannelColor("channel:0") != "red" {
+		t.Fatalf("expected only known colors to be kept, got %v", full.UI.ChannelColors)
+	}
+}
+
+func TestDisplayConfigScalePerMonitor(t *testing.T) {
+	var cfg DisplayConfig
+	if got := cfg.ScaleFor(MonitorKey(1)); got != 1 {
+		t.Fatalf("expected 100%% without saved scales, got %v", got)
+	}
+
+	laptop := MonitorKey(2)
+	cfg.SetScale(laptop, 1.27)
+	saved := cfg
+	cfg.SetScale(MonitorKey(1), 0.9)
+	if got := cfg.ScaleFor(laptop); got != 1.25 {
+		t.Fatalf("expected the laptop scale to be rounded to 1.25, got %v", got)
+	}
+	if got := cfg.ScaleFor(MonitorKey(1.5)); got != 0.9 {
+		t.Fatalf("expected unknown monitors to use the last scale, got %v", got)
+	}
+	if _, ok := saved.MonitorScales[MonitorKey(1)]; ok {
+		t.Fatalf("expected earlier copies to keep their scales")
+	}
+
+	cfg.SetScale(laptop, 10)
+	if got := cfg.ScaleFor(laptop); got != MaxUIScale {
+		t.Fatalf("expected the scale to be clamped to %v, got %v", MaxUIScale, got)
+	}
+}
+
+func TestAppConfigFillMissingDefaultsNormalizesDisplay(t *testing.T) {
+	cfg := Default()
+	cfg.UI.Display = DisplayConfig{Scale: 0.1, MonitorScales: map[string]float64{" 2.00 ": 1.5, "1.00": -1, "": 2}}
+	cfg.FillMissingDefaults()
+
+	if cfg.UI.Display.Scale != MinUIScale {
+		t.Fatalf("expected the scale to be clamped to %v, got %v", MinUIScale, cfg.UI.Display.Scale)
+	}
+	if len(cfg.UI.Display.MonitorScales) != 1 || cfg.UI.Display.MonitorScales["2.00"] != 1.5 {
+		t.Fatalf("expected only the valid monitor scale to be kept, got %+v", cfg.UI.Display.MonitorScales)
+	}
+}
+
+func TestAppConfigFillMissingDefaultsNormalizesSounds(t *testing.T) {
+	cfg := Default()
+	cfg.UI.Sounds = SoundsConfig{
+		Enabled: true,
+		Send:    SoundEventConfig{Sound: "  ", Volume: 150},
+		Receive: SoundEventConfig{Enabled: true, Sound: " /home/me/ding.wav ", Volume: 25},
+	}
+	cfg.FillMissingDefaults()
+
+	if cfg.UI.Sounds.Send.Sound != SoundClick || cfg.UI.Sounds.Send.Volume != DefaultSendSoundVolume || cfg.UI.Sounds.Send.Enabled {
+		t.Fatalf("expected the send sound to fall back to defaults while staying disabled, got %+v", cfg.UI.Sounds.Send)
+	}
+	want := SoundEventConfig{Enabled: true, Sound: "/home/me/ding.wav", Volume: 25}
+	if cfg.UI.Sounds.Receive != want {
+		t.Fatalf("expected %+v, got %+v", want, cfg.UI.Sounds.Receive)
+	}
+}
+
+func TestDisplayConfigLowBatteryThreshold(t *testing.T) {
+	tests := []struct {
+		percent int
+		want    int
+	}{
+		{percent: 0, want: DefaultLowBatteryPercent},
+		{percent: 35, want: 35},
+		{percent: 100, want: DefaultLowBatteryPercent},
+		{percent: -5, want: DefaultLowBatteryPercent},
+	}
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.UI.Display.LowBatteryPercent = tt.percent
+		cfg.FillMissingDefaults()
+		if got := cfg.UI.Display.LowBatteryThreshold(); got != tt.want {
+			t.Fatalf("unexpected threshold for %d%%: expected %d, got %d", tt.percent, tt.want, got)
+		}
+	}
+}
+
+func TestAppConfigFillMissingDefaultsNormalizesTheme(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    DisplayConfig
+		want  ThemeMode
+		tray  TrayIconStyle
+		color string
+	}{
+		{name: "empty", want: ThemeModeSystem, tray: TrayIconStyleAuto},
+		{name: "known values", in: DisplayConfig{Theme: ThemeModeLight, TrayIcon: TrayIconStyleDark, AccentColor: " Purple "}, want: ThemeModeLight, tray: TrayIconStyleDark, color: "purple"},
+		{name: "high contrast", in: DisplayConfig{Theme: ThemeModeHighContrast}, want: ThemeModeHighContrast, tray: TrayIconStyleAuto},
+		{name: "unknown values", in: DisplayConfig{Theme: "sepia", TrayIcon: "blue", AccentColor: "teal"}, want: ThemeModeSystem, tray: TrayIconStyleAuto},
+	}
+
+	for _, tt := range tests {
+		cfg := Default()
+		cfg.UI.Display = tt.in
+		cfg.FillMissingDefaults()
+		got := cfg.UI.Display
+		if got.Theme != tt.want || got.TrayIcon != tt.tray || got.AccentColor != tt.color {
+			t.Fatalf("%s: expected %v/%v/%q, got %v/%v/%q", tt.name, tt.want, tt.tray, tt.color, got.Theme, got.TrayIcon, got.AccentColor)
+		}
+	}
+}
+
+func TestAppConfigFillMissingDefaultsNormalizesLanguage(t *testing.T) {
+	cfg := Default()
+	cfg.UI.Language = " RU "
+	cfg.FillMissingDefaults()
+	if cfg.UI.Language != "ru" {
+		t.Fatalf("expected language %q, got %q", "ru", cfg.UI.Language)
+	}
+}
+
+func TestAppConfigFillMissingDefaultsNormalizesShortcuts(t *testing.T) {
+	cfg := Default()
+	cfg.UI.Shortcuts = map[string]string{" Search ": " Ctrl+G ", "": "Ctrl+H", "send": ""}
+	cfg.FillMissingDefaults()
+	want := map[string]string{"search": "Ctrl+G", "send": ""}
+	if !reflect.DeepEqual(cfg.UI.Shortcuts, want) {
+		t.Fatalf("expected shortcuts %v, got %v", want, cfg.UI.Shortcuts)
+	}
+
+	cfg.UI.Shortcuts = map[string]string{" ": "Ctrl+H"}
+	cfg.FillMissingDefaults()
+	if cfg.UI.Shortcuts != nil {
+		t.Fatalf("expected nil shortcuts, got %v", cfg.UI.Shortcuts)
+	}
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"maps"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// UI scale limits and the slider step.
+const (
+	MinUIScale  = 0.5
+	MaxUIScale  = 2.5
+	UIScaleStep = 0.05
+)
+
+// ThemeMode selects the light or dark look of the app.
+type ThemeMode string
+
+const (
+	ThemeModeSystem ThemeMode = "system"
+	ThemeModeDark   ThemeMode = "dark"
+	ThemeModeLight  ThemeMode = "light"
+	// ThemeModeHighContrast is a dark look with pure colors and strong outlines.
+	ThemeModeHighContrast ThemeMode = "high_contrast"
+)
+
+// TrayIconStyle selects the tray icon variant. System trays are often dark while the
+// app is light, or the other way round, so the icon can be chosen separately.
+type TrayIconStyle string
+
+const (
+	TrayIconStyleAuto  TrayIconStyle = "auto"
+	TrayIconStyleDark  TrayIconStyle = "dark"
+	TrayIconStyleLight TrayIconStyle = "light"
+)
+
+// AccentColors are the accent color names supported by the UI toolkit.
+var AccentColors = []string{"red", "orange", "yellow", "green", "blue", "purple", "brown", "gray"}
+
+// DisplayConfig stores the UI scale on top of the system scale and the app theme.
+// Laptops that are docked and undocked show the window on monitors with different pixel
+// densities, so a scale can be kept per monitor.
+type DisplayConfig struct {
+	// Scale is used on monitors without their own scale. Zero means 100%.
+	Scale float64 `json:"scale,omitempty"`
+	// MonitorScales maps a monitor key from MonitorKey to the scale used there.
+	MonitorScales map[string]float64 `json:"monitor_scales,omitempty"`
+	Theme         ThemeMode          `json:"theme,omitempty"`
+	// AccentColor is one of AccentColors. Empty follows the system accent.
+	AccentColor string `json:"accent_color,omitempty"`
+	// TrayIcon is the background the tray icon is drawn for.
+	TrayIcon TrayIconStyle `json:"tray_icon,omitempty"`
+	// LowBatteryPercent is the battery level at or below which the node list highlights
+	// the battery. Zero means DefaultLowBatteryPercent.
+	LowBatteryPercent int `json:"low_battery_percent,omitempty"`
+}
+
+// DefaultLowBatteryPercent is the low battery threshold used until the user picks one.
+const DefaultLowBatteryPercent = 20
+
+// LowBatteryThreshold returns the battery percentage at or below which a battery is low.
+func (c DisplayConfig) LowBatteryThreshold() int {
+	if c.LowBatteryPercent <= 0 {
+		return DefaultLowBatteryPercent
+	}
+
+	return c.LowBatteryPercent
+}
+
+// MonitorKey identifies a monitor by the pixel scale the system reports for it. Fyne
+// does not expose monitor names, and the density is what the UI scale compensates for.
+func MonitorKey(pixelScale float32) string {
+	if pixelScale <= 0 {
+		pixelScale = 1
+	}
+
+	return strconv.FormatFloat(float64(pixelScale), 'f', 2, 32)
+}
+
+// ScaleFor returns the UI scale for the monitor.
+func (c DisplayConfig) ScaleFor(monitor string) float64 {
+	if scale, ok := c.MonitorScales[strings.TrimSpace(monitor)]; ok {
+		return scale
+	}
+	if c.Scale > 0 {
+		return c.Scale
+	}
+
+	return 1
+}
+
+// SetScale records the scale for the monitor and makes it the default for monitors
+// without their own scale.
+func (c *DisplayConfig) SetScale(monitor string, scale float64) {
+	scale = normalizeUIScale(scale)
+	scales := maps.Clone(c.MonitorScales)
+	if scales == nil {
+		scales = make(map[string]float64, 1)
+	}
+	if monitor = strings.TrimSpace(monitor); monitor != "" {
+		scales[monitor] = scale
+	}
+	c.MonitorScales = scales
+	c.Scale = scale
+}
+
+func normalizeDisplayConfig(display DisplayConfig) DisplayConfig {
+	if display.Scale != 0 {
+		display.Scale = normalizeUIScale(display.Scale)
+	}
+	switch display.Theme {
+	case ThemeModeDark, ThemeModeLight, ThemeModeHighContrast:
+	default:
+		display.Theme = ThemeModeSystem
+	}
+	switch display.TrayIcon {
+	case TrayIconStyleDark, TrayIconStyleLight:
+	default:
+		display.TrayIcon = TrayIconStyleAuto
+	}
+	display.AccentColor = normalizeAccentColor(display.AccentColor)
+	if display.LowBatteryPercent < 0 || display.LowBatteryPercent >= 100 {
+		display.LowBatteryPercent = 0
+	}
+	if len(display.MonitorScales) == 0 {
+		display.MonitorScales = nil
+
+		return display
+	}
+	scales := make(map[string]float64, len(display.MonitorScales))
+	for key, value := range display.MonitorScales {
+		key = strings.TrimSpace(key)
+		if key == "" || value <= 0 {
+			continue
+		}
+		scales[key] = normalizeUIScale(value)
+	}
+	display.MonitorScales = scales
+
+	return display
+}
+
+// normalizeUIScale clamps the scale to the supported range and rounds it to the slider step.
+func normalizeUIScale(scale float64) float64 {
+	if scale <= 0 || math.IsNaN(scale) {
+		return 1
+	}
+	scale = math.Round(scale/UIScaleStep) * UIScaleStep
+
+	return math.Round(math.Min(MaxUIScale, math.Max(MinUIScale, scale))*100) / 100
+}
+
+func normalizeAccentColor(accent string) string {
+	accent = strings.ToLower(strings.TrimSpace(accent))
+	for _, known := range AccentColors {
+		if accent == known {
+			return accent
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultDoNotDisturbStart = "23:00"
+	DefaultDoNotDisturbEnd   = "07:00"
+)
+
+// DoNotDisturbConfig is a daily schedule that silences desktop notifications and the
+// sounds of received messages. Notifications are still listed in the notification center.
+type DoNotDisturbConfig struct {
+	Enabled bool `json:"enabled"`
+	// Start and End are local clock times like "23:00". A schedule that ends before it
+	// starts runs past midnight.
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// AllowBells lets messages with the alert bell character through.
+	AllowBells bool `json:"allow_bells"`
+}
+
+func defaultDoNotDisturbConfig() DoNotDisturbConfig {
+	return DoNotDisturbConfig{
+		Start:      DefaultDoNotDisturbStart,
+		End:        DefaultDoNotDisturbEnd,
+		AllowBells: true,
+	}
+}
+
+// ActiveAt reports whether the schedule is on and covers the local time of now.
+func (c DoNotDisturbConfig) ActiveAt(now time.Time) bool {
+	if !c.Enabled {
+		return false
+	}
+	start, err := ParseClockMinutes(c.Start)
+	if err != nil {
+		return false
+	}
+	end, err := ParseClockMinutes(c.End)
+	if err != nil || start == end {
+		return false
+	}
+	now = now.Local()
+	minutes := now.Hour()*60 + now.Minute()
+	if start < end {
+		return minutes >= start && minutes < end
+	}
+
+	return minutes >= start || minutes < end
+}
+
+// SilencesAt reports whether a notification is held back at now. Messages that ring the
+// alert bell get through when AllowBells is set.
+func (c DoNotDisturbConfig) SilencesAt(now time.Time, bell bool) bool {
+	return c.ActiveAt(now) && (!bell || !c.AllowBells)
+}
+
+// ParseClockMinutes parses a "15:04" clock time into minutes since midnight.
+func ParseClockMinutes(raw string) (int, error) {
+	at, err := time.Parse("15:04", raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", raw)
+	}
+
+	return at.Hour()*60 + at.Minute(), nil
+}
+
+func normalizeDoNotDisturbConfig(dnd DoNotDisturbConfig) DoNotDisturbConfig {
+	defaults := defaultDoNotDisturbConfig()
+	if _, err := ParseClockMinutes(dnd.Start); err != nil {
+		dnd.Start = defaults.Start
+	}
+	if _, err := ParseClockMinutes(dnd.End); err != nil {
+		dnd.End = defaults.End
+	}
+
+	return dnd
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDoNotDisturbActiveAt(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 3, 10, hour, minute, 0, 0, time.Local)
+	}
+	overnight := DoNotDisturbConfig{Enabled: true, Start: "23:00", End: "07:00"}
+	daytime := DoNotDisturbConfig{Enabled: true, Start: "09:30", End: "17:00"}
+	tests := []struct {
+		name   string
+		config DoNotDisturbConfig
+		now    time.Time
+		want   bool
+	}{
+		{name: "overnight before start", config: overnight, now: at(22, 59), want: false},
+		{name: "overnight at start", config: overnight, now: at(23, 0), want: true},
+		{name: "overnight past midnight", config: overnight, now: at(3, 15), want: true},
+		{name: "overnight at end", config: overnight, now: at(7, 0), want: false},
+		{name: "daytime inside", config: daytime, now: at(12, 0), want: true},
+		{name: "daytime before start", config: daytime, now: at(9, 29), want: false},
+		{name: "disabled", config: DoNotDisturbConfig{Start: "00:00", End: "23:59"}, now: at(12, 0), want: false},
+		{name: "empty window", config: DoNotDisturbConfig{Enabled: true, Start: "08:00", End: "08:00"}, now: at(8, 0), want: false},
+		{name: "invalid time", config: DoNotDisturbConfig{Enabled: true, Start: "late", End: "07:00"}, now: at(3, 0), want: false},
+	}
+	for _, tc := range tests {
+		if got := tc.config.ActiveAt(tc.now); got != tc.want {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestAppConfigFillMissingDefaultsNormalizesDoNotDisturb(t *testing.T) {
+	cfg := Default()
+	if dnd := cfg.UI.Notifications.DoNotDisturb; dnd.Enabled || dnd.Start != DefaultDoNotDisturbStart || dnd.End != DefaultDoNotDisturbEnd || !dnd.AllowBells {
+		t.Fatalf("unexpected default do not disturb config: %+v", dnd)
+	}
+
+	cfg.UI.Notifications.DoNotDisturb = DoNotDisturbConfig{Enabled: true, Start: "25:00", End: "06:30"}
+	cfg.FillMissingDefaults()
+	if got := cfg.UI.Notifications.DoNotDisturb; got.Start != DefaultDoNotDisturbStart || got.End != "06:30" {
+		t.Fatalf("expected an invalid start to be reset and a valid end to be kept, got %+v", got)
+	}
+}
@@ -0,0 +1,103 @@
+package config
+
+// TimeFormat selects the clock style for displayed times.
+type TimeFormat string
+
+// DateFormat selects the order of date components for displayed dates.
+type DateFormat string
+
+// DecimalSeparator selects the separator used for displayed fractional numbers.
+type DecimalSeparator string
+
+// TemperatureUnit selects the unit for displayed temperatures.
+type TemperatureUnit string
+
+// CoordinateFormat selects how latitude and longitude are displayed.
+type CoordinateFormat string
+
+// MessageTimestamp selects whether chat messages show the clock time or the time elapsed.
+type MessageTimestamp string
+
+// "auto" values are resolved from the system locale by the UI.
+const (
+	TimeFormatAuto TimeFormat = "auto"
+	TimeFormat24h  TimeFormat = "24h"
+	TimeFormat12h  TimeFormat = "12h"
+
+	DateFormatAuto DateFormat = "auto"
+	DateFormatISO  DateFormat = "iso"
+	DateFormatDMY  DateFormat = "dmy"
+	DateFormatMDY  DateFormat = "mdy"
+
+	DecimalSeparatorAuto  DecimalSeparator = "auto"
+	DecimalSeparatorPoint DecimalSeparator = "point"
+	DecimalSeparatorComma DecimalSeparator = "comma"
+
+	TemperatureUnitAuto       TemperatureUnit = "auto"
+	TemperatureUnitCelsius    TemperatureUnit = "celsius"
+	TemperatureUnitFahrenheit TemperatureUnit = "fahrenheit"
+
+	CoordinateFormatDecimal    CoordinateFormat = "dd"
+	CoordinateFormatDMS        CoordinateFormat = "dms"
+	CoordinateFormatMGRS       CoordinateFormat = "mgrs"
+	CoordinateFormatMaidenhead CoordinateFormat = "maidenhead"
+
+	MessageTimestampAbsolute MessageTimestamp = "absolute"
+	MessageTimestampRelative MessageTimestamp = "relative"
+)
+
+// FormatsConfig stores locale-dependent display preferences.
+type FormatsConfig struct {
+	TimeFormat       TimeFormat       `json:"time_format"`
+	DateFormat       DateFormat       `json:"date_format"`
+	DecimalSeparator DecimalSeparator `json:"decimal_separator"`
+	TemperatureUnit  TemperatureUnit  `json:"temperature_unit"`
+	CoordinateFormat CoordinateFormat `json:"coordinate_format"`
+	MessageTimestamp MessageTimestamp `json:"message_timestamp"`
+	// ChatDaySeparators shows the date above the first message of each day in chats.
+	ChatDaySeparators bool `json:"chat_day_separators"`
+}
+
+func defaultFormatsConfig() FormatsConfig {
+	return FormatsConfig{
+		TimeFormat:       TimeFormatAuto,
+		DateFormat:       DateFormatAuto,
+		DecimalSeparator: DecimalSeparatorAuto,
+		TemperatureUnit:  TemperatureUnitAuto,
+		CoordinateFormat: CoordinateFormatDecimal,
+		MessageTimestamp: MessageTimestampAbsolute,
+	}
+}
+
+func normalizeFormatsConfig(formats FormatsConfig) FormatsConfig {
+	switch formats.TimeFormat {
+	case TimeFormat24h, TimeFormat12h:
+	default:
+		formats.TimeFormat = TimeFormatAuto
+	}
+	switch formats.DateFormat {
+	case DateFormatISO, DateFormatDMY, DateFormatMDY:
+	default:
+		formats.DateFormat = DateFormatAuto
+	}
+	switch formats.DecimalSeparator {
+	case DecimalSeparatorPoint, DecimalSeparatorComma:
+	default:
+		formats.DecimalSeparator = DecimalSeparatorAuto
+	}
+	switch formats.TemperatureUnit {
+	case TemperatureUnitCelsius, TemperatureUnitFahrenheit:
+	default:
+		formats.TemperatureUnit = TemperatureUnitAuto
+	}
+	switch formats.CoordinateFormat {
+	case CoordinateFormatDMS, CoordinateFormatMGRS, CoordinateFormatMaidenhead:
+	default:
+		formats.CoordinateFormat = CoordinateFormatDecimal
+	}
+	if formats.MessageTimestamp != MessageTimestampRelative {
+		formats.MessageTimestamp = MessageTimestampAbsolute
+	}
+
+	return formats
+}
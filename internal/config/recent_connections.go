@@ -0,0 +1,118 @@
+package config
+
+import "strings"
+
+// MaxRecentConnectionsPerTransport is how many recent endpoints are kept for each
+// transport type.
+const MaxRecentConnectionsPerTransport = 5
+
+// RememberConnection puts conn first in the recent endpoints, dropping an older entry
+// for the same endpoint and the oldest ones over MaxRecentConnectionsPerTransport. It
+// reports whether the list changed.
+func (c *AppConfig) RememberConnection(conn ConnectionConfig) bool {
+	conn = recentConnectionEntry(conn)
+	if recentConnectionKey(conn) == "" {
+		return false
+	}
+	if len(c.RecentConnections) > 0 && c.RecentConnections[0] == conn {
+		return false
+	}
+	next := make([]ConnectionConfig, 0, len(c.RecentConnections)+1)
+	next = append(next, conn)
+	for _, existing := range c.RecentConnections {
+		if sameRecentConnection(existing, conn) {
+			continue
+		}
+		next = append(next, existing)
+	}
+	c.RecentConnections = normalizeRecentConnections(next)
+
+	return true
+}
+
+// RecentConnectionsFor returns the recent endpoints of transport, most recent first.
+func (c AppConfig) RecentConnectionsFor(transport TransportType) []ConnectionConfig {
+	out := make([]ConnectionConfig, 0, MaxRecentConnectionsPerTransport)
+	for _, conn := range c.RecentConnections {
+		if conn.Transport == transport {
+			out = append(out, conn)
+		}
+	}
+
+	return out
+}
+
+// recentConnectionEntry keeps only the fields of conn that its transport uses.
+func recentConnectionEntry(conn ConnectionConfig) ConnectionConfig {
+	entry := ConnectionConfig{Transport: conn.Transport}
+	switch conn.Transport {
+	case TransportIP:
+		entry.Host = strings.TrimSpace(conn.Host)
+	case TransportSerial:
+		entry.SerialPort = strings.TrimSpace(conn.SerialPort)
+		entry.SerialBaud = conn.SerialBaud
+		if entry.SerialBaud <= 0 {
+			entry.SerialBaud = DefaultSerialBaud
+		}
+	case TransportBluetooth:
+		entry.BluetoothAddress = strings.TrimSpace(conn.BluetoothAddress)
+		entry.BluetoothAdapter = strings.TrimSpace(conn.BluetoothAdapter)
+	}
+
+	return entry
+}
+
+// recentConnectionKey identifies the endpoint of conn. It is empty for entries that
+// can't be connected to.
+func recentConnectionKey(conn ConnectionConfig) string {
+	var target string
+	switch conn.Transport {
+	case TransportIP:
+		target = conn.Host
+	case TransportSerial:
+		target = conn.SerialPort
+	case TransportBluetooth:
+		target = strings.ToUpper(conn.BluetoothAddress)
+	}
+	if target == "" {
+		return ""
+	}
+
+	return string(conn.Transport) + "|" + target
+}
+
+func sameRecentConnection(a, b ConnectionConfig) bool {
+	return recentConnectionKey(a) == recentConnectionKey(b)
+}
+
+// normalizeRecentConnections drops unusable and repeated endpoints and keeps at most
+// MaxRecentConnectionsPerTransport per transport.
+func normalizeRecentConnections(conns []ConnectionConfig) []ConnectionConfig {
+	if len(conns) == 0 {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(conns))
+	perTransport := make(map[TransportType]int)
+	out := make([]ConnectionConfig, 0, len(conns))
+	for _, conn := range conns {
+		conn = recentConnectionEntry(conn)
+		key := recentConnectionKey(conn)
+		if key == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		if perTransport[conn.Transport] >= MaxRecentConnectionsPerTransport {
+			continue
+		}
+		seen[key] = struct{}{}
+		perTransport[conn.Transport]++
+		out = append(out, conn)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+
+	return out
+}
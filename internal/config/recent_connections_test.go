@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestAppConfigRememberConnection(t *testing.T) {
+	cfg := AppConfig{}
+	if !cfg.RememberConnection(ConnectionConfig{Transport: TransportIP, Host: " 10.0.0.1 ", BluetoothTestingEnabled: true}) {
+		t.Fatalf("expected first endpoint to be remembered")
+	}
+	cfg.RememberConnection(ConnectionConfig{Transport: TransportSerial, SerialPort: "COM3", Host: "ignored"})
+	cfg.RememberConnection(ConnectionConfig{Transport: TransportIP, Host: "10.0.0.2"})
+	if !cfg.RememberConnection(ConnectionConfig{Transport: TransportIP, Host: "10.0.0.1"}) {
+		t.Fatalf("expected reused endpoint to move to the front")
+	}
+	if cfg.RememberConnection(ConnectionConfig{Transport: TransportIP, Host: "10.0.0.1"}) {
+		t.Fatalf("expected the most recent endpoint to stay unchanged")
+	}
+	if cfg.RememberConnection(ConnectionConfig{Transport: TransportIP}) {
+		t.Fatalf("expected an endpoint without host to be ignored")
+	}
+
+	want := []ConnectionConfig{
+		{Transport: TransportIP, Host: "10.0.0.1"},
+		{Transport: TransportIP, Host: "10.0.0.2"},
+		{Transport: TransportSerial, SerialPort: "COM3", SerialBaud: DefaultSerialBaud},
+	}
+	if !reflect.DeepEqual(cfg.RecentConnections, want) {
+		t.Fatalf("unexpected recent connections: expected %+v, got %+v", want, cfg.RecentConnections)
+	}
+	serial := cfg.RecentConnectionsFor(TransportSerial)
+	if len(serial) != 1 || serial[0].SerialPort != "COM3" {
+		t.Fatalf("unexpected serial endpoints: %+v", serial)
+	}
+}
+
+func TestAppConfigRememberConnectionKeepsLimitPerTransport(t *testing.T) {
+	cfg := AppConfig{}
+	cfg.RememberConnection(ConnectionConfig{Transport: TransportSerial, SerialPort: "/dev/ttyUSB0"})
+	for i := range MaxRecentConnectionsPerTransport + 2 {
+		cfg.RememberConnection(ConnectionConfig{Transport: TransportIP, Host: fmt.Sprintf("node-%d.local", i)})
+	}
+
+	ip := cfg.RecentConnectionsFor(TransportIP)
+	if len(ip) != MaxRecentConnectionsPerTransport {
+		t.Fatalf("expected %d IP endpoints, got %d", MaxRecentConnectionsPerTransport, len(ip))
+	}
+	if ip[0].Host != fmt.Sprintf("node-%d.local", MaxRecentConnectionsPerTransport+1) {
+		t.Fatalf("expected the newest IP endpoint first, got %q", ip[0].Host)
+	}
+	if len(cfg.RecentConnectionsFor(TransportSerial)) != 1 {
+		t.Fatalf("expected serial endpoint to survive IP history rotation")
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+// Bundled sound names. Any other sound is the path to a WAV file.
+const (
+	SoundChime = "chime"
+	SoundBell  = "bell"
+	SoundPop   = "pop"
+	SoundClick = "click"
+)
+
+// BundledSounds lists the sounds shipped with the app.
+var BundledSounds = []string{SoundChime, SoundBell, SoundPop, SoundClick}
+
+const (
+	// Default sound volumes are in percent.
+	DefaultSendSoundVolume    = 50
+	DefaultReceiveSoundVolume = 70
+	DefaultAlertSoundVolume   = 100
+)
+
+// SoundsConfig stores the sound effects played for chat messages.
+type SoundsConfig struct {
+	// Enabled turns all sound effects on or off; each event can be turned off as well.
+	Enabled bool             `json:"enabled"`
+	Send    SoundEventConfig `json:"send"`
+	// Receive is the sound of channel messages.
+	Receive SoundEventConfig `json:"receive"`
+	// Direct is the sound of direct messages.
+	Direct SoundEventConfig `json:"direct"`
+	// Alert is the sound of messages that ring the alert bell, in any chat.
+	Alert SoundEventConfig `json:"alert"`
+}
+
+// SoundEventConfig stores the sound of one event.
+type SoundEventConfig struct {
+	Enabled bool `json:"enabled"`
+	// Sound is one of BundledSounds or the path to a WAV file.
+	Sound string `json:"sound"`
+	// Volume is in percent of the sound's own loudness, 1-100.
+	Volume int `json:"volume"`
+}
+
+// IsBundledSound reports whether sound names a sound shipped with the app.
+func IsBundledSound(sound string) bool {
+	return slices.Contains(BundledSounds, sound)
+}
+
+func defaultSoundsConfig() SoundsConfig {
+	return SoundsConfig{
+		Send:    SoundEventConfig{Enabled: true, Sound: SoundClick, Volume: DefaultSendSoundVolume},
+		Receive: SoundEventConfig{Enabled: true, Sound: SoundChime, Volume: DefaultReceiveSoundVolume},
+		Direct:  SoundEventConfig{Enabled: true, Sound: SoundPop, Volume: DefaultReceiveSoundVolume},
+		Alert:   SoundEventConfig{Enabled: true, Sound: SoundBell, Volume: DefaultAlertSoundVolume},
+	}
+}
+
+func normalizeSoundsConfig(sounds SoundsConfig) SoundsConfig {
+	defaults := defaultSoundsConfig()
+	sounds.Send = normalizeSoundEventConfig(sounds.Send, defaults.Send)
+	sounds.Receive = normalizeSoundEventConfig(sounds.Receive, defaults.Receive)
+	sounds.Direct = normalizeSoundEventConfig(sounds.Direct, defaults.Direct)
+	sounds.Alert = normalizeSoundEventConfig(sounds.Alert, defaults.Alert)
+
+	return sounds
+}
+
+func normalizeSoundEventConfig(event, defaults SoundEventConfig) SoundEventConfig {
+	event.Sound = strings.TrimSpace(event.Sound)
+	if event.Sound == "" {
+		event.Sound = defaults.Sound
+	}
+	if event.Volume <= 0 || event.Volume > 100 {
+		event.Volume = defaults.Volume
+	}
+
+	return event
+}
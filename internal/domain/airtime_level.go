@@ -0,0 +1,44 @@
+package domain
+
+// Airtime thresholds of the Meshtastic firmware, in percent.
+const (
+	// ChannelUtilizationPolite is where the firmware starts holding back non-essential sends.
+	ChannelUtilizationPolite = 25.0
+	// ChannelUtilizationMax is where the firmware stops all but essential sends.
+	ChannelUtilizationMax = 40.0
+	// AirUtilTxPolite is half the hourly duty cycle of the 10% regions, where the firmware
+	// starts holding back its own broadcasts.
+	AirUtilTxPolite = 5.0
+	// AirUtilTxMax is the hourly duty cycle of the 10% regions such as EU_868.
+	AirUtilTxMax = 10.0
+)
+
+// AirtimeLevel classifies an airtime percentage against the firmware thresholds.
+type AirtimeLevel int
+
+const (
+	AirtimeNormal AirtimeLevel = iota
+	AirtimeElevated
+	AirtimeCritical
+)
+
+// ChannelUtilizationLevel classifies the channel utilization reported by a node.
+func ChannelUtilizationLevel(percent float64) AirtimeLevel {
+	return airtimeLevel(percent, ChannelUtilizationPolite, ChannelUtilizationMax)
+}
+
+// AirUtilTxLevel classifies the TX airtime reported by a node.
+func AirUtilTxLevel(percent float64) AirtimeLevel {
+	return airtimeLevel(percent, AirUtilTxPolite, AirUtilTxMax)
+}
+
+func airtimeLevel(percent, polite, limit float64) AirtimeLevel {
+	switch {
+	case percent >= limit:
+		return AirtimeCritical
+	case percent >= polite:
+		return AirtimeElevated
+	default:
+		return AirtimeNormal
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "testing"
+
+func TestAirtimeLevels(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   func(float64) AirtimeLevel
+		percent float64
+		want    AirtimeLevel
+	}{
+		{name: "quiet channel", level: ChannelUtilizationLevel, percent: 12.5, want: AirtimeNormal},
+		{name: "polite channel", level: ChannelUtilizationLevel, percent: 25, want: AirtimeElevated},
+		{name: "busy channel", level: ChannelUtilizationLevel, percent: 41.2, want: AirtimeCritical},
+		{name: "low tx", level: AirUtilTxLevel, percent: 1.3, want: AirtimeNormal},
+		{name: "polite tx", level: AirUtilTxLevel, percent: 6, want: AirtimeElevated},
+		{name: "tx over duty cycle", level: AirUtilTxLevel, percent: 10, want: AirtimeCritical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.level(tt.percent); got != tt.want {
+				t.Fatalf("unexpected level: expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// BatteryEstimateWindow is how far back battery telemetry is used for a runtime estimate.
+	BatteryEstimateWindow = 12 * time.Hour
+	// batteryLevelPowered is reported by Meshtastic firmware for nodes on external power.
+	batteryLevelPowered = 101
+	// batteryEstimateMinSpan keeps a couple of samples minutes apart from producing wild rates.
+	batteryEstimateMinSpan = 30 * time.Minute
+	// batteryEstimateMinRate is the slowest change, in percent per hour, treated as a trend.
+	batteryEstimateMinRate = 0.2
+	// batteryTrendBreak is the jump against the trend, in percent, that starts a new
+	// charge or discharge cycle, e.g. when a charger is plugged in.
+	batteryTrendBreak = 3
+)
+
+// BatteryEstimate is a linear extrapolation of recent battery telemetry.
+type BatteryEstimate struct {
+	Level uint32
+	// RatePerHour is the battery level change in percent per hour, negative while discharging.
+	RatePerHour float64
+	// TimeToEmpty is set while discharging.
+	TimeToEmpty time.Duration
+	// TimeToFull is set while charging.
+	TimeToFull time.Duration
+}
+
+// Charging reports whether the battery level is rising.
+func (e BatteryEstimate) Charging() bool {
+	return e.RatePerHour > 0
+}
+
+// Remaining returns the time until the battery is empty or full, depending on the trend.
+func (e BatteryEstimate) Remaining() time.Duration {
+	if e.Charging() {
+		return e.TimeToFull
+	}
+
+	return e.TimeToEmpty
+}
+
+// Summary describes the estimate for people, e.g. "about 3h 20m to empty".
+func (e BatteryEstimate) Summary() string {
+	target := "empty"
+	if e.Charging() {
+		target = "full"
+	}
+
+	return "about " + FormatRoughDuration(e.Remaining()) + " to " + target
+}
+
+type batterySample struct {
+	at    time.Time
+	level float64
+}
+
+// EstimateBatteryRuntime fits a line through the battery levels of the current charge or
+// discharge cycle within BatteryEstimateWindow before now. It reports false when the node
+// is on external power or there is too little history to see a trend.
+func EstimateBatteryRuntime(history []NodeTelemetryHistoryEntry, now time.Time) (BatteryEstimate, bool) {
+	samples := make([]batterySample, 0, len(history))
+	for _, entry := range history {
+		if entry.BatteryLevel == nil || entry.ObservedAt.IsZero() {
+			continue
+		}
+		if entry.ObservedAt.Before(now.Add(-BatteryEstimateWindow)) || entry.ObservedAt.After(now) {
+			continue
+		}
+		samples = append(samples, batterySample{at: entry.ObservedAt, level: float64(*entry.BatteryLevel)})
+	}
+	if len(samples) < 3 {
+		return BatteryEstimate{}, false
+	}
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].at.Before(samples[j].at)
+	})
+	latest := samples[len(samples)-1]
+	if latest.level >= batteryLevelPowered {
+		return BatteryEstimate{}, false
+	}
+	samples = currentBatteryCycle(samples)
+	if len(samples) < 3 || latest.at.Sub(samples[0].at) < batteryEstimateMinSpan {
+		return BatteryEstimate{}, false
+	}
+
+	rate := batteryLevelSlopePerHour(samples)
+	if math.Abs(rate) < batteryEstimateMinRate {
+		return BatteryEstimate{}, false
+	}
+	estimate := BatteryEstimate{Level: uint32(latest.level), RatePerHour: rate}
+	if rate > 0 {
+		estimate.TimeToFull = hoursToDuration((100 - latest.level) / rate)
+	} else {
+		estimate.TimeToEmpty = hoursToDuration(latest.level / -rate)
+	}
+
+	return estimate, true
+}
+
+// currentBatteryCycle drops samples from before the last charger plug or unplug. Walking
+// back in time, a discharging battery only gets fuller and a charging one only gets emptier;
+// a sample well against that direction belongs to the previous cycle.
+func currentBatteryCycle(samples []batterySample) []batterySample {
+	last := len(samples) - 1
+	latest := samples[last].level
+	discharging := true
+	for i := last - 1; i >= 0; i-- {
+		if math.Abs(samples[i].level-latest) >= batteryTrendBreak {
+			discharging = samples[i].level > latest
+
+			break
+		}
+	}
+
+	peak, trough := latest, latest
+	for i := last - 1; i >= 0; i-- {
+		level := samples[i].level
+		if level >= batteryLevelPowered ||
+			(discharging && level <= peak-batteryTrendBreak) ||
+			(!discharging && level >= trough+batteryTrendBreak) {
+			return samples[i+1:]
+		}
+		peak, trough = max(peak, level), min(trough, level)
+	}
+
+	return samples
+}
+
+// batteryLevelSlopePerHour is the least squares slope of the level over time.
+func batteryLevelSlopePerHour(samples []batterySample) float64 {
+	origin := samples[0].at
+	var sumX, sumY, sumXY, sumXX float64
+	for _, sample := range samples {
+		x := sample.at.Sub(origin).Hours()
+		sumX += x
+		sumY += sample.level
+		sumXY += x * sample.level
+		sumXX += x * x
+	}
+	n := float64(len(samples))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+func hoursToDuration(hours float64) time.Duration {
+	return time.Duration(hours * float64(time.Hour)).Round(time.Minute)
+}
+
+// FormatRoughDuration keeps the two largest units of a duration, like "3h 10m", which is
+// as precise as an extrapolation or a last heard time gets.
+func FormatRoughDuration(d time.Duration) string {
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	hours := d / time.Hour
+	minutes := (d - hours*time.Hour) / time.Minute
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func batteryHistory(now time.Time, step time.Duration, levels ...uint32) []NodeTelemetryHistoryEntry {
+	out := make([]NodeTelemetryHistoryEntry, 0, len(levels))
+	for i, level := range levels {
+		// History is read newest first, like the repository returns it.
+		out = append([]NodeTelemetryHistoryEntry{{
+			BatteryLevel: &level,
+			ObservedAt:   now.Add(-time.Duration(len(levels)-1-i) * step),
+		}}, out...)
+	}
+
+	return out
+}
+
+func TestEstimateBatteryRuntime(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		history   []NodeTelemetryHistoryEntry
+		wantOK    bool
+		wantEmpty time.Duration
+		wantFull  time.Duration
+	}{
+		{
+			name:      "steady discharge",
+			history:   batteryHistory(now, time.Hour, 80, 78, 76, 74, 72, 70),
+			wantOK:    true,
+			wantEmpty: 35 * time.Hour,
+		},
+		{
+			name:     "steady charge",
+			history:  batteryHistory(now, 30*time.Minute, 40, 45, 50, 55, 60),
+			wantOK:   true,
+			wantFull: 4 * time.Hour,
+		},
+		{
+			name:      "discharge after the charger was unplugged",
+			history:   batteryHistory(now, time.Hour, 40, 60, 80, 90, 88, 86, 84),
+			wantOK:    true,
+			wantEmpty: 42 * time.Hour,
+		},
+		{
+			name:      "discharge after external power",
+			history:   batteryHistory(now, time.Hour, 101, 101, 100, 98, 96),
+			wantOK:    true,
+			wantEmpty: 48 * time.Hour,
+		},
+		{
+			name:    "external power",
+			history: batteryHistory(now, time.Hour, 90, 95, 101),
+		},
+		{
+			name:    "flat level",
+			history: batteryHistory(now, time.Hour, 70, 70, 70, 70),
+		},
+		{
+			name:    "too few samples",
+			history: batteryHistory(now, time.Hour, 80, 70),
+		},
+		{
+			name:    "samples too close together",
+			history: batteryHistory(now, 5*time.Minute, 80, 79, 78, 77),
+		},
+		{
+			name:    "samples outside the window",
+			history: batteryHistory(now.Add(-BatteryEstimateWindow), time.Hour, 80, 78, 76, 74),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			estimate, ok := EstimateBatteryRuntime(tt.history, now)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok=%v, got %v (%+v)", tt.wantOK, ok, estimate)
+			}
+			if !ok {
+				return
+			}
+			if estimate.TimeToEmpty != tt.wantEmpty || estimate.TimeToFull != tt.wantFull {
+				t.Fatalf("unexpected estimate: %+v", estimate)
+			}
+			if estimate.Charging() != (tt.wantFull > 0) || estimate.Remaining() != tt.wantEmpty+tt.wantFull {
+				t.Fatalf("unexpected estimate direction: %+v", estimate)
+			}
+		})
+	}
+}
+
+func TestBatteryEstimateSummary(t *testing.T) {
+	tests := []struct {
+		estimate BatteryEstimate
+		want     string
+	}{
+		{estimate: BatteryEstimate{RatePerHour: -2, TimeToEmpty: 35*time.Hour + 20*time.Minute}, want: "about 1d 11h to empty"},
+		{estimate: BatteryEstimate{RatePerHour: -5, TimeToEmpty: 3*time.Hour + 20*time.Minute}, want: "about 3h 20m to empty"},
+		{estimate: BatteryEstimate{RatePerHour: 20, TimeToFull: 45 * time.Minute}, want: "about 45m to full"},
+	}
+	for _, tt := range tests {
+		if got := tt.estimate.Summary(); got != tt.want {
+			t.Fatalf("Summary() = %q, want %q", got, tt.want)
+		}
+	}
+}
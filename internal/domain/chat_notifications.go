@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ChatNotificationPrefs are per-chat notification settings.
+type ChatNotificationPrefs struct {
+	Muted bool
+	// MutedUntil ends a temporary mute. Zero keeps the chat muted until it is unmuted.
+	MutedUntil time.Time
+	// MentionsOnly notifies only about messages that mention the local node.
+	MentionsOnly bool
+}
+
+// MutedAt reports whether notifications for the chat are muted at the given time.
+func (p ChatNotificationPrefs) MutedAt(now time.Time) bool {
+	if !p.Muted {
+		return false
+	}
+
+	return p.MutedUntil.IsZero() || now.Before(p.MutedUntil)
+}
+
+// MessageBell is the ASCII bell character that Meshtastic clients put in a message to
+// raise an alert on the receiving devices.
+const MessageBell = '\a'
+
+// MessageRingsBell reports whether the text carries the alert bell.
+func MessageRingsBell(text string) bool {
+	return strings.ContainsRune(text, MessageBell)
+}
+
+// MessageMentionsNode reports whether the text mentions the node by ID, long name or
+// short name. Names match case-insensitively as whole words, with or without "@".
+func MessageMentionsNode(text string, node Node) bool {
+	text = strings.ToLower(text)
+	for _, name := range []string{node.NodeID, node.LongName, node.ShortName} {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" && containsWord(text, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsWord(text, word string) bool {
+	for offset := 0; offset < len(text); {
+		idx := strings.Index(text[offset:], word)
+		if idx < 0 {
+			return false
+		}
+		start := offset + idx
+		end := start + len(word)
+		if isWordBoundary(text, start-1) && isWordBoundary(text, end) {
+			return true
+		}
+		offset = start + 1
+	}
+
+	return false
+}
+
+func isWordBoundary(text string, idx int) bool {
+	if idx < 0 || idx >= len(text) {
+		return true
+	}
+	r := rune(text[idx])
+	if r >= 0x80 {
+		// Bytes of multi-byte runes are treated as letters.
+		return false
+	}
+
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+}
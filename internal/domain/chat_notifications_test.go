@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChatNotificationPrefsMutedAt(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		prefs ChatNotificationPrefs
+		want  bool
+	}{
+		{name: "not muted", prefs: ChatNotificationPrefs{}, want: false},
+		{name: "muted until unmuted", prefs: ChatNotificationPrefs{Muted: true}, want: true},
+		{name: "temporary mute active", prefs: ChatNotificationPrefs{Muted: true, MutedUntil: now.Add(time.Minute)}, want: true},
+		{name: "temporary mute expired", prefs: ChatNotificationPrefs{Muted: true, MutedUntil: now}, want: false},
+	}
+
+	for _, tc := range tests {
+		if got := tc.prefs.MutedAt(now); got != tc.want {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestMessageMentionsNode(t *testing.T) {
+	node := Node{NodeID: "!a1b2c3d4", LongName: "Base Camp", ShortName: "BC"}
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{text: "@bc are you there?", want: true},
+		{text: "calling base camp, over", want: true},
+		{text: "ping !A1B2C3D4", want: true},
+		{text: "abc is not a mention", want: false},
+		{text: "bcc someone", want: false},
+		{text: "hello all", want: false},
+	}
+
+	for _, tc := range tests {
+		if got := MessageMentionsNode(tc.text, node); got != tc.want {
+			t.Fatalf("%q: expected %v, got %v", tc.text, tc.want, got)
+		}
+	}
+}
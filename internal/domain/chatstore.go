@@ -2,6 +2,8 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -117,6 +119,11 @@ func (s *ChatStore) UpsertChat(chat Chat) {
 
 	existing, ok := s.chats[chat.Key]
 	if ok {
+		// Notification preferences change only through SetChatNotifications.
+		chat.Notifications = existing.Notifications
+		if existing.ReadUpTo.After(chat.ReadUpTo) {
+			chat.ReadUpTo = existing.ReadUpTo
+		}
 		if !chat.LastSentByMeAt.After(existing.LastSentByMeAt) {
 			chat.LastSentByMeAt = existing.LastSentByMeAt
 		}
@@ -131,12 +138,51 @@ func (s *ChatStore) UpsertChat(chat Chat) {
 	s.notify()
 }
 
+// SetChatNotifications replaces the chat's notification preferences. It returns false
+// when the chat is unknown.
+func (s *ChatStore) SetChatNotifications(chatKey string, prefs ChatNotificationPrefs) bool {
+	chatKey = strings.TrimSpace(chatKey)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chat, ok := s.chats[chatKey]
+	if !ok {
+		return false
+	}
+	chat.Notifications = prefs
+	s.chats[chatKey] = chat
+	s.notify()
+
+	return true
+}
+
+// MarkChatRead moves the read watermark of the chat forward to at. It returns false when
+// the chat is unknown or was already read that far. Listeners are not notified, as no
+// chat list content changes.
+func (s *ChatStore) MarkChatRead(chatKey string, at time.Time) bool {
+	chatKey = strings.TrimSpace(chatKey)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chat, ok := s.chats[chatKey]
+	if !ok || !at.After(chat.ReadUpTo) {
+		return false
+	}
+	chat.ReadUpTo = at
+	s.chats[chatKey] = chat
+
+	return true
+}
+
 func (s *ChatStore) AppendMessage(msg ChatMessage) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if msg.At.IsZero() {
 		msg.At = time.Now()
 	}
+	if s.replaceQueuedLocked(msg) {
+		return
+	}
 	if msg.DeviceMessageID != "" {
 		msgs := s.messages[msg.ChatKey]
 		for i := range msgs {
@@ -200,6 +246,70 @@ func (s *ChatStore) AppendMessage(msg ChatMessage) {
 	s.notify()
 }
 
+// PrependMessages adds older history of a chat, skipping messages that are already
+// loaded, and returns how many were added. It does not notify subscribers: older
+// history changes neither the chat order nor unread counts.
+func (s *ChatStore) PrependMessages(chatKey string, older []ChatMessage) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current := s.messages[chatKey]
+	known := make(map[string]struct{}, len(current))
+	for _, msg := range current {
+		known[MessageIdentity(msg)] = struct{}{}
+	}
+	added := make([]ChatMessage, 0, len(older))
+	for _, msg := range older {
+		identity := MessageIdentity(msg)
+		if _, ok := known[identity]; ok {
+			continue
+		}
+		known[identity] = struct{}{}
+		added = append(added, msg)
+	}
+	if len(added) == 0 {
+		return 0
+	}
+	s.messages[chatKey] = append(added, current...)
+
+	return len(added)
+}
+
+// MessageIdentity tells messages apart by packet id, or by time, direction and text
+// for messages without one.
+func MessageIdentity(msg ChatMessage) string {
+	if id := strings.TrimSpace(msg.DeviceMessageID); id != "" {
+		return "id:" + id
+	}
+
+	return fmt.Sprintf("at:%d:%d:%s", msg.At.UnixMilli(), msg.Direction, msg.Body)
+}
+
+// replaceQueuedLocked gives the outbox entry of a sent message its packet id and status.
+// The entry keeps the time it was written at, so it stays in place in the chat.
+func (s *ChatStore) replaceQueuedLocked(msg ChatMessage) bool {
+	queuedID := strings.TrimSpace(msg.QueuedMessageID)
+	if queuedID == "" {
+		return false
+	}
+	msgs := s.messages[msg.ChatKey]
+	for i := range msgs {
+		if msgs[i].DeviceMessageID != queuedID {
+			continue
+		}
+		msgs[i].DeviceMessageID = msg.DeviceMessageID
+		msgs[i].Status = msg.Status
+		msgs[i].StatusReason = ""
+		if msg.MetaJSON != "" {
+			msgs[i].MetaJSON = msg.MetaJSON
+		}
+		s.notify()
+
+		return true
+	}
+
+	return false
+}
+
 func (s *ChatStore) UpdateMessageStatusByDeviceID(deviceMessageID string, status MessageStatus, reason string) {
 	deviceMessageID = strings.TrimSpace(deviceMessageID)
 	if deviceMessageID == "" || status == 0 {
@@ -278,6 +388,9 @@ func (s *ChatStore) ChatByKey(chatKey string) (Chat, bool) {
 }
 
 func ChatDisplayTitle(chat Chat) string {
+	if IsLoopbackKey(chat.Key) {
+		return LoopbackChatTitle
+	}
 	if title := strings.TrimSpace(chat.Title); title != "" {
 		return title
 	}
@@ -352,6 +465,31 @@ func (s *ChatStore) DeleteChat(chatKey string) {
 	s.notify()
 }
 
+// DeleteMessage removes a message from its chat, matching it by MessageIdentity.
+// It reports whether the message was found.
+func (s *ChatStore) DeleteMessage(msg ChatMessage) bool {
+	if s == nil {
+		return false
+	}
+	identity := MessageIdentity(msg)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msgs := s.messages[msg.ChatKey]
+	for i := range msgs {
+		if MessageIdentity(msgs[i]) != identity {
+			continue
+		}
+		s.messages[msg.ChatKey] = slices.Delete(msgs, i, i+1)
+		s.notify()
+
+		return true
+	}
+
+	return false
+}
+
 func (s *ChatStore) notify() {
 	select {
 	case s.changes <- struct{}{}:
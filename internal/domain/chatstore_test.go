@@ -1,6 +1,10 @@
 package domain
 
-import "testing"
+import (
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestChatStore_AppendMessage_DedupesByDeviceMessageID(t *testing.T) {
 	store := NewChatStore()
@@ -61,6 +65,35 @@ func TestChatStore_AppendMessage_DedupeMergesReplyAndEmoji(t *testing.T) {
 	}
 }
 
+func TestChatStore_AppendMessage_SentMessageReplacesQueuedEntry(t *testing.T) {
+	store := NewChatStore()
+	queued := ChatMessage{
+		ChatKey:         "channel:0",
+		DeviceMessageID: "outbox:1",
+		Direction:       MessageDirectionOut,
+		Body:            "hello",
+		Status:          MessageStatusPending,
+	}
+	store.AppendMessage(queued)
+	store.AppendMessage(ChatMessage{
+		ChatKey:         "channel:0",
+		DeviceMessageID: "100",
+		QueuedMessageID: "outbox:1",
+		Direction:       MessageDirectionOut,
+		Body:            "hello",
+		Status:          MessageStatusPending,
+		MetaJSON:        `{"from":"!00000001"}`,
+	})
+
+	msgs := store.Messages("channel:0")
+	if len(msgs) != 1 {
+		t.Fatalf("expected the queued entry to be replaced, got %d messages", len(msgs))
+	}
+	if msgs[0].DeviceMessageID != "100" || msgs[0].MetaJSON == "" {
+		t.Fatalf("expected the sent packet id and meta, got %+v", msgs[0])
+	}
+}
+
 func TestChatStore_UpdateMessageStatusByDeviceID_SetsFailedReason(t *testing.T) {
 	store := NewChatStore()
 	store.AppendMessage(ChatMessage{
@@ -167,3 +200,124 @@ func TestChatStoreDeleteChat_BlankOrMissingKeyIsNoop(t *testing.T) {
 		t.Fatalf("expected messages to remain, got %d", got)
 	}
 }
+
+func TestChatStoreSetChatNotifications_SurvivesChatUpserts(t *testing.T) {
+	store := NewChatStore()
+	if store.SetChatNotifications("channel:0", ChatNotificationPrefs{Muted: true}) {
+		t.Fatalf("expected unknown chats to be rejected")
+	}
+
+	store.UpsertChat(Chat{Key: "channel:0", Title: "General", Type: ChatTypeChannel})
+	if !store.SetChatNotifications("channel:0", ChatNotificationPrefs{Muted: true, MentionsOnly: true}) {
+		t.Fatalf("expected preferences to be stored")
+	}
+	store.UpsertChat(Chat{Key: "channel:0", Title: "Renamed", Type: ChatTypeChannel})
+	store.AppendMessage(ChatMessage{ChatKey: "channel:0", Direction: MessageDirectionIn, Body: "hi"})
+
+	chat, ok := store.ChatByKey("channel:0")
+	if !ok || !chat.Notifications.Muted || !chat.Notifications.MentionsOnly {
+		t.Fatalf("expected preferences to be kept, got %+v", chat.Notifications)
+	}
+}
+
+func TestChatStoreMarkChatRead_OnlyMovesForward(t *testing.T) {
+	store := NewChatStore()
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if store.MarkChatRead("channel:0", at) {
+		t.Fatalf("expected unknown chats to be rejected")
+	}
+
+	store.UpsertChat(Chat{Key: "channel:0", Title: "General", Type: ChatTypeChannel})
+	if !store.MarkChatRead("channel:0", at) {
+		t.Fatalf("expected the watermark to move")
+	}
+	if store.MarkChatRead("channel:0", at.Add(-time.Minute)) {
+		t.Fatalf("expected an older watermark to be ignored")
+	}
+	store.UpsertChat(Chat{Key: "channel:0", Title: "Renamed", Type: ChatTypeChannel})
+	store.AppendMessage(ChatMessage{ChatKey: "channel:0", Direction: MessageDirectionIn, Body: "hi"})
+
+	chat, ok := store.ChatByKey("channel:0")
+	if !ok || !chat.ReadUpTo.Equal(at) {
+		t.Fatalf("expected the watermark to be kept, got %v", chat.ReadUpTo)
+	}
+}
+
+func TestChatStorePrependMessages_SkipsLoadedMessages(t *testing.T) {
+	store := NewChatStore()
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store.Load(nil, map[string][]ChatMessage{
+		"channel:0": {
+			{ChatKey: "channel:0", DeviceMessageID: "3", Body: "three", At: at.Add(3 * time.Minute)},
+			{ChatKey: "channel:0", Body: "four", At: at.Add(4 * time.Minute)},
+		},
+	})
+	<-store.Changes()
+
+	added := store.PrependMessages("channel:0", []ChatMessage{
+		{ChatKey: "channel:0", DeviceMessageID: "1", Body: "one", At: at.Add(time.Minute)},
+		{ChatKey: "channel:0", Body: "two", At: at.Add(2 * time.Minute)},
+		{ChatKey: "channel:0", DeviceMessageID: "3", Body: "three", At: at.Add(3 * time.Minute)},
+	})
+	if added != 2 {
+		t.Fatalf("expected 2 added messages, got %d", added)
+	}
+	if again := store.PrependMessages("channel:0", []ChatMessage{{ChatKey: "channel:0", Body: "two", At: at.Add(2 * time.Minute)}}); again != 0 {
+		t.Fatalf("expected duplicates to be skipped, got %d added", again)
+	}
+
+	var bodies []string
+	for _, msg := range store.Messages("channel:0") {
+		bodies = append(bodies, msg.Body)
+	}
+	if strings.Join(bodies, ",") != "one,two,three,four" {
+		t.Fatalf("unexpected messages: %v", bodies)
+	}
+	select {
+	case <-store.Changes():
+		t.Fatalf("expected prepending older history not to notify")
+	default:
+	}
+}
+
+func TestChatStore_DeleteMessage(t *testing.T) {
+	store := NewChatStore()
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	withID := ChatMessage{ChatKey: "channel:0", DeviceMessageID: "100", Direction: MessageDirectionIn, Body: "one", At: at}
+	withoutID := ChatMessage{ChatKey: "channel:0", Direction: MessageDirectionOut, Body: "two", At: at.Add(time.Second)}
+	store.AppendMessage(withID)
+	store.AppendMessage(withoutID)
+
+	if !store.DeleteMessage(withoutID) {
+		t.Fatalf("expected message without device id to be deleted")
+	}
+	if store.DeleteMessage(withoutID) {
+		t.Fatalf("expected second delete to find nothing")
+	}
+	if !store.DeleteMessage(ChatMessage{ChatKey: "channel:0", DeviceMessageID: "100"}) {
+		t.Fatalf("expected message to be deleted by device id")
+	}
+	if msgs := store.Messages("channel:0"); len(msgs) != 0 {
+		t.Fatalf("expected no messages left, got %d", len(msgs))
+	}
+}
+
+func TestParseQueuedMessageID(t *testing.T) {
+	tests := []struct {
+		id     string
+		want   int64
+		wantOK bool
+	}{
+		{id: "outbox:12", want: 12, wantOK: true},
+		{id: " outbox:3 ", want: 3, wantOK: true},
+		{id: "outbox:", wantOK: false},
+		{id: "outbox:x", wantOK: false},
+		{id: "12", wantOK: false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseQueuedMessageID(tt.id)
+		if got != tt.want || ok != tt.wantOK {
+			t.Fatalf("ParseQueuedMessageID(%q): expected %d/%v, got %d/%v", tt.id, tt.want, tt.wantOK, got, ok)
+		}
+	}
+}
@@ -10,3 +10,12 @@ type NodeHistoryQuery struct {
 	BeforeRowID      int64
 	Order            SortOrder
 }
+
+// NodeTrackQuery selects track points of a node observed within [From, To]. Zero bounds
+// leave that side open.
+type NodeTrackQuery struct {
+	NodeID string
+	From   time.Time
+	To     time.Time
+	Limit  int
+}
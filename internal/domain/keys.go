@@ -5,6 +5,12 @@ import (
 	"strings"
 )
 
+// ChatKeyLoopback is the key of the built-in loopback test chat.
+const ChatKeyLoopback = "loopback"
+
+// LoopbackChatTitle is the display title of the loopback test chat.
+const LoopbackChatTitle = "Loopback"
+
 func ChatKeyForChannel(index int) string {
 	return fmt.Sprintf("channel:%d", index)
 }
@@ -17,10 +23,23 @@ func IsDMKey(key string) bool {
 	return strings.HasPrefix(strings.TrimSpace(key), "dm:")
 }
 
+// IsLoopbackKey reports whether the key belongs to the loopback test chat.
+func IsLoopbackKey(key string) bool {
+	return strings.TrimSpace(key) == ChatKeyLoopback
+}
+
+// LoopbackChat returns the built-in loopback test chat.
+func LoopbackChat() Chat {
+	return Chat{Key: ChatKeyLoopback, Title: LoopbackChatTitle, Type: ChatTypeLoopback}
+}
+
 func ChatTypeForKey(key string) ChatType {
 	if IsDMKey(key) {
 		return ChatTypeDM
 	}
+	if IsLoopbackKey(key) {
+		return ChatTypeLoopback
+	}
 
 	return ChatTypeChannel
 }
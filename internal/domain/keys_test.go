@@ -10,6 +10,7 @@ func TestChatTypeForKey(t *testing.T) {
 	}{
 		{name: "dm key", key: "dm:!11111111", want: ChatTypeDM},
 		{name: "channel key", key: "channel:0", want: ChatTypeChannel},
+		{name: "loopback key", key: " loopback ", want: ChatTypeLoopback},
 		{name: "unknown key defaults to channel", key: "custom", want: ChatTypeChannel},
 	}
 
@@ -75,3 +76,17 @@ func TestNodeIDFromDMChatKey(t *testing.T) {
 		}
 	}
 }
+
+func TestWithLoopbackChat(t *testing.T) {
+	chats := withLoopbackChat([]Chat{{Key: "channel:0"}})
+	if len(chats) != 2 || chats[1] != LoopbackChat() {
+		t.Fatalf("expected loopback chat to be added, got %+v", chats)
+	}
+	stored := []Chat{{Key: ChatKeyLoopback, Title: ChatKeyLoopback, Type: ChatTypeLoopback}}
+	if got := withLoopbackChat(stored); len(got) != 1 {
+		t.Fatalf("expected stored loopback chat to be kept, got %+v", got)
+	}
+	if got := ChatDisplayTitle(stored[0]); got != LoopbackChatTitle {
+		t.Fatalf("expected loopback title %q, got %q", LoopbackChatTitle, got)
+	}
+}
@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// MeshActiveWindow is how recently a node must be heard to count as active,
+	// matching the "online" window of the official Meshtastic apps.
+	MeshActiveWindow = 2 * time.Hour
+	// MeshSendWindow is how far back outgoing messages count towards failed sends.
+	MeshSendWindow = 24 * time.Hour
+
+	MeshHealthGood = 75
+	MeshHealthFair = 50
+)
+
+// Score ranges of the mesh health components. Values beyond a range clamp to 0 or 100.
+const (
+	meshHealthSNRBad          = float64(SNRFair)
+	meshHealthSNRGood         = 5.0
+	meshHealthUtilizationGood = 10.0
+	meshHealthUtilizationBad  = ChannelUtilizationMax
+	// meshHealthTrendBad is the share of earlier active nodes that scores zero when left.
+	meshHealthTrendBad = 0.5
+)
+
+// MeshHealthInput is everything the mesh health score is computed from.
+type MeshHealthInput struct {
+	Now         time.Time
+	LocalNodeID string
+	Nodes       []Node
+	// Outgoing holds sent messages; only those within MeshSendWindow count.
+	Outgoing []ChatMessage
+	// ActiveNodesEarlier is the active node count sampled about an hour before Now,
+	// or nil when the app has not been running long enough to know.
+	ActiveNodesEarlier *int
+}
+
+// MeshHealthComponent is one metric contributing to the mesh health score.
+// Score is 0-100 and only meaningful when Available is set.
+type MeshHealthComponent struct {
+	Available bool
+	Score     int
+}
+
+// MeshHealth is a coarse 0-100 summary of how well the mesh works from this node's view.
+type MeshHealth struct {
+	// Score averages the available components; it is only meaningful when Available is set.
+	Score     int
+	Available bool
+
+	ActiveNodes        int
+	ActiveNodesEarlier int
+	ActiveTrend        MeshHealthComponent
+
+	MedianSNR  float64
+	SNRSamples int
+	SNR        MeshHealthComponent
+
+	ChannelUtilization float64
+	Utilization        MeshHealthComponent
+
+	SentMessages   int
+	FailedMessages int
+	Delivery       MeshHealthComponent
+}
+
+// Rating names the score band: "good", "fair" or "poor", or "unknown" without data.
+func (h MeshHealth) Rating() string {
+	switch {
+	case !h.Available:
+		return "unknown"
+	case h.Score >= MeshHealthGood:
+		return "good"
+	case h.Score >= MeshHealthFair:
+		return "fair"
+	default:
+		return "poor"
+	}
+}
+
+// ComputeMeshHealth scores the active node trend, the median SNR of active nodes, channel
+// utilization seen by the local node and the share of failed sends.
+func ComputeMeshHealth(input MeshHealthInput) MeshHealth {
+	var health MeshHealth
+	localNodeID := strings.TrimSpace(input.LocalNodeID)
+	snrs := make([]float64, 0, len(input.Nodes))
+	utilizations := make([]float64, 0, len(input.Nodes))
+	localUtilization := (*float64)(nil)
+	for _, node := range input.Nodes {
+		isLocal := localNodeID != "" && node.NodeID == localNodeID
+		if isLocal {
+			localUtilization = node.ChannelUtilization
+		}
+		if isLocal || node.LastHeardAt.IsZero() || input.Now.Sub(node.LastHeardAt) > MeshActiveWindow {
+			continue
+		}
+		health.ActiveNodes++
+		if node.SNR != nil {
+			snrs = append(snrs, *node.SNR)
+		}
+		if node.ChannelUtilization != nil {
+			utilizations = append(utilizations, *node.ChannelUtilization)
+		}
+	}
+
+	if input.ActiveNodesEarlier != nil && *input.ActiveNodesEarlier > 0 {
+		health.ActiveNodesEarlier = *input.ActiveNodesEarlier
+		ratio := float64(health.ActiveNodes) / float64(health.ActiveNodesEarlier)
+		health.ActiveTrend = meshHealthComponent(ratio, meshHealthTrendBad, 1)
+	}
+	if len(snrs) > 0 {
+		health.SNRSamples = len(snrs)
+		health.MedianSNR = median(snrs)
+		health.SNR = meshHealthComponent(health.MedianSNR, meshHealthSNRBad, meshHealthSNRGood)
+	}
+	// The local node measures the channel it actually transmits on; others are a fallback.
+	switch {
+	case localUtilization != nil:
+		health.ChannelUtilization = *localUtilization
+		health.Utilization = meshHealthComponent(health.ChannelUtilization, meshHealthUtilizationBad, meshHealthUtilizationGood)
+	case len(utilizations) > 0:
+		health.ChannelUtilization = median(utilizations)
+		health.Utilization = meshHealthComponent(health.ChannelUtilization, meshHealthUtilizationBad, meshHealthUtilizationGood)
+	}
+	for _, msg := range input.Outgoing {
+		if msg.Direction != MessageDirectionOut || input.Now.Sub(msg.At) > MeshSendWindow {
+			continue
+		}
+		health.SentMessages++
+		if msg.Status == MessageStatusFailed {
+			health.FailedMessages++
+		}
+	}
+	if health.SentMessages > 0 {
+		delivered := 1 - float64(health.FailedMessages)/float64(health.SentMessages)
+		health.Delivery = meshHealthComponent(delivered, 0, 1)
+	}
+
+	total, count := 0, 0
+	for _, component := range []MeshHealthComponent{health.ActiveTrend, health.SNR, health.Utilization, health.Delivery} {
+		if component.Available {
+			total += component.Score
+			count++
+		}
+	}
+	if count > 0 {
+		health.Available = true
+		health.Score = int(math.Round(float64(total) / float64(count)))
+	}
+
+	return health
+}
+
+// meshHealthComponent maps value linearly from bad (0) to good (100); bad may exceed good
+// for metrics where lower is better.
+func meshHealthComponent(value, bad, good float64) MeshHealthComponent {
+	share := (value - bad) / (good - bad)
+
+	return MeshHealthComponent{
+		Available: true,
+		Score:     int(math.Round(100 * math.Min(1, math.Max(0, share)))),
+	}
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	return sorted[mid]
+}
+
+// ActiveNodeSamples remembers active node counts over time to tell the mesh health trend.
+// It is not safe for concurrent use.
+type ActiveNodeSamples struct {
+	samples []activeNodeSample
+}
+
+type activeNodeSample struct {
+	at    time.Time
+	count int
+}
+
+// meshTrendWindow is how far back the trend compares the active node count.
+const meshTrendWindow = time.Hour
+
+// Record stores the count seen at now and drops samples no longer needed.
+func (s *ActiveNodeSamples) Record(now time.Time, count int) {
+	s.samples = append(s.samples, activeNodeSample{at: now, count: count})
+	// Keep the newest sample that is at least a window old as the comparison point.
+	drop := 0
+	for i := range s.samples {
+		if now.Sub(s.samples[i].at) >= meshTrendWindow {
+			drop = i
+		}
+	}
+	s.samples = s.samples[drop:]
+}
+
+// Earlier returns the count sampled at least an hour before now, if there is one.
+func (s *ActiveNodeSamples) Earlier(now time.Time) (int, bool) {
+	if len(s.samples) == 0 || now.Sub(s.samples[0].at) < meshTrendWindow {
+		return 0, false
+	}
+
+	return s.samples[0].count, true
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeMeshHealth(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	f := func(v float64) *float64 { return &v }
+	nodes := []Node{
+		{NodeID: "!00000001", LastHeardAt: now, ChannelUtilization: f(25)},
+		{NodeID: "!00000002", LastHeardAt: now.Add(-time.Minute), SNR: f(5), ChannelUtilization: f(5)},
+		{NodeID: "!00000003", LastHeardAt: now.Add(-time.Hour), SNR: f(-5)},
+		{NodeID: "!00000004", LastHeardAt: now.Add(-90 * time.Minute), SNR: f(-10)},
+		// Not heard within the active window.
+		{NodeID: "!00000005", LastHeardAt: now.Add(-3 * time.Hour), SNR: f(-20)},
+	}
+	outgoing := []ChatMessage{
+		{Direction: MessageDirectionOut, Status: MessageStatusAcked, At: now.Add(-time.Hour)},
+		{Direction: MessageDirectionOut, Status: MessageStatusFailed, At: now.Add(-2 * time.Hour)},
+		{Direction: MessageDirectionOut, Status: MessageStatusSent, At: now.Add(-3 * time.Hour)},
+		{Direction: MessageDirectionOut, Status: MessageStatusAcked, At: now.Add(-4 * time.Hour)},
+		{Direction: MessageDirectionOut, Status: MessageStatusFailed, At: now.Add(-48 * time.Hour)},
+		{Direction: MessageDirectionIn, At: now},
+	}
+	earlier := 4
+
+	health := ComputeMeshHealth(MeshHealthInput{
+		Now:                now,
+		LocalNodeID:        "!00000001",
+		Nodes:              nodes,
+		Outgoing:           outgoing,
+		ActiveNodesEarlier: &earlier,
+	})
+
+	if health.ActiveNodes != 3 || health.ActiveNodesEarlier != 4 || health.ActiveTrend.Score != 50 {
+		t.Fatalf("unexpected active trend: %+v", health)
+	}
+	if health.SNRSamples != 3 || health.MedianSNR != -5 || health.SNR.Score != 50 {
+		t.Fatalf("unexpected SNR: %+v", health)
+	}
+	// The local node's own utilization wins over the median of other nodes.
+	if health.ChannelUtilization != 25 || health.Utilization.Score != 50 {
+		t.Fatalf("unexpected utilization: %+v", health)
+	}
+	if health.SentMessages != 4 || health.FailedMessages != 1 || health.Delivery.Score != 75 {
+		t.Fatalf("unexpected delivery: %+v", health)
+	}
+	if !health.Available || health.Score != 56 || health.Rating() != "fair" {
+		t.Fatalf("unexpected score: %d (%s)", health.Score, health.Rating())
+	}
+}
+
+func TestComputeMeshHealthSkipsMissingComponents(t *testing.T) {
+	now := time.Now()
+	snr := 8.0
+
+	health := ComputeMeshHealth(MeshHealthInput{
+		Now:   now,
+		Nodes: []Node{{NodeID: "!00000002", LastHeardAt: now, SNR: &snr}},
+	})
+	if health.ActiveTrend.Available || health.Utilization.Available || health.Delivery.Available {
+		t.Fatalf("expected only SNR to be available: %+v", health)
+	}
+	if !health.Available || health.Score != 100 || health.Rating() != "good" {
+		t.Fatalf("unexpected score: %+v", health)
+	}
+
+	if empty := ComputeMeshHealth(MeshHealthInput{Now: now}); empty.Available || empty.Rating() != "unknown" {
+		t.Fatalf("expected no score without data: %+v", empty)
+	}
+}
+
+func TestActiveNodeSamples(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var samples ActiveNodeSamples
+
+	samples.Record(start, 10)
+	if _, ok := samples.Earlier(start.Add(30 * time.Minute)); ok {
+		t.Fatalf("expected no earlier count before an hour has passed")
+	}
+	samples.Record(start.Add(30*time.Minute), 12)
+	samples.Record(start.Add(70*time.Minute), 8)
+	if count, ok := samples.Earlier(start.Add(70 * time.Minute)); !ok || count != 10 {
+		t.Fatalf("expected the sample from an hour ago, got %d %v", count, ok)
+	}
+	samples.Record(start.Add(100*time.Minute), 9)
+	if count, ok := samples.Earlier(start.Add(100 * time.Minute)); !ok || count != 12 {
+		t.Fatalf("expected the newest sample at least an hour old, got %d %v", count, ok)
+	}
+}
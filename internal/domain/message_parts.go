@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxMessageParts is the most parts a long message is split into.
+const MaxMessageParts = 99
+
+// messagePartPattern matches the "k/n " prefix of a message part.
+var messagePartPattern = regexp.MustCompile(`^(\d{1,2})/(\d{1,2}) `)
+
+// MessagePart is one numbered part of a long message split to fit into packets.
+type MessagePart struct {
+	Index int
+	Total int
+	Text  string
+}
+
+// ParseMessagePart reads the "k/n " prefix of a message part. Part texts are joined
+// as they are, a part split at a space ends with that space.
+func ParseMessagePart(body string) (MessagePart, bool) {
+	match := messagePartPattern.FindStringSubmatch(body)
+	if match == nil {
+		return MessagePart{}, false
+	}
+	index, _ := strconv.Atoi(match[1])
+	total, _ := strconv.Atoi(match[2])
+	if total < 2 || index < 1 || index > total {
+		return MessagePart{}, false
+	}
+
+	return MessagePart{Index: index, Total: total, Text: body[len(match[0]):]}, true
+}
+
+// SplitMessageParts splits text into numbered parts of at most limit bytes each,
+// preferring to cut after whitespace. It returns nil when the text does not fit into
+// MaxMessageParts parts.
+func SplitMessageParts(text string, limit int) []string {
+	for total := 2; total <= MaxMessageParts; total++ {
+		budget := limit - len(messagePartPrefix(total, total))
+		if budget <= 0 {
+			return nil
+		}
+		chunks := splitTextChunks(text, budget)
+		if len(chunks) > total {
+			continue
+		}
+		parts := make([]string, 0, len(chunks))
+		for i, chunk := range chunks {
+			parts = append(parts, messagePartPrefix(i+1, len(chunks))+chunk)
+		}
+
+		return parts
+	}
+
+	return nil
+}
+
+func messagePartPrefix(index, total int) string {
+	return fmt.Sprintf("%d/%d ", index, total)
+}
+
+// splitTextChunks cuts text into chunks of at most budget bytes without breaking
+// UTF-8 sequences.
+func splitTextChunks(text string, budget int) []string {
+	var chunks []string
+	for len(text) > budget {
+		cut := budget
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+		if cut == 0 {
+			return nil
+		}
+		if space := strings.LastIndexFunc(text[:cut], unicode.IsSpace); space > 0 {
+			_, size := utf8.DecodeRuneInString(text[space:])
+			cut = space + size
+		}
+		chunks = append(chunks, text[:cut])
+		text = text[cut:]
+	}
+	if text != "" {
+		chunks = append(chunks, text)
+	}
+
+	return chunks
+}
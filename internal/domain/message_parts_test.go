@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMessagePart(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		want   MessagePart
+		wantOK bool
+	}{
+		{name: "first part", body: "1/3 hello ", want: MessagePart{Index: 1, Total: 3, Text: "hello "}, wantOK: true},
+		{name: "last part", body: "3/3 world", want: MessagePart{Index: 3, Total: 3, Text: "world"}, wantOK: true},
+		{name: "index above total", body: "4/3 text", wantOK: false},
+		{name: "single part", body: "1/1 text", wantOK: false},
+		{name: "no space", body: "1/2text", wantOK: false},
+		{name: "plain text", body: "meet at 5/6 pm", wantOK: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := ParseMessagePart(tc.body)
+			if ok != tc.wantOK {
+				t.Fatalf("unexpected ok: expected %v, got %v", tc.wantOK, ok)
+			}
+			if got != tc.want {
+				t.Fatalf("unexpected part: expected %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestSplitMessageParts(t *testing.T) {
+	text := strings.Repeat("word ", 50) + strings.Repeat("ж", 80)
+	parts := SplitMessageParts(text, 100)
+	if len(parts) < 3 {
+		t.Fatalf("expected at least 3 parts, got %d", len(parts))
+	}
+
+	var joined strings.Builder
+	for i, part := range parts {
+		if len(part) > 100 {
+			t.Fatalf("part %d exceeds the limit: %d bytes", i+1, len(part))
+		}
+		parsed, ok := ParseMessagePart(part)
+		if !ok {
+			t.Fatalf("part %d is not parseable: %q", i+1, part)
+		}
+		if parsed.Index != i+1 || parsed.Total != len(parts) {
+			t.Fatalf("unexpected numbering: expected %d/%d, got %d/%d", i+1, len(parts), parsed.Index, parsed.Total)
+		}
+		if i < len(parts)-2 && !strings.HasSuffix(part, " ") {
+			t.Fatalf("expected part %d to be cut after a space, got %q", i+1, part)
+		}
+		joined.WriteString(parsed.Text)
+	}
+	if joined.String() != text {
+		t.Fatalf("expected parts to join back into the text, got %q", joined.String())
+	}
+}
+
+func TestSplitMessagePartsTooLong(t *testing.T) {
+	if parts := SplitMessageParts(strings.Repeat("a", 100*MaxMessageParts), 100); parts != nil {
+		t.Fatalf("expected nil for text over %d parts, got %d parts", MaxMessageParts, len(parts))
+	}
+}
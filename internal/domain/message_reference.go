@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Waypoint name and description limits come from the Meshtastic protobuf options.
+const (
+	WaypointNameMaxBytes        = 30
+	WaypointDescriptionMaxBytes = 100
+)
+
+// referencePin marks a shared location in compact message text.
+const referencePin = "📍"
+
+// Waypoint is a named map point shared into a chat.
+type Waypoint struct {
+	Name        string
+	Description string
+	Latitude    float64
+	Longitude   float64
+}
+
+// Validate checks that the waypoint fits into a Meshtastic waypoint packet.
+func (w Waypoint) Validate() error {
+	name := strings.TrimSpace(w.Name)
+	if name == "" {
+		return errors.New("waypoint name is required")
+	}
+	if len(name) > WaypointNameMaxBytes {
+		return fmt.Errorf("waypoint name exceeds %d bytes", WaypointNameMaxBytes)
+	}
+	if len(strings.TrimSpace(w.Description)) > WaypointDescriptionMaxBytes {
+		return fmt.Errorf("waypoint description exceeds %d bytes", WaypointDescriptionMaxBytes)
+	}
+	if !validReferenceCoordinates(w.Latitude, w.Longitude) {
+		return errors.New("waypoint coordinates are out of range")
+	}
+
+	return nil
+}
+
+// NodeReferenceText renders a node as compact message text any client can read.
+func NodeReferenceText(node Node) string {
+	nodeID := strings.TrimSpace(node.NodeID)
+	// The local alias is private to this client and is not sent over the mesh.
+	node.Alias = ""
+	name := NodeDisplayName(node)
+	if name == "" || name == nodeID {
+		return nodeID
+	}
+
+	return fmt.Sprintf("%s (%s)", name, nodeID)
+}
+
+// PositionReferenceText renders coordinates as compact message text. Five decimals
+// keep about a meter of precision.
+func PositionReferenceText(latitude, longitude float64) string {
+	return fmt.Sprintf("%s %.5f,%.5f", referencePin, latitude, longitude)
+}
+
+// GeoURIText renders a position as a geo: URI (RFC 5870) that map apps can open.
+func GeoURIText(latitude, longitude float64) string {
+	return fmt.Sprintf("geo:%.5f,%.5f", latitude, longitude)
+}
+
+// ReferenceText renders the waypoint as compact message text for chats and
+// clients that do not show waypoint packets.
+func (w Waypoint) ReferenceText() string {
+	text := PositionReferenceText(w.Latitude, w.Longitude)
+	if name := strings.TrimSpace(w.Name); name != "" {
+		text = fmt.Sprintf("%s %s %.5f,%.5f", referencePin, name, w.Latitude, w.Longitude)
+	}
+	if description := strings.TrimSpace(w.Description); description != "" {
+		text += " (" + description + ")"
+	}
+
+	return text
+}
+
+func validReferenceCoordinates(latitude, longitude float64) bool {
+	return latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMessageReferenceText(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "node with name", got: NodeReferenceText(Node{NodeID: "!a1b2c3d4", LongName: "Alice"}), want: "Alice (!a1b2c3d4)"},
+		{name: "node without name", got: NodeReferenceText(Node{NodeID: "!a1b2c3d4"}), want: "!a1b2c3d4"},
+		{name: "node alias stays local", got: NodeReferenceText(Node{NodeID: "!a1b2c3d4", LongName: "Alice", Alias: "Sis"}), want: "Alice (!a1b2c3d4)"},
+		{name: "position", got: PositionReferenceText(55.755831, 37.6173), want: "📍 55.75583,37.61730"},
+		{name: "geo uri", got: GeoURIText(55.755831, -37.6173), want: "geo:55.75583,-37.61730"},
+		{name: "waypoint", got: Waypoint{Name: " Camp ", Latitude: 1.5, Longitude: -2.25}.ReferenceText(), want: "📍 Camp 1.50000,-2.25000"},
+		{
+			name: "waypoint with description",
+			got:  Waypoint{Name: "Camp", Description: "north gate", Latitude: 1.5, Longitude: -2.25}.ReferenceText(),
+			want: "📍 Camp 1.50000,-2.25000 (north gate)",
+		},
+	}
+
+	for _, tc := range tests {
+		if tc.got != tc.want {
+			t.Fatalf("%s: expected %q, got %q", tc.name, tc.want, tc.got)
+		}
+	}
+}
+
+func TestWaypointValidate(t *testing.T) {
+	valid := Waypoint{Name: "Camp", Latitude: 10, Longitude: 20}
+	tests := []struct {
+		name    string
+		mutate  func(*Waypoint)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(*Waypoint) {}},
+		{name: "missing name", mutate: func(w *Waypoint) { w.Name = "  " }, wantErr: true},
+		{name: "long name", mutate: func(w *Waypoint) { w.Name = strings.Repeat("x", WaypointNameMaxBytes+1) }, wantErr: true},
+		{name: "long description", mutate: func(w *Waypoint) { w.Description = strings.Repeat("x", WaypointDescriptionMaxBytes+1) }, wantErr: true},
+		{name: "latitude out of range", mutate: func(w *Waypoint) { w.Latitude = 91 }, wantErr: true},
+		{name: "longitude out of range", mutate: func(w *Waypoint) { w.Longitude = -181 }, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		waypoint := valid
+		tc.mutate(&waypoint)
+		if err := waypoint.Validate(); (err != nil) != tc.wantErr {
+			t.Fatalf("%s: expected error=%v, got %v", tc.name, tc.wantErr, err)
+		}
+	}
+}
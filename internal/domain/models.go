@@ -12,6 +12,8 @@ type ChatType int
 const (
 	ChatTypeChannel ChatType = iota + 1
 	ChatTypeDM
+	// ChatTypeLoopback is the built-in test chat whose messages never leave the app.
+	ChatTypeLoopback
 )
 
 // MessageDirection indicates whether a message was received or sent locally.
@@ -39,6 +41,27 @@ type Chat struct {
 	Type           ChatType
 	LastSentByMeAt time.Time
 	UpdatedAt      time.Time
+	Notifications  ChatNotificationPrefs
+	// ReadUpTo is the time of the newest incoming message the user has seen. Zero means
+	// the chat was never marked read.
+	ReadUpTo time.Time
+}
+
+// DeletedItemKind identifies what kind of record was soft-deleted.
+type DeletedItemKind string
+
+const (
+	DeletedItemChat DeletedItemKind = "chat"
+	DeletedItemNode DeletedItemKind = "node"
+)
+
+// DeletedItem is a soft-deleted chat or node that can still be restored until purged.
+type DeletedItem struct {
+	Kind DeletedItemKind
+	// Key is the chat key for chats and the node ID for nodes.
+	Key       string
+	Title     string
+	DeletedAt time.Time
 }
 
 // ChatMessage is a single message item stored and shown in a chat timeline.
@@ -56,6 +79,36 @@ type ChatMessage struct {
 	StatusReason string
 	At           time.Time
 	MetaJSON     string
+	// QueuedMessageID is set on a sent message that left the outbox. It takes over the
+	// chat entry shown under that id instead of adding a new one.
+	QueuedMessageID string
+}
+
+// MessageAnnotation holds local-only marks attached to a message. It is never sent to the mesh.
+type MessageAnnotation struct {
+	ChatKey         string
+	DeviceMessageID string
+	Starred         bool
+	Tags            []string
+	UpdatedAt       time.Time
+}
+
+// IsEmpty reports whether the annotation carries no marks and can be dropped.
+func (a MessageAnnotation) IsEmpty() bool {
+	return !a.Starred && len(a.Tags) == 0
+}
+
+// AnnotatedMessage pairs a stored message with its local annotation.
+type AnnotatedMessage struct {
+	Message    ChatMessage
+	Annotation MessageAnnotation
+}
+
+// MessagePin marks a message kept at the top of its chat. Pins are local-only.
+type MessagePin struct {
+	ChatKey         string
+	DeviceMessageID string
+	PinnedAt        time.Time
 }
 
 // MessageStatusUpdate updates delivery status by device message id.
@@ -122,12 +175,19 @@ type Node struct {
 	FirmwareVersion       string
 	Role                  string
 	IsFavorite            *bool
+	IsIgnored             *bool
 	IsUnmessageable       *bool
+	HopsAway              *uint32
+	ViaMQTT               *bool
 	PositionUpdatedAt     time.Time
 	LastHeardAt           time.Time
 	RSSI                  *int
 	SNR                   *float64
 	UpdatedAt             time.Time
+	// Alias, Note and Tags are local-only and never come from the radio.
+	Alias string
+	Note  string
+	Tags  []string
 }
 
 // NodeCore stores primary identity/activity snapshot fields.
@@ -141,11 +201,17 @@ type NodeCore struct {
 	FirmwareVersion string
 	Role            string
 	IsFavorite      *bool
+	IsIgnored       *bool
 	IsUnmessageable *bool
+	HopsAway        *uint32
+	ViaMQTT         *bool
 	LastHeardAt     time.Time
 	RSSI            *int
 	SNR             *float64
 	UpdatedAt       time.Time
+	Alias           string
+	Note            string
+	Tags            []string
 }
 
 // NodePosition stores latest known node geospatial data and related metadata.
@@ -250,6 +316,18 @@ type NodePositionHistoryEntry struct {
 	FromPacket bool
 }
 
+// NodeTrackPoint is one received position of a node. Unlike position history, every
+// received position is kept, including repeats of the previous one.
+type NodeTrackPoint struct {
+	NodeID     string
+	Latitude   float64
+	Longitude  float64
+	Altitude   *int32
+	PositionAt time.Time
+	ObservedAt time.Time
+	Source     NodeUpdateType
+}
+
 // NodeTelemetryHistoryEntry is one persisted telemetry history point for a node.
 type NodeTelemetryHistoryEntry struct {
 	RowID              int64
@@ -291,6 +369,31 @@ type NodeIdentityHistoryEntry struct {
 	FromPacket bool
 }
 
+// NodeSignalHistoryEntry is one persisted RSSI/SNR reading of packets received from a node.
+type NodeSignalHistoryEntry struct {
+	RowID      int64
+	NodeID     string
+	RSSI       *int
+	SNR        *float64
+	ObservedAt time.Time
+}
+
+// RawPacketDirection tells whether a raw frame was read from or written to the radio.
+type RawPacketDirection string
+
+const (
+	RawPacketFromRadio RawPacketDirection = "from_radio"
+	RawPacketToRadio   RawPacketDirection = "to_radio"
+)
+
+// RawPacketLogEntry is one raw FromRadio/ToRadio frame kept for protocol debugging.
+type RawPacketLogEntry struct {
+	RowID     int64
+	Direction RawPacketDirection
+	Payload   []byte
+	LoggedAt  time.Time
+}
+
 // ChannelList carries known device channels published by the radio.
 type ChannelList struct {
 	Items []ChannelInfo
@@ -302,7 +405,7 @@ type ChannelInfo struct {
 	Title string
 }
 
-// TracerouteRecord stores one traceroute run state for future history UI.
+// TracerouteRecord stores one traceroute run state for the traceroute history.
 type TracerouteRecord struct {
 	RequestID    string
 	TargetNodeID string
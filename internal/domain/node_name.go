@@ -3,6 +3,9 @@ package domain
 import "strings"
 
 func NodeDisplayName(node Node) string {
+	if value := strings.TrimSpace(node.Alias); value != "" {
+		return value
+	}
 	if value := strings.TrimSpace(node.LongName); value != "" {
 		return value
 	}
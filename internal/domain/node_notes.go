@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	NodeAliasMaxRunes = 40
+	NodeNoteMaxRunes  = 1000
+	NodeTagMaxRunes   = 24
+	NodeTagsMaxCount  = 16
+)
+
+// NormalizeNodeNotes trims a local node alias and note and checks their lengths.
+// Whitespace runs in the alias collapse to single spaces; the note keeps its line breaks.
+func NormalizeNodeNotes(alias, note string) (string, string, error) {
+	alias = strings.Join(strings.Fields(alias), " ")
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(alias) > NodeAliasMaxRunes {
+		return "", "", fmt.Errorf("alias exceeds %d characters", NodeAliasMaxRunes)
+	}
+	if utf8.RuneCountInString(note) > NodeNoteMaxRunes {
+		return "", "", fmt.Errorf("note exceeds %d characters", NodeNoteMaxRunes)
+	}
+
+	return alias, note, nil
+}
+
+// NormalizeNodeTags normalizes local node tags and checks their count and lengths.
+func NormalizeNodeTags(tags []string) ([]string, error) {
+	tags = NormalizeTags(tags)
+	if len(tags) > NodeTagsMaxCount {
+		return nil, fmt.Errorf("a node can have at most %d tags", NodeTagsMaxCount)
+	}
+	for _, tag := range tags {
+		if utf8.RuneCountInString(tag) > NodeTagMaxRunes {
+			return nil, fmt.Errorf("tag %q exceeds %d characters", tag, NodeTagMaxRunes)
+		}
+	}
+
+	return tags, nil
+}
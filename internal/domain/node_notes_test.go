@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalizeNodeNotes(t *testing.T) {
+	tests := []struct {
+		name      string
+		alias     string
+		note      string
+		wantAlias string
+		wantNote  string
+		wantErr   bool
+	}{
+		{name: "empty", alias: "  ", note: "\n", wantAlias: "", wantNote: ""},
+		{name: "collapses alias spaces", alias: "  Base   camp ", note: " line one\nline two \n", wantAlias: "Base camp", wantNote: "line one\nline two"},
+		{name: "alias at limit", alias: strings.Repeat("ж", NodeAliasMaxRunes), wantAlias: strings.Repeat("ж", NodeAliasMaxRunes)},
+		{name: "alias too long", alias: strings.Repeat("a", NodeAliasMaxRunes+1), wantErr: true},
+		{name: "note too long", note: strings.Repeat("a", NodeNoteMaxRunes+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alias, note, err := NormalizeNodeNotes(tt.alias, tt.note)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if alias != tt.wantAlias || note != tt.wantNote {
+				t.Fatalf("expected (%q, %q), got (%q, %q)", tt.wantAlias, tt.wantNote, alias, note)
+			}
+		})
+	}
+}
+
+func TestNormalizeNodeTags(t *testing.T) {
+	tags, err := NormalizeNodeTags([]string{" solar ", "Router", "SOLAR", ""})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(tags, ",") != "solar,Router" {
+		t.Fatalf("expected %q, got %q", "solar,Router", strings.Join(tags, ","))
+	}
+
+	if _, err := NormalizeNodeTags([]string{strings.Repeat("a", NodeTagMaxRunes+1)}); err == nil {
+		t.Fatalf("expected error for a long tag")
+	}
+	tooMany := make([]string, 0, NodeTagsMaxCount+1)
+	for i := range NodeTagsMaxCount + 1 {
+		tooMany = append(tooMany, strings.Repeat("t", i+1))
+	}
+	if _, err := NormalizeNodeTags(tooMany); err == nil {
+		t.Fatalf("expected error for too many tags")
+	}
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,14 +12,17 @@ import (
 
 // NodeStore keeps the latest node snapshots in memory for the UI.
 type NodeStore struct {
-	mu      sync.RWMutex
-	nodes   map[string]Node
+	mu    sync.RWMutex
+	nodes map[string]Node
+	// hidden holds soft-deleted node IDs with their deletion time.
+	hidden  map[string]time.Time
 	changes chan struct{}
 }
 
 func NewNodeStore() *NodeStore {
 	return &NodeStore{
 		nodes:   make(map[string]Node),
+		hidden:  make(map[string]time.Time),
 		changes: make(chan struct{}, 1),
 	}
 }
@@ -81,6 +85,7 @@ func sanitizeNodeCoreUpdate(update NodeCoreUpdate) NodeCoreUpdate {
 	// keep local/store value until a NodeInfoSnapshot arrives.
 	if update.Type == NodeUpdateTypeNodeInfoPacket {
 		update.Core.IsFavorite = nil
+		update.Core.IsIgnored = nil
 	}
 
 	return update
@@ -90,6 +95,14 @@ func (s *NodeStore) Upsert(node Node) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if hiddenAt, hidden := s.hidden[node.NodeID]; hidden {
+		// A deleted node comes back only when it is heard again after the deletion.
+		if !node.LastHeardAt.After(hiddenAt) {
+			return
+		}
+		delete(s.hidden, node.NodeID)
+	}
+
 	existing, ok := s.nodes[node.NodeID]
 	if ok {
 		// Merge sparse updates without wiping cached metadata.
@@ -180,9 +193,18 @@ func (s *NodeStore) Upsert(node Node) {
 		if node.IsFavorite == nil {
 			node.IsFavorite = existing.IsFavorite
 		}
+		if node.IsIgnored == nil {
+			node.IsIgnored = existing.IsIgnored
+		}
 		if node.IsUnmessageable == nil {
 			node.IsUnmessageable = existing.IsUnmessageable
 		}
+		if node.HopsAway == nil {
+			node.HopsAway = existing.HopsAway
+		}
+		if node.ViaMQTT == nil {
+			node.ViaMQTT = existing.ViaMQTT
+		}
 		if node.RSSI == nil {
 			node.RSSI = existing.RSSI
 		}
@@ -198,6 +220,15 @@ func (s *NodeStore) Upsert(node Node) {
 		if existing.UpdatedAt.After(node.UpdatedAt) {
 			node.UpdatedAt = existing.UpdatedAt
 		}
+		if node.Alias == "" {
+			node.Alias = existing.Alias
+		}
+		if node.Note == "" {
+			node.Note = existing.Note
+		}
+		if node.Tags == nil {
+			node.Tags = existing.Tags
+		}
 	}
 	if node.UpdatedAt.IsZero() {
 		node.UpdatedAt = time.Now()
@@ -269,6 +300,14 @@ func (s *NodeStore) SnapshotSorted() []Node {
 	return out
 }
 
+// Len returns the number of known nodes, not counting hidden ones.
+func (s *NodeStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.nodes)
+}
+
 func (s *NodeStore) Get(nodeID string) (Node, bool) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -277,6 +316,61 @@ func (s *NodeStore) Get(nodeID string) (Node, bool) {
 	return node, ok
 }
 
+// SetNotes replaces the local alias and note of a known node.
+func (s *NodeStore) SetNotes(nodeID, alias, note string) bool {
+	nodeID = strings.TrimSpace(nodeID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	node, ok := s.nodes[nodeID]
+	if !ok {
+		return false
+	}
+	node.Alias = alias
+	node.Note = note
+	s.nodes[nodeID] = node
+	s.notify()
+
+	return true
+}
+
+// SetTags replaces the local tags of a known node.
+func (s *NodeStore) SetTags(nodeID string, tags []string) bool {
+	nodeID = strings.TrimSpace(nodeID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	node, ok := s.nodes[nodeID]
+	if !ok {
+		return false
+	}
+	node.Tags = tags
+	s.nodes[nodeID] = node
+	s.notify()
+
+	return true
+}
+
+// Hide removes a soft-deleted node and ignores its updates until it is heard after deletedAt.
+func (s *NodeStore) Hide(nodeID string, deletedAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hidden[nodeID] = deletedAt
+	if _, ok := s.nodes[nodeID]; ok {
+		delete(s.nodes, nodeID)
+		s.notify()
+	}
+}
+
+// Restore unhides a soft-deleted node and puts its snapshot back.
+func (s *NodeStore) Restore(node Node) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.hidden, node.NodeID)
+	s.nodes[node.NodeID] = node
+	s.notify()
+}
+
 func (s *NodeStore) Changes() <-chan struct{} {
 	return s.changes
 }
@@ -285,6 +379,7 @@ func (s *NodeStore) Reset() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.nodes = make(map[string]Node)
+	s.hidden = make(map[string]time.Time)
 	s.notify()
 }
 
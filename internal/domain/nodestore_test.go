@@ -111,3 +111,77 @@ func TestSanitizeNodeCoreUpdate_PreservesFavoriteFromSnapshot(t *testing.T) {
 		t.Fatalf("expected snapshot favorite to be preserved, got %v", got.Core.IsFavorite)
 	}
 }
+
+func TestNodeStoreHide_IgnoresStaleUpdatesUntilHeardAgain(t *testing.T) {
+	deletedAt := time.Unix(1000, 0)
+	store := NewNodeStore()
+	store.Upsert(Node{NodeID: "!00000001", LongName: "Alice", LastHeardAt: deletedAt.Add(-time.Minute)})
+
+	store.Hide("!00000001", deletedAt)
+	if _, ok := store.Get("!00000001"); ok {
+		t.Fatalf("expected hidden node to be removed")
+	}
+
+	store.Upsert(Node{NodeID: "!00000001", LastHeardAt: deletedAt.Add(-time.Second)})
+	if _, ok := store.Get("!00000001"); ok {
+		t.Fatalf("expected stale update for hidden node to be ignored")
+	}
+
+	store.Upsert(Node{NodeID: "!00000001", LongName: "Alice", LastHeardAt: deletedAt.Add(time.Second)})
+	if _, ok := store.Get("!00000001"); !ok {
+		t.Fatalf("expected node heard after deletion to reappear")
+	}
+}
+
+func TestNodeStoreRestore_UnhidesNode(t *testing.T) {
+	store := NewNodeStore()
+	store.Hide("!00000002", time.Unix(1000, 0))
+	store.Restore(Node{NodeID: "!00000002", ShortName: "BOB"})
+
+	store.Upsert(Node{NodeID: "!00000002", LongName: "Bob"})
+	node, ok := store.Get("!00000002")
+	if !ok || node.LongName != "Bob" || node.ShortName != "BOB" {
+		t.Fatalf("expected restored node to accept updates, got %+v (ok=%v)", node, ok)
+	}
+}
+
+func TestNodeStoreSetNotes_SurvivesRadioUpdates(t *testing.T) {
+	store := NewNodeStore()
+	if store.SetNotes("!00000003", "Camp", "") {
+		t.Fatalf("expected unknown node to be rejected")
+	}
+
+	store.Upsert(Node{NodeID: "!00000003", LongName: "Carol"})
+	if !store.SetNotes("!00000003", "Camp", "Relay") {
+		t.Fatalf("expected known node to accept notes")
+	}
+	store.Upsert(Node{NodeID: "!00000003", LongName: "Carol 2"})
+
+	node, _ := store.Get("!00000003")
+	if node.Alias != "Camp" || node.Note != "Relay" || node.LongName != "Carol 2" {
+		t.Fatalf("expected notes to survive radio update, got %+v", node)
+	}
+	if got := NodeDisplayName(node); got != "Camp" {
+		t.Fatalf("expected alias display name, got %q", got)
+	}
+}
+
+func TestNodeStoreSetTags_SurvivesRadioUpdates(t *testing.T) {
+	store := NewNodeStore()
+	store.Upsert(Node{NodeID: "!00000004", LongName: "Dave"})
+	if !store.SetTags("!00000004", []string{"solar", "router"}) {
+		t.Fatalf("expected known node to accept tags")
+	}
+	store.Upsert(Node{NodeID: "!00000004", LongName: "Dave 2"})
+
+	node, _ := store.Get("!00000004")
+	if !HasTag(node.Tags, "solar") || !HasTag(node.Tags, "router") {
+		t.Fatalf("expected tags to survive radio update, got %+v", node.Tags)
+	}
+
+	store.SetTags("!00000004", nil)
+	node, _ = store.Get("!00000004")
+	if len(node.Tags) != 0 {
+		t.Fatalf("expected tags to be cleared, got %+v", node.Tags)
+	}
+}
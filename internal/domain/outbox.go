@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QueuedMessageIDPrefix marks the device message id a message is shown under while it
+// waits in the outbox, before the radio assigns it a packet id.
+const QueuedMessageIDPrefix = "outbox:"
+
+// OutboxStatus tracks an outbox entry.
+type OutboxStatus string
+
+const (
+	OutboxStatusQueued OutboxStatus = "queued"
+	// OutboxStatusFailed entries were given up after repeated send errors.
+	OutboxStatusFailed OutboxStatus = "failed"
+)
+
+// OutboxMessage is a chat message written while the radio could not take it.
+type OutboxMessage struct {
+	ID                     int64
+	ChatKey                string
+	Body                   string
+	ReplyToDeviceMessageID string
+	Emoji                  uint32
+	Status                 OutboxStatus
+	Attempts               int
+	LastError              string
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+// QueuedMessageID is the device message id the entry is shown under in its chat.
+func (m OutboxMessage) QueuedMessageID() string {
+	return QueuedMessageIDPrefix + strconv.FormatInt(m.ID, 10)
+}
+
+// IsQueuedMessageID reports whether id belongs to a message still waiting in the outbox.
+func IsQueuedMessageID(id string) bool {
+	return strings.HasPrefix(strings.TrimSpace(id), QueuedMessageIDPrefix)
+}
+
+// ParseQueuedMessageID returns the outbox entry id a queued message is shown under.
+func ParseQueuedMessageID(id string) (int64, bool) {
+	raw, ok := strings.CutPrefix(strings.TrimSpace(id), QueuedMessageIDPrefix)
+	if !ok {
+		return 0, false
+	}
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || value <= 0 {
+		return 0, false
+	}
+
+	return value, true
+}
@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // NodeCoreRepository persists node core snapshots.
 type NodeCoreRepository interface {
@@ -9,12 +12,13 @@ type NodeCoreRepository interface {
 	GetByNodeID(ctx context.Context, nodeID string) (NodeCore, bool, error)
 }
 
-// NodePositionRepository persists node position latest snapshot and history.
+// NodePositionRepository persists node position latest snapshot, history and tracks.
 type NodePositionRepository interface {
 	Upsert(ctx context.Context, update NodePositionUpdate, historyLimit int) error
 	ListLatest(ctx context.Context) ([]NodePosition, error)
 	GetLatestByNodeID(ctx context.Context, nodeID string) (NodePosition, bool, error)
 	ListHistoryByNodeID(ctx context.Context, query NodeHistoryQuery) ([]NodePositionHistoryEntry, error)
+	ListTrack(ctx context.Context, query NodeTrackQuery) ([]NodeTrackPoint, error)
 }
 
 // NodeTelemetryRepository persists node telemetry latest snapshot and history.
@@ -30,6 +34,12 @@ type NodeIdentityHistoryRepository interface {
 	ListHistoryByNodeID(ctx context.Context, query NodeHistoryQuery) ([]NodeIdentityHistoryEntry, error)
 }
 
+// NodeSignalHistoryRepository persists downsampled per-node signal readings.
+type NodeSignalHistoryRepository interface {
+	Record(ctx context.Context, entry NodeSignalHistoryEntry, historyLimit int) error
+	ListHistoryByNodeID(ctx context.Context, query NodeHistoryQuery) ([]NodeSignalHistoryEntry, error)
+}
+
 // ChatRepository persists chat metadata.
 type ChatRepository interface {
 	Upsert(ctx context.Context, c Chat) error
@@ -42,10 +52,33 @@ type MessageRepository interface {
 	Insert(ctx context.Context, m ChatMessage) (int64, error)
 	DeleteByChat(ctx context.Context, chatKey string) error
 	LoadRecentPerChat(ctx context.Context, limit int) (map[string][]ChatMessage, error)
+	// ListPageBeforeByChat returns up to limit messages older than before, oldest first.
+	ListPageBeforeByChat(ctx context.Context, chatKey string, before ChatMessage, limit int) ([]ChatMessage, error)
 	UpdateStatusByDeviceMessageID(ctx context.Context, deviceMessageID string, status MessageStatus) error
+	// ReplaceQueued moves a sent message onto the entry stored for it while it was queued.
+	ReplaceQueued(ctx context.Context, m ChatMessage) error
 }
 
 // TracerouteRepository persists traceroute request/response snapshots.
 type TracerouteRepository interface {
 	Upsert(ctx context.Context, rec TracerouteRecord) error
+	// ListByTargetNodeID returns traceroutes toward the node, newest first.
+	ListByTargetNodeID(ctx context.Context, nodeID string, limit int) ([]TracerouteRecord, error)
+}
+
+// RawPacketLogRepository persists raw radio frames in a size-capped log.
+type RawPacketLogRepository interface {
+	// Append stores the frame and drops the oldest frames once the log exceeds maxBytes
+	// of payload. Zero maxBytes leaves the log uncapped.
+	Append(ctx context.Context, entry RawPacketLogEntry, maxBytes int64) error
+	// List returns logged frames oldest first.
+	List(ctx context.Context) ([]RawPacketLogEntry, error)
+}
+
+// StatisticsRepository maintains activity aggregates for the statistics view.
+type StatisticsRepository interface {
+	RecordMessage(ctx context.Context, chatKey string, direction MessageDirection, at time.Time) error
+	RecordNodePacket(ctx context.Context, nodeID string, at time.Time) error
+	// Load returns daily counts since the given day and the busiest nodeLimit nodes.
+	Load(ctx context.Context, since time.Time, nodeLimit int) (MeshStatistics, error)
 }
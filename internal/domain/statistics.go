@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// StatisticsDayLayout formats the local calendar day activity is aggregated by.
+const StatisticsDayLayout = "2006-01-02"
+
+// ChatDailyMessageCount is the number of messages a chat had on one local day.
+type ChatDailyMessageCount struct {
+	ChatKey  string
+	Day      string
+	Incoming int
+	Outgoing int
+}
+
+// NodePacketCount is the number of packets heard from a node.
+type NodePacketCount struct {
+	NodeID       string
+	Packets      int
+	LastPacketAt time.Time
+}
+
+// HourlyActivity is the activity seen during one local hour of the day, summed over all days.
+type HourlyActivity struct {
+	Packets  int
+	Messages int
+}
+
+// MeshStatistics holds the activity aggregates shown in the statistics view.
+type MeshStatistics struct {
+	Daily []ChatDailyMessageCount
+	Nodes []NodePacketCount
+	Hours [24]HourlyActivity
+}
+
+// DailyMessageTotal is the number of messages of all chats on one local day.
+type DailyMessageTotal struct {
+	Day      time.Time
+	Incoming int
+	Outgoing int
+}
+
+// Total returns the number of messages in both directions.
+func (t DailyMessageTotal) Total() int {
+	return t.Incoming + t.Outgoing
+}
+
+// ChatMessageTotal is the number of messages of one chat over the loaded days.
+type ChatMessageTotal struct {
+	ChatKey  string
+	Incoming int
+	Outgoing int
+}
+
+// Total returns the number of messages in both directions.
+func (t ChatMessageTotal) Total() int {
+	return t.Incoming + t.Outgoing
+}
+
+// StatisticsDay returns the local day key of at.
+func StatisticsDay(at time.Time) string {
+	return at.Local().Format(StatisticsDayLayout)
+}
+
+// DailyMessageTotals sums chat counts per day for the days ending with the day of end.
+// Days without messages are included with zero counts so charts keep a steady scale.
+func DailyMessageTotals(counts []ChatDailyMessageCount, end time.Time, days int) []DailyMessageTotal {
+	if days <= 0 {
+		return nil
+	}
+	end = end.Local()
+	last := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+	out := make([]DailyMessageTotal, days)
+	index := make(map[string]int, days)
+	for i := range out {
+		day := last.AddDate(0, 0, i-days+1)
+		out[i].Day = day
+		index[day.Format(StatisticsDayLayout)] = i
+	}
+	for _, count := range counts {
+		i, ok := index[count.Day]
+		if !ok {
+			continue
+		}
+		out[i].Incoming += count.Incoming
+		out[i].Outgoing += count.Outgoing
+	}
+
+	return out
+}
+
+// ChatMessageTotals sums daily counts per chat, busiest chat first.
+func ChatMessageTotals(counts []ChatDailyMessageCount) []ChatMessageTotal {
+	byChat := make(map[string]*ChatMessageTotal)
+	for _, count := range counts {
+		total, ok := byChat[count.ChatKey]
+		if !ok {
+			total = &ChatMessageTotal{ChatKey: count.ChatKey}
+			byChat[count.ChatKey] = total
+		}
+		total.Incoming += count.Incoming
+		total.Outgoing += count.Outgoing
+	}
+
+	out := make([]ChatMessageTotal, 0, len(byChat))
+	for _, total := range byChat {
+		out = append(out, *total)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Total() != out[j].Total() {
+			return out[i].Total() > out[j].Total()
+		}
+
+		return out[i].ChatKey < out[j].ChatKey
+	})
+
+	return out
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyMessageTotals_FillsMissingDays(t *testing.T) {
+	end := time.Date(2026, 3, 5, 15, 30, 0, 0, time.Local)
+	counts := []ChatDailyMessageCount{
+		{ChatKey: "channel:0", Day: "2026-03-05", Incoming: 3, Outgoing: 1},
+		{ChatKey: "dm:!00000001", Day: "2026-03-05", Incoming: 1},
+		{ChatKey: "channel:0", Day: "2026-03-03", Outgoing: 2},
+		{ChatKey: "channel:0", Day: "2026-02-01", Incoming: 9},
+	}
+
+	totals := DailyMessageTotals(counts, end, 3)
+	if len(totals) != 3 {
+		t.Fatalf("expected 3 days, got %d", len(totals))
+	}
+	want := []struct {
+		day   string
+		total int
+	}{
+		{day: "2026-03-03", total: 2},
+		{day: "2026-03-04", total: 0},
+		{day: "2026-03-05", total: 5},
+	}
+	for i, w := range want {
+		if got := totals[i].Day.Format(StatisticsDayLayout); got != w.day || totals[i].Total() != w.total {
+			t.Fatalf("day %d: expected %s/%d, got %s/%d", i, w.day, w.total, got, totals[i].Total())
+		}
+	}
+}
+
+func TestChatMessageTotals_BusiestFirst(t *testing.T) {
+	counts := []ChatDailyMessageCount{
+		{ChatKey: "dm:!00000001", Day: "2026-03-04", Incoming: 1},
+		{ChatKey: "channel:0", Day: "2026-03-04", Incoming: 2},
+		{ChatKey: "channel:0", Day: "2026-03-05", Outgoing: 2},
+		{ChatKey: "dm:!00000002", Day: "2026-03-05", Outgoing: 1},
+	}
+
+	totals := ChatMessageTotals(counts)
+	if len(totals) != 3 {
+		t.Fatalf("expected 3 chats, got %d", len(totals))
+	}
+	if totals[0].ChatKey != "channel:0" || totals[0].Total() != 4 {
+		t.Fatalf("expected channel:0 with 4 messages first, got %+v", totals[0])
+	}
+	if totals[1].ChatKey != "dm:!00000001" || totals[2].ChatKey != "dm:!00000002" {
+		t.Fatalf("expected ties ordered by chat key, got %+v", totals)
+	}
+}
@@ -39,11 +39,105 @@ func LoadStoresFromRepositories(
 	}
 
 	nodes.Load(mergeNodeSnapshots(coreItems, positionItems, telemetryItems))
-	chats.Load(chatItems, messageItems)
+	chats.Load(withLoopbackChat(chatItems), messageItems)
 
 	return nil
 }
 
+// withLoopbackChat adds the built-in loopback chat unless it is already stored.
+func withLoopbackChat(chats []Chat) []Chat {
+	for _, chat := range chats {
+		if IsLoopbackKey(chat.Key) {
+			return chats
+		}
+	}
+
+	return append(chats, LoopbackChat())
+}
+
+// RestoreNodeFromRepositories reloads a single node from storage and unhides it in the store.
+func RestoreNodeFromRepositories(
+	ctx context.Context,
+	nodes *NodeStore,
+	coreRepo NodeCoreRepository,
+	positionRepo NodePositionRepository,
+	telemetryRepo NodeTelemetryRepository,
+	nodeID string,
+) error {
+	core, ok, err := coreRepo.GetByNodeID(ctx, nodeID)
+	if err != nil {
+		return fmt.Errorf("load node core from db: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("node %q not found", nodeID)
+	}
+	var positionItems []NodePosition
+	position, ok, err := positionRepo.GetLatestByNodeID(ctx, nodeID)
+	if err != nil {
+		return fmt.Errorf("load node position from db: %w", err)
+	}
+	if ok {
+		positionItems = append(positionItems, position)
+	}
+	var telemetryItems []NodeTelemetry
+	telemetry, ok, err := telemetryRepo.GetLatestByNodeID(ctx, nodeID)
+	if err != nil {
+		return fmt.Errorf("load node telemetry from db: %w", err)
+	}
+	if ok {
+		telemetryItems = append(telemetryItems, telemetry)
+	}
+
+	for _, node := range mergeNodeSnapshots([]NodeCore{core}, positionItems, telemetryItems) {
+		nodes.Restore(node)
+	}
+
+	return nil
+}
+
+// RestoreChatFromRepositories reloads a single chat with its recent messages from storage.
+func RestoreChatFromRepositories(
+	ctx context.Context,
+	chats *ChatStore,
+	chatRepo ChatRepository,
+	msgRepo MessageRepository,
+	chatKey string,
+) error {
+	chatItems, err := chatRepo.ListSortedByLastSentByMe(ctx)
+	if err != nil {
+		return fmt.Errorf("load chats from db: %w", err)
+	}
+	for _, chat := range chatItems {
+		if chat.Key != chatKey {
+			continue
+		}
+		messageItems, err := msgRepo.LoadRecentPerChat(ctx, defaultRecentMessagesLoad)
+		if err != nil {
+			return fmt.Errorf("load messages from db: %w", err)
+		}
+		chats.Load([]Chat{chat}, map[string][]ChatMessage{chatKey: messageItems[chatKey]})
+
+		return nil
+	}
+
+	return fmt.Errorf("chat %q not found", chatKey)
+}
+
+// LoadOlderChatMessages adds up to limit messages older than the loaded ones to the
+// chat and returns how many were added. Zero means the whole history is loaded.
+func LoadOlderChatMessages(ctx context.Context, chats *ChatStore, msgRepo MessageRepository, chatKey string, limit int) (int, error) {
+	loaded := chats.Messages(chatKey)
+	if len(loaded) == 0 || limit <= 0 {
+		return 0, nil
+	}
+	older, err := msgRepo.ListPageBeforeByChat(ctx, chatKey, loaded[0], limit)
+	if err != nil {
+		return 0, fmt.Errorf("load older messages from db: %w", err)
+	}
+
+	return chats.PrependMessages(chatKey, older), nil
+}
+
 func mergeNodeSnapshots(coreItems []NodeCore, positionItems []NodePosition, telemetryItems []NodeTelemetry) []Node {
 	outByID := make(map[string]Node, len(coreItems))
 	for _, core := range coreItems {
@@ -88,11 +182,17 @@ func nodeFromCore(core NodeCore) Node {
 		FirmwareVersion: core.FirmwareVersion,
 		Role:            core.Role,
 		IsFavorite:      core.IsFavorite,
+		IsIgnored:       core.IsIgnored,
+		HopsAway:        core.HopsAway,
+		ViaMQTT:         core.ViaMQTT,
 		IsUnmessageable: core.IsUnmessageable,
 		LastHeardAt:     core.LastHeardAt,
 		RSSI:            core.RSSI,
 		SNR:             core.SNR,
 		UpdatedAt:       core.UpdatedAt,
+		Alias:           core.Alias,
+		Note:            core.Note,
+		Tags:            core.Tags,
 	}
 
 	return node
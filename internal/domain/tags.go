@@ -0,0 +1,44 @@
+package domain
+
+import "strings"
+
+// ParseTags splits comma-separated user input into normalized tags.
+func ParseTags(raw string) []string {
+	return NormalizeTags(strings.Split(raw, ","))
+}
+
+// NormalizeTags trims tags, drops empty ones and removes case-insensitive duplicates,
+// keeping the first spelling and the original order.
+func NormalizeTags(tags []string) []string {
+	var out []string
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.Join(strings.Fields(tag), " ")
+		if tag == "" {
+			continue
+		}
+		key := strings.ToLower(tag)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, tag)
+	}
+
+	return out
+}
+
+// HasTag reports whether tags contain tag, ignoring case.
+func HasTag(tags []string, tag string) bool {
+	tag = strings.Join(strings.Fields(tag), " ")
+	if tag == "" {
+		return false
+	}
+	for _, candidate := range tags {
+		if strings.EqualFold(candidate, tag) {
+			return true
+		}
+	}
+
+	return false
+}
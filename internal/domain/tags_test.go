@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want []string
+	}{
+		{raw: "", want: nil},
+		{raw: " , ,", want: nil},
+		{raw: "QSL", want: []string{"QSL"}},
+		{raw: "action  item, QSL, qsl ,Action item", want: []string{"action item", "QSL"}},
+	}
+
+	for _, tc := range tests {
+		if got := ParseTags(tc.raw); !slices.Equal(got, tc.want) {
+			t.Fatalf("ParseTags(%q): expected %v, got %v", tc.raw, tc.want, got)
+		}
+	}
+}
+
+func TestHasTag(t *testing.T) {
+	tags := []string{"Solar", "base camp"}
+	tests := []struct {
+		tag  string
+		want bool
+	}{
+		{tag: "solar", want: true},
+		{tag: " Base   Camp ", want: true},
+		{tag: "router", want: false},
+		{tag: " ", want: false},
+	}
+
+	for _, tc := range tests {
+		if got := HasTag(tags, tc.tag); got != tc.want {
+			t.Fatalf("HasTag(%q): expected %v, got %v", tc.tag, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// dmsSuffixPattern and dmsPrefixPattern match one angle written with a degree
+// sign, optional minutes and seconds, and an optional hemisphere letter after or
+// before it, e.g. `50°27'01.2"N`, `N 50° 27.02'` or `-30.5°`. Both produce the
+// same groups: prefix letter, degrees, minutes, seconds, suffix letter.
+var (
+	dmsSuffixPattern = regexp.MustCompile(
+		`()(-?\d+(?:\.\d+)?)\s*°\s*(?:(\d+(?:\.\d+)?)\s*['′]\s*)?(?:(\d+(?:\.\d+)?)\s*(?:["″]|'')\s*)?([NSEW])?`,
+	)
+	dmsPrefixPattern = regexp.MustCompile(
+		`([NSEW])\s*(-?\d+(?:\.\d+)?)\s*°\s*(?:(\d+(?:\.\d+)?)\s*['′]\s*)?(?:(\d+(?:\.\d+)?)\s*(?:["″]|'')\s*)?()`,
+	)
+)
+
+var decimalPairSeparator = regexp.MustCompile(`\s*[,;]\s*|\s+`)
+
+// FormatDMS renders degrees, minutes and seconds with a hemisphere letter, e.g. 50°27'01.2"N.
+func FormatDMS(value float64, positive, negative string) string {
+	hemisphere := positive
+	if value < 0 {
+		hemisphere = negative
+		value = -value
+	}
+	// Round to tenths of a second first so 59.96" does not render as 60.0".
+	tenths := int64(math.Round(value * 36000))
+	degrees := tenths / 36000
+	minutes := (tenths % 36000) / 600
+	seconds := float64(tenths%600) / 10
+
+	return fmt.Sprintf("%d°%02d'%04.1f\"%s", degrees, minutes, seconds, hemisphere)
+}
+
+// ParseLatitude parses a single latitude in decimal degrees or DMS.
+func ParseLatitude(raw string) (float64, error) {
+	value, hemisphere, err := parseAngle(raw)
+	if err != nil {
+		return 0, err
+	}
+	switch hemisphere {
+	case 'E', 'W':
+		return 0, fmt.Errorf("expected a latitude, got a longitude")
+	}
+
+	return value, checkLatitude(value)
+}
+
+// ParseLongitude parses a single longitude in decimal degrees or DMS.
+func ParseLongitude(raw string) (float64, error) {
+	value, hemisphere, err := parseAngle(raw)
+	if err != nil {
+		return 0, err
+	}
+	switch hemisphere {
+	case 'N', 'S':
+		return 0, fmt.Errorf("expected a longitude, got a latitude")
+	}
+
+	return value, checkLongitude(value)
+}
+
+// ParseCoordinates parses a position written as a decimal degree pair
+// ("50.4503, 30.5233"), a DMS pair, an MGRS reference or a Maidenhead locator.
+func ParseCoordinates(raw string) (lat, lon float64, err error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, 0, fmt.Errorf("coordinates are empty")
+	}
+	// Single-field locators shorter than a square are too coarse to be useful as a position.
+	if compact := strings.ReplaceAll(raw, " ", ""); len(compact) >= 4 && maidenheadPattern.MatchString(strings.ToUpper(compact)) {
+		return ParseMaidenhead(compact)
+	}
+	if mgrsPattern.MatchString(strings.ToUpper(raw)) {
+		return ParseMGRS(raw)
+	}
+	if strings.Contains(raw, "°") {
+		return parseDMSPair(raw)
+	}
+
+	parts := decimalPairSeparator.Split(raw, -1)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("unrecognized coordinates %q", raw)
+	}
+	lat, latErr := strconv.ParseFloat(parts[0], 64)
+	lon, lonErr := strconv.ParseFloat(parts[1], 64)
+	if latErr != nil || lonErr != nil {
+		return 0, 0, fmt.Errorf("unrecognized coordinates %q", raw)
+	}
+	if err := checkLatitude(lat); err != nil {
+		return 0, 0, err
+	}
+	if err := checkLongitude(lon); err != nil {
+		return 0, 0, err
+	}
+
+	return lat, lon, nil
+}
+
+func parseDMSPair(raw string) (lat, lon float64, err error) {
+	upper := strings.ToUpper(raw)
+	matches := dmsPatternFor(upper).FindAllStringSubmatch(upper, -1)
+	if len(matches) != 2 {
+		return 0, 0, fmt.Errorf("expected latitude and longitude in %q", raw)
+	}
+	first, firstHemisphere, err := dmsMatchValue(matches[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	second, secondHemisphere, err := dmsMatchValue(matches[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	lat, lon = first, second
+	if firstHemisphere == 'E' || firstHemisphere == 'W' || secondHemisphere == 'N' || secondHemisphere == 'S' {
+		lat, lon = second, first
+	}
+	if err := checkLatitude(lat); err != nil {
+		return 0, 0, err
+	}
+	if err := checkLongitude(lon); err != nil {
+		return 0, 0, err
+	}
+
+	return lat, lon, nil
+}
+
+// parseAngle parses decimal degrees or a single DMS component and reports its hemisphere letter, if any.
+func parseAngle(raw string) (float64, byte, error) {
+	raw = strings.TrimSpace(raw)
+	if value, err := strconv.ParseFloat(raw, 64); err == nil {
+		return value, 0, nil
+	}
+	upper := strings.ToUpper(raw)
+	match := dmsPatternFor(upper).FindStringSubmatch(upper)
+	if match == nil || strings.TrimSpace(match[0]) != upper {
+		return 0, 0, fmt.Errorf("%q is not a decimal or DMS angle", raw)
+	}
+
+	return dmsMatchValue(match)
+}
+
+func dmsPatternFor(upper string) *regexp.Regexp {
+	if strings.IndexAny(upper, "NSEW") == 0 {
+		return dmsPrefixPattern
+	}
+
+	return dmsSuffixPattern
+}
+
+func dmsMatchValue(match []string) (float64, byte, error) {
+	degrees, err := strconv.ParseFloat(match[2], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid degrees in %q", strings.TrimSpace(match[0]))
+	}
+	negative := strings.HasPrefix(match[2], "-")
+	value := math.Abs(degrees)
+	for i, divisor := range []float64{60, 3600} {
+		part := match[3+i]
+		if part == "" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(part, 64)
+		if err != nil || parsed >= 60 {
+			return 0, 0, fmt.Errorf("invalid minutes or seconds in %q", strings.TrimSpace(match[0]))
+		}
+		value += parsed / divisor
+	}
+
+	var hemisphere byte
+	if letter := match[1] + match[5]; letter != "" {
+		hemisphere = letter[0]
+	}
+	if hemisphere == 'S' || hemisphere == 'W' {
+		if negative {
+			return 0, 0, fmt.Errorf("angle %q has both a sign and a hemisphere", strings.TrimSpace(match[0]))
+		}
+		negative = true
+	}
+	if negative {
+		value = -value
+	}
+
+	return value, hemisphere, nil
+}
+
+func checkLatitude(value float64) error {
+	if value < -90 || value > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+
+	return nil
+}
+
+func checkLongitude(value float64) error {
+	if value < -180 || value > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+
+	return nil
+}
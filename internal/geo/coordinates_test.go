@@ -0,0 +1,77 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormatDMS(t *testing.T) {
+	tests := []struct {
+		value float64
+		want  string
+	}{
+		{value: 50.450333, want: "50°27'01.2\"N"},
+		{value: -0.999999, want: "1°00'00.0\"S"},
+		{value: 0, want: "0°00'00.0\"N"},
+	}
+	for _, tc := range tests {
+		if got := FormatDMS(tc.value, "N", "S"); got != tc.want {
+			t.Fatalf("FormatDMS(%v) = %q, want %q", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestParseCoordinates(t *testing.T) {
+	tests := []struct {
+		name      string
+		raw       string
+		lat, lon  float64
+		tolerance float64
+	}{
+		{name: "decimal comma separated", raw: "50.450333, 30.523333", lat: 50.450333, lon: 30.523333, tolerance: 1e-9},
+		{name: "decimal space separated", raw: "-33.8688 151.2093", lat: -33.8688, lon: 151.2093, tolerance: 1e-9},
+		{name: "dms suffix", raw: `50°27'01.2"N 30°31'24.0"E`, lat: 50.450333, lon: 30.523333, tolerance: 1e-6},
+		{name: "dms prefix with minutes", raw: "S 33° 52.128' E 151° 12.558'", lat: -33.8688, lon: 151.2093, tolerance: 1e-6},
+		{name: "dms longitude first", raw: `30°31'24"E, 50°27'01.2"N`, lat: 50.450333, lon: 30.523333, tolerance: 1e-6},
+		{name: "dms signed degrees", raw: "-0°30' 10.5°", lat: -0.5, lon: 10.5, tolerance: 1e-9},
+		{name: "mgrs", raw: "18S UJ 23486 06483", lat: 38.8895, lon: -77.0352, tolerance: 1e-4},
+		{name: "mgrs compact", raw: "18suj2348606483", lat: 38.8895, lon: -77.0352, tolerance: 1e-4},
+		{name: "maidenhead", raw: "KO50gk", lat: 50.4375, lon: 30.541667, tolerance: 1e-6},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lat, lon, err := ParseCoordinates(tc.raw)
+			if err != nil {
+				t.Fatalf("parse %q: %v", tc.raw, err)
+			}
+			if math.Abs(lat-tc.lat) > tc.tolerance || math.Abs(lon-tc.lon) > tc.tolerance {
+				t.Fatalf("parse %q = %.6f, %.6f, want %.6f, %.6f", tc.raw, lat, lon, tc.lat, tc.lon)
+			}
+		})
+	}
+}
+
+func TestParseCoordinatesRejectsInvalidInput(t *testing.T) {
+	for _, raw := range []string{"", "hello", "91, 10", "10, 181", "1 2 3", `50°27'N`, "N 95° 0'  E 10°"} {
+		if _, _, err := ParseCoordinates(raw); err == nil {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+}
+
+func TestParseLatitudeAndLongitude(t *testing.T) {
+	lat, err := ParseLatitude(`50°27'01.2"N`)
+	if err != nil || math.Abs(lat-50.450333) > 1e-6 {
+		t.Fatalf("unexpected latitude: %v, %v", lat, err)
+	}
+	lon, err := ParseLongitude("-77.0352")
+	if err != nil || lon != -77.0352 {
+		t.Fatalf("unexpected longitude: %v, %v", lon, err)
+	}
+	if _, err := ParseLatitude(`30°31'24"E`); err == nil {
+		t.Fatalf("expected longitude to be rejected as latitude")
+	}
+	if _, err := ParseLongitude("200"); err == nil {
+		t.Fatalf("expected out of range longitude to be rejected")
+	}
+}
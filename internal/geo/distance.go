@@ -0,0 +1,46 @@
+package geo
+
+import "math"
+
+const earthRadiusKm = 6371.0
+
+// DistanceKm returns the great-circle distance between two positions in kilometers.
+func DistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1, phi2 := lat1*math.Pi/180, lat2*math.Pi/180
+	dPhi := phi2 - phi1
+	dLambda := (lon2 - lon1) * math.Pi / 180
+
+	sinPhi := math.Sin(dPhi / 2)
+	sinLambda := math.Sin(dLambda / 2)
+	h := sinPhi*sinPhi + math.Cos(phi1)*math.Cos(phi2)*sinLambda*sinLambda
+
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// InitialBearing returns the initial great-circle bearing from the first position to the
+// second in degrees clockwise from true north, in the [0, 360) range.
+func InitialBearing(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1, phi2 := lat1*math.Pi/180, lat2*math.Pi/180
+	dLambda := (lon2 - lon1) * math.Pi / 180
+
+	y := math.Sin(dLambda) * math.Cos(phi2)
+	x := math.Cos(phi1)*math.Sin(phi2) - math.Sin(phi1)*math.Cos(phi2)*math.Cos(dLambda)
+	bearing := math.Atan2(y, x) * 180 / math.Pi
+
+	return math.Mod(bearing+360, 360)
+}
+
+// GridDistance returns the distance in kilometers and the initial bearing between the
+// centers of two Maidenhead grid squares, as exchanged on HF nets.
+func GridDistance(from, to string) (km, bearing float64, err error) {
+	lat1, lon1, err := ParseMaidenhead(from)
+	if err != nil {
+		return 0, 0, err
+	}
+	lat2, lon2, err := ParseMaidenhead(to)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return DistanceKm(lat1, lon1, lat2, lon2), InitialBearing(lat1, lon1, lat2, lon2), nil
+}
@@ -0,0 +1,49 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistanceAndBearing(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		wantKm, wantBearing    float64
+	}{
+		{name: "quarter of the equator", lat2: 0, lon2: 90, wantKm: earthRadiusKm * math.Pi / 2, wantBearing: 90},
+		{name: "due north", lat2: 10, wantKm: earthRadiusKm * 10 * math.Pi / 180, wantBearing: 0},
+		{name: "due west", lon2: -10, wantKm: earthRadiusKm * 10 * math.Pi / 180, wantBearing: 270},
+		{name: "kyiv to washington", lat1: 50.4501, lon1: 30.5234, lat2: 38.8895, lon2: -77.0352, wantKm: 7834.1, wantBearing: 308.0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := DistanceKm(tc.lat1, tc.lon1, tc.lat2, tc.lon2); math.Abs(got-tc.wantKm) > 0.5 {
+				t.Fatalf("DistanceKm = %.2f, want %.2f", got, tc.wantKm)
+			}
+			if got := InitialBearing(tc.lat1, tc.lon1, tc.lat2, tc.lon2); math.Abs(got-tc.wantBearing) > 0.1 {
+				t.Fatalf("InitialBearing = %.2f, want %.2f", got, tc.wantBearing)
+			}
+		})
+	}
+}
+
+func TestGridDistance(t *testing.T) {
+	km, bearing, err := GridDistance("JJ00aa", "jj00aa")
+	if err != nil || km != 0 || bearing != 0 {
+		t.Fatalf("expected zero distance to the same square, got %v km %v° %v", km, bearing, err)
+	}
+
+	km, bearing, err = GridDistance("KO50", "KO60")
+	if err != nil {
+		t.Fatalf("grid distance: %v", err)
+	}
+	// Neighbouring squares two degrees of longitude apart at 50.5°N.
+	if math.Abs(km-141.5) > 0.5 || math.Abs(bearing-90) > 1 {
+		t.Fatalf("unexpected distance %v km, bearing %v°", km, bearing)
+	}
+
+	if _, _, err := GridDistance("KO50", "nowhere"); err == nil {
+		t.Fatalf("expected invalid locator to be rejected")
+	}
+}
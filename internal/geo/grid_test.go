@@ -0,0 +1,93 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFormatMGRS(t *testing.T) {
+	tests := []struct {
+		name     string
+		lat, lon float64
+		want     string
+	}{
+		{name: "washington monument", lat: 38.8895, lon: -77.0352, want: "18S UJ 23486 06483"},
+		{name: "southern hemisphere", lat: -33.8688, lon: 151.2093, want: "56H LH 34368 50948"},
+		{name: "norway exception", lat: 60.39, lon: 5.32, want: "32V KN 97230 00510"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FormatMGRS(tc.lat, tc.lon)
+			if err != nil {
+				t.Fatalf("format: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("FormatMGRS(%v, %v) = %q, want %q", tc.lat, tc.lon, got, tc.want)
+			}
+		})
+	}
+	if _, err := FormatMGRS(85, 0); err == nil {
+		t.Fatalf("expected polar latitude to be rejected")
+	}
+}
+
+func TestMGRSRoundTrip(t *testing.T) {
+	for _, point := range [][2]float64{
+		{50.450333, 30.523333},
+		{-33.8688, 151.2093},
+		{0.0001, -0.0001},
+		{-79.5, 179.9},
+		{83.5, -40},
+		{78.22, 15.65},
+	} {
+		ref, err := FormatMGRS(point[0], point[1])
+		if err != nil {
+			t.Fatalf("format %v: %v", point, err)
+		}
+		lat, lon, err := ParseMGRS(ref)
+		if err != nil {
+			t.Fatalf("parse %q: %v", ref, err)
+		}
+		// Parsing returns the center of the 1 m cell the point was truncated into.
+		if math.Abs(lat-point[0]) > 2e-5 || math.Abs(lon-point[1]) > 1e-4 {
+			t.Fatalf("round trip %v -> %q -> %.6f, %.6f", point, ref, lat, lon)
+		}
+	}
+}
+
+func TestMaidenhead(t *testing.T) {
+	tests := []struct {
+		lat, lon float64
+		pairs    int
+		want     string
+	}{
+		{lat: 50.450333, lon: 30.523333, pairs: 3, want: "KO50gk"},
+		{lat: 38.8895, lon: -77.0352, pairs: 3, want: "FM18lv"},
+		{lat: -33.8688, lon: 151.2093, pairs: 4, want: "QF56od51"},
+		{lat: 90, lon: 180, pairs: 2, want: "RR99"},
+		{lat: -90, lon: -180, pairs: 1, want: "AA"},
+	}
+	for _, tc := range tests {
+		if got := Maidenhead(tc.lat, tc.lon, tc.pairs); got != tc.want {
+			t.Fatalf("Maidenhead(%v, %v, %d) = %q, want %q", tc.lat, tc.lon, tc.pairs, got, tc.want)
+		}
+	}
+}
+
+func TestParseMaidenheadReturnsSquareCenter(t *testing.T) {
+	lat, lon, err := ParseMaidenhead("fm18")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if lat != 38.5 || lon != -77 {
+		t.Fatalf("unexpected square center: %v, %v", lat, lon)
+	}
+	if got := Maidenhead(lat, lon, 2); got != "FM18" {
+		t.Fatalf("expected center to stay in the square, got %q", got)
+	}
+	for _, raw := range []string{"SS00", "KO5", "KO50zz"} {
+		if _, _, err := ParseMaidenhead(raw); err == nil {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+var maidenheadPattern = regexp.MustCompile(`^[A-R]{2}(\d{2}([A-X]{2}(\d{2})?)?)?$`)
+
+// Maidenhead returns the Maidenhead grid locator for a position with the given
+// number of character pairs (1 to 4), e.g. "KO50fk" for three pairs.
+func Maidenhead(lat, lon float64, pairs int) string {
+	pairs = min(max(pairs, 1), 4)
+	// Keep the north pole and antimeridian inside the last field.
+	lon = math.Min(math.Max(lon, -180), 180-1e-9) + 180
+	lat = math.Min(math.Max(lat, -90), 90-1e-9) + 90
+
+	var b strings.Builder
+	b.WriteByte(byte('A' + int(lon/20)))
+	b.WriteByte(byte('A' + int(lat/10)))
+	lon, lat = math.Mod(lon, 20), math.Mod(lat, 10)
+	if pairs >= 2 {
+		b.WriteByte(byte('0' + int(lon/2)))
+		b.WriteByte(byte('0' + int(lat)))
+		lon, lat = math.Mod(lon, 2), math.Mod(lat, 1)
+	}
+	if pairs >= 3 {
+		lon, lat = lon*12, lat*24
+		b.WriteByte(byte('a' + int(lon)))
+		b.WriteByte(byte('a' + int(lat)))
+		lon, lat = math.Mod(lon, 1), math.Mod(lat, 1)
+	}
+	if pairs >= 4 {
+		b.WriteByte(byte('0' + int(lon*10)))
+		b.WriteByte(byte('0' + int(lat*10)))
+	}
+
+	return b.String()
+}
+
+// ParseMaidenhead parses a 2 to 8 character grid locator and returns the center of the square.
+func ParseMaidenhead(raw string) (lat, lon float64, err error) {
+	locator := strings.ToUpper(strings.TrimSpace(raw))
+	if !maidenheadPattern.MatchString(locator) {
+		return 0, 0, fmt.Errorf("not a Maidenhead grid locator")
+	}
+
+	lonSize, latSize := 20.0, 10.0
+	lon = float64(locator[0]-'A') * lonSize
+	lat = float64(locator[1]-'A') * latSize
+	if len(locator) >= 4 {
+		lonSize, latSize = 2, 1
+		lon += float64(locator[2]-'0') * lonSize
+		lat += float64(locator[3]-'0') * latSize
+	}
+	if len(locator) >= 6 {
+		lonSize, latSize = lonSize/24, latSize/24
+		lon += float64(locator[4]-'A') * lonSize
+		lat += float64(locator[5]-'A') * latSize
+	}
+	if len(locator) == 8 {
+		lonSize, latSize = lonSize/10, latSize/10
+		lon += float64(locator[6]-'0') * lonSize
+		lat += float64(locator[7]-'0') * latSize
+	}
+
+	return lat + latSize/2 - 90, lon + lonSize/2 - 180, nil
+}
@@ -0,0 +1,248 @@
+package geo
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// WGS84 ellipsoid and UTM projection constants.
+const (
+	wgs84SemiMajorAxis = 6378137.0
+	wgs84Flattening    = 1 / 298.257223563
+	utmScaleFactor     = 0.9996
+	utmFalseEasting    = 500000.0
+	utmFalseNorthing   = 10000000.0
+
+	mgrsMinLatitude = -80.0
+	mgrsMaxLatitude = 84.0
+)
+
+const (
+	mgrsBandLetters   = "CDEFGHJKLMNPQRSTUVWX"
+	mgrsRowLetters    = "ABCDEFGHJKLMNPQRSTUV"
+	mgrsColumnLetters = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+)
+
+var mgrsPattern = regexp.MustCompile(`^(\d{1,2})\s*([C-HJ-NP-X])\s*([A-HJ-NP-Z])([A-HJ-NP-V])\s*(\d*)\s*(\d*)$`)
+
+type utmCoordinate struct {
+	zone     int
+	south    bool
+	easting  float64
+	northing float64
+}
+
+// FormatMGRS renders a position as an MGRS reference with 1 m precision,
+// e.g. "36U UU 58330 89340". MGRS does not cover the polar regions, so
+// latitudes outside -80..84 return an error.
+func FormatMGRS(lat, lon float64) (string, error) {
+	if lat < mgrsMinLatitude || lat > mgrsMaxLatitude {
+		return "", fmt.Errorf("latitude %.6f is outside the MGRS range", lat)
+	}
+	if lon < -180 || lon > 180 {
+		return "", fmt.Errorf("longitude %.6f is out of range", lon)
+	}
+	if lon == 180 {
+		lon = -180
+	}
+
+	zone := utmZone(lat, lon)
+	utm := latLonToUTM(lat, lon, zone)
+	band := mgrsBandLetter(lat)
+	columnIndex := int(math.Floor(utm.easting/100000)) - 1
+	rowIndex := (int(math.Floor(utm.northing/100000)) + mgrsRowOffset(zone)) % 20
+	columns := mgrsColumnSet(zone)
+	if columnIndex < 0 || columnIndex >= len(columns) {
+		return "", fmt.Errorf("easting %.0f is outside UTM zone %d", utm.easting, zone)
+	}
+	easting := int(math.Floor(math.Mod(utm.easting, 100000)))
+	northing := int(math.Floor(math.Mod(utm.northing, 100000)))
+
+	return fmt.Sprintf("%d%c %c%c %05d %05d", zone, band, columns[columnIndex], mgrsRowLetters[rowIndex], easting, northing), nil
+}
+
+// ParseMGRS parses an MGRS reference with 0 to 5 digit pairs, with or without
+// spaces, and returns the center of the referenced grid cell.
+func ParseMGRS(raw string) (lat, lon float64, err error) {
+	match := mgrsPattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(raw)))
+	if match == nil {
+		return 0, 0, fmt.Errorf("not an MGRS reference")
+	}
+
+	zone, _ := strconv.Atoi(match[1])
+	if zone < 1 || zone > 60 {
+		return 0, 0, fmt.Errorf("MGRS zone %d is out of range", zone)
+	}
+	band := match[2][0]
+	eastingDigits, northingDigits := match[5], match[6]
+	if northingDigits == "" {
+		if len(eastingDigits)%2 != 0 {
+			return 0, 0, fmt.Errorf("MGRS easting and northing must have the same number of digits")
+		}
+		half := len(eastingDigits) / 2
+		eastingDigits, northingDigits = eastingDigits[:half], eastingDigits[half:]
+	}
+	if len(eastingDigits) != len(northingDigits) || len(eastingDigits) > 5 {
+		return 0, 0, fmt.Errorf("MGRS easting and northing must have the same number of digits, up to 5")
+	}
+
+	columnIndex := strings.IndexByte(mgrsColumnSet(zone), match[3][0])
+	if columnIndex < 0 {
+		return 0, 0, fmt.Errorf("MGRS column letter %s is not used in zone %d", match[3], zone)
+	}
+	rowIndex := strings.IndexByte(mgrsRowLetters, match[4][0])
+
+	precision := math.Pow10(5 - len(eastingDigits))
+	easting := float64(columnIndex+1)*100000 + parseGridDigits(eastingDigits)*precision + precision/2
+	northing := float64((rowIndex-mgrsRowOffset(zone)+20)%20)*100000 + parseGridDigits(northingDigits)*precision + precision/2
+
+	// The row letters repeat every 2000 km: pick the cycle that falls into the latitude band.
+	bandIndex := strings.IndexByte(mgrsBandLetters, band)
+	bandSouth := mgrsMinLatitude + float64(bandIndex)*8
+	south := bandSouth < 0
+	lon0 := utmCentralMeridian(zone)
+	minNorthing := latLonToUTM(bandSouth, lon0, zone).northing - 100000
+	for northing < minNorthing {
+		northing += 2000000
+	}
+
+	lat, lon = utmToLatLon(utmCoordinate{zone: zone, south: south, easting: easting, northing: northing})
+
+	return lat, lon, nil
+}
+
+func parseGridDigits(digits string) float64 {
+	if digits == "" {
+		return 0
+	}
+	value, _ := strconv.Atoi(digits)
+
+	return float64(value)
+}
+
+func mgrsBandLetter(lat float64) byte {
+	index := int(math.Floor((lat - mgrsMinLatitude) / 8))
+	// Band X is 12 degrees tall and covers 72..84.
+	index = min(max(index, 0), len(mgrsBandLetters)-1)
+
+	return mgrsBandLetters[index]
+}
+
+// mgrsColumnSet returns the eight 100 km column letters used by a UTM zone.
+func mgrsColumnSet(zone int) string {
+	set := (zone - 1) % 3
+
+	return mgrsColumnLetters[set*8 : set*8+8]
+}
+
+// mgrsRowOffset shifts row lettering by five letters in even zones.
+func mgrsRowOffset(zone int) int {
+	if zone%2 == 0 {
+		return 5
+	}
+
+	return 0
+}
+
+func utmZone(lat, lon float64) int {
+	zone := int(math.Floor((lon+180)/6)) + 1
+	zone = min(max(zone, 1), 60)
+
+	// Norway and Svalbard use widened zones.
+	if lat >= 56 && lat < 64 && lon >= 3 && lon < 12 {
+		return 32
+	}
+	if lat >= 72 && lat < 84 {
+		switch {
+		case lon >= 0 && lon < 9:
+			return 31
+		case lon >= 9 && lon < 21:
+			return 33
+		case lon >= 21 && lon < 33:
+			return 35
+		case lon >= 33 && lon < 42:
+			return 37
+		}
+	}
+
+	return zone
+}
+
+func utmCentralMeridian(zone int) float64 {
+	return float64(zone-1)*6 - 180 + 3
+}
+
+func ellipsoidEccentricitySquared() float64 {
+	return wgs84Flattening * (2 - wgs84Flattening)
+}
+
+func meridianArc(phi float64) float64 {
+	e2 := ellipsoidEccentricitySquared()
+	e4 := e2 * e2
+	e6 := e4 * e2
+
+	return wgs84SemiMajorAxis * ((1-e2/4-3*e4/64-5*e6/256)*phi -
+		(3*e2/8+3*e4/32+45*e6/1024)*math.Sin(2*phi) +
+		(15*e4/256+45*e6/1024)*math.Sin(4*phi) -
+		(35*e6/3072)*math.Sin(6*phi))
+}
+
+func latLonToUTM(lat, lon float64, zone int) utmCoordinate {
+	e2 := ellipsoidEccentricitySquared()
+	ep2 := e2 / (1 - e2)
+	phi := lat * math.Pi / 180
+	lambda := (lon - utmCentralMeridian(zone)) * math.Pi / 180
+
+	sinPhi, cosPhi := math.Sin(phi), math.Cos(phi)
+	n := wgs84SemiMajorAxis / math.Sqrt(1-e2*sinPhi*sinPhi)
+	t := math.Tan(phi) * math.Tan(phi)
+	c := ep2 * cosPhi * cosPhi
+	a := cosPhi * lambda
+
+	easting := utmScaleFactor*n*(a+(1-t+c)*math.Pow(a, 3)/6+
+		(5-18*t+t*t+72*c-58*ep2)*math.Pow(a, 5)/120) + utmFalseEasting
+	northing := utmScaleFactor * (meridianArc(phi) + n*math.Tan(phi)*(a*a/2+
+		(5-t+9*c+4*c*c)*math.Pow(a, 4)/24+
+		(61-58*t+t*t+600*c-330*ep2)*math.Pow(a, 6)/720))
+	south := lat < 0
+	if south {
+		northing += utmFalseNorthing
+	}
+
+	return utmCoordinate{zone: zone, south: south, easting: easting, northing: northing}
+}
+
+func utmToLatLon(utm utmCoordinate) (lat, lon float64) {
+	e2 := ellipsoidEccentricitySquared()
+	ep2 := e2 / (1 - e2)
+	northing := utm.northing
+	if utm.south {
+		northing -= utmFalseNorthing
+	}
+
+	m := northing / utmScaleFactor
+	mu := m / (wgs84SemiMajorAxis * (1 - e2/4 - 3*e2*e2/64 - 5*e2*e2*e2/256))
+	e1 := (1 - math.Sqrt(1-e2)) / (1 + math.Sqrt(1-e2))
+	phi1 := mu + (3*e1/2-27*math.Pow(e1, 3)/32)*math.Sin(2*mu) +
+		(21*e1*e1/16-55*math.Pow(e1, 4)/32)*math.Sin(4*mu) +
+		(151*math.Pow(e1, 3)/96)*math.Sin(6*mu) +
+		(1097*math.Pow(e1, 4)/512)*math.Sin(8*mu)
+
+	sinPhi1, cosPhi1 := math.Sin(phi1), math.Cos(phi1)
+	n1 := wgs84SemiMajorAxis / math.Sqrt(1-e2*sinPhi1*sinPhi1)
+	t1 := math.Tan(phi1) * math.Tan(phi1)
+	c1 := ep2 * cosPhi1 * cosPhi1
+	r1 := wgs84SemiMajorAxis * (1 - e2) / math.Pow(1-e2*sinPhi1*sinPhi1, 1.5)
+	d := (utm.easting - utmFalseEasting) / (n1 * utmScaleFactor)
+
+	phi := phi1 - (n1*math.Tan(phi1)/r1)*(d*d/2-
+		(5+3*t1+10*c1-4*c1*c1-9*ep2)*math.Pow(d, 4)/24+
+		(61+90*t1+298*c1+45*t1*t1-252*ep2-3*c1*c1)*math.Pow(d, 6)/720)
+	lambda := (d - (1+2*t1+c1)*math.Pow(d, 3)/6 +
+		(5-2*c1+28*t1-3*c1*c1+8*ep2+24*t1*t1)*math.Pow(d, 5)/120) / cosPhi1
+
+	return phi * 180 / math.Pi, utmCentralMeridian(utm.zone) + lambda*180/math.Pi
+}
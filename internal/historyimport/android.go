@@ -0,0 +1,385 @@
+package historyimport
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
+
+	_ "modernc.org/sqlite" // register sqlite driver
+)
+
+// Android app identifiers used in its packet table.
+const (
+	androidTextMessagePort = 1
+	androidBroadcastID     = "^all"
+	androidLocalID         = "^local"
+)
+
+// androidDataPacket is the JSON form of the Android app's DataPacket stored in packet.data.
+type androidDataPacket struct {
+	To       string       `json:"to"`
+	From     string       `json:"from"`
+	Bytes    androidBytes `json:"bytes"`
+	DataType int          `json:"dataType"`
+	Time     int64        `json:"time"`
+	ID       int64        `json:"id"`
+	Status   string       `json:"status"`
+	Channel  int          `json:"channel"`
+	ReplyID  int64        `json:"replyId"`
+	Emoji    int64        `json:"emoji"`
+	HopStart int          `json:"hopStart"`
+	HopLimit int          `json:"hopLimit"`
+}
+
+// androidBytes decodes payloads serialized either as a signed byte array or as base64.
+type androidBytes []byte
+
+func (b *androidBytes) UnmarshalJSON(raw []byte) error {
+	if string(raw) == "null" {
+		*b = nil
+
+		return nil
+	}
+	var encoded string
+	if err := json.Unmarshal(raw, &encoded); err == nil {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return fmt.Errorf("decode payload: %w", err)
+		}
+		*b = decoded
+
+		return nil
+	}
+	var values []int
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+	out := make([]byte, len(values))
+	for i, v := range values {
+		out[i] = byte(v)
+	}
+	*b = out
+
+	return nil
+}
+
+// ReadAndroidDatabase reads text messages and nodes from a Meshtastic Android
+// app database file (meshtastic_database or a backup copy of it).
+func ReadAndroidDatabase(ctx context.Context, path string) (Snapshot, error) {
+	dsn := "file:" + (&url.URL{Path: path}).EscapedPath() + "?mode=ro"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("open android database: %w", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	tables, err := androidTableColumns(ctx, db, "packet")
+	if err != nil {
+		return Snapshot{}, err
+	}
+	if len(tables) == 0 {
+		return Snapshot{}, fmt.Errorf("file is not a Meshtastic Android database: packet table is missing")
+	}
+
+	snapshot := Snapshot{Source: SourceAndroidDatabase}
+	snapshot.Messages, err = readAndroidMessages(ctx, db)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	snapshot.Nodes, err = readAndroidNodes(ctx, db)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	return snapshot, nil
+}
+
+func androidTableColumns(ctx context.Context, db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return nil, fmt.Errorf("read %s columns: %w", table, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("scan %s column: %w", table, err)
+		}
+		columns[name] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read %s columns: %w", table, err)
+	}
+
+	return columns, nil
+}
+
+func readAndroidMessages(ctx context.Context, db *sql.DB) ([]domain.ChatMessage, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT myNodeNum, contact_key, received_time, data
+		FROM packet
+		WHERE port_num = ?
+		ORDER BY received_time ASC
+	`, androidTextMessagePort)
+	if err != nil {
+		return nil, fmt.Errorf("query android messages: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var messages []domain.ChatMessage
+	for rows.Next() {
+		var (
+			myNodeNum    int64
+			contactKey   string
+			receivedTime int64
+			data         string
+		)
+		if err := rows.Scan(&myNodeNum, &contactKey, &receivedTime, &data); err != nil {
+			return nil, fmt.Errorf("scan android message: %w", err)
+		}
+		var packet androidDataPacket
+		if err := json.Unmarshal([]byte(data), &packet); err != nil {
+			// Packets written by other app versions may not decode; they are skipped, not fatal.
+			continue
+		}
+		message, ok := androidMessage(packet, contactKey, formatNodeNum(uint32(myNodeNum)), receivedTime)
+		if ok {
+			messages = append(messages, message)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read android messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+func androidMessage(packet androidDataPacket, contactKey, localNodeID string, receivedTime int64) (domain.ChatMessage, bool) {
+	chatKey := androidChatKey(contactKey)
+	body := string(packet.Bytes)
+	if chatKey == "" || strings.TrimSpace(body) == "" {
+		return domain.ChatMessage{}, false
+	}
+
+	at := packet.Time
+	if at <= 0 {
+		at = receivedTime
+	}
+	message := domain.ChatMessage{
+		DeviceMessageID:        packetIDString(packet.ID),
+		ReplyToDeviceMessageID: packetIDString(packet.ReplyID),
+		Emoji:                  uint32(packet.Emoji),
+		ChatKey:                chatKey,
+		Body:                   body,
+		At:                     time.UnixMilli(at),
+	}
+
+	if packet.From == androidLocalID || (localNodeID != "" && parseNodeID(packet.From) == localNodeID) {
+		message.Direction = domain.MessageDirectionOut
+		message.Status = androidMessageStatus(packet.Status)
+		if localNodeID != "" {
+			message.MetaJSON = mustMetaJSON(map[string]any{"from": localNodeID})
+		}
+
+		return message, true
+	}
+
+	message.Direction = domain.MessageDirectionIn
+	message.Status = domain.MessageStatusSent
+	meta := map[string]any{
+		"codec":   importCodec,
+		"from":    parseNodeID(packet.From),
+		"channel": packet.Channel,
+	}
+	if to := androidMetaDestination(packet.To); to != "" {
+		meta["to"] = to
+	}
+	if message.DeviceMessageID != "" {
+		meta["packet_id"] = uint32(packet.ID)
+	}
+	if packet.HopStart > 0 && packet.HopLimit <= packet.HopStart {
+		meta["hops"] = packet.HopStart - packet.HopLimit
+	}
+	message.MetaJSON = mustMetaJSON(meta)
+
+	return message, true
+}
+
+// androidChatKey maps the app's contact keys ("0^all" for channel 0, "1!1234abcd" for a DM) to meshgo chat keys.
+func androidChatKey(contactKey string) string {
+	contactKey = strings.TrimSpace(contactKey)
+	split := strings.IndexFunc(contactKey, func(r rune) bool { return r < '0' || r > '9' })
+	if split <= 0 {
+		return ""
+	}
+	channel, err := strconv.Atoi(contactKey[:split])
+	if err != nil {
+		return ""
+	}
+	target := contactKey[split:]
+	if target == androidBroadcastID {
+		return domain.ChatKeyForChannel(channel)
+	}
+	if nodeID := parseNodeID(target); nodeID != "" {
+		return domain.ChatKeyForDM(nodeID)
+	}
+
+	return ""
+}
+
+func androidMetaDestination(to string) string {
+	if to == androidBroadcastID {
+		return "!ffffffff"
+	}
+
+	return parseNodeID(to)
+}
+
+func androidMessageStatus(status string) domain.MessageStatus {
+	switch strings.ToUpper(strings.TrimSpace(status)) {
+	case "DELIVERED", "RECEIVED":
+		return domain.MessageStatusAcked
+	case "ERROR":
+		return domain.MessageStatusFailed
+	default:
+		return domain.MessageStatusSent
+	}
+}
+
+func readAndroidNodes(ctx context.Context, db *sql.DB) ([]Node, error) {
+	columns, err := androidTableColumns(ctx, db, "nodes")
+	if err != nil {
+		return nil, err
+	}
+	if !columns["num"] {
+		return nil, nil
+	}
+
+	// Column sets differ between app versions, so select what exists and default the rest.
+	selectColumn := func(name, fallback string) string {
+		if columns[name] {
+			return name
+		}
+
+		return fallback + " AS " + name
+	}
+	query := fmt.Sprintf(`SELECT num, %s, %s, %s, %s, %s, %s, %s, %s FROM nodes ORDER BY num`,
+		selectColumn("user", "NULL"),
+		selectColumn("long_name", "NULL"),
+		selectColumn("short_name", "NULL"),
+		selectColumn("latitude", "NULL"),
+		selectColumn("longitude", "NULL"),
+		selectColumn("last_heard", "0"),
+		selectColumn("snr", "NULL"),
+		selectColumn("is_favorite", "0"),
+	)
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("query android nodes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var nodes []Node
+	for rows.Next() {
+		var (
+			num        int64
+			userBlob   []byte
+			longName   sql.NullString
+			shortName  sql.NullString
+			latitude   sql.NullFloat64
+			longitude  sql.NullFloat64
+			lastHeard  sql.NullInt64
+			snr        sql.NullFloat64
+			isFavorite sql.NullBool
+		)
+		if err := rows.Scan(&num, &userBlob, &longName, &shortName, &latitude, &longitude, &lastHeard, &snr, &isFavorite); err != nil {
+			return nil, fmt.Errorf("scan android node: %w", err)
+		}
+		nodeID := formatNodeNum(uint32(num))
+		if domain.NormalizeNodeID(nodeID) == "" {
+			continue
+		}
+		lastHeardAt := unixSecondsToTime(lastHeard.Int64)
+		core := domain.NodeCore{
+			NodeID:      nodeID,
+			LongName:    strings.TrimSpace(longName.String),
+			ShortName:   strings.TrimSpace(shortName.String),
+			LastHeardAt: lastHeardAt,
+			UpdatedAt:   lastHeardAt,
+		}
+		applyAndroidUser(&core, userBlob)
+		if snr.Valid && snr.Float64 != 0 {
+			value := snr.Float64
+			core.SNR = &value
+		}
+		if isFavorite.Valid {
+			value := isFavorite.Bool
+			core.IsFavorite = &value
+		}
+
+		node := Node{Core: core}
+		// 0,0 is what the app stores for nodes without a known position.
+		if latitude.Valid && longitude.Valid && (latitude.Float64 != 0 || longitude.Float64 != 0) {
+			lat, lon := latitude.Float64, longitude.Float64
+			node.Position = &domain.NodePosition{
+				NodeID:            nodeID,
+				Latitude:          &lat,
+				Longitude:         &lon,
+				PositionUpdatedAt: lastHeardAt,
+				ObservedAt:        lastHeardAt,
+				UpdatedAt:         lastHeardAt,
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read android nodes: %w", err)
+	}
+
+	return nodes, nil
+}
+
+func applyAndroidUser(core *domain.NodeCore, blob []byte) {
+	if len(blob) == 0 {
+		return
+	}
+	var user generated.User
+	if err := proto.Unmarshal(blob, &user); err != nil {
+		return
+	}
+	if name := strings.TrimSpace(user.GetLongName()); name != "" {
+		core.LongName = name
+	}
+	if name := strings.TrimSpace(user.GetShortName()); name != "" {
+		core.ShortName = name
+	}
+	if model := user.GetHwModel(); model != generated.HardwareModel_UNSET {
+		core.BoardModel = model.String()
+	}
+	core.Role = user.GetRole().String()
+	if key := user.GetPublicKey(); len(key) > 0 {
+		core.PublicKey = append([]byte(nil), key...)
+	}
+}
+
+func mustMetaJSON(meta map[string]any) string {
+	raw, err := json.Marshal(meta)
+	if err != nil {
+		return ""
+	}
+
+	return string(raw)
+}
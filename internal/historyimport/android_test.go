@@ -0,0 +1,153 @@
+package historyimport
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
+)
+
+func writeAndroidDatabase(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "meshtastic_database")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	user, err := proto.Marshal(&generated.User{
+		Id:        "!0000abcd",
+		LongName:  "Base Station",
+		ShortName: "BASE",
+		HwModel:   generated.HardwareModel_TBEAM,
+		Role:      generated.Config_DeviceConfig_ROUTER,
+	})
+	if err != nil {
+		t.Fatalf("marshal user: %v", err)
+	}
+
+	statements := []string{
+		`CREATE TABLE packet (uuid INTEGER PRIMARY KEY, myNodeNum INTEGER NOT NULL, port_num INTEGER NOT NULL, contact_key TEXT NOT NULL, received_time INTEGER NOT NULL, read INTEGER NOT NULL DEFAULT 1, data TEXT NOT NULL)`,
+		`CREATE TABLE nodes (num INTEGER PRIMARY KEY, user BLOB, long_name TEXT, short_name TEXT, latitude REAL, longitude REAL, snr REAL, last_heard INTEGER, is_favorite INTEGER)`,
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("create schema: %v", err)
+		}
+	}
+
+	packets := []struct {
+		contactKey string
+		port       int
+		data       string
+	}{
+		// "hi" as a signed byte array, as the app serializes it.
+		{"0^all", 1, `{"to":"^all","from":"!0000abcd","bytes":[104,105],"dataType":1,"time":1700000000000,"id":-2,"status":"RECEIVED","channel":0,"hopStart":3,"hopLimit":1}`},
+		{"0^all", 1, `{"to":"^all","from":"^local","bytes":"eW8=","dataType":1,"time":1700000060000,"id":77,"status":"DELIVERED","channel":0,"replyId":-2}`},
+		{"1!0000abcd", 1, `{"to":"!00001234","from":"!0000abcd","bytes":[100,109],"dataType":1,"time":1700000120000,"id":78,"status":"RECEIVED","channel":1}`},
+		// Position packets and undecodable rows are ignored.
+		{"0^all", 3, `{"to":"^all","from":"!0000abcd","bytes":[1,2],"dataType":3,"time":1700000000000,"id":79}`},
+		{"0^all", 1, `not json`},
+	}
+	for _, packet := range packets {
+		if _, err := db.Exec(
+			`INSERT INTO packet(myNodeNum, port_num, contact_key, received_time, data) VALUES (?, ?, ?, ?, ?)`,
+			0x1234, packet.port, packet.contactKey, 1700000000000, packet.data,
+		); err != nil {
+			t.Fatalf("insert packet: %v", err)
+		}
+	}
+	if _, err := db.Exec(
+		`INSERT INTO nodes(num, user, long_name, short_name, latitude, longitude, snr, last_heard, is_favorite) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?), (?, NULL, ?, ?, 0, 0, 0, ?, 0)`,
+		0xabcd, user, "Stale", "OLD", 50.45, 30.52, 6.5, 1700000100, 1,
+		0x1234, "Me", "ME", 1700000200,
+	); err != nil {
+		t.Fatalf("insert nodes: %v", err)
+	}
+
+	return path
+}
+
+func TestReadAndroidDatabase(t *testing.T) {
+	snapshot, err := Read(context.Background(), writeAndroidDatabase(t))
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if snapshot.Source != SourceAndroidDatabase {
+		t.Fatalf("unexpected source: %q", snapshot.Source)
+	}
+	if len(snapshot.Messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d: %+v", len(snapshot.Messages), snapshot.Messages)
+	}
+
+	incoming := snapshot.Messages[0]
+	if incoming.ChatKey != "channel:0" || incoming.Body != "hi" || incoming.Direction != domain.MessageDirectionIn {
+		t.Fatalf("unexpected incoming message: %+v", incoming)
+	}
+	if incoming.DeviceMessageID != "4294967294" || !incoming.At.Equal(time.UnixMilli(1700000000000)) {
+		t.Fatalf("unexpected incoming id or time: %+v", incoming)
+	}
+	wantMeta := `{"channel":0,"codec":"history-import","from":"!0000abcd","hops":2,"packet_id":4294967294,"to":"!ffffffff"}`
+	if incoming.MetaJSON != wantMeta {
+		t.Fatalf("unexpected incoming meta: %s", incoming.MetaJSON)
+	}
+
+	outgoing := snapshot.Messages[1]
+	if outgoing.Direction != domain.MessageDirectionOut || outgoing.Body != "yo" || outgoing.Status != domain.MessageStatusAcked {
+		t.Fatalf("unexpected outgoing message: %+v", outgoing)
+	}
+	if outgoing.ReplyToDeviceMessageID != "4294967294" || outgoing.MetaJSON != `{"from":"!00001234"}` {
+		t.Fatalf("unexpected outgoing reply or meta: %+v", outgoing)
+	}
+
+	if dm := snapshot.Messages[2]; dm.ChatKey != "dm:!0000abcd" || dm.Body != "dm" {
+		t.Fatalf("unexpected dm message: %+v", dm)
+	}
+
+	if len(snapshot.Nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %d", len(snapshot.Nodes))
+	}
+	local, remote := snapshot.Nodes[0], snapshot.Nodes[1]
+	if local.Core.NodeID != "!00001234" || local.Core.LongName != "Me" || local.Position != nil || local.Core.SNR != nil {
+		t.Fatalf("unexpected local node: %+v", local)
+	}
+	core := remote.Core
+	if core.NodeID != "!0000abcd" || core.LongName != "Base Station" || core.ShortName != "BASE" {
+		t.Fatalf("user blob should override name columns: %+v", core)
+	}
+	if core.BoardModel != "TBEAM" || core.Role != "ROUTER" || core.IsFavorite == nil || !*core.IsFavorite {
+		t.Fatalf("unexpected node metadata: %+v", core)
+	}
+	if !core.LastHeardAt.Equal(time.Unix(1700000100, 0)) || core.SNR == nil || *core.SNR != 6.5 {
+		t.Fatalf("unexpected node radio fields: %+v", core)
+	}
+	if remote.Position == nil || *remote.Position.Latitude != 50.45 || *remote.Position.Longitude != 30.52 {
+		t.Fatalf("unexpected node position: %+v", remote.Position)
+	}
+}
+
+func TestAndroidChatKey(t *testing.T) {
+	tests := []struct {
+		contactKey string
+		want       string
+	}{
+		{contactKey: "0^all", want: "channel:0"},
+		{contactKey: "3^all", want: "channel:3"},
+		{contactKey: "0!1234abcd", want: "dm:!1234abcd"},
+		{contactKey: "^all", want: ""},
+		{contactKey: "0^local", want: ""},
+	}
+	for _, tc := range tests {
+		if got := androidChatKey(tc.contactKey); got != tc.want {
+			t.Fatalf("androidChatKey(%q): got %q, want %q", tc.contactKey, got, tc.want)
+		}
+	}
+}
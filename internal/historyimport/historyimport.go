@@ -0,0 +1,228 @@
+package historyimport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// Source identifies the client an import file was produced by.
+type Source string
+
+const (
+	SourceAndroidDatabase Source = "android_database"
+	SourcePythonCLI       Source = "python_cli"
+)
+
+// Label returns a human-readable source name.
+func (s Source) Label() string {
+	switch s {
+	case SourceAndroidDatabase:
+		return "Meshtastic Android database"
+	case SourcePythonCLI:
+		return "Meshtastic Python CLI"
+	default:
+		return string(s)
+	}
+}
+
+// importCodec marks imported messages in their meta JSON, next to "meshtastic-proto" for live ones.
+const importCodec = "history-import"
+
+var sqliteHeader = []byte("SQLite format 3\x00")
+
+// Node is an imported node snapshot.
+type Node struct {
+	Core     domain.NodeCore
+	Position *domain.NodePosition
+}
+
+// Snapshot is everything read from an import file, already mapped to meshgo's chat keys and node ids.
+type Snapshot struct {
+	Source   Source
+	Messages []domain.ChatMessage
+	Nodes    []Node
+}
+
+// Report summarizes what a merge changed.
+type Report struct {
+	Source           Source
+	MessagesRead     int
+	MessagesImported int
+	ChatsUpdated     int
+	NodesRead        int
+	NodesImported    int
+}
+
+// MessagesSkipped returns how many messages were already present.
+func (r Report) MessagesSkipped() int {
+	return r.MessagesRead - r.MessagesImported
+}
+
+// NodesSkipped returns how many nodes were already known with newer data.
+func (r Report) NodesSkipped() int {
+	return r.NodesRead - r.NodesImported
+}
+
+// Target is the store imported data is merged into.
+type Target interface {
+	// InsertMessage stores a message unless an equivalent one already exists and reports whether it was added.
+	InsertMessage(ctx context.Context, message domain.ChatMessage) (bool, error)
+	UpsertChat(ctx context.Context, chat domain.Chat) error
+	// NodeLastHeard returns when a stored node was last heard, if it is known.
+	NodeLastHeard(ctx context.Context, nodeID string) (time.Time, bool, error)
+	UpsertNode(ctx context.Context, node Node) error
+}
+
+// Read detects the file format and reads an import snapshot from path.
+func Read(ctx context.Context, path string) (Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("open import file: %w", err)
+	}
+	header := make([]byte, len(sqliteHeader))
+	n, err := io.ReadFull(file, header)
+	_ = file.Close()
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return Snapshot{}, fmt.Errorf("read import file: %w", err)
+	}
+	if bytes.Equal(header[:n], sqliteHeader) {
+		return ReadAndroidDatabase(ctx, path)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("read import file: %w", err)
+	}
+
+	return ReadPythonNodes(raw)
+}
+
+// Merge writes a snapshot into target. Messages already stored are skipped,
+// and nodes are only updated when the import has heard from them more recently.
+func Merge(ctx context.Context, snapshot Snapshot, target Target) (Report, error) {
+	report := Report{
+		Source:       snapshot.Source,
+		MessagesRead: len(snapshot.Messages),
+		NodesRead:    len(snapshot.Nodes),
+	}
+
+	chats := make(map[string]domain.Chat)
+	for _, message := range snapshot.Messages {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		inserted, err := target.InsertMessage(ctx, message)
+		if err != nil {
+			return report, fmt.Errorf("import message %s: %w", message.DeviceMessageID, err)
+		}
+		if !inserted {
+			continue
+		}
+		report.MessagesImported++
+		chats[message.ChatKey] = extendChat(chats[message.ChatKey], message)
+	}
+
+	keys := make([]string, 0, len(chats))
+	for key := range chats {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := target.UpsertChat(ctx, chats[key]); err != nil {
+			return report, fmt.Errorf("import chat %s: %w", key, err)
+		}
+		report.ChatsUpdated++
+	}
+
+	for _, node := range snapshot.Nodes {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		lastHeard, known, err := target.NodeLastHeard(ctx, node.Core.NodeID)
+		if err != nil {
+			return report, fmt.Errorf("look up node %s: %w", node.Core.NodeID, err)
+		}
+		if known && !node.Core.LastHeardAt.After(lastHeard) {
+			continue
+		}
+		if err := target.UpsertNode(ctx, node); err != nil {
+			return report, fmt.Errorf("import node %s: %w", node.Core.NodeID, err)
+		}
+		report.NodesImported++
+	}
+
+	return report, nil
+}
+
+func extendChat(chat domain.Chat, message domain.ChatMessage) domain.Chat {
+	if chat.Key == "" {
+		chat.Key = message.ChatKey
+		// The chat key doubles as a placeholder title that never replaces a known one.
+		chat.Title = message.ChatKey
+		chat.Type = domain.ChatTypeChannel
+		if domain.IsDMKey(message.ChatKey) {
+			chat.Type = domain.ChatTypeDM
+		}
+	}
+	if message.At.After(chat.UpdatedAt) {
+		chat.UpdatedAt = message.At
+	}
+	if message.Direction == domain.MessageDirectionOut && message.At.After(chat.LastSentByMeAt) {
+		chat.LastSentByMeAt = message.At
+	}
+
+	return chat
+}
+
+// formatNodeNum renders a node number the way the radio codec does, e.g. "!1234abcd".
+func formatNodeNum(num uint32) string {
+	if num == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("!%08x", num)
+}
+
+// parseNodeID accepts "!1234abcd" ids and plain decimal node numbers.
+func parseNodeID(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "!") {
+		num, err := strconv.ParseUint(raw[1:], 16, 32)
+		if err != nil {
+			return ""
+		}
+
+		return domain.NormalizeNodeID(formatNodeNum(uint32(num)))
+	}
+	if num, err := strconv.ParseUint(raw, 10, 32); err == nil {
+		return domain.NormalizeNodeID(formatNodeNum(uint32(num)))
+	}
+
+	return ""
+}
+
+func packetIDString(id int64) string {
+	// Android stores packet ids as signed 32-bit integers.
+	if id == 0 {
+		return ""
+	}
+
+	return strconv.FormatUint(uint64(uint32(id)), 10)
+}
+
+func unixSecondsToTime(sec int64) time.Time {
+	if sec <= 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(sec, 0)
+}
@@ -0,0 +1,141 @@
+package historyimport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+type fakeTarget struct {
+	messages  map[string]domain.ChatMessage
+	chats     []domain.Chat
+	lastHeard map[string]time.Time
+	nodes     []Node
+}
+
+func newFakeTarget() *fakeTarget {
+	return &fakeTarget{
+		messages:  make(map[string]domain.ChatMessage),
+		lastHeard: make(map[string]time.Time),
+	}
+}
+
+func (t *fakeTarget) InsertMessage(_ context.Context, message domain.ChatMessage) (bool, error) {
+	key := message.ChatKey + "|" + message.DeviceMessageID + "|" + message.Body
+	if _, ok := t.messages[key]; ok {
+		return false, nil
+	}
+	t.messages[key] = message
+
+	return true, nil
+}
+
+func (t *fakeTarget) UpsertChat(_ context.Context, chat domain.Chat) error {
+	t.chats = append(t.chats, chat)
+
+	return nil
+}
+
+func (t *fakeTarget) NodeLastHeard(_ context.Context, nodeID string) (time.Time, bool, error) {
+	at, ok := t.lastHeard[nodeID]
+
+	return at, ok, nil
+}
+
+func (t *fakeTarget) UpsertNode(_ context.Context, node Node) error {
+	t.nodes = append(t.nodes, node)
+	t.lastHeard[node.Core.NodeID] = node.Core.LastHeardAt
+
+	return nil
+}
+
+func TestMergeSkipsDuplicatesAndStaleNodes(t *testing.T) {
+	base := time.Unix(1_700_000_000, 0)
+	snapshot := Snapshot{
+		Source: SourceAndroidDatabase,
+		Messages: []domain.ChatMessage{
+			{DeviceMessageID: "1", ChatKey: "channel:0", Body: "hi", Direction: domain.MessageDirectionIn, At: base},
+			{DeviceMessageID: "2", ChatKey: "channel:0", Body: "yo", Direction: domain.MessageDirectionOut, At: base.Add(time.Minute)},
+			{DeviceMessageID: "3", ChatKey: "dm:!0000abcd", Body: "dm", Direction: domain.MessageDirectionIn, At: base.Add(2 * time.Minute)},
+		},
+		Nodes: []Node{
+			{Core: domain.NodeCore{NodeID: "!00000001", LastHeardAt: base}},
+			{Core: domain.NodeCore{NodeID: "!00000002", LastHeardAt: base.Add(time.Hour)}},
+			{Core: domain.NodeCore{NodeID: "!00000003", LastHeardAt: base}},
+		},
+	}
+	target := newFakeTarget()
+	target.messages["channel:0|1|hi"] = snapshot.Messages[0]
+	target.lastHeard["!00000001"] = base.Add(time.Hour)
+	target.lastHeard["!00000002"] = base
+
+	report, err := Merge(context.Background(), snapshot, target)
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+
+	want := Report{
+		Source:           SourceAndroidDatabase,
+		MessagesRead:     3,
+		MessagesImported: 2,
+		ChatsUpdated:     2,
+		NodesRead:        3,
+		NodesImported:    2,
+	}
+	if report != want {
+		t.Fatalf("unexpected report: got %+v, want %+v", report, want)
+	}
+	if report.MessagesSkipped() != 1 || report.NodesSkipped() != 1 {
+		t.Fatalf("unexpected skipped counts: messages %d, nodes %d", report.MessagesSkipped(), report.NodesSkipped())
+	}
+
+	if len(target.chats) != 2 {
+		t.Fatalf("expected 2 chat upserts, got %d", len(target.chats))
+	}
+	channel, dm := target.chats[0], target.chats[1]
+	if channel.Key != "channel:0" || channel.Type != domain.ChatTypeChannel || channel.Title != "channel:0" {
+		t.Fatalf("unexpected channel chat: %+v", channel)
+	}
+	if !channel.UpdatedAt.Equal(base.Add(time.Minute)) || !channel.LastSentByMeAt.Equal(base.Add(time.Minute)) {
+		t.Fatalf("unexpected channel chat times: %+v", channel)
+	}
+	if dm.Type != domain.ChatTypeDM || !dm.LastSentByMeAt.IsZero() {
+		t.Fatalf("unexpected dm chat: %+v", dm)
+	}
+
+	if target.nodes[0].Core.NodeID != "!00000002" || target.nodes[1].Core.NodeID != "!00000003" {
+		t.Fatalf("unexpected imported nodes: %+v", target.nodes)
+	}
+}
+
+func TestMergeStopsOnCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	snapshot := Snapshot{Messages: []domain.ChatMessage{{ChatKey: "channel:0", Body: "hi"}}}
+	if _, err := Merge(ctx, snapshot, newFakeTarget()); err == nil {
+		t.Fatalf("expected canceled merge to fail")
+	}
+}
+
+func TestParseNodeID(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+	}{
+		{raw: "!1234ABCD", want: "!1234abcd"},
+		{raw: "!abcd", want: "!0000abcd"},
+		{raw: "305419896", want: "!12345678"},
+		{raw: "!ffffffff", want: ""},
+		{raw: "^all", want: ""},
+		{raw: "!nothex", want: ""},
+		{raw: "", want: ""},
+	}
+	for _, tc := range tests {
+		if got := parseNodeID(tc.raw); got != tc.want {
+			t.Fatalf("parseNodeID(%q): got %q, want %q", tc.raw, got, tc.want)
+		}
+	}
+}
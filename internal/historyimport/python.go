@@ -0,0 +1,166 @@
+package historyimport
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// pythonNodesMarker precedes the node database in `meshtastic --info` output.
+const pythonNodesMarker = "Nodes in mesh:"
+
+// pythonNode is one entry of the Python CLI node database, as printed by `meshtastic --info`.
+type pythonNode struct {
+	Num  uint32 `json:"num"`
+	User *struct {
+		ID        string `json:"id"`
+		LongName  string `json:"longName"`
+		ShortName string `json:"shortName"`
+		HwModel   string `json:"hwModel"`
+		Role      string `json:"role"`
+		PublicKey string `json:"publicKey"`
+	} `json:"user"`
+	Position *struct {
+		Latitude   *float64 `json:"latitude"`
+		Longitude  *float64 `json:"longitude"`
+		LatitudeI  *int32   `json:"latitudeI"`
+		LongitudeI *int32   `json:"longitudeI"`
+		Altitude   *int32   `json:"altitude"`
+		Time       int64    `json:"time"`
+	} `json:"position"`
+	LastHeard  int64    `json:"lastHeard"`
+	SNR        *float64 `json:"snr"`
+	IsFavorite *bool    `json:"isFavorite"`
+}
+
+// ReadPythonNodes parses the node database written by the Meshtastic Python CLI.
+// It accepts the full `meshtastic --info` output, a {"nodes": ...} object, or the
+// nodes themselves as a map keyed by node id or as a list. The CLI keeps no message
+// history, so the snapshot only carries nodes.
+func ReadPythonNodes(raw []byte) (Snapshot, error) {
+	if index := bytes.Index(raw, []byte(pythonNodesMarker)); index >= 0 {
+		raw = raw[index+len(pythonNodesMarker):]
+	}
+	var value json.RawMessage
+	// Decode only the first JSON value: --info output continues with other sections.
+	if err := json.NewDecoder(bytes.NewReader(raw)).Decode(&value); err != nil {
+		return Snapshot{}, fmt.Errorf("file is neither a Meshtastic Android database nor Python CLI node JSON: %w", err)
+	}
+
+	var wrapped struct {
+		Nodes json.RawMessage `json:"nodes"`
+	}
+	if err := json.Unmarshal(value, &wrapped); err == nil && len(wrapped.Nodes) > 0 {
+		value = wrapped.Nodes
+	}
+
+	var list []pythonNode
+	if err := json.Unmarshal(value, &list); err != nil {
+		byID := make(map[string]pythonNode)
+		if err := json.Unmarshal(value, &byID); err != nil {
+			return Snapshot{}, fmt.Errorf("decode python cli nodes: %w", err)
+		}
+		keys := make([]string, 0, len(byID))
+		for key := range byID {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			node := byID[key]
+			if node.Num == 0 {
+				node.Num = nodeNumFromID(key)
+			}
+			list = append(list, node)
+		}
+	}
+
+	snapshot := Snapshot{Source: SourcePythonCLI}
+	for _, entry := range list {
+		if node, ok := pythonNodeToNode(entry); ok {
+			snapshot.Nodes = append(snapshot.Nodes, node)
+		}
+	}
+	if len(snapshot.Nodes) == 0 {
+		return Snapshot{}, fmt.Errorf("no nodes found in python cli json")
+	}
+
+	return snapshot, nil
+}
+
+func pythonNodeToNode(entry pythonNode) (Node, bool) {
+	nodeID := formatNodeNum(entry.Num)
+	if entry.User != nil && nodeID == "" {
+		nodeID = parseNodeID(entry.User.ID)
+	}
+	if domain.NormalizeNodeID(nodeID) == "" {
+		return Node{}, false
+	}
+
+	lastHeardAt := unixSecondsToTime(entry.LastHeard)
+	core := domain.NodeCore{
+		NodeID:      nodeID,
+		LastHeardAt: lastHeardAt,
+		UpdatedAt:   lastHeardAt,
+		SNR:         entry.SNR,
+		IsFavorite:  entry.IsFavorite,
+	}
+	if user := entry.User; user != nil {
+		core.LongName = strings.TrimSpace(user.LongName)
+		core.ShortName = strings.TrimSpace(user.ShortName)
+		if model := strings.TrimSpace(user.HwModel); model != "" && model != "UNSET" {
+			core.BoardModel = model
+		}
+		core.Role = strings.TrimSpace(user.Role)
+		if key, err := base64.StdEncoding.DecodeString(user.PublicKey); err == nil && len(key) > 0 {
+			core.PublicKey = key
+		}
+	}
+
+	node := Node{Core: core}
+	if position := entry.Position; position != nil {
+		lat, lon := position.Latitude, position.Longitude
+		if lat == nil && position.LatitudeI != nil {
+			value := float64(*position.LatitudeI) * 1e-7
+			lat = &value
+		}
+		if lon == nil && position.LongitudeI != nil {
+			value := float64(*position.LongitudeI) * 1e-7
+			lon = &value
+		}
+		if lat != nil && lon != nil && (*lat != 0 || *lon != 0) {
+			positionAt := unixSecondsToTime(position.Time)
+			if positionAt.IsZero() {
+				positionAt = lastHeardAt
+			}
+			node.Position = &domain.NodePosition{
+				NodeID:            nodeID,
+				Latitude:          lat,
+				Longitude:         lon,
+				Altitude:          position.Altitude,
+				PositionUpdatedAt: positionAt,
+				ObservedAt:        lastHeardAt,
+				UpdatedAt:         lastHeardAt,
+			}
+		}
+	}
+
+	return node, true
+}
+
+func nodeNumFromID(raw string) uint32 {
+	nodeID := parseNodeID(raw)
+	if nodeID == "" {
+		return 0
+	}
+	var num uint32
+	if _, err := fmt.Sscanf(nodeID, "!%08x", &num); err != nil {
+		return 0
+	}
+
+	return num
+}
@@ -0,0 +1,93 @@
+package historyimport
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const pythonInfoOutput = `Connected to radio
+Owner: Base Station (BASE)
+Nodes in mesh: {
+  "!0000abcd": {
+    "num": 43981,
+    "user": {"id": "!0000abcd", "longName": "Base Station", "shortName": "BASE", "hwModel": "TBEAM", "role": "ROUTER"},
+    "position": {"latitudeI": 504500000, "longitudeI": 305200000, "altitude": 180, "time": 1700000050},
+    "snr": 6.5,
+    "lastHeard": 1700000100,
+    "isFavorite": true
+  },
+  "!00001234": {
+    "user": {"id": "!00001234", "longName": "Me", "shortName": "ME", "hwModel": "UNSET"},
+    "position": {"latitude": 0, "longitude": 0}
+  }
+}
+
+Preferences: { "device": {} }
+`
+
+func TestReadPythonNodesFromInfoOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "info.txt")
+	if err := os.WriteFile(path, []byte(pythonInfoOutput), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	snapshot, err := Read(context.Background(), path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if snapshot.Source != SourcePythonCLI || len(snapshot.Messages) != 0 || len(snapshot.Nodes) != 2 {
+		t.Fatalf("unexpected snapshot: %+v", snapshot)
+	}
+
+	// Map entries are read in node id order.
+	me, base := snapshot.Nodes[0], snapshot.Nodes[1]
+	if base.Core.NodeID != "!0000abcd" || base.Core.LongName != "Base Station" || base.Core.BoardModel != "TBEAM" || base.Core.Role != "ROUTER" {
+		t.Fatalf("unexpected node core: %+v", base.Core)
+	}
+	if !base.Core.LastHeardAt.Equal(time.Unix(1700000100, 0)) || base.Core.IsFavorite == nil || !*base.Core.IsFavorite {
+		t.Fatalf("unexpected node state: %+v", base.Core)
+	}
+	position := base.Position
+	if position == nil || math.Abs(*position.Latitude-50.45) > 1e-9 || math.Abs(*position.Longitude-30.52) > 1e-9 || *position.Altitude != 180 {
+		t.Fatalf("unexpected node position: %+v", position)
+	}
+	if !position.PositionUpdatedAt.Equal(time.Unix(1700000050, 0)) {
+		t.Fatalf("unexpected position time: %v", position.PositionUpdatedAt)
+	}
+
+	// The node number is taken from the map key when missing, and 0,0 means no position.
+	if me.Core.NodeID != "!00001234" || me.Core.BoardModel != "" || me.Position != nil {
+		t.Fatalf("unexpected second node: %+v", me)
+	}
+}
+
+func TestReadPythonNodesAcceptsJSONShapes(t *testing.T) {
+	tests := map[string]string{
+		"wrapped": `{"nodes": {"!0000abcd": {"num": 43981, "user": {"longName": "A"}}}}`,
+		"list":    `[{"num": 43981, "user": {"longName": "A"}}]`,
+		"user id": `[{"user": {"id": "!0000abcd", "longName": "A"}}]`,
+	}
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			snapshot, err := ReadPythonNodes([]byte(raw))
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if len(snapshot.Nodes) != 1 || snapshot.Nodes[0].Core.NodeID != "!0000abcd" || snapshot.Nodes[0].Core.LongName != "A" {
+				t.Fatalf("unexpected nodes: %+v", snapshot.Nodes)
+			}
+		})
+	}
+}
+
+func TestReadPythonNodesRejectsUnknownFiles(t *testing.T) {
+	for _, raw := range []string{"", "hello", `{"nodes": {}}`, `[]`} {
+		if _, err := ReadPythonNodes([]byte(raw)); err == nil {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Package i18n translates UI strings. Source strings are written in English and double
+// as catalog keys, so a string without a translation is shown as is.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SourceLanguage is the language UI strings are written in.
+const SourceLanguage = "en"
+
+//go:embed locales/*.json
+var builtinLocales embed.FS
+
+// Language is a language with a catalog.
+type Language struct {
+	Code string
+	Name string
+}
+
+// catalogFile is the JSON layout of a catalog. Language is the native name of the
+// language; Messages maps source strings to translations.
+type catalogFile struct {
+	Language string            `json:"language"`
+	Messages map[string]string `json:"messages"`
+}
+
+type catalog struct {
+	name     string
+	messages map[string]string
+}
+
+var state = struct {
+	mu        sync.RWMutex
+	catalogs  map[string]catalog
+	current   string
+	listeners []func(code string)
+}{
+	catalogs: map[string]catalog{},
+	current:  SourceLanguage,
+}
+
+func init() {
+	if err := loadFS(builtinLocales, "locales"); err != nil {
+		panic(fmt.Sprintf("load built-in translations: %v", err))
+	}
+}
+
+// T returns the translation of source in the current language.
+func T(source string) string {
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	if translated := state.catalogs[state.current].messages[source]; translated != "" {
+		return translated
+	}
+
+	return source
+}
+
+// Tf translates the format string and formats it with args.
+func Tf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Current returns the code of the current language.
+func Current() string {
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+
+	return state.current
+}
+
+// SetLanguage switches to the language and notifies listeners when it changed. Unknown
+// languages fall back to the source language. It returns the language in use.
+func SetLanguage(code string) string {
+	code = normalizeCode(code)
+	state.mu.Lock()
+	if _, ok := state.catalogs[code]; !ok {
+		code = SourceLanguage
+	}
+	changed := code != state.current
+	state.current = code
+	listeners := append([]func(string){}, state.listeners...)
+	state.mu.Unlock()
+
+	if changed {
+		for _, listener := range listeners {
+			listener(code)
+		}
+	}
+
+	return code
+}
+
+// OnChange registers a listener called after the language changes.
+func OnChange(listener func(code string)) {
+	if listener == nil {
+		return
+	}
+	state.mu.Lock()
+	state.listeners = append(state.listeners, listener)
+	state.mu.Unlock()
+}
+
+// Languages lists languages with a catalog: the source language first, then the rest
+// by code.
+func Languages() []Language {
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	out := make([]Language, 0, len(state.catalogs))
+	for code, item := range state.catalogs {
+		out = append(out, Language{Code: code, Name: item.name})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if (out[i].Code == SourceLanguage) != (out[j].Code == SourceLanguage) {
+			return out[i].Code == SourceLanguage
+		}
+
+		return out[i].Code < out[j].Code
+	})
+
+	return out
+}
+
+// Resolve picks the language for the setting. An empty setting follows the system
+// locale, e.g. "ru-RU" or "de_DE.UTF-8".
+func Resolve(setting, systemLocale string) string {
+	code := normalizeCode(setting)
+	if code == "" {
+		code = normalizeCode(systemLocale)
+	}
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	if _, ok := state.catalogs[code]; ok {
+		return code
+	}
+
+	return SourceLanguage
+}
+
+// LoadDir adds catalogs from <code>.json files in dir. They extend built-in catalogs of
+// the same language, so community translations work without rebuilding the app. A
+// missing dir is not an error.
+func LoadDir(dir string) error {
+	if strings.TrimSpace(dir) == "" {
+		return nil
+	}
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	return loadFS(os.DirFS(dir), ".")
+}
+
+func loadFS(fsys fs.FS, dir string) error {
+	paths, err := fs.Glob(fsys, filepath.ToSlash(filepath.Join(dir, "*.json")))
+	if err != nil {
+		return fmt.Errorf("list translation catalogs: %w", err)
+	}
+	for _, path := range paths {
+		raw, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return fmt.Errorf("read translation catalog %s: %w", path, err)
+		}
+		code := normalizeCode(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+		if err := addCatalog(code, raw); err != nil {
+			return fmt.Errorf("parse translation catalog %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+func addCatalog(code string, raw []byte) error {
+	if code == "" {
+		return fmt.Errorf("language code is empty")
+	}
+	var file catalogFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return err
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	item, ok := state.catalogs[code]
+	if !ok {
+		item = catalog{name: code, messages: map[string]string{}}
+	}
+	if name := strings.TrimSpace(file.Language); name != "" {
+		item.name = name
+	}
+	for source, translated := range file.Messages {
+		if code == SourceLanguage {
+			// The source catalog is a template listing strings; it never overrides them.
+			item.messages[source] = ""
+
+			continue
+		}
+		if strings.TrimSpace(translated) == "" {
+			continue
+		}
+		item.messages[source] = translated
+	}
+	state.catalogs[code] = item
+
+	return nil
+}
+
+// normalizeCode reduces a locale to its lowercase language code.
+func normalizeCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if i := strings.IndexAny(code, "-_.@"); i >= 0 {
+		code = code[:i]
+	}
+
+	return code
+}
@@ -0,0 +1,225 @@
+package i18n
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var formatVerbPattern = regexp.MustCompile(`%[-+# 0]*[0-9]*(?:\.[0-9]+)?[a-zA-Z%]`)
+
+func withLanguage(t *testing.T, code string) {
+	t.Helper()
+	previous := Current()
+	SetLanguage(code)
+	t.Cleanup(func() {
+		SetLanguage(previous)
+	})
+}
+
+func readCatalog(t *testing.T, path string) catalogFile {
+	t.Helper()
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	var file catalogFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		t.Fatalf("parse %s: %v", path, err)
+	}
+
+	return file
+}
+
+func TestTranslateFallsBackToSource(t *testing.T) {
+	withLanguage(t, "ru")
+	if got := T("Save"); got == "Save" || got == "" {
+		t.Fatalf("expected translated %q, got %q", "Save", got)
+	}
+	if got := T("string without translation"); got != "string without translation" {
+		t.Fatalf("expected source string, got %q", got)
+	}
+	if got := Tf("Version: %s", "1.0"); !strings.HasSuffix(got, "1.0") || strings.HasPrefix(got, "Version") {
+		t.Fatalf("expected translated version line, got %q", got)
+	}
+}
+
+func TestSetLanguageAndResolve(t *testing.T) {
+	withLanguage(t, SourceLanguage)
+	var notified []string
+	OnChange(func(code string) {
+		notified = append(notified, code)
+	})
+	if got := SetLanguage("DE"); got != "de" {
+		t.Fatalf("expected de, got %q", got)
+	}
+	if got := SetLanguage("de"); got != "de" {
+		t.Fatalf("expected de, got %q", got)
+	}
+	if got := SetLanguage("xx"); got != SourceLanguage {
+		t.Fatalf("expected fallback to %q, got %q", SourceLanguage, got)
+	}
+	if !slices.Equal(notified, []string{"de", SourceLanguage}) {
+		t.Fatalf("expected notifications [de en], got %v", notified)
+	}
+
+	tests := []struct {
+		setting string
+		system  string
+		want    string
+	}{
+		{setting: "", system: "ru-RU", want: "ru"},
+		{setting: "", system: "es_ES.UTF-8", want: "es"},
+		{setting: "", system: "ja-JP", want: SourceLanguage},
+		{setting: "de", system: "ru-RU", want: "de"},
+		{setting: "xx", system: "ru-RU", want: SourceLanguage},
+	}
+	for _, tt := range tests {
+		if got := Resolve(tt.setting, tt.system); got != tt.want {
+			t.Fatalf("resolve %q/%q: expected %q, got %q", tt.setting, tt.system, tt.want, got)
+		}
+	}
+}
+
+func TestLoadDirAddsUserCatalogs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "eo.json"), []byte(`{"language":"Esperanto","messages":{"Save":"Konservi"}}`), 0o600); err != nil {
+		t.Fatalf("write catalog: %v", err)
+	}
+	if err := LoadDir(dir); err != nil {
+		t.Fatalf("load dir: %v", err)
+	}
+	if err := LoadDir(filepath.Join(dir, "missing")); err != nil {
+		t.Fatalf("expected missing dir to be ignored, got %v", err)
+	}
+	if !slices.Contains(Languages(), Language{Code: "eo", Name: "Esperanto"}) {
+		t.Fatalf("expected Esperanto in %v", Languages())
+	}
+	if got := Languages()[0].Code; got != SourceLanguage {
+		t.Fatalf("expected source language first, got %q", got)
+	}
+	withLanguage(t, "eo")
+	if got := T("Save"); got != "Konservi" {
+		t.Fatalf("expected %q, got %q", "Konservi", got)
+	}
+}
+
+// TestCatalogsMatchTemplate keeps built-in catalogs in sync with en.json: every string
+// is translated and keeps the format verbs of the source.
+func TestCatalogsMatchTemplate(t *testing.T) {
+	template := readCatalog(t, filepath.Join("locales", "en.json"))
+	paths, err := filepath.Glob(filepath.Join("locales", "*.json"))
+	if err != nil {
+		t.Fatalf("list catalogs: %v", err)
+	}
+	for _, path := range paths {
+		if filepath.Base(path) == "en.json" {
+			continue
+		}
+		file := readCatalog(t, path)
+		if strings.TrimSpace(file.Language) == "" {
+			t.Fatalf("%s: expected language name", path)
+		}
+		for source := range template.Messages {
+			translated, ok := file.Messages[source]
+			if !ok || strings.TrimSpace(translated) == "" {
+				t.Fatalf("%s: expected translation of %q", path, source)
+			}
+			want := formatVerbPattern.FindAllString(source, -1)
+			if got := formatVerbPattern.FindAllString(translated, -1); !slices.Equal(got, want) {
+				t.Fatalf("%s: expected verbs %v in translation of %q, got %v", path, want, source, got)
+			}
+		}
+		for source := range file.Messages {
+			if _, ok := template.Messages[source]; !ok {
+				t.Fatalf("%s: unexpected string %q missing from en.json", path, source)
+			}
+		}
+	}
+}
+
+// TestTemplateListsUIStrings checks that strings passed to T and Tf in the UI and in
+// notifications, as literals or package constants, are listed in en.json.
+func TestTemplateListsUIStrings(t *testing.T) {
+	template := readCatalog(t, filepath.Join("locales", "en.json"))
+	for _, pkg := range []string{"ui", "app", "notifications"} {
+		checkTemplateListsStrings(t, template, filepath.Join("..", pkg))
+	}
+}
+
+func checkTemplateListsStrings(t *testing.T, template catalogFile, dir string) {
+	t.Helper()
+	fset := token.NewFileSet()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		t.Fatalf("list %s sources: %v", dir, err)
+	}
+	files := make([]*ast.File, 0, len(paths))
+	consts := map[string]string{}
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatalf("parse %s: %v", path, err)
+		}
+		files = append(files, file)
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				value := spec.(*ast.ValueSpec)
+				for i, name := range value.Names {
+					if i >= len(value.Values) {
+						continue
+					}
+					if lit, ok := value.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						consts[name.Name], _ = strconv.Unquote(lit.Value)
+					}
+				}
+			}
+		}
+	}
+
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 {
+				return true
+			}
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || (selector.Sel.Name != "T" && selector.Sel.Name != "Tf") {
+				return true
+			}
+			if pkg, ok := selector.X.(*ast.Ident); !ok || pkg.Name != "i18n" {
+				return true
+			}
+			var source string
+			switch arg := call.Args[0].(type) {
+			case *ast.BasicLit:
+				source, _ = strconv.Unquote(arg.Value)
+			case *ast.Ident:
+				source = consts[arg.Name]
+			}
+			if source == "" {
+				return true
+			}
+			if _, ok := template.Messages[source]; !ok {
+				t.Errorf("%s: %q is missing from en.json", fset.Position(call.Pos()), source)
+			}
+
+			return true
+		})
+	}
+}
@@ -17,11 +17,12 @@ type Manager struct {
 	mu     sync.RWMutex
 	logger *slog.Logger
 	file   *os.File
+	recent *recentLines
 }
 
 func NewManager() *Manager {
-	m := &Manager{}
-	m.logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	m := &Manager{recent: newRecentLines(recentLinesCapacity)}
+	m.logger = slog.New(slog.NewTextHandler(newFanoutWriter(os.Stdout, m.recent), &slog.HandlerOptions{Level: slog.LevelInfo}))
 
 	return m
 }
@@ -40,7 +41,7 @@ func (m *Manager) Configure(cfg config.LoggingConfig, filePath string) error {
 		return err
 	}
 
-	writer := io.Writer(os.Stdout)
+	writer := newFanoutWriter(os.Stdout, m.recent)
 	if cfg.LogToFile {
 		cleanPath := filepath.Clean(filePath)
 		// #nosec G304 -- path is resolved by app runtime and points to user config dir.
@@ -49,7 +50,7 @@ func (m *Manager) Configure(cfg config.LoggingConfig, filePath string) error {
 			return fmt.Errorf("open log file: %w", err)
 		}
 		m.file = file
-		writer = newFanoutWriter(os.Stdout, file)
+		writer = newFanoutWriter(os.Stdout, file, m.recent)
 	}
 
 	h := slog.NewTextHandler(writer, &slog.HandlerOptions{Level: level})
@@ -66,6 +67,12 @@ func (m *Manager) Logger(component string) *slog.Logger {
 	return m.logger.With("component", component)
 }
 
+// RecentLines returns up to limit of the latest log lines written by component, oldest
+// first. An empty component matches every line.
+func (m *Manager) RecentLines(component string, limit int) []string {
+	return m.recent.Lines(component, limit)
+}
+
 func (m *Manager) Close() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
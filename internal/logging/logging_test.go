@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/skobkin/meshgo/internal/config"
@@ -75,3 +76,43 @@ type errorWriter struct {
 func (w errorWriter) Write(_ []byte) (int, error) {
 	return 0, w.err
 }
+
+func TestRecentLines_FiltersByComponentAndKeepsLatest(t *testing.T) {
+	recent := newRecentLines(3)
+	for _, line := range []string{
+		"level=WARN msg=first component=ui.chats\n",
+		"level=INFO msg=other component=ui.chats_list\n",
+		"level=WARN msg=second component=ui.chats\nlevel=WARN msg=third component=ui.chats\n",
+	} {
+		if _, err := recent.Write([]byte(line)); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	got := recent.Lines("ui.chats", 10)
+	want := []string{"level=WARN msg=second component=ui.chats", "level=WARN msg=third component=ui.chats"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("unexpected lines: expected %q, got %q", want, got)
+	}
+	if got := recent.Lines("", 1); len(got) != 1 || got[0] != want[1] {
+		t.Fatalf("expected only the latest line, got %q", got)
+	}
+}
+
+func TestManagerRecentLines_CollectsLoggedRecords(t *testing.T) {
+	origDefault := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(origDefault) })
+
+	m := NewManager()
+	t.Cleanup(func() { _ = m.Close() })
+	if err := m.Configure(config.LoggingConfig{Level: "info"}, ""); err != nil {
+		t.Fatalf("configure manager: %v", err)
+	}
+	m.Logger("radio").Warn("connection lost")
+	m.Logger("ui.settings").Info("settings saved")
+
+	lines := m.RecentLines("radio", 5)
+	if len(lines) != 1 || !strings.Contains(lines[0], "connection lost") {
+		t.Fatalf("expected the radio line, got %q", lines)
+	}
+}
@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"strings"
+	"sync"
+)
+
+// recentLinesCapacity is how many log lines are kept in memory for in-app error details.
+const recentLinesCapacity = 500
+
+// recentLines keeps the latest log lines in a ring so the UI can show them next to an
+// error without reading the log file, which may be disabled.
+type recentLines struct {
+	mu    sync.Mutex
+	lines []string
+	next  int
+	full  bool
+}
+
+func newRecentLines(capacity int) *recentLines {
+	return &recentLines{lines: make([]string, max(capacity, 1))}
+}
+
+func (r *recentLines) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		r.lines[r.next] = line
+		r.next = (r.next + 1) % len(r.lines)
+		if r.next == 0 {
+			r.full = true
+		}
+	}
+
+	return len(p), nil
+}
+
+// Lines returns up to limit of the latest lines logged by component, oldest first.
+func (r *recentLines) Lines(component string, limit int) []string {
+	if r == nil || limit <= 0 {
+		return nil
+	}
+	r.mu.Lock()
+	ordered := make([]string, 0, len(r.lines))
+	if r.full {
+		ordered = append(ordered, r.lines[r.next:]...)
+	}
+	ordered = append(ordered, r.lines[:r.next]...)
+	r.mu.Unlock()
+
+	matched := make([]string, 0, limit)
+	for i := len(ordered) - 1; i >= 0 && len(matched) < limit; i-- {
+		if lineHasComponent(ordered[i], component) {
+			matched = append(matched, ordered[i])
+		}
+	}
+	for i, j := 0, len(matched)-1; i < j; i, j = i+1, j-1 {
+		matched[i], matched[j] = matched[j], matched[i]
+	}
+
+	return matched
+}
+
+func lineHasComponent(line, component string) bool {
+	if component == "" {
+		return true
+	}
+	attr := "component=" + component
+	for _, field := range strings.Fields(line) {
+		if field == attr {
+			return true
+		}
+	}
+
+	return false
+}
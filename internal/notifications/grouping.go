@@ -0,0 +1,91 @@
+package notifications
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// DefaultGroupWindow is how long follow-up notifications with the same group key are stacked.
+const DefaultGroupWindow = 10 * time.Second
+
+// GroupingSender stacks notifications sharing a group key. The first notification of a
+// group is shown immediately; the ones arriving within the window are folded into a single
+// summary, so backends without native notification groups do not flood the desktop.
+type GroupingSender struct {
+	next      Sender
+	window    time.Duration
+	afterFunc func(time.Duration, func())
+
+	mu     sync.Mutex
+	groups map[string]*pendingGroup
+}
+
+type pendingGroup struct {
+	folded int
+	latest Payload
+}
+
+func NewGroupingSender(next Sender, window time.Duration) *GroupingSender {
+	return &GroupingSender{
+		next:   next,
+		window: window,
+		afterFunc: func(delay time.Duration, fn func()) {
+			time.AfterFunc(delay, fn)
+		},
+		groups: make(map[string]*pendingGroup),
+	}
+}
+
+func (s *GroupingSender) Send(payload Payload) {
+	key := strings.TrimSpace(payload.GroupKey)
+	if key == "" || s.window <= 0 {
+		s.next.Send(payload)
+
+		return
+	}
+
+	s.mu.Lock()
+	if group, ok := s.groups[key]; ok {
+		group.folded++
+		group.latest = payload
+		s.mu.Unlock()
+
+		return
+	}
+	s.groups[key] = &pendingGroup{}
+	s.mu.Unlock()
+
+	s.next.Send(payload)
+	s.afterFunc(s.window, func() { s.flush(key) })
+}
+
+func (s *GroupingSender) flush(key string) {
+	s.mu.Lock()
+	group, ok := s.groups[key]
+	delete(s.groups, key)
+	s.mu.Unlock()
+	if !ok || group.folded == 0 {
+		return
+	}
+	if group.folded == 1 {
+		s.next.Send(group.latest)
+
+		return
+	}
+
+	title := strings.TrimSpace(group.latest.GroupTitle)
+	if title == "" {
+		title = group.latest.Title
+	}
+	s.next.Send(Payload{
+		Title:      title,
+		Content:    i18n.Tf("%d new messages. Latest: %s", group.folded, group.latest.Content),
+		GroupKey:   group.latest.GroupKey,
+		GroupTitle: group.latest.GroupTitle,
+		ChatKey:    group.latest.ChatKey,
+		NodeID:     group.latest.NodeID,
+	})
+}
@@ -0,0 +1,125 @@
+package notifications
+
+import (
+	"testing"
+	"time"
+)
+
+type recordingSender struct {
+	sent []Payload
+}
+
+func (s *recordingSender) Send(payload Payload) {
+	s.sent = append(s.sent, payload)
+}
+
+func newManualGroupingSender(next Sender) (*GroupingSender, func()) {
+	sender := NewGroupingSender(next, time.Minute)
+	var pending []func()
+	sender.afterFunc = func(_ time.Duration, fn func()) {
+		pending = append(pending, fn)
+	}
+
+	return sender, func() {
+		fns := pending
+		pending = nil
+		for _, fn := range fns {
+			fn()
+		}
+	}
+}
+
+func TestGroupingSender(t *testing.T) {
+	tests := []struct {
+		name        string
+		payloads    []Payload
+		wantTitles  []string
+		wantContent []string
+	}{
+		{
+			name: "ungrouped notifications pass through",
+			payloads: []Payload{
+				{Title: "a", Content: "1"},
+				{Title: "b", Content: "2"},
+			},
+			wantTitles:  []string{"a", "b"},
+			wantContent: []string{"1", "2"},
+		},
+		{
+			name: "single follow-up is delivered as is",
+			payloads: []Payload{
+				{Title: "#General", Content: "1", GroupKey: "chat:0"},
+				{Title: "#General", Content: "2", GroupKey: "chat:0"},
+			},
+			wantTitles:  []string{"#General", "#General"},
+			wantContent: []string{"1", "2"},
+		},
+		{
+			name: "several follow-ups are folded into a summary",
+			payloads: []Payload{
+				{Title: "#General", Content: "1", GroupKey: "messages", GroupTitle: "New messages"},
+				{Title: "@Alice", Content: "2", GroupKey: "messages", GroupTitle: "New messages"},
+				{Title: "#General", Content: "3", GroupKey: "messages", GroupTitle: "New messages"},
+			},
+			wantTitles:  []string{"#General", "New messages"},
+			wantContent: []string{"1", "2 new messages. Latest: 3"},
+		},
+		{
+			name: "different groups are independent",
+			payloads: []Payload{
+				{Title: "#General", Content: "1", GroupKey: "chat:0"},
+				{Title: "@Alice", Content: "2", GroupKey: "chat:!00000001"},
+			},
+			wantTitles:  []string{"#General", "@Alice"},
+			wantContent: []string{"1", "2"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := &recordingSender{}
+			sender, fireTimers := newManualGroupingSender(recorder)
+			for _, payload := range tc.payloads {
+				sender.Send(payload)
+			}
+			fireTimers()
+
+			if len(recorder.sent) != len(tc.wantTitles) {
+				t.Fatalf("expected %d notifications, got %+v", len(tc.wantTitles), recorder.sent)
+			}
+			for i, payload := range recorder.sent {
+				if payload.Title != tc.wantTitles[i] || payload.Content != tc.wantContent[i] {
+					t.Fatalf("notification %d: got %q/%q, want %q/%q", i, payload.Title, payload.Content, tc.wantTitles[i], tc.wantContent[i])
+				}
+			}
+		})
+	}
+}
+
+func TestGroupingSenderSummaryOpensLatestChat(t *testing.T) {
+	recorder := &recordingSender{}
+	sender, fireTimers := newManualGroupingSender(recorder)
+
+	sender.Send(Payload{Title: "#General", Content: "1", GroupKey: "messages", ChatKey: "channel:0"})
+	sender.Send(Payload{Title: "@Alice", Content: "2", GroupKey: "messages", ChatKey: "dm:!00000001"})
+	sender.Send(Payload{Title: "#General", Content: "3", GroupKey: "messages", ChatKey: "channel:0"})
+	sender.Send(Payload{Title: "@Alice", Content: "4", GroupKey: "messages", ChatKey: "dm:!00000001"})
+	fireTimers()
+
+	if len(recorder.sent) != 2 || recorder.sent[1].ChatKey != "dm:!00000001" {
+		t.Fatalf("expected the summary to open the latest chat, got %+v", recorder.sent)
+	}
+}
+
+func TestGroupingSenderStartsNewGroupAfterFlush(t *testing.T) {
+	recorder := &recordingSender{}
+	sender, fireTimers := newManualGroupingSender(recorder)
+
+	sender.Send(Payload{Title: "#General", Content: "1", GroupKey: "chat:0"})
+	fireTimers()
+	sender.Send(Payload{Title: "#General", Content: "2", GroupKey: "chat:0"})
+
+	if len(recorder.sent) != 2 || recorder.sent[1].Content != "2" {
+		t.Fatalf("expected a notification after the window closed, got %+v", recorder.sent)
+	}
+}
@@ -0,0 +1,104 @@
+package notifications
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultHistoryLimit is how many notifications the in-app history keeps.
+const DefaultHistoryLimit = 200
+
+// HistoryEntry is a notification kept in the in-app history.
+type HistoryEntry struct {
+	At      time.Time
+	Payload Payload
+	// Shown tells whether the notification also reached the desktop. It is unset for
+	// notifications suppressed while the app was focused.
+	Shown bool
+}
+
+// History keeps the latest notifications, so the ones missed on the desktop can be
+// reviewed in the app.
+type History struct {
+	limit   int
+	changes chan struct{}
+
+	mu      sync.Mutex
+	entries []HistoryEntry
+	unread  int
+}
+
+func NewHistory(limit int) *History {
+	if limit <= 0 {
+		limit = DefaultHistoryLimit
+	}
+
+	return &History{
+		limit:   limit,
+		changes: make(chan struct{}, 1),
+	}
+}
+
+// Add records entry as unread, dropping the oldest entries over the limit.
+func (h *History) Add(entry HistoryEntry) {
+	h.mu.Lock()
+	h.entries = append(h.entries, entry)
+	if over := len(h.entries) - h.limit; over > 0 {
+		h.entries = append(h.entries[:0:0], h.entries[over:]...)
+	}
+	h.unread = min(h.unread+1, len(h.entries))
+	h.mu.Unlock()
+	h.notify()
+}
+
+// Entries returns the recorded notifications, newest first.
+func (h *History) Entries() []HistoryEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	out := make([]HistoryEntry, 0, len(h.entries))
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		out = append(out, h.entries[i])
+	}
+
+	return out
+}
+
+// Unread is the number of notifications recorded since the last MarkRead.
+func (h *History) Unread() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.unread
+}
+
+func (h *History) MarkRead() {
+	h.mu.Lock()
+	changed := h.unread != 0
+	h.unread = 0
+	h.mu.Unlock()
+	if changed {
+		h.notify()
+	}
+}
+
+func (h *History) Clear() {
+	h.mu.Lock()
+	h.entries = nil
+	h.unread = 0
+	h.mu.Unlock()
+	h.notify()
+}
+
+// Changes signals after the history changed. Signals are coalesced and the channel
+// is meant for a single reader.
+func (h *History) Changes() <-chan struct{} {
+	return h.changes
+}
+
+func (h *History) notify() {
+	select {
+	case h.changes <- struct{}{}:
+	default:
+	}
+}
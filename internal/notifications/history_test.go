@@ -0,0 +1,40 @@
+package notifications
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	history := NewHistory(2)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, title := range []string{"first", "second", "third"} {
+		history.Add(HistoryEntry{At: base.Add(time.Duration(i) * time.Minute), Payload: Payload{Title: title}})
+	}
+
+	entries := history.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("unexpected entry count: expected %d, got %d", 2, len(entries))
+	}
+	if entries[0].Payload.Title != "third" || entries[1].Payload.Title != "second" {
+		t.Fatalf("expected newest entries first, got %q and %q", entries[0].Payload.Title, entries[1].Payload.Title)
+	}
+	if got := history.Unread(); got != 2 {
+		t.Fatalf("unexpected unread count: expected %d, got %d", 2, got)
+	}
+	select {
+	case <-history.Changes():
+	default:
+		t.Fatalf("expected a change signal after adding entries")
+	}
+
+	history.MarkRead()
+	if got := history.Unread(); got != 0 {
+		t.Fatalf("unexpected unread count after MarkRead: expected %d, got %d", 0, got)
+	}
+
+	history.Clear()
+	if got := len(history.Entries()); got != 0 {
+		t.Fatalf("unexpected entry count after Clear: expected %d, got %d", 0, got)
+	}
+}
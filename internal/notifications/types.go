@@ -4,6 +4,16 @@ package notifications
 type Payload struct {
 	Title   string
 	Content string
+	// GroupKey stacks related notifications together. Empty means the notification is standalone.
+	GroupKey string
+	// GroupTitle is used as the title of a stacked group summary.
+	GroupTitle string
+	// ChatKey is the chat a click on the notification opens. Empty means it is not about a chat.
+	ChatKey string
+	// NodeID is the node the notification is about. Empty means it is not about a node.
+	NodeID string
+	// Bell marks a message that rang the alert bell, which may get through do-not-disturb.
+	Bell bool
 }
 
 // Sender sends notifications using a platform-specific backend.
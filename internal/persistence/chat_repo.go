@@ -5,12 +5,14 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/skobkin/meshgo/internal/domain"
 )
 
 // ChatRepo implements domain.ChatRepository using SQLite.
 type ChatRepo struct {
+	deviceScoped
 	db *sql.DB
 }
 
@@ -24,7 +26,7 @@ func (r *ChatRepo) Delete(ctx context.Context, chatKey string) error {
 		return nil
 	}
 
-	if _, err := r.db.ExecContext(ctx, `DELETE FROM chats WHERE chat_key = ?`, chatKey); err != nil {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM chats WHERE device_id = ? AND chat_key = ?`, r.deviceID(ctx), chatKey); err != nil {
 		return fmt.Errorf("delete chat: %w", err)
 	}
 
@@ -32,10 +34,10 @@ func (r *ChatRepo) Delete(ctx context.Context, chatKey string) error {
 }
 
 func (r *ChatRepo) Upsert(ctx context.Context, c domain.Chat) error {
-	_, err := r.db.ExecContext(ctx, `
-		INSERT INTO chats(chat_key, type, title, last_sent_by_me_at, updated_at)
-		VALUES(?, ?, ?, ?, ?)
-		ON CONFLICT(chat_key) DO UPDATE SET
+	_, err := executorFor(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO chats(device_id, chat_key, type, title, last_sent_by_me_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?)
+		ON CONFLICT(device_id, chat_key) DO UPDATE SET
 			type = excluded.type,
 			title = CASE
 				WHEN excluded.title = excluded.chat_key
@@ -52,8 +54,13 @@ func (r *ChatRepo) Upsert(ctx context.Context, c domain.Chat) error {
 			updated_at = CASE
 				WHEN excluded.updated_at > chats.updated_at THEN excluded.updated_at
 				ELSE chats.updated_at
+			END,
+			-- New activity after a deletion brings the chat back with its history.
+			deleted_at = CASE
+				WHEN excluded.updated_at > COALESCE(chats.deleted_at, 0) THEN NULL
+				ELSE chats.deleted_at
 			END
-	`, c.Key, int(c.Type), c.Title, timeToUnixMillis(c.LastSentByMeAt), timeToUnixMillis(c.UpdatedAt))
+	`, r.deviceID(ctx), c.Key, int(c.Type), c.Title, timeToUnixMillis(c.LastSentByMeAt), timeToUnixMillis(c.UpdatedAt))
 	if err != nil {
 		return fmt.Errorf("upsert chat: %w", err)
 	}
@@ -61,12 +68,51 @@ func (r *ChatRepo) Upsert(ctx context.Context, c domain.Chat) error {
 	return nil
 }
 
+// SetNotifications stores the chat's notification preferences.
+func (r *ChatRepo) SetNotifications(ctx context.Context, chatKey string, prefs domain.ChatNotificationPrefs) error {
+	chatKey = strings.TrimSpace(chatKey)
+	if chatKey == "" {
+		return nil
+	}
+
+	_, err := executorFor(ctx, r.db).ExecContext(ctx, `
+		UPDATE chats SET muted = ?, muted_until = ?, notify_mentions_only = ?
+		WHERE device_id = ? AND chat_key = ?
+	`, boolToInt64(prefs.Muted), nullableTime(prefs.MutedUntil), boolToInt64(prefs.MentionsOnly), r.deviceID(ctx), chatKey)
+	if err != nil {
+		return fmt.Errorf("set chat notifications: %w", err)
+	}
+
+	return nil
+}
+
+// SetReadUpTo moves the read watermark of the chat forward to at. An older at is
+// ignored, so writes that arrive out of order can't mark messages unread again.
+func (r *ChatRepo) SetReadUpTo(ctx context.Context, chatKey string, at time.Time) error {
+	chatKey = strings.TrimSpace(chatKey)
+	if chatKey == "" || at.IsZero() {
+		return nil
+	}
+
+	readUpTo := timeToUnixMillis(at)
+	_, err := executorFor(ctx, r.db).ExecContext(ctx, `
+		UPDATE chats SET read_up_to = ?
+		WHERE device_id = ? AND chat_key = ? AND COALESCE(read_up_to, 0) < ?
+	`, readUpTo, r.deviceID(ctx), chatKey, readUpTo)
+	if err != nil {
+		return fmt.Errorf("set chat read watermark: %w", err)
+	}
+
+	return nil
+}
+
 func (r *ChatRepo) ListSortedByLastSentByMe(ctx context.Context) ([]domain.Chat, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT chat_key, type, title, last_sent_by_me_at, updated_at
+		SELECT chat_key, type, title, last_sent_by_me_at, updated_at, muted, muted_until, notify_mentions_only, read_up_to
 		FROM chats
+		WHERE device_id = ? AND deleted_at IS NULL
 		ORDER BY last_sent_by_me_at DESC, updated_at DESC
-	`)
+	`, r.deviceID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("list chats: %w", err)
 	}
@@ -81,8 +127,20 @@ func (r *ChatRepo) ListSortedByLastSentByMe(ctx context.Context) ([]domain.Chat,
 			lastSentMs sql.NullInt64
 			updatedMs  int64
 			typeInt    int
+			mutedUntil sql.NullInt64
+			readUpTo   sql.NullInt64
 		)
-		if err := rows.Scan(&chat.Key, &typeInt, &chat.Title, &lastSentMs, &updatedMs); err != nil {
+		if err := rows.Scan(
+			&chat.Key,
+			&typeInt,
+			&chat.Title,
+			&lastSentMs,
+			&updatedMs,
+			&chat.Notifications.Muted,
+			&mutedUntil,
+			&chat.Notifications.MentionsOnly,
+			&readUpTo,
+		); err != nil {
 			return nil, fmt.Errorf("scan chat: %w", err)
 		}
 		chat.Type = domain.ChatType(typeInt)
@@ -90,6 +148,12 @@ func (r *ChatRepo) ListSortedByLastSentByMe(ctx context.Context) ([]domain.Chat,
 			chat.LastSentByMeAt = unixMillisToTime(lastSentMs.Int64)
 		}
 		chat.UpdatedAt = unixMillisToTime(updatedMs)
+		if mutedUntil.Valid {
+			chat.Notifications.MutedUntil = unixMillisToTime(mutedUntil.Int64)
+		}
+		if readUpTo.Valid {
+			chat.ReadUpTo = unixMillisToTime(readUpTo.Int64)
+		}
 		out = append(out, chat)
 	}
 	if err := rows.Err(); err != nil {
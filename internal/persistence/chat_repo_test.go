@@ -88,3 +88,78 @@ func TestChatRepoDelete_RemovesOnlyTargetChat(t *testing.T) {
 		t.Fatalf("expected remaining chat to be channel:0, got %q", chats[0].Key)
 	}
 }
+
+func TestChatRepoSetNotifications_SurvivesUpsert(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "app.db")
+
+	db, err := Open(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewChatRepo(db)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	if err := repo.Upsert(ctx, domain.Chat{Key: "channel:0", Type: domain.ChatTypeChannel, Title: "General", UpdatedAt: now}); err != nil {
+		t.Fatalf("upsert chat: %v", err)
+	}
+
+	prefs := domain.ChatNotificationPrefs{Muted: true, MutedUntil: now.Add(time.Hour), MentionsOnly: true}
+	if err := repo.SetNotifications(ctx, "channel:0", prefs); err != nil {
+		t.Fatalf("set notifications: %v", err)
+	}
+	if err := repo.Upsert(ctx, domain.Chat{Key: "channel:0", Type: domain.ChatTypeChannel, Title: "General", UpdatedAt: now.Add(time.Minute)}); err != nil {
+		t.Fatalf("upsert chat again: %v", err)
+	}
+
+	chats, err := repo.ListSortedByLastSentByMe(ctx)
+	if err != nil {
+		t.Fatalf("list chats: %v", err)
+	}
+	if len(chats) != 1 {
+		t.Fatalf("expected one chat, got %d", len(chats))
+	}
+	got := chats[0].Notifications
+	if !got.Muted || !got.MentionsOnly || !got.MutedUntil.Equal(prefs.MutedUntil) {
+		t.Fatalf("expected stored preferences %+v, got %+v", prefs, got)
+	}
+}
+
+func TestChatRepoSetReadUpTo_SurvivesReopen(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "app.db")
+
+	db, err := Open(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	repo := NewChatRepo(db)
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	if err := repo.Upsert(ctx, domain.Chat{Key: "channel:0", Type: domain.ChatTypeChannel, Title: "LongFast", UpdatedAt: now}); err != nil {
+		t.Fatalf("upsert chat: %v", err)
+	}
+	readUpTo := now.Add(-time.Minute)
+	if err := repo.SetReadUpTo(ctx, "channel:0", readUpTo); err != nil {
+		t.Fatalf("set read watermark: %v", err)
+	}
+	if err := repo.SetReadUpTo(ctx, "channel:0", readUpTo.Add(-time.Hour)); err != nil {
+		t.Fatalf("set older read watermark: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatalf("close db: %v", err)
+	}
+
+	db, err = Open(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("reopen db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	chats, err := NewChatRepo(db).ListSortedByLastSentByMe(ctx)
+	if err != nil {
+		t.Fatalf("list chats: %v", err)
+	}
+	if len(chats) != 1 || !chats[0].ReadUpTo.Equal(readUpTo) {
+		t.Fatalf("expected the read watermark %v to survive a reopen, got %+v", readUpTo, chats)
+	}
+}
@@ -8,15 +8,25 @@ import (
 
 //goland:noinspection SqlWithoutWhere
 var clearDatabaseStatements = []string{
+	`DELETE FROM message_annotations;`,
+	`DELETE FROM message_pins;`,
 	`DELETE FROM messages;`,
 	`DELETE FROM chats;`,
 	`DELETE FROM node_identity_history;`,
+	`DELETE FROM node_signal_history;`,
 	`DELETE FROM node_telemetry_history;`,
 	`DELETE FROM node_telemetry_latest;`,
 	`DELETE FROM node_position_history;`,
+	`DELETE FROM node_position_tracks;`,
 	`DELETE FROM node_position_latest;`,
 	`DELETE FROM nodes;`,
 	`DELETE FROM traceroutes;`,
+	`DELETE FROM raw_packet_log;`,
+	`DELETE FROM stats_chat_daily;`,
+	`DELETE FROM stats_node_packets;`,
+	`DELETE FROM stats_hourly;`,
+	`DELETE FROM outbox_messages;`,
+	`DELETE FROM device_namespaces;`,
 }
 
 func ClearDatabase(ctx context.Context, db *sql.DB) error {
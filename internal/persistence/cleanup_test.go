@@ -30,6 +30,12 @@ func TestClearDatabase_ClearsAllTables(t *testing.T) {
 	`, domain.ChatKeyForChannel(0), int(domain.MessageDirectionIn), "hello", int(domain.MessageStatusSent), now); err != nil {
 		t.Fatalf("seed messages: %v", err)
 	}
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO message_annotations(chat_key, device_message_id, starred, updated_at)
+		VALUES(?, ?, ?, ?)
+	`, domain.ChatKeyForChannel(0), "100", 1, now); err != nil {
+		t.Fatalf("seed message annotations: %v", err)
+	}
 	if _, err := db.ExecContext(ctx, `
 		INSERT INTO nodes(node_id, last_heard_at, updated_at)
 		VALUES(?, ?, ?)
@@ -42,6 +48,12 @@ func TestClearDatabase_ClearsAllTables(t *testing.T) {
 	`, "request-1", "!00000001", now, now, "in_progress"); err != nil {
 		t.Fatalf("seed traceroutes: %v", err)
 	}
+	if _, err := db.ExecContext(ctx, `
+		INSERT INTO outbox_messages(chat_key, body, status, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?)
+	`, domain.ChatKeyForChannel(0), "queued", string(domain.OutboxStatusQueued), now, now); err != nil {
+		t.Fatalf("seed outbox messages: %v", err)
+	}
 
 	if err := ClearDatabase(ctx, db); err != nil {
 		t.Fatalf("clear database: %v", err)
@@ -51,6 +63,7 @@ func TestClearDatabase_ClearsAllTables(t *testing.T) {
 		name  string
 		query string
 	}{
+		{name: "message_annotations", query: "SELECT COUNT(*) FROM message_annotations;"},
 		{name: "messages", query: "SELECT COUNT(*) FROM messages;"},
 		{name: "chats", query: "SELECT COUNT(*) FROM chats;"},
 		{name: "node_identity_history", query: "SELECT COUNT(*) FROM node_identity_history;"},
@@ -60,6 +73,7 @@ func TestClearDatabase_ClearsAllTables(t *testing.T) {
 		{name: "node_position_latest", query: "SELECT COUNT(*) FROM node_position_latest;"},
 		{name: "nodes", query: "SELECT COUNT(*) FROM nodes;"},
 		{name: "traceroutes", query: "SELECT COUNT(*) FROM traceroutes;"},
+		{name: "outbox_messages", query: "SELECT COUNT(*) FROM outbox_messages;"},
 	}
 	for _, table := range tableChecks {
 		var count int
@@ -3,15 +3,38 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	persistmigrations "github.com/skobkin/meshgo/internal/persistence/migrations"
 
-	_ "modernc.org/sqlite" // register sqlite driver
+	"modernc.org/sqlite"
 )
 
+// BusyTimeout is how long a connection waits for another process to release its write
+// lock before failing with "database is locked".
+const BusyTimeout = 5 * time.Second
+
+// SQLite primary result codes returned when another connection or process holds a lock,
+// or when the database cannot be written.
+const (
+	sqliteBusy     = 5
+	sqliteLocked   = 6
+	sqliteReadOnly = 8
+)
+
+// ErrDatabaseReadOnly means the database file or its directory cannot be written.
+var ErrDatabaseReadOnly = errors.New("database file is read-only")
+
+var busyTimeoutPragma = fmt.Sprintf("_pragma=busy_timeout(%d)", BusyTimeout.Milliseconds())
+
 func Open(ctx context.Context, path string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite", path)
+	// The driver applies busy_timeout to every pooled connection before any other pragma.
+	db, err := sql.Open("sqlite", path+"?"+busyTimeoutPragma)
 	if err != nil {
 		return nil, fmt.Errorf("open sqlite db: %w", err)
 	}
@@ -28,13 +51,82 @@ func Open(ctx context.Context, path string) (*sql.DB, error) {
 	if _, err := db.ExecContext(ctx, `PRAGMA journal_mode = WAL;`); err != nil {
 		_ = db.Close()
 
-		return nil, fmt.Errorf("set wal mode: %w", err)
+		return nil, wrapReadOnlyError(fmt.Errorf("set wal mode: %w", err))
 	}
 	if err := persistmigrations.Apply(ctx, db); err != nil {
 		_ = db.Close()
 
-		return nil, err
+		return nil, wrapReadOnlyError(err)
+	}
+
+	return db, nil
+}
+
+// OpenReadOnly opens an existing database file without creating it or applying
+// migrations. Writes fail with a read-only error, so another process that owns the file
+// can keep using it.
+func OpenReadOnly(ctx context.Context, path string) (*sql.DB, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("open sqlite db read-only: %w", err)
+	}
+	db, err := sql.Open("sqlite", readOnlyDSN(path))
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite db read-only: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("ping sqlite db: %w", err)
 	}
 
 	return db, nil
 }
+
+// readOnlyDSN builds a SQLite URI filename; mode=ro is only honored in that form.
+func readOnlyDSN(path string) string {
+	uriPath := filepath.ToSlash(path)
+	uriPath = strings.NewReplacer("%", "%25", "?", "%3f", "#", "%23").Replace(uriPath)
+	if filepath.VolumeName(path) != "" && !strings.HasPrefix(uriPath, "/") {
+		// Windows drive paths need an empty authority: file:///C:/...
+		uriPath = "/" + uriPath
+	}
+
+	return "file://" + uriPath + "?mode=ro&" + busyTimeoutPragma + "&_pragma=query_only(1)"
+}
+
+// IsLockedError reports whether err means another connection kept the database locked
+// for longer than BusyTimeout.
+func IsLockedError(err error) bool {
+	switch sqliteResultCode(err) {
+	case sqliteBusy, sqliteLocked:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsReadOnlyError reports whether err means the database was opened read-only or its
+// file cannot be written.
+func IsReadOnlyError(err error) bool {
+	return sqliteResultCode(err) == sqliteReadOnly
+}
+
+// wrapReadOnlyError marks err with ErrDatabaseReadOnly when SQLite refused to write, so
+// callers can tell a permissions problem from a broken database.
+func wrapReadOnlyError(err error) error {
+	if IsReadOnlyError(err) {
+		return fmt.Errorf("%w: %w", ErrDatabaseReadOnly, err)
+	}
+
+	return err
+}
+
+func sqliteResultCode(err error) int {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return 0
+	}
+
+	// Extended result codes keep the primary code in the low byte.
+	return sqliteErr.Code() & 0xff
+}
@@ -0,0 +1,92 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestOpen_WaitsForWriterInAnotherConnection(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "app.db")
+
+	owner, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("open owner db: %v", err)
+	}
+	defer func() { _ = owner.Close() }()
+	second, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("open second db: %v", err)
+	}
+	defer func() { _ = second.Close() }()
+
+	tx, err := owner.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("begin owner tx: %v", err)
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO chats(chat_key, type, title, updated_at) VALUES ('channel:0', 1, 'owner', 0)`); err != nil {
+		t.Fatalf("owner insert: %v", err)
+	}
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		_ = tx.Commit()
+	}()
+
+	if err := NewChatRepo(second).Upsert(ctx, domain.Chat{Key: "channel:1", Type: domain.ChatTypeChannel, Title: "second", UpdatedAt: time.Now()}); err != nil {
+		t.Fatalf("expected second writer to wait for the lock, got %v", err)
+	}
+	var count int
+	if err := second.QueryRowContext(ctx, `SELECT COUNT(*) FROM chats`).Scan(&count); err != nil {
+		t.Fatalf("count chats: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected both writes, got %d chats", count)
+	}
+}
+
+func TestOpenReadOnly_ReadsButRejectsWrites(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "app data.db")
+
+	owner, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("open owner db: %v", err)
+	}
+	defer func() { _ = owner.Close() }()
+	if err := NewChatRepo(owner).Upsert(ctx, domain.Chat{Key: "channel:0", Type: domain.ChatTypeChannel, Title: "LongFast", UpdatedAt: time.Now()}); err != nil {
+		t.Fatalf("upsert chat: %v", err)
+	}
+
+	reader, err := OpenReadOnly(ctx, path)
+	if err != nil {
+		t.Fatalf("open read-only db: %v", err)
+	}
+	defer func() { _ = reader.Close() }()
+	var title string
+	if err := reader.QueryRowContext(ctx, `SELECT title FROM chats WHERE chat_key = 'channel:0'`).Scan(&title); err != nil {
+		t.Fatalf("read chat: %v", err)
+	}
+	if title != "LongFast" {
+		t.Fatalf("unexpected title %q", title)
+	}
+	_, err = reader.ExecContext(ctx, `DELETE FROM chats`)
+	if !IsReadOnlyError(err) {
+		t.Fatalf("expected read-only error, got %v", err)
+	}
+}
+
+func TestOpenReadOnly_MissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.db")
+	if _, err := OpenReadOnly(context.Background(), path); err == nil {
+		t.Fatalf("expected error for missing database")
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected read-only open to leave no file behind, got %v", err)
+	}
+}
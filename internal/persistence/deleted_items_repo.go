@@ -0,0 +1,183 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// DeletedItemsRepo soft-deletes chats and nodes, restores them and purges expired ones.
+type DeletedItemsRepo struct {
+	deviceScoped
+	db *sql.DB
+}
+
+func NewDeletedItemsRepo(db *sql.DB) *DeletedItemsRepo {
+	return &DeletedItemsRepo{db: db}
+}
+
+func (r *DeletedItemsRepo) DeleteChat(ctx context.Context, chatKey string, at time.Time) error {
+	return r.setDeletedAt(ctx, `UPDATE chats SET deleted_at = ? WHERE device_id = ? AND chat_key = ?`, "chat", strings.TrimSpace(chatKey), at)
+}
+
+func (r *DeletedItemsRepo) RestoreChat(ctx context.Context, chatKey string) error {
+	return r.setDeletedAt(ctx, `UPDATE chats SET deleted_at = ? WHERE device_id = ? AND chat_key = ?`, "chat", strings.TrimSpace(chatKey), time.Time{})
+}
+
+func (r *DeletedItemsRepo) DeleteNode(ctx context.Context, nodeID string, at time.Time) error {
+	return r.setDeletedAt(ctx, `UPDATE nodes SET deleted_at = ? WHERE device_id = ? AND node_id = ?`, "node", strings.TrimSpace(nodeID), at)
+}
+
+func (r *DeletedItemsRepo) RestoreNode(ctx context.Context, nodeID string) error {
+	return r.setDeletedAt(ctx, `UPDATE nodes SET deleted_at = ? WHERE device_id = ? AND node_id = ?`, "node", strings.TrimSpace(nodeID), time.Time{})
+}
+
+// ListDeleted returns soft-deleted chats and nodes, most recently deleted first.
+func (r *DeletedItemsRepo) ListDeleted(ctx context.Context) ([]domain.DeletedItem, error) {
+	if r == nil || r.db == nil {
+		return nil, fmt.Errorf("deleted items repo is not initialized")
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT 'chat', chat_key, COALESCE(title, ''), deleted_at FROM chats WHERE device_id = ?1 AND deleted_at IS NOT NULL
+		UNION ALL
+		SELECT 'node', node_id, COALESCE(NULLIF(long_name, ''), NULLIF(short_name, ''), ''), deleted_at FROM nodes WHERE device_id = ?1 AND deleted_at IS NOT NULL
+		ORDER BY 4 DESC
+	`, r.deviceID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list deleted items: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var out []domain.DeletedItem
+	for rows.Next() {
+		var (
+			item      domain.DeletedItem
+			kind      string
+			deletedMs int64
+		)
+		if err := rows.Scan(&kind, &item.Key, &item.Title, &deletedMs); err != nil {
+			return nil, fmt.Errorf("scan deleted item: %w", err)
+		}
+		item.Kind = domain.DeletedItemKind(kind)
+		item.DeletedAt = unixMillisToTime(deletedMs)
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate deleted items: %w", err)
+	}
+
+	return out, nil
+}
+
+// ListDeletedNodeIDs returns deletion times of soft-deleted nodes keyed by node ID.
+func (r *DeletedItemsRepo) ListDeletedNodeIDs(ctx context.Context) (map[string]time.Time, error) {
+	items, err := r.ListDeleted(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]time.Time)
+	for _, item := range items {
+		if item.Kind == domain.DeletedItemNode {
+			out[item.Key] = item.DeletedAt
+		}
+	}
+
+	return out, nil
+}
+
+// PurgeDeletedBefore permanently removes chats and nodes soft-deleted before cutoff,
+// together with their messages, annotations and pins, and with node history,
+// traceroutes and packet statistics. Local notes and tags live on the node row. It
+// purges every device namespace, not only the selected one.
+func (r *DeletedItemsRepo) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	if r == nil || r.db == nil {
+		return 0, fmt.Errorf("deleted items repo is not initialized")
+	}
+	cutoffMs := timeToUnixMillis(cutoff)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin purge deleted items tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	const expiredChats = `SELECT device_id, chat_key FROM chats WHERE deleted_at IS NOT NULL AND deleted_at < ?`
+	const expiredNodes = `SELECT device_id, node_id FROM nodes WHERE deleted_at IS NOT NULL AND deleted_at < ?`
+	statements := []string{
+		`DELETE FROM message_annotations WHERE (device_id, chat_key) IN (` + expiredChats + `)`,
+		`DELETE FROM message_pins WHERE (device_id, chat_key) IN (` + expiredChats + `)`,
+		`DELETE FROM messages WHERE (device_id, chat_key) IN (` + expiredChats + `)`,
+		`DELETE FROM node_identity_history WHERE (device_id, node_id) IN (` + expiredNodes + `)`,
+		`DELETE FROM node_signal_history WHERE (device_id, node_id) IN (` + expiredNodes + `)`,
+		`DELETE FROM node_telemetry_history WHERE (device_id, node_id) IN (` + expiredNodes + `)`,
+		`DELETE FROM node_telemetry_latest WHERE (device_id, node_id) IN (` + expiredNodes + `)`,
+		`DELETE FROM node_position_history WHERE (device_id, node_id) IN (` + expiredNodes + `)`,
+		`DELETE FROM node_position_tracks WHERE (device_id, node_id) IN (` + expiredNodes + `)`,
+		`DELETE FROM node_position_latest WHERE (device_id, node_id) IN (` + expiredNodes + `)`,
+		`DELETE FROM traceroutes WHERE (device_id, target_node_id) IN (` + expiredNodes + `)`,
+		`DELETE FROM stats_node_packets WHERE (device_id, node_id) IN (` + expiredNodes + `)`,
+	}
+	for _, stmt := range statements {
+		if _, err := tx.ExecContext(ctx, stmt, cutoffMs); err != nil {
+			return 0, fmt.Errorf("purge deleted item data: %w", err)
+		}
+	}
+
+	purged := 0
+	for _, stmt := range []string{
+		`DELETE FROM chats WHERE deleted_at IS NOT NULL AND deleted_at < ?`,
+		`DELETE FROM nodes WHERE deleted_at IS NOT NULL AND deleted_at < ?`,
+	} {
+		res, err := tx.ExecContext(ctx, stmt, cutoffMs)
+		if err != nil {
+			return 0, fmt.Errorf("purge deleted items: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("count purged items: %w", err)
+		}
+		purged += int(affected)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit purge deleted items tx: %w", err)
+	}
+
+	return purged, nil
+}
+
+func (r *DeletedItemsRepo) setDeletedAt(ctx context.Context, query, kind, key string, at time.Time) error {
+	if r == nil || r.db == nil {
+		return fmt.Errorf("deleted items repo is not initialized")
+	}
+	if key == "" {
+		return fmt.Errorf("%s key is required", kind)
+	}
+
+	var deletedAt any
+	if !at.IsZero() {
+		deletedAt = timeToUnixMillis(at)
+	}
+	res, err := r.db.ExecContext(ctx, query, deletedAt, r.deviceID(ctx), key)
+	if err != nil {
+		return fmt.Errorf("update %s deleted state: %w", kind, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("check %s deleted state update: %w", kind, err)
+	}
+	// Deleting an item that was never persisted is a no-op, restoring one is an error.
+	if affected == 0 && at.IsZero() {
+		return fmt.Errorf("%s %q not found", kind, key)
+	}
+
+	return nil
+}
@@ -0,0 +1,242 @@
+package persistence
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestDeletedItemsRepo_SoftDeleteRestoreAndRevive(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	chatRepo := NewChatRepo(db)
+	messageRepo := NewMessageRepo(db)
+	coreRepo := NewNodeCoreRepo(db)
+	repo := NewDeletedItemsRepo(db)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	if err := chatRepo.Upsert(ctx, domain.Chat{Key: "dm:!00000001", Type: domain.ChatTypeDM, Title: "Alice", UpdatedAt: now}); err != nil {
+		t.Fatalf("upsert chat: %v", err)
+	}
+	if _, err := messageRepo.Insert(ctx, domain.ChatMessage{ChatKey: "dm:!00000001", Direction: domain.MessageDirectionIn, Body: "hi", At: now}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+	if err := coreRepo.Upsert(ctx, domain.NodeCoreUpdate{
+		Core: domain.NodeCore{NodeID: "!00000001", LongName: "Alice", LastHeardAt: now, UpdatedAt: now},
+		Type: domain.NodeUpdateTypeNodeInfoSnapshot,
+	}, 50); err != nil {
+		t.Fatalf("upsert node: %v", err)
+	}
+
+	deletedAt := now.Add(time.Second)
+	if err := repo.DeleteChat(ctx, "dm:!00000001", deletedAt); err != nil {
+		t.Fatalf("delete chat: %v", err)
+	}
+	if err := repo.DeleteNode(ctx, "!00000001", deletedAt); err != nil {
+		t.Fatalf("delete node: %v", err)
+	}
+
+	chats, err := chatRepo.ListSortedByLastSentByMe(ctx)
+	if err != nil {
+		t.Fatalf("list chats: %v", err)
+	}
+	nodes, err := coreRepo.ListSortedByLastHeard(ctx)
+	if err != nil {
+		t.Fatalf("list nodes: %v", err)
+	}
+	if len(chats) != 0 || len(nodes) != 0 {
+		t.Fatalf("expected deleted items to be hidden, got %d chats and %d nodes", len(chats), len(nodes))
+	}
+
+	deleted, err := repo.ListDeleted(ctx)
+	if err != nil {
+		t.Fatalf("list deleted: %v", err)
+	}
+	if len(deleted) != 2 {
+		t.Fatalf("expected two deleted items, got %+v", deleted)
+	}
+	for _, item := range deleted {
+		if item.Title != "Alice" || !item.DeletedAt.Equal(deletedAt) {
+			t.Fatalf("unexpected deleted item: %+v", item)
+		}
+	}
+
+	// Stale node info must not revive the node, a newer one must.
+	if err := coreRepo.Upsert(ctx, domain.NodeCoreUpdate{
+		Core: domain.NodeCore{NodeID: "!00000001", LastHeardAt: now, UpdatedAt: deletedAt.Add(time.Second)},
+		Type: domain.NodeUpdateTypeNodeInfoSnapshot,
+	}, 50); err != nil {
+		t.Fatalf("upsert stale node: %v", err)
+	}
+	if nodes, _ := coreRepo.ListSortedByLastHeard(ctx); len(nodes) != 0 {
+		t.Fatalf("expected stale update to keep node deleted")
+	}
+	if err := coreRepo.Upsert(ctx, domain.NodeCoreUpdate{
+		Core: domain.NodeCore{NodeID: "!00000001", LastHeardAt: deletedAt.Add(time.Second), UpdatedAt: deletedAt.Add(time.Second)},
+		Type: domain.NodeUpdateTypeNodeInfoPacket,
+	}, 50); err != nil {
+		t.Fatalf("upsert fresh node: %v", err)
+	}
+	if nodes, _ := coreRepo.ListSortedByLastHeard(ctx); len(nodes) != 1 {
+		t.Fatalf("expected node heard after deletion to be revived")
+	}
+
+	if err := repo.RestoreChat(ctx, "dm:!00000001"); err != nil {
+		t.Fatalf("restore chat: %v", err)
+	}
+	messages, err := messageRepo.LoadRecentPerChat(ctx, 10)
+	if err != nil {
+		t.Fatalf("load messages: %v", err)
+	}
+	if len(messages["dm:!00000001"]) != 1 {
+		t.Fatalf("expected restored chat history, got %+v", messages)
+	}
+}
+
+func TestDeletedItemsRepo_PurgeDeletedBefore(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	chatRepo := NewChatRepo(db)
+	messageRepo := NewMessageRepo(db)
+	repo := NewDeletedItemsRepo(db)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	for _, key := range []string{"dm:!00000001", "dm:!00000002"} {
+		if err := chatRepo.Upsert(ctx, domain.Chat{Key: key, Type: domain.ChatTypeDM, Title: key, UpdatedAt: now}); err != nil {
+			t.Fatalf("upsert chat %s: %v", key, err)
+		}
+		if _, err := messageRepo.Insert(ctx, domain.ChatMessage{ChatKey: key, Direction: domain.MessageDirectionIn, Body: "hi", At: now}); err != nil {
+			t.Fatalf("insert message %s: %v", key, err)
+		}
+	}
+	if err := repo.DeleteChat(ctx, "dm:!00000001", now.Add(-48*time.Hour)); err != nil {
+		t.Fatalf("delete old chat: %v", err)
+	}
+	if err := repo.DeleteChat(ctx, "dm:!00000002", now); err != nil {
+		t.Fatalf("delete recent chat: %v", err)
+	}
+
+	purged, err := repo.PurgeDeletedBefore(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if purged != 1 {
+		t.Fatalf("expected one purged item, got %d", purged)
+	}
+	deleted, err := repo.ListDeleted(ctx)
+	if err != nil {
+		t.Fatalf("list deleted: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].Key != "dm:!00000002" {
+		t.Fatalf("expected only recent chat to remain restorable, got %+v", deleted)
+	}
+	oldMessages, err := messageRepo.ListRecentByChat(ctx, "dm:!00000001", 10)
+	if err != nil {
+		t.Fatalf("list purged messages: %v", err)
+	}
+	if len(oldMessages) != 0 {
+		t.Fatalf("expected purged chat messages to be removed, got %d", len(oldMessages))
+	}
+}
+
+func TestDeletedItemsRepo_PurgeDeletedBeforeClearsEveryNodeTable(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewDeletedItemsRepo(db)
+	// Node-keyed tables and the column holding the node ID. A table added later with
+	// a node ID column fails the schema check below until it is listed here and purged.
+	tables := map[string]string{
+		"nodes":                  "node_id",
+		"node_identity_history":  "node_id",
+		"node_signal_history":    "node_id",
+		"node_telemetry_history": "node_id",
+		"node_telemetry_latest":  "node_id",
+		"node_position_history":  "node_id",
+		"node_position_tracks":   "node_id",
+		"node_position_latest":   "node_id",
+		"traceroutes":            "target_node_id",
+		"stats_node_packets":     "node_id",
+	}
+	rows, err := db.QueryContext(ctx, `
+		SELECT m.name, c.name FROM sqlite_master m, pragma_table_info(m.name) c
+		WHERE m.type = 'table' AND c.name IN ('node_id', 'target_node_id')
+	`)
+	if err != nil {
+		t.Fatalf("list node tables: %v", err)
+	}
+	found := 0
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			t.Fatalf("scan node table: %v", err)
+		}
+		if tables[table] != column {
+			t.Fatalf("node table %s.%s is not covered by the purge test", table, column)
+		}
+		found++
+	}
+	_ = rows.Close()
+	if found != len(tables) {
+		t.Fatalf("expected %d node tables, found %d", len(tables), found)
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	nowMs := timeToUnixMillis(now)
+	for _, nodeID := range []string{"!00000001", "!00000002"} {
+		for _, stmt := range []string{
+			`INSERT INTO nodes(node_id, long_name, last_heard_at, updated_at, local_alias, local_note, local_tags_json) VALUES(?1, 'Node', ?2, ?2, 'alias', 'note', '["tag"]')`,
+			`INSERT INTO node_identity_history(node_id, observed_at, written_at, update_type, from_packet) VALUES(?1, ?2, ?2, 'test', 1)`,
+			`INSERT INTO node_signal_history(node_id, rssi, observed_at) VALUES(?1, -90, ?2)`,
+			`INSERT INTO node_telemetry_history(node_id, observed_at, written_at, update_type, from_packet) VALUES(?1, ?2, ?2, 'test', 1)`,
+			`INSERT INTO node_telemetry_latest(node_id, observed_at, written_at, update_type, from_packet) VALUES(?1, ?2, ?2, 'test', 1)`,
+			`INSERT INTO node_position_history(node_id, observed_at, written_at, update_type, from_packet) VALUES(?1, ?2, ?2, 'test', 1)`,
+			`INSERT INTO node_position_tracks(node_id, latitude, longitude, observed_at, source) VALUES(?1, 1, 2, ?2, 'test')`,
+			`INSERT INTO node_position_latest(node_id, observed_at, written_at, update_type, from_packet) VALUES(?1, ?2, ?2, 'test', 1)`,
+			`INSERT INTO traceroutes(request_id, target_node_id, started_at, updated_at, status) VALUES('tr-' || ?1, ?1, ?2, ?2, 'completed')`,
+			`INSERT INTO stats_node_packets(node_id, packets, last_packet_at) VALUES(?1, 3, ?2)`,
+		} {
+			if _, err := db.ExecContext(ctx, stmt, nodeID, nowMs); err != nil {
+				t.Fatalf("seed %s: %v", nodeID, err)
+			}
+		}
+	}
+	if err := repo.DeleteNode(ctx, "!00000001", now.Add(-48*time.Hour)); err != nil {
+		t.Fatalf("delete old node: %v", err)
+	}
+	if err := repo.DeleteNode(ctx, "!00000002", now); err != nil {
+		t.Fatalf("delete recent node: %v", err)
+	}
+
+	if _, err := repo.PurgeDeletedBefore(ctx, now.Add(-24*time.Hour)); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	for table, column := range tables {
+		for nodeID, want := range map[string]int{"!00000001": 0, "!00000002": 1} {
+			var count int
+			if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+table+` WHERE `+column+` = ?`, nodeID).Scan(&count); err != nil {
+				t.Fatalf("count %s: %v", table, err)
+			}
+			if count != want {
+				t.Fatalf("expected %d rows of %s in %s after purge, got %d", want, nodeID, table, count)
+			}
+		}
+	}
+}
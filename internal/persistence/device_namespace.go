@@ -0,0 +1,160 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DeviceScope selects the device namespace repositories read and write. Mesh data is
+// keyed by the node ID of the radio it came through, so connecting another radio does
+// not mix its nodes, chats and messages with the previous one's. The empty namespace
+// holds data recorded before the first device was known.
+type DeviceScope struct {
+	mu       sync.RWMutex
+	deviceID string
+}
+
+func NewDeviceScope(deviceID string) *DeviceScope {
+	return &DeviceScope{deviceID: strings.TrimSpace(deviceID)}
+}
+
+// DeviceID returns the selected namespace. A nil scope selects the empty namespace.
+func (s *DeviceScope) DeviceID() string {
+	if s == nil {
+		return ""
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.deviceID
+}
+
+// Set switches the namespace for all repositories sharing the scope.
+func (s *DeviceScope) Set(deviceID string) {
+	s.mu.Lock()
+	s.deviceID = strings.TrimSpace(deviceID)
+	s.mu.Unlock()
+}
+
+// deviceScoped is embedded by repositories that keep per-device mesh data.
+type deviceScoped struct {
+	scope *DeviceScope
+}
+
+// UseDeviceScope makes the repository work in the namespace selected by scope. It must
+// be called before the repository is shared between goroutines.
+func (d *deviceScoped) UseDeviceScope(scope *DeviceScope) {
+	d.scope = scope
+}
+
+// deviceID returns the namespace ctx was pinned to by the writer queue, or the one the
+// scope selects now.
+func (d *deviceScoped) deviceID(ctx context.Context) string {
+	if deviceID, ok := ctx.Value(deviceNamespaceKey{}).(string); ok {
+		return deviceID
+	}
+
+	return d.scope.DeviceID()
+}
+
+type deviceNamespaceKey struct{}
+
+// withDeviceNamespace makes repositories called with ctx write to deviceID, whatever
+// namespace the scope selects by the time they run.
+func withDeviceNamespace(ctx context.Context, deviceID string) context.Context {
+	return context.WithValue(ctx, deviceNamespaceKey{}, deviceID)
+}
+
+// deviceNamespaceTables lists tables keyed by device_id. Nodes children follow their
+// parent through ON UPDATE CASCADE, so nodes stands for all of them.
+var deviceNamespaceTables = []string{
+	"nodes",
+	"chats",
+	"messages",
+	"message_annotations",
+	"message_pins",
+	"traceroutes",
+	"outbox_messages",
+}
+
+// DeviceNamespaceRepo records which devices own namespaces in the database.
+type DeviceNamespaceRepo struct {
+	db *sql.DB
+}
+
+func NewDeviceNamespaceRepo(db *sql.DB) *DeviceNamespaceRepo {
+	return &DeviceNamespaceRepo{db: db}
+}
+
+// LastConnected returns the device that connected most recently, if any did.
+func (r *DeviceNamespaceRepo) LastConnected(ctx context.Context) (string, bool, error) {
+	var deviceID string
+	err := r.db.QueryRowContext(ctx, `
+		SELECT device_id
+		FROM device_namespaces
+		ORDER BY last_connected_at DESC
+		LIMIT 1
+	`).Scan(&deviceID)
+	switch {
+	case err == sql.ErrNoRows:
+		return "", false, nil
+	case err != nil:
+		return "", false, fmt.Errorf("query last connected device: %w", err)
+	}
+
+	return deviceID, true, nil
+}
+
+// Activate records a connection of deviceID. The first device ever activated adopts the
+// empty namespace, which holds the history of databases created before namespaces; it
+// reports whether that happened.
+func (r *DeviceNamespaceRepo) Activate(ctx context.Context, deviceID string, at time.Time) (bool, error) {
+	deviceID = strings.TrimSpace(deviceID)
+	if deviceID == "" {
+		return false, fmt.Errorf("device id is required")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("begin device activation tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var known int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM device_namespaces`).Scan(&known); err != nil {
+		return false, fmt.Errorf("count device namespaces: %w", err)
+	}
+	adopted := false
+	if known == 0 {
+		for _, table := range deviceNamespaceTables {
+			res, err := tx.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET device_id = ? WHERE device_id = ''`, table), deviceID)
+			if err != nil {
+				return false, fmt.Errorf("adopt %s for device %s: %w", table, deviceID, err)
+			}
+			if affected, err := res.RowsAffected(); err == nil && affected > 0 {
+				adopted = true
+			}
+		}
+	}
+
+	atMs := timeToUnixMillis(at)
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO device_namespaces(device_id, first_connected_at, last_connected_at)
+		VALUES(?, ?, ?)
+		ON CONFLICT(device_id) DO UPDATE SET last_connected_at = excluded.last_connected_at
+	`, deviceID, atMs, atMs); err != nil {
+		return false, fmt.Errorf("record device namespace: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit device activation tx: %w", err)
+	}
+
+	return adopted, nil
+}
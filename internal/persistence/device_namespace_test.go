@@ -0,0 +1,128 @@
+package persistence
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestDeviceNamespaces_FirstDeviceAdoptsLegacyDataAndOthersStartEmpty(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	scope := NewDeviceScope("")
+	nodes := NewNodeCoreRepo(db)
+	chats := NewChatRepo(db)
+	messages := NewMessageRepo(db)
+	nodes.UseDeviceScope(scope)
+	chats.UseDeviceScope(scope)
+	messages.UseDeviceScope(scope)
+	namespaces := NewDeviceNamespaceRepo(db)
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	seed := func(longName, body string) {
+		t.Helper()
+		if err := nodes.Upsert(ctx, domain.NodeCoreUpdate{
+			Core: domain.NodeCore{NodeID: "!00000001", LongName: longName, LastHeardAt: now, UpdatedAt: now},
+			Type: domain.NodeUpdateTypeNodeInfoSnapshot,
+		}, 10); err != nil {
+			t.Fatalf("upsert node: %v", err)
+		}
+		if err := chats.Upsert(ctx, domain.Chat{Key: "channel:0", Type: domain.ChatTypeChannel, Title: "LongFast", UpdatedAt: now}); err != nil {
+			t.Fatalf("upsert chat: %v", err)
+		}
+		if _, err := messages.Insert(ctx, domain.ChatMessage{
+			ChatKey:         "channel:0",
+			DeviceMessageID: "42",
+			Direction:       domain.MessageDirectionIn,
+			Body:            body,
+			Status:          domain.MessageStatusSent,
+			At:              now,
+		}); err != nil {
+			t.Fatalf("insert message: %v", err)
+		}
+	}
+	assertNamespace := func(deviceID, wantLongName, wantBody string) {
+		t.Helper()
+		scope.Set(deviceID)
+		listedNodes, err := nodes.ListSortedByLastHeard(ctx)
+		if err != nil {
+			t.Fatalf("list nodes: %v", err)
+		}
+		listedMessages, err := messages.ListRecentByChat(ctx, "channel:0", 10)
+		if err != nil {
+			t.Fatalf("list messages: %v", err)
+		}
+		if wantLongName == "" {
+			if len(listedNodes) != 0 || len(listedMessages) != 0 {
+				t.Fatalf("expected empty namespace %q, got %d nodes and %d messages", deviceID, len(listedNodes), len(listedMessages))
+			}
+
+			return
+		}
+		if len(listedNodes) != 1 || listedNodes[0].LongName != wantLongName {
+			t.Fatalf("expected node %q in namespace %q, got %+v", wantLongName, deviceID, listedNodes)
+		}
+		if len(listedMessages) != 1 || listedMessages[0].Body != wantBody {
+			t.Fatalf("expected message %q in namespace %q, got %+v", wantBody, deviceID, listedMessages)
+		}
+	}
+
+	seed("Legacy", "before namespaces")
+
+	adopted, err := namespaces.Activate(ctx, "!0000aaaa", now)
+	if err != nil {
+		t.Fatalf("activate first device: %v", err)
+	}
+	if !adopted {
+		t.Fatalf("expected first device to adopt legacy data")
+	}
+	assertNamespace("", "", "")
+	assertNamespace("!0000aaaa", "Legacy", "before namespaces")
+
+	adopted, err = namespaces.Activate(ctx, "!0000bbbb", now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("activate second device: %v", err)
+	}
+	if adopted {
+		t.Fatalf("expected second device not to adopt data")
+	}
+	assertNamespace("!0000bbbb", "", "")
+
+	// The same node, chat and device message id live independently per device.
+	seed("Second radio view", "via second radio")
+	assertNamespace("!0000bbbb", "Second radio view", "via second radio")
+	assertNamespace("!0000aaaa", "Legacy", "before namespaces")
+
+	last, ok, err := namespaces.LastConnected(ctx)
+	if err != nil {
+		t.Fatalf("last connected device: %v", err)
+	}
+	if !ok || last != "!0000bbbb" {
+		t.Fatalf("expected last connected device !0000bbbb, got %q (found=%v)", last, ok)
+	}
+}
+
+func TestDeviceNamespaceRepo_LastConnectedWithoutDevices(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	deviceID, ok, err := NewDeviceNamespaceRepo(db).LastConnected(ctx)
+	if err != nil {
+		t.Fatalf("last connected device: %v", err)
+	}
+	if ok || deviceID != "" {
+		t.Fatalf("expected no device, got %q", deviceID)
+	}
+}
@@ -0,0 +1,421 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	persistmigrations "github.com/skobkin/meshgo/internal/persistence/migrations"
+)
+
+// DatabaseKeySize is the AES-256 key length used for encrypted databases.
+const DatabaseKeySize = 32
+
+// encryptedDBHeader starts every encrypted database file and is authenticated with the payload.
+var encryptedDBHeader = []byte("MESHGODB\x01")
+
+// ErrDatabaseKeyRejected means an encrypted database could not be decrypted with the given key.
+var ErrDatabaseKeyRejected = errors.New("database key was rejected or the encrypted file is damaged")
+
+// sqliteSerializer is implemented by modernc.org/sqlite driver connections.
+type sqliteSerializer interface {
+	Serialize() ([]byte, error)
+	Deserialize([]byte) error
+}
+
+// EncryptedDatabase keeps the SQLite database in memory and persists it as an
+// AES-256-GCM encrypted snapshot file. Changes reach the disk on Flush and Close.
+type EncryptedDatabase struct {
+	db   *sql.DB
+	path string
+	aead cipher.AEAD
+
+	mu             sync.Mutex
+	flushedChanges int64
+}
+
+// NewDatabaseKey returns a random key for EncryptDatabaseFile and OpenEncrypted.
+func NewDatabaseKey() ([]byte, error) {
+	key := make([]byte, DatabaseKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generate database key: %w", err)
+	}
+
+	return key, nil
+}
+
+// OpenEncrypted opens the encrypted database at path, creating an empty one when the file
+// does not exist yet, and applies migrations.
+func OpenEncrypted(ctx context.Context, path string, key []byte) (*EncryptedDatabase, error) {
+	aead, err := newDatabaseCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var plain []byte
+	sealed, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("read encrypted database: %w", err)
+	default:
+		plain, err = openDatabaseSnapshot(aead, sealed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	db, err := openSnapshotDB(ctx, plain)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.ExecContext(ctx, `PRAGMA foreign_keys = ON;`); err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("enable foreign keys: %w", err)
+	}
+	if err := persistmigrations.Apply(ctx, db); err != nil {
+		_ = db.Close()
+
+		return nil, err
+	}
+
+	encrypted := &EncryptedDatabase{db: db, path: path, aead: aead, flushedChanges: -1}
+	// Write right away so a new or migrated database is on disk before any data arrives.
+	if err := encrypted.Flush(ctx); err != nil {
+		_ = db.Close()
+
+		return nil, err
+	}
+
+	return encrypted, nil
+}
+
+// OpenEncryptedReadOnly loads the last flushed snapshot of the encrypted database at path
+// for reading. Nothing is migrated or written back, so it is safe while another process
+// owns the file, but changes that process has not flushed yet are not visible.
+func OpenEncryptedReadOnly(ctx context.Context, path string, key []byte) (*sql.DB, error) {
+	aead, err := newDatabaseCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return openSealedReadOnly(ctx, aead, path)
+}
+
+func openSealedReadOnly(ctx context.Context, aead cipher.AEAD, path string) (*sql.DB, error) {
+	sealed, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read encrypted database: %w", err)
+	}
+	plain, err := openDatabaseSnapshot(aead, sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := openSnapshotDB(ctx, plain)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.ExecContext(ctx, `PRAGMA query_only = ON;`); err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("enable query only mode: %w", err)
+	}
+
+	return db, nil
+}
+
+// openSnapshotDB opens an in-memory database holding the decrypted image plain.
+func openSnapshotDB(ctx context.Context, plain []byte) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		return nil, fmt.Errorf("open in-memory sqlite db: %w", err)
+	}
+	// Every connection to ":memory:" is a separate database, so the pool must never open a second one.
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+
+	if len(plain) > 0 {
+		markRollbackJournal(plain)
+		if err := withSerializer(ctx, db, func(s sqliteSerializer) error { return s.Deserialize(plain) }); err != nil {
+			_ = db.Close()
+
+			return nil, fmt.Errorf("load decrypted database: %w", err)
+		}
+	}
+
+	return db, nil
+}
+
+// DB returns the handle repositories should use.
+func (d *EncryptedDatabase) DB() *sql.DB {
+	return d.db
+}
+
+// Path returns the encrypted file location.
+func (d *EncryptedDatabase) Path() string {
+	return d.path
+}
+
+// Flush writes the database to disk if it changed since the last flush.
+func (d *EncryptedDatabase) Flush(ctx context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var changes int64
+	if err := d.db.QueryRowContext(ctx, `SELECT total_changes();`).Scan(&changes); err != nil {
+		return fmt.Errorf("read database change counter: %w", err)
+	}
+	if changes == d.flushedChanges {
+		return nil
+	}
+
+	if err := d.writeSnapshot(ctx, d.path); err != nil {
+		return err
+	}
+	d.flushedChanges = changes
+
+	return nil
+}
+
+// SnapshotTo writes an encrypted copy of the database to path, sealed with the key of
+// the database. The copy is never written to disk unencrypted.
+func (d *EncryptedDatabase) SnapshotTo(ctx context.Context, path string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.writeSnapshot(ctx, path)
+}
+
+// OpenSnapshotReadOnly opens a copy made by SnapshotTo for reading. It fails with
+// ErrDatabaseKeyRejected when the copy was sealed with another key.
+func (d *EncryptedDatabase) OpenSnapshotReadOnly(ctx context.Context, path string) (*sql.DB, error) {
+	return openSealedReadOnly(ctx, d.aead, path)
+}
+
+func (d *EncryptedDatabase) writeSnapshot(ctx context.Context, path string) error {
+	var plain []byte
+	err := withSerializer(ctx, d.db, func(s sqliteSerializer) error {
+		var err error
+		plain, err = s.Serialize()
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("serialize database: %w", err)
+	}
+	if err := writeFileAtomic(path, sealDatabaseSnapshot(d.aead, plain)); err != nil {
+		return fmt.Errorf("write encrypted database: %w", err)
+	}
+
+	return nil
+}
+
+// Close flushes pending changes and closes the database.
+func (d *EncryptedDatabase) Close() error {
+	flushErr := d.Flush(context.Background())
+	closeErr := d.db.Close()
+
+	return errors.Join(flushErr, closeErr)
+}
+
+// EncryptDatabaseFile converts the plain database at plainPath into an encrypted file at
+// encryptedPath and removes the plain file with its WAL and shared memory companions.
+func EncryptDatabaseFile(ctx context.Context, plainPath, encryptedPath string, key []byte) error {
+	aead, err := newDatabaseCipher(key)
+	if err != nil {
+		return err
+	}
+
+	db, err := Open(ctx, plainPath)
+	if err != nil {
+		return err
+	}
+	// Leaving WAL mode checkpoints the log into the main file.
+	if _, err := db.ExecContext(ctx, `PRAGMA journal_mode = DELETE;`); err != nil {
+		_ = db.Close()
+
+		return fmt.Errorf("checkpoint plain database: %w", err)
+	}
+	var plain []byte
+	err = withSerializer(ctx, db, func(s sqliteSerializer) error {
+		var err error
+		plain, err = s.Serialize()
+
+		return err
+	})
+	if closeErr := db.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("serialize plain database: %w", err)
+	}
+
+	if err := writeFileAtomic(encryptedPath, sealDatabaseSnapshot(aead, plain)); err != nil {
+		return fmt.Errorf("write encrypted database: %w", err)
+	}
+
+	return removeDatabaseFiles(plainPath)
+}
+
+// DecryptDatabaseFile converts the encrypted database at encryptedPath back into a plain
+// SQLite file at plainPath and removes the encrypted file.
+func DecryptDatabaseFile(encryptedPath, plainPath string, key []byte) error {
+	aead, err := newDatabaseCipher(key)
+	if err != nil {
+		return err
+	}
+	sealed, err := os.ReadFile(encryptedPath)
+	if err != nil {
+		return fmt.Errorf("read encrypted database: %w", err)
+	}
+	plain, err := openDatabaseSnapshot(aead, sealed)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(plainPath, plain); err != nil {
+		return fmt.Errorf("write plain database: %w", err)
+	}
+	if err := os.Remove(encryptedPath); err != nil {
+		return fmt.Errorf("remove encrypted database: %w", err)
+	}
+
+	return nil
+}
+
+// markRollbackJournal rewrites the file format version bytes of a WAL-mode image:
+// an in-memory database cannot use WAL and refuses to open such an image.
+func markRollbackJournal(image []byte) {
+	const (
+		writeVersionOffset = 18
+		readVersionOffset  = 19
+		walVersion         = 2
+	)
+	if len(image) <= readVersionOffset {
+		return
+	}
+	if image[writeVersionOffset] == walVersion {
+		image[writeVersionOffset] = 1
+	}
+	if image[readVersionOffset] == walVersion {
+		image[readVersionOffset] = 1
+	}
+}
+
+func newDatabaseCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) != DatabaseKeySize {
+		return nil, fmt.Errorf("database key must be %d bytes, got %d", DatabaseKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create database cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create database cipher: %w", err)
+	}
+
+	return aead, nil
+}
+
+// sealDatabaseSnapshot lays the file out as header, nonce, then ciphertext.
+func sealDatabaseSnapshot(aead cipher.AEAD, plain []byte) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	// crypto/rand.Read never returns an error on supported platforms.
+	_, _ = rand.Read(nonce)
+	out := make([]byte, 0, len(encryptedDBHeader)+len(nonce)+len(plain)+aead.Overhead())
+	out = append(out, encryptedDBHeader...)
+	out = append(out, nonce...)
+
+	return aead.Seal(out, nonce, plain, encryptedDBHeader)
+}
+
+func openDatabaseSnapshot(aead cipher.AEAD, sealed []byte) ([]byte, error) {
+	if !bytes.HasPrefix(sealed, encryptedDBHeader) {
+		return nil, fmt.Errorf("file is not a meshgo encrypted database")
+	}
+	sealed = sealed[len(encryptedDBHeader):]
+	if len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrDatabaseKeyRejected
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, encryptedDBHeader)
+	if err != nil {
+		return nil, ErrDatabaseKeyRejected
+	}
+
+	return plain, nil
+}
+
+func withSerializer(ctx context.Context, db *sql.DB, fn func(sqliteSerializer) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire db connection: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	return conn.Raw(func(driverConn any) error {
+		serializer, ok := driverConn.(sqliteSerializer)
+		if !ok {
+			return fmt.Errorf("sqlite driver does not support serialization")
+		}
+
+		return fn(serializer)
+	})
+}
+
+// writeFileAtomic replaces path so a crash leaves either the old or the new content.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer func() {
+		_ = os.Remove(tmpPath)
+	}()
+
+	if err := tmp.Chmod(0o600); err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+func removeDatabaseFiles(path string) error {
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		if err := os.Remove(path + suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove plain database%s file: %w", suffix, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestEncryptedDatabase_PersistsChangesAcrossReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "app.db.enc")
+	key := mustDatabaseKey(t)
+
+	encrypted, err := OpenEncrypted(ctx, path, key)
+	if err != nil {
+		t.Fatalf("open encrypted db: %v", err)
+	}
+	now := time.Now().UTC()
+	if err := NewChatRepo(encrypted.DB()).Upsert(ctx, domain.Chat{Key: "channel:0", Type: domain.ChatTypeChannel, Title: "LongFast", UpdatedAt: now}); err != nil {
+		t.Fatalf("upsert chat: %v", err)
+	}
+	if _, err := NewMessageRepo(encrypted.DB()).Insert(ctx, domain.ChatMessage{ChatKey: "channel:0", Direction: domain.MessageDirectionIn, Body: "secret words", At: now}); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+	if err := encrypted.Close(); err != nil {
+		t.Fatalf("close encrypted db: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read encrypted file: %v", err)
+	}
+	if bytes.Contains(raw, []byte("secret words")) || bytes.Contains(raw, []byte("SQLite format 3")) {
+		t.Fatalf("expected encrypted file to hide database content")
+	}
+
+	reopened, err := OpenEncrypted(ctx, path, key)
+	if err != nil {
+		t.Fatalf("reopen encrypted db: %v", err)
+	}
+	defer func() { _ = reopened.Close() }()
+	messages, err := NewMessageRepo(reopened.DB()).ListRecentByChat(ctx, "channel:0", 10)
+	if err != nil {
+		t.Fatalf("list messages: %v", err)
+	}
+	if len(messages) != 1 || messages[0].Body != "secret words" {
+		t.Fatalf("unexpected messages after reopen: %+v", messages)
+	}
+}
+
+func TestEncryptedDatabase_RejectsWrongKey(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "app.db.enc")
+
+	encrypted, err := OpenEncrypted(ctx, path, mustDatabaseKey(t))
+	if err != nil {
+		t.Fatalf("open encrypted db: %v", err)
+	}
+	if err := encrypted.Close(); err != nil {
+		t.Fatalf("close encrypted db: %v", err)
+	}
+
+	if _, err := OpenEncrypted(ctx, path, mustDatabaseKey(t)); !errors.Is(err, ErrDatabaseKeyRejected) {
+		t.Fatalf("expected wrong key to be rejected, got %v", err)
+	}
+	if _, err := OpenEncrypted(ctx, path, []byte("short")); err == nil {
+		t.Fatalf("expected short key to be rejected")
+	}
+}
+
+func TestEncryptedDatabase_FlushSkipsUnchangedDatabase(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "app.db.enc")
+
+	encrypted, err := OpenEncrypted(ctx, path, mustDatabaseKey(t))
+	if err != nil {
+		t.Fatalf("open encrypted db: %v", err)
+	}
+	defer func() { _ = encrypted.Close() }()
+
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read encrypted file: %v", err)
+	}
+	if err := encrypted.Flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read encrypted file: %v", err)
+	}
+	// A rewrite would use a fresh nonce and change the bytes.
+	if !bytes.Equal(before, after) {
+		t.Fatalf("expected unchanged database not to be rewritten")
+	}
+}
+
+func TestEncryptDatabaseFile_MigratesPlainDatabaseAndBack(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	plainPath := filepath.Join(dir, "app.db")
+	encryptedPath := filepath.Join(dir, "app.db.enc")
+	key := mustDatabaseKey(t)
+	seedDatabase(t, plainPath)
+
+	if err := EncryptDatabaseFile(ctx, plainPath, encryptedPath, key); err != nil {
+		t.Fatalf("encrypt database file: %v", err)
+	}
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		if _, err := os.Stat(plainPath + suffix); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected plain database%s file to be removed, got %v", suffix, err)
+		}
+	}
+
+	encrypted, err := OpenEncrypted(ctx, encryptedPath, key)
+	if err != nil {
+		t.Fatalf("open migrated db: %v", err)
+	}
+	assertSeededChat(t, encrypted.DB())
+	if err := encrypted.Close(); err != nil {
+		t.Fatalf("close migrated db: %v", err)
+	}
+
+	if err := DecryptDatabaseFile(encryptedPath, plainPath, key); err != nil {
+		t.Fatalf("decrypt database file: %v", err)
+	}
+	if _, err := os.Stat(encryptedPath); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected encrypted file to be removed, got %v", err)
+	}
+	db, err := Open(ctx, plainPath)
+	if err != nil {
+		t.Fatalf("open decrypted db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	assertSeededChat(t, db)
+}
+
+func TestOpenEncryptedReadOnly_ReadsFlushedSnapshot(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "app.db.enc")
+	key := mustDatabaseKey(t)
+
+	owner, err := OpenEncrypted(ctx, path, key)
+	if err != nil {
+		t.Fatalf("open encrypted db: %v", err)
+	}
+	defer func() { _ = owner.Close() }()
+	now := time.Now().UTC()
+	if err := NewChatRepo(owner.DB()).Upsert(ctx, domain.Chat{Key: "channel:0", Type: domain.ChatTypeChannel, Title: "LongFast", UpdatedAt: now}); err != nil {
+		t.Fatalf("upsert chat: %v", err)
+	}
+	if err := owner.Flush(ctx); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read encrypted file: %v", err)
+	}
+
+	reader, err := OpenEncryptedReadOnly(ctx, path, key)
+	if err != nil {
+		t.Fatalf("open read-only: %v", err)
+	}
+	defer func() { _ = reader.Close() }()
+	chats, err := NewChatRepo(reader).ListSortedByLastSentByMe(ctx)
+	if err != nil {
+		t.Fatalf("list chats: %v", err)
+	}
+	if len(chats) != 1 || chats[0].Title != "LongFast" {
+		t.Fatalf("unexpected chats: %+v", chats)
+	}
+	if _, err := reader.ExecContext(ctx, `DELETE FROM chats`); !IsReadOnlyError(err) {
+		t.Fatalf("expected read-only error, got %v", err)
+	}
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reread encrypted file: %v", err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Fatalf("expected read-only open to leave the encrypted file untouched")
+	}
+}
+
+func TestEncryptedDatabase_WriterBatchReachesDisk(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	path := filepath.Join(t.TempDir(), "app.db.enc")
+	key := mustDatabaseKey(t)
+
+	owner, err := OpenEncrypted(ctx, path, key)
+	if err != nil {
+		t.Fatalf("open encrypted db: %v", err)
+	}
+	defer func() { _ = owner.Close() }()
+	queue := NewWriterQueue(slog.New(slog.NewTextHandler(io.Discard, nil)), 8)
+	queue.EnableBatching(owner.DB(), 10*time.Millisecond)
+	flushed := make(chan error, 1)
+	queue.OnBatchWritten(func(ctx context.Context) {
+		flushed <- owner.Flush(ctx)
+	})
+	queue.Start(ctx)
+
+	queue.Enqueue("upsert_chat", func(writeCtx context.Context) error {
+		return NewChatRepo(owner.DB()).Upsert(writeCtx, domain.Chat{Key: "channel:0", Type: domain.ChatTypeChannel, Title: "LongFast", UpdatedAt: time.Now()})
+	})
+	select {
+	case err := <-flushed:
+		if err != nil {
+			t.Fatalf("flush after batch: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected a flush after the batch")
+	}
+
+	reader, err := OpenEncryptedReadOnly(ctx, path, key)
+	if err != nil {
+		t.Fatalf("open read-only: %v", err)
+	}
+	defer func() { _ = reader.Close() }()
+	chats, err := NewChatRepo(reader).ListSortedByLastSentByMe(ctx)
+	if err != nil {
+		t.Fatalf("list chats: %v", err)
+	}
+	if len(chats) != 1 || chats[0].Title != "LongFast" {
+		t.Fatalf("expected the batch on disk, got %+v", chats)
+	}
+}
+
+func mustDatabaseKey(t *testing.T) []byte {
+	t.Helper()
+	key, err := NewDatabaseKey()
+	if err != nil {
+		t.Fatalf("new database key: %v", err)
+	}
+
+	return key
+}
+
+func assertSeededChat(t *testing.T, db *sql.DB) {
+	t.Helper()
+	messages, err := NewMessageRepo(db).ListRecentByChat(context.Background(), "dm:!00000001", 10)
+	if err != nil {
+		t.Fatalf("list seeded messages: %v", err)
+	}
+	if len(messages) != 1 || messages[0].Body != "hi" {
+		t.Fatalf("unexpected seeded messages: %+v", messages)
+	}
+}
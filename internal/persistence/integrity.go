@@ -0,0 +1,303 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// SQLite primary result codes that mean the file itself is damaged.
+const (
+	sqliteCorrupt = 11
+	sqliteNotADB  = 26
+)
+
+// DatabaseRepairReport describes an automatic recovery of a corrupted database file.
+type DatabaseRepairReport struct {
+	Problem         string
+	BackupPath      string
+	RecoveredTables []string
+	LostTables      []string
+}
+
+// Summary returns a user-facing description of the repair.
+func (r DatabaseRepairReport) Summary() string {
+	var b strings.Builder
+	b.WriteString("The local database was damaged and has been rebuilt.\n")
+	fmt.Fprintf(&b, "The damaged file was kept as %s.\n", r.BackupPath)
+	if len(r.LostTables) == 0 {
+		b.WriteString("All stored data was recovered.")
+	} else {
+		fmt.Fprintf(&b, "Could not recover: %s.", strings.Join(r.LostTables, ", "))
+	}
+
+	return b.String()
+}
+
+// OpenWithIntegrityCheck opens the database like Open, but first runs a quick integrity check.
+// When the file is corrupted it is moved aside, a fresh database is created in its place and
+// readable rows are copied over. The returned report is nil when no repair was needed.
+func OpenWithIntegrityCheck(ctx context.Context, path string) (*sql.DB, *DatabaseRepairReport, error) {
+	problem, err := checkDatabaseIntegrity(ctx, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if problem == "" {
+		db, err := Open(ctx, path)
+
+		return db, nil, err
+	}
+
+	slog.Warn("database integrity check failed, attempting recovery", "path", path, "problem", problem)
+	report, db, err := repairDatabase(ctx, path, problem)
+	if err != nil {
+		return nil, nil, fmt.Errorf("repair corrupted database: %w", err)
+	}
+	slog.Warn(
+		"database recovered from corrupted file",
+		"backup_path", report.BackupPath,
+		"recovered_tables", len(report.RecoveredTables),
+		"lost_tables", strings.Join(report.LostTables, ","),
+	)
+
+	return db, report, nil
+}
+
+// checkDatabaseIntegrity returns a non-empty problem description when the database file is corrupted.
+func checkDatabaseIntegrity(ctx context.Context, path string) (string, error) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+
+	db, err := sql.Open("sqlite", path+"?"+busyTimeoutPragma)
+	if err != nil {
+		return "", fmt.Errorf("open sqlite db for integrity check: %w", err)
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	rows, err := db.QueryContext(ctx, `PRAGMA quick_check;`)
+	if err != nil {
+		if isCorruptionError(err) {
+			return err.Error(), nil
+		}
+
+		return "", fmt.Errorf("run integrity check: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var messages []string
+	for rows.Next() {
+		var message string
+		if err := rows.Scan(&message); err != nil {
+			return "", fmt.Errorf("scan integrity check result: %w", err)
+		}
+		if message != "ok" {
+			messages = append(messages, message)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		if isCorruptionError(err) {
+			return err.Error(), nil
+		}
+
+		return "", fmt.Errorf("iterate integrity check results: %w", err)
+	}
+
+	return strings.Join(messages, "; "), nil
+}
+
+func isCorruptionError(err error) bool {
+	switch sqliteResultCode(err) {
+	case sqliteCorrupt, sqliteNotADB:
+		return true
+	default:
+		return false
+	}
+}
+
+func repairDatabase(ctx context.Context, path, problem string) (*DatabaseRepairReport, *sql.DB, error) {
+	backupPath := fmt.Sprintf("%s.corrupt-%s", path, time.Now().Format("20060102-150405"))
+	if err := os.Rename(path, backupPath); err != nil {
+		return nil, nil, fmt.Errorf("move damaged database aside: %w", err)
+	}
+	// WAL and shared memory files belong to the damaged database and must follow it.
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Rename(path+suffix, backupPath+suffix); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, nil, fmt.Errorf("move damaged database %s file aside: %w", suffix, err)
+		}
+	}
+
+	db, err := Open(ctx, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &DatabaseRepairReport{Problem: problem, BackupPath: backupPath}
+	if err := salvageTables(ctx, db, backupPath, report); err != nil {
+		slog.Warn("salvage data from damaged database failed", "backup_path", backupPath, "error", err)
+		report.RecoveredTables = nil
+		report.LostTables = []string{"all data"}
+	}
+
+	return report, db, nil
+}
+
+// salvageTables copies every readable table of the damaged database into the fresh one.
+func salvageTables(ctx context.Context, db *sql.DB, damagedPath string, report *DatabaseRepairReport) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire db connection: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	tables, err := listTables(ctx, conn, "main")
+	if err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF;`); err != nil {
+		return fmt.Errorf("disable foreign keys: %w", err)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), `PRAGMA foreign_keys = ON;`)
+	}()
+	if _, err := conn.ExecContext(ctx, `ATTACH DATABASE ? AS damaged;`, damagedPath); err != nil {
+		return fmt.Errorf("attach damaged database: %w", err)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), `DETACH DATABASE damaged;`)
+	}()
+
+	damagedTables, err := listTables(ctx, conn, "damaged")
+	if err != nil {
+		return err
+	}
+	available := make(map[string]bool, len(damagedTables))
+	for _, table := range damagedTables {
+		available[table] = true
+	}
+
+	for _, table := range tables {
+		if !available[table] {
+			continue
+		}
+		if err := copyTable(ctx, conn, table); err != nil {
+			slog.Warn("table could not be recovered from damaged database", "table", table, "error", err)
+			report.LostTables = append(report.LostTables, table)
+
+			continue
+		}
+		report.RecoveredTables = append(report.RecoveredTables, table)
+	}
+
+	return nil
+}
+
+func listTables(ctx context.Context, conn *sql.Conn, schema string) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf(
+		`SELECT name FROM %s.sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%%' ORDER BY name`,
+		schema,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("list %s tables: %w", schema, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var out []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("scan %s table name: %w", schema, err)
+		}
+		out = append(out, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate %s tables: %w", schema, err)
+	}
+
+	return out, nil
+}
+
+// copyTable copies the columns both schemas share, so files left at an older schema version still recover.
+func copyTable(ctx context.Context, conn *sql.Conn, table string) error {
+	target, err := tableColumns(ctx, conn, "main", table)
+	if err != nil {
+		return err
+	}
+	source, err := tableColumns(ctx, conn, "damaged", table)
+	if err != nil {
+		return err
+	}
+	sourceSet := make(map[string]bool, len(source))
+	for _, column := range source {
+		sourceSet[column] = true
+	}
+	var columns []string
+	for _, column := range target {
+		if sourceSet[column] {
+			columns = append(columns, quoteIdentifier(column))
+		}
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("no shared columns")
+	}
+
+	columnList := strings.Join(columns, ", ")
+	quotedTable := quoteIdentifier(table)
+	_, err = conn.ExecContext(ctx, fmt.Sprintf(
+		`INSERT OR IGNORE INTO main.%s (%s) SELECT %s FROM damaged.%s`,
+		quotedTable, columnList, columnList, quotedTable,
+	))
+	if err != nil {
+		return fmt.Errorf("copy rows: %w", err)
+	}
+
+	return nil
+}
+
+func tableColumns(ctx context.Context, conn *sql.Conn, schema, table string) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, fmt.Sprintf(`PRAGMA %s.table_info(%s)`, schema, quoteIdentifier(table)))
+	if err != nil {
+		return nil, fmt.Errorf("read %s.%s columns: %w", schema, table, err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var out []string
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return nil, fmt.Errorf("scan %s.%s column: %w", schema, table, err)
+		}
+		out = append(out, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate %s.%s columns: %w", schema, table, err)
+	}
+
+	return out, nil
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
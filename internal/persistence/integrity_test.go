@@ -0,0 +1,134 @@
+package persistence
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestOpenWithIntegrityCheck_HealthyDatabaseNeedsNoRepair(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "app.db")
+	seedDatabase(t, dbPath)
+
+	db, report, err := OpenWithIntegrityCheck(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	if report != nil {
+		t.Fatalf("expected no repair report, got %+v", report)
+	}
+}
+
+func TestOpenWithIntegrityCheck_RebuildsUnreadableFile(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "app.db")
+	garbage := make([]byte, 8192)
+	for i := range garbage {
+		garbage[i] = byte(i % 251)
+	}
+	if err := os.WriteFile(dbPath, garbage, 0o600); err != nil {
+		t.Fatalf("write garbage db: %v", err)
+	}
+
+	db, report, err := OpenWithIntegrityCheck(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	if report == nil {
+		t.Fatalf("expected repair report")
+	}
+	backup, err := os.ReadFile(report.BackupPath)
+	if err != nil {
+		t.Fatalf("read backup: %v", err)
+	}
+	if len(backup) != len(garbage) {
+		t.Fatalf("expected damaged file to be kept as backup")
+	}
+	if _, err := NewChatRepo(db).ListSortedByLastSentByMe(ctx); err != nil {
+		t.Fatalf("expected rebuilt database to be usable: %v", err)
+	}
+}
+
+func TestOpenWithIntegrityCheck_RecoversReadableTables(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "app.db")
+	seedDatabase(t, dbPath)
+	corruptTableRootPage(t, dbPath, "traceroutes")
+
+	db, report, err := OpenWithIntegrityCheck(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	if report == nil {
+		t.Fatalf("expected repair report")
+	}
+	if len(report.LostTables) != 1 || report.LostTables[0] != "traceroutes" {
+		t.Fatalf("expected only traceroutes to be lost, got %+v", report.LostTables)
+	}
+
+	messages, err := NewMessageRepo(db).LoadRecentPerChat(ctx, 10)
+	if err != nil {
+		t.Fatalf("load messages: %v", err)
+	}
+	if len(messages["dm:!00000001"]) != 1 {
+		t.Fatalf("expected messages to be recovered, got %+v", messages)
+	}
+}
+
+func seedDatabase(t *testing.T, dbPath string) {
+	t.Helper()
+	ctx := context.Background()
+	db, err := Open(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("open seed db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	now := time.Now().UTC()
+	if err := NewChatRepo(db).Upsert(ctx, domain.Chat{Key: "dm:!00000001", Type: domain.ChatTypeDM, Title: "Alice", UpdatedAt: now}); err != nil {
+		t.Fatalf("seed chat: %v", err)
+	}
+	if _, err := NewMessageRepo(db).Insert(ctx, domain.ChatMessage{ChatKey: "dm:!00000001", Direction: domain.MessageDirectionIn, Body: "hi", At: now}); err != nil {
+		t.Fatalf("seed message: %v", err)
+	}
+	if err := NewTracerouteRepo(db).Upsert(ctx, domain.TracerouteRecord{RequestID: "1", TargetNodeID: "!00000001", StartedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("seed traceroute: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `PRAGMA wal_checkpoint(TRUNCATE);`); err != nil {
+		t.Fatalf("checkpoint seed db: %v", err)
+	}
+}
+
+// corruptTableRootPage overwrites the b-tree page type of the table root page.
+func corruptTableRootPage(t *testing.T, dbPath, table string) {
+	t.Helper()
+	db, err := Open(context.Background(), dbPath)
+	if err != nil {
+		t.Fatalf("open db to locate page: %v", err)
+	}
+	var rootPage, pageSize int64
+	if err := db.QueryRow(`SELECT rootpage FROM sqlite_master WHERE type = 'table' AND name = ?`, table).Scan(&rootPage); err != nil {
+		t.Fatalf("read root page: %v", err)
+	}
+	if err := db.QueryRow(`PRAGMA page_size;`).Scan(&pageSize); err != nil {
+		t.Fatalf("read page size: %v", err)
+	}
+	_ = db.Close()
+
+	f, err := os.OpenFile(dbPath, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatalf("open db file: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.WriteAt([]byte{0xff}, (rootPage-1)*pageSize); err != nil {
+		t.Fatalf("corrupt page: %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultVacuumFreeRatio is the share of free pages above which maintenance vacuums.
+	DefaultVacuumFreeRatio = 0.2
+	// minVacuumFreePages keeps small databases from being rewritten for a few free pages.
+	minVacuumFreePages = 256
+)
+
+// MaintenanceReport describes one RunMaintenance pass. Page counts are taken before VACUUM.
+type MaintenanceReport struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+	PageCount  int64
+	FreePages  int64
+	// Checkpointed is set when the WAL was fully copied into the database and truncated.
+	Checkpointed bool
+	Analyzed     bool
+	Vacuumed     bool
+}
+
+// FreeRatio is the share of database pages that are unused.
+func (r MaintenanceReport) FreeRatio() float64 {
+	if r.PageCount <= 0 {
+		return 0
+	}
+
+	return float64(r.FreePages) / float64(r.PageCount)
+}
+
+// DatabaseSize returns the size of the database pages in bytes. It works for in-memory
+// databases as well and leaves out the WAL.
+func DatabaseSize(ctx context.Context, db *sql.DB) (int64, error) {
+	if db == nil {
+		return 0, fmt.Errorf("database is not initialized")
+	}
+	var pageCount, pageSize int64
+	if err := db.QueryRowContext(ctx, `PRAGMA page_count;`).Scan(&pageCount); err != nil {
+		return 0, fmt.Errorf("read page count: %w", err)
+	}
+	if err := db.QueryRowContext(ctx, `PRAGMA page_size;`).Scan(&pageSize); err != nil {
+		return 0, fmt.Errorf("read page size: %w", err)
+	}
+
+	return pageCount * pageSize, nil
+}
+
+// RunMaintenance checkpoints the WAL, refreshes query planner statistics and vacuums the
+// database when at least vacuumFreeRatio of its pages are free. A non-positive ratio
+// uses DefaultVacuumFreeRatio.
+func RunMaintenance(ctx context.Context, db *sql.DB, vacuumFreeRatio float64) (MaintenanceReport, error) {
+	if db == nil {
+		return MaintenanceReport{}, fmt.Errorf("database is not initialized")
+	}
+	if vacuumFreeRatio <= 0 {
+		vacuumFreeRatio = DefaultVacuumFreeRatio
+	}
+	report := MaintenanceReport{StartedAt: time.Now()}
+
+	var journalMode string
+	if err := db.QueryRowContext(ctx, `PRAGMA journal_mode;`).Scan(&journalMode); err != nil {
+		return report, fmt.Errorf("read journal mode: %w", err)
+	}
+	if strings.EqualFold(journalMode, "wal") {
+		var busy, logFrames, checkpointed int64
+		if err := db.QueryRowContext(ctx, `PRAGMA wal_checkpoint(TRUNCATE);`).Scan(&busy, &logFrames, &checkpointed); err != nil {
+			return report, fmt.Errorf("checkpoint wal: %w", err)
+		}
+		report.Checkpointed = busy == 0
+	}
+
+	if _, err := db.ExecContext(ctx, `ANALYZE;`); err != nil {
+		return report, fmt.Errorf("analyze database: %w", err)
+	}
+	report.Analyzed = true
+
+	if err := db.QueryRowContext(ctx, `PRAGMA page_count;`).Scan(&report.PageCount); err != nil {
+		return report, fmt.Errorf("read page count: %w", err)
+	}
+	if err := db.QueryRowContext(ctx, `PRAGMA freelist_count;`).Scan(&report.FreePages); err != nil {
+		return report, fmt.Errorf("read free page count: %w", err)
+	}
+	if report.FreePages >= minVacuumFreePages && report.FreeRatio() >= vacuumFreeRatio {
+		if _, err := db.ExecContext(ctx, `VACUUM;`); err != nil {
+			return report, fmt.Errorf("vacuum database: %w", err)
+		}
+		report.Vacuumed = true
+	}
+	report.FinishedAt = time.Now()
+
+	return report, nil
+}
@@ -0,0 +1,84 @@
+package persistence
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunMaintenance(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	report, err := RunMaintenance(ctx, db, 0)
+	if err != nil {
+		t.Fatalf("run maintenance: %v", err)
+	}
+	if !report.Checkpointed || !report.Analyzed || report.Vacuumed || report.FinishedAt.IsZero() {
+		t.Fatalf("unexpected report for a fresh database: %+v", report)
+	}
+
+	// Fill and empty a table to leave plenty of free pages behind.
+	if _, err := db.ExecContext(ctx, `CREATE TABLE filler (payload TEXT NOT NULL)`); err != nil {
+		t.Fatalf("create filler table: %v", err)
+	}
+	payload := strings.Repeat("x", 2048)
+	for i := 0; i < 1000; i++ {
+		if _, err := db.ExecContext(ctx, `INSERT INTO filler(payload) VALUES (?)`, payload); err != nil {
+			t.Fatalf("insert filler row: %v", err)
+		}
+	}
+	if _, err := db.ExecContext(ctx, `DELETE FROM filler`); err != nil {
+		t.Fatalf("delete filler rows: %v", err)
+	}
+
+	report, err = RunMaintenance(ctx, db, 0)
+	if err != nil {
+		t.Fatalf("run maintenance after delete: %v", err)
+	}
+	if !report.Vacuumed || report.FreeRatio() < DefaultVacuumFreeRatio {
+		t.Fatalf("expected fragmented database to be vacuumed: %+v", report)
+	}
+	var freePages int64
+	if err := db.QueryRowContext(ctx, `PRAGMA freelist_count`).Scan(&freePages); err != nil {
+		t.Fatalf("read free pages: %v", err)
+	}
+	if freePages != 0 {
+		t.Fatalf("expected no free pages after vacuum, got %d", freePages)
+	}
+}
+
+func TestDatabaseSize(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	size, err := DatabaseSize(ctx, db)
+	if err != nil {
+		t.Fatalf("database size: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `CREATE TABLE filler (payload TEXT NOT NULL)`); err != nil {
+		t.Fatalf("create filler table: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, `INSERT INTO filler(payload) VALUES (?)`, strings.Repeat("x", 64*1024)); err != nil {
+		t.Fatalf("insert filler row: %v", err)
+	}
+	grown, err := DatabaseSize(ctx, db)
+	if err != nil {
+		t.Fatalf("database size after insert: %v", err)
+	}
+	if size <= 0 || grown < size+64*1024 {
+		t.Fatalf("expected the size to grow by the inserted row: before %d, after %d", size, grown)
+	}
+	if _, err := DatabaseSize(ctx, nil); err == nil {
+		t.Fatalf("expected an error without database")
+	}
+}
@@ -0,0 +1,186 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// MessageAnnotationRepo stores local-only stars and tags attached to messages.
+type MessageAnnotationRepo struct {
+	deviceScoped
+	db *sql.DB
+}
+
+func NewMessageAnnotationRepo(db *sql.DB) *MessageAnnotationRepo {
+	return &MessageAnnotationRepo{db: db}
+}
+
+// Save stores the annotation, or removes it when it no longer carries any marks.
+func (r *MessageAnnotationRepo) Save(ctx context.Context, a domain.MessageAnnotation) error {
+	a.ChatKey = strings.TrimSpace(a.ChatKey)
+	a.DeviceMessageID = strings.TrimSpace(a.DeviceMessageID)
+	if a.ChatKey == "" || a.DeviceMessageID == "" {
+		return fmt.Errorf("message annotation requires chat key and device message id")
+	}
+	a.Tags = domain.NormalizeTags(a.Tags)
+	deviceID := r.deviceID(ctx)
+	if a.IsEmpty() {
+		if _, err := r.db.ExecContext(ctx, `
+			DELETE FROM message_annotations WHERE device_id = ? AND chat_key = ? AND device_message_id = ?
+		`, deviceID, a.ChatKey, a.DeviceMessageID); err != nil {
+			return fmt.Errorf("delete message annotation: %w", err)
+		}
+
+		return nil
+	}
+
+	tagsJSON, err := marshalJSONNullable(a.Tags)
+	if err != nil {
+		return fmt.Errorf("marshal message tags: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO message_annotations(device_id, chat_key, device_message_id, starred, tags_json, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?)
+		ON CONFLICT(device_id, chat_key, device_message_id) DO UPDATE SET
+			starred = excluded.starred,
+			tags_json = excluded.tags_json,
+			updated_at = excluded.updated_at
+	`, deviceID, a.ChatKey, a.DeviceMessageID, boolToInt64(a.Starred), tagsJSON, timeToUnixMillis(a.UpdatedAt))
+	if err != nil {
+		return fmt.Errorf("upsert message annotation: %w", err)
+	}
+
+	return nil
+}
+
+// ListAll returns every stored annotation.
+func (r *MessageAnnotationRepo) ListAll(ctx context.Context) ([]domain.MessageAnnotation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT chat_key, device_message_id, starred, tags_json, updated_at
+		FROM message_annotations
+		WHERE device_id = ?
+		ORDER BY updated_at DESC
+	`, r.deviceID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list message annotations: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var out []domain.MessageAnnotation
+	for rows.Next() {
+		a, err := scanMessageAnnotation(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate message annotations: %w", err)
+	}
+
+	return out, nil
+}
+
+// ListAnnotatedMessages returns annotated messages across all visible chats, newest first.
+func (r *MessageAnnotationRepo) ListAnnotatedMessages(ctx context.Context) ([]domain.AnnotatedMessage, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT a.chat_key, a.device_message_id, a.starred, a.tags_json, a.updated_at,
+			m.local_id, m.chat_key, m.device_message_id, m.reply_to_device_message_id, m.emoji,
+			m.direction, m.body, m.status, m.at, m.meta_json
+		FROM message_annotations a
+		JOIN messages m ON m.device_id = a.device_id AND m.chat_key = a.chat_key AND m.device_message_id = a.device_message_id
+		LEFT JOIN chats c ON c.device_id = a.device_id AND c.chat_key = a.chat_key
+		WHERE a.device_id = ? AND c.deleted_at IS NULL
+		ORDER BY m.at DESC
+	`, r.deviceID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list annotated messages: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var out []domain.AnnotatedMessage
+	for rows.Next() {
+		var (
+			item        domain.AnnotatedMessage
+			starred     int
+			tagsRaw     sql.NullString
+			updatedAtMs int64
+			atMs        int64
+			direction   int
+			status      int
+			deviceIDRaw sql.NullString
+			replyIDRaw  sql.NullString
+			metaRaw     sql.NullString
+		)
+		if err := rows.Scan(
+			&item.Annotation.ChatKey, &item.Annotation.DeviceMessageID, &starred, &tagsRaw, &updatedAtMs,
+			&item.Message.LocalID, &item.Message.ChatKey, &deviceIDRaw, &replyIDRaw, &item.Message.Emoji,
+			&direction, &item.Message.Body, &status, &atMs, &metaRaw,
+		); err != nil {
+			return nil, fmt.Errorf("scan annotated message: %w", err)
+		}
+		tags, err := unmarshalTags(tagsRaw)
+		if err != nil {
+			return nil, err
+		}
+		item.Annotation.Starred = starred != 0
+		item.Annotation.Tags = tags
+		item.Annotation.UpdatedAt = unixMillisToTime(updatedAtMs)
+		item.Message.Direction = domain.MessageDirection(direction)
+		item.Message.Status = domain.MessageStatus(status)
+		item.Message.At = unixMillisToTime(atMs)
+		item.Message.DeviceMessageID = deviceIDRaw.String
+		item.Message.ReplyToDeviceMessageID = replyIDRaw.String
+		item.Message.MetaJSON = metaRaw.String
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate annotated messages: %w", err)
+	}
+
+	return out, nil
+}
+
+func scanMessageAnnotation(scanner interface {
+	Scan(dest ...any) error
+}) (domain.MessageAnnotation, error) {
+	var (
+		a           domain.MessageAnnotation
+		starred     int
+		tagsRaw     sql.NullString
+		updatedAtMs int64
+	)
+	if err := scanner.Scan(&a.ChatKey, &a.DeviceMessageID, &starred, &tagsRaw, &updatedAtMs); err != nil {
+		return domain.MessageAnnotation{}, fmt.Errorf("scan message annotation: %w", err)
+	}
+	a.Starred = starred != 0
+	a.UpdatedAt = unixMillisToTime(updatedAtMs)
+	tags, err := unmarshalTags(tagsRaw)
+	if err != nil {
+		return domain.MessageAnnotation{}, err
+	}
+	a.Tags = tags
+
+	return a, nil
+}
+
+func unmarshalTags(raw sql.NullString) ([]string, error) {
+	if !raw.Valid || raw.String == "" {
+		return nil, nil
+	}
+	var tags []string
+	if err := json.Unmarshal([]byte(raw.String), &tags); err != nil {
+		return nil, fmt.Errorf("unmarshal tags: %w", err)
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,99 @@
+package persistence
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestMessageAnnotationRepoSaveListAndClear(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	messages := NewMessageRepo(db)
+	repo := NewMessageAnnotationRepo(db)
+	now := time.Now().UTC().Truncate(time.Second)
+	for i, id := range []string{"100", "101"} {
+		if _, err := messages.Insert(ctx, domain.ChatMessage{
+			DeviceMessageID: id,
+			ChatKey:         "channel:0",
+			Direction:       domain.MessageDirectionIn,
+			Body:            "message " + id,
+			Status:          domain.MessageStatusSent,
+			At:              now.Add(time.Duration(i) * time.Minute),
+		}); err != nil {
+			t.Fatalf("insert message %s: %v", id, err)
+		}
+	}
+
+	if err := repo.Save(ctx, domain.MessageAnnotation{
+		ChatKey:         "channel:0",
+		DeviceMessageID: "100",
+		Starred:         true,
+		Tags:            []string{"QSL", " qsl ", "action item"},
+		UpdatedAt:       now,
+	}); err != nil {
+		t.Fatalf("save annotation: %v", err)
+	}
+	if err := repo.Save(ctx, domain.MessageAnnotation{ChatKey: "channel:0", DeviceMessageID: "101", Tags: []string{"later"}, UpdatedAt: now}); err != nil {
+		t.Fatalf("save second annotation: %v", err)
+	}
+
+	items, err := repo.ListAnnotatedMessages(ctx)
+	if err != nil {
+		t.Fatalf("list annotated messages: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected two annotated messages, got %d", len(items))
+	}
+	if items[0].Message.DeviceMessageID != "101" || items[1].Message.Body != "message 100" {
+		t.Fatalf("expected newest message first with bodies joined, got %+v", items)
+	}
+	if !items[1].Annotation.Starred || !slices.Equal(items[1].Annotation.Tags, []string{"QSL", "action item"}) {
+		t.Fatalf("unexpected annotation: %+v", items[1].Annotation)
+	}
+
+	if err := repo.Save(ctx, domain.MessageAnnotation{ChatKey: "channel:0", DeviceMessageID: "101", UpdatedAt: now}); err != nil {
+		t.Fatalf("clear annotation: %v", err)
+	}
+	all, err := repo.ListAll(ctx)
+	if err != nil {
+		t.Fatalf("list annotations: %v", err)
+	}
+	if len(all) != 1 || all[0].DeviceMessageID != "100" {
+		t.Fatalf("expected cleared annotation to be removed, got %+v", all)
+	}
+
+	if err := messages.DeleteByChat(ctx, "channel:0"); err != nil {
+		t.Fatalf("delete chat messages: %v", err)
+	}
+	all, err = repo.ListAll(ctx)
+	if err != nil {
+		t.Fatalf("list annotations after delete: %v", err)
+	}
+	if len(all) != 0 {
+		t.Fatalf("expected annotations to be removed with chat messages, got %+v", all)
+	}
+}
+
+func TestMessageAnnotationRepoSaveRequiresMessageKey(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	err = NewMessageAnnotationRepo(db).Save(ctx, domain.MessageAnnotation{ChatKey: "channel:0", Starred: true})
+	if err == nil {
+		t.Fatalf("expected error for annotation without device message id")
+	}
+}
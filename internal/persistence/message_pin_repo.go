@@ -0,0 +1,82 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// MessagePinRepo stores local-only message pins.
+type MessagePinRepo struct {
+	deviceScoped
+	db *sql.DB
+}
+
+func NewMessagePinRepo(db *sql.DB) *MessagePinRepo {
+	return &MessagePinRepo{db: db}
+}
+
+// Pin stores the pin. Pinning an already pinned message keeps its original time.
+func (r *MessagePinRepo) Pin(ctx context.Context, pin domain.MessagePin) error {
+	pin.ChatKey = strings.TrimSpace(pin.ChatKey)
+	pin.DeviceMessageID = strings.TrimSpace(pin.DeviceMessageID)
+	if pin.ChatKey == "" || pin.DeviceMessageID == "" {
+		return fmt.Errorf("message pin requires chat key and device message id")
+	}
+	if _, err := r.db.ExecContext(ctx, `
+		INSERT OR IGNORE INTO message_pins(device_id, chat_key, device_message_id, pinned_at)
+		VALUES(?, ?, ?, ?)
+	`, r.deviceID(ctx), pin.ChatKey, pin.DeviceMessageID, timeToUnixMillis(pin.PinnedAt)); err != nil {
+		return fmt.Errorf("insert message pin: %w", err)
+	}
+
+	return nil
+}
+
+// Unpin removes the pin if the message is pinned.
+func (r *MessagePinRepo) Unpin(ctx context.Context, chatKey, deviceMessageID string) error {
+	if _, err := r.db.ExecContext(ctx, `
+		DELETE FROM message_pins WHERE device_id = ? AND chat_key = ? AND device_message_id = ?
+	`, r.deviceID(ctx), strings.TrimSpace(chatKey), strings.TrimSpace(deviceMessageID)); err != nil {
+		return fmt.Errorf("delete message pin: %w", err)
+	}
+
+	return nil
+}
+
+// ListAll returns every stored pin, oldest first.
+func (r *MessagePinRepo) ListAll(ctx context.Context) ([]domain.MessagePin, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT chat_key, device_message_id, pinned_at
+		FROM message_pins
+		WHERE device_id = ?
+		ORDER BY pinned_at ASC, device_message_id ASC
+	`, r.deviceID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("list message pins: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var out []domain.MessagePin
+	for rows.Next() {
+		var (
+			pin        domain.MessagePin
+			pinnedAtMs int64
+		)
+		if err := rows.Scan(&pin.ChatKey, &pin.DeviceMessageID, &pinnedAtMs); err != nil {
+			return nil, fmt.Errorf("scan message pin: %w", err)
+		}
+		pin.PinnedAt = unixMillisToTime(pinnedAtMs)
+		out = append(out, pin)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate message pins: %w", err)
+	}
+
+	return out, nil
+}
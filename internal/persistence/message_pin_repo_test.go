@@ -0,0 +1,63 @@
+package persistence
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestMessagePinRepoPinUnpinAndDeleteByChat(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewMessagePinRepo(db)
+	now := time.Now().UTC().Truncate(time.Second)
+	pins := []domain.MessagePin{
+		{ChatKey: "channel:0", DeviceMessageID: "101", PinnedAt: now.Add(time.Minute)},
+		{ChatKey: "channel:0", DeviceMessageID: "100", PinnedAt: now},
+		{ChatKey: "channel:1", DeviceMessageID: "200", PinnedAt: now},
+	}
+	for _, pin := range pins {
+		if err := repo.Pin(ctx, pin); err != nil {
+			t.Fatalf("pin %s: %v", pin.DeviceMessageID, err)
+		}
+	}
+	if err := repo.Pin(ctx, domain.MessagePin{ChatKey: "channel:0", DeviceMessageID: "100", PinnedAt: now.Add(time.Hour)}); err != nil {
+		t.Fatalf("pin again: %v", err)
+	}
+	if err := repo.Pin(ctx, domain.MessagePin{ChatKey: "channel:0"}); err == nil {
+		t.Fatalf("expected error for pin without device message id")
+	}
+
+	all, err := repo.ListAll(ctx)
+	if err != nil {
+		t.Fatalf("list pins: %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("expected three pins, got %+v", all)
+	}
+	if all[0].DeviceMessageID != "100" || !all[0].PinnedAt.Equal(now) {
+		t.Fatalf("expected repeated pin to keep its time and order, got %+v", all)
+	}
+
+	if err := repo.Unpin(ctx, "channel:0", "101"); err != nil {
+		t.Fatalf("unpin: %v", err)
+	}
+	if err := NewMessageRepo(db).DeleteByChat(ctx, "channel:1"); err != nil {
+		t.Fatalf("delete chat messages: %v", err)
+	}
+	all, err = repo.ListAll(ctx)
+	if err != nil {
+		t.Fatalf("list pins after unpin: %v", err)
+	}
+	if len(all) != 1 || all[0].DeviceMessageID != "100" {
+		t.Fatalf("expected only channel:0 message 100 to stay pinned, got %+v", all)
+	}
+}
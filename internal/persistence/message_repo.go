@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/skobkin/meshgo/internal/domain"
@@ -11,6 +12,7 @@ import (
 
 // MessageRepo implements domain.MessageRepository using SQLite.
 type MessageRepo struct {
+	deviceScoped
 	db *sql.DB
 }
 
@@ -24,18 +26,64 @@ func (r *MessageRepo) DeleteByChat(ctx context.Context, chatKey string) error {
 		return nil
 	}
 
-	if _, err := r.db.ExecContext(ctx, `DELETE FROM messages WHERE chat_key = ?`, chatKey); err != nil {
+	deviceID := r.deviceID(ctx)
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM messages WHERE device_id = ? AND chat_key = ?`, deviceID, chatKey); err != nil {
 		return fmt.Errorf("delete messages by chat: %w", err)
 	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM message_annotations WHERE device_id = ? AND chat_key = ?`, deviceID, chatKey); err != nil {
+		return fmt.Errorf("delete message annotations by chat: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM message_pins WHERE device_id = ? AND chat_key = ?`, deviceID, chatKey); err != nil {
+		return fmt.Errorf("delete message pins by chat: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes one message of a chat, by packet id when it has one or by time,
+// direction and text otherwise, with its annotation and pin.
+func (r *MessageRepo) Delete(ctx context.Context, m domain.ChatMessage) error {
+	deviceID := r.deviceID(ctx)
+	deviceMessageID := strings.TrimSpace(m.DeviceMessageID)
+	if deviceMessageID == "" {
+		if _, err := r.db.ExecContext(ctx, `
+			DELETE FROM messages WHERE device_id = ? AND chat_key = ? AND device_message_id IS NULL AND direction = ? AND body = ? AND at = ?
+		`, deviceID, m.ChatKey, int(m.Direction), m.Body, timeToUnixMillis(m.At)); err != nil {
+			return fmt.Errorf("delete message: %w", err)
+		}
+
+		return nil
+	}
+
+	tx, err := beginWriteTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("begin delete message tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM messages WHERE device_id = ? AND chat_key = ? AND device_message_id = ?`, deviceID, m.ChatKey, deviceMessageID); err != nil {
+		return fmt.Errorf("delete message: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM message_annotations WHERE device_id = ? AND chat_key = ? AND device_message_id = ?`, deviceID, m.ChatKey, deviceMessageID); err != nil {
+		return fmt.Errorf("delete message annotation: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM message_pins WHERE device_id = ? AND chat_key = ? AND device_message_id = ?`, deviceID, m.ChatKey, deviceMessageID); err != nil {
+		return fmt.Errorf("delete message pin: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit delete message tx: %w", err)
+	}
 
 	return nil
 }
 
 func (r *MessageRepo) Insert(ctx context.Context, m domain.ChatMessage) (int64, error) {
-	res, err := r.db.ExecContext(ctx, `
-		INSERT OR IGNORE INTO messages(chat_key, device_message_id, reply_to_device_message_id, emoji, direction, body, status, at, meta_json)
-		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`, m.ChatKey, nullableString(m.DeviceMessageID), nullableString(m.ReplyToDeviceMessageID), int(m.Emoji), int(m.Direction), m.Body, int(m.Status), timeToUnixMillis(m.At), nullableString(m.MetaJSON))
+	res, err := executorFor(ctx, r.db).ExecContext(ctx, `
+		INSERT OR IGNORE INTO messages(device_id, chat_key, device_message_id, reply_to_device_message_id, emoji, direction, body, status, at, meta_json)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, r.deviceID(ctx), m.ChatKey, nullableString(m.DeviceMessageID), nullableString(m.ReplyToDeviceMessageID), int(m.Emoji), int(m.Direction), m.Body, int(m.Status), timeToUnixMillis(m.At), nullableString(m.MetaJSON))
 	if err != nil {
 		return 0, fmt.Errorf("insert message: %w", err)
 	}
@@ -51,14 +99,28 @@ func (r *MessageRepo) Insert(ctx context.Context, m domain.ChatMessage) (int64,
 	return id, nil
 }
 
+// ExistsByContent reports whether a chat already holds a message with the same
+// direction, body and timestamp. It deduplicates messages without a device id.
+func (r *MessageRepo) ExistsByContent(ctx context.Context, m domain.ChatMessage) (bool, error) {
+	var exists int
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM messages WHERE device_id = ? AND chat_key = ? AND direction = ? AND body = ? AND at = ?)
+	`, r.deviceID(ctx), m.ChatKey, int(m.Direction), m.Body, timeToUnixMillis(m.At)).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check message exists: %w", err)
+	}
+
+	return exists == 1, nil
+}
+
 func (r *MessageRepo) ListRecentByChat(ctx context.Context, chatKey string, limit int) ([]domain.ChatMessage, error) {
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT local_id, chat_key, device_message_id, reply_to_device_message_id, emoji, direction, body, status, at, meta_json
 		FROM messages
-		WHERE chat_key = ?
+		WHERE device_id = ? AND chat_key = ?
 		ORDER BY at DESC
 		LIMIT ?
-	`, chatKey, limit)
+	`, r.deviceID(ctx), chatKey, limit)
 	if err != nil {
 		return nil, fmt.Errorf("list messages by chat: %w", err)
 	}
@@ -85,8 +147,138 @@ func (r *MessageRepo) ListRecentByChat(ctx context.Context, chatKey string, limi
 	return out, nil
 }
 
+// CountByChat returns the number of stored messages in a chat.
+func (r *MessageRepo) CountByChat(ctx context.Context, chatKey string) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM messages WHERE device_id = ? AND chat_key = ?`, r.deviceID(ctx), chatKey).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count messages by chat: %w", err)
+	}
+
+	return count, nil
+}
+
+// Count returns the number of stored messages of the current device.
+func (r *MessageRepo) Count(ctx context.Context) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM messages WHERE device_id = ?`, r.deviceID(ctx)).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count messages: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListPageByChat returns up to limit messages of a chat in chronological order,
+// starting right after the given message. Pass a zero message for the first page.
+func (r *MessageRepo) ListPageByChat(ctx context.Context, chatKey string, after domain.ChatMessage, limit int) ([]domain.ChatMessage, error) {
+	afterMs := timeToUnixMillis(after.At)
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT local_id, chat_key, device_message_id, reply_to_device_message_id, emoji, direction, body, status, at, meta_json
+		FROM messages
+		WHERE device_id = ? AND chat_key = ? AND (at > ? OR (at = ? AND local_id > ?))
+		ORDER BY at ASC, local_id ASC
+		LIMIT ?
+	`, r.deviceID(ctx), chatKey, afterMs, afterMs, after.LocalID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list message page by chat: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var out []domain.ChatMessage
+	for rows.Next() {
+		m, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate message page by chat: %w", err)
+	}
+
+	return out, nil
+}
+
+// ListPageBeforeByChat returns up to limit messages of a chat right before the given
+// message, in chronological order. It pages the history backwards from the loaded
+// messages; messages kept only in memory have no local id and match by time alone.
+func (r *MessageRepo) ListPageBeforeByChat(ctx context.Context, chatKey string, before domain.ChatMessage, limit int) ([]domain.ChatMessage, error) {
+	beforeMs := timeToUnixMillis(before.At)
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT local_id, chat_key, device_message_id, reply_to_device_message_id, emoji, direction, body, status, at, meta_json
+		FROM messages
+		WHERE device_id = ? AND chat_key = ? AND (at < ? OR (at = ? AND local_id < ?))
+		ORDER BY at DESC, local_id DESC
+		LIMIT ?
+	`, r.deviceID(ctx), chatKey, beforeMs, beforeMs, before.LocalID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list message page before by chat: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var out []domain.ChatMessage
+	for rows.Next() {
+		m, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate message page before by chat: %w", err)
+	}
+
+	slices.Reverse(out)
+
+	return out, nil
+}
+
+// SearchByChat returns up to limit of the newest chat messages whose text contains the
+// query, in chronological order. Reactions are skipped. Matching ignores case in Go,
+// since SQLite folds case for ASCII letters only.
+func (r *MessageRepo) SearchByChat(ctx context.Context, chatKey, query string, limit int) ([]domain.ChatMessage, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" || limit <= 0 {
+		return nil, nil
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT local_id, chat_key, device_message_id, reply_to_device_message_id, emoji, direction, body, status, at, meta_json
+		FROM messages
+		WHERE device_id = ? AND chat_key = ? AND emoji = 0
+		ORDER BY at DESC, local_id DESC
+	`, r.deviceID(ctx), chatKey)
+	if err != nil {
+		return nil, fmt.Errorf("search messages by chat: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var out []domain.ChatMessage
+	for rows.Next() && len(out) < limit {
+		m, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.Contains(strings.ToLower(m.Body), query) {
+			continue
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate message search results: %w", err)
+	}
+
+	slices.Reverse(out)
+
+	return out, nil
+}
+
 func (r *MessageRepo) LoadRecentPerChat(ctx context.Context, limit int) (map[string][]domain.ChatMessage, error) {
-	rows, err := r.db.QueryContext(ctx, `SELECT chat_key FROM chats`)
+	rows, err := r.db.QueryContext(ctx, `SELECT chat_key FROM chats WHERE device_id = ? AND deleted_at IS NULL`, r.deviceID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("list chat keys: %w", err)
 	}
@@ -121,11 +313,12 @@ func (r *MessageRepo) UpdateStatusByDeviceMessageID(ctx context.Context, deviceM
 		return nil
 	}
 
-	rows, err := r.db.QueryContext(ctx, `
+	exec := executorFor(ctx, r.db)
+	rows, err := exec.QueryContext(ctx, `
 		SELECT local_id, status
 		FROM messages
-		WHERE device_message_id = ?
-	`, deviceMessageID)
+		WHERE device_id = ? AND device_message_id = ?
+	`, r.deviceID(ctx), deviceMessageID)
 	if err != nil {
 		return fmt.Errorf("query messages by device id: %w", err)
 	}
@@ -160,7 +353,7 @@ func (r *MessageRepo) UpdateStatusByDeviceMessageID(ctx context.Context, deviceM
 	}
 
 	for _, item := range toUpdate {
-		if _, err := r.db.ExecContext(ctx, `
+		if _, err := exec.ExecContext(ctx, `
 			UPDATE messages
 			SET status = ?
 			WHERE local_id = ?
@@ -172,6 +365,34 @@ func (r *MessageRepo) UpdateStatusByDeviceMessageID(ctx context.Context, deviceM
 	return nil
 }
 
+// ReplaceQueued gives the row stored for a queued message the packet id, status and
+// meta of its sent copy. Without such a row the message is inserted as is.
+func (r *MessageRepo) ReplaceQueued(ctx context.Context, m domain.ChatMessage) error {
+	queuedID := strings.TrimSpace(m.QueuedMessageID)
+	if queuedID == "" {
+		_, err := r.Insert(ctx, m)
+
+		return err
+	}
+
+	res, err := executorFor(ctx, r.db).ExecContext(ctx, `
+		UPDATE messages
+		SET device_message_id = ?, status = ?, meta_json = COALESCE(?, meta_json)
+		WHERE device_id = ? AND chat_key = ? AND device_message_id = ?
+	`, nullableString(m.DeviceMessageID), int(m.Status), nullableString(m.MetaJSON), r.deviceID(ctx), m.ChatKey, queuedID)
+	if err != nil {
+		return fmt.Errorf("replace queued message: %w", err)
+	}
+	if rowsAffected, err := res.RowsAffected(); err == nil && rowsAffected > 0 {
+		return nil
+	}
+	if _, err := r.Insert(ctx, m); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func scanMessage(scanner interface {
 	Scan(dest ...any) error
 }) (domain.ChatMessage, error) {
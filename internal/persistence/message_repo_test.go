@@ -2,7 +2,10 @@ package persistence
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"testing"
 	"time"
 
@@ -101,3 +104,352 @@ func TestMessageRepoDeleteByChat_RemovesOnlyTargetMessages(t *testing.T) {
 		t.Fatalf("expected non-target messages to remain, got %d", len(channelMessages))
 	}
 }
+
+func TestMessageRepoCount_CountsAllChats(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewMessageRepo(db)
+	now := time.Now().UTC().Truncate(time.Second)
+	for i, chatKey := range []string{"dm:!1234abcd", "channel:0", "channel:0"} {
+		if _, err := repo.Insert(ctx, domain.ChatMessage{
+			DeviceMessageID: strconv.Itoa(100 + i),
+			ChatKey:         chatKey,
+			Direction:       domain.MessageDirectionIn,
+			Body:            "hello",
+			Status:          domain.MessageStatusSent,
+			At:              now,
+		}); err != nil {
+			t.Fatalf("insert message %d: %v", i, err)
+		}
+	}
+
+	count, err := repo.Count(ctx)
+	if err != nil {
+		t.Fatalf("count messages: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 messages, got %d", count)
+	}
+}
+
+func TestMessageRepoListPageByChat_PagesInChronologicalOrder(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewMessageRepo(db)
+	now := time.Now().UTC().Truncate(time.Second)
+	// Two messages share a timestamp to exercise the local id tie-breaker.
+	offsets := []time.Duration{2 * time.Minute, 0, time.Minute, time.Minute, 3 * time.Minute}
+	for i, offset := range offsets {
+		if _, err := repo.Insert(ctx, domain.ChatMessage{
+			ChatKey:   "channel:0",
+			Direction: domain.MessageDirectionIn,
+			Body:      fmt.Sprintf("message %d", i),
+			Status:    domain.MessageStatusSent,
+			At:        now.Add(offset),
+		}); err != nil {
+			t.Fatalf("insert message %d: %v", i, err)
+		}
+	}
+	if _, err := repo.Insert(ctx, domain.ChatMessage{ChatKey: "channel:1", Body: "other", At: now}); err != nil {
+		t.Fatalf("insert other chat message: %v", err)
+	}
+
+	count, err := repo.CountByChat(ctx, "channel:0")
+	if err != nil {
+		t.Fatalf("count messages: %v", err)
+	}
+	if count != len(offsets) {
+		t.Fatalf("expected %d messages, got %d", len(offsets), count)
+	}
+
+	var (
+		bodies []string
+		after  domain.ChatMessage
+	)
+	for {
+		page, err := repo.ListPageByChat(ctx, "channel:0", after, 2)
+		if err != nil {
+			t.Fatalf("list page: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, m := range page {
+			bodies = append(bodies, m.Body)
+		}
+		after = page[len(page)-1]
+	}
+
+	want := []string{"message 1", "message 2", "message 3", "message 0", "message 4"}
+	if !slices.Equal(bodies, want) {
+		t.Fatalf("unexpected page order: got %v, want %v", bodies, want)
+	}
+}
+
+func TestMessageRepoListPageBeforeByChat_PagesBackwards(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewMessageRepo(db)
+	now := time.Now().UTC().Truncate(time.Second)
+	// Two messages share a timestamp to exercise the local id tie-breaker.
+	offsets := []time.Duration{0, time.Minute, time.Minute, 2 * time.Minute, 3 * time.Minute}
+	for i, offset := range offsets {
+		if _, err := repo.Insert(ctx, domain.ChatMessage{
+			ChatKey: "channel:0",
+			Body:    fmt.Sprintf("message %d", i),
+			At:      now.Add(offset),
+		}); err != nil {
+			t.Fatalf("insert message %d: %v", i, err)
+		}
+	}
+
+	recent, err := repo.ListRecentByChat(ctx, "channel:0", 2)
+	if err != nil {
+		t.Fatalf("list recent: %v", err)
+	}
+	bodies := make([]string, 0, len(offsets))
+	for _, m := range recent {
+		bodies = append(bodies, m.Body)
+	}
+	oldest := recent[0]
+	for {
+		page, err := repo.ListPageBeforeByChat(ctx, "channel:0", oldest, 2)
+		if err != nil {
+			t.Fatalf("list page before: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		pageBodies := make([]string, 0, len(page))
+		for _, m := range page {
+			pageBodies = append(pageBodies, m.Body)
+		}
+		bodies = append(pageBodies, bodies...)
+		oldest = page[0]
+	}
+
+	want := []string{"message 0", "message 1", "message 2", "message 3", "message 4"}
+	if !slices.Equal(bodies, want) {
+		t.Fatalf("unexpected history: got %v, want %v", bodies, want)
+	}
+}
+
+func TestMessageRepoSearchByChat_MatchesTextIgnoringCase(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewMessageRepo(db)
+	now := time.Now().UTC().Truncate(time.Second)
+	messages := []domain.ChatMessage{
+		{ChatKey: "channel:0", Body: "Привет всем", At: now},
+		{ChatKey: "channel:0", Body: "meeting at noon", At: now.Add(time.Minute)},
+		{ChatKey: "channel:0", Body: "всем пока", At: now.Add(2 * time.Minute)},
+		{ChatKey: "channel:0", Body: "👍 всем", Emoji: 1, At: now.Add(3 * time.Minute)},
+		{ChatKey: "channel:0", Body: "ВСЕМ спасибо", At: now.Add(4 * time.Minute)},
+		{ChatKey: "channel:1", Body: "всем из другого чата", At: now},
+	}
+	for i, m := range messages {
+		if _, err := repo.Insert(ctx, m); err != nil {
+			t.Fatalf("insert message %d: %v", i, err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{name: "case insensitive", query: " всем ", limit: 10, want: []string{"Привет всем", "всем пока", "ВСЕМ спасибо"}},
+		{name: "limit keeps newest", query: "всем", limit: 2, want: []string{"всем пока", "ВСЕМ спасибо"}},
+		{name: "no match", query: "nothing", limit: 10, want: nil},
+		{name: "empty query", query: " ", limit: 10, want: nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			found, err := repo.SearchByChat(ctx, "channel:0", tc.query, tc.limit)
+			if err != nil {
+				t.Fatalf("search messages: %v", err)
+			}
+			var bodies []string
+			for _, m := range found {
+				bodies = append(bodies, m.Body)
+			}
+			if !slices.Equal(bodies, tc.want) {
+				t.Fatalf("unexpected matches: got %v, want %v", bodies, tc.want)
+			}
+		})
+	}
+}
+
+func TestMessageRepoExistsByContent_MatchesDirectionBodyAndTime(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "app.db")
+
+	db, err := Open(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewMessageRepo(db)
+	stored := domain.ChatMessage{
+		ChatKey:   "channel:0",
+		Direction: domain.MessageDirectionOut,
+		Body:      "hello",
+		Status:    domain.MessageStatusSent,
+		At:        time.UnixMilli(1_700_000_000_123),
+	}
+	if _, err := repo.Insert(ctx, stored); err != nil {
+		t.Fatalf("insert message: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*domain.ChatMessage)
+		want   bool
+	}{
+		{name: "same content", mutate: func(*domain.ChatMessage) {}, want: true},
+		{name: "other chat", mutate: func(m *domain.ChatMessage) { m.ChatKey = "channel:1" }, want: false},
+		{name: "other direction", mutate: func(m *domain.ChatMessage) { m.Direction = domain.MessageDirectionIn }, want: false},
+		{name: "other body", mutate: func(m *domain.ChatMessage) { m.Body = "hello!" }, want: false},
+		{name: "other time", mutate: func(m *domain.ChatMessage) { m.At = m.At.Add(time.Millisecond) }, want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			candidate := stored
+			tc.mutate(&candidate)
+			got, err := repo.ExistsByContent(ctx, candidate)
+			if err != nil {
+				t.Fatalf("exists by content: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMessageRepoReplaceQueued_TakesOverQueuedRow(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewMessageRepo(db)
+	queuedAt := time.Now().UTC().Add(-time.Minute).Truncate(time.Millisecond)
+	if _, err := repo.Insert(ctx, domain.ChatMessage{
+		DeviceMessageID: "outbox:7",
+		ChatKey:         "channel:0",
+		Direction:       domain.MessageDirectionOut,
+		Body:            "hello",
+		Status:          domain.MessageStatusPending,
+		At:              queuedAt,
+	}); err != nil {
+		t.Fatalf("insert queued message: %v", err)
+	}
+
+	sent := domain.ChatMessage{
+		DeviceMessageID: "123",
+		QueuedMessageID: "outbox:7",
+		ChatKey:         "channel:0",
+		Direction:       domain.MessageDirectionOut,
+		Body:            "hello",
+		Status:          domain.MessageStatusPending,
+		At:              time.Now().UTC(),
+		MetaJSON:        `{"from":"!00000001"}`,
+	}
+	if err := repo.ReplaceQueued(ctx, sent); err != nil {
+		t.Fatalf("replace queued message: %v", err)
+	}
+
+	loaded, err := repo.ListRecentByChat(ctx, "channel:0", 10)
+	if err != nil {
+		t.Fatalf("load messages: %v", err)
+	}
+	if len(loaded) != 1 {
+		t.Fatalf("expected the queued row to be replaced, got %d messages", len(loaded))
+	}
+	if loaded[0].DeviceMessageID != "123" || loaded[0].MetaJSON != sent.MetaJSON {
+		t.Fatalf("expected sent id and meta on the queued row, got %+v", loaded[0])
+	}
+	if !loaded[0].At.Equal(queuedAt) {
+		t.Fatalf("expected the queued time to stay, got %v", loaded[0].At)
+	}
+
+	sent.DeviceMessageID = "124"
+	sent.QueuedMessageID = "outbox:8"
+	if err := repo.ReplaceQueued(ctx, sent); err != nil {
+		t.Fatalf("replace missing queued message: %v", err)
+	}
+	count, err := repo.CountByChat(ctx, "channel:0")
+	if err != nil {
+		t.Fatalf("count messages: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected a missing queued row to insert the message, got %d messages", count)
+	}
+}
+
+func TestMessageRepoDelete_RemovesOnlyTargetMessage(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewMessageRepo(db)
+	now := time.Now().UTC().Truncate(time.Second)
+	messages := []domain.ChatMessage{
+		{DeviceMessageID: "100", ChatKey: "channel:0", Direction: domain.MessageDirectionIn, Body: "keep", At: now},
+		{DeviceMessageID: "101", ChatKey: "channel:0", Direction: domain.MessageDirectionIn, Body: "drop by id", At: now.Add(time.Second)},
+		{ChatKey: "channel:0", Direction: domain.MessageDirectionOut, Body: "drop by content", At: now.Add(2 * time.Second)},
+		{ChatKey: "channel:0", Direction: domain.MessageDirectionOut, Body: "keep too", At: now.Add(3 * time.Second)},
+	}
+	for _, m := range messages {
+		if _, err := repo.Insert(ctx, m); err != nil {
+			t.Fatalf("insert message %q: %v", m.Body, err)
+		}
+	}
+
+	if err := repo.Delete(ctx, messages[1]); err != nil {
+		t.Fatalf("delete by id: %v", err)
+	}
+	if err := repo.Delete(ctx, messages[2]); err != nil {
+		t.Fatalf("delete by content: %v", err)
+	}
+
+	loaded, err := repo.ListRecentByChat(ctx, "channel:0", 10)
+	if err != nil {
+		t.Fatalf("load messages: %v", err)
+	}
+	bodies := make([]string, 0, len(loaded))
+	for _, m := range loaded {
+		bodies = append(bodies, m.Body)
+	}
+	if want := []string{"keep", "keep too"}; !slices.Equal(bodies, want) {
+		t.Fatalf("expected %v, got %v", want, bodies)
+	}
+}
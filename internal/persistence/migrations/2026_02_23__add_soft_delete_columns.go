@@ -0,0 +1,15 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV15AddSoftDeleteColumns(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`ALTER TABLE chats ADD COLUMN deleted_at INTEGER NULL;`,
+		`ALTER TABLE nodes ADD COLUMN deleted_at INTEGER NULL;`,
+	}
+
+	return applyStatements(ctx, tx, "v15 add soft delete columns", statements)
+}
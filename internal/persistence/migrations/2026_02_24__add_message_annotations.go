@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV16AddMessageAnnotations(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS message_annotations (
+			chat_key TEXT NOT NULL,
+			device_message_id TEXT NOT NULL,
+			starred INTEGER NOT NULL DEFAULT 0,
+			tags_json TEXT NULL,
+			updated_at INTEGER NOT NULL,
+			PRIMARY KEY (chat_key, device_message_id)
+		);`,
+	}
+
+	return applyStatements(ctx, tx, "v16 add message annotations", statements)
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV17AddNodePositionTracks(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS node_position_tracks (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			node_id TEXT NOT NULL,
+			latitude REAL NOT NULL,
+			longitude REAL NOT NULL,
+			altitude INTEGER NULL,
+			position_at INTEGER NULL,
+			observed_at INTEGER NOT NULL,
+			source TEXT NOT NULL,
+			FOREIGN KEY(node_id) REFERENCES nodes(node_id) ON DELETE CASCADE
+		);`,
+		`CREATE INDEX IF NOT EXISTS node_position_tracks_node_observed_idx ON node_position_tracks(node_id, observed_at, id);`,
+		`INSERT INTO node_position_tracks(node_id, latitude, longitude, altitude, position_at, observed_at, source)
+		 SELECT node_id, latitude, longitude, altitude, position_updated_at, observed_at, update_type
+		 FROM node_position_history
+		 WHERE latitude IS NOT NULL AND longitude IS NOT NULL
+		 ORDER BY observed_at, id;`,
+	}
+
+	return applyStatements(ctx, tx, "v17 add node position tracks", statements)
+}
@@ -0,0 +1,241 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Column lists shared by the copy statements below, without device_id.
+const (
+	v18NodeColumns       = `node_id, long_name, short_name, public_key, channel, board_model, firmware_version, device_role, is_favorite, is_unmessageable, last_heard_at, rssi, snr, updated_at, deleted_at`
+	v18PositionColumns   = `node_id, channel, latitude, longitude, altitude, precision_bits, position_updated_at, observed_at, written_at, update_type, from_packet`
+	v18TelemetryColumns  = `node_id, channel, battery_level, voltage, uptime_seconds, channel_utilization, air_util_tx, temperature, humidity, pressure, soil_temperature, soil_moisture, gas_resistance, lux, uv_lux, radiation, air_quality_index, power_voltage, power_current, observed_at, written_at, update_type, from_packet`
+	v18IdentityColumns   = `node_id, long_name, short_name, public_key, observed_at, written_at, update_type, from_packet`
+	v18TrackColumns      = `node_id, latitude, longitude, altitude, position_at, observed_at, source`
+	v18ChatColumns       = `chat_key, type, title, last_sent_by_me_at, updated_at, deleted_at`
+	v18AnnotationColumns = `chat_key, device_message_id, starred, tags_json, updated_at`
+)
+
+// migrateV18AddDeviceNamespaces keys mesh data by the node ID of the radio it came from.
+// Existing rows land in the empty namespace, which the first device to connect adopts.
+func migrateV18AddDeviceNamespaces(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`CREATE TABLE device_namespaces (
+			device_id TEXT PRIMARY KEY,
+			first_connected_at INTEGER NOT NULL,
+			last_connected_at INTEGER NOT NULL
+		);`,
+
+		// Renaming nodes first repoints the old child tables at nodes_legacy.
+		`ALTER TABLE nodes RENAME TO nodes_legacy;`,
+		`ALTER TABLE node_position_latest RENAME TO node_position_latest_legacy;`,
+		`ALTER TABLE node_position_history RENAME TO node_position_history_legacy;`,
+		`ALTER TABLE node_position_tracks RENAME TO node_position_tracks_legacy;`,
+		`ALTER TABLE node_telemetry_latest RENAME TO node_telemetry_latest_legacy;`,
+		`ALTER TABLE node_telemetry_history RENAME TO node_telemetry_history_legacy;`,
+		`ALTER TABLE node_identity_history RENAME TO node_identity_history_legacy;`,
+		`ALTER TABLE chats RENAME TO chats_legacy;`,
+		`ALTER TABLE message_annotations RENAME TO message_annotations_legacy;`,
+		`DROP INDEX IF EXISTS nodes_last_heard_at_idx;`,
+		`DROP INDEX IF EXISTS node_position_history_node_observed_idx;`,
+		`DROP INDEX IF EXISTS node_position_tracks_node_observed_idx;`,
+		`DROP INDEX IF EXISTS node_telemetry_history_node_observed_idx;`,
+		`DROP INDEX IF EXISTS node_identity_history_node_observed_idx;`,
+		`DROP INDEX IF EXISTS chats_last_sent_by_me_idx;`,
+
+		`CREATE TABLE nodes (
+			device_id TEXT NOT NULL DEFAULT '',
+			node_id TEXT NOT NULL,
+			long_name TEXT,
+			short_name TEXT,
+			public_key BLOB NULL,
+			channel INTEGER NULL,
+			board_model TEXT NULL,
+			firmware_version TEXT NULL,
+			device_role TEXT NULL,
+			is_favorite INTEGER NULL,
+			is_unmessageable INTEGER NULL,
+			last_heard_at INTEGER NOT NULL,
+			rssi INTEGER NULL,
+			snr REAL NULL,
+			updated_at INTEGER NOT NULL,
+			deleted_at INTEGER NULL,
+			PRIMARY KEY (device_id, node_id)
+		);`,
+		`CREATE INDEX nodes_last_heard_at_idx ON nodes(device_id, last_heard_at DESC);`,
+		`INSERT INTO nodes(` + v18NodeColumns + `) SELECT ` + v18NodeColumns + ` FROM nodes_legacy;`,
+
+		`CREATE TABLE node_position_latest (
+			device_id TEXT NOT NULL DEFAULT '',
+			node_id TEXT NOT NULL,
+			channel INTEGER NULL,
+			latitude REAL NULL,
+			longitude REAL NULL,
+			altitude INTEGER NULL,
+			precision_bits INTEGER NULL,
+			position_updated_at INTEGER NULL,
+			observed_at INTEGER NOT NULL,
+			written_at INTEGER NOT NULL,
+			update_type TEXT NOT NULL,
+			from_packet INTEGER NOT NULL,
+			PRIMARY KEY (device_id, node_id),
+			FOREIGN KEY(device_id, node_id) REFERENCES nodes(device_id, node_id) ON DELETE CASCADE ON UPDATE CASCADE
+		);`,
+		`INSERT INTO node_position_latest(` + v18PositionColumns + `) SELECT ` + v18PositionColumns + ` FROM node_position_latest_legacy;`,
+
+		`CREATE TABLE node_position_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			device_id TEXT NOT NULL DEFAULT '',
+			node_id TEXT NOT NULL,
+			channel INTEGER NULL,
+			latitude REAL NULL,
+			longitude REAL NULL,
+			altitude INTEGER NULL,
+			precision_bits INTEGER NULL,
+			position_updated_at INTEGER NULL,
+			observed_at INTEGER NOT NULL,
+			written_at INTEGER NOT NULL,
+			update_type TEXT NOT NULL,
+			from_packet INTEGER NOT NULL,
+			FOREIGN KEY(device_id, node_id) REFERENCES nodes(device_id, node_id) ON DELETE CASCADE ON UPDATE CASCADE
+		);`,
+		`CREATE INDEX node_position_history_node_observed_idx ON node_position_history(device_id, node_id, observed_at DESC, id DESC);`,
+		`INSERT INTO node_position_history(id, ` + v18PositionColumns + `) SELECT id, ` + v18PositionColumns + ` FROM node_position_history_legacy;`,
+
+		`CREATE TABLE node_position_tracks (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			device_id TEXT NOT NULL DEFAULT '',
+			node_id TEXT NOT NULL,
+			latitude REAL NOT NULL,
+			longitude REAL NOT NULL,
+			altitude INTEGER NULL,
+			position_at INTEGER NULL,
+			observed_at INTEGER NOT NULL,
+			source TEXT NOT NULL,
+			FOREIGN KEY(device_id, node_id) REFERENCES nodes(device_id, node_id) ON DELETE CASCADE ON UPDATE CASCADE
+		);`,
+		`CREATE INDEX node_position_tracks_node_observed_idx ON node_position_tracks(device_id, node_id, observed_at, id);`,
+		`INSERT INTO node_position_tracks(id, ` + v18TrackColumns + `) SELECT id, ` + v18TrackColumns + ` FROM node_position_tracks_legacy;`,
+
+		`CREATE TABLE node_telemetry_latest (
+			device_id TEXT NOT NULL DEFAULT '',
+			node_id TEXT NOT NULL,
+			channel INTEGER NULL,
+			battery_level INTEGER NULL,
+			voltage REAL NULL,
+			uptime_seconds INTEGER NULL,
+			channel_utilization REAL NULL,
+			air_util_tx REAL NULL,
+			temperature REAL NULL,
+			humidity REAL NULL,
+			pressure REAL NULL,
+			soil_temperature REAL NULL,
+			soil_moisture INTEGER NULL,
+			gas_resistance REAL NULL,
+			lux REAL NULL,
+			uv_lux REAL NULL,
+			radiation REAL NULL,
+			air_quality_index REAL NULL,
+			power_voltage REAL NULL,
+			power_current REAL NULL,
+			observed_at INTEGER NOT NULL,
+			written_at INTEGER NOT NULL,
+			update_type TEXT NOT NULL,
+			from_packet INTEGER NOT NULL,
+			PRIMARY KEY (device_id, node_id),
+			FOREIGN KEY(device_id, node_id) REFERENCES nodes(device_id, node_id) ON DELETE CASCADE ON UPDATE CASCADE
+		);`,
+		`INSERT INTO node_telemetry_latest(` + v18TelemetryColumns + `) SELECT ` + v18TelemetryColumns + ` FROM node_telemetry_latest_legacy;`,
+
+		`CREATE TABLE node_telemetry_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			device_id TEXT NOT NULL DEFAULT '',
+			node_id TEXT NOT NULL,
+			channel INTEGER NULL,
+			battery_level INTEGER NULL,
+			voltage REAL NULL,
+			uptime_seconds INTEGER NULL,
+			channel_utilization REAL NULL,
+			air_util_tx REAL NULL,
+			temperature REAL NULL,
+			humidity REAL NULL,
+			pressure REAL NULL,
+			soil_temperature REAL NULL,
+			soil_moisture INTEGER NULL,
+			gas_resistance REAL NULL,
+			lux REAL NULL,
+			uv_lux REAL NULL,
+			radiation REAL NULL,
+			air_quality_index REAL NULL,
+			power_voltage REAL NULL,
+			power_current REAL NULL,
+			observed_at INTEGER NOT NULL,
+			written_at INTEGER NOT NULL,
+			update_type TEXT NOT NULL,
+			from_packet INTEGER NOT NULL,
+			FOREIGN KEY(device_id, node_id) REFERENCES nodes(device_id, node_id) ON DELETE CASCADE ON UPDATE CASCADE
+		);`,
+		`CREATE INDEX node_telemetry_history_node_observed_idx ON node_telemetry_history(device_id, node_id, observed_at DESC, id DESC);`,
+		`INSERT INTO node_telemetry_history(id, ` + v18TelemetryColumns + `) SELECT id, ` + v18TelemetryColumns + ` FROM node_telemetry_history_legacy;`,
+
+		`CREATE TABLE node_identity_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			device_id TEXT NOT NULL DEFAULT '',
+			node_id TEXT NOT NULL,
+			long_name TEXT NULL,
+			short_name TEXT NULL,
+			public_key BLOB NULL,
+			observed_at INTEGER NOT NULL,
+			written_at INTEGER NOT NULL,
+			update_type TEXT NOT NULL,
+			from_packet INTEGER NOT NULL,
+			FOREIGN KEY(device_id, node_id) REFERENCES nodes(device_id, node_id) ON DELETE CASCADE ON UPDATE CASCADE
+		);`,
+		`CREATE INDEX node_identity_history_node_observed_idx ON node_identity_history(device_id, node_id, observed_at DESC, id DESC);`,
+		`INSERT INTO node_identity_history(id, ` + v18IdentityColumns + `) SELECT id, ` + v18IdentityColumns + ` FROM node_identity_history_legacy;`,
+
+		`CREATE TABLE chats (
+			device_id TEXT NOT NULL DEFAULT '',
+			chat_key TEXT NOT NULL,
+			type INTEGER NOT NULL,
+			title TEXT NOT NULL,
+			last_sent_by_me_at INTEGER NULL,
+			updated_at INTEGER NOT NULL,
+			deleted_at INTEGER NULL,
+			PRIMARY KEY (device_id, chat_key)
+		);`,
+		`CREATE INDEX chats_last_sent_by_me_idx ON chats(device_id, last_sent_by_me_at DESC);`,
+		`INSERT INTO chats(` + v18ChatColumns + `) SELECT ` + v18ChatColumns + ` FROM chats_legacy;`,
+
+		`CREATE TABLE message_annotations (
+			device_id TEXT NOT NULL DEFAULT '',
+			chat_key TEXT NOT NULL,
+			device_message_id TEXT NOT NULL,
+			starred INTEGER NOT NULL DEFAULT 0,
+			tags_json TEXT NULL,
+			updated_at INTEGER NOT NULL,
+			PRIMARY KEY (device_id, chat_key, device_message_id)
+		);`,
+		`INSERT INTO message_annotations(` + v18AnnotationColumns + `) SELECT ` + v18AnnotationColumns + ` FROM message_annotations_legacy;`,
+
+		`ALTER TABLE messages ADD COLUMN device_id TEXT NOT NULL DEFAULT '';`,
+		`DROP INDEX IF EXISTS messages_chat_at_idx;`,
+		`DROP INDEX IF EXISTS messages_chat_device_unique_idx;`,
+		`CREATE INDEX messages_chat_at_idx ON messages(device_id, chat_key, at ASC);`,
+		`CREATE UNIQUE INDEX messages_chat_device_unique_idx ON messages(device_id, chat_key, device_message_id) WHERE device_message_id IS NOT NULL;`,
+		`ALTER TABLE traceroutes ADD COLUMN device_id TEXT NOT NULL DEFAULT '';`,
+
+		// Children go first, nothing references nodes_legacy once they are gone.
+		`DROP TABLE node_position_latest_legacy;`,
+		`DROP TABLE node_position_history_legacy;`,
+		`DROP TABLE node_position_tracks_legacy;`,
+		`DROP TABLE node_telemetry_latest_legacy;`,
+		`DROP TABLE node_telemetry_history_legacy;`,
+		`DROP TABLE node_identity_history_legacy;`,
+		`DROP TABLE nodes_legacy;`,
+		`DROP TABLE chats_legacy;`,
+		`DROP TABLE message_annotations_legacy;`,
+	}
+
+	return applyStatements(ctx, tx, "v18 add device namespaces", statements)
+}
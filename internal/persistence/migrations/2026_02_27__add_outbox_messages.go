@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV19AddOutboxMessages(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS outbox_messages (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			device_id TEXT NOT NULL DEFAULT '',
+			chat_key TEXT NOT NULL,
+			body TEXT NOT NULL,
+			reply_to_device_message_id TEXT NULL,
+			emoji INTEGER NOT NULL DEFAULT 0,
+			status TEXT NOT NULL,
+			attempts INTEGER NOT NULL DEFAULT 0,
+			last_error TEXT NULL,
+			created_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL
+		);`,
+		`CREATE INDEX IF NOT EXISTS outbox_messages_device_status_idx ON outbox_messages(device_id, status, id);`,
+	}
+
+	return applyStatements(ctx, tx, "v19 add outbox messages", statements)
+}
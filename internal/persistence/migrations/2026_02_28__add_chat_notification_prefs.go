@@ -0,0 +1,16 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV20AddChatNotificationPrefs(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`ALTER TABLE chats ADD COLUMN muted INTEGER NOT NULL DEFAULT 0;`,
+		`ALTER TABLE chats ADD COLUMN muted_until INTEGER NULL;`,
+		`ALTER TABLE chats ADD COLUMN notify_mentions_only INTEGER NOT NULL DEFAULT 0;`,
+	}
+
+	return applyStatements(ctx, tx, "v20 add chat notification prefs", statements)
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV21AddMessagePins(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS message_pins (
+			device_id TEXT NOT NULL DEFAULT '',
+			chat_key TEXT NOT NULL,
+			device_message_id TEXT NOT NULL,
+			pinned_at INTEGER NOT NULL,
+			PRIMARY KEY (device_id, chat_key, device_message_id)
+		);`,
+	}
+
+	return applyStatements(ctx, tx, "v21 add message pins", statements)
+}
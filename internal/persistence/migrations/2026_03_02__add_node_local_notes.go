@@ -0,0 +1,15 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV22AddNodeLocalNotes(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`ALTER TABLE nodes ADD COLUMN local_alias TEXT NULL;`,
+		`ALTER TABLE nodes ADD COLUMN local_note TEXT NULL;`,
+	}
+
+	return applyStatements(ctx, tx, "v22 add node local notes", statements)
+}
@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV23AddNodeSignalHistory(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS node_signal_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			device_id TEXT NOT NULL DEFAULT '',
+			node_id TEXT NOT NULL,
+			rssi INTEGER NULL,
+			snr REAL NULL,
+			observed_at INTEGER NOT NULL,
+			FOREIGN KEY(device_id, node_id) REFERENCES nodes(device_id, node_id) ON DELETE CASCADE ON UPDATE CASCADE
+		);`,
+		`CREATE INDEX IF NOT EXISTS node_signal_history_node_observed_idx ON node_signal_history(device_id, node_id, observed_at DESC, id DESC);`,
+	}
+
+	return applyStatements(ctx, tx, "v23 add node signal history", statements)
+}
@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV24AddRawPacketLog(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS raw_packet_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			direction TEXT NOT NULL,
+			payload BLOB NOT NULL,
+			logged_at INTEGER NOT NULL
+		);`,
+	}
+
+	return applyStatements(ctx, tx, "v24 add raw packet log", statements)
+}
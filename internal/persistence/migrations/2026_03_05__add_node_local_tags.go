@@ -0,0 +1,14 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV25AddNodeLocalTags(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`ALTER TABLE nodes ADD COLUMN local_tags_json TEXT NULL;`,
+	}
+
+	return applyStatements(ctx, tx, "v25 add node local tags", statements)
+}
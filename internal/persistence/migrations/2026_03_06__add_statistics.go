@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV26AddStatistics(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`CREATE TABLE IF NOT EXISTS stats_chat_daily (
+			device_id TEXT NOT NULL DEFAULT '',
+			chat_key TEXT NOT NULL,
+			day TEXT NOT NULL,
+			incoming INTEGER NOT NULL DEFAULT 0,
+			outgoing INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (device_id, chat_key, day)
+		);`,
+		`CREATE INDEX IF NOT EXISTS stats_chat_daily_day_idx ON stats_chat_daily(device_id, day);`,
+		`CREATE TABLE IF NOT EXISTS stats_node_packets (
+			device_id TEXT NOT NULL DEFAULT '',
+			node_id TEXT NOT NULL,
+			packets INTEGER NOT NULL DEFAULT 0,
+			last_packet_at INTEGER NOT NULL,
+			PRIMARY KEY (device_id, node_id)
+		);`,
+		`CREATE TABLE IF NOT EXISTS stats_hourly (
+			device_id TEXT NOT NULL DEFAULT '',
+			hour INTEGER NOT NULL,
+			packets INTEGER NOT NULL DEFAULT 0,
+			messages INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY (device_id, hour)
+		);`,
+	}
+
+	return applyStatements(ctx, tx, "v26 add statistics", statements)
+}
@@ -0,0 +1,14 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV27AddNodeIgnoredFlag(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`ALTER TABLE nodes ADD COLUMN is_ignored INTEGER NULL;`,
+	}
+
+	return applyStatements(ctx, tx, "v27 add node ignored flag", statements)
+}
@@ -0,0 +1,14 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV28AddNodeHopsAway(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`ALTER TABLE nodes ADD COLUMN hops_away INTEGER NULL;`,
+	}
+
+	return applyStatements(ctx, tx, "v28 add node hops away", statements)
+}
@@ -0,0 +1,14 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV29AddNodeViaMQTT(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`ALTER TABLE nodes ADD COLUMN via_mqtt INTEGER NULL;`,
+	}
+
+	return applyStatements(ctx, tx, "v29 add node via mqtt", statements)
+}
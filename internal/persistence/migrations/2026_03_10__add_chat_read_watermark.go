@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+)
+
+func migrateV30AddChatReadWatermark(ctx context.Context, tx *sql.Tx) error {
+	statements := []string{
+		`ALTER TABLE chats ADD COLUMN read_up_to INTEGER NULL;`,
+		// Chats used to start read on every launch, so existing ones keep doing so once.
+		`UPDATE chats SET read_up_to = (
+			SELECT MAX(messages.at) FROM messages
+			WHERE messages.device_id = chats.device_id
+				AND messages.chat_key = chats.chat_key
+				AND messages.direction = 1
+		);`,
+	}
+
+	return applyStatements(ctx, tx, "v30 add chat read watermark", statements)
+}
@@ -7,7 +7,7 @@ import (
 	"log/slog"
 )
 
-const targetSchemaVersion = 14
+const targetSchemaVersion = 30
 
 type migrationStep struct {
 	version int
@@ -30,6 +30,22 @@ var schemaMigrations = []migrationStep{
 	{version: 12, name: "split_node_secondary_metadata", apply: migrateV12SplitNodeSecondaryMetadata},
 	{version: 13, name: "add_extended_environment_telemetry", apply: migrateV13AddExtendedEnvironmentTelemetry},
 	{version: 14, name: "add_node_favorite_flag", apply: migrateV14AddNodeFavoriteFlag},
+	{version: 15, name: "add_soft_delete_columns", apply: migrateV15AddSoftDeleteColumns},
+	{version: 16, name: "add_message_annotations", apply: migrateV16AddMessageAnnotations},
+	{version: 17, name: "add_node_position_tracks", apply: migrateV17AddNodePositionTracks},
+	{version: 18, name: "add_device_namespaces", apply: migrateV18AddDeviceNamespaces},
+	{version: 19, name: "add_outbox_messages", apply: migrateV19AddOutboxMessages},
+	{version: 20, name: "add_chat_notification_prefs", apply: migrateV20AddChatNotificationPrefs},
+	{version: 21, name: "add_message_pins", apply: migrateV21AddMessagePins},
+	{version: 22, name: "add_node_local_notes", apply: migrateV22AddNodeLocalNotes},
+	{version: 23, name: "add_node_signal_history", apply: migrateV23AddNodeSignalHistory},
+	{version: 24, name: "add_raw_packet_log", apply: migrateV24AddRawPacketLog},
+	{version: 25, name: "add_node_local_tags", apply: migrateV25AddNodeLocalTags},
+	{version: 26, name: "add_statistics", apply: migrateV26AddStatistics},
+	{version: 27, name: "add_node_ignored_flag", apply: migrateV27AddNodeIgnoredFlag},
+	{version: 28, name: "add_node_hops_away", apply: migrateV28AddNodeHopsAway},
+	{version: 29, name: "add_node_via_mqtt", apply: migrateV29AddNodeViaMQTT},
+	{version: 30, name: "add_chat_read_watermark", apply: migrateV30AddChatReadWatermark},
 }
 
 func Apply(ctx context.Context, db *sql.DB) error {
@@ -94,6 +110,16 @@ func applyMigration(ctx context.Context, db *sql.DB, migration migrationStep) er
 	return nil
 }
 
+// TargetVersion returns the schema version Apply migrates databases to.
+func TargetVersion() int {
+	return targetSchemaVersion
+}
+
+// Version returns the schema version recorded in db without migrating it.
+func Version(ctx context.Context, db *sql.DB) (int, error) {
+	return readSchemaVersion(ctx, db)
+}
+
 func readSchemaVersion(ctx context.Context, db *sql.DB) (int, error) {
 	var version int
 	if err := db.QueryRowContext(ctx, `PRAGMA user_version;`).Scan(&version); err != nil {
@@ -13,6 +13,7 @@ import (
 
 // NodeCoreRepo persists and queries node core identity/activity snapshots.
 type NodeCoreRepo struct {
+	deviceScoped
 	db *sql.DB
 }
 
@@ -28,8 +29,9 @@ func (r *NodeCoreRepo) Upsert(ctx context.Context, update domain.NodeCoreUpdate,
 	if nodeID == "" {
 		return nil
 	}
+	deviceID := r.deviceID(ctx)
 
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := beginWriteTx(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("begin node core upsert tx: %w", err)
 	}
@@ -37,7 +39,7 @@ func (r *NodeCoreRepo) Upsert(ctx context.Context, update domain.NodeCoreUpdate,
 		_ = tx.Rollback()
 	}()
 
-	prev, prevFound, err := fetchNodeIdentitySnapshot(ctx, tx, nodeID)
+	prev, prevFound, err := fetchNodeIdentitySnapshot(ctx, tx.Tx, deviceID, nodeID)
 	if err != nil {
 		return err
 	}
@@ -48,6 +50,7 @@ func (r *NodeCoreRepo) Upsert(ctx context.Context, update domain.NodeCoreUpdate,
 		// NODEINFO packet user payload does not provide authoritative favorite
 		// state; keep stored value until a NodeInfoSnapshot arrives.
 		core.IsFavorite = nil
+		core.IsIgnored = nil
 	}
 	if core.UpdatedAt.IsZero() {
 		core.UpdatedAt = writtenAt
@@ -57,7 +60,10 @@ func (r *NodeCoreRepo) Upsert(ctx context.Context, update domain.NodeCoreUpdate,
 		publicKey       any
 		channel         any
 		isFavorite      any
+		isIgnored       any
 		isUnmessageable any
+		hopsAway        any
+		viaMQTT         any
 		rssi            any
 		snr             any
 	)
@@ -74,6 +80,13 @@ func (r *NodeCoreRepo) Upsert(ctx context.Context, update domain.NodeCoreUpdate,
 			isFavorite = int64(0)
 		}
 	}
+	if core.IsIgnored != nil {
+		if *core.IsIgnored {
+			isIgnored = int64(1)
+		} else {
+			isIgnored = int64(0)
+		}
+	}
 	if core.IsUnmessageable != nil {
 		if *core.IsUnmessageable {
 			isUnmessageable = int64(1)
@@ -81,6 +94,16 @@ func (r *NodeCoreRepo) Upsert(ctx context.Context, update domain.NodeCoreUpdate,
 			isUnmessageable = int64(0)
 		}
 	}
+	if core.HopsAway != nil {
+		hopsAway = int64(*core.HopsAway)
+	}
+	if core.ViaMQTT != nil {
+		if *core.ViaMQTT {
+			viaMQTT = int64(1)
+		} else {
+			viaMQTT = int64(0)
+		}
+	}
 	if core.RSSI != nil {
 		rssi = *core.RSSI
 	}
@@ -89,9 +112,9 @@ func (r *NodeCoreRepo) Upsert(ctx context.Context, update domain.NodeCoreUpdate,
 	}
 
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO nodes(node_id, long_name, short_name, public_key, channel, board_model, firmware_version, device_role, is_favorite, is_unmessageable, last_heard_at, rssi, snr, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-		ON CONFLICT(node_id) DO UPDATE SET
+		INSERT INTO nodes(device_id, node_id, long_name, short_name, public_key, channel, board_model, firmware_version, device_role, is_favorite, is_ignored, is_unmessageable, hops_away, via_mqtt, last_heard_at, rssi, snr, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(device_id, node_id) DO UPDATE SET
 			long_name = CASE
 				WHEN excluded.long_name IS NOT NULL AND excluded.long_name <> '' THEN excluded.long_name
 				ELSE nodes.long_name
@@ -118,18 +141,27 @@ func (r *NodeCoreRepo) Upsert(ctx context.Context, update domain.NodeCoreUpdate,
 				ELSE nodes.device_role
 			END,
 			is_favorite = COALESCE(excluded.is_favorite, nodes.is_favorite),
+			is_ignored = COALESCE(excluded.is_ignored, nodes.is_ignored),
 			is_unmessageable = COALESCE(excluded.is_unmessageable, nodes.is_unmessageable),
+			hops_away = COALESCE(excluded.hops_away, nodes.hops_away),
+			via_mqtt = COALESCE(excluded.via_mqtt, nodes.via_mqtt),
 			last_heard_at = CASE
 				WHEN excluded.last_heard_at > nodes.last_heard_at THEN excluded.last_heard_at
 				ELSE nodes.last_heard_at
 			END,
 			rssi = COALESCE(excluded.rssi, nodes.rssi),
 			snr = COALESCE(excluded.snr, nodes.snr),
+			-- A deleted node comes back once it is heard after the deletion.
+			deleted_at = CASE
+				WHEN excluded.last_heard_at > COALESCE(nodes.deleted_at, 0) THEN NULL
+				ELSE nodes.deleted_at
+			END,
 			updated_at = CASE
 				WHEN excluded.updated_at > nodes.updated_at THEN excluded.updated_at
 				ELSE nodes.updated_at
 			END
 	`,
+		deviceID,
 		nodeID,
 		core.LongName,
 		core.ShortName,
@@ -139,7 +171,10 @@ func (r *NodeCoreRepo) Upsert(ctx context.Context, update domain.NodeCoreUpdate,
 		nullableString(core.FirmwareVersion),
 		nullableString(core.Role),
 		isFavorite,
+		isIgnored,
 		isUnmessageable,
+		hopsAway,
+		viaMQTT,
 		timeToUnixMillis(core.LastHeardAt),
 		rssi,
 		snr,
@@ -150,7 +185,7 @@ func (r *NodeCoreRepo) Upsert(ctx context.Context, update domain.NodeCoreUpdate,
 	}
 
 	if isReliableIdentityUpdateSource(update.Type) {
-		next, nextFound, fetchErr := fetchNodeIdentitySnapshot(ctx, tx, nodeID)
+		next, nextFound, fetchErr := fetchNodeIdentitySnapshot(ctx, tx.Tx, deviceID, nodeID)
 		if fetchErr != nil {
 			return fetchErr
 		}
@@ -163,9 +198,10 @@ func (r *NodeCoreRepo) Upsert(ctx context.Context, update domain.NodeCoreUpdate,
 				observedAt = writtenAt
 			}
 			_, err = tx.ExecContext(ctx, `
-				INSERT INTO node_identity_history(node_id, long_name, short_name, public_key, observed_at, written_at, update_type, from_packet)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+				INSERT INTO node_identity_history(device_id, node_id, long_name, short_name, public_key, observed_at, written_at, update_type, from_packet)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 			`,
+				deviceID,
 				nodeID,
 				nullableString(next.LongName),
 				nullableString(next.ShortName),
@@ -178,7 +214,7 @@ func (r *NodeCoreRepo) Upsert(ctx context.Context, update domain.NodeCoreUpdate,
 			if err != nil {
 				return fmt.Errorf("insert node identity history: %w", err)
 			}
-			if err := pruneHistoryRows(ctx, tx, "node_identity_history", nodeID, identityHistoryLimit); err != nil {
+			if err := pruneHistoryRows(ctx, tx.Tx, "node_identity_history", deviceID, nodeID, identityHistoryLimit); err != nil {
 				return err
 			}
 		}
@@ -193,10 +229,11 @@ func (r *NodeCoreRepo) Upsert(ctx context.Context, update domain.NodeCoreUpdate,
 
 func (r *NodeCoreRepo) ListSortedByLastHeard(ctx context.Context) ([]domain.NodeCore, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT node_id, long_name, short_name, public_key, channel, board_model, firmware_version, device_role, is_favorite, is_unmessageable, last_heard_at, rssi, snr, updated_at
+		SELECT node_id, long_name, short_name, public_key, channel, board_model, firmware_version, device_role, is_favorite, is_ignored, is_unmessageable, hops_away, via_mqtt, last_heard_at, rssi, snr, updated_at, local_alias, local_note, local_tags_json
 		FROM nodes
+		WHERE device_id = ? AND deleted_at IS NULL
 		ORDER BY last_heard_at DESC
-	`)
+	`, r.deviceID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("list node core: %w", err)
 	}
@@ -221,11 +258,11 @@ func (r *NodeCoreRepo) ListSortedByLastHeard(ctx context.Context) ([]domain.Node
 
 func (r *NodeCoreRepo) GetByNodeID(ctx context.Context, nodeID string) (domain.NodeCore, bool, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT node_id, long_name, short_name, public_key, channel, board_model, firmware_version, device_role, is_favorite, is_unmessageable, last_heard_at, rssi, snr, updated_at
+		SELECT node_id, long_name, short_name, public_key, channel, board_model, firmware_version, device_role, is_favorite, is_ignored, is_unmessageable, hops_away, via_mqtt, last_heard_at, rssi, snr, updated_at, local_alias, local_note, local_tags_json
 		FROM nodes
-		WHERE node_id = ?
+		WHERE device_id = ? AND node_id = ?
 		LIMIT 1
-	`, strings.TrimSpace(nodeID))
+	`, r.deviceID(ctx), strings.TrimSpace(nodeID))
 	if err != nil {
 		return domain.NodeCore{}, false, fmt.Errorf("query node core by id: %w", err)
 	}
@@ -243,6 +280,68 @@ func (r *NodeCoreRepo) GetByNodeID(ctx context.Context, nodeID string) (domain.N
 	return item, true, nil
 }
 
+// SetLocalNotes stores the user's alias and note for a node. Radio updates never touch them.
+func (r *NodeCoreRepo) SetLocalNotes(ctx context.Context, nodeID, alias, note string) error {
+	if r == nil || r.db == nil {
+		return fmt.Errorf("node core repo is not initialized")
+	}
+	nodeID = strings.TrimSpace(nodeID)
+	if nodeID == "" {
+		return fmt.Errorf("node id is empty")
+	}
+
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE nodes
+		SET local_alias = ?, local_note = ?
+		WHERE device_id = ? AND node_id = ?
+	`, nullableString(alias), nullableString(note), r.deviceID(ctx), nodeID)
+	if err != nil {
+		return fmt.Errorf("update node local notes: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update node local notes rows affected: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("node %s is not known", nodeID)
+	}
+
+	return nil
+}
+
+// SetLocalTags stores the user's tags for a node. Radio updates never touch them.
+func (r *NodeCoreRepo) SetLocalTags(ctx context.Context, nodeID string, tags []string) error {
+	if r == nil || r.db == nil {
+		return fmt.Errorf("node core repo is not initialized")
+	}
+	nodeID = strings.TrimSpace(nodeID)
+	if nodeID == "" {
+		return fmt.Errorf("node id is empty")
+	}
+	tagsJSON, err := marshalJSONNullable(tags)
+	if err != nil {
+		return fmt.Errorf("marshal node tags: %w", err)
+	}
+
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE nodes
+		SET local_tags_json = ?
+		WHERE device_id = ? AND node_id = ?
+	`, tagsJSON, r.deviceID(ctx), nodeID)
+	if err != nil {
+		return fmt.Errorf("update node local tags: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update node local tags rows affected: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("node %s is not known", nodeID)
+	}
+
+	return nil
+}
+
 func scanNodeCore(scanner interface{ Scan(dest ...any) error }) (domain.NodeCore, error) {
 	var (
 		item          domain.NodeCore
@@ -254,13 +353,19 @@ func scanNodeCore(scanner interface{ Scan(dest ...any) error }) (domain.NodeCore
 		firmware      sql.NullString
 		role          sql.NullString
 		favorite      sql.NullInt64
+		ignored       sql.NullInt64
 		unmessageable sql.NullInt64
+		hopsAway      sql.NullInt64
+		viaMQTT       sql.NullInt64
 		heardMS       int64
 		rssi          sql.NullInt64
 		snr           sql.NullFloat64
 		updatedMS     int64
+		alias         sql.NullString
+		note          sql.NullString
+		tagsRaw       sql.NullString
 	)
-	if err := scanner.Scan(&item.NodeID, &longName, &shortName, &publicKey, &channel, &board, &firmware, &role, &favorite, &unmessageable, &heardMS, &rssi, &snr, &updatedMS); err != nil {
+	if err := scanner.Scan(&item.NodeID, &longName, &shortName, &publicKey, &channel, &board, &firmware, &role, &favorite, &ignored, &unmessageable, &hopsAway, &viaMQTT, &heardMS, &rssi, &snr, &updatedMS, &alias, &note, &tagsRaw); err != nil {
 		return domain.NodeCore{}, fmt.Errorf("scan node core row: %w", err)
 	}
 	if longName.Valid {
@@ -291,10 +396,23 @@ func scanNodeCore(scanner interface{ Scan(dest ...any) error }) (domain.NodeCore
 		v := favorite.Int64 != 0
 		item.IsFavorite = &v
 	}
+	if ignored.Valid {
+		v := ignored.Int64 != 0
+		item.IsIgnored = &v
+	}
 	if unmessageable.Valid {
 		v := unmessageable.Int64 != 0
 		item.IsUnmessageable = &v
 	}
+	if hopsAway.Valid {
+		if v, ok := int64ToUint32(hopsAway.Int64); ok {
+			item.HopsAway = &v
+		}
+	}
+	if viaMQTT.Valid {
+		v := viaMQTT.Int64 != 0
+		item.ViaMQTT = &v
+	}
 	item.LastHeardAt = unixMillisToTime(heardMS)
 	if rssi.Valid {
 		if v, ok := int64ToInt32(rssi.Int64); ok {
@@ -307,6 +425,17 @@ func scanNodeCore(scanner interface{ Scan(dest ...any) error }) (domain.NodeCore
 		item.SNR = &v
 	}
 	item.UpdatedAt = unixMillisToTime(updatedMS)
+	if alias.Valid {
+		item.Alias = alias.String
+	}
+	if note.Valid {
+		item.Note = note.String
+	}
+	tags, err := unmarshalTags(tagsRaw)
+	if err != nil {
+		return domain.NodeCore{}, err
+	}
+	item.Tags = tags
 
 	return item, nil
 }
@@ -317,7 +446,7 @@ type nodeIdentitySnapshot struct {
 	PublicKey []byte
 }
 
-func fetchNodeIdentitySnapshot(ctx context.Context, tx *sql.Tx, nodeID string) (nodeIdentitySnapshot, bool, error) {
+func fetchNodeIdentitySnapshot(ctx context.Context, tx *sql.Tx, deviceID, nodeID string) (nodeIdentitySnapshot, bool, error) {
 	var (
 		value     nodeIdentitySnapshot
 		longName  sql.NullString
@@ -327,9 +456,9 @@ func fetchNodeIdentitySnapshot(ctx context.Context, tx *sql.Tx, nodeID string) (
 	err := tx.QueryRowContext(ctx, `
 		SELECT long_name, short_name, public_key
 		FROM nodes
-		WHERE node_id = ?
+		WHERE device_id = ? AND node_id = ?
 		LIMIT 1
-	`, nodeID).Scan(&longName, &shortName, &publicKey)
+	`, deviceID, nodeID).Scan(&longName, &shortName, &publicKey)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nodeIdentitySnapshot{}, false, nil
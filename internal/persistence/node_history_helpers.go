@@ -44,13 +44,13 @@ func applyHistoryCursor(base string, query domain.NodeHistoryQuery, args []any)
 	return base, args
 }
 
-func pruneHistoryRows(ctx context.Context, tx *sql.Tx, table, nodeID string, limit int) error {
+func pruneHistoryRows(ctx context.Context, tx *sql.Tx, table, deviceID, nodeID string, limit int) error {
 	if limit <= 0 {
 		return nil
 	}
 	safeTable := strings.TrimSpace(table)
 	switch safeTable {
-	case "node_position_history", "node_telemetry_history", "node_identity_history":
+	case "node_position_history", "node_telemetry_history", "node_identity_history", "node_signal_history":
 	default:
 		return fmt.Errorf("unsafe history table name: %q", safeTable)
 	}
@@ -60,11 +60,12 @@ func pruneHistoryRows(ctx context.Context, tx *sql.Tx, table, nodeID string, lim
 			DELETE FROM %s
 			WHERE id IN (
 				SELECT id FROM %s
-				WHERE node_id = ?
+				WHERE device_id = ? AND node_id = ?
 				ORDER BY observed_at DESC, id DESC
 				LIMIT -1 OFFSET ?
 			)
 		`, safeTable, safeTable),
+		deviceID,
 		nodeID,
 		limit,
 	)
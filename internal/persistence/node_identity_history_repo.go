@@ -11,6 +11,7 @@ import (
 
 // NodeIdentityHistoryRepo reads historical node identity snapshots.
 type NodeIdentityHistoryRepo struct {
+	deviceScoped
 	db *sql.DB
 }
 
@@ -24,8 +25,8 @@ func (r *NodeIdentityHistoryRepo) ListHistoryByNodeID(ctx context.Context, query
 		return nil, nil
 	}
 	order := historyOrderSQL(query.Order)
-	where := "WHERE node_id = ?"
-	args := []any{nodeID}
+	where := "WHERE device_id = ? AND node_id = ?"
+	args := []any{r.deviceID(ctx), nodeID}
 	where, args = applyHistoryCursor(where, query, args)
 	limit := historyLimitValue(query.Limit)
 	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
@@ -10,8 +10,9 @@ import (
 	"github.com/skobkin/meshgo/internal/domain"
 )
 
-// NodePositionRepo persists and queries node position snapshots and history.
+// NodePositionRepo persists and queries node position snapshots, history and tracks.
 type NodePositionRepo struct {
+	deviceScoped
 	db *sql.DB
 }
 
@@ -28,6 +29,7 @@ func (r *NodePositionRepo) Upsert(ctx context.Context, update domain.NodePositio
 		return nil
 	}
 	incoming := update.Position
+	deviceID := r.deviceID(ctx)
 
 	writtenAt := time.Now()
 	if incoming.ObservedAt.IsZero() {
@@ -40,7 +42,7 @@ func (r *NodePositionRepo) Upsert(ctx context.Context, update domain.NodePositio
 		incoming.UpdatedAt = writtenAt
 	}
 
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := beginWriteTx(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("begin node position upsert tx: %w", err)
 	}
@@ -49,15 +51,15 @@ func (r *NodePositionRepo) Upsert(ctx context.Context, update domain.NodePositio
 	}()
 
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO nodes(node_id, last_heard_at, updated_at)
-		VALUES (?, ?, ?)
-		ON CONFLICT(node_id) DO NOTHING
-	`, nodeID, timeToUnixMillis(incoming.ObservedAt), timeToUnixMillis(incoming.UpdatedAt))
+		INSERT INTO nodes(device_id, node_id, last_heard_at, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(device_id, node_id) DO NOTHING
+	`, deviceID, nodeID, timeToUnixMillis(incoming.ObservedAt), timeToUnixMillis(incoming.UpdatedAt))
 	if err != nil {
 		return fmt.Errorf("ensure node core row for position: %w", err)
 	}
 
-	existing, found, err := fetchNodePositionLatest(ctx, tx, nodeID)
+	existing, found, err := fetchNodePositionLatest(ctx, tx.Tx, deviceID, nodeID)
 	if err != nil {
 		return err
 	}
@@ -70,9 +72,9 @@ func (r *NodePositionRepo) Upsert(ctx context.Context, update domain.NodePositio
 	}
 
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO node_position_latest(node_id, channel, latitude, longitude, altitude, precision_bits, position_updated_at, observed_at, written_at, update_type, from_packet)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-		ON CONFLICT(node_id) DO UPDATE SET
+		INSERT INTO node_position_latest(device_id, node_id, channel, latitude, longitude, altitude, precision_bits, position_updated_at, observed_at, written_at, update_type, from_packet)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(device_id, node_id) DO UPDATE SET
 			channel = COALESCE(excluded.channel, node_position_latest.channel),
 			latitude = COALESCE(excluded.latitude, node_position_latest.latitude),
 			longitude = COALESCE(excluded.longitude, node_position_latest.longitude),
@@ -89,6 +91,7 @@ func (r *NodePositionRepo) Upsert(ctx context.Context, update domain.NodePositio
 			update_type = excluded.update_type,
 			from_packet = excluded.from_packet
 	`,
+		deviceID,
 		nodeID,
 		nullableUint32(next.Channel),
 		nullableFloat64(next.Latitude),
@@ -107,9 +110,10 @@ func (r *NodePositionRepo) Upsert(ctx context.Context, update domain.NodePositio
 
 	if hasPositionCoordinates(next) && (!found || !nodePositionEqual(existing, next)) {
 		_, err = tx.ExecContext(ctx, `
-			INSERT INTO node_position_history(node_id, channel, latitude, longitude, altitude, precision_bits, position_updated_at, observed_at, written_at, update_type, from_packet)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			INSERT INTO node_position_history(device_id, node_id, channel, latitude, longitude, altitude, precision_bits, position_updated_at, observed_at, written_at, update_type, from_packet)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`,
+			deviceID,
 			nodeID,
 			nullableUint32(next.Channel),
 			nullableFloat64(next.Latitude),
@@ -125,11 +129,31 @@ func (r *NodePositionRepo) Upsert(ctx context.Context, update domain.NodePositio
 		if err != nil {
 			return fmt.Errorf("insert node position history: %w", err)
 		}
-		if err := pruneHistoryRows(ctx, tx, "node_position_history", nodeID, historyLimit); err != nil {
+		if err := pruneHistoryRows(ctx, tx.Tx, "node_position_history", deviceID, nodeID, historyLimit); err != nil {
 			return err
 		}
 	}
 
+	// Tracks keep every received position, so only coordinates from this update count.
+	if hasPositionCoordinates(incoming) {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO node_position_tracks(device_id, node_id, latitude, longitude, altitude, position_at, observed_at, source)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		`,
+			deviceID,
+			nodeID,
+			*incoming.Latitude,
+			*incoming.Longitude,
+			nullableInt32(incoming.Altitude),
+			nullableTime(incoming.PositionUpdatedAt),
+			timeToUnixMillis(incoming.ObservedAt),
+			string(update.Type),
+		)
+		if err != nil {
+			return fmt.Errorf("insert node position track point: %w", err)
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("commit node position upsert tx: %w", err)
 	}
@@ -141,7 +165,8 @@ func (r *NodePositionRepo) ListLatest(ctx context.Context) ([]domain.NodePositio
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT node_id, channel, latitude, longitude, altitude, precision_bits, position_updated_at, observed_at, written_at
 		FROM node_position_latest
-	`)
+		WHERE device_id = ?1 AND node_id NOT IN (SELECT node_id FROM nodes WHERE device_id = ?1 AND deleted_at IS NOT NULL)
+	`, r.deviceID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("list node position latest: %w", err)
 	}
@@ -167,9 +192,9 @@ func (r *NodePositionRepo) GetLatestByNodeID(ctx context.Context, nodeID string)
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT node_id, channel, latitude, longitude, altitude, precision_bits, position_updated_at, observed_at, written_at
 		FROM node_position_latest
-		WHERE node_id = ?
+		WHERE device_id = ? AND node_id = ?
 		LIMIT 1
-	`, strings.TrimSpace(nodeID))
+	`, r.deviceID(ctx), strings.TrimSpace(nodeID))
 	if err != nil {
 		return domain.NodePosition{}, false, fmt.Errorf("query node position latest by id: %w", err)
 	}
@@ -193,8 +218,8 @@ func (r *NodePositionRepo) ListHistoryByNodeID(ctx context.Context, query domain
 		return nil, nil
 	}
 	order := historyOrderSQL(query.Order)
-	where := "WHERE node_id = ?"
-	args := []any{nodeID}
+	where := "WHERE device_id = ? AND node_id = ?"
+	args := []any{r.deviceID(ctx), nodeID}
 	where, args = applyHistoryCursor(where, query, args)
 	limit := historyLimitValue(query.Limit)
 	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
@@ -226,13 +251,79 @@ func (r *NodePositionRepo) ListHistoryByNodeID(ctx context.Context, query domain
 	return out, nil
 }
 
-func fetchNodePositionLatest(ctx context.Context, tx *sql.Tx, nodeID string) (domain.NodePosition, bool, error) {
+// ListTrack returns track points of a node in the order they were observed. Without a
+// limit the whole range is returned.
+func (r *NodePositionRepo) ListTrack(ctx context.Context, query domain.NodeTrackQuery) ([]domain.NodeTrackPoint, error) {
+	nodeID := strings.TrimSpace(query.NodeID)
+	if nodeID == "" {
+		return nil, nil
+	}
+	where := "WHERE device_id = ? AND node_id = ?"
+	args := []any{r.deviceID(ctx), nodeID}
+	if !query.From.IsZero() {
+		where += " AND observed_at >= ?"
+		args = append(args, timeToUnixMillis(query.From))
+	}
+	if !query.To.IsZero() {
+		where += " AND observed_at <= ?"
+		args = append(args, timeToUnixMillis(query.To))
+	}
+	limit := -1
+	if query.Limit > 0 {
+		limit = query.Limit
+	}
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT node_id, latitude, longitude, altitude, position_at, observed_at, source
+		FROM node_position_tracks
+		%s
+		ORDER BY observed_at ASC, id ASC
+		LIMIT ?
+	`, where), append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("list node position track: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	out := make([]domain.NodeTrackPoint, 0)
+	for rows.Next() {
+		var (
+			item       domain.NodeTrackPoint
+			altitude   sql.NullInt64
+			positionMS sql.NullInt64
+			observedMS int64
+			source     string
+		)
+		if err := rows.Scan(&item.NodeID, &item.Latitude, &item.Longitude, &altitude, &positionMS, &observedMS, &source); err != nil {
+			return nil, fmt.Errorf("scan node position track row: %w", err)
+		}
+		if altitude.Valid {
+			if v, ok := int64ToInt32(altitude.Int64); ok {
+				item.Altitude = &v
+			}
+		}
+		if positionMS.Valid {
+			item.PositionAt = unixMillisToTime(positionMS.Int64)
+		}
+		item.ObservedAt = unixMillisToTime(observedMS)
+		item.Source = domain.NodeUpdateType(strings.TrimSpace(source))
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate node position track rows: %w", err)
+	}
+
+	return out, nil
+}
+
+func fetchNodePositionLatest(ctx context.Context, tx *sql.Tx, deviceID, nodeID string) (domain.NodePosition, bool, error) {
 	rows, err := tx.QueryContext(ctx, `
 		SELECT node_id, channel, latitude, longitude, altitude, precision_bits, position_updated_at, observed_at, written_at
 		FROM node_position_latest
-		WHERE node_id = ?
+		WHERE device_id = ? AND node_id = ?
 		LIMIT 1
-	`, nodeID)
+	`, deviceID, nodeID)
 	if err != nil {
 		return domain.NodePosition{}, false, fmt.Errorf("query existing node position latest: %w", err)
 	}
@@ -275,6 +275,144 @@ func TestNodeCoreRepo_Upsert_PersistsFavoriteFromSnapshot(t *testing.T) {
 	}
 }
 
+func TestNodeCoreRepo_Upsert_KeepsViaMQTTUntilReported(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewNodeCoreRepo(db)
+	now := time.Now().UTC()
+	nodeID := "!abcd1234"
+	viaMQTT := true
+	for i, core := range []domain.NodeCore{
+		{NodeID: nodeID, ViaMQTT: &viaMQTT, LastHeardAt: now, UpdatedAt: now},
+		{NodeID: nodeID, LastHeardAt: now.Add(time.Second), UpdatedAt: now.Add(time.Second)},
+	} {
+		if err := repo.Upsert(ctx, domain.NodeCoreUpdate{Core: core, FromPacket: true}, 0); err != nil {
+			t.Fatalf("upsert %d: %v", i, err)
+		}
+	}
+
+	item, ok, err := repo.GetByNodeID(ctx, nodeID)
+	if err != nil || !ok {
+		t.Fatalf("get node by id: ok=%v err=%v", ok, err)
+	}
+	if item.ViaMQTT == nil || !*item.ViaMQTT {
+		t.Fatalf("expected via MQTT to be kept, got %v", item.ViaMQTT)
+	}
+}
+
+func TestNodeCoreRepo_SetLocalNotes_SurvivesRadioUpdates(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewNodeCoreRepo(db)
+	now := time.Now().UTC()
+	nodeID := "!abcd1234"
+
+	if err := repo.SetLocalNotes(ctx, nodeID, "Base camp", "note"); err == nil {
+		t.Fatalf("expected error for unknown node")
+	}
+	if err := repo.Upsert(ctx, domain.NodeCoreUpdate{
+		Core:       domain.NodeCore{NodeID: nodeID, LongName: "Alpha", LastHeardAt: now, UpdatedAt: now},
+		FromPacket: true,
+		Type:       domain.NodeUpdateTypeNodeInfoPacket,
+	}, 0); err != nil {
+		t.Fatalf("seed node: %v", err)
+	}
+	if err := repo.SetLocalNotes(ctx, nodeID, "Base camp", "Solar relay on the ridge"); err != nil {
+		t.Fatalf("set local notes: %v", err)
+	}
+	if err := repo.Upsert(ctx, domain.NodeCoreUpdate{
+		Core:       domain.NodeCore{NodeID: nodeID, LongName: "Alpha 2", LastHeardAt: now.Add(time.Second), UpdatedAt: now.Add(time.Second)},
+		FromPacket: true,
+		Type:       domain.NodeUpdateTypeNodeInfoPacket,
+	}, 0); err != nil {
+		t.Fatalf("radio upsert: %v", err)
+	}
+
+	item, ok, err := repo.GetByNodeID(ctx, nodeID)
+	if err != nil || !ok {
+		t.Fatalf("get node by id: ok=%v err=%v", ok, err)
+	}
+	if item.LongName != "Alpha 2" {
+		t.Fatalf("expected long name %q, got %q", "Alpha 2", item.LongName)
+	}
+	if item.Alias != "Base camp" || item.Note != "Solar relay on the ridge" {
+		t.Fatalf("expected local notes to survive, got alias=%q note=%q", item.Alias, item.Note)
+	}
+
+	if err := repo.SetLocalNotes(ctx, nodeID, "", ""); err != nil {
+		t.Fatalf("clear local notes: %v", err)
+	}
+	items, err := repo.ListSortedByLastHeard(ctx)
+	if err != nil {
+		t.Fatalf("list nodes: %v", err)
+	}
+	if len(items) != 1 || items[0].Alias != "" || items[0].Note != "" {
+		t.Fatalf("expected cleared local notes, got %+v", items)
+	}
+}
+
+func TestNodeCoreRepo_SetLocalTags_SurvivesRadioUpdates(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewNodeCoreRepo(db)
+	now := time.Now().UTC()
+	nodeID := "!abcd1234"
+
+	if err := repo.SetLocalTags(ctx, nodeID, []string{"solar"}); err == nil {
+		t.Fatalf("expected error for unknown node")
+	}
+	seed := domain.NodeCoreUpdate{
+		Core:       domain.NodeCore{NodeID: nodeID, LongName: "Alpha", LastHeardAt: now, UpdatedAt: now},
+		FromPacket: true,
+		Type:       domain.NodeUpdateTypeNodeInfoPacket,
+	}
+	if err := repo.Upsert(ctx, seed, 0); err != nil {
+		t.Fatalf("seed node: %v", err)
+	}
+	if err := repo.SetLocalTags(ctx, nodeID, []string{"solar", "router"}); err != nil {
+		t.Fatalf("set local tags: %v", err)
+	}
+	seed.Core.LastHeardAt = now.Add(time.Second)
+	seed.Core.UpdatedAt = now.Add(time.Second)
+	if err := repo.Upsert(ctx, seed, 0); err != nil {
+		t.Fatalf("radio upsert: %v", err)
+	}
+
+	item, ok, err := repo.GetByNodeID(ctx, nodeID)
+	if err != nil || !ok {
+		t.Fatalf("get node by id: ok=%v err=%v", ok, err)
+	}
+	if len(item.Tags) != 2 || item.Tags[0] != "solar" || item.Tags[1] != "router" {
+		t.Fatalf("expected local tags to survive, got %v", item.Tags)
+	}
+
+	if err := repo.SetLocalTags(ctx, nodeID, nil); err != nil {
+		t.Fatalf("clear local tags: %v", err)
+	}
+	items, err := repo.ListSortedByLastHeard(ctx)
+	if err != nil {
+		t.Fatalf("list nodes: %v", err)
+	}
+	if len(items) != 1 || len(items[0].Tags) != 0 {
+		t.Fatalf("expected cleared local tags, got %+v", items)
+	}
+}
+
 func TestNodeCoreRepo_ListSortedByLastHeard_IgnoresOutOfRangeRSSI(t *testing.T) {
 	ctx := context.Background()
 	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
@@ -393,6 +531,39 @@ func TestOpenMigratesV11ToV12AndBackfillsSplitTables(t *testing.T) {
 		`CREATE INDEX nodes_last_heard_at_idx ON nodes(last_heard_at DESC);`,
 		`INSERT INTO nodes(node_id, long_name, short_name, public_key, channel, latitude, longitude, altitude, precision_bits, battery_level, voltage, uptime_seconds, channel_utilization, air_util_tx, temperature, humidity, pressure, air_quality_index, power_voltage, power_current, board_model, firmware_version, device_role, is_unmessageable, position_updated_at, last_heard_at, rssi, snr, updated_at)
 		 VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		`CREATE TABLE chats (
+			chat_key TEXT PRIMARY KEY,
+			type INTEGER NOT NULL,
+			title TEXT NOT NULL,
+			last_sent_by_me_at INTEGER NULL,
+			updated_at INTEGER NOT NULL
+		);`,
+		`CREATE TABLE messages (
+			local_id INTEGER PRIMARY KEY AUTOINCREMENT,
+			chat_key TEXT NOT NULL,
+			device_message_id TEXT NULL,
+			reply_to_device_message_id TEXT NULL,
+			emoji INTEGER NOT NULL DEFAULT 0,
+			direction INTEGER NOT NULL,
+			body TEXT NOT NULL,
+			status INTEGER NOT NULL,
+			at INTEGER NOT NULL,
+			meta_json TEXT NULL
+		);`,
+		`CREATE TABLE traceroutes (
+			request_id TEXT PRIMARY KEY,
+			target_node_id TEXT NOT NULL,
+			started_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL,
+			completed_at INTEGER NULL,
+			status TEXT NOT NULL,
+			forward_route_json TEXT NULL,
+			forward_snr_json TEXT NULL,
+			return_route_json TEXT NULL,
+			return_snr_json TEXT NULL,
+			error_text TEXT NULL,
+			duration_ms INTEGER NULL
+		);`,
 		`PRAGMA user_version = 11;`,
 	}
 	for i, stmt := range stmts {
@@ -425,8 +596,8 @@ func TestOpenMigratesV11ToV12AndBackfillsSplitTables(t *testing.T) {
 	if err := migrated.QueryRowContext(ctx, `PRAGMA user_version;`).Scan(&version); err != nil {
 		t.Fatalf("read user_version: %v", err)
 	}
-	if version != 14 {
-		t.Fatalf("expected schema version 14, got %d", version)
+	if version != 30 {
+		t.Fatalf("expected schema version 30, got %d", version)
 	}
 
 	if hasColumn(t, migrated, "nodes", "latitude") {
@@ -511,6 +682,39 @@ func TestOpenMigratesV11ToV12_DoesNotBackfillPositionWithoutCoordinates(t *testi
 		`CREATE INDEX nodes_last_heard_at_idx ON nodes(last_heard_at DESC);`,
 		`INSERT INTO nodes(node_id, long_name, short_name, channel, altitude, precision_bits, position_updated_at, last_heard_at, updated_at)
 		 VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		`CREATE TABLE chats (
+			chat_key TEXT PRIMARY KEY,
+			type INTEGER NOT NULL,
+			title TEXT NOT NULL,
+			last_sent_by_me_at INTEGER NULL,
+			updated_at INTEGER NOT NULL
+		);`,
+		`CREATE TABLE messages (
+			local_id INTEGER PRIMARY KEY AUTOINCREMENT,
+			chat_key TEXT NOT NULL,
+			device_message_id TEXT NULL,
+			reply_to_device_message_id TEXT NULL,
+			emoji INTEGER NOT NULL DEFAULT 0,
+			direction INTEGER NOT NULL,
+			body TEXT NOT NULL,
+			status INTEGER NOT NULL,
+			at INTEGER NOT NULL,
+			meta_json TEXT NULL
+		);`,
+		`CREATE TABLE traceroutes (
+			request_id TEXT PRIMARY KEY,
+			target_node_id TEXT NOT NULL,
+			started_at INTEGER NOT NULL,
+			updated_at INTEGER NOT NULL,
+			completed_at INTEGER NULL,
+			status TEXT NOT NULL,
+			forward_route_json TEXT NULL,
+			forward_snr_json TEXT NULL,
+			return_route_json TEXT NULL,
+			return_snr_json TEXT NULL,
+			error_text TEXT NULL,
+			duration_ms INTEGER NULL
+		);`,
 		`PRAGMA user_version = 11;`,
 	}
 	for i, stmt := range stmts {
@@ -595,6 +799,13 @@ func TestOpenMigratesV4ToV12(t *testing.T) {
 			at INTEGER NOT NULL,
 			meta_json TEXT NULL
 		);`,
+		`CREATE TABLE chats (
+			chat_key TEXT PRIMARY KEY,
+			type INTEGER NOT NULL,
+			title TEXT NOT NULL,
+			last_sent_by_me_at INTEGER NULL,
+			updated_at INTEGER NOT NULL
+		);`,
 		`PRAGMA user_version = 4;`,
 	}
 	for _, stmt := range stmts {
@@ -615,8 +826,8 @@ func TestOpenMigratesV4ToV12(t *testing.T) {
 	if err := migrated.QueryRowContext(ctx, `PRAGMA user_version;`).Scan(&version); err != nil {
 		t.Fatalf("read user_version: %v", err)
 	}
-	if version != 14 {
-		t.Fatalf("expected schema version 14, got %d", version)
+	if version != 30 {
+		t.Fatalf("expected schema version 30, got %d", version)
 	}
 }
 
@@ -667,3 +878,78 @@ func assertSingleTableRow(t *testing.T, db *sql.DB, tableName string) {
 		t.Fatalf("unexpected %s row count: got %d want %d", tableName, got, want)
 	}
 }
+
+func TestNodePositionRepo_ListTrack_KeepsEveryReceivedPosition(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewNodePositionRepo(db)
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	nodeID := "!abcd1234"
+	upsert := func(offset time.Duration, lat, lon float64, updateType domain.NodeUpdateType) {
+		t.Helper()
+		if err := repo.Upsert(ctx, domain.NodePositionUpdate{
+			Position: domain.NodePosition{
+				NodeID:     nodeID,
+				Latitude:   &lat,
+				Longitude:  &lon,
+				ObservedAt: start.Add(offset),
+				UpdatedAt:  start.Add(offset),
+			},
+			FromPacket: true,
+			Type:       updateType,
+		}, 10); err != nil {
+			t.Fatalf("upsert position: %v", err)
+		}
+	}
+	upsert(0, 50.45, 30.52, domain.NodeUpdateTypeNodeInfoSnapshot)
+	upsert(time.Minute, 50.46, 30.53, domain.NodeUpdateTypePositionPacket)
+	// The same position again is a history duplicate but still a track point.
+	upsert(2*time.Minute, 50.46, 30.53, domain.NodeUpdateTypePositionPacket)
+	upsert(3*time.Minute, 50.47, 30.54, domain.NodeUpdateTypePositionPacket)
+	// Updates without coordinates do not produce track points.
+	if err := repo.Upsert(ctx, domain.NodePositionUpdate{
+		Position: domain.NodePosition{NodeID: nodeID, ObservedAt: start.Add(4 * time.Minute)},
+		Type:     domain.NodeUpdateTypePositionPacket,
+	}, 10); err != nil {
+		t.Fatalf("upsert position without coordinates: %v", err)
+	}
+
+	track, err := repo.ListTrack(ctx, domain.NodeTrackQuery{NodeID: nodeID})
+	if err != nil {
+		t.Fatalf("list track: %v", err)
+	}
+	if len(track) != 4 {
+		t.Fatalf("expected 4 track points, got %d", len(track))
+	}
+	if !track[0].ObservedAt.Equal(start) || track[0].Source != domain.NodeUpdateTypeNodeInfoSnapshot {
+		t.Fatalf("unexpected first track point: %+v", track[0])
+	}
+	if track[3].Latitude != 50.47 || track[3].Longitude != 30.54 || track[3].Source != domain.NodeUpdateTypePositionPacket {
+		t.Fatalf("unexpected last track point: %+v", track[3])
+	}
+
+	track, err = repo.ListTrack(ctx, domain.NodeTrackQuery{
+		NodeID: nodeID,
+		From:   start.Add(time.Minute),
+		To:     start.Add(2 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("list track range: %v", err)
+	}
+	if len(track) != 2 || !track[0].ObservedAt.Equal(start.Add(time.Minute)) || !track[1].ObservedAt.Equal(start.Add(2*time.Minute)) {
+		t.Fatalf("unexpected track range: %+v", track)
+	}
+
+	track, err = repo.ListTrack(ctx, domain.NodeTrackQuery{NodeID: nodeID, From: start.Add(2 * time.Minute), Limit: 1})
+	if err != nil {
+		t.Fatalf("list limited track: %v", err)
+	}
+	if len(track) != 1 || !track[0].ObservedAt.Equal(start.Add(2*time.Minute)) {
+		t.Fatalf("unexpected limited track: %+v", track)
+	}
+}
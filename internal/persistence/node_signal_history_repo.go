@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// signalHistorySampleInterval is the shortest gap kept between two readings of a node.
+// Busy nodes send many packets a minute; one reading per interval is enough for a trend.
+const signalHistorySampleInterval = time.Minute
+
+// NodeSignalHistoryRepo stores downsampled RSSI/SNR readings of received packets.
+type NodeSignalHistoryRepo struct {
+	deviceScoped
+	db *sql.DB
+}
+
+func NewNodeSignalHistoryRepo(db *sql.DB) *NodeSignalHistoryRepo {
+	return &NodeSignalHistoryRepo{db: db}
+}
+
+// Record stores the reading unless the node already has one within the sample interval.
+func (r *NodeSignalHistoryRepo) Record(ctx context.Context, entry domain.NodeSignalHistoryEntry, historyLimit int) error {
+	if r == nil || r.db == nil {
+		return fmt.Errorf("node signal history repo is not initialized")
+	}
+	nodeID := strings.TrimSpace(entry.NodeID)
+	if nodeID == "" || (entry.RSSI == nil && entry.SNR == nil) {
+		return nil
+	}
+	if entry.ObservedAt.IsZero() {
+		entry.ObservedAt = time.Now()
+	}
+	deviceID := r.deviceID(ctx)
+
+	tx, err := beginWriteTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("begin node signal history tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var latestMS sql.NullInt64
+	if err := tx.QueryRowContext(ctx, `
+		SELECT MAX(observed_at) FROM node_signal_history WHERE device_id = ? AND node_id = ?
+	`, deviceID, nodeID).Scan(&latestMS); err != nil {
+		return fmt.Errorf("query latest node signal reading: %w", err)
+	}
+	if latestMS.Valid && entry.ObservedAt.Sub(unixMillisToTime(latestMS.Int64)) < signalHistorySampleInterval {
+		return nil
+	}
+
+	var (
+		rssi any
+		snr  any
+	)
+	if entry.RSSI != nil {
+		rssi = *entry.RSSI
+	}
+	if entry.SNR != nil {
+		snr = *entry.SNR
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO node_signal_history(device_id, node_id, rssi, snr, observed_at)
+		VALUES (?, ?, ?, ?, ?)
+	`, deviceID, nodeID, rssi, snr, timeToUnixMillis(entry.ObservedAt)); err != nil {
+		return fmt.Errorf("insert node signal history: %w", err)
+	}
+	if err := pruneHistoryRows(ctx, tx.Tx, "node_signal_history", deviceID, nodeID, historyLimit); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit node signal history tx: %w", err)
+	}
+
+	return nil
+}
+
+func (r *NodeSignalHistoryRepo) ListHistoryByNodeID(ctx context.Context, query domain.NodeHistoryQuery) ([]domain.NodeSignalHistoryEntry, error) {
+	nodeID := strings.TrimSpace(query.NodeID)
+	if nodeID == "" {
+		return nil, nil
+	}
+	order := historyOrderSQL(query.Order)
+	where := "WHERE device_id = ? AND node_id = ?"
+	args := []any{r.deviceID(ctx), nodeID}
+	where, args = applyHistoryCursor(where, query, args)
+	limit := historyLimitValue(query.Limit)
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id, node_id, rssi, snr, observed_at
+		FROM node_signal_history
+		%s
+		ORDER BY observed_at %s, id %s
+		LIMIT ?
+	`, where, order, order), append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("list node signal history: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	out := make([]domain.NodeSignalHistoryEntry, 0)
+	for rows.Next() {
+		var (
+			item       domain.NodeSignalHistoryEntry
+			rssi       sql.NullInt64
+			snr        sql.NullFloat64
+			observedMS int64
+		)
+		if err := rows.Scan(&item.RowID, &item.NodeID, &rssi, &snr, &observedMS); err != nil {
+			return nil, fmt.Errorf("scan node signal history row: %w", err)
+		}
+		if rssi.Valid {
+			if v, ok := int64ToInt32(rssi.Int64); ok {
+				rssiValue := int(v)
+				item.RSSI = &rssiValue
+			}
+		}
+		if snr.Valid {
+			v := snr.Float64
+			item.SNR = &v
+		}
+		item.ObservedAt = unixMillisToTime(observedMS)
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate node signal history rows: %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,58 @@
+package persistence
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestNodeSignalHistoryRepoRecord_DownsamplesAndPrunes(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	nodeID := "!00000042"
+	if err := NewNodeCoreRepo(db).Upsert(ctx, domain.NodeCoreUpdate{
+		Core: domain.NodeCore{NodeID: nodeID, LastHeardAt: base, UpdatedAt: base},
+	}, 0); err != nil {
+		t.Fatalf("seed node: %v", err)
+	}
+
+	repo := NewNodeSignalHistoryRepo(db)
+	reading := func(at time.Time, rssi int, snr float64) domain.NodeSignalHistoryEntry {
+		return domain.NodeSignalHistoryEntry{NodeID: nodeID, RSSI: &rssi, SNR: &snr, ObservedAt: at}
+	}
+	entries := []domain.NodeSignalHistoryEntry{
+		reading(base, -90, 6.5),
+		reading(base.Add(20*time.Second), -95, 5),
+		reading(base.Add(time.Minute), -100, 2.25),
+		reading(base.Add(3*time.Minute), -110, -4),
+		{NodeID: nodeID, ObservedAt: base.Add(5 * time.Minute)},
+	}
+	for _, entry := range entries {
+		if err := repo.Record(ctx, entry, 2); err != nil {
+			t.Fatalf("record signal: %v", err)
+		}
+	}
+
+	items, err := repo.ListHistoryByNodeID(ctx, domain.NodeHistoryQuery{NodeID: nodeID, Order: domain.SortAscending})
+	if err != nil {
+		t.Fatalf("list signal history: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 readings after downsampling and pruning, got %d", len(items))
+	}
+	if !items[0].ObservedAt.Equal(base.Add(time.Minute)) || items[0].RSSI == nil || *items[0].RSSI != -100 {
+		t.Fatalf("unexpected first reading: %+v", items[0])
+	}
+	if !items[1].ObservedAt.Equal(base.Add(3*time.Minute)) || items[1].SNR == nil || *items[1].SNR != -4 {
+		t.Fatalf("unexpected second reading: %+v", items[1])
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 // NodeTelemetryRepo persists and queries node telemetry snapshots and history.
 type NodeTelemetryRepo struct {
+	deviceScoped
 	db *sql.DB
 }
 
@@ -28,6 +29,7 @@ func (r *NodeTelemetryRepo) Upsert(ctx context.Context, update domain.NodeTeleme
 		return nil
 	}
 	incoming := update.Telemetry
+	deviceID := r.deviceID(ctx)
 	writtenAt := time.Now()
 	if incoming.ObservedAt.IsZero() {
 		incoming.ObservedAt = incoming.UpdatedAt
@@ -39,7 +41,7 @@ func (r *NodeTelemetryRepo) Upsert(ctx context.Context, update domain.NodeTeleme
 		incoming.UpdatedAt = writtenAt
 	}
 
-	tx, err := r.db.BeginTx(ctx, nil)
+	tx, err := beginWriteTx(ctx, r.db)
 	if err != nil {
 		return fmt.Errorf("begin node telemetry upsert tx: %w", err)
 	}
@@ -48,15 +50,15 @@ func (r *NodeTelemetryRepo) Upsert(ctx context.Context, update domain.NodeTeleme
 	}()
 
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO nodes(node_id, last_heard_at, updated_at)
-		VALUES (?, ?, ?)
-		ON CONFLICT(node_id) DO NOTHING
-	`, nodeID, timeToUnixMillis(incoming.ObservedAt), timeToUnixMillis(incoming.UpdatedAt))
+		INSERT INTO nodes(device_id, node_id, last_heard_at, updated_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(device_id, node_id) DO NOTHING
+	`, deviceID, nodeID, timeToUnixMillis(incoming.ObservedAt), timeToUnixMillis(incoming.UpdatedAt))
 	if err != nil {
 		return fmt.Errorf("ensure node core row for telemetry: %w", err)
 	}
 
-	existing, found, err := fetchNodeTelemetryLatest(ctx, tx, nodeID)
+	existing, found, err := fetchNodeTelemetryLatest(ctx, tx.Tx, deviceID, nodeID)
 	if err != nil {
 		return err
 	}
@@ -69,9 +71,9 @@ func (r *NodeTelemetryRepo) Upsert(ctx context.Context, update domain.NodeTeleme
 	}
 
 	_, err = tx.ExecContext(ctx, `
-		INSERT INTO node_telemetry_latest(node_id, channel, battery_level, voltage, uptime_seconds, channel_utilization, air_util_tx, temperature, humidity, pressure, soil_temperature, soil_moisture, gas_resistance, lux, uv_lux, radiation, air_quality_index, power_voltage, power_current, observed_at, written_at, update_type, from_packet)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-		ON CONFLICT(node_id) DO UPDATE SET
+		INSERT INTO node_telemetry_latest(device_id, node_id, channel, battery_level, voltage, uptime_seconds, channel_utilization, air_util_tx, temperature, humidity, pressure, soil_temperature, soil_moisture, gas_resistance, lux, uv_lux, radiation, air_quality_index, power_voltage, power_current, observed_at, written_at, update_type, from_packet)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(device_id, node_id) DO UPDATE SET
 			channel = COALESCE(excluded.channel, node_telemetry_latest.channel),
 			battery_level = COALESCE(excluded.battery_level, node_telemetry_latest.battery_level),
 			voltage = COALESCE(excluded.voltage, node_telemetry_latest.voltage),
@@ -95,6 +97,7 @@ func (r *NodeTelemetryRepo) Upsert(ctx context.Context, update domain.NodeTeleme
 			update_type = excluded.update_type,
 			from_packet = excluded.from_packet
 	`,
+		deviceID,
 		nodeID,
 		nullableUint32(next.Channel),
 		nullableUint32(next.BatteryLevel),
@@ -125,9 +128,10 @@ func (r *NodeTelemetryRepo) Upsert(ctx context.Context, update domain.NodeTeleme
 
 	if hasTelemetryData(next) && (!found || !nodeTelemetryEqual(existing, next)) {
 		_, err = tx.ExecContext(ctx, `
-				INSERT INTO node_telemetry_history(node_id, channel, battery_level, voltage, uptime_seconds, channel_utilization, air_util_tx, temperature, humidity, pressure, soil_temperature, soil_moisture, gas_resistance, lux, uv_lux, radiation, air_quality_index, power_voltage, power_current, observed_at, written_at, update_type, from_packet)
-				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				INSERT INTO node_telemetry_history(device_id, node_id, channel, battery_level, voltage, uptime_seconds, channel_utilization, air_util_tx, temperature, humidity, pressure, soil_temperature, soil_moisture, gas_resistance, lux, uv_lux, radiation, air_quality_index, power_voltage, power_current, observed_at, written_at, update_type, from_packet)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 			`,
+			deviceID,
 			nodeID,
 			nullableUint32(next.Channel),
 			nullableUint32(next.BatteryLevel),
@@ -155,7 +159,7 @@ func (r *NodeTelemetryRepo) Upsert(ctx context.Context, update domain.NodeTeleme
 		if err != nil {
 			return fmt.Errorf("insert node telemetry history: %w", err)
 		}
-		if err := pruneHistoryRows(ctx, tx, "node_telemetry_history", nodeID, historyLimit); err != nil {
+		if err := pruneHistoryRows(ctx, tx.Tx, "node_telemetry_history", deviceID, nodeID, historyLimit); err != nil {
 			return err
 		}
 	}
@@ -171,7 +175,8 @@ func (r *NodeTelemetryRepo) ListLatest(ctx context.Context) ([]domain.NodeTeleme
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT node_id, channel, battery_level, voltage, uptime_seconds, channel_utilization, air_util_tx, temperature, humidity, pressure, soil_temperature, soil_moisture, gas_resistance, lux, uv_lux, radiation, air_quality_index, power_voltage, power_current, observed_at, written_at
 		FROM node_telemetry_latest
-	`)
+		WHERE device_id = ?1 AND node_id NOT IN (SELECT node_id FROM nodes WHERE device_id = ?1 AND deleted_at IS NOT NULL)
+	`, r.deviceID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("list node telemetry latest: %w", err)
 	}
@@ -198,9 +203,9 @@ func (r *NodeTelemetryRepo) GetLatestByNodeID(ctx context.Context, nodeID string
 	rows, err := r.db.QueryContext(ctx, `
 		SELECT node_id, channel, battery_level, voltage, uptime_seconds, channel_utilization, air_util_tx, temperature, humidity, pressure, soil_temperature, soil_moisture, gas_resistance, lux, uv_lux, radiation, air_quality_index, power_voltage, power_current, observed_at, written_at
 		FROM node_telemetry_latest
-		WHERE node_id = ?
+		WHERE device_id = ? AND node_id = ?
 		LIMIT 1
-	`, strings.TrimSpace(nodeID))
+	`, r.deviceID(ctx), strings.TrimSpace(nodeID))
 	if err != nil {
 		return domain.NodeTelemetry{}, false, fmt.Errorf("query node telemetry latest by id: %w", err)
 	}
@@ -224,8 +229,8 @@ func (r *NodeTelemetryRepo) ListHistoryByNodeID(ctx context.Context, query domai
 		return nil, nil
 	}
 	order := historyOrderSQL(query.Order)
-	where := "WHERE node_id = ?"
-	args := []any{nodeID}
+	where := "WHERE device_id = ? AND node_id = ?"
+	args := []any{r.deviceID(ctx), nodeID}
 	where, args = applyHistoryCursor(where, query, args)
 	limit := historyLimitValue(query.Limit)
 	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
@@ -257,13 +262,13 @@ func (r *NodeTelemetryRepo) ListHistoryByNodeID(ctx context.Context, query domai
 	return out, nil
 }
 
-func fetchNodeTelemetryLatest(ctx context.Context, tx *sql.Tx, nodeID string) (domain.NodeTelemetry, bool, error) {
+func fetchNodeTelemetryLatest(ctx context.Context, tx *sql.Tx, deviceID, nodeID string) (domain.NodeTelemetry, bool, error) {
 	rows, err := tx.QueryContext(ctx, `
 		SELECT node_id, channel, battery_level, voltage, uptime_seconds, channel_utilization, air_util_tx, temperature, humidity, pressure, soil_temperature, soil_moisture, gas_resistance, lux, uv_lux, radiation, air_quality_index, power_voltage, power_current, observed_at, written_at
 		FROM node_telemetry_latest
-		WHERE node_id = ?
+		WHERE device_id = ? AND node_id = ?
 		LIMIT 1
-	`, nodeID)
+	`, deviceID, nodeID)
 	if err != nil {
 		return domain.NodeTelemetry{}, false, fmt.Errorf("query existing node telemetry latest: %w", err)
 	}
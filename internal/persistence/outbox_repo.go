@@ -0,0 +1,120 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// OutboxRepo keeps messages that wait for the radio to come back.
+type OutboxRepo struct {
+	deviceScoped
+	db *sql.DB
+}
+
+func NewOutboxRepo(db *sql.DB) *OutboxRepo {
+	return &OutboxRepo{db: db}
+}
+
+// Add queues a message and returns its outbox id.
+func (r *OutboxRepo) Add(ctx context.Context, m domain.OutboxMessage) (int64, error) {
+	createdAt := m.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	status := m.Status
+	if status == "" {
+		status = domain.OutboxStatusQueued
+	}
+	res, err := executorFor(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO outbox_messages(device_id, chat_key, body, reply_to_device_message_id, emoji, status, attempts, last_error, created_at, updated_at)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, r.deviceID(ctx), m.ChatKey, m.Body, nullableString(m.ReplyToDeviceMessageID), int64(m.Emoji), string(status), m.Attempts, nullableString(m.LastError), timeToUnixMillis(createdAt), timeToUnixMillis(createdAt))
+	if err != nil {
+		return 0, fmt.Errorf("insert outbox message: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("get outbox message id: %w", err)
+	}
+
+	return id, nil
+}
+
+// ListQueued returns messages still waiting to be sent, oldest first.
+func (r *OutboxRepo) ListQueued(ctx context.Context) ([]domain.OutboxMessage, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, chat_key, body, reply_to_device_message_id, emoji, status, attempts, last_error, created_at, updated_at
+		FROM outbox_messages
+		WHERE device_id = ? AND status = ?
+		ORDER BY id ASC
+	`, r.deviceID(ctx), string(domain.OutboxStatusQueued))
+	if err != nil {
+		return nil, fmt.Errorf("list queued outbox messages: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	var out []domain.OutboxMessage
+	for rows.Next() {
+		var (
+			m          domain.OutboxMessage
+			replyIDRaw sql.NullString
+			emojiRaw   int64
+			statusRaw  string
+			lastErrRaw sql.NullString
+			createdMs  int64
+			updatedMs  int64
+		)
+		if err := rows.Scan(&m.ID, &m.ChatKey, &m.Body, &replyIDRaw, &emojiRaw, &statusRaw, &m.Attempts, &lastErrRaw, &createdMs, &updatedMs); err != nil {
+			return nil, fmt.Errorf("scan outbox message: %w", err)
+		}
+		m.ReplyToDeviceMessageID = replyIDRaw.String
+		m.Emoji = uint32(emojiRaw)
+		m.Status = domain.OutboxStatus(statusRaw)
+		m.LastError = lastErrRaw.String
+		m.CreatedAt = unixMillisToTime(createdMs)
+		m.UpdatedAt = unixMillisToTime(updatedMs)
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate outbox messages: %w", err)
+	}
+
+	return out, nil
+}
+
+// MarkAttempt records a failed send of a message that stays queued.
+func (r *OutboxRepo) MarkAttempt(ctx context.Context, id int64, sendErr string) error {
+	return r.update(ctx, id, domain.OutboxStatusQueued, sendErr)
+}
+
+// MarkFailed stops retrying a message.
+func (r *OutboxRepo) MarkFailed(ctx context.Context, id int64, sendErr string) error {
+	return r.update(ctx, id, domain.OutboxStatusFailed, sendErr)
+}
+
+func (r *OutboxRepo) update(ctx context.Context, id int64, status domain.OutboxStatus, sendErr string) error {
+	if _, err := executorFor(ctx, r.db).ExecContext(ctx, `
+		UPDATE outbox_messages
+		SET status = ?, attempts = attempts + 1, last_error = ?, updated_at = ?
+		WHERE device_id = ? AND id = ?
+	`, string(status), nullableString(sendErr), timeToUnixMillis(time.Now()), r.deviceID(ctx), id); err != nil {
+		return fmt.Errorf("update outbox message: %w", err)
+	}
+
+	return nil
+}
+
+// Delete drops a message the radio has taken.
+func (r *OutboxRepo) Delete(ctx context.Context, id int64) error {
+	if _, err := executorFor(ctx, r.db).ExecContext(ctx, `DELETE FROM outbox_messages WHERE device_id = ? AND id = ?`, r.deviceID(ctx), id); err != nil {
+		return fmt.Errorf("delete outbox message: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package persistence
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestOutboxRepo_QueuesRetriesAndDeletes(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewOutboxRepo(db)
+	firstID, err := repo.Add(ctx, domain.OutboxMessage{ChatKey: "channel:0", Body: "first", ReplyToDeviceMessageID: "99", Emoji: 1})
+	if err != nil {
+		t.Fatalf("add first: %v", err)
+	}
+	secondID, err := repo.Add(ctx, domain.OutboxMessage{ChatKey: "channel:0", Body: "second"})
+	if err != nil {
+		t.Fatalf("add second: %v", err)
+	}
+
+	queued, err := repo.ListQueued(ctx)
+	if err != nil {
+		t.Fatalf("list queued: %v", err)
+	}
+	if len(queued) != 2 || queued[0].ID != firstID || queued[1].ID != secondID {
+		t.Fatalf("expected both messages oldest first, got %+v", queued)
+	}
+	if queued[0].ReplyToDeviceMessageID != "99" || queued[0].Emoji != 1 || queued[0].Status != domain.OutboxStatusQueued {
+		t.Fatalf("unexpected first message: %+v", queued[0])
+	}
+
+	if err := repo.MarkAttempt(ctx, firstID, "radio busy"); err != nil {
+		t.Fatalf("mark attempt: %v", err)
+	}
+	if err := repo.MarkFailed(ctx, secondID, "rejected"); err != nil {
+		t.Fatalf("mark failed: %v", err)
+	}
+	queued, err = repo.ListQueued(ctx)
+	if err != nil {
+		t.Fatalf("list queued after attempts: %v", err)
+	}
+	if len(queued) != 1 || queued[0].ID != firstID {
+		t.Fatalf("expected only the retried message to stay queued, got %+v", queued)
+	}
+	if queued[0].Attempts != 1 || queued[0].LastError != "radio busy" {
+		t.Fatalf("expected the attempt to be recorded, got %+v", queued[0])
+	}
+
+	if err := repo.Delete(ctx, firstID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	queued, err = repo.ListQueued(ctx)
+	if err != nil {
+		t.Fatalf("list queued after delete: %v", err)
+	}
+	if len(queued) != 0 {
+		t.Fatalf("expected an empty outbox, got %+v", queued)
+	}
+}
+
+func TestOutboxRepo_KeepsDeviceNamespacesApart(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	scope := NewDeviceScope("!00000001")
+	repo := NewOutboxRepo(db)
+	repo.UseDeviceScope(scope)
+	if _, err := repo.Add(ctx, domain.OutboxMessage{ChatKey: "channel:0", Body: "hello"}); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	scope.Set("!00000002")
+	queued, err := repo.ListQueued(ctx)
+	if err != nil {
+		t.Fatalf("list queued: %v", err)
+	}
+	if len(queued) != 0 {
+		t.Fatalf("expected another device to see no queued messages, got %+v", queued)
+	}
+}
@@ -0,0 +1,106 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// rawPacketLogPruneEvery is how many frames are appended between size checks. Summing
+// the log on every frame would cost more than storing it.
+const rawPacketLogPruneEvery = 64
+
+// RawPacketLogRepo stores raw radio frames. The log is not device-scoped: frames are
+// logged before the radio reports which device it is.
+type RawPacketLogRepo struct {
+	db *sql.DB
+
+	mu                sync.Mutex
+	appendsSincePrune int
+}
+
+func NewRawPacketLogRepo(db *sql.DB) *RawPacketLogRepo {
+	return &RawPacketLogRepo{db: db}
+}
+
+func (r *RawPacketLogRepo) Append(ctx context.Context, entry domain.RawPacketLogEntry, maxBytes int64) error {
+	if r == nil || r.db == nil {
+		return fmt.Errorf("raw packet log repo is not initialized")
+	}
+	if len(entry.Payload) == 0 {
+		return nil
+	}
+	if entry.LoggedAt.IsZero() {
+		entry.LoggedAt = time.Now()
+	}
+
+	exec := executorFor(ctx, r.db)
+	if _, err := exec.ExecContext(ctx, `
+		INSERT INTO raw_packet_log(direction, payload, logged_at) VALUES (?, ?, ?)
+	`, string(entry.Direction), entry.Payload, timeToUnixMillis(entry.LoggedAt)); err != nil {
+		return fmt.Errorf("insert raw packet: %w", err)
+	}
+
+	r.mu.Lock()
+	// The first frame after start is checked too, in case the cap was lowered.
+	prune := r.appendsSincePrune == 0
+	r.appendsSincePrune = (r.appendsSincePrune + 1) % rawPacketLogPruneEvery
+	r.mu.Unlock()
+	if !prune || maxBytes <= 0 {
+		return nil
+	}
+	if _, err := exec.ExecContext(ctx, `
+		DELETE FROM raw_packet_log
+		WHERE id <= (
+			SELECT id FROM (
+				SELECT id, SUM(length(payload)) OVER (ORDER BY id DESC) AS kept_bytes
+				FROM raw_packet_log
+			)
+			WHERE kept_bytes > ?
+			ORDER BY id DESC
+			LIMIT 1
+		)
+	`, maxBytes); err != nil {
+		return fmt.Errorf("prune raw packet log: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RawPacketLogRepo) List(ctx context.Context) ([]domain.RawPacketLogEntry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, direction, payload, logged_at
+		FROM raw_packet_log
+		ORDER BY id ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list raw packet log: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	out := make([]domain.RawPacketLogEntry, 0)
+	for rows.Next() {
+		var (
+			item      domain.RawPacketLogEntry
+			direction string
+			loggedMS  int64
+		)
+		if err := rows.Scan(&item.RowID, &direction, &item.Payload, &loggedMS); err != nil {
+			return nil, fmt.Errorf("scan raw packet log row: %w", err)
+		}
+		item.Direction = domain.RawPacketDirection(direction)
+		item.LoggedAt = unixMillisToTime(loggedMS)
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate raw packet log rows: %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,55 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestRawPacketLogRepoAppend_PrunesBySize(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	frame := func(i int, direction domain.RawPacketDirection) domain.RawPacketLogEntry {
+		return domain.RawPacketLogEntry{
+			Direction: direction,
+			Payload:   bytes.Repeat([]byte{byte(i)}, 10),
+			LoggedAt:  base.Add(time.Duration(i) * time.Second),
+		}
+	}
+	uncapped := NewRawPacketLogRepo(db)
+	for i := 1; i <= 3; i++ {
+		if err := uncapped.Append(ctx, frame(i, domain.RawPacketFromRadio), 0); err != nil {
+			t.Fatalf("append frame %d: %v", i, err)
+		}
+	}
+
+	// A fresh repo checks the size on its first append.
+	capped := NewRawPacketLogRepo(db)
+	if err := capped.Append(ctx, frame(4, domain.RawPacketToRadio), 25); err != nil {
+		t.Fatalf("append capped frame: %v", err)
+	}
+
+	items, err := capped.List(ctx)
+	if err != nil {
+		t.Fatalf("list raw packet log: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 frames within the size cap, got %d", len(items))
+	}
+	if items[0].Payload[0] != 3 || items[0].Direction != domain.RawPacketFromRadio || !items[0].LoggedAt.Equal(base.Add(3*time.Second)) {
+		t.Fatalf("unexpected first frame: %+v", items[0])
+	}
+	if items[1].Payload[0] != 4 || items[1].Direction != domain.RawPacketToRadio {
+		t.Fatalf("unexpected second frame: %+v", items[1])
+	}
+}
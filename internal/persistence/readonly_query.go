@@ -0,0 +1,175 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrQueryNotReadOnly means a statement was rejected by ReadOnlyQuery.
+var ErrQueryNotReadOnly = errors.New("only single SELECT or WITH statements are allowed")
+
+// QueryResult holds the columns and rows returned by ReadOnlyQuery. Values are nil,
+// int64, float64 or string; BLOBs are rendered as hex strings.
+type QueryResult struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// CannedQuery is a ready-made report for the database query tool.
+type CannedQuery struct {
+	Name        string
+	Description string
+	SQL         string
+}
+
+var cannedQueries = []CannedQuery{
+	{
+		Name:        "top-senders",
+		Description: "nodes that sent the most received messages",
+		SQL: `SELECT json_extract(m.meta_json, '$.from') AS node_id,
+				COALESCE(n.long_name, '') AS long_name,
+				COUNT(*) AS messages,
+				datetime(MAX(m.at) / 1000, 'unixepoch', 'localtime') AS last_message_at
+			FROM messages m
+			LEFT JOIN nodes n ON n.device_id = m.device_id AND n.node_id = json_extract(m.meta_json, '$.from')
+			WHERE m.direction = 1 AND json_valid(m.meta_json) AND json_extract(m.meta_json, '$.from') IS NOT NULL
+			GROUP BY json_extract(m.meta_json, '$.from')
+			ORDER BY messages DESC, node_id
+			LIMIT 20`,
+	},
+	{
+		Name:        "messages-per-day",
+		Description: "received and sent messages per local calendar day",
+		SQL: `SELECT date(at / 1000, 'unixepoch', 'localtime') AS day,
+				SUM(direction = 1) AS received,
+				SUM(direction = 2) AS sent,
+				COUNT(*) AS total
+			FROM messages
+			GROUP BY day
+			ORDER BY day`,
+	},
+	{
+		Name:        "nodes-last-heard",
+		Description: "known nodes, most recently heard first",
+		SQL: `SELECT node_id,
+				COALESCE(long_name, '') AS long_name,
+				COALESCE(short_name, '') AS short_name,
+				datetime(last_heard_at / 1000, 'unixepoch', 'localtime') AS last_heard_at
+			FROM nodes
+			WHERE deleted_at IS NULL
+			ORDER BY nodes.last_heard_at DESC`,
+	},
+	{
+		Name:        "tables",
+		Description: "tables of the app database",
+		SQL:         `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name`,
+	},
+}
+
+// CannedQueries lists the ready-made reports in display order.
+func CannedQueries() []CannedQuery {
+	return append([]CannedQuery(nil), cannedQueries...)
+}
+
+// LookupCannedQuery finds a ready-made report by name.
+func LookupCannedQuery(name string) (CannedQuery, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, query := range cannedQueries {
+		if query.Name == name {
+			return query, true
+		}
+	}
+
+	return CannedQuery{}, false
+}
+
+// ReadOnlyQuery runs a single SELECT or WITH statement. Besides the statement check, the
+// connection is switched to query_only mode, so statements that would still write fail.
+func ReadOnlyQuery(ctx context.Context, db *sql.DB, statement string) (QueryResult, error) {
+	if db == nil {
+		return QueryResult{}, fmt.Errorf("database is not initialized")
+	}
+	statement, err := normalizeReadOnlyStatement(statement)
+	if err != nil {
+		return QueryResult{}, err
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return QueryResult{}, fmt.Errorf("acquire query connection: %w", err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	if _, err := conn.ExecContext(ctx, `PRAGMA query_only = ON;`); err != nil {
+		return QueryResult{}, fmt.Errorf("enable query only mode: %w", err)
+	}
+	defer func() {
+		// The connection goes back to the pool, so writes must work again for other users.
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), `PRAGMA query_only = OFF;`)
+	}()
+
+	rows, err := conn.QueryContext(ctx, statement)
+	if err != nil {
+		return QueryResult{}, fmt.Errorf("run query: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return QueryResult{}, fmt.Errorf("read query columns: %w", err)
+	}
+	result := QueryResult{Columns: columns, Rows: make([][]any, 0)}
+	for rows.Next() {
+		values := make([]any, len(columns))
+		targets := make([]any, len(columns))
+		for i := range values {
+			targets[i] = &values[i]
+		}
+		if err := rows.Scan(targets...); err != nil {
+			return QueryResult{}, fmt.Errorf("scan query row: %w", err)
+		}
+		for i, value := range values {
+			values[i] = normalizeQueryValue(value)
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return QueryResult{}, fmt.Errorf("iterate query rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// normalizeReadOnlyStatement trims a trailing semicolon and rejects anything but one
+// SELECT or WITH statement. Semicolons inside the statement are refused outright, even
+// within string literals, to keep the check simple.
+func normalizeReadOnlyStatement(statement string) (string, error) {
+	statement = strings.TrimSpace(strings.TrimRight(statement, "; \t\r\n"))
+	if statement == "" {
+		return "", fmt.Errorf("query is empty")
+	}
+	if strings.Contains(statement, ";") {
+		return "", ErrQueryNotReadOnly
+	}
+	switch strings.ToUpper(strings.Fields(statement)[0]) {
+	case "SELECT", "WITH":
+		return statement, nil
+	default:
+		return "", ErrQueryNotReadOnly
+	}
+}
+
+func normalizeQueryValue(value any) any {
+	if blob, ok := value.([]byte); ok {
+		return hex.EncodeToString(blob)
+	}
+
+	return value
+}
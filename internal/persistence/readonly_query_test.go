@@ -0,0 +1,97 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestReadOnlyQuery(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := NewMessageRepo(db)
+	for _, msg := range []domain.ChatMessage{
+		{ChatKey: "channel:0", DeviceMessageID: "1", Direction: domain.MessageDirectionIn, Body: "hi", Status: domain.MessageStatusAcked, At: now, MetaJSON: `{"from":"!00000001"}`},
+		{ChatKey: "channel:0", DeviceMessageID: "2", Direction: domain.MessageDirectionIn, Body: "again", Status: domain.MessageStatusAcked, At: now, MetaJSON: `{"from":"!00000001"}`},
+		{ChatKey: "channel:0", DeviceMessageID: "3", Direction: domain.MessageDirectionIn, Body: "yo", Status: domain.MessageStatusAcked, At: now, MetaJSON: `{"from":"!00000002"}`},
+		{ChatKey: "channel:0", DeviceMessageID: "4", Direction: domain.MessageDirectionOut, Body: "ok", Status: domain.MessageStatusSent, At: now},
+	} {
+		if _, err := repo.Insert(ctx, msg); err != nil {
+			t.Fatalf("insert message: %v", err)
+		}
+	}
+
+	result, err := ReadOnlyQuery(ctx, db, "  select body, x'0a0b' AS raw from messages order by local_id limit 1;  ")
+	if err != nil {
+		t.Fatalf("run query: %v", err)
+	}
+	if len(result.Columns) != 2 || result.Columns[0] != "body" || len(result.Rows) != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Rows[0][0] != "hi" || result.Rows[0][1] != "0a0b" {
+		t.Fatalf("unexpected row: %+v", result.Rows[0])
+	}
+
+	canned, ok := LookupCannedQuery("top-senders")
+	if !ok {
+		t.Fatalf("expected top-senders canned query")
+	}
+	result, err = ReadOnlyQuery(ctx, db, canned.SQL)
+	if err != nil {
+		t.Fatalf("run top senders: %v", err)
+	}
+	if len(result.Rows) != 2 || result.Rows[0][0] != "!00000001" || result.Rows[0][2] != int64(2) {
+		t.Fatalf("unexpected top senders: %+v", result.Rows)
+	}
+	for _, query := range CannedQueries() {
+		if _, err := ReadOnlyQuery(ctx, db, query.SQL); err != nil {
+			t.Fatalf("run canned query %s: %v", query.Name, err)
+		}
+	}
+}
+
+func TestReadOnlyQueryRejectsWrites(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	tests := []struct {
+		name      string
+		statement string
+		notRead   bool
+	}{
+		{name: "empty", statement: " ; "},
+		{name: "delete", statement: "DELETE FROM messages", notRead: true},
+		{name: "stacked", statement: "SELECT 1; DELETE FROM messages", notRead: true},
+		{name: "pragma", statement: "PRAGMA journal_mode = DELETE", notRead: true},
+		// Passes the keyword check, but query_only mode stops the write.
+		{name: "cte delete", statement: "WITH gone AS (SELECT 1) DELETE FROM messages"},
+	}
+	for _, tc := range tests {
+		_, err := ReadOnlyQuery(ctx, db, tc.statement)
+		if err == nil {
+			t.Fatalf("%s: expected error", tc.name)
+		}
+		if tc.notRead && !errors.Is(err, ErrQueryNotReadOnly) {
+			t.Fatalf("%s: expected ErrQueryNotReadOnly, got %v", tc.name, err)
+		}
+	}
+
+	// The pooled connection is writable again afterwards.
+	if _, err := db.ExecContext(ctx, `INSERT INTO chats(chat_key, title, type, last_sent_by_me_at, updated_at) VALUES ('c', 'c', 1, 0, 0)`); err != nil {
+		t.Fatalf("expected writes to work after read-only queries: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+
+	persistmigrations "github.com/skobkin/meshgo/internal/persistence/migrations"
+)
+
+// LatestSchemaVersion returns the schema version this build migrates databases to.
+func LatestSchemaVersion() int {
+	return persistmigrations.TargetVersion()
+}
+
+// SchemaVersion returns the schema version of an opened database.
+func SchemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	return persistmigrations.Version(ctx, db)
+}
+
+// SnapshotDatabase writes a consistent copy of db to path as a plain SQLite file.
+// It works for encrypted in-memory databases too, so the copy is never encrypted.
+func SnapshotDatabase(ctx context.Context, db *sql.DB, path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove old database snapshot: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, `VACUUM INTO ?;`, path); err != nil {
+		return fmt.Errorf("snapshot database: %w", err)
+	}
+
+	return nil
+}
+
+// InspectDatabaseFile checks that the plain database file at path is intact and returns
+// its schema version. The file is neither migrated nor modified.
+func InspectDatabaseFile(ctx context.Context, path string) (int, error) {
+	if _, err := os.Stat(path); err != nil {
+		return 0, fmt.Errorf("inspect database file: %w", err)
+	}
+	problem, err := checkDatabaseIntegrity(ctx, path)
+	if err != nil {
+		return 0, err
+	}
+	if problem != "" {
+		return 0, fmt.Errorf("database file is damaged: %s", problem)
+	}
+
+	db, err := OpenReadOnly(ctx, path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	return SchemaVersion(ctx, db)
+}
@@ -0,0 +1,60 @@
+package persistence
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSnapshotDatabase_CopiesDataAndSchemaVersion(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "app.db")
+	seedDatabase(t, dbPath)
+	db, err := Open(ctx, dbPath)
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	snapshotPath := filepath.Join(dir, "snapshot.db")
+	if err := os.WriteFile(snapshotPath, []byte("stale"), 0o600); err != nil {
+		t.Fatalf("write stale snapshot: %v", err)
+	}
+	if err := SnapshotDatabase(ctx, db, snapshotPath); err != nil {
+		t.Fatalf("snapshot database: %v", err)
+	}
+
+	version, err := InspectDatabaseFile(ctx, snapshotPath)
+	if err != nil {
+		t.Fatalf("inspect snapshot: %v", err)
+	}
+	if version != LatestSchemaVersion() {
+		t.Fatalf("expected schema version %d, got %d", LatestSchemaVersion(), version)
+	}
+
+	snapshot, err := OpenReadOnly(ctx, snapshotPath)
+	if err != nil {
+		t.Fatalf("open snapshot: %v", err)
+	}
+	defer func() { _ = snapshot.Close() }()
+	var messages int
+	if err := snapshot.QueryRowContext(ctx, `SELECT COUNT(*) FROM messages`).Scan(&messages); err != nil {
+		t.Fatalf("count snapshot messages: %v", err)
+	}
+	if messages != 1 {
+		t.Fatalf("expected 1 message in snapshot, got %d", messages)
+	}
+}
+
+func TestInspectDatabaseFile_RejectsDamagedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.db")
+	if err := os.WriteFile(path, []byte("not a database, just some bytes that are long enough to matter"), 0o600); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	if _, err := InspectDatabaseFile(context.Background(), path); err == nil {
+		t.Fatalf("expected damaged file to be rejected")
+	}
+}
@@ -0,0 +1,201 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// StatisticsRepo maintains activity aggregates. Days and hours are local time, so
+// charts match the operator's clock.
+type StatisticsRepo struct {
+	deviceScoped
+	db *sql.DB
+}
+
+func NewStatisticsRepo(db *sql.DB) *StatisticsRepo {
+	return &StatisticsRepo{db: db}
+}
+
+// RecordMessage counts a stored message for its chat, day and hour.
+func (r *StatisticsRepo) RecordMessage(ctx context.Context, chatKey string, direction domain.MessageDirection, at time.Time) error {
+	if r == nil || r.db == nil {
+		return fmt.Errorf("statistics repo is not initialized")
+	}
+	chatKey = strings.TrimSpace(chatKey)
+	if chatKey == "" {
+		return nil
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	incoming, outgoing := 1, 0
+	if direction == domain.MessageDirectionOut {
+		incoming, outgoing = 0, 1
+	}
+	deviceID := r.deviceID(ctx)
+
+	tx, err := beginWriteTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("begin message statistics tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO stats_chat_daily(device_id, chat_key, day, incoming, outgoing)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(device_id, chat_key, day) DO UPDATE SET
+			incoming = incoming + excluded.incoming,
+			outgoing = outgoing + excluded.outgoing
+	`, deviceID, chatKey, domain.StatisticsDay(at), incoming, outgoing); err != nil {
+		return fmt.Errorf("update chat daily statistics: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO stats_hourly(device_id, hour, messages)
+		VALUES (?, ?, 1)
+		ON CONFLICT(device_id, hour) DO UPDATE SET messages = messages + 1
+	`, deviceID, at.Local().Hour()); err != nil {
+		return fmt.Errorf("update hourly message statistics: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit message statistics tx: %w", err)
+	}
+
+	return nil
+}
+
+// RecordNodePacket counts a packet heard from the node for the node and hour.
+func (r *StatisticsRepo) RecordNodePacket(ctx context.Context, nodeID string, at time.Time) error {
+	if r == nil || r.db == nil {
+		return fmt.Errorf("statistics repo is not initialized")
+	}
+	nodeID = strings.TrimSpace(nodeID)
+	if nodeID == "" {
+		return nil
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	deviceID := r.deviceID(ctx)
+
+	tx, err := beginWriteTx(ctx, r.db)
+	if err != nil {
+		return fmt.Errorf("begin packet statistics tx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO stats_node_packets(device_id, node_id, packets, last_packet_at)
+		VALUES (?, ?, 1, ?)
+		ON CONFLICT(device_id, node_id) DO UPDATE SET
+			packets = packets + 1,
+			last_packet_at = MAX(last_packet_at, excluded.last_packet_at)
+	`, deviceID, nodeID, timeToUnixMillis(at)); err != nil {
+		return fmt.Errorf("update node packet statistics: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO stats_hourly(device_id, hour, packets)
+		VALUES (?, ?, 1)
+		ON CONFLICT(device_id, hour) DO UPDATE SET packets = packets + 1
+	`, deviceID, at.Local().Hour()); err != nil {
+		return fmt.Errorf("update hourly packet statistics: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit packet statistics tx: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StatisticsRepo) Load(ctx context.Context, since time.Time, nodeLimit int) (domain.MeshStatistics, error) {
+	var out domain.MeshStatistics
+	deviceID := r.deviceID(ctx)
+
+	daily, err := r.db.QueryContext(ctx, `
+		SELECT chat_key, day, incoming, outgoing
+		FROM stats_chat_daily
+		WHERE device_id = ? AND day >= ?
+		ORDER BY day ASC, chat_key ASC
+	`, deviceID, domain.StatisticsDay(since))
+	if err != nil {
+		return out, fmt.Errorf("list chat daily statistics: %w", err)
+	}
+	defer func() {
+		_ = daily.Close()
+	}()
+	for daily.Next() {
+		var item domain.ChatDailyMessageCount
+		if err := daily.Scan(&item.ChatKey, &item.Day, &item.Incoming, &item.Outgoing); err != nil {
+			return out, fmt.Errorf("scan chat daily statistics row: %w", err)
+		}
+		out.Daily = append(out.Daily, item)
+	}
+	if err := daily.Err(); err != nil {
+		return out, fmt.Errorf("iterate chat daily statistics rows: %w", err)
+	}
+
+	nodes, err := r.db.QueryContext(ctx, `
+		SELECT node_id, packets, last_packet_at
+		FROM stats_node_packets
+		WHERE device_id = ?
+		ORDER BY packets DESC, node_id ASC
+		LIMIT ?
+	`, deviceID, historyLimitValue(nodeLimit))
+	if err != nil {
+		return out, fmt.Errorf("list node packet statistics: %w", err)
+	}
+	defer func() {
+		_ = nodes.Close()
+	}()
+	for nodes.Next() {
+		var (
+			item   domain.NodePacketCount
+			lastMS int64
+		)
+		if err := nodes.Scan(&item.NodeID, &item.Packets, &lastMS); err != nil {
+			return out, fmt.Errorf("scan node packet statistics row: %w", err)
+		}
+		item.LastPacketAt = unixMillisToTime(lastMS)
+		out.Nodes = append(out.Nodes, item)
+	}
+	if err := nodes.Err(); err != nil {
+		return out, fmt.Errorf("iterate node packet statistics rows: %w", err)
+	}
+
+	hours, err := r.db.QueryContext(ctx, `
+		SELECT hour, packets, messages FROM stats_hourly WHERE device_id = ?
+	`, deviceID)
+	if err != nil {
+		return out, fmt.Errorf("list hourly statistics: %w", err)
+	}
+	defer func() {
+		_ = hours.Close()
+	}()
+	for hours.Next() {
+		var (
+			hour     int
+			activity domain.HourlyActivity
+		)
+		if err := hours.Scan(&hour, &activity.Packets, &activity.Messages); err != nil {
+			return out, fmt.Errorf("scan hourly statistics row: %w", err)
+		}
+		if hour >= 0 && hour < len(out.Hours) {
+			out.Hours[hour] = activity
+		}
+	}
+	if err := hours.Err(); err != nil {
+		return out, fmt.Errorf("iterate hourly statistics rows: %w", err)
+	}
+
+	return out, nil
+}
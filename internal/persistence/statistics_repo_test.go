@@ -0,0 +1,65 @@
+package persistence
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestStatisticsRepoAggregates(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewStatisticsRepo(db)
+	day := time.Date(2026, 3, 1, 9, 30, 0, 0, time.Local)
+	messages := []struct {
+		chatKey   string
+		direction domain.MessageDirection
+		at        time.Time
+	}{
+		{chatKey: "channel:0", direction: domain.MessageDirectionIn, at: day},
+		{chatKey: "channel:0", direction: domain.MessageDirectionOut, at: day.Add(10 * time.Minute)},
+		{chatKey: "channel:0", direction: domain.MessageDirectionIn, at: day.AddDate(0, 0, 1)},
+		{chatKey: "dm:!00000042", direction: domain.MessageDirectionIn, at: day.Add(-48 * time.Hour)},
+	}
+	for _, message := range messages {
+		if err := repo.RecordMessage(ctx, message.chatKey, message.direction, message.at); err != nil {
+			t.Fatalf("record message: %v", err)
+		}
+	}
+	for _, nodeID := range []string{"!00000042", "!00000042", "!00000043"} {
+		if err := repo.RecordNodePacket(ctx, nodeID, day); err != nil {
+			t.Fatalf("record packet: %v", err)
+		}
+	}
+
+	stats, err := repo.Load(ctx, day, 1)
+	if err != nil {
+		t.Fatalf("load statistics: %v", err)
+	}
+	want := []domain.ChatDailyMessageCount{
+		{ChatKey: "channel:0", Day: "2026-03-01", Incoming: 1, Outgoing: 1},
+		{ChatKey: "channel:0", Day: "2026-03-02", Incoming: 1},
+	}
+	if len(stats.Daily) != len(want) {
+		t.Fatalf("expected %d daily rows, got %+v", len(want), stats.Daily)
+	}
+	for i := range want {
+		if stats.Daily[i] != want[i] {
+			t.Fatalf("daily row %d: expected %+v, got %+v", i, want[i], stats.Daily[i])
+		}
+	}
+	if len(stats.Nodes) != 1 || stats.Nodes[0].NodeID != "!00000042" || stats.Nodes[0].Packets != 2 {
+		t.Fatalf("unexpected node statistics: %+v", stats.Nodes)
+	}
+	if got := stats.Hours[9]; got.Packets != 3 || got.Messages != 4 {
+		t.Fatalf("hour 9: expected 3 packets and 4 messages, got %+v", got)
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
 )
 
 // TracerouteRepo implements domain.TracerouteRepository using SQLite.
 type TracerouteRepo struct {
+	deviceScoped
 	db *sql.DB
 }
 
@@ -36,12 +39,12 @@ func (r *TracerouteRepo) Upsert(ctx context.Context, rec domain.TracerouteRecord
 		return fmt.Errorf("marshal return snr: %w", err)
 	}
 
-	_, err = r.db.ExecContext(ctx, `
+	_, err = executorFor(ctx, r.db).ExecContext(ctx, `
 		INSERT INTO traceroutes(
-			request_id, target_node_id, started_at, updated_at, completed_at, status,
+			device_id, request_id, target_node_id, started_at, updated_at, completed_at, status,
 			forward_route_json, forward_snr_json, return_route_json, return_snr_json, error_text, duration_ms
 		)
-		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(request_id) DO UPDATE SET
 			target_node_id = excluded.target_node_id,
 			started_at = excluded.started_at,
@@ -55,6 +58,7 @@ func (r *TracerouteRepo) Upsert(ctx context.Context, rec domain.TracerouteRecord
 			error_text = excluded.error_text,
 			duration_ms = excluded.duration_ms
 	`,
+		r.deviceID(ctx),
 		rec.RequestID,
 		rec.TargetNodeID,
 		timeToUnixMillis(rec.StartedAt),
@@ -75,6 +79,98 @@ func (r *TracerouteRepo) Upsert(ctx context.Context, rec domain.TracerouteRecord
 	return nil
 }
 
+// ListByTargetNodeID returns traceroutes toward the node, newest first.
+func (r *TracerouteRepo) ListByTargetNodeID(ctx context.Context, nodeID string, limit int) ([]domain.TracerouteRecord, error) {
+	nodeID = strings.TrimSpace(nodeID)
+	if nodeID == "" {
+		return nil, nil
+	}
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT request_id, target_node_id, started_at, updated_at, completed_at, status,
+			forward_route_json, forward_snr_json, return_route_json, return_snr_json, error_text, duration_ms
+		FROM traceroutes
+		WHERE device_id = ? AND target_node_id = ?
+		ORDER BY started_at DESC, request_id DESC
+		LIMIT ?
+	`, r.deviceID(ctx), nodeID, historyLimitValue(limit))
+	if err != nil {
+		return nil, fmt.Errorf("list traceroutes: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	out := make([]domain.TracerouteRecord, 0)
+	for rows.Next() {
+		item, scanErr := scanTraceroute(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate traceroute rows: %w", err)
+	}
+
+	return out, nil
+}
+
+func scanTraceroute(scanner interface{ Scan(dest ...any) error }) (domain.TracerouteRecord, error) {
+	var (
+		item         domain.TracerouteRecord
+		startedMS    int64
+		updatedMS    int64
+		completedMS  sql.NullInt64
+		status       string
+		forwardRoute sql.NullString
+		forwardSNR   sql.NullString
+		returnRoute  sql.NullString
+		returnSNR    sql.NullString
+		errorText    sql.NullString
+		durationMS   sql.NullInt64
+	)
+	if err := scanner.Scan(
+		&item.RequestID, &item.TargetNodeID, &startedMS, &updatedMS, &completedMS, &status,
+		&forwardRoute, &forwardSNR, &returnRoute, &returnSNR, &errorText, &durationMS,
+	); err != nil {
+		return domain.TracerouteRecord{}, fmt.Errorf("scan traceroute row: %w", err)
+	}
+	item.StartedAt = unixMillisToTime(startedMS)
+	item.UpdatedAt = unixMillisToTime(updatedMS)
+	if completedMS.Valid {
+		item.CompletedAt = unixMillisToTime(completedMS.Int64)
+	}
+	item.Status = busmsg.TracerouteStatus(status)
+	if err := unmarshalJSONNullable(forwardRoute, &item.ForwardRoute); err != nil {
+		return domain.TracerouteRecord{}, fmt.Errorf("decode forward route: %w", err)
+	}
+	if err := unmarshalJSONNullable(forwardSNR, &item.ForwardSNR); err != nil {
+		return domain.TracerouteRecord{}, fmt.Errorf("decode forward snr: %w", err)
+	}
+	if err := unmarshalJSONNullable(returnRoute, &item.ReturnRoute); err != nil {
+		return domain.TracerouteRecord{}, fmt.Errorf("decode return route: %w", err)
+	}
+	if err := unmarshalJSONNullable(returnSNR, &item.ReturnSNR); err != nil {
+		return domain.TracerouteRecord{}, fmt.Errorf("decode return snr: %w", err)
+	}
+	if errorText.Valid {
+		item.ErrorText = errorText.String
+	}
+	if durationMS.Valid {
+		item.DurationMS = durationMS.Int64
+	}
+
+	return item, nil
+}
+
+func unmarshalJSONNullable(raw sql.NullString, v any) error {
+	if !raw.Valid || raw.String == "" {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(raw.String), v)
+}
+
 func marshalJSONNullable(v any) (any, error) {
 	raw, err := json.Marshal(v)
 	if err != nil {
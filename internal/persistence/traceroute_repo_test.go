@@ -89,3 +89,82 @@ func TestTracerouteRepoUpsert_PersistsAndUpdatesRecord(t *testing.T) {
 		t.Fatalf("expected return route json to be set")
 	}
 }
+
+func TestTracerouteRepoListByTargetNodeID_ReturnsNewestFirst(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	repo := NewTracerouteRepo(db)
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	records := []domain.TracerouteRecord{
+		{
+			RequestID:    "1",
+			TargetNodeID: "!00000042",
+			StartedAt:    base,
+			UpdatedAt:    base.Add(2 * time.Second),
+			CompletedAt:  base.Add(2 * time.Second),
+			Status:       busmsg.TracerouteStatusCompleted,
+			ForwardRoute: []string{"!00000001", "!00000010", "!00000042"},
+			ForwardSNR:   []int32{24, -8},
+			ReturnRoute:  []string{"!00000042", "!00000001"},
+			ReturnSNR:    []int32{12},
+			DurationMS:   2000,
+		},
+		{
+			RequestID:    "2",
+			TargetNodeID: "!00000042",
+			StartedAt:    base.Add(time.Hour),
+			UpdatedAt:    base.Add(time.Hour + 30*time.Second),
+			Status:       busmsg.TracerouteStatusTimedOut,
+			ErrorText:    "timed out",
+		},
+		{
+			RequestID:    "3",
+			TargetNodeID: "!00000099",
+			StartedAt:    base.Add(2 * time.Hour),
+			UpdatedAt:    base.Add(2 * time.Hour),
+			Status:       busmsg.TracerouteStatusStarted,
+		},
+	}
+	for _, rec := range records {
+		if err := repo.Upsert(ctx, rec); err != nil {
+			t.Fatalf("upsert traceroute %s: %v", rec.RequestID, err)
+		}
+	}
+
+	items, err := repo.ListByTargetNodeID(ctx, " !00000042 ", 0)
+	if err != nil {
+		t.Fatalf("list traceroutes: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 traceroutes, got %d", len(items))
+	}
+	if items[0].RequestID != "2" || items[0].Status != busmsg.TracerouteStatusTimedOut || items[0].ErrorText != "timed out" {
+		t.Fatalf("unexpected newest traceroute: %+v", items[0])
+	}
+	if !items[0].CompletedAt.IsZero() || len(items[0].ForwardRoute) != 0 {
+		t.Fatalf("expected empty route for timed out traceroute, got %+v", items[0])
+	}
+	got := items[1]
+	if !got.StartedAt.Equal(base) || !got.CompletedAt.Equal(base.Add(2*time.Second)) || got.DurationMS != 2000 {
+		t.Fatalf("unexpected traceroute timing: %+v", got)
+	}
+	if len(got.ForwardRoute) != 3 || got.ForwardRoute[1] != "!00000010" || len(got.ForwardSNR) != 2 || got.ForwardSNR[1] != -8 {
+		t.Fatalf("unexpected forward route: %v %v", got.ForwardRoute, got.ForwardSNR)
+	}
+	if len(got.ReturnRoute) != 2 || len(got.ReturnSNR) != 1 || got.ReturnSNR[0] != 12 {
+		t.Fatalf("unexpected return route: %v %v", got.ReturnRoute, got.ReturnSNR)
+	}
+
+	limited, err := repo.ListByTargetNodeID(ctx, "!00000042", 1)
+	if err != nil {
+		t.Fatalf("list limited traceroutes: %v", err)
+	}
+	if len(limited) != 1 || limited[0].RequestID != "2" {
+		t.Fatalf("expected limit to keep newest traceroute, got %+v", limited)
+	}
+}
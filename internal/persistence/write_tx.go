@@ -0,0 +1,93 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// dbExecutor is implemented by *sql.DB and *sql.Tx.
+type dbExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type writeBatchKey struct{}
+
+// withWriteBatch makes repositories called with ctx write through the writer queue batch.
+func withWriteBatch(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, writeBatchKey{}, tx)
+}
+
+func writeBatchFrom(ctx context.Context) *sql.Tx {
+	tx, _ := ctx.Value(writeBatchKey{}).(*sql.Tx)
+
+	return tx
+}
+
+// executorFor returns the writer batch transaction when ctx carries one. A batch holds
+// the write lock, so statements of a batched command must not go through db directly.
+func executorFor(ctx context.Context, db *sql.DB) dbExecutor {
+	if tx := writeBatchFrom(ctx); tx != nil {
+		return tx
+	}
+
+	return db
+}
+
+// writeTx is a repository transaction. Inside a writer batch it joins the batch
+// transaction, and the queue decides whether the command's changes are kept.
+type writeTx struct {
+	*sql.Tx
+	joined bool
+}
+
+func beginWriteTx(ctx context.Context, db *sql.DB) (*writeTx, error) {
+	if tx := writeBatchFrom(ctx); tx != nil {
+		return &writeTx{Tx: tx, joined: true}, nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &writeTx{Tx: tx}, nil
+}
+
+func (t *writeTx) Commit() error {
+	if t.joined {
+		return nil
+	}
+
+	return t.Tx.Commit()
+}
+
+func (t *writeTx) Rollback() error {
+	if t.joined {
+		return nil
+	}
+
+	return t.Tx.Rollback()
+}
+
+// runInSavepoint runs fn so that its changes are undone on failure without aborting
+// the surrounding batch transaction.
+func runInSavepoint(ctx context.Context, tx *sql.Tx, fn func(context.Context) error) error {
+	if _, err := tx.ExecContext(ctx, `SAVEPOINT writer_cmd;`); err != nil {
+		return fmt.Errorf("open write savepoint: %w", err)
+	}
+	if err := fn(withWriteBatch(ctx, tx)); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, `ROLLBACK TO writer_cmd;`); rbErr != nil {
+			return fmt.Errorf("%w (roll back write savepoint: %v)", err, rbErr)
+		}
+		_, _ = tx.ExecContext(ctx, `RELEASE writer_cmd;`)
+
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `RELEASE writer_cmd;`); err != nil {
+		return fmt.Errorf("release write savepoint: %w", err)
+	}
+
+	return nil
+}
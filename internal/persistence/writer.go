@@ -2,19 +2,95 @@ package persistence
 
 import (
 	"context"
+	"database/sql"
 	"log/slog"
+	"sync"
 	"time"
 )
 
+const (
+	// maxWriteBatch caps how many commands share one transaction.
+	maxWriteBatch    = 128
+	maxWriteAttempts = 3
+)
+
 type writeCmd struct {
-	name string
-	fn   func(context.Context) error
+	name       string
+	key        string
+	fn         func(context.Context) error
+	enqueuedAt time.Time
+	// deviceID is the namespace selected when the command was queued; scoped tells
+	// whether the queue had a scope to read it from.
+	deviceID string
+	scoped   bool
+}
+
+// context returns ctx pinned to the namespace the command was queued in.
+func (c writeCmd) context(ctx context.Context) context.Context {
+	if !c.scoped {
+		return ctx
+	}
+
+	return withDeviceNamespace(ctx, c.deviceID)
+}
+
+// coalesceKey keeps commands of different namespaces apart, so a command queued for
+// one device never supersedes one queued for another.
+func (c writeCmd) coalesceKey() string {
+	if c.key == "" {
+		return ""
+	}
+
+	return c.deviceID + "\x00" + c.key
+}
+
+// WriterQueueStats describes the work done by a WriterQueue. Latency is measured from
+// enqueueing a command until its write is committed.
+type WriterQueueStats struct {
+	Batches       uint64
+	Commands      uint64
+	Coalesced     uint64
+	Failed        uint64
+	LastBatchSize int
+	MaxBatchSize  int
+	TotalLatency  time.Duration
+	MaxLatency    time.Duration
+}
+
+// AverageBatchSize is the mean number of commands written per batch.
+func (s WriterQueueStats) AverageBatchSize() float64 {
+	if s.Batches == 0 {
+		return 0
+	}
+
+	return float64(s.Commands) / float64(s.Batches)
+}
+
+// AverageLatency is the mean time commands waited until they were written.
+func (s WriterQueueStats) AverageLatency() time.Duration {
+	if s.Commands == 0 {
+		return 0
+	}
+
+	return s.TotalLatency / time.Duration(s.Commands)
 }
 
-// WriterQueue runs persistence commands asynchronously with bounded retries.
+// WriterQueue runs persistence commands asynchronously with bounded retries. Commands
+// queued close together are written as one batch: repeated commands with the same
+// coalescing key collapse to the latest one and, once batching is enabled, the batch
+// shares a single transaction.
 type WriterQueue struct {
 	logger *slog.Logger
 	queue  chan writeCmd
+
+	db     *sql.DB
+	window time.Duration
+
+	afterBatch func(context.Context)
+	scope      *DeviceScope
+
+	statsMu sync.Mutex
+	stats   WriterQueueStats
 }
 
 func NewWriterQueue(logger *slog.Logger, capacity int) *WriterQueue {
@@ -28,8 +104,39 @@ func NewWriterQueue(logger *slog.Logger, capacity int) *WriterQueue {
 	}
 }
 
+// EnableBatching makes the queue wait up to window for more commands after the first
+// one of a batch and run the batch in one transaction on db. It must be called before
+// Start.
+func (w *WriterQueue) EnableBatching(db *sql.DB, window time.Duration) {
+	w.db = db
+	w.window = window
+}
+
+// OnBatchWritten sets fn to run after each batch, once its commands are written or have
+// failed. It must be called before Start.
+func (w *WriterQueue) OnBatchWritten(fn func(context.Context)) {
+	w.afterBatch = fn
+}
+
+// UseDeviceScope makes commands write to the namespace scope selects when they are
+// queued rather than when they run, so writes queued before a device switch stay with
+// the previous device. It must be called before Start.
+func (w *WriterQueue) UseDeviceScope(scope *DeviceScope) {
+	w.scope = scope
+}
+
 func (w *WriterQueue) Enqueue(name string, fn func(context.Context) error) {
-	cmd := writeCmd{name: name, fn: fn}
+	w.EnqueueCoalesced(name, "", fn)
+}
+
+// EnqueueCoalesced queues a command that supersedes a not yet written command with the
+// same key, so only the latest one runs. An empty key never coalesces.
+func (w *WriterQueue) EnqueueCoalesced(name, key string, fn func(context.Context) error) {
+	cmd := writeCmd{name: name, key: key, fn: fn, enqueuedAt: time.Now()}
+	if w.scope != nil {
+		cmd.deviceID = w.scope.DeviceID()
+		cmd.scoped = true
+	}
 	select {
 	case w.queue <- cmd:
 	default:
@@ -37,6 +144,14 @@ func (w *WriterQueue) Enqueue(name string, fn func(context.Context) error) {
 	}
 }
 
+// Stats returns a snapshot of the queue counters.
+func (w *WriterQueue) Stats() WriterQueueStats {
+	w.statsMu.Lock()
+	defer w.statsMu.Unlock()
+
+	return w.stats
+}
+
 func (w *WriterQueue) Start(ctx context.Context) {
 	go func() {
 		for {
@@ -44,29 +159,196 @@ func (w *WriterQueue) Start(ctx context.Context) {
 			case <-ctx.Done():
 				return
 			case cmd := <-w.queue:
-				w.runWithRetry(ctx, cmd)
+				batch, ok := w.collectBatch(ctx, cmd)
+				if !ok {
+					return
+				}
+				w.runBatch(ctx, batch)
+				if w.afterBatch != nil {
+					w.afterBatch(ctx)
+				}
 			}
 		}
 	}()
 }
 
-func (w *WriterQueue) runWithRetry(ctx context.Context, cmd writeCmd) {
-	const maxAttempts = 3
-	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		if err := cmd.fn(ctx); err != nil {
-			w.logger.Error("db write failed", "cmd", cmd.name, "attempt", attempt, "error", err)
-			if attempt == maxAttempts {
-				return
+// collectBatch gathers commands queued within the batching window, keeping only the
+// latest command per coalescing key in the position it was last queued.
+func (w *WriterQueue) collectBatch(ctx context.Context, first writeCmd) ([]writeCmd, bool) {
+	batch := []writeCmd{first}
+	var deadline <-chan time.Time
+	if w.window > 0 {
+		timer := time.NewTimer(w.window)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	for len(batch) < maxWriteBatch {
+		var cmd writeCmd
+		if deadline == nil {
+			select {
+			case <-ctx.Done():
+				return nil, false
+			case cmd = <-w.queue:
+			default:
+				return coalesceWriteCmds(batch, w.countCoalesced), true
 			}
+		} else {
 			select {
 			case <-ctx.Done():
-				return
-			case <-time.After(time.Duration(attempt) * 300 * time.Millisecond):
+				return nil, false
+			case cmd = <-w.queue:
+			case <-deadline:
+				return coalesceWriteCmds(batch, w.countCoalesced), true
 			}
+		}
+		batch = append(batch, cmd)
+	}
+
+	return coalesceWriteCmds(batch, w.countCoalesced), true
+}
 
+func coalesceWriteCmds(batch []writeCmd, onCoalesced func(int)) []writeCmd {
+	latest := make(map[string]int)
+	for i, cmd := range batch {
+		if key := cmd.coalesceKey(); key != "" {
+			latest[key] = i
+		}
+	}
+	if len(latest) == 0 {
+		return batch
+	}
+
+	out := batch[:0]
+	for i, cmd := range batch {
+		if key := cmd.coalesceKey(); key != "" && latest[key] != i {
 			continue
 		}
+		out = append(out, cmd)
+	}
+	if dropped := len(batch) - len(out); dropped > 0 && onCoalesced != nil {
+		onCoalesced(dropped)
+	}
+
+	return out
+}
+
+func (w *WriterQueue) countCoalesced(n int) {
+	w.statsMu.Lock()
+	w.stats.Coalesced += uint64(n)
+	w.statsMu.Unlock()
+}
+
+// runBatch writes the batch in one transaction when batching is enabled. Commands that
+// fail inside the batch are rolled back alone and retried on their own afterwards.
+func (w *WriterQueue) runBatch(ctx context.Context, batch []writeCmd) {
+	if w.db == nil || len(batch) == 1 {
+		w.runBatchUnbatched(ctx, batch)
+
+		return
+	}
+
+	started := time.Now()
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		w.logger.Warn("begin write batch failed, writing commands one by one", "commands", len(batch), "error", err)
+		w.runBatchUnbatched(ctx, batch)
+
+		return
+	}
+	var written, failed []writeCmd
+	for _, cmd := range batch {
+		if err := runInSavepoint(cmd.context(ctx), tx, cmd.fn); err != nil {
+			w.logger.Error("db write failed", "cmd", cmd.name, "attempt", 1, "error", err)
+			failed = append(failed, cmd)
+
+			continue
+		}
+		written = append(written, cmd)
+	}
+	if err := tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		w.logger.Warn("commit write batch failed, writing commands one by one", "commands", len(batch), "error", err)
+		w.runBatchUnbatched(ctx, batch)
+
+		return
+	}
+	w.recordBatch(len(written))
+	for _, cmd := range written {
+		w.recordResult(cmd, true)
+	}
+	w.logger.Debug(
+		"write batch committed",
+		"commands", len(written),
+		"failed", len(failed),
+		"duration", time.Since(started),
+	)
+	for _, cmd := range failed {
+		w.runAlone(ctx, cmd, 2)
+	}
+}
+
+func (w *WriterQueue) runBatchUnbatched(ctx context.Context, batch []writeCmd) {
+	for _, cmd := range batch {
+		w.runAlone(ctx, cmd, 1)
+	}
+}
+
+func (w *WriterQueue) runAlone(ctx context.Context, cmd writeCmd, firstAttempt int) {
+	ok := w.runWithRetry(ctx, cmd, firstAttempt)
+	if ok {
+		w.recordBatch(1)
+	}
+	w.recordResult(cmd, ok)
+}
+
+func (w *WriterQueue) runWithRetry(ctx context.Context, cmd writeCmd, firstAttempt int) bool {
+	for attempt := firstAttempt; attempt <= maxWriteAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return false
+			case <-time.After(time.Duration(attempt-1) * 300 * time.Millisecond):
+			}
+		}
+		if err := cmd.fn(cmd.context(ctx)); err != nil {
+			w.logger.Error("db write failed", "cmd", cmd.name, "attempt", attempt, "error", err)
+
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func (w *WriterQueue) recordBatch(size int) {
+	if size == 0 {
+		return
+	}
+	w.statsMu.Lock()
+	defer w.statsMu.Unlock()
+
+	w.stats.Batches++
+	w.stats.LastBatchSize = size
+	if size > w.stats.MaxBatchSize {
+		w.stats.MaxBatchSize = size
+	}
+}
+
+func (w *WriterQueue) recordResult(cmd writeCmd, ok bool) {
+	w.statsMu.Lock()
+	defer w.statsMu.Unlock()
+
+	if !ok {
+		w.stats.Failed++
 
 		return
 	}
+	latency := time.Since(cmd.enqueuedAt)
+	w.stats.Commands++
+	w.stats.TotalLatency += latency
+	if latency > w.stats.MaxLatency {
+		w.stats.MaxLatency = latency
+	}
 }
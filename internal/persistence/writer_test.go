@@ -0,0 +1,183 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestCoalesceWriteCmds(t *testing.T) {
+	tests := []struct {
+		name          string
+		cmds          []writeCmd
+		want          []string
+		wantCoalesced int
+	}{
+		{
+			name: "without keys",
+			cmds: []writeCmd{{name: "a"}, {name: "b"}},
+			want: []string{"a", "b"},
+		},
+		{
+			name:          "keeps latest per key at its position",
+			cmds:          []writeCmd{{name: "a1", key: "a"}, {name: "b"}, {name: "a2", key: "a"}, {name: "c1", key: "c"}},
+			want:          []string{"b", "a2", "c1"},
+			wantCoalesced: 1,
+		},
+		{
+			name:          "collapses long runs",
+			cmds:          []writeCmd{{name: "a1", key: "a"}, {name: "a2", key: "a"}, {name: "a3", key: "a"}},
+			want:          []string{"a3"},
+			wantCoalesced: 2,
+		},
+		{
+			name:          "keeps namespaces apart",
+			cmds:          []writeCmd{{name: "a1", key: "a", deviceID: "!1"}, {name: "a2", key: "a", deviceID: "!2"}, {name: "a3", key: "a", deviceID: "!1"}},
+			want:          []string{"a2", "a3"},
+			wantCoalesced: 1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			coalesced := 0
+			got := coalesceWriteCmds(tc.cmds, func(n int) { coalesced += n })
+			names := make([]string, 0, len(got))
+			for _, cmd := range got {
+				names = append(names, cmd.name)
+			}
+			if !slices.Equal(names, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, names)
+			}
+			if coalesced != tc.wantCoalesced {
+				t.Fatalf("expected %d coalesced, got %d", tc.wantCoalesced, coalesced)
+			}
+		})
+	}
+}
+
+func TestWriterQueue_BatchesCommandsAndIsolatesFailures(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	nodes := NewNodeCoreRepo(db)
+	chats := NewChatRepo(db)
+	queue := NewWriterQueue(slog.New(slog.NewTextHandler(io.Discard, nil)), 64)
+	queue.EnableBatching(db, 200*time.Millisecond)
+
+	now := time.Now()
+	for i, name := range []string{"First", "Second", "Latest"} {
+		update := domain.NodeCoreUpdate{
+			Core: domain.NodeCore{NodeID: "!00000001", LongName: name, LastHeardAt: now.Add(time.Duration(i) * time.Second)},
+			Type: domain.NodeUpdateTypeNodeInfoPacket,
+		}
+		queue.EnqueueCoalesced("upsert_node_core", "node:!00000001", func(writeCtx context.Context) error {
+			return nodes.Upsert(writeCtx, update, 10)
+		})
+	}
+	queue.Enqueue("upsert_chat", func(writeCtx context.Context) error {
+		return chats.Upsert(writeCtx, domain.Chat{Key: "channel:0", Type: domain.ChatTypeChannel, Title: "LongFast", UpdatedAt: now})
+	})
+	failures := 0
+	queue.Enqueue("broken", func(writeCtx context.Context) error {
+		failures++
+		tx, err := beginWriteTx(writeCtx, db)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = tx.Rollback() }()
+		if _, err := tx.ExecContext(writeCtx, `DELETE FROM chats`); err != nil {
+			return err
+		}
+
+		return errors.New("broken command")
+	})
+	queue.Start(ctx)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for queue.Stats().Failed == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("queue did not finish, stats %+v", queue.Stats())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	stats := queue.Stats()
+	if stats.Commands != 2 || stats.Coalesced != 2 || stats.Failed != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if stats.MaxBatchSize != 2 {
+		t.Fatalf("expected the node and chat writes in one batch, got max batch size %d", stats.MaxBatchSize)
+	}
+	if failures != maxWriteAttempts {
+		t.Fatalf("expected %d attempts of the broken command, got %d", maxWriteAttempts, failures)
+	}
+
+	listed, err := nodes.ListSortedByLastHeard(ctx)
+	if err != nil {
+		t.Fatalf("list nodes: %v", err)
+	}
+	if len(listed) != 1 || listed[0].LongName != "Latest" {
+		t.Fatalf("expected latest node update to be written, got %+v", listed)
+	}
+	// The broken command's delete was rolled back with it.
+	chatList, err := chats.ListSortedByLastSentByMe(ctx)
+	if err != nil {
+		t.Fatalf("list chats: %v", err)
+	}
+	if len(chatList) != 1 {
+		t.Fatalf("expected the batched chat to survive the failed command, got %+v", chatList)
+	}
+}
+
+func TestWriterQueue_WritesToNamespaceSelectedWhenQueued(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "app.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	scope := NewDeviceScope("!00000001")
+	chats := NewChatRepo(db)
+	chats.UseDeviceScope(scope)
+	queue := NewWriterQueue(slog.New(slog.NewTextHandler(io.Discard, nil)), 64)
+	queue.EnableBatching(db, 0)
+	queue.UseDeviceScope(scope)
+
+	queue.Enqueue("upsert_chat", func(writeCtx context.Context) error {
+		return chats.Upsert(writeCtx, domain.Chat{Key: "channel:0", Type: domain.ChatTypeChannel, Title: "LongFast", UpdatedAt: time.Now()})
+	})
+	// The device switches before the queue gets to the command.
+	scope.Set("!00000002")
+	queue.Start(ctx)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for queue.Stats().Commands == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("queue did not finish, stats %+v", queue.Stats())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if listed, err := chats.ListSortedByLastSentByMe(ctx); err != nil || len(listed) != 0 {
+		t.Fatalf("expected no chats in the new namespace, got %+v (err %v)", listed, err)
+	}
+	scope.Set("!00000001")
+	if listed, err := chats.ListSortedByLastSentByMe(ctx); err != nil || len(listed) != 1 {
+		t.Fatalf("expected the chat in the namespace it was queued in, got %+v (err %v)", listed, err)
+	}
+}
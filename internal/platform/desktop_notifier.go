@@ -0,0 +1,72 @@
+package platform
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrDesktopNotifierUnsupported is returned when the desktop cannot report clicks on
+// notifications.
+var ErrDesktopNotifierUnsupported = errors.New("clickable desktop notifications are not supported")
+
+// DesktopNotifier shows native notifications and reports clicks on them.
+type DesktopNotifier interface {
+	// Notify shows a notification. Notifications with the same non-empty group are
+	// stacked together where the desktop supports it. onClick runs on a notifier
+	// goroutine when the user clicks it; nil shows a notification without a click
+	// action. AppleScript notifications on macOS never report clicks.
+	Notify(title, body, group string, onClick func()) error
+	Close() error
+}
+
+// NewDesktopNotifier connects to the desktop notification service on behalf of appName.
+func NewDesktopNotifier(appName string) (DesktopNotifier, error) {
+	return newDesktopNotifier(appName)
+}
+
+// notificationClickHandlersLimit bounds the click handlers kept for notifications that
+// went to the notification center unclicked, where they can still be clicked until the
+// system drops them.
+const notificationClickHandlersLimit = 100
+
+// notificationClickHandlers numbers notifications and keeps their click handlers until
+// they are clicked or closed.
+type notificationClickHandlers struct {
+	mu      sync.Mutex
+	nextID  uint64
+	onClick map[uint64]func()
+	order   []uint64
+}
+
+// add returns the ID of a new notification and keeps its click handler, if any.
+func (h *notificationClickHandlers) add(onClick func()) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.nextID++
+	if onClick == nil {
+		return h.nextID
+	}
+	if h.onClick == nil {
+		h.onClick = make(map[uint64]func())
+	}
+	h.onClick[h.nextID] = onClick
+	h.order = append(h.order, h.nextID)
+	if len(h.order) > notificationClickHandlersLimit {
+		delete(h.onClick, h.order[0])
+		h.order = h.order[1:]
+	}
+
+	return h.nextID
+}
+
+// take removes and returns the click handler of the notification.
+func (h *notificationClickHandlers) take(id uint64) func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	fn := h.onClick[id]
+	delete(h.onClick, id)
+
+	return fn
+}
@@ -0,0 +1,105 @@
+//go:build darwin && cgo
+
+package platform
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Foundation -framework UserNotifications
+
+#include <stdbool.h>
+#include <stdlib.h>
+
+bool meshgoNotificationsBundled(void);
+void meshgoNotificationsStart(void);
+void meshgoNotificationsSend(unsigned long long notificationID, char *title, char *body, char *thread);
+*/
+import "C"
+
+import (
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"unsafe"
+)
+
+// userNotificationCenter shows notifications through the macOS notification center. The
+// delegate of the center is process-wide, so only one can be open at a time.
+type userNotificationCenter struct {
+	thread string
+	clicks notificationClickHandlers
+	denied atomic.Bool
+}
+
+var activeUserNotificationCenter atomic.Pointer[userNotificationCenter]
+
+// newDesktopNotifier asks for the permission to notify once, when it is created. The
+// notification center only serves app bundles, so a bare binary falls back to
+// AppleScript notifications.
+func newDesktopNotifier(appName string) (DesktopNotifier, error) {
+	if !bool(C.meshgoNotificationsBundled()) {
+		slog.Info("not running from an app bundle, using AppleScript notifications")
+
+		return osascriptNotifier{start: startCommandReaped}, nil
+	}
+	center := &userNotificationCenter{thread: appName}
+	if !activeUserNotificationCenter.CompareAndSwap(nil, center) {
+		return nil, errors.New("notification center is already open")
+	}
+	C.meshgoNotificationsStart()
+
+	return center, nil
+}
+
+func (n *userNotificationCenter) Notify(title, body, group string, onClick func()) error {
+	// The user turned notifications off for the app; respect it.
+	if n.denied.Load() {
+		return nil
+	}
+	id := n.clicks.add(onClick)
+	cTitle := C.CString(title)
+	defer C.free(unsafe.Pointer(cTitle))
+	cBody := C.CString(body)
+	defer C.free(unsafe.Pointer(cBody))
+	// Notifications outside of a group share the thread of the app.
+	thread := n.thread
+	if group != "" {
+		thread = group
+	}
+	cThread := C.CString(thread)
+	defer C.free(unsafe.Pointer(cThread))
+	C.meshgoNotificationsSend(C.ulonglong(id), cTitle, cBody, cThread)
+
+	return nil
+}
+
+func (n *userNotificationCenter) Close() error {
+	activeUserNotificationCenter.CompareAndSwap(n, nil)
+
+	return nil
+}
+
+//export meshgoNotificationClicked
+func meshgoNotificationClicked(id C.ulonglong) {
+	center := activeUserNotificationCenter.Load()
+	if center == nil {
+		return
+	}
+	if fn := center.clicks.take(uint64(id)); fn != nil {
+		fn()
+	}
+}
+
+//export meshgoNotificationsAuthorized
+func meshgoNotificationsAuthorized(granted C.bool, message *C.char) {
+	center := activeUserNotificationCenter.Load()
+	if center == nil {
+		return
+	}
+	center.denied.Store(!bool(granted))
+	switch {
+	case message != nil:
+		slog.Warn("notification permission request failed", "error", C.GoString(message))
+	case !bool(granted):
+		slog.Info("notifications are turned off for the app in the system settings")
+	}
+}
@@ -0,0 +1,79 @@
+//go:build darwin && cgo
+
+#import <Foundation/Foundation.h>
+#import <UserNotifications/UserNotifications.h>
+
+#include <stdbool.h>
+
+extern void meshgoNotificationClicked(unsigned long long id);
+extern void meshgoNotificationsAuthorized(bool granted, char *err);
+
+static NSString *const meshgoNotificationIDKey = @"meshgo-id";
+
+@interface MeshgoNotificationDelegate : NSObject <UNUserNotificationCenterDelegate>
+@end
+
+@implementation MeshgoNotificationDelegate
+
+// The notification center hides notifications of the app in front unless asked to show
+// them. The app decides on its own when a notification is worth showing.
+- (void)userNotificationCenter:(UNUserNotificationCenter *)center
+       willPresentNotification:(UNNotification *)notification
+         withCompletionHandler:(void (^)(UNNotificationPresentationOptions options))completionHandler {
+    if (@available(macOS 11.0, *)) {
+        completionHandler(UNNotificationPresentationOptionBanner | UNNotificationPresentationOptionList);
+    } else {
+        completionHandler(UNNotificationPresentationOptionAlert);
+    }
+}
+
+- (void)userNotificationCenter:(UNUserNotificationCenter *)center
+didReceiveNotificationResponse:(UNNotificationResponse *)response
+         withCompletionHandler:(void (^)(void))completionHandler {
+    if ([response.actionIdentifier isEqualToString:UNNotificationDefaultActionIdentifier]) {
+        NSNumber *notificationID = response.notification.request.content.userInfo[meshgoNotificationIDKey];
+        if (notificationID != nil) {
+            meshgoNotificationClicked([notificationID unsignedLongLongValue]);
+        }
+    }
+    completionHandler();
+}
+
+@end
+
+static MeshgoNotificationDelegate *meshgoNotificationDelegate = nil;
+
+bool meshgoNotificationsBundled(void) {
+    return [[NSBundle mainBundle] bundleIdentifier] != nil;
+}
+
+void meshgoNotificationsStart(void) {
+    UNUserNotificationCenter *center = [UNUserNotificationCenter currentNotificationCenter];
+    if (meshgoNotificationDelegate == nil) {
+        meshgoNotificationDelegate = [[MeshgoNotificationDelegate alloc] init];
+    }
+    center.delegate = meshgoNotificationDelegate;
+    // The app plays its own sounds, so notifications only ask for banners.
+    [center requestAuthorizationWithOptions:UNAuthorizationOptionAlert
+                          completionHandler:^(BOOL granted, NSError *_Nullable error) {
+        meshgoNotificationsAuthorized(granted, error == nil ? NULL : (char *)[[error localizedDescription] UTF8String]);
+    }];
+}
+
+void meshgoNotificationsSend(unsigned long long notificationID, char *cTitle, char *cBody, char *cThread) {
+    @autoreleasepool {
+        UNMutableNotificationContent *content = [[[UNMutableNotificationContent alloc] init] autorelease];
+        content.title = [NSString stringWithUTF8String:cTitle];
+        content.body = [NSString stringWithUTF8String:cBody];
+        content.threadIdentifier = [NSString stringWithUTF8String:cThread];
+        content.userInfo = @{meshgoNotificationIDKey: @(notificationID)};
+        NSString *identifier = [NSString stringWithFormat:@"meshgo-%llu", notificationID];
+        UNNotificationRequest *request = [UNNotificationRequest requestWithIdentifier:identifier content:content trigger:nil];
+        [[UNUserNotificationCenter currentNotificationCenter] addNotificationRequest:request
+                                                               withCompletionHandler:^(NSError *_Nullable error) {
+            if (error != nil) {
+                NSLog(@"meshgo: could not show notification: %@", error);
+            }
+        }];
+    }
+}
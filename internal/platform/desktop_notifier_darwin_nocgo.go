@@ -0,0 +1,9 @@
+//go:build darwin && !cgo
+
+package platform
+
+// Without cgo there is no way to the notification center, so AppleScript shows the
+// notifications.
+func newDesktopNotifier(string) (DesktopNotifier, error) {
+	return osascriptNotifier{start: startCommandReaped}, nil
+}
@@ -0,0 +1,27 @@
+package platform
+
+// osascriptNotifier shows notifications through AppleScript, which works for binaries
+// outside of an app bundle. The notifications can't report clicks.
+type osascriptNotifier struct {
+	start commandStarter
+}
+
+func (n osascriptNotifier) Notify(title, body, _ string, _ func()) error {
+	spec := osascriptNotificationCommand(title, body)
+
+	return n.start(spec.name, spec.args...)
+}
+
+func (osascriptNotifier) Close() error {
+	return nil
+}
+
+// osascriptNotificationCommand passes the texts as arguments, so they need no escaping.
+func osascriptNotificationCommand(title, body string) commandSpec {
+	return commandSpec{name: "osascript", args: []string{
+		"-e", "on run argv",
+		"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+		"-e", "end run",
+		title, body,
+	}}
+}
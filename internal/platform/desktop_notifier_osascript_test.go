@@ -0,0 +1,18 @@
+package platform
+
+import "testing"
+
+func TestOsascriptNotifierPassesTextsAsArguments(t *testing.T) {
+	var got []string
+	notifier := osascriptNotifier{start: func(name string, args ...string) error {
+		got = append([]string{name}, args...)
+
+		return nil
+	}}
+	if err := notifier.Notify(`say "hi"`, "line\nbreak", "", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) < 2 || got[0] != "osascript" || got[len(got)-2] != `say "hi"` || got[len(got)-1] != "line\nbreak" {
+		t.Fatalf("expected the texts to be passed as arguments, got %q", got)
+	}
+}
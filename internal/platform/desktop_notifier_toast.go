@@ -0,0 +1,175 @@
+package platform
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type toastRequest struct {
+	ID    uint64 `json:"id"`
+	Group string `json:"group"`
+	XML   string `json:"xml"`
+}
+
+// toastXML builds a toast with the title and the body. A toast with a click action also
+// gets an "Open" button.
+func toastXML(title, body string, clickable bool) string {
+	var b strings.Builder
+	b.WriteString(`<toast activationType="foreground" launch="open"><visual><binding template="ToastGeneric">`)
+	for _, text := range []string{title, body} {
+		if text == "" {
+			continue
+		}
+		b.WriteString("<text>")
+		writeXMLText(&b, text)
+		b.WriteString("</text>")
+	}
+	b.WriteString("</binding></visual>")
+	if clickable {
+		b.WriteString(`<actions><action content="Open" arguments="open" activationType="foreground"/></actions>`)
+	}
+	b.WriteString("</toast>")
+
+	return b.String()
+}
+
+func writeXMLText(b *strings.Builder, text string) {
+	for _, r := range text {
+		switch r {
+		case '&':
+			b.WriteString("&amp;")
+		case '<':
+			b.WriteString("&lt;")
+		case '>':
+			b.WriteString("&gt;")
+		case '"':
+			b.WriteString("&quot;")
+		case '\'':
+			b.WriteString("&apos;")
+		default:
+			// XML 1.0 has no way to carry most control characters, not even escaped.
+			if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+}
+
+// toastGroupMaxLen is the longest group the Action Center accepts.
+const toastGroupMaxLen = 64
+
+// toastGroup returns the Action Center group of a toast: its notification group, or the
+// group of the app for toasts outside of one. Groups too long for the Action Center are
+// replaced with their hash.
+func toastGroup(appGroup, group string) string {
+	switch {
+	case group == "":
+		return appGroup
+	case len(group) <= toastGroupMaxLen:
+		return group
+	default:
+		sum := fnv.New64a()
+		_, _ = sum.Write([]byte(group))
+
+		return strconv.FormatUint(sum.Sum64(), 16)
+	}
+}
+
+// toastNotifier talks to the toast host process over its standard input and output.
+type toastNotifier struct {
+	group string
+	close func() error
+
+	writeMu  sync.Mutex
+	requests io.WriteCloser
+
+	clicks notificationClickHandlers
+
+	mu     sync.Mutex
+	exited bool
+}
+
+func newToastNotifier(group string, requests io.WriteCloser, events io.Reader, closeHost func() error) *toastNotifier {
+	n := &toastNotifier{
+		group:    group,
+		close:    closeHost,
+		requests: requests,
+	}
+	go n.dispatchEvents(events)
+
+	return n
+}
+
+func (n *toastNotifier) Notify(title, body, group string, onClick func()) error {
+	n.mu.Lock()
+	exited := n.exited
+	n.mu.Unlock()
+	if exited {
+		return errors.New("toast host is not running")
+	}
+	// Keep the handler before sending the toast, so its events can't arrive ahead of it.
+	id := n.clicks.add(onClick)
+	line, err := json.Marshal(toastRequest{ID: id, Group: toastGroup(n.group, group), XML: toastXML(title, body, onClick != nil)})
+	if err != nil {
+		n.clicks.take(id)
+
+		return fmt.Errorf("encode toast: %w", err)
+	}
+
+	n.writeMu.Lock()
+	defer n.writeMu.Unlock()
+	if _, err := n.requests.Write(append(line, '\n')); err != nil {
+		n.clicks.take(id)
+
+		return fmt.Errorf("send toast: %w", err)
+	}
+
+	return nil
+}
+
+func (n *toastNotifier) Close() error {
+	n.writeMu.Lock()
+	err := n.requests.Close()
+	n.writeMu.Unlock()
+	if n.close != nil {
+		err = errors.Join(err, n.close())
+	}
+
+	return err
+}
+
+// dispatchEvents runs click handlers until the host exits.
+func (n *toastNotifier) dispatchEvents(events io.Reader) {
+	scanner := bufio.NewScanner(events)
+	for scanner.Scan() {
+		kind, rest, _ := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		rawID, message, _ := strings.Cut(rest, " ")
+		id, err := strconv.ParseUint(rawID, 10, 64)
+		if err != nil {
+			continue
+		}
+		switch kind {
+		case "activated":
+			if fn := n.clicks.take(id); fn != nil {
+				fn()
+			}
+		case "dismissed":
+			n.clicks.take(id)
+		case "failed":
+			n.clicks.take(id)
+			slog.Warn("toast notification failed", "error", message)
+		}
+	}
+	n.mu.Lock()
+	n.exited = true
+	n.mu.Unlock()
+}
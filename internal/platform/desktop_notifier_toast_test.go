@@ -0,0 +1,96 @@
+package platform
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestToastXML(t *testing.T) {
+	got := toastXML(`Bob & "Alice"`, "<hi>\a", true)
+	want := `<toast activationType="foreground" launch="open"><visual><binding template="ToastGeneric">` +
+		`<text>Bob &amp; &quot;Alice&quot;</text><text>&lt;hi&gt;</text></binding></visual>` +
+		`<actions><action content="Open" arguments="open" activationType="foreground"/></actions></toast>`
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	if got := toastXML("Title", "", false); got != `<toast activationType="foreground" launch="open"><visual><binding template="ToastGeneric"><text>Title</text></binding></visual></toast>` {
+		t.Fatalf("expected a toast without actions, got %s", got)
+	}
+}
+
+func TestToastGroup(t *testing.T) {
+	long := strings.Repeat("x", toastGroupMaxLen+1)
+	tests := []struct {
+		name  string
+		group string
+		want  string
+	}{
+		{name: "no group", group: "", want: "meshgo"},
+		{name: "short group", group: "chat:dm:!0000002a", want: "chat:dm:!0000002a"},
+		{name: "long group", group: long, want: "7d70a52549704607"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := toastGroup("meshgo", tc.group)
+			if got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+			if len(got) > toastGroupMaxLen {
+				t.Fatalf("expected at most %d characters, got %d", toastGroupMaxLen, len(got))
+			}
+		})
+	}
+}
+
+func TestToastNotifierRunsClickHandlers(t *testing.T) {
+	requestsReader, requestsWriter := io.Pipe()
+	eventsReader, eventsWriter := io.Pipe()
+	notifier := newToastNotifier("meshgo", requestsWriter, eventsReader, nil)
+	requests := bufio.NewScanner(requestsReader)
+	nextRequest := func() toastRequest {
+		if !requests.Scan() {
+			t.Fatalf("expected a toast request")
+		}
+		var request toastRequest
+		if err := json.Unmarshal(requests.Bytes(), &request); err != nil {
+			t.Fatalf("decode toast request: %v", err)
+		}
+
+		return request
+	}
+
+	clicks := make(chan string, 2)
+	go func() { _ = notifier.Notify("first", "", "", func() { clicks <- "first" }) }()
+	first := nextRequest()
+	go func() { _ = notifier.Notify("second", "", "chat:channel:0", func() { clicks <- "second" }) }()
+	second := nextRequest()
+	if first.Group != "meshgo" || second.Group != "chat:channel:0" || first.ID == second.ID {
+		t.Fatalf("expected toasts in their groups with their own IDs, got %+v and %+v", first, second)
+	}
+
+	_, _ = fmt.Fprintf(eventsWriter, "dismissed %d\nactivated %d\nactivated %d\n", first.ID, first.ID, second.ID)
+	select {
+	case got := <-clicks:
+		if got != "second" {
+			t.Fatalf("expected only the second toast to be clicked, got %q", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the click handler to run")
+	}
+
+	go func() { _, _ = io.Copy(io.Discard, requestsReader) }()
+	_ = eventsWriter.Close()
+	deadline := time.Now().Add(time.Second)
+	for notifier.Notify("late", "", "", nil) == nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected an error once the toast host exited")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !linux && !windows && !darwin
+
+package platform
+
+// Other platforms keep using the notifications of the UI toolkit.
+func newDesktopNotifier(string) (DesktopNotifier, error) {
+	return nil, ErrDesktopNotifierUnsupported
+}
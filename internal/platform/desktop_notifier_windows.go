@@ -0,0 +1,113 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+)
+
+// toastHostScript shows Windows toasts in a long-lived PowerShell process. It reads one
+// JSON request per line and reports toast events as "activated <id>" and
+// "dismissed <id>" lines. Toasts that time out stay clickable in the Action Center, so
+// only a dismissal by the user or the app is reported.
+const toastHostScript = `
+$ErrorActionPreference = 'Stop'
+[Console]::InputEncoding = [Text.Encoding]::UTF8
+[Console]::OutputEncoding = [Text.Encoding]::UTF8
+$null = [Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime]
+$null = [Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom, ContentType = WindowsRuntime]
+$notifier = [Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($env:MESHGO_TOAST_APP_ID)
+$pending = [Console]::In.ReadLineAsync()
+while ($true) {
+	if ($pending.IsCompleted) {
+		$line = $pending.Result
+		if ($null -eq $line) { break }
+		$request = ConvertFrom-Json $line
+		try {
+			$xml = New-Object Windows.Data.Xml.Dom.XmlDocument
+			$xml.LoadXml($request.xml)
+			$toast = New-Object Windows.UI.Notifications.ToastNotification $xml
+			$toast.Tag = [string]$request.id
+			$toast.Group = $request.group
+			$null = Register-ObjectEvent -InputObject $toast -EventName Activated -SourceIdentifier "activated $($request.id)"
+			$null = Register-ObjectEvent -InputObject $toast -EventName Dismissed -SourceIdentifier "dismissed $($request.id)"
+			$notifier.Show($toast)
+		} catch {
+			[Console]::Out.WriteLine("failed $($request.id) $($_.Exception.Message)")
+		}
+		$pending = [Console]::In.ReadLineAsync()
+	}
+	foreach ($event in @(Get-Event)) {
+		$kind, $id = $event.SourceIdentifier.Split(' ')
+		Remove-Event -EventIdentifier $event.EventIdentifier
+		if ($kind -eq 'dismissed' -and $event.SourceEventArgs.Reason -eq [Windows.UI.Notifications.ToastDismissalReason]::TimedOut) {
+			continue
+		}
+		Unregister-Event -SourceIdentifier "activated $id" -ErrorAction SilentlyContinue
+		Unregister-Event -SourceIdentifier "dismissed $id" -ErrorAction SilentlyContinue
+		[Console]::Out.WriteLine("$kind $id")
+	}
+	Start-Sleep -Milliseconds 100
+}
+`
+
+// newDesktopNotifier shows WinRT toasts under an app ID registered for the current
+// user, so they are grouped under the app name and kept in the Action Center. Clicks
+// are reported while the app runs.
+func newDesktopNotifier(appName string) (DesktopNotifier, error) {
+	appID := normalizeInstanceLockComponent(appName, "app")
+	if err := registerToastAppID(appID, appName); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDesktopNotifierUnsupported, err)
+	}
+	powershell, err := exec.LookPath("powershell")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDesktopNotifierUnsupported, err)
+	}
+
+	// #nosec G204 -- the script is a constant and the app ID is passed via the environment.
+	cmd := exec.Command(powershell, "-NoProfile", "-NonInteractive", "-ExecutionPolicy", "Bypass", "-Command", toastHostScript)
+	cmd.Env = append(os.Environ(), "MESHGO_TOAST_APP_ID="+appID)
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true, CreationFlags: windows.CREATE_NO_WINDOW}
+	requests, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("open toast host input: %w", err)
+	}
+	events, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("open toast host output: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("%w: start toast host: %v", ErrDesktopNotifierUnsupported, err)
+	}
+
+	return newToastNotifier(appID, requests, events, func() error {
+		// The host exits once its input is closed.
+		if err := cmd.Wait(); err != nil {
+			return fmt.Errorf("wait for toast host: %w", err)
+		}
+
+		return nil
+	}), nil
+}
+
+// registerToastAppID registers the app ID toasts are shown for. Unpackaged apps need it
+// to show toasts under their own name instead of the one of PowerShell.
+func registerToastAppID(appID, displayName string) error {
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, `Software\Classes\AppUserModelId\`+appID, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("create app ID key: %w", err)
+	}
+	defer func() { _ = key.Close() }()
+
+	if err := key.SetStringValue("DisplayName", displayName); err != nil {
+		return fmt.Errorf("set app ID display name: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package platform
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrIdleTimeUnsupported is returned when the system does not report how long the user
+// has been away from the keyboard and mouse.
+var ErrIdleTimeUnsupported = errors.New("user idle time is not supported")
+
+// IdleTime returns how long ago the user last used the keyboard or mouse.
+func IdleTime() (time.Duration, error) {
+	return idleTime()
+}
@@ -0,0 +1,43 @@
+//go:build linux
+
+package platform
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const idleTimeCallTimeout = time.Second
+
+// idleTime asks the desktop session over D-Bus. GNOME exposes the Mutter idle monitor,
+// while KDE and other desktops implement the freedesktop screensaver interface.
+func idleTime() (time.Duration, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return 0, fmt.Errorf("%w: connect to session bus: %v", ErrIdleTimeUnsupported, err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), idleTimeCallTimeout)
+	defer cancel()
+
+	var idleMillis uint64
+	mutterErr := conn.Object("org.gnome.Mutter.IdleMonitor", "/org/gnome/Mutter/IdleMonitor/Core").
+		CallWithContext(ctx, "org.gnome.Mutter.IdleMonitor.GetIdletime", 0).
+		Store(&idleMillis)
+	if mutterErr == nil {
+		return time.Duration(idleMillis) * time.Millisecond, nil
+	}
+
+	var idleSeconds uint32
+	screenSaverErr := conn.Object("org.freedesktop.ScreenSaver", "/org/freedesktop/ScreenSaver").
+		CallWithContext(ctx, "org.freedesktop.ScreenSaver.GetSessionIdleTime", 0).
+		Store(&idleSeconds)
+	if screenSaverErr == nil {
+		return time.Duration(idleSeconds) * time.Second, nil
+	}
+
+	return 0, fmt.Errorf("%w: %v", ErrIdleTimeUnsupported, errors.Join(mutterErr, screenSaverErr))
+}
@@ -0,0 +1,9 @@
+//go:build !linux && !windows
+
+package platform
+
+import "time"
+
+func idleTime() (time.Duration, error) {
+	return 0, ErrIdleTimeUnsupported
+}
@@ -0,0 +1,35 @@
+//go:build windows
+
+package platform
+
+import (
+	"fmt"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	user32               = windows.NewLazySystemDLL("user32.dll")
+	procGetLastInputInfo = user32.NewProc("GetLastInputInfo")
+	kernel32             = windows.NewLazySystemDLL("kernel32.dll")
+	procGetTickCount     = kernel32.NewProc("GetTickCount")
+)
+
+type lastInputInfo struct {
+	cbSize uint32
+	dwTime uint32
+}
+
+func idleTime() (time.Duration, error) {
+	info := lastInputInfo{cbSize: uint32(unsafe.Sizeof(lastInputInfo{}))}
+	r, _, callErr := procGetLastInputInfo.Call(uintptr(unsafe.Pointer(&info)))
+	if r == 0 {
+		return 0, fmt.Errorf("get last input info: %w", callErr)
+	}
+	now, _, _ := procGetTickCount.Call()
+
+	// Both values are 32-bit tick counts, so the subtraction stays correct across wraparound.
+	return time.Duration(uint32(now)-info.dwTime) * time.Millisecond, nil
+}
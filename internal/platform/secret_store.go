@@ -0,0 +1,20 @@
+package platform
+
+import "errors"
+
+// secretStoreService groups meshgo secrets in the OS keyring.
+const secretStoreService = "meshgo"
+
+// ErrSecretNotFound is returned when the keyring holds no secret with the requested name.
+var ErrSecretNotFound = errors.New("secret not found in keyring")
+
+// SecretStore keeps small secrets, such as the database key, in the OS keyring.
+type SecretStore interface {
+	Get(name string) (string, error)
+	Set(name, value string) error
+	Delete(name string) error
+}
+
+func NewSecretStore() SecretStore {
+	return newSecretStore()
+}
@@ -0,0 +1,27 @@
+//go:build linux || darwin
+
+package platform
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// commandRunner runs a command with stdin and returns its stdout.
+type commandRunner func(stdin string, name string, args ...string) ([]byte, error)
+
+func runCommand(stdin string, name string, args ...string) ([]byte, error) {
+	// #nosec G204 -- the command name is a fixed tool and arguments are not shell-interpreted.
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil && stderr.Len() > 0 {
+		return out, fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return out, err
+}
@@ -0,0 +1,87 @@
+//go:build darwin
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// keychainItemNotFound is the exit status of the security tool when no keychain item
+// matches, errSecItemNotFound.
+const keychainItemNotFound = 44
+
+// darwinSecretStore keeps secrets as generic passwords in the login keychain through the
+// security tool, which handles unlock prompts itself.
+type darwinSecretStore struct {
+	run commandRunner
+}
+
+func newSecretStore() SecretStore {
+	return darwinSecretStore{run: runCommand}
+}
+
+func (s darwinSecretStore) Get(name string) (string, error) {
+	out, err := s.run("", "security", "find-generic-password", "-s", secretStoreService, "-a", name, "-w")
+	if isKeychainItemNotFound(err) {
+		return "", ErrSecretNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("read %s from keychain: %w", name, err)
+	}
+	value := strings.TrimRight(string(out), "\n")
+	if value == "" {
+		return "", ErrSecretNotFound
+	}
+
+	return value, nil
+}
+
+// Set passes the command to an interactive security session on its input, so the
+// secret never shows up in the process list.
+func (s darwinSecretStore) Set(name, value string) error {
+	label := fmt.Sprintf("%s %s", secretStoreService, name)
+	command := strings.Join([]string{
+		"add-generic-password", "-U",
+		"-s", quoteSecurityArg(secretStoreService),
+		"-a", quoteSecurityArg(name),
+		"-l", quoteSecurityArg(label),
+		"-w", quoteSecurityArg(value),
+	}, " ")
+	if _, err := s.run(command+"\n", "security", "-i"); err != nil {
+		return fmt.Errorf("store %s in keychain: %w", name, err)
+	}
+
+	return nil
+}
+
+func (s darwinSecretStore) Delete(name string) error {
+	if _, err := s.run("", "security", "delete-generic-password", "-s", secretStoreService, "-a", name); err != nil {
+		if isKeychainItemNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("remove %s from keychain: %w", name, err)
+	}
+
+	return nil
+}
+
+// quoteSecurityArg quotes an argument for an interactive security session, which splits
+// its commands on spaces.
+func quoteSecurityArg(arg string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+	return `"` + replacer.Replace(arg) + `"`
+}
+
+func isKeychainItemNotFound(err error) bool {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+
+	return exitErr.ExitCode() == keychainItemNotFound || strings.Contains(err.Error(), "could not be found in the keychain")
+}
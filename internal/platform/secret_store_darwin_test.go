@@ -0,0 +1,87 @@
+//go:build darwin
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+type fakeSecurityTool struct {
+	secrets map[string]string
+	stdin   []string
+}
+
+func (f *fakeSecurityTool) run(stdin string, name string, args ...string) ([]byte, error) {
+	notFound := fmt.Errorf("%w: security: SecKeychainSearchCopyNext: The specified item could not be found in the keychain.", &exec.ExitError{})
+	switch args[0] {
+	case "find-generic-password":
+		value, ok := f.secrets[args[4]]
+		if !ok {
+			return nil, notFound
+		}
+
+		return []byte(value + "\n"), nil
+	case "-i":
+		f.stdin = append(f.stdin, stdin)
+		fields := strings.Fields(stdin)
+		f.secrets[strings.Trim(fields[5], `"`)] = strings.Trim(fields[len(fields)-1], `"`)
+
+		return nil, nil
+	case "delete-generic-password":
+		if _, ok := f.secrets[args[4]]; !ok {
+			return nil, notFound
+		}
+		delete(f.secrets, args[4])
+
+		return nil, nil
+	default:
+		return nil, errors.New("unexpected command")
+	}
+}
+
+func TestDarwinSecretStoreRoundTrip(t *testing.T) {
+	tool := &fakeSecurityTool{secrets: map[string]string{}}
+	store := darwinSecretStore{run: tool.run}
+
+	if _, err := store.Get("database-key"); !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected missing secret, got %v", err)
+	}
+	if err := store.Set("database-key", "c2VjcmV0"); err != nil {
+		t.Fatalf("set secret: %v", err)
+	}
+	got, err := store.Get("database-key")
+	if err != nil || got != "c2VjcmV0" {
+		t.Fatalf("unexpected secret %q, err %v", got, err)
+	}
+	if err := store.Delete("database-key"); err != nil {
+		t.Fatalf("delete secret: %v", err)
+	}
+	if err := store.Delete("database-key"); err != nil {
+		t.Fatalf("deleting a missing secret should succeed, got %v", err)
+	}
+
+	wantStdin := `add-generic-password -U -s "meshgo" -a "database-key" -l "meshgo database-key" -w "c2VjcmV0"` + "\n"
+	if len(tool.stdin) != 1 || tool.stdin[0] != wantStdin {
+		t.Fatalf("unexpected security session input: %q", tool.stdin)
+	}
+}
+
+func TestDarwinSecretStoreReportsToolFailures(t *testing.T) {
+	store := darwinSecretStore{run: func(string, string, ...string) ([]byte, error) {
+		return nil, exec.ErrNotFound
+	}}
+
+	if _, err := store.Get("database-key"); err == nil || errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected missing security tool to be reported, got %v", err)
+	}
+}
+
+func TestQuoteSecurityArg(t *testing.T) {
+	if got := quoteSecurityArg(`a "b" \c`); got != `"a \"b\" \\c"` {
+		t.Fatalf("unexpected quoted argument %s", got)
+	}
+}
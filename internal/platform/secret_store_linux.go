@@ -0,0 +1,61 @@
+//go:build linux
+
+package platform
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// linuxSecretStore uses secret-tool from libsecret, which talks to the Secret Service
+// (GNOME Keyring, KWallet) and handles unlock prompts itself.
+type linuxSecretStore struct {
+	run commandRunner
+}
+
+func newSecretStore() SecretStore {
+	return linuxSecretStore{run: runCommand}
+}
+
+func (s linuxSecretStore) Get(name string) (string, error) {
+	out, err := s.run("", "secret-tool", "lookup", "service", secretStoreService, "account", name)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(bytes.TrimSpace(out)) == 0 {
+		// secret-tool exits with status 1 and no output when nothing matches.
+		return "", ErrSecretNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("read %s from keyring: %w", name, err)
+	}
+	value := strings.TrimRight(string(out), "\n")
+	if value == "" {
+		return "", ErrSecretNotFound
+	}
+
+	return value, nil
+}
+
+func (s linuxSecretStore) Set(name, value string) error {
+	label := fmt.Sprintf("%s %s", secretStoreService, name)
+	if _, err := s.run(value, "secret-tool", "store", "--label="+label, "service", secretStoreService, "account", name); err != nil {
+		return fmt.Errorf("store %s in keyring: %w", name, err)
+	}
+
+	return nil
+}
+
+func (s linuxSecretStore) Delete(name string) error {
+	if _, err := s.run("", "secret-tool", "clear", "service", secretStoreService, "account", name); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil
+		}
+
+		return fmt.Errorf("remove %s from keyring: %w", name, err)
+	}
+
+	return nil
+}
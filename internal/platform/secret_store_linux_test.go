@@ -0,0 +1,79 @@
+//go:build linux
+
+package platform
+
+import (
+	"errors"
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+type fakeSecretTool struct {
+	secrets map[string]string
+	calls   [][]string
+}
+
+func (f *fakeSecretTool) run(stdin string, name string, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, append([]string{name}, args...))
+	account := args[len(args)-1]
+	switch args[0] {
+	case "lookup":
+		value, ok := f.secrets[account]
+		if !ok {
+			return nil, &exec.ExitError{}
+		}
+
+		return []byte(value + "\n"), nil
+	case "store":
+		f.secrets[account] = stdin
+
+		return nil, nil
+	case "clear":
+		if _, ok := f.secrets[account]; !ok {
+			return nil, &exec.ExitError{}
+		}
+		delete(f.secrets, account)
+
+		return nil, nil
+	default:
+		return nil, errors.New("unexpected command")
+	}
+}
+
+func TestLinuxSecretStoreRoundTrip(t *testing.T) {
+	tool := &fakeSecretTool{secrets: map[string]string{}}
+	store := linuxSecretStore{run: tool.run}
+
+	if _, err := store.Get("database-key"); !errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected missing secret, got %v", err)
+	}
+	if err := store.Set("database-key", "c2VjcmV0"); err != nil {
+		t.Fatalf("set secret: %v", err)
+	}
+	got, err := store.Get("database-key")
+	if err != nil || got != "c2VjcmV0" {
+		t.Fatalf("unexpected secret %q, err %v", got, err)
+	}
+	if err := store.Delete("database-key"); err != nil {
+		t.Fatalf("delete secret: %v", err)
+	}
+	if err := store.Delete("database-key"); err != nil {
+		t.Fatalf("deleting a missing secret should succeed, got %v", err)
+	}
+
+	wantStore := []string{"secret-tool", "store", "--label=meshgo database-key", "service", "meshgo", "account", "database-key"}
+	if !slices.Equal(tool.calls[1], wantStore) {
+		t.Fatalf("unexpected store command: %v", tool.calls[1])
+	}
+}
+
+func TestLinuxSecretStoreReportsToolFailures(t *testing.T) {
+	store := linuxSecretStore{run: func(string, string, ...string) ([]byte, error) {
+		return nil, exec.ErrNotFound
+	}}
+
+	if _, err := store.Get("database-key"); err == nil || errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("expected missing secret-tool to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+//go:build !linux && !windows && !darwin
+
+package platform
+
+import (
+	"fmt"
+	"runtime"
+)
+
+type unsupportedSecretStore struct{}
+
+func newSecretStore() SecretStore {
+	return unsupportedSecretStore{}
+}
+
+func (unsupportedSecretStore) Get(string) (string, error) {
+	return "", fmt.Errorf("keyring is not supported on %s", runtime.GOOS)
+}
+
+func (unsupportedSecretStore) Set(string, string) error {
+	return fmt.Errorf("keyring is not supported on %s", runtime.GOOS)
+}
+
+func (unsupportedSecretStore) Delete(string) error {
+	return fmt.Errorf("keyring is not supported on %s", runtime.GOOS)
+}
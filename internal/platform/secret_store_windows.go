@@ -0,0 +1,119 @@
+//go:build windows
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	credTypeGeneric          = 1
+	credPersistLocalMachine  = 2
+	windowsErrorNotFound     = windows.Errno(1168)
+	windowsCredentialPrefix  = secretStoreService + ":"
+	windowsCredentialComment = "Stored by meshgo"
+)
+
+var (
+	advapi32       = windows.NewLazySystemDLL("advapi32.dll")
+	procCredReadW  = advapi32.NewProc("CredReadW")
+	procCredWriteW = advapi32.NewProc("CredWriteW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+// windowsCredential mirrors the Win32 CREDENTIALW structure.
+type windowsCredential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        windows.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// windowsSecretStore keeps secrets as generic credentials in Windows Credential Manager.
+type windowsSecretStore struct{}
+
+func newSecretStore() SecretStore {
+	return windowsSecretStore{}
+}
+
+func (windowsSecretStore) Get(name string) (string, error) {
+	target, err := windows.UTF16PtrFromString(windowsCredentialPrefix + name)
+	if err != nil {
+		return "", err
+	}
+	var credential *windowsCredential
+	r, _, callErr := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&credential)))
+	if r == 0 {
+		if errors.Is(callErr, windowsErrorNotFound) {
+			return "", ErrSecretNotFound
+		}
+
+		return "", fmt.Errorf("read %s from credential manager: %w", name, callErr)
+	}
+	defer func() {
+		_, _, _ = procCredFree.Call(uintptr(unsafe.Pointer(credential)))
+	}()
+
+	blob := unsafe.Slice(credential.CredentialBlob, credential.CredentialBlobSize)
+
+	return string(blob), nil
+}
+
+func (windowsSecretStore) Set(name, value string) error {
+	target, err := windows.UTF16PtrFromString(windowsCredentialPrefix + name)
+	if err != nil {
+		return err
+	}
+	comment, err := windows.UTF16PtrFromString(windowsCredentialComment)
+	if err != nil {
+		return err
+	}
+	userName, err := windows.UTF16PtrFromString(secretStoreService)
+	if err != nil {
+		return err
+	}
+	blob := []byte(value)
+	credential := windowsCredential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		Comment:            comment,
+		CredentialBlobSize: uint32(len(blob)),
+		Persist:            credPersistLocalMachine,
+		UserName:           userName,
+	}
+	if len(blob) > 0 {
+		credential.CredentialBlob = &blob[0]
+	}
+	r, _, callErr := procCredWriteW.Call(uintptr(unsafe.Pointer(&credential)), 0)
+	if r == 0 {
+		return fmt.Errorf("store %s in credential manager: %w", name, callErr)
+	}
+
+	return nil
+}
+
+func (windowsSecretStore) Delete(name string) error {
+	target, err := windows.UTF16PtrFromString(windowsCredentialPrefix + name)
+	if err != nil {
+		return err
+	}
+	r, _, callErr := procCredDelete.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if r == 0 && !errors.Is(callErr, windowsErrorNotFound) {
+		return fmt.Errorf("remove %s from credential manager: %w", name, callErr)
+	}
+
+	return nil
+}
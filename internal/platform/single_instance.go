@@ -11,6 +11,9 @@ var ErrInstanceAlreadyRunning = errors.New("instance already running")
 // ErrInstanceLockUnsupported indicates the current platform has no lock backend implementation.
 var ErrInstanceLockUnsupported = errors.New("instance lock unsupported")
 
+// ErrFileLocked indicates another process holds the advisory lock on a file.
+var ErrFileLocked = errors.New("file is locked by another process")
+
 // InstanceLock represents an acquired single-instance lock.
 type InstanceLock interface {
 	Release() error
@@ -20,6 +23,12 @@ func AcquireInstanceLock(appID string) (InstanceLock, error) {
 	return acquireInstanceLock(normalizeInstanceLockComponent(appID, "app"))
 }
 
+// AcquireFileLock takes an exclusive advisory lock on path, creating the file if needed.
+// It fails with ErrFileLocked instead of waiting when another process holds the lock.
+func AcquireFileLock(path string) (InstanceLock, error) {
+	return acquireFileLock(path)
+}
+
 func normalizeInstanceLockComponent(raw, fallback string) string {
 	raw = strings.TrimSpace(raw)
 	if raw == "" {
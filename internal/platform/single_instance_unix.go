@@ -24,10 +24,20 @@ func acquireInstanceLock(appID string) (InstanceLock, error) {
 		return nil, err
 	}
 
-	// #nosec G304,G703 -- lockPath is built from process-owned runtime/temp directories.
+	return flockExclusive(lockPath, ErrInstanceAlreadyRunning)
+}
+
+func acquireFileLock(path string) (InstanceLock, error) {
+	return flockExclusive(path, ErrFileLocked)
+}
+
+// flockExclusive takes a non-blocking exclusive flock on path and returns contended when
+// another open file description already holds it.
+func flockExclusive(lockPath string, contended error) (InstanceLock, error) {
+	// #nosec G304,G703 -- lockPath is built from process-owned app directories.
 	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0o600)
 	if err != nil {
-		return nil, fmt.Errorf("open instance lock file: %w", err)
+		return nil, fmt.Errorf("open lock file: %w", err)
 	}
 
 	fd, err := syscallFD(file)
@@ -39,10 +49,10 @@ func acquireInstanceLock(appID string) (InstanceLock, error) {
 	if err := syscall.Flock(fd, syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
 		_ = file.Close()
 		if isUnixLockContention(err) {
-			return nil, ErrInstanceAlreadyRunning
+			return nil, contended
 		}
 
-		return nil, fmt.Errorf("acquire instance file lock: %w", err)
+		return nil, fmt.Errorf("acquire file lock: %w", err)
 	}
 
 	return &unixInstanceLock{file: file}, nil
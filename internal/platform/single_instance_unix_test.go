@@ -180,3 +180,26 @@ func runInstanceLockHelperProcess() {
 	_, _ = fmt.Fprintln(os.Stdout, "ready")
 	select {}
 }
+
+func TestAcquireFileLock_ContentionAndRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "database.lock")
+
+	lock1, err := AcquireFileLock(path)
+	if err != nil {
+		t.Fatalf("acquire first lock: %v", err)
+	}
+	if _, err := AcquireFileLock(path); !errors.Is(err, ErrFileLocked) {
+		t.Fatalf("expected %v, got %v", ErrFileLocked, err)
+	}
+	if err := lock1.Release(); err != nil {
+		t.Fatalf("release first lock: %v", err)
+	}
+
+	lock2, err := AcquireFileLock(path)
+	if err != nil {
+		t.Fatalf("acquire lock after release: %v", err)
+	}
+	if err := lock2.Release(); err != nil {
+		t.Fatalf("release second lock: %v", err)
+	}
+}
@@ -10,3 +10,7 @@ import (
 func acquireInstanceLock(_ string) (InstanceLock, error) {
 	return nil, fmt.Errorf("%w on %s", ErrInstanceLockUnsupported, runtime.GOOS)
 }
+
+func acquireFileLock(_ string) (InstanceLock, error) {
+	return nil, fmt.Errorf("%w on %s", ErrInstanceLockUnsupported, runtime.GOOS)
+}
@@ -0,0 +1,81 @@
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// SoundPlayer plays WAV files with a player the system ships.
+type SoundPlayer interface {
+	// Play starts playing the file and returns without waiting for it to finish.
+	Play(path string) error
+}
+
+func NewSoundPlayer() SoundPlayer {
+	return commandSoundPlayer{goos: runtime.GOOS, lookPath: exec.LookPath, start: startCommandReaped}
+}
+
+type commandSoundPlayer struct {
+	goos     string
+	lookPath func(file string) (string, error)
+	start    commandStarter
+}
+
+func (p commandSoundPlayer) Play(path string) error {
+	commands, err := soundPlayerCommandsForOS(p.goos, path)
+	if err != nil {
+		return err
+	}
+	for _, spec := range commands {
+		if _, err := p.lookPath(spec.name); err != nil {
+			continue
+		}
+		if err := p.start(spec.name, spec.args...); err != nil {
+			return fmt.Errorf("%s: %w", spec.name, err)
+		}
+
+		return nil
+	}
+
+	return errors.New("no sound player found")
+}
+
+func soundPlayerCommandsForOS(goos, path string) ([]commandSpec, error) {
+	switch strings.ToLower(strings.TrimSpace(goos)) {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return []commandSpec{
+			{name: "pw-play", args: []string{path}},
+			{name: "paplay", args: []string{path}},
+			{name: "aplay", args: []string{"-q", path}},
+		}, nil
+	case "darwin":
+		return []commandSpec{{name: "afplay", args: []string{path}}}, nil
+	case "windows":
+		script := "(New-Object Media.SoundPlayer '" + strings.ReplaceAll(path, "'", "''") + "').PlaySync()"
+
+		return []commandSpec{{name: "powershell", args: []string{"-NoProfile", "-NonInteractive", "-Command", script}}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported operating system: %s", goos)
+	}
+}
+
+// startCommandReaped starts a command and waits for it in the background, so short
+// commands started often don't pile up as zombie processes.
+func startCommandReaped(name string, args ...string) error {
+	// #nosec G204 -- command specs are selected from static per-OS allowlisted command tables.
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() {
+		if err := cmd.Wait(); err != nil {
+			slog.Debug("command exited with error", "command", name, "error", err)
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package platform
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSoundPlayerCommandsForOS(t *testing.T) {
+	linux, err := soundPlayerCommandsForOS("linux", "/tmp/a.wav")
+	if err != nil {
+		t.Fatalf("unexpected linux commands error: %v", err)
+	}
+	if len(linux) != 3 || linux[0].name != "pw-play" {
+		t.Fatalf("unexpected linux commands: %+v", linux)
+	}
+
+	windows, err := soundPlayerCommandsForOS("windows", `C:\Users\O'Brien\a.wav`)
+	if err != nil {
+		t.Fatalf("unexpected windows commands error: %v", err)
+	}
+	script := windows[0].args[len(windows[0].args)-1]
+	if !strings.Contains(script, `'C:\Users\O''Brien\a.wav'`) {
+		t.Fatalf("expected the path to be quoted, got %q", script)
+	}
+
+	if _, err := soundPlayerCommandsForOS("plan9", "/tmp/a.wav"); err == nil {
+		t.Fatalf("expected unsupported os error")
+	}
+}
+
+func TestCommandSoundPlayer_UsesFirstAvailablePlayer(t *testing.T) {
+	var started []string
+	player := commandSoundPlayer{
+		goos: "linux",
+		lookPath: func(file string) (string, error) {
+			if file == "pw-play" {
+				return "", errors.New("not found")
+			}
+
+			return "/usr/bin/" + file, nil
+		},
+		start: func(name string, args ...string) error {
+			started = append(started, name+" "+strings.Join(args, " "))
+
+			return nil
+		},
+	}
+
+	if err := player.Play("/tmp/a.wav"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(started) != 1 || started[0] != "paplay /tmp/a.wav" {
+		t.Fatalf("expected paplay to be started, got %v", started)
+	}
+
+	player.lookPath = func(string) (string, error) { return "", errors.New("not found") }
+	if err := player.Play("/tmp/a.wav"); err == nil {
+		t.Fatalf("expected an error without players")
+	}
+}
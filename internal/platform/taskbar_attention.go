@@ -0,0 +1,21 @@
+package platform
+
+import "errors"
+
+// ErrTaskbarAttentionUnsupported is returned when the desktop cannot highlight the app
+// in its taskbar or dock.
+var ErrTaskbarAttentionUnsupported = errors.New("taskbar attention is not supported")
+
+// TaskbarAttention highlights the app's taskbar entry until the user comes back to it.
+type TaskbarAttention interface {
+	// Request starts highlighting. window is the native window handle, or 0 when the
+	// window system does not expose one.
+	Request(window uintptr) error
+	// Cancel stops highlighting.
+	Cancel(window uintptr) error
+}
+
+// NewTaskbarAttention prepares taskbar highlighting for the app with the given ID.
+func NewTaskbarAttention(appID string) (TaskbarAttention, error) {
+	return newTaskbarAttention(appID)
+}
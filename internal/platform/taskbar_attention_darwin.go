@@ -0,0 +1,53 @@
+//go:build darwin && cgo
+
+package platform
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework AppKit
+
+#import <AppKit/AppKit.h>
+
+static long meshgoRequestUserAttention(void) {
+	return (long)[NSApp requestUserAttention:NSCriticalRequest];
+}
+
+static void meshgoCancelUserAttention(long request) {
+	[NSApp cancelUserAttentionRequest:(NSInteger)request];
+}
+*/
+import "C"
+
+import "sync"
+
+// darwinTaskbarAttention bounces the dock icon until the app is activated. macOS only
+// bounces it while the app is inactive, and stops on its own when the user comes back.
+type darwinTaskbarAttention struct {
+	mu      sync.Mutex
+	request C.long
+}
+
+func newTaskbarAttention(string) (TaskbarAttention, error) {
+	return &darwinTaskbarAttention{}, nil
+}
+
+// Request must run on the main thread, as AppKit requires.
+func (a *darwinTaskbarAttention) Request(uintptr) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.request = C.meshgoRequestUserAttention()
+
+	return nil
+}
+
+// Cancel must run on the main thread, as AppKit requires.
+func (a *darwinTaskbarAttention) Cancel(uintptr) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.request != 0 {
+		C.meshgoCancelUserAttention(a.request)
+		a.request = 0
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+//go:build linux
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	launcherEntryPath   = dbus.ObjectPath("/com/canonical/unity/launcherentry/meshgo")
+	launcherEntryUpdate = "com.canonical.Unity.LauncherEntry.Update"
+)
+
+// linuxTaskbarAttention marks the app urgent through the launcher entry API. KDE Plasma,
+// the Ubuntu dock and Dash to Dock honour it on both X11 and Wayland, where a window
+// handle cannot be used for this. On X11 the window also gets the urgency hint, which
+// the other window managers and taskbars show.
+type linuxTaskbarAttention struct {
+	conn    *dbus.Conn
+	appURI  string
+	urgency *x11UrgencyHint
+}
+
+func newTaskbarAttention(appID string) (TaskbarAttention, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("%w: connect to session bus: %v", ErrTaskbarAttentionUnsupported, err)
+	}
+
+	return &linuxTaskbarAttention{
+		conn:    conn,
+		appURI:  "application://" + appID + ".desktop",
+		urgency: newX11UrgencyHint(),
+	}, nil
+}
+
+func (a *linuxTaskbarAttention) Request(window uintptr) error {
+	return a.setUrgent(window, true)
+}
+
+func (a *linuxTaskbarAttention) Cancel(window uintptr) error {
+	return a.setUrgent(window, false)
+}
+
+func (a *linuxTaskbarAttention) setUrgent(window uintptr, urgent bool) error {
+	var err error
+	props := map[string]dbus.Variant{"urgent": dbus.MakeVariant(urgent)}
+	if emitErr := a.conn.Emit(launcherEntryPath, launcherEntryUpdate, a.appURI, props); emitErr != nil {
+		err = fmt.Errorf("update launcher entry: %w", emitErr)
+	}
+	// Wayland windows have no handle; the launcher entry is all there is for them.
+	if window != 0 {
+		if hintErr := a.urgency.set(window, urgent); hintErr != nil {
+			err = errors.Join(err, fmt.Errorf("set urgency hint: %w", hintErr))
+		}
+	}
+
+	return err
+}
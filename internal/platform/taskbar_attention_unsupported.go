@@ -0,0 +1,7 @@
+//go:build !linux && !windows && !(darwin && cgo)
+
+package platform
+
+func newTaskbarAttention(string) (TaskbarAttention, error) {
+	return nil, ErrTaskbarAttentionUnsupported
+}
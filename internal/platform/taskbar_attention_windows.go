@@ -0,0 +1,59 @@
+//go:build windows
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+var procFlashWindowEx = user32.NewProc("FlashWindowEx")
+
+const (
+	flashwStop = 0x0
+	flashwTray = 0x2
+	// flashwTimerNoFG keeps flashing until the window comes to the foreground.
+	flashwTimerNoFG = 0xC
+)
+
+type flashWInfo struct {
+	cbSize    uint32
+	hwnd      uintptr
+	dwFlags   uint32
+	uCount    uint32
+	dwTimeout uint32
+}
+
+type windowsTaskbarAttention struct{}
+
+func newTaskbarAttention(string) (TaskbarAttention, error) {
+	if err := procFlashWindowEx.Find(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTaskbarAttentionUnsupported, err)
+	}
+
+	return windowsTaskbarAttention{}, nil
+}
+
+func (windowsTaskbarAttention) Request(window uintptr) error {
+	return flashWindow(window, flashwTray|flashwTimerNoFG)
+}
+
+func (windowsTaskbarAttention) Cancel(window uintptr) error {
+	return flashWindow(window, flashwStop)
+}
+
+func flashWindow(window uintptr, flags uint32) error {
+	if window == 0 {
+		return errors.New("window handle is unavailable")
+	}
+	info := flashWInfo{
+		cbSize:  uint32(unsafe.Sizeof(flashWInfo{})),
+		hwnd:    window,
+		dwFlags: flags,
+	}
+	// FlashWindowEx returns the previous window state rather than an error code.
+	_, _, _ = procFlashWindowEx.Call(uintptr(unsafe.Pointer(&info)))
+
+	return nil
+}
@@ -0,0 +1,71 @@
+//go:build linux && cgo && !wayland
+
+package platform
+
+/*
+#cgo LDFLAGS: -lX11
+
+#include <stdlib.h>
+#include <X11/Xlib.h>
+#include <X11/Xutil.h>
+
+static int meshgoSetUrgencyHint(Display *display, Window window, int urgent) {
+	XWMHints *hints = XGetWMHints(display, window);
+	if (hints == NULL) {
+		hints = XAllocWMHints();
+		if (hints == NULL) {
+			return 0;
+		}
+	}
+	if (urgent) {
+		hints->flags |= XUrgencyHint;
+	} else {
+		hints->flags &= ~XUrgencyHint;
+	}
+	XSetWMHints(display, window, hints);
+	XFree(hints);
+	XFlush(display);
+
+	return 1;
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"sync"
+)
+
+// x11UrgencyHint sets the urgency hint of a window, which X11 window managers and
+// taskbars show by highlighting it. It keeps its own connection to the X server, opened
+// on first use.
+type x11UrgencyHint struct {
+	mu      sync.Mutex
+	display *C.Display
+	failed  bool
+}
+
+func newX11UrgencyHint() *x11UrgencyHint {
+	return &x11UrgencyHint{}
+}
+
+func (h *x11UrgencyHint) set(window uintptr, urgent bool) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.display == nil && !h.failed {
+		h.display = C.XOpenDisplay(nil)
+		h.failed = h.display == nil
+	}
+	if h.display == nil {
+		return errors.New("cannot connect to the X server")
+	}
+	flag := C.int(0)
+	if urgent {
+		flag = 1
+	}
+	if C.meshgoSetUrgencyHint(h.display, C.Window(window), flag) == 0 {
+		return errors.New("cannot allocate window hints")
+	}
+
+	return nil
+}
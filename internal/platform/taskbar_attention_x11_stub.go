@@ -0,0 +1,15 @@
+//go:build linux && (!cgo || wayland)
+
+package platform
+
+// x11UrgencyHint does nothing in builds without X11 support; the launcher entry is the
+// only way to ask for attention there.
+type x11UrgencyHint struct{}
+
+func newX11UrgencyHint() *x11UrgencyHint {
+	return &x11UrgencyHint{}
+}
+
+func (*x11UrgencyHint) set(uintptr, bool) error {
+	return nil
+}
@@ -0,0 +1,54 @@
+package platform
+
+import "errors"
+
+// ErrWindowPlacementUnsupported is returned when the window system does not let the app
+// read or move its window, as on Wayland.
+var ErrWindowPlacementUnsupported = errors.New("window placement is not supported")
+
+// windowVisibleMargin is how much of the title bar, in pixels, must be on a monitor for
+// a saved position to be restored.
+const windowVisibleMargin = 48
+
+// WindowRect is a window or monitor rectangle in screen pixels.
+type WindowRect struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// WindowPlacement reads and restores the position and maximized state of a window.
+// window is the native window handle.
+type WindowPlacement interface {
+	// Get returns the bounds the window has when not maximized and whether it is maximized.
+	Get(window uintptr) (WindowRect, bool, error)
+	// Set restores bounds and the maximized state. Bounds off every connected monitor,
+	// such as those of an unplugged display, are ignored.
+	Set(window uintptr, bounds WindowRect, maximized bool) error
+}
+
+// NewWindowPlacement returns the window placement of the current window system.
+func NewWindowPlacement() (WindowPlacement, error) {
+	return newWindowPlacement()
+}
+
+// WindowVisible reports whether enough of the title bar of bounds is on one of monitors
+// for the user to grab it.
+func WindowVisible(bounds WindowRect, monitors []WindowRect) bool {
+	if bounds.Width <= 0 || bounds.Height <= 0 {
+		return false
+	}
+	titleBar := WindowRect{X: bounds.X, Y: bounds.Y, Width: bounds.Width, Height: min(bounds.Height, windowVisibleMargin)}
+	for _, monitor := range monitors {
+		left := max(titleBar.X, monitor.X)
+		right := min(titleBar.X+titleBar.Width, monitor.X+monitor.Width)
+		top := max(titleBar.Y, monitor.Y)
+		bottom := min(titleBar.Y+titleBar.Height, monitor.Y+monitor.Height)
+		if right-left >= windowVisibleMargin && bottom > top {
+			return true
+		}
+	}
+
+	return false
+}
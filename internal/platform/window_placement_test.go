@@ -0,0 +1,30 @@
+package platform
+
+import "testing"
+
+func TestWindowVisible(t *testing.T) {
+	monitors := []WindowRect{
+		{X: 0, Y: 0, Width: 1920, Height: 1080},
+		{X: 1920, Y: 0, Width: 1280, Height: 1024},
+	}
+	tests := []struct {
+		name   string
+		bounds WindowRect
+		want   bool
+	}{
+		{name: "on primary monitor", bounds: WindowRect{X: 100, Y: 100, Width: 1000, Height: 700}, want: true},
+		{name: "on secondary monitor", bounds: WindowRect{X: 2200, Y: 50, Width: 800, Height: 600}, want: true},
+		{name: "spanning both monitors", bounds: WindowRect{X: 1500, Y: 200, Width: 1000, Height: 700}, want: true},
+		{name: "on an unplugged monitor", bounds: WindowRect{X: 3500, Y: 100, Width: 1000, Height: 700}, want: false},
+		{name: "title bar above the screen", bounds: WindowRect{X: 100, Y: -800, Width: 1000, Height: 700}, want: false},
+		{name: "barely on screen", bounds: WindowRect{X: -990, Y: 100, Width: 1000, Height: 700}, want: false},
+		{name: "empty bounds", bounds: WindowRect{}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WindowVisible(tt.bounds, monitors); got != tt.want {
+				t.Fatalf("unexpected visibility: expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !windows
+
+package platform
+
+func newWindowPlacement() (WindowPlacement, error) {
+	return nil, ErrWindowPlacementUnsupported
+}
@@ -0,0 +1,129 @@
+//go:build windows
+
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	procGetWindowPlacement  = user32.NewProc("GetWindowPlacement")
+	procSetWindowPlacement  = user32.NewProc("SetWindowPlacement")
+	procEnumDisplayMonitors = user32.NewProc("EnumDisplayMonitors")
+
+	// Callbacks are never released, so the monitor enumeration shares one.
+	monitorEnumCallback = windows.NewCallback(collectMonitor)
+	monitorEnumMu       sync.Mutex
+	monitorEnumRects    []WindowRect
+)
+
+const (
+	swShowNormal    = 1
+	swShowMaximized = 3
+)
+
+type winPoint struct {
+	x, y int32
+}
+
+type winRect struct {
+	left, top, right, bottom int32
+}
+
+type winWindowPlacement struct {
+	length           uint32
+	flags            uint32
+	showCmd          uint32
+	ptMinPosition    winPoint
+	ptMaxPosition    winPoint
+	rcNormalPosition winRect
+}
+
+type windowsWindowPlacement struct{}
+
+func newWindowPlacement() (WindowPlacement, error) {
+	for _, proc := range []*windows.LazyProc{procGetWindowPlacement, procSetWindowPlacement, procEnumDisplayMonitors} {
+		if err := proc.Find(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrWindowPlacementUnsupported, err)
+		}
+	}
+
+	return windowsWindowPlacement{}, nil
+}
+
+func (windowsWindowPlacement) Get(window uintptr) (WindowRect, bool, error) {
+	if window == 0 {
+		return WindowRect{}, false, errors.New("window handle is unavailable")
+	}
+	placement := winWindowPlacement{length: uint32(unsafe.Sizeof(winWindowPlacement{}))}
+	r, _, callErr := procGetWindowPlacement.Call(window, uintptr(unsafe.Pointer(&placement)))
+	if r == 0 {
+		return WindowRect{}, false, fmt.Errorf("get window placement: %w", callErr)
+	}
+
+	return rectFromWin(placement.rcNormalPosition), placement.showCmd == swShowMaximized, nil
+}
+
+// Set checks bounds against the monitor rectangles. The placement uses workspace
+// coordinates, which differ from them by the size of a taskbar at the top or left;
+// that is well within the visible margin.
+func (windowsWindowPlacement) Set(window uintptr, bounds WindowRect, maximized bool) error {
+	if window == 0 {
+		return errors.New("window handle is unavailable")
+	}
+	placement := winWindowPlacement{length: uint32(unsafe.Sizeof(winWindowPlacement{}))}
+	r, _, callErr := procGetWindowPlacement.Call(window, uintptr(unsafe.Pointer(&placement)))
+	if r == 0 {
+		return fmt.Errorf("get window placement: %w", callErr)
+	}
+	if WindowVisible(bounds, displayMonitors()) {
+		placement.rcNormalPosition = winRect{
+			left:   int32(bounds.X),
+			top:    int32(bounds.Y),
+			right:  int32(bounds.X + bounds.Width),
+			bottom: int32(bounds.Y + bounds.Height),
+		}
+	}
+	placement.showCmd = swShowNormal
+	if maximized {
+		placement.showCmd = swShowMaximized
+	}
+	r, _, callErr = procSetWindowPlacement.Call(window, uintptr(unsafe.Pointer(&placement)))
+	if r == 0 {
+		return fmt.Errorf("set window placement: %w", callErr)
+	}
+
+	return nil
+}
+
+func displayMonitors() []WindowRect {
+	monitorEnumMu.Lock()
+	defer monitorEnumMu.Unlock()
+
+	monitorEnumRects = nil
+	_, _, _ = procEnumDisplayMonitors.Call(0, 0, monitorEnumCallback, 0)
+
+	return monitorEnumRects
+}
+
+func collectMonitor(_, _ uintptr, rect *winRect, _ uintptr) uintptr {
+	if rect != nil {
+		monitorEnumRects = append(monitorEnumRects, rectFromWin(*rect))
+	}
+
+	return 1
+}
+
+func rectFromWin(rect winRect) WindowRect {
+	return WindowRect{
+		X:      int(rect.left),
+		Y:      int(rect.top),
+		Width:  int(rect.right - rect.left),
+		Height: int(rect.bottom - rect.top),
+	}
+}
@@ -0,0 +1,133 @@
+package projections
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// ChatTitleBackfillProjection replaces placeholder DM chat titles (chat key or raw node ID)
+// with node names once they become known, both in the chat store and in storage.
+type ChatTitleBackfillProjection struct {
+	chatStore *domain.ChatStore
+	queue     WriteQueue
+	chatRepo  domain.ChatRepository
+	logger    *slog.Logger
+}
+
+func NewChatTitleBackfillProjection(
+	chatStore *domain.ChatStore,
+	queue WriteQueue,
+	chatRepo domain.ChatRepository,
+	logger *slog.Logger,
+) *ChatTitleBackfillProjection {
+	if logger == nil {
+		logger = slog.Default().With("component", "projections.chat_title_backfill")
+	}
+
+	return &ChatTitleBackfillProjection{
+		chatStore: chatStore,
+		queue:     queue,
+		chatRepo:  chatRepo,
+		logger:    logger,
+	}
+}
+
+func (p *ChatTitleBackfillProjection) Start(ctx context.Context, messageBus bus.MessageBus) {
+	if p == nil || p.chatStore == nil || messageBus == nil {
+		return
+	}
+	nodeSub := messageBus.Subscribe(bus.TopicNodeCore)
+
+	go func() {
+		defer messageBus.Unsubscribe(nodeSub, bus.TopicNodeCore)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case raw, ok := <-nodeSub:
+				if !ok {
+					return
+				}
+				update, ok := raw.(domain.NodeCoreUpdate)
+				if !ok {
+					continue
+				}
+				p.backfillNode(update.Core.NodeID, nodeCoreDisplayName(update.Core))
+			}
+		}
+	}()
+}
+
+// BackfillFromStore renames every placeholder DM chat whose node name is already known.
+// It returns the number of renamed chats.
+func (p *ChatTitleBackfillProjection) BackfillFromStore(nodeStore *domain.NodeStore) int {
+	if p == nil || p.chatStore == nil || nodeStore == nil {
+		return 0
+	}
+
+	renamed := 0
+	for _, chat := range p.chatStore.ChatListSorted() {
+		nodeID := domain.NormalizeNodeID(domain.NodeIDFromDMChatKey(chat.Key))
+		if nodeID == "" {
+			continue
+		}
+		node, ok := nodeStore.Get(nodeID)
+		if !ok {
+			continue
+		}
+		if p.backfillNode(nodeID, nodeCoreDisplayName(domain.NodeCore{
+			NodeID:    node.NodeID,
+			LongName:  node.LongName,
+			ShortName: node.ShortName,
+		})) {
+			renamed++
+		}
+	}
+	if renamed > 0 {
+		p.logger.Info("backfilled DM chat titles from known nodes", "renamed", renamed)
+	}
+
+	return renamed
+}
+
+func (p *ChatTitleBackfillProjection) backfillNode(nodeID, name string) bool {
+	nodeID = domain.NormalizeNodeID(nodeID)
+	if nodeID == "" || name == "" {
+		return false
+	}
+	chat, ok := p.chatStore.ChatByKey(domain.ChatKeyForDM(nodeID))
+	if !ok || !isPlaceholderDMChatTitle(chat, nodeID) {
+		return false
+	}
+
+	chat.Title = name
+	p.chatStore.UpsertChat(chat)
+	p.logger.Debug("backfilled DM chat title", "chat_key", chat.Key, "title", name)
+	if p.queue != nil && p.chatRepo != nil {
+		renamed := chat
+		p.queue.Enqueue("backfill_chat_title", func(writeCtx context.Context) error {
+			return p.chatRepo.Upsert(writeCtx, renamed)
+		})
+	}
+
+	return true
+}
+
+// nodeCoreDisplayName returns the human-readable node name or empty when only the ID is known.
+func nodeCoreDisplayName(core domain.NodeCore) string {
+	if name := strings.TrimSpace(core.LongName); name != "" {
+		return name
+	}
+
+	return strings.TrimSpace(core.ShortName)
+}
+
+func isPlaceholderDMChatTitle(chat domain.Chat, nodeID string) bool {
+	title := strings.TrimSpace(chat.Title)
+
+	return title == "" || title == strings.TrimSpace(chat.Key) || strings.EqualFold(title, nodeID)
+}
@@ -0,0 +1,146 @@
+package projections
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+type immediateWriteQueue struct{}
+
+func (immediateWriteQueue) Enqueue(_ string, fn func(context.Context) error) {
+	_ = fn(context.Background())
+}
+
+func (immediateWriteQueue) EnqueueCoalesced(_, _ string, fn func(context.Context) error) {
+	_ = fn(context.Background())
+}
+
+type recordingChatRepo struct {
+	mu      sync.Mutex
+	upserts []domain.Chat
+}
+
+func (r *recordingChatRepo) Upsert(_ context.Context, c domain.Chat) error {
+	r.mu.Lock()
+	r.upserts = append(r.upserts, c)
+	r.mu.Unlock()
+
+	return nil
+}
+
+func (r *recordingChatRepo) ListSortedByLastSentByMe(context.Context) ([]domain.Chat, error) {
+	return nil, nil
+}
+
+func (r *recordingChatRepo) Delete(context.Context, string) error {
+	return nil
+}
+
+func (r *recordingChatRepo) snapshot() []domain.Chat {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]domain.Chat(nil), r.upserts...)
+}
+
+func TestChatTitleBackfillProjection_BackfillFromStore(t *testing.T) {
+	tests := []struct {
+		name      string
+		chat      domain.Chat
+		wantTitle string
+	}{
+		{
+			name:      "key placeholder",
+			chat:      domain.Chat{Key: "dm:!00000001", Type: domain.ChatTypeDM, Title: "dm:!00000001"},
+			wantTitle: "Alice",
+		},
+		{
+			name:      "node id placeholder",
+			chat:      domain.Chat{Key: "dm:!00000001", Type: domain.ChatTypeDM, Title: "!00000001"},
+			wantTitle: "Alice",
+		},
+		{
+			name:      "empty title",
+			chat:      domain.Chat{Key: "dm:!00000001", Type: domain.ChatTypeDM},
+			wantTitle: "Alice",
+		},
+		{
+			name:      "custom title is kept",
+			chat:      domain.Chat{Key: "dm:!00000001", Type: domain.ChatTypeDM, Title: "Base camp"},
+			wantTitle: "Base camp",
+		},
+		{
+			name:      "channel chat is ignored",
+			chat:      domain.Chat{Key: domain.ChatKeyForChannel(1), Type: domain.ChatTypeChannel, Title: "channel:1"},
+			wantTitle: "channel:1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			nodeStore := domain.NewNodeStore()
+			nodeStore.Upsert(domain.Node{NodeID: "!00000001", LongName: "Alice", ShortName: "ALC"})
+			chatStore := domain.NewChatStore()
+			chatStore.UpsertChat(tc.chat)
+			repo := &recordingChatRepo{}
+
+			proj := NewChatTitleBackfillProjection(chatStore, immediateWriteQueue{}, repo, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			renamed := proj.BackfillFromStore(nodeStore)
+
+			chat, _ := chatStore.ChatByKey(tc.chat.Key)
+			if chat.Title != tc.wantTitle {
+				t.Fatalf("expected title %q, got %q", tc.wantTitle, chat.Title)
+			}
+			wantRenamed := 0
+			if tc.wantTitle != tc.chat.Title {
+				wantRenamed = 1
+			}
+			if renamed != wantRenamed || len(repo.snapshot()) != wantRenamed {
+				t.Fatalf("expected %d renamed and persisted chats, got %d/%d", wantRenamed, renamed, len(repo.snapshot()))
+			}
+		})
+	}
+}
+
+func TestChatTitleBackfillProjection_RenamesWhenNodeNameArrives(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	messageBus := bus.New(logger)
+	t.Cleanup(messageBus.Close)
+
+	chatStore := domain.NewChatStore()
+	chatStore.AppendMessage(domain.ChatMessage{
+		ChatKey:   "dm:!00000002",
+		Direction: domain.MessageDirectionIn,
+		Body:      "hi",
+	})
+	repo := &recordingChatRepo{}
+	proj := NewChatTitleBackfillProjection(chatStore, immediateWriteQueue{}, repo, logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	proj.Start(ctx, messageBus)
+
+	// Updates without a name must not touch the placeholder.
+	messageBus.Publish(bus.TopicNodeCore, domain.NodeCoreUpdate{Core: domain.NodeCore{NodeID: "!00000002"}})
+	messageBus.Publish(bus.TopicNodeCore, domain.NodeCoreUpdate{Core: domain.NodeCore{NodeID: "!00000002", ShortName: "BOB"}})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if chat, _ := chatStore.ChatByKey("dm:!00000002"); chat.Title == "BOB" {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if chat, _ := chatStore.ChatByKey("dm:!00000002"); chat.Title != "BOB" {
+		t.Fatalf("expected DM chat title to be backfilled, got %q", chat.Title)
+	}
+	if upserts := repo.snapshot(); len(upserts) != 1 || upserts[0].Title != "BOB" {
+		t.Fatalf("expected backfilled title to be persisted once, got %+v", upserts)
+	}
+}
@@ -2,7 +2,9 @@ package projections
 
 import (
 	"context"
+	"encoding/json"
 	"strconv"
+	"sync"
 
 	"github.com/skobkin/meshgo/internal/bus"
 	"github.com/skobkin/meshgo/internal/domain"
@@ -12,6 +14,8 @@ import (
 // WriteQueue serializes persistence writes from async domain events.
 type WriteQueue interface {
 	Enqueue(name string, fn func(context.Context) error)
+	// EnqueueCoalesced drops a still queued command with the same key in favour of fn.
+	EnqueueCoalesced(name, key string, fn func(context.Context) error)
 }
 
 // HistoryLimitsProvider returns current node history caps.
@@ -19,6 +23,7 @@ type HistoryLimitsProvider interface {
 	PositionHistoryLimit() int
 	TelemetryHistoryLimit() int
 	IdentityHistoryLimit() int
+	SignalHistoryLimit() int
 }
 
 func StartPersistenceProjection(
@@ -28,10 +33,12 @@ func StartPersistenceProjection(
 	coreRepo domain.NodeCoreRepository,
 	positionRepo domain.NodePositionRepository,
 	telemetryRepo domain.NodeTelemetryRepository,
+	signalRepo domain.NodeSignalHistoryRepository,
 	historyLimits HistoryLimitsProvider,
 	chatRepo domain.ChatRepository,
 	msgRepo domain.MessageRepository,
 	tracerouteRepo domain.TracerouteRepository,
+	statsRepo domain.StatisticsRepository,
 ) {
 	coreSub := b.Subscribe(bus.TopicNodeCore)
 	positionSub := b.Subscribe(bus.TopicNodePosition)
@@ -44,6 +51,7 @@ func StartPersistenceProjection(
 		tracerouteSub = b.Subscribe(bus.TopicTracerouteUpdate)
 	}
 
+	pendingCore := newPendingNodeCoreUpdates()
 	go func() {
 		defer b.Unsubscribe(coreSub, bus.TopicNodeCore)
 		for {
@@ -59,14 +67,49 @@ func StartPersistenceProjection(
 					continue
 				}
 				copyUpdate := update
-				queue.Enqueue("upsert_node_core", func(writeCtx context.Context) error {
+				// NodeInfo floods repeat the same node many times, so updates of a kind
+				// still waiting to be written are merged and written once.
+				key := "node_core:" + copyUpdate.Core.NodeID + ":" + string(copyUpdate.Type)
+				pendingCore.add(key, copyUpdate)
+				var merged *domain.NodeCoreUpdate
+				queue.EnqueueCoalesced("upsert_node_core", key, func(writeCtx context.Context) error {
+					if merged == nil {
+						next, ok := pendingCore.take(key)
+						if !ok {
+							// An earlier command already wrote this update.
+							return nil
+						}
+						merged = &next
+					}
 					limit := 0
 					if historyLimits != nil {
 						limit = historyLimits.IdentityHistoryLimit()
 					}
 
-					return coreRepo.Upsert(writeCtx, copyUpdate, limit)
+					return coreRepo.Upsert(writeCtx, *merged, limit)
 				})
+				if signalRepo != nil && copyUpdate.FromPacket && (copyUpdate.Core.RSSI != nil || copyUpdate.Core.SNR != nil) {
+					entry := domain.NodeSignalHistoryEntry{
+						NodeID:     copyUpdate.Core.NodeID,
+						RSSI:       copyUpdate.Core.RSSI,
+						SNR:        copyUpdate.Core.SNR,
+						ObservedAt: copyUpdate.Core.LastHeardAt,
+					}
+					queue.Enqueue("record_node_signal", func(writeCtx context.Context) error {
+						limit := 0
+						if historyLimits != nil {
+							limit = historyLimits.SignalHistoryLimit()
+						}
+
+						return signalRepo.Record(writeCtx, entry, limit)
+					})
+				}
+				if statsRepo != nil && copyUpdate.FromPacket && copyUpdate.Type != domain.NodeUpdateTypeNodeInfoSnapshot {
+					nodeID, heardAt := copyUpdate.Core.NodeID, copyUpdate.Core.LastHeardAt
+					queue.Enqueue("record_node_packet", func(writeCtx context.Context) error {
+						return statsRepo.RecordNodePacket(writeCtx, nodeID, heardAt)
+					})
+				}
 			}
 		}
 	}()
@@ -145,7 +188,7 @@ func StartPersistenceProjection(
 						Title: ch.Title,
 						Type:  domain.ChatTypeChannel,
 					}
-					queue.Enqueue("upsert_channel_chat", func(writeCtx context.Context) error {
+					queue.EnqueueCoalesced("upsert_channel_chat", "chat:"+chat.Key, func(writeCtx context.Context) error {
 						return chatRepo.Upsert(writeCtx, chat)
 					})
 				}
@@ -169,9 +212,16 @@ func StartPersistenceProjection(
 				}
 				copyMsg := msg
 				queue.Enqueue("insert_message", func(writeCtx context.Context) error {
-					_, err := msgRepo.Insert(writeCtx, copyMsg)
-					if err != nil {
+					if copyMsg.QueuedMessageID != "" {
+						if err := msgRepo.ReplaceQueued(writeCtx, copyMsg); err != nil {
+							return err
+						}
+					} else if id, err := msgRepo.Insert(writeCtx, copyMsg); err != nil {
 						return err
+					} else if id > 0 && statsRepo != nil {
+						if err := recordMessageStatistics(writeCtx, statsRepo, copyMsg); err != nil {
+							return err
+						}
 					}
 					chat := domain.Chat{
 						Key:       copyMsg.ChatKey,
@@ -240,7 +290,7 @@ func StartPersistenceProjection(
 						ErrorText:    update.Error,
 						DurationMS:   update.DurationMS,
 					}
-					queue.Enqueue("upsert_traceroute", func(writeCtx context.Context) error {
+					queue.EnqueueCoalesced("upsert_traceroute", "traceroute:"+rec.RequestID, func(writeCtx context.Context) error {
 						return tracerouteRepo.Upsert(writeCtx, rec)
 					})
 				}
@@ -249,6 +299,116 @@ func StartPersistenceProjection(
 	}
 }
 
+// recordMessageStatistics counts a newly stored message. A received message is also a
+// packet heard from its sender.
+func recordMessageStatistics(ctx context.Context, statsRepo domain.StatisticsRepository, msg domain.ChatMessage) error {
+	if err := statsRepo.RecordMessage(ctx, msg.ChatKey, msg.Direction, msg.At); err != nil {
+		return err
+	}
+	if msg.Direction != domain.MessageDirectionIn || msg.MetaJSON == "" {
+		return nil
+	}
+	var meta struct {
+		From string `json:"from"`
+	}
+	if err := json.Unmarshal([]byte(msg.MetaJSON), &meta); err != nil || meta.From == "" {
+		return nil
+	}
+
+	return statsRepo.RecordNodePacket(ctx, meta.From, msg.At)
+}
+
 func stringFromUint32(v uint32) string {
 	return strconv.FormatUint(uint64(v), 10)
 }
+
+// pendingNodeCoreUpdates holds node core updates between being queued and being written.
+// The writer queue keeps only the latest command of a coalescing key, so the command
+// writes the merge of every update queued under its key.
+type pendingNodeCoreUpdates struct {
+	mu      sync.Mutex
+	updates map[string]domain.NodeCoreUpdate
+}
+
+func newPendingNodeCoreUpdates() *pendingNodeCoreUpdates {
+	return &pendingNodeCoreUpdates{updates: make(map[string]domain.NodeCoreUpdate)}
+}
+
+func (p *pendingNodeCoreUpdates) add(key string, update domain.NodeCoreUpdate) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if pending, ok := p.updates[key]; ok {
+		update = mergeNodeCoreUpdates(pending, update)
+	}
+	p.updates[key] = update
+}
+
+func (p *pendingNodeCoreUpdates) take(key string) (domain.NodeCoreUpdate, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	update, ok := p.updates[key]
+	delete(p.updates, key)
+
+	return update, ok
+}
+
+// mergeNodeCoreUpdates applies next over prev the way the node core upsert applies an
+// update over the stored row: fields next leaves empty keep their earlier value and the
+// timestamps only move forward.
+func mergeNodeCoreUpdates(prev, next domain.NodeCoreUpdate) domain.NodeCoreUpdate {
+	merged := next
+	merged.FromPacket = prev.FromPacket || next.FromPacket
+	core, older := &merged.Core, prev.Core
+	mergeString := func(value *string, earlier string) {
+		if *value == "" {
+			*value = earlier
+		}
+	}
+	mergeString(&core.LongName, older.LongName)
+	mergeString(&core.ShortName, older.ShortName)
+	mergeString(&core.BoardModel, older.BoardModel)
+	mergeString(&core.FirmwareVersion, older.FirmwareVersion)
+	mergeString(&core.Role, older.Role)
+	mergeString(&core.Alias, older.Alias)
+	mergeString(&core.Note, older.Note)
+	if len(core.PublicKey) == 0 {
+		core.PublicKey = older.PublicKey
+	}
+	if core.Tags == nil {
+		core.Tags = older.Tags
+	}
+	if core.Channel == nil {
+		core.Channel = older.Channel
+	}
+	if core.IsFavorite == nil {
+		core.IsFavorite = older.IsFavorite
+	}
+	if core.IsIgnored == nil {
+		core.IsIgnored = older.IsIgnored
+	}
+	if core.IsUnmessageable == nil {
+		core.IsUnmessageable = older.IsUnmessageable
+	}
+	if core.HopsAway == nil {
+		core.HopsAway = older.HopsAway
+	}
+	if core.ViaMQTT == nil {
+		core.ViaMQTT = older.ViaMQTT
+	}
+	if core.RSSI == nil {
+		core.RSSI = older.RSSI
+	}
+	if core.SNR == nil {
+		core.SNR = older.SNR
+	}
+	if older.LastHeardAt.After(core.LastHeardAt) {
+		core.LastHeardAt = older.LastHeardAt
+	}
+	if older.UpdatedAt.After(core.UpdatedAt) {
+		core.UpdatedAt = older.UpdatedAt
+	}
+
+	return merged
+}
@@ -0,0 +1,68 @@
+package projections
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestPendingNodeCoreUpdates_MergesFieldsQueuedUnderOneKey(t *testing.T) {
+	heardFirst := time.Unix(1_700_000_000, 0)
+	heardSecond := heardFirst.Add(time.Minute)
+	hops := uint32(2)
+	rssi := -90
+	favorite := true
+
+	pending := newPendingNodeCoreUpdates()
+	pending.add("node_core:!1", domain.NodeCoreUpdate{
+		Core: domain.NodeCore{
+			NodeID:      "!1",
+			LongName:    "Alpha",
+			ShortName:   "ALP",
+			IsFavorite:  &favorite,
+			HopsAway:    &hops,
+			LastHeardAt: heardSecond,
+		},
+		Type: domain.NodeUpdateTypeNodeInfoSnapshot,
+	})
+	pending.add("node_core:!1", domain.NodeCoreUpdate{
+		Core: domain.NodeCore{
+			NodeID:      "!1",
+			ShortName:   "ALF",
+			RSSI:        &rssi,
+			LastHeardAt: heardFirst,
+		},
+		FromPacket: true,
+		Type:       domain.NodeUpdateTypeNodeInfoSnapshot,
+	})
+
+	got, ok := pending.take("node_core:!1")
+	if !ok {
+		t.Fatalf("expected a pending update")
+	}
+	if got.Core.LongName != "Alpha" {
+		t.Fatalf("long name: expected Alpha, got %q", got.Core.LongName)
+	}
+	if got.Core.ShortName != "ALF" {
+		t.Fatalf("short name: expected ALF, got %q", got.Core.ShortName)
+	}
+	if got.Core.IsFavorite == nil || !*got.Core.IsFavorite {
+		t.Fatalf("favorite: expected true, got %v", got.Core.IsFavorite)
+	}
+	if got.Core.HopsAway == nil || *got.Core.HopsAway != hops {
+		t.Fatalf("hops away: expected %d, got %v", hops, got.Core.HopsAway)
+	}
+	if got.Core.RSSI == nil || *got.Core.RSSI != rssi {
+		t.Fatalf("rssi: expected %d, got %v", rssi, got.Core.RSSI)
+	}
+	if !got.Core.LastHeardAt.Equal(heardSecond) {
+		t.Fatalf("last heard: expected %v, got %v", heardSecond, got.Core.LastHeardAt)
+	}
+	if !got.FromPacket {
+		t.Fatalf("from packet: expected true, got false")
+	}
+	if _, ok := pending.take("node_core:!1"); ok {
+		t.Fatalf("expected the update to be taken only once")
+	}
+}
@@ -0,0 +1,68 @@
+package projections
+
+import (
+	"context"
+	"encoding/hex"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+// RawPacketLogSettings reports whether raw frames are logged and how large the log may grow.
+type RawPacketLogSettings interface {
+	RawPacketLogEnabled() bool
+	RawPacketLogMaxBytes() int64
+}
+
+// StartRawPacketLogProjection stores raw radio frames while the raw packet log is enabled.
+func StartRawPacketLogProjection(
+	ctx context.Context,
+	b bus.MessageBus,
+	queue WriteQueue,
+	repo domain.RawPacketLogRepository,
+	settings RawPacketLogSettings,
+) {
+	if repo == nil || settings == nil {
+		return
+	}
+	inSub := b.Subscribe(bus.TopicRawFrameIn)
+	outSub := b.Subscribe(bus.TopicRawFrameOut)
+
+	logFrame := func(raw any, direction domain.RawPacketDirection) {
+		frame, ok := raw.(busmsg.RawFrame)
+		if !ok || !settings.RawPacketLogEnabled() {
+			return
+		}
+		payload, err := hex.DecodeString(frame.Hex)
+		if err != nil || len(payload) == 0 {
+			return
+		}
+		entry := domain.RawPacketLogEntry{Direction: direction, Payload: payload, LoggedAt: time.Now()}
+		queue.Enqueue("append_raw_packet", func(writeCtx context.Context) error {
+			return repo.Append(writeCtx, entry, settings.RawPacketLogMaxBytes())
+		})
+	}
+
+	go func() {
+		defer b.Unsubscribe(inSub, bus.TopicRawFrameIn)
+		defer b.Unsubscribe(outSub, bus.TopicRawFrameOut)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case raw, ok := <-inSub:
+				if !ok {
+					return
+				}
+				logFrame(raw, domain.RawPacketFromRadio)
+			case raw, ok := <-outSub:
+				if !ok {
+					return
+				}
+				logFrame(raw, domain.RawPacketToRadio)
+			}
+		}
+	}()
+}
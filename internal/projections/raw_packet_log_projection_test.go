@@ -0,0 +1,84 @@
+package projections
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+type staticRawPacketLogSettings struct {
+	enabled  bool
+	maxBytes int64
+}
+
+func (s staticRawPacketLogSettings) RawPacketLogEnabled() bool   { return s.enabled }
+func (s staticRawPacketLogSettings) RawPacketLogMaxBytes() int64 { return s.maxBytes }
+
+type recordingRawPacketLogRepo struct {
+	mu       sync.Mutex
+	entries  []domain.RawPacketLogEntry
+	maxBytes []int64
+}
+
+func (r *recordingRawPacketLogRepo) Append(_ context.Context, entry domain.RawPacketLogEntry, maxBytes int64) error {
+	r.mu.Lock()
+	r.entries = append(r.entries, entry)
+	r.maxBytes = append(r.maxBytes, maxBytes)
+	r.mu.Unlock()
+
+	return nil
+}
+
+func (r *recordingRawPacketLogRepo) List(context.Context) ([]domain.RawPacketLogEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]domain.RawPacketLogEntry(nil), r.entries...), nil
+}
+
+func TestRawPacketLogProjection_StoresFramesInBothDirections(t *testing.T) {
+	messageBus := bus.New(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	repo := &recordingRawPacketLogRepo{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	StartRawPacketLogProjection(ctx, messageBus, immediateWriteQueue{}, repo, staticRawPacketLogSettings{enabled: true, maxBytes: 1024})
+
+	messageBus.Publish(bus.TopicRawFrameIn, busmsg.RawFrame{Hex: "0A0B", Len: 2})
+	messageBus.Publish(bus.TopicRawFrameOut, busmsg.RawFrame{Hex: "1A", Len: 1})
+
+	deadline := time.Now().Add(2 * time.Second)
+	var entries []domain.RawPacketLogEntry
+	for time.Now().Before(deadline) {
+		entries, _ = repo.List(ctx)
+		if len(entries) == 2 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 logged frames, got %d", len(entries))
+	}
+	byDirection := make(map[domain.RawPacketDirection][]byte, len(entries))
+	for _, entry := range entries {
+		if entry.LoggedAt.IsZero() {
+			t.Fatalf("expected frame timestamp to be set")
+		}
+		byDirection[entry.Direction] = entry.Payload
+	}
+	if got := byDirection[domain.RawPacketFromRadio]; string(got) != "\x0a\x0b" {
+		t.Fatalf("unexpected from-radio payload: %x", got)
+	}
+	if got := byDirection[domain.RawPacketToRadio]; string(got) != "\x1a" {
+		t.Fatalf("unexpected to-radio payload: %x", got)
+	}
+	if repo.maxBytes[0] != 1024 {
+		t.Fatalf("expected size cap to be passed to the repo, got %d", repo.maxBytes[0])
+	}
+}
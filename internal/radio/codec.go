@@ -9,6 +9,8 @@ import (
 // DecodedFrame is a parsed inbound radio frame with optional event payloads.
 type DecodedFrame struct {
 	Raw                 []byte
+	PacketFrom          uint32
+	PacketID            uint32
 	NodeCoreUpdate      *domain.NodeCoreUpdate
 	NodePositionUpdate  *domain.NodePositionUpdate
 	NodeTelemetryUpdate *domain.NodeTelemetryUpdate
@@ -26,6 +28,8 @@ type DecodedFrame struct {
 type TextSendOptions struct {
 	ReplyToDeviceMessageID string
 	Emoji                  uint32
+	// QueuedMessageID is the outbox id the message was shown under before it was sent.
+	QueuedMessageID string
 }
 
 // EncodedText contains an outbound text frame and its tracking metadata.
@@ -75,6 +79,7 @@ type Codec interface {
 	EncodeWantConfig() ([]byte, error)
 	EncodeHeartbeat() ([]byte, error)
 	EncodeText(chatKey, text string, opts TextSendOptions) (EncodedText, error)
+	EncodeWaypoint(chatKey string, waypoint domain.Waypoint) (EncodedText, error)
 	EncodeAdmin(to uint32, channel uint32, wantResponse bool, payload *generated.AdminMessage) (EncodedAdmin, error)
 	EncodeTraceroute(to uint32, channel uint32) (EncodedTraceroute, error)
 	EncodeNodeInfoRequest(to uint32, channel uint32, requester *generated.User) (EncodedNodeInfoRequest, error)
@@ -18,6 +18,9 @@ import (
 )
 
 const broadcastNodeNum = ^uint32(0)
+
+// loopbackNodeNum sends the echoes of loopback chat messages. It mirrors 127.0.0.1.
+const loopbackNodeNum = uint32(0x7f000001)
 const meshtasticPositionScale = 1e-7
 
 // MeshtasticCodec implements Codec for Meshtastic protobuf frames.
@@ -105,6 +108,75 @@ func (c *MeshtasticCodec) EncodeText(chatKey, text string, opts TextSendOptions)
 	}, nil
 }
 
+// EncodeWaypoint builds a waypoint packet for the chat. Clients that show waypoints put
+// it on their map; meshgo renders it as a chat message.
+func (c *MeshtasticCodec) EncodeWaypoint(chatKey string, waypoint domain.Waypoint) (EncodedText, error) {
+	to, channel, err := parseChatTarget(chatKey)
+	if err != nil {
+		return EncodedText{}, err
+	}
+	encodedWaypoint, err := proto.Marshal(&generated.Waypoint{
+		Id:          c.nextNonZeroID(),
+		LatitudeI:   int32Ptr(int32(math.Round(waypoint.Latitude * 1e7))),
+		LongitudeI:  int32Ptr(int32(math.Round(waypoint.Longitude * 1e7))),
+		Name:        strings.TrimSpace(waypoint.Name),
+		Description: strings.TrimSpace(waypoint.Description),
+	})
+	if err != nil {
+		return EncodedText{}, fmt.Errorf("marshal waypoint: %w", err)
+	}
+	packetID := c.nextNonZeroID()
+
+	packet := &generated.MeshPacket{
+		To:      to,
+		Channel: channel,
+		Id:      packetID,
+		WantAck: true,
+		PayloadVariant: &generated.MeshPacket_Decoded{Decoded: &generated.Data{
+			Portnum: generated.PortNum_WAYPOINT_APP,
+			Payload: encodedWaypoint,
+		}},
+	}
+	wire := &generated.ToRadio{PayloadVariant: &generated.ToRadio_Packet{Packet: packet}}
+	payload, err := proto.Marshal(wire)
+	if err != nil {
+		return EncodedText{}, err
+	}
+
+	return EncodedText{
+		Payload:         payload,
+		DeviceMessageID: strconv.FormatUint(uint64(packetID), 10),
+		WantAck:         packet.GetWantAck(),
+		TargetNodeNum:   to,
+	}, nil
+}
+
+// EchoFromRadio turns the packet of an outgoing frame into the frame the radio would
+// deliver if loopbackNodeNum had sent it to the local node. The echo gets its own
+// packet id so it is stored next to the sent message instead of replacing it.
+func (c *MeshtasticCodec) EchoFromRadio(toRadio []byte) ([]byte, error) {
+	var wire generated.ToRadio
+	if err := proto.Unmarshal(toRadio, &wire); err != nil {
+		return nil, fmt.Errorf("decode toradio protobuf: %w", err)
+	}
+	packet := wire.GetPacket()
+	if packet == nil {
+		return nil, fmt.Errorf("toradio frame carries no packet")
+	}
+
+	echo, ok := proto.Clone(packet).(*generated.MeshPacket)
+	if !ok {
+		return nil, fmt.Errorf("clone mesh packet")
+	}
+	echo.From = loopbackNodeNum
+	echo.To = c.localNodeNum.Load()
+	echo.Id = c.nextNonZeroID()
+	echo.WantAck = false
+	echo.RxTime = uint32(time.Now().Unix())
+
+	return proto.Marshal(&generated.FromRadio{PayloadVariant: &generated.FromRadio_Packet{Packet: echo}})
+}
+
 func (c *MeshtasticCodec) EncodeAdmin(
 	to uint32,
 	channel uint32,
@@ -295,6 +367,8 @@ func (c *MeshtasticCodec) DecodeFromRadio(payload []byte) (DecodedFrame, error)
 	}
 
 	if packet := wire.GetPacket(); packet != nil {
+		out.PacketFrom = packet.GetFrom()
+		out.PacketID = packet.GetId()
 		decodePacket(packet, now, c.localNodeNum.Load(), &out)
 	}
 
@@ -311,8 +385,12 @@ func decodePacket(packet *generated.MeshPacket, now time.Time, localNode uint32,
 	}
 
 	switch decoded.GetPortnum() {
-	case generated.PortNum_TEXT_MESSAGE_APP, generated.PortNum_TEXT_MESSAGE_COMPRESSED_APP, generated.PortNum_DETECTION_SENSOR_APP, generated.PortNum_ALERT_APP:
+	case generated.PortNum_TEXT_MESSAGE_APP, generated.PortNum_TEXT_MESSAGE_COMPRESSED_APP, generated.PortNum_DETECTION_SENSOR_APP, generated.PortNum_ALERT_APP,
+		generated.PortNum_WAYPOINT_APP:
 		text := strings.TrimSpace(string(decoded.GetPayload()))
+		if decoded.GetPortnum() == generated.PortNum_WAYPOINT_APP {
+			text = decodeWaypointText(decoded.GetPayload(), now)
+		}
 		if text == "" {
 			return
 		}
@@ -372,6 +450,28 @@ func decodePacket(packet *generated.MeshPacket, now time.Time, localNode uint32,
 	}
 }
 
+// decodeWaypointText renders a waypoint packet as chat text. Expired waypoints, which
+// clients send to delete one, are skipped.
+func decodeWaypointText(payload []byte, now time.Time) string {
+	var waypoint generated.Waypoint
+	if err := proto.Unmarshal(payload, &waypoint); err != nil {
+		return ""
+	}
+	if waypoint.LatitudeI == nil || waypoint.LongitudeI == nil {
+		return ""
+	}
+	if expire := waypoint.GetExpire(); expire != 0 && int64(expire) <= now.Unix() {
+		return ""
+	}
+
+	return domain.Waypoint{
+		Name:        strings.TrimSpace(waypoint.GetName()),
+		Description: strings.TrimSpace(waypoint.GetDescription()),
+		Latitude:    float64(waypoint.GetLatitudeI()) / 1e7,
+		Longitude:   float64(waypoint.GetLongitudeI()) / 1e7,
+	}.ReferenceText()
+}
+
 func assignSplitNodeUpdates(out *DecodedFrame, update domain.NodeUpdate) {
 	if out == nil {
 		return
@@ -404,7 +504,10 @@ func splitNodeCoreUpdate(update domain.NodeUpdate) (domain.NodeCoreUpdate, bool)
 			FirmwareVersion: node.FirmwareVersion,
 			Role:            node.Role,
 			IsFavorite:      node.IsFavorite,
+			IsIgnored:       node.IsIgnored,
 			IsUnmessageable: node.IsUnmessageable,
+			HopsAway:        node.HopsAway,
+			ViaMQTT:         node.ViaMQTT,
 			LastHeardAt:     node.LastHeardAt,
 			RSSI:            node.RSSI,
 			SNR:             node.SNR,
@@ -632,6 +735,14 @@ func decodeNodeInfo(nodeInfo *generated.NodeInfo, now time.Time) domain.NodeUpda
 	}
 	isFavorite := nodeInfo.GetIsFavorite()
 	node.IsFavorite = &isFavorite
+	isIgnored := nodeInfo.GetIsIgnored()
+	node.IsIgnored = &isIgnored
+	if nodeInfo.HopsAway != nil && !nodeInfo.GetViaMqtt() {
+		hops := nodeInfo.GetHopsAway()
+		node.HopsAway = &hops
+	}
+	viaMQTT := nodeInfo.GetViaMqtt()
+	node.ViaMQTT = &viaMQTT
 	if user != nil && user.IsUnmessagable != nil {
 		v := user.GetIsUnmessagable()
 		node.IsUnmessageable = &v
@@ -680,6 +791,7 @@ func decodeNodeTelemetryFromPacket(packet *generated.MeshPacket, payload []byte,
 	if telemetry.GetEnvironmentMetrics() == nil && telemetry.GetPowerMetrics() == nil && telemetry.GetAirQualityMetrics() == nil && telemetry.GetDeviceMetrics() == nil {
 		return domain.NodeUpdate{}, false
 	}
+	applyPacketHops(&node, packet)
 	if rssi := packet.GetRxRssi(); rssi != 0 {
 		rssiVal := int(rssi)
 		node.RSSI = &rssiVal
@@ -795,6 +907,7 @@ func decodeNodeFromPacketPayload(packet *generated.MeshPacket, payload []byte, n
 		v := user.GetIsUnmessagable()
 		node.IsUnmessageable = &v
 	}
+	applyPacketHops(&node, packet)
 	if rssi := packet.GetRxRssi(); rssi != 0 {
 		rssiVal := int(rssi)
 		node.RSSI = &rssiVal
@@ -831,6 +944,7 @@ func decodeNodePositionFromPacket(packet *generated.MeshPacket, payload []byte,
 		return domain.NodeUpdate{}, false
 	}
 	node.PositionUpdatedAt = positionUpdateTime(&position, packetTimestamp(packet.GetRxTime(), now))
+	applyPacketHops(&node, packet)
 	if rssi := packet.GetRxRssi(); rssi != 0 {
 		rssiVal := int(rssi)
 		node.RSSI = &rssiVal
@@ -1211,6 +1325,20 @@ func packetMetaJSON(port generated.PortNum, packet *generated.MeshPacket) string
 	return string(raw)
 }
 
+// applyPacketHops records how many hops the packet took to reach us and whether it
+// came through an MQTT bridge. Bridged packets say nothing about the RF path, so their
+// hops are skipped.
+func applyPacketHops(node *domain.Node, packet *generated.MeshPacket) {
+	viaMQTT := packet.GetViaMqtt()
+	node.ViaMQTT = &viaMQTT
+	if viaMQTT {
+		return
+	}
+	if hops, ok := packetHops(packet); ok {
+		node.HopsAway = uint32Ptr(uint32(hops))
+	}
+}
+
 func packetHops(packet *generated.MeshPacket) (int, bool) {
 	hopStart := packet.GetHopStart()
 	hopLimit := packet.GetHopLimit()
@@ -1228,6 +1356,10 @@ func uint32Ptr(v uint32) *uint32 {
 	return &v
 }
 
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
 func (c *MeshtasticCodec) nextNonZeroID() uint32 {
 	for {
 		id := c.packetID.Add(1)
@@ -437,6 +437,43 @@ func TestMeshtasticCodec_DecodeFromRadioTextIncludesReplyAndEmoji(t *testing.T)
 	}
 }
 
+func TestMeshtasticCodec_EncodeWaypointRoundTripsAsText(t *testing.T) {
+	codec := mustNewMeshtasticCodec(t)
+	waypoint := domain.Waypoint{Name: "Camp", Description: "north gate", Latitude: 55.75583, Longitude: 37.6173}
+	encoded, err := codec.EncodeWaypoint("channel:1", waypoint)
+	if err != nil {
+		t.Fatalf("encode waypoint: %v", err)
+	}
+	if encoded.DeviceMessageID == "" || !encoded.WantAck {
+		t.Fatalf("expected a tracked waypoint packet, got %+v", encoded)
+	}
+
+	var wire generated.ToRadio
+	if err := proto.Unmarshal(encoded.Payload, &wire); err != nil {
+		t.Fatalf("unmarshal toradio: %v", err)
+	}
+	packet := wire.GetPacket()
+	if packet.GetDecoded().GetPortnum() != generated.PortNum_WAYPOINT_APP {
+		t.Fatalf("expected waypoint portnum, got %v", packet.GetDecoded().GetPortnum())
+	}
+
+	packet.From = 0x1234abcd
+	raw, err := proto.Marshal(&generated.FromRadio{PayloadVariant: &generated.FromRadio_Packet{Packet: packet}})
+	if err != nil {
+		t.Fatalf("marshal fromradio: %v", err)
+	}
+	frame, err := codec.DecodeFromRadio(raw)
+	if err != nil {
+		t.Fatalf("decode fromradio: %v", err)
+	}
+	if frame.TextMessage == nil {
+		t.Fatalf("expected waypoint to be decoded as a text message")
+	}
+	if frame.TextMessage.Body != waypoint.ReferenceText() {
+		t.Fatalf("expected body %q, got %q", waypoint.ReferenceText(), frame.TextMessage.Body)
+	}
+}
+
 func TestMeshtasticCodec_DecodeFromRadioTelemetryEnvironmentPacket(t *testing.T) {
 	codec := mustNewMeshtasticCodec(t)
 
@@ -656,6 +693,73 @@ func TestMeshtasticCodec_DecodeFromRadioPositionPacket(t *testing.T) {
 	}
 }
 
+func TestMeshtasticCodec_DecodeFromRadioPositionPacketHopsAway(t *testing.T) {
+	codec := mustNewMeshtasticCodec(t)
+	positionPayload, err := proto.Marshal(&generated.Position{
+		LatitudeI:  proto.Int32(37_774_9000),
+		LongitudeI: proto.Int32(-122_419_4000),
+	})
+	if err != nil {
+		t.Fatalf("marshal position: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		hopStart uint32
+		hopLimit uint32
+		viaMQTT  bool
+		want     *uint32
+	}{
+		{name: "direct", hopStart: 3, hopLimit: 3, want: uint32Ptr(0)},
+		{name: "relayed", hopStart: 3, hopLimit: 1, want: uint32Ptr(2)},
+		{name: "via mqtt", hopStart: 3, hopLimit: 3, viaMQTT: true},
+		{name: "unknown", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := proto.Marshal(&generated.FromRadio{
+				PayloadVariant: &generated.FromRadio_Packet{
+					Packet: &generated.MeshPacket{
+						From:     0x1234abcd,
+						HopStart: tt.hopStart,
+						HopLimit: tt.hopLimit,
+						ViaMqtt:  tt.viaMQTT,
+						PayloadVariant: &generated.MeshPacket_Decoded{
+							Decoded: &generated.Data{
+								Portnum: generated.PortNum_POSITION_APP,
+								Payload: positionPayload,
+							},
+						},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("marshal fromradio: %v", err)
+			}
+
+			frame, err := codec.DecodeFromRadio(raw)
+			if err != nil {
+				t.Fatalf("decode position packet: %v", err)
+			}
+			if frame.NodeCoreUpdate == nil {
+				t.Fatalf("expected node core update")
+			}
+			if viaMQTT := frame.NodeCoreUpdate.Core.ViaMQTT; viaMQTT == nil || *viaMQTT != tt.viaMQTT {
+				t.Fatalf("expected via MQTT %v, got %v", tt.viaMQTT, viaMQTT)
+			}
+			got := frame.NodeCoreUpdate.Core.HopsAway
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("expected unknown hops, got %d", *got)
+				}
+
+				return
+			}
+			assertUint32Ptr(t, got, *tt.want, "hops away")
+		})
+	}
+}
+
 func TestMeshtasticCodec_DecodeFromRadioPositionPacketInvalidCoordinatesIgnored(t *testing.T) {
 	codec := mustNewMeshtasticCodec(t)
 
@@ -0,0 +1,72 @@
+package radio
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+// loopbackCodec is implemented by codecs that can echo an outgoing frame back as the
+// frame a radio would deliver for it.
+type loopbackCodec interface {
+	EchoFromRadio(toRadio []byte) ([]byte, error)
+}
+
+// loopbackTargetChatKey is the chat the loopback messages are encoded for. The frames
+// never reach the transport, the target only has to be a valid direct message.
+var loopbackTargetChatKey = domain.ChatKeyForDM(formatNodeNum(loopbackNodeNum))
+
+// handleLoopbackSend runs a loopback chat message through the codec both ways instead of
+// the transport. The sent message and its decoded echo are published like a regular
+// send and receive, so the stores, persistence and notifications handle them as usual.
+// It works without a connected radio.
+func (s *Service) handleLoopbackSend(req sendRequest) SendResult {
+	echoer, ok := s.codec.(loopbackCodec)
+	if !ok {
+		return SendResult{Err: errors.New("loopback chat is not supported by the radio codec")}
+	}
+	encoded, err := s.encodeSend(loopbackTargetChatKey, req)
+	if err != nil {
+		return SendResult{Err: fmt.Errorf("encode loopback message: %w", err)}
+	}
+	echoPayload, err := echoer.EchoFromRadio(encoded.Payload)
+	if err != nil {
+		return SendResult{Err: fmt.Errorf("echo loopback message: %w", err)}
+	}
+	decoded, err := s.codec.DecodeFromRadio(echoPayload)
+	if err != nil {
+		return SendResult{Err: fmt.Errorf("decode loopback echo: %w", err)}
+	}
+	if decoded.TextMessage == nil {
+		return SendResult{Err: errors.New("loopback echo carries no message")}
+	}
+
+	msg := domain.ChatMessage{
+		DeviceMessageID:        encoded.DeviceMessageID,
+		ReplyToDeviceMessageID: strings.TrimSpace(req.opts.ReplyToDeviceMessageID),
+		Emoji:                  req.opts.Emoji,
+		ChatKey:                req.chatKey,
+		Direction:              domain.MessageDirectionOut,
+		Body:                   req.text,
+		Status:                 domain.MessageStatusAcked,
+		At:                     time.Now(),
+		MetaJSON:               outgoingMessageMetaJSON(s.LocalNodeID()),
+		QueuedMessageID:        strings.TrimSpace(req.opts.QueuedMessageID),
+	}
+	echo := *decoded.TextMessage
+	echo.ChatKey = req.chatKey
+	s.logger.Debug("loopback message echoed", "device_message_id", msg.DeviceMessageID, "echo_message_id", echo.DeviceMessageID)
+
+	s.bus.Publish(bus.TopicRawFrameOut, busmsg.RawFrame{Hex: strings.ToUpper(hex.EncodeToString(encoded.Payload)), Len: len(encoded.Payload)})
+	s.bus.Publish(bus.TopicTextMessage, msg)
+	s.bus.Publish(bus.TopicRawFrameIn, busmsg.RawFrame{Hex: strings.ToUpper(hex.EncodeToString(echoPayload)), Len: len(echoPayload)})
+	s.bus.Publish(bus.TopicTextMessage, echo)
+
+	return SendResult{Message: msg}
+}
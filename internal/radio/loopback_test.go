@@ -0,0 +1,52 @@
+package radio
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestServiceLoopbackSendEchoesThroughCodec(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	messageBus := bus.New(logger)
+	defer messageBus.Close()
+	codec, err := NewMeshtasticCodec()
+	if err != nil {
+		t.Fatalf("new codec: %v", err)
+	}
+	svc := NewService(logger, messageBus, nil, codec)
+	sub := messageBus.Subscribe(bus.TopicTextMessage)
+
+	res := svc.handleSend(t.Context(), sendRequest{chatKey: domain.ChatKeyLoopback, text: "ping"})
+	if res.Err != nil {
+		t.Fatalf("loopback send: %v", res.Err)
+	}
+
+	var got []domain.ChatMessage
+	for len(got) < 2 {
+		select {
+		case raw := <-sub:
+			msg, ok := raw.(domain.ChatMessage)
+			if !ok {
+				t.Fatalf("unexpected bus payload %T", raw)
+			}
+			got = append(got, msg)
+		case <-time.After(time.Second):
+			t.Fatalf("expected sent message and echo, got %+v", got)
+		}
+	}
+	sent, echo := got[0], got[1]
+	if sent.Direction != domain.MessageDirectionOut || sent.Status != domain.MessageStatusAcked || sent.DeviceMessageID != res.Message.DeviceMessageID {
+		t.Fatalf("unexpected sent message: %+v", sent)
+	}
+	if echo.Direction != domain.MessageDirectionIn || echo.ChatKey != domain.ChatKeyLoopback || echo.Body != "ping" {
+		t.Fatalf("unexpected echo: %+v", echo)
+	}
+	if echo.DeviceMessageID == "" || echo.DeviceMessageID == sent.DeviceMessageID {
+		t.Fatalf("expected echo to get its own packet id, got %q for sent %q", echo.DeviceMessageID, sent.DeviceMessageID)
+	}
+}
@@ -0,0 +1,72 @@
+package radio
+
+import (
+	"container/list"
+	"sync"
+)
+
+const defaultPacketDedupCapacity = 512
+
+type packetDedupKey struct {
+	from uint32
+	id   uint32
+}
+
+// packetDedupCache is a bounded LRU of recently seen mesh packet identities.
+// Rebroadcasts and MQTT-mirrored copies keep the original sender and packet ID,
+// so (from, id) is enough to recognize them.
+type packetDedupCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[packetDedupKey]*list.Element
+	dropped  uint64
+}
+
+func newPacketDedupCache(capacity int) *packetDedupCache {
+	if capacity <= 0 {
+		capacity = defaultPacketDedupCapacity
+	}
+
+	return &packetDedupCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[packetDedupKey]*list.Element, capacity),
+	}
+}
+
+// Seen records the packet identity and reports whether it was already present.
+// Packets without an ID can't be correlated and are never treated as duplicates.
+func (c *packetDedupCache) Seen(from, id uint32) bool {
+	if id == 0 {
+		return false
+	}
+	key := packetDedupKey{from: from, id: id}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		c.order.MoveToFront(elem)
+		c.dropped++
+
+		return true
+	}
+
+	c.entries[key] = c.order.PushFront(key)
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(packetDedupKey))
+	}
+
+	return false
+}
+
+// Dropped returns how many duplicate packets were detected so far.
+func (c *packetDedupCache) Dropped() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.dropped
+}
@@ -0,0 +1,63 @@
+package radio
+
+import "testing"
+
+func TestPacketDedupCache_Seen(t *testing.T) {
+	tests := []struct {
+		name        string
+		capacity    int
+		packets     [][2]uint32
+		wantSeen    []bool
+		wantDropped uint64
+	}{
+		{
+			name:        "first copy passes, rebroadcast is dropped",
+			capacity:    4,
+			packets:     [][2]uint32{{0x1, 100}, {0x1, 100}},
+			wantSeen:    []bool{false, true},
+			wantDropped: 1,
+		},
+		{
+			name:        "same id from different senders is distinct",
+			capacity:    4,
+			packets:     [][2]uint32{{0x1, 100}, {0x2, 100}},
+			wantSeen:    []bool{false, false},
+			wantDropped: 0,
+		},
+		{
+			name:        "zero packet id is never deduplicated",
+			capacity:    4,
+			packets:     [][2]uint32{{0x1, 0}, {0x1, 0}},
+			wantSeen:    []bool{false, false},
+			wantDropped: 0,
+		},
+		{
+			name:        "oldest entry is evicted over capacity",
+			capacity:    2,
+			packets:     [][2]uint32{{0x1, 1}, {0x1, 2}, {0x1, 3}, {0x1, 1}},
+			wantSeen:    []bool{false, false, false, false},
+			wantDropped: 0,
+		},
+		{
+			name:        "duplicate refreshes recency",
+			capacity:    2,
+			packets:     [][2]uint32{{0x1, 1}, {0x1, 2}, {0x1, 1}, {0x1, 3}, {0x1, 1}},
+			wantSeen:    []bool{false, false, true, false, true},
+			wantDropped: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cache := newPacketDedupCache(tc.capacity)
+			for i, packet := range tc.packets {
+				if got := cache.Seen(packet[0], packet[1]); got != tc.wantSeen[i] {
+					t.Fatalf("packet %d (%d/%d): expected seen=%v, got %v", i, packet[0], packet[1], tc.wantSeen[i], got)
+				}
+			}
+			if got := cache.Dropped(); got != tc.wantDropped {
+				t.Fatalf("expected %d dropped, got %d", tc.wantDropped, got)
+			}
+		})
+	}
+}
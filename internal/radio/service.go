@@ -26,10 +26,11 @@ type SendResult struct {
 }
 
 type sendRequest struct {
-	chatKey string
-	text    string
-	opts    TextSendOptions
-	result  chan SendResult
+	chatKey  string
+	text     string
+	opts     TextSendOptions
+	waypoint *domain.Waypoint
+	result   chan SendResult
 }
 
 type ackTrackState struct {
@@ -43,9 +44,20 @@ type Service struct {
 	codec     Codec
 	bus       bus.MessageBus
 	outbox    chan sendRequest
+	dedup     *packetDedupCache
 
 	ackTrackMu sync.Mutex
 	ackTrack   map[string]ackTrackState
+
+	// onLocalNodeChange and lastLocalNodeID are only used by the reader loop.
+	onLocalNodeChange func(nodeID string)
+	lastLocalNodeID   string
+
+	// paused keeps the transport closed after a manual disconnect. wake interrupts
+	// the reconnect wait when the user disconnects or reconnects.
+	pauseMu sync.Mutex
+	paused  bool
+	wake    chan struct{}
 }
 
 type localNodeIDCodec interface {
@@ -59,42 +71,129 @@ func NewService(logger *slog.Logger, b bus.MessageBus, tr transport.Transport, c
 		codec:     codec,
 		bus:       b,
 		outbox:    make(chan sendRequest, 128),
+		dedup:     newPacketDedupCache(defaultPacketDedupCapacity),
 		ackTrack:  make(map[string]ackTrackState),
+		wake:      make(chan struct{}, 1),
 	}
 }
 
+// OnLocalNodeChange registers fn to run when the connected radio reports a node ID that
+// differs from the previous one. It runs on the reader loop before the frame carrying
+// the new ID is published, so handlers see the switch ahead of that radio's data. It
+// must be called before Start.
+func (s *Service) OnLocalNodeChange(fn func(nodeID string)) {
+	s.onLocalNodeChange = fn
+}
+
 func (s *Service) Start(ctx context.Context) {
 	go s.runOutbox(ctx)
 	go s.runTransport(ctx)
 }
 
+// Disconnect closes the transport and keeps it closed until Reconnect.
+func (s *Service) Disconnect() {
+	s.pauseMu.Lock()
+	s.paused = true
+	s.pauseMu.Unlock()
+	s.logger.Info("disconnecting on user request")
+	_ = s.transport.Close()
+	s.wakeTransport()
+}
+
+// Reconnect connects again after Disconnect, or retries right away while the
+// transport waits to reconnect.
+func (s *Service) Reconnect() {
+	s.pauseMu.Lock()
+	s.paused = false
+	s.pauseMu.Unlock()
+	s.logger.Info("reconnecting on user request")
+	s.wakeTransport()
+}
+
+func (s *Service) isPaused() bool {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+
+	return s.paused
+}
+
+func (s *Service) wakeTransport() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// waitReconnect waits for the backoff to pass or for a user request, which also
+// resets the backoff.
+func (s *Service) waitReconnect(ctx context.Context, backoff *time.Duration) bool {
+	timer := time.NewTimer(*backoff)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		if *backoff < 15*time.Second {
+			*backoff *= 2
+		}
+	case <-s.wake:
+		*backoff = time.Second
+	}
+
+	return true
+}
+
 func (s *Service) SendText(chatKey, text string, opts TextSendOptions) <-chan SendResult {
 	resCh := make(chan SendResult, 1)
 	chatKey = strings.TrimSpace(chatKey)
-	if chatKey == "" {
-		resCh <- SendResult{Err: errors.New("chat key is required")}
+	if err := ValidateText(chatKey, text); err != nil {
+		resCh <- SendResult{Err: err}
 		close(resCh)
 
 		return resCh
 	}
-	if utf8.RuneCountInString(text) == 0 {
-		resCh <- SendResult{Err: errors.New("message body is empty")}
-		close(resCh)
 
-		return resCh
+	s.outbox <- sendRequest{chatKey: chatKey, text: text, opts: opts, result: resCh}
+
+	return resCh
+}
+
+// SendWaypoint shares a waypoint into the chat as a waypoint packet. The chat message
+// carries the waypoint's compact text so it reads the same as a received one.
+func (s *Service) SendWaypoint(chatKey string, waypoint domain.Waypoint) <-chan SendResult {
+	resCh := make(chan SendResult, 1)
+	chatKey = strings.TrimSpace(chatKey)
+	err := waypoint.Validate()
+	if err == nil && chatKey == "" {
+		err = errors.New("chat key is required")
 	}
-	if len([]byte(text)) > 200 {
-		resCh <- SendResult{Err: fmt.Errorf("message body exceeds 200 bytes: %d", len([]byte(text)))}
+	if err != nil {
+		resCh <- SendResult{Err: err}
 		close(resCh)
 
 		return resCh
 	}
 
-	s.outbox <- sendRequest{chatKey: chatKey, text: text, opts: opts, result: resCh}
+	s.outbox <- sendRequest{chatKey: chatKey, text: waypoint.ReferenceText(), waypoint: &waypoint, result: resCh}
 
 	return resCh
 }
 
+// ValidateText checks that a text message fits into a single radio frame.
+func ValidateText(chatKey, text string) error {
+	if strings.TrimSpace(chatKey) == "" {
+		return errors.New("chat key is required")
+	}
+	if utf8.RuneCountInString(text) == 0 {
+		return errors.New("message body is empty")
+	}
+	if len([]byte(text)) > 200 {
+		return fmt.Errorf("message body exceeds 200 bytes: %d", len([]byte(text)))
+	}
+
+	return nil
+}
+
 func (s *Service) LocalNodeID() string {
 	codec, ok := s.codec.(localNodeIDCodec)
 	if !ok {
@@ -104,23 +203,35 @@ func (s *Service) LocalNodeID() string {
 	return strings.TrimSpace(codec.LocalNodeID())
 }
 
+// DuplicatePacketsDropped returns how many rebroadcast or mirrored packet copies were suppressed.
+func (s *Service) DuplicatePacketsDropped() uint64 {
+	return s.dedup.Dropped()
+}
+
 func (s *Service) runTransport(ctx context.Context) {
 	backoff := time.Second
 	for {
 		if err := ctx.Err(); err != nil {
 			return
 		}
+		if s.isPaused() {
+			s.publishConnStatus(busmsg.ConnectionStateDisconnected, nil)
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.wake:
+			}
+
+			continue
+		}
 
 		s.publishConnStatus(busmsg.ConnectionStateConnecting, nil)
 		if err := s.transport.Connect(ctx); err != nil {
 			s.publishConnStatus(busmsg.ConnectionStateReconnecting, err)
 			s.logger.Error("transport connect failed", "error", err)
-			if !sleepWithContext(ctx, backoff) {
+			if !s.waitReconnect(ctx, &backoff) {
 				return
 			}
-			if backoff < 15*time.Second {
-				backoff *= 2
-			}
 
 			continue
 		}
@@ -136,14 +247,14 @@ func (s *Service) runTransport(ctx context.Context) {
 		err := s.runReader(ctx)
 		cancelKeepAlive()
 		_ = s.transport.Close()
+		if s.isPaused() {
+			continue
+		}
 		s.publishConnStatus(busmsg.ConnectionStateReconnecting, err)
 
-		if !sleepWithContext(ctx, backoff) {
+		if !s.waitReconnect(ctx, &backoff) {
 			return
 		}
-		if backoff < 15*time.Second {
-			backoff *= 2
-		}
 	}
 }
 
@@ -167,6 +278,17 @@ func (s *Service) runReader(ctx context.Context) error {
 
 			continue
 		}
+		if s.dedup.Seen(decoded.PacketFrom, decoded.PacketID) {
+			s.logger.Debug(
+				"duplicate packet dropped",
+				"from", decoded.PacketFrom,
+				"packet_id", decoded.PacketID,
+				"dropped_total", s.dedup.Dropped(),
+			)
+
+			continue
+		}
+		s.notifyLocalNodeChange()
 		s.bus.Publish(bus.TopicRadioFrom, decoded)
 
 		if decoded.NodeCoreUpdate != nil {
@@ -200,6 +322,18 @@ func (s *Service) runReader(ctx context.Context) error {
 	}
 }
 
+func (s *Service) notifyLocalNodeChange() {
+	nodeID := s.LocalNodeID()
+	if nodeID == "" || nodeID == s.lastLocalNodeID {
+		return
+	}
+	s.lastLocalNodeID = nodeID
+	s.logger.Info("connected radio node identified", "node_id", nodeID)
+	if s.onLocalNodeChange != nil {
+		s.onLocalNodeChange(nodeID)
+	}
+}
+
 func (s *Service) runKeepAlive(ctx context.Context) {
 	ticker := time.NewTicker(25 * time.Second)
 	defer ticker.Stop()
@@ -242,7 +376,10 @@ func (s *Service) runOutbox(ctx context.Context) {
 }
 
 func (s *Service) handleSend(ctx context.Context, req sendRequest) SendResult {
-	encoded, err := s.codec.EncodeText(req.chatKey, req.text, req.opts)
+	if domain.IsLoopbackKey(req.chatKey) {
+		return s.handleLoopbackSend(req)
+	}
+	encoded, err := s.encodeSend(req.chatKey, req)
 	if err != nil {
 		return SendResult{Err: fmt.Errorf("encode outgoing message: %w", err)}
 	}
@@ -268,6 +405,7 @@ func (s *Service) handleSend(ctx context.Context, req sendRequest) SendResult {
 		Status:                 initialStatus,
 		At:                     now,
 		MetaJSON:               outgoingMessageMetaJSON(s.LocalNodeID()),
+		QueuedMessageID:        strings.TrimSpace(req.opts.QueuedMessageID),
 	}
 
 	s.bus.Publish(bus.TopicRawFrameOut, busmsg.RawFrame{Hex: strings.ToUpper(hex.EncodeToString(encoded.Payload)), Len: len(encoded.Payload)})
@@ -276,6 +414,15 @@ func (s *Service) handleSend(ctx context.Context, req sendRequest) SendResult {
 	return SendResult{Message: msg}
 }
 
+// encodeSend encodes the request for the given chat target.
+func (s *Service) encodeSend(target string, req sendRequest) (EncodedText, error) {
+	if req.waypoint != nil {
+		return s.codec.EncodeWaypoint(target, *req.waypoint)
+	}
+
+	return s.codec.EncodeText(target, req.text, req.opts)
+}
+
 func (s *Service) sendWantConfig(ctx context.Context) error {
 	payload, err := s.codec.EncodeWantConfig()
 	if err != nil {
@@ -373,15 +520,6 @@ func (s *Service) publishConnStatus(state busmsg.ConnectionState, err error) {
 	s.bus.Publish(bus.TopicConnStatus, status)
 }
 
-func sleepWithContext(ctx context.Context, d time.Duration) bool {
-	select {
-	case <-ctx.Done():
-		return false
-	case <-time.After(d):
-		return true
-	}
-}
-
 func outgoingMessageMetaJSON(localNodeID string) string {
 	localNodeID = strings.TrimSpace(localNodeID)
 	if localNodeID == "" {
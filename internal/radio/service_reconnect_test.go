@@ -0,0 +1,94 @@
+package radio
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+// blockingTransport connects at once and blocks reads until it is closed.
+type blockingTransport struct {
+	mu     sync.Mutex
+	closed chan struct{}
+}
+
+func (t *blockingTransport) Name() string { return "test" }
+
+func (t *blockingTransport) Connect(context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closed = make(chan struct{})
+
+	return nil
+}
+
+func (t *blockingTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed != nil {
+		close(t.closed)
+		t.closed = nil
+	}
+
+	return nil
+}
+
+func (t *blockingTransport) ReadFrame(ctx context.Context) ([]byte, error) {
+	t.mu.Lock()
+	closed := t.closed
+	t.mu.Unlock()
+	if closed == nil {
+		return nil, errors.New("transport is closed")
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-closed:
+		return nil, errors.New("transport is closed")
+	}
+}
+
+func (t *blockingTransport) WriteFrame(context.Context, []byte) error { return nil }
+
+func TestServiceDisconnectAndReconnect(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	messageBus := bus.New(logger)
+	defer messageBus.Close()
+	codec, err := NewMeshtasticCodec()
+	if err != nil {
+		t.Fatalf("new codec: %v", err)
+	}
+	svc := NewService(logger, messageBus, &blockingTransport{}, codec)
+	sub := messageBus.Subscribe(bus.TopicConnStatus)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc.Start(ctx)
+
+	waitState := func(want busmsg.ConnectionState) {
+		t.Helper()
+		deadline := time.After(2 * time.Second)
+		for {
+			select {
+			case raw := <-sub:
+				if status, ok := raw.(busmsg.ConnectionStatus); ok && status.State == want {
+					return
+				}
+			case <-deadline:
+				t.Fatalf("expected connection state %q", want)
+			}
+		}
+	}
+
+	waitState(busmsg.ConnectionStateConnected)
+	svc.Disconnect()
+	waitState(busmsg.ConnectionStateDisconnected)
+	svc.Reconnect()
+	waitState(busmsg.ConnectionStateConnected)
+}
@@ -1,6 +1,9 @@
 package radio
 
 import (
+	"io"
+	"log/slog"
+	"slices"
 	"testing"
 
 	"github.com/skobkin/meshgo/internal/domain"
@@ -77,3 +80,31 @@ func TestNormalizeMessageStatus_FailedClearsTracking(t *testing.T) {
 		t.Fatalf("expected tracking to be cleared on failure")
 	}
 }
+
+type localNodeStubCodec struct {
+	Codec
+	nodeID string
+}
+
+func (c *localNodeStubCodec) LocalNodeID() string {
+	return c.nodeID
+}
+
+func TestNotifyLocalNodeChange_RunsOnlyWhenNodeIDChanges(t *testing.T) {
+	codec := &localNodeStubCodec{}
+	svc := &Service{logger: slog.New(slog.NewTextHandler(io.Discard, nil)), codec: codec}
+	var got []string
+	svc.OnLocalNodeChange(func(nodeID string) {
+		got = append(got, nodeID)
+	})
+
+	for _, nodeID := range []string{"", "!0000beef", "!0000beef", "!0000cafe", "!0000cafe"} {
+		codec.nodeID = nodeID
+		svc.notifyLocalNodeChange()
+	}
+
+	want := []string{"!0000beef", "!0000cafe"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected node changes %v, got %v", want, got)
+	}
+}
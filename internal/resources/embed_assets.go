@@ -62,6 +62,39 @@ var uiDarkRefresh []byte
 //go:embed ui/dark/favorite.svg
 var uiDarkFavorite []byte
 
+//go:embed ui/dark/hw_board.svg
+var uiDarkHwBoard []byte
+
+//go:embed ui/dark/hw_handheld.svg
+var uiDarkHwHandheld []byte
+
+//go:embed ui/dark/hw_tracker.svg
+var uiDarkHwTracker []byte
+
+//go:embed ui/dark/hw_module.svg
+var uiDarkHwModule []byte
+
+//go:embed ui/dark/hw_computer.svg
+var uiDarkHwComputer []byte
+
+//go:embed ui/dark/hw_unknown.svg
+var uiDarkHwUnknown []byte
+
+//go:embed ui/dark/battery_full.svg
+var uiDarkBatteryFull []byte
+
+//go:embed ui/dark/battery_half.svg
+var uiDarkBatteryHalf []byte
+
+//go:embed ui/dark/battery_low.svg
+var uiDarkBatteryLow []byte
+
+//go:embed ui/dark/battery_alert.svg
+var uiDarkBatteryAlert []byte
+
+//go:embed ui/dark/power_plug.svg
+var uiDarkPowerPlug []byte
+
 //go:embed ui/light/chats.svg
 var uiLightChats []byte
 
@@ -119,6 +152,39 @@ var uiLightRefresh []byte
 //go:embed ui/light/favorite.svg
 var uiLightFavorite []byte
 
+//go:embed ui/light/hw_board.svg
+var uiLightHwBoard []byte
+
+//go:embed ui/light/hw_handheld.svg
+var uiLightHwHandheld []byte
+
+//go:embed ui/light/hw_tracker.svg
+var uiLightHwTracker []byte
+
+//go:embed ui/light/hw_module.svg
+var uiLightHwModule []byte
+
+//go:embed ui/light/hw_computer.svg
+var uiLightHwComputer []byte
+
+//go:embed ui/light/hw_unknown.svg
+var uiLightHwUnknown []byte
+
+//go:embed ui/light/battery_full.svg
+var uiLightBatteryFull []byte
+
+//go:embed ui/light/battery_half.svg
+var uiLightBatteryHalf []byte
+
+//go:embed ui/light/battery_low.svg
+var uiLightBatteryLow []byte
+
+//go:embed ui/light/battery_alert.svg
+var uiLightBatteryAlert []byte
+
+//go:embed ui/light/power_plug.svg
+var uiLightPowerPlug []byte
+
 //go:embed ui/dark/icon_32.png
 var uiDarkIcon32 []byte
 
@@ -0,0 +1,206 @@
+package resources
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strconv"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// TrayState is the connection state shown on the tray icon.
+type TrayState string
+
+const (
+	TrayStateDisconnected TrayState = "disconnected"
+	TrayStateConnecting   TrayState = "connecting"
+	TrayStateConnected    TrayState = "connected"
+)
+
+var (
+	trayStateColors = map[TrayState]color.NRGBA{
+		TrayStateDisconnected: {R: 0x9e, G: 0x9e, B: 0x9e, A: 0xff},
+		TrayStateConnecting:   {R: 0xfb, G: 0xc0, B: 0x2d, A: 0xff},
+		TrayStateConnected:    {R: 0x43, G: 0xa0, B: 0x47, A: 0xff},
+	}
+	trayUnreadColor  = color.NRGBA{R: 0xe5, G: 0x39, B: 0x35, A: 0xff}
+	trayOutlineColor = color.NRGBA{R: 0x21, G: 0x21, B: 0x21, A: 0xff}
+	trayBadgeText    = color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+)
+
+// trayBadgeMaxCount is the largest unread count spelled out on the badge; larger counts
+// show as "99+".
+const trayBadgeMaxCount = 99
+
+// trayBadgeGlyphs is a 3x5 pixel font for the badge, as there is no font to render text
+// with at tray icon sizes. Each row is three bits, the leftmost pixel first.
+var trayBadgeGlyphs = map[rune][5]uint8{
+	'0': {0b111, 0b101, 0b101, 0b101, 0b111},
+	'1': {0b010, 0b110, 0b010, 0b010, 0b111},
+	'2': {0b111, 0b001, 0b111, 0b100, 0b111},
+	'3': {0b111, 0b001, 0b111, 0b001, 0b111},
+	'4': {0b101, 0b101, 0b111, 0b001, 0b001},
+	'5': {0b111, 0b100, 0b111, 0b001, 0b111},
+	'6': {0b111, 0b100, 0b111, 0b101, 0b111},
+	'7': {0b111, 0b001, 0b001, 0b001, 0b001},
+	'8': {0b111, 0b101, 0b111, 0b101, 0b111},
+	'9': {0b111, 0b101, 0b111, 0b001, 0b111},
+	'+': {0b000, 0b010, 0b111, 0b010, 0b000},
+}
+
+type trayStatusIconKey struct {
+	variant fyne.ThemeVariant
+	state   TrayState
+	badge   string
+}
+
+var (
+	trayStatusIconsMu sync.Mutex
+	trayStatusIcons   = map[trayStatusIconKey]fyne.Resource{}
+)
+
+// TrayStatusIconResource returns the tray icon with a dot for the connection state in
+// the bottom right corner and, when there is something unread, a badge with the unread
+// count in the top right corner. Icons are rendered once and reused.
+func TrayStatusIconResource(variant fyne.ThemeVariant, state TrayState, unread int) fyne.Resource {
+	if variant != theme.VariantLight {
+		variant = theme.VariantDark
+	}
+	if _, ok := trayStateColors[state]; !ok {
+		state = TrayStateDisconnected
+	}
+	key := trayStatusIconKey{variant: variant, state: state, badge: trayBadgeLabel(unread)}
+
+	trayStatusIconsMu.Lock()
+	defer trayStatusIconsMu.Unlock()
+	if res, ok := trayStatusIcons[key]; ok {
+		return res
+	}
+	base := TrayIconResource(variant)
+	content, err := renderTrayStatusIcon(base.Content(), state, key.badge)
+	if err != nil {
+		fyne.LogError("failed to render tray status icon", err)
+
+		return base
+	}
+	name := fmt.Sprintf("tray_%d_%s", variant, state)
+	if key.badge != "" {
+		name += "_unread_" + key.badge
+	}
+	res := fyne.NewStaticResource(name+".png", content)
+	trayStatusIcons[key] = res
+
+	return res
+}
+
+// trayBadgeLabel returns the text of the unread badge, or "" when there is nothing
+// unread.
+func trayBadgeLabel(unread int) string {
+	switch {
+	case unread <= 0:
+		return ""
+	case unread > trayBadgeMaxCount:
+		return strconv.Itoa(trayBadgeMaxCount) + "+"
+	default:
+		return strconv.Itoa(unread)
+	}
+}
+
+func renderTrayStatusIcon(basePNG []byte, state TrayState, badge string) ([]byte, error) {
+	base, err := png.Decode(bytes.NewReader(basePNG))
+	if err != nil {
+		return nil, fmt.Errorf("decode tray icon: %w", err)
+	}
+	bounds := base.Bounds()
+	icon := image.NewNRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(icon, icon.Bounds(), base, bounds.Min, draw.Src)
+
+	size := float64(bounds.Dx())
+	radius := size * 0.2
+	drawTrayDot(icon, size-radius-1, size-radius-1, radius, trayStateColors[state])
+	drawTrayBadge(icon, badge)
+
+	var out bytes.Buffer
+	if err := png.Encode(&out, icon); err != nil {
+		return nil, fmt.Errorf("encode tray icon: %w", err)
+	}
+
+	return out.Bytes(), nil
+}
+
+// drawTrayDot paints a filled circle with an outline, so it stands out on both the icon
+// and the tray.
+func drawTrayDot(img *image.NRGBA, cx, cy, radius float64, fill color.NRGBA) {
+	outline := radius * 0.25
+	for y := int(cy - radius - 1); y <= int(cy+radius+1); y++ {
+		for x := int(cx - radius - 1); x <= int(cx+radius+1); x++ {
+			if !(image.Point{X: x, Y: y}).In(img.Rect) {
+				continue
+			}
+			dx, dy := float64(x)+0.5-cx, float64(y)+0.5-cy
+			distance := dx*dx + dy*dy
+			switch {
+			case distance <= (radius-outline)*(radius-outline):
+				img.SetNRGBA(x, y, fill)
+			case distance <= radius*radius:
+				img.SetNRGBA(x, y, trayOutlineColor)
+			}
+		}
+	}
+}
+
+// drawTrayBadge paints the text on a pill in the top right corner. The pixel font is
+// scaled by whole pixels to stay sharp, and a single digit gets a round badge.
+func drawTrayBadge(img *image.NRGBA, text string) {
+	if text == "" {
+		return
+	}
+	scale := max(1, img.Rect.Dx()/16)
+	glyphs := len([]rune(text))
+	textWidth := (glyphs*4 - 1) * scale
+	height := 9 * scale
+	width := min(max(textWidth+4*scale, height), img.Rect.Dx())
+	left := img.Rect.Max.X - width
+
+	// The pill is every point close enough to the segment between its end centers.
+	radius := float64(height) / 2
+	outline := float64(scale)
+	cy := radius
+	startX, endX := float64(left)+radius, float64(img.Rect.Max.X)-radius
+	for y := 0; y < height; y++ {
+		for x := left; x < img.Rect.Max.X; x++ {
+			px, py := float64(x)+0.5, float64(y)+0.5
+			dx := px - math.Min(math.Max(px, startX), endX)
+			distance := math.Hypot(dx, py-cy)
+			switch {
+			case distance <= radius-outline:
+				img.SetNRGBA(x, y, trayUnreadColor)
+			case distance <= radius:
+				img.SetNRGBA(x, y, trayOutlineColor)
+			}
+		}
+	}
+
+	x := left + (width-textWidth+1)/2
+	top := 2 * scale
+	for _, r := range text {
+		glyph := trayBadgeGlyphs[r]
+		for row, bits := range glyph {
+			for col := range 3 {
+				if bits&(0b100>>col) == 0 {
+					continue
+				}
+				fill := image.Rect(x+col*scale, top+row*scale, x+(col+1)*scale, top+(row+1)*scale)
+				draw.Draw(img, fill, image.NewUniform(trayBadgeText), image.Point{}, draw.Src)
+			}
+		}
+		x += 4 * scale
+	}
+}
@@ -9,69 +9,102 @@ import (
 type UIIcon string
 
 const (
-	UIIconChats           UIIcon = "chats"
-	UIIconNodes           UIIcon = "nodes"
-	UIIconMap             UIIcon = "map"
-	UIIconNodeSettings    UIIcon = "node_settings"
-	UIIconAppSettings     UIIcon = "app_settings"
-	UIIconConnected       UIIcon = "connected"
-	UIIconDisconnected    UIIcon = "disconnected"
-	UIIconMapNodeMarker   UIIcon = "map_node_marker"
-	UIIconUpdateAvailable UIIcon = "update_available"
-	UIIconCloudUpload     UIIcon = "cloud_upload"
-	UIIconCloudDownload   UIIcon = "cloud_download"
-	UIIconLockGreen       UIIcon = "lock_green"
-	UIIconLockYellow      UIIcon = "lock_yellow"
-	UIIconLockRed         UIIcon = "lock_red"
-	UIIconLockRedWarning  UIIcon = "lock_red_warning"
-	UIIconSpeakerMute     UIIcon = "speaker_mute"
-	UIIconBadgePrimary    UIIcon = "badge_primary"
-	UIIconRefresh         UIIcon = "refresh"
-	UIIconFavorite        UIIcon = "favorite"
+	UIIconChats            UIIcon = "chats"
+	UIIconNodes            UIIcon = "nodes"
+	UIIconMap              UIIcon = "map"
+	UIIconNodeSettings     UIIcon = "node_settings"
+	UIIconAppSettings      UIIcon = "app_settings"
+	UIIconConnected        UIIcon = "connected"
+	UIIconDisconnected     UIIcon = "disconnected"
+	UIIconMapNodeMarker    UIIcon = "map_node_marker"
+	UIIconUpdateAvailable  UIIcon = "update_available"
+	UIIconCloudUpload      UIIcon = "cloud_upload"
+	UIIconCloudDownload    UIIcon = "cloud_download"
+	UIIconLockGreen        UIIcon = "lock_green"
+	UIIconLockYellow       UIIcon = "lock_yellow"
+	UIIconLockRed          UIIcon = "lock_red"
+	UIIconLockRedWarning   UIIcon = "lock_red_warning"
+	UIIconSpeakerMute      UIIcon = "speaker_mute"
+	UIIconBadgePrimary     UIIcon = "badge_primary"
+	UIIconRefresh          UIIcon = "refresh"
+	UIIconFavorite         UIIcon = "favorite"
+	UIIconHardwareBoard    UIIcon = "hw_board"
+	UIIconHardwareHandheld UIIcon = "hw_handheld"
+	UIIconHardwareTracker  UIIcon = "hw_tracker"
+	UIIconHardwareModule   UIIcon = "hw_module"
+	UIIconHardwareComputer UIIcon = "hw_computer"
+	UIIconHardwareUnknown  UIIcon = "hw_unknown"
+	UIIconBatteryFull      UIIcon = "battery_full"
+	UIIconBatteryHalf      UIIcon = "battery_half"
+	UIIconBatteryLow       UIIcon = "battery_low"
+	UIIconBatteryAlert     UIIcon = "battery_alert"
+	UIIconPowerPlug        UIIcon = "power_plug"
 )
 
 var uiDarkIconResources = map[UIIcon]fyne.Resource{
-	UIIconChats:           fyne.NewStaticResource("resources/ui/dark/chats.svg", uiDarkChats),
-	UIIconNodes:           fyne.NewStaticResource("resources/ui/dark/nodes.svg", uiDarkNodes),
-	UIIconMap:             fyne.NewStaticResource("resources/ui/dark/map.svg", uiDarkMap),
-	UIIconNodeSettings:    fyne.NewStaticResource("resources/ui/dark/node_settings.svg", uiDarkNodeSettings),
-	UIIconAppSettings:     fyne.NewStaticResource("resources/ui/dark/app_settings.svg", uiDarkAppSettings),
-	UIIconConnected:       fyne.NewStaticResource("resources/ui/dark/connected.svg", uiDarkConnected),
-	UIIconDisconnected:    fyne.NewStaticResource("resources/ui/dark/disconnected.svg", uiDarkDisconnected),
-	UIIconMapNodeMarker:   fyne.NewStaticResource("resources/ui/dark/map_node_marker.svg", uiDarkMapNodeMarker),
-	UIIconUpdateAvailable: fyne.NewStaticResource("resources/ui/dark/update_available.svg", uiDarkUpdateAvailable),
-	UIIconCloudUpload:     fyne.NewStaticResource("resources/ui/dark/cloud_upload.svg", uiDarkCloudUpload),
-	UIIconCloudDownload:   fyne.NewStaticResource("resources/ui/dark/cloud_download.svg", uiDarkCloudDownload),
-	UIIconLockGreen:       fyne.NewStaticResource("resources/ui/dark/lock_green.svg", uiDarkLockGreen),
-	UIIconLockYellow:      fyne.NewStaticResource("resources/ui/dark/lock_yellow.svg", uiDarkLockYellow),
-	UIIconLockRed:         fyne.NewStaticResource("resources/ui/dark/lock_red.svg", uiDarkLockRed),
-	UIIconLockRedWarning:  fyne.NewStaticResource("resources/ui/dark/lock_red_warning.svg", uiDarkLockRedWarning),
-	UIIconSpeakerMute:     fyne.NewStaticResource("resources/ui/dark/speaker_mute.svg", uiDarkSpeakerMute),
-	UIIconBadgePrimary:    fyne.NewStaticResource("resources/ui/dark/badge_primary.svg", uiDarkBadgePrimary),
-	UIIconRefresh:         fyne.NewStaticResource("resources/ui/dark/refresh.svg", uiDarkRefresh),
-	UIIconFavorite:        fyne.NewStaticResource("resources/ui/dark/favorite.svg", uiDarkFavorite),
+	UIIconChats:            fyne.NewStaticResource("resources/ui/dark/chats.svg", uiDarkChats),
+	UIIconNodes:            fyne.NewStaticResource("resources/ui/dark/nodes.svg", uiDarkNodes),
+	UIIconMap:              fyne.NewStaticResource("resources/ui/dark/map.svg", uiDarkMap),
+	UIIconNodeSettings:     fyne.NewStaticResource("resources/ui/dark/node_settings.svg", uiDarkNodeSettings),
+	UIIconAppSettings:      fyne.NewStaticResource("resources/ui/dark/app_settings.svg", uiDarkAppSettings),
+	UIIconConnected:        fyne.NewStaticResource("resources/ui/dark/connected.svg", uiDarkConnected),
+	UIIconDisconnected:     fyne.NewStaticResource("resources/ui/dark/disconnected.svg", uiDarkDisconnected),
+	UIIconMapNodeMarker:    fyne.NewStaticResource("resources/ui/dark/map_node_marker.svg", uiDarkMapNodeMarker),
+	UIIconUpdateAvailable:  fyne.NewStaticResource("resources/ui/dark/update_available.svg", uiDarkUpdateAvailable),
+	UIIconCloudUpload:      fyne.NewStaticResource("resources/ui/dark/cloud_upload.svg", uiDarkCloudUpload),
+	UIIconCloudDownload:    fyne.NewStaticResource("resources/ui/dark/cloud_download.svg", uiDarkCloudDownload),
+	UIIconLockGreen:        fyne.NewStaticResource("resources/ui/dark/lock_green.svg", uiDarkLockGreen),
+	UIIconLockYellow:       fyne.NewStaticResource("resources/ui/dark/lock_yellow.svg", uiDarkLockYellow),
+	UIIconLockRed:          fyne.NewStaticResource("resources/ui/dark/lock_red.svg", uiDarkLockRed),
+	UIIconLockRedWarning:   fyne.NewStaticResource("resources/ui/dark/lock_red_warning.svg", uiDarkLockRedWarning),
+	UIIconSpeakerMute:      fyne.NewStaticResource("resources/ui/dark/speaker_mute.svg", uiDarkSpeakerMute),
+	UIIconBadgePrimary:     fyne.NewStaticResource("resources/ui/dark/badge_primary.svg", uiDarkBadgePrimary),
+	UIIconRefresh:          fyne.NewStaticResource("resources/ui/dark/refresh.svg", uiDarkRefresh),
+	UIIconFavorite:         fyne.NewStaticResource("resources/ui/dark/favorite.svg", uiDarkFavorite),
+	UIIconHardwareBoard:    fyne.NewStaticResource("resources/ui/dark/hw_board.svg", uiDarkHwBoard),
+	UIIconHardwareHandheld: fyne.NewStaticResource("resources/ui/dark/hw_handheld.svg", uiDarkHwHandheld),
+	UIIconHardwareTracker:  fyne.NewStaticResource("resources/ui/dark/hw_tracker.svg", uiDarkHwTracker),
+	UIIconHardwareModule:   fyne.NewStaticResource("resources/ui/dark/hw_module.svg", uiDarkHwModule),
+	UIIconHardwareComputer: fyne.NewStaticResource("resources/ui/dark/hw_computer.svg", uiDarkHwComputer),
+	UIIconHardwareUnknown:  fyne.NewStaticResource("resources/ui/dark/hw_unknown.svg", uiDarkHwUnknown),
+	UIIconBatteryFull:      fyne.NewStaticResource("resources/ui/dark/battery_full.svg", uiDarkBatteryFull),
+	UIIconBatteryHalf:      fyne.NewStaticResource("resources/ui/dark/battery_half.svg", uiDarkBatteryHalf),
+	UIIconBatteryLow:       fyne.NewStaticResource("resources/ui/dark/battery_low.svg", uiDarkBatteryLow),
+	UIIconBatteryAlert:     fyne.NewStaticResource("resources/ui/dark/battery_alert.svg", uiDarkBatteryAlert),
+	UIIconPowerPlug:        fyne.NewStaticResource("resources/ui/dark/power_plug.svg", uiDarkPowerPlug),
 }
 
 var uiLightIconResources = map[UIIcon]fyne.Resource{
-	UIIconChats:           fyne.NewStaticResource("resources/ui/light/chats.svg", uiLightChats),
-	UIIconNodes:           fyne.NewStaticResource("resources/ui/light/nodes.svg", uiLightNodes),
-	UIIconMap:             fyne.NewStaticResource("resources/ui/light/map.svg", uiLightMap),
-	UIIconNodeSettings:    fyne.NewStaticResource("resources/ui/light/node_settings.svg", uiLightNodeSettings),
-	UIIconAppSettings:     fyne.NewStaticResource("resources/ui/light/app_settings.svg", uiLightAppSettings),
-	UIIconConnected:       fyne.NewStaticResource("resources/ui/light/connected.svg", uiLightConnected),
-	UIIconDisconnected:    fyne.NewStaticResource("resources/ui/light/disconnected.svg", uiLightDisconnected),
-	UIIconMapNodeMarker:   fyne.NewStaticResource("resources/ui/light/map_node_marker.svg", uiLightMapNodeMarker),
-	UIIconUpdateAvailable: fyne.NewStaticResource("resources/ui/light/update_available.svg", uiLightUpdateAvailable),
-	UIIconCloudUpload:     fyne.NewStaticResource("resources/ui/light/cloud_upload.svg", uiLightCloudUpload),
-	UIIconCloudDownload:   fyne.NewStaticResource("resources/ui/light/cloud_download.svg", uiLightCloudDownload),
-	UIIconLockGreen:       fyne.NewStaticResource("resources/ui/light/lock_green.svg", uiLightLockGreen),
-	UIIconLockYellow:      fyne.NewStaticResource("resources/ui/light/lock_yellow.svg", uiLightLockYellow),
-	UIIconLockRed:         fyne.NewStaticResource("resources/ui/light/lock_red.svg", uiLightLockRed),
-	UIIconLockRedWarning:  fyne.NewStaticResource("resources/ui/light/lock_red_warning.svg", uiLightLockRedWarning),
-	UIIconSpeakerMute:     fyne.NewStaticResource("resources/ui/light/speaker_mute.svg", uiLightSpeakerMute),
-	UIIconBadgePrimary:    fyne.NewStaticResource("resources/ui/light/badge_primary.svg", uiLightBadgePrimary),
-	UIIconRefresh:         fyne.NewStaticResource("resources/ui/light/refresh.svg", uiLightRefresh),
-	UIIconFavorite:        fyne.NewStaticResource("resources/ui/light/favorite.svg", uiLightFavorite),
+	UIIconChats:            fyne.NewStaticResource("resources/ui/light/chats.svg", uiLightChats),
+	UIIconNodes:            fyne.NewStaticResource("resources/ui/light/nodes.svg", uiLightNodes),
+	UIIconMap:              fyne.NewStaticResource("resources/ui/light/map.svg", uiLightMap),
+	UIIconNodeSettings:     fyne.NewStaticResource("resources/ui/light/node_settings.svg", uiLightNodeSettings),
+	UIIconAppSettings:      fyne.NewStaticResource("resources/ui/light/app_settings.svg", uiLightAppSettings),
+	UIIconConnected:        fyne.NewStaticResource("resources/ui/light/connected.svg", uiLightConnected),
+	UIIconDisconnected:     fyne.NewStaticResource("resources/ui/light/disconnected.svg", uiLightDisconnected),
+	UIIconMapNodeMarker:    fyne.NewStaticResource("resources/ui/light/map_node_marker.svg", uiLightMapNodeMarker),
+	UIIconUpdateAvailable:  fyne.NewStaticResource("resources/ui/light/update_available.svg", uiLightUpdateAvailable),
+	UIIconCloudUpload:      fyne.NewStaticResource("resources/ui/light/cloud_upload.svg", uiLightCloudUpload),
+	UIIconCloudDownload:    fyne.NewStaticResource("resources/ui/light/cloud_download.svg", uiLightCloudDownload),
+	UIIconLockGreen:        fyne.NewStaticResource("resources/ui/light/lock_green.svg", uiLightLockGreen),
+	UIIconLockYellow:       fyne.NewStaticResource("resources/ui/light/lock_yellow.svg", uiLightLockYellow),
+	UIIconLockRed:          fyne.NewStaticResource("resources/ui/light/lock_red.svg", uiLightLockRed),
+	UIIconLockRedWarning:   fyne.NewStaticResource("resources/ui/light/lock_red_warning.svg", uiLightLockRedWarning),
+	UIIconSpeakerMute:      fyne.NewStaticResource("resources/ui/light/speaker_mute.svg", uiLightSpeakerMute),
+	UIIconBadgePrimary:     fyne.NewStaticResource("resources/ui/light/badge_primary.svg", uiLightBadgePrimary),
+	UIIconRefresh:          fyne.NewStaticResource("resources/ui/light/refresh.svg", uiLightRefresh),
+	UIIconFavorite:         fyne.NewStaticResource("resources/ui/light/favorite.svg", uiLightFavorite),
+	UIIconHardwareBoard:    fyne.NewStaticResource("resources/ui/light/hw_board.svg", uiLightHwBoard),
+	UIIconHardwareHandheld: fyne.NewStaticResource("resources/ui/light/hw_handheld.svg", uiLightHwHandheld),
+	UIIconHardwareTracker:  fyne.NewStaticResource("resources/ui/light/hw_tracker.svg", uiLightHwTracker),
+	UIIconHardwareModule:   fyne.NewStaticResource("resources/ui/light/hw_module.svg", uiLightHwModule),
+	UIIconHardwareComputer: fyne.NewStaticResource("resources/ui/light/hw_computer.svg", uiLightHwComputer),
+	UIIconHardwareUnknown:  fyne.NewStaticResource("resources/ui/light/hw_unknown.svg", uiLightHwUnknown),
+	UIIconBatteryFull:      fyne.NewStaticResource("resources/ui/light/battery_full.svg", uiLightBatteryFull),
+	UIIconBatteryHalf:      fyne.NewStaticResource("resources/ui/light/battery_half.svg", uiLightBatteryHalf),
+	UIIconBatteryLow:       fyne.NewStaticResource("resources/ui/light/battery_low.svg", uiLightBatteryLow),
+	UIIconBatteryAlert:     fyne.NewStaticResource("resources/ui/light/battery_alert.svg", uiLightBatteryAlert),
+	UIIconPowerPlug:        fyne.NewStaticResource("resources/ui/light/power_plug.svg", uiLightPowerPlug),
 }
 
 func UIIconResource(icon UIIcon, variant fyne.ThemeVariant) fyne.Resource {
@@ -21,6 +21,17 @@ func TestUIIconResource_AllIconsMappedForBothVariants(t *testing.T) {
 		UIIconBadgePrimary,
 		UIIconRefresh,
 		UIIconFavorite,
+		UIIconHardwareBoard,
+		UIIconHardwareHandheld,
+		UIIconHardwareTracker,
+		UIIconHardwareModule,
+		UIIconHardwareComputer,
+		UIIconHardwareUnknown,
+		UIIconBatteryFull,
+		UIIconBatteryHalf,
+		UIIconBatteryLow,
+		UIIconBatteryAlert,
+		UIIconPowerPlug,
 	}
 
 	variants := []struct {
@@ -39,3 +50,48 @@ func TestUIIconResource_AllIconsMappedForBothVariants(t *testing.T) {
 		}
 	}
 }
+
+func TestTrayStatusIconResource(t *testing.T) {
+	connected := TrayStatusIconResource(theme.VariantDark, TrayStateConnected, 0)
+	if connected == TrayIconResource(theme.VariantDark) {
+		t.Fatalf("expected the status icon to be rendered over the tray icon")
+	}
+	if again := TrayStatusIconResource(theme.VariantDark, TrayStateConnected, 0); again != connected {
+		t.Fatalf("expected the rendered icon to be reused")
+	}
+	states := []TrayState{TrayStateDisconnected, TrayStateConnecting, TrayStateConnected}
+	seen := map[string]bool{}
+	for _, state := range states {
+		for _, unread := range []int{0, 1, 7, 42, 100} {
+			res := TrayStatusIconResource(theme.VariantLight, state, unread)
+			if seen[string(res.Content())] {
+				t.Fatalf("expected a distinct icon for %s, unread %d", state, unread)
+			}
+			seen[string(res.Content())] = true
+		}
+	}
+	if got := TrayStatusIconResource(theme.VariantDark, "unknown", 0); got != TrayStatusIconResource(theme.VariantDark, TrayStateDisconnected, 0) {
+		t.Fatalf("expected an unknown state to show as disconnected, got %s", got.Name())
+	}
+	if got, want := TrayStatusIconResource(theme.VariantDark, TrayStateConnected, 250), TrayStatusIconResource(theme.VariantDark, TrayStateConnected, 100); got != want {
+		t.Fatalf("expected counts over 99 to share the 99+ badge, got %s", got.Name())
+	}
+}
+
+func TestTrayBadgeLabel(t *testing.T) {
+	tests := []struct {
+		unread int
+		want   string
+	}{
+		{unread: -1, want: ""},
+		{unread: 0, want: ""},
+		{unread: 1, want: "1"},
+		{unread: 99, want: "99"},
+		{unread: 100, want: "99+"},
+	}
+	for _, tt := range tests {
+		if got := trayBadgeLabel(tt.unread); got != tt.want {
+			t.Fatalf("unread %d: expected %q, got %q", tt.unread, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package sounds
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/skobkin/meshgo/internal/config"
+)
+
+// bundledSampleRate is the sample rate of the synthesized sounds.
+const bundledSampleRate = 22050
+
+// tone is one decaying sine partial of a bundled sound.
+type tone struct {
+	start     float64 // seconds
+	frequency float64 // Hz
+	amplitude float64
+	decay     float64 // seconds until the partial fades to 1/e
+	// sweep changes the frequency by this many Hz over the first decay period.
+	sweep float64
+}
+
+// bundledSounds describes the sounds shipped with the app by their config names.
+var bundledSounds = map[string]struct {
+	length float64 // seconds
+	tones  []tone
+}{
+	config.SoundChime: {length: 0.9, tones: []tone{
+		{start: 0, frequency: 880, amplitude: 0.45, decay: 0.25},
+		{start: 0.12, frequency: 1318.5, amplitude: 0.4, decay: 0.3},
+		{start: 0.12, frequency: 2637, amplitude: 0.08, decay: 0.15},
+	}},
+	config.SoundBell: {length: 1.4, tones: []tone{
+		{start: 0, frequency: 660, amplitude: 0.4, decay: 0.5},
+		{start: 0, frequency: 1320 * 1.19, amplitude: 0.2, decay: 0.3},
+		{start: 0, frequency: 660 * 2.76, amplitude: 0.12, decay: 0.18},
+		{start: 0, frequency: 660 * 5.4, amplitude: 0.05, decay: 0.08},
+	}},
+	config.SoundPop: {length: 0.18, tones: []tone{
+		{start: 0, frequency: 320, amplitude: 0.7, decay: 0.04, sweep: 520},
+	}},
+	config.SoundClick: {length: 0.06, tones: []tone{
+		{start: 0, frequency: 2200, amplitude: 0.55, decay: 0.008},
+		{start: 0, frequency: 1100, amplitude: 0.3, decay: 0.012},
+	}},
+}
+
+// Bundled synthesizes the bundled sound with the given name.
+func Bundled(name string) (Clip, error) {
+	sound, ok := bundledSounds[name]
+	if !ok {
+		return Clip{}, fmt.Errorf("unknown bundled sound %q", name)
+	}
+
+	samples := make([]int16, int(sound.length*bundledSampleRate))
+	// Fade the last 10ms out so the sound doesn't end with a click.
+	fadeSamples := bundledSampleRate / 100
+	for i := range samples {
+		at := float64(i) / bundledSampleRate
+		var value float64
+		for _, partial := range sound.tones {
+			elapsed := at - partial.start
+			if elapsed < 0 {
+				continue
+			}
+			envelope := math.Exp(-elapsed / partial.decay)
+			// Integrate the frequency so a sweep doesn't jump in phase.
+			sweepTime := partial.decay * (1 - math.Exp(-elapsed/partial.decay))
+			phase := 2 * math.Pi * (partial.frequency*elapsed + partial.sweep*sweepTime)
+			value += partial.amplitude * envelope * math.Sin(phase)
+		}
+		if left := len(samples) - 1 - i; left < fadeSamples {
+			value *= float64(left) / float64(fadeSamples)
+		}
+		samples[i] = sampleFromFloat(value)
+	}
+
+	return Clip{SampleRate: bundledSampleRate, Channels: 1, Samples: samples}, nil
+}
@@ -0,0 +1,83 @@
+package sounds
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/skobkin/meshgo/internal/config"
+)
+
+// Prepare writes the sound at the given volume to a WAV file in dir and returns its
+// path. sound is a bundled sound name or the path to a WAV file. Files are reused while
+// the sound, its modification time and the volume stay the same.
+func Prepare(dir, sound string, volume int) (string, error) {
+	key := sound + "\x00" + strconv.Itoa(volume)
+	if !config.IsBundledSound(sound) {
+		info, err := os.Stat(sound)
+		if err != nil {
+			return "", fmt.Errorf("stat sound file: %w", err)
+		}
+		key += "\x00" + strconv.FormatInt(info.ModTime().UnixNano(), 10) + "\x00" + strconv.FormatInt(info.Size(), 10)
+	}
+	hasher := fnv.New64a()
+	_, _ = hasher.Write([]byte(key))
+	path := filepath.Join(dir, fmt.Sprintf("%016x.wav", hasher.Sum64()))
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	clip, err := load(sound)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("create sound cache dir: %w", err)
+	}
+	// Write to a temporary file first, so a player never gets a partial one.
+	tmp, err := os.CreateTemp(dir, "sound-*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("create sound file: %w", err)
+	}
+	_, writeErr := tmp.Write(EncodeWAV(clip.WithVolume(volume)))
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		_ = os.Remove(tmp.Name())
+
+		return "", fmt.Errorf("write sound file: %w", errors.Join(writeErr, closeErr))
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return "", fmt.Errorf("save sound file: %w", err)
+	}
+
+	return path, nil
+}
+
+// Validate checks that the file is a WAV file the app can play.
+func Validate(path string) error {
+	_, err := load(path)
+
+	return err
+}
+
+func load(sound string) (Clip, error) {
+	if config.IsBundledSound(sound) {
+		return Bundled(sound)
+	}
+	// #nosec G304 -- the path is a sound file picked by the user.
+	data, err := os.ReadFile(sound)
+	if err != nil {
+		return Clip{}, fmt.Errorf("read sound file: %w", err)
+	}
+	clip, err := DecodeWAV(data)
+	if err != nil {
+		return Clip{}, fmt.Errorf("decode sound file: %w", err)
+	}
+
+	return clip, nil
+}
@@ -0,0 +1,140 @@
+package sounds
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/config"
+)
+
+func TestBundled_SynthesizesEveryConfigSound(t *testing.T) {
+	for _, name := range config.BundledSounds {
+		clip, err := Bundled(name)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if clip.SampleRate != bundledSampleRate || clip.Channels != 1 || len(clip.Samples) == 0 {
+			t.Fatalf("%s: unexpected clip: rate=%d channels=%d samples=%d", name, clip.SampleRate, clip.Channels, len(clip.Samples))
+		}
+		if last := clip.Samples[len(clip.Samples)-1]; last != 0 {
+			t.Fatalf("%s: expected the sound to fade out, got last sample %d", name, last)
+		}
+	}
+	if _, err := Bundled("siren"); err == nil {
+		t.Fatalf("expected an error for an unknown sound")
+	}
+}
+
+func TestWAV_RoundTrip(t *testing.T) {
+	clip := Clip{SampleRate: 8000, Channels: 2, Samples: []int16{0, 1000, -1000, 32767, -32768, 5}}
+
+	decoded, err := DecodeWAV(EncodeWAV(clip))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.SampleRate != clip.SampleRate || decoded.Channels != clip.Channels {
+		t.Fatalf("unexpected format: expected %d/%d, got %d/%d", clip.SampleRate, clip.Channels, decoded.SampleRate, decoded.Channels)
+	}
+	if len(decoded.Samples) != len(clip.Samples) {
+		t.Fatalf("unexpected sample count: expected %d, got %d", len(clip.Samples), len(decoded.Samples))
+	}
+	for i := range clip.Samples {
+		if decoded.Samples[i] != clip.Samples[i] {
+			t.Fatalf("sample %d: expected %d, got %d", i, clip.Samples[i], decoded.Samples[i])
+		}
+	}
+}
+
+func TestDecodeWAV_RejectsUnsupportedFiles(t *testing.T) {
+	eightBit := EncodeWAV(Clip{SampleRate: 8000, Channels: 1, Samples: []int16{1, 2}})
+	eightBit[34] = 8
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{name: "not riff", data: []byte("ID3\x04 not a wav file")},
+		{name: "8-bit", data: eightBit, want: ErrUnsupportedWAV},
+		{name: "no data", data: EncodeWAV(Clip{SampleRate: 8000, Channels: 1})[:36]},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := DecodeWAV(tc.data)
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if tc.want != nil && !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestClipWithVolume(t *testing.T) {
+	clip := Clip{SampleRate: 8000, Channels: 1, Samples: []int16{1000, -2000}}
+
+	half := clip.WithVolume(50)
+	if half.Samples[0] != 500 || half.Samples[1] != -1000 {
+		t.Fatalf("expected samples to be halved, got %v", half.Samples)
+	}
+	if clip.Samples[0] != 1000 {
+		t.Fatalf("expected the original clip to stay unchanged, got %v", clip.Samples)
+	}
+	if louder := clip.WithVolume(150); louder.Samples[0] != 1000 {
+		t.Fatalf("expected volume to be capped at 100%%, got %v", louder.Samples)
+	}
+}
+
+func TestPrepare_ReusesFilesUntilTheSoundChanges(t *testing.T) {
+	dir := t.TempDir()
+	cacheDir := filepath.Join(dir, "cache")
+
+	first, err := Prepare(cacheDir, config.SoundPop, 40)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	again, err := Prepare(cacheDir, config.SoundPop, 40)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again != first {
+		t.Fatalf("expected the file to be reused: expected %q, got %q", first, again)
+	}
+	quieter, err := Prepare(cacheDir, config.SoundPop, 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if quieter == first {
+		t.Fatalf("expected another file for another volume")
+	}
+
+	custom := filepath.Join(dir, "custom.wav")
+	if err := os.WriteFile(custom, EncodeWAV(Clip{SampleRate: 8000, Channels: 1, Samples: []int16{1000, 2000}}), 0o600); err != nil {
+		t.Fatalf("write custom sound: %v", err)
+	}
+	path, err := Prepare(cacheDir, custom, 50)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read prepared sound: %v", err)
+	}
+	clip, err := DecodeWAV(data)
+	if err != nil {
+		t.Fatalf("decode prepared sound: %v", err)
+	}
+	if clip.Samples[0] != 500 || clip.Samples[1] != 1000 {
+		t.Fatalf("expected the custom sound at half volume, got %v", clip.Samples)
+	}
+
+	if _, err := Prepare(cacheDir, filepath.Join(dir, "missing.wav"), 50); err == nil {
+		t.Fatalf("expected an error for a missing file")
+	}
+	if err := Validate(filepath.Join(dir, "missing.wav")); err == nil {
+		t.Fatalf("expected validation to fail for a missing file")
+	}
+}
@@ -0,0 +1,114 @@
+// Package sounds synthesizes the bundled sound effects and prepares WAV files at the
+// volume picked by the user, so any system player can play them as they are.
+package sounds
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Clip is 16-bit PCM audio. Samples of several channels are interleaved.
+type Clip struct {
+	SampleRate int
+	Channels   int
+	Samples    []int16
+}
+
+// WithVolume returns a copy of the clip scaled to percent of its loudness.
+func (c Clip) WithVolume(percent int) Clip {
+	percent = min(max(percent, 0), 100)
+	scaled := Clip{SampleRate: c.SampleRate, Channels: c.Channels, Samples: make([]int16, len(c.Samples))}
+	for i, sample := range c.Samples {
+		scaled.Samples[i] = int16(int(sample) * percent / 100)
+	}
+
+	return scaled
+}
+
+// EncodeWAV writes the clip as a RIFF WAVE file.
+func EncodeWAV(c Clip) []byte {
+	const headerSize = 44
+	dataSize := len(c.Samples) * 2
+	blockAlign := c.Channels * 2
+
+	var buf bytes.Buffer
+	buf.Grow(headerSize + dataSize)
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(headerSize-8+dataSize))
+	buf.WriteString("WAVEfmt ")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(16))
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(1))
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(c.Channels))
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(c.SampleRate))
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(c.SampleRate*blockAlign))
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(blockAlign))
+	_ = binary.Write(&buf, binary.LittleEndian, uint16(16))
+	buf.WriteString("data")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(dataSize))
+	_ = binary.Write(&buf, binary.LittleEndian, c.Samples)
+
+	return buf.Bytes()
+}
+
+// ErrUnsupportedWAV is returned for WAV files that are not 16-bit PCM.
+var ErrUnsupportedWAV = errors.New("only 16-bit PCM WAV files are supported")
+
+// DecodeWAV reads a 16-bit PCM WAV file.
+func DecodeWAV(data []byte) (Clip, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return Clip{}, fmt.Errorf("not a WAV file")
+	}
+	var (
+		clip      Clip
+		formatSet bool
+	)
+	for rest := data[12:]; len(rest) >= 8; {
+		id := string(rest[:4])
+		size := int(binary.LittleEndian.Uint32(rest[4:8]))
+		rest = rest[8:]
+		if size > len(rest) {
+			size = len(rest)
+		}
+		chunk := rest[:size]
+		switch id {
+		case "fmt ":
+			if len(chunk) < 16 {
+				return Clip{}, fmt.Errorf("WAV format chunk is too short")
+			}
+			format := binary.LittleEndian.Uint16(chunk[0:2])
+			bits := binary.LittleEndian.Uint16(chunk[14:16])
+			// 0xFFFE is WAVE_FORMAT_EXTENSIBLE, which editors also use for plain PCM.
+			if (format != 1 && format != 0xFFFE) || bits != 16 {
+				return Clip{}, ErrUnsupportedWAV
+			}
+			clip.Channels = int(binary.LittleEndian.Uint16(chunk[2:4]))
+			clip.SampleRate = int(binary.LittleEndian.Uint32(chunk[4:8]))
+			formatSet = true
+		case "data":
+			if !formatSet {
+				return Clip{}, fmt.Errorf("WAV data comes before its format")
+			}
+			clip.Samples = make([]int16, size/2)
+			if err := binary.Read(bytes.NewReader(chunk[:len(clip.Samples)*2]), binary.LittleEndian, clip.Samples); err != nil {
+				return Clip{}, fmt.Errorf("read WAV samples: %w", err)
+			}
+			if clip.Channels <= 0 || clip.SampleRate <= 0 {
+				return Clip{}, ErrUnsupportedWAV
+			}
+
+			return clip, nil
+		}
+		// Chunks are padded to an even size.
+		rest = rest[min(size+size%2, len(rest)):]
+	}
+
+	return Clip{}, fmt.Errorf("WAV file has no audio data")
+}
+
+// sampleFromFloat converts a -1..1 sample to 16-bit PCM.
+func sampleFromFloat(value float64) int16 {
+	return int16(math.Round(min(max(value, -1), 1) * math.MaxInt16))
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	meshapp "github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// myNodeShareHint tells how the QR code of the local node is used.
+const myNodeShareHint = "Scan with the Meshtastic phone app to add this node as a contact. The link opens the app on phones where it is installed."
+
+// handleMyNodeShareAction shows the contact QR code of the connected node, built from
+// the identity the device reported, with a shortcut to sharing its channels.
+func handleMyNodeShareAction(window fyne.Window, dep RuntimeDependencies) {
+	if window == nil {
+		window = currentRuntimeWindow(dep)
+	}
+	if window == nil {
+		return
+	}
+	snapshot := localNodeSnapshot(dep)
+	if strings.TrimSpace(snapshot.ID) == "" {
+		showInfoModal(dep, i18n.T("My QR code"), i18n.T("Connect to a device to share its contact."))
+
+		return
+	}
+
+	rawURL, err := meshapp.BuildSharedContactURL(snapshot.Node)
+	if err != nil {
+		showErrorModal(dep, fmt.Errorf("build shared contact URL: %w", err))
+
+		return
+	}
+
+	var actions []fyne.CanvasObject
+	if dep.Actions.NodeSettings != nil {
+		actions = append(actions, widget.NewButton(i18n.T("Share channels…"), func() {
+			handleChannelShareAction(window, dep, domain.Chat{Key: domain.ChatKeyForChannel(0)})
+		}))
+	}
+	showQRCodeShareModal(window, qrShareModalPayload{
+		Title:   i18n.Tf("My contact: %s", nodeDisplayName(snapshot.Node)),
+		URL:     rawURL,
+		Hint:    i18n.T(myNodeShareHint),
+		Actions: actions,
+	})
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// nodeCopyItem is a piece of node data that can be put on the clipboard.
+type nodeCopyItem struct {
+	Action NodeAction
+	// Label is the untranslated menu label.
+	Label string
+	Value string
+}
+
+// nodeCopyItems lists the node data known for node, in menu order.
+func nodeCopyItems(node domain.Node) []nodeCopyItem {
+	items := make([]nodeCopyItem, 0, 5)
+	if nodeID := strings.TrimSpace(node.NodeID); nodeID != "" {
+		items = append(items, nodeCopyItem{Action: NodeActionCopyID, Label: "Node ID", Value: nodeID})
+	}
+	if hexID := nodeHexID(node.NodeID); hexID != "" {
+		items = append(items, nodeCopyItem{Action: NodeActionCopyHexID, Label: "Hex ID", Value: hexID})
+	}
+	if publicKey := encodeNodeSettingsKeyBase64(node.PublicKey); publicKey != "" {
+		items = append(items, nodeCopyItem{Action: NodeActionCopyPublicKey, Label: "Public key", Value: publicKey})
+	}
+	if coordinate, ok := nodeCoordinate(node); ok {
+		items = append(items,
+			nodeCopyItem{
+				Action: NodeActionCopyCoordinates,
+				Label:  "Coordinates",
+				Value:  fmt.Sprintf("%.5f,%.5f", coordinate.Latitude, coordinate.Longitude),
+			},
+			nodeCopyItem{
+				Action: NodeActionCopyGeoURI,
+				Label:  "geo: URI",
+				Value:  domain.GeoURIText(coordinate.Latitude, coordinate.Longitude),
+			},
+		)
+	}
+
+	return items
+}
+
+// nodeHexID renders a !xxxxxxxx node ID as the 0x-prefixed node number, or returns ""
+// when nodeID is not in that form.
+func nodeHexID(nodeID string) string {
+	raw, ok := strings.CutPrefix(strings.TrimSpace(nodeID), "!")
+	if !ok {
+		return ""
+	}
+	num, err := strconv.ParseUint(raw, 16, 32)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("0x%08x", num)
+}
+
+// newNodeCopyMenuItems builds one menu item per known piece of node data.
+func newNodeCopyMenuItems(node domain.Node, onCopy func(item nodeCopyItem)) []*fyne.MenuItem {
+	items := nodeCopyItems(node)
+	menuItems := make([]*fyne.MenuItem, 0, len(items))
+	for _, item := range items {
+		menuItems = append(menuItems, fyne.NewMenuItem(i18n.T(item.Label), func() {
+			if onCopy != nil {
+				onCopy(item)
+			}
+		}))
+	}
+
+	return menuItems
+}
+
+// handleNodeCopyAction puts the node data picked in the "Copy" menu on the clipboard.
+func handleNodeCopyAction(window fyne.Window, node domain.Node, action NodeAction) {
+	if window == nil {
+		return
+	}
+	for _, item := range nodeCopyItems(node) {
+		if item.Action != action {
+			continue
+		}
+		if err := copyTextToClipboard(item.Value); err != nil {
+			nodeCopyGridShowErrorDialog(fmt.Errorf("copy %s: %w", strings.ToLower(item.Label), err), window)
+		}
+
+		return
+	}
+	nodeCopyGridShowErrorDialog(fmt.Errorf("node data is unknown"), window)
+}
@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+var nodeCopyGridShowErrorDialog = dialog.ShowError
+
+// handleNodeCopyGridAction puts the node grid square on the clipboard, which is how
+// locators are usually exchanged on HF nets.
+func handleNodeCopyGridAction(window fyne.Window, node domain.Node) {
+	if window == nil {
+		return
+	}
+	grid := nodeGridSquare(node)
+	if grid == "" {
+		nodeCopyGridShowErrorDialog(fmt.Errorf("node position is unknown"), window)
+
+		return
+	}
+	if err := copyTextToClipboard(grid); err != nil {
+		nodeCopyGridShowErrorDialog(fmt.Errorf("copy grid square: %w", err), window)
+	}
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestNodeCopyItems(t *testing.T) {
+	lat, lon := 50.450333, 30.523333
+	node := domain.Node{NodeID: "!0000002a", PublicKey: []byte{1, 2, 3}, Latitude: &lat, Longitude: &lon}
+
+	want := map[NodeAction]string{
+		NodeActionCopyID:          "!0000002a",
+		NodeActionCopyHexID:       "0x0000002a",
+		NodeActionCopyPublicKey:   "AQID",
+		NodeActionCopyCoordinates: "50.45033,30.52333",
+		NodeActionCopyGeoURI:      "geo:50.45033,30.52333",
+	}
+	items := nodeCopyItems(node)
+	if len(items) != len(want) {
+		t.Fatalf("unexpected item count: expected %d, got %d", len(want), len(items))
+	}
+	for _, item := range items {
+		if item.Value != want[item.Action] {
+			t.Fatalf("unexpected %s value: expected %q, got %q", item.Action, want[item.Action], item.Value)
+		}
+	}
+
+	if items := nodeCopyItems(domain.Node{NodeID: "!0000002a"}); len(items) != 2 {
+		t.Fatalf("expected only the IDs of a node without key and position, got %+v", items)
+	}
+}
+
+func TestNodeHexID(t *testing.T) {
+	tests := []struct {
+		nodeID string
+		want   string
+	}{
+		{"!0000002a", "0x0000002a"},
+		{" !DEADBEEF ", "0xdeadbeef"},
+		{"0000002a", ""},
+		{"!zz", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := nodeHexID(tt.nodeID); got != tt.want {
+			t.Fatalf("unexpected hex ID for %q: expected %q, got %q", tt.nodeID, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+var (
+	nodeDeleteShowConfirmDialog = dialog.ShowConfirm
+	nodeDeleteShowErrorDialog   = dialog.ShowError
+)
+
+func handleNodeDeleteAction(window fyne.Window, dep RuntimeDependencies, node domain.Node) {
+	if window == nil {
+		return
+	}
+	if dep.Actions.OnDeleteNode == nil {
+		nodeDeleteShowErrorDialog(fmt.Errorf("delete action is unavailable: database is not configured"), window)
+
+		return
+	}
+	nodeDeleteShowConfirmDialog(
+		i18n.T("Delete node?"),
+		i18n.Tf(
+			"Remove %s from the node list?\nIt comes back when heard again and can be restored from App → Maintenance → Recently deleted until it is purged.",
+			nodeDisplayName(node),
+		),
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			if err := dep.Actions.OnDeleteNode(node.NodeID); err != nil {
+				nodeDeleteShowErrorDialog(err, window)
+			}
+		},
+		window,
+	)
+}
@@ -29,3 +29,21 @@ func handleNodeFavoriteAction(window fyne.Window, dep RuntimeDependencies, node
 		}
 	}(node.NodeID, favorite)
 }
+
+func handleNodeIgnoreAction(window fyne.Window, dep RuntimeDependencies, node domain.Node, ignored bool) {
+	if window == nil {
+		return
+	}
+	if dep.Actions.NodeIgnore == nil {
+		nodeFavoriteShowErrorDialog(fmt.Errorf("ignore action is unavailable: radio service is not configured"), window)
+
+		return
+	}
+	go func(nodeID string, wantIgnored bool) {
+		if err := dep.Actions.NodeIgnore.SetIgnored(context.Background(), nodeID, wantIgnored); err != nil {
+			fyne.Do(func() {
+				nodeFavoriteShowErrorDialog(err, window)
+			})
+		}
+	}(node.NodeID, ignored)
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+var nodeMuteShowErrorDialog = dialog.ShowError
+
+// nodeNotificationsMuted reports whether notifications about messages from node are muted.
+func nodeNotificationsMuted(dep RuntimeDependencies, node domain.Node) bool {
+	if dep.Data.CurrentConfig != nil {
+		return dep.Data.CurrentConfig().UI.Notifications.MutesNode(node.NodeID)
+	}
+
+	return dep.Data.Config.UI.Notifications.MutesNode(node.NodeID)
+}
+
+// handleNodeMuteAction mutes or unmutes notifications about DMs and channel messages
+// from node. The messages are still stored.
+func handleNodeMuteAction(window fyne.Window, dep RuntimeDependencies, node domain.Node, muted bool) {
+	if window == nil {
+		return
+	}
+	if dep.Actions.OnSetNodeMuted == nil {
+		nodeMuteShowErrorDialog(fmt.Errorf("mute action is unavailable"), window)
+
+		return
+	}
+	if err := dep.Actions.OnSetNodeMuted(node.NodeID, muted); err != nil {
+		nodeMuteShowErrorDialog(err, window)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fyne.io/fyne/v2/dialog"
 
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 	"github.com/skobkin/meshgo/internal/radio"
 )
 
@@ -37,7 +38,7 @@ func handleNodeRequestUserInfoAction(dep RuntimeDependencies, node domain.Node)
 
 				return
 			}
-			showNodeOverviewInfo(dep, fmt.Sprintf("Requested user info from %s.", nodeDisplayName(node)))
+			showNodeOverviewInfo(dep, i18n.Tf("Requested user info from %s.", nodeDisplayName(node)))
 		})
 	}()
 }
@@ -61,7 +62,7 @@ func handleNodeRequestTelemetryAction(dep RuntimeDependencies, node domain.Node,
 
 				return
 			}
-			showNodeOverviewInfo(dep, fmt.Sprintf("Requested %s telemetry from %s.", telemetryKindTitle(kind), nodeDisplayName(node)))
+			showNodeOverviewInfo(dep, i18n.Tf("Requested %s telemetry from %s.", telemetryKindTitle(kind), nodeDisplayName(node)))
 		})
 	}()
 }
@@ -69,15 +70,15 @@ func handleNodeRequestTelemetryAction(dep RuntimeDependencies, node domain.Node,
 func telemetryKindTitle(kind radio.TelemetryRequestKind) string {
 	switch kind {
 	case radio.TelemetryRequestDevice:
-		return "device"
+		return i18n.T("device")
 	case radio.TelemetryRequestEnvironment:
-		return "environment"
+		return i18n.T("environment")
 	case radio.TelemetryRequestAirQuality:
-		return "air quality"
+		return i18n.T("air quality")
 	case radio.TelemetryRequestPower:
-		return "power"
+		return i18n.T("power")
 	default:
-		return "unknown"
+		return i18n.T("unknown")
 	}
 }
 
@@ -88,12 +89,12 @@ func showNodeOverviewInfo(dep RuntimeDependencies, message string) {
 	}
 	window := currentRuntimeWindow(dep)
 	if dep.UIHooks.ShowInfoDialog != nil {
-		dep.UIHooks.ShowInfoDialog("Node overview", message, window)
+		dep.UIHooks.ShowInfoDialog(i18n.T("Node overview"), message, window)
 
 		return
 	}
 	if window == nil {
 		return
 	}
-	dialog.ShowInformation("Node overview", message, window)
+	dialog.ShowInformation(i18n.T("Node overview"), message, window)
 }
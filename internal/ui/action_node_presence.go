@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+const nodePresenceOptionOff = "Off"
+
+// nodePresenceMinutesOptions are the silences offered for presence alerts.
+var nodePresenceMinutesOptions = []int{15, 30, 60, 120, 360, 720, 1440, 4320}
+
+func nodePresenceMinutesLabels() []string {
+	labels := make([]string, 0, len(nodePresenceMinutesOptions)+1)
+	labels = append(labels, i18n.T(nodePresenceOptionOff))
+	for _, minutes := range nodePresenceMinutesOptions {
+		labels = append(labels, nodePresenceMinutesLabel(minutes))
+	}
+
+	return labels
+}
+
+func nodePresenceMinutesLabel(minutes int) string {
+	switch {
+	case minutes <= 0:
+		return i18n.T(nodePresenceOptionOff)
+	case minutes%1440 == 0:
+		return fmt.Sprintf("%d d", minutes/1440)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%d h", minutes/60)
+	default:
+		return fmt.Sprintf("%d min", minutes)
+	}
+}
+
+func parseNodePresenceMinutesLabel(label string) int {
+	var (
+		count int
+		unit  string
+	)
+	if _, err := fmt.Sscanf(label, "%d %s", &count, &unit); err != nil || count <= 0 {
+		return 0
+	}
+	switch unit {
+	case "d":
+		return count * 1440
+	case "h":
+		return count * 60
+	default:
+		return count
+	}
+}
+
+// nodePresenceAlert returns the presence alerts set for node.
+func nodePresenceAlert(dep RuntimeDependencies, node domain.Node) config.NodePresenceAlert {
+	if dep.Data.CurrentConfig != nil {
+		return dep.Data.CurrentConfig().UI.Notifications.PresenceAlert(node.NodeID)
+	}
+
+	return dep.Data.Config.UI.Notifications.PresenceAlert(node.NodeID)
+}
+
+// handleNodePresenceAction sets when the favorite node alerts about going silent and
+// being heard again.
+func handleNodePresenceAction(window fyne.Window, dep RuntimeDependencies, node domain.Node) {
+	if window == nil {
+		return
+	}
+	if dep.Actions.OnSetNodePresenceAlert == nil {
+		dialog.ShowError(errors.New(i18n.T("Presence alerts are unavailable")), window)
+
+		return
+	}
+	current := nodePresenceAlert(dep, node)
+	// Keep an unusual saved value selectable instead of silently turning it off.
+	silentSelect := widget.NewSelect(uniqueValues(append(nodePresenceMinutesLabels(), nodePresenceMinutesLabel(current.SilentMinutes))), nil)
+	silentSelect.SetSelected(nodePresenceMinutesLabel(current.SilentMinutes))
+	heardAgainSelect := widget.NewSelect(uniqueValues(append(nodePresenceMinutesLabels(), nodePresenceMinutesLabel(current.HeardAgainMinutes))), nil)
+	heardAgainSelect.SetSelected(nodePresenceMinutesLabel(current.HeardAgainMinutes))
+	help := widget.NewLabel(i18n.T("Favorite nodes only. Notifications for these alerts can be turned off in Settings."))
+	help.Wrapping = fyne.TextWrapWord
+
+	presenceDialog := dialog.NewForm(
+		i18n.Tf("Presence alerts: %s", nodeDisplayName(node)),
+		i18n.T("Save"),
+		i18n.T("Cancel"),
+		[]*widget.FormItem{
+			widget.NewFormItem(i18n.T("Silent for"), silentSelect),
+			widget.NewFormItem(i18n.T("Heard again after"), heardAgainSelect),
+			widget.NewFormItem("", help),
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			alert := config.NodePresenceAlert{
+				SilentMinutes:     parseNodePresenceMinutesLabel(silentSelect.Selected),
+				HeardAgainMinutes: parseNodePresenceMinutesLabel(heardAgainSelect.Selected),
+			}
+			if err := dep.Actions.OnSetNodePresenceAlert(node.NodeID, alert); err != nil {
+				dialog.ShowError(err, window)
+			}
+		},
+		window,
+	)
+	presenceDialog.Resize(fyne.NewSize(420, presenceDialog.MinSize().Height))
+	presenceDialog.Show()
+}
@@ -0,0 +1,23 @@
+package ui
+
+import "testing"
+
+func TestNodePresenceMinutesLabelRoundTrip(t *testing.T) {
+	tests := []struct {
+		minutes int
+		label   string
+	}{
+		{minutes: 0, label: "Off"},
+		{minutes: 45, label: "45 min"},
+		{minutes: 120, label: "2 h"},
+		{minutes: 4320, label: "3 d"},
+	}
+	for _, tc := range tests {
+		if got := nodePresenceMinutesLabel(tc.minutes); got != tc.label {
+			t.Fatalf("minutes %d: expected %q, got %q", tc.minutes, tc.label, got)
+		}
+		if got := parseNodePresenceMinutesLabel(tc.label); got != tc.minutes {
+			t.Fatalf("label %q: expected %d, got %d", tc.label, tc.minutes, got)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	meshapp "github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func handleNodeShareContactAction(window fyne.Window, dep RuntimeDependencies, node domain.Node) {
@@ -25,7 +26,7 @@ func handleNodeShareContactAction(window fyne.Window, dep RuntimeDependencies, n
 	}
 
 	showQRCodeShareModal(window, qrShareModalPayload{
-		Title: "Share contact",
+		Title: i18n.T("Share contact"),
 		URL:   rawURL,
 	})
 }
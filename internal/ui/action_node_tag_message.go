@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+	"github.com/skobkin/meshgo/internal/radio"
+)
+
+// tagMessageRecipients returns the DM chat keys of tagged nodes that can receive a
+// direct message. The local node and infrastructure nodes are skipped.
+func tagMessageRecipients(nodes []domain.Node, tag, localNodeID string) []string {
+	out := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if !domain.HasTag(node.Tags, tag) || isLocalNode(node, localNodeID) {
+			continue
+		}
+		if node.IsUnmessageable != nil && *node.IsUnmessageable {
+			continue
+		}
+		nodeID := domain.NormalizeNodeID(node.NodeID)
+		if nodeID == "" {
+			continue
+		}
+		out = append(out, domain.ChatKeyForDM(nodeID))
+	}
+
+	return out
+}
+
+func tagMessageResultText(tag string, sent, total int) string {
+	if sent == total {
+		return i18n.Tf("Message sent to %d nodes tagged %q.", total, tag)
+	}
+
+	return i18n.Tf("Message sent to %d of %d nodes tagged %q.", sent, total, tag)
+}
+
+// handleNodeTagMessageAction sends the same direct message to every node with the tag.
+func handleNodeTagMessageAction(window fyne.Window, dep RuntimeDependencies, tag string, nodes []domain.Node) {
+	if window == nil {
+		return
+	}
+	if dep.Actions.Sender == nil {
+		dialog.ShowError(errors.New("messaging is unavailable: radio service is not configured"), window)
+
+		return
+	}
+	recipients := tagMessageRecipients(nodes, tag, localNodeIDValue(dep.Data.LocalNodeID))
+	if len(recipients) == 0 {
+		dialog.ShowInformation(i18n.T("Message tag"), i18n.Tf("No nodes tagged %q can receive direct messages.", tag), window)
+
+		return
+	}
+
+	entry := widget.NewMultiLineEntry()
+	entry.Wrapping = fyne.TextWrapWord
+	entry.SetPlaceHolder(i18n.Tf("Up to %d bytes", maxTextMessageBytes))
+	entry.SetMinRowsVisible(3)
+	dialog.ShowForm(
+		i18n.Tf("Message %d nodes tagged %q", len(recipients), tag),
+		i18n.T("Send"),
+		i18n.T("Cancel"),
+		[]*widget.FormItem{widget.NewFormItem(i18n.T("Message"), entry)},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			compactCyrillic := dep.Data.Config.UI.Messaging.CompactCyrillicEncoding
+			if dep.Data.CurrentConfig != nil {
+				compactCyrillic = dep.Data.CurrentConfig().UI.Messaging.CompactCyrillicEncoding
+			}
+			prepared := prepareOutgoingText(entry.Text, compactCyrillic)
+			if prepared.body == "" {
+				return
+			}
+			if prepared.byteCount > maxTextMessageBytes {
+				dialog.ShowError(fmt.Errorf("message exceeds %d bytes", maxTextMessageBytes), window)
+
+				return
+			}
+			go sendTagMessage(window, dep, tag, recipients, prepared.body)
+		},
+		window,
+	)
+}
+
+func sendTagMessage(window fyne.Window, dep RuntimeDependencies, tag string, recipients []string, body string) {
+	sent := 0
+	var failures []string
+	for _, chatKey := range recipients {
+		if dep.Data.ChatStore != nil {
+			dep.Data.ChatStore.UpsertChat(domain.Chat{Key: chatKey, Title: chatKey, Type: domain.ChatTypeDM})
+		}
+		res := <-dep.Actions.Sender.SendText(chatKey, body, radio.TextSendOptions{})
+		if res.Err != nil {
+			appLogger.Warn("tag message send failed", "chat_key", chatKey, "tag", tag, "error", res.Err)
+			failures = append(failures, fmt.Sprintf("%s: %v", domain.NodeIDFromDMChatKey(chatKey), res.Err))
+
+			continue
+		}
+		sent++
+	}
+	appLogger.Info("tag message sent", "tag", tag, "sent", sent, "total", len(recipients))
+
+	fyne.Do(func() {
+		text := tagMessageResultText(tag, sent, len(recipients))
+		if len(failures) > 0 {
+			text += "\n\n" + strings.Join(failures, "\n")
+		}
+		dialog.ShowInformation(i18n.T("Message tag"), text, window)
+	})
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
 )
 
 var tracerouteShowErrorDialog = dialog.ShowError
@@ -26,7 +27,22 @@ func handleNodeTracerouteAction(window fyne.Window, dep RuntimeDependencies, nod
 		return
 	}
 
-	initial, err := dep.Actions.Traceroute.StartTraceroute(context.Background(), app.TracerouteTarget{NodeID: node.NodeID})
+	start := func() (busmsg.TracerouteUpdate, error) {
+		return startNodeTraceroute(dep.Actions.Traceroute, node)
+	}
+	initial, err := start()
+	if err != nil {
+		tracerouteShowErrorDialog(err, window)
+
+		return
+	}
+
+	tracerouteShowModal(window, dep.Data.Bus, dep.Data.NodeStore, node, initial, start)
+}
+
+// startNodeTraceroute starts a traceroute to node, explaining the cooldown lock.
+func startNodeTraceroute(action TracerouteAction, node domain.Node) (busmsg.TracerouteUpdate, error) {
+	update, err := action.StartTraceroute(context.Background(), app.TracerouteTarget{NodeID: node.NodeID})
 	if err != nil {
 		var cooldownErr *app.TracerouteCooldownError
 		if errors.As(err, &cooldownErr) {
@@ -34,14 +50,12 @@ func handleNodeTracerouteAction(window fyne.Window, dep RuntimeDependencies, nod
 			if remaining < time.Second {
 				remaining = time.Second
 			}
-			tracerouteShowErrorDialog(fmt.Errorf("traceroute is locked, try again in %s", remaining), window)
 
-			return
+			return busmsg.TracerouteUpdate{}, fmt.Errorf("traceroute is locked, try again in %s", remaining)
 		}
-		tracerouteShowErrorDialog(err, window)
 
-		return
+		return busmsg.TracerouteUpdate{}, err
 	}
 
-	tracerouteShowModal(window, dep.Data.Bus, dep.Data.NodeStore, node, initial)
+	return update, nil
 }
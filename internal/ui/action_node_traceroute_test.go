@@ -132,6 +132,7 @@ func TestHandleNodeTracerouteAction_SuccessShowsModal(t *testing.T) {
 	nodeStore := domain.NewNodeStore()
 
 	modalCalled := 0
+	var restart func() (busmsg.TracerouteUpdate, error)
 	origShowModal := tracerouteShowModal
 	tracerouteShowModal = func(
 		gotWindow fyne.Window,
@@ -139,8 +140,10 @@ func TestHandleNodeTracerouteAction_SuccessShowsModal(t *testing.T) {
 		gotNodeStore *domain.NodeStore,
 		gotNode domain.Node,
 		gotInitial busmsg.TracerouteUpdate,
+		gotRestart func() (busmsg.TracerouteUpdate, error),
 	) {
 		modalCalled++
+		restart = gotRestart
 		if gotWindow != window {
 			t.Fatalf("unexpected window passed to modal")
 		}
@@ -176,4 +179,13 @@ func TestHandleNodeTracerouteAction_SuccessShowsModal(t *testing.T) {
 	if modalCalled != 1 {
 		t.Fatalf("expected modal to be shown once, got %d", modalCalled)
 	}
+	if restart == nil {
+		t.Fatalf("expected modal to get a restart function")
+	}
+	if _, err := restart(); err != nil {
+		t.Fatalf("restart traceroute: %v", err)
+	}
+	if spy.startCalls != 2 {
+		t.Fatalf("expected restart to start another traceroute, got %d start calls", spy.startCalls)
+	}
 }
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// airtimeGaugeWidth is the width of the bar of an airtime gauge.
+const airtimeGaugeWidth = float32(48)
+
+// airtimeGauge is a small captioned bar showing an airtime percentage, colored by how
+// close it is to the firmware thresholds.
+type airtimeGauge struct {
+	level   func(float64) domain.AirtimeLevel
+	track   *canvas.Rectangle
+	fill    *canvas.Rectangle
+	bar     *gaugeBarLayout
+	value   *widget.Label
+	content *fyne.Container
+}
+
+func newAirtimeGauge(caption string, level func(float64) domain.AirtimeLevel) *airtimeGauge {
+	gauge := &airtimeGauge{
+		level: level,
+		track: canvas.NewRectangle(theme.Color(theme.ColorNameInputBackground)),
+		fill:  canvas.NewRectangle(theme.Color(theme.ColorNameSuccess)),
+		bar:   &gaugeBarLayout{},
+		value: widget.NewLabel(""),
+	}
+	gauge.track.CornerRadius = 2
+	gauge.fill.CornerRadius = 2
+	bar := container.New(gauge.bar, gauge.track, gauge.fill)
+	gauge.content = container.NewHBox(
+		widget.NewLabel(caption),
+		container.NewCenter(container.NewGridWrap(fyne.NewSize(airtimeGaugeWidth, theme.CaptionTextSize()), bar)),
+		gauge.value,
+	)
+	gauge.content.Hide()
+
+	return gauge
+}
+
+// Set shows percent, or hides the gauge when it is nil.
+func (g *airtimeGauge) Set(percent *float64) {
+	if percent == nil {
+		g.content.Hide()
+
+		return
+	}
+	g.bar.fraction = float32(min(max(*percent, 0), 100) / 100)
+	g.fill.FillColor = airtimeLevelColor(g.level(*percent))
+	g.value.SetText(currentDisplayFormatter().Number("%.1f%%", *percent))
+	g.value.Importance = airtimeLevelImportance(g.level(*percent))
+	g.content.Show()
+	g.content.Refresh()
+}
+
+func airtimeLevelColor(level domain.AirtimeLevel) color.Color {
+	switch level {
+	case domain.AirtimeCritical:
+		return theme.Color(theme.ColorNameError)
+	case domain.AirtimeElevated:
+		return theme.Color(theme.ColorNameWarning)
+	default:
+		return theme.Color(theme.ColorNameSuccess)
+	}
+}
+
+func airtimeLevelImportance(level domain.AirtimeLevel) widget.Importance {
+	switch level {
+	case domain.AirtimeCritical:
+		return widget.DangerImportance
+	case domain.AirtimeElevated:
+		return widget.WarningImportance
+	default:
+		return widget.MediumImportance
+	}
+}
+
+// gaugeBarLayout stretches the first object over the whole bar and the second over
+// fraction of its width.
+type gaugeBarLayout struct {
+	fraction float32
+}
+
+func (l *gaugeBarLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	if len(objects) < 2 {
+		return
+	}
+	objects[0].Move(fyne.NewPos(0, 0))
+	objects[0].Resize(size)
+	objects[1].Move(fyne.NewPos(0, 0))
+	objects[1].Resize(fyne.NewSize(size.Width*l.fraction, size.Height))
+}
+
+func (l *gaugeBarLayout) MinSize(_ []fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(0, 0)
+}
@@ -50,3 +50,11 @@ func nodeChanges(store *domain.NodeStore) <-chan struct{} {
 
 	return store.Changes()
 }
+
+func nodeSnapshot(store *domain.NodeStore) func() []domain.Node {
+	if store == nil {
+		return nil
+	}
+
+	return store.SnapshotSorted
+}
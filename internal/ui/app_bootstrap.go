@@ -4,7 +4,10 @@ import (
 	"fyne.io/fyne/v2"
 	fyneapp "fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/i18n"
 	"github.com/skobkin/meshgo/internal/resources"
 )
 
@@ -13,30 +16,54 @@ var newFyneApp = func() fyne.App {
 }
 
 func runWithApp(dep RuntimeDependencies, fyApp fyne.App) error {
-	initialVariant := fyApp.Settings().ThemeVariant()
+	if err := i18n.LoadDir(dep.Data.Paths.TranslationsDir); err != nil {
+		appLogger.Warn("load user translations failed", "dir", dep.Data.Paths.TranslationsDir, "error", err)
+	}
+	language := applyUILanguage(dep.Data.Config.UI.Language)
+	window := fyApp.NewWindow("")
+	windowState := newWindowStateKeeper(dep, window)
+	windowState.RestoreSize()
+	displayScale := newDisplayScaleRuntime(fyApp, window, dep.Data.Config.UI.Display)
+	initialVariant := appThemeVariant(fyApp)
 	fyApp.SetIcon(resources.AppIconResource(initialVariant))
 	appLogger.Info(
 		"starting UI runtime",
 		"start_hidden", dep.Launch.StartHidden,
 		"initial_theme", initialVariant,
+		"language", language,
 	)
 
 	initialStatus := resolveInitialConnStatus(dep)
+	setDisplayFormats(dep.Data.Config.UI.Formats)
+	setShortcutOverrides(dep.Data.Config.UI.Shortcuts)
 
-	window := fyApp.NewWindow("")
-	window.Resize(fyne.NewSize(1000, 700))
+	attention := newUserAttention(!dep.Launch.StartHidden, dep.Platform.IdleTime)
 	view := buildMainView(
 		dep,
 		fyApp,
 		window,
 		initialVariant,
 		initialStatus,
+		attention,
 	)
 
+	windowState.RestoreTab(view.sidebar.SwitchTab)
+
 	themeRuntime := newThemeRuntime(fyApp, view.sidebar, view.updateIndicator, view.applyMapTheme, view.connStatusPresenter)
 	themeRuntime.BindSettings()
 
-	stopNotifications := startNotificationService(dep, fyApp, dep.Launch.StartHidden)
+	currentConfig := dep.Data.CurrentConfig
+	if currentConfig == nil {
+		currentConfig = func() config.AppConfig { return dep.Data.Config }
+	}
+	stopNotifications := startNotificationService(
+		dep,
+		fyApp,
+		attention,
+		newTaskbarFlasherFor(dep, window, attention, currentConfig),
+		view.notificationHistory,
+		notificationClickHandler(window, currentConfig, view.openChat),
+	)
 
 	stopUIListeners, stopUpdateSnapshots := bindPresentationListeners(
 		dep,
@@ -50,8 +77,32 @@ func runWithApp(dep RuntimeDependencies, fyApp fyne.App) error {
 		dep.Actions.OnStartUpdateChecker()
 	}
 
-	content := container.NewBorder(nil, nil, view.left, nil, view.rightStack)
+	content := container.NewBorder(
+		nil,
+		view.statusBar,
+		view.left,
+		nil,
+		container.NewStack(view.rightStack, view.errorToasts.Content()),
+	)
 	window.SetContent(content)
+	stopDisplayScale := displayScale.Start()
+	stopActivity := view.statusStrip.StartActivity(dep.Data.Bus)
+	stopNotificationCenter := view.notificationCenter.Start()
+	stopDoNotDisturb := view.doNotDisturb.Start()
+	stopDiagnostics := view.diagnostics.Start()
+	stopConnectionToasts := view.errorToasts.StartConnectionWatch(dep.Data.Bus)
+	stopPresentation := stopUIListeners
+	stopUIListeners = func() {
+		stopDisplayScale()
+		stopActivity()
+		stopNotificationCenter()
+		stopDoNotDisturb()
+		stopDiagnostics()
+		stopConnectionToasts()
+		if stopPresentation != nil {
+			stopPresentation()
+		}
+	}
 
 	uiRuntime := newUIRuntime(
 		fyApp,
@@ -61,12 +112,32 @@ func runWithApp(dep RuntimeDependencies, fyApp fyne.App) error {
 		stopUpdateSnapshots,
 		dep.Actions.OnQuit,
 	)
-	uiRuntime.BindCloseIntercept()
+	uiRuntime.BindWindowState(windowState.RestorePlacement, func() {
+		windowState.Save(view.sidebar.ActiveTab())
+	})
+	uiRuntime.BindCloseIntercept(view.closeButton)
+	uiRuntime.BindMainMenu()
 
-	setTrayIcon := configureSystemTray(fyApp, window, initialVariant, uiRuntime.Quit)
-	themeRuntime.SetTrayIconSetter(setTrayIcon)
+	tray := configureSystemTray(fyApp, window, initialVariant, view.quickConnect.Show, view.notificationMute, uiRuntime.Quit)
+	themeRuntime.SetTrayIconSetter(tray.SetVariant)
+	view.connStatusPresenter.SetTray(tray)
+	if view.chats != nil {
+		// The tray counts unread chat messages, like the chat list badges.
+		view.chats.OnUnreadChange(tray.SetUnread)
+	}
 	themeRuntime.Apply(initialVariant)
 
+	if dep.Data.DatabaseRepairNotice != "" {
+		dialog.ShowInformation(i18n.T("Database repaired"), dep.Data.DatabaseRepairNotice, window)
+	}
+	if emergency := dep.Actions.EmergencyMode; emergency != nil && emergency.Active() {
+		dialog.ShowInformation(
+			i18n.T("Emergency mode is still on"),
+			i18n.T("meshgo was closed while emergency mode was on. The settings saved before it are kept: restore normal mode in Node → Maintenance once the node is connected."),
+			window,
+		)
+	}
+
 	uiRuntime.Run(dep.Launch.StartHidden)
 
 	return nil
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// appDataBackupFunc writes or restores a zip with the app database and settings.
+type appDataBackupFunc func(ctx context.Context, path string) (app.AppDataBackup, error)
+
+func appDataBackupText(backup app.AppDataBackup) string {
+	return i18n.Tf(
+		"Made by meshgo %s on %s (database schema %d).",
+		backup.AppVersion,
+		backup.CreatedAt.Local().Format("2006-01-02 15:04"),
+		backup.SchemaVersion,
+	)
+}
+
+// showAppDataBackupDialog asks where to save the backup and writes it in the background.
+func showAppDataBackupDialog(window fyne.Window, backup appDataBackupFunc) {
+	if window == nil || backup == nil {
+		return
+	}
+
+	dialog.ShowConfirm(
+		i18n.T("Backup app data"),
+		i18n.T("The backup contains your message history, nodes and settings.\nAn encrypted database stays encrypted with its key, so the backup can only be restored while that key is in the OS keyring. Keep the file somewhere safe."),
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+				if err != nil {
+					settingsLogger.Warn("app data backup file selection failed", "error", err)
+					dialog.ShowError(err, window)
+
+					return
+				}
+				if writer == nil {
+					return
+				}
+				path := writer.URI().Path()
+				_ = writer.Close()
+				runAppDataBackup(window, i18n.T("Backing up app data"), i18n.T("Saving the database and settings..."), path, backup,
+					func(app.AppDataBackup) {
+						dialog.ShowInformation(i18n.T("Backup complete"), i18n.Tf("Saved to %s.", path), window)
+					})
+			}, window)
+			saveDialog.SetFileName(app.AppDataBackupName(time.Now()))
+			saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".zip"}))
+			saveDialog.Show()
+		},
+		window,
+	)
+}
+
+// showAppDataRestoreDialog asks for a backup, checks it and stages it to replace the
+// current data on the next start.
+func showAppDataRestoreDialog(window fyne.Window, restore appDataBackupFunc) {
+	if window == nil || restore == nil {
+		return
+	}
+
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			settingsLogger.Warn("app data restore file selection failed", "error", err)
+			dialog.ShowError(err, window)
+
+			return
+		}
+		if reader == nil {
+			return
+		}
+		path := reader.URI().Path()
+		_ = reader.Close()
+		dialog.ShowConfirm(
+			i18n.T("Restore app data?"),
+			i18n.T("Message history, nodes and settings will be replaced by the backup the next time meshgo starts.\nEverything received after the backup was made will be lost."),
+			func(ok bool) {
+				if !ok {
+					return
+				}
+				runAppDataBackup(window, i18n.T("Restoring app data"), i18n.T("Checking the backup..."), path, restore,
+					func(result app.AppDataBackup) {
+						dialog.ShowInformation(
+							i18n.T("Restart to finish restoring"),
+							appDataBackupText(result)+"\n"+i18n.T("Quit and start meshgo again to use the restored data."),
+							window,
+						)
+					})
+			},
+			window,
+		)
+	}, window)
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".zip"}))
+	openDialog.Show()
+}
+
+func runAppDataBackup(
+	window fyne.Window,
+	title, message, path string,
+	run appDataBackupFunc,
+	onDone func(result app.AppDataBackup),
+) {
+	ctx, cancel := context.WithCancel(context.Background())
+	progressBar := widget.NewProgressBarInfinite()
+	progress := dialog.NewCustom(
+		title,
+		i18n.T("Cancel"),
+		container.NewVBox(widget.NewLabel(message), progressBar),
+		window,
+	)
+	progress.SetOnClosed(cancel)
+	progress.Show()
+
+	go func() {
+		result, err := run(ctx, path)
+		fyne.Do(func() {
+			progress.SetOnClosed(nil)
+			progress.Hide()
+			progressBar.Stop()
+			cancel()
+			switch {
+			case errors.Is(err, context.Canceled):
+				settingsLogger.Info("app data backup operation canceled", "title", title, "path", path)
+			case err != nil:
+				settingsLogger.Warn("app data backup operation failed", "title", title, "path", path, "error", err)
+				dialog.ShowError(err, window)
+			default:
+				onDone(result)
+			}
+		})
+	}()
+}
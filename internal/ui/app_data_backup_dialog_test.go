@@ -0,0 +1,17 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/app"
+)
+
+func TestAppDataBackupText(t *testing.T) {
+	createdAt := time.Date(2026, 3, 14, 9, 26, 0, 0, time.Local)
+	got := appDataBackupText(app.AppDataBackup{AppVersion: "v1.2.3", SchemaVersion: 19, CreatedAt: createdAt.UTC()})
+	want := "Made by meshgo v1.2.3 on 2026-03-14 09:26 (database schema 19)."
+	if got != want {
+		t.Fatalf("unexpected backup text: %q", got)
+	}
+}
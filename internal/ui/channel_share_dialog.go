@@ -14,6 +14,7 @@ import (
 
 	meshapp "github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 type channelShareOption struct {
@@ -35,7 +36,7 @@ func handleChannelShareAction(window fyne.Window, dep RuntimeDependencies, chat
 		return
 	}
 	if !isNodeSettingsConnected(dep) {
-		showInfoModal(dep, "Channel sharing", "Channel sharing is available only while connected to a device.")
+		showInfoModal(dep, i18n.T("Channel sharing"), i18n.T("Channel sharing is available only while connected to a device."))
 
 		return
 	}
@@ -47,7 +48,7 @@ func handleChannelShareAction(window fyne.Window, dep RuntimeDependencies, chat
 		return
 	}
 
-	loading := showBusyDialog(window, "Channel sharing", "Loading current channel and LoRa settings from the connected device…")
+	loading := showBusyDialog(window, i18n.T("Channel sharing"), i18n.T("Loading current channel and LoRa settings from the connected device…"))
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), nodeSettingsOpTimeout*3)
 		defer cancel()
@@ -99,15 +100,16 @@ func showChannelShareConfigDialog(
 	presetTitle := nodeLoRaPrimaryChannelTitle(lora, "")
 	options := buildChannelShareOptions(loaded.Channels, presetTitle)
 	if len(options) == 0 {
-		showInfoModal(dep, "Channel sharing", "There are no channels available to share.")
+		showInfoModal(dep, i18n.T("Channel sharing"), i18n.T("There are no channels available to share."))
 
 		return
 	}
 
 	selected := initialChannelShareSelection(options, channelIndexFromChatKey(chat.Key))
-	modeGroup := widget.NewRadioGroup([]string{"Replace", "Add"}, nil)
+	modeReplace, modeAdd := i18n.T("Replace"), i18n.T("Add")
+	modeGroup := widget.NewRadioGroup([]string{modeReplace, modeAdd}, nil)
 	modeGroup.Horizontal = true
-	modeGroup.SetSelected("Replace")
+	modeGroup.SetSelected(modeReplace)
 
 	statusLabel := widget.NewLabel("")
 	statusLabel.Wrapping = fyne.TextWrapWord
@@ -117,7 +119,7 @@ func showChannelShareConfigDialog(
 		option := option
 		title := option.Title
 		if title == "" {
-			title = fmt.Sprintf("Channel %d", option.Index+1)
+			title = i18n.Tf("Channel %d", option.Index+1)
 		}
 		check := widget.NewCheck(fmt.Sprintf("%d. %s", option.Index+1, title), func(checked bool) {
 			selected[option.Index] = checked
@@ -128,13 +130,13 @@ func showChannelShareConfigDialog(
 
 	presetValue := strings.TrimSpace(presetTitle)
 	if presetValue == "" {
-		presetValue = "Custom"
+		presetValue = i18n.T("Custom")
 	}
-	modeHint := widget.NewLabel("Replace includes radio settings in the shared payload. Add keeps the receiver's current radio settings.")
+	modeHint := widget.NewLabel(i18n.T("Replace includes radio settings in the shared payload. Add keeps the receiver's current radio settings."))
 	modeHint.Wrapping = fyne.TextWrapWord
 
-	generateButton := widget.NewButton("Generate", nil)
-	closeButton := widget.NewButton("Close", nil)
+	generateButton := widget.NewButton(i18n.T("Generate"), nil)
+	closeButton := widget.NewButton(i18n.T("Close"), nil)
 
 	content := container.NewBorder(
 		nil,
@@ -143,17 +145,17 @@ func showChannelShareConfigDialog(
 		nil,
 		container.NewVBox(
 			widget.NewForm(
-				widget.NewFormItem("Mode", modeGroup),
-				widget.NewFormItem("LoRa preset", widget.NewLabel(presetValue)),
+				widget.NewFormItem(i18n.T("Mode"), modeGroup),
+				widget.NewFormItem(i18n.T("LoRa preset"), widget.NewLabel(presetValue)),
 			),
 			modeHint,
 			widget.NewSeparator(),
-			widget.NewLabel("Channels to share"),
+			widget.NewLabel(i18n.T("Channels to share")),
 			channelList,
 		),
 	)
 
-	modal := dialog.NewCustomWithoutButtons("Share channels", content, window)
+	modal := dialog.NewCustomWithoutButtons(i18n.T("Share channels"), content, window)
 	closeButton.OnTapped = modal.Hide
 	generateButton.OnTapped = func() {
 		selectedChannels := make([]meshapp.NodeChannelSettings, 0, len(options))
@@ -163,21 +165,21 @@ func showChannelShareConfigDialog(
 			}
 		}
 		if len(selectedChannels) == 0 {
-			statusLabel.SetText("Select at least one channel to share.")
+			statusLabel.SetText(i18n.T("Select at least one channel to share."))
 
 			return
 		}
 
-		rawURL, err := meshapp.BuildChannelShareURL(selectedChannels, lora, modeGroup.Selected == "Add")
+		rawURL, err := meshapp.BuildChannelShareURL(selectedChannels, lora, modeGroup.Selected == modeAdd)
 		if err != nil {
-			statusLabel.SetText("Generate failed: " + err.Error())
+			statusLabel.SetText(i18n.Tf("Generate failed: %s", err))
 
 			return
 		}
 
 		modal.Hide()
 		showQRCodeShareModal(window, qrShareModalPayload{
-			Title: "Share channels QR code",
+			Title: i18n.T("Share channels QR code"),
 			URL:   rawURL,
 		})
 	}
@@ -7,14 +7,24 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 // ChatAction identifies a message-level action available from context menu.
 type ChatAction string
 
 const (
-	ChatActionReply ChatAction = "reply"
-	ChatActionReact ChatAction = "react"
+	ChatActionReply    ChatAction = "reply"
+	ChatActionReact    ChatAction = "react"
+	ChatActionStar     ChatAction = "star"
+	ChatActionEditTags ChatAction = "edit_tags"
+	ChatActionPin      ChatAction = "pin"
+	ChatActionQuote    ChatAction = "quote"
+	ChatActionCopyText ChatAction = "copy_text"
+	// ChatActionCopySender copies the node id of the message author.
+	ChatActionCopySender ChatAction = "copy_sender"
+	ChatActionResend     ChatAction = "resend"
+	ChatActionDelete     ChatAction = "delete"
 )
 
 // ChatActionHandler handles selected chat message context action.
@@ -23,7 +33,7 @@ type ChatActionHandler func(message domain.ChatMessage, action ChatAction)
 const chatMenuTitleMaxLen = 32
 
 func newChatMessageContextMenu(message domain.ChatMessage, onAction ChatActionHandler) *fyne.Menu {
-	title := "Message"
+	title := i18n.T("Message")
 	if body := strings.TrimSpace(message.Body); body != "" {
 		title = body
 		if len(title) > chatMenuTitleMaxLen {
@@ -31,7 +41,7 @@ func newChatMessageContextMenu(message domain.ChatMessage, onAction ChatActionHa
 		}
 	}
 
-	itemReply := fyne.NewMenuItem("Reply", func() {
+	itemReply := fyne.NewMenuItem(i18n.T("Reply"), func() {
 		if onAction != nil {
 			onAction(message, ChatActionReply)
 		}
@@ -40,7 +50,16 @@ func newChatMessageContextMenu(message domain.ChatMessage, onAction ChatActionHa
 		itemReply.Disabled = true
 	}
 
-	itemReact := fyne.NewMenuItem("Add reaction", func() {
+	itemQuote := fyne.NewMenuItem(i18n.T("Quote"), func() {
+		if onAction != nil {
+			onAction(message, ChatActionQuote)
+		}
+	})
+	if !canQuoteMessage(message) {
+		itemQuote.Disabled = true
+	}
+
+	itemReact := fyne.NewMenuItem(i18n.T("Add reaction"), func() {
 		if onAction != nil {
 			onAction(message, ChatActionReact)
 		}
@@ -49,17 +68,126 @@ func newChatMessageContextMenu(message domain.ChatMessage, onAction ChatActionHa
 		itemReact.Disabled = true
 	}
 
-	return fyne.NewMenu(title, itemReply, itemReact)
+	return fyne.NewMenu(title, itemReply, itemQuote, itemReact)
+}
+
+// withMessageAnnotationItems appends local star and tag actions to a message menu.
+func withMessageAnnotationItems(
+	menu *fyne.Menu,
+	message domain.ChatMessage,
+	annotation domain.MessageAnnotation,
+	onAction ChatActionHandler,
+) *fyne.Menu {
+	starLabel := i18n.T("Star")
+	if annotation.Starred {
+		starLabel = i18n.T("Unstar")
+	}
+	itemStar := fyne.NewMenuItem(starLabel, func() {
+		if onAction != nil {
+			onAction(message, ChatActionStar)
+		}
+	})
+	itemTags := fyne.NewMenuItem(i18n.T("Edit tags…"), func() {
+		if onAction != nil {
+			onAction(message, ChatActionEditTags)
+		}
+	})
+	if !canAnnotateMessage(message) {
+		itemStar.Disabled = true
+		itemTags.Disabled = true
+	}
+	menu.Items = append(menu.Items, fyne.NewMenuItemSeparator(), itemStar, itemTags)
+
+	return menu
+}
+
+// withMessagePinItems appends the pin toggle to a message menu.
+func withMessagePinItems(menu *fyne.Menu, message domain.ChatMessage, pinned bool, onAction ChatActionHandler) *fyne.Menu {
+	label := i18n.T("Pin message")
+	if pinned {
+		label = i18n.T("Unpin message")
+	}
+	itemPin := fyne.NewMenuItem(label, func() {
+		if onAction != nil {
+			onAction(message, ChatActionPin)
+		}
+	})
+	if !canAnnotateMessage(message) {
+		itemPin.Disabled = true
+	}
+	menu.Items = append(menu.Items, itemPin)
+
+	return menu
 }
 
+// withMessageManageItems appends the copy actions, resend for failed outgoing messages
+// and the local delete. senderID is the author node id, or "" when it is unknown.
+func withMessageManageItems(
+	menu *fyne.Menu,
+	message domain.ChatMessage,
+	senderID string,
+	deletable bool,
+	onAction ChatActionHandler,
+) *fyne.Menu {
+	action := func(action ChatAction) func() {
+		return func() {
+			if onAction != nil {
+				onAction(message, action)
+			}
+		}
+	}
+	itemCopyText := fyne.NewMenuItem(i18n.T("Copy text"), action(ChatActionCopyText))
+	itemCopyText.Disabled = strings.TrimSpace(message.Body) == ""
+	itemCopySender := fyne.NewMenuItem(i18n.T("Copy sender ID"), action(ChatActionCopySender))
+	itemCopySender.Disabled = strings.TrimSpace(senderID) == ""
+	menu.Items = append(menu.Items, fyne.NewMenuItemSeparator(), itemCopyText, itemCopySender)
+	if canResendMessage(message) {
+		menu.Items = append(menu.Items, fyne.NewMenuItem(i18n.T("Resend"), action(ChatActionResend)))
+	}
+	if deletable {
+		menu.Items = append(menu.Items, fyne.NewMenuItem(i18n.T("Delete locally…"), action(ChatActionDelete)))
+	}
+
+	return menu
+}
+
+// canQuoteMessage reports whether the message has text to quote in a new message.
+func canQuoteMessage(message domain.ChatMessage) bool {
+	return !isReactionMessage(message) && strings.TrimSpace(message.Body) != ""
+}
+
+// canResendMessage reports whether the message is an outgoing text that failed to send.
+func canResendMessage(message domain.ChatMessage) bool {
+	return message.Direction == domain.MessageDirectionOut &&
+		message.Status == domain.MessageStatusFailed &&
+		canQuoteMessage(message)
+}
+
+// showChatMessageContextMenu shows message actions. Star and tag actions are added
+// only when annotation is not nil, the pin toggle only when pinned is not nil.
 func showChatMessageContextMenu(
 	fyneCanvas fyne.Canvas,
 	position fyne.Position,
 	message domain.ChatMessage,
+	annotation *domain.MessageAnnotation,
+	pinned *bool,
+	senderID string,
+	deletable bool,
 	onAction ChatActionHandler,
 ) {
 	if fyneCanvas == nil {
 		return
 	}
-	widget.ShowPopUpMenuAtPosition(newChatMessageContextMenu(message, onAction), fyneCanvas, position)
+	menu := newChatMessageContextMenu(message, onAction)
+	if annotation != nil {
+		menu = withMessageAnnotationItems(menu, message, *annotation, onAction)
+	}
+	if pinned != nil {
+		if annotation == nil {
+			menu.Items = append(menu.Items, fyne.NewMenuItemSeparator())
+		}
+		menu = withMessagePinItems(menu, message, *pinned, onAction)
+	}
+	menu = withMessageManageItems(menu, message, senderID, deletable, onAction)
+	widget.ShowPopUpMenuAtPosition(menu, fyneCanvas, position)
 }
@@ -1,8 +1,12 @@
 package ui
 
 import (
+	"slices"
+	"strings"
 	"testing"
 
+	"fyne.io/fyne/v2"
+
 	"github.com/skobkin/meshgo/internal/domain"
 )
 
@@ -41,6 +45,16 @@ func TestCanReactToMessage(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "queued in the outbox",
+			message: domain.ChatMessage{
+				DeviceMessageID: "outbox:1",
+				ChatKey:         "channel:0",
+				Direction:       domain.MessageDirectionOut,
+				Status:          domain.MessageStatusFailed,
+			},
+			want: false,
+		},
 		{
 			name: "no device message id",
 			message: domain.ChatMessage{
@@ -121,17 +135,20 @@ func TestNewChatMessageContextMenu_Reactions(t *testing.T) {
 				gotActions = append(gotActions, action)
 			})
 
-			if got, want := len(menu.Items), 2; got != want {
+			if got, want := len(menu.Items), 3; got != want {
 				t.Fatalf("expected %d menu items, got %d", want, got)
 			}
 
 			reply := menu.Items[0]
-			react := menu.Items[1]
+			react := menu.Items[2]
+			if menu.Items[1].Label != "Quote" {
+				t.Fatalf("second item: expected label %q, got %q", "Quote", menu.Items[1].Label)
+			}
 			if reply.Label != "Reply" {
 				t.Fatalf("first item: expected label %q, got %q", "Reply", reply.Label)
 			}
 			if react.Label != "Add reaction" {
-				t.Fatalf("second item: expected label %q, got %q", "Add reaction", react.Label)
+				t.Fatalf("third item: expected label %q, got %q", "Add reaction", react.Label)
 			}
 			if reply.Disabled != tc.wantReplyDisabled {
 				t.Fatalf("Reply.Disabled = %v, want %v", reply.Disabled, tc.wantReplyDisabled)
@@ -191,3 +208,52 @@ func TestNewChatMessageContextMenu_TitleTruncation(t *testing.T) {
 		t.Fatalf("expected fallback title %q, got %q", "Message", menu2.Label)
 	}
 }
+
+func TestWithMessageManageItems(t *testing.T) {
+	labels := func(menu *fyne.Menu) []string {
+		out := make([]string, 0, len(menu.Items))
+		for _, item := range menu.Items {
+			if !item.IsSeparator {
+				out = append(out, item.Label)
+			}
+		}
+
+		return out
+	}
+
+	failed := domain.ChatMessage{ChatKey: "channel:0", DeviceMessageID: "7", Body: "hello", Direction: domain.MessageDirectionOut, Status: domain.MessageStatusFailed}
+	var gotActions []ChatAction
+	menu := withMessageManageItems(newChatMessageContextMenu(failed, nil), failed, "!1234abcd", true, func(_ domain.ChatMessage, action ChatAction) {
+		gotActions = append(gotActions, action)
+	})
+	want := []string{"Reply", "Quote", "Add reaction", "Copy text", "Copy sender ID", "Resend", "Delete locally…"}
+	if got := labels(menu); !slices.Equal(got, want) {
+		t.Fatalf("expected items %v, got %v", want, got)
+	}
+	for _, item := range menu.Items[len(menu.Items)-4:] {
+		item.Action()
+	}
+	if wantActions := []ChatAction{ChatActionCopyText, ChatActionCopySender, ChatActionResend, ChatActionDelete}; !slices.Equal(gotActions, wantActions) {
+		t.Fatalf("expected actions %v, got %v", wantActions, gotActions)
+	}
+
+	incoming := domain.ChatMessage{ChatKey: "channel:0", DeviceMessageID: "8", Body: "hi", Direction: domain.MessageDirectionIn}
+	menu = withMessageManageItems(newChatMessageContextMenu(incoming, nil), incoming, "", false, nil)
+	want = []string{"Reply", "Quote", "Add reaction", "Copy text", "Copy sender ID"}
+	if got := labels(menu); !slices.Equal(got, want) {
+		t.Fatalf("expected items %v, got %v", want, got)
+	}
+	if !menu.Items[len(menu.Items)-1].Disabled {
+		t.Fatalf("expected copy sender to be disabled without a sender id")
+	}
+}
+
+func TestQuoteMessageText(t *testing.T) {
+	if got := quoteMessageText("  hello\n world "); got != "\"hello world\" " {
+		t.Fatalf("unexpected quote: %q", got)
+	}
+	long := quoteMessageText(strings.Repeat("a", 100))
+	if got := len([]rune(long)); got != messageQuoteMaxRunes+3 {
+		t.Fatalf("expected truncated quote of %d runes, got %d (%q)", messageQuoteMaxRunes+3, got, long)
+	}
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"hash/fnv"
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// channelAutoColors are the colors channels get from their name. Gray is only used when
+// picked, so it stays distinct from the neutral look of direct messages.
+var channelAutoColors = []string{"red", "orange", "yellow", "green", "blue", "purple", "brown"}
+
+// chatColorActions reads and changes the accent colors of channel chats.
+type chatColorActions struct {
+	Config func() map[string]string
+	Set    func(chatKey, color string) error
+}
+
+// colorName returns the accent color name of chat, or "" for chats without one.
+func (a chatColorActions) colorName(chat domain.Chat) string {
+	var overrides map[string]string
+	if a.Config != nil {
+		overrides = a.Config()
+	}
+
+	return chatColorName(chat, overrides)
+}
+
+// picked returns the color picked for the chat, or "" when it uses the automatic one.
+// It returns nil when colors can't be changed.
+func (a chatColorActions) picked(chatKey string) *string {
+	if a.Set == nil {
+		return nil
+	}
+	var picked string
+	if a.Config != nil {
+		picked = a.Config()[chatKey]
+	}
+
+	return &picked
+}
+
+// chatColorName returns the color picked for a channel chat or the one its name hashes
+// to. Direct messages and the loopback chat have no color.
+func chatColorName(chat domain.Chat, overrides map[string]string) string {
+	if domain.IsDMChat(chat) || domain.IsLoopbackKey(chat.Key) {
+		return ""
+	}
+	if picked := overrides[chat.Key]; picked != "" {
+		return picked
+	}
+
+	return channelAutoColor(chat)
+}
+
+// channelAutoColor hashes the channel name, so a channel keeps its color across devices
+// where it has another index.
+func channelAutoColor(chat domain.Chat) string {
+	name := strings.TrimSpace(chat.Title)
+	if name == "" {
+		name = chat.Key
+	}
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(strings.ToLower(name)))
+
+	return channelAutoColors[hasher.Sum32()%uint32(len(channelAutoColors))]
+}
+
+// chatAccentColor resolves a color name to the color drawn in the UI, or nil for "".
+func chatAccentColor(name string) color.Color {
+	if name == "" {
+		return nil
+	}
+
+	return theme.PrimaryColorNamed(name)
+}
+
+// chatAccentBubbleFill tints the fill of incoming bubbles with the chat accent.
+func chatAccentBubbleFill(fill, accent color.Color) color.Color {
+	if accent == nil {
+		return fill
+	}
+	base := toNRGBA(fill)
+	if isDarkColor(base) {
+		return mixNRGBA(base, toNRGBA(accent), 0.16)
+	}
+
+	return mixNRGBA(base, toNRGBA(accent), 0.12)
+}
+
+// withChatColorItems adds the color submenu of channel chats above the delete action.
+// current is the picked color, "" when the chat uses the automatic one.
+func withChatColorItems(menu *fyne.Menu, chat domain.Chat, current string, onPick func(chat domain.Chat, color string)) *fyne.Menu {
+	if domain.IsDMChat(chat) || domain.IsLoopbackKey(chat.Key) {
+		return menu
+	}
+	pick := func(color string) func() {
+		return func() {
+			if onPick != nil {
+				onPick(chat, color)
+			}
+		}
+	}
+
+	autoItem := fyne.NewMenuItem(i18n.Tf("Automatic (%s)", accentColorLabel(channelAutoColor(chat))), pick(""))
+	autoItem.Checked = current == ""
+	children := []*fyne.MenuItem{autoItem, fyne.NewMenuItemSeparator()}
+	for _, name := range config.AccentColors {
+		item := fyne.NewMenuItem(accentColorLabel(name), pick(name))
+		item.Checked = current == name
+		children = append(children, item)
+	}
+	colorItem := fyne.NewMenuItem(i18n.T("Color"), nil)
+	colorItem.ChildMenu = fyne.NewMenu("", children...)
+
+	// Keep the separator and "Delete chat" at the end.
+	at := len(menu.Items) - 2
+	if at < 0 {
+		at = len(menu.Items)
+	}
+	items := make([]*fyne.MenuItem, 0, len(menu.Items)+1)
+	items = append(items, menu.Items[:at]...)
+	items = append(items, colorItem)
+	menu.Items = append(items, menu.Items[at:]...)
+
+	return menu
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestChatColorName(t *testing.T) {
+	general := domain.Chat{Key: "channel:0", Title: "General", Type: domain.ChatTypeChannel}
+	auto := chatColorName(general, nil)
+	if !slices.Contains(channelAutoColors, auto) {
+		t.Fatalf("expected an automatic color, got %q", auto)
+	}
+	moved := domain.Chat{Key: "channel:3", Title: "general", Type: domain.ChatTypeChannel}
+	if got := chatColorName(moved, nil); got != auto {
+		t.Fatalf("expected the color to follow the channel name: expected %q, got %q", auto, got)
+	}
+	if got := chatColorName(general, map[string]string{"channel:0": "gray"}); got != "gray" {
+		t.Fatalf("expected the picked color: expected %q, got %q", "gray", got)
+	}
+	dm := domain.Chat{Key: "dm:!1234abcd", Title: "Alice", Type: domain.ChatTypeDM}
+	if got := chatColorName(dm, map[string]string{"dm:!1234abcd": "red"}); got != "" {
+		t.Fatalf("expected no color for direct messages, got %q", got)
+	}
+}
+
+func TestWithChatColorItems(t *testing.T) {
+	channel := domain.Chat{Key: "channel:1", Title: "Hikers", Type: domain.ChatTypeChannel}
+	var picked string
+	menu := withChatColorItems(newChatListContextMenu(channel, nil, nil), channel, "blue", func(_ domain.Chat, color string) {
+		picked = color
+	})
+	if len(menu.Items) < 3 || menu.Items[len(menu.Items)-3].Label != "Color" {
+		t.Fatalf("expected the color submenu above the delete action, got %d items", len(menu.Items))
+	}
+	colorItems := menu.Items[len(menu.Items)-3].ChildMenu.Items
+	if colorItems[0].Checked {
+		t.Fatalf("expected the automatic color to be unchecked")
+	}
+	for _, item := range colorItems {
+		if item.Label == "Blue" && !item.Checked {
+			t.Fatalf("expected the picked color to be checked")
+		}
+		if item.Label == "Red" {
+			item.Action()
+		}
+	}
+	if picked != "red" {
+		t.Fatalf("unexpected picked color: expected %q, got %q", "red", picked)
+	}
+
+	dm := domain.Chat{Key: "dm:!1234abcd", Title: "Alice", Type: domain.ChatTypeDM}
+	dmMenu := newChatListContextMenu(dm, nil, nil)
+	if got := len(withChatColorItems(dmMenu, dm, "", nil).Items); got != len(newChatListContextMenu(dm, nil, nil).Items) {
+		t.Fatalf("expected no color submenu for direct messages, got %d items", got)
+	}
+}
@@ -2,38 +2,99 @@ package ui
 
 import (
 	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/widget"
 
+	meshapp "github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/config"
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 type chatListAction string
 
 const (
-	chatListActionShare  chatListAction = "share"
-	chatListActionDelete chatListAction = "delete"
+	chatListActionShare     chatListAction = "share"
+	chatListActionExport    chatListAction = "export"
+	chatListActionExportAll chatListAction = "export_all"
+	chatListActionDelete    chatListAction = "delete"
+	// chatListActionTaskbarFlash toggles taskbar flashing for the chat.
+	chatListActionTaskbarFlash chatListAction = "taskbar_flash"
+
+	chatListActionMuteHour       chatListAction = "mute_hour"
+	chatListActionMuteEightHours chatListAction = "mute_8_hours"
+	chatListActionMute           chatListAction = "mute"
+	chatListActionUnmute         chatListAction = "unmute"
+	chatListActionMentionsOnly   chatListAction = "mentions_only"
 )
 
+// chatTaskbarFlashActions reads and changes per-chat taskbar flashing. The chat menu
+// offers the toggle only while flashing is enabled in settings.
+type chatTaskbarFlashActions struct {
+	Config func() config.TaskbarFlashConfig
+	Set    func(chatKey string, enabled bool) error
+}
+
+// menuState returns whether the chat flashes the taskbar, or nil when the menu should
+// not offer the toggle.
+func (a chatTaskbarFlashActions) menuState(chatKey string) *bool {
+	if a.Config == nil || a.Set == nil {
+		return nil
+	}
+	prefs := a.Config()
+	if !prefs.Enabled {
+		return nil
+	}
+	enabled := prefs.FlashesChat(chatKey, meshapp.TaskbarFlashChatDefault(chatKey))
+
+	return &enabled
+}
+
 type chatListActionHandler func(chat domain.Chat, action chatListAction)
 
-func newChatListContextMenu(chat domain.Chat, onAction chatListActionHandler) *fyne.Menu {
+// newChatListContextMenu builds the chat menu. taskbarFlash is the chat's flashing
+// state; nil leaves the toggle out.
+func newChatListContextMenu(chat domain.Chat, taskbarFlash *bool, onAction chatListActionHandler) *fyne.Menu {
 	title := strings.TrimSpace(chatDisplayTitle(chat, nil))
 	if title == "" {
-		title = "Chat"
+		title = i18n.T("Chat")
 	}
 
-	items := make([]*fyne.MenuItem, 0, 2)
-	if !domain.IsDMChat(chat) {
-		items = append(items, fyne.NewMenuItem("Share", func() {
+	items := make([]*fyne.MenuItem, 0, 6)
+	if !domain.IsDMChat(chat) && !domain.IsLoopbackKey(chat.Key) {
+		items = append(items, fyne.NewMenuItem(i18n.T("Share"), func() {
 			if onAction != nil {
 				onAction(chat, chatListActionShare)
 			}
 		}))
 	}
 
-	deleteItem := fyne.NewMenuItem("Delete chat", func() {
+	items = append(items,
+		fyne.NewMenuItem(i18n.T("Export chat…"), func() {
+			if onAction != nil {
+				onAction(chat, chatListActionExport)
+			}
+		}),
+		fyne.NewMenuItem(i18n.T("Export all chats…"), func() {
+			if onAction != nil {
+				onAction(chat, chatListActionExportAll)
+			}
+		}),
+	)
+	if taskbarFlash != nil {
+		flashItem := fyne.NewMenuItem(i18n.T("Flash taskbar on new messages"), func() {
+			if onAction != nil {
+				onAction(chat, chatListActionTaskbarFlash)
+			}
+		})
+		flashItem.Checked = *taskbarFlash
+		items = append(items, flashItem)
+	}
+	items = append(items, fyne.NewMenuItemSeparator())
+
+	deleteItem := fyne.NewMenuItem(i18n.T("Delete chat"), func() {
 		if onAction != nil {
 			onAction(chat, chatListActionDelete)
 		}
@@ -46,14 +107,95 @@ func newChatListContextMenu(chat domain.Chat, onAction chatListActionHandler) *f
 	return fyne.NewMenu(title, items...)
 }
 
+// withChatMuteItems adds the notification submenu above the delete action.
+func withChatMuteItems(menu *fyne.Menu, chat domain.Chat, now time.Time, onAction chatListActionHandler) *fyne.Menu {
+	action := func(selected chatListAction) func() {
+		return func() {
+			if onAction != nil {
+				onAction(chat, selected)
+			}
+		}
+	}
+	prefs := chat.Notifications
+	muted := prefs.MutedAt(now)
+
+	children := make([]*fyne.MenuItem, 0, 7)
+	if muted {
+		status := i18n.T("Muted until unmuted")
+		if !prefs.MutedUntil.IsZero() {
+			status = i18n.Tf("Muted until %s", currentDisplayFormatter().DateTime(prefs.MutedUntil))
+		}
+		statusItem := fyne.NewMenuItem(status, nil)
+		statusItem.Disabled = true
+		children = append(children, statusItem, fyne.NewMenuItem(i18n.T("Unmute"), action(chatListActionUnmute)))
+	}
+	children = append(children,
+		fyne.NewMenuItem(i18n.T("Mute for 1 hour"), action(chatListActionMuteHour)),
+		fyne.NewMenuItem(i18n.T("Mute for 8 hours"), action(chatListActionMuteEightHours)),
+		fyne.NewMenuItem(i18n.T("Mute until unmuted"), action(chatListActionMute)),
+		fyne.NewMenuItemSeparator(),
+	)
+	mentionsItem := fyne.NewMenuItem(i18n.T("Notify only on mentions"), action(chatListActionMentionsOnly))
+	mentionsItem.Checked = prefs.MentionsOnly
+	children = append(children, mentionsItem)
+
+	muteItem := fyne.NewMenuItem(i18n.T("Notifications"), nil)
+	muteItem.Checked = muted
+	muteItem.ChildMenu = fyne.NewMenu("", children...)
+
+	// Keep the separator and "Delete chat" at the end.
+	at := len(menu.Items) - 2
+	if at < 0 {
+		at = len(menu.Items)
+	}
+	items := make([]*fyne.MenuItem, 0, len(menu.Items)+1)
+	items = append(items, menu.Items[:at]...)
+	items = append(items, muteItem)
+	menu.Items = append(items, menu.Items[at:]...)
+
+	return menu
+}
+
+// chatNotificationPrefsForAction returns the chat's preferences after a notification
+// menu action. ok is false for other actions.
+func chatNotificationPrefsForAction(prefs domain.ChatNotificationPrefs, action chatListAction, now time.Time) (domain.ChatNotificationPrefs, bool) {
+	switch action {
+	case chatListActionMuteHour:
+		prefs.Muted, prefs.MutedUntil = true, now.Add(time.Hour)
+	case chatListActionMuteEightHours:
+		prefs.Muted, prefs.MutedUntil = true, now.Add(8*time.Hour)
+	case chatListActionMute:
+		prefs.Muted, prefs.MutedUntil = true, time.Time{}
+	case chatListActionUnmute:
+		prefs.Muted, prefs.MutedUntil = false, time.Time{}
+	case chatListActionMentionsOnly:
+		prefs.MentionsOnly = !prefs.MentionsOnly
+	default:
+		return prefs, false
+	}
+
+	return prefs, true
+}
+
 func showChatListContextMenu(
 	fyneCanvas fyne.Canvas,
 	position fyne.Position,
 	chat domain.Chat,
+	taskbarFlash *bool,
+	withMute bool,
+	pickedColor *string,
 	onAction chatListActionHandler,
+	onPickColor func(chat domain.Chat, color string),
 ) {
 	if fyneCanvas == nil {
 		return
 	}
-	widget.ShowPopUpMenuAtPosition(newChatListContextMenu(chat, onAction), fyneCanvas, position)
+	menu := newChatListContextMenu(chat, taskbarFlash, onAction)
+	if withMute {
+		menu = withChatMuteItems(menu, chat, time.Now(), onAction)
+	}
+	if pickedColor != nil {
+		menu = withChatColorItems(menu, chat, *pickedColor, onPickColor)
+	}
+	widget.ShowPopUpMenuAtPosition(menu, fyneCanvas, position)
 }
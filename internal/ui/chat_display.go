@@ -2,16 +2,30 @@ package ui
 
 import (
 	"strings"
+	"time"
 
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func chatTypeLabel(chat domain.Chat) string {
 	if domain.IsDMChat(chat) {
-		return "DM"
+		return i18n.T("DM")
+	}
+	if domain.IsLoopbackKey(chat.Key) {
+		return i18n.T("Test")
+	}
+
+	return i18n.T("Channel")
+}
+
+// chatMutedMarker marks chats with muted notifications in the chat list.
+func chatMutedMarker(chat domain.Chat, now time.Time) string {
+	if chat.Notifications.MutedAt(now) {
+		return " 🔕"
 	}
 
-	return "Channel"
+	return ""
 }
 
 func chatDisplayTitle(chat domain.Chat, nodeNameByID func(string) string) string {
@@ -40,7 +54,7 @@ func chatDisplayTitle(chat domain.Chat, nodeNameByID func(string) string) string
 
 func chatTitleByKey(chats []domain.Chat, key string, nodeNameByID func(string) string) string {
 	if key == "" {
-		return "No chat selected"
+		return i18n.T("No chat selected")
 	}
 	for _, chat := range chats {
 		if chat.Key == key {
@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/chatexport"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// chatExportFunc writes the history of the selected chats in the requested format.
+type chatExportFunc func(ctx context.Context, w io.Writer, opts chatexport.Options) error
+
+func chatExportFormatLabel(format chatexport.Format) string {
+	switch format {
+	case chatexport.FormatHTML:
+		return i18n.T("HTML transcript")
+	case chatexport.FormatText:
+		return i18n.T("Plain text transcript")
+	case chatexport.FormatJSON:
+		return "JSON"
+	case chatexport.FormatCSV:
+		return i18n.T("CSV spreadsheet")
+	default:
+		return strings.ToUpper(string(format))
+	}
+}
+
+func chatExportFormatLabels() []string {
+	formats := chatexport.Formats()
+	labels := make([]string, 0, len(formats))
+	for _, format := range formats {
+		labels = append(labels, chatExportFormatLabel(format))
+	}
+
+	return labels
+}
+
+func parseChatExportFormatLabel(label string) chatexport.Format {
+	for _, format := range chatexport.Formats() {
+		if chatExportFormatLabel(format) == label {
+			return format
+		}
+	}
+
+	return chatexport.Formats()[0]
+}
+
+func chatExportTargets(chats []domain.Chat, nodeNameByID func(string) string) []chatexport.Chat {
+	out := make([]chatexport.Chat, 0, len(chats))
+	for _, chat := range chats {
+		title := strings.TrimSpace(chatDisplayTitle(chat, nodeNameByID))
+		if title == "" {
+			title = chat.Key
+		}
+		out = append(out, chatexport.Chat{Key: chat.Key, Title: title, Type: chat.Type})
+	}
+
+	return out
+}
+
+func chatExportSenderName(nodeNameByID func(string) string, localNodeID func() string) func(domain.ChatMessage) string {
+	return func(message domain.ChatMessage) string {
+		meta, hasMeta := parseMessageMeta(message.MetaJSON)
+		sender, _, hasSender := messageTextParts(message, meta, hasMeta, nodeNameByID, localNodeID)
+		if !hasSender {
+			return ""
+		}
+
+		return sender
+	}
+}
+
+func chatExportProgressText(done, total int) string {
+	if total <= 0 {
+		return i18n.T("Preparing export...")
+	}
+
+	return i18n.Tf("Exported %d of %d messages", done, total)
+}
+
+// showChatExportDialog asks for a format and file, then exports chats in the background
+// with a cancellable progress dialog.
+func showChatExportDialog(
+	window fyne.Window,
+	chats []domain.Chat,
+	export chatExportFunc,
+	nodeNameByID func(string) string,
+	localNodeID func() string,
+) {
+	if window == nil || export == nil || len(chats) == 0 {
+		return
+	}
+
+	targets := chatExportTargets(chats, nodeNameByID)
+	scope := i18n.Tf("all %d chats", len(targets))
+	if len(targets) == 1 {
+		scope = targets[0].Title
+	}
+	formatSelect := widget.NewSelect(chatExportFormatLabels(), nil)
+	formatSelect.SetSelected(chatExportFormatLabel(chatexport.FormatHTML))
+
+	dialog.ShowForm(
+		i18n.T("Export chat history"),
+		i18n.T("Choose file…"),
+		i18n.T("Cancel"),
+		[]*widget.FormItem{
+			widget.NewFormItem(i18n.T("Chats"), widget.NewLabel(scope)),
+			widget.NewFormItem(i18n.T("Format"), formatSelect),
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			format := parseChatExportFormatLabel(formatSelect.Selected)
+			saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+				if err != nil {
+					chatsLogger.Warn("chat export file selection failed", "error", err)
+					dialog.ShowError(err, window)
+
+					return
+				}
+				if writer == nil {
+					return
+				}
+				runChatExport(window, writer, export, chatexport.Options{
+					Format:     format,
+					Chats:      targets,
+					SenderName: chatExportSenderName(nodeNameByID, localNodeID),
+				})
+			}, window)
+			saveDialog.SetFileName(chatexport.DefaultFileName(format, targets, time.Now()))
+			saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{format.Extension()}))
+			saveDialog.Show()
+		},
+		window,
+	)
+}
+
+func runChatExport(window fyne.Window, writer fyne.URIWriteCloser, export chatExportFunc, opts chatexport.Options) {
+	ctx, cancel := context.WithCancel(context.Background())
+	progressBar := widget.NewProgressBar()
+	progressLabel := widget.NewLabel(chatExportProgressText(0, 0))
+	progress := dialog.NewCustom(
+		i18n.T("Exporting chat history"),
+		i18n.T("Cancel"),
+		container.NewVBox(progressLabel, progressBar),
+		window,
+	)
+	progress.SetOnClosed(cancel)
+	progress.Show()
+
+	exported := 0
+	opts.Progress = func(done, total int) {
+		fyne.Do(func() {
+			exported = done
+			progressLabel.SetText(chatExportProgressText(done, total))
+			if total > 0 {
+				progressBar.SetValue(float64(done) / float64(total))
+			}
+		})
+	}
+
+	go func() {
+		err := export(ctx, writer, opts)
+		if closeErr := writer.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("close export file: %w", closeErr)
+		}
+		if err != nil {
+			// Do not leave a truncated file behind.
+			if removeErr := storage.Delete(writer.URI()); removeErr != nil {
+				chatsLogger.Debug("remove incomplete chat export failed", "uri", writer.URI().String(), "error", removeErr)
+			}
+		}
+		fyne.Do(func() {
+			progress.SetOnClosed(nil)
+			progress.Hide()
+			cancel()
+			switch {
+			case errors.Is(err, context.Canceled):
+				chatsLogger.Info("chat export canceled", "uri", writer.URI().String())
+			case err != nil:
+				chatsLogger.Warn("chat export failed", "format", opts.Format, "error", err)
+				dialog.ShowError(err, window)
+			default:
+				dialog.ShowInformation(
+					i18n.T("Export complete"),
+					i18n.Tf("Exported %d messages to %s.", exported, writer.URI().Name()),
+					window,
+				)
+			}
+		})
+	}()
+}
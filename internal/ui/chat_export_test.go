@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/chatexport"
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestChatExportFormatLabelsRoundTrip(t *testing.T) {
+	for _, format := range chatexport.Formats() {
+		if got := parseChatExportFormatLabel(chatExportFormatLabel(format)); got != format {
+			t.Fatalf("expected %q to roundtrip, got %q", format, got)
+		}
+	}
+	if got := parseChatExportFormatLabel("unknown"); got != chatexport.FormatHTML {
+		t.Fatalf("expected unknown label to fall back to html, got %q", got)
+	}
+}
+
+func TestChatExportTargetsAndSenderName(t *testing.T) {
+	nodeNameByID := func(id string) string {
+		if id == "!1234abcd" {
+			return "Alice"
+		}
+
+		return ""
+	}
+	targets := chatExportTargets([]domain.Chat{
+		{Key: "channel:0", Title: "Primary", Type: domain.ChatTypeChannel},
+		{Key: "dm:!1234abcd", Type: domain.ChatTypeDM},
+	}, nodeNameByID)
+	if len(targets) != 2 || targets[0].Title != "Primary" || targets[1].Title != "Alice" || targets[1].Type != domain.ChatTypeDM {
+		t.Fatalf("unexpected export targets: %+v", targets)
+	}
+
+	senderName := chatExportSenderName(nodeNameByID, func() string { return "!0000beef" })
+	incoming := domain.ChatMessage{Direction: domain.MessageDirectionIn, Body: "hi", MetaJSON: `{"from":"!1234abcd"}`}
+	if got := senderName(incoming); got != "Alice" {
+		t.Fatalf("expected incoming sender to resolve, got %q", got)
+	}
+}
+
+func TestChatExportProgressText(t *testing.T) {
+	if got := chatExportProgressText(0, 0); got != "Preparing export..." {
+		t.Fatalf("unexpected preparing text: %q", got)
+	}
+	if got := chatExportProgressText(250, 1000); got != "Exported 250 of 1000 messages" {
+		t.Fatalf("unexpected progress text: %q", got)
+	}
+}
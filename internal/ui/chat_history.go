@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+// messageRowHeightFallback is used for rows of prepended history before any row of
+// the chat has been measured.
+const messageRowHeightFallback float32 = 72
+
+// chatHistoryActions reach the stored chat history beyond the messages kept in memory.
+type chatHistoryActions struct {
+	Search chatMessageSearchFunc
+	// LoadOlder adds the page of history before the loaded messages to the chat store
+	// and returns how many messages were added.
+	LoadOlder func(chatKey string) (int, error)
+	// Delete removes a message from this desktop app only.
+	Delete func(message domain.ChatMessage) error
+	// MarkRead stores how far the chat was read, so unread messages stay unread across
+	// restarts.
+	MarkRead func(chatKey string, readUpTo time.Time)
+}
+
+const (
+	olderHistoryRetryDelay    = 2 * time.Second
+	olderHistoryRetryMaxDelay = time.Minute
+)
+
+// olderHistoryFailure backs off loading older history after failed attempts, so a
+// failing database is not queried on every bind of the first row but is tried again.
+type olderHistoryFailure struct {
+	count   int
+	retryAt time.Time
+}
+
+// next records one more failure at now.
+func (f olderHistoryFailure) next(now time.Time) olderHistoryFailure {
+	f.count++
+	// The delay doubles with each failure in a row: 2s, 4s, 8s and so on.
+	f.retryAt = now.Add(min(olderHistoryRetryDelay<<min(f.count-1, 6), olderHistoryRetryMaxDelay))
+
+	return f
+}
+
+// waiting reports whether the next attempt must wait at now.
+func (f olderHistoryFailure) waiting(now time.Time) bool {
+	return now.Before(f.retryAt)
+}
+
+// messageTimelineIndexOf returns the position of a message in the timeline, or -1
+// when the message is not loaded or is hidden by the filter.
+func messageTimelineIndexOf(timeline []domain.ChatMessage, message domain.ChatMessage) int {
+	identity := domain.MessageIdentity(message)
+	for i, item := range timeline {
+		if domain.MessageIdentity(item) == identity {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// shiftedMessageItemHeights moves measured row heights down by shift rows, as rows
+// for older messages were inserted above them.
+func shiftedMessageItemHeights(heights map[widget.ListItemID]float32, shift int) map[widget.ListItemID]float32 {
+	out := make(map[widget.ListItemID]float32, len(heights))
+	for id, height := range heights {
+		out[id+shift] = height
+	}
+
+	return out
+}
+
+// estimatedMessageRowHeight is the average measured row height, used for rows that
+// were not rendered yet.
+func estimatedMessageRowHeight(heights map[widget.ListItemID]float32) float32 {
+	if len(heights) == 0 {
+		return messageRowHeightFallback
+	}
+	var total float32
+	for _, height := range heights {
+		total += height
+	}
+
+	return total / float32(len(heights))
+}
+
+// prependedHistoryOffset returns the scroll offset that keeps the rows shown at offset
+// in place after shift rows of rowHeight were inserted above them.
+func prependedHistoryOffset(offset float32, shift int, rowHeight, separator float32) float32 {
+	if shift <= 0 {
+		return offset
+	}
+
+	return offset + float32(shift)*(rowHeight+separator)
+}
+
+// jumpToLatestVisible reports whether the jump to latest button is needed after the
+// row with the given id was shown: it is hidden once the newest message is shown.
+func jumpToLatestVisible(boundID, length int) bool {
+	return length > 0 && boundID < length-1
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestMessageTimelineIndexOf(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	timeline := []domain.ChatMessage{
+		{DeviceMessageID: "10", Body: "hello", At: at},
+		{Body: "queued", Direction: domain.MessageDirectionOut, At: at.Add(time.Minute + 250*time.Microsecond)},
+		{DeviceMessageID: "11", Body: "hello again", At: at.Add(2 * time.Minute)},
+	}
+
+	tests := []struct {
+		name  string
+		match domain.ChatMessage
+		want  int
+	}{
+		{name: "by packet id", match: domain.ChatMessage{DeviceMessageID: "11", Body: "hello again"}, want: 2},
+		{
+			name: "without packet id",
+			// Stored times are rounded to milliseconds.
+			match: domain.ChatMessage{Body: "queued", Direction: domain.MessageDirectionOut, At: at.Add(time.Minute)},
+			want:  1,
+		},
+		{name: "other direction", match: domain.ChatMessage{Body: "queued", At: at.Add(time.Minute)}, want: -1},
+		{name: "not loaded", match: domain.ChatMessage{DeviceMessageID: "9", Body: "hello"}, want: -1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := messageTimelineIndexOf(timeline, tc.match); got != tc.want {
+				t.Fatalf("unexpected index: expected %d, got %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPrependedHistoryKeepsRowsInPlace(t *testing.T) {
+	heights := map[widget.ListItemID]float32{0: 60, 1: 100}
+
+	shifted := shiftedMessageItemHeights(heights, 3)
+	if len(shifted) != 2 || shifted[3] != 60 || shifted[4] != 100 {
+		t.Fatalf("unexpected shifted heights: %v", shifted)
+	}
+	if got := estimatedMessageRowHeight(heights); got != 80 {
+		t.Fatalf("unexpected estimated row height: expected 80, got %v", got)
+	}
+	if got := estimatedMessageRowHeight(nil); got != messageRowHeightFallback {
+		t.Fatalf("unexpected fallback row height: expected %v, got %v", messageRowHeightFallback, got)
+	}
+	if got := prependedHistoryOffset(10, 3, 80, 4); got != 262 {
+		t.Fatalf("unexpected offset: expected 262, got %v", got)
+	}
+	if got := prependedHistoryOffset(10, 0, 80, 4); got != 10 {
+		t.Fatalf("unexpected offset without new rows: expected 10, got %v", got)
+	}
+}
+
+func TestJumpToLatestVisible(t *testing.T) {
+	tests := []struct {
+		name    string
+		boundID int
+		length  int
+		want    bool
+	}{
+		{name: "newest row shown", boundID: 9, length: 10, want: false},
+		{name: "older row shown", boundID: 3, length: 10, want: true},
+		{name: "empty chat", boundID: 0, length: 0, want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := jumpToLatestVisible(tc.boundID, tc.length); got != tc.want {
+				t.Fatalf("unexpected visibility: expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestOlderHistoryFailureBacksOff(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var failure olderHistoryFailure
+	if failure.waiting(now) {
+		t.Fatalf("expected no wait before the first failure")
+	}
+
+	failure = failure.next(now)
+	if !failure.waiting(now.Add(time.Second)) || failure.waiting(now.Add(olderHistoryRetryDelay)) {
+		t.Fatalf("expected a retry after %s, got %s", olderHistoryRetryDelay, failure.retryAt.Sub(now))
+	}
+	failure = failure.next(now)
+	if got := failure.retryAt.Sub(now); got != 2*olderHistoryRetryDelay {
+		t.Fatalf("expected the delay to double, got %s", got)
+	}
+	for range 10 {
+		failure = failure.next(now)
+	}
+	if got := failure.retryAt.Sub(now); got != olderHistoryRetryMaxDelay {
+		t.Fatalf("expected the delay to stop at %s, got %s", olderHistoryRetryMaxDelay, got)
+	}
+}
@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// chatListPrefsActions reads and saves the sorting and filter of the chat list.
+type chatListPrefsActions struct {
+	Config func() config.ChatListConfig
+	Set    func(prefs config.ChatListConfig) error
+}
+
+func (a chatListPrefsActions) current() config.ChatListConfig {
+	prefs := config.ChatListConfig{Sort: config.ChatListSortRecent, Filter: config.ChatListFilterAll}
+	if a.Config != nil {
+		loaded := a.Config()
+		if loaded.Sort != "" {
+			prefs.Sort = loaded.Sort
+		}
+		if loaded.Filter != "" {
+			prefs.Filter = loaded.Filter
+		}
+	}
+
+	return prefs
+}
+
+var chatListSorts = []config.ChatListSort{
+	config.ChatListSortRecent,
+	config.ChatListSortUnreadFirst,
+	config.ChatListSortAlphabetical,
+}
+
+var chatListFilters = []config.ChatListFilter{
+	config.ChatListFilterAll,
+	config.ChatListFilterChannels,
+	config.ChatListFilterDMs,
+	config.ChatListFilterUnread,
+}
+
+func chatListSortLabel(sort config.ChatListSort) string {
+	switch sort {
+	case config.ChatListSortUnreadFirst:
+		return i18n.T("Unread first")
+	case config.ChatListSortAlphabetical:
+		return i18n.T("Alphabetical")
+	default:
+		return i18n.T("Recent activity")
+	}
+}
+
+func chatListFilterLabel(filter config.ChatListFilter) string {
+	switch filter {
+	case config.ChatListFilterChannels:
+		return i18n.T("Channels")
+	case config.ChatListFilterDMs:
+		return i18n.T("Direct messages")
+	case config.ChatListFilterUnread:
+		return i18n.T("Unread")
+	default:
+		return i18n.T("All chats")
+	}
+}
+
+// arrangeChatList filters and orders chats for the chat list. Chats come in the store
+// order, latest activity first. Chats listed in keep stay visible whatever the filter,
+// so the open chat does not disappear once it is read.
+func arrangeChatList(
+	chats []domain.Chat,
+	prefs config.ChatListConfig,
+	unreadByKey map[string]int,
+	title func(domain.Chat) string,
+	keep ...string,
+) []domain.Chat {
+	out := make([]domain.Chat, 0, len(chats))
+	for _, chat := range chats {
+		if chatListFilterMatches(chat, prefs.Filter, unreadByKey[chat.Key]) || slices.Contains(keep, chat.Key) {
+			out = append(out, chat)
+		}
+	}
+
+	switch prefs.Sort {
+	case config.ChatListSortUnreadFirst:
+		slices.SortStableFunc(out, func(a, b domain.Chat) int {
+			return compareUnread(unreadByKey[a.Key], unreadByKey[b.Key])
+		})
+	case config.ChatListSortAlphabetical:
+		slices.SortStableFunc(out, func(a, b domain.Chat) int {
+			return strings.Compare(strings.ToLower(title(a)), strings.ToLower(title(b)))
+		})
+	}
+
+	return out
+}
+
+func compareUnread(a, b int) int {
+	switch {
+	case a > 0 && b == 0:
+		return -1
+	case a == 0 && b > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func chatListFilterMatches(chat domain.Chat, filter config.ChatListFilter, unread int) bool {
+	switch filter {
+	case config.ChatListFilterChannels:
+		return !domain.IsDMChat(chat) && !domain.IsLoopbackKey(chat.Key)
+	case config.ChatListFilterDMs:
+		return domain.IsDMChat(chat)
+	case config.ChatListFilterUnread:
+		return unread > 0
+	default:
+		return true
+	}
+}
+
+// newChatListOptionsMenu offers the sortings and filters, checking the current ones.
+func newChatListOptionsMenu(prefs config.ChatListConfig, onChange func(config.ChatListConfig)) *fyne.Menu {
+	items := make([]*fyne.MenuItem, 0, len(chatListSorts)+len(chatListFilters)+1)
+	for _, sort := range chatListSorts {
+		item := fyne.NewMenuItem(i18n.Tf("Sort: %s", chatListSortLabel(sort)), func() {
+			next := prefs
+			next.Sort = sort
+			onChange(next)
+		})
+		item.Checked = prefs.Sort == sort
+		items = append(items, item)
+	}
+	items = append(items, fyne.NewMenuItemSeparator())
+	for _, filter := range chatListFilters {
+		item := fyne.NewMenuItem(i18n.Tf("Show: %s", chatListFilterLabel(filter)), func() {
+			next := prefs
+			next.Filter = filter
+			onChange(next)
+		})
+		item.Checked = prefs.Filter == filter
+		items = append(items, item)
+	}
+
+	return fyne.NewMenu(i18n.T("Chat list"), items...)
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestArrangeChatList(t *testing.T) {
+	chats := []domain.Chat{
+		{Key: "dm:!00000002", Title: "bravo", Type: domain.ChatTypeDM},
+		{Key: "channel:0", Title: "Charlie", Type: domain.ChatTypeChannel},
+		{Key: "dm:!00000001", Title: "alpha", Type: domain.ChatTypeDM},
+		{Key: "channel:1", Title: "Delta", Type: domain.ChatTypeChannel},
+	}
+	unread := map[string]int{"channel:1": 2, "dm:!00000001": 1}
+	title := func(chat domain.Chat) string { return chat.Title }
+
+	tests := []struct {
+		name  string
+		prefs config.ChatListConfig
+		keep  []string
+		want  []string
+	}{
+		{
+			name:  "recent keeps store order",
+			prefs: config.ChatListConfig{Sort: config.ChatListSortRecent, Filter: config.ChatListFilterAll},
+			want:  []string{"dm:!00000002", "channel:0", "dm:!00000001", "channel:1"},
+		},
+		{
+			name:  "unread first keeps recent order within groups",
+			prefs: config.ChatListConfig{Sort: config.ChatListSortUnreadFirst, Filter: config.ChatListFilterAll},
+			want:  []string{"dm:!00000001", "channel:1", "dm:!00000002", "channel:0"},
+		},
+		{
+			name:  "alphabetical ignores case",
+			prefs: config.ChatListConfig{Sort: config.ChatListSortAlphabetical, Filter: config.ChatListFilterAll},
+			want:  []string{"dm:!00000001", "dm:!00000002", "channel:0", "channel:1"},
+		},
+		{
+			name:  "channels only",
+			prefs: config.ChatListConfig{Sort: config.ChatListSortRecent, Filter: config.ChatListFilterChannels},
+			want:  []string{"channel:0", "channel:1"},
+		},
+		{
+			name:  "dms only",
+			prefs: config.ChatListConfig{Sort: config.ChatListSortRecent, Filter: config.ChatListFilterDMs},
+			want:  []string{"dm:!00000002", "dm:!00000001"},
+		},
+		{
+			name:  "unread keeps the open chat",
+			prefs: config.ChatListConfig{Sort: config.ChatListSortRecent, Filter: config.ChatListFilterUnread},
+			keep:  []string{"channel:0"},
+			want:  []string{"channel:0", "dm:!00000001", "channel:1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := arrangeChatList(chats, tt.prefs, unread, title, tt.keep...)
+			keys := make([]string, 0, len(got))
+			for _, chat := range got {
+				keys = append(keys, chat.Key)
+			}
+			if len(keys) != len(tt.want) {
+				t.Fatalf("unexpected chats: expected %v, got %v", tt.want, keys)
+			}
+			for i := range keys {
+				if keys[i] != tt.want[i] {
+					t.Fatalf("unexpected chats: expected %v, got %v", tt.want, keys)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+const (
+	shareLocationAsWaypoint = "Waypoint"
+	shareLocationAsGeoURI   = "geo: link"
+)
+
+// defaultSharedLocationName is the waypoint name of a point picked on the map.
+const defaultSharedLocationName = "Shared location"
+
+// locationShareComposer sends a location shared from the map to the selected chat.
+type locationShareComposer struct {
+	window       fyne.Window
+	chatTitle    string
+	sendWaypoint func(waypoint domain.Waypoint)
+	sendText     func(text string)
+}
+
+// showShareLocationDialog asks how to share location, as a waypoint or as a geo: text
+// message, and sends it.
+func showShareLocationDialog(composer locationShareComposer, location sharedLocation) {
+	if composer.window == nil {
+		return
+	}
+	asWaypoint, asGeoURI := i18n.T(shareLocationAsWaypoint), i18n.T(shareLocationAsGeoURI)
+	format := widget.NewRadioGroup([]string{asWaypoint, asGeoURI}, nil)
+	format.Horizontal = true
+	format.Required = true
+	format.SetSelected(asWaypoint)
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(sharedLocationWaypointName(location))
+	descriptionEntry := widget.NewEntry()
+	descriptionEntry.SetPlaceHolder(i18n.T("Optional"))
+	format.OnChanged = func(selected string) {
+		if selected == asGeoURI {
+			descriptionEntry.Disable()
+		} else {
+			descriptionEntry.Enable()
+		}
+	}
+
+	dialog.ShowForm(
+		i18n.Tf("Share location to %s", composer.chatTitle),
+		i18n.T("Send"),
+		i18n.T("Cancel"),
+		[]*widget.FormItem{
+			widget.NewFormItem(i18n.T("Position"), widget.NewLabel(domain.GeoURIText(location.Latitude, location.Longitude))),
+			widget.NewFormItem(i18n.T("Send as"), format),
+			widget.NewFormItem(i18n.T("Name"), nameEntry),
+			widget.NewFormItem(i18n.T("Description"), descriptionEntry),
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			if format.Selected == asGeoURI {
+				if composer.sendText != nil {
+					composer.sendText(sharedLocationGeoText(nameEntry.Text, location))
+				}
+
+				return
+			}
+			waypoint := domain.Waypoint{
+				Name:        strings.TrimSpace(nameEntry.Text),
+				Description: strings.TrimSpace(descriptionEntry.Text),
+				Latitude:    location.Latitude,
+				Longitude:   location.Longitude,
+			}
+			if err := waypoint.Validate(); err != nil {
+				dialog.ShowError(err, composer.window)
+
+				return
+			}
+			if composer.sendWaypoint != nil {
+				composer.sendWaypoint(waypoint)
+
+				return
+			}
+			if composer.sendText != nil {
+				composer.sendText(waypoint.ReferenceText())
+			}
+		},
+		composer.window,
+	)
+}
+
+// sharedLocationWaypointName fits the location name into a waypoint name.
+func sharedLocationWaypointName(location sharedLocation) string {
+	name := strings.TrimSpace(location.Name)
+	if name == "" {
+		name = i18n.T(defaultSharedLocationName)
+	}
+	for len(name) > domain.WaypointNameMaxBytes {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+
+	return strings.TrimSpace(name)
+}
+
+// sharedLocationGeoText is the geo: URI of location, after its name when there is one.
+func sharedLocationGeoText(name string, location sharedLocation) string {
+	uri := domain.GeoURIText(location.Latitude, location.Longitude)
+	if name = strings.TrimSpace(name); name != "" {
+		return fmt.Sprintf("%s %s", name, uri)
+	}
+
+	return uri
+}
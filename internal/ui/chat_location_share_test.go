@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestSharedLocationWaypointName(t *testing.T) {
+	tests := []struct {
+		name     string
+		location sharedLocation
+		want     string
+	}{
+		{name: "map point", location: sharedLocation{}, want: defaultSharedLocationName},
+		{name: "node", location: sharedLocation{Name: " Base camp "}, want: "Base camp"},
+		{name: "long name", location: sharedLocation{Name: strings.Repeat("ж", 20)}, want: strings.Repeat("ж", 15)},
+	}
+
+	for _, tc := range tests {
+		got := sharedLocationWaypointName(tc.location)
+		if got != tc.want {
+			t.Fatalf("%s: expected %q, got %q", tc.name, tc.want, got)
+		}
+		if len(got) > domain.WaypointNameMaxBytes {
+			t.Fatalf("%s: name exceeds %d bytes: %q", tc.name, domain.WaypointNameMaxBytes, got)
+		}
+	}
+}
+
+func TestSharedLocationGeoText(t *testing.T) {
+	location := sharedLocation{Latitude: 55.755831, Longitude: 37.6173}
+	if got := sharedLocationGeoText(" ", location); got != "geo:55.75583,37.61730" {
+		t.Fatalf("unexpected text without name: %q", got)
+	}
+	if got := sharedLocationGeoText("Camp", location); got != "Camp geo:55.75583,37.61730" {
+		t.Fatalf("unexpected text with name: %q", got)
+	}
+}
@@ -0,0 +1,273 @@
+package ui
+
+import (
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+const chatSearchNotePreviewMaxLen = 64
+
+// chatMessageSearchFunc returns stored messages of a chat whose text contains the
+// query, oldest first.
+type chatMessageSearchFunc func(chatKey, query string) ([]domain.ChatMessage, error)
+
+func chatSearchCounterText(current, total int) string {
+	if total == 0 {
+		return i18n.T("No matches")
+	}
+
+	return i18n.Tf("%d of %d", current+1, total)
+}
+
+// chatSearchUnloadedNote describes a match that is older than the loaded messages.
+func chatSearchUnloadedNote(match domain.ChatMessage) string {
+	return i18n.Tf(
+		"Older message from %s: %s",
+		currentDisplayFormatter().DateTime(match.At),
+		truncatePreview(compactWhitespace(match.Body), chatSearchNotePreviewMaxLen),
+	)
+}
+
+// chatMessageSearch is the search bar of an open chat. It queries stored messages and
+// steps through the matches, newest first.
+type chatMessageSearch struct {
+	search  chatMessageSearchFunc
+	chatKey func() string
+	// reveal scrolls to a match and reports whether it is shown in the timeline.
+	reveal func(match domain.ChatMessage) bool
+	// onHighlightChange refreshes the rows after matches or the current match change.
+	onHighlightChange func()
+	onError           func(err error)
+
+	entry   *shortcutEntry
+	counter *widget.Label
+	note    *widget.Label
+	older   *widget.Button
+	newer   *widget.Button
+	content *fyne.Container
+
+	query      string
+	matches    []domain.ChatMessage
+	matchKeys  map[string]struct{}
+	current    int
+	generation int
+}
+
+func newChatMessageSearch(
+	search chatMessageSearchFunc,
+	chatKey func() string,
+	reveal func(match domain.ChatMessage) bool,
+	onHighlightChange func(),
+	onError func(err error),
+) *chatMessageSearch {
+	s := &chatMessageSearch{
+		search:            search,
+		chatKey:           chatKey,
+		reveal:            reveal,
+		onHighlightChange: onHighlightChange,
+		onError:           onError,
+		current:           -1,
+	}
+	s.entry = newShortcutEntry()
+	s.entry.SetPlaceHolder(i18n.T("Search messages"))
+	s.entry.OnSubmitted = func(text string) {
+		if strings.TrimSpace(text) == s.query && len(s.matches) > 0 {
+			s.move(-1)
+
+			return
+		}
+		s.run(text)
+	}
+	s.entry.onTypedKey = func(event *fyne.KeyEvent) bool {
+		if event.Name != fyne.KeyEscape {
+			return false
+		}
+		s.Close()
+
+		return true
+	}
+	s.counter = widget.NewLabel("")
+	s.note = widget.NewLabel("")
+	s.note.Truncation = fyne.TextTruncateEllipsis
+	s.note.Hide()
+	s.older = widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() { s.move(-1) })
+	s.newer = widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() { s.move(1) })
+	closeButton := widget.NewButtonWithIcon("", theme.CancelIcon(), s.Close)
+	s.content = container.NewVBox(
+		container.NewBorder(nil, nil, nil, container.NewHBox(s.counter, s.older, s.newer, closeButton), s.entry),
+		s.note,
+	)
+	s.content.Hide()
+	s.refreshControls()
+
+	return s
+}
+
+func (s *chatMessageSearch) enabled() bool {
+	return s != nil && s.search != nil
+}
+
+// Open shows the search bar and focuses its field.
+func (s *chatMessageSearch) Open() {
+	if !s.enabled() {
+		return
+	}
+	s.content.Show()
+	focusEntry(s.entry)
+}
+
+// Close hides the search bar and drops its matches.
+func (s *chatMessageSearch) Close() {
+	if s == nil {
+		return
+	}
+	s.Reset()
+	if fyneCanvas := canvasForObject(s.entry); fyneCanvas != nil && fyneCanvas.Focused() == s.entry {
+		fyneCanvas.Unfocus()
+	}
+	s.content.Hide()
+}
+
+// Reset clears the query and matches, e.g. when another chat is opened.
+func (s *chatMessageSearch) Reset() {
+	if s == nil {
+		return
+	}
+	s.generation++
+	s.entry.SetText("")
+	hadMatches := len(s.matches) > 0
+	s.setMatches("", nil)
+	if hadMatches {
+		s.highlightChanged()
+	}
+}
+
+// IsMatch reports whether the message matches the query and whether it is the current match.
+func (s *chatMessageSearch) IsMatch(message domain.ChatMessage) (matched, current bool) {
+	if s == nil || len(s.matchKeys) == 0 {
+		return false, false
+	}
+	key := domain.MessageIdentity(message)
+	if _, ok := s.matchKeys[key]; !ok {
+		return false, false
+	}
+
+	return true, s.current >= 0 && domain.MessageIdentity(s.matches[s.current]) == key
+}
+
+func (s *chatMessageSearch) run(text string) {
+	query := strings.TrimSpace(text)
+	chatKey := s.chatKey()
+	if query == "" || chatKey == "" {
+		s.setMatches("", nil)
+		s.highlightChanged()
+
+		return
+	}
+	s.generation++
+	generation := s.generation
+	s.counter.SetText(i18n.T("Searching..."))
+	go func() {
+		matches, err := s.search(chatKey, query)
+		fyne.Do(func() {
+			// A newer search or another chat replaced this one.
+			if generation != s.generation || chatKey != s.chatKey() {
+				return
+			}
+			if err != nil {
+				s.setMatches("", nil)
+				if s.onError != nil {
+					s.onError(err)
+				}
+
+				return
+			}
+			s.setMatches(query, matches)
+			s.highlightChanged()
+			s.showCurrent()
+		})
+	}()
+}
+
+// move steps through the matches: a negative delta goes to older messages.
+func (s *chatMessageSearch) move(delta int) {
+	if len(s.matches) == 0 {
+		return
+	}
+	s.current = relativeListIndex(s.current, delta, len(s.matches))
+	s.refreshControls()
+	s.highlightChanged()
+	s.showCurrent()
+}
+
+func (s *chatMessageSearch) setMatches(query string, matches []domain.ChatMessage) {
+	s.query = query
+	s.matches = matches
+	s.matchKeys = make(map[string]struct{}, len(matches))
+	for _, match := range matches {
+		s.matchKeys[domain.MessageIdentity(match)] = struct{}{}
+	}
+	s.current = len(matches) - 1
+	s.note.Hide()
+	s.refreshControls()
+}
+
+func (s *chatMessageSearch) showCurrent() {
+	if s.current < 0 || s.current >= len(s.matches) {
+		return
+	}
+	match := s.matches[s.current]
+	if s.reveal != nil && s.reveal(match) {
+		s.note.Hide()
+
+		return
+	}
+	s.note.SetText(chatSearchUnloadedNote(match))
+	s.note.Show()
+}
+
+func (s *chatMessageSearch) highlightChanged() {
+	if s.onHighlightChange != nil {
+		s.onHighlightChange()
+	}
+}
+
+func (s *chatMessageSearch) refreshControls() {
+	if s.query == "" {
+		s.counter.SetText("")
+	} else {
+		s.counter.SetText(chatSearchCounterText(s.current, len(s.matches)))
+	}
+	if s.current > 0 {
+		s.older.Enable()
+	} else {
+		s.older.Disable()
+	}
+	if s.current >= 0 && s.current < len(s.matches)-1 {
+		s.newer.Enable()
+	} else {
+		s.newer.Disable()
+	}
+}
+
+// chatSearchBubbleStroke outlines the bubbles of search matches. The current match
+// gets a thicker outline.
+func chatSearchBubbleStroke(matched, current bool) (color.Color, float32) {
+	if !matched {
+		return color.Transparent, 0
+	}
+	stroke := theme.Color(theme.ColorNamePrimary)
+	if current {
+		return stroke, 3
+	}
+
+	return stroke, 1
+}
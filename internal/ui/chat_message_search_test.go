@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"testing"
+
+	fynetest "fyne.io/fyne/v2/test"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestChatMessageSearchIsMatch(t *testing.T) {
+	_ = fynetest.NewTempApp(t)
+	search := newChatMessageSearch(nil, func() string { return "channel:0" }, nil, nil, nil)
+	first := domain.ChatMessage{DeviceMessageID: "1", Body: "one"}
+	second := domain.ChatMessage{DeviceMessageID: "2", Body: "two"}
+	search.setMatches("o", []domain.ChatMessage{first, second})
+
+	if matched, current := search.IsMatch(second); !matched || !current {
+		t.Fatalf("expected the newest match to be current, got matched=%v current=%v", matched, current)
+	}
+	if got := search.counter.Text; got != "2 of 2" {
+		t.Fatalf("unexpected counter: expected %q, got %q", "2 of 2", got)
+	}
+
+	search.move(-1)
+	if matched, current := search.IsMatch(first); !matched || !current {
+		t.Fatalf("expected the older match to be current, got matched=%v current=%v", matched, current)
+	}
+	if matched, _ := search.IsMatch(domain.ChatMessage{DeviceMessageID: "3"}); matched {
+		t.Fatalf("expected other messages not to match")
+	}
+
+	search.Reset()
+	if matched, _ := search.IsMatch(first); matched {
+		t.Fatalf("expected no matches after reset")
+	}
+	if got := search.counter.Text; got != "" {
+		t.Fatalf("unexpected counter after reset: expected empty, got %q", got)
+	}
+}
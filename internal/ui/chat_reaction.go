@@ -8,6 +8,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 	"github.com/skobkin/meshgo/internal/radio"
 )
 
@@ -64,7 +65,7 @@ func openReactionPicker(
 				fyne.Do(func() {
 					logger.Warn("reaction send failed", "chat_key", chatKey, "emoji", emoji, "target_message_id", targetID, "error", res.Err)
 					if statusLabel != nil {
-						statusLabel.SetText("Reaction failed: " + res.Err.Error())
+						statusLabel.SetText(i18n.Tf("Reaction failed: %s", res.Err))
 					}
 				})
 
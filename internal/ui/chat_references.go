@@ -0,0 +1,228 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+	"github.com/skobkin/meshgo/internal/radio"
+)
+
+// waypointSender is implemented by message senders that can share a waypoint as a
+// waypoint packet. Other senders get the waypoint as compact text in the composer.
+type waypointSender interface {
+	SendWaypoint(chatKey string, waypoint domain.Waypoint) <-chan radio.SendResult
+}
+
+// chatReferenceSource provides the nodes the composer "+" menu can reference.
+type chatReferenceSource struct {
+	Nodes       func() []domain.Node
+	LocalNodeID func() string
+}
+
+func (s chatReferenceSource) nodes() []domain.Node {
+	if s.Nodes == nil {
+		return nil
+	}
+
+	return s.Nodes()
+}
+
+// localPosition returns the local node's last known coordinates.
+func (s chatReferenceSource) localPosition() (latitude, longitude float64, ok bool) {
+	localID := localNodeIDValue(s.LocalNodeID)
+	if localID == "" {
+		return 0, 0, false
+	}
+	for _, node := range s.nodes() {
+		if !isLocalNode(node, localID) {
+			continue
+		}
+		if node.Latitude == nil || node.Longitude == nil {
+			return 0, 0, false
+		}
+
+		return *node.Latitude, *node.Longitude, true
+	}
+
+	return 0, 0, false
+}
+
+// chatReferenceComposer connects the "+" menu to the chat composer.
+type chatReferenceComposer struct {
+	window       fyne.Window
+	source       chatReferenceSource
+	insert       func(text string)
+	sendWaypoint func(waypoint domain.Waypoint)
+}
+
+// newChatReferenceButton builds the composer "+" button. sendWaypoint may be nil, then
+// waypoints are inserted as text like the other references.
+func newChatReferenceButton(composer chatReferenceComposer) *widget.Button {
+	var button *widget.Button
+	button = widget.NewButtonWithIcon("", theme.ContentAddIcon(), func() {
+		fyneCanvas := canvasForObject(button)
+		if fyneCanvas == nil {
+			return
+		}
+		// The pop-up is clamped to the canvas, so it opens upwards from the composer.
+		position := fyne.CurrentApp().Driver().AbsolutePositionForObject(button)
+		widget.ShowPopUpMenuAtPosition(newChatReferenceMenu(composer), fyneCanvas, position)
+	})
+
+	return button
+}
+
+func newChatReferenceMenu(composer chatReferenceComposer) *fyne.Menu {
+	itemNode := fyne.NewMenuItem(i18n.T("Node card…"), func() {
+		showNodeReferenceDialog(composer)
+	})
+	if len(composer.source.nodes()) == 0 {
+		itemNode.Disabled = true
+	}
+	itemWaypoint := fyne.NewMenuItem(i18n.T("Waypoint…"), func() {
+		showWaypointReferenceDialog(composer)
+	})
+	itemPosition := fyne.NewMenuItem(i18n.T("My position"), func() {
+		latitude, longitude, ok := composer.source.localPosition()
+		if ok && composer.insert != nil {
+			composer.insert(domain.PositionReferenceText(latitude, longitude))
+		}
+	})
+	if _, _, ok := composer.source.localPosition(); !ok {
+		itemPosition.Disabled = true
+	}
+
+	return fyne.NewMenu("", itemNode, itemWaypoint, itemPosition)
+}
+
+// nodeReferenceOptions returns the distinct node reference texts in node order.
+func nodeReferenceOptions(nodes []domain.Node) []string {
+	out := make([]string, 0, len(nodes))
+	seen := make(map[string]struct{}, len(nodes))
+	for _, node := range nodes {
+		text := domain.NodeReferenceText(node)
+		if text == "" {
+			continue
+		}
+		if _, ok := seen[text]; ok {
+			continue
+		}
+		seen[text] = struct{}{}
+		out = append(out, text)
+	}
+
+	return out
+}
+
+func showNodeReferenceDialog(composer chatReferenceComposer) {
+	if composer.window == nil {
+		return
+	}
+	nodeSelect := widget.NewSelect(nodeReferenceOptions(composer.source.nodes()), nil)
+	dialog.ShowForm(
+		i18n.T("Insert node card"),
+		i18n.T("Insert"),
+		i18n.T("Cancel"),
+		[]*widget.FormItem{
+			widget.NewFormItem(i18n.T("Node"), nodeSelect),
+		},
+		func(ok bool) {
+			if ok && nodeSelect.Selected != "" && composer.insert != nil {
+				composer.insert(nodeSelect.Selected)
+			}
+		},
+		composer.window,
+	)
+}
+
+func showWaypointReferenceDialog(composer chatReferenceComposer) {
+	if composer.window == nil {
+		return
+	}
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder(i18n.Tf("Up to %d bytes", domain.WaypointNameMaxBytes))
+	descriptionEntry := widget.NewEntry()
+	descriptionEntry.SetPlaceHolder(i18n.T("Optional"))
+	latitudeEntry := widget.NewEntry()
+	latitudeEntry.SetPlaceHolder("55.75583")
+	longitudeEntry := widget.NewEntry()
+	longitudeEntry.SetPlaceHolder("37.61730")
+	if latitude, longitude, ok := composer.source.localPosition(); ok {
+		latitudeEntry.SetText(strconv.FormatFloat(latitude, 'f', 5, 64))
+		longitudeEntry.SetText(strconv.FormatFloat(longitude, 'f', 5, 64))
+	}
+
+	dialog.ShowForm(
+		i18n.T("Share waypoint"),
+		i18n.T("Share"),
+		i18n.T("Cancel"),
+		[]*widget.FormItem{
+			widget.NewFormItem(i18n.T("Name"), nameEntry),
+			widget.NewFormItem(i18n.T("Description"), descriptionEntry),
+			widget.NewFormItem(i18n.T("Latitude"), latitudeEntry),
+			widget.NewFormItem(i18n.T("Longitude"), longitudeEntry),
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			waypoint, err := parseWaypointForm(nameEntry.Text, descriptionEntry.Text, latitudeEntry.Text, longitudeEntry.Text)
+			if err != nil {
+				dialog.ShowError(err, composer.window)
+
+				return
+			}
+			if composer.sendWaypoint != nil {
+				composer.sendWaypoint(waypoint)
+
+				return
+			}
+			if composer.insert != nil {
+				composer.insert(waypoint.ReferenceText())
+			}
+		},
+		composer.window,
+	)
+}
+
+func parseWaypointForm(name, description, latitude, longitude string) (domain.Waypoint, error) {
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latitude), 64)
+	if err != nil {
+		return domain.Waypoint{}, fmt.Errorf("invalid latitude: %q", latitude)
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(longitude), 64)
+	if err != nil {
+		return domain.Waypoint{}, fmt.Errorf("invalid longitude: %q", longitude)
+	}
+	waypoint := domain.Waypoint{
+		Name:        strings.TrimSpace(name),
+		Description: strings.TrimSpace(description),
+		Latitude:    lat,
+		Longitude:   lon,
+	}
+	if err := waypoint.Validate(); err != nil {
+		return domain.Waypoint{}, err
+	}
+
+	return waypoint, nil
+}
+
+// insertReferenceText appends a reference to the composer text, separated by a space.
+func insertReferenceText(current, reference string) string {
+	if strings.TrimSpace(current) == "" {
+		return reference
+	}
+	if strings.HasSuffix(current, " ") {
+		return current + reference
+	}
+
+	return current + " " + reference
+}
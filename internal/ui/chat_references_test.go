@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestNodeReferenceOptionsSkipsDuplicates(t *testing.T) {
+	got := nodeReferenceOptions([]domain.Node{
+		{NodeID: "!00000001", LongName: "Alice"},
+		{NodeID: "!00000002"},
+		{NodeID: "!00000001", LongName: "Alice"},
+		{},
+	})
+	want := []string{"Alice (!00000001)", "!00000002"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestChatReferenceSourceLocalPosition(t *testing.T) {
+	lat, lon := 10.5, -20.25
+	source := chatReferenceSource{
+		Nodes: func() []domain.Node {
+			return []domain.Node{
+				{NodeID: "!00000002", Latitude: &lon, Longitude: &lat},
+				{NodeID: "!00000001", Latitude: &lat, Longitude: &lon},
+			}
+		},
+		LocalNodeID: func() string { return "!00000001" },
+	}
+	gotLat, gotLon, ok := source.localPosition()
+	if !ok || gotLat != lat || gotLon != lon {
+		t.Fatalf("expected local position %v,%v, got %v,%v (ok=%v)", lat, lon, gotLat, gotLon, ok)
+	}
+
+	source.LocalNodeID = func() string { return "!00000003" }
+	if _, _, ok := source.localPosition(); ok {
+		t.Fatalf("expected no position for an unknown local node")
+	}
+}
+
+func TestParseWaypointForm(t *testing.T) {
+	waypoint, err := parseWaypointForm(" Camp ", "", "1.5", " -2.25 ")
+	if err != nil {
+		t.Fatalf("parse waypoint: %v", err)
+	}
+	if waypoint.Name != "Camp" || waypoint.Latitude != 1.5 || waypoint.Longitude != -2.25 {
+		t.Fatalf("unexpected waypoint: %+v", waypoint)
+	}
+	if _, err := parseWaypointForm("Camp", "", "north", "1"); err == nil {
+		t.Fatalf("expected invalid latitude to be rejected")
+	}
+	if _, err := parseWaypointForm("", "", "1", "1"); err == nil {
+		t.Fatalf("expected missing name to be rejected")
+	}
+}
+
+func TestInsertReferenceText(t *testing.T) {
+	tests := map[string]string{
+		"":      "📍 1.00000,2.00000",
+		"meet":  "meet 📍 1.00000,2.00000",
+		"meet ": "meet 📍 1.00000,2.00000",
+		"  \t ": "📍 1.00000,2.00000",
+	}
+	for current, want := range tests {
+		if got := insertReferenceText(current, "📍 1.00000,2.00000"); got != want {
+			t.Fatalf("insert into %q: expected %q, got %q", current, want, got)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package ui
+
+import "github.com/skobkin/meshgo/internal/domain"
+
+// chatResumeState keeps the open chat while the chat store is emptied and reloaded,
+// e.g. after a reconnect to another device namespace, so the chat, its scroll position
+// and the half-composed message come back instead of a fresh view.
+type chatResumeState struct {
+	ChatKey                string
+	Draft                  string
+	ReplyToDeviceMessageID string
+	ScrollOffset           float32
+}
+
+func (s chatResumeState) pending() bool {
+	return s.ChatKey != ""
+}
+
+// restorableIn reports whether the remembered chat is back in the reloaded chat list.
+func (s chatResumeState) restorableIn(chats []domain.Chat) bool {
+	return s.pending() && hasChat(chats, s.ChatKey)
+}
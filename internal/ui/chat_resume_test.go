@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestChatResumeStateRestorableIn(t *testing.T) {
+	chats := []domain.Chat{{Key: "channel:0"}, {Key: "dm:!0000002a"}}
+	tests := []struct {
+		name  string
+		state chatResumeState
+		chats []domain.Chat
+		want  bool
+	}{
+		{name: "nothing to resume", state: chatResumeState{}, chats: chats, want: false},
+		{name: "store still empty", state: chatResumeState{ChatKey: "dm:!0000002a"}, chats: nil, want: false},
+		{name: "chat is back", state: chatResumeState{ChatKey: "dm:!0000002a"}, chats: chats, want: true},
+		{name: "chat is gone", state: chatResumeState{ChatKey: "channel:1"}, chats: chats, want: false},
+	}
+	for _, tc := range tests {
+		if got := tc.state.restorableIn(tc.chats); got != tc.want {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// chatUnreadBadgeMax is the largest count shown in full, bigger counts show as "99+".
+const chatUnreadBadgeMax = 99
+
+// chatUnreadBadge is the unread count bubble of a chat list row. It hides itself when
+// there is nothing unread.
+type chatUnreadBadge struct {
+	widget.BaseWidget
+
+	count      int
+	background *canvas.Rectangle
+	text       *canvas.Text
+}
+
+func newChatUnreadBadge() *chatUnreadBadge {
+	badge := &chatUnreadBadge{
+		background: canvas.NewRectangle(theme.Color(theme.ColorNamePrimary)),
+		text:       canvas.NewText("", theme.Color(theme.ColorNameForegroundOnPrimary)),
+	}
+	badge.text.TextStyle = fyne.TextStyle{Bold: true}
+	badge.text.TextSize = theme.CaptionTextSize()
+	badge.ExtendBaseWidget(badge)
+	badge.Hide()
+
+	return badge
+}
+
+// SetCount shows count in the bubble, or hides the bubble when count is not positive.
+func (b *chatUnreadBadge) SetCount(count int) {
+	b.count = count
+	b.text.Text = chatUnreadBadgeText(count)
+	if count > 0 {
+		b.Show()
+	} else {
+		b.Hide()
+	}
+	b.Refresh()
+}
+
+func (b *chatUnreadBadge) Refresh() {
+	b.background.FillColor = theme.Color(theme.ColorNamePrimary)
+	b.text.Color = theme.Color(theme.ColorNameForegroundOnPrimary)
+	b.text.TextSize = theme.CaptionTextSize()
+	b.background.CornerRadius = b.MinSize().Height / 2
+	b.BaseWidget.Refresh()
+}
+
+func (b *chatUnreadBadge) MinSize() fyne.Size {
+	b.ExtendBaseWidget(b)
+	text := fyne.MeasureText(b.text.Text, theme.CaptionTextSize(), b.text.TextStyle)
+	height := text.Height + theme.InnerPadding()/2
+
+	return fyne.NewSize(max(height, text.Width+theme.InnerPadding()), height)
+}
+
+func (b *chatUnreadBadge) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewStack(b.background, container.NewCenter(b.text)))
+}
+
+func chatUnreadBadgeText(unread int) string {
+	if unread <= 0 {
+		return ""
+	}
+	if unread > chatUnreadBadgeMax {
+		return strconv.Itoa(chatUnreadBadgeMax) + "+"
+	}
+
+	return strconv.Itoa(unread)
+}
@@ -14,19 +14,23 @@ import (
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
-	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/skobkin/meshgo/internal/config"
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 	"github.com/skobkin/meshgo/internal/radio"
 	"github.com/skobkin/meshgo/internal/textutil"
 	"github.com/skobkin/meshgo/internal/ui/widgets"
 	chatlayout "github.com/skobkin/meshgo/internal/ui/widgets/layout"
 )
 
-var chatsLogger = slog.With("component", "ui.chats")
+// chatsLogComponent names the chats log lines shown in error toast details.
+const chatsLogComponent = "ui.chats"
+
+var chatsLogger = slog.With("component", chatsLogComponent)
 
 // messageRowMeasureEpsilon filters sub-pixel measurement jitter so list item
 // heights are updated only on meaningful size changes.
@@ -51,6 +55,62 @@ func prepareOutgoingText(input string, compactCyrillic bool) preparedOutgoingTex
 	}
 }
 
+// chatAttention reports whether the user is looking at the app, so chats are marked as
+// read only once their messages could actually have been seen.
+type chatAttention interface {
+	Active() bool
+	OnRegained(func())
+}
+
+// chatsTabContent lets the sidebar tell the chats tab that it was opened.
+type chatsTabContent struct {
+	widget.BaseWidget
+	content   fyne.CanvasObject
+	onShow    func()
+	shortcuts listShortcutTarget
+	// shareLocation sends a location picked on the map to the selected chat.
+	shareLocation func(location sharedLocation)
+	// unread is the number of unread incoming messages in all chats.
+	unread   int
+	onUnread func(unread int)
+}
+
+func newChatsTabContent(content fyne.CanvasObject, onShow func()) *chatsTabContent {
+	c := &chatsTabContent{content: content, onShow: onShow}
+	c.ExtendBaseWidget(c)
+
+	return c
+}
+
+func (c *chatsTabContent) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(c.content)
+}
+
+// OnUnreadChange calls listener with the number of unread messages in all chats now and
+// whenever it changes.
+func (c *chatsTabContent) OnUnreadChange(listener func(unread int)) {
+	c.onUnread = listener
+	if listener != nil {
+		listener(c.unread)
+	}
+}
+
+func (c *chatsTabContent) setUnread(unread int) {
+	if c == nil || c.unread == unread {
+		return
+	}
+	c.unread = unread
+	if c.onUnread != nil {
+		c.onUnread(unread)
+	}
+}
+
+func (c *chatsTabContent) OnShow() {
+	if c.onShow != nil {
+		c.onShow()
+	}
+}
+
 func newChatsTab(
 	window fyne.Window,
 	store *domain.ChatStore,
@@ -65,12 +125,46 @@ func newChatsTab(
 	onDeleteDMChat func(string) error,
 	onShareChannel func(domain.Chat),
 	compactCyrillicEncodingEnabled func() bool,
+	annotations chatAnnotationActions,
+	taskbarFlash chatTaskbarFlashActions,
+	exportChats chatExportFunc,
+	attention chatAttention,
+	references chatReferenceSource,
+	setChatNotifications func(chatKey string, prefs domain.ChatNotificationPrefs) error,
+	pins chatPinActions,
+	history chatHistoryActions,
+	listPrefs chatListPrefsActions,
+	colors chatColorActions,
+	reportError reportErrorFunc,
 ) fyne.CanvasObject {
-	chats := store.ChatListSorted()
+	allChats := store.ChatListSorted()
+	readIncomingUpToByKey := initialReadIncomingByChat(allChats)
+	unreadByKey := chatUnreadCountByKey(store, allChats, readIncomingUpToByKey)
+	chatListPrefs := listPrefs.current()
+	chatTitleOf := func(chat domain.Chat) string {
+		return chatDisplayTitle(chat, nodeNameByID)
+	}
+	chats := arrangeChatList(allChats, chatListPrefs, unreadByKey, chatTitleOf, strings.TrimSpace(initialSelectedKey))
+	annotationsByKey := make(map[string]domain.MessageAnnotation)
+	if annotations.List != nil {
+		loaded, err := annotations.List()
+		if err != nil {
+			chatsLogger.Warn("load message annotations failed", "error", err)
+		} else {
+			annotationsByKey = messageAnnotationsByKey(loaded)
+		}
+	}
+	pinsByKey := make(map[string]domain.MessagePin)
+	if pins.List != nil {
+		loaded, err := pins.List()
+		if err != nil {
+			chatsLogger.Warn("load message pins failed", "error", err)
+		} else {
+			pinsByKey = messagePinsByKey(loaded)
+		}
+	}
 	previewsByKey := chatPreviewByKey(store, chats, nodeNameByID)
 	selectedKey := strings.TrimSpace(initialSelectedKey)
-	readIncomingUpToByKey := initialReadIncomingByChat(store, chats)
-	unreadByKey := chatUnreadByKey(store, chats, readIncomingUpToByKey)
 	if selectedKey != "" && len(chats) > 0 && !hasChat(chats, selectedKey) {
 		selectedKey = ""
 	}
@@ -82,42 +176,89 @@ func newChatsTab(
 		"chat_count", len(chats),
 		"initial_selected_chat", selectedKey,
 	)
-	markChatRead(store, readIncomingUpToByKey, selectedKey)
-	unreadByKey = chatUnreadByKey(store, chats, readIncomingUpToByKey)
-	messageView := buildChatMessageView(store.Messages(selectedKey), nodeNameByID, localNodeID)
+	var content *chatsTabContent
+	var chatList *widget.List
+	// Background refreshes keep the selected chat unread while the tab is hidden, the
+	// window is not focused or the user is idle.
+	chatViewed := func() bool {
+		if content != nil && !content.Visible() {
+			return false
+		}
+
+		return attention == nil || attention.Active()
+	}
+	// Incoming messages are read once their row is shown while the chat is viewed.
+	markMessageSeen := func(msg domain.ChatMessage) {
+		if !chatViewed() || !markIncomingRead(readIncomingUpToByKey, selectedKey, msg) {
+			return
+		}
+		if history.MarkRead != nil {
+			history.MarkRead(selectedKey, msg.At)
+		}
+		unreadByKey[selectedKey] = chatUnreadCount(store.Messages(selectedKey), readIncomingUpToByKey[selectedKey])
+		content.setUnread(totalUnread(unreadByKey))
+		if index := chatIndexByKey(chats, selectedKey); index >= 0 && chatList != nil {
+			chatList.RefreshItem(index)
+		}
+	}
+	messageFilterEntry := newShortcutEntry()
+	messageFilterEntry.SetPlaceHolder(i18n.T("Filter by sender or text"))
+	loadMessageView := func(chatKey string) chatMessageView {
+		return filterChatMessageView(
+			buildChatMessageView(store.Messages(chatKey), nodeNameByID, localNodeID),
+			messageFilterEntry.Text,
+			nodeNameByID,
+			localNodeID,
+		)
+	}
+	messageView := loadMessageView(selectedKey)
 	var messageList *widget.List
+	var messageSearch *chatMessageSearch
 	var chatTitle *widget.Label
-	var entry *widget.Entry
+	var entry *shortcutEntry
 	var tooltipManager *widgets.HoverTooltipManager
 	var replyLabel *widget.Label
 	var replyIndicator *fyne.Container
 	var sendStatusLabel *widget.Label
 	var refreshReplyIndicator func()
-	var ensureReplyShortcut func()
+	var applyComposerState func()
+	var refreshPinnedStrip func()
+	var ensureShortcuts func()
+	var sendCurrent func()
+	var resendMessage func(message domain.ChatMessage)
+	var confirmDeleteMessage func(message domain.ChatMessage)
 	pendingScrollChatKey := ""
 	pendingScrollMinCount := 0
 	replyToDeviceMessageID := ""
 	hoveredReplyTargetDeviceMessageID := ""
-	replyShortcutRegistered := false
+	shortcutsRegistered := false
 	pendingRequestedChatKey := ""
+	var resume chatResumeState
 	messageItemHeightByID := make(map[widget.ListItemID]float32)
 	messageItemWidthByID := make(map[widget.ListItemID]float32)
+	// Older history is loaded a page at a time once the first row is shown.
+	historyCompleteByKey := make(map[string]bool)
+	historyFailureByKey := make(map[string]olderHistoryFailure)
+	historyLoadingKey := ""
+	var loadOlderMessages func()
+	var jumpToLatestButton *widget.Button
 	clearSelectionOnRefresh := false
 
-	var chatList *widget.List
+	var onMessageFilterChanged func(string)
 	chatList = widget.NewList(
 		func() int { return len(chats) },
 		func() fyne.CanvasObject {
-			unreadLabel := widget.NewLabel("●")
 			titleLabel := widget.NewLabel("chat")
-			titleLabel.TextStyle = fyne.TextStyle{Bold: true}
+			unreadBadge := newChatUnreadBadge()
 			typeLabel := widget.NewLabel("type")
 			previewLabel := widget.NewLabel("preview")
+			accentBar := canvas.NewRectangle(color.Transparent)
+			accentBar.SetMinSize(fyne.NewSize(4, 0))
 
-			return newChatRowItem(container.NewVBox(
-				container.NewHBox(unreadLabel, titleLabel, layout.NewSpacer(), typeLabel),
+			return newChatRowItem(container.NewBorder(nil, nil, accentBar, nil, container.NewVBox(
+				container.NewHBox(titleLabel, layout.NewSpacer(), container.NewCenter(unreadBadge), typeLabel),
 				previewLabel,
-			))
+			)))
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
 			if id < 0 || id >= len(chats) {
@@ -132,12 +273,29 @@ func newChatsTab(
 				chatList.Select(id)
 			}
 			rowItem.onSecondary = func(position fyne.Position) {
-				showChatListContextMenu(canvasForObject(rowItem), position, chat, func(selected domain.Chat, action chatListAction) {
+				showChatListContextMenu(canvasForObject(rowItem), position, chat, taskbarFlash.menuState(chat.Key), setChatNotifications != nil, colors.picked(chat.Key), func(selected domain.Chat, action chatListAction) {
+					if prefs, ok := chatNotificationPrefsForAction(selected.Notifications, action, time.Now()); ok {
+						if err := setChatNotifications(selected.Key, prefs); err != nil {
+							chatsLogger.Warn("change chat notifications failed", "chat_key", selected.Key, "error", err)
+						}
+
+						return
+					}
 					switch action {
+					case chatListActionTaskbarFlash:
+						if state := taskbarFlash.menuState(selected.Key); state != nil {
+							if err := taskbarFlash.Set(selected.Key, !*state); err != nil {
+								chatsLogger.Warn("change chat taskbar flash failed", "chat_key", selected.Key, "error", err)
+							}
+						}
 					case chatListActionShare:
 						if onShareChannel != nil {
 							onShareChannel(selected)
 						}
+					case chatListActionExport:
+						showChatExportDialog(window, []domain.Chat{selected}, exportChats, nodeNameByID, localNodeID)
+					case chatListActionExportAll:
+						showChatExportDialog(window, store.ChatListSorted(), exportChats, nodeNameByID, localNodeID)
 					case chatListActionDelete:
 						if !domain.IsDMChat(selected) || onDeleteDMChat == nil {
 							return
@@ -152,8 +310,8 @@ func newChatsTab(
 							title = selected.Key
 						}
 						dialog.ShowConfirm(
-							"Delete DM chat?",
-							fmt.Sprintf("Delete local DM history for %s from this desktop app?", title),
+							i18n.T("Delete DM chat?"),
+							i18n.Tf("Delete local DM history for %s from this desktop app?\nIt can be restored from App → Maintenance → Recently deleted until it is purged.", title),
 							func(ok bool) {
 								if !ok {
 									return
@@ -172,22 +330,40 @@ func newChatsTab(
 							window,
 						)
 					}
+				}, func(selected domain.Chat, picked string) {
+					if err := colors.Set(selected.Key, picked); err != nil {
+						chatsLogger.Warn("change chat color failed", "chat_key", selected.Key, "error", err)
+
+						return
+					}
+					chatList.Refresh()
+					messageList.Refresh()
 				})
 			}
-			root := rowItem.content.(*fyne.Container)
+			outer := rowItem.content.(*fyne.Container)
+			root := outer.Objects[0].(*fyne.Container)
+			accentBar := outer.Objects[1].(*canvas.Rectangle)
+			if accent := chatAccentColor(colors.colorName(chat)); accent != nil {
+				accentBar.FillColor = accent
+			} else {
+				accentBar.FillColor = color.Transparent
+			}
+			accentBar.Refresh()
 			line1 := root.Objects[0].(*fyne.Container)
-			unreadLabel := line1.Objects[0].(*widget.Label)
-			titleLabel := line1.Objects[1].(*widget.Label)
+			titleLabel := line1.Objects[0].(*widget.Label)
+			unreadBadge := line1.Objects[2].(*fyne.Container).Objects[0].(*chatUnreadBadge)
 			typeLabel := line1.Objects[3].(*widget.Label)
 			previewLabel := root.Objects[1].(*widget.Label)
 
-			unreadLabel.SetText(chatUnreadMarker(unreadByKey[chat.Key]))
+			unread := unreadByKey[chat.Key]
+			unreadBadge.SetCount(unread)
+			titleLabel.TextStyle = fyne.TextStyle{Bold: unread > 0}
 			titleLabel.SetText(chatDisplayTitle(chat, nodeNameByID))
-			typeLabel.SetText(chatTypeLabel(chat))
+			typeLabel.SetText(chatTypeLabel(chat) + chatMutedMarker(chat, time.Now()))
 			if preview, ok := previewsByKey[chat.Key]; ok {
 				previewLabel.SetText(preview)
 			} else {
-				previewLabel.SetText("No messages yet")
+				previewLabel.SetText(i18n.T("No messages yet"))
 			}
 		},
 	)
@@ -203,26 +379,36 @@ func newChatsTab(
 		)
 		tooltipManager.Hide(nil)
 		selectedKey = chats[id].Key
-		markChatRead(store, readIncomingUpToByKey, selectedKey)
-		unreadByKey = chatUnreadByKey(store, chats, readIncomingUpToByKey)
 		if onChatSelected != nil {
 			onChatSelected(selectedKey)
 		}
-		messageView = buildChatMessageView(store.Messages(selectedKey), nodeNameByID, localNodeID)
+		// The filter belongs to the chat it was typed in.
+		messageFilterEntry.OnChanged = nil
+		messageFilterEntry.SetText("")
+		messageFilterEntry.OnChanged = onMessageFilterChanged
+		messageSearch.Reset()
+		messageView = loadMessageView(selectedKey)
 		replyToDeviceMessageID = ""
 		hoveredReplyTargetDeviceMessageID = ""
 		clear(messageItemHeightByID)
 		clear(messageItemWidthByID)
 		refreshReplyIndicator()
+		refreshPinnedStrip()
+		// Scroll before the rows are rebound, so only rows shown at the final position
+		// are marked as read. The chat opens at its oldest unread message.
+		if index := firstUnreadMessageIndex(messageView.Timeline, readIncomingUpToByKey[selectedKey]); index >= 0 {
+			messageList.ScrollTo(index)
+		} else {
+			scrollMessageListToEnd(messageList, len(messageView.Timeline))
+		}
 		chatList.Refresh()
 		messageList.Refresh()
 		chatTitle.SetText(chatDisplayTitle(chats[id], nodeNameByID))
-		scrollMessageListToEnd(messageList, len(messageView.Timeline))
-		ensureReplyShortcut()
+		ensureShortcuts()
 		focusEntry(entry)
 	}
 
-	chatTitle = widget.NewLabel("No chat selected")
+	chatTitle = widget.NewLabel(i18n.T("No chat selected"))
 	if selectedKey != "" {
 		chatTitle.SetText(chatTitleByKey(chats, selectedKey, nodeNameByID))
 	}
@@ -234,6 +420,10 @@ func newChatsTab(
 		if replyIndicator == nil || replyLabel == nil {
 			return
 		}
+		if applyComposerState != nil {
+			// The reply ID takes part of the byte budget.
+			defer applyComposerState()
+		}
 		replyID := strings.TrimSpace(replyToDeviceMessageID)
 		if replyID == "" {
 			replyIndicator.Hide()
@@ -242,18 +432,18 @@ func newChatsTab(
 
 			return
 		}
-		replyLabel.SetText("Replying to message: original message unavailable")
+		replyLabel.SetText(i18n.T("Replying to message: original message unavailable"))
 		if original, ok := messageView.ByDeviceID[replyID]; ok {
 			meta, hasMeta := parseMessageMeta(original.MetaJSON)
 			sender, _, hasSender := messageTextParts(*original, meta, hasMeta, nodeNameByID, localNodeID)
 			body := compactWhitespace(original.Body)
 			if body == "" {
-				body = "(empty)"
+				body = i18n.T("(empty)")
 			}
 			if hasSender {
-				replyLabel.SetText(fmt.Sprintf("Replying to %s: %s", sender, body))
+				replyLabel.SetText(i18n.Tf("Replying to %s: %s", sender, body))
 			} else {
-				replyLabel.SetText(fmt.Sprintf("Replying to message: %s", body))
+				replyLabel.SetText(i18n.Tf("Replying to message: %s", body))
 			}
 		}
 		replyIndicator.Show()
@@ -268,7 +458,7 @@ func newChatsTab(
 	setReplyTarget := func(message *domain.ChatMessage) bool {
 		if message == nil || !canReplyToMessage(*message) {
 			if sendStatusLabel != nil {
-				sendStatusLabel.SetText("Reply unavailable for this message")
+				sendStatusLabel.SetText(i18n.T("Reply unavailable for this message"))
 			}
 
 			return false
@@ -296,19 +486,96 @@ func newChatsTab(
 		}
 	}
 
-	ensureReplyShortcut = func() {
-		if replyShortcutRegistered || entry == nil {
+	ensureShortcuts = func() {
+		if shortcutsRegistered || entry == nil {
 			return
 		}
-		fyneCanvas := canvasForObject(entry)
-		if fyneCanvas == nil {
+		shortcuts := shortcutsForCanvas(canvasForObject(entry))
+		if shortcuts == nil {
 			return
 		}
-		shortcut := &desktop.CustomShortcut{KeyName: fyne.KeyR, Modifier: fyne.KeyModifierControl}
-		fyneCanvas.AddShortcut(shortcut, func(fyne.Shortcut) {
+		shortcuts.Handle(shortcutReply, func() bool {
+			if content == nil || !content.Visible() {
+				return false
+			}
 			replyFromShortcut()
+
+			return true
+		})
+		shortcuts.Handle(shortcutSend, func() bool {
+			if content == nil || !content.Visible() || entry.Disabled() {
+				return false
+			}
+			sendCurrent()
+
+			return true
 		})
-		replyShortcutRegistered = true
+		shortcutsRegistered = true
+	}
+
+	saveAnnotation := func(message domain.ChatMessage, next domain.MessageAnnotation) {
+		if !annotations.enabled() {
+			return
+		}
+		next.ChatKey = message.ChatKey
+		next.DeviceMessageID = message.DeviceMessageID
+		if err := annotations.Save(next); err != nil {
+			chatsLogger.Warn(
+				"save message annotation failed",
+				"chat_key", message.ChatKey,
+				"device_message_id", message.DeviceMessageID,
+				"error", err,
+			)
+			sendStatusLabel.SetText(i18n.Tf("Saving message tags failed: %s", err))
+			reportError.report(i18n.T("Message tags not saved"), err, chatsLogComponent)
+
+			return
+		}
+		key := messageAnnotationKey(message.ChatKey, message.DeviceMessageID)
+		next.Tags = domain.NormalizeTags(next.Tags)
+		if next.IsEmpty() {
+			delete(annotationsByKey, key)
+		} else {
+			annotationsByKey[key] = next
+		}
+		clear(messageItemHeightByID)
+		clear(messageItemWidthByID)
+		messageList.Refresh()
+	}
+
+	togglePin := func(message domain.ChatMessage) {
+		if !pins.enabled() {
+			return
+		}
+		key := messageAnnotationKey(message.ChatKey, message.DeviceMessageID)
+		_, pinned := pinsByKey[key]
+		var err error
+		if pinned {
+			err = pins.Unpin(message.ChatKey, message.DeviceMessageID)
+		} else {
+			err = pins.Pin(message.ChatKey, message.DeviceMessageID)
+		}
+		if err != nil {
+			chatsLogger.Warn(
+				"toggle message pin failed",
+				"chat_key", message.ChatKey,
+				"device_message_id", message.DeviceMessageID,
+				"error", err,
+			)
+			sendStatusLabel.SetText(i18n.Tf("Pinning message failed: %s", err))
+			reportError.report(i18n.T("Message pin not saved"), err, chatsLogComponent)
+
+			return
+		}
+		if pinned {
+			delete(pinsByKey, key)
+		} else {
+			pinsByKey[key] = domain.MessagePin{ChatKey: message.ChatKey, DeviceMessageID: message.DeviceMessageID, PinnedAt: time.Now()}
+		}
+		refreshPinnedStrip()
+		clear(messageItemHeightByID)
+		clear(messageItemWidthByID)
+		messageList.Refresh()
 	}
 
 	messageList = widget.NewList(
@@ -331,13 +598,15 @@ func newChatsTab(
 			quoteLine.Hide()
 
 			transportBadge := widgets.NewTooltipLabel("", "", tooltipManager)
+			annotationLabel := widget.NewLabel("")
+			annotationLabel.Hide()
 			messageText := widget.NewRichTextWithText("message")
 			messageText.Wrapping = fyne.TextWrapWord
 			messageLine := container.NewBorder(
 				nil,
 				nil,
 				nil,
-				container.NewHBox(horizontalSpacer(theme.Padding()), transportBadge, horizontalSpacer(theme.Padding())),
+				container.NewHBox(horizontalSpacer(theme.Padding()), transportBadge, horizontalSpacer(theme.Padding()), annotationLabel),
 				messageText,
 			)
 			metaParts := container.NewHBox(widget.NewRichTextWithText("meta"))
@@ -359,7 +628,10 @@ func newChatsTab(
 			bubbleBg.CornerRadius = 10
 			bubble := container.NewStack(bubbleBg, container.NewPadded(row))
 
-			return newChatMessageRowItem(container.New(chatlayout.NewChatRowLayout(false), bubble))
+			daySeparator := widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+			daySeparator.Hide()
+
+			return newChatMessageRowItem(container.New(chatlayout.NewChatRowLayout(false), bubble, daySeparator))
 		},
 		func(id widget.ListItemID, obj fyne.CanvasObject) {
 			if id < 0 || id >= len(messageView.Timeline) {
@@ -372,14 +644,68 @@ func newChatsTab(
 				return
 			}
 			message := msg
+			markMessageSeen(msg)
+			if id == 0 {
+				loadOlderMessages()
+			}
+			// Rows are bound as they scroll into view, so the last bound row tells
+			// whether the newest message is on screen.
+			if jumpToLatestButton != nil && jumpToLatestVisible(id, len(messageView.Timeline)) != jumpToLatestButton.Visible() {
+				if jumpToLatestButton.Visible() {
+					jumpToLatestButton.Hide()
+				} else {
+					jumpToLatestButton.Show()
+				}
+			}
+			annotation := annotationsByKey[messageAnnotationKey(msg.ChatKey, msg.DeviceMessageID)]
+			_, pinned := pinsByKey[messageAnnotationKey(msg.ChatKey, msg.DeviceMessageID)]
 			rowItem.onSecondary = func(position fyne.Position) {
 				fyneCanvas := canvasForObject(rowItem)
-				showChatMessageContextMenu(fyneCanvas, position, message, func(message domain.ChatMessage, action ChatAction) {
+				var menuAnnotation *domain.MessageAnnotation
+				if annotations.enabled() {
+					menuAnnotation = &annotation
+				}
+				var menuPinned *bool
+				if pins.enabled() {
+					menuPinned = &pinned
+				}
+				senderID := messageSenderID(message, meta, hasMeta, localNodeID)
+				showChatMessageContextMenu(fyneCanvas, position, message, menuAnnotation, menuPinned, senderID, history.Delete != nil, func(message domain.ChatMessage, action ChatAction) {
 					switch action {
 					case ChatActionReply:
 						_ = setReplyTarget(&message)
+					case ChatActionQuote:
+						if canReplyToMessage(message) {
+							_ = setReplyTarget(&message)
+						}
+						entry.SetText(insertReferenceText(entry.Text, quoteMessageText(message.Body)))
+						focusEntry(entry)
+					case ChatActionCopyText:
+						if err := copyTextToClipboard(message.Body); err != nil {
+							chatsLogger.Warn("copy message text failed", "error", err)
+						}
+					case ChatActionCopySender:
+						if err := copyTextToClipboard(senderID); err != nil {
+							chatsLogger.Warn("copy message sender failed", "error", err)
+						}
+					case ChatActionResend:
+						resendMessage(message)
+					case ChatActionDelete:
+						confirmDeleteMessage(message)
 					case ChatActionReact:
 						openReactionPicker(fyneCanvas, rowItem, message, sender, sendStatusLabel, chatsLogger)
+					case ChatActionStar:
+						next := annotation
+						next.Starred = !next.Starred
+						saveAnnotation(message, next)
+					case ChatActionEditTags:
+						showMessageTagsDialog(window, annotation, func(tags []string) {
+							next := annotation
+							next.Tags = tags
+							saveAnnotation(message, next)
+						})
+					case ChatActionPin:
+						togglePin(message)
 					}
 				})
 			}
@@ -403,9 +729,20 @@ func newChatsTab(
 			if ok {
 				rowLayout.SetAlignRight(msg.Direction == domain.MessageDirectionOut)
 			}
+			daySeparator := rowContainer.Objects[1].(*widget.Label)
+			if text := messageDaySeparatorText(messageView.Timeline, id, time.Now()); text != "" {
+				daySeparator.SetText(text)
+				daySeparator.Show()
+			} else {
+				daySeparator.Hide()
+			}
 			bubble := rowContainer.Objects[0].(*fyne.Container)
 			bubbleBg := bubble.Objects[0].(*canvas.Rectangle)
 			bubbleBg.FillColor = chatBubbleFillColor(msg.Direction)
+			if chat, ok := store.ChatByKey(msg.ChatKey); ok && msg.Direction != domain.MessageDirectionOut {
+				bubbleBg.FillColor = chatAccentBubbleFill(bubbleBg.FillColor, chatAccentColor(colors.colorName(chat)))
+			}
+			bubbleBg.StrokeColor, bubbleBg.StrokeWidth = chatSearchBubbleStroke(messageSearch.IsMatch(msg))
 			bubbleBg.Refresh()
 			box := bubble.Objects[1].(*fyne.Container).Objects[0].(*fyne.Container)
 			quoteLine := box.Objects[0].(*fyne.Container)
@@ -416,7 +753,7 @@ func newChatsTab(
 					quoteText.Segments = quoteSegments(*original, nodeNameByID, localNodeID)
 				} else {
 					quoteText.Segments = []widget.RichTextSegment{
-						&widget.TextSegment{Text: "Original message unavailable", Style: widget.RichTextStyleInline},
+						&widget.TextSegment{Text: i18n.T("Original message unavailable"), Style: widget.RichTextStyleInline},
 					}
 				}
 				quoteText.Refresh()
@@ -432,6 +769,18 @@ func newChatsTab(
 			messageText.Wrapping = fyne.TextWrapWord
 			messageText.Refresh()
 			transportBadge.SetBadge(messageTransportBadge(msg, meta, hasMeta))
+			annotationLabel := transportSlot.Objects[3].(*widget.Label)
+			badge := messageAnnotationBadge(annotation)
+			if pinned {
+				badge = strings.TrimSpace("📌 " + badge)
+			}
+			if badge != "" {
+				annotationLabel.SetText(badge)
+				annotationLabel.Show()
+			} else {
+				annotationLabel.SetText("")
+				annotationLabel.Hide()
+			}
 			metaRow := box.Objects[2].(*fyne.Container)
 			metaParts := metaRow.Objects[0].(*fyne.Container)
 			widgets.HideTooltipWidgets(metaParts.Objects)
@@ -483,15 +832,17 @@ func newChatsTab(
 		},
 	)
 
-	entry = widget.NewEntry()
-	entry.SetPlaceHolder("Type message (max 200 bytes)")
-	counterLabel := widget.NewLabel("0/200 bytes")
+	entry = newShortcutEntry()
+	entry.SetPlaceHolder(i18n.T("Type message"))
+	counterLabel := widgets.NewTooltipLabel("", "", tooltipManager)
 	sendStatusLabel = widget.NewLabel("")
 	sendStatusLabel.Truncation = fyne.TextTruncateEllipsis
-	sendButton := widget.NewButton("Send", nil)
+	sendButton := widget.NewButton(i18n.T("Send"), nil)
+	splitSendButton := widget.NewButton("", nil)
+	splitSendButton.Hide()
 	replyLabel = widget.NewLabel("")
 	replyLabel.Truncation = fyne.TextTruncateEllipsis
-	replyCancelButton := widget.NewButton("Cancel", func() {
+	replyCancelButton := widget.NewButton(i18n.T("Cancel"), func() {
 		clearReplyTarget()
 		focusEntry(entry)
 	})
@@ -504,23 +855,48 @@ func newChatsTab(
 	)
 	replyIndicator.Hide()
 
-	updateCounter := func(text string) {
-		compactCyrillic := compactCyrillicEncodingEnabled != nil && compactCyrillicEncodingEnabled()
-		prepared := prepareOutgoingText(text, compactCyrillic)
-		counterLabel.SetText(fmt.Sprintf("%d/200 bytes", prepared.byteCount))
-	}
-	entry.OnChanged = updateCounter
 	isSending := false
+	var referenceButton *widget.Button
+
+	composeLimitFor := func(chatKey string) composeLimit {
+		return composeTextLimit(chatKey, strings.TrimSpace(replyToDeviceMessageID) != "")
+	}
+
+	applyComposerState = func() {
+		compactCyrillic := compactCyrillicEncodingEnabled != nil && compactCyrillicEncodingEnabled()
+		prepared := prepareOutgoingText(entry.Text, compactCyrillic)
+		limit := composeLimitFor(selectedKey)
+		overLimit := prepared.byteCount > limit.Bytes
+		counterLabel.SetBadge(composeCounterText(prepared.byteCount, limit), composeLimitTooltip(prepared.byteCount, limit))
+		if overLimit {
+			counterLabel.SetImportance(widget.DangerImportance)
+		} else {
+			counterLabel.SetImportance(widget.MediumImportance)
+		}
 
-	applyComposerState := func() {
 		canSend := !isSending && selectedKey != "" && sender != nil
 		if canSend {
 			entry.Enable()
-			sendButton.Enable()
+			referenceButton.Enable()
 		} else {
 			entry.Disable()
+			referenceButton.Disable()
+		}
+		if canSend && !overLimit {
+			sendButton.Enable()
+		} else {
 			sendButton.Disable()
 		}
+		// A message over the limit can still go out as numbered parts.
+		if parts := domain.SplitMessageParts(prepared.body, limit.Bytes); canSend && overLimit && parts != nil {
+			splitSendButton.SetText(i18n.Tf("Send as %d parts", len(parts)))
+			splitSendButton.Show()
+		} else {
+			splitSendButton.Hide()
+		}
+	}
+	entry.OnChanged = func(string) {
+		applyComposerState()
 	}
 
 	setSending := func(inFlight bool) {
@@ -528,7 +904,7 @@ func newChatsTab(
 		applyComposerState()
 	}
 
-	sendCurrent := func() {
+	sendCurrent = func() {
 		compactCyrillic := compactCyrillicEncodingEnabled != nil && compactCyrillicEncodingEnabled()
 		prepared := prepareOutgoingText(entry.Text, compactCyrillic)
 		if selectedKey == "" {
@@ -541,8 +917,9 @@ func newChatsTab(
 
 			return
 		}
-		if prepared.byteCount > maxTextMessageBytes {
-			chatsLogger.Info("send blocked: message exceeds 200 bytes", "chat_key", selectedKey, "bytes", prepared.byteCount)
+		if limit := composeLimitFor(selectedKey); prepared.byteCount > limit.Bytes {
+			chatsLogger.Info("send blocked: message exceeds the byte limit", "chat_key", selectedKey, "bytes", prepared.byteCount, "limit", limit.Bytes)
+			sendStatusLabel.SetText(i18n.Tf("Message is %d bytes over the limit, send it in parts instead", prepared.byteCount-limit.Bytes))
 
 			return
 		}
@@ -562,7 +939,8 @@ func newChatsTab(
 						pendingScrollChatKey = ""
 						pendingScrollMinCount = 0
 					}
-					sendStatusLabel.SetText("Send failed: " + res.Err.Error())
+					sendStatusLabel.SetText(i18n.Tf("Send failed: %s", res.Err.Error()))
+					reportError.report(i18n.T("Message not sent"), res.Err, chatsLogComponent)
 					setSending(false)
 				})
 
@@ -578,27 +956,368 @@ func newChatsTab(
 		}(selectedKey, prepared.body, opts)
 	}
 
+	// sendSplit sends a message over the limit as numbered parts, one after another.
+	// Senders with a queue space the parts out; only the first part is a reply.
+	sendSplit := func() {
+		compactCyrillic := compactCyrillicEncodingEnabled != nil && compactCyrillicEncodingEnabled()
+		prepared := prepareOutgoingText(entry.Text, compactCyrillic)
+		if selectedKey == "" || sender == nil {
+			return
+		}
+		parts := domain.SplitMessageParts(prepared.body, composeLimitFor(selectedKey).Bytes)
+		if len(parts) == 0 {
+			chatsLogger.Debug("split send ignored: message cannot be split", "chat_key", selectedKey, "bytes", prepared.byteCount)
+
+			return
+		}
+
+		chatsLogger.Info("sending chat message in parts", "chat_key", selectedKey, "bytes", prepared.byteCount, "parts", len(parts))
+		pendingScrollChatKey = selectedKey
+		pendingScrollMinCount = len(messageView.Timeline) + 1
+		sendStatusLabel.SetText("")
+		replyID := strings.TrimSpace(replyToDeviceMessageID)
+		setSending(true)
+		go func(chatKey string) {
+			for i, part := range parts {
+				opts := radio.TextSendOptions{}
+				if i == 0 {
+					opts.ReplyToDeviceMessageID = replyID
+				}
+				var res radio.SendResult
+				if queue, ok := sender.(queuedTextSender); ok {
+					res = <-queue.QueueText(chatKey, part, opts)
+				} else {
+					res = <-sender.SendText(chatKey, part, opts)
+				}
+				if res.Err != nil {
+					fyne.Do(func() {
+						chatsLogger.Warn("chat message part send failed", "chat_key", chatKey, "part", i+1, "parts", len(parts), "error", res.Err)
+						if pendingScrollChatKey == chatKey {
+							pendingScrollChatKey = ""
+							pendingScrollMinCount = 0
+						}
+						sendStatusLabel.SetText(i18n.Tf("Send failed at part %d/%d: %s", i+1, len(parts), res.Err.Error()))
+						reportError.report(i18n.Tf("Message part %d/%d not sent", i+1, len(parts)), res.Err, chatsLogComponent)
+						setSending(false)
+					})
+
+					return
+				}
+			}
+			fyne.Do(func() {
+				chatsLogger.Info("chat message parts sent", "chat_key", chatKey, "parts", len(parts))
+				sendStatusLabel.SetText("")
+				entry.SetText("")
+				clearReplyTarget()
+				setSending(false)
+			})
+		}(selectedKey)
+	}
+
+	// A failed message is sent again as a new one; the failed copy is then deleted
+	// when local deletes are available.
+	resendMessage = func(message domain.ChatMessage) {
+		if sender == nil || !canResendMessage(message) {
+			return
+		}
+		chatsLogger.Info("resending chat message", "chat_key", message.ChatKey, "device_message_id", message.DeviceMessageID)
+		sendStatusLabel.SetText("")
+		opts := radio.TextSendOptions{ReplyToDeviceMessageID: strings.TrimSpace(message.ReplyToDeviceMessageID)}
+		go func() {
+			res := <-sender.SendText(message.ChatKey, message.Body, opts)
+			fyne.Do(func() {
+				if res.Err != nil {
+					chatsLogger.Warn("chat message resend failed", "chat_key", message.ChatKey, "error", res.Err)
+					sendStatusLabel.SetText(i18n.Tf("Send failed: %s", res.Err.Error()))
+					reportError.report(i18n.T("Message not sent"), res.Err, chatsLogComponent)
+
+					return
+				}
+				if history.Delete == nil {
+					return
+				}
+				if err := history.Delete(message); err != nil {
+					chatsLogger.Warn("delete resent message failed", "chat_key", message.ChatKey, "error", err)
+				}
+			})
+		}()
+	}
+	confirmDeleteMessage = func(message domain.ChatMessage) {
+		if history.Delete == nil {
+			return
+		}
+		if window == nil {
+			chatsLogger.Warn("delete message failed: active window unavailable", "chat_key", message.ChatKey)
+
+			return
+		}
+		dialog.ShowConfirm(
+			i18n.T("Delete message?"),
+			i18n.T("Delete this message from this desktop app?\nOther nodes keep their copy."),
+			func(ok bool) {
+				if !ok {
+					return
+				}
+				if err := history.Delete(message); err != nil {
+					chatsLogger.Warn("delete message failed", "chat_key", message.ChatKey, "error", err)
+					dialog.ShowError(err, window)
+				}
+			},
+			window,
+		)
+	}
+
 	entry.OnSubmitted = func(_ string) { sendCurrent() }
 	sendButton.OnTapped = sendCurrent
+	splitSendButton.OnTapped = sendSplit
+
+	// sendToSelected sends something other than the composer text to the selected chat.
+	sendToSelected := func(kind string, send func(chatKey string) <-chan radio.SendResult) {
+		if selectedKey == "" {
+			return
+		}
+		chatsLogger.Info("sending "+kind, "chat_key", selectedKey)
+		pendingScrollChatKey = selectedKey
+		pendingScrollMinCount = len(messageView.Timeline) + 1
+		sendStatusLabel.SetText("")
+		setSending(true)
+		go func(chatKey string) {
+			res := <-send(chatKey)
+			fyne.Do(func() {
+				if res.Err != nil {
+					chatsLogger.Warn(kind+" send failed", "chat_key", chatKey, "error", res.Err)
+					if pendingScrollChatKey == chatKey {
+						pendingScrollChatKey = ""
+						pendingScrollMinCount = 0
+					}
+					sendStatusLabel.SetText(i18n.Tf("Send failed: %s", res.Err.Error()))
+					reportError.report(i18n.T("Message not sent"), res.Err, chatsLogComponent)
+				}
+				setSending(false)
+			})
+		}(selectedKey)
+	}
+	var sendWaypoint func(waypoint domain.Waypoint)
+	if waypoints, ok := sender.(waypointSender); ok {
+		sendWaypoint = func(waypoint domain.Waypoint) {
+			sendToSelected("waypoint", func(chatKey string) <-chan radio.SendResult {
+				return waypoints.SendWaypoint(chatKey, waypoint)
+			})
+		}
+	}
+	// A location shared from the map goes to the selected chat right away.
+	shareLocation := func(location sharedLocation) {
+		if selectedKey == "" || sender == nil {
+			dialog.ShowInformation(i18n.T("Share location"), i18n.T("Open a chat to share the location to."), window)
+
+			return
+		}
+		showShareLocationDialog(locationShareComposer{
+			window:       window,
+			chatTitle:    chatTitleByKey(chats, selectedKey, nodeNameByID),
+			sendWaypoint: sendWaypoint,
+			sendText: func(text string) {
+				sendToSelected("location", func(chatKey string) <-chan radio.SendResult {
+					return sender.SendText(chatKey, text, radio.TextSendOptions{})
+				})
+			},
+		}, location)
+	}
+	referenceButton = newChatReferenceButton(chatReferenceComposer{
+		window: window,
+		source: references,
+		insert: func(text string) {
+			entry.SetText(insertReferenceText(entry.Text, text))
+			focusEntry(entry)
+		},
+		sendWaypoint: sendWaypoint,
+	})
+
+	onMessageFilterChanged = func(string) {
+		tooltipManager.Hide(nil)
+		messageView = loadMessageView(selectedKey)
+		hoveredReplyTargetDeviceMessageID = ""
+		clear(messageItemHeightByID)
+		clear(messageItemWidthByID)
+		messageList.Refresh()
+		scrollMessageListToEnd(messageList, len(messageView.Timeline))
+	}
+	messageFilterEntry.OnChanged = onMessageFilterChanged
 
-	composer := container.NewBorder(nil, nil, nil, sendButton, entry)
+	composer := container.NewBorder(nil, nil, referenceButton, container.NewHBox(splitSendButton, sendButton), entry)
 	composerStatusRow := container.NewHBox(counterLabel, layout.NewSpacer(), sendStatusLabel)
+	var openRequestedChat func(chatKey string)
+	annotatedMessagesButton := widget.NewButton("★", func() {
+		showAnnotatedMessagesDialog(
+			window,
+			annotations.ListAnnotated,
+			func(chatKey string) string { return chatTitleByKey(chats, chatKey, nodeNameByID) },
+			nodeNameByID,
+			localNodeID,
+			func(chatKey string) { openRequestedChat(chatKey) },
+		)
+	})
+	if annotations.ListAnnotated == nil {
+		annotatedMessagesButton.Hide()
+	}
+	pinnedStrip := newPinnedMessagesStrip(
+		nodeNameByID,
+		func(message domain.ChatMessage) {
+			index := messageTimelineIndex(messageView.Timeline, message.DeviceMessageID)
+			if index < 0 && messageFilterEntry.Text != "" {
+				// The pinned message is hidden by the filter.
+				messageFilterEntry.SetText("")
+				index = messageTimelineIndex(messageView.Timeline, message.DeviceMessageID)
+			}
+			if index >= 0 {
+				messageList.ScrollTo(index)
+			}
+		},
+		togglePin,
+	)
+	refreshPinnedStrip = func() {
+		pinnedStrip.SetMessages(pinnedChatMessages(pinsByKey, store.Messages(selectedKey)))
+	}
+	refreshPinnedStrip()
+	loadOlderMessages = func() {
+		chatKey := selectedKey
+		if history.LoadOlder == nil || chatKey == "" || historyCompleteByKey[chatKey] || historyLoadingKey != "" ||
+			historyFailureByKey[chatKey].waiting(time.Now()) {
+			return
+		}
+		historyLoadingKey = chatKey
+		go func() {
+			added, err := history.LoadOlder(chatKey)
+			fyne.Do(func() {
+				historyLoadingKey = ""
+				if err != nil {
+					failure := historyFailureByKey[chatKey].next(time.Now())
+					historyFailureByKey[chatKey] = failure
+					chatsLogger.Warn("load older messages failed", "chat_key", chatKey, "attempt", failure.count, "error", err)
+
+					return
+				}
+				delete(historyFailureByKey, chatKey)
+				if added == 0 {
+					historyCompleteByKey[chatKey] = true
+
+					return
+				}
+				if chatKey != selectedKey || len(messageView.Timeline) == 0 {
+					return
+				}
+				// Keep the rows on screen in place: shift the measured heights along with
+				// their rows and scroll down by the height of the inserted rows.
+				firstShown := messageView.Timeline[0]
+				offset := messageList.GetScrollOffset()
+				messageView = loadMessageView(selectedKey)
+				shift := messageTimelineIndexOf(messageView.Timeline, firstShown)
+				if shift <= 0 {
+					messageList.Refresh()
+
+					return
+				}
+				rowHeight := estimatedMessageRowHeight(messageItemHeightByID)
+				messageItemHeightByID = shiftedMessageItemHeights(messageItemHeightByID, shift)
+				messageItemWidthByID = shiftedMessageItemHeights(messageItemWidthByID, shift)
+				for id := range len(messageView.Timeline) {
+					height, ok := messageItemHeightByID[id]
+					if !ok {
+						height = rowHeight
+					}
+					messageList.SetItemHeight(id, height)
+				}
+				messageList.Refresh()
+				messageList.ScrollToOffset(prependedHistoryOffset(offset, shift, rowHeight, theme.Padding()))
+				chatsLogger.Debug("older messages prepended", "chat_key", chatKey, "rows", shift)
+			})
+		}()
+	}
+	messageSearch = newChatMessageSearch(
+		history.Search,
+		func() string { return selectedKey },
+		func(match domain.ChatMessage) bool {
+			index := messageTimelineIndexOf(messageView.Timeline, match)
+			if index < 0 && messageFilterEntry.Text != "" {
+				// The match is hidden by the filter.
+				messageFilterEntry.SetText("")
+				index = messageTimelineIndexOf(messageView.Timeline, match)
+			}
+			if index < 0 {
+				return false
+			}
+			messageList.ScrollTo(index)
+
+			return true
+		},
+		func() {
+			messageList.Refresh()
+		},
+		func(err error) {
+			sendStatusLabel.SetText(i18n.Tf("Search failed: %s", err.Error()))
+		},
+	)
+	searchButton := widget.NewButtonWithIcon("", theme.SearchIcon(), messageSearch.Open)
+	if !messageSearch.enabled() {
+		searchButton.Hide()
+	}
+	jumpToLatestButton = widget.NewButtonWithIcon(i18n.T("Jump to latest"), theme.MoveDownIcon(), func() {
+		scrollMessageListToEnd(messageList, len(messageView.Timeline))
+		jumpToLatestButton.Hide()
+	})
+	jumpToLatestButton.Importance = widget.HighImportance
+	jumpToLatestButton.Hide()
 	right := container.NewBorder(
-		chatTitle,
+		container.NewVBox(
+			container.NewBorder(nil, nil, chatTitle, container.NewHBox(searchButton, annotatedMessagesButton), messageFilterEntry),
+			messageSearch.content,
+			pinnedStrip.content,
+		),
 		container.NewVBox(replyIndicator, composerStatusRow, composer),
 		nil,
 		nil,
-		messageList,
+		container.NewStack(
+			messageList,
+			container.NewVBox(layout.NewSpacer(), container.NewHBox(layout.NewSpacer(), jumpToLatestButton, horizontalSpacer(theme.Padding()*4))),
+		),
 	)
 
+	var refreshFromStore func()
+	chatListSummary := widget.NewLabel("")
+	chatListSummary.Truncation = fyne.TextTruncateEllipsis
+	refreshChatListSummary := func() {
+		chatListSummary.SetText(chatListFilterLabel(chatListPrefs.Filter) + " · " + chatListSortLabel(chatListPrefs.Sort))
+	}
+	refreshChatListSummary()
+	var chatListOptionsButton *widget.Button
+	chatListOptionsButton = widget.NewButtonWithIcon("", theme.ListIcon(), func() {
+		menu := newChatListOptionsMenu(chatListPrefs, func(next config.ChatListConfig) {
+			chatListPrefs = next
+			refreshChatListSummary()
+			if listPrefs.Set != nil {
+				if err := listPrefs.Set(next); err != nil {
+					chatsLogger.Warn("save chat list preferences failed", "error", err)
+					reportError.report(i18n.T("Chat list options not saved"), err, chatsLogComponent)
+				}
+			}
+			refreshFromStore()
+		})
+		widget.ShowPopUpMenuAtRelativePosition(menu, canvasForObject(chatListOptionsButton), fyne.NewPos(0, chatListOptionsButton.Size().Height), chatListOptionsButton)
+	})
+
 	split := container.NewHSplit(
-		container.NewBorder(nil, nil, nil, nil, chatList),
+		container.NewBorder(
+			container.NewBorder(nil, nil, nil, chatListOptionsButton, chatListSummary),
+			nil,
+			nil,
+			nil,
+			chatList,
+		),
 		right,
 	)
 	split.Offset = 0.32
 
-	var refreshFromStore func()
-	openRequestedChat := func(chatKey string) {
+	openRequestedChat = func(chatKey string) {
 		requested := strings.TrimSpace(chatKey)
 		if requested == "" {
 			return
@@ -622,13 +1341,44 @@ func newChatsTab(
 			"selected_chat", selectedKey,
 			"chat_count", len(chats),
 		)
-		updatedChats := store.ChatListSorted()
+		allChats := store.ChatListSorted()
+		if selectedKey != "" && len(allChats) == 0 && !clearSelectionOnRefresh && !resume.pending() {
+			// The store is emptied before it is reloaded, e.g. after a reconnect.
+			resume = chatResumeState{
+				ChatKey:                selectedKey,
+				Draft:                  entry.Text,
+				ReplyToDeviceMessageID: replyToDeviceMessageID,
+				ScrollOffset:           messageList.GetScrollOffset(),
+			}
+			chatsLogger.Debug("chat store emptied, keeping open chat to resume", "chat_key", selectedKey)
+		}
+		pruneReadIncomingByChat(readIncomingUpToByKey, allChats)
+		seedReadIncomingByChat(readIncomingUpToByKey, allChats)
+		updatedUnreadByKey := chatUnreadCountByKey(store, allChats, readIncomingUpToByKey)
+		updatedChats := arrangeChatList(
+			allChats,
+			chatListPrefs,
+			updatedUnreadByKey,
+			chatTitleOf,
+			selectedKey,
+			strings.TrimSpace(pendingRequestedChatKey),
+			resume.ChatKey,
+		)
 		nextSelectedKey := selectedKey
 		requestedChatKey := strings.TrimSpace(pendingRequestedChatKey)
+		resuming := false
 		switch {
 		case requestedChatKey != "" && hasChat(updatedChats, requestedChatKey):
 			nextSelectedKey = requestedChatKey
 			pendingRequestedChatKey = ""
+			resume = chatResumeState{}
+		case resume.restorableIn(updatedChats):
+			nextSelectedKey = resume.ChatKey
+			resuming = true
+		case resume.pending() && len(updatedChats) > 0:
+			chatsLogger.Debug("chat to resume is gone after store reload", "chat_key", resume.ChatKey)
+			resume = chatResumeState{}
+			nextSelectedKey = updatedChats[0].Key
 		case clearSelectionOnRefresh:
 			nextSelectedKey = ""
 			clearSelectionOnRefresh = false
@@ -643,7 +1393,7 @@ func newChatsTab(
 			}
 			clearSelectionOnRefresh = false
 		}
-		updatedView := buildChatMessageView(store.Messages(nextSelectedKey), nodeNameByID, localNodeID)
+		updatedView := loadMessageView(nextSelectedKey)
 		if slices.Equal(chats, updatedChats) &&
 			nextSelectedKey == selectedKey &&
 			slices.Equal(messageView.Timeline, updatedView.Timeline) &&
@@ -659,7 +1409,6 @@ func newChatsTab(
 
 		tooltipManager.Hide(nil)
 		chats = updatedChats
-		pruneReadIncomingByChat(readIncomingUpToByKey, chats)
 		previewsByKey = chatPreviewByKey(store, chats, nodeNameByID)
 		if nextSelectedKey != selectedKey {
 			replyToDeviceMessageID = ""
@@ -670,10 +1419,10 @@ func newChatsTab(
 		messageView = updatedView
 		clear(messageItemHeightByID)
 		clear(messageItemWidthByID)
-		markChatRead(store, readIncomingUpToByKey, selectedKey)
-		unreadByKey = chatUnreadByKey(store, chats, readIncomingUpToByKey)
+		unreadByKey = updatedUnreadByKey
+		content.setUnread(totalUnread(unreadByKey))
 		if selectedKey == "" {
-			chatTitle.SetText("No chat selected")
+			chatTitle.SetText(i18n.T("No chat selected"))
 			entry.SetText("")
 			sendStatusLabel.SetText("")
 			pendingScrollChatKey = ""
@@ -687,15 +1436,29 @@ func newChatsTab(
 			}
 		}
 		refreshReplyIndicator()
+		refreshPinnedStrip()
 		applyComposerState()
 		chatList.Refresh()
 		messageList.Refresh()
-		ensureReplyShortcut()
+		ensureShortcuts()
 		if selectedIndex >= 0 {
 			chatList.Select(selectedIndex)
 		} else {
 			chatList.UnselectAll()
 		}
+		if resuming {
+			restored := resume
+			resume = chatResumeState{}
+			chatsLogger.Debug("resuming chat after store reload", "chat_key", restored.ChatKey)
+			entry.SetText(restored.Draft)
+			if _, ok := messageView.ByDeviceID[restored.ReplyToDeviceMessageID]; ok {
+				replyToDeviceMessageID = restored.ReplyToDeviceMessageID
+				refreshReplyIndicator()
+			}
+			messageList.ScrollToOffset(restored.ScrollOffset)
+
+			return
+		}
 		if pendingScrollChatKey != "" &&
 			selectedKey == pendingScrollChatKey &&
 			len(messageView.Timeline) >= pendingScrollMinCount {
@@ -715,7 +1478,7 @@ func newChatsTab(
 		fyne.Do(func() {
 			refreshReplyIndicator()
 			applyComposerState()
-			ensureReplyShortcut()
+			ensureShortcuts()
 			messageList.Refresh()
 		})
 	} else if len(chats) > 0 {
@@ -723,7 +1486,7 @@ func newChatsTab(
 		fyne.Do(func() {
 			refreshReplyIndicator()
 			applyComposerState()
-			ensureReplyShortcut()
+			ensureShortcuts()
 			messageList.Refresh()
 		})
 	} else {
@@ -742,16 +1505,40 @@ func newChatsTab(
 			})
 		}
 	}()
+	// Relative message times and "Today" separators go stale, so rebind the shown rows.
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for range ticker.C {
+			formatter := currentDisplayFormatter()
+			if !formatter.relativeMessageTimes && !formatter.daySeparators {
+				continue
+			}
+			fyne.Do(func() {
+				messageList.Refresh()
+			})
+		}
+	}()
 	if nodeChanges != nil {
 		chatsLogger.Debug("starting node change listener for chat labels")
 		go func() {
 			for range nodeChanges {
 				fyne.Do(func() {
-					tooltipManager.Hide(nil)
 					previewsByKey = chatPreviewByKey(store, chats, nodeNameByID)
 					chatTitle.SetText(chatTitleByKey(chats, selectedKey, nodeNameByID))
-					refreshReplyIndicator()
 					chatList.Refresh()
+					// Most node updates are telemetry and positions, which leave the open
+					// chat as it is.
+					if !messageView.senderRenamed(nodeNameByID) {
+						return
+					}
+					tooltipManager.Hide(nil)
+					// Reaction sender labels are resolved when the view is built, so rebuild it
+					// to replace node IDs with names learned after the history was loaded.
+					messageView = loadMessageView(selectedKey)
+					clear(messageItemHeightByID)
+					clear(messageItemWidthByID)
+					refreshReplyIndicator()
 					messageList.Refresh()
 				})
 			}
@@ -769,10 +1556,37 @@ func newChatsTab(
 		}()
 	}
 
-	return container.New(layout.NewStackLayout(), split, tooltipLayer)
+	onChatSeen := func() {
+		if selectedKey == "" || !chatViewed() {
+			return
+		}
+		// Rebinding the visible rows marks what the user can see now.
+		messageList.Refresh()
+	}
+	if attention != nil {
+		attention.OnRegained(func() {
+			fyne.Do(onChatSeen)
+		})
+	}
+	content = newChatsTabContent(container.New(layout.NewStackLayout(), split, tooltipLayer), onChatSeen)
+	content.setUnread(totalUnread(unreadByKey))
+	content.shareLocation = shareLocation
+	content.shortcuts = listShortcutTarget{
+		focusSearch: func() {
+			focusEntry(messageFilterEntry)
+		},
+		selectRelative: func(delta int) {
+			if index := relativeListIndex(chatIndexByKey(chats, selectedKey), delta, len(chats)); index >= 0 {
+				chatList.Select(index)
+				chatList.ScrollTo(index)
+			}
+		},
+	}
+
+	return content
 }
 
-func focusEntry(entry *widget.Entry) {
+func focusEntry(entry *shortcutEntry) {
 	if entry == nil {
 		return
 	}
@@ -811,14 +1625,6 @@ func shouldUpdateMessageItemHeight(hasPrev bool, prevHeight, prevWidth, rowHeigh
 	return false
 }
 
-func chatUnreadMarker(hasUnread bool) string {
-	if hasUnread {
-		return "●"
-	}
-
-	return " "
-}
-
 func chatPreviewByKey(store *domain.ChatStore, chats []domain.Chat, nodeNameByID func(string) string) map[string]string {
 	previews := make(map[string]string, len(chats))
 	for _, chat := range chats {
@@ -831,11 +1637,11 @@ func chatPreviewByKey(store *domain.ChatStore, chats []domain.Chat, nodeNameByID
 func chatPreviewLine(messages []domain.ChatMessage, nodeNameByID func(string) string) string {
 	last, ok := latestNonReactionMessage(messages)
 	if !ok {
-		return "No messages yet"
+		return i18n.T("No messages yet")
 	}
 	body := compactWhitespace(last.Body)
 	if body == "" {
-		body = "(empty)"
+		body = i18n.T("(empty)")
 	}
 
 	return truncatePreview(fmt.Sprintf("%s: %s", previewSender(last, nodeNameByID), body), 72)
@@ -843,17 +1649,17 @@ func chatPreviewLine(messages []domain.ChatMessage, nodeNameByID func(string) st
 
 func previewSender(msg domain.ChatMessage, nodeNameByID func(string) string) string {
 	if msg.Direction == domain.MessageDirectionOut {
-		return "you"
+		return i18n.T("you")
 	}
 	meta, hasMeta := parseMessageMeta(msg.MetaJSON)
 	if !hasMeta {
-		return "someone"
+		return i18n.T("someone")
 	}
 	if sender := domain.NormalizeNodeID(meta.From); sender != "" {
 		return displaySender(sender, nodeNameByID)
 	}
 
-	return "someone"
+	return i18n.T("someone")
 }
 
 func compactWhitespace(s string) string {
@@ -929,7 +1735,7 @@ func messageTextParts(m domain.ChatMessage, meta messageMeta, hasMeta bool, node
 			}
 		}
 
-		return "you", m.Body, true
+		return i18n.T("you"), m.Body, true
 	}
 	if hasMeta {
 		if sender := domain.NormalizeNodeID(meta.From); sender != "" {
@@ -1073,12 +1879,12 @@ func hopTooltipSegments(
 		return nil
 	}
 
-	lines := []string{fmt.Sprintf("Hops: %d", hops)}
+	lines := []string{i18n.Tf("Hops: %d", hops)}
 	if relay := resolveRelayNodeDisplay(meta, hasMeta, nodeNameByID, relayNodeNameByLastByte); relay != "" {
-		lines = append(lines, fmt.Sprintf("Received from: %s (last relay node)", relay))
+		lines = append(lines, i18n.Tf("Received from: %s (last relay node)", relay))
 	}
 	if isMessageFromMQTT(meta, hasMeta) {
-		lines = append(lines, "MQTT involved")
+		lines = append(lines, i18n.T("MQTT involved"))
 	}
 
 	return []widget.RichTextSegment{
@@ -1091,7 +1897,7 @@ func signalTooltipSegments(meta messageMeta) []widget.RichTextSegment {
 	if meta.RxRSSI != nil {
 		rssiText := fmt.Sprintf("%d", *meta.RxRSSI)
 		segments = append(segments,
-			&widget.TextSegment{Text: "RSSI: ", Style: widget.RichTextStyleInline},
+			&widget.TextSegment{Text: i18n.T("RSSI: "), Style: widget.RichTextStyleInline},
 			&widget.TextSegment{
 				Text:  rssiText,
 				Style: signalRichTextStyle(signalThemeColorForRSSI(*meta.RxRSSI), false),
@@ -1104,7 +1910,7 @@ func signalTooltipSegments(meta messageMeta) []widget.RichTextSegment {
 		}
 		snrText := fmt.Sprintf("%.2f", *meta.RxSNR)
 		segments = append(segments,
-			&widget.TextSegment{Text: "SNR: ", Style: widget.RichTextStyleInline},
+			&widget.TextSegment{Text: i18n.T("SNR: "), Style: widget.RichTextStyleInline},
 			&widget.TextSegment{
 				Text:  snrText,
 				Style: signalRichTextStyle(signalThemeColorForSNR(*meta.RxSNR), false),
@@ -1142,32 +1948,37 @@ func messageStatusBadge(m domain.ChatMessage) (text, tooltip string) {
 	}
 	switch m.Status {
 	case domain.MessageStatusPending:
-		return "◷", messageStatusPendingTooltipText
+		if domain.IsQueuedMessageID(m.DeviceMessageID) {
+			return "◷", i18n.T(messageStatusQueuedTooltipText)
+		}
+
+		return "◷", i18n.T(messageStatusPendingTooltipText)
 	case domain.MessageStatusSent:
 		if domain.IsDMKey(m.ChatKey) {
-			return "✓", messageStatusSentDMTooltipText
+			return "✓", i18n.T(messageStatusSentDMTooltipText)
 		}
 
-		return "✓", messageStatusSentChannelTooltipText
+		return "✓", i18n.T(messageStatusSentChannelTooltipText)
 	case domain.MessageStatusAcked:
 		if domain.IsDMKey(m.ChatKey) {
-			return "✓✓", messageStatusAckedDMTooltipText
+			return "✓✓", i18n.T(messageStatusAckedDMTooltipText)
 		}
 
-		return "✓✓", messageStatusAckedChannelTooltipText
+		return "✓✓", i18n.T(messageStatusAckedChannelTooltipText)
 	case domain.MessageStatusFailed:
 		reason := compactWhitespace(strings.TrimSpace(m.StatusReason))
 		if reason == "" {
-			return "⚠", messageStatusFailedTooltipText
+			return "⚠", i18n.T(messageStatusFailedTooltipText)
 		}
 
-		return "⚠", fmt.Sprintf("%s\nReason: %s.", messageStatusFailedTooltipText, reason)
+		return "⚠", i18n.Tf("%s\nReason: %s.", i18n.T(messageStatusFailedTooltipText), reason)
 	default:
 		return "", ""
 	}
 }
 
 type messageStatusTooltipCache struct {
+	queued        fyne.CanvasObject
 	pending       fyne.CanvasObject
 	sentChannel   fyne.CanvasObject
 	sentDM        fyne.CanvasObject
@@ -1177,6 +1988,8 @@ type messageStatusTooltipCache struct {
 }
 
 const (
+	messageStatusQueuedTooltipText = `Saved on PC.
+Waiting for the radio to connect.`
 	messageStatusPendingTooltipText = `Sent from PC to device.
 Waiting for mesh confirmation.`
 	messageStatusSentChannelTooltipText = `Sent from PC to device.
@@ -1197,12 +2010,13 @@ Transmission or delivery failed.`
 
 func newMessageStatusTooltipCache() messageStatusTooltipCache {
 	return messageStatusTooltipCache{
-		pending:       widget.NewLabel(messageStatusPendingTooltipText),
-		sentChannel:   widget.NewLabel(messageStatusSentChannelTooltipText),
-		sentDM:        widget.NewLabel(messageStatusSentDMTooltipText),
-		ackedChannel:  widget.NewLabel(messageStatusAckedChannelTooltipText),
-		ackedDM:       widget.NewLabel(messageStatusAckedDMTooltipText),
-		failedGeneric: widget.NewLabel(messageStatusFailedTooltipText),
+		queued:        widget.NewLabel(i18n.T(messageStatusQueuedTooltipText)),
+		pending:       widget.NewLabel(i18n.T(messageStatusPendingTooltipText)),
+		sentChannel:   widget.NewLabel(i18n.T(messageStatusSentChannelTooltipText)),
+		sentDM:        widget.NewLabel(i18n.T(messageStatusSentDMTooltipText)),
+		ackedChannel:  widget.NewLabel(i18n.T(messageStatusAckedChannelTooltipText)),
+		ackedDM:       widget.NewLabel(i18n.T(messageStatusAckedDMTooltipText)),
+		failedGeneric: widget.NewLabel(i18n.T(messageStatusFailedTooltipText)),
 	}
 }
 
@@ -1213,6 +2027,10 @@ func messageStatusTooltipContent(m domain.ChatMessage, cache messageStatusToolti
 
 	switch m.Status {
 	case domain.MessageStatusPending:
+		if domain.IsQueuedMessageID(m.DeviceMessageID) {
+			return cache.queued
+		}
+
 		return cache.pending
 	case domain.MessageStatusSent:
 		if domain.IsDMKey(m.ChatKey) {
@@ -1273,10 +2091,10 @@ func messageTransportBadge(m domain.ChatMessage, meta messageMeta, hasMeta bool)
 		return "", ""
 	}
 	if isMessageFromMQTT(meta, hasMeta) {
-		return "☁", "via MQTT"
+		return "☁", i18n.T("via MQTT")
 	}
 
-	return "📡", "via Radio"
+	return "📡", i18n.T("via Radio")
 }
 
 func messageTimeLabel(at time.Time) string {
@@ -1284,7 +2102,21 @@ func messageTimeLabel(at time.Time) string {
 		return ""
 	}
 
-	return at.Local().Format("15:04")
+	return currentDisplayFormatter().MessageTime(at, time.Now())
+}
+
+// messageDaySeparatorText returns the date to show above the timeline message at index,
+// or "" when separators are off or the message is on the same day as the previous one.
+func messageDaySeparatorText(timeline []domain.ChatMessage, index int, now time.Time) string {
+	formatter := currentDisplayFormatter()
+	if !formatter.DaySeparators() || index < 0 || index >= len(timeline) || timeline[index].At.IsZero() {
+		return ""
+	}
+	if index > 0 && !timeline[index-1].At.IsZero() && localDay(timeline[index-1].At).Equal(localDay(timeline[index].At)) {
+		return ""
+	}
+
+	return formatter.DaySeparator(timeline[index].At, now)
 }
 
 func chatBubbleFillColor(direction domain.MessageDirection) color.Color {
@@ -1369,6 +2201,19 @@ type chatMessageView struct {
 	Timeline                  []domain.ChatMessage
 	ByDeviceID                map[string]*domain.ChatMessage
 	ReactionsByTargetDeviceID map[string][]reactionChip
+	// SenderLabels are the names the senders had when the view was built, by node ID.
+	SenderLabels map[string]string
+}
+
+// senderRenamed reports whether a sender of the view is now shown under another name.
+func (v chatMessageView) senderRenamed(nodeNameByID func(string) string) bool {
+	for nodeID, label := range v.SenderLabels {
+		if displaySender(nodeID, nodeNameByID) != label {
+			return true
+		}
+	}
+
+	return false
 }
 
 type reactionChip struct {
@@ -1385,10 +2230,17 @@ func buildChatMessageView(
 		Timeline:                  make([]domain.ChatMessage, 0, len(messages)),
 		ByDeviceID:                make(map[string]*domain.ChatMessage),
 		ReactionsByTargetDeviceID: make(map[string][]reactionChip),
+		SenderLabels:              make(map[string]string),
 	}
 	reactionSenderSetByTargetAndEmoji := make(map[string]map[string]map[string]string)
 	reactionEmojiOrderByTarget := make(map[string][]string)
 	for _, msg := range messages {
+		meta, hasMeta := parseMessageMeta(msg.MetaJSON)
+		if senderID := chatMessageSenderID(msg, meta, hasMeta, localNodeID); senderID != "" {
+			if _, ok := view.SenderLabels[senderID]; !ok {
+				view.SenderLabels[senderID] = displaySender(senderID, nodeNameByID)
+			}
+		}
 		if isReactionMessage(msg) {
 			targetID := strings.TrimSpace(msg.ReplyToDeviceMessageID)
 			if targetID == "" {
@@ -1398,7 +2250,6 @@ func buildChatMessageView(
 			if emoji == "" {
 				continue
 			}
-			meta, hasMeta := parseMessageMeta(msg.MetaJSON)
 			senderKey, senderLabel := reactionSenderKeyAndLabel(msg, meta, hasMeta, nodeNameByID, localNodeID)
 			targetMap, ok := reactionSenderSetByTargetAndEmoji[targetID]
 			if !ok {
@@ -1416,7 +2267,7 @@ func buildChatMessageView(
 			}
 			if senderKey == "" {
 				senderKey = "unknown"
-				senderLabel = "someone"
+				senderLabel = i18n.T("someone")
 			}
 			senderSet[senderKey] = senderLabel
 
@@ -1425,6 +2276,8 @@ func buildChatMessageView(
 
 		view.Timeline = append(view.Timeline, msg)
 	}
+	timeline, partAliases := mergeMessageParts(view.Timeline, localNodeID)
+	view.Timeline = timeline
 	for i := range view.Timeline {
 		deviceID := strings.TrimSpace(view.Timeline[i].DeviceMessageID)
 		if deviceID == "" {
@@ -1432,6 +2285,12 @@ func buildChatMessageView(
 		}
 		view.ByDeviceID[deviceID] = &view.Timeline[i]
 	}
+	// Replies to any part of a split message point at the merged message.
+	for partID, firstID := range partAliases {
+		if message, ok := view.ByDeviceID[firstID]; ok {
+			view.ByDeviceID[partID] = message
+		}
+	}
 	for targetID, byEmoji := range reactionSenderSetByTargetAndEmoji {
 		emojiOrder := reactionEmojiOrderByTarget[targetID]
 		chips := make([]reactionChip, 0, len(byEmoji))
@@ -1455,16 +2314,72 @@ func buildChatMessageView(
 	return view
 }
 
+// filterChatMessageView keeps timeline messages whose sender or text contains the query.
+// Lookups by device ID stay complete so quotes of hidden messages still resolve.
+func filterChatMessageView(
+	view chatMessageView,
+	query string,
+	nodeNameByID func(string) string,
+	localNodeID func() string,
+) chatMessageView {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return view
+	}
+
+	filtered := make([]domain.ChatMessage, 0, len(view.Timeline))
+	for _, msg := range view.Timeline {
+		meta, hasMeta := parseMessageMeta(msg.MetaJSON)
+		sender, body, _ := messageTextParts(msg, meta, hasMeta, nodeNameByID, localNodeID)
+		if strings.Contains(strings.ToLower(sender), query) || strings.Contains(strings.ToLower(body), query) {
+			filtered = append(filtered, msg)
+		}
+	}
+	view.Timeline = filtered
+
+	return view
+}
+
 func isReactionMessage(message domain.ChatMessage) bool {
 	return strings.TrimSpace(message.ReplyToDeviceMessageID) != "" && message.Emoji != 0
 }
 
+// messageQuoteMaxRunes keeps a quote short enough to leave room for the answer.
+const messageQuoteMaxRunes = 48
+
+// quoteMessageText returns a short quote of a message for the composer.
+func quoteMessageText(body string) string {
+	quote := []rune(compactWhitespace(body))
+	if len(quote) > messageQuoteMaxRunes {
+		quote = append(quote[:messageQuoteMaxRunes-1], '…')
+	}
+
+	return "\"" + string(quote) + "\" "
+}
+
+// messageSenderID returns the node id of the message author, or "" when unknown.
+func messageSenderID(message domain.ChatMessage, meta messageMeta, hasMeta bool, localNodeID func() string) string {
+	if hasMeta {
+		if sender := domain.NormalizeNodeID(meta.From); sender != "" {
+			return sender
+		}
+	}
+	if message.Direction == domain.MessageDirectionOut && localNodeID != nil {
+		return domain.NormalizeNodeID(localNodeID())
+	}
+
+	return ""
+}
+
 func canReplyToMessage(message domain.ChatMessage) bool {
 	if isReactionMessage(message) {
 		return false
 	}
 
-	return strings.TrimSpace(message.DeviceMessageID) != ""
+	deviceMessageID := strings.TrimSpace(message.DeviceMessageID)
+
+	// A queued message has no packet id yet, so the mesh could not match a reply to it.
+	return deviceMessageID != "" && !domain.IsQueuedMessageID(deviceMessageID)
 }
 
 // canReactToMessage reports whether a reaction can be attached to the given
@@ -1569,6 +2484,20 @@ func reactionChipSegments(chip reactionChip) []widget.RichTextSegment {
 	return segments
 }
 
+// chatMessageSenderID returns the node ID a message is shown as sent by, if known.
+func chatMessageSenderID(message domain.ChatMessage, meta messageMeta, hasMeta bool, localNodeID func() string) string {
+	if hasMeta {
+		if sender := domain.NormalizeNodeID(meta.From); sender != "" {
+			return sender
+		}
+	}
+	if message.Direction == domain.MessageDirectionOut && localNodeID != nil {
+		return domain.NormalizeNodeID(localNodeID())
+	}
+
+	return ""
+}
+
 func reactionSenderKeyAndLabel(
 	message domain.ChatMessage,
 	meta messageMeta,
@@ -1588,10 +2517,10 @@ func reactionSenderKeyAndLabel(
 			}
 		}
 
-		return "you", "you"
+		return "you", i18n.T("you")
 	}
 
-	return "someone", "someone"
+	return "someone", i18n.T("someone")
 }
 
 func reactionMapEqual(a, b map[string][]reactionChip) bool {
@@ -1665,21 +2594,34 @@ func chatIndexByKey(chats []domain.Chat, key string) int {
 	return -1
 }
 
-func initialReadIncomingByChat(store *domain.ChatStore, chats []domain.Chat) map[string]time.Time {
+// initialReadIncomingByChat returns the stored read watermarks of the chats.
+func initialReadIncomingByChat(chats []domain.Chat) map[string]time.Time {
 	readIncomingUpToByKey := make(map[string]time.Time, len(chats))
-	for _, chat := range chats {
-		readIncomingUpToByKey[chat.Key] = latestIncomingAt(store.Messages(chat.Key))
-	}
+	seedReadIncomingByChat(readIncomingUpToByKey, chats)
 
 	return readIncomingUpToByKey
 }
 
-func markChatRead(store *domain.ChatStore, readIncomingUpToByKey map[string]time.Time, chatKey string) {
+// seedReadIncomingByChat adds stored read watermarks that are newer than the known ones,
+// e.g. for chats that come back after the store is reloaded.
+func seedReadIncomingByChat(readIncomingUpToByKey map[string]time.Time, chats []domain.Chat) {
+	for _, chat := range chats {
+		if chat.ReadUpTo.After(readIncomingUpToByKey[chat.Key]) {
+			readIncomingUpToByKey[chat.Key] = chat.ReadUpTo
+		}
+	}
+}
+
+// markIncomingRead moves the read watermark of the chat up to the message. It reports
+// whether the watermark moved.
+func markIncomingRead(readIncomingUpToByKey map[string]time.Time, chatKey string, msg domain.ChatMessage) bool {
 	chatKey = strings.TrimSpace(chatKey)
-	if chatKey == "" {
-		return
+	if chatKey == "" || msg.Direction != domain.MessageDirectionIn || !msg.At.After(readIncomingUpToByKey[chatKey]) {
+		return false
 	}
-	readIncomingUpToByKey[chatKey] = latestIncomingAt(store.Messages(chatKey))
+	readIncomingUpToByKey[chatKey] = msg.At
+
+	return true
 }
 
 func pruneReadIncomingByChat(readIncomingUpToByKey map[string]time.Time, chats []domain.Chat) {
@@ -1695,27 +2637,44 @@ func pruneReadIncomingByChat(readIncomingUpToByKey map[string]time.Time, chats [
 	}
 }
 
-func chatUnreadByKey(store *domain.ChatStore, chats []domain.Chat, readIncomingUpToByKey map[string]time.Time) map[string]bool {
-	unreadByKey := make(map[string]bool, len(chats))
+func totalUnread(unreadByKey map[string]int) int {
+	total := 0
+	for _, unread := range unreadByKey {
+		total += unread
+	}
+
+	return total
+}
+
+func chatUnreadCountByKey(store *domain.ChatStore, chats []domain.Chat, readIncomingUpToByKey map[string]time.Time) map[string]int {
+	unreadByKey := make(map[string]int, len(chats))
 	for _, chat := range chats {
-		latestIncoming := latestIncomingAt(store.Messages(chat.Key))
-		lastReadIncoming := readIncomingUpToByKey[chat.Key]
-		unreadByKey[chat.Key] = latestIncoming.After(lastReadIncoming)
+		unreadByKey[chat.Key] = chatUnreadCount(store.Messages(chat.Key), readIncomingUpToByKey[chat.Key])
 	}
 
 	return unreadByKey
 }
 
-func latestIncomingAt(messages []domain.ChatMessage) time.Time {
-	var latest time.Time
+// chatUnreadCount counts incoming messages newer than the read watermark.
+func chatUnreadCount(messages []domain.ChatMessage, readUpTo time.Time) int {
+	count := 0
 	for _, msg := range messages {
-		if msg.Direction != domain.MessageDirectionIn {
-			continue
+		if msg.Direction == domain.MessageDirectionIn && msg.At.After(readUpTo) {
+			count++
 		}
-		if msg.At.After(latest) {
-			latest = msg.At
+	}
+
+	return count
+}
+
+// firstUnreadMessageIndex returns the timeline position of the oldest unread incoming
+// message, or -1 when everything is read.
+func firstUnreadMessageIndex(timeline []domain.ChatMessage, readUpTo time.Time) int {
+	for i, msg := range timeline {
+		if msg.Direction == domain.MessageDirectionIn && msg.At.After(readUpTo) {
+			return i
 		}
 	}
 
-	return latest
+	return -1
 }
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"errors"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/skobkin/meshgo/internal/config"
 	"github.com/skobkin/meshgo/internal/domain"
 	"github.com/skobkin/meshgo/internal/radio"
 )
@@ -70,7 +72,7 @@ func TestChatsTabCompactCyrillicComposerBehavior(t *testing.T) {
 			enabled:     true,
 			wantBody:    "Aa3",
 			wantSend:    true,
-			wantCounter: "3/200 bytes",
+			wantCounter: "3/232 bytes",
 		},
 		{
 			name:        "oversized original accepted when effective body fits",
@@ -78,14 +80,14 @@ func TestChatsTabCompactCyrillicComposerBehavior(t *testing.T) {
 			enabled:     true,
 			wantBody:    strings.Repeat("A", 200),
 			wantSend:    true,
-			wantCounter: "200/200 bytes",
+			wantCounter: "200/232 bytes",
 		},
 		{
 			name:        "disabled optimization rejects oversized text",
-			input:       strings.Repeat("А", 101),
+			input:       strings.Repeat("А", 117),
 			enabled:     false,
 			wantSend:    false,
-			wantCounter: "202/200 bytes",
+			wantCounter: "234/232 bytes",
 		},
 	}
 
@@ -118,13 +120,27 @@ func TestChatsTabCompactCyrillicComposerBehavior(t *testing.T) {
 				nil,
 				nil,
 				func() bool { return tc.enabled },
+				chatAnnotationActions{},
+				chatTaskbarFlashActions{},
+				nil,
+				nil,
+				chatReferenceSource{},
+				nil,
+				chatPinActions{},
+				chatHistoryActions{},
+				chatListPrefsActions{},
+				chatColorActions{},
+				nil,
 			)
 			_ = fynetest.NewTempWindow(t, tab)
-			entry := mustFindEntryByPlaceholder(t, tab, "Type message (max 200 bytes)")
+			entry := mustFindEntryByPlaceholder(t, tab, "Type message")
 			entry.SetText(tc.input)
 			if counter := findLabelByPrefix(tab, tc.wantCounter); counter == nil {
 				t.Fatalf("expected counter %q", tc.wantCounter)
 			}
+			if send := mustFindButtonByText(t, tab, "Send"); send.Disabled() == tc.wantSend {
+				t.Fatalf("unexpected send button state: expected disabled %v, got %v", !tc.wantSend, send.Disabled())
+			}
 
 			fynetest.Tap(mustFindButtonByText(t, tab, "Send"))
 			select {
@@ -144,6 +160,67 @@ func TestChatsTabCompactCyrillicComposerBehavior(t *testing.T) {
 	}
 }
 
+func TestChatsTabSendsOversizedMessageInParts(t *testing.T) {
+	if raceDetectorEnabled {
+		t.Skip("Fyne GUI interaction tests are not stable under the race detector")
+	}
+
+	sent := make(chan string, 4)
+	store := domain.NewChatStore()
+	store.Load(
+		[]domain.Chat{{Key: "ch:general", Title: "General", Type: domain.ChatTypeChannel, UpdatedAt: time.Now()}},
+		map[string][]domain.ChatMessage{},
+	)
+	tab := newChatsTab(
+		nil,
+		store,
+		sendTextFunc(func(_ string, text string, _ radio.TextSendOptions) <-chan radio.SendResult {
+			sent <- text
+			result := make(chan radio.SendResult, 1)
+			result <- radio.SendResult{}
+			close(result)
+
+			return result
+		}),
+		nil,
+		nil,
+		nil,
+		nil,
+		"ch:general",
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		chatAnnotationActions{},
+		chatTaskbarFlashActions{},
+		nil,
+		nil,
+		chatReferenceSource{},
+		nil,
+		chatPinActions{},
+		chatHistoryActions{},
+		chatListPrefsActions{},
+		chatColorActions{},
+		nil,
+	)
+	_ = fynetest.NewTempWindow(t, tab)
+	entry := mustFindEntryByPlaceholder(t, tab, "Type message")
+	entry.SetText(strings.Repeat("word ", 60))
+
+	fynetest.Tap(mustFindButtonByText(t, tab, "Send as 2 parts"))
+	for i := 1; i <= 2; i++ {
+		select {
+		case got := <-sent:
+			if part, ok := domain.ParseMessagePart(got); !ok || part.Index != i || part.Total != 2 {
+				t.Fatalf("expected part %d/2, got %q", i, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected part %d to be sent", i)
+		}
+	}
+}
+
 func TestChatsTabSendReadsCurrentCompactCyrillicPreference(t *testing.T) {
 	if raceDetectorEnabled {
 		t.Skip("Fyne GUI interaction tests are not stable under the race detector")
@@ -177,11 +254,22 @@ func TestChatsTabSendReadsCurrentCompactCyrillicPreference(t *testing.T) {
 		nil,
 		nil,
 		func() bool { return enabled },
+		chatAnnotationActions{},
+		chatTaskbarFlashActions{},
+		nil,
+		nil,
+		chatReferenceSource{},
+		nil,
+		chatPinActions{},
+		chatHistoryActions{},
+		chatListPrefsActions{},
+		chatColorActions{},
+		nil,
 	)
 	_ = fynetest.NewTempWindow(t, tab)
-	entry := mustFindEntryByPlaceholder(t, tab, "Type message (max 200 bytes)")
+	entry := mustFindEntryByPlaceholder(t, tab, "Type message")
 	entry.SetText("А")
-	if counter := findLabelByPrefix(tab, "2/200 bytes"); counter == nil {
+	if counter := findLabelByPrefix(tab, "2/232 bytes"); counter == nil {
 		t.Fatalf("expected disabled preference to count original bytes")
 	}
 
@@ -198,15 +286,49 @@ func TestChatsTabSendReadsCurrentCompactCyrillicPreference(t *testing.T) {
 	}
 }
 
-func TestChatUnreadMarker(t *testing.T) {
-	if got := chatUnreadMarker(true); got != "●" {
-		t.Fatalf("unexpected unread marker: %q", got)
+func TestChatUnreadBadgeText(t *testing.T) {
+	tests := []struct {
+		unread int
+		want   string
+	}{
+		{unread: 0, want: ""},
+		{unread: 3, want: "3"},
+		{unread: 99, want: "99"},
+		{unread: 100, want: "99+"},
+	}
+	for _, tt := range tests {
+		if got := chatUnreadBadgeText(tt.unread); got != tt.want {
+			t.Fatalf("unexpected badge text for %d: expected %q, got %q", tt.unread, tt.want, got)
+		}
 	}
-	if got := chatUnreadMarker(false); got != " " {
-		t.Fatalf("unexpected read marker: %q", got)
+}
+
+func TestChatUnreadBadgeSetCount(t *testing.T) {
+	badge := newChatUnreadBadge()
+	if badge.Visible() {
+		t.Fatalf("expected a new badge to be hidden")
+	}
+
+	badge.SetCount(2)
+	if !badge.Visible() || badge.text.Text != "2" {
+		t.Fatalf("expected a visible badge with 2, got visible=%v text=%q", badge.Visible(), badge.text.Text)
+	}
+	badge.SetCount(0)
+	if badge.Visible() {
+		t.Fatalf("expected the badge to hide once read")
 	}
 }
 
+func hasUnreadBadge(root fyne.CanvasObject, count int) bool {
+	for _, object := range fynetest.LaidOutObjects(root) {
+		if badge, ok := object.(*chatUnreadBadge); ok && badge.Visible() && badge.count == count {
+			return true
+		}
+	}
+
+	return false
+}
+
 func TestChatPreviewLine_Empty(t *testing.T) {
 	if got := chatPreviewLine(nil, nil); got != "No messages yet" {
 		t.Fatalf("unexpected preview: %q", got)
@@ -289,6 +411,31 @@ func TestChatPreviewLine_IgnoresReactionMessages(t *testing.T) {
 	}
 }
 
+func TestChatMessageViewSenderRenamed(t *testing.T) {
+	names := map[string]string{"!aaaa0001": "Alice"}
+	nodeNameByID := func(nodeID string) string { return names[nodeID] }
+	view := buildChatMessageView(
+		[]domain.ChatMessage{
+			{DeviceMessageID: "300", Direction: domain.MessageDirectionIn, Body: "hi", MetaJSON: `{"from":"!aaaa0001"}`},
+			{DeviceMessageID: "301", Direction: domain.MessageDirectionOut, Body: "hello"},
+		},
+		nodeNameByID,
+		func() string { return "!ffff0001" },
+	)
+
+	if view.senderRenamed(nodeNameByID) {
+		t.Fatalf("expected no rename before names change")
+	}
+	names["!bbbb0002"] = "Bob"
+	if view.senderRenamed(nodeNameByID) {
+		t.Fatalf("expected a node outside the chat to be ignored")
+	}
+	names["!ffff0001"] = "Me"
+	if !view.senderRenamed(nodeNameByID) {
+		t.Fatalf("expected the local sender name to be noticed")
+	}
+}
+
 func TestBuildChatMessageView_GroupsReactionsByTargetAndEmoji(t *testing.T) {
 	view := buildChatMessageView(
 		[]domain.ChatMessage{
@@ -351,6 +498,54 @@ func TestBuildChatMessageView_GroupsReactionsByTargetAndEmoji(t *testing.T) {
 	}
 }
 
+func TestFilterChatMessageView(t *testing.T) {
+	nodeNameByID := func(nodeID string) string {
+		if nodeID == "!aaaa0001" {
+			return "Alice"
+		}
+
+		return nodeID
+	}
+	view := buildChatMessageView(
+		[]domain.ChatMessage{
+			{DeviceMessageID: "300", Direction: domain.MessageDirectionIn, Body: "Meet at the hill", MetaJSON: `{"from":"!aaaa0001"}`},
+			{DeviceMessageID: "301", Direction: domain.MessageDirectionIn, Body: "On my way", MetaJSON: `{"from":"!bbbb0002"}`},
+			{DeviceMessageID: "302", Direction: domain.MessageDirectionOut, Body: "See them there"},
+		},
+		nodeNameByID,
+		nil,
+	)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "empty query keeps all", query: "  ", want: []string{"300", "301", "302"}},
+		{name: "body substring ignores case", query: "HILL", want: []string{"300"}},
+		{name: "resolved sender name", query: "alice", want: []string{"300"}},
+		{name: "sender node id", query: "!bbbb", want: []string{"301"}},
+		{name: "outgoing sender", query: "you", want: []string{"302"}},
+		{name: "no matches", query: "nothing", want: []string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			filtered := filterChatMessageView(view, tc.query, nodeNameByID, nil)
+			got := make([]string, 0, len(filtered.Timeline))
+			for _, msg := range filtered.Timeline {
+				got = append(got, msg.DeviceMessageID)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+			if len(filtered.ByDeviceID) != 3 {
+				t.Fatalf("expected device ID lookup to keep all messages, got %d", len(filtered.ByDeviceID))
+			}
+		})
+	}
+}
+
 func TestReactionChipSegments_EmojiUsesHeadingSizeAndCount(t *testing.T) {
 	segments := reactionChipSegments(reactionChip{
 		Emoji:   "👍",
@@ -734,26 +929,12 @@ func TestChatIndexByKey(t *testing.T) {
 	}
 }
 
-func TestLatestIncomingAt(t *testing.T) {
-	base := time.Date(2026, 2, 11, 12, 0, 0, 0, time.UTC)
-	messages := []domain.ChatMessage{
-		{Direction: domain.MessageDirectionOut, At: base.Add(1 * time.Minute)},
-		{Direction: domain.MessageDirectionIn, At: base.Add(2 * time.Minute)},
-		{Direction: domain.MessageDirectionIn, At: base.Add(5 * time.Minute)},
-	}
-
-	got := latestIncomingAt(messages)
-	want := base.Add(5 * time.Minute)
-	if !got.Equal(want) {
-		t.Fatalf("unexpected latest incoming time: got %v want %v", got, want)
-	}
-}
-
 func TestChatUnreadByKeyAndMarkRead(t *testing.T) {
 	base := time.Date(2026, 2, 11, 12, 0, 0, 0, time.UTC)
 	chats := []domain.Chat{
-		{Key: "ch:1", Title: "One", Type: domain.ChatTypeChannel},
+		{Key: "ch:1", Title: "One", Type: domain.ChatTypeChannel, ReadUpTo: base},
 		{Key: "ch:2", Title: "Two", Type: domain.ChatTypeChannel},
+		{Key: "ch:3", Title: "Three", Type: domain.ChatTypeChannel},
 	}
 	store := domain.NewChatStore()
 	store.Load(chats, map[string][]domain.ChatMessage{
@@ -763,34 +944,69 @@ func TestChatUnreadByKeyAndMarkRead(t *testing.T) {
 		"ch:2": {
 			{ChatKey: "ch:2", Direction: domain.MessageDirectionOut, Body: "out", At: base},
 		},
+		"ch:3": {
+			{ChatKey: "ch:3", Direction: domain.MessageDirectionIn, Body: "never read", At: base},
+		},
 	})
 
-	read := initialReadIncomingByChat(store, chats)
-	unread := chatUnreadByKey(store, chats, read)
-	if unread["ch:1"] {
-		t.Fatalf("chat ch:1 should be read initially")
+	read := initialReadIncomingByChat(chats)
+	unread := chatUnreadCountByKey(store, chats, read)
+	if unread["ch:1"] != 0 {
+		t.Fatalf("chat ch:1 should be read up to its stored watermark")
 	}
-	if unread["ch:2"] {
-		t.Fatalf("chat ch:2 should be read initially")
+	if unread["ch:2"] != 0 {
+		t.Fatalf("chat ch:2 should have nothing to read")
+	}
+	if unread["ch:3"] != 1 {
+		t.Fatalf("chat ch:3 was never read: expected 1 unread, got %d", unread["ch:3"])
 	}
 
-	store.AppendMessage(domain.ChatMessage{
+	first := domain.ChatMessage{
 		ChatKey:   "ch:1",
 		Direction: domain.MessageDirectionIn,
 		Body:      "new",
 		At:        base.Add(10 * time.Minute),
 		MetaJSON:  `{"from":"!abcd1234"}`,
-	})
+	}
+	second := first
+	second.Body = "newer"
+	second.At = base.Add(11 * time.Minute)
+	store.AppendMessage(first)
+	store.AppendMessage(second)
 
-	unread = chatUnreadByKey(store, chats, read)
-	if !unread["ch:1"] {
-		t.Fatalf("chat ch:1 should be unread after new incoming message")
+	unread = chatUnreadCountByKey(store, chats, read)
+	if unread["ch:1"] != 2 {
+		t.Fatalf("unread count after new incoming messages: expected 2, got %d", unread["ch:1"])
+	}
+	if got := firstUnreadMessageIndex(store.Messages("ch:1"), read["ch:1"]); got != 1 {
+		t.Fatalf("first unread index: expected 1, got %d", got)
+	}
+
+	if !markIncomingRead(read, "ch:1", first) {
+		t.Fatalf("expected the watermark to move to the first new message")
+	}
+	unread = chatUnreadCountByKey(store, chats, read)
+	if unread["ch:1"] != 1 {
+		t.Fatalf("unread count after reading one message: expected 1, got %d", unread["ch:1"])
+	}
+	if markIncomingRead(read, "ch:1", store.Messages("ch:1")[0]) {
+		t.Fatalf("expected an older message not to move the watermark back")
 	}
 
-	markChatRead(store, read, "ch:1")
-	unread = chatUnreadByKey(store, chats, read)
-	if unread["ch:1"] {
-		t.Fatalf("chat ch:1 should be read after markChatRead")
+	markIncomingRead(read, "ch:1", second)
+	unread = chatUnreadCountByKey(store, chats, read)
+	if unread["ch:1"] != 0 {
+		t.Fatalf("chat ch:1 should be read after its newest message is seen")
+	}
+	if got := firstUnreadMessageIndex(store.Messages("ch:1"), read["ch:1"]); got != -1 {
+		t.Fatalf("first unread index: expected -1, got %d", got)
+	}
+
+	// After a store reload the chats come back with their stored watermarks.
+	pruneReadIncomingByChat(read, nil)
+	seedReadIncomingByChat(read, []domain.Chat{{Key: "ch:1", ReadUpTo: second.At}})
+	if !read["ch:1"].Equal(second.At) {
+		t.Fatalf("expected the stored watermark to be restored, got %v", read["ch:1"])
 	}
 }
 
@@ -802,6 +1018,7 @@ func TestMessageStatusBadge_Outgoing(t *testing.T) {
 		hint    string
 	}{
 		{name: "pending", message: domain.ChatMessage{Direction: domain.MessageDirectionOut, Status: domain.MessageStatusPending}, want: "◷", hint: messageStatusPendingTooltipText},
+		{name: "queued", message: domain.ChatMessage{Direction: domain.MessageDirectionOut, Status: domain.MessageStatusPending, DeviceMessageID: "outbox:1"}, want: "◷", hint: messageStatusQueuedTooltipText},
 		{name: "sent channel", message: domain.ChatMessage{Direction: domain.MessageDirectionOut, Status: domain.MessageStatusSent, ChatKey: "channel:0"}, want: "✓", hint: messageStatusSentChannelTooltipText},
 		{name: "sent dm", message: domain.ChatMessage{Direction: domain.MessageDirectionOut, Status: domain.MessageStatusSent, ChatKey: "dm:!abcd1234"}, want: "✓", hint: messageStatusSentDMTooltipText},
 		{name: "acked channel", message: domain.ChatMessage{Direction: domain.MessageDirectionOut, Status: domain.MessageStatusAcked, ChatKey: "channel:0"}, want: "✓✓", hint: messageStatusAckedChannelTooltipText},
@@ -829,6 +1046,13 @@ func TestMessageStatusTooltipContent_UsesPrebuiltContent(t *testing.T) {
 		t.Fatalf("pending should reuse prebuilt tooltip object")
 	}
 
+	if got := messageStatusTooltipContent(
+		domain.ChatMessage{Direction: domain.MessageDirectionOut, Status: domain.MessageStatusPending, DeviceMessageID: "outbox:1"},
+		cache,
+	); got != cache.queued {
+		t.Fatalf("queued should reuse prebuilt queued tooltip object")
+	}
+
 	if got := messageStatusTooltipContent(
 		domain.ChatMessage{Direction: domain.MessageDirectionOut, Status: domain.MessageStatusSent, ChatKey: "channel:0"},
 		cache,
@@ -878,6 +1102,27 @@ func TestMessageTimeLabel(t *testing.T) {
 	}
 }
 
+func TestMessageDaySeparatorText(t *testing.T) {
+	now := time.Date(2026, 2, 11, 12, 0, 0, 0, time.Local)
+	timeline := []domain.ChatMessage{
+		{At: now.Add(-26 * time.Hour)},
+		{At: now.Add(-25 * time.Hour)},
+		{At: now.Add(-time.Hour)},
+	}
+	if got := messageDaySeparatorText(timeline, 0, now); got != "" {
+		t.Fatalf("expected no separators by default, got %q", got)
+	}
+
+	setDisplayFormats(config.FormatsConfig{ChatDaySeparators: true})
+	t.Cleanup(func() { activeDisplayFormatter.Store(nil) })
+	want := []string{"Yesterday", "", "Today"}
+	for index, expected := range want {
+		if got := messageDaySeparatorText(timeline, index, now); got != expected {
+			t.Fatalf("separator of message %d: expected %q, got %q", index, expected, got)
+		}
+	}
+}
+
 func TestShouldUpdateMessageItemHeight(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -989,10 +1234,21 @@ func TestChatsTabSendFailureShowsStatusAndKeepsEntryText(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		chatAnnotationActions{},
+		chatTaskbarFlashActions{},
+		nil,
+		nil,
+		chatReferenceSource{},
+		nil,
+		chatPinActions{},
+		chatHistoryActions{},
+		chatListPrefsActions{},
+		chatColorActions{},
+		nil,
 	)
 	_ = fynetest.NewTempWindow(t, tab)
 
-	entry := mustFindEntryByPlaceholder(t, tab, "Type message (max 200 bytes)")
+	entry := mustFindEntryByPlaceholder(t, tab, "Type message")
 	sendButton := mustFindButtonByText(t, tab, "Send")
 	entry.SetText("hello from test")
 
@@ -1050,10 +1306,21 @@ func TestChatsTabSendSuccessClearsPreviousFailureStatus(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		chatAnnotationActions{},
+		chatTaskbarFlashActions{},
+		nil,
+		nil,
+		chatReferenceSource{},
+		nil,
+		chatPinActions{},
+		chatHistoryActions{},
+		chatListPrefsActions{},
+		chatColorActions{},
+		nil,
 	)
 	_ = fynetest.NewTempWindow(t, tab)
 
-	entry := mustFindEntryByPlaceholder(t, tab, "Type message (max 200 bytes)")
+	entry := mustFindEntryByPlaceholder(t, tab, "Type message")
 	sendButton := mustFindButtonByText(t, tab, "Send")
 	entry.SetText("first")
 	fynetest.Tap(sendButton)
@@ -1119,6 +1386,17 @@ func TestChatsTabMessageRichTextWrapsLongSingleLine(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		chatAnnotationActions{},
+		chatTaskbarFlashActions{},
+		nil,
+		nil,
+		chatReferenceSource{},
+		nil,
+		chatPinActions{},
+		chatHistoryActions{},
+		chatListPrefsActions{},
+		chatColorActions{},
+		nil,
 	)
 	_ = fynetest.NewTempWindow(t, tab)
 
@@ -1169,6 +1447,17 @@ func TestChatsTabOpenRequestSelectsExistingChat(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		chatAnnotationActions{},
+		chatTaskbarFlashActions{},
+		nil,
+		nil,
+		chatReferenceSource{},
+		nil,
+		chatPinActions{},
+		chatHistoryActions{},
+		chatListPrefsActions{},
+		chatColorActions{},
+		nil,
 	)
 	_ = fynetest.NewTempWindow(t, tab)
 
@@ -1185,35 +1474,192 @@ func TestChatsTabOpenRequestSelectsExistingChat(t *testing.T) {
 	})
 }
 
+func TestChatsTabKeepsSelectedChatUnreadUntilUserReturns(t *testing.T) {
+	if raceDetectorEnabled {
+		t.Skip("Fyne GUI interaction tests are not stable under the race detector")
+	}
+
+	store := domain.NewChatStore()
+	base := time.Now()
+	store.Load(
+		[]domain.Chat{{Key: "channel:0", Title: "General", Type: domain.ChatTypeChannel, UpdatedAt: base}},
+		map[string][]domain.ChatMessage{
+			"channel:0": {{ChatKey: "channel:0", Direction: domain.MessageDirectionIn, Body: "seen", At: base}},
+		},
+	)
+	attention := newUserAttention(false, nil)
+	tab := newChatsTab(
+		nil,
+		store,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		"channel:0",
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		chatAnnotationActions{},
+		chatTaskbarFlashActions{},
+		nil,
+		attention,
+		chatReferenceSource{},
+		nil,
+		chatPinActions{},
+		chatHistoryActions{},
+		chatListPrefsActions{},
+		chatColorActions{},
+		nil,
+	)
+	window := fynetest.NewTempWindow(t, tab)
+	window.Resize(fyne.NewSize(900, 700))
+
+	store.AppendMessage(domain.ChatMessage{
+		ChatKey:   "channel:0",
+		Direction: domain.MessageDirectionIn,
+		Body:      "arrived while away",
+		At:        base.Add(time.Minute),
+	})
+	waitForCondition(t, func() bool {
+		return findRichTextBySubstringAndWrapping(tab, "arrived while away", fyne.TextWrapWord) != nil &&
+			hasUnreadBadge(tab, 1)
+	})
+
+	attention.SetForeground(true)
+	waitForCondition(t, func() bool {
+		return !hasUnreadBadge(tab, 1)
+	})
+}
+
 func TestChatListContextMenuDeleteDisabledForChannel(t *testing.T) {
-	menu := newChatListContextMenu(domain.Chat{Key: "channel:0", Title: "General", Type: domain.ChatTypeChannel}, nil)
-	if len(menu.Items) != 2 {
-		t.Fatalf("expected two menu items, got %d", len(menu.Items))
+	menu := newChatListContextMenu(domain.Chat{Key: "channel:0", Title: "General", Type: domain.ChatTypeChannel}, nil, nil)
+	if len(menu.Items) != 5 {
+		t.Fatalf("expected five menu items, got %d", len(menu.Items))
 	}
 	if menu.Items[0].Label != "Share" {
 		t.Fatalf("unexpected first menu item label: %q", menu.Items[0].Label)
 	}
-	if menu.Items[1].Label != "Delete chat" {
-		t.Fatalf("unexpected second menu item label: %q", menu.Items[1].Label)
+	if menu.Items[1].Label != "Export chat…" || menu.Items[2].Label != "Export all chats…" {
+		t.Fatalf("unexpected export menu item labels: %q, %q", menu.Items[1].Label, menu.Items[2].Label)
 	}
-	if !menu.Items[1].Disabled {
+	if menu.Items[4].Label != "Delete chat" {
+		t.Fatalf("unexpected last menu item label: %q", menu.Items[4].Label)
+	}
+	if !menu.Items[4].Disabled {
 		t.Fatalf("expected delete action to be disabled for channel chat")
 	}
 }
 
 func TestChatListContextMenuDeleteEnabledForDM(t *testing.T) {
-	menu := newChatListContextMenu(domain.Chat{Key: "dm:!12345678", Title: "Alice", Type: domain.ChatTypeDM}, nil)
-	if len(menu.Items) != 1 {
-		t.Fatalf("expected one menu item, got %d", len(menu.Items))
+	menu := newChatListContextMenu(domain.Chat{Key: "dm:!12345678", Title: "Alice", Type: domain.ChatTypeDM}, nil, nil)
+	if len(menu.Items) != 4 {
+		t.Fatalf("expected four menu items, got %d", len(menu.Items))
+	}
+	if menu.Items[0].Label != "Export chat…" {
+		t.Fatalf("unexpected first item label: %q", menu.Items[0].Label)
 	}
-	if menu.Items[0].Label != "Delete chat" {
-		t.Fatalf("unexpected item label: %q", menu.Items[0].Label)
+	if menu.Items[3].Label != "Delete chat" {
+		t.Fatalf("unexpected item label: %q", menu.Items[3].Label)
 	}
-	if menu.Items[0].Disabled {
+	if menu.Items[3].Disabled {
 		t.Fatalf("expected delete action to be enabled for dm chat")
 	}
 }
 
+func TestChatListContextMenuTaskbarFlashToggle(t *testing.T) {
+	enabled := true
+	var got []chatListAction
+	menu := newChatListContextMenu(
+		domain.Chat{Key: "dm:!12345678", Title: "Alice", Type: domain.ChatTypeDM},
+		&enabled,
+		func(_ domain.Chat, action chatListAction) { got = append(got, action) },
+	)
+	if len(menu.Items) != 5 {
+		t.Fatalf("expected five menu items, got %d", len(menu.Items))
+	}
+	item := menu.Items[2]
+	if item.Label != "Flash taskbar on new messages" || !item.Checked {
+		t.Fatalf("unexpected taskbar flash item: %q checked=%v", item.Label, item.Checked)
+	}
+	item.Action()
+	if len(got) != 1 || got[0] != chatListActionTaskbarFlash {
+		t.Fatalf("expected the taskbar flash action, got %v", got)
+	}
+}
+
+func TestChatListContextMenuMuteItems(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	chat := domain.Chat{
+		Key:           "channel:0",
+		Title:         "General",
+		Type:          domain.ChatTypeChannel,
+		Notifications: domain.ChatNotificationPrefs{Muted: true, MentionsOnly: true},
+	}
+	var got []chatListAction
+	menu := withChatMuteItems(newChatListContextMenu(chat, nil, nil), chat, now, func(_ domain.Chat, action chatListAction) {
+		got = append(got, action)
+	})
+	if len(menu.Items) != 6 {
+		t.Fatalf("expected six menu items, got %d", len(menu.Items))
+	}
+	item := menu.Items[3]
+	if item.Label != "Notifications" || !item.Checked || item.ChildMenu == nil {
+		t.Fatalf("unexpected notifications item: %q checked=%v", item.Label, item.Checked)
+	}
+	if menu.Items[5].Label != "Delete chat" {
+		t.Fatalf("expected delete to stay last, got %q", menu.Items[5].Label)
+	}
+	children := item.ChildMenu.Items
+	if children[1].Label != "Unmute" || !children[len(children)-1].Checked {
+		t.Fatalf("unexpected notification submenu: %q, mentions checked=%v", children[1].Label, children[len(children)-1].Checked)
+	}
+	children[1].Action()
+	if len(got) != 1 || got[0] != chatListActionUnmute {
+		t.Fatalf("expected the unmute action, got %v", got)
+	}
+}
+
+func TestChatNotificationPrefsForAction(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	prefs, ok := chatNotificationPrefsForAction(domain.ChatNotificationPrefs{}, chatListActionMuteHour, now)
+	if !ok || !prefs.MutedAt(now) || !prefs.MutedUntil.Equal(now.Add(time.Hour)) {
+		t.Fatalf("expected a one hour mute, got %+v", prefs)
+	}
+	prefs, _ = chatNotificationPrefsForAction(prefs, chatListActionMentionsOnly, now)
+	prefs, _ = chatNotificationPrefsForAction(prefs, chatListActionUnmute, now)
+	if prefs.Muted || !prefs.MutedUntil.IsZero() || !prefs.MentionsOnly {
+		t.Fatalf("expected unmuted mentions-only preferences, got %+v", prefs)
+	}
+	if _, ok := chatNotificationPrefsForAction(prefs, chatListActionShare, now); ok {
+		t.Fatalf("expected other actions to be ignored")
+	}
+}
+
+func TestChatTaskbarFlashActionsMenuState(t *testing.T) {
+	prefs := config.TaskbarFlashConfig{Enabled: true, Chats: map[string]bool{"channel:1": true}}
+	actions := chatTaskbarFlashActions{
+		Config: func() config.TaskbarFlashConfig { return prefs },
+		Set:    func(string, bool) error { return nil },
+	}
+
+	if state := actions.menuState("channel:0"); state == nil || *state {
+		t.Fatalf("expected channels to be offered unchecked by default")
+	}
+	if state := actions.menuState("channel:1"); state == nil || !*state {
+		t.Fatalf("expected an enabled channel to be offered checked")
+	}
+	if state := actions.menuState("dm:!12345678"); state == nil || !*state {
+		t.Fatalf("expected direct messages to be offered checked by default")
+	}
+	prefs.Enabled = false
+	if state := actions.menuState("dm:!12345678"); state != nil {
+		t.Fatalf("expected no toggle while taskbar flashing is disabled")
+	}
+}
+
 func TestChatsTabStoreDeleteSelectedDMClearsSelectionAndDisablesComposer(t *testing.T) {
 	if raceDetectorEnabled {
 		t.Skip("Fyne GUI interaction tests are not stable under the race detector")
@@ -1253,6 +1699,17 @@ func TestChatsTabStoreDeleteSelectedDMClearsSelectionAndDisablesComposer(t *test
 		nil,
 		nil,
 		nil,
+		chatAnnotationActions{},
+		chatTaskbarFlashActions{},
+		nil,
+		nil,
+		chatReferenceSource{},
+		nil,
+		chatPinActions{},
+		chatHistoryActions{},
+		chatListPrefsActions{},
+		chatColorActions{},
+		nil,
 	)
 	_ = fynetest.NewTempWindow(t, tab)
 
@@ -1260,7 +1717,7 @@ func TestChatsTabStoreDeleteSelectedDMClearsSelectionAndDisablesComposer(t *test
 		store.DeleteChat("dm:!0000002a")
 	})
 
-	entry := mustFindEntryByPlaceholder(t, tab, "Type message (max 200 bytes)")
+	entry := mustFindEntryByPlaceholder(t, tab, "Type message")
 	sendButton := mustFindButtonByText(t, tab, "Send")
 	waitForCondition(t, func() bool {
 		title := findLabelByPrefix(tab, "No chat selected")
@@ -1269,6 +1726,69 @@ func TestChatsTabStoreDeleteSelectedDMClearsSelectionAndDisablesComposer(t *test
 	})
 }
 
+func TestChatsTabResumesOpenChatAndDraftAfterStoreReload(t *testing.T) {
+	if raceDetectorEnabled {
+		t.Skip("Fyne GUI interaction tests are not stable under the race detector")
+	}
+
+	store := domain.NewChatStore()
+	base := time.Now()
+	chats := []domain.Chat{
+		{Key: "channel:0", Title: "General", Type: domain.ChatTypeChannel, UpdatedAt: base.Add(1 * time.Hour)},
+		{Key: "channel:1", Title: "Hiking", Type: domain.ChatTypeChannel, UpdatedAt: base},
+	}
+	messages := map[string][]domain.ChatMessage{
+		"channel:1": {
+			{ChatKey: "channel:1", Body: "hello", Direction: domain.MessageDirectionIn, Status: domain.MessageStatusSent, At: base},
+		},
+	}
+	store.Load(chats, messages)
+
+	tab := newChatsTab(
+		nil,
+		store,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		"channel:1",
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		chatAnnotationActions{},
+		chatTaskbarFlashActions{},
+		nil,
+		nil,
+		chatReferenceSource{},
+		nil,
+		chatPinActions{},
+		chatHistoryActions{},
+		chatListPrefsActions{},
+		chatColorActions{},
+		nil,
+	)
+	_ = fynetest.NewTempWindow(t, tab)
+
+	entry := mustFindEntryByPlaceholder(t, tab, "Type message")
+	fyne.DoAndWait(func() {
+		entry.SetText("half-composed")
+		store.Reset()
+	})
+	waitForCondition(t, func() bool {
+		return findLabelByPrefix(tab, "No chat selected") != nil
+	})
+
+	fyne.DoAndWait(func() {
+		store.Load(chats, messages)
+	})
+	waitForCondition(t, func() bool {
+		return findLabelByPrefix(tab, "No chat selected") == nil && entry.Text == "half-composed"
+	})
+}
+
 func ptrInt(v int) *int {
 	return &v
 }
@@ -1333,3 +1853,43 @@ func findRichTextBySubstringAndWrapping(root fyne.CanvasObject, substring string
 
 	return nil
 }
+
+type idleChatAttention struct{}
+
+func (idleChatAttention) Active() bool      { return false }
+func (idleChatAttention) OnRegained(func()) {}
+
+func TestChatsTabReportsTotalUnread(t *testing.T) {
+	base := time.Date(2026, 2, 11, 12, 0, 0, 0, time.UTC)
+	store := domain.NewChatStore()
+	store.Load(
+		[]domain.Chat{
+			{Key: "ch:1", Title: "One", Type: domain.ChatTypeChannel, UpdatedAt: base, ReadUpTo: base},
+			{Key: "ch:2", Title: "Two", Type: domain.ChatTypeChannel, UpdatedAt: base},
+		},
+		map[string][]domain.ChatMessage{
+			"ch:1": {
+				{ChatKey: "ch:1", Direction: domain.MessageDirectionIn, Body: "read", At: base},
+				{ChatKey: "ch:1", Direction: domain.MessageDirectionIn, Body: "new", At: base.Add(time.Minute)},
+			},
+			"ch:2": {
+				{ChatKey: "ch:2", Direction: domain.MessageDirectionIn, Body: "one", At: base},
+				{ChatKey: "ch:2", Direction: domain.MessageDirectionIn, Body: "two", At: base.Add(time.Minute)},
+			},
+		},
+	)
+	tab := newChatsTab(
+		nil, store, nil, nil, nil, nil, nil, "ch:1", nil, nil, nil, nil, nil,
+		chatAnnotationActions{}, chatTaskbarFlashActions{}, nil, idleChatAttention{}, chatReferenceSource{}, nil,
+		chatPinActions{}, chatHistoryActions{}, chatListPrefsActions{}, chatColorActions{}, nil,
+	)
+	content, ok := tab.(*chatsTabContent)
+	if !ok {
+		t.Fatalf("expected chats tab content, got %T", tab)
+	}
+	var got []int
+	content.OnUnreadChange(func(unread int) { got = append(got, unread) })
+	if len(got) != 1 || got[0] != 3 {
+		t.Fatalf("expected 3 unread messages in all chats, got %v", got)
+	}
+}
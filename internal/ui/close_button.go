@@ -0,0 +1,161 @@
+package ui
+
+import (
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+const (
+	closeButtonOptionHideToTray = "Hide to the tray"
+	closeButtonOptionQuit       = "Quit the app"
+)
+
+// closeButtonChoice is what the close button of the main window does. The first close
+// and the settings tab both change it, so the settings tab listens for changes.
+type closeButtonChoice struct {
+	save func(action config.CloseButtonAction) error
+
+	mu        sync.Mutex
+	action    config.CloseButtonAction
+	listeners []func(action config.CloseButtonAction)
+}
+
+func newCloseButtonChoice(dep RuntimeDependencies) *closeButtonChoice {
+	cfg := dep.Data.Config
+	if dep.Data.CurrentConfig != nil {
+		cfg = dep.Data.CurrentConfig()
+	}
+	cfg.FillMissingDefaults()
+
+	return &closeButtonChoice{save: dep.Actions.OnSetCloseButtonAction, action: cfg.UI.CloseButton}
+}
+
+// Action returns the current choice.
+func (c *closeButtonChoice) Action() config.CloseButtonAction {
+	if c == nil {
+		return config.CloseButtonHideToTray
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.action
+}
+
+// Set saves the choice and notifies the listeners. The choice is kept unchanged when it
+// can't be saved.
+func (c *closeButtonChoice) Set(action config.CloseButtonAction) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	if c.action == action {
+		c.mu.Unlock()
+
+		return nil
+	}
+	if c.save != nil {
+		if err := c.save(action); err != nil {
+			c.mu.Unlock()
+
+			return err
+		}
+	}
+	c.action = action
+	listeners := append([]func(config.CloseButtonAction){}, c.listeners...)
+	c.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(action)
+	}
+
+	return nil
+}
+
+// OnChange registers a listener called after the choice changes.
+func (c *closeButtonChoice) OnChange(listener func(action config.CloseButtonAction)) {
+	if c == nil || listener == nil {
+		return
+	}
+	c.mu.Lock()
+	c.listeners = append(c.listeners, listener)
+	c.mu.Unlock()
+}
+
+// showCloseButtonPrompt explains what the close button can do and passes the picked
+// action to onChoice. Canceling keeps the window open and asks again next time.
+func showCloseButtonPrompt(window fyne.Window, onChoice func(action config.CloseButtonAction)) {
+	message := widget.NewLabel(i18n.T(
+		"meshgo can keep running in the tray when its window is closed, so messages keep arriving and you are notified about them. You can change this later in Settings.",
+	))
+	message.Wrapping = fyne.TextWrapWord
+
+	prompt := dialog.NewCustomWithoutButtons(i18n.T("Close the window"), container.NewVBox(message), window)
+	choose := func(action config.CloseButtonAction) func() {
+		return func() {
+			prompt.Hide()
+			onChoice(action)
+		}
+	}
+	hide := widget.NewButton(i18n.T("Keep running in the tray"), choose(config.CloseButtonHideToTray))
+	hide.Importance = widget.HighImportance
+	prompt.SetButtons([]fyne.CanvasObject{
+		widget.NewButton(i18n.T("Cancel"), prompt.Hide),
+		widget.NewButton(i18n.T("Quit"), choose(config.CloseButtonQuit)),
+		hide,
+	})
+	prompt.Resize(fyne.NewSize(460, prompt.MinSize().Height))
+	prompt.Show()
+}
+
+// newCloseButtonSelect shows the close button choice in the settings tab. It saves right
+// away, as the first close may change it while the tab is open.
+func newCloseButtonSelect(choice *closeButtonChoice) *widget.Select {
+	closeSelect := widget.NewSelect([]string{i18n.T(closeButtonOptionHideToTray), i18n.T(closeButtonOptionQuit)}, nil)
+	closeSelect.PlaceHolder = i18n.T("Ask on the next close")
+	show := func(action config.CloseButtonAction) {
+		if action == config.CloseButtonAsk {
+			closeSelect.ClearSelected()
+
+			return
+		}
+		closeSelect.SetSelected(closeButtonOptionFromAction(action))
+	}
+	show(choice.Action())
+	closeSelect.OnChanged = func(selected string) {
+		if selected == "" {
+			return
+		}
+		if err := choice.Set(closeButtonActionFromOption(selected)); err != nil {
+			settingsLogger.Warn("failed to change close button action", "error", err)
+			show(choice.Action())
+		}
+	}
+	choice.OnChange(func(action config.CloseButtonAction) {
+		fyne.Do(func() { show(action) })
+	})
+
+	return closeSelect
+}
+
+func closeButtonOptionFromAction(action config.CloseButtonAction) string {
+	if action == config.CloseButtonQuit {
+		return i18n.T(closeButtonOptionQuit)
+	}
+
+	return i18n.T(closeButtonOptionHideToTray)
+}
+
+func closeButtonActionFromOption(value string) config.CloseButtonAction {
+	if value == i18n.T(closeButtonOptionQuit) {
+		return config.CloseButtonQuit
+	}
+
+	return config.CloseButtonHideToTray
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// Sizes of a Meshtastic LoRa frame. A text packet is rejected by the firmware when its
+// encoded payload does not fit in the frame after the packet header, and DMs
+// encrypted with the node keys (PKC) also carry a nonce and an auth tag.
+const (
+	loraFrameBytes        = 255
+	loraHeaderBytes       = 16
+	pkcOverheadBytes      = 12
+	textDataOverheadBytes = 7
+	replyIDOverheadBytes  = 5
+)
+
+// composeLimit is the byte budget of the message being composed.
+type composeLimit struct {
+	Bytes    int
+	Overhead int
+	PKC      bool
+}
+
+// composeTextLimit returns the text budget for a message to chatKey: what is left
+// of the LoRa frame after the header, the PKC nonce and tag for DMs, and the Data
+// framing of the text and of the reply ID.
+func composeTextLimit(chatKey string, replying bool) composeLimit {
+	limit := composeLimit{
+		Overhead: loraHeaderBytes + textDataOverheadBytes,
+		PKC:      domain.IsDMKey(chatKey),
+	}
+	if limit.PKC {
+		limit.Overhead += pkcOverheadBytes
+	}
+	if replying {
+		limit.Overhead += replyIDOverheadBytes
+	}
+	limit.Bytes = loraFrameBytes - limit.Overhead
+
+	return limit
+}
+
+// composeCounterText shows the used bytes and how many are left, or how far over
+// the limit the text is.
+func composeCounterText(byteCount int, limit composeLimit) string {
+	if byteCount > limit.Bytes {
+		return i18n.Tf("%d/%d bytes (%d over)", byteCount, limit.Bytes, byteCount-limit.Bytes)
+	}
+
+	return i18n.Tf("%d/%d bytes (%d left)", byteCount, limit.Bytes, limit.Bytes-byteCount)
+}
+
+// composeLimitTooltip explains where the limit comes from and why sending is blocked.
+func composeLimitTooltip(byteCount int, limit composeLimit) string {
+	encryption := i18n.T("channel")
+	if limit.PKC {
+		encryption = "PKC"
+	}
+	text := i18n.Tf(
+		"Messages are limited to %d bytes of UTF-8 text. A LoRa frame holds %d bytes, %d of them are taken by the header and %s framing.",
+		limit.Bytes, loraFrameBytes, limit.Overhead, encryption,
+	)
+	if byteCount > limit.Bytes {
+		text += " " + i18n.Tf("Remove %d bytes to send the message.", byteCount-limit.Bytes)
+	}
+
+	return text
+}
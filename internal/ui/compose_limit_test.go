@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestComposeTextLimit(t *testing.T) {
+	tests := []struct {
+		name         string
+		chatKey      string
+		replying     bool
+		wantOverhead int
+		wantBytes    int
+		wantPKC      bool
+	}{
+		{name: "channel", chatKey: "ch:general", wantOverhead: 23, wantBytes: 232},
+		{name: "channel reply", chatKey: "ch:general", replying: true, wantOverhead: 28, wantBytes: 227},
+		{name: "direct message", chatKey: "dm:!1234abcd", wantOverhead: 35, wantBytes: 220, wantPKC: true},
+		{name: "direct reply", chatKey: "dm:!1234abcd", replying: true, wantOverhead: 40, wantBytes: 215, wantPKC: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := composeTextLimit(tc.chatKey, tc.replying)
+			if got.Overhead != tc.wantOverhead {
+				t.Fatalf("unexpected overhead: expected %d, got %d", tc.wantOverhead, got.Overhead)
+			}
+			if got.PKC != tc.wantPKC {
+				t.Fatalf("unexpected PKC flag: expected %v, got %v", tc.wantPKC, got.PKC)
+			}
+			if got.Bytes != tc.wantBytes {
+				t.Fatalf("unexpected limit: expected %d, got %d", tc.wantBytes, got.Bytes)
+			}
+		})
+	}
+}
+
+func TestComposeCounterText(t *testing.T) {
+	limit := composeLimit{Bytes: 200, Overhead: 23}
+	tests := []struct {
+		bytes int
+		want  string
+	}{
+		{bytes: 0, want: "0/200 bytes (200 left)"},
+		{bytes: 200, want: "200/200 bytes (0 left)"},
+		{bytes: 202, want: "202/200 bytes (2 over)"},
+	}
+
+	for _, tc := range tests {
+		if got := composeCounterText(tc.bytes, limit); got != tc.want {
+			t.Fatalf("unexpected counter for %d bytes: expected %q, got %q", tc.bytes, tc.want, got)
+		}
+	}
+}
+
+func TestComposeLimitTooltip(t *testing.T) {
+	limit := composeTextLimit("dm:!1234abcd", false)
+	if got := composeLimitTooltip(10, limit); !strings.Contains(got, "PKC") || strings.Contains(got, "Remove") {
+		t.Fatalf("unexpected tooltip within the limit: %q", got)
+	}
+	if got := composeLimitTooltip(225, limit); !strings.Contains(got, "Remove 5 bytes") {
+		t.Fatalf("expected tooltip to explain the blocked send, got %q", got)
+	}
+}
@@ -0,0 +1,238 @@
+package ui
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	meshapp "github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+// activityBlinkDuration is how long an RX/TX light stays lit after the last frame.
+const activityBlinkDuration = 150 * time.Millisecond
+
+// connectionStatusStrip is the status bar at the bottom of the window. It shows the
+// connected endpoint and device, lights up on radio traffic and disconnects or
+// reconnects in one click. Airtime gauges show the channel load reported by the
+// connected device.
+type connectionStatusStrip struct {
+	endpoint     *widget.Label
+	device       *widget.Label
+	channelUtil  *airtimeGauge
+	airUtilTx    *airtimeGauge
+	rx           *activityLight
+	tx           *activityLight
+	toggle       *widget.Button
+	localNode    func() meshapp.LocalNodeSnapshot
+	onDisconnect func()
+	onReconnect  func()
+	connected    bool
+
+	content fyne.CanvasObject
+}
+
+func newConnectionStatusStrip(
+	localNode func() meshapp.LocalNodeSnapshot,
+	onDisconnect func(),
+	onReconnect func(),
+) *connectionStatusStrip {
+	strip := &connectionStatusStrip{
+		endpoint:     widget.NewLabel(""),
+		device:       widget.NewLabel(""),
+		channelUtil:  newAirtimeGauge("ChUtil", domain.ChannelUtilizationLevel),
+		airUtilTx:    newAirtimeGauge("AirTX", domain.AirUtilTxLevel),
+		rx:           newActivityLight("RX"),
+		tx:           newActivityLight("TX"),
+		localNode:    localNode,
+		onDisconnect: onDisconnect,
+		onReconnect:  onReconnect,
+	}
+	strip.endpoint.Truncation = fyne.TextTruncateEllipsis
+	strip.device.Importance = widget.LowImportance
+	strip.toggle = widget.NewButton(i18n.T("Disconnect"), strip.toggleConnection)
+	strip.toggle.Importance = widget.LowImportance
+	if onDisconnect == nil || onReconnect == nil {
+		strip.toggle.Hide()
+	}
+	strip.content = container.NewBorder(
+		nil,
+		nil,
+		nil,
+		container.NewHBox(
+			strip.device,
+			strip.channelUtil.content,
+			strip.airUtilTx.content,
+			strip.rx.content,
+			strip.tx.content,
+			strip.toggle,
+		),
+		strip.endpoint,
+	)
+
+	return strip
+}
+
+func (s *connectionStatusStrip) Content() fyne.CanvasObject {
+	return s.content
+}
+
+// SetStatus shows status and the details of the connected device.
+func (s *connectionStatusStrip) SetStatus(status busmsg.ConnectionStatus) {
+	s.endpoint.SetText(formatConnStatus(status, ""))
+	s.device.SetText(connectionStripDeviceText(s.localNode))
+	s.setAirtime()
+	s.connected = status.State == busmsg.ConnectionStateConnected || status.State == busmsg.ConnectionStateConnecting
+	if s.connected {
+		s.toggle.SetText(i18n.T("Disconnect"))
+	} else {
+		s.toggle.SetText(i18n.T("Reconnect"))
+	}
+}
+
+func (s *connectionStatusStrip) toggleConnection() {
+	if s.connected {
+		if s.onDisconnect != nil {
+			s.onDisconnect()
+		}
+
+		return
+	}
+	if s.onReconnect != nil {
+		s.onReconnect()
+	}
+}
+
+// StartActivity blinks the RX/TX lights on raw frames until the returned stop is called.
+func (s *connectionStatusStrip) StartActivity(messageBus bus.MessageBus) func() {
+	if messageBus == nil {
+		return func() {}
+	}
+	inSub := messageBus.Subscribe(bus.TopicRawFrameIn)
+	outSub := messageBus.Subscribe(bus.TopicRawFrameOut)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case _, ok := <-inSub:
+				if !ok {
+					return
+				}
+				s.rx.Blink()
+			case _, ok := <-outSub:
+				if !ok {
+					return
+				}
+				s.tx.Blink()
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			close(done)
+			messageBus.Unsubscribe(inSub, bus.TopicRawFrameIn)
+			messageBus.Unsubscribe(outSub, bus.TopicRawFrameOut)
+		})
+	}
+}
+
+// setAirtime shows the airtime last reported by the connected device.
+func (s *connectionStatusStrip) setAirtime() {
+	var node domain.Node
+	if s.localNode != nil {
+		node = s.localNode().Node
+	}
+	s.channelUtil.Set(node.ChannelUtilization)
+	s.airUtilTx.Set(node.AirUtilTx)
+}
+
+// connectionStripDeviceText is the short name and firmware of the connected device.
+func connectionStripDeviceText(localNode func() meshapp.LocalNodeSnapshot) string {
+	if localNode == nil {
+		return ""
+	}
+	snapshot := localNode()
+	if strings.TrimSpace(snapshot.ID) == "" {
+		return ""
+	}
+	parts := make([]string, 0, 2)
+	if name := strings.TrimSpace(snapshot.Node.ShortName); name != "" {
+		parts = append(parts, name)
+	} else {
+		parts = append(parts, strings.TrimSpace(snapshot.ID))
+	}
+	if firmware := strings.TrimSpace(snapshot.Node.FirmwareVersion); firmware != "" {
+		parts = append(parts, i18n.Tf("firmware %s", firmware))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// activityLight is a small dot with a caption that lights up for a moment.
+type activityLight struct {
+	dot     *canvas.Circle
+	content fyne.CanvasObject
+
+	mu       sync.Mutex
+	litUntil time.Time
+}
+
+func newActivityLight(caption string) *activityLight {
+	light := &activityLight{dot: canvas.NewCircle(theme.Color(theme.ColorNameDisabled))}
+	size := theme.CaptionTextSize()
+	light.content = container.NewHBox(
+		container.NewCenter(container.NewGridWrap(fyne.NewSquareSize(size*0.75), light.dot)),
+		canvas.NewText(caption, theme.Color(theme.ColorNameForeground)),
+	)
+
+	return light
+}
+
+// Blink lights the dot up and turns it off activityBlinkDuration after the last call.
+// It may be called from any goroutine.
+func (l *activityLight) Blink() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	wasLit := !l.litUntil.IsZero()
+	l.litUntil = time.Now().Add(activityBlinkDuration)
+	if wasLit {
+		return
+	}
+	fyne.Do(func() { l.setLit(true) })
+	time.AfterFunc(activityBlinkDuration, l.expire)
+}
+
+func (l *activityLight) expire() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if remaining := time.Until(l.litUntil); remaining > 0 {
+		time.AfterFunc(remaining, l.expire)
+
+		return
+	}
+	l.litUntil = time.Time{}
+	fyne.Do(func() { l.setLit(false) })
+}
+
+func (l *activityLight) setLit(lit bool) {
+	if lit {
+		l.dot.FillColor = theme.Color(theme.ColorNameSuccess)
+	} else {
+		l.dot.FillColor = theme.Color(theme.ColorNameDisabled)
+	}
+	l.dot.Refresh()
+}
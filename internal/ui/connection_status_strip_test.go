@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/widget"
+
+	meshapp "github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+func TestConnectionStripDeviceText(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot meshapp.LocalNodeSnapshot
+		want     string
+	}{
+		{name: "unknown device", snapshot: meshapp.LocalNodeSnapshot{}, want: ""},
+		{
+			name:     "short name and firmware",
+			snapshot: meshapp.LocalNodeSnapshot{ID: "!0000abcd", Node: domain.Node{ShortName: "ABCD", FirmwareVersion: "2.6.11"}},
+			want:     "ABCD, firmware 2.6.11",
+		},
+		{
+			name:     "id without name",
+			snapshot: meshapp.LocalNodeSnapshot{ID: "!0000abcd"},
+			want:     "!0000abcd",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := connectionStripDeviceText(func() meshapp.LocalNodeSnapshot { return tt.snapshot })
+			if got != tt.want {
+				t.Fatalf("unexpected device text: expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConnectionStatusStripToggle(t *testing.T) {
+	var disconnects, reconnects int
+	strip := newConnectionStatusStrip(nil, func() { disconnects++ }, func() { reconnects++ })
+
+	strip.SetStatus(busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnected})
+	if strip.toggle.Text != "Disconnect" {
+		t.Fatalf("unexpected toggle text: expected %q, got %q", "Disconnect", strip.toggle.Text)
+	}
+	strip.toggle.OnTapped()
+	if disconnects != 1 || reconnects != 0 {
+		t.Fatalf("expected one disconnect, got %d disconnects and %d reconnects", disconnects, reconnects)
+	}
+
+	strip.SetStatus(busmsg.ConnectionStatus{State: busmsg.ConnectionStateDisconnected})
+	if strip.toggle.Text != "Reconnect" {
+		t.Fatalf("unexpected toggle text: expected %q, got %q", "Reconnect", strip.toggle.Text)
+	}
+	strip.toggle.OnTapped()
+	if reconnects != 1 {
+		t.Fatalf("expected one reconnect, got %d", reconnects)
+	}
+}
+
+func TestConnectionStatusStripAirtimeGauges(t *testing.T) {
+	channelUtil := 31.5
+	snapshot := meshapp.LocalNodeSnapshot{ID: "!0000abcd", Node: domain.Node{ChannelUtilization: &channelUtil}}
+	strip := newConnectionStatusStrip(func() meshapp.LocalNodeSnapshot { return snapshot }, nil, nil)
+
+	strip.SetStatus(busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnected})
+	if !strip.channelUtil.content.Visible() {
+		t.Fatalf("expected channel utilization gauge to be visible")
+	}
+	if strip.channelUtil.value.Importance != widget.WarningImportance {
+		t.Fatalf("unexpected channel utilization importance: expected %v, got %v", widget.WarningImportance, strip.channelUtil.value.Importance)
+	}
+	if strip.airUtilTx.content.Visible() {
+		t.Fatalf("expected air utilization gauge without data to be hidden")
+	}
+}
@@ -20,6 +20,8 @@ type connectionStatusPresenter struct {
 	statusLabel    *widget.Label
 	sidebarIcon    *widget.Icon
 	localShortName func() string
+	strip          *connectionStatusStrip
+	tray           *trayIcon
 
 	mu      sync.RWMutex
 	current busmsg.ConnectionStatus
@@ -48,6 +50,22 @@ func (p *connectionStatusPresenter) SidebarIcon() *widget.Icon {
 	return p.sidebarIcon
 }
 
+// SetStrip makes the presenter keep strip up to date as well.
+func (p *connectionStatusPresenter) SetStrip(strip *connectionStatusStrip) {
+	p.strip = strip
+	if strip != nil {
+		strip.SetStatus(p.CurrentStatus())
+	}
+}
+
+// SetTray makes the presenter show the connection state on the tray icon.
+func (p *connectionStatusPresenter) SetTray(tray *trayIcon) {
+	p.tray = tray
+	if tray != nil {
+		tray.SetConnectionStatus(p.CurrentStatus())
+	}
+}
+
 func (p *connectionStatusPresenter) Set(status busmsg.ConnectionStatus, variant fyne.ThemeVariant) {
 	p.mu.Lock()
 	p.current = status
@@ -84,6 +102,12 @@ func (p *connectionStatusPresenter) applyUI(status busmsg.ConnectionStatus, vari
 		localShortName = p.localShortName()
 	}
 	applyConnStatusUI(p.window, p.statusLabel, p.sidebarIcon, status, variant, localShortName)
+	if p.strip != nil {
+		p.strip.SetStatus(status)
+	}
+	if p.tray != nil {
+		p.tray.SetConnectionStatus(status)
+	}
 }
 
 func formatConnStatus(status busmsg.ConnectionStatus, localShortName string) string {
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+func databaseMaintenanceStatusText(status app.DatabaseMaintenanceStatus, known bool) string {
+	if !known {
+		return i18n.T("Database maintenance has not run yet. It runs daily, starting a few minutes after launch.")
+	}
+	formatter := currentDisplayFormatter()
+	report := status.Report
+	if status.Err != nil {
+		return i18n.Tf("Database maintenance on %s failed: %s", formatter.DateTime(report.StartedAt), status.Err)
+	}
+
+	steps := make([]string, 0, 3)
+	if report.Checkpointed {
+		steps = append(steps, i18n.T("WAL checkpoint"))
+	}
+	if report.Analyzed {
+		steps = append(steps, "ANALYZE")
+	}
+	free := formatter.Number("%.0f%%", 100*report.FreeRatio())
+	if report.Vacuumed {
+		steps = append(steps, i18n.Tf("VACUUM of %s free space", free))
+	} else {
+		steps = append(steps, i18n.Tf("no VACUUM needed (%s free)", free))
+	}
+
+	return i18n.Tf(
+		"Last database maintenance: %s in %s; %s",
+		formatter.DateTime(report.StartedAt),
+		report.FinishedAt.Sub(report.StartedAt).Round(time.Millisecond),
+		strings.Join(steps, ", "),
+	)
+}
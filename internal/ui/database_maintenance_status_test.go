@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/persistence"
+)
+
+func TestDatabaseMaintenanceStatusText(t *testing.T) {
+	started := time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local)
+	report := persistence.MaintenanceReport{
+		StartedAt:    started,
+		FinishedAt:   started.Add(1500 * time.Millisecond),
+		PageCount:    1000,
+		FreePages:    250,
+		Checkpointed: true,
+		Analyzed:     true,
+		Vacuumed:     true,
+	}
+
+	tests := []struct {
+		name   string
+		status app.DatabaseMaintenanceStatus
+		known  bool
+		want   []string
+	}{
+		{name: "never ran", want: []string{"has not run yet"}},
+		{name: "vacuumed", status: app.DatabaseMaintenanceStatus{Report: report}, known: true, want: []string{"in 1.5s", "WAL checkpoint, ANALYZE, VACUUM of 25% free space"}},
+		{
+			name:   "no vacuum",
+			status: app.DatabaseMaintenanceStatus{Report: persistence.MaintenanceReport{StartedAt: started, FinishedAt: started, PageCount: 10, FreePages: 1, Analyzed: true}},
+			known:  true,
+			want:   []string{"ANALYZE, no VACUUM needed (10% free)"},
+		},
+		{name: "failed", status: app.DatabaseMaintenanceStatus{Report: report, Err: errors.New("database is locked")}, known: true, want: []string{"failed: database is locked"}},
+	}
+	for _, tc := range tests {
+		got := databaseMaintenanceStatusText(tc.status, tc.known)
+		for _, want := range tc.want {
+			if !strings.Contains(got, want) {
+				t.Fatalf("%s: expected %q in %q", tc.name, want, got)
+			}
+		}
+	}
+}
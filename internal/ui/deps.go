@@ -2,13 +2,18 @@ package ui
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"fyne.io/fyne/v2"
 
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/chatexport"
 	"github.com/skobkin/meshgo/internal/config"
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/historyimport"
+	"github.com/skobkin/meshgo/internal/platform"
 	"github.com/skobkin/meshgo/internal/radio"
 	"github.com/skobkin/meshgo/internal/radio/busmsg"
 	app_generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
@@ -88,7 +93,11 @@ type NodeOverviewAction interface {
 	RequestTelemetry(ctx context.Context, targetNodeID string, kind radio.TelemetryRequestKind) error
 	ListTelemetryHistory(ctx context.Context, nodeID string, limit int) ([]domain.NodeTelemetryHistoryEntry, error)
 	ListPositionHistory(ctx context.Context, nodeID string, limit int) ([]domain.NodePositionHistoryEntry, error)
+	ListPositionTrack(ctx context.Context, nodeID string, from, to time.Time) ([]domain.NodeTrackPoint, error)
 	ListIdentityHistory(ctx context.Context, nodeID string, limit int) ([]domain.NodeIdentityHistoryEntry, error)
+	ListTracerouteHistory(ctx context.Context, nodeID string, limit int) ([]domain.TracerouteRecord, error)
+	ListSignalHistory(ctx context.Context, nodeID string, limit int) ([]domain.NodeSignalHistoryEntry, error)
+	EstimateBatteryRuntime(ctx context.Context, nodeID string) (domain.BatteryEstimate, bool, error)
 }
 
 // NodeFavoriteAction handles marking remote nodes as favorite on local node DB.
@@ -96,6 +105,18 @@ type NodeFavoriteAction interface {
 	SetFavorite(ctx context.Context, targetNodeID string, favorite bool) error
 }
 
+// NodeIgnoreAction handles adding remote nodes to the ignore list of the local node.
+type NodeIgnoreAction interface {
+	SetIgnored(ctx context.Context, targetNodeID string, ignored bool) error
+}
+
+// EmergencyModeAction toggles the emergency preset on the local node and app.
+type EmergencyModeAction interface {
+	Active() bool
+	Activate(ctx context.Context, target app.NodeSettingsTarget) error
+	Deactivate(ctx context.Context) error
+}
+
 // DataDependencies contains read-only state consumed by UI tabs.
 type DataDependencies struct {
 	Config            config.AppConfig
@@ -108,6 +129,10 @@ type DataDependencies struct {
 	LocalNodeSnapshot func() app.LocalNodeSnapshot
 	CurrentConfig     func() config.AppConfig
 	CurrentConnStatus func() (busmsg.ConnectionStatus, bool)
+	// DatabaseRepairNotice is shown once on startup when a corrupted database was rebuilt.
+	DatabaseRepairNotice string
+	// DatabaseMaintenanceStatus reports the latest periodic database maintenance pass.
+	DatabaseMaintenanceStatus func() (app.DatabaseMaintenanceStatus, bool)
 }
 
 // ActionDependencies contains user-triggered operations invoked from UI.
@@ -117,21 +142,72 @@ type ActionDependencies struct {
 	OnSave                    func(cfg config.AppConfig) error
 	OnChatSelected            func(chatKey string)
 	OnDeleteDMChat            func(chatKey string) error
+	OnDeleteChatMessage       func(message domain.ChatMessage) error
+	OnSetChatTaskbarFlash     func(chatKey string, enabled bool) error
+	OnSetChatListPrefs        func(prefs config.ChatListConfig) error
+	OnSetNodeListPrefs        func(prefs config.NodeListConfig) error
+	OnSetChatNotifications    func(chatKey string, prefs domain.ChatNotificationPrefs) error
+	OnMarkChatRead            func(chatKey string, readUpTo time.Time)
+	OnDeleteNode              func(nodeID string) error
+	OnSetNodeNotes            func(nodeID, alias, note string) error
+	OnSetNodeTags             func(nodeID string, tags []string) error
+	OnSetNodeMuted            func(nodeID string, muted bool) error
+	OnSetNodePresenceAlert    func(nodeID string, alert config.NodePresenceAlert) error
+	OnSetNotificationsMuted   func(muted bool, until time.Time) error
+	OnSetChannelColor         func(chatKey, color string) error
+	OnSetCloseButtonAction    func(action config.CloseButtonAction) error
+	ListRecentlyDeleted       func() ([]domain.DeletedItem, error)
+	LoadDiagnostics           func(ctx context.Context) (app.RuntimeDiagnostics, error)
+	RecentLogLines            func(component string, limit int) []string
+	OnRestoreDeleted          func(item domain.DeletedItem) error
+	OnSaveMessageAnnotation   func(annotation domain.MessageAnnotation) error
+	ListMessageAnnotations    func() ([]domain.MessageAnnotation, error)
+	ListAnnotatedMessages     func() ([]domain.AnnotatedMessage, error)
+	OnPinMessage              func(chatKey, deviceMessageID string) error
+	OnUnpinMessage            func(chatKey, deviceMessageID string) error
+	ListMessagePins           func() ([]domain.MessagePin, error)
+	SearchChatMessages        func(chatKey, query string) ([]domain.ChatMessage, error)
+	LoadOlderChatMessages     func(chatKey string) (int, error)
+	LoadStatistics            func(ctx context.Context, since time.Time, nodeLimit int) (domain.MeshStatistics, error)
+	ExportChats               func(ctx context.Context, w io.Writer, opts chatexport.Options) error
+	ExportRawPacketLog        func(ctx context.Context, w io.Writer) (int, error)
+	ImportHistory             func(ctx context.Context, path string) (historyimport.Report, error)
+	BackupAppData             func(ctx context.Context, path string) (app.AppDataBackup, error)
+	RestoreAppData            func(ctx context.Context, path string) (app.AppDataBackup, error)
+	UploadDiagnostics         func(ctx context.Context) (app.DiagnosticsUpload, error)
+	RunDatabaseMaintenance    func(ctx context.Context) (app.DatabaseMaintenanceStatus, error)
 	OnMapViewportChanged      func(zoom, x, y int)
 	OnMapDisplayConfigChanged func(cfg config.MapDisplayConfig)
 	OnClearDB                 func() error
 	OnClearCache              func() error
 	OnStartUpdateChecker      func()
-	OnQuit                    func()
-	NodeSettings              NodeSettingsAction
-	NodeOverview              NodeOverviewAction
-	NodeFavorite              NodeFavoriteAction
+	// OnDisconnect closes the radio connection until OnReconnect.
+	OnDisconnect  func()
+	OnReconnect   func()
+	OnQuit        func()
+	NodeSettings  NodeSettingsAction
+	NodeOverview  NodeOverviewAction
+	NodeFavorite  NodeFavoriteAction
+	NodeIgnore    NodeIgnoreAction
+	EmergencyMode EmergencyModeAction
 }
 
 // PlatformDependencies contains OS-specific helpers used by UI actions.
 type PlatformDependencies struct {
 	BluetoothScanner      BluetoothScanner
 	OpenBluetoothSettings func() error
+	IdleTime              func() (time.Duration, error)
+	// NewDesktopNotifier opens native notifications that report clicks. Nil or an error
+	// keeps plain Fyne notifications.
+	NewDesktopNotifier func() (platform.DesktopNotifier, error)
+	// NewTaskbarAttention highlights the taskbar entry. Nil or an error disables taskbar
+	// flashing.
+	NewTaskbarAttention func() (platform.TaskbarAttention, error)
+	// NewWindowPlacement saves and restores the window position. Nil or an error keeps
+	// only the window size.
+	NewWindowPlacement func() (platform.WindowPlacement, error)
+	// NewSoundPlayer plays message sounds. Nil disables them.
+	NewSoundPlayer func() platform.SoundPlayer
 }
 
 // UIHooks overrides default UI interactions for tests and custom embedding.
@@ -16,6 +16,11 @@ func BuildRuntimeDependencies(rt *meshapp.Runtime, launch LaunchOptions, onQuit
 		},
 		Platform: PlatformDependencies{
 			OpenBluetoothSettings: systemActions.OpenBluetoothSettings,
+			IdleTime:              platform.IdleTime,
+			NewDesktopNotifier:    newDesktopNotifier,
+			NewTaskbarAttention:   newTaskbarAttention,
+			NewWindowPlacement:    platform.NewWindowPlacement,
+			NewSoundPlayer:        platform.NewSoundPlayer,
 		},
 	}
 
@@ -35,15 +40,62 @@ func BuildRuntimeDependencies(rt *meshapp.Runtime, launch LaunchOptions, onQuit
 		CurrentConnStatus: rt.CurrentConnStatus,
 		CurrentConfig:     rt.CurrentConfig,
 	}
+	if rt.Persistence.RepairReport != nil {
+		dep.Data.DatabaseRepairNotice = rt.Persistence.RepairReport.Summary()
+	}
+	if rt.Persistence.Maintainer != nil {
+		dep.Data.DatabaseMaintenanceStatus = rt.Persistence.Maintainer.LastStatus
+		dep.Actions.RunDatabaseMaintenance = rt.Persistence.Maintainer.Run
+	}
 
 	dep.Platform = PlatformDependencies{
 		BluetoothScanner:      NewTinyGoBluetoothScanner(defaultBluetoothScanDuration),
 		OpenBluetoothSettings: systemActions.OpenBluetoothSettings,
+		IdleTime:              platform.IdleTime,
+		NewDesktopNotifier:    newDesktopNotifier,
+		NewTaskbarAttention:   newTaskbarAttention,
+		NewWindowPlacement:    platform.NewWindowPlacement,
+		NewSoundPlayer:        platform.NewSoundPlayer,
 	}
 
 	dep.Actions.OnSave = rt.SaveAndApplyConfig
 	dep.Actions.OnChatSelected = rt.RememberSelectedChat
 	dep.Actions.OnDeleteDMChat = rt.DeleteDMChat
+	dep.Actions.OnDeleteChatMessage = rt.DeleteChatMessage
+	dep.Actions.OnSetChatTaskbarFlash = rt.SetChatTaskbarFlash
+	dep.Actions.OnSetChatListPrefs = rt.SetChatListPrefs
+	dep.Actions.OnSetNodeListPrefs = rt.SetNodeListPrefs
+	dep.Actions.OnSetChatNotifications = rt.SetChatNotifications
+	dep.Actions.OnMarkChatRead = rt.MarkChatRead
+	dep.Actions.OnSetNodeNotes = rt.SetNodeNotes
+	dep.Actions.OnSetNodeTags = rt.SetNodeTags
+	dep.Actions.OnSetNodeMuted = rt.SetNodeNotificationsMuted
+	dep.Actions.OnSetNodePresenceAlert = rt.SetNodePresenceAlert
+	dep.Actions.OnSetNotificationsMuted = rt.SetNotificationsMuted
+	dep.Actions.OnSetChannelColor = rt.SetChannelColor
+	dep.Actions.OnSetCloseButtonAction = rt.SetCloseButtonAction
+	dep.Actions.OnDeleteNode = rt.DeleteNode
+	dep.Actions.ListRecentlyDeleted = rt.ListRecentlyDeleted
+	dep.Actions.LoadDiagnostics = rt.Diagnostics
+	if rt.Core.LogManager != nil {
+		dep.Actions.RecentLogLines = rt.Core.LogManager.RecentLines
+	}
+	dep.Actions.OnRestoreDeleted = rt.RestoreDeletedItem
+	dep.Actions.OnSaveMessageAnnotation = rt.SaveMessageAnnotation
+	dep.Actions.ListMessageAnnotations = rt.ListMessageAnnotations
+	dep.Actions.ListAnnotatedMessages = rt.ListAnnotatedMessages
+	dep.Actions.OnPinMessage = rt.PinMessage
+	dep.Actions.OnUnpinMessage = rt.UnpinMessage
+	dep.Actions.ListMessagePins = rt.ListMessagePins
+	dep.Actions.SearchChatMessages = rt.SearchChatMessages
+	dep.Actions.LoadOlderChatMessages = rt.LoadOlderChatMessages
+	dep.Actions.LoadStatistics = rt.LoadStatistics
+	dep.Actions.ExportChats = rt.ExportChats
+	dep.Actions.ExportRawPacketLog = rt.ExportRawPacketLog
+	dep.Actions.ImportHistory = rt.ImportHistory
+	dep.Actions.BackupAppData = rt.BackupAppData
+	dep.Actions.RestoreAppData = rt.RestoreAppData
+	dep.Actions.UploadDiagnostics = rt.UploadDiagnostics
 	dep.Actions.OnMapViewportChanged = rt.RememberMapViewport
 	dep.Actions.OnClearDB = rt.ClearDatabase
 	dep.Actions.OnClearCache = rt.ClearCache
@@ -51,6 +103,11 @@ func BuildRuntimeDependencies(rt *meshapp.Runtime, launch LaunchOptions, onQuit
 
 	if rt.Connectivity.Radio != nil {
 		dep.Actions.Sender = rt.Connectivity.Radio
+		dep.Actions.OnDisconnect = rt.Connectivity.Radio.Disconnect
+		dep.Actions.OnReconnect = rt.Connectivity.Radio.Reconnect
+		if rt.Connectivity.Outbox != nil {
+			dep.Actions.Sender = rt.Connectivity.Outbox
+		}
 		var overviewLoggerArg = (*slog.Logger)(nil)
 		if rt.Core.LogManager != nil {
 			overviewLoggerArg = rt.Core.LogManager.Logger("ui.node_overview")
@@ -61,10 +118,12 @@ func BuildRuntimeDependencies(rt *meshapp.Runtime, launch LaunchOptions, onQuit
 			rt.Persistence.NodeTelemetryRepo,
 			rt.Persistence.NodePositionRepo,
 			rt.Persistence.NodeIdentityHistory,
+			rt.Persistence.TracerouteRepo,
+			rt.Persistence.NodeSignalHistory,
 			rt.CurrentConnStatus,
 			overviewLoggerArg,
 		)
-		dep.Actions.NodeFavorite = meshapp.NewNodeFavoriteService(
+		nodeFavorites := meshapp.NewNodeFavoriteService(
 			rt.Connectivity.Radio,
 			rt.Domain.NodeStore,
 			rt.Domain.Bus,
@@ -72,21 +131,35 @@ func BuildRuntimeDependencies(rt *meshapp.Runtime, launch LaunchOptions, onQuit
 			rt.CurrentConnStatus,
 			overviewLoggerArg,
 		)
+		dep.Actions.NodeFavorite = nodeFavorites
+		dep.Actions.NodeIgnore = nodeFavorites
+		var nodeSettings *meshapp.NodeSettingsService
+		var emergencyLogger *slog.Logger
 		if rt.Core.LogManager != nil {
-			dep.Actions.NodeSettings = meshapp.NewNodeSettingsService(
+			nodeSettings = meshapp.NewNodeSettingsService(
 				rt.Domain.Bus,
 				rt.Connectivity.Radio,
 				rt.CurrentConnStatus,
 				rt.Core.LogManager.Logger("ui.node_settings"),
 			)
+			emergencyLogger = rt.Core.LogManager.Logger("app.emergency_mode")
 		} else {
-			dep.Actions.NodeSettings = meshapp.NewNodeSettingsService(
+			nodeSettings = meshapp.NewNodeSettingsService(
 				rt.Domain.Bus,
 				rt.Connectivity.Radio,
 				rt.CurrentConnStatus,
 				nil,
 			)
 		}
+		dep.Actions.NodeSettings = nodeSettings
+		dep.Actions.EmergencyMode = meshapp.NewEmergencyModeService(
+			nodeSettings,
+			rt.CurrentConfig,
+			rt.SaveAndApplyConfig,
+			meshapp.DefaultEmergencyModeProfile(),
+			rt.Core.Paths.EmergencyModeStateFile,
+			emergencyLogger,
+		)
 	}
 	if rt.Connectivity.Traceroute != nil {
 		dep.Actions.Traceroute = rt.Connectivity.Traceroute
@@ -94,3 +167,11 @@ func BuildRuntimeDependencies(rt *meshapp.Runtime, launch LaunchOptions, onQuit
 
 	return dep
 }
+
+func newDesktopNotifier() (platform.DesktopNotifier, error) {
+	return platform.NewDesktopNotifier("meshgo")
+}
+
+func newTaskbarAttention() (platform.TaskbarAttention, error) {
+	return platform.NewTaskbarAttention("meshgo")
+}
@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	meshapp "github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+const (
+	diagnosticsRefreshInterval = 5 * time.Second
+	diagnosticsLoadTimeout     = 3 * time.Second
+)
+
+// diagnosticsStatus is the part of the status bar showing the database size, counts,
+// memory use and connection uptime. Clicking it opens the detailed diagnostics.
+type diagnosticsStatus struct {
+	window fyne.Window
+	load   func(ctx context.Context) (meshapp.RuntimeDiagnostics, error)
+	button *widget.Button
+}
+
+func newDiagnosticsStatus(
+	window fyne.Window,
+	load func(ctx context.Context) (meshapp.RuntimeDiagnostics, error),
+) *diagnosticsStatus {
+	status := &diagnosticsStatus{window: window, load: load}
+	status.button = widget.NewButton("", status.showDetails)
+	status.button.Importance = widget.LowImportance
+	if load == nil {
+		status.button.Hide()
+	}
+
+	return status
+}
+
+func (s *diagnosticsStatus) Content() fyne.CanvasObject {
+	return s.button
+}
+
+// Start refreshes the summary periodically until the returned stop is called.
+func (s *diagnosticsStatus) Start() func() {
+	if s.load == nil {
+		return func() {}
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(diagnosticsRefreshInterval)
+		defer ticker.Stop()
+		for {
+			s.refresh()
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+func (s *diagnosticsStatus) refresh() {
+	diag, err := s.loadDiagnostics()
+	if err != nil {
+		appLogger.Debug("diagnostics refresh incomplete", "error", err)
+	}
+	fyne.Do(func() { s.button.SetText(diagnosticsSummary(diag)) })
+}
+
+func (s *diagnosticsStatus) loadDiagnostics() (meshapp.RuntimeDiagnostics, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), diagnosticsLoadTimeout)
+	defer cancel()
+
+	return s.load(ctx)
+}
+
+func (s *diagnosticsStatus) showDetails() {
+	if s.load == nil || s.window == nil {
+		return
+	}
+	form := widget.NewForm()
+	errLabel := widget.NewLabel("")
+	errLabel.Wrapping = fyne.TextWrapWord
+	errLabel.Importance = widget.DangerImportance
+	fill := func() {
+		diag, err := s.loadDiagnostics()
+		form.Items = nil
+		for _, row := range diagnosticsDetails(diag) {
+			value := widget.NewLabel(row.value)
+			value.Selectable = true
+			form.Append(row.label, value)
+		}
+		form.Refresh()
+		if err != nil {
+			errLabel.SetText(i18n.Tf("Some values could not be read: %s", err))
+			errLabel.Show()
+		} else {
+			errLabel.Hide()
+		}
+		s.button.SetText(diagnosticsSummary(diag))
+	}
+	fill()
+	refresh := widget.NewButton(i18n.T("Refresh"), fill)
+	content := container.NewVBox(form, errLabel, container.NewHBox(refresh))
+	dialog.NewCustom(i18n.T("Diagnostics"), i18n.T("Close"), content, s.window).Show()
+}
+
+// diagnosticsSummary is the short status bar text.
+func diagnosticsSummary(diag meshapp.RuntimeDiagnostics) string {
+	parts := []string{
+		i18n.Tf("DB %s", formatByteSize(uint64(max(diag.DatabaseBytes, 0)))),
+		i18n.Tf("%d nodes", diag.Nodes),
+		i18n.Tf("%d msgs", diag.Messages),
+		i18n.Tf("RAM %s", formatByteSize(diag.HeapBytes)),
+	}
+	if uptime := diag.ConnectionUptime(); uptime > 0 {
+		parts = append(parts, i18n.Tf("Up %s", formatUptime(uptime)))
+	} else {
+		parts = append(parts, i18n.T("Offline"))
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+type diagnosticsRow struct {
+	label string
+	value string
+}
+
+func diagnosticsDetails(diag meshapp.RuntimeDiagnostics) []diagnosticsRow {
+	database := formatByteSize(uint64(max(diag.DatabaseBytes, 0)))
+	if diag.DatabaseEncrypted {
+		database = i18n.Tf("%s (encrypted, kept in memory)", database)
+	}
+	connectedFor := i18n.T("Not connected")
+	if uptime := diag.ConnectionUptime(); uptime > 0 {
+		connectedFor = formatUptime(uptime)
+	}
+	connection := formatConnStatus(diag.Connection, "")
+	if strings.TrimSpace(connection) == "" {
+		connection = i18n.T("Unknown")
+	}
+	writer := diag.Writer
+
+	return []diagnosticsRow{
+		{label: i18n.T("Database size"), value: database},
+		{label: i18n.T("Nodes"), value: fmt.Sprintf("%d", diag.Nodes)},
+		{label: i18n.T("Chats"), value: fmt.Sprintf("%d", diag.Chats)},
+		{label: i18n.T("Messages"), value: fmt.Sprintf("%d", diag.Messages)},
+		{label: i18n.T("Memory in use"), value: formatByteSize(diag.HeapBytes)},
+		{label: i18n.T("Memory reserved"), value: formatByteSize(diag.SystemBytes)},
+		{label: i18n.T("Goroutines"), value: fmt.Sprintf("%d", diag.Goroutines)},
+		{label: i18n.T("Garbage collections"), value: fmt.Sprintf("%d", diag.GCCycles)},
+		{label: i18n.T("Connection"), value: connection},
+		{label: i18n.T("Connected for"), value: connectedFor},
+		{label: i18n.T("App running for"), value: formatUptime(diag.At.Sub(diag.StartedAt))},
+		{label: i18n.T("Database writes"), value: i18n.Tf(
+			"%d in %d batches, %d failed, %s average latency",
+			writer.Commands, writer.Batches, writer.Failed, writer.AverageLatency().Round(time.Millisecond),
+		)},
+	}
+}
+
+// formatByteSize shows a size in binary units, e.g. "12.5 MB".
+func formatByteSize(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value := float64(bytes)
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		value /= unit
+		if value < unit || suffix == "GB" {
+			return currentDisplayFormatter().Number("%.1f "+suffix, value)
+		}
+	}
+
+	return fmt.Sprintf("%d B", bytes)
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	meshapp "github.com/skobkin/meshgo/internal/app"
+)
+
+func TestFormatByteSize(t *testing.T) {
+	tests := []struct {
+		bytes uint64
+		want  string
+	}{
+		{bytes: 0, want: "0 B"},
+		{bytes: 1023, want: "1023 B"},
+		{bytes: 1536, want: "1.5 KB"},
+		{bytes: 12*1024*1024 + 512*1024, want: "12.5 MB"},
+		{bytes: 3 * 1024 * 1024 * 1024 * 1024, want: "3072.0 GB"},
+	}
+	for _, tc := range tests {
+		if got := formatByteSize(tc.bytes); got != tc.want {
+			t.Fatalf("%d bytes: expected %q, got %q", tc.bytes, tc.want, got)
+		}
+	}
+}
+
+func TestDiagnosticsSummary(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	diag := meshapp.RuntimeDiagnostics{
+		At:            at,
+		DatabaseBytes: 2 * 1024 * 1024,
+		Nodes:         42,
+		Messages:      1200,
+		HeapBytes:     48 * 1024 * 1024,
+	}
+
+	if got, want := diagnosticsSummary(diag), "DB 2.0 MB · 42 nodes · 1200 msgs · RAM 48.0 MB · Offline"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	diag.ConnectedSince = at.Add(-(time.Hour + 5*time.Minute))
+	if got, want := diagnosticsSummary(diag), "DB 2.0 MB · 42 nodes · 1200 msgs · RAM 48.0 MB · Up 1h 5m"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
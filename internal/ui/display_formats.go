@@ -0,0 +1,306 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/geo"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// displayFormatter renders times, numbers, temperatures and coordinates
+// with locale preferences already resolved from "auto".
+type displayFormatter struct {
+	clock12h     bool
+	dateFormat   config.DateFormat
+	decimalComma bool
+	fahrenheit   bool
+	coordinates  config.CoordinateFormat
+	// relativeMessageTimes shows chat message times as the time elapsed, e.g. "5 min ago".
+	relativeMessageTimes bool
+	daySeparators        bool
+}
+
+var activeDisplayFormatter atomic.Pointer[displayFormatter]
+
+// setDisplayFormats applies format preferences to every view rendered afterwards.
+func setDisplayFormats(formats config.FormatsConfig) {
+	formatter := newDisplayFormatter(formats, systemLocale())
+	activeDisplayFormatter.Store(&formatter)
+}
+
+// currentDisplayFormatter returns the applied formatter. Until preferences are
+// applied it uses locale-neutral formats: ISO dates, 24-hour clock, decimal point and Celsius.
+func currentDisplayFormatter() displayFormatter {
+	if formatter := activeDisplayFormatter.Load(); formatter != nil {
+		return *formatter
+	}
+
+	return newDisplayFormatter(config.FormatsConfig{}, "")
+}
+
+func newDisplayFormatter(formats config.FormatsConfig, locale string) displayFormatter {
+	region := localeRegion(locale)
+	formatter := displayFormatter{
+		clock12h:     localeUses12hClock(region),
+		dateFormat:   localeDateFormat(region),
+		decimalComma: localeUsesDecimalComma(region),
+		fahrenheit:   localeUsesFahrenheit(region),
+		coordinates:  formats.CoordinateFormat,
+
+		relativeMessageTimes: formats.MessageTimestamp == config.MessageTimestampRelative,
+		daySeparators:        formats.ChatDaySeparators,
+	}
+
+	switch formats.TimeFormat {
+	case config.TimeFormat24h:
+		formatter.clock12h = false
+	case config.TimeFormat12h:
+		formatter.clock12h = true
+	}
+	switch formats.DateFormat {
+	case config.DateFormatISO, config.DateFormatDMY, config.DateFormatMDY:
+		formatter.dateFormat = formats.DateFormat
+	}
+	switch formats.DecimalSeparator {
+	case config.DecimalSeparatorPoint:
+		formatter.decimalComma = false
+	case config.DecimalSeparatorComma:
+		formatter.decimalComma = true
+	}
+	switch formats.TemperatureUnit {
+	case config.TemperatureUnitCelsius:
+		formatter.fahrenheit = false
+	case config.TemperatureUnitFahrenheit:
+		formatter.fahrenheit = true
+	}
+	if formatter.coordinates == "" {
+		formatter.coordinates = config.CoordinateFormatDecimal
+	}
+
+	return formatter
+}
+
+// Time formats a clock time without seconds, e.g. "15:04" or "3:04 PM".
+func (f displayFormatter) Time(at time.Time) string {
+	if f.clock12h {
+		return at.Local().Format("3:04 PM")
+	}
+
+	return at.Local().Format("15:04")
+}
+
+// Date formats a calendar date.
+func (f displayFormatter) Date(at time.Time) string {
+	switch f.dateFormat {
+	case config.DateFormatDMY:
+		return at.Local().Format("02/01/2006")
+	case config.DateFormatMDY:
+		return at.Local().Format("01/02/2006")
+	default:
+		return at.Local().Format("2006-01-02")
+	}
+}
+
+// DateTime formats a date with a clock time without seconds.
+func (f displayFormatter) DateTime(at time.Time) string {
+	return f.Date(at) + " " + f.Time(at)
+}
+
+// DateTimeSeconds formats a date with a clock time including seconds.
+func (f displayFormatter) DateTimeSeconds(at time.Time) string {
+	if f.clock12h {
+		return f.Date(at) + " " + at.Local().Format("3:04:05 PM")
+	}
+
+	return f.Date(at) + " " + at.Local().Format("15:04:05")
+}
+
+// MessageTime formats the time of a chat message. Relative times fall back to the
+// date and time for messages older than a day.
+func (f displayFormatter) MessageTime(at, now time.Time) string {
+	if !f.relativeMessageTimes {
+		return f.Time(at)
+	}
+	elapsed := now.Sub(at)
+	switch {
+	case elapsed < time.Minute:
+		return i18n.T("just now")
+	case elapsed < time.Hour:
+		return i18n.Tf("%d min ago", int(elapsed/time.Minute))
+	case elapsed < 24*time.Hour:
+		return i18n.Tf("%d h ago", int(elapsed/time.Hour))
+	default:
+		return f.DateTime(at)
+	}
+}
+
+// DaySeparator formats the date shown between chat messages of different days,
+// e.g. "Today" or "Monday, 2026-03-02".
+func (f displayFormatter) DaySeparator(at, now time.Time) string {
+	day := localDay(at)
+	today := localDay(now)
+	switch {
+	case day.Equal(today):
+		return i18n.T("Today")
+	case day.Equal(today.AddDate(0, 0, -1)):
+		return i18n.T("Yesterday")
+	default:
+		return i18n.Tf("%s, %s", i18n.T(day.Weekday().String()), f.Date(at))
+	}
+}
+
+// DaySeparators reports whether chats show the date between messages of different days.
+func (f displayFormatter) DaySeparators() bool {
+	return f.daySeparators
+}
+
+func localDay(at time.Time) time.Time {
+	year, month, day := at.Local().Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// Number formats a value with a printf verb and swaps the decimal separator when needed.
+func (f displayFormatter) Number(format string, value float64) string {
+	out := fmt.Sprintf(format, value)
+	if f.decimalComma {
+		out = strings.ReplaceAll(out, ".", ",")
+	}
+
+	return out
+}
+
+// Temperature formats a Celsius reading in the preferred unit with one decimal.
+func (f displayFormatter) Temperature(celsius float64) string {
+	if f.fahrenheit {
+		return f.Number("%.1f F", celsius*9/5+32)
+	}
+
+	return f.Number("%.1f C", celsius)
+}
+
+// Latitude formats a latitude on its own. Grid formats cover both axes at once,
+// so they fall back to decimal degrees here; see Coordinates.
+func (f displayFormatter) Latitude(value float64) string {
+	if f.coordinates == config.CoordinateFormatDMS {
+		return geo.FormatDMS(value, "N", "S")
+	}
+
+	return f.Number("%.6f", value)
+}
+
+// Longitude formats a longitude on its own, like Latitude.
+func (f displayFormatter) Longitude(value float64) string {
+	if f.coordinates == config.CoordinateFormatDMS {
+		return geo.FormatDMS(value, "E", "W")
+	}
+
+	return f.Number("%.6f", value)
+}
+
+// Coordinates formats a position in the preferred format, e.g. "18S UJ 23486 06483" for MGRS.
+func (f displayFormatter) Coordinates(lat, lon float64) string {
+	switch f.coordinates {
+	case config.CoordinateFormatMGRS:
+		// MGRS does not cover the poles; decimal degrees are still better than nothing.
+		if ref, err := geo.FormatMGRS(lat, lon); err == nil {
+			return ref
+		}
+	case config.CoordinateFormatMaidenhead:
+		return geo.Maidenhead(lat, lon, 3)
+	case config.CoordinateFormatDMS:
+		return f.Latitude(lat) + " " + f.Longitude(lon)
+	}
+	// A decimal comma would be ambiguous with the comma between latitude and longitude.
+	separator := ", "
+	if f.decimalComma {
+		separator = "; "
+	}
+
+	return f.Latitude(lat) + separator + f.Longitude(lon)
+}
+
+// GridLabel names the grid reference shown by Coordinates, or returns "" when
+// the preferred format is not a grid.
+func (f displayFormatter) GridLabel() string {
+	switch f.coordinates {
+	case config.CoordinateFormatMGRS:
+		return "MGRS"
+	case config.CoordinateFormatMaidenhead:
+		return i18n.T("Grid square")
+	default:
+		return ""
+	}
+}
+
+// systemLocale returns the POSIX locale from the environment, e.g. "de_DE.UTF-8".
+func systemLocale() string {
+	for _, name := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if value := strings.TrimSpace(os.Getenv(name)); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// localeRegion extracts the upper-case region code from locales like "en_US.UTF-8" or "pt-BR".
+func localeRegion(locale string) string {
+	locale = strings.TrimSpace(locale)
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	i := strings.IndexAny(locale, "_-")
+	if i < 0 {
+		return ""
+	}
+
+	return strings.ToUpper(locale[i+1:])
+}
+
+func localeUses12hClock(region string) bool {
+	switch region {
+	case "US", "CA", "AU", "NZ", "IN", "PH", "PK", "BD", "EG", "SA", "MY":
+		return true
+	default:
+		return false
+	}
+}
+
+func localeDateFormat(region string) config.DateFormat {
+	switch region {
+	case "":
+		return config.DateFormatISO
+	case "US", "PH":
+		return config.DateFormatMDY
+	case "CN", "JP", "KR", "TW", "HU", "LT", "SE", "CA", "ZA", "MN":
+		return config.DateFormatISO
+	default:
+		return config.DateFormatDMY
+	}
+}
+
+func localeUsesDecimalComma(region string) bool {
+	switch region {
+	case "AT", "BE", "BG", "BY", "CZ", "DE", "DK", "EE", "ES", "FI", "FR", "GR", "HR", "HU", "IT", "LT",
+		"LV", "NL", "NO", "PL", "PT", "RO", "RS", "RU", "SE", "SI", "SK", "TR", "UA",
+		"AR", "BR", "CL", "CO", "ID", "VN", "ZA":
+		return true
+	default:
+		return false
+	}
+}
+
+func localeUsesFahrenheit(region string) bool {
+	switch region {
+	case "US", "LR", "BS", "BZ", "KY", "PW":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,257 @@
+package ui
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/config"
+)
+
+func TestMain(m *testing.M) {
+	// Settings tests apply "auto" formats; pin the locale so output does not depend on the developer machine.
+	_ = os.Setenv("LC_ALL", "C")
+	os.Exit(m.Run())
+}
+
+func TestLocaleRegion(t *testing.T) {
+	tests := map[string]string{
+		"":                 "",
+		"C":                "",
+		"en_US.UTF-8":      "US",
+		"de_DE@euro":       "DE",
+		"pt-br":            "BR",
+		"sr_RS.UTF-8@latn": "RS",
+	}
+	for locale, want := range tests {
+		if got := localeRegion(locale); got != want {
+			t.Fatalf("localeRegion(%q): expected %q, got %q", locale, want, got)
+		}
+	}
+}
+
+func TestDisplayFormatterResolvesLocaleAndOverrides(t *testing.T) {
+	at := time.Date(2026, time.January, 31, 15, 4, 5, 0, time.Local)
+
+	tests := []struct {
+		name        string
+		formats     config.FormatsConfig
+		locale      string
+		wantTime    string
+		wantDate    string
+		wantSeconds string
+		wantNumber  string
+		wantTemp    string
+	}{
+		{
+			name:        "neutral without locale",
+			wantTime:    "15:04",
+			wantDate:    "2026-01-31",
+			wantSeconds: "2026-01-31 15:04:05",
+			wantNumber:  "3.14 V",
+			wantTemp:    "21.5 C",
+		},
+		{
+			name:        "us locale",
+			locale:      "en_US.UTF-8",
+			wantTime:    "3:04 PM",
+			wantDate:    "01/31/2026",
+			wantSeconds: "01/31/2026 3:04:05 PM",
+			wantNumber:  "3.14 V",
+			wantTemp:    "70.7 F",
+		},
+		{
+			name:        "german locale",
+			locale:      "de_DE.UTF-8",
+			wantTime:    "15:04",
+			wantDate:    "31/01/2026",
+			wantSeconds: "31/01/2026 15:04:05",
+			wantNumber:  "3,14 V",
+			wantTemp:    "21,5 C",
+		},
+		{
+			name: "explicit overrides win over locale",
+			formats: config.FormatsConfig{
+				TimeFormat:       config.TimeFormat24h,
+				DateFormat:       config.DateFormatISO,
+				DecimalSeparator: config.DecimalSeparatorComma,
+				TemperatureUnit:  config.TemperatureUnitCelsius,
+			},
+			locale:      "en_US.UTF-8",
+			wantTime:    "15:04",
+			wantDate:    "2026-01-31",
+			wantSeconds: "2026-01-31 15:04:05",
+			wantNumber:  "3,14 V",
+			wantTemp:    "21,5 C",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			formatter := newDisplayFormatter(tc.formats, tc.locale)
+			if got := formatter.Time(at); got != tc.wantTime {
+				t.Fatalf("time: expected %q, got %q", tc.wantTime, got)
+			}
+			if got := formatter.Date(at); got != tc.wantDate {
+				t.Fatalf("date: expected %q, got %q", tc.wantDate, got)
+			}
+			if got := formatter.DateTimeSeconds(at); got != tc.wantSeconds {
+				t.Fatalf("date time: expected %q, got %q", tc.wantSeconds, got)
+			}
+			if got := formatter.Number("%.2f V", 3.14159); got != tc.wantNumber {
+				t.Fatalf("number: expected %q, got %q", tc.wantNumber, got)
+			}
+			if got := formatter.Temperature(21.5); got != tc.wantTemp {
+				t.Fatalf("temperature: expected %q, got %q", tc.wantTemp, got)
+			}
+		})
+	}
+}
+
+func TestDisplayFormatterCoordinates(t *testing.T) {
+	decimal := newDisplayFormatter(config.FormatsConfig{}, "")
+	if got := decimal.Latitude(50.450333); got != "50.450333" {
+		t.Fatalf("expected decimal latitude, got %q", got)
+	}
+
+	dms := newDisplayFormatter(config.FormatsConfig{CoordinateFormat: config.CoordinateFormatDMS}, "")
+	tests := []struct {
+		got  string
+		want string
+	}{
+		{got: dms.Latitude(50.450333), want: "50°27'01.2\"N"},
+		{got: dms.Longitude(-30.523333), want: "30°31'24.0\"W"},
+		{got: dms.Latitude(-0.999999), want: "1°00'00.0\"S"},
+		{got: dms.Longitude(0), want: "0°00'00.0\"E"},
+		{got: dms.Coordinates(50.450333, 30.523333), want: "50°27'01.2\"N 30°31'24.0\"E"},
+		{got: decimal.Coordinates(50.450333, 30.523333), want: "50.450333, 30.523333"},
+	}
+	for _, tc := range tests {
+		if tc.got != tc.want {
+			t.Fatalf("expected %q, got %q", tc.want, tc.got)
+		}
+	}
+}
+
+func TestDisplayFormatterGridCoordinates(t *testing.T) {
+	tests := []struct {
+		name      string
+		formats   config.FormatsConfig
+		lat, lon  float64
+		want      string
+		wantLabel string
+	}{
+		{
+			name:      "mgrs",
+			formats:   config.FormatsConfig{CoordinateFormat: config.CoordinateFormatMGRS},
+			lat:       38.8895,
+			lon:       -77.0352,
+			want:      "18S UJ 23486 06483",
+			wantLabel: "MGRS",
+		},
+		{
+			name:      "mgrs falls back near the pole",
+			formats:   config.FormatsConfig{CoordinateFormat: config.CoordinateFormatMGRS},
+			lat:       89.5,
+			lon:       10,
+			want:      "89.500000, 10.000000",
+			wantLabel: "MGRS",
+		},
+		{
+			name:      "maidenhead",
+			formats:   config.FormatsConfig{CoordinateFormat: config.CoordinateFormatMaidenhead},
+			lat:       50.450333,
+			lon:       30.523333,
+			want:      "KO50gk",
+			wantLabel: "Grid square",
+		},
+		{
+			name:    "decimal comma uses semicolon between axes",
+			formats: config.FormatsConfig{DecimalSeparator: config.DecimalSeparatorComma},
+			lat:     50.5,
+			lon:     30.25,
+			want:    "50,500000; 30,250000",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			formatter := newDisplayFormatter(tc.formats, "")
+			if got := formatter.Coordinates(tc.lat, tc.lon); got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+			if got := formatter.GridLabel(); got != tc.wantLabel {
+				t.Fatalf("expected label %q, got %q", tc.wantLabel, got)
+			}
+		})
+	}
+}
+
+func TestFormatsSettingsFormRoundTrip(t *testing.T) {
+	want := config.FormatsConfig{
+		TimeFormat:        config.TimeFormat12h,
+		DateFormat:        config.DateFormatDMY,
+		DecimalSeparator:  config.DecimalSeparatorComma,
+		TemperatureUnit:   config.TemperatureUnitFahrenheit,
+		CoordinateFormat:  config.CoordinateFormatDMS,
+		MessageTimestamp:  config.MessageTimestampRelative,
+		ChatDaySeparators: true,
+	}
+
+	form := newFormatsSettingsForm(config.Default().UI.Formats)
+	if got := form.read(); got != config.Default().UI.Formats {
+		t.Fatalf("expected defaults to round trip, got %+v", got)
+	}
+	form.set(want)
+	if got := form.read(); got != want {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestDisplayFormatterMessageTime(t *testing.T) {
+	now := time.Date(2026, time.March, 2, 15, 4, 0, 0, time.Local)
+	absolute := newDisplayFormatter(config.FormatsConfig{TimeFormat: config.TimeFormat24h, DateFormat: config.DateFormatISO}, "")
+	relative := newDisplayFormatter(config.FormatsConfig{
+		TimeFormat:       config.TimeFormat24h,
+		DateFormat:       config.DateFormatISO,
+		MessageTimestamp: config.MessageTimestampRelative,
+	}, "")
+
+	tests := []struct {
+		name      string
+		formatter displayFormatter
+		at        time.Time
+		want      string
+	}{
+		{name: "absolute", formatter: absolute, at: now.Add(-5 * time.Minute), want: "14:59"},
+		{name: "just now", formatter: relative, at: now.Add(-20 * time.Second), want: "just now"},
+		{name: "minutes", formatter: relative, at: now.Add(-5 * time.Minute), want: "5 min ago"},
+		{name: "hours", formatter: relative, at: now.Add(-3 * time.Hour), want: "3 h ago"},
+		{name: "older than a day", formatter: relative, at: now.Add(-30 * time.Hour), want: "2026-03-01 09:04"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.formatter.MessageTime(tt.at, now); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDisplayFormatterDaySeparator(t *testing.T) {
+	now := time.Date(2026, time.March, 2, 0, 30, 0, 0, time.Local)
+	formatter := newDisplayFormatter(config.FormatsConfig{DateFormat: config.DateFormatDMY}, "")
+
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{at: now.Add(-10 * time.Minute), want: "Today"},
+		{at: now.Add(-time.Hour), want: "Yesterday"},
+		{at: now.Add(-3 * 24 * time.Hour), want: "Friday, 27/02/2026"},
+	}
+	for _, tt := range tests {
+		if got := formatter.DaySeparator(tt.at, now); got != tt.want {
+			t.Fatalf("day separator for %v: expected %q, got %q", tt.at, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,325 @@
+package ui
+
+import (
+	"image/color"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+
+	"github.com/skobkin/meshgo/internal/config"
+)
+
+// displayScaleCheckInterval is how often the window is checked for a move to another
+// monitor. Fyne does not report monitor changes.
+const displayScaleCheckInterval = 2 * time.Second
+
+// appTheme is the default theme with sizes multiplied by the user UI scale, an optional
+// fixed light or dark variant and an optional accent color.
+type appTheme struct {
+	scale  float32
+	mode   config.ThemeMode
+	accent string
+}
+
+func newAppTheme(display config.DisplayConfig, scale float64) appTheme {
+	mode := display.Theme
+	if mode == "" {
+		mode = config.ThemeModeSystem
+	}
+
+	return appTheme{scale: float32(scale), mode: mode, accent: display.AccentColor}
+}
+
+// isDefault reports whether the theme looks exactly like the default theme.
+func (t appTheme) isDefault() bool {
+	return t.scale == 1 && t.mode == config.ThemeModeSystem && t.accent == ""
+}
+
+func (t appTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	variant = resolveThemeVariant(t.mode, variant)
+	if t.mode == config.ThemeModeHighContrast {
+		if c, ok := highContrastColor(name, t.accent); ok {
+			return c
+		}
+	}
+	if t.accent != "" {
+		switch name {
+		case theme.ColorNamePrimary, theme.ColorNameHyperlink:
+			return theme.PrimaryColorNamed(t.accent)
+		case theme.ColorNameFocus:
+			return accentWithAlpha(t.accent, 0x7f)
+		case theme.ColorNameSelection:
+			return accentWithAlpha(t.accent, 0x3f)
+		}
+	}
+
+	return theme.DefaultTheme().Color(name, variant)
+}
+
+func (t appTheme) Font(style fyne.TextStyle) fyne.Resource {
+	return theme.DefaultTheme().Font(style)
+}
+
+func (t appTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return theme.DefaultTheme().Icon(name)
+}
+
+func (t appTheme) Size(name fyne.ThemeSizeName) float32 {
+	size := theme.DefaultTheme().Size(name)
+	if t.mode == config.ThemeModeHighContrast {
+		switch name {
+		case theme.SizeNameInputBorder, theme.SizeNameSeparatorThickness:
+			size *= 2
+		}
+	}
+
+	return size * t.scale
+}
+
+// highContrastPalette is drawn on black with pure colors, so text and outlines stay
+// readable for users with low vision.
+var highContrastPalette = map[fyne.ThemeColorName]color.Color{
+	theme.ColorNameBackground:          color.Black,
+	theme.ColorNameOverlayBackground:   color.Black,
+	theme.ColorNameMenuBackground:      color.Black,
+	theme.ColorNameHeaderBackground:    color.Black,
+	theme.ColorNameInputBackground:     color.Black,
+	theme.ColorNameButton:              color.NRGBA{R: 0x1a, G: 0x1a, B: 0x1a, A: 0xff},
+	theme.ColorNameDisabledButton:      color.NRGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff},
+	theme.ColorNameForeground:          color.White,
+	theme.ColorNameDisabled:            color.NRGBA{R: 0xb0, G: 0xb0, B: 0xb0, A: 0xff},
+	theme.ColorNamePlaceHolder:         color.NRGBA{R: 0xc8, G: 0xc8, B: 0xc8, A: 0xff},
+	theme.ColorNameInputBorder:         color.White,
+	theme.ColorNameSeparator:           color.White,
+	theme.ColorNameScrollBar:           color.White,
+	theme.ColorNameHover:               color.NRGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xff},
+	theme.ColorNamePressed:             color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0x66},
+	theme.ColorNamePrimary:             color.NRGBA{R: 0xff, G: 0xd7, B: 0x00, A: 0xff},
+	theme.ColorNameForegroundOnPrimary: color.Black,
+	theme.ColorNameFocus:               color.NRGBA{R: 0xff, G: 0xd7, B: 0x00, A: 0xff},
+	theme.ColorNameSelection:           color.NRGBA{R: 0xff, G: 0xd7, B: 0x00, A: 0x66},
+	theme.ColorNameHyperlink:           color.NRGBA{R: 0x00, G: 0xff, B: 0xff, A: 0xff},
+	theme.ColorNameSuccess:             color.NRGBA{R: 0x00, G: 0xff, B: 0x00, A: 0xff},
+	theme.ColorNameForegroundOnSuccess: color.Black,
+	theme.ColorNameWarning:             color.NRGBA{R: 0xff, G: 0xa5, B: 0x00, A: 0xff},
+	theme.ColorNameForegroundOnWarning: color.Black,
+	theme.ColorNameError:               color.NRGBA{R: 0xff, G: 0x50, B: 0x50, A: 0xff},
+	theme.ColorNameForegroundOnError:   color.Black,
+}
+
+// highContrastColor returns the high contrast color of name. An accent color replaces
+// the yellow of primary elements and the focus outline.
+func highContrastColor(name fyne.ThemeColorName, accent string) (color.Color, bool) {
+	if accent != "" {
+		switch name {
+		case theme.ColorNamePrimary, theme.ColorNameFocus:
+			return theme.PrimaryColorNamed(accent), true
+		case theme.ColorNameSelection:
+			return accentWithAlpha(accent, 0x66), true
+		}
+	}
+	c, ok := highContrastPalette[name]
+
+	return c, ok
+}
+
+func accentWithAlpha(accent string, alpha uint8) color.NRGBA {
+	c := color.NRGBAModel.Convert(theme.PrimaryColorNamed(accent)).(color.NRGBA)
+	c.A = alpha
+
+	return c
+}
+
+// resolveThemeVariant returns the variant the app is drawn in for the system variant.
+func resolveThemeVariant(mode config.ThemeMode, system fyne.ThemeVariant) fyne.ThemeVariant {
+	switch mode {
+	case config.ThemeModeDark, config.ThemeModeHighContrast:
+		return theme.VariantDark
+	case config.ThemeModeLight:
+		return theme.VariantLight
+	default:
+		return system
+	}
+}
+
+// trayIconVariant returns the tray icon variant for the app variant.
+func trayIconVariant(style config.TrayIconStyle, appVariant fyne.ThemeVariant) fyne.ThemeVariant {
+	switch style {
+	case config.TrayIconStyleDark:
+		return theme.VariantDark
+	case config.TrayIconStyleLight:
+		return theme.VariantLight
+	default:
+		return appVariant
+	}
+}
+
+// displayScaleRuntime applies the configured theme and the UI scale for the monitor the
+// window is on, and re-applies them when the window moves to a monitor with another
+// pixel density.
+type displayScaleRuntime struct {
+	fyApp  fyne.App
+	window fyne.Window
+
+	mu          sync.Mutex
+	config      config.DisplayConfig
+	monitor     string
+	applied     appTheme
+	appliedTray config.TrayIconStyle
+}
+
+var activeDisplayScale struct {
+	mu      sync.Mutex
+	runtime *displayScaleRuntime
+}
+
+func newDisplayScaleRuntime(fyApp fyne.App, window fyne.Window, cfg config.DisplayConfig) *displayScaleRuntime {
+	r := &displayScaleRuntime{fyApp: fyApp, window: window, config: cfg}
+	activeDisplayScale.mu.Lock()
+	activeDisplayScale.runtime = r
+	activeDisplayScale.mu.Unlock()
+
+	return r
+}
+
+// setDisplayScaleConfig applies saved display preferences to the running window.
+func setDisplayScaleConfig(cfg config.DisplayConfig) {
+	activeDisplayScale.mu.Lock()
+	r := activeDisplayScale.runtime
+	activeDisplayScale.mu.Unlock()
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.config = cfg
+	r.mu.Unlock()
+	r.apply()
+}
+
+// currentDisplayConfig returns the display preferences applied to the running window.
+func currentDisplayConfig() config.DisplayConfig {
+	activeDisplayScale.mu.Lock()
+	r := activeDisplayScale.runtime
+	activeDisplayScale.mu.Unlock()
+	if r == nil {
+		return config.DisplayConfig{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.config
+}
+
+// appThemeVariant returns the variant the app is drawn in, honoring the theme setting.
+func appThemeVariant(fyApp fyne.App) fyne.ThemeVariant {
+	if fyApp == nil || fyApp.Settings() == nil {
+		return theme.VariantDark
+	}
+
+	return resolveThemeVariant(currentDisplayConfig().Theme, fyApp.Settings().ThemeVariant())
+}
+
+// currentMonitorKey returns the key of the monitor the main window is on.
+func currentMonitorKey() string {
+	activeDisplayScale.mu.Lock()
+	r := activeDisplayScale.runtime
+	activeDisplayScale.mu.Unlock()
+	if r == nil {
+		return config.MonitorKey(1)
+	}
+
+	return r.monitorKey()
+}
+
+func (r *displayScaleRuntime) monitorKey() string {
+	if r.window == nil || r.window.Canvas() == nil {
+		return config.MonitorKey(1)
+	}
+
+	return config.MonitorKey(r.window.Canvas().Scale() / r.appliedSystemScale())
+}
+
+// appliedSystemScale is the Fyne settings scale that is already part of the canvas scale.
+func (r *displayScaleRuntime) appliedSystemScale() float32 {
+	if r.fyApp == nil || r.fyApp.Settings().Scale() <= 0 {
+		return 1
+	}
+
+	return r.fyApp.Settings().Scale()
+}
+
+// apply sets the theme for the current monitor if its scale or the theme settings
+// changed. It must run on the UI goroutine.
+func (r *displayScaleRuntime) apply() {
+	if r.fyApp == nil {
+		return
+	}
+	monitor := r.monitorKey()
+	r.mu.Lock()
+	scale := r.config.ScaleFor(monitor)
+	next := newAppTheme(r.config, scale)
+	// The tray icon is refreshed by theme listeners, so a tray change re-applies the theme.
+	changed := next != r.applied || r.config.TrayIcon != r.appliedTray
+	if monitor != r.monitor && r.monitor != "" {
+		appLogger.Info("main window moved to another monitor", "from", r.monitor, "to", monitor, "ui_scale", scale)
+	}
+	r.monitor = monitor
+	r.applied = next
+	r.appliedTray = r.config.TrayIcon
+	r.mu.Unlock()
+	if !changed {
+		return
+	}
+
+	appLogger.Debug("applying UI theme", "monitor", monitor, "ui_scale", scale, "theme", next.mode, "accent", next.accent)
+	if next.isDefault() {
+		r.fyApp.Settings().SetTheme(theme.DefaultTheme())
+
+		return
+	}
+	r.fyApp.Settings().SetTheme(next)
+}
+
+// Start applies the scale and keeps it in sync with the window's monitor. The returned
+// function stops monitor checks.
+func (r *displayScaleRuntime) Start() func() {
+	r.mu.Lock()
+	r.applied = newAppTheme(config.DisplayConfig{}, 1)
+	r.appliedTray = r.config.TrayIcon
+	r.mu.Unlock()
+	r.apply()
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(displayScaleCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				fyne.Do(r.apply)
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+// recoverWindow brings the main window back to the visible screen area, e.g. after the
+// monitor it was on was disconnected.
+func recoverWindow(window fyne.Window) {
+	if window == nil {
+		return
+	}
+	appLogger.Info("recovering main window position")
+	window.Show()
+	window.CenterOnScreen()
+	window.RequestFocus()
+}
@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+
+	"github.com/skobkin/meshgo/internal/config"
+)
+
+func TestAppThemeMultipliesSizes(t *testing.T) {
+	scaled := newAppTheme(config.DisplayConfig{}, 1.5)
+	base := theme.DefaultTheme().Size(theme.SizeNameText)
+	if got := scaled.Size(theme.SizeNameText); got != base*1.5 {
+		t.Fatalf("expected text size %v, got %v", base*1.5, got)
+	}
+}
+
+func TestAppThemeForcesVariantAndAccent(t *testing.T) {
+	light := newAppTheme(config.DisplayConfig{Theme: config.ThemeModeLight}, 1)
+	want := theme.DefaultTheme().Color(theme.ColorNameBackground, theme.VariantLight)
+	if got := light.Color(theme.ColorNameBackground, theme.VariantDark); got != want {
+		t.Fatalf("expected light background %v, got %v", want, got)
+	}
+
+	accented := newAppTheme(config.DisplayConfig{AccentColor: theme.ColorOrange}, 1)
+	if got, want := accented.Color(theme.ColorNamePrimary, theme.VariantDark), theme.PrimaryColorNamed(theme.ColorOrange); got != want {
+		t.Fatalf("expected orange primary %v, got %v", want, got)
+	}
+	if accented.isDefault() || !newAppTheme(config.DisplayConfig{}, 1).isDefault() {
+		t.Fatalf("expected only the unchanged theme to be default")
+	}
+}
+
+func TestAppThemeHighContrast(t *testing.T) {
+	contrast := newAppTheme(config.DisplayConfig{Theme: config.ThemeModeHighContrast}, 1)
+	if got := contrast.Color(theme.ColorNameBackground, theme.VariantLight); got != color.Black {
+		t.Fatalf("unexpected background: expected %v, got %v", color.Black, got)
+	}
+	if got := contrast.Color(theme.ColorNameForeground, theme.VariantLight); got != color.White {
+		t.Fatalf("unexpected foreground: expected %v, got %v", color.White, got)
+	}
+	if got, want := contrast.Size(theme.SizeNameInputBorder), theme.DefaultTheme().Size(theme.SizeNameInputBorder)*2; got != want {
+		t.Fatalf("unexpected input border: expected %v, got %v", want, got)
+	}
+
+	accented := newAppTheme(config.DisplayConfig{Theme: config.ThemeModeHighContrast, AccentColor: theme.ColorBlue}, 1)
+	if got, want := accented.Color(theme.ColorNameFocus, theme.VariantDark), theme.PrimaryColorNamed(theme.ColorBlue); got != want {
+		t.Fatalf("unexpected accented focus: expected %v, got %v", want, got)
+	}
+}
+
+func TestResolveThemeAndTrayVariants(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   config.ThemeMode
+		tray   config.TrayIconStyle
+		system fyne.ThemeVariant
+		app    fyne.ThemeVariant
+		icon   fyne.ThemeVariant
+	}{
+		{name: "system", mode: config.ThemeModeSystem, tray: config.TrayIconStyleAuto, system: theme.VariantLight, app: theme.VariantLight, icon: theme.VariantLight},
+		{name: "forced dark", mode: config.ThemeModeDark, tray: config.TrayIconStyleAuto, system: theme.VariantLight, app: theme.VariantDark, icon: theme.VariantDark},
+		{name: "high contrast", mode: config.ThemeModeHighContrast, tray: config.TrayIconStyleAuto, system: theme.VariantLight, app: theme.VariantDark, icon: theme.VariantDark},
+		{name: "dark tray on light app", mode: config.ThemeModeLight, tray: config.TrayIconStyleDark, system: theme.VariantDark, app: theme.VariantLight, icon: theme.VariantDark},
+	}
+
+	for _, tt := range tests {
+		app := resolveThemeVariant(tt.mode, tt.system)
+		if app != tt.app {
+			t.Fatalf("%s: expected app variant %v, got %v", tt.name, tt.app, app)
+		}
+		if got := trayIconVariant(tt.tray, app); got != tt.icon {
+			t.Fatalf("%s: expected tray variant %v, got %v", tt.name, tt.icon, got)
+		}
+	}
+}
+
+func TestUIScaleLabel(t *testing.T) {
+	if got := uiScaleLabel(1.25); got != "125%" {
+		t.Fatalf("expected 125%%, got %q", got)
+	}
+}
+
+func TestThemeSettingLabelsRoundTrip(t *testing.T) {
+	for _, mode := range []config.ThemeMode{config.ThemeModeSystem, config.ThemeModeDark, config.ThemeModeLight, config.ThemeModeHighContrast} {
+		if got := parseThemeModeLabel(themeModeLabel(mode)); got != mode {
+			t.Fatalf("theme mode %q: expected round trip, got %q", mode, got)
+		}
+	}
+	for _, style := range []config.TrayIconStyle{config.TrayIconStyleAuto, config.TrayIconStyleDark, config.TrayIconStyleLight} {
+		if got := parseTrayIconLabel(trayIconLabel(style)); got != style {
+			t.Fatalf("tray icon style %q: expected round trip, got %q", style, got)
+		}
+	}
+	for _, accent := range append([]string{""}, config.AccentColors...) {
+		if got := parseAccentColorLabel(accentColorLabel(accent)); got != accent {
+			t.Fatalf("accent %q: expected round trip, got %q", accent, got)
+		}
+	}
+}
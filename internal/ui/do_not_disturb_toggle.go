@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// doNotDisturbRefreshInterval is how often the status bar button updates the time left
+// of a timed mute.
+const doNotDisturbRefreshInterval = 30 * time.Second
+
+// doNotDisturbDurations are the timed mutes offered by the do not disturb toggle.
+var doNotDisturbDurations = []struct {
+	label    string
+	duration time.Duration
+}{
+	{label: "For 30 minutes", duration: 30 * time.Minute},
+	{label: "For 1 hour", duration: time.Hour},
+	{label: "For 4 hours", duration: 4 * time.Hour},
+}
+
+// doNotDisturbMenuItems lets the notification mute be turned on for a while or until
+// turned off, and turned off again.
+func doNotDisturbMenuItems(mute *notificationMute) []*fyne.MenuItem {
+	set := func(change func() error) func() {
+		return func() {
+			if err := change(); err != nil {
+				appLogger.Warn("failed to change notifications mute", "error", err)
+			}
+		}
+	}
+	items := make([]*fyne.MenuItem, 0, len(doNotDisturbDurations)+3)
+	for _, option := range doNotDisturbDurations {
+		items = append(items, fyne.NewMenuItem(i18n.T(option.label), set(func() error { return mute.MuteFor(option.duration) })))
+	}
+	muted := mute.Muted()
+	untilOff := fyne.NewMenuItem(i18n.T("Until I turn it off"), set(func() error { return mute.Set(true) }))
+	untilOff.Checked = muted && mute.Until().IsZero()
+	items = append(items, untilOff)
+	if muted {
+		items = append(items,
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem(i18n.T("Turn off do not disturb"), set(func() error { return mute.Set(false) })),
+		)
+	}
+
+	return items
+}
+
+// doNotDisturbTrayLabel names the do not disturb submenu of the tray, with when a timed
+// mute ends. Tray menus are not redrawn while open, so the end is shown instead of the
+// time left.
+func doNotDisturbTrayLabel(mute *notificationMute) string {
+	switch {
+	case !mute.Muted():
+		return i18n.T("Do not disturb")
+	case mute.Until().IsZero():
+		return i18n.T("Do not disturb: on")
+	default:
+		return i18n.Tf("Do not disturb until %s", currentDisplayFormatter().Time(mute.Until()))
+	}
+}
+
+// doNotDisturbTimeLeft returns the time left of a timed mute, rounded up to a minute, so
+// a fresh 30 minute mute shows as 30m.
+func doNotDisturbTimeLeft(until, now time.Time) string {
+	left := until.Sub(now)
+	if left < 0 {
+		left = 0
+	}
+
+	return domain.FormatRoughDuration((left + time.Minute - 1).Truncate(time.Minute))
+}
+
+// doNotDisturbToggle is the status bar button of the notification mute. It shows the
+// time left of a timed mute and opens the do not disturb menu.
+type doNotDisturbToggle struct {
+	window fyne.Window
+	mute   *notificationMute
+	button *widget.Button
+}
+
+func newDoNotDisturbToggle(window fyne.Window, mute *notificationMute) *doNotDisturbToggle {
+	toggle := &doNotDisturbToggle{window: window, mute: mute}
+	toggle.button = widget.NewButtonWithIcon("", theme.VolumeUpIcon(), toggle.Show)
+	toggle.button.Importance = widget.LowImportance
+	toggle.refresh()
+	mute.OnChange(func(bool) {
+		fyne.Do(toggle.refresh)
+	})
+
+	return toggle
+}
+
+func (t *doNotDisturbToggle) Button() fyne.CanvasObject {
+	return t.button
+}
+
+// Start keeps the time left of a timed mute up to date until the returned stop is
+// called.
+func (t *doNotDisturbToggle) Start() func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(doNotDisturbRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				fyne.Do(t.refresh)
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// Show opens the do not disturb menu at the button.
+func (t *doNotDisturbToggle) Show() {
+	if t.window == nil {
+		return
+	}
+	menu := fyne.NewMenu("", doNotDisturbMenuItems(t.mute)...)
+	widget.ShowPopUpMenuAtRelativePosition(menu, t.window.Canvas(), fyne.NewPos(0, 0), t.button)
+}
+
+func (t *doNotDisturbToggle) refresh() {
+	if !t.mute.Muted() {
+		t.button.SetIcon(theme.VolumeUpIcon())
+		t.button.SetText("")
+		t.button.Importance = widget.LowImportance
+		t.button.Refresh()
+
+		return
+	}
+	text := ""
+	if until := t.mute.Until(); !until.IsZero() {
+		text = i18n.Tf("%s left", doNotDisturbTimeLeft(until, t.mute.currentTime()))
+	}
+	t.button.SetIcon(theme.VolumeMuteIcon())
+	t.button.SetText(text)
+	t.button.Importance = widget.WarningImportance
+	t.button.Refresh()
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	fynetest "fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestDoNotDisturbTimeLeft(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		until time.Time
+		want  string
+	}{
+		{until: now.Add(30 * time.Minute), want: "30m"},
+		{until: now.Add(29*time.Minute + time.Second), want: "30m"},
+		{until: now.Add(4 * time.Hour), want: "4h 0m"},
+		{until: now.Add(-time.Minute), want: "0m"},
+	}
+	for _, tt := range tests {
+		if got := doNotDisturbTimeLeft(tt.until, now); got != tt.want {
+			t.Fatalf("until %v: expected %q, got %q", tt.until, tt.want, got)
+		}
+	}
+}
+
+func TestDoNotDisturbToggleShowsTimeLeft(t *testing.T) {
+	app := fynetest.NewApp()
+	t.Cleanup(app.Quit)
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mute := &notificationMute{now: func() time.Time { return now }}
+	t.Cleanup(func() { _ = mute.Set(false) })
+	toggle := newDoNotDisturbToggle(app.NewWindow("dnd"), mute)
+	button := toggle.Button().(*widget.Button)
+	if button.Text != "" || button.Importance != widget.LowImportance {
+		t.Fatalf("expected a plain button while off, got %q", button.Text)
+	}
+
+	if err := mute.MuteFor(time.Hour); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if button.Text != "1h 0m left" || button.Importance != widget.WarningImportance {
+		t.Fatalf("expected the time left to be shown, got %q", button.Text)
+	}
+	now = now.Add(15 * time.Minute)
+	toggle.refresh()
+	if button.Text != "45m left" {
+		t.Fatalf("expected the time left to count down, got %q", button.Text)
+	}
+
+	if err := mute.Set(true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if button.Text != "" || button.Importance != widget.WarningImportance {
+		t.Fatalf("expected no time left until turned off, got %q", button.Text)
+	}
+}
@@ -0,0 +1,251 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/i18n"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+const (
+	errorToastDismissAfter = 10 * time.Second
+	errorToastMaxVisible   = 3
+	errorToastLogLines     = 50
+)
+
+// errorReport is an operational error shown to the user as a toast.
+type errorReport struct {
+	Title string
+	Err   error
+	// Component is the logger component whose recent lines the details show.
+	Component string
+}
+
+// reportErrorFunc shows an error toast. Reporting to a nil func is a no-op, so code
+// built without toasts, like most tests, keeps working.
+type reportErrorFunc func(report errorReport)
+
+func (f reportErrorFunc) report(title string, err error, component string) {
+	if f == nil || err == nil {
+		return
+	}
+	f(errorReport{Title: title, Err: err, Component: component})
+}
+
+// errorToasts shows non-modal error toasts in the bottom right corner of the window.
+// Each toast closes by itself and links to the log lines of the failed operation.
+type errorToasts struct {
+	window       fyne.Window
+	logLines     func(component string, limit int) []string
+	dismissAfter time.Duration
+
+	box     *fyne.Container
+	content fyne.CanvasObject
+	visible []*errorToast
+}
+
+type errorToast struct {
+	report errorReport
+	object fyne.CanvasObject
+}
+
+func newErrorToasts(window fyne.Window, logLines func(component string, limit int) []string) *errorToasts {
+	toasts := &errorToasts{
+		window:       window,
+		logLines:     logLines,
+		dismissAfter: errorToastDismissAfter,
+		box:          container.NewVBox(),
+	}
+	// The empty half keeps toasts out of the way of the list on the left.
+	toasts.content = container.NewBorder(nil, container.NewGridWithColumns(2, layout.NewSpacer(), toasts.box), nil, nil)
+
+	return toasts
+}
+
+// Content is the layer stacked over the main content.
+func (t *errorToasts) Content() fyne.CanvasObject {
+	return t.content
+}
+
+// Report shows a toast from any goroutine.
+func (t *errorToasts) Report(report errorReport) {
+	fyne.Do(func() { t.Show(report) })
+}
+
+// Show adds a toast. A toast with the same title and error as a visible one is dropped.
+func (t *errorToasts) Show(report errorReport) {
+	for _, toast := range t.visible {
+		if toast.report.Title == report.Title && errorText(toast.report.Err) == errorText(report.Err) {
+			return
+		}
+	}
+	toast := &errorToast{report: report}
+	toast.object = t.newToastObject(toast)
+	t.visible = append(t.visible, toast)
+	for len(t.visible) > errorToastMaxVisible {
+		t.dismiss(t.visible[0])
+	}
+	t.box.Add(toast.object)
+	if t.dismissAfter > 0 {
+		time.AfterFunc(t.dismissAfter, func() {
+			fyne.Do(func() { t.dismiss(toast) })
+		})
+	}
+}
+
+func (t *errorToasts) dismiss(toast *errorToast) {
+	for i, visible := range t.visible {
+		if visible == toast {
+			t.visible = append(t.visible[:i], t.visible[i+1:]...)
+			t.box.Remove(toast.object)
+
+			return
+		}
+	}
+}
+
+func (t *errorToasts) newToastObject(toast *errorToast) fyne.CanvasObject {
+	title := widget.NewLabel(toast.report.Title)
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	title.Truncation = fyne.TextTruncateEllipsis
+	message := widget.NewLabel(errorText(toast.report.Err))
+	message.Wrapping = fyne.TextWrapWord
+	details := widget.NewHyperlink(i18n.T("Details"), nil)
+	details.OnTapped = func() { t.showDetails(toast.report) }
+	closeButton := widget.NewButtonWithIcon("", theme.CancelIcon(), func() { t.dismiss(toast) })
+	closeButton.Importance = widget.LowImportance
+
+	icon := widget.NewIcon(theme.NewErrorThemedResource(theme.ErrorIcon()))
+	background := canvas.NewRectangle(theme.Color(theme.ColorNameOverlayBackground))
+	background.StrokeColor = theme.Color(theme.ColorNameError)
+	background.StrokeWidth = 1
+	background.CornerRadius = theme.InputRadiusSize()
+	body := container.NewBorder(
+		title,
+		container.NewHBox(details),
+		container.NewVBox(icon),
+		container.NewVBox(closeButton),
+		message,
+	)
+
+	return container.NewStack(background, container.NewPadded(body))
+}
+
+// showDetails opens the error together with the latest log lines of its component.
+func (t *errorToasts) showDetails(report errorReport) {
+	if t.window == nil {
+		return
+	}
+	var lines []string
+	if t.logLines != nil {
+		lines = t.logLines(report.Component, errorToastLogLines)
+	}
+	text := strings.Join(lines, "\n")
+	if text == "" {
+		text = i18n.T("No recent log lines for this error.")
+	}
+	logLabel := widget.NewLabel(text)
+	logLabel.TextStyle = fyne.TextStyle{Monospace: true}
+	logLabel.Selectable = true
+	message := widget.NewLabel(errorText(report.Err))
+	message.Wrapping = fyne.TextWrapWord
+	message.Selectable = true
+	copyButton := widget.NewButton(i18n.T("Copy log lines"), func() {
+		fyne.CurrentApp().Clipboard().SetContent(text)
+	})
+	if len(lines) == 0 {
+		copyButton.Disable()
+	}
+	content := container.NewBorder(
+		container.NewVBox(message, widget.NewLabel(i18n.T("Recent log lines:"))),
+		container.NewHBox(copyButton),
+		nil,
+		nil,
+		container.NewScroll(logLabel),
+	)
+	details := dialog.NewCustom(report.Title, i18n.T("Close"), content, t.window)
+	details.Resize(fyne.NewSize(760, 420))
+	details.Show()
+}
+
+// StartConnectionWatch shows a toast each time an established connection drops until
+// the returned stop is called. Disconnects asked for by the user aren't reported.
+func (t *errorToasts) StartConnectionWatch(messageBus bus.MessageBus) func() {
+	if messageBus == nil {
+		return func() {}
+	}
+	sub := messageBus.Subscribe(bus.TopicConnStatus)
+	done := make(chan struct{})
+	go func() {
+		var last busmsg.ConnectionState
+		for {
+			select {
+			case <-done:
+				return
+			case raw, ok := <-sub:
+				if !ok {
+					return
+				}
+				status, ok := raw.(busmsg.ConnectionStatus)
+				if !ok {
+					continue
+				}
+				if report, lost := connectionLostReport(last, status); lost {
+					t.Report(report)
+				}
+				last = status.State
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			close(done)
+			messageBus.Unsubscribe(sub, bus.TopicConnStatus)
+		})
+	}
+}
+
+// connectionLostReport reports a connection that was up and is now being re-established.
+// A manual disconnect goes straight to the disconnected state and isn't an error.
+func connectionLostReport(previous busmsg.ConnectionState, status busmsg.ConnectionStatus) (errorReport, bool) {
+	if previous != busmsg.ConnectionStateConnected || status.State != busmsg.ConnectionStateReconnecting {
+		return errorReport{}, false
+	}
+	reason := strings.TrimSpace(status.Err)
+	if reason == "" {
+		reason = i18n.T("The device stopped responding")
+	}
+	target := strings.TrimSpace(status.Target)
+	if target == "" {
+		target = strings.TrimSpace(status.TransportName)
+	}
+	title := i18n.T("Connection lost")
+	if target != "" {
+		title = i18n.Tf("Connection to %s lost", target)
+	}
+
+	return errorReport{Title: title, Err: errors.New(reason), Component: "radio"}, true
+}
+
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	fynetest "fyne.io/fyne/v2/test"
+
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+func TestConnectionLostReport(t *testing.T) {
+	tests := []struct {
+		name      string
+		previous  busmsg.ConnectionState
+		status    busmsg.ConnectionStatus
+		wantLost  bool
+		wantTitle string
+		wantErr   string
+	}{
+		{
+			name:      "connection dropped",
+			previous:  busmsg.ConnectionStateConnected,
+			status:    busmsg.ConnectionStatus{State: busmsg.ConnectionStateReconnecting, Err: "read: EOF", Target: "192.168.1.5"},
+			wantLost:  true,
+			wantTitle: "Connection to 192.168.1.5 lost",
+			wantErr:   "read: EOF",
+		},
+		{
+			name:      "dropped without a reason",
+			previous:  busmsg.ConnectionStateConnected,
+			status:    busmsg.ConnectionStatus{State: busmsg.ConnectionStateReconnecting},
+			wantLost:  true,
+			wantTitle: "Connection lost",
+			wantErr:   "The device stopped responding",
+		},
+		{
+			name:     "manual disconnect",
+			previous: busmsg.ConnectionStateConnected,
+			status:   busmsg.ConnectionStatus{State: busmsg.ConnectionStateDisconnected},
+		},
+		{
+			name:     "retry while reconnecting",
+			previous: busmsg.ConnectionStateConnecting,
+			status:   busmsg.ConnectionStatus{State: busmsg.ConnectionStateReconnecting, Err: "dial: refused"},
+		},
+	}
+	for _, tc := range tests {
+		report, lost := connectionLostReport(tc.previous, tc.status)
+		if lost != tc.wantLost {
+			t.Fatalf("%s: expected lost %v, got %v", tc.name, tc.wantLost, lost)
+		}
+		if !lost {
+			continue
+		}
+		if report.Title != tc.wantTitle || report.Err.Error() != tc.wantErr || report.Component != "radio" {
+			t.Fatalf("%s: unexpected report %+v", tc.name, report)
+		}
+	}
+}
+
+func TestErrorToastsShowDropsDuplicatesAndOldest(t *testing.T) {
+	fyApp := fynetest.NewApp()
+	t.Cleanup(fyApp.Quit)
+	window := fyApp.NewWindow("test")
+
+	toasts := newErrorToasts(window, nil)
+	toasts.dismissAfter = 0
+	toasts.Show(errorReport{Title: "Message not sent", Err: errors.New("not connected")})
+	toasts.Show(errorReport{Title: "Message not sent", Err: errors.New("not connected")})
+	if got := len(toasts.box.Objects); got != 1 {
+		t.Fatalf("expected a duplicate to be dropped: expected 1 toast, got %d", got)
+	}
+
+	for _, text := range []string{"a", "b", "c"} {
+		toasts.Show(errorReport{Title: "Settings not saved", Err: errors.New(text)})
+	}
+	if got := len(toasts.box.Objects); got != errorToastMaxVisible {
+		t.Fatalf("expected %d toasts, got %d", errorToastMaxVisible, got)
+	}
+	if got := toasts.visible[0].report.Err.Error(); got != "a" {
+		t.Fatalf("expected the oldest toast to be dropped first, got %q first", got)
+	}
+
+	toasts.dismiss(toasts.visible[0])
+	if got := len(toasts.box.Objects); got != errorToastMaxVisible-1 {
+		t.Fatalf("expected %d toasts after dismiss, got %d", errorToastMaxVisible-1, got)
+	}
+}
+
+func TestReportErrorFuncIgnoresNilFuncAndError(t *testing.T) {
+	var reports []errorReport
+	reportErrorFunc(nil).report("Message not sent", errors.New("boom"), chatsLogComponent)
+	report := reportErrorFunc(func(r errorReport) { reports = append(reports, r) })
+	report.report("Message not sent", nil, chatsLogComponent)
+	report.report("Message not sent", errors.New("boom"), chatsLogComponent)
+	if len(reports) != 1 || reports[0].Component != chatsLogComponent {
+		t.Fatalf("expected one report from the chats, got %+v", reports)
+	}
+}
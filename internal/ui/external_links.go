@@ -8,6 +8,8 @@ import (
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 var externalURLLogger = slog.With("component", "ui.external_url")
@@ -59,7 +61,7 @@ func newSafeHyperlink(label string, rawURL string, status *widget.Label) fyne.Ca
 		if status == nil {
 			return
 		}
-		status.SetText(fmt.Sprintf("%s link is unavailable: %v", label, err))
+		status.SetText(i18n.Tf("%s link is unavailable: %s", label, err))
 	})
 	fallback.Importance = widget.LowImportance
 
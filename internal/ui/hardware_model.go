@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/skobkin/meshgo/internal/i18n"
+	"github.com/skobkin/meshgo/internal/resources"
+)
+
+// hardwareModel is how a node board is shown to the user.
+type hardwareModel struct {
+	// Code is the hw_model enum name the node reported, e.g. "TBEAM".
+	Code string
+	Name string
+	Icon resources.UIIcon
+}
+
+// hardwareModelNames overrides the generated names of common boards with the names
+// they are sold under.
+var hardwareModelNames = map[string]string{
+	"TLORA_V1":                     "LilyGO T-LoRa V1",
+	"TLORA_V1_1P3":                 "LilyGO T-LoRa V1.3",
+	"TLORA_V2":                     "LilyGO T-LoRa V2",
+	"TLORA_V2_1_1P6":               "LilyGO T-LoRa V2.1-1.6",
+	"TLORA_V2_1_1P8":               "LilyGO T-LoRa V2.1-1.8",
+	"TLORA_T3_S3":                  "LilyGO T3-S3",
+	"TBEAM":                        "LilyGO T-Beam",
+	"TBEAM_V0P7":                   "LilyGO T-Beam V0.7",
+	"LILYGO_TBEAM_S3_CORE":         "LilyGO T-Beam Supreme",
+	"T_ECHO":                       "LilyGO T-Echo",
+	"T_DECK":                       "LilyGO T-Deck",
+	"T_DECK_PRO":                   "LilyGO T-Deck Pro",
+	"T_WATCH_S3":                   "LilyGO T-Watch S3",
+	"T_LORA_PAGER":                 "LilyGO T-LoRa Pager",
+	"HELTEC_V1":                    "Heltec V1",
+	"HELTEC_V2_0":                  "Heltec V2.0",
+	"HELTEC_V2_1":                  "Heltec V2.1",
+	"HELTEC_V3":                    "Heltec V3",
+	"HELTEC_V4":                    "Heltec V4",
+	"HELTEC_WSL_V3":                "Heltec Wireless Stick Lite V3",
+	"HELTEC_MESH_NODE_T114":        "Heltec Mesh Node T114",
+	"HELTEC_MESH_POCKET":           "Heltec MeshPocket",
+	"RAK4631":                      "RAK WisBlock 4631",
+	"RAK11200":                     "RAK WisBlock 11200",
+	"RAK11310":                     "RAK WisBlock 11310",
+	"WISMESH_TAP":                  "RAK WisMesh Tap",
+	"WISMESH_TAP_V2":               "RAK WisMesh Tap V2",
+	"WISMESH_TAG":                  "RAK WisMesh Tag",
+	"TRACKER_T1000_E":              "Seeed SenseCAP T1000-E",
+	"SENSECAP_INDICATOR":           "Seeed SenseCAP Indicator",
+	"WIO_E5":                       "Seeed Wio-E5",
+	"SEEED_XIAO_S3":                "Seeed XIAO ESP32-S3",
+	"XIAO_NRF52_KIT":               "Seeed XIAO nRF52840 Kit",
+	"STATION_G1":                   "B&Q Station G1",
+	"STATION_G2":                   "B&Q Station G2",
+	"NANO_G1":                      "B&Q Nano G1",
+	"NANO_G1_EXPLORER":             "B&Q Nano G1 Explorer",
+	"NANO_G2_ULTRA":                "B&Q Nano G2 Ultra",
+	"RPI_PICO":                     "Raspberry Pi Pico",
+	"RPI_PICO2":                    "Raspberry Pi Pico 2",
+	"PORTDUINO":                    "Linux native",
+	"ANDROID_SIM":                  "Android simulator",
+	"PRIVATE_HW":                   "Private hardware",
+	"NRF52_UNKNOWN":                "Unknown nRF52 board",
+	"NRF52_PROMICRO_DIY":           "nRF52 Pro Micro DIY",
+	"HELTEC_WIRELESS_TRACKER_V1_0": "Heltec Wireless Tracker V1.0",
+	"HELTEC_WIRELESS_PAPER_V1_0":   "Heltec Wireless Paper V1.0",
+}
+
+// hardwareModelWords spells the vendor and product words of generated names.
+var hardwareModelWords = map[string]string{
+	"HELTEC":    "Heltec",
+	"LILYGO":    "LilyGO",
+	"TBEAM":     "T-Beam",
+	"TLORA":     "T-LoRa",
+	"TDISPLAY":  "T-Display",
+	"RAK":       "RAK",
+	"SEEED":     "Seeed",
+	"SENSECAP":  "SenseCAP",
+	"M5STACK":   "M5Stack",
+	"THINKNODE": "ThinkNode",
+	"WISMESH":   "WisMesh",
+	"XIAO":      "XIAO",
+	"NRF52":     "nRF52",
+	"RPI":       "RPi",
+	"DIY":       "DIY",
+	"EINK":      "E-Ink",
+	"EPAPER":    "E-Paper",
+	"LORA":      "LoRa",
+}
+
+// hardwareModelIconPrefixes picks the icon by the start of the enum name. The first
+// match wins, so more specific prefixes go first.
+var hardwareModelIconPrefixes = []struct {
+	prefix string
+	icon   resources.UIIcon
+}{
+	{"T_DECK", resources.UIIconHardwareHandheld},
+	{"T_WATCH", resources.UIIconHardwareHandheld},
+	{"T_LORA_PAGER", resources.UIIconHardwareHandheld},
+	{"WISMESH_TAP", resources.UIIconHardwareHandheld},
+	{"SENSECAP_INDICATOR", resources.UIIconHardwareHandheld},
+	{"CHATTER", resources.UIIconHardwareHandheld},
+	{"UNPHONE", resources.UIIconHardwareHandheld},
+	{"WIPHONE", resources.UIIconHardwareHandheld},
+	{"PICOMPUTER", resources.UIIconHardwareHandheld},
+	{"MESH_TAB", resources.UIIconHardwareHandheld},
+	{"CROWPANEL", resources.UIIconHardwareHandheld},
+	{"M5STACK_CARDPUTER", resources.UIIconHardwareHandheld},
+	{"T_ECHO", resources.UIIconHardwareTracker},
+	{"TRACKER_", resources.UIIconHardwareTracker},
+	{"HELTEC_WIRELESS_TRACKER", resources.UIIconHardwareTracker},
+	{"HELTEC_MESH_POCKET", resources.UIIconHardwareTracker},
+	{"SEEED_WIO_TRACKER", resources.UIIconHardwareTracker},
+	{"WISMESH_TAG", resources.UIIconHardwareTracker},
+	{"THINKNODE", resources.UIIconHardwareTracker},
+	{"RAK", resources.UIIconHardwareModule},
+	{"WISMESH", resources.UIIconHardwareModule},
+	{"PORTDUINO", resources.UIIconHardwareComputer},
+	{"ANDROID_SIM", resources.UIIconHardwareComputer},
+}
+
+// describeHardwareModel resolves the board a node reported. Enum values this build
+// doesn't know arrive as their number and are shown as such.
+func describeHardwareModel(raw string) hardwareModel {
+	code := strings.TrimSpace(raw)
+	switch {
+	case code == "" || code == "UNSET":
+		return hardwareModel{Code: code, Name: i18n.T("Unknown device"), Icon: resources.UIIconHardwareUnknown}
+	case isHardwareModelNumber(code):
+		return hardwareModel{Code: code, Name: i18n.Tf("Unknown model (%s)", code), Icon: resources.UIIconHardwareUnknown}
+	case !isHardwareModelEnumName(code):
+		// Names imported from other apps are already human-readable.
+		return hardwareModel{Code: code, Name: code, Icon: resources.UIIconHardwareBoard}
+	}
+
+	model := hardwareModel{Code: code, Name: hardwareModelNames[code], Icon: resources.UIIconHardwareBoard}
+	if model.Name == "" {
+		model.Name = humanizeHardwareModel(code)
+	}
+	if code == "PRIVATE_HW" {
+		model.Icon = resources.UIIconHardwareUnknown
+	}
+	for _, entry := range hardwareModelIconPrefixes {
+		if strings.HasPrefix(code, entry.prefix) {
+			model.Icon = entry.icon
+
+			break
+		}
+	}
+
+	return model
+}
+
+// humanizeHardwareModel turns an enum name like "HELTEC_VISION_MASTER_E290" into
+// "Heltec Vision Master E290".
+func humanizeHardwareModel(code string) string {
+	parts := strings.Split(code, "_")
+	words := make([]string, 0, len(parts))
+	for i := 0; i < len(parts); i++ {
+		part := parts[i]
+		if part == "" {
+			continue
+		}
+		if word, ok := hardwareModelWords[part]; ok {
+			words = append(words, word)
+
+			continue
+		}
+		// "V2_1" is version 2.1 and "1P6" is 1.6.
+		if len(part) > 1 && part[0] == 'V' && isHardwareModelNumber(part[1:]) {
+			version := part
+			for i+1 < len(parts) && isHardwareModelNumber(parts[i+1]) {
+				i++
+				version += "." + parts[i]
+			}
+			words = append(words, version)
+
+			continue
+		}
+		if before, after, ok := strings.Cut(part, "P"); ok && isHardwareModelNumber(before) && isHardwareModelNumber(after) {
+			words = append(words, before+"."+after)
+
+			continue
+		}
+		if strings.ContainsAny(part, "0123456789") {
+			words = append(words, part)
+
+			continue
+		}
+		words = append(words, part[:1]+strings.ToLower(part[1:]))
+	}
+
+	return strings.Join(words, " ")
+}
+
+func isHardwareModelNumber(raw string) bool {
+	if raw == "" {
+		return false
+	}
+	_, err := strconv.ParseUint(raw, 10, 32)
+
+	return err == nil
+}
+
+func isHardwareModelEnumName(raw string) bool {
+	for _, r := range raw {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hardwareModelIconResource returns the themed icon of the board.
+func hardwareModelIconResource(model hardwareModel) fyne.Resource {
+	return themedUIIconResource(model.Icon)
+}
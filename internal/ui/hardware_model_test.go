@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/resources"
+)
+
+func TestDescribeHardwareModel(t *testing.T) {
+	tests := []struct {
+		raw  string
+		name string
+		icon resources.UIIcon
+	}{
+		{raw: "TBEAM", name: "LilyGO T-Beam", icon: resources.UIIconHardwareBoard},
+		{raw: "HELTEC_V3", name: "Heltec V3", icon: resources.UIIconHardwareBoard},
+		{raw: "RAK4631", name: "RAK WisBlock 4631", icon: resources.UIIconHardwareModule},
+		{raw: "T_DECK", name: "LilyGO T-Deck", icon: resources.UIIconHardwareHandheld},
+		{raw: "TRACKER_T1000_E", name: "Seeed SenseCAP T1000-E", icon: resources.UIIconHardwareTracker},
+		{raw: "PORTDUINO", name: "Linux native", icon: resources.UIIconHardwareComputer},
+		{raw: "HELTEC_VISION_MASTER_E290", name: "Heltec Vision Master E290", icon: resources.UIIconHardwareBoard},
+		{raw: "HELTEC_WIRELESS_TRACKER_V2", name: "Heltec Wireless Tracker V2", icon: resources.UIIconHardwareTracker},
+		{raw: "TBEAM_1_WATT", name: "T-Beam 1 Watt", icon: resources.UIIconHardwareBoard},
+		{raw: "137", name: "Unknown model (137)", icon: resources.UIIconHardwareUnknown},
+		{raw: "UNSET", name: "Unknown device", icon: resources.UIIconHardwareUnknown},
+		{raw: "", name: "Unknown device", icon: resources.UIIconHardwareUnknown},
+		{raw: "T-Echo", name: "T-Echo", icon: resources.UIIconHardwareBoard},
+	}
+	for _, tt := range tests {
+		got := describeHardwareModel(tt.raw)
+		if got.Name != tt.name || got.Icon != tt.icon {
+			t.Fatalf("unexpected model for %q: expected %q/%s, got %q/%s", tt.raw, tt.name, tt.icon, got.Name, got.Icon)
+		}
+	}
+}
+
+func TestHumanizeHardwareModel(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{code: "HELTEC_V2_1", want: "Heltec V2.1"},
+		{code: "TLORA_V2_1_1P6", want: "T-LoRa V2.1 1.6"},
+		{code: "SEEED_WIO_TRACKER_L1_EINK", want: "Seeed Wio Tracker L1 E-Ink"},
+		{code: "ESP32_S3_PICO", want: "ESP32 S3 Pico"},
+	}
+	for _, tt := range tests {
+		if got := humanizeHardwareModel(tt.code); got != tt.want {
+			t.Fatalf("unexpected name for %q: expected %q, got %q", tt.code, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/historyimport"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// historyImportFunc merges history exported by another Meshtastic client from a local file.
+type historyImportFunc func(ctx context.Context, path string) (historyimport.Report, error)
+
+func historyImportReportText(report historyimport.Report) string {
+	lines := []string{
+		i18n.Tf("Source: %s", report.Source.Label()),
+		i18n.Tf(
+			"Messages: %d imported, %d already present, %d chats updated",
+			report.MessagesImported,
+			report.MessagesSkipped(),
+			report.ChatsUpdated,
+		),
+		i18n.Tf("Nodes: %d imported, %d already up to date", report.NodesImported, report.NodesSkipped()),
+	}
+	if report.Source == historyimport.SourcePythonCLI {
+		lines = append(lines, i18n.T("The Python CLI does not keep message history, so only nodes were imported."))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// showHistoryImportDialog asks for an Android app database or Python CLI node JSON
+// and imports it in the background with a cancellable progress dialog.
+func showHistoryImportDialog(window fyne.Window, importHistory historyImportFunc) {
+	if window == nil || importHistory == nil {
+		return
+	}
+
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			settingsLogger.Warn("history import file selection failed", "error", err)
+			dialog.ShowError(err, window)
+
+			return
+		}
+		if reader == nil {
+			return
+		}
+		path := reader.URI().Path()
+		_ = reader.Close()
+		runHistoryImport(window, path, importHistory)
+	}, window)
+	openDialog.Show()
+}
+
+func runHistoryImport(window fyne.Window, path string, importHistory historyImportFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	progressBar := widget.NewProgressBarInfinite()
+	progress := dialog.NewCustom(
+		i18n.T("Importing history"),
+		i18n.T("Cancel"),
+		container.NewVBox(widget.NewLabel(i18n.T("Merging messages and nodes...")), progressBar),
+		window,
+	)
+	progress.SetOnClosed(cancel)
+	progress.Show()
+
+	go func() {
+		report, err := importHistory(ctx, path)
+		fyne.Do(func() {
+			progress.SetOnClosed(nil)
+			progress.Hide()
+			progressBar.Stop()
+			cancel()
+			switch {
+			case errors.Is(err, context.Canceled):
+				settingsLogger.Info("history import canceled", "path", path)
+			case err != nil:
+				settingsLogger.Warn("history import failed", "path", path, "error", err)
+				dialog.ShowError(err, window)
+			default:
+				dialog.ShowInformation(i18n.T("Import complete"), historyImportReportText(report), window)
+			}
+		})
+	}()
+}
@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/historyimport"
+)
+
+func TestHistoryImportReportText(t *testing.T) {
+	report := historyimport.Report{
+		Source:           historyimport.SourceAndroidDatabase,
+		MessagesRead:     10,
+		MessagesImported: 7,
+		ChatsUpdated:     2,
+		NodesRead:        5,
+		NodesImported:    4,
+	}
+	want := "Source: Meshtastic Android database\n" +
+		"Messages: 7 imported, 3 already present, 2 chats updated\n" +
+		"Nodes: 4 imported, 1 already up to date"
+	if got := historyImportReportText(report); got != want {
+		t.Fatalf("unexpected report text:\n%s", got)
+	}
+
+	report.Source = historyimport.SourcePythonCLI
+	if got := historyImportReportText(report); !strings.Contains(got, "only nodes were imported") {
+		t.Fatalf("expected python cli note, got:\n%s", got)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func handleNodeIdentityLogAction(window fyne.Window, dep RuntimeDependencies, node domain.Node) {
@@ -34,9 +35,9 @@ func handleNodeIdentityLogAction(window fyne.Window, dep RuntimeDependencies, no
 }
 
 func showIdentityLogModal(window fyne.Window, dep RuntimeDependencies, node domain.Node) {
-	loading := widget.NewLabel("Loading identity history...")
+	loading := widget.NewLabel(i18n.T("Loading identity history..."))
 	body := container.NewStack(loading)
-	closeButton := widget.NewButton("Close", nil)
+	closeButton := widget.NewButton(i18n.T("Close"), nil)
 	content := container.NewBorder(nil, closeButton, nil, nil, body)
 	modal := widget.NewModalPopUp(content, window.Canvas())
 	closeButton.OnTapped = modal.Hide
@@ -62,11 +63,11 @@ func showIdentityLogModal(window fyne.Window, dep RuntimeDependencies, node doma
 
 func newIdentityLogTable(items []domain.NodeIdentityHistoryEntry) fyne.CanvasObject {
 	headers := []string{
-		"Long name",
-		"Short name",
-		"Public key",
-		"Update",
-		"Observed at",
+		i18n.T("Long name"),
+		i18n.T("Short name"),
+		i18n.T("Public key"),
+		i18n.T("Update"),
+		i18n.T("Observed at"),
 		// "From packet",
 	}
 	rows := make([][]string, 0, len(items))
@@ -75,7 +76,7 @@ func newIdentityLogTable(items []domain.NodeIdentityHistoryEntry) fyne.CanvasObj
 	}
 	if len(rows) == 0 {
 		rows = append(rows, []string{
-			"No identity history yet",
+			i18n.T("No identity history yet"),
 			"", "", "", "", "",
 		})
 	}
@@ -113,7 +114,7 @@ func newIdentityLogTable(items []domain.NodeIdentityHistoryEntry) fyne.CanvasObj
 
 	return container.NewBorder(
 		container.NewVBox(
-			widget.NewLabelWithStyle("Identity log", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			widget.NewLabelWithStyle(i18n.T("Identity log"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 			widget.NewSeparator(),
 		),
 		nil,
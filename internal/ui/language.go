@@ -0,0 +1,13 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2/lang"
+
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// applyUILanguage switches UI strings to the configured language. An empty setting
+// follows the system locale.
+func applyUILanguage(setting string) string {
+	return i18n.SetLanguage(i18n.Resolve(setting, lang.SystemLocale().LanguageString()))
+}
@@ -3,36 +3,102 @@ package ui
 import (
 	"context"
 	"log/slog"
-	"sync/atomic"
+	"path/filepath"
 
 	"fyne.io/fyne/v2"
 
 	meshapp "github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/notifications"
 )
 
-func startNotificationService(dep RuntimeDependencies, fyApp fyne.App, startHidden bool) func() {
-	var appForeground atomic.Bool
-	appForeground.Store(!startHidden)
+// startNotificationService owns the app lifecycle hooks: they keep attention in sync
+// with the window focus and stop the notification, taskbar flash and idle polling on
+// exit. flasher may be nil; history, when set, keeps every notification for the
+// notification center.
+func startNotificationService(
+	dep RuntimeDependencies,
+	fyApp fyne.App,
+	attention *userAttention,
+	flasher *taskbarFlasher,
+	history *notifications.History,
+	onNotificationClicked func(notifications.Payload),
+) func() {
 	lifecycle := fyApp.Lifecycle()
 	lifecycle.SetOnEnteredForeground(func() {
-		appForeground.Store(true)
+		attention.SetForeground(true)
 	})
 	lifecycle.SetOnExitedForeground(func() {
-		appForeground.Store(false)
+		attention.SetForeground(false)
 	})
 
 	notificationsCtx, stopNotifications := context.WithCancel(context.Background())
 	lifecycle.SetOnStopped(stopNotifications)
+	attention.Start(notificationsCtx)
+	if flasher != nil {
+		attention.OnRegained(flasher.Cancel)
+		flasher.Start(notificationsCtx, dep.Data.Bus)
+	}
+	logger := slog.With("component", "ui.notifications")
+	var sender notifications.Sender = NewFyneNotificationSender(fyApp)
+	if dep.Platform.NewDesktopNotifier != nil {
+		notifier, err := dep.Platform.NewDesktopNotifier()
+		if err != nil {
+			logger.Info("notification clicks are unavailable, using Fyne notifications", "error", err)
+		} else {
+			context.AfterFunc(notificationsCtx, func() { _ = notifier.Close() })
+			sender = NewDesktopNotificationSender(notifier, sender, onNotificationClicked)
+		}
+	}
 	notificationService := meshapp.NewNotificationService(
 		dep.Data.Bus,
 		dep.Data.ChatStore,
 		dep.Data.NodeStore,
 		dep.Data.CurrentConfig,
-		appForeground.Load,
-		NewFyneNotificationSender(fyApp),
-		slog.With("component", "ui.notifications"),
+		attention.Foreground,
+		// Desktop notifications have no portable groups, so stack them before they reach the OS.
+		notifications.NewGroupingSender(sender, notifications.DefaultGroupWindow),
+		logger,
 	)
+	var estimateBattery meshapp.BatteryRuntimeEstimator
+	if dep.Actions.NodeOverview != nil {
+		estimateBattery = dep.Actions.NodeOverview.EstimateBatteryRuntime
+	}
+	notificationService.SetLocalNodeID(dep.Data.LocalNodeID)
+	notificationService.SetHistory(history)
+	notificationService.SetBatteryAlertSources(dep.Data.LocalNodeID, estimateBattery)
 	notificationService.Start(notificationsCtx)
+	newMessageSoundService(dep).Start(notificationsCtx)
 
 	return stopNotifications
 }
+
+// newMessageSoundService returns nil when the platform can't play sounds.
+func newMessageSoundService(dep RuntimeDependencies) *meshapp.MessageSoundService {
+	if dep.Platform.NewSoundPlayer == nil {
+		return nil
+	}
+	currentConfig := dep.Data.CurrentConfig
+	if currentConfig == nil {
+		currentConfig = func() config.AppConfig { return dep.Data.Config }
+	}
+
+	return meshapp.NewMessageSoundService(
+		dep.Data.Bus,
+		dep.Data.ChatStore,
+		currentConfig,
+		dep.Platform.NewSoundPlayer(),
+		filepath.Join(dep.Data.Paths.CacheDir, "sounds"),
+		slog.With("component", "ui.sounds"),
+	)
+}
+
+// messageSoundPreview plays a sound from settings, or is nil when sounds can't be played.
+func messageSoundPreview(dep RuntimeDependencies) func(config.SoundEventConfig) error {
+	service := newMessageSoundService(dep)
+	if service == nil {
+		return nil
+	}
+
+	return service.Preview
+}
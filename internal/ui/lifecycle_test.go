@@ -26,7 +26,8 @@ func TestStartNotificationServiceRegistersLifecycleHooks(t *testing.T) {
 		},
 	}
 
-	stop := startNotificationService(dep, app, true)
+	attention := newUserAttention(false, nil)
+	stop := startNotificationService(dep, app, attention, nil, nil, nil)
 	if stop == nil {
 		t.Fatalf("expected notification stop function")
 	}
@@ -41,7 +42,13 @@ func TestStartNotificationServiceRegistersLifecycleHooks(t *testing.T) {
 	}
 
 	lifecycle.onEnteredForeground()
+	if !attention.Foreground() {
+		t.Fatalf("expected entering foreground to be tracked")
+	}
 	lifecycle.onExitedForeground()
+	if attention.Foreground() {
+		t.Fatalf("expected exiting foreground to be tracked")
+	}
 	lifecycle.onStopped()
 	stop()
 	stop()
@@ -1,11 +1,17 @@
 package ui
 
 import (
+	"image/color"
+
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/widget"
 
 	meshapp "github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/config"
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+	"github.com/skobkin/meshgo/internal/notifications"
 	"github.com/skobkin/meshgo/internal/radio/busmsg"
 	"github.com/skobkin/meshgo/internal/resources"
 )
@@ -17,6 +23,18 @@ type mainView struct {
 	applyMapTheme       func(fyne.ThemeVariant)
 	updateIndicator     *updateIndicator
 	connStatusPresenter *connectionStatusPresenter
+	statusStrip         *connectionStatusStrip
+	statusBar           fyne.CanvasObject
+	notificationHistory *notifications.History
+	notificationCenter  *notificationCenter
+	quickConnect        *quickConnect
+	notificationMute    *notificationMute
+	chats               *chatsTabContent
+	doNotDisturb        *doNotDisturbToggle
+	closeButton         *closeButtonChoice
+	diagnostics         *diagnosticsStatus
+	errorToasts         *errorToasts
+	openChat            func(chatKey string)
 }
 
 func buildMainView(
@@ -25,6 +43,7 @@ func buildMainView(
 	window fyne.Window,
 	initialVariant fyne.ThemeVariant,
 	initialStatus busmsg.ConnectionStatus,
+	attention chatAttention,
 ) mainView {
 	settingsConnStatus := widget.NewLabel("")
 	settingsConnStatus.Truncation = fyne.TextTruncateEllipsis
@@ -38,6 +57,17 @@ func buildMainView(
 		}
 	}
 
+	errorToasts := newErrorToasts(window, dep.Actions.RecentLogLines)
+	chatColors := chatColorActions{
+		Config: func() map[string]string {
+			if dep.Data.CurrentConfig != nil {
+				return dep.Data.CurrentConfig().UI.ChannelColors
+			}
+
+			return dep.Data.Config.UI.ChannelColors
+		},
+		Set: dep.Actions.OnSetChannelColor,
+	}
 	chatsTab := newChatsTab(
 		window,
 		dep.Data.ChatStore,
@@ -60,6 +90,51 @@ func buildMainView(
 
 			return dep.Data.Config.UI.Messaging.CompactCyrillicEncoding
 		},
+		chatAnnotationActions{
+			List:          dep.Actions.ListMessageAnnotations,
+			Save:          dep.Actions.OnSaveMessageAnnotation,
+			ListAnnotated: dep.Actions.ListAnnotatedMessages,
+		},
+		chatTaskbarFlashActions{
+			Config: func() config.TaskbarFlashConfig {
+				if dep.Data.CurrentConfig != nil {
+					return dep.Data.CurrentConfig().UI.TaskbarFlash
+				}
+
+				return dep.Data.Config.UI.TaskbarFlash
+			},
+			Set: dep.Actions.OnSetChatTaskbarFlash,
+		},
+		dep.Actions.ExportChats,
+		attention,
+		chatReferenceSource{
+			Nodes:       nodeSnapshot(dep.Data.NodeStore),
+			LocalNodeID: dep.Data.LocalNodeID,
+		},
+		dep.Actions.OnSetChatNotifications,
+		chatPinActions{
+			List:  dep.Actions.ListMessagePins,
+			Pin:   dep.Actions.OnPinMessage,
+			Unpin: dep.Actions.OnUnpinMessage,
+		},
+		chatHistoryActions{
+			Search:    dep.Actions.SearchChatMessages,
+			LoadOlder: dep.Actions.LoadOlderChatMessages,
+			Delete:    dep.Actions.OnDeleteChatMessage,
+			MarkRead:  dep.Actions.OnMarkChatRead,
+		},
+		chatListPrefsActions{
+			Config: func() config.ChatListConfig {
+				if dep.Data.CurrentConfig != nil {
+					return dep.Data.CurrentConfig().UI.ChatList
+				}
+
+				return dep.Data.Config.UI.ChatList
+			},
+			Set: dep.Actions.OnSetChatListPrefs,
+		},
+		chatColors,
+		errorToasts.Report,
 	)
 	nodeActionHandler := func(node domain.Node, action NodeAction) {
 		switch action {
@@ -67,25 +142,61 @@ func buildMainView(
 			handleNodeDirectMessageAction(dep, switchToChats, openDMChat, node)
 		case NodeActionShare:
 			handleNodeShareContactAction(window, dep, node)
+		case NodeActionCopyGrid:
+			handleNodeCopyGridAction(window, node)
+		case NodeActionCopyID, NodeActionCopyHexID, NodeActionCopyPublicKey, NodeActionCopyCoordinates, NodeActionCopyGeoURI:
+			handleNodeCopyAction(window, node, action)
 		case NodeActionFavorite:
 			handleNodeFavoriteAction(window, dep, node, node.IsFavorite == nil || !*node.IsFavorite)
+		case NodeActionMute:
+			handleNodeMuteAction(window, dep, node, !nodeNotificationsMuted(dep, node))
+		case NodeActionPresenceAlerts:
+			handleNodePresenceAction(window, dep, node)
 		case NodeActionTraceroute:
 			handleNodeTracerouteAction(window, dep, node)
 		case NodeActionInfo:
 			showNodeOverviewModal(window, dep, node, switchToChats, openDMChat)
+		case NodeActionDelete:
+			handleNodeDeleteAction(window, dep, node)
 		}
 	}
-	nodesTab := newNodesTabWithActions(dep.Data.NodeStore, dep.Data.LocalNodeID, DefaultNodeRowRenderer(), NodesTabActions{
+	nodesShortcuts := &listShortcutTarget{}
+	nodesTab := newNodesTabWithActions(dep.Data.NodeStore, dep.Data.LocalNodeID, newNodeRowRenderer(nodeSignalTrendSource(dep), storeLocalNode(dep.Data.NodeStore, dep.Data.LocalNodeID)), NodesTabActions{
 		OnNodeSecondaryTapped: func(node domain.Node, position fyne.Position) {
 			showNodeContextMenu(
 				window.Canvas(),
 				position,
 				node,
 				isLocalNode(node, localNodeIDValue(dep.Data.LocalNodeID)),
+				nodeNotificationsMuted(dep, node),
 				nodeActionHandler,
 			)
 		},
+		OnMessageTag: func(tag string, nodes []domain.Node) {
+			handleNodeTagMessageAction(window, dep, tag, nodes)
+		},
+		ListConfig: func() config.NodeListConfig {
+			if dep.Data.CurrentConfig != nil {
+				return dep.Data.CurrentConfig().UI.NodeList
+			}
+
+			return dep.Data.Config.UI.NodeList
+		},
+		OnListConfigChanged: dep.Actions.OnSetNodeListPrefs,
+		shortcuts:           nodesShortcuts,
+	})
+	meshHealthCard := newMeshHealthCard(window, &meshHealthSource{
+		nodeStore:   dep.Data.NodeStore,
+		chatStore:   dep.Data.ChatStore,
+		localNodeID: dep.Data.LocalNodeID,
 	})
+	if dep.Actions.LoadStatistics != nil {
+		statisticsButton := widget.NewButton(i18n.T("Statistics"), func() {
+			showStatisticsModal(window, dep)
+		})
+		meshHealthCard = container.NewBorder(nil, nil, nil, statisticsButton, meshHealthCard)
+	}
+	nodesView := container.NewBorder(container.NewVBox(meshHealthCard, widget.NewSeparator()), nil, nil, nil, nodesTab)
 	mapTab := newMapTab(
 		dep.Data.NodeStore,
 		dep.Data.LocalNodeID,
@@ -99,13 +210,26 @@ func buildMainView(
 	if mapWidget, ok := mapTab.(*mapTabWidget); ok {
 		applyMapTheme = mapWidget.applyThemeVariant
 		dep.Actions.OnMapDisplayConfigChanged = mapWidget.applyMapDisplayConfig
+		mapWidget.enableAreaDownload(window)
+		if dep.Actions.NodeOverview != nil {
+			mapWidget.enableTrackPlayback(dep.Actions.NodeOverview.ListPositionTrack)
+		}
+		if chats, ok := chatsTab.(*chatsTabContent); ok && chats.shareLocation != nil {
+			mapWidget.enableLocationSharing(func(location sharedLocation) {
+				switchToChats()
+				chats.shareLocation(location)
+			})
+		}
 	}
 	nodeSettingsTab := newNodeTabWithOnShow(dep)
-	settingsTab := newSettingsTab(dep, settingsConnStatus)
+	mute := newNotificationMute(dep)
+	closeButton := newCloseButtonChoice(dep)
+	settingsTab, syncSettingsConnection := newSettingsTabWithConnectionSync(dep, settingsConnStatus, mute, closeButton, errorToasts.Report)
+	quickConnect := newQuickConnect(window, dep, syncSettingsConnection)
 
 	tabContent := map[string]fyne.CanvasObject{
 		"Chats": chatsTab,
-		"Nodes": nodesTab,
+		"Nodes": nodesView,
 		"Map":   mapTab,
 		"Node":  nodeSettingsTab,
 		"App":   settingsTab,
@@ -123,7 +247,7 @@ func buildMainView(
 		initialVariant,
 		false,
 		func(snapshot meshapp.UpdateSnapshot) {
-			showUpdateDialog(window, fyApp.Settings().ThemeVariant(), snapshot, openExternalURL)
+			showUpdateDialog(window, appThemeVariant(fyApp), snapshot, openExternalURL)
 		},
 	)
 	connStatusPresenter := newConnectionStatusPresenter(
@@ -135,6 +259,37 @@ func buildMainView(
 			return localNodeDisplayName(dep.Data.LocalNodeSnapshot)
 		},
 	)
+	statusStrip := newConnectionStatusStrip(dep.Data.LocalNodeSnapshot, dep.Actions.OnDisconnect, dep.Actions.OnReconnect)
+	connStatusPresenter.SetStrip(statusStrip)
+	notificationHistory := notifications.NewHistory(notifications.DefaultHistoryLimit)
+	notificationCenter := newNotificationCenter(
+		window,
+		notificationHistory,
+		func(chatKey string) {
+			switchToChats()
+			openDMChat(chatKey)
+		},
+		func(nodeID string) {
+			node := domain.Node{NodeID: nodeID}
+			if dep.Data.NodeStore != nil {
+				if known, ok := dep.Data.NodeStore.Get(nodeID); ok {
+					node = known
+				}
+			}
+			showNodeOverviewModal(window, dep, node, switchToChats, openDMChat)
+		},
+		func(chatKey string) color.Color {
+			if dep.Data.ChatStore == nil {
+				return nil
+			}
+			chat, ok := dep.Data.ChatStore.ChatByKey(chatKey)
+			if !ok {
+				return nil
+			}
+
+			return chatAccentColor(chatColors.colorName(chat))
+		},
+	)
 	sidebar := buildSidebarLayout(
 		initialVariant,
 		tabContent,
@@ -146,6 +301,24 @@ func buildMainView(
 	switchToChats = func() {
 		sidebar.SwitchTab("Chats")
 	}
+	shortcutTargets := map[string]*listShortcutTarget{"Nodes": nodesShortcuts}
+	if chats, ok := chatsTab.(*chatsTabContent); ok {
+		shortcutTargets["Chats"] = &chats.shortcuts
+	}
+	bindMainViewShortcuts(window, sidebar, tabContent, shortcutTargets, func() {
+		if dep.Data.ChatStore == nil {
+			return
+		}
+		showQuickChatSwitcher(window, dep.Data.ChatStore.ChatListSorted(), resolveNodeDisplayName(dep.Data.NodeStore), func(chatKey string) {
+			switchToChats()
+			openDMChat(chatKey)
+		})
+	})
+
+	diagnostics := newDiagnosticsStatus(window, dep.Actions.LoadDiagnostics)
+	doNotDisturb := newDoNotDisturbToggle(window, mute)
+
+	chats, _ := chatsTab.(*chatsTabContent)
 
 	return mainView{
 		left:                sidebar.left,
@@ -154,5 +327,26 @@ func buildMainView(
 		applyMapTheme:       applyMapTheme,
 		updateIndicator:     updateIndicator,
 		connStatusPresenter: connStatusPresenter,
+		statusStrip:         statusStrip,
+		statusBar: container.NewBorder(
+			widget.NewSeparator(),
+			nil,
+			nil,
+			container.NewHBox(diagnostics.Content(), quickConnect.Button(), doNotDisturb.Button(), notificationCenter.Button()),
+			statusStrip.Content(),
+		),
+		notificationHistory: notificationHistory,
+		notificationCenter:  notificationCenter,
+		quickConnect:        quickConnect,
+		notificationMute:    mute,
+		chats:               chats,
+		doNotDisturb:        doNotDisturb,
+		closeButton:         closeButton,
+		diagnostics:         diagnostics,
+		errorToasts:         errorToasts,
+		openChat: func(chatKey string) {
+			switchToChats()
+			openDMChat(chatKey)
+		},
 	}
 }
@@ -36,6 +36,7 @@ func TestBuildMainViewBuildsSidebarAndPresentationComponents(t *testing.T) {
 			State:         busmsg.ConnectionStateConnecting,
 			TransportName: "ip",
 		},
+		nil,
 	)
 
 	if view.left == nil || view.rightStack == nil {
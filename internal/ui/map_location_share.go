@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// sharedLocation is a map point picked to be shared into a chat. Name is set when the
+// point is a node position.
+type sharedLocation struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+}
+
+// enableLocationSharing adds "share location" items to the secondary tap menus of the
+// map and its node markers.
+func (t *mapTabWidget) enableLocationSharing(onShare func(location sharedLocation)) {
+	if t == nil || onShare == nil {
+		return
+	}
+	t.onShareLocation = onShare
+	t.interactionLayer.SetSecondaryTapHandler(t.handleMapSecondaryTap)
+	t.renderMarkers()
+}
+
+func (t *mapTabWidget) handleMapSecondaryTap(event *fyne.PointEvent) {
+	if t.onShareLocation == nil || t.isOverControlPanel(event.Position) {
+		return
+	}
+	coord, ok := screenToCoordinateWithTileSize(
+		event.Position,
+		t.viewState,
+		t.markerLayer.Size(),
+		mapTileLogicalSizeForObject(t.mapWidget),
+	)
+	if !ok {
+		return
+	}
+	location := sharedLocation{Latitude: coord.Latitude, Longitude: coord.Longitude}
+	t.showShareLocationMenu(i18n.T("Share this location…"), location, event.AbsolutePosition)
+}
+
+func (t *mapTabWidget) handleMarkerSecondaryTap(node domain.Node, event *fyne.PointEvent) {
+	coord, ok := nodeCoordinate(node)
+	if !ok || t.onShareLocation == nil {
+		return
+	}
+	location := sharedLocation{
+		Name:      nodeDisplayName(node),
+		Latitude:  coord.Latitude,
+		Longitude: coord.Longitude,
+	}
+	t.showShareLocationMenu(i18n.T("Share node position…"), location, event.AbsolutePosition)
+}
+
+func (t *mapTabWidget) showShareLocationMenu(label string, location sharedLocation, position fyne.Position) {
+	fyneCanvas := canvasForObject(t)
+	if fyneCanvas == nil {
+		return
+	}
+	menu := fyne.NewMenu("", fyne.NewMenuItem(label, func() {
+		t.onShareLocation(location)
+	}))
+	widget.ShowPopUpMenuAtPosition(menu, fyneCanvas, position)
+}
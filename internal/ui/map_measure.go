@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/geo"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+const (
+	mapMeasureStrokeWidth = float32(2)
+	mapMeasurePointRadius = float32(4)
+)
+
+// mapMeasurePoint is an end of a measurement: a point on the map or a node position.
+// Name is set for nodes.
+type mapMeasurePoint struct {
+	Name       string
+	Coordinate mapCoordinate
+}
+
+// mapMeasure holds the points picked while the measure tool is on. A third point starts
+// a new measurement.
+type mapMeasure struct {
+	active bool
+	points []mapMeasurePoint
+}
+
+func (m *mapMeasure) add(point mapMeasurePoint) {
+	if len(m.points) >= 2 {
+		m.points = nil
+	}
+	m.points = append(m.points, point)
+}
+
+// result returns the distance and the initial bearing from the first point to the second.
+func (m *mapMeasure) result() (km, bearing float64, ok bool) {
+	if len(m.points) < 2 {
+		return 0, 0, false
+	}
+	from, to := m.points[0].Coordinate, m.points[1].Coordinate
+
+	return geo.DistanceKm(from.Latitude, from.Longitude, to.Latitude, to.Longitude),
+		geo.InitialBearing(from.Latitude, from.Longitude, to.Latitude, to.Longitude),
+		true
+}
+
+// mapMeasureText describes the measurement, naming the nodes it was taken between.
+func mapMeasureText(points []mapMeasurePoint, km, bearing float64) string {
+	text := formatDistanceBearing(km, bearing)
+	if len(points) < 2 || (points[0].Name == "" && points[1].Name == "") {
+		return text
+	}
+	name := func(point mapMeasurePoint) string {
+		if point.Name == "" {
+			return i18n.T("point")
+		}
+
+		return point.Name
+	}
+
+	return i18n.Tf("%s → %s: %s", name(points[0]), name(points[1]), text)
+}
+
+func (t *mapTabWidget) toggleMeasure() {
+	t.measure.active = !t.measure.active
+	t.measure.points = nil
+	if t.measure.active {
+		t.measureButton.Importance = widget.HighImportance
+		t.interactionLayer.SetTapHandler(t.handleMeasureTap)
+	} else {
+		t.measureButton.Importance = widget.MediumImportance
+		t.interactionLayer.SetTapHandler(nil)
+	}
+	t.measureButton.Refresh()
+	t.renderMarkers()
+}
+
+func (t *mapTabWidget) handleMeasureTap(event *fyne.PointEvent) {
+	if !t.measure.active || t.isOverControlPanel(event.Position) {
+		return
+	}
+	coord, ok := screenToCoordinateWithTileSize(
+		event.Position,
+		t.viewState,
+		t.measureLayer.Size(),
+		mapTileLogicalSizeForObject(t.mapWidget),
+	)
+	if !ok {
+		return
+	}
+	t.measure.add(mapMeasurePoint{Coordinate: coord})
+	t.renderMeasure()
+}
+
+func (t *mapTabWidget) handleMarkerMeasureTap(node domain.Node) {
+	coord, ok := nodeCoordinate(node)
+	if !ok {
+		return
+	}
+	t.measure.add(mapMeasurePoint{Name: nodeDisplayName(node), Coordinate: coord})
+	t.renderMeasure()
+}
+
+func (t *mapTabWidget) renderMeasure() {
+	if t == nil || t.measureLayer == nil {
+		return
+	}
+	if !t.measure.active || len(t.measure.points) == 0 {
+		t.measureLayer.Objects = nil
+		t.measureLayer.Refresh()
+
+		return
+	}
+
+	size := t.measureLayer.Size()
+	tileSize := mapTileLogicalSizeForObject(t.mapWidget)
+	measureColor := theme.Color(theme.ColorNamePrimary)
+	positions := make([]fyne.Position, 0, len(t.measure.points))
+	for _, point := range t.measure.points {
+		if pos, ok := projectCoordinateToScreenWithTileSize(point.Coordinate, t.viewState, size, tileSize); ok {
+			positions = append(positions, pos)
+		}
+	}
+
+	objects := make([]fyne.CanvasObject, 0, len(positions)+3)
+	if len(positions) == 2 {
+		line := canvas.NewLine(measureColor)
+		line.StrokeWidth = mapMeasureStrokeWidth
+		line.Position1 = positions[0]
+		line.Position2 = positions[1]
+		objects = append(objects, line)
+	}
+	for _, pos := range positions {
+		dot := canvas.NewCircle(measureColor)
+		dot.StrokeColor = theme.Color(theme.ColorNameBackground)
+		dot.StrokeWidth = 2
+		dot.Resize(fyne.NewSize(mapMeasurePointRadius*2, mapMeasurePointRadius*2))
+		dot.Move(fyne.NewPos(pos.X-mapMeasurePointRadius, pos.Y-mapMeasurePointRadius))
+		objects = append(objects, dot)
+	}
+	if km, bearing, ok := t.measure.result(); ok && len(positions) == 2 {
+		objects = append(objects, newMapMeasureLabel(mapMeasureText(t.measure.points, km, bearing), fyne.NewPos(
+			(positions[0].X+positions[1].X)/2,
+			(positions[0].Y+positions[1].Y)/2,
+		))...)
+	}
+
+	t.measureLayer.Objects = objects
+	t.measureLayer.Refresh()
+}
+
+// newMapMeasureLabel draws the measurement text on a background centered on the line.
+func newMapMeasureLabel(text string, center fyne.Position) []fyne.CanvasObject {
+	label := canvas.NewText(text, theme.Color(theme.ColorNameForeground))
+	label.TextStyle = fyne.TextStyle{Bold: true}
+	padding := theme.Padding()
+	textSize := label.MinSize()
+	boxSize := fyne.NewSize(textSize.Width+2*padding, textSize.Height+padding)
+	backgroundColor := toNRGBA(theme.Color(theme.ColorNameOverlayBackground))
+	backgroundColor.A = 0xe0
+	background := canvas.NewRectangle(backgroundColor)
+	background.CornerRadius = theme.InputRadiusSize()
+	background.Resize(boxSize)
+	background.Move(fyne.NewPos(center.X-boxSize.Width/2, center.Y-boxSize.Height-padding))
+	label.Resize(textSize)
+	label.Move(fyne.NewPos(center.X-textSize.Width/2, center.Y-boxSize.Height-padding/2))
+
+	return []fyne.CanvasObject{background, label}
+}
@@ -0,0 +1,29 @@
+package ui
+
+import "testing"
+
+func TestMapMeasure(t *testing.T) {
+	var measure mapMeasure
+	if _, _, ok := measure.result(); ok {
+		t.Fatalf("expected no result without points")
+	}
+	origin := mapMeasurePoint{Name: "Base", Coordinate: mapCoordinate{Latitude: 0, Longitude: 0}}
+	east := mapMeasurePoint{Coordinate: mapCoordinate{Latitude: 0, Longitude: 1}}
+	measure.add(origin)
+	measure.add(east)
+	km, bearing, ok := measure.result()
+	if !ok {
+		t.Fatalf("expected a result for two points")
+	}
+	if got, want := mapMeasureText(measure.points, km, bearing), "Base → point: 111.2 km at 90°"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if got, want := mapMeasureText([]mapMeasurePoint{east, east}, km, bearing), "111.2 km at 90°"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	measure.add(east)
+	if len(measure.points) != 1 || measure.points[0] != east {
+		t.Fatalf("expected a third point to start a new measurement, got %+v", measure.points)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fyne.io/fyne/v2"
 
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/geo"
 )
 
 const (
@@ -208,6 +209,55 @@ func projectCoordinateToScreenWithTileSize(
 	return fyne.NewPos(float32(screenX), float32(screenY)), true
 }
 
+// screenToCoordinateWithTileSize is the inverse of projectCoordinateToScreenWithTileSize.
+func screenToCoordinateWithTileSize(
+	pos fyne.Position,
+	view mapViewportState,
+	canvasSize fyne.Size,
+	tileSize float64,
+) (mapCoordinate, bool) {
+	tileX, tileY, ok := screenToTileWithTileSize(pos, view, canvasSize, tileSize)
+	if !ok {
+		return mapCoordinate{}, false
+	}
+	coord := tileToLatLon(tileX, tileY, view.Zoom)
+	if !isValidCoordinate(coord.Latitude, coord.Longitude) {
+		return mapCoordinate{}, false
+	}
+
+	return coord, true
+}
+
+// screenToTileWithTileSize returns the fractional tile coordinates under a screen
+// position at the zoom of the view. They may fall outside of the world.
+func screenToTileWithTileSize(
+	pos fyne.Position,
+	view mapViewportState,
+	canvasSize fyne.Size,
+	tileSize float64,
+) (float64, float64, bool) {
+	if canvasSize.Width <= 0 || canvasSize.Height <= 0 {
+		return 0, 0, false
+	}
+	if tileSize <= 0 {
+		tileSize = float64(mapTileSize)
+	}
+
+	w := float64(canvasSize.Width)
+	h := float64(canvasSize.Height)
+	midTileX := (w - tileSize*2) / 2
+	midTileY := (h - tileSize*2) / 2
+	if view.Zoom == 0 {
+		midTileX += tileSize / 2
+		midTileY += tileSize / 2
+	}
+	offset := mapTileOffset(view.Zoom)
+	tileX := (float64(pos.X)-midTileX)/tileSize + float64(view.X+offset)
+	tileY := (float64(pos.Y)-midTileY)/tileSize + float64(view.Y+offset)
+
+	return tileX, tileY, true
+}
+
 func mapTileLogicalSizeForScale(scale float32) float64 {
 	if scale <= 0 {
 		scale = 1
@@ -266,19 +316,18 @@ func latLonToTile(coord mapCoordinate, zoom int) (float64, float64) {
 	return x, y
 }
 
-func haversineKilometers(a, b mapCoordinate) float64 {
-	const earthRadiusKm = 6371.0
-
-	lat1 := a.Latitude * math.Pi / 180
-	lat2 := b.Latitude * math.Pi / 180
-	dLat := lat2 - lat1
-	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+func tileToLatLon(x, y float64, zoom int) mapCoordinate {
+	n := math.Pow(2, float64(zoom))
+	latRad := math.Atan(math.Sinh(math.Pi * (1 - 2*y/n)))
 
-	sinLat := math.Sin(dLat / 2)
-	sinLon := math.Sin(dLon / 2)
-	h := sinLat*sinLat + math.Cos(lat1)*math.Cos(lat2)*sinLon*sinLon
+	return mapCoordinate{
+		Latitude:  latRad * 180 / math.Pi,
+		Longitude: x/n*360 - 180,
+	}
+}
 
-	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+func haversineKilometers(a, b mapCoordinate) float64 {
+	return geo.DistanceKm(a.Latitude, a.Longitude, b.Latitude, b.Longitude)
 }
 
 func medianFloat64(values []float64) float64 {
@@ -168,3 +168,22 @@ func TestProjectCoordinateToScreenWithTileSize_TracksPanWithFractionalScale(t *t
 		t.Fatalf("expected one tile shift (%v), got %v", tileSize, shift)
 	}
 }
+
+func TestScreenToCoordinateWithTileSize_InvertsProjection(t *testing.T) {
+	coord := mapCoordinate{Latitude: 55.7558, Longitude: 37.6173}
+	view := centerCoordinateToViewport(mapCoordinate{Latitude: 55.7, Longitude: 37.5}, 12)
+	size := fyne.NewSize(900, 600)
+	tileSize := mapTileLogicalSizeForScale(1.5)
+
+	pos, ok := projectCoordinateToScreenWithTileSize(coord, view, size, tileSize)
+	if !ok {
+		t.Fatalf("expected projection")
+	}
+	got, ok := screenToCoordinateWithTileSize(pos, view, size, tileSize)
+	if !ok {
+		t.Fatalf("expected inverse projection")
+	}
+	if math.Abs(got.Latitude-coord.Latitude) > 1e-4 || math.Abs(got.Longitude-coord.Longitude) > 1e-4 {
+		t.Fatalf("expected %+v, got %+v", coord, got)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	meshapp "github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/config"
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 	"github.com/skobkin/meshgo/internal/resources"
 	"github.com/skobkin/meshgo/internal/ui/widgets"
 	mapwidgets "github.com/skobkin/meshgo/internal/ui/widgets/map"
@@ -66,13 +67,13 @@ func newMapTab(
 ) fyne.CanvasObject {
 	if store == nil {
 		mapLogger.Warn("map tab is unavailable: node store is nil")
-		placeholder := widget.NewLabel("Map is unavailable")
+		placeholder := widget.NewLabel(i18n.T("Map is unavailable"))
 		placeholder.Wrapping = fyne.TextWrapWord
 
 		return container.NewCenter(placeholder)
 	}
 
-	mapClient := mapwidgets.NewMapTileHTTPClient(paths.MapTilesDir, mapwidgets.DefaultMapTileCacheSizeBytes)
+	mapClient := mapwidgets.NewMapTileHTTPClient(paths.MapTilesDir, initialDisplay.TileCacheMaxBytes())
 	baseMap := xwidget.NewMapWithOptions(
 		xwidget.WithOsmTiles(),
 		xwidget.WithZoomButtons(false),
@@ -140,14 +141,23 @@ type mapTabWidget struct {
 
 	onViewportChanged  func(zoom, x, y int)
 	viewportPersistSeq uint64
+	onShareLocation    func(location sharedLocation)
 
 	interactionLayer *mapwidgets.MapInteractionLayer
 	circleLayer      *fyne.Container
+	trackLayer       *fyne.Container
+	measureLayer     *fyne.Container
 	markerLayer      *fyne.Container
 	tooltipLayer     *fyne.Container
 	emptyLabel       *widget.Label
 	emptyLayer       *fyne.Container
 	controlPanel     *fyne.Container
+	trackButton      *widget.Button
+	trackPanel       *fyne.Container
+	trackPlayback    *mapTrackPlayback
+	downloadButton   *widget.Button
+	measureButton    *widget.Button
+	measure          mapMeasure
 	loadingLabel     *widget.Label
 	loadingProgress  *widget.ProgressBar
 	retryButton      *widget.Button
@@ -161,20 +171,21 @@ type mapTabWidget struct {
 	showPrecisionCircles            bool
 	showPrecisionCirclesOnlyOnHover bool
 
-	loadingEnabled   bool
-	warmupDone       bool
-	warmupInFlight   atomic.Bool
-	firstShownAt     time.Time
-	firstFrameLogged bool
-	asyncRefreshSeq  uint64
-	viewLoadingSeq   uint64
-	tileCacheDir     string
-	benchmarkCache   string
-	benchmarkTiles   int
-	benchmarkOK      int
-	benchmarkFailed  int
-	benchmarkWarmup  time.Duration
-	prefetchURLsFn   func(context.Context, *http.Client, []string, func(done, total int)) (okCount, failedCount int)
+	loadingEnabled    bool
+	warmupDone        bool
+	warmupInFlight    atomic.Bool
+	firstShownAt      time.Time
+	firstFrameLogged  bool
+	asyncRefreshSeq   uint64
+	viewLoadingSeq    uint64
+	tileCacheDir      string
+	tileCacheMaxBytes int64
+	benchmarkCache    string
+	benchmarkTiles    int
+	benchmarkOK       int
+	benchmarkFailed   int
+	benchmarkWarmup   time.Duration
+	prefetchURLsFn    func(context.Context, *http.Client, []string, func(done, total int)) (okCount, failedCount int)
 }
 
 type mapProgressPlacement int
@@ -243,11 +254,13 @@ func newMapProgressIndicator(placement mapProgressPlacement, labelText, actionTe
 
 func newMapTabWidget(mapWidget *xwidget.Map, localNodeID func() string) *mapTabWidget {
 	circleLayer := container.NewWithoutLayout()
+	trackLayer := container.NewWithoutLayout()
+	measureLayer := container.NewWithoutLayout()
 	markerLayer := container.NewWithoutLayout()
 	tooltipLayer := container.NewWithoutLayout()
-	emptyLabel := widget.NewLabel("No node positions yet")
+	emptyLabel := widget.NewLabel(i18n.T("No node positions yet"))
 	emptyLayer := container.NewCenter(emptyLabel)
-	startupProgress := newMapProgressIndicator(mapProgressPlacementCenter, "Loading map tiles...", "Retry")
+	startupProgress := newMapProgressIndicator(mapProgressPlacementCenter, i18n.T("Loading map tiles..."), i18n.T("Retry"))
 	viewProgress := newMapProgressIndicator(mapProgressPlacementTop, "", "")
 
 	tab := &mapTabWidget{
@@ -255,6 +268,9 @@ func newMapTabWidget(mapWidget *xwidget.Map, localNodeID func() string) *mapTabW
 		localNodeID:      localNodeID,
 		tooltipManager:   widgets.NewHoverTooltipManager(tooltipLayer),
 		circleLayer:      circleLayer,
+		trackLayer:       trackLayer,
+		measureLayer:     measureLayer,
+		trackPanel:       container.NewStack(),
 		markerLayer:      markerLayer,
 		tooltipLayer:     tooltipLayer,
 		emptyLabel:       emptyLabel,
@@ -315,11 +331,16 @@ func (t *mapTabWidget) newControlPanel() *fyne.Container {
 		t.renderMarkers()
 		t.scheduleViewportPersist()
 	})
-	recenter := widget.NewButton("Center", func() {
+	recenter := widget.NewButton(i18n.T("Center"), func() {
 		t.centerToPreferred(t.viewState.Zoom)
 		t.renderMarkers()
 		t.scheduleViewportPersist()
 	})
+	t.measureButton = widget.NewButton(i18n.T("Measure"), t.toggleMeasure)
+	t.trackButton = widget.NewButtonWithIcon(i18n.T("Track"), theme.MediaPlayIcon(), nil)
+	t.trackButton.Hide()
+	t.downloadButton = widget.NewButtonWithIcon(i18n.T("Offline"), theme.DownloadIcon(), nil)
+	t.downloadButton.Hide()
 
 	panGrid := container.NewGridWithColumns(3,
 		layout.NewSpacer(),
@@ -338,6 +359,9 @@ func (t *mapTabWidget) newControlPanel() *fyne.Container {
 		zoomOut,
 		panGrid,
 		recenter,
+		t.measureButton,
+		t.trackButton,
+		t.downloadButton,
 	)
 }
 
@@ -351,7 +375,7 @@ func (t *mapTabWidget) enableDeferredTileWarmup(mapClient *http.Client, tileSour
 	t.tileCacheDir = tileCacheDir
 	t.warmupDone = false
 	t.firstFrameLogged = false
-	t.showLoadingState("Loading map tiles...", 0, false)
+	t.showLoadingState(i18n.T("Loading map tiles..."), 0, false)
 }
 
 func (t *mapTabWidget) OnShow() {
@@ -379,7 +403,7 @@ func (t *mapTabWidget) OnShow() {
 		)
 	}
 
-	t.showLoadingState("Loading map tiles...", 0, false)
+	t.showLoadingState(i18n.T("Loading map tiles..."), 0, false)
 	state, size, tileSize := t.warmupSnapshot()
 	urls := visibleMapTileURLs(t.tileSource, state, size, tileSize)
 	mapLogger.Info(
@@ -457,7 +481,7 @@ func (t *mapTabWidget) runTileWarmup(urls []string, state mapViewportState, size
 		}
 
 		if okCount == 0 && failedCount > 0 {
-			t.showLoadingState("Map tiles are taking longer than expected.", 0, true)
+			t.showLoadingState(i18n.T("Map tiles are taking longer than expected."), 0, true)
 			mapLogger.Warn(
 				"map tile warmup did not fetch any visible tiles",
 				"tile_count", len(urls),
@@ -701,6 +725,12 @@ func (t *mapTabWidget) applyMapDisplayConfig(cfg config.MapDisplayConfig) {
 		return
 	}
 
+	t.tileCacheMaxBytes = cfg.TileCacheMaxBytes()
+	if t.tileCacheMaxBytes <= 0 {
+		t.tileCacheMaxBytes = mapwidgets.DefaultMapTileCacheSizeBytes
+	}
+	mapwidgets.SetMapTileClientMaxBytes(t.mapClient, t.tileCacheMaxBytes)
+
 	nextShow := cfg.ShowPrecisionCircles
 	nextOnlyOnHover := cfg.ShowPrecisionCirclesOnlyOnHover
 	if !nextShow {
@@ -816,6 +846,16 @@ func (t *mapTabWidget) renderMarkers() {
 		marker.SetHoverChangeHandler(func(hovered bool) {
 			t.handleMarkerHoverChanged(nodeID, hovered)
 		})
+		if t.measure.active {
+			marker.SetTapHandler(func(*fyne.PointEvent) {
+				t.handleMarkerMeasureTap(node)
+			})
+		}
+		if t.onShareLocation != nil {
+			marker.SetSecondaryTapHandler(func(event *fyne.PointEvent) {
+				t.handleMarkerSecondaryTap(node, event)
+			})
+		}
 		markerSize := marker.MinSize()
 		marker.Resize(markerSize)
 		marker.Move(fyne.NewPos(
@@ -832,6 +872,8 @@ func (t *mapTabWidget) renderMarkers() {
 	}
 	t.markerLayer.Refresh()
 	t.renderCircles()
+	t.renderTrack()
+	t.renderMeasure()
 	t.emptyLayer.Refresh()
 	mapLogger.Debug(
 		"rendered map markers",
@@ -1045,11 +1087,14 @@ func mapMarkerTooltip(node domain.Node) string {
 	if name == "" {
 		name = node.NodeID
 	}
-	if name == node.NodeID {
+	if name != node.NodeID {
+		name = fmt.Sprintf("%s (%s)", name, node.NodeID)
+	}
+	if node.Latitude == nil || node.Longitude == nil {
 		return name
 	}
 
-	return fmt.Sprintf("%s (%s)", name, node.NodeID)
+	return name + "\n" + currentDisplayFormatter().Coordinates(*node.Latitude, *node.Longitude)
 }
 
 func (t *mapTabWidget) CreateRenderer() fyne.WidgetRenderer {
@@ -1057,9 +1102,12 @@ func (t *mapTabWidget) CreateRenderer() fyne.WidgetRenderer {
 		t.mapWidget,
 		t.interactionLayer,
 		t.circleLayer,
+		t.trackLayer,
+		t.measureLayer,
 		t.markerLayer,
 		t.emptyLayer,
 		t.controlPanel,
+		t.trackPanel,
 		t.loadingLayer,
 		t.viewLoadingLayer,
 		t.tooltipLayer,
@@ -1081,6 +1129,8 @@ func (r *mapTabRenderer) Layout(size fyne.Size) {
 		r.tab.mapWidget,
 		r.tab.interactionLayer,
 		r.tab.circleLayer,
+		r.tab.trackLayer,
+		r.tab.measureLayer,
 		r.tab.markerLayer,
 		r.tab.emptyLayer,
 		r.tab.loadingLayer,
@@ -1098,6 +1148,10 @@ func (r *mapTabRenderer) Layout(size fyne.Size) {
 		max(0, size.Width-panelSize.Width-padding),
 		padding,
 	))
+	trackPanelSize := r.tab.trackPanel.MinSize()
+	trackPanelSize.Width = min(max(trackPanelSize.Width, mapTrackPanelMinWidth), max(0, size.Width-2*padding))
+	r.tab.trackPanel.Resize(trackPanelSize)
+	r.tab.trackPanel.Move(fyne.NewPos(padding, max(0, size.Height-trackPanelSize.Height-padding)))
 
 	if r.tab.lastCanvasSize != size {
 		r.tab.lastCanvasSize = size
@@ -1318,6 +1372,16 @@ func prefetchMapTileURLs(
 	client *http.Client,
 	urls []string,
 	onProgress func(done, total int),
+) (okCount, failedCount int) {
+	return prefetchMapTileURLsWithWorkers(ctx, client, urls, mapWarmupParallelism, onProgress)
+}
+
+func prefetchMapTileURLsWithWorkers(
+	ctx context.Context,
+	client *http.Client,
+	urls []string,
+	workers int,
+	onProgress func(done, total int),
 ) (okCount, failedCount int) {
 	if len(urls) == 0 {
 		return 0, 0
@@ -1326,7 +1390,9 @@ func prefetchMapTileURLs(
 		return 0, len(urls)
 	}
 
-	workers := mapWarmupParallelism
+	if workers < 1 {
+		workers = 1
+	}
 	if workers > len(urls) {
 		workers = len(urls)
 	}
@@ -1500,11 +1566,22 @@ func (t *mapTabWidget) handleMapDrag(position fyne.Position, delta fyne.Delta) {
 }
 
 func (t *mapTabWidget) isOverControlPanel(pos fyne.Position) bool {
-	if t == nil || t.controlPanel == nil || !t.controlPanel.Visible() {
+	if t == nil {
+		return false
+	}
+	if t.trackPlayback != nil && t.trackPlayback.panel.Visible() && isOverMapPanel(t.trackPanel, pos) {
+		return true
+	}
+
+	return isOverMapPanel(t.controlPanel, pos)
+}
+
+func isOverMapPanel(panel *fyne.Container, pos fyne.Position) bool {
+	if panel == nil || !panel.Visible() {
 		return false
 	}
-	panelPos := t.controlPanel.Position()
-	panelSize := t.controlPanel.Size()
+	panelPos := panel.Position()
+	panelSize := panel.Size()
 	if panelSize.Width <= 0 || panelSize.Height <= 0 {
 		return false
 	}
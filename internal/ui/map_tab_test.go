@@ -683,3 +683,26 @@ func mustStubPNG(t *testing.T) []byte {
 
 	return buf.Bytes()
 }
+
+func TestMapMarkerTooltipIncludesCoordinates(t *testing.T) {
+	lat, lon := 50.450333, 30.523333
+	tests := []struct {
+		name string
+		node domain.Node
+		want string
+	}{
+		{name: "id only", node: domain.Node{NodeID: "!1234abcd"}, want: "!1234abcd"},
+		{
+			name: "named with position",
+			node: domain.Node{NodeID: "!1234abcd", LongName: "Alice", Latitude: &lat, Longitude: &lon},
+			want: "Alice (!1234abcd)\n50.450333, 30.523333",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := mapMarkerTooltip(tc.node); got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -100,6 +100,28 @@ func TestMapTileCacheTransport_EvictsOldestFilesWhenSizeCapExceeded(t *testing.T
 	}
 }
 
+func TestMapTileCacheTransport_SetMaxBytesEvictsRightAway(t *testing.T) {
+	cacheDir := t.TempDir()
+	transport := &mapwidgets.MapTileCacheTransport{
+		CacheDir: cacheDir,
+		MaxBytes: 1024,
+	}
+
+	pathA := transport.CachePathForURL("https://tile.example/1")
+	pathB := transport.CachePathForURL("https://tile.example/2")
+	transport.WriteCachedTile(pathA, []byte("123456789012"))
+	time.Sleep(10 * time.Millisecond)
+	transport.WriteCachedTile(pathB, []byte("abcdefghijk"))
+
+	transport.SetMaxBytes(20)
+	if _, err := os.Stat(pathA); err == nil {
+		t.Fatalf("expected oldest tile to be evicted after lowering the cap")
+	}
+	if _, err := os.Stat(pathB); err != nil {
+		t.Fatalf("expected newest tile to remain cached: %v", err)
+	}
+}
+
 func TestMapTileCacheTransport_AsyncMissReturnsPlaceholderAndCachesInBackground(t *testing.T) {
 	cacheDir := t.TempDir()
 	tile := mustPNGBytes(t)
@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+const (
+	mapDownloadMaxZoom = 17
+	// mapDownloadMaxTiles keeps area downloads within the bulk download policy of the
+	// public OSM tile servers.
+	mapDownloadMaxTiles      = 15000
+	mapDownloadParallelism   = 2
+	mapDownloadZoomLevels    = 4
+	mapDownloadAvgTileBytes  = 25 * 1024
+	mapDownloadProgressWidth = float32(320)
+)
+
+// mapArea is a lat/lon bounding box.
+type mapArea struct {
+	North float64
+	West  float64
+	South float64
+	East  float64
+}
+
+func mapTileCacheSizeOptionLabels() []string {
+	return []string{"50 MB", "100 MB", "200 MB", "500 MB", "1000 MB", "2000 MB", "5000 MB"}
+}
+
+func mapTileCacheSizeLabel(sizeMB int) string {
+	return fmt.Sprintf("%d MB", sizeMB)
+}
+
+func parseMapTileCacheSizeLabel(label string) (int, error) {
+	var sizeMB int
+	if _, err := fmt.Sscanf(label, "%d MB", &sizeMB); err != nil || sizeMB <= 0 {
+		return 0, fmt.Errorf("invalid map tile cache size %q", label)
+	}
+
+	return sizeMB, nil
+}
+
+// mapAreaFromView returns the part of the world visible in the view.
+func mapAreaFromView(view mapViewportState, canvasSize fyne.Size, tileSize float64) (mapArea, bool) {
+	x0, y0, ok := screenToTileWithTileSize(fyne.NewPos(0, 0), view, canvasSize, tileSize)
+	if !ok {
+		return mapArea{}, false
+	}
+	x1, y1, _ := screenToTileWithTileSize(fyne.NewPos(canvasSize.Width, canvasSize.Height), view, canvasSize, tileSize)
+
+	world := math.Pow(2, float64(max(view.Zoom, 0)))
+	clamp := func(v float64) float64 { return max(0, min(world, v)) }
+	x0, y0, x1, y1 = clamp(x0), clamp(y0), clamp(x1), clamp(y1)
+	if x1 <= x0 || y1 <= y0 {
+		return mapArea{}, false
+	}
+	northWest := tileToLatLon(x0, y0, view.Zoom)
+	southEast := tileToLatLon(x1, y1, view.Zoom)
+
+	return mapArea{
+		North: northWest.Latitude,
+		West:  northWest.Longitude,
+		South: southEast.Latitude,
+		East:  southEast.Longitude,
+	}, true
+}
+
+// mapAreaTileRange returns the inclusive range of tiles covering the area at a zoom.
+func mapAreaTileRange(area mapArea, zoom int) (minX, minY, maxX, maxY int) {
+	last := (1 << zoom) - 1
+	x0, y0 := latLonToTile(mapCoordinate{Latitude: area.North, Longitude: area.West}, zoom)
+	x1, y1 := latLonToTile(mapCoordinate{Latitude: area.South, Longitude: area.East}, zoom)
+	clamp := func(v float64) int { return max(0, min(last, int(math.Floor(v)))) }
+
+	return clamp(x0), clamp(y0), clamp(x1), clamp(y1)
+}
+
+// mapAreaTileCount counts the tiles covering the area from minZoom to maxZoom.
+func mapAreaTileCount(area mapArea, minZoom, maxZoom int) int {
+	count := 0
+	for zoom := minZoom; zoom <= maxZoom; zoom++ {
+		minX, minY, maxX, maxY := mapAreaTileRange(area, zoom)
+		count += (maxX - minX + 1) * (maxY - minY + 1)
+	}
+
+	return count
+}
+
+// mapAreaTileURLs lists the tiles covering the area from minZoom to maxZoom, the lower
+// zoom levels first.
+func mapAreaTileURLs(tileSource string, area mapArea, minZoom, maxZoom int) []string {
+	if tileSource == "" {
+		return nil
+	}
+	urls := make([]string, 0, mapAreaTileCount(area, minZoom, maxZoom))
+	for zoom := minZoom; zoom <= maxZoom; zoom++ {
+		minX, minY, maxX, maxY := mapAreaTileRange(area, zoom)
+		for x := minX; x <= maxX; x++ {
+			for y := minY; y <= maxY; y++ {
+				urls = append(urls, fmt.Sprintf(tileSource, zoom, x, y))
+			}
+		}
+	}
+
+	return urls
+}
+
+// mapDownloadZoomOptions lists the deepest zoom levels a download of the current view can
+// go to.
+func mapDownloadZoomOptions(currentZoom int) []string {
+	first := max(currentZoom, 0)
+	last := max(first, min(first+mapDownloadZoomLevels, mapDownloadMaxZoom))
+	options := make([]string, 0, last-first+1)
+	for zoom := first; zoom <= last; zoom++ {
+		options = append(options, fmt.Sprintf("%d", zoom))
+	}
+
+	return options
+}
+
+// enableAreaDownload shows the control that saves the visible area for offline use.
+func (t *mapTabWidget) enableAreaDownload(window fyne.Window) {
+	if t == nil || window == nil || t.mapClient == nil || t.tileSource == "" {
+		return
+	}
+	t.downloadButton.OnTapped = func() {
+		t.showAreaDownloadDialog(window)
+	}
+	t.downloadButton.Show()
+}
+
+func (t *mapTabWidget) showAreaDownloadDialog(window fyne.Window) {
+	area, ok := mapAreaFromView(t.viewState, t.markerLayer.Size(), mapTileLogicalSizeForObject(t.mapWidget))
+	if !ok {
+		dialog.ShowInformation(i18n.T("Download map area"), i18n.T("There is nothing to download in this view."), window)
+
+		return
+	}
+	minZoom := max(t.viewState.Zoom, 0)
+
+	help := widget.NewLabel(i18n.T("Saves the tiles of the visible area to the tile cache, so the map keeps working without internet."))
+	help.Wrapping = fyne.TextWrapWord
+	summary := widget.NewLabel("")
+	summary.Wrapping = fyne.TextWrapWord
+	warning := widget.NewLabel("")
+	warning.Wrapping = fyne.TextWrapWord
+	warning.Importance = widget.WarningImportance
+
+	var (
+		download *widget.Button
+		maxZoom  = minZoom
+	)
+	update := func() {
+		tileCount := mapAreaTileCount(area, minZoom, maxZoom)
+		estimate := int64(tileCount) * mapDownloadAvgTileBytes
+		summary.SetText(i18n.Tf(
+			"Zoom %d to %d: %d tiles, about %s.",
+			minZoom, maxZoom, tileCount, formatByteSize(uint64(estimate)),
+		))
+		switch {
+		case tileCount > mapDownloadMaxTiles:
+			warning.SetText(i18n.Tf("That is more than %d tiles. Zoom in or lower the deepest zoom.", mapDownloadMaxTiles))
+			warning.Show()
+			download.Disable()
+		case estimate > t.tileCacheMaxBytes:
+			warning.SetText(i18n.Tf(
+				"The area may not fit in the tile cache (%s). Older tiles will be dropped; raise the cache size in Settings.",
+				formatByteSize(uint64(t.tileCacheMaxBytes)),
+			))
+			warning.Show()
+			download.Enable()
+		default:
+			warning.Hide()
+			download.Enable()
+		}
+	}
+	zoomSelect := widget.NewSelect(mapDownloadZoomOptions(minZoom), func(selected string) {
+		if _, err := fmt.Sscanf(selected, "%d", &maxZoom); err != nil {
+			maxZoom = minZoom
+		}
+		if download != nil {
+			update()
+		}
+	})
+
+	content := container.NewVBox(
+		help,
+		widget.NewForm(widget.NewFormItem(i18n.T("Deepest zoom"), zoomSelect)),
+		summary,
+		warning,
+	)
+	areaDialog := dialog.NewCustomWithoutButtons(i18n.T("Download map area"), content, window)
+	download = widget.NewButton(i18n.T("Download"), func() {
+		areaDialog.Hide()
+		t.downloadArea(window, mapAreaTileURLs(t.tileSource, area, minZoom, maxZoom))
+	})
+	download.Importance = widget.HighImportance
+	cancel := widget.NewButton(i18n.T("Cancel"), areaDialog.Hide)
+	areaDialog.SetButtons([]fyne.CanvasObject{cancel, download})
+	zoomSelect.SetSelectedIndex(len(zoomSelect.Options) / 2)
+	areaDialog.Resize(fyne.NewSize(460, areaDialog.MinSize().Height))
+	areaDialog.Show()
+}
+
+// downloadArea fetches the tiles into the cache, showing the progress until it completes
+// or is canceled.
+func (t *mapTabWidget) downloadArea(window fyne.Window, urls []string) {
+	if len(urls) == 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	progress := widget.NewProgressBar()
+	progress.Max = float64(len(urls))
+	status := widget.NewLabel(i18n.Tf("0 of %d tiles", len(urls)))
+	bar := container.NewGridWrap(fyne.NewSize(mapDownloadProgressWidth, progress.MinSize().Height), progress)
+	progressDialog := dialog.NewCustom(i18n.T("Downloading map area"), i18n.T("Cancel"), container.NewVBox(bar, status), window)
+	progressDialog.SetOnClosed(cancel)
+	progressDialog.Show()
+
+	mapLogger.Info("downloading map area", "tiles", len(urls))
+	go func() {
+		okCount, failedCount := prefetchMapTileURLsWithWorkers(ctx, t.mapClient, urls, mapDownloadParallelism, func(done, total int) {
+			fyne.Do(func() {
+				progress.SetValue(float64(done))
+				status.SetText(i18n.Tf("%d of %d tiles", done, total))
+			})
+		})
+		canceled := ctx.Err() != nil
+		cancel()
+		mapLogger.Info("map area download finished", "tiles", len(urls), "ok", okCount, "failed", failedCount, "canceled", canceled)
+		fyne.Do(func() {
+			if canceled {
+				return
+			}
+			progressDialog.Hide()
+			if t.mapWidget != nil {
+				t.mapWidget.Refresh()
+			}
+			message := i18n.Tf("Saved %d tiles for offline use.", okCount)
+			if failedCount > 0 {
+				message = i18n.Tf("Saved %d tiles for offline use, %d failed. Run the download again to retry them.", okCount, failedCount)
+			}
+			dialog.ShowInformation(i18n.T("Map area downloaded"), message, window)
+		})
+	}()
+}
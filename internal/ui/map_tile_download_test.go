@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+func TestMapAreaTileURLs(t *testing.T) {
+	// A quarter of the world at zoom 1: the north-west tile, then its four children.
+	area := mapArea{North: 80, West: -170, South: 10, East: -10}
+	got := mapAreaTileURLs("t/%d/%d/%d", area, 1, 2)
+	want := []string{"t/1/0/0", "t/2/0/0", "t/2/0/1", "t/2/1/0", "t/2/1/1"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if count := mapAreaTileCount(area, 1, 2); count != len(want) {
+		t.Fatalf("expected tile count %d, got %d", len(want), count)
+	}
+	if got := mapAreaTileURLs("", area, 1, 2); got != nil {
+		t.Fatalf("expected no tiles without a tile source, got %v", got)
+	}
+}
+
+func TestMapAreaFromViewClampsToWorld(t *testing.T) {
+	area, ok := mapAreaFromView(mapViewportState{Zoom: 0}, fyne.NewSize(2000, 2000), mapTileSize)
+	if !ok {
+		t.Fatalf("expected the world view to have an area")
+	}
+	if area.West != -180 || area.East != 180 || area.North < 85 || area.South > -85 {
+		t.Fatalf("expected the whole world, got %+v", area)
+	}
+	if count := mapAreaTileCount(area, 0, 1); count != 5 {
+		t.Fatalf("expected 5 tiles for zoom 0 to 1, got %d", count)
+	}
+}
+
+func TestMapDownloadZoomOptions(t *testing.T) {
+	tests := []struct {
+		zoom int
+		want []string
+	}{
+		{zoom: 10, want: []string{"10", "11", "12", "13", "14"}},
+		{zoom: 16, want: []string{"16", "17"}},
+		{zoom: 18, want: []string{"18"}},
+	}
+	for _, tc := range tests {
+		if got := mapDownloadZoomOptions(tc.zoom); !slices.Equal(got, tc.want) {
+			t.Fatalf("zoom %d: expected %v, got %v", tc.zoom, tc.want, got)
+		}
+	}
+}
+
+func TestParseMapTileCacheSizeLabel(t *testing.T) {
+	for _, label := range mapTileCacheSizeOptionLabels() {
+		sizeMB, err := parseMapTileCacheSizeLabel(label)
+		if err != nil {
+			t.Fatalf("parse %q: %v", label, err)
+		}
+		if got := mapTileCacheSizeLabel(sizeMB); got != label {
+			t.Fatalf("expected %q to round trip, got %q", label, got)
+		}
+	}
+	if _, err := parseMapTileCacheSizeLabel("0 MB"); err == nil {
+		t.Fatalf("expected an error for a zero size")
+	}
+}
@@ -0,0 +1,469 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+const (
+	mapTrackPlaybackTick  = 200 * time.Millisecond
+	mapTrackSliderSteps   = 1000
+	mapTrackLoadTimeout   = 5 * time.Second
+	mapTrackPanelMinWidth = float32(560)
+	mapTrackPointRadius   = float32(6)
+	mapTrackStrokeWidth   = float32(3)
+)
+
+// mapTrackLoader reads the track of a node between from and to, oldest first.
+type mapTrackLoader func(ctx context.Context, nodeID string, from, to time.Time) ([]domain.NodeTrackPoint, error)
+
+type mapTrackRange struct {
+	Label  string
+	Window time.Duration
+}
+
+var mapTrackRanges = []mapTrackRange{
+	{Label: "Last hour", Window: time.Hour},
+	{Label: "Last 6 hours", Window: 6 * time.Hour},
+	{Label: "Last 24 hours", Window: 24 * time.Hour},
+	{Label: "Last 7 days", Window: 7 * 24 * time.Hour},
+	{Label: "Last 30 days", Window: 30 * 24 * time.Hour},
+}
+
+type mapTrackSpeed struct {
+	Label  string
+	Factor float64
+}
+
+// mapTrackSpeeds are playback speeds relative to real time. Tracks are sparse, so the
+// useful speeds are much faster than real time.
+var mapTrackSpeeds = []mapTrackSpeed{
+	{Label: "1 min/s", Factor: 60},
+	{Label: "10 min/s", Factor: 600},
+	{Label: "1 h/s", Factor: 3600},
+	{Label: "6 h/s", Factor: 6 * 3600},
+}
+
+// mapTrackIndexAt returns the index of the last point observed at or before at, or -1
+// when playback has not reached the first point yet.
+func mapTrackIndexAt(points []domain.NodeTrackPoint, at time.Time) int {
+	return sort.Search(len(points), func(i int) bool {
+		return points[i].ObservedAt.After(at)
+	}) - 1
+}
+
+// mapTrackTimeAt maps a slider fraction in [0, 1] onto the time span of the track.
+func mapTrackTimeAt(points []domain.NodeTrackPoint, fraction float64) time.Time {
+	if len(points) == 0 {
+		return time.Time{}
+	}
+	fraction = max(0, min(1, fraction))
+	first, last := points[0].ObservedAt, points[len(points)-1].ObservedAt
+
+	return first.Add(time.Duration(float64(last.Sub(first)) * fraction))
+}
+
+// mapTrackFraction is the inverse of mapTrackTimeAt.
+func mapTrackFraction(points []domain.NodeTrackPoint, at time.Time) float64 {
+	if len(points) == 0 {
+		return 0
+	}
+	first, last := points[0].ObservedAt, points[len(points)-1].ObservedAt
+	span := last.Sub(first)
+	if span <= 0 {
+		return 1
+	}
+
+	return max(0, min(1, float64(at.Sub(first))/float64(span)))
+}
+
+// mapTrackNodeOptions lists positioned nodes as select labels, sorted by label.
+func mapTrackNodeOptions(nodes []domain.Node) ([]string, map[string]string) {
+	labels := make([]string, 0, len(nodes))
+	ids := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		if _, ok := nodeCoordinate(node); !ok {
+			continue
+		}
+		label := node.NodeID
+		if name := nodeDisplayName(node); name != "" && name != node.NodeID {
+			label = fmt.Sprintf("%s (%s)", name, node.NodeID)
+		}
+		labels = append(labels, label)
+		ids[label] = node.NodeID
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		return strings.ToLower(labels[i]) < strings.ToLower(labels[j])
+	})
+
+	return labels, ids
+}
+
+// mapTrackPlayback replays the stored track of one node on the map.
+type mapTrackPlayback struct {
+	loader   mapTrackLoader
+	nodes    func() []domain.Node
+	onChange func()
+	onLoaded func(point domain.NodeTrackPoint)
+
+	nodeIDs  map[string]string
+	points   []domain.NodeTrackPoint
+	at       time.Time
+	speed    float64
+	playing  bool
+	playSeq  uint64
+	loadSeq  uint64
+	syncing  bool
+	nodeID   string
+	rangeIdx int
+
+	nodeSelect  *widget.Select
+	rangeSelect *widget.Select
+	speedSelect *widget.Select
+	loadButton  *widget.Button
+	playButton  *widget.Button
+	slider      *widget.Slider
+	timeLabel   *widget.Label
+	panel       *fyne.Container
+}
+
+func newMapTrackPlayback(
+	loader mapTrackLoader,
+	nodes func() []domain.Node,
+	onChange func(),
+	onLoaded func(point domain.NodeTrackPoint),
+) *mapTrackPlayback {
+	p := &mapTrackPlayback{
+		loader:   loader,
+		nodes:    nodes,
+		onChange: onChange,
+		onLoaded: onLoaded,
+		speed:    mapTrackSpeeds[1].Factor,
+		rangeIdx: 2,
+	}
+
+	p.nodeSelect = widget.NewSelect(nil, func(label string) {
+		p.nodeID = p.nodeIDs[label]
+	})
+	p.nodeSelect.PlaceHolder = i18n.T("Select node")
+	rangeLabels := make([]string, 0, len(mapTrackRanges))
+	for _, item := range mapTrackRanges {
+		rangeLabels = append(rangeLabels, i18n.T(item.Label))
+	}
+	p.rangeSelect = widget.NewSelect(rangeLabels, func(label string) {
+		for i, item := range mapTrackRanges {
+			if i18n.T(item.Label) == label {
+				p.rangeIdx = i
+			}
+		}
+	})
+	p.rangeSelect.SetSelected(i18n.T(mapTrackRanges[p.rangeIdx].Label))
+	speedLabels := make([]string, 0, len(mapTrackSpeeds))
+	for _, item := range mapTrackSpeeds {
+		speedLabels = append(speedLabels, i18n.T(item.Label))
+	}
+	p.speedSelect = widget.NewSelect(speedLabels, func(label string) {
+		for _, item := range mapTrackSpeeds {
+			if i18n.T(item.Label) == label {
+				p.speed = item.Factor
+			}
+		}
+	})
+	p.speedSelect.SetSelected(i18n.T(mapTrackSpeeds[1].Label))
+	p.loadButton = widget.NewButton(i18n.T("Load"), p.load)
+	p.playButton = widget.NewButtonWithIcon("", theme.MediaPlayIcon(), p.togglePlaying)
+	p.slider = widget.NewSlider(0, mapTrackSliderSteps)
+	p.slider.OnChanged = func(value float64) {
+		if p.syncing || len(p.points) == 0 {
+			return
+		}
+		p.at = mapTrackTimeAt(p.points, value/mapTrackSliderSteps)
+		p.sync()
+	}
+	p.timeLabel = widget.NewLabel(i18n.T("No track loaded"))
+
+	background := canvas.NewRectangle(theme.Color(theme.ColorNameOverlayBackground))
+	background.CornerRadius = theme.InputRadiusSize()
+	p.panel = container.NewStack(background, container.NewPadded(container.NewVBox(
+		container.NewBorder(nil, nil, nil, container.NewHBox(p.rangeSelect, p.loadButton), p.nodeSelect),
+		container.NewBorder(nil, nil, p.playButton, p.speedSelect, p.slider),
+		p.timeLabel,
+	)))
+	p.panel.Hide()
+	p.sync()
+
+	return p
+}
+
+// Toggle shows or hides the playback panel. Hiding it stops playback and clears the trail.
+func (p *mapTrackPlayback) Toggle() {
+	if p.panel.Visible() {
+		p.pause()
+		p.loadSeq++
+		p.points = nil
+		p.panel.Hide()
+		p.sync()
+
+		return
+	}
+	p.refreshNodeOptions()
+	p.panel.Show()
+}
+
+func (p *mapTrackPlayback) refreshNodeOptions() {
+	var nodes []domain.Node
+	if p.nodes != nil {
+		nodes = p.nodes()
+	}
+	labels, ids := mapTrackNodeOptions(nodes)
+	p.nodeIDs = ids
+	p.nodeSelect.SetOptions(labels)
+	for label, id := range ids {
+		if id == p.nodeID {
+			p.nodeSelect.SetSelected(label)
+		}
+	}
+}
+
+// Visible returns the trail up to the playback time, or nothing when no track is shown.
+func (p *mapTrackPlayback) Visible() []domain.NodeTrackPoint {
+	if !p.panel.Visible() {
+		return nil
+	}
+
+	return p.points[:mapTrackIndexAt(p.points, p.at)+1]
+}
+
+func (p *mapTrackPlayback) load() {
+	if p.loader == nil || p.nodeID == "" {
+		return
+	}
+	p.pause()
+	p.loadSeq++
+	seq := p.loadSeq
+	nodeID := p.nodeID
+	to := time.Now()
+	from := to.Add(-mapTrackRanges[p.rangeIdx].Window)
+	p.timeLabel.SetText(i18n.T("Loading track..."))
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mapTrackLoadTimeout)
+		defer cancel()
+		points, err := p.loader(ctx, nodeID, from, to)
+		fyne.Do(func() {
+			if seq != p.loadSeq {
+				return
+			}
+			if err != nil {
+				mapLogger.Warn("loading node track failed", "node_id", nodeID, "error", err)
+				p.points = nil
+				p.sync()
+				p.timeLabel.SetText(i18n.Tf("Track is unavailable: %s", err))
+
+				return
+			}
+			p.points = points
+			p.at = time.Time{}
+			if len(points) > 0 {
+				p.at = points[0].ObservedAt
+				if p.onLoaded != nil {
+					p.onLoaded(points[0])
+				}
+			}
+			p.sync()
+		})
+	}()
+}
+
+func (p *mapTrackPlayback) togglePlaying() {
+	if p.playing {
+		p.pause()
+		p.sync()
+
+		return
+	}
+	p.play()
+}
+
+func (p *mapTrackPlayback) play() {
+	if len(p.points) < 2 {
+		return
+	}
+	if !p.at.Before(p.points[len(p.points)-1].ObservedAt) {
+		p.at = p.points[0].ObservedAt
+	}
+	p.playing = true
+	p.playSeq++
+	seq := p.playSeq
+	p.sync()
+
+	go func() {
+		ticker := time.NewTicker(mapTrackPlaybackTick)
+		defer ticker.Stop()
+		for range ticker.C {
+			stopped := false
+			fyne.DoAndWait(func() {
+				if !p.playing || p.playSeq != seq {
+					stopped = true
+
+					return
+				}
+				stopped = !p.advance(mapTrackPlaybackTick)
+				p.sync()
+			})
+			if stopped {
+				return
+			}
+		}
+	}()
+}
+
+func (p *mapTrackPlayback) pause() {
+	p.playing = false
+	p.playSeq++
+}
+
+// advance moves playback forward by elapsed real time. It returns false once the end
+// of the track is reached.
+func (p *mapTrackPlayback) advance(elapsed time.Duration) bool {
+	if len(p.points) == 0 {
+		p.playing = false
+
+		return false
+	}
+	last := p.points[len(p.points)-1].ObservedAt
+	p.at = p.at.Add(time.Duration(float64(elapsed) * p.speed))
+	if p.at.Before(last) {
+		return true
+	}
+	p.at = last
+	p.playing = false
+
+	return false
+}
+
+// sync updates the controls from the playback state and redraws the trail.
+func (p *mapTrackPlayback) sync() {
+	p.syncing = true
+	p.slider.SetValue(mapTrackFraction(p.points, p.at) * mapTrackSliderSteps)
+	p.syncing = false
+	if p.playing {
+		p.playButton.SetIcon(theme.MediaPauseIcon())
+	} else {
+		p.playButton.SetIcon(theme.MediaPlayIcon())
+	}
+	if len(p.points) < 2 {
+		p.playButton.Disable()
+		p.slider.Disable()
+	} else {
+		p.playButton.Enable()
+		p.slider.Enable()
+	}
+	p.timeLabel.SetText(mapTrackStatusText(p.points, p.at))
+	if p.onChange != nil {
+		p.onChange()
+	}
+}
+
+func mapTrackStatusText(points []domain.NodeTrackPoint, at time.Time) string {
+	if len(points) == 0 {
+		return i18n.T("No track points in the selected range")
+	}
+	index := mapTrackIndexAt(points, at)
+
+	return i18n.Tf("%s · point %d of %d", currentDisplayFormatter().DateTimeSeconds(at), index+1, len(points))
+}
+
+// renderTrack draws the played part of the track as a polyline ending at the current point.
+func (t *mapTabWidget) renderTrack() {
+	if t == nil || t.trackLayer == nil {
+		return
+	}
+	var points []domain.NodeTrackPoint
+	if t.trackPlayback != nil {
+		points = t.trackPlayback.Visible()
+	}
+	if len(points) == 0 {
+		t.trackLayer.Objects = nil
+		t.trackLayer.Refresh()
+
+		return
+	}
+
+	size := t.trackLayer.Size()
+	tileSize := mapTileLogicalSizeForObject(t.mapWidget)
+	trackColor := mapCircleColorForNode(points[0].NodeID)
+	positions := make([]fyne.Position, 0, len(points))
+	for _, point := range points {
+		pos, ok := projectCoordinateToScreenWithTileSize(
+			mapCoordinate{Latitude: point.Latitude, Longitude: point.Longitude},
+			t.viewState,
+			size,
+			tileSize,
+		)
+		if ok {
+			positions = append(positions, pos)
+		}
+	}
+
+	objects := make([]fyne.CanvasObject, 0, len(positions))
+	for i := 1; i < len(positions); i++ {
+		line := canvas.NewLine(trackColor)
+		line.StrokeWidth = mapTrackStrokeWidth
+		line.Position1 = positions[i-1]
+		line.Position2 = positions[i]
+		objects = append(objects, line)
+	}
+	if len(positions) > 0 {
+		current := positions[len(positions)-1]
+		dot := canvas.NewCircle(trackColor)
+		dot.StrokeColor = theme.Color(theme.ColorNameBackground)
+		dot.StrokeWidth = 2
+		dot.Resize(fyne.NewSize(mapTrackPointRadius*2, mapTrackPointRadius*2))
+		dot.Move(fyne.NewPos(current.X-mapTrackPointRadius, current.Y-mapTrackPointRadius))
+		objects = append(objects, dot)
+	}
+
+	t.trackLayer.Objects = objects
+	t.trackLayer.Refresh()
+}
+
+// enableTrackPlayback adds the track playback panel to the map.
+func (t *mapTabWidget) enableTrackPlayback(loader mapTrackLoader) {
+	if t == nil || loader == nil || t.trackPlayback != nil {
+		return
+	}
+	t.trackPlayback = newMapTrackPlayback(
+		loader,
+		func() []domain.Node {
+			return t.nodes
+		},
+		t.renderTrack,
+		func(point domain.NodeTrackPoint) {
+			t.panToViewport(centerCoordinateToViewport(
+				mapCoordinate{Latitude: point.Latitude, Longitude: point.Longitude},
+				t.viewState.Zoom,
+			))
+			t.renderMarkers()
+			t.scheduleViewportPersist()
+		},
+	)
+	t.trackPanel.Objects = []fyne.CanvasObject{t.trackPlayback.panel}
+	t.trackButton.OnTapped = func() {
+		t.trackPlayback.Toggle()
+		t.Refresh()
+	}
+	t.trackButton.Show()
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestMapTrackIndexAt(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	points := []domain.NodeTrackPoint{
+		{ObservedAt: start},
+		{ObservedAt: start.Add(time.Minute)},
+		{ObservedAt: start.Add(3 * time.Minute)},
+	}
+	tests := []struct {
+		name string
+		at   time.Time
+		want int
+	}{
+		{name: "before first", at: start.Add(-time.Second), want: -1},
+		{name: "at first", at: start, want: 0},
+		{name: "between points", at: start.Add(2 * time.Minute), want: 1},
+		{name: "after last", at: start.Add(time.Hour), want: 2},
+	}
+
+	for _, tt := range tests {
+		if got := mapTrackIndexAt(points, tt.at); got != tt.want {
+			t.Fatalf("%s: expected %d, got %d", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestMapTrackTimeAtAndFraction(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	points := []domain.NodeTrackPoint{
+		{ObservedAt: start},
+		{ObservedAt: start.Add(4 * time.Minute)},
+	}
+	if got, want := mapTrackTimeAt(points, 0.25), start.Add(time.Minute); !got.Equal(want) {
+		t.Fatalf("time at fraction: expected %v, got %v", want, got)
+	}
+	if got, want := mapTrackTimeAt(points, 2), start.Add(4*time.Minute); !got.Equal(want) {
+		t.Fatalf("time at clamped fraction: expected %v, got %v", want, got)
+	}
+	if got := mapTrackFraction(points, start.Add(3*time.Minute)); got != 0.75 {
+		t.Fatalf("fraction: expected 0.75, got %v", got)
+	}
+	if got := mapTrackFraction(points[:1], start); got != 1 {
+		t.Fatalf("single point fraction: expected 1, got %v", got)
+	}
+}
+
+func TestMapTrackNodeOptionsSkipsUnpositionedNodes(t *testing.T) {
+	lat, lon := 55.75, 37.62
+	labels, ids := mapTrackNodeOptions([]domain.Node{
+		{NodeID: "!00000002", LongName: "Zulu", Latitude: &lat, Longitude: &lon},
+		{NodeID: "!00000003", LongName: "No position"},
+		{NodeID: "!00000001", LongName: "alpha", Latitude: &lat, Longitude: &lon},
+	})
+	want := []string{"alpha (!00000001)", "Zulu (!00000002)"}
+	if len(labels) != len(want) {
+		t.Fatalf("expected labels %v, got %v", want, labels)
+	}
+	for i := range want {
+		if labels[i] != want[i] {
+			t.Fatalf("label %d: expected %q, got %q", i, want[i], labels[i])
+		}
+	}
+	if ids["Zulu (!00000002)"] != "!00000002" {
+		t.Fatalf("expected label to map to node id, got %v", ids)
+	}
+}
+
+func TestMapTrackPlaybackAdvanceStopsAtEnd(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	p := &mapTrackPlayback{
+		points:  []domain.NodeTrackPoint{{ObservedAt: start}, {ObservedAt: start.Add(10 * time.Minute)}},
+		at:      start,
+		speed:   600,
+		playing: true,
+	}
+	if !p.advance(500 * time.Millisecond) {
+		t.Fatalf("expected playback to continue")
+	}
+	if want := start.Add(5 * time.Minute); !p.at.Equal(want) {
+		t.Fatalf("expected playback at %v, got %v", want, p.at)
+	}
+	if p.advance(time.Second) {
+		t.Fatalf("expected playback to stop at the end")
+	}
+	if p.playing || !p.at.Equal(start.Add(10*time.Minute)) {
+		t.Fatalf("expected playback to stop at last point, got playing=%v at=%v", p.playing, p.at)
+	}
+}
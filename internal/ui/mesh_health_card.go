@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// meshHealthRefreshInterval is how often the card is recomputed. Store change channels
+// have a single reader each, so the card polls instead of competing for them.
+const meshHealthRefreshInterval = 30 * time.Second
+
+type meshHealthSource struct {
+	nodeStore   *domain.NodeStore
+	chatStore   *domain.ChatStore
+	localNodeID func() string
+	samples     domain.ActiveNodeSamples
+	lastSample  time.Time
+}
+
+// compute scores the mesh and records the active node count for the trend. It must be
+// called from one goroutine at a time.
+func (s *meshHealthSource) compute(now time.Time) domain.MeshHealth {
+	input := domain.MeshHealthInput{Now: now, LocalNodeID: localNodeIDValue(s.localNodeID)}
+	if s.nodeStore != nil {
+		input.Nodes = s.nodeStore.SnapshotSorted()
+	}
+	if s.chatStore != nil {
+		for _, chat := range s.chatStore.ChatListSorted() {
+			for _, msg := range s.chatStore.Messages(chat.Key) {
+				if msg.Direction == domain.MessageDirectionOut {
+					input.Outgoing = append(input.Outgoing, msg)
+				}
+			}
+		}
+	}
+	if earlier, ok := s.samples.Earlier(now); ok {
+		input.ActiveNodesEarlier = &earlier
+	}
+
+	health := domain.ComputeMeshHealth(input)
+	if now.Sub(s.lastSample) >= time.Minute {
+		s.samples.Record(now, health.ActiveNodes)
+		s.lastSample = now
+	}
+
+	return health
+}
+
+func newMeshHealthCard(window fyne.Window, source *meshHealthSource) fyne.CanvasObject {
+	scoreLabel := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	summaryLabel := widget.NewLabel("")
+	summaryLabel.Truncation = fyne.TextTruncateEllipsis
+	var health domain.MeshHealth
+	render := func(next domain.MeshHealth) {
+		health = next
+		scoreLabel.SetText(meshHealthScoreText(health))
+		summaryLabel.SetText(meshHealthSummaryText(health))
+	}
+	render(source.compute(time.Now()))
+
+	detailsButton := widget.NewButton(i18n.T("Details"), func() {
+		if window == nil {
+			return
+		}
+		content := container.NewVBox()
+		for _, line := range meshHealthDetailLines(health) {
+			label := widget.NewLabel(line)
+			label.Wrapping = fyne.TextWrapWord
+			content.Add(label)
+		}
+		details := dialog.NewCustom(i18n.Tf("Mesh health: %s", meshHealthScoreValue(health)), i18n.T("Close"), content, window)
+		details.Resize(fyne.NewSize(480, 0))
+		details.Show()
+	})
+
+	go func() {
+		ticker := time.NewTicker(meshHealthRefreshInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			next := source.compute(time.Now())
+			fyne.Do(func() {
+				render(next)
+			})
+		}
+	}()
+
+	return container.NewBorder(nil, nil, scoreLabel, detailsButton, summaryLabel)
+}
+
+func meshHealthScoreValue(health domain.MeshHealth) string {
+	if !health.Available {
+		return i18n.T("unknown")
+	}
+
+	return i18n.Tf("%d/100 (%s)", health.Score, i18n.T(health.Rating()))
+}
+
+func meshHealthScoreText(health domain.MeshHealth) string {
+	return i18n.Tf("Mesh health: %s", meshHealthScoreValue(health))
+}
+
+func meshHealthSummaryText(health domain.MeshHealth) string {
+	parts := []string{i18n.Tf("%d active", health.ActiveNodes)}
+	if health.SNR.Available {
+		parts = append(parts, "SNR "+currentDisplayFormatter().Number("%.1f dB", health.MedianSNR))
+	}
+	if health.Utilization.Available {
+		parts = append(parts, "ChUtil "+currentDisplayFormatter().Number("%.1f%%", health.ChannelUtilization))
+	}
+	if health.Delivery.Available {
+		parts = append(parts, i18n.Tf("%d/%d sends failed", health.FailedMessages, health.SentMessages))
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// meshHealthDetailLines explains every component and its share of the score.
+func meshHealthDetailLines(health domain.MeshHealth) []string {
+	formatter := currentDisplayFormatter()
+	lines := []string{
+		i18n.Tf("The score averages the components below; each scores 0-100. Active means heard within the last %.0f hours.", domain.MeshActiveWindow.Hours()),
+	}
+
+	trend := i18n.Tf("Active nodes: %d", health.ActiveNodes)
+	if health.ActiveTrend.Available {
+		trend = i18n.Tf("%s, %d an hour ago", trend, health.ActiveNodesEarlier)
+	} else {
+		trend = i18n.Tf("%s, trend is known after an hour of running", trend)
+	}
+	lines = append(lines, meshHealthComponentLine(trend, health.ActiveTrend))
+
+	snr := i18n.T("Median SNR of active nodes: no data")
+	if health.SNR.Available {
+		snr = i18n.Tf("Median SNR of %d active nodes: %s", health.SNRSamples, formatter.Number("%.1f dB", health.MedianSNR))
+	}
+	lines = append(lines, meshHealthComponentLine(snr, health.SNR))
+
+	utilization := i18n.T("Channel utilization: no data")
+	if health.Utilization.Available {
+		utilization = i18n.Tf("Channel utilization: %s", formatter.Number("%.1f%%", health.ChannelUtilization))
+	}
+	lines = append(lines, meshHealthComponentLine(utilization, health.Utilization))
+
+	delivery := i18n.T("Failed sends in the last 24h: nothing sent")
+	if health.Delivery.Available {
+		delivery = i18n.Tf("Failed sends in the last 24h: %d of %d", health.FailedMessages, health.SentMessages)
+	}
+	lines = append(lines, meshHealthComponentLine(delivery, health.Delivery))
+
+	return lines
+}
+
+func meshHealthComponentLine(text string, component domain.MeshHealthComponent) string {
+	if !component.Available {
+		return i18n.Tf("%s (not scored)", text)
+	}
+
+	return i18n.Tf("%s (score %d)", text, component.Score)
+}
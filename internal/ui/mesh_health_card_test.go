@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestMeshHealthSourceCompute(t *testing.T) {
+	now := time.Now()
+	snr := 5.0
+	nodeStore := domain.NewNodeStore()
+	nodeStore.Upsert(domain.Node{NodeID: "!00000001", LastHeardAt: now})
+	nodeStore.Upsert(domain.Node{NodeID: "!00000002", LastHeardAt: now, SNR: &snr})
+	chatStore := domain.NewChatStore()
+	chatStore.AppendMessage(domain.ChatMessage{ChatKey: "channel:0", Direction: domain.MessageDirectionOut, Status: domain.MessageStatusFailed, At: now})
+	chatStore.AppendMessage(domain.ChatMessage{ChatKey: "channel:0", Direction: domain.MessageDirectionIn, At: now})
+
+	source := &meshHealthSource{
+		nodeStore:   nodeStore,
+		chatStore:   chatStore,
+		localNodeID: func() string { return "!00000001" },
+	}
+	health := source.compute(now)
+	if health.ActiveNodes != 1 || health.SentMessages != 1 || health.FailedMessages != 1 || health.ActiveTrend.Available {
+		t.Fatalf("unexpected health: %+v", health)
+	}
+
+	// An hour later the first sample becomes the trend reference.
+	health = source.compute(now.Add(time.Hour))
+	if !health.ActiveTrend.Available || health.ActiveNodesEarlier != 1 {
+		t.Fatalf("expected active trend after an hour: %+v", health)
+	}
+}
+
+func TestMeshHealthTexts(t *testing.T) {
+	health := domain.MeshHealth{
+		Available:          true,
+		Score:              56,
+		ActiveNodes:        3,
+		MedianSNR:          -5,
+		SNRSamples:         3,
+		SNR:                domain.MeshHealthComponent{Available: true, Score: 50},
+		ChannelUtilization: 25,
+		Utilization:        domain.MeshHealthComponent{Available: true, Score: 50},
+		SentMessages:       4,
+		FailedMessages:     1,
+		Delivery:           domain.MeshHealthComponent{Available: true, Score: 75},
+	}
+
+	if got := meshHealthScoreText(health); got != "Mesh health: 56/100 (fair)" {
+		t.Fatalf("unexpected score text: %q", got)
+	}
+	if got := meshHealthScoreText(domain.MeshHealth{}); got != "Mesh health: unknown" {
+		t.Fatalf("unexpected empty score text: %q", got)
+	}
+	if got := meshHealthSummaryText(health); got != "3 active · SNR -5.0 dB · ChUtil 25.0% · 1/4 sends failed" {
+		t.Fatalf("unexpected summary: %q", got)
+	}
+
+	details := strings.Join(meshHealthDetailLines(health), "\n")
+	for _, want := range []string{
+		"Active nodes: 3, trend is known after an hour of running (not scored)",
+		"Median SNR of 3 active nodes: -5.0 dB (score 50)",
+		"Channel utilization: 25.0% (score 50)",
+		"Failed sends in the last 24h: 1 of 4 (score 75)",
+	} {
+		if !strings.Contains(details, want) {
+			t.Fatalf("expected %q in details:\n%s", want, details)
+		}
+	}
+}
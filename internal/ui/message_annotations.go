@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+const (
+	annotatedFilterStarred = "Starred"
+	annotatedFilterAll     = "All starred or tagged"
+	annotatedFilterTag     = "Tag: "
+)
+
+// chatAnnotationActions loads and stores local stars and tags for chat messages.
+type chatAnnotationActions struct {
+	List          func() ([]domain.MessageAnnotation, error)
+	Save          func(annotation domain.MessageAnnotation) error
+	ListAnnotated func() ([]domain.AnnotatedMessage, error)
+}
+
+func (a chatAnnotationActions) enabled() bool {
+	return a.Save != nil
+}
+
+func canAnnotateMessage(message domain.ChatMessage) bool {
+	return strings.TrimSpace(message.DeviceMessageID) != "" && !isReactionMessage(message)
+}
+
+func messageAnnotationKey(chatKey, deviceMessageID string) string {
+	return strings.TrimSpace(chatKey) + "\x00" + strings.TrimSpace(deviceMessageID)
+}
+
+func messageAnnotationsByKey(annotations []domain.MessageAnnotation) map[string]domain.MessageAnnotation {
+	out := make(map[string]domain.MessageAnnotation, len(annotations))
+	for _, annotation := range annotations {
+		out[messageAnnotationKey(annotation.ChatKey, annotation.DeviceMessageID)] = annotation
+	}
+
+	return out
+}
+
+// messageAnnotationBadge renders a short marker like "★ QSL, action item" for a message bubble.
+func messageAnnotationBadge(annotation domain.MessageAnnotation) string {
+	parts := make([]string, 0, 2)
+	if annotation.Starred {
+		parts = append(parts, "★")
+	}
+	if len(annotation.Tags) > 0 {
+		parts = append(parts, strings.Join(annotation.Tags, ", "))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// annotatedMessageFilterOptions lists the starred view, one option per known tag, and the combined view.
+func annotatedMessageFilterOptions(items []domain.AnnotatedMessage) []string {
+	tags := make(map[string]string)
+	for _, item := range items {
+		for _, tag := range item.Annotation.Tags {
+			key := strings.ToLower(tag)
+			if _, ok := tags[key]; !ok {
+				tags[key] = tag
+			}
+		}
+	}
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out := make([]string, 0, len(keys)+2)
+	out = append(out, i18n.T(annotatedFilterStarred))
+	for _, key := range keys {
+		out = append(out, i18n.T(annotatedFilterTag)+tags[key])
+	}
+
+	return append(out, i18n.T(annotatedFilterAll))
+}
+
+func filterAnnotatedMessages(items []domain.AnnotatedMessage, option string) []domain.AnnotatedMessage {
+	out := make([]domain.AnnotatedMessage, 0, len(items))
+	tagPrefix := i18n.T(annotatedFilterTag)
+	for _, item := range items {
+		switch {
+		case option == i18n.T(annotatedFilterAll):
+			out = append(out, item)
+		case strings.HasPrefix(option, tagPrefix):
+			wanted := strings.TrimPrefix(option, tagPrefix)
+			for _, tag := range item.Annotation.Tags {
+				if strings.EqualFold(tag, wanted) {
+					out = append(out, item)
+
+					break
+				}
+			}
+		default:
+			if item.Annotation.Starred {
+				out = append(out, item)
+			}
+		}
+	}
+
+	return out
+}
+
+func showMessageTagsDialog(window fyne.Window, annotation domain.MessageAnnotation, onSave func(tags []string)) {
+	if window == nil {
+		return
+	}
+	tagsEntry := widget.NewEntry()
+	tagsEntry.SetPlaceHolder("action item, QSL")
+	tagsEntry.SetText(strings.Join(annotation.Tags, ", "))
+	dialog.ShowForm(
+		i18n.T("Message tags"),
+		i18n.T("Save"),
+		i18n.T("Cancel"),
+		[]*widget.FormItem{
+			widget.NewFormItem(i18n.T("Tags"), tagsEntry),
+		},
+		func(ok bool) {
+			if ok {
+				onSave(domain.ParseTags(tagsEntry.Text))
+			}
+		},
+		window,
+	)
+}
+
+func showAnnotatedMessagesDialog(
+	window fyne.Window,
+	listAnnotated func() ([]domain.AnnotatedMessage, error),
+	chatTitleByKey func(chatKey string) string,
+	nodeNameByID func(string) string,
+	localNodeID func() string,
+	onOpenChat func(chatKey string),
+) {
+	if window == nil || listAnnotated == nil {
+		return
+	}
+
+	items, err := listAnnotated()
+	if err != nil {
+		chatsLogger.Warn("list annotated messages failed", "error", err)
+		dialog.ShowError(err, window)
+
+		return
+	}
+
+	var popup dialog.Dialog
+	rows := container.NewVBox()
+	filterSelect := widget.NewSelect(annotatedMessageFilterOptions(items), func(option string) {
+		rows.Objects = annotatedMessageRows(
+			filterAnnotatedMessages(items, option),
+			chatTitleByKey,
+			nodeNameByID,
+			localNodeID,
+			func(chatKey string) {
+				if popup != nil {
+					popup.Hide()
+				}
+				onOpenChat(chatKey)
+			},
+		)
+		rows.Refresh()
+	})
+	filterSelect.SetSelected(i18n.T(annotatedFilterStarred))
+
+	scroll := container.NewVScroll(rows)
+	scroll.SetMinSize(fyne.NewSize(520, 320))
+	popup = dialog.NewCustom(i18n.T("Starred and tagged messages"), i18n.T("Close"), container.NewBorder(filterSelect, nil, nil, nil, scroll), window)
+	popup.Show()
+}
+
+func annotatedMessageRows(
+	items []domain.AnnotatedMessage,
+	chatTitleByKey func(chatKey string) string,
+	nodeNameByID func(string) string,
+	localNodeID func() string,
+	onOpen func(chatKey string),
+) []fyne.CanvasObject {
+	if len(items) == 0 {
+		return []fyne.CanvasObject{widget.NewLabel(i18n.T("No messages here yet."))}
+	}
+
+	out := make([]fyne.CanvasObject, 0, len(items))
+	for _, item := range items {
+		meta, hasMeta := parseMessageMeta(item.Message.MetaJSON)
+		text := messageTextLine(item.Message, meta, hasMeta, nodeNameByID, localNodeID)
+		header := fmt.Sprintf("%s · %s", chatTitleByKey(item.Message.ChatKey), currentDisplayFormatter().DateTime(item.Message.At))
+		if badge := messageAnnotationBadge(item.Annotation); badge != "" {
+			header += " · " + badge
+		}
+		headerLabel := widget.NewLabel(header)
+		headerLabel.TextStyle = fyne.TextStyle{Bold: true}
+		textLabel := widget.NewLabel(text)
+		textLabel.Wrapping = fyne.TextWrapWord
+		chatKey := item.Message.ChatKey
+		out = append(out, container.NewBorder(
+			nil,
+			nil,
+			nil,
+			container.NewVBox(widget.NewButton(i18n.T("Open"), func() { onOpen(chatKey) }), layout.NewSpacer()),
+			container.NewVBox(headerLabel, textLabel),
+		))
+	}
+
+	return out
+}
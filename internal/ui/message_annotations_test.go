@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestMessageAnnotationBadge(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation domain.MessageAnnotation
+		want       string
+	}{
+		{name: "empty", want: ""},
+		{name: "starred", annotation: domain.MessageAnnotation{Starred: true}, want: "★"},
+		{name: "tags", annotation: domain.MessageAnnotation{Tags: []string{"QSL", "action item"}}, want: "QSL, action item"},
+		{name: "starred with tags", annotation: domain.MessageAnnotation{Starred: true, Tags: []string{"QSL"}}, want: "★ QSL"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := messageAnnotationBadge(tc.annotation); got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestAnnotatedMessageFilters(t *testing.T) {
+	items := []domain.AnnotatedMessage{
+		{Message: domain.ChatMessage{DeviceMessageID: "1"}, Annotation: domain.MessageAnnotation{Starred: true}},
+		{Message: domain.ChatMessage{DeviceMessageID: "2"}, Annotation: domain.MessageAnnotation{Tags: []string{"QSL"}}},
+		{Message: domain.ChatMessage{DeviceMessageID: "3"}, Annotation: domain.MessageAnnotation{Starred: true, Tags: []string{"qsl", "Action item"}}},
+	}
+
+	options := annotatedMessageFilterOptions(items)
+	wantOptions := []string{annotatedFilterStarred, "Tag: Action item", "Tag: QSL", annotatedFilterAll}
+	if !slices.Equal(options, wantOptions) {
+		t.Fatalf("expected options %v, got %v", wantOptions, options)
+	}
+
+	tests := []struct {
+		option string
+		want   []string
+	}{
+		{option: annotatedFilterStarred, want: []string{"1", "3"}},
+		{option: "Tag: QSL", want: []string{"2", "3"}},
+		{option: "Tag: Action item", want: []string{"3"}},
+		{option: annotatedFilterAll, want: []string{"1", "2", "3"}},
+	}
+	for _, tc := range tests {
+		filtered := filterAnnotatedMessages(items, tc.option)
+		got := make([]string, 0, len(filtered))
+		for _, item := range filtered {
+			got = append(got, item.Message.DeviceMessageID)
+		}
+		if !slices.Equal(got, tc.want) {
+			t.Fatalf("%s: expected %v, got %v", tc.option, tc.want, got)
+		}
+	}
+}
+
+func TestWithMessageAnnotationItems(t *testing.T) {
+	message := domain.ChatMessage{DeviceMessageID: "abc", ChatKey: "channel:0", Body: "hello", Direction: domain.MessageDirectionIn}
+	var gotActions []ChatAction
+	onAction := func(_ domain.ChatMessage, action ChatAction) {
+		gotActions = append(gotActions, action)
+	}
+
+	menu := withMessageAnnotationItems(newChatMessageContextMenu(message, onAction), message, domain.MessageAnnotation{Starred: true}, onAction)
+	if got, want := len(menu.Items), 5; got != want {
+		t.Fatalf("expected %d menu items, got %d", want, got)
+	}
+	if !menu.Items[2].IsSeparator {
+		t.Fatalf("expected separator before annotation items")
+	}
+	star, tags := menu.Items[3], menu.Items[4]
+	if star.Label != "Unstar" || tags.Label != "Edit tags…" {
+		t.Fatalf("unexpected annotation item labels: %q, %q", star.Label, tags.Label)
+	}
+	star.Action()
+	tags.Action()
+	if !slices.Equal(gotActions, []ChatAction{ChatActionStar, ChatActionEditTags}) {
+		t.Fatalf("unexpected actions: %v", gotActions)
+	}
+
+	unsent := domain.ChatMessage{ChatKey: "channel:0", Body: "draft", Direction: domain.MessageDirectionOut}
+	menu = withMessageAnnotationItems(newChatMessageContextMenu(unsent, nil), unsent, domain.MessageAnnotation{}, nil)
+	if menu.Items[3].Label != "Star" || !menu.Items[3].Disabled || !menu.Items[4].Disabled {
+		t.Fatalf("expected annotation items to be disabled for message without device id")
+	}
+}
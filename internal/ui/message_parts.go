@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/radio"
+)
+
+// messagePartsWindow is how long after the first part the other parts of a split
+// message are looked for.
+const messagePartsWindow = 10 * time.Minute
+
+// incompleteMessagePartsMarker ends a reassembled message that is missing parts.
+const incompleteMessagePartsMarker = " …"
+
+// queuedTextSender is implemented by message senders with a rate-limited queue. The
+// parts of a split message go through it, so they are sent one by one.
+type queuedTextSender interface {
+	QueueText(chatKey, text string, opts radio.TextSendOptions) <-chan radio.SendResult
+}
+
+type messagePartsSender struct {
+	direction domain.MessageDirection
+	nodeID    string
+}
+
+func messagePartSender(message domain.ChatMessage, localNodeID func() string) messagePartsSender {
+	meta, hasMeta := parseMessageMeta(message.MetaJSON)
+
+	return messagePartsSender{
+		direction: message.Direction,
+		nodeID:    messageSenderID(message, meta, hasMeta, localNodeID),
+	}
+}
+
+// mergeMessageParts shows the numbered parts of a split message as one message. Parts
+// are joined to the first part when they come from the same sender, in order, within
+// messagePartsWindow; other messages in between stay where they are. It returns the
+// merged timeline and the device ids of the merged parts mapped to the id of their
+// first part.
+func mergeMessageParts(timeline []domain.ChatMessage, localNodeID func() string) ([]domain.ChatMessage, map[string]string) {
+	aliases := make(map[string]string)
+	merged := make([]bool, len(timeline))
+	out := make([]domain.ChatMessage, 0, len(timeline))
+	for i, message := range timeline {
+		if merged[i] {
+			continue
+		}
+		first, ok := domain.ParseMessagePart(message.Body)
+		if !ok || first.Index != 1 {
+			out = append(out, message)
+
+			continue
+		}
+
+		sender := messagePartSender(message, localNodeID)
+		var body strings.Builder
+		body.WriteString(first.Text)
+		combined := message
+		next := 2
+		for j := i + 1; j < len(timeline) && next <= first.Total; j++ {
+			candidate := timeline[j]
+			if candidate.At.Sub(message.At) > messagePartsWindow {
+				break
+			}
+			part, ok := domain.ParseMessagePart(candidate.Body)
+			if merged[j] || !ok || part.Total != first.Total || part.Index != next {
+				continue
+			}
+			if messagePartSender(candidate, localNodeID) != sender {
+				continue
+			}
+			merged[j] = true
+			next++
+			body.WriteString(part.Text)
+			if combined.Status != domain.MessageStatusFailed {
+				combined.Status = candidate.Status
+			}
+			if id := strings.TrimSpace(candidate.DeviceMessageID); id != "" {
+				aliases[id] = strings.TrimSpace(message.DeviceMessageID)
+			}
+		}
+		if next == 2 {
+			// A lone first part reads better as it came.
+			out = append(out, message)
+
+			continue
+		}
+		combined.Body = body.String()
+		if next <= first.Total {
+			combined.Body = strings.TrimRight(combined.Body, " ") + incompleteMessagePartsMarker
+		}
+		out = append(out, combined)
+	}
+
+	return out, aliases
+}
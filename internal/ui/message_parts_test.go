@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestMergeMessageParts(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	incoming := func(id, from, body string, offset time.Duration) domain.ChatMessage {
+		return domain.ChatMessage{
+			DeviceMessageID: id,
+			Direction:       domain.MessageDirectionIn,
+			Body:            body,
+			MetaJSON:        `{"from":"` + from + `"}`,
+			At:              base.Add(offset),
+		}
+	}
+	tests := []struct {
+		name        string
+		timeline    []domain.ChatMessage
+		wantBodies  []string
+		wantAliases map[string]string
+	}{
+		{
+			name: "interleaved parts are joined",
+			timeline: []domain.ChatMessage{
+				incoming("1", "!00000001", "1/2 hello ", 0),
+				incoming("2", "!00000002", "unrelated", time.Second),
+				incoming("3", "!00000001", "2/2 world", 2*time.Second),
+			},
+			wantBodies:  []string{"hello world", "unrelated"},
+			wantAliases: map[string]string{"3": "1"},
+		},
+		{
+			name: "parts of another sender are not joined",
+			timeline: []domain.ChatMessage{
+				incoming("1", "!00000001", "1/2 hello ", 0),
+				incoming("2", "!00000002", "2/2 world", time.Second),
+			},
+			wantBodies:  []string{"1/2 hello ", "2/2 world"},
+			wantAliases: map[string]string{},
+		},
+		{
+			name: "missing parts are marked",
+			timeline: []domain.ChatMessage{
+				incoming("1", "!00000001", "1/3 one ", 0),
+				incoming("2", "!00000001", "2/3 two ", time.Second),
+			},
+			wantBodies:  []string{"one two" + incompleteMessagePartsMarker},
+			wantAliases: map[string]string{"2": "1"},
+		},
+		{
+			name: "late parts stay apart",
+			timeline: []domain.ChatMessage{
+				incoming("1", "!00000001", "1/2 hello ", 0),
+				incoming("2", "!00000001", "2/2 world", messagePartsWindow+time.Second),
+			},
+			wantBodies:  []string{"1/2 hello ", "2/2 world"},
+			wantAliases: map[string]string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, aliases := mergeMessageParts(tc.timeline, nil)
+			if len(got) != len(tc.wantBodies) {
+				t.Fatalf("unexpected message count: expected %d, got %d", len(tc.wantBodies), len(got))
+			}
+			for i, want := range tc.wantBodies {
+				if got[i].Body != want {
+					t.Fatalf("unexpected body %d: expected %q, got %q", i, want, got[i].Body)
+				}
+			}
+			if len(aliases) != len(tc.wantAliases) {
+				t.Fatalf("unexpected aliases: expected %v, got %v", tc.wantAliases, aliases)
+			}
+			for partID, firstID := range tc.wantAliases {
+				if aliases[partID] != firstID {
+					t.Fatalf("unexpected alias of %s: expected %q, got %q", partID, firstID, aliases[partID])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+const pinnedMessagePreviewMaxLen = 72
+
+// chatPinActions loads and stores local message pins.
+type chatPinActions struct {
+	List  func() ([]domain.MessagePin, error)
+	Pin   func(chatKey, deviceMessageID string) error
+	Unpin func(chatKey, deviceMessageID string) error
+}
+
+func (a chatPinActions) enabled() bool {
+	return a.Pin != nil && a.Unpin != nil
+}
+
+func messagePinsByKey(pins []domain.MessagePin) map[string]domain.MessagePin {
+	out := make(map[string]domain.MessagePin, len(pins))
+	for _, pin := range pins {
+		out[messageAnnotationKey(pin.ChatKey, pin.DeviceMessageID)] = pin
+	}
+
+	return out
+}
+
+// pinnedChatMessages returns the pinned messages of a chat in pin order. Pins of
+// messages that are not loaded are skipped.
+func pinnedChatMessages(pinsByKey map[string]domain.MessagePin, messages []domain.ChatMessage) []domain.ChatMessage {
+	type pinnedMessage struct {
+		message  domain.ChatMessage
+		pinnedAt int64
+	}
+	pinned := make([]pinnedMessage, 0)
+	for _, message := range messages {
+		pin, ok := pinsByKey[messageAnnotationKey(message.ChatKey, message.DeviceMessageID)]
+		if !ok || !canAnnotateMessage(message) {
+			continue
+		}
+		pinned = append(pinned, pinnedMessage{message: message, pinnedAt: pin.PinnedAt.UnixMilli()})
+	}
+	sort.SliceStable(pinned, func(i, j int) bool {
+		return pinned[i].pinnedAt < pinned[j].pinnedAt
+	})
+
+	out := make([]domain.ChatMessage, 0, len(pinned))
+	for _, item := range pinned {
+		out = append(out, item.message)
+	}
+
+	return out
+}
+
+func pinnedMessagePreview(message domain.ChatMessage, nodeNameByID func(string) string) string {
+	body := compactWhitespace(message.Body)
+	if body == "" {
+		body = i18n.T("(empty)")
+	}
+
+	return truncatePreview(fmt.Sprintf("%s: %s", previewSender(message, nodeNameByID), body), pinnedMessagePreviewMaxLen)
+}
+
+// pinnedStripTitle shows the latest pin while the strip is collapsed.
+func pinnedStripTitle(previews []string, expanded bool) string {
+	switch {
+	case len(previews) == 0:
+		return ""
+	case expanded:
+		return i18n.Tf("📌 Pinned messages (%d)", len(previews))
+	case len(previews) == 1:
+		return "📌 " + previews[0]
+	default:
+		return fmt.Sprintf("📌 %s (+%d)", previews[len(previews)-1], len(previews)-1)
+	}
+}
+
+// pinnedMessagesStrip is the collapsible list of pinned messages above the message list.
+type pinnedMessagesStrip struct {
+	nodeNameByID func(string) string
+	onOpen       func(message domain.ChatMessage)
+	onUnpin      func(message domain.ChatMessage)
+
+	expanded bool
+	messages []domain.ChatMessage
+	toggle   *widget.Button
+	rows     *fyne.Container
+	content  *fyne.Container
+}
+
+func newPinnedMessagesStrip(
+	nodeNameByID func(string) string,
+	onOpen func(message domain.ChatMessage),
+	onUnpin func(message domain.ChatMessage),
+) *pinnedMessagesStrip {
+	s := &pinnedMessagesStrip{nodeNameByID: nodeNameByID, onOpen: onOpen, onUnpin: onUnpin}
+	s.toggle = widget.NewButton("", func() {
+		s.expanded = !s.expanded
+		s.render()
+	})
+	s.toggle.Alignment = widget.ButtonAlignLeading
+	s.toggle.Importance = widget.LowImportance
+	s.rows = container.NewVBox()
+	s.content = container.NewVBox(s.toggle, s.rows)
+	s.render()
+
+	return s
+}
+
+// SetMessages replaces the pinned messages shown in the strip.
+func (s *pinnedMessagesStrip) SetMessages(messages []domain.ChatMessage) {
+	s.messages = messages
+	s.render()
+}
+
+func (s *pinnedMessagesStrip) render() {
+	if len(s.messages) == 0 {
+		s.content.Hide()
+
+		return
+	}
+
+	previews := make([]string, 0, len(s.messages))
+	for _, message := range s.messages {
+		previews = append(previews, pinnedMessagePreview(message, s.nodeNameByID))
+	}
+	s.toggle.SetText(pinnedStripTitle(previews, s.expanded))
+	if s.expanded {
+		s.toggle.SetIcon(theme.MenuDropUpIcon())
+	} else {
+		s.toggle.SetIcon(theme.MenuDropDownIcon())
+	}
+
+	s.rows.Objects = s.rows.Objects[:0]
+	if s.expanded {
+		for i, message := range s.messages {
+			open := widget.NewButton(previews[i], func() {
+				if s.onOpen != nil {
+					s.onOpen(message)
+				}
+			})
+			open.Alignment = widget.ButtonAlignLeading
+			open.Importance = widget.LowImportance
+			unpin := widget.NewButtonWithIcon("", theme.CancelIcon(), func() {
+				if s.onUnpin != nil {
+					s.onUnpin(message)
+				}
+			})
+			unpin.Importance = widget.LowImportance
+			s.rows.Add(container.NewBorder(nil, nil, nil, unpin, open))
+		}
+	}
+	s.rows.Refresh()
+	s.content.Show()
+	s.content.Refresh()
+}
+
+// messageTimelineIndex returns the list position of the message, or -1 when it is not shown.
+func messageTimelineIndex(timeline []domain.ChatMessage, deviceMessageID string) int {
+	if deviceMessageID == "" {
+		return -1
+	}
+	for i, message := range timeline {
+		if message.DeviceMessageID == deviceMessageID {
+			return i
+		}
+	}
+
+	return -1
+}
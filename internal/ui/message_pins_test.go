@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestPinnedChatMessagesFollowsPinOrder(t *testing.T) {
+	base := time.Now()
+	messages := []domain.ChatMessage{
+		{ChatKey: "channel:0", DeviceMessageID: "1", Body: "rules"},
+		{ChatKey: "channel:0", DeviceMessageID: "2", Body: "coordinates"},
+		{ChatKey: "channel:0", DeviceMessageID: "3", Body: "not pinned"},
+		{ChatKey: "channel:0", Body: "pending without id"},
+	}
+	pins := messagePinsByKey([]domain.MessagePin{
+		{ChatKey: "channel:0", DeviceMessageID: "2", PinnedAt: base},
+		{ChatKey: "channel:0", DeviceMessageID: "1", PinnedAt: base.Add(time.Minute)},
+		{ChatKey: "channel:0", DeviceMessageID: "404", PinnedAt: base},
+		{ChatKey: "channel:1", DeviceMessageID: "3", PinnedAt: base},
+	})
+
+	got := pinnedChatMessages(pins, messages)
+	ids := make([]string, 0, len(got))
+	for _, message := range got {
+		ids = append(ids, message.DeviceMessageID)
+	}
+	if want := []string{"2", "1"}; !slices.Equal(ids, want) {
+		t.Fatalf("expected pinned ids %v, got %v", want, ids)
+	}
+}
+
+func TestPinnedStripTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		previews []string
+		expanded bool
+		want     string
+	}{
+		{name: "empty", want: ""},
+		{name: "single", previews: []string{"you: rules"}, want: "📌 you: rules"},
+		{name: "collapsed shows latest", previews: []string{"you: rules", "you: grid"}, want: "📌 you: grid (+1)"},
+		{name: "expanded", previews: []string{"you: rules", "you: grid"}, expanded: true, want: "📌 Pinned messages (2)"},
+	}
+	for _, tc := range tests {
+		if got := pinnedStripTitle(tc.previews, tc.expanded); got != tc.want {
+			t.Fatalf("%s: expected %q, got %q", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestMessageTimelineIndex(t *testing.T) {
+	timeline := []domain.ChatMessage{{DeviceMessageID: "1"}, {}, {DeviceMessageID: "2"}}
+	if got := messageTimelineIndex(timeline, "2"); got != 2 {
+		t.Fatalf("expected index 2, got %d", got)
+	}
+	if got := messageTimelineIndex(timeline, ""); got != -1 {
+		t.Fatalf("expected -1 for empty id, got %d", got)
+	}
+}
+
+func TestWithMessagePinItems(t *testing.T) {
+	message := domain.ChatMessage{DeviceMessageID: "abc", ChatKey: "channel:0", Body: "hello", Direction: domain.MessageDirectionIn}
+	var gotAction ChatAction
+	menu := withMessagePinItems(newChatMessageContextMenu(message, nil), message, true, func(_ domain.ChatMessage, action ChatAction) {
+		gotAction = action
+	})
+	item := menu.Items[len(menu.Items)-1]
+	if item.Label != "Unpin message" || item.Disabled {
+		t.Fatalf("unexpected pin item: %q disabled=%v", item.Label, item.Disabled)
+	}
+	item.Action()
+	if gotAction != ChatActionPin {
+		t.Fatalf("expected %q action, got %q", ChatActionPin, gotAction)
+	}
+
+	unsent := domain.ChatMessage{ChatKey: "channel:0", Body: "draft", Direction: domain.MessageDirectionOut}
+	menu = withMessagePinItems(newChatMessageContextMenu(unsent, nil), unsent, false, nil)
+	item = menu.Items[len(menu.Items)-1]
+	if item.Label != "Pin message" || !item.Disabled {
+		t.Fatalf("expected disabled pin item for message without device id")
+	}
+}
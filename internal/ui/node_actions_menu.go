@@ -7,59 +7,105 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 // NodeAction identifies a node-level action available from context menu.
 type NodeAction string
 
 const (
-	NodeActionDirectMessage NodeAction = "direct_message"
-	NodeActionShare         NodeAction = "share"
-	NodeActionFavorite      NodeAction = "favorite"
-	NodeActionTraceroute    NodeAction = "traceroute"
-	NodeActionInfo          NodeAction = "info"
+	NodeActionDirectMessage   NodeAction = "direct_message"
+	NodeActionShare           NodeAction = "share"
+	NodeActionCopyGrid        NodeAction = "copy_grid"
+	NodeActionCopyID          NodeAction = "copy_id"
+	NodeActionCopyHexID       NodeAction = "copy_hex_id"
+	NodeActionCopyPublicKey   NodeAction = "copy_public_key"
+	NodeActionCopyCoordinates NodeAction = "copy_coordinates"
+	NodeActionCopyGeoURI      NodeAction = "copy_geo_uri"
+	NodeActionFavorite        NodeAction = "favorite"
+	NodeActionMute            NodeAction = "mute"
+	NodeActionPresenceAlerts  NodeAction = "presence_alerts"
+	NodeActionTraceroute      NodeAction = "traceroute"
+	NodeActionInfo            NodeAction = "info"
+	NodeActionDelete          NodeAction = "delete"
 )
 
 // NodeActionHandler handles selected node action menu item.
 type NodeActionHandler func(node domain.Node, action NodeAction)
 
-func newNodeContextMenu(node domain.Node, isLocal bool, onAction NodeActionHandler) *fyne.Menu {
+// newNodeContextMenu builds the node actions menu. muted tells whether notifications
+// about messages from the node are muted.
+func newNodeContextMenu(node domain.Node, isLocal, muted bool, onAction NodeActionHandler) *fyne.Menu {
 	menuTitle := strings.TrimSpace(nodeDisplayName(node))
 	if menuTitle == "" {
-		menuTitle = "Node"
+		menuTitle = i18n.T("Node")
 	}
 
 	items := []*fyne.MenuItem{
-		fyne.NewMenuItem("Direct message", func() {
+		fyne.NewMenuItem(i18n.T("Direct message"), func() {
 			if onAction != nil {
 				onAction(node, NodeActionDirectMessage)
 			}
 		}),
-		fyne.NewMenuItem("Share", func() {
+		fyne.NewMenuItem(i18n.T("Share"), func() {
 			if onAction != nil {
 				onAction(node, NodeActionShare)
 			}
 		}),
 	}
+	if grid := nodeGridSquare(node); grid != "" {
+		items = append(items, fyne.NewMenuItem(i18n.Tf("Copy grid square (%s)", grid), func() {
+			if onAction != nil {
+				onAction(node, NodeActionCopyGrid)
+			}
+		}))
+	}
+	if copyItems := newNodeCopyMenuItems(node, func(item nodeCopyItem) {
+		if onAction != nil {
+			onAction(node, item.Action)
+		}
+	}); len(copyItems) > 0 {
+		copyItem := fyne.NewMenuItem(i18n.T("Copy"), nil)
+		copyItem.ChildMenu = fyne.NewMenu("", copyItems...)
+		items = append(items, copyItem)
+	}
 	if !isLocal {
 		items = append(items, fyne.NewMenuItem(nodeFavoriteMenuLabel(node), func() {
 			if onAction != nil {
 				onAction(node, NodeActionFavorite)
 			}
+		}), fyne.NewMenuItem(nodeMuteMenuLabel(muted), func() {
+			if onAction != nil {
+				onAction(node, NodeActionMute)
+			}
 		}))
+		if nodeIsFavorite(node) {
+			items = append(items, fyne.NewMenuItem(i18n.T("Presence alerts…"), func() {
+				if onAction != nil {
+					onAction(node, NodeActionPresenceAlerts)
+				}
+			}))
+		}
 	}
 	items = append(items,
-		fyne.NewMenuItem("Traceroute", func() {
+		fyne.NewMenuItem(i18n.T("Traceroute"), func() {
 			if onAction != nil {
 				onAction(node, NodeActionTraceroute)
 			}
 		}),
-		fyne.NewMenuItem("Node info", func() {
+		fyne.NewMenuItem(i18n.T("Node info"), func() {
 			if onAction != nil {
 				onAction(node, NodeActionInfo)
 			}
 		}),
 	)
+	if !isLocal {
+		items = append(items, fyne.NewMenuItem(i18n.T("Delete"), func() {
+			if onAction != nil {
+				onAction(node, NodeActionDelete)
+			}
+		}))
+	}
 
 	return fyne.NewMenu(menuTitle, items...)
 }
@@ -69,18 +115,43 @@ func showNodeContextMenu(
 	position fyne.Position,
 	node domain.Node,
 	isLocal bool,
+	muted bool,
 	onAction NodeActionHandler,
 ) {
 	if canvas == nil {
 		return
 	}
-	widget.ShowPopUpMenuAtPosition(newNodeContextMenu(node, isLocal, onAction), canvas, position)
+	widget.ShowPopUpMenuAtPosition(newNodeContextMenu(node, isLocal, muted, onAction), canvas, position)
 }
 
 func nodeFavoriteMenuLabel(node domain.Node) string {
-	if node.IsFavorite != nil && *node.IsFavorite {
-		return "Unfavorite"
+	if nodeIsFavorite(node) {
+		return i18n.T("Unfavorite")
 	}
 
-	return "Favorite"
+	return i18n.T("Favorite")
+}
+
+func nodeMuteMenuLabel(muted bool) string {
+	if muted {
+		return i18n.T("Unmute notifications")
+	}
+
+	return i18n.T("Mute notifications")
+}
+
+func nodeIgnoreLabel(node domain.Node) string {
+	if nodeIsIgnored(node) {
+		return i18n.T("Unignore")
+	}
+
+	return i18n.T("Ignore")
+}
+
+func nodeIsFavorite(node domain.Node) bool {
+	return node.IsFavorite != nil && *node.IsFavorite
+}
+
+func nodeIsIgnored(node domain.Node) bool {
+	return node.IsIgnored != nil && *node.IsIgnored
 }
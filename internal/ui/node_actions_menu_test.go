@@ -3,103 +3,112 @@ package ui
 import (
 	"testing"
 
+	"fyne.io/fyne/v2"
+
 	"github.com/skobkin/meshgo/internal/domain"
 )
 
 func TestNewNodeContextMenu_ContainsNodeInfoAction(t *testing.T) {
 	node := domain.Node{NodeID: "!0000002a", LongName: "Alpha", ShortName: "ALPH"}
 
-	calledActions := make([]NodeAction, 0, 5)
-	menu := newNodeContextMenu(node, false, func(_ domain.Node, action NodeAction) {
+	assertNodeContextMenu(t, newNodeContextMenu,
+		node, false,
+		[]string{"Direct message", "Share", "Copy", "Favorite", "Mute notifications", "Traceroute", "Node info", "Delete"},
+		[]NodeAction{NodeActionDirectMessage, NodeActionShare, NodeActionFavorite, NodeActionMute, NodeActionTraceroute, NodeActionInfo, NodeActionDelete},
+	)
+}
+
+func TestNewNodeContextMenu_FavoriteContainsPresenceAlertsAction(t *testing.T) {
+	favorite := true
+	node := domain.Node{NodeID: "!0000002a", LongName: "Alpha", ShortName: "ALPH", IsFavorite: &favorite}
+
+	assertNodeContextMenu(t, newNodeContextMenu,
+		node, false,
+		[]string{"Direct message", "Share", "Copy", "Unfavorite", "Mute notifications", "Presence alerts…", "Traceroute", "Node info", "Delete"},
+		[]NodeAction{NodeActionDirectMessage, NodeActionShare, NodeActionFavorite, NodeActionMute, NodeActionPresenceAlerts, NodeActionTraceroute, NodeActionInfo, NodeActionDelete},
+	)
+}
+
+func TestNewNodeContextMenu_LocalNodeDoesNotContainFavoriteAction(t *testing.T) {
+	node := domain.Node{NodeID: "!0000002a", LongName: "Alpha", ShortName: "ALPH"}
+
+	assertNodeContextMenu(t, newNodeContextMenu,
+		node, true,
+		[]string{"Direct message", "Share", "Copy", "Traceroute", "Node info"},
+		[]NodeAction{NodeActionDirectMessage, NodeActionShare, NodeActionTraceroute, NodeActionInfo},
+	)
+}
+
+// assertNodeContextMenu checks the menu item labels and the actions of the items that
+// have no submenu.
+func assertNodeContextMenu(
+	t *testing.T,
+	build func(domain.Node, bool, bool, NodeActionHandler) *fyne.Menu,
+	node domain.Node,
+	isLocal bool,
+	labels []string,
+	actions []NodeAction,
+) {
+	t.Helper()
+
+	calledActions := make([]NodeAction, 0, len(actions))
+	menu := build(node, isLocal, false, func(_ domain.Node, action NodeAction) {
 		calledActions = append(calledActions, action)
 	})
 	if menu == nil {
 		t.Fatalf("expected menu")
 	}
-	if len(menu.Items) != 5 {
-		t.Fatalf("expected five menu items, got %d", len(menu.Items))
-	}
-	if menu.Items[0].Label != "Direct message" {
-		t.Fatalf("unexpected first menu item label: %q", menu.Items[0].Label)
+	if len(menu.Items) != len(labels) {
+		t.Fatalf("unexpected menu item count: expected %d, got %d", len(labels), len(menu.Items))
 	}
-	if menu.Items[1].Label != "Share" {
-		t.Fatalf("unexpected second menu item label: %q", menu.Items[1].Label)
+	for i, item := range menu.Items {
+		if item.Label != labels[i] {
+			t.Fatalf("unexpected menu item %d label: expected %q, got %q", i, labels[i], item.Label)
+		}
+		if item.ChildMenu == nil {
+			item.Action()
+		}
 	}
-	if menu.Items[2].Label != "Favorite" {
-		t.Fatalf("unexpected third menu item label: %q", menu.Items[2].Label)
+	if len(calledActions) != len(actions) {
+		t.Fatalf("unexpected callback invocations: expected %v, got %v", actions, calledActions)
 	}
-	if menu.Items[3].Label != "Traceroute" {
-		t.Fatalf("unexpected fourth menu item label: %q", menu.Items[3].Label)
-	}
-	if menu.Items[4].Label != "Node info" {
-		t.Fatalf("unexpected fifth menu item label: %q", menu.Items[4].Label)
-	}
-	for _, item := range menu.Items {
-		item.Action()
-	}
-	if len(calledActions) != 5 {
-		t.Fatalf("expected five callback invocations, got %d", len(calledActions))
-	}
-	if calledActions[0] != NodeActionDirectMessage {
-		t.Fatalf("unexpected first action: %q", calledActions[0])
-	}
-	if calledActions[1] != NodeActionShare {
-		t.Fatalf("unexpected second action: %q", calledActions[1])
-	}
-	if calledActions[2] != NodeActionFavorite {
-		t.Fatalf("unexpected third action: %q", calledActions[2])
-	}
-	if calledActions[3] != NodeActionTraceroute {
-		t.Fatalf("unexpected fourth action: %q", calledActions[3])
-	}
-	if calledActions[4] != NodeActionInfo {
-		t.Fatalf("unexpected fifth action: %q", calledActions[4])
+	for i, action := range actions {
+		if calledActions[i] != action {
+			t.Fatalf("unexpected action %d: expected %q, got %q", i, action, calledActions[i])
+		}
 	}
 }
 
-func TestNewNodeContextMenu_LocalNodeDoesNotContainFavoriteAction(t *testing.T) {
-	node := domain.Node{NodeID: "!0000002a", LongName: "Alpha", ShortName: "ALPH"}
+func TestNewNodeContextMenu_NodeWithPositionContainsCopyGridAction(t *testing.T) {
+	lat, lon := 50.450333, 30.523333
+	node := domain.Node{NodeID: "!0000002a", LongName: "Alpha", Latitude: &lat, Longitude: &lon}
 
-	calledActions := make([]NodeAction, 0, 4)
-	menu := newNodeContextMenu(node, true, func(_ domain.Node, action NodeAction) {
-		calledActions = append(calledActions, action)
+	var calledAction NodeAction
+	menu := newNodeContextMenu(node, true, false, func(_ domain.Node, action NodeAction) {
+		calledAction = action
 	})
-	if menu == nil {
-		t.Fatalf("expected menu")
-	}
-	if len(menu.Items) != 4 {
-		t.Fatalf("expected four menu items, got %d", len(menu.Items))
-	}
-	if menu.Items[0].Label != "Direct message" {
-		t.Fatalf("unexpected first menu item label: %q", menu.Items[0].Label)
+	if len(menu.Items) != 6 {
+		t.Fatalf("expected six menu items, got %d", len(menu.Items))
 	}
-	if menu.Items[1].Label != "Share" {
-		t.Fatalf("unexpected second menu item label: %q", menu.Items[1].Label)
-	}
-	if menu.Items[2].Label != "Traceroute" {
+	if menu.Items[2].Label != "Copy grid square (KO50gk)" {
 		t.Fatalf("unexpected third menu item label: %q", menu.Items[2].Label)
 	}
-	if menu.Items[3].Label != "Node info" {
-		t.Fatalf("unexpected fourth menu item label: %q", menu.Items[3].Label)
+	menu.Items[2].Action()
+	if calledAction != NodeActionCopyGrid {
+		t.Fatalf("unexpected action: %q", calledAction)
 	}
+}
+
+func TestNewNodeContextMenu_MutedNodeOffersUnmute(t *testing.T) {
+	node := domain.Node{NodeID: "!0000002a", LongName: "Alpha"}
+
+	menu := newNodeContextMenu(node, false, true, nil)
 	for _, item := range menu.Items {
-		item.Action()
-	}
-	if len(calledActions) != 4 {
-		t.Fatalf("expected four callback invocations, got %d", len(calledActions))
-	}
-	if calledActions[0] != NodeActionDirectMessage {
-		t.Fatalf("unexpected first action: %q", calledActions[0])
-	}
-	if calledActions[1] != NodeActionShare {
-		t.Fatalf("unexpected second action: %q", calledActions[1])
-	}
-	if calledActions[2] != NodeActionTraceroute {
-		t.Fatalf("unexpected third action: %q", calledActions[2])
-	}
-	if calledActions[3] != NodeActionInfo {
-		t.Fatalf("unexpected fourth action: %q", calledActions[3])
+		if item.Label == "Unmute notifications" {
+			return
+		}
 	}
+	t.Fatalf("expected an unmute action for a muted node")
 }
 
 func TestNodeFavoriteMenuLabel(t *testing.T) {
@@ -111,3 +120,34 @@ func TestNodeFavoriteMenuLabel(t *testing.T) {
 		t.Fatalf("unexpected favorite label for marked node: %q", got)
 	}
 }
+
+func TestNewNodeContextMenu_CopySubmenu(t *testing.T) {
+	lat, lon := 50.450333, 30.523333
+	node := domain.Node{NodeID: "!0000002a", PublicKey: []byte{1, 2, 3}, Latitude: &lat, Longitude: &lon}
+
+	var calledActions []NodeAction
+	menu := newNodeContextMenu(node, true, false, func(_ domain.Node, action NodeAction) {
+		calledActions = append(calledActions, action)
+	})
+	var copyMenu *fyne.Menu
+	for _, item := range menu.Items {
+		if item.Label == "Copy" {
+			copyMenu = item.ChildMenu
+		}
+	}
+	if copyMenu == nil {
+		t.Fatalf("expected a copy submenu")
+	}
+	for _, item := range copyMenu.Items {
+		item.Action()
+	}
+	want := []NodeAction{NodeActionCopyID, NodeActionCopyHexID, NodeActionCopyPublicKey, NodeActionCopyCoordinates, NodeActionCopyGeoURI}
+	if len(calledActions) != len(want) {
+		t.Fatalf("unexpected copy actions: expected %v, got %v", want, calledActions)
+	}
+	for i := range want {
+		if calledActions[i] != want[i] {
+			t.Fatalf("unexpected copy action %d: expected %q, got %q", i, want[i], calledActions[i])
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/geo"
 )
 
 func resolveNodeDisplayName(store *domain.NodeStore) func(string) string {
@@ -90,3 +91,17 @@ func resolveRelayDisplayFromNodeID(relayByte uint32, nodeID string, nodeNameByID
 
 	return displaySender(nodeID, nodeNameByID)
 }
+
+func nodeHasPosition(node domain.Node) bool {
+	return node.Latitude != nil && node.Longitude != nil
+}
+
+// nodeGridSquare returns the six character Maidenhead locator of the node position,
+// or "" when the position is unknown.
+func nodeGridSquare(node domain.Node) string {
+	if !nodeHasPosition(node) {
+		return ""
+	}
+
+	return geo.Maidenhead(*node.Latitude, *node.Longitude, 3)
+}
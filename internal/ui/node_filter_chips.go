@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// nodeRecentlyHeardWindow is how recently a node must be heard to pass the recently
+// heard filter.
+const nodeRecentlyHeardWindow = time.Hour
+
+// nodeQuickFilters are the filter chips of the node list. They narrow the list on top
+// of the text filter. Ignored nodes are hidden unless ShowIgnored is set.
+type nodeQuickFilters struct {
+	Favorites     bool
+	RecentlyHeard bool
+	DirectRF      bool
+	HasPosition   bool
+	ShowIgnored   bool
+}
+
+func (f nodeQuickFilters) matches(node domain.Node, now time.Time) bool {
+	if !f.ShowIgnored && nodeIsIgnored(node) {
+		return false
+	}
+	if f.Favorites && !nodeIsFavorite(node) {
+		return false
+	}
+	if f.RecentlyHeard && (node.LastHeardAt.IsZero() || now.Sub(node.LastHeardAt) > nodeRecentlyHeardWindow) {
+		return false
+	}
+	if f.DirectRF && (node.HopsAway == nil || *node.HopsAway != 0) {
+		return false
+	}
+	if f.HasPosition && (node.Latitude == nil || node.Longitude == nil) {
+		return false
+	}
+
+	return true
+}
+
+// applyNodeQuickFilters keeps the nodes passing the chips, preserving their order.
+// The local node is always kept.
+func applyNodeQuickFilters(nodes []domain.Node, filters nodeQuickFilters, localNodeID string, now time.Time) []domain.Node {
+	out := make([]domain.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if isLocalNode(node, localNodeID) || filters.matches(node, now) {
+			out = append(out, node)
+		}
+	}
+
+	return out
+}
+
+// newNodeFilterChips builds a toggle button per filter of filters. onChange is called
+// after every toggle.
+func newNodeFilterChips(filters *nodeQuickFilters, onChange func()) fyne.CanvasObject {
+	chips := []struct {
+		label string
+		value *bool
+	}{
+		{label: "Favorites", value: &filters.Favorites},
+		{label: "Heard < 1h", value: &filters.RecentlyHeard},
+		{label: "Direct RF", value: &filters.DirectRF},
+		{label: "Has position", value: &filters.HasPosition},
+		{label: "Show ignored", value: &filters.ShowIgnored},
+	}
+	objects := make([]fyne.CanvasObject, 0, len(chips))
+	for _, chip := range chips {
+		var button *widget.Button
+		button = widget.NewButton(i18n.T(chip.label), func() {
+			*chip.value = !*chip.value
+			setNodeFilterChipState(button, *chip.value)
+			if onChange != nil {
+				onChange()
+			}
+		})
+		setNodeFilterChipState(button, *chip.value)
+		objects = append(objects, button)
+	}
+
+	return container.NewHBox(objects...)
+}
+
+func setNodeFilterChipState(button *widget.Button, active bool) {
+	if active {
+		button.Importance = widget.HighImportance
+	} else {
+		button.Importance = widget.LowImportance
+	}
+	button.Refresh()
+}
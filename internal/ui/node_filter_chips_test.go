@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestApplyNodeQuickFilters(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	yes := true
+	lat, lon := 55.75, 37.61
+	direct := uint32(0)
+	relayed := uint32(2)
+	nodes := []domain.Node{
+		{NodeID: "!local", IsIgnored: &yes},
+		{NodeID: "!favorite", IsFavorite: &yes, LastHeardAt: now.Add(-2 * time.Hour)},
+		{NodeID: "!recent", LastHeardAt: now.Add(-10 * time.Minute), HopsAway: &direct},
+		{NodeID: "!relayed", LastHeardAt: now.Add(-10 * time.Minute), HopsAway: &relayed, Latitude: &lat, Longitude: &lon},
+		{NodeID: "!ignored", IsIgnored: &yes, HopsAway: &direct},
+	}
+
+	tests := []struct {
+		name    string
+		filters nodeQuickFilters
+		want    []string
+	}{
+		{name: "hides ignored by default", filters: nodeQuickFilters{}, want: []string{"!local", "!favorite", "!recent", "!relayed"}},
+		{name: "shows ignored", filters: nodeQuickFilters{ShowIgnored: true}, want: []string{"!local", "!favorite", "!recent", "!relayed", "!ignored"}},
+		{name: "favorites", filters: nodeQuickFilters{Favorites: true}, want: []string{"!local", "!favorite"}},
+		{name: "recently heard", filters: nodeQuickFilters{RecentlyHeard: true}, want: []string{"!local", "!recent", "!relayed"}},
+		{name: "direct rf", filters: nodeQuickFilters{DirectRF: true, ShowIgnored: true}, want: []string{"!local", "!recent", "!ignored"}},
+		{name: "has position", filters: nodeQuickFilters{HasPosition: true}, want: []string{"!local", "!relayed"}},
+		{name: "combined", filters: nodeQuickFilters{RecentlyHeard: true, DirectRF: true}, want: []string{"!local", "!recent"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := applyNodeQuickFilters(nodes, tt.filters, "!local", now)
+			ids := make([]string, 0, len(got))
+			for _, node := range got {
+				ids = append(ids, node.NodeID)
+			}
+			if len(ids) != len(tt.want) {
+				t.Fatalf("unexpected nodes: expected %v, got %v", tt.want, ids)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Fatalf("unexpected nodes: expected %v, got %v", tt.want, ids)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+const (
+	// nodeFreshWindow is how recently a node must be heard to show as fresh.
+	nodeFreshWindow = 15 * time.Minute
+	// nodeLastHeardTick is how often the last heard times of the node list are updated.
+	nodeLastHeardTick = 15 * time.Second
+)
+
+// nodeFreshness tells how recently a node was heard.
+type nodeFreshness int
+
+const (
+	nodeFreshnessUnknown nodeFreshness = iota
+	nodeFreshnessStale
+	nodeFreshnessRecent
+	nodeFreshnessFresh
+)
+
+// nodeFreshnessAt classifies lastHeard: fresh within nodeFreshWindow, recent while the
+// node still counts as active on the mesh, stale after that.
+func nodeFreshnessAt(lastHeard, now time.Time) nodeFreshness {
+	if lastHeard.IsZero() {
+		return nodeFreshnessUnknown
+	}
+	age := now.Sub(lastHeard)
+	switch {
+	case age < nodeFreshWindow:
+		return nodeFreshnessFresh
+	case age < domain.MeshActiveWindow:
+		return nodeFreshnessRecent
+	default:
+		return nodeFreshnessStale
+	}
+}
+
+func nodeFreshnessImportance(freshness nodeFreshness) widget.Importance {
+	switch freshness {
+	case nodeFreshnessFresh:
+		return widget.SuccessImportance
+	case nodeFreshnessRecent:
+		return widget.WarningImportance
+	default:
+		return widget.LowImportance
+	}
+}
+
+// setNodeLastHeardLabel shows when node was last heard, colored by freshness.
+func setNodeLastHeardLabel(label *widget.Label, node domain.Node, now time.Time) {
+	label.Importance = nodeFreshnessImportance(nodeFreshnessAt(node.LastHeardAt, now))
+	label.SetText(formatSeenAgo(node.LastHeardAt, now))
+}
@@ -0,0 +1,326 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/geo"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+const nodeListEmptyCell = "-"
+
+func nodeListColumnTitle(column config.NodeListColumn) string {
+	switch column {
+	case config.NodeListColumnShortName:
+		return i18n.T("Short name")
+	case config.NodeListColumnLongName:
+		return i18n.T("Long name")
+	case config.NodeListColumnBattery:
+		return i18n.T("Battery")
+	case config.NodeListColumnSNR:
+		return i18n.T("SNR")
+	case config.NodeListColumnHops:
+		return i18n.T("Hops")
+	case config.NodeListColumnLastHeard:
+		return i18n.T("Last heard")
+	case config.NodeListColumnDistance:
+		return i18n.T("Distance")
+	default:
+		return string(column)
+	}
+}
+
+// nodeDistanceKm returns the distance from the local node, when both positions are known.
+func nodeDistanceKm(localNode *domain.Node, node domain.Node) (float64, bool) {
+	if localNode == nil || localNode.NodeID == node.NodeID || !nodeHasPosition(*localNode) || !nodeHasPosition(node) {
+		return 0, false
+	}
+
+	return geo.DistanceKm(*localNode.Latitude, *localNode.Longitude, *node.Latitude, *node.Longitude), true
+}
+
+// formatDistanceKm shows distances under a kilometer in meters.
+func formatDistanceKm(km float64) string {
+	if km < 1 {
+		return currentDisplayFormatter().Number("%.0f m", km*1000)
+	}
+
+	return currentDisplayFormatter().Number("%.1f km", km)
+}
+
+// formatDistanceBearing shows a distance together with the initial bearing in degrees.
+func formatDistanceBearing(km, bearing float64) string {
+	return i18n.Tf("%s at %d°", formatDistanceKm(km), int(math.Round(bearing))%360)
+}
+
+func nodeListCellText(node domain.Node, column config.NodeListColumn, localNode *domain.Node, now time.Time) string {
+	switch column {
+	case config.NodeListColumnShortName:
+		if name := strings.TrimSpace(node.ShortName); name != "" {
+			return name
+		}
+	case config.NodeListColumnLongName:
+		return nodeDisplayName(node)
+	case config.NodeListColumnBattery:
+		if node.BatteryLevel != nil {
+			if *node.BatteryLevel > 100 {
+				return i18n.T("ext")
+			}
+
+			return fmt.Sprintf("%d%%", *node.BatteryLevel)
+		}
+	case config.NodeListColumnSNR:
+		if node.SNR != nil {
+			return currentDisplayFormatter().Number("%.1f dB", *node.SNR)
+		}
+	case config.NodeListColumnHops:
+		if node.HopsAway != nil {
+			return fmt.Sprintf("%d", *node.HopsAway)
+		}
+	case config.NodeListColumnLastHeard:
+		return formatSeenAgo(node.LastHeardAt, now)
+	case config.NodeListColumnDistance:
+		if km, ok := nodeDistanceKm(localNode, node); ok {
+			return formatDistanceKm(km)
+		}
+	}
+
+	return nodeListEmptyCell
+}
+
+// sortNodesForList orders nodes by the sort column of prefs. Nodes without a value
+// for the column go last in either direction; ties keep the incoming order.
+func sortNodesForList(nodes []domain.Node, prefs config.NodeListConfig, localNode *domain.Node) []domain.Node {
+	out := slices.Clone(nodes)
+	slices.SortStableFunc(out, func(a, b domain.Node) int {
+		return compareNodesByColumn(a, b, prefs.SortBy, prefs.SortDescending, localNode)
+	})
+
+	return out
+}
+
+func compareNodesByColumn(a, b domain.Node, column config.NodeListColumn, descending bool, localNode *domain.Node) int {
+	switch column {
+	case config.NodeListColumnShortName:
+		return compareOptional(nodeListNameKey(a.ShortName), nodeListNameKey(b.ShortName), descending)
+	case config.NodeListColumnLongName:
+		return compareOptional(nodeListNameKey(a.LongName), nodeListNameKey(b.LongName), descending)
+	case config.NodeListColumnBattery:
+		return compareOptional(a.BatteryLevel, b.BatteryLevel, descending)
+	case config.NodeListColumnSNR:
+		return compareOptional(a.SNR, b.SNR, descending)
+	case config.NodeListColumnHops:
+		return compareOptional(a.HopsAway, b.HopsAway, descending)
+	case config.NodeListColumnLastHeard:
+		return compareOptional(nodeListTimeKey(a.LastHeardAt), nodeListTimeKey(b.LastHeardAt), descending)
+	case config.NodeListColumnDistance:
+		return compareOptional(nodeListDistanceKey(localNode, a), nodeListDistanceKey(localNode, b), descending)
+	default:
+		return 0
+	}
+}
+
+// compareOptional compares two values with missing ones last.
+func compareOptional[T cmp.Ordered](a, b *T, descending bool) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	if descending {
+		return cmp.Compare(*b, *a)
+	}
+
+	return cmp.Compare(*a, *b)
+}
+
+func nodeListNameKey(name string) *string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return nil
+	}
+
+	return &name
+}
+
+func nodeListTimeKey(at time.Time) *int64 {
+	if at.IsZero() {
+		return nil
+	}
+	value := at.UnixMilli()
+
+	return &value
+}
+
+func nodeListDistanceKey(localNode *domain.Node, node domain.Node) *float64 {
+	km, ok := nodeDistanceKm(localNode, node)
+	if !ok {
+		return nil
+	}
+
+	return &km
+}
+
+// newNodeColumnsRowRenderer renders a node as one row of the chosen columns.
+func newNodeColumnsRowRenderer(columns []config.NodeListColumn, localNode func() *domain.Node) NodeRowRenderer {
+	return NodeRowRenderer{
+		Create: func() fyne.CanvasObject {
+			cells := make([]fyne.CanvasObject, 0, len(columns))
+			for range columns {
+				label := widget.NewLabel("")
+				label.Truncation = fyne.TextTruncateEllipsis
+				cells = append(cells, label)
+			}
+
+			return container.NewGridWithColumns(len(columns), cells...)
+		},
+		Update: func(obj fyne.CanvasObject, node domain.Node) {
+			row, ok := obj.(*fyne.Container)
+			if !ok {
+				return
+			}
+			local := localNode()
+			now := time.Now()
+			for i, cell := range row.Objects {
+				label, ok := cell.(*widget.Label)
+				if !ok || i >= len(columns) {
+					continue
+				}
+				if columns[i] == config.NodeListColumnLastHeard {
+					setNodeLastHeardLabel(label, node, now)
+
+					continue
+				}
+				label.Importance = widget.MediumImportance
+				label.SetText(nodeListCellText(node, columns[i], local, now))
+			}
+		},
+	}
+}
+
+// newNodeColumnsHeader shows a button per column. Tapping a column sorts by it, tapping
+// the sort column again flips the direction.
+func newNodeColumnsHeader(prefs config.NodeListConfig, onChange func(config.NodeListConfig)) fyne.CanvasObject {
+	buttons := make([]fyne.CanvasObject, 0, len(prefs.Columns))
+	for _, column := range prefs.Columns {
+		text := nodeListColumnTitle(column)
+		if column == prefs.SortBy {
+			text += nodeListSortArrow(prefs.SortDescending)
+		}
+		button := widget.NewButton(text, func() {
+			onChange(nodeListSortedBy(prefs, column))
+		})
+		button.Importance = widget.LowImportance
+		button.Alignment = widget.ButtonAlignLeading
+		buttons = append(buttons, button)
+	}
+
+	return container.NewGridWithColumns(max(len(buttons), 1), buttons...)
+}
+
+func nodeListSortArrow(descending bool) string {
+	if descending {
+		return " ▼"
+	}
+
+	return " ▲"
+}
+
+// nodeListSortedBy sorts by column, flipping the direction when it is already the
+// sort column.
+func nodeListSortedBy(prefs config.NodeListConfig, column config.NodeListColumn) config.NodeListConfig {
+	if prefs.SortBy == column {
+		prefs.SortDescending = !prefs.SortDescending
+
+		return prefs
+	}
+	prefs.SortBy = column
+	prefs.SortDescending = column == config.NodeListColumnLastHeard || column == config.NodeListColumnBattery
+
+	return prefs
+}
+
+// nodeListWithColumn shows or hides a column, keeping the display order of the columns.
+func nodeListWithColumn(prefs config.NodeListConfig, column config.NodeListColumn, shown bool) config.NodeListConfig {
+	columns := make([]config.NodeListColumn, 0, len(config.NodeListColumns))
+	for _, candidate := range config.NodeListColumns {
+		visible := slices.Contains(prefs.Columns, candidate)
+		if candidate == column {
+			visible = shown
+		}
+		if visible {
+			columns = append(columns, candidate)
+		}
+	}
+	prefs.Columns = columns
+
+	return prefs
+}
+
+// newNodeListViewMenu offers the columns, the sort column and grouping by hops, checking
+// the current ones.
+func newNodeListViewMenu(prefs config.NodeListConfig, onChange func(config.NodeListConfig)) *fyne.Menu {
+	items := make([]*fyne.MenuItem, 0, 2*len(config.NodeListColumns)+4)
+	for _, column := range config.NodeListColumns {
+		shown := slices.Contains(prefs.Columns, column)
+		item := fyne.NewMenuItem(i18n.Tf("Column: %s", nodeListColumnTitle(column)), func() {
+			onChange(nodeListWithColumn(prefs, column, !shown))
+		})
+		item.Checked = shown
+		items = append(items, item)
+	}
+	items = append(items, fyne.NewMenuItemSeparator())
+	for _, column := range config.NodeListColumns {
+		item := fyne.NewMenuItem(i18n.Tf("Sort: %s", nodeListColumnTitle(column)), func() {
+			onChange(nodeListSortedBy(prefs, column))
+		})
+		if column == prefs.SortBy {
+			item.Label += nodeListSortArrow(prefs.SortDescending)
+			item.Checked = true
+		}
+		items = append(items, item)
+	}
+	items = append(items, fyne.NewMenuItemSeparator())
+	group := fyne.NewMenuItem(i18n.T("Group by hops"), func() {
+		next := prefs
+		next.GroupByHops = !prefs.GroupByHops
+		onChange(next)
+	})
+	group.Checked = prefs.GroupByHops
+	items = append(items, group)
+
+	return fyne.NewMenu(i18n.T("Node list"), items...)
+}
+
+// normalizedNodeListPrefs returns the saved list preferences, most recently heard
+// nodes first when nothing is saved.
+func normalizedNodeListPrefs(load func() config.NodeListConfig) config.NodeListConfig {
+	prefs := config.NodeListConfig{SortBy: config.NodeListColumnLastHeard, SortDescending: true}
+	if load == nil {
+		return prefs
+	}
+	loaded := load()
+	prefs.Columns = loaded.Columns
+	prefs.GroupByHops = loaded.GroupByHops
+	prefs.CollapsedGroups = loaded.CollapsedGroups
+	if slices.Contains(config.NodeListColumns, loaded.SortBy) {
+		prefs.SortBy = loaded.SortBy
+		prefs.SortDescending = loaded.SortDescending
+	}
+
+	return prefs
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestSortNodesForList(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	battery := func(v uint32) *uint32 { return &v }
+	snr := func(v float64) *float64 { return &v }
+	localLat, localLon := 55.75, 37.61
+	nearLat, nearLon := 55.76, 37.61
+	farLat, farLon := 56.75, 37.61
+	local := domain.Node{NodeID: "!local", Latitude: &localLat, Longitude: &localLon}
+	nodes := []domain.Node{
+		{NodeID: "!b", ShortName: "beta", BatteryLevel: battery(40), SNR: snr(-3), LastHeardAt: now.Add(-time.Hour), Latitude: &farLat, Longitude: &farLon},
+		{NodeID: "!none"},
+		{NodeID: "!a", ShortName: "Alpha", BatteryLevel: battery(90), SNR: snr(5), HopsAway: battery(2), LastHeardAt: now, Latitude: &nearLat, Longitude: &nearLon},
+		{NodeID: "!c", ShortName: "charlie", HopsAway: battery(0), LastHeardAt: now.Add(-time.Minute)},
+	}
+
+	tests := []struct {
+		name  string
+		prefs config.NodeListConfig
+		want  []string
+	}{
+		{name: "short name ascending", prefs: config.NodeListConfig{SortBy: config.NodeListColumnShortName}, want: []string{"!a", "!b", "!c", "!none"}},
+		{name: "short name descending", prefs: config.NodeListConfig{SortBy: config.NodeListColumnShortName, SortDescending: true}, want: []string{"!c", "!b", "!a", "!none"}},
+		{name: "battery descending", prefs: config.NodeListConfig{SortBy: config.NodeListColumnBattery, SortDescending: true}, want: []string{"!a", "!b", "!none", "!c"}},
+		{name: "snr ascending", prefs: config.NodeListConfig{SortBy: config.NodeListColumnSNR}, want: []string{"!b", "!a", "!none", "!c"}},
+		{name: "hops ascending", prefs: config.NodeListConfig{SortBy: config.NodeListColumnHops}, want: []string{"!c", "!a", "!b", "!none"}},
+		{name: "last heard descending", prefs: config.NodeListConfig{SortBy: config.NodeListColumnLastHeard, SortDescending: true}, want: []string{"!a", "!c", "!b", "!none"}},
+		{name: "distance ascending", prefs: config.NodeListConfig{SortBy: config.NodeListColumnDistance}, want: []string{"!a", "!b", "!none", "!c"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sortNodesForList(nodes, tt.prefs, &local)
+			ids := make([]string, 0, len(got))
+			for _, node := range got {
+				ids = append(ids, node.NodeID)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Fatalf("unexpected order: expected %v, got %v", tt.want, ids)
+			}
+		})
+	}
+}
+
+func TestNodeListCellText(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	battery := uint32(101)
+	hops := uint32(3)
+	snr := 4.5
+	lat, lon := 55.75, 37.61
+	node := domain.Node{NodeID: "!a", ShortName: "AL", LongName: "Alpha", BatteryLevel: &battery, SNR: &snr, HopsAway: &hops, LastHeardAt: now.Add(-5 * time.Minute)}
+
+	tests := []struct {
+		column config.NodeListColumn
+		local  *domain.Node
+		want   string
+	}{
+		{column: config.NodeListColumnShortName, want: "AL"},
+		{column: config.NodeListColumnLongName, want: "[AL] Alpha"},
+		{column: config.NodeListColumnBattery, want: "ext"},
+		{column: config.NodeListColumnSNR, want: "4.5 dB"},
+		{column: config.NodeListColumnHops, want: "3"},
+		{column: config.NodeListColumnLastHeard, want: "5 min"},
+		{column: config.NodeListColumnDistance, local: &domain.Node{NodeID: "!local", Latitude: &lat, Longitude: &lon}, want: "-"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.column), func(t *testing.T) {
+			if got := nodeListCellText(node, tt.column, tt.local, now); got != tt.want {
+				t.Fatalf("unexpected cell text: expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNodeListSortedBy(t *testing.T) {
+	prefs := config.NodeListConfig{SortBy: config.NodeListColumnSNR}
+
+	flipped := nodeListSortedBy(prefs, config.NodeListColumnSNR)
+	if flipped.SortBy != config.NodeListColumnSNR || !flipped.SortDescending {
+		t.Fatalf("expected the sort direction to flip, got %+v", flipped)
+	}
+	switched := nodeListSortedBy(flipped, config.NodeListColumnShortName)
+	if switched.SortBy != config.NodeListColumnShortName || switched.SortDescending {
+		t.Fatalf("expected ascending short name sort, got %+v", switched)
+	}
+}
+
+func TestNodeListWithColumn(t *testing.T) {
+	prefs := config.NodeListConfig{Columns: []config.NodeListColumn{config.NodeListColumnSNR, config.NodeListColumnShortName}}
+
+	shown := nodeListWithColumn(prefs, config.NodeListColumnHops, true)
+	want := []config.NodeListColumn{config.NodeListColumnShortName, config.NodeListColumnSNR, config.NodeListColumnHops}
+	if !slices.Equal(shown.Columns, want) {
+		t.Fatalf("unexpected columns: expected %v, got %v", want, shown.Columns)
+	}
+	hidden := nodeListWithColumn(shown, config.NodeListColumnSNR, false)
+	want = []config.NodeListColumn{config.NodeListColumnShortName, config.NodeListColumnHops}
+	if !slices.Equal(hidden.Columns, want) {
+		t.Fatalf("unexpected columns: expected %v, got %v", want, hidden.Columns)
+	}
+}
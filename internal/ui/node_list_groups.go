@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// nodeListRow is a row of the node list: a node or, when grouping by hops, the header
+// of a section.
+type nodeListRow struct {
+	node   domain.Node
+	header bool
+	group  config.NodeHopGroup
+	count  int
+}
+
+// nodeHopGroupOf picks the section of a node. Nodes last heard through MQTT go to their
+// own section since their hop count says nothing about the RF path.
+func nodeHopGroupOf(node domain.Node) config.NodeHopGroup {
+	switch {
+	case node.ViaMQTT != nil && *node.ViaMQTT:
+		return config.NodeHopGroupMQTT
+	case node.HopsAway == nil:
+		return config.NodeHopGroupUnknown
+	case *node.HopsAway == 0:
+		return config.NodeHopGroupDirect
+	case *node.HopsAway == 1:
+		return config.NodeHopGroupOneHop
+	default:
+		return config.NodeHopGroupMultiHop
+	}
+}
+
+func nodeHopGroupTitle(group config.NodeHopGroup) string {
+	switch group {
+	case config.NodeHopGroupDirect:
+		return i18n.T("Direct")
+	case config.NodeHopGroupOneHop:
+		return i18n.T("1 hop")
+	case config.NodeHopGroupMultiHop:
+		return i18n.T("2+ hops")
+	case config.NodeHopGroupMQTT:
+		return i18n.T("Heard via MQTT")
+	case config.NodeHopGroupUnknown:
+		return i18n.T("Unknown hops")
+	default:
+		return string(group)
+	}
+}
+
+// nodeListRows lays out nodes as list rows. Grouped by hops, every non-empty section gets
+// a header and collapsed sections hide their nodes. The local node stays on top outside
+// of the sections. The order of nodes within a section is kept.
+func nodeListRows(nodes []domain.Node, prefs config.NodeListConfig, localNodeID string) []nodeListRow {
+	if !prefs.GroupByHops {
+		rows := make([]nodeListRow, 0, len(nodes))
+		for _, node := range nodes {
+			rows = append(rows, nodeListRow{node: node})
+		}
+
+		return rows
+	}
+
+	rows := make([]nodeListRow, 0, len(nodes)+len(config.NodeHopGroups))
+	byGroup := make(map[config.NodeHopGroup][]domain.Node, len(config.NodeHopGroups))
+	for _, node := range nodes {
+		if isLocalNode(node, localNodeID) {
+			rows = append(rows, nodeListRow{node: node})
+
+			continue
+		}
+		group := nodeHopGroupOf(node)
+		byGroup[group] = append(byGroup[group], node)
+	}
+	for _, group := range config.NodeHopGroups {
+		members := byGroup[group]
+		if len(members) == 0 {
+			continue
+		}
+		rows = append(rows, nodeListRow{header: true, group: group, count: len(members)})
+		if slices.Contains(prefs.CollapsedGroups, group) {
+			continue
+		}
+		for _, node := range members {
+			rows = append(rows, nodeListRow{node: node, group: group})
+		}
+	}
+
+	return rows
+}
+
+// nodeListWithGroupCollapsed collapses or expands a section.
+func nodeListWithGroupCollapsed(prefs config.NodeListConfig, group config.NodeHopGroup, collapsed bool) config.NodeListConfig {
+	groups := make([]config.NodeHopGroup, 0, len(prefs.CollapsedGroups)+1)
+	for _, candidate := range prefs.CollapsedGroups {
+		if candidate != group {
+			groups = append(groups, candidate)
+		}
+	}
+	if collapsed {
+		groups = append(groups, group)
+	}
+	prefs.CollapsedGroups = groups
+
+	return prefs
+}
+
+// nextNodeListIndex moves from index by delta to the closest node row, skipping section
+// headers, or returns -1 when there is none.
+func nextNodeListIndex(rows []nodeListRow, index, delta int) int {
+	next := relativeListIndex(index, delta, len(rows))
+	step := 1
+	if delta < 0 {
+		step = -1
+	}
+	for next >= 0 && next < len(rows) && rows[next].header {
+		next += step
+	}
+	if next < 0 || next >= len(rows) {
+		return -1
+	}
+
+	return next
+}
+
+// newNodeListGroupHeader is the section header row. Tapping it collapses or expands
+// the section.
+func newNodeListGroupHeader() *widget.Button {
+	header := widget.NewButton("", nil)
+	header.Alignment = widget.ButtonAlignLeading
+	header.Importance = widget.LowImportance
+
+	return header
+}
+
+func updateNodeListGroupHeader(header *widget.Button, row nodeListRow, collapsed bool, onToggle func()) {
+	header.SetText(fmt.Sprintf("%s (%d)", nodeHopGroupTitle(row.group), row.count))
+	if collapsed {
+		header.SetIcon(theme.MenuExpandIcon())
+	} else {
+		header.SetIcon(theme.MenuDropDownIcon())
+	}
+	header.OnTapped = onToggle
+}
+
+// nodeListRowHeights returns the heights of a section header and of a node row, which
+// also holds a hidden header.
+func nodeListRowHeights(renderer NodeRowRenderer) (header, node float32) {
+	header = newNodeListGroupHeader().MinSize().Height
+
+	return header, max(header, newNodeRowItem(renderer.Create()).MinSize().Height)
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestNodeHopGroupOf(t *testing.T) {
+	hops := func(v uint32) *uint32 { return &v }
+	yes, no := true, false
+	tests := []struct {
+		name string
+		node domain.Node
+		want config.NodeHopGroup
+	}{
+		{name: "direct", node: domain.Node{HopsAway: hops(0), ViaMQTT: &no}, want: config.NodeHopGroupDirect},
+		{name: "one hop", node: domain.Node{HopsAway: hops(1)}, want: config.NodeHopGroupOneHop},
+		{name: "many hops", node: domain.Node{HopsAway: hops(4)}, want: config.NodeHopGroupMultiHop},
+		{name: "mqtt wins over stale hops", node: domain.Node{HopsAway: hops(0), ViaMQTT: &yes}, want: config.NodeHopGroupMQTT},
+		{name: "unknown", node: domain.Node{}, want: config.NodeHopGroupUnknown},
+	}
+	for _, tc := range tests {
+		if got := nodeHopGroupOf(tc.node); got != tc.want {
+			t.Fatalf("%s: expected %q, got %q", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestNodeListRows(t *testing.T) {
+	hops := func(v uint32) *uint32 { return &v }
+	yes := true
+	nodes := []domain.Node{
+		{NodeID: "!local", HopsAway: hops(0)},
+		{NodeID: "!far", HopsAway: hops(3)},
+		{NodeID: "!near", HopsAway: hops(0)},
+		{NodeID: "!mqtt", ViaMQTT: &yes},
+		{NodeID: "!near2", HopsAway: hops(0)},
+	}
+	describe := func(rows []nodeListRow) []string {
+		out := make([]string, 0, len(rows))
+		for _, row := range rows {
+			if row.header {
+				out = append(out, nodeHopGroupTitle(row.group))
+			} else {
+				out = append(out, row.node.NodeID)
+			}
+		}
+
+		return out
+	}
+
+	flat := nodeListRows(nodes, config.NodeListConfig{}, "!local")
+	if got, want := describe(flat), []string{"!local", "!far", "!near", "!mqtt", "!near2"}; !slices.Equal(got, want) {
+		t.Fatalf("ungrouped: expected %v, got %v", want, got)
+	}
+
+	grouped := nodeListRows(nodes, config.NodeListConfig{GroupByHops: true}, "!local")
+	want := []string{"!local", "Direct", "!near", "!near2", "2+ hops", "!far", "Heard via MQTT", "!mqtt"}
+	if got := describe(grouped); !slices.Equal(got, want) {
+		t.Fatalf("grouped: expected %v, got %v", want, got)
+	}
+	if grouped[1].count != 2 {
+		t.Fatalf("expected 2 direct nodes in the header, got %d", grouped[1].count)
+	}
+
+	prefs := nodeListWithGroupCollapsed(config.NodeListConfig{GroupByHops: true}, config.NodeHopGroupDirect, true)
+	collapsed := nodeListRows(nodes, prefs, "!local")
+	want = []string{"!local", "Direct", "2+ hops", "!far", "Heard via MQTT", "!mqtt"}
+	if got := describe(collapsed); !slices.Equal(got, want) {
+		t.Fatalf("collapsed: expected %v, got %v", want, got)
+	}
+	if prefs = nodeListWithGroupCollapsed(prefs, config.NodeHopGroupDirect, false); len(prefs.CollapsedGroups) != 0 {
+		t.Fatalf("expected the section to expand, got %v", prefs.CollapsedGroups)
+	}
+}
+
+func TestNextNodeListIndexSkipsHeaders(t *testing.T) {
+	rows := []nodeListRow{{header: true}, {}, {}, {header: true}, {}}
+	tests := []struct {
+		index, delta, want int
+	}{
+		{index: -1, delta: 1, want: 1},
+		{index: 2, delta: 1, want: 4},
+		{index: 4, delta: -1, want: 2},
+		{index: 1, delta: -1, want: -1},
+		{index: -1, delta: -1, want: 4},
+	}
+	for _, tc := range tests {
+		if got := nextNodeListIndex(rows, tc.index, tc.delta); got != tc.want {
+			t.Fatalf("from %d by %d: expected %d, got %d", tc.index, tc.delta, tc.want, got)
+		}
+	}
+}
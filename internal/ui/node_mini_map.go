@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/png" // OSM tiles are PNG images.
+	"math"
+	"net/http"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/i18n"
+	mapwidgets "github.com/skobkin/meshgo/internal/ui/widgets/map"
+)
+
+const (
+	nodeMiniMapZoom       = 14
+	nodeMiniMapSize       = mapTileSize
+	nodeMiniMapMarkerSize = 28
+)
+
+var nodeMiniMapClients = struct {
+	sync.Mutex
+	byDir map[string]*http.Client
+}{byDir: map[string]*http.Client{}}
+
+// nodeMiniMapClient shares one caching tile client per cache directory between the
+// node overviews, so they reuse the tiles downloaded by the map tab.
+func nodeMiniMapClient(cacheDir string) *http.Client {
+	nodeMiniMapClients.Lock()
+	defer nodeMiniMapClients.Unlock()
+	if client, ok := nodeMiniMapClients.byDir[cacheDir]; ok {
+		return client
+	}
+	client := mapwidgets.NewMapTileHTTPClient(cacheDir, mapwidgets.DefaultMapTileCacheSizeBytes)
+	nodeMiniMapClients.byDir[cacheDir] = client
+
+	return client
+}
+
+// miniMapTile is a map tile and the position of its top left corner in the mini-map.
+type miniMapTile struct {
+	X, Y   int
+	Offset image.Point
+}
+
+// nodeMiniMapTiles returns the tiles covering a square of size pixels centered on the
+// coordinate at the zoom level.
+func nodeMiniMapTiles(coord mapCoordinate, zoom, size int) []miniMapTile {
+	tileX, tileY := latLonToTile(coord, zoom)
+	left := tileX*float64(mapTileSize) - float64(size)/2
+	top := tileY*float64(mapTileSize) - float64(size)/2
+	firstX := int(math.Floor(left / float64(mapTileSize)))
+	firstY := int(math.Floor(top / float64(mapTileSize)))
+	lastX := int(math.Floor((left + float64(size) - 1) / float64(mapTileSize)))
+	lastY := int(math.Floor((top + float64(size) - 1) / float64(mapTileSize)))
+	tilesPerAxis := 1 << zoom
+
+	tiles := make([]miniMapTile, 0, (lastX-firstX+1)*(lastY-firstY+1))
+	for y := firstY; y <= lastY; y++ {
+		if y < 0 || y >= tilesPerAxis {
+			continue
+		}
+		for x := firstX; x <= lastX; x++ {
+			tiles = append(tiles, miniMapTile{
+				X: ((x % tilesPerAxis) + tilesPerAxis) % tilesPerAxis,
+				Y: y,
+				Offset: image.Pt(
+					int(math.Round(float64(x*mapTileSize)-left)),
+					int(math.Round(float64(y*mapTileSize)-top)),
+				),
+			})
+		}
+	}
+
+	return tiles
+}
+
+// composeNodeMiniMap draws the tiles into one image of size pixels. Missing tiles
+// are left blank.
+func composeNodeMiniMap(tiles []miniMapTile, images []image.Image, size int) *image.RGBA {
+	out := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(out, out.Bounds(), image.NewUniform(color.Gray{Y: 0xe0}), image.Point{}, draw.Src)
+	for i, tile := range tiles {
+		if i >= len(images) || images[i] == nil {
+			continue
+		}
+		bounds := images[i].Bounds()
+		target := image.Rectangle{Min: tile.Offset, Max: tile.Offset.Add(bounds.Size())}
+		draw.Draw(out, target, images[i], bounds.Min, draw.Src)
+	}
+
+	return out
+}
+
+func loadNodeMiniMap(ctx context.Context, client *http.Client, coord mapCoordinate) (image.Image, error) {
+	tiles := nodeMiniMapTiles(coord, nodeMiniMapZoom, nodeMiniMapSize)
+	images := make([]image.Image, len(tiles))
+	var errs []error
+	for i, tile := range tiles {
+		img, err := fetchNodeMiniMapTile(ctx, client, fmt.Sprintf(mapTileSourceOSM, nodeMiniMapZoom, tile.X, tile.Y))
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+		images[i] = img
+	}
+	if len(errs) == len(tiles) {
+		return nil, errors.Join(errs...)
+	}
+
+	return composeNodeMiniMap(tiles, images, nodeMiniMapSize), nil
+}
+
+func fetchNodeMiniMapTile(parent context.Context, client *http.Client, rawURL string) (image.Image, error) {
+	reqCtx, cancel := context.WithTimeout(parent, mapWarmupRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("User-Agent", "meshgo node mini-map")
+	req.Header.Set(mapwidgets.MapTileFetchModeHeader, mapwidgets.MapTileFetchModeSync)
+	// #nosec G704 -- the mini-map fetches tiles from the OSM tile source URL by design.
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request tile: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	img, _, err := image.Decode(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("decode tile: %w", err)
+	}
+
+	return img, nil
+}
+
+// newNodeMiniMap shows the map around the coordinate with a marker in the center.
+// Tiles are loaded in the background.
+func newNodeMiniMap(client *http.Client, coord mapCoordinate, variant fyne.ThemeVariant) fyne.CanvasObject {
+	status := widget.NewLabel(i18n.T("Loading map..."))
+	mapImage := canvas.NewImageFromImage(nil)
+	mapImage.FillMode = canvas.ImageFillContain
+	mapImage.SetMinSize(fyne.NewSquareSize(nodeMiniMapSize))
+	marker := canvas.NewImageFromResource(mapMarkerResource(variant))
+	marker.FillMode = canvas.ImageFillContain
+	marker.SetMinSize(fyne.NewSquareSize(nodeMiniMapMarkerSize))
+	marker.Hide()
+
+	go func() {
+		img, err := loadNodeMiniMap(context.Background(), client, coord)
+		fyne.Do(func() {
+			if err != nil {
+				appLogger.Debug("node mini-map is unavailable", "error", err)
+				status.SetText(i18n.T("Map is unavailable"))
+
+				return
+			}
+			status.Hide()
+			mapImage.Image = img
+			mapImage.Refresh()
+			marker.Show()
+		})
+	}()
+
+	return container.NewHBox(container.NewStack(
+		mapImage,
+		container.NewCenter(status),
+		container.NewCenter(marker),
+	))
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestNodeMiniMapTiles_CenteredOnCoordinate(t *testing.T) {
+	coord := mapCoordinate{Latitude: 55.7558, Longitude: 37.6173}
+	tileX, tileY := latLonToTile(coord, nodeMiniMapZoom)
+	tiles := nodeMiniMapTiles(coord, nodeMiniMapZoom, nodeMiniMapSize)
+	if len(tiles) == 0 {
+		t.Fatalf("expected tiles for the coordinate")
+	}
+	found := false
+	for _, tile := range tiles {
+		if tile.X != int(tileX) || tile.Y != int(tileY) {
+			continue
+		}
+		found = true
+		// The coordinate inside its own tile must land in the mini-map center.
+		centerX := float64(tile.Offset.X) + (tileX-float64(tile.X))*mapTileSize
+		centerY := float64(tile.Offset.Y) + (tileY-float64(tile.Y))*mapTileSize
+		if diff := centerX - nodeMiniMapSize/2; diff < -1 || diff > 1 {
+			t.Fatalf("unexpected horizontal center: expected %d, got %.1f", nodeMiniMapSize/2, centerX)
+		}
+		if diff := centerY - nodeMiniMapSize/2; diff < -1 || diff > 1 {
+			t.Fatalf("unexpected vertical center: expected %d, got %.1f", nodeMiniMapSize/2, centerY)
+		}
+	}
+	if !found {
+		t.Fatalf("expected the tile of the coordinate to be included, got %v", tiles)
+	}
+}
+
+func TestNodeMiniMapTiles_SkipsRowsOutsideWorld(t *testing.T) {
+	tiles := nodeMiniMapTiles(mapCoordinate{Latitude: 85, Longitude: 0}, 2, mapTileSize)
+	for _, tile := range tiles {
+		if tile.Y < 0 {
+			t.Fatalf("unexpected tile above the world: %v", tile)
+		}
+	}
+}
+
+func TestComposeNodeMiniMap_PlacesTilesAtOffsets(t *testing.T) {
+	red := image.NewUniform(color.RGBA{R: 0xff, A: 0xff})
+	tile := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			tile.Set(x, y, red)
+		}
+	}
+	out := composeNodeMiniMap(
+		[]miniMapTile{{Offset: image.Pt(2, 2)}, {Offset: image.Pt(-2, -2)}},
+		[]image.Image{tile, nil},
+		8,
+	)
+	if got := out.RGBAAt(3, 3); got.R != 0xff {
+		t.Fatalf("expected the tile drawn at its offset, got %v", got)
+	}
+	if got := out.RGBAAt(0, 0); got.R == 0xff {
+		t.Fatalf("expected a missing tile to be left blank, got %v", got)
+	}
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// nodeNotesCard edits the local alias, note and tags of the node shown in the overview.
+type nodeNotesCard struct {
+	onSave     func(nodeID, alias, note string) error
+	onSaveTags func(nodeID string, tags []string) error
+
+	nodeID      string
+	aliasEntry  *widget.Entry
+	noteEntry   *widget.Entry
+	tagsEntry   *widget.Entry
+	saveButton  *widget.Button
+	statusLabel *widget.Label
+	content     *fyne.Container
+}
+
+func newNodeNotesCard(
+	onSave func(nodeID, alias, note string) error,
+	onSaveTags func(nodeID string, tags []string) error,
+) *nodeNotesCard {
+	c := &nodeNotesCard{onSave: onSave, onSaveTags: onSaveTags}
+	c.aliasEntry = widget.NewEntry()
+	c.aliasEntry.SetPlaceHolder(i18n.T("Shown instead of the radio name"))
+	c.noteEntry = widget.NewMultiLineEntry()
+	c.noteEntry.SetPlaceHolder(i18n.T("Only stored on this computer"))
+	c.noteEntry.Wrapping = fyne.TextWrapWord
+	c.noteEntry.SetMinRowsVisible(3)
+	c.tagsEntry = widget.NewEntry()
+	c.tagsEntry.SetPlaceHolder(i18n.T("Comma-separated, e.g. solar, router"))
+	c.statusLabel = widget.NewLabel("")
+	c.saveButton = widget.NewButton(i18n.T("Save"), c.save)
+
+	form := widget.NewForm(
+		widget.NewFormItem(i18n.T("Alias"), c.aliasEntry),
+		widget.NewFormItem(i18n.T("Note"), c.noteEntry),
+	)
+	if onSaveTags != nil {
+		form.Append(i18n.T("Tags"), c.tagsEntry)
+	}
+	c.content = overviewCard(i18n.T("Notes"), container.NewVBox(
+		form,
+		container.NewBorder(nil, nil, nil, c.saveButton, c.statusLabel),
+	))
+
+	return c
+}
+
+// SetNode fills the entries when another node is shown. Store updates for the same
+// node keep the entries as they are so they do not overwrite unsaved edits.
+func (c *nodeNotesCard) SetNode(node domain.Node) {
+	if c.nodeID == node.NodeID {
+		return
+	}
+	c.nodeID = node.NodeID
+	c.aliasEntry.SetText(node.Alias)
+	c.noteEntry.SetText(node.Note)
+	c.tagsEntry.SetText(strings.Join(node.Tags, ", "))
+	c.statusLabel.SetText("")
+}
+
+func (c *nodeNotesCard) save() {
+	if c.nodeID == "" {
+		return
+	}
+	if c.onSave != nil {
+		if err := c.onSave(c.nodeID, c.aliasEntry.Text, c.noteEntry.Text); err != nil {
+			c.statusLabel.SetText(i18n.Tf("Save failed: %s", err))
+
+			return
+		}
+	}
+	if c.onSaveTags != nil {
+		tags := domain.ParseTags(c.tagsEntry.Text)
+		if err := c.onSaveTags(c.nodeID, tags); err != nil {
+			c.statusLabel.SetText(i18n.Tf("Save failed: %s", err))
+
+			return
+		}
+		c.tagsEntry.SetText(strings.Join(tags, ", "))
+	}
+	c.statusLabel.SetText(i18n.T("Saved"))
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	fynetest "fyne.io/fyne/v2/test"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestNodeNotesCard_KeepsUnsavedEditsAndSaves(t *testing.T) {
+	var (
+		saved     [3]string
+		savedTags []string
+	)
+	card := newNodeNotesCard(func(nodeID, alias, note string) error {
+		saved = [3]string{nodeID, alias, note}
+
+		return nil
+	}, func(_ string, tags []string) error {
+		savedTags = tags
+
+		return nil
+	})
+	_ = fynetest.NewTempWindow(t, card.content)
+
+	card.SetNode(domain.Node{NodeID: "!00000001", Alias: "Camp", Note: "Relay", Tags: []string{"solar", "router"}})
+	if card.aliasEntry.Text != "Camp" || card.noteEntry.Text != "Relay" || card.tagsEntry.Text != "solar, router" {
+		t.Fatalf("expected entries to be filled, got %q / %q / %q", card.aliasEntry.Text, card.noteEntry.Text, card.tagsEntry.Text)
+	}
+
+	card.aliasEntry.SetText("Base camp")
+	card.tagsEntry.SetText("solar,  friends, Solar")
+	card.SetNode(domain.Node{NodeID: "!00000001", Alias: "Camp", Note: "Relay"})
+	if card.aliasEntry.Text != "Base camp" {
+		t.Fatalf("expected unsaved alias to be kept, got %q", card.aliasEntry.Text)
+	}
+
+	fynetest.Tap(card.saveButton)
+	if saved != [3]string{"!00000001", "Base camp", "Relay"} {
+		t.Fatalf("unexpected saved notes: %v", saved)
+	}
+	if strings.Join(savedTags, ",") != "solar,friends" || card.tagsEntry.Text != "solar, friends" {
+		t.Fatalf("unexpected saved tags: %v (entry %q)", savedTags, card.tagsEntry.Text)
+	}
+	if card.statusLabel.Text != "Saved" {
+		t.Fatalf("expected saved status, got %q", card.statusLabel.Text)
+	}
+
+	card.SetNode(domain.Node{NodeID: "!00000002"})
+	if card.aliasEntry.Text != "" || card.statusLabel.Text != "" {
+		t.Fatalf("expected entries to reset for another node, got %q / %q", card.aliasEntry.Text, card.statusLabel.Text)
+	}
+}
+
+func TestNodeNotesCard_ShowsSaveError(t *testing.T) {
+	card := newNodeNotesCard(func(string, string, string) error {
+		return errors.New("alias exceeds 40 characters")
+	}, nil)
+	_ = fynetest.NewTempWindow(t, card.content)
+	card.SetNode(domain.Node{NodeID: "!00000001"})
+
+	fynetest.Tap(card.saveButton)
+	if card.statusLabel.Text != "Save failed: alias exceeds 40 characters" {
+		t.Fatalf("unexpected status: %q", card.statusLabel.Text)
+	}
+}
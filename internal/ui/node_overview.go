@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"net/url"
@@ -14,6 +15,8 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/geo"
+	"github.com/skobkin/meshgo/internal/i18n"
 	"github.com/skobkin/meshgo/internal/radio"
 	"github.com/skobkin/meshgo/internal/resources"
 )
@@ -29,7 +32,17 @@ type nodeOverviewOptions struct {
 	OnTelemetryLog     func(domain.Node)
 	OnPositionLog      func(domain.Node)
 	OnIdentityLog      func(domain.Node)
+	OnTracerouteLog    func(domain.Node)
+	OnShareContact     func(domain.Node)
+	OnSetFavorite      func(domain.Node, bool)
+	OnSetIgnored       func(domain.Node, bool)
 	PositionMapURL     func(domain.Node) *url.URL
+	PositionMiniMap    func(domain.Node) fyne.CanvasObject
+	LocalNodeID        func() string
+	BatteryEstimate    func(nodeID string) (domain.BatteryEstimate, bool)
+	SignalHistory      func(nodeID string) []domain.NodeSignalHistoryEntry
+	OnSaveNotes        func(nodeID, alias, note string) error
+	OnSaveTags         func(nodeID string, tags []string) error
 	ShowCloseButton    bool
 	OnClose            func()
 	ShowActions        bool
@@ -57,7 +70,7 @@ func newNodeOverviewContent(opts nodeOverviewOptions) (fyne.CanvasObject, func()
 	identityTables := container.NewVBox()
 	publicKeyEntry := widget.NewEntry()
 	publicKeyEntry.Disable()
-	publicKeyCopyButton := widget.NewButton("Copy", func() {
+	publicKeyCopyButton := widget.NewButton(i18n.T("Copy"), func() {
 		if err := copyTextToClipboard(publicKeyEntry.Text); err != nil {
 			nodeSettingsTabLogger.Debug("copy public key from overview failed", "error", err)
 		}
@@ -66,7 +79,7 @@ func newNodeOverviewContent(opts nodeOverviewOptions) (fyne.CanvasObject, func()
 	identity := container.NewVBox(
 		identityTables,
 		widget.NewForm(widget.NewFormItem(
-			"Public key",
+			i18n.T("Public key"),
 			container.NewBorder(nil, nil, nil, publicKeyCopyButton, publicKeyEntry),
 		)),
 	)
@@ -76,6 +89,7 @@ func newNodeOverviewContent(opts nodeOverviewOptions) (fyne.CanvasObject, func()
 	airQualitySection := container.NewVBox()
 	otherSection := container.NewVBox()
 	positionSection := container.NewVBox()
+	signalSection := container.NewVBox()
 	firmwareSection := container.NewVBox()
 
 	var requestIdentityButton *widget.Button
@@ -117,38 +131,54 @@ func newNodeOverviewContent(opts nodeOverviewOptions) (fyne.CanvasObject, func()
 		otherCardActions = append(otherCardActions, requestOtherButton)
 	}
 
-	identityCard := overviewCard("Identity", identity, identityCardActions...)
-	powerCard := overviewCard("Telemetry: Power", powerSection, powerCardActions...)
-	environmentCard := overviewCard("Telemetry: Environmental", environmentSection, environmentCardActions...)
-	airQualityCard := overviewCard("Telemetry: Air Quality", airQualitySection, airQualityCardActions...)
-	otherCard := overviewCard("Telemetry: Other", otherSection, otherCardActions...)
-	positionCardTitle := container.NewStack(overviewCardTitleLabel("Position"))
+	identityCard := overviewCard(i18n.T("Identity"), identity, identityCardActions...)
+	powerCard := overviewCard(i18n.T("Telemetry: Power"), powerSection, powerCardActions...)
+	environmentCard := overviewCard(i18n.T("Telemetry: Environmental"), environmentSection, environmentCardActions...)
+	airQualityCard := overviewCard(i18n.T("Telemetry: Air Quality"), airQualitySection, airQualityCardActions...)
+	otherCard := overviewCard(i18n.T("Telemetry: Other"), otherSection, otherCardActions...)
+	positionCardTitle := container.NewStack(overviewCardTitleLabel(i18n.T("Position")))
 	positionCard := overviewCardWithTitle(positionCardTitle, positionSection)
-	firmwareCard := overviewCard("Firmware and Board", firmwareSection)
+	boardIcon := widget.NewIcon(nil)
+	firmwareCard := overviewCard(i18n.T("Firmware and Board"), container.NewBorder(nil, nil, boardIcon, nil, firmwareSection))
+	signalCard := overviewCard(i18n.T("Signal history"), signalSection)
+	var notesCard *nodeNotesCard
+	if opts.OnSaveNotes != nil || opts.OnSaveTags != nil {
+		notesCard = newNodeNotesCard(opts.OnSaveNotes, opts.OnSaveTags)
+	}
 
-	adminButton := widget.NewButton("Administration", nil)
+	adminButton := widget.NewButton(i18n.T("Administration"), nil)
 	adminButton.Disable()
-	adminCard := overviewCard("Remote Administration", container.NewVBox(
-		widget.NewLabel("Remote administration is not implemented yet."),
+	adminCard := overviewCard(i18n.T("Remote Administration"), container.NewVBox(
+		widget.NewLabel(i18n.T("Remote administration is not implemented yet.")),
 		adminButton,
 	))
 
-	chatButton := widget.NewButton("Chat", nil)
-	tracerouteButton := widget.NewButton("Traceroute", nil)
-	telemetryLogButton := widget.NewButton("Telemetry log", nil)
-	positionLogButton := widget.NewButton("Position log", nil)
-	identityLogButton := widget.NewButton("Identity log", nil)
-	tracerouteLogButton := widget.NewButton("Traceroute log", nil)
+	chatButton := widget.NewButton(i18n.T("Chat"), nil)
+	tracerouteButton := widget.NewButton(i18n.T("Traceroute"), nil)
+	favoriteButton := widget.NewButton(nodeFavoriteMenuLabel(domain.Node{}), nil)
+	ignoreButton := widget.NewButton(nodeIgnoreLabel(domain.Node{}), nil)
+	telemetryLogButton := widget.NewButton(i18n.T("Telemetry log"), nil)
+	positionLogButton := widget.NewButton(i18n.T("Position log"), nil)
+	identityLogButton := widget.NewButton(i18n.T("Identity log"), nil)
+	tracerouteLogButton := widget.NewButton(i18n.T("Traceroute log"), nil)
+	shareContactButton := widget.NewButton(i18n.T("Share contact"), nil)
+	if opts.ModeLocalNode {
+		shareContactButton.SetText(i18n.T("My QR code"))
+	}
 	telemetryLogButton.Disable()
 	positionLogButton.Disable()
 	identityLogButton.Disable()
 	tracerouteLogButton.Disable()
+	shareContactButton.Disable()
+	copyButton := widget.NewButton(i18n.T("Copy…"), nil)
+	copyButton.Disable()
 
 	actionsContent := container.NewVBox(
-		container.NewGridWithColumns(2, chatButton, tracerouteButton),
+		container.NewGridWithColumns(4, chatButton, tracerouteButton, favoriteButton, ignoreButton),
 		container.NewGridWithColumns(4, telemetryLogButton, positionLogButton, identityLogButton, tracerouteLogButton),
+		container.NewGridWithColumns(4, shareContactButton, copyButton),
 	)
-	actionsCard := overviewCard("Actions", actionsContent)
+	actionsCard := overviewCard(i18n.T("Actions"), actionsContent)
 	if !opts.ShowActions {
 		actionsCard.Hide()
 	}
@@ -181,6 +211,9 @@ func newNodeOverviewContent(opts nodeOverviewOptions) (fyne.CanvasObject, func()
 		scroll,
 	)
 
+	var miniMap fyne.CanvasObject
+	miniMapKey := ""
+
 	stopCh := make(chan struct{})
 
 	resolveNode := func() (domain.Node, bool) {
@@ -200,12 +233,12 @@ func newNodeOverviewContent(opts nodeOverviewOptions) (fyne.CanvasObject, func()
 		if !ok {
 			setOverviewSectionMetricRows(identityTables, [][]overviewMetric{
 				{
-					{Label: "Node", Value: "information is unavailable"},
+					{Label: i18n.T("Node"), Value: i18n.T("information is unavailable")},
 				},
 				{
-					{Label: "Last heard", Value: "unknown"},
-					{Label: "RSSI", Value: "unknown"},
-					{Label: "SNR", Value: "unknown"},
+					{Label: i18n.T("Last heard"), Value: i18n.T("unknown")},
+					{Label: i18n.T("RSSI"), Value: i18n.T("unknown")},
+					{Label: i18n.T("SNR"), Value: i18n.T("unknown")},
 				},
 			})
 			publicKeyEntry.SetText("")
@@ -226,15 +259,19 @@ func newNodeOverviewContent(opts nodeOverviewOptions) (fyne.CanvasObject, func()
 				requestOtherButton.Disable()
 			}
 			setOverviewSectionMetricRows(firmwareSection, [][]overviewMetric{{
-				{Label: "Firmware", Value: "unknown"},
-				{Label: "Board", Value: "unknown"},
-				{Label: "Image", Value: "unavailable (placeholder)"},
+				{Label: i18n.T("Firmware"), Value: i18n.T("unknown")},
+				{Label: i18n.T("Board"), Value: i18n.T("unknown")},
 			}})
+			boardIcon.SetResource(hardwareModelIconResource(describeHardwareModel("")))
 			chatButton.Disable()
 			tracerouteButton.Disable()
+			favoriteButton.Disable()
+			ignoreButton.Disable()
 			telemetryLogButton.Disable()
 			positionLogButton.Disable()
 			identityLogButton.Disable()
+			tracerouteLogButton.Disable()
+			copyButton.Disable()
 			setBodyCards([]fyne.CanvasObject{
 				identityCard,
 				adminCard,
@@ -246,17 +283,18 @@ func newNodeOverviewContent(opts nodeOverviewOptions) (fyne.CanvasObject, func()
 		}
 
 		identityMetrics := []overviewMetric{
-			{Label: "ID", Value: orUnknown(node.NodeID)},
-			{Label: "Short name", Value: orUnknown(node.ShortName)},
-			{Label: "Long name", Value: orUnknown(node.LongName)},
+			{Label: i18n.T("ID"), Value: orUnknown(node.NodeID)},
+			{Label: i18n.T("Short name"), Value: orUnknown(node.ShortName)},
+			{Label: i18n.T("Long name"), Value: orUnknown(node.LongName)},
+			{Label: i18n.T("Role"), Value: orUnknown(node.Role)},
 		}
-		if uptime := overviewUptime(node.UptimeSeconds); uptime != "unknown" {
-			identityMetrics = append(identityMetrics, overviewMetric{Label: "Uptime", Value: uptime})
+		if uptime := overviewUptime(node.UptimeSeconds); uptime != i18n.T("unknown") {
+			identityMetrics = append(identityMetrics, overviewMetric{Label: i18n.T("Uptime"), Value: uptime})
 		}
 		setOverviewSectionMetricRows(identityTables, [][]overviewMetric{
 			identityMetrics,
 			{
-				{Label: "Last heard", Value: overviewAgo(node.LastHeardAt)},
+				{Label: i18n.T("Last heard"), Value: overviewAgo(node.LastHeardAt)},
 				overviewRSSIMetric(node),
 				overviewSNRMetric(node),
 			},
@@ -280,6 +318,20 @@ func newNodeOverviewContent(opts nodeOverviewOptions) (fyne.CanvasObject, func()
 		} else {
 			tracerouteButton.Disable()
 		}
+		favoriteButton.SetText(nodeFavoriteMenuLabel(node))
+		if opts.OnSetFavorite != nil && !opts.ModeLocalNode {
+			favoriteButton.Enable()
+			favoriteButton.OnTapped = func() { opts.OnSetFavorite(node, !nodeIsFavorite(node)) }
+		} else {
+			favoriteButton.Disable()
+		}
+		ignoreButton.SetText(nodeIgnoreLabel(node))
+		if opts.OnSetIgnored != nil && !opts.ModeLocalNode {
+			ignoreButton.Enable()
+			ignoreButton.OnTapped = func() { opts.OnSetIgnored(node, !nodeIsIgnored(node)) }
+		} else {
+			ignoreButton.Disable()
+		}
 		if opts.OnTelemetryLog != nil {
 			telemetryLogButton.Enable()
 			telemetryLogButton.OnTapped = func() { opts.OnTelemetryLog(node) }
@@ -298,6 +350,31 @@ func newNodeOverviewContent(opts nodeOverviewOptions) (fyne.CanvasObject, func()
 		} else {
 			identityLogButton.Disable()
 		}
+		if opts.OnTracerouteLog != nil && !opts.ModeLocalNode {
+			tracerouteLogButton.Enable()
+			tracerouteLogButton.OnTapped = func() { opts.OnTracerouteLog(node) }
+		} else {
+			tracerouteLogButton.Disable()
+		}
+		if opts.OnShareContact != nil {
+			shareContactButton.Enable()
+			shareContactButton.OnTapped = func() { opts.OnShareContact(node) }
+		} else {
+			shareContactButton.Disable()
+		}
+		if copyItems := newNodeCopyMenuItems(node, copyNodeOverviewItem); len(copyItems) > 0 {
+			copyButton.Enable()
+			copyButton.OnTapped = func() {
+				widget.ShowPopUpMenuAtRelativePosition(
+					fyne.NewMenu("", copyItems...),
+					canvasForObject(copyButton),
+					fyne.NewPos(0, copyButton.Size().Height),
+					copyButton,
+				)
+			}
+		} else {
+			copyButton.Disable()
+		}
 		if requestIdentityButton != nil && opts.OnRequestUserInfo != nil {
 			requestIdentityButton.Enable()
 			requestIdentityButton.OnTapped = func() { opts.OnRequestUserInfo(node) }
@@ -321,6 +398,11 @@ func newNodeOverviewContent(opts nodeOverviewOptions) (fyne.CanvasObject, func()
 		}
 
 		powerMetrics := overviewPowerTelemetryMetrics(node)
+		if opts.BatteryEstimate != nil && node.BatteryLevel != nil {
+			if estimate, ok := opts.BatteryEstimate(node.NodeID); ok {
+				powerMetrics = append(powerMetrics, overviewMetric{Label: i18n.T("Estimated runtime"), Value: estimate.Summary()})
+			}
+		}
 		if len(powerMetrics) > 0 {
 			setOverviewSectionMetrics(powerSection, powerMetrics)
 		}
@@ -344,24 +426,63 @@ func newNodeOverviewContent(opts nodeOverviewOptions) (fyne.CanvasObject, func()
 			positionURL = opts.PositionMapURL(node)
 		}
 		positionMetrics := overviewPositionMetrics(node)
+		if opts.LocalNodeID != nil && opts.NodeStore != nil {
+			if localNode, ok := opts.NodeStore.Get(opts.LocalNodeID()); ok {
+				if metric, ok := overviewDistanceMetric(localNode, node); ok {
+					positionMetrics = append(positionMetrics, metric)
+				}
+			}
+		}
 		if positionURL != nil {
-			positionCardTitle.Objects = []fyne.CanvasObject{widget.NewHyperlink("Position", positionURL)}
+			positionCardTitle.Objects = []fyne.CanvasObject{widget.NewHyperlink(i18n.T("Position"), positionURL)}
 		} else {
-			positionCardTitle.Objects = []fyne.CanvasObject{overviewCardTitleLabel("Position")}
+			positionCardTitle.Objects = []fyne.CanvasObject{overviewCardTitleLabel(i18n.T("Position"))}
 		}
 		positionCardTitle.Refresh()
 		if len(positionMetrics) > 0 {
 			setOverviewSectionMetricRows(positionSection, [][]overviewMetric{positionMetrics})
+			if opts.PositionMiniMap != nil && node.Latitude != nil && node.Longitude != nil {
+				// Rebuild the map only when the node moves, not on every node update.
+				if key := fmt.Sprintf("%.6f,%.6f", *node.Latitude, *node.Longitude); key != miniMapKey {
+					miniMapKey = key
+					miniMap = opts.PositionMiniMap(node)
+				}
+				if miniMap != nil {
+					positionSection.Add(miniMap)
+				}
+			}
 		}
 
+		board := describeHardwareModel(node.BoardModel)
+		boardName := i18n.T("unknown")
+		if board.Code != "" {
+			boardName = board.Name
+		}
 		setOverviewSectionMetricRows(firmwareSection, [][]overviewMetric{{
-			{Label: "Firmware", Value: orUnknown(node.FirmwareVersion)},
-			{Label: "Board", Value: orUnknown(node.BoardModel)},
-			{Label: "Image", Value: "unavailable (placeholder)"},
+			{Label: i18n.T("Firmware"), Value: orUnknown(node.FirmwareVersion)},
+			{Label: i18n.T("Board"), Value: boardName},
+			{Label: i18n.T("Model code"), Value: orUnknown(board.Code)},
 		}})
+		boardIcon.SetResource(hardwareModelIconResource(board))
+
+		var signalRows fyne.CanvasObject
+		if opts.SignalHistory != nil {
+			signalRows = newSignalHistoryRows(opts.SignalHistory(node.NodeID))
+		}
+		if signalRows != nil {
+			signalSection.Objects = []fyne.CanvasObject{signalRows}
+			signalSection.Refresh()
+		}
 
-		cards := make([]fyne.CanvasObject, 0, 9)
+		cards := make([]fyne.CanvasObject, 0, 11)
 		cards = append(cards, identityCard)
+		if notesCard != nil {
+			notesCard.SetNode(node)
+			cards = append(cards, notesCard.content)
+		}
+		if signalRows != nil {
+			cards = append(cards, signalCard)
+		}
 		if len(powerMetrics) > 0 {
 			cards = append(cards, powerCard)
 		}
@@ -416,6 +537,12 @@ func newNodeOverviewContent(opts nodeOverviewOptions) (fyne.CanvasObject, func()
 	return content, stop
 }
 
+func copyNodeOverviewItem(item nodeCopyItem) {
+	if err := copyTextToClipboard(item.Value); err != nil {
+		nodeSettingsTabLogger.Debug("copy node data from overview failed", "item", item.Action, "error", err)
+	}
+}
+
 func overviewCard(title string, body fyne.CanvasObject, actions ...fyne.CanvasObject) *fyne.Container {
 	return overviewCardWithTitle(overviewCardTitleLabel(title), body, actions...)
 }
@@ -447,7 +574,7 @@ func overviewCardWithTitle(titleLabel fyne.CanvasObject, body fyne.CanvasObject,
 func overviewRefreshIconResource() fyne.Resource {
 	app := fyne.CurrentApp()
 	if app != nil {
-		if res := resources.UIIconResource(resources.UIIconRefresh, app.Settings().ThemeVariant()); res != nil {
+		if res := resources.UIIconResource(resources.UIIconRefresh, appThemeVariant(app)); res != nil {
 			return res
 		}
 	}
@@ -525,14 +652,14 @@ func overviewMetricsRowColumnCount(metricCount int) int {
 
 func overviewAgo(t time.Time) string {
 	if t.IsZero() {
-		return "unknown"
+		return i18n.T("unknown")
 	}
 
 	return formatSeenAgo(t, time.Now())
 }
 
 func overviewRSSIMetric(node domain.Node) overviewMetric {
-	metric := overviewMetric{Label: "RSSI", Value: "unknown"}
+	metric := overviewMetric{Label: i18n.T("RSSI"), Value: i18n.T("unknown")}
 	if node.RSSI != nil {
 		metric.Value = fmt.Sprintf("%d dBm", *node.RSSI)
 		metric.ColorName = signalThemeColorForRSSI(*node.RSSI)
@@ -542,9 +669,9 @@ func overviewRSSIMetric(node domain.Node) overviewMetric {
 }
 
 func overviewSNRMetric(node domain.Node) overviewMetric {
-	metric := overviewMetric{Label: "SNR", Value: "unknown"}
+	metric := overviewMetric{Label: i18n.T("SNR"), Value: i18n.T("unknown")}
 	if node.SNR != nil {
-		metric.Value = fmt.Sprintf("%.2f dB", *node.SNR)
+		metric.Value = currentDisplayFormatter().Number("%.2f dB", *node.SNR)
 		metric.ColorName = signalThemeColorForSNR(*node.SNR)
 	}
 
@@ -553,11 +680,16 @@ func overviewSNRMetric(node domain.Node) overviewMetric {
 
 func overviewUptime(uptimeSeconds *uint32) string {
 	if uptimeSeconds == nil {
-		return "unknown"
+		return i18n.T("unknown")
 	}
-	d := time.Duration(*uptimeSeconds) * time.Second
+
+	return formatUptime(time.Duration(*uptimeSeconds) * time.Second)
+}
+
+// formatUptime shows a duration as days, hours and minutes, e.g. "2d 3h 15m".
+func formatUptime(d time.Duration) string {
 	if d < time.Minute {
-		return d.String()
+		return d.Round(time.Second).String()
 	}
 	days := d / (24 * time.Hour)
 	d -= days * 24 * time.Hour
@@ -581,16 +713,16 @@ func overviewPowerTelemetry(node domain.Node) string {
 func overviewPowerTelemetryMetrics(node domain.Node) []overviewMetric {
 	metrics := make([]overviewMetric, 0, 4)
 	if node.BatteryLevel != nil {
-		metrics = append(metrics, overviewMetric{Label: "Battery", Value: fmt.Sprintf("%d%%", *node.BatteryLevel)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Battery"), Value: fmt.Sprintf("%d%%", *node.BatteryLevel)})
 	}
 	if node.Voltage != nil {
-		metrics = append(metrics, overviewMetric{Label: "Voltage", Value: fmt.Sprintf("%.2f V", *node.Voltage)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Voltage"), Value: currentDisplayFormatter().Number("%.2f V", *node.Voltage)})
 	}
 	if node.PowerVoltage != nil {
-		metrics = append(metrics, overviewMetric{Label: "Power voltage", Value: fmt.Sprintf("%.2f V", *node.PowerVoltage)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Power voltage"), Value: currentDisplayFormatter().Number("%.2f V", *node.PowerVoltage)})
 	}
 	if node.PowerCurrent != nil {
-		metrics = append(metrics, overviewMetric{Label: "Power current", Value: fmt.Sprintf("%.3f A", *node.PowerCurrent)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Power current"), Value: currentDisplayFormatter().Number("%.3f A", *node.PowerCurrent)})
 	}
 
 	return metrics
@@ -603,34 +735,34 @@ func overviewEnvironmentTelemetry(node domain.Node) string {
 func overviewEnvironmentTelemetryMetrics(node domain.Node) []overviewMetric {
 	metrics := make([]overviewMetric, 0, 10)
 	if node.Temperature != nil {
-		metrics = append(metrics, overviewMetric{Label: "Temperature", Value: fmt.Sprintf("%.1f C", *node.Temperature)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Temperature"), Value: currentDisplayFormatter().Temperature(*node.Temperature)})
 	}
 	if node.Humidity != nil {
-		metrics = append(metrics, overviewMetric{Label: "Humidity", Value: fmt.Sprintf("%.1f%%", *node.Humidity)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Humidity"), Value: currentDisplayFormatter().Number("%.1f%%", *node.Humidity)})
 	}
 	if node.Pressure != nil {
-		metrics = append(metrics, overviewMetric{Label: "Pressure", Value: fmt.Sprintf("%.1f hPa", *node.Pressure)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Pressure"), Value: currentDisplayFormatter().Number("%.1f hPa", *node.Pressure)})
 	}
 	if node.SoilTemperature != nil {
-		metrics = append(metrics, overviewMetric{Label: "Soil temperature", Value: fmt.Sprintf("%.1f C", *node.SoilTemperature)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Soil temperature"), Value: currentDisplayFormatter().Temperature(*node.SoilTemperature)})
 	}
 	if node.SoilMoisture != nil {
-		metrics = append(metrics, overviewMetric{Label: "Soil moisture", Value: fmt.Sprintf("%d%%", *node.SoilMoisture)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Soil moisture"), Value: fmt.Sprintf("%d%%", *node.SoilMoisture)})
 	}
 	if dewPoint, ok := calculateDewPointCelsius(node.Temperature, node.Humidity); ok {
-		metrics = append(metrics, overviewMetric{Label: "Dew point", Value: fmt.Sprintf("%.1f C", dewPoint)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Dew point"), Value: currentDisplayFormatter().Temperature(dewPoint)})
 	}
 	if node.GasResistance != nil {
-		metrics = append(metrics, overviewMetric{Label: "Gas resistance", Value: fmt.Sprintf("%.2f MOhm", *node.GasResistance)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Gas resistance"), Value: currentDisplayFormatter().Number("%.2f MOhm", *node.GasResistance)})
 	}
 	if node.Lux != nil {
-		metrics = append(metrics, overviewMetric{Label: "Light", Value: fmt.Sprintf("%.1f lx", *node.Lux)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Light"), Value: currentDisplayFormatter().Number("%.1f lx", *node.Lux)})
 	}
 	if node.UVLux != nil {
-		metrics = append(metrics, overviewMetric{Label: "UV light", Value: fmt.Sprintf("%.1f UVlx", *node.UVLux)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("UV light"), Value: currentDisplayFormatter().Number("%.1f UVlx", *node.UVLux)})
 	}
 	if node.Radiation != nil {
-		metrics = append(metrics, overviewMetric{Label: "Radiation", Value: fmt.Sprintf("%.2f uR/h", *node.Radiation)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Radiation"), Value: currentDisplayFormatter().Number("%.2f uR/h", *node.Radiation)})
 	}
 
 	return metrics
@@ -659,7 +791,7 @@ func calculateDewPointCelsius(temperature, humidity *float64) (float64, bool) {
 func overviewAirQualityTelemetryMetrics(node domain.Node) []overviewMetric {
 	metrics := make([]overviewMetric, 0, 1)
 	if node.AirQualityIndex != nil {
-		metrics = append(metrics, overviewMetric{Label: "Air quality index", Value: fmt.Sprintf("%.1f", *node.AirQualityIndex)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Air quality index"), Value: currentDisplayFormatter().Number("%.1f", *node.AirQualityIndex)})
 	}
 
 	return metrics
@@ -672,10 +804,10 @@ func overviewOtherTelemetry(node domain.Node) string {
 func overviewOtherTelemetryMetrics(node domain.Node) []overviewMetric {
 	metrics := make([]overviewMetric, 0, 2)
 	if node.ChannelUtilization != nil {
-		metrics = append(metrics, overviewMetric{Label: "Channel utilization", Value: fmt.Sprintf("%.2f%%", *node.ChannelUtilization)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Channel utilization"), Value: currentDisplayFormatter().Number("%.2f%%", *node.ChannelUtilization)})
 	}
 	if node.AirUtilTx != nil {
-		metrics = append(metrics, overviewMetric{Label: "TX air utilization", Value: fmt.Sprintf("%.2f%%", *node.AirUtilTx)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("TX air utilization"), Value: currentDisplayFormatter().Number("%.2f%%", *node.AirUtilTx)})
 	}
 
 	return metrics
@@ -689,25 +821,45 @@ func overviewPositionMetrics(node domain.Node) []overviewMetric {
 	if node.Latitude == nil || node.Longitude == nil {
 		return nil
 	}
+	formatter := currentDisplayFormatter()
 	metrics := []overviewMetric{
-		{Label: "Latitude", Value: fmt.Sprintf("%.6f", *node.Latitude)},
-		{Label: "Longitude", Value: fmt.Sprintf("%.6f", *node.Longitude)},
+		{Label: i18n.T("Latitude"), Value: formatter.Latitude(*node.Latitude)},
+		{Label: i18n.T("Longitude"), Value: formatter.Longitude(*node.Longitude)},
+	}
+	if label := formatter.GridLabel(); label == "MGRS" {
+		metrics = append(metrics, overviewMetric{Label: label, Value: formatter.Coordinates(*node.Latitude, *node.Longitude)})
 	}
+	metrics = append(metrics, overviewMetric{Label: i18n.T("Grid square"), Value: nodeGridSquare(node)})
 	if node.Altitude != nil {
-		metrics = append(metrics, overviewMetric{Label: "Altitude", Value: fmt.Sprintf("%d m", *node.Altitude)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Altitude"), Value: fmt.Sprintf("%d m", *node.Altitude)})
 	}
 	if node.PositionPrecisionBits != nil {
-		metrics = append(metrics, overviewMetric{Label: "Precision", Value: nodeChannelPositionPrecisionLabel(*node.PositionPrecisionBits)})
+		metrics = append(metrics, overviewMetric{Label: i18n.T("Precision"), Value: nodeChannelPositionPrecisionLabel(*node.PositionPrecisionBits)})
 	}
 	relevancy := node.PositionUpdatedAt
 	if relevancy.IsZero() {
 		relevancy = node.LastHeardAt
 	}
-	metrics = append(metrics, overviewMetric{Label: "Position age", Value: overviewAgo(relevancy)})
+	metrics = append(metrics, overviewMetric{Label: i18n.T("Position age"), Value: overviewAgo(relevancy)})
 
 	return metrics
 }
 
+// overviewDistanceMetric describes how far the node is from the local node, or
+// reports false when either position is unknown or both are the same node.
+func overviewDistanceMetric(localNode, node domain.Node) (overviewMetric, bool) {
+	if localNode.NodeID == node.NodeID || !nodeHasPosition(localNode) || !nodeHasPosition(node) {
+		return overviewMetric{}, false
+	}
+	km := geo.DistanceKm(*localNode.Latitude, *localNode.Longitude, *node.Latitude, *node.Longitude)
+	bearing := geo.InitialBearing(*localNode.Latitude, *localNode.Longitude, *node.Latitude, *node.Longitude)
+
+	return overviewMetric{
+		Label: i18n.T("Distance"),
+		Value: formatDistanceBearing(km, bearing),
+	}, true
+}
+
 func overviewMetricLines(metrics []overviewMetric) string {
 	lines := make([]string, 0, len(metrics))
 	for _, metric := range metrics {
@@ -758,9 +910,35 @@ func showNodeOverviewModal(
 		OnIdentityLog: func(target domain.Node) {
 			handleNodeIdentityLogAction(window, dep, target)
 		},
+		OnTracerouteLog: func(target domain.Node) {
+			handleNodeTracerouteLogAction(window, dep, target)
+		},
+		OnShareContact: func(target domain.Node) {
+			handleNodeShareContactAction(window, dep, target)
+		},
+		OnSetFavorite: func(target domain.Node, favorite bool) {
+			handleNodeFavoriteAction(window, dep, target, favorite)
+		},
+		OnSetIgnored: func(target domain.Node, ignored bool) {
+			handleNodeIgnoreAction(window, dep, target, ignored)
+		},
+		LocalNodeID: func() string {
+			return localNodeIDValue(dep.Data.LocalNodeID)
+		},
+		BatteryEstimate: nodeOverviewBatteryEstimate(dep),
+		SignalHistory:   nodeOverviewSignalHistory(dep),
 		PositionMapURL: func(target domain.Node) *url.URL {
 			return overviewNodePositionURL(dep, target)
 		},
+		PositionMiniMap: func(target domain.Node) fyne.CanvasObject {
+			return newNodeMiniMap(
+				nodeMiniMapClient(dep.Data.Paths.MapTilesDir),
+				mapCoordinate{Latitude: *target.Latitude, Longitude: *target.Longitude},
+				appThemeVariant(fyne.CurrentApp()),
+			)
+		},
+		OnSaveNotes: dep.Actions.OnSetNodeNotes,
+		OnSaveTags:  dep.Actions.OnSetNodeTags,
 	}
 	var modal *widget.PopUp
 	var stop func()
@@ -782,13 +960,14 @@ func showNodeOverviewModal(
 
 func newNodeOverviewSettingsPage(dep RuntimeDependencies) fyne.CanvasObject {
 	content, _ := newNodeOverviewContent(nodeOverviewOptions{
-		Title:     "Node overview",
+		Title:     i18n.T("Node overview"),
 		NodeStore: dep.Data.NodeStore,
 		NodeID: func() string {
 			return localNodeSnapshot(dep).ID
 		},
-		ShowActions:   true,
-		ModeLocalNode: true,
+		ShowActions:     true,
+		ModeLocalNode:   true,
+		BatteryEstimate: nodeOverviewBatteryEstimate(dep),
 		OnTelemetryLog: func(target domain.Node) {
 			handleNodeTelemetryLogAction(currentRuntimeWindow(dep), dep, target)
 		},
@@ -798,6 +977,9 @@ func newNodeOverviewSettingsPage(dep RuntimeDependencies) fyne.CanvasObject {
 		OnIdentityLog: func(target domain.Node) {
 			handleNodeIdentityLogAction(currentRuntimeWindow(dep), dep, target)
 		},
+		OnShareContact: func(domain.Node) {
+			handleMyNodeShareAction(currentRuntimeWindow(dep), dep)
+		},
 		PositionMapURL: func(target domain.Node) *url.URL {
 			return overviewNodePositionURL(dep, target)
 		},
@@ -823,3 +1005,41 @@ func overviewNodePositionURL(dep RuntimeDependencies, node domain.Node) *url.URL
 
 	return parsed
 }
+
+func nodeOverviewSignalHistory(dep RuntimeDependencies) func(string) []domain.NodeSignalHistoryEntry {
+	if dep.Actions.NodeOverview == nil {
+		return nil
+	}
+
+	return func(nodeID string) []domain.NodeSignalHistoryEntry {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		items, err := dep.Actions.NodeOverview.ListSignalHistory(ctx, nodeID, 0)
+		if err != nil {
+			nodeSettingsTabLogger.Debug("signal history load failed", "node_id", nodeID, "error", err)
+
+			return nil
+		}
+
+		return items
+	}
+}
+
+func nodeOverviewBatteryEstimate(dep RuntimeDependencies) func(string) (domain.BatteryEstimate, bool) {
+	if dep.Actions.NodeOverview == nil {
+		return nil
+	}
+
+	return func(nodeID string) (domain.BatteryEstimate, bool) {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		estimate, ok, err := dep.Actions.NodeOverview.EstimateBatteryRuntime(ctx, nodeID)
+		if err != nil {
+			nodeSettingsTabLogger.Debug("battery runtime estimate failed", "node_id", nodeID, "error", err)
+
+			return domain.BatteryEstimate{}, false
+		}
+
+		return estimate, ok
+	}
+}
@@ -65,6 +65,8 @@ func TestNewNodeOverviewContent_RemoteActionsEnabled(t *testing.T) {
 		OnTelemetryLog:  func(domain.Node) {},
 		OnPositionLog:   func(domain.Node) {},
 		OnIdentityLog:   func(domain.Node) {},
+		OnSetFavorite:   func(domain.Node, bool) {},
+		OnSetIgnored:    func(domain.Node, bool) {},
 	})
 	defer stop()
 	_ = fynetest.NewTempWindow(t, content)
@@ -89,6 +91,15 @@ func TestNewNodeOverviewContent_RemoteActionsEnabled(t *testing.T) {
 	if identityLog.Disabled() {
 		t.Fatalf("expected identity log action enabled")
 	}
+	if favorite := mustFindOverviewButtonByText(t, content, "Favorite"); favorite.Disabled() {
+		t.Fatalf("expected favorite action enabled for remote node")
+	}
+	if ignore := mustFindOverviewButtonByText(t, content, "Ignore"); ignore.Disabled() {
+		t.Fatalf("expected ignore action enabled for remote node")
+	}
+	if !hasLabelText(content, "Role") {
+		t.Fatalf("expected role field in identity")
+	}
 	if hasLabelText(content, "Uptime") {
 		t.Fatalf("did not expect uptime field when uptime is unknown")
 	}
@@ -133,6 +144,34 @@ func TestNewNodeOverviewContent_LocalNodeHidesRefreshActions(t *testing.T) {
 	}
 }
 
+func TestNewNodeOverviewContent_LocalNodeSharesOwnContact(t *testing.T) {
+	store := domain.NewNodeStore()
+	store.Upsert(domain.Node{NodeID: "!00000001", LongName: "Alpha", LastHeardAt: time.Now()})
+
+	var shared []string
+	content, stop := newNodeOverviewContent(nodeOverviewOptions{
+		Title:     "Node",
+		NodeStore: store,
+		NodeID: func() string {
+			return "!00000001"
+		},
+		ShowActions:    true,
+		ModeLocalNode:  true,
+		OnShareContact: func(node domain.Node) { shared = append(shared, node.NodeID) },
+	})
+	defer stop()
+	_ = fynetest.NewTempWindow(t, content)
+
+	button := mustFindOverviewButtonByText(t, content, "My QR code")
+	if button.Disabled() {
+		t.Fatalf("expected the QR code action enabled for the local node")
+	}
+	fynetest.Tap(button)
+	if len(shared) != 1 || shared[0] != "!00000001" {
+		t.Fatalf("expected the local node to be shared once, got %v", shared)
+	}
+}
+
 func TestNewNodeOverviewContent_HidesTelemetrySectionsWithoutData(t *testing.T) {
 	store := domain.NewNodeStore()
 	store.Upsert(domain.Node{
@@ -288,6 +327,32 @@ func TestOverviewTelemetryHelpers(t *testing.T) {
 	}
 }
 
+func TestOverviewDistanceMetric(t *testing.T) {
+	localLat, localLon := 50.5, 29.0
+	remoteLat, remoteLon := 50.5, 31.0
+	local := domain.Node{NodeID: "!00000001", Latitude: &localLat, Longitude: &localLon}
+	remote := domain.Node{NodeID: "!00000002", Latitude: &remoteLat, Longitude: &remoteLon}
+
+	metric, ok := overviewDistanceMetric(local, remote)
+	if !ok || metric.Label != "Distance" || metric.Value != "141.5 km at 89°" {
+		t.Fatalf("unexpected distance metric: %+v, %v", metric, ok)
+	}
+	if _, ok := overviewDistanceMetric(local, local); ok {
+		t.Fatalf("expected no distance to the local node itself")
+	}
+	if _, ok := overviewDistanceMetric(domain.Node{NodeID: "!00000001"}, remote); ok {
+		t.Fatalf("expected no distance without a local position")
+	}
+}
+
+func TestOverviewPositionMetricsIncludeGridSquare(t *testing.T) {
+	lat, lon := 50.450333, 30.523333
+	got := overviewPosition(domain.Node{Latitude: &lat, Longitude: &lon})
+	if !strings.Contains(got, "Grid square: KO50gk") {
+		t.Fatalf("expected grid square in position text, got %q", got)
+	}
+}
+
 func TestOverviewMetricsColumnCount(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -373,3 +438,33 @@ func mustFindOverviewHyperlinkByText(t *testing.T, root fyne.CanvasObject, text
 
 	return nil
 }
+
+func TestNewNodeOverviewContent_IgnoreActionTogglesFlag(t *testing.T) {
+	store := domain.NewNodeStore()
+	ignored := true
+	store.Upsert(domain.Node{NodeID: "!00000001", LongName: "Alpha", LastHeardAt: time.Now(), IsIgnored: &ignored})
+
+	var gotIgnored *bool
+	content, stop := newNodeOverviewContent(nodeOverviewOptions{
+		Title:     "Node",
+		NodeStore: store,
+		NodeID: func() string {
+			return "!00000001"
+		},
+		ShowActions: true,
+		OnSetIgnored: func(_ domain.Node, value bool) {
+			gotIgnored = &value
+		},
+	})
+	defer stop()
+	_ = fynetest.NewTempWindow(t, content)
+
+	unignore := mustFindOverviewButtonByText(t, content, "Unignore")
+	fynetest.Tap(unignore)
+	if gotIgnored == nil || *gotIgnored {
+		t.Fatalf("expected tapping Unignore to clear the flag, got %v", gotIgnored)
+	}
+	if favorite := mustFindOverviewButtonByText(t, content, "Favorite"); !favorite.Disabled() {
+		t.Fatalf("expected favorite action disabled without a handler")
+	}
+}
@@ -55,6 +55,14 @@ func TestNodeMaintenancePageDisablesActionsWithoutNodeSettingsService(t *testing
 	}
 }
 
+func TestNodeMaintenancePageDisablesEmergencyModeWithoutService(t *testing.T) {
+	page := newNodeMaintenancePage(RuntimeDependencies{})
+
+	if button := mustFindButtonByText(t, page, "Activate emergency mode"); !button.Disabled() {
+		t.Fatalf("expected emergency mode button to be disabled without emergency mode service")
+	}
+}
+
 func TestDefaultNodeSettingsProfileFilenameUsesSanitizedLocalNodeName(t *testing.T) {
 	filename := defaultNodeSettingsProfileFilename(RuntimeDependencies{
 		Data: DataDependencies{
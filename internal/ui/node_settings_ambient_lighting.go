@@ -10,11 +10,12 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodeAmbientLightingSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) (fyne.CanvasObject, func()) {
 	return newManagedNodeSettingsPage(
-		dep, saveGate, "module.ambient_lighting", "Loading ambient lighting settings…", "Ambient lighting settings loaded.",
+		dep, saveGate, "module.ambient_lighting", i18n.T("Loading ambient lighting settings…"), i18n.T("Ambient lighting settings loaded."),
 		func(ctx context.Context, target app.NodeSettingsTarget) (app.NodeAmbientLightingSettings, error) {
 			return dep.Actions.NodeSettings.LoadAmbientLightingSettings(ctx, target)
 		},
@@ -30,7 +31,7 @@ func newNodeAmbientLightingSettingsPage(dep RuntimeDependencies, saveGate *nodeS
 }
 
 func buildNodeAmbientLightingSettingsForm(onChanged func()) nodeManagedSettingsForm[app.NodeAmbientLightingSettings] {
-	nodeID := widget.NewLabel("unknown")
+	nodeID := widget.NewLabel(i18n.T("unknown"))
 	nodeID.TextStyle = fyne.TextStyle{Monospace: true}
 	ledState := newSettingsCheck(onChanged)
 	current := newNumberEntry(onChanged)
@@ -38,12 +39,12 @@ func buildNodeAmbientLightingSettingsForm(onChanged func()) nodeManagedSettingsF
 	green := newNumberEntry(onChanged)
 	blue := newNumberEntry(onChanged)
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeID),
-		widget.NewFormItem("LED state", ledState),
-		widget.NewFormItem("Current", current),
-		widget.NewFormItem("Red", red),
-		widget.NewFormItem("Green", green),
-		widget.NewFormItem("Blue", blue),
+		widget.NewFormItem(i18n.T("Node ID"), nodeID),
+		widget.NewFormItem(i18n.T("LED state"), ledState),
+		widget.NewFormItem(i18n.T("Current"), current),
+		widget.NewFormItem(i18n.T("Red"), red),
+		widget.NewFormItem(i18n.T("Green"), green),
+		widget.NewFormItem(i18n.T("Blue"), blue),
 	)
 
 	return nodeManagedSettingsForm[app.NodeAmbientLightingSettings]{
@@ -10,11 +10,12 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodeAudioSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) (fyne.CanvasObject, func()) {
 	return newManagedNodeSettingsPage(
-		dep, saveGate, "module.audio", "Loading audio settings…", "Audio settings loaded.",
+		dep, saveGate, "module.audio", i18n.T("Loading audio settings…"), i18n.T("Audio settings loaded."),
 		func(ctx context.Context, target app.NodeSettingsTarget) (app.NodeAudioSettings, error) {
 			return dep.Actions.NodeSettings.LoadAudioSettings(ctx, target)
 		},
@@ -30,7 +31,7 @@ func newNodeAudioSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSav
 }
 
 func buildNodeAudioSettingsForm(onChanged func()) nodeManagedSettingsForm[app.NodeAudioSettings] {
-	nodeID := widget.NewLabel("unknown")
+	nodeID := widget.NewLabel(i18n.T("unknown"))
 	nodeID.TextStyle = fyne.TextStyle{Monospace: true}
 	codec2Enabled := newSettingsCheck(onChanged)
 	pttPin := newNumberEntry(onChanged)
@@ -41,14 +42,14 @@ func buildNodeAudioSettingsForm(onChanged func()) nodeManagedSettingsForm[app.No
 	i2sDin := newNumberEntry(onChanged)
 	i2sSck := newNumberEntry(onChanged)
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeID),
-		widget.NewFormItem("Codec2 enabled", codec2Enabled),
-		widget.NewFormItem("PTT pin", pttPin),
-		widget.NewFormItem("Bitrate", bitrate),
-		widget.NewFormItem("I2S WS", i2sWs),
-		widget.NewFormItem("I2S SD", i2sSd),
-		widget.NewFormItem("I2S DIN", i2sDin),
-		widget.NewFormItem("I2S SCK", i2sSck),
+		widget.NewFormItem(i18n.T("Node ID"), nodeID),
+		widget.NewFormItem(i18n.T("Codec2 enabled"), codec2Enabled),
+		widget.NewFormItem(i18n.T("PTT pin"), pttPin),
+		widget.NewFormItem(i18n.T("Bitrate"), bitrate),
+		widget.NewFormItem(i18n.T("I2S WS"), i2sWs),
+		widget.NewFormItem(i18n.T("I2S SD"), i2sSd),
+		widget.NewFormItem(i18n.T("I2S DIN"), i2sDin),
+		widget.NewFormItem(i18n.T("I2S SCK"), i2sSck),
 	)
 
 	return nodeManagedSettingsForm[app.NodeAudioSettings]{
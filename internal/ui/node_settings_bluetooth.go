@@ -14,6 +14,7 @@ import (
 
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/i18n"
 	generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
 )
 
@@ -21,12 +22,12 @@ func newNodeBluetoothSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 	const pageID = "device.bluetooth"
 	nodeSettingsTabLogger.Debug("building node bluetooth settings page", "page_id", pageID, "service_configured", dep.Actions.NodeSettings != nil)
 
-	controls := newNodeSettingsPageControls("Loading bluetooth settings…")
+	controls := newNodeSettingsPageControls(i18n.T("Loading bluetooth settings…"))
 	saveButton := controls.saveButton
 	cancelButton := controls.cancelButton
 	reloadButton := controls.reloadButton
 
-	nodeIDLabel := widget.NewLabel("unknown")
+	nodeIDLabel := widget.NewLabel(i18n.T("unknown"))
 	nodeIDLabel.TextStyle = fyne.TextStyle{Monospace: true}
 
 	enabledBox := widget.NewCheck("", nil)
@@ -35,10 +36,10 @@ func newNodeBluetoothSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 	fixedPINEntry.SetPlaceHolder("123456")
 
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeIDLabel),
-		widget.NewFormItem("Bluetooth enabled", enabledBox),
-		widget.NewFormItem("Pairing mode", pairingModeSelect),
-		widget.NewFormItem("Fixed PIN", fixedPINEntry),
+		widget.NewFormItem(i18n.T("Node ID"), nodeIDLabel),
+		widget.NewFormItem(i18n.T("Bluetooth enabled"), enabledBox),
+		widget.NewFormItem(i18n.T("Pairing mode"), pairingModeSelect),
+		widget.NewFormItem(i18n.T("Fixed PIN"), fixedPINEntry),
 	)
 
 	var (
@@ -173,25 +174,25 @@ func newNodeBluetoothSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node bluetooth settings reload failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Reload failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Reload failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node bluetooth settings reload unavailable: service is not configured", "page_id", pageID)
-			controls.SetStatus("Reload is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Reload is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node bluetooth settings reload blocked: device is disconnected", "page_id", pageID, "node_id", target.NodeID)
-			controls.SetStatus("Reload from device is unavailable while disconnected.", 0, 2)
+			controls.SetStatus(i18n.T("Reload from device is unavailable while disconnected."), 0, 2)
 
 			return
 		}
 
 		nodeSettingsTabLogger.Info("reloading node bluetooth settings from device", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Reloading bluetooth settings from device…", 1, 2)
+		controls.SetStatus(i18n.T("Reloading bluetooth settings from device…"), 1, 2)
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), nodeSettingsOpTimeout)
 			defer cancel()
@@ -199,14 +200,14 @@ func newNodeBluetoothSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 			fyne.Do(func() {
 				if err != nil {
 					nodeSettingsTabLogger.Warn("reloading node bluetooth settings from device failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Reload failed: "+err.Error(), 0, 2)
+					controls.SetStatus(i18n.Tf("Reload failed: %s", err), 0, 2)
 					updateButtons()
 
 					return
 				}
 				applyLoadedSettings(loaded)
 				nodeSettingsTabLogger.Info("reloaded node bluetooth settings from device", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Reloaded bluetooth settings from device.", 2, 2)
+				controls.SetStatus(i18n.T("Reloaded bluetooth settings from device."), 2, 2)
 			})
 		}()
 	}
@@ -225,7 +226,7 @@ func newNodeBluetoothSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 		dirty = false
 		mu.Unlock()
 		applyForm(settings)
-		controls.SetStatus("Local edits reverted.", 1, 1)
+		controls.SetStatus(i18n.T("Local edits reverted."), 1, 1)
 		updateButtons()
 	}
 
@@ -233,13 +234,13 @@ func newNodeBluetoothSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 		nodeSettingsTabLogger.Info("node bluetooth settings save requested", "page_id", pageID)
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node bluetooth settings save unavailable: service is not configured")
-			controls.SetStatus("Save is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node bluetooth settings save blocked: device is disconnected", "page_id", pageID)
-			controls.SetStatus("Save is unavailable while disconnected.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable while disconnected."), 0, 1)
 			updateButtons()
 
 			return
@@ -247,13 +248,13 @@ func newNodeBluetoothSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node bluetooth settings save failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Save failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Save failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if saveGate != nil && !saveGate.TryAcquire(pageID) {
 			nodeSettingsTabLogger.Info("node bluetooth settings save blocked: another page save is active", "page_id", pageID, "active_page", saveGate.ActivePage())
-			controls.SetStatus("Another settings save is in progress on a different page.", 0, 1)
+			controls.SetStatus(i18n.T("Another settings save is in progress on a different page."), 0, 1)
 			updateButtons()
 
 			return
@@ -265,7 +266,7 @@ func newNodeBluetoothSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 				saveGate.Release(pageID)
 			}
 			nodeSettingsTabLogger.Warn("node bluetooth settings save failed: invalid form values", "page_id", pageID, "error", err)
-			controls.SetStatus("Save failed: "+err.Error(), 0, 1)
+			controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 1)
 			updateButtons()
 
 			return
@@ -275,7 +276,7 @@ func newNodeBluetoothSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 		saving = true
 		mu.Unlock()
 		nodeSettingsTabLogger.Info("saving node bluetooth settings", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Saving bluetooth settings…", 1, 3)
+		controls.SetStatus(i18n.T("Saving bluetooth settings…"), 1, 3)
 		updateButtons()
 
 		go func(settings app.NodeBluetoothSettings) {
@@ -290,7 +291,7 @@ func newNodeBluetoothSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 				}
 				if err != nil {
 					nodeSettingsTabLogger.Warn("saving node bluetooth settings failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Save failed: "+err.Error(), 0, 3)
+					controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 3)
 					mu.Unlock()
 					updateButtons()
 
@@ -304,7 +305,7 @@ func newNodeBluetoothSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 
 				applyForm(applied)
 				nodeSettingsTabLogger.Info("saved node bluetooth settings", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Saved bluetooth settings.", 3, 3)
+				controls.SetStatus(i18n.T("Saved bluetooth settings."), 3, 3)
 				updateButtons()
 			})
 		}(next)
@@ -323,9 +324,9 @@ func newNodeBluetoothSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 	}
 	applyLoadedSettings(initial)
 	if dep.Actions.NodeSettings == nil {
-		controls.SetStatus("Bluetooth settings are unavailable: node settings service is not configured.", 0, 1)
+		controls.SetStatus(i18n.T("Bluetooth settings are unavailable: node settings service is not configured."), 0, 1)
 	} else {
-		controls.SetStatus("Bluetooth settings will load when this tab is opened.", 0, 1)
+		controls.SetStatus(i18n.T("Bluetooth settings will load when this tab is opened."), 0, 1)
 	}
 
 	if dep.Data.Bus != nil {
@@ -343,7 +344,7 @@ func newNodeBluetoothSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 	updateButtons()
 
 	content := container.NewVBox(
-		widget.NewLabel("Bluetooth settings are loaded from and saved to the connected local node."),
+		widget.NewLabel(i18n.T("Bluetooth settings are loaded from and saved to the connected local node.")),
 		form,
 	)
 
@@ -422,7 +423,7 @@ func nodeBluetoothSetEnumSelect(selectWidget *widget.Select, options []nodeBluet
 func nodeBluetoothEnumLabel(value int32, options []nodeBluetoothEnumOption) string {
 	for _, option := range options {
 		if option.Value == value {
-			return option.Label
+			return i18n.T(option.Label)
 		}
 	}
 
@@ -432,7 +433,7 @@ func nodeBluetoothEnumLabel(value int32, options []nodeBluetoothEnumOption) stri
 func nodeBluetoothEnumOptionsLabels(options []nodeBluetoothEnumOption) []string {
 	out := make([]string, 0, len(options))
 	for _, option := range options {
-		out = append(out, option.Label)
+		out = append(out, i18n.T(option.Label))
 	}
 
 	return out
@@ -448,7 +449,7 @@ func nodeBluetoothParseEnumLabel(fieldName, selected string, options []nodeBluet
 		return 0, fmt.Errorf("%s must be selected", fieldName)
 	}
 	for _, option := range options {
-		if option.Label == selected {
+		if i18n.T(option.Label) == selected {
 			return option.Value, nil
 		}
 	}
@@ -10,11 +10,12 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodeCannedMessageSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) (fyne.CanvasObject, func()) {
 	return newManagedNodeSettingsPage(
-		dep, saveGate, "module.canned_message", "Loading canned message settings…", "Canned message settings loaded.",
+		dep, saveGate, "module.canned_message", i18n.T("Loading canned message settings…"), i18n.T("Canned message settings loaded."),
 		func(ctx context.Context, target app.NodeSettingsTarget) (app.NodeCannedMessageSettings, error) {
 			return dep.Actions.NodeSettings.LoadCannedMessageSettings(ctx, target)
 		},
@@ -33,7 +34,7 @@ func newNodeCannedMessageSettingsPage(dep RuntimeDependencies, saveGate *nodeSet
 }
 
 func buildNodeCannedMessageSettingsForm(onChanged func()) nodeManagedSettingsForm[app.NodeCannedMessageSettings] {
-	nodeID := widget.NewLabel("unknown")
+	nodeID := widget.NewLabel(i18n.T("unknown"))
 	nodeID.TextStyle = fyne.TextStyle{Monospace: true}
 	rotary1Enabled := newSettingsCheck(onChanged)
 	pinA := newNumberEntry(onChanged)
@@ -50,19 +51,19 @@ func buildNodeCannedMessageSettingsForm(onChanged func()) nodeManagedSettingsFor
 	messages := widget.NewMultiLineEntry()
 	messages.OnChanged = func(string) { onChanged() }
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeID),
-		widget.NewFormItem("Rotary 1 enabled", rotary1Enabled),
-		widget.NewFormItem("Input broker pin A", pinA),
-		widget.NewFormItem("Input broker pin B", pinB),
-		widget.NewFormItem("Input broker pin press", pinPress),
-		widget.NewFormItem("Input broker event CW", eventCW),
-		widget.NewFormItem("Input broker event CCW", eventCCW),
-		widget.NewFormItem("Input broker event press", eventPress),
-		widget.NewFormItem("Up/down 1 enabled", upDown1Enabled),
-		widget.NewFormItem("Enabled", enabled),
-		widget.NewFormItem("Allow input source", allowInputSource),
-		widget.NewFormItem("Send bell", sendBell),
-		widget.NewFormItem("Messages", messages),
+		widget.NewFormItem(i18n.T("Node ID"), nodeID),
+		widget.NewFormItem(i18n.T("Rotary 1 enabled"), rotary1Enabled),
+		widget.NewFormItem(i18n.T("Input broker pin A"), pinA),
+		widget.NewFormItem(i18n.T("Input broker pin B"), pinB),
+		widget.NewFormItem(i18n.T("Input broker pin press"), pinPress),
+		widget.NewFormItem(i18n.T("Input broker event CW"), eventCW),
+		widget.NewFormItem(i18n.T("Input broker event CCW"), eventCCW),
+		widget.NewFormItem(i18n.T("Input broker event press"), eventPress),
+		widget.NewFormItem(i18n.T("Up/down 1 enabled"), upDown1Enabled),
+		widget.NewFormItem(i18n.T("Enabled"), enabled),
+		widget.NewFormItem(i18n.T("Allow input source"), allowInputSource),
+		widget.NewFormItem(i18n.T("Send bell"), sendBell),
+		widget.NewFormItem(i18n.T("Messages"), messages),
 	)
 
 	return nodeManagedSettingsForm[app.NodeCannedMessageSettings]{
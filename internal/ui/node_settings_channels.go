@@ -18,6 +18,7 @@ import (
 
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/i18n"
 	"github.com/skobkin/meshgo/internal/resources"
 )
 
@@ -35,23 +36,23 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 	const pageID = nodeChannelsSettingsPageID
 	nodeSettingsTabLogger.Debug("building node channels settings page", "page_id", pageID, "service_configured", dep.Actions.NodeSettings != nil)
 
-	controls := newNodeSettingsPageControls("Loading channel settings…")
+	controls := newNodeSettingsPageControls(i18n.T("Loading channel settings…"))
 	saveButton := controls.saveButton
 	cancelButton := controls.cancelButton
 	reloadButton := controls.reloadButton
 
-	nodeIDLabel := widget.NewLabel("unknown")
+	nodeIDLabel := widget.NewLabel(i18n.T("unknown"))
 	nodeIDLabel.TextStyle = fyne.TextStyle{Monospace: true}
-	summaryLabel := widget.NewLabel("No channels loaded")
-	statusHint := widget.NewLabel("Reorder, add, edit, and delete channels locally, then click Save to upload to the device.")
+	summaryLabel := widget.NewLabel(i18n.T("No channels loaded"))
+	statusHint := widget.NewLabel(i18n.T("Reorder, add, edit, and delete channels locally, then click Save to upload to the device."))
 	statusHint.Wrapping = fyne.TextWrapWord
 	rows := container.NewVBox()
-	addButton := widget.NewButtonWithIcon("Add channel", theme.ContentAddIcon(), nil)
-	clearButton := widget.NewButtonWithIcon("Clear", theme.DeleteIcon(), nil)
+	addButton := widget.NewButtonWithIcon(i18n.T("Add channel"), theme.ContentAddIcon(), nil)
+	clearButton := widget.NewButtonWithIcon(i18n.T("Clear"), theme.DeleteIcon(), nil)
 
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeIDLabel),
-		widget.NewFormItem("Channels", summaryLabel),
+		widget.NewFormItem(i18n.T("Node ID"), nodeIDLabel),
+		widget.NewFormItem(i18n.T("Channels"), summaryLabel),
 	)
 
 	var (
@@ -79,7 +80,7 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		slots := maxSlots
 		mu.Unlock()
 
-		summaryLabel.SetText(fmt.Sprintf("%d of %d channels configured", count, slots))
+		summaryLabel.SetText(i18n.Tf("%d of %d channels configured", count, slots))
 	}
 
 	var refreshDraft func([]app.NodeChannelSettings)
@@ -140,7 +141,7 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 					current := cloneNodeChannelSettingsEntry(draft[rowIndex])
 					mu.Unlock()
 
-					showNodeChannelEditDialog(dep, "Edit channel", current, func(updated app.NodeChannelSettings) {
+					showNodeChannelEditDialog(dep, i18n.T("Edit channel"), current, func(updated app.NodeChannelSettings) {
 						mu.Lock()
 						if rowIndex < 0 || rowIndex >= len(draft) {
 							mu.Unlock()
@@ -237,18 +238,18 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node channels settings reload failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Reload failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Reload failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node channels settings reload unavailable: service is not configured", "page_id", pageID)
-			controls.SetStatus("Reload is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Reload is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
-			controls.SetStatus("Reload from device is unavailable while disconnected.", 0, 2)
+			controls.SetStatus(i18n.T("Reload from device is unavailable while disconnected."), 0, 2)
 			updateButtonsForNodeChannels(
 				dep,
 				saveGate,
@@ -269,7 +270,7 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		}
 
 		if setStatus {
-			controls.SetStatus("Reloading channel settings from device…", 1, 2)
+			controls.SetStatus(i18n.T("Reloading channel settings from device…"), 1, 2)
 		}
 
 		go func() {
@@ -291,7 +292,7 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 			fyne.Do(func() {
 				if err != nil {
 					if setStatus {
-						controls.SetStatus("Reload failed: "+err.Error(), 0, 2)
+						controls.SetStatus(i18n.Tf("Reload failed: %s", err), 0, 2)
 					}
 					updateButtonsForNodeChannels(
 						dep,
@@ -313,7 +314,7 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 				}
 				applyLoadedSettings(loaded, displayPresetTitle, preserveDraft)
 				if setStatus {
-					controls.SetStatus("Reloaded channel settings from device.", 2, 2)
+					controls.SetStatus(i18n.T("Reloaded channel settings from device."), 2, 2)
 				}
 			})
 		}()
@@ -336,14 +337,14 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		}
 		if len(draft) >= maxSlots {
 			mu.Unlock()
-			controls.SetStatus(fmt.Sprintf("No free channel slots left (%d max).", maxSlots), 0, 1)
+			controls.SetStatus(i18n.Tf("No free channel slots left (%d max).", maxSlots), 0, 1)
 
 			return
 		}
 		initial := defaultNodeChannelSettings()
 		mu.Unlock()
 
-		showNodeChannelEditDialog(dep, "Add channel", initial, func(next app.NodeChannelSettings) {
+		showNodeChannelEditDialog(dep, i18n.T("Add channel"), initial, func(next app.NodeChannelSettings) {
 			mu.Lock()
 			if saving || len(draft) >= maxSlots {
 				mu.Unlock()
@@ -368,7 +369,7 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		nextDraft := cloneNodeChannelSettings(draft)
 		mu.Unlock()
 		refreshDraft(nextDraft)
-		controls.SetStatus("Cleared local channel list.", 1, 1)
+		controls.SetStatus(i18n.T("Cleared local channel list."), 1, 1)
 	}
 
 	cancelButton.OnTapped = func() {
@@ -378,7 +379,7 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		nextDraft := cloneNodeChannelSettings(draft)
 		mu.Unlock()
 		applyForm(nextDraft)
-		controls.SetStatus("Local edits reverted.", 1, 1)
+		controls.SetStatus(i18n.T("Local edits reverted."), 1, 1)
 		updateButtonsForNodeChannels(
 			dep,
 			saveGate,
@@ -398,12 +399,12 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 
 	saveButton.OnTapped = func() {
 		if dep.Actions.NodeSettings == nil {
-			controls.SetStatus("Save is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
-			controls.SetStatus("Save is unavailable while disconnected.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable while disconnected."), 0, 1)
 			updateButtonsForNodeChannels(
 				dep,
 				saveGate,
@@ -424,12 +425,12 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		}
 		target, ok := localTarget()
 		if !ok {
-			controls.SetStatus("Save failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Save failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if saveGate != nil && !saveGate.TryAcquire(pageID) {
-			controls.SetStatus("Another settings save is in progress on a different page.", 0, 1)
+			controls.SetStatus(i18n.T("Another settings save is in progress on a different page."), 0, 1)
 			updateButtonsForNodeChannels(
 				dep,
 				saveGate,
@@ -457,7 +458,7 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		}
 		saving = true
 		mu.Unlock()
-		controls.SetStatus("Saving channel settings…", 1, 3)
+		controls.SetStatus(i18n.T("Saving channel settings…"), 1, 3)
 		updateButtonsForNodeChannels(
 			dep,
 			saveGate,
@@ -486,7 +487,7 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 				}
 				if err != nil {
 					mu.Unlock()
-					controls.SetStatus("Save failed: "+err.Error(), 0, 3)
+					controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 3)
 					showErrorModal(dep, fmt.Errorf("channel upload failed: %w", err))
 					reloadFromDevice(false, true)
 					updateButtonsForNodeChannels(
@@ -513,7 +514,7 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 				nextDraft := cloneNodeChannelSettings(draft)
 				mu.Unlock()
 				applyForm(nextDraft)
-				controls.SetStatus("Saved channel settings.", 3, 3)
+				controls.SetStatus(i18n.T("Saved channel settings."), 3, 3)
 				updateButtonsForNodeChannels(
 					dep,
 					saveGate,
@@ -543,9 +544,9 @@ func newNodeChannelsSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 	}
 	applyLoadedSettings(initial, nodeChannelsFallbackTitle, false)
 	if dep.Actions.NodeSettings == nil {
-		controls.SetStatus("Channel settings are unavailable: node settings service is not configured.", 0, 1)
+		controls.SetStatus(i18n.T("Channel settings are unavailable: node settings service is not configured."), 0, 1)
 	} else {
-		controls.SetStatus("Channel settings will load when this tab is opened.", 0, 1)
+		controls.SetStatus(i18n.T("Channel settings will load when this tab is opened."), 0, 1)
 	}
 
 	if dep.Data.Bus != nil {
@@ -784,7 +785,7 @@ func showNodeChannelEditDialog(
 	pskEntry := widget.NewEntry()
 	pskEntry.Password = true
 	pskEntry.SetText(encodeNodeSettingsKeyBase64(initial.PSK))
-	pskCopyButton := widget.NewButton("Copy", nil)
+	pskCopyButton := widget.NewButton(i18n.T("Copy"), nil)
 	uplinkBox := widget.NewCheck("", nil)
 	uplinkBox.SetChecked(initial.UplinkEnabled)
 	downlinkBox := widget.NewCheck("", nil)
@@ -811,26 +812,26 @@ func showNodeChannelEditDialog(
 	}
 
 	form := widget.NewForm(
-		widget.NewFormItem("Name", nameEntry),
-		widget.NewFormItem("PSK (base64)", pskField),
-		widget.NewFormItem("Uplink", uplinkBox),
-		widget.NewFormItem("Downlink", downlinkBox),
-		widget.NewFormItem("Muted", muteBox),
-		widget.NewFormItem("Position precision", positionSelect),
+		widget.NewFormItem(i18n.T("Name"), nameEntry),
+		widget.NewFormItem(i18n.T("PSK (base64)"), pskField),
+		widget.NewFormItem(i18n.T("Uplink"), uplinkBox),
+		widget.NewFormItem(i18n.T("Downlink"), downlinkBox),
+		widget.NewFormItem(i18n.T("Muted"), muteBox),
+		widget.NewFormItem(i18n.T("Position precision"), positionSelect),
 	)
-	hint := widget.NewLabel("Name max 11 bytes. PSK must decode to 0, 1, 16, or 32 bytes.")
+	hint := widget.NewLabel(i18n.T("Name max 11 bytes. PSK must decode to 0, 1, 16, or 32 bytes."))
 	hint.Wrapping = fyne.TextWrapWord
 
 	var modal dialog.Dialog
-	cancelButton := widget.NewButton("Cancel", func() {
+	cancelButton := widget.NewButton(i18n.T("Cancel"), func() {
 		if modal != nil {
 			modal.Hide()
 		}
 	})
-	saveButton := widget.NewButton("Save", func() {
+	saveButton := widget.NewButton(i18n.T("Save"), func() {
 		next, err := buildNodeChannelFromForm(nameEntry.Text, pskEntry.Text, uplinkBox.Checked, downlinkBox.Checked, muteBox.Checked, positionSelect.Selected)
 		if err != nil {
-			errorLabel.SetText("Validation failed: " + err.Error())
+			errorLabel.SetText(i18n.Tf("Validation failed: %s", err))
 
 			return
 		}
@@ -846,11 +847,11 @@ func showNodeChannelEditDialog(
 	}
 	pskCopyButton.OnTapped = func() {
 		if err := copyTextToClipboard(pskEntry.Text); err != nil {
-			errorLabel.SetText("Copy failed: " + err.Error())
+			errorLabel.SetText(i18n.Tf("Copy failed: %s", err))
 
 			return
 		}
-		errorLabel.SetText("PSK copied.")
+		errorLabel.SetText(i18n.T("PSK copied."))
 	}
 	updatePSKCopyButton()
 	buttons := container.NewHBox(layout.NewSpacer(), cancelButton, saveButton)
@@ -945,9 +946,9 @@ func nodeChannelPositionPrecisionLabels() []string {
 func nodeChannelPositionPrecisionLabel(value uint32) string {
 	switch value {
 	case 0:
-		return "Disabled"
+		return i18n.T("Disabled")
 	case 32:
-		return "Precise"
+		return i18n.T("Precise")
 	default:
 		return fmt.Sprintf("~ %s", nodeChannelDistanceLabel(nodeChannelPrecisionBitsToMeters(value)))
 	}
@@ -964,10 +965,10 @@ func nodeChannelDisplayTitle(channel app.NodeChannelSettings, index int, presetT
 	}
 
 	if index == 0 {
-		return "Primary channel"
+		return i18n.T("Primary channel")
 	}
 
-	return fmt.Sprintf("Channel %d", index+1)
+	return i18n.Tf("Channel %d", index+1)
 }
 
 func nodeChannelPrecisionBitsToMeters(bits uint32) float64 {
@@ -1055,12 +1056,7 @@ func defaultNodeChannelSettings() app.NodeChannelSettings {
 }
 
 func currentThemeVariant() fyne.ThemeVariant {
-	currentApp := fyne.CurrentApp()
-	if currentApp == nil || currentApp.Settings() == nil {
-		return theme.VariantDark
-	}
-
-	return currentApp.Settings().ThemeVariant()
+	return appThemeVariant(fyne.CurrentApp())
 }
 
 func containsString(values []string, candidate string) bool {
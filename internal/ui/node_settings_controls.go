@@ -7,6 +7,8 @@ import (
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 type nodeSettingsPageControls struct {
@@ -25,9 +27,9 @@ func newNodeSettingsPageControls(initialStatus string) *nodeSettingsPageControls
 	progress := widget.NewProgressBar()
 	progress.SetValue(0)
 
-	saveButton := widget.NewButton("Save", nil)
-	cancelButton := widget.NewButton("Cancel", nil)
-	reloadButton := widget.NewButton("Reload", nil)
+	saveButton := widget.NewButton(i18n.T("Save"), nil)
+	cancelButton := widget.NewButton(i18n.T("Cancel"), nil)
+	reloadButton := widget.NewButton(i18n.T("Reload"), nil)
 
 	buttons := container.NewHBox(reloadButton, layout.NewSpacer(), cancelButton, saveButton)
 	root := container.NewVBox(
@@ -10,11 +10,12 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodeDetectionSensorSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) (fyne.CanvasObject, func()) {
 	return newManagedNodeSettingsPage(
-		dep, saveGate, "module.detection_sensor", "Loading detection sensor settings…", "Detection sensor settings loaded.",
+		dep, saveGate, "module.detection_sensor", i18n.T("Loading detection sensor settings…"), i18n.T("Detection sensor settings loaded."),
 		func(ctx context.Context, target app.NodeSettingsTarget) (app.NodeDetectionSensorSettings, error) {
 			return dep.Actions.NodeSettings.LoadDetectionSensorSettings(ctx, target)
 		},
@@ -30,7 +31,7 @@ func newNodeDetectionSensorSettingsPage(dep RuntimeDependencies, saveGate *nodeS
 }
 
 func buildNodeDetectionSensorSettingsForm(onChanged func()) nodeManagedSettingsForm[app.NodeDetectionSensorSettings] {
-	nodeID := widget.NewLabel("unknown")
+	nodeID := widget.NewLabel(i18n.T("unknown"))
 	nodeID.TextStyle = fyne.TextStyle{Monospace: true}
 	enabled := newSettingsCheck(onChanged)
 	minimumBroadcast := widget.NewSelect(nil, nil)
@@ -45,15 +46,15 @@ func buildNodeDetectionSensorSettingsForm(onChanged func()) nodeManagedSettingsF
 	triggerType.OnChanged = func(string) { onChanged() }
 	usePullup := newSettingsCheck(onChanged)
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeID),
-		widget.NewFormItem("Enabled", enabled),
-		widget.NewFormItem("Minimum broadcast secs", minimumBroadcast),
-		widget.NewFormItem("State broadcast secs", stateBroadcast),
-		widget.NewFormItem("Send bell", sendBell),
-		widget.NewFormItem("Name", name),
-		widget.NewFormItem("Monitor pin", monitorPin),
-		widget.NewFormItem("Detection trigger type", triggerType),
-		widget.NewFormItem("Use pullup", usePullup),
+		widget.NewFormItem(i18n.T("Node ID"), nodeID),
+		widget.NewFormItem(i18n.T("Enabled"), enabled),
+		widget.NewFormItem(i18n.T("Minimum broadcast secs"), minimumBroadcast),
+		widget.NewFormItem(i18n.T("State broadcast secs"), stateBroadcast),
+		widget.NewFormItem(i18n.T("Send bell"), sendBell),
+		widget.NewFormItem(i18n.T("Name"), name),
+		widget.NewFormItem(i18n.T("Monitor pin"), monitorPin),
+		widget.NewFormItem(i18n.T("Detection trigger type"), triggerType),
+		widget.NewFormItem(i18n.T("Use pullup"), usePullup),
 	)
 
 	return nodeManagedSettingsForm[app.NodeDetectionSensorSettings]{
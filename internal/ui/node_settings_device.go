@@ -14,6 +14,7 @@ import (
 
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/i18n"
 	generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
 )
 
@@ -35,12 +36,12 @@ func newNodeDeviceSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSa
 	const pageID = "device.device"
 	nodeSettingsTabLogger.Debug("building node device settings page", "page_id", pageID, "service_configured", dep.Actions.NodeSettings != nil)
 
-	controls := newNodeSettingsPageControls("Loading device settings…")
+	controls := newNodeSettingsPageControls(i18n.T("Loading device settings…"))
 	saveButton := controls.saveButton
 	cancelButton := controls.cancelButton
 	reloadButton := controls.reloadButton
 
-	nodeIDLabel := widget.NewLabel("unknown")
+	nodeIDLabel := widget.NewLabel(i18n.T("unknown"))
 	nodeIDLabel.TextStyle = fyne.TextStyle{Monospace: true}
 
 	roleSelect := widget.NewSelect(nil, nil)
@@ -55,17 +56,17 @@ func newNodeDeviceSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSa
 	ledHeartbeatDisabledBox := widget.NewCheck("", nil)
 
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeIDLabel),
-		widget.NewFormItem("Role", roleSelect),
-		widget.NewFormItem("Rebroadcast mode", rebroadcastModeSelect),
-		widget.NewFormItem("Node info broadcast interval", nodeInfoBroadcastSecsSelect),
-		widget.NewFormItem("Button GPIO", buttonGPIOEntry),
-		widget.NewFormItem("Buzzer GPIO", buzzerGPIOEntry),
-		widget.NewFormItem("Timezone (POSIX TZDEF)", tzdefEntry),
-		widget.NewFormItem("Double tap as button press", doubleTapAsButtonPressBox),
-		widget.NewFormItem("Disable triple-click shortcut", disableTripleClickBox),
-		widget.NewFormItem("Disable LED heartbeat", ledHeartbeatDisabledBox),
-		widget.NewFormItem("Buzzer mode", buzzerModeSelect),
+		widget.NewFormItem(i18n.T("Node ID"), nodeIDLabel),
+		widget.NewFormItem(i18n.T("Role"), roleSelect),
+		widget.NewFormItem(i18n.T("Rebroadcast mode"), rebroadcastModeSelect),
+		widget.NewFormItem(i18n.T("Node info broadcast interval"), nodeInfoBroadcastSecsSelect),
+		widget.NewFormItem(i18n.T("Button GPIO"), buttonGPIOEntry),
+		widget.NewFormItem(i18n.T("Buzzer GPIO"), buzzerGPIOEntry),
+		widget.NewFormItem(i18n.T("Timezone (POSIX TZDEF)"), tzdefEntry),
+		widget.NewFormItem(i18n.T("Double tap as button press"), doubleTapAsButtonPressBox),
+		widget.NewFormItem(i18n.T("Disable triple-click shortcut"), disableTripleClickBox),
+		widget.NewFormItem(i18n.T("Disable LED heartbeat"), ledHeartbeatDisabledBox),
+		widget.NewFormItem(i18n.T("Buzzer mode"), buzzerModeSelect),
 	)
 
 	var (
@@ -220,25 +221,25 @@ func newNodeDeviceSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSa
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node device settings reload failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Reload failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Reload failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node device settings reload unavailable: service is not configured", "page_id", pageID)
-			controls.SetStatus("Reload is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Reload is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node device settings reload blocked: device is disconnected", "page_id", pageID, "node_id", target.NodeID)
-			controls.SetStatus("Reload from device is unavailable while disconnected.", 0, 2)
+			controls.SetStatus(i18n.T("Reload from device is unavailable while disconnected."), 0, 2)
 
 			return
 		}
 
 		nodeSettingsTabLogger.Info("reloading node device settings from device", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Reloading device settings from device…", 1, 2)
+		controls.SetStatus(i18n.T("Reloading device settings from device…"), 1, 2)
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), nodeSettingsOpTimeout)
 			defer cancel()
@@ -246,14 +247,14 @@ func newNodeDeviceSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSa
 			fyne.Do(func() {
 				if err != nil {
 					nodeSettingsTabLogger.Warn("reloading node device settings from device failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Reload failed: "+err.Error(), 0, 2)
+					controls.SetStatus(i18n.Tf("Reload failed: %s", err), 0, 2)
 					updateButtons()
 
 					return
 				}
 				applyLoadedSettings(loaded)
 				nodeSettingsTabLogger.Info("reloaded node device settings from device", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Reloaded device settings from device.", 2, 2)
+				controls.SetStatus(i18n.T("Reloaded device settings from device."), 2, 2)
 			})
 		}()
 	}
@@ -276,7 +277,7 @@ func newNodeDeviceSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSa
 		dirty = false
 		mu.Unlock()
 		applyForm(settings)
-		controls.SetStatus("Local edits reverted.", 1, 1)
+		controls.SetStatus(i18n.T("Local edits reverted."), 1, 1)
 		updateButtons()
 	}
 
@@ -284,13 +285,13 @@ func newNodeDeviceSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSa
 		nodeSettingsTabLogger.Info("node device settings save requested", "page_id", pageID)
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node device settings save unavailable: service is not configured")
-			controls.SetStatus("Save is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node device settings save blocked: device is disconnected", "page_id", pageID)
-			controls.SetStatus("Save is unavailable while disconnected.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable while disconnected."), 0, 1)
 			updateButtons()
 
 			return
@@ -298,13 +299,13 @@ func newNodeDeviceSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSa
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node device settings save failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Save failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Save failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if saveGate != nil && !saveGate.TryAcquire(pageID) {
 			nodeSettingsTabLogger.Info("node device settings save blocked: another page save is active", "page_id", pageID, "active_page", saveGate.ActivePage())
-			controls.SetStatus("Another settings save is in progress on a different page.", 0, 1)
+			controls.SetStatus(i18n.T("Another settings save is in progress on a different page."), 0, 1)
 			updateButtons()
 
 			return
@@ -316,7 +317,7 @@ func newNodeDeviceSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSa
 				saveGate.Release(pageID)
 			}
 			nodeSettingsTabLogger.Warn("node device settings save failed: invalid form values", "page_id", pageID, "error", err)
-			controls.SetStatus("Save failed: "+err.Error(), 0, 1)
+			controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 1)
 			updateButtons()
 
 			return
@@ -326,7 +327,7 @@ func newNodeDeviceSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSa
 		saving = true
 		mu.Unlock()
 		nodeSettingsTabLogger.Info("saving node device settings", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Saving device settings…", 1, 3)
+		controls.SetStatus(i18n.T("Saving device settings…"), 1, 3)
 		updateButtons()
 
 		go func(settings app.NodeDeviceSettings) {
@@ -341,7 +342,7 @@ func newNodeDeviceSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSa
 				}
 				if err != nil {
 					nodeSettingsTabLogger.Warn("saving node device settings failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Save failed: "+err.Error(), 0, 3)
+					controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 3)
 					mu.Unlock()
 					updateButtons()
 
@@ -355,7 +356,7 @@ func newNodeDeviceSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSa
 
 				applyForm(applied)
 				nodeSettingsTabLogger.Info("saved node device settings", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Saved device settings.", 3, 3)
+				controls.SetStatus(i18n.T("Saved device settings."), 3, 3)
 				updateButtons()
 			})
 		}(next)
@@ -376,9 +377,9 @@ func newNodeDeviceSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSa
 	}
 	applyLoadedSettings(initial)
 	if dep.Actions.NodeSettings == nil {
-		controls.SetStatus("Device settings are unavailable: node settings service is not configured.", 0, 1)
+		controls.SetStatus(i18n.T("Device settings are unavailable: node settings service is not configured."), 0, 1)
 	} else {
-		controls.SetStatus("Device settings will load when this tab is opened.", 0, 1)
+		controls.SetStatus(i18n.T("Device settings will load when this tab is opened."), 0, 1)
 	}
 
 	if dep.Data.Bus != nil {
@@ -396,7 +397,7 @@ func newNodeDeviceSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSa
 	updateButtons()
 
 	content := container.NewVBox(
-		widget.NewLabel("Device settings are loaded from and saved to the connected local node."),
+		widget.NewLabel(i18n.T("Device settings are loaded from and saved to the connected local node.")),
 		form,
 	)
 
@@ -548,7 +549,7 @@ func nodeDeviceSetEnumSelect(selectWidget *widget.Select, options []nodeDeviceEn
 func nodeDeviceEnumLabel(value int32, options []nodeDeviceEnumOption) string {
 	for _, option := range options {
 		if option.Value == value {
-			return option.Label
+			return i18n.T(option.Label)
 		}
 	}
 
@@ -558,7 +559,7 @@ func nodeDeviceEnumLabel(value int32, options []nodeDeviceEnumOption) string {
 func nodeDeviceEnumOptionsLabels(options []nodeDeviceEnumOption) []string {
 	out := make([]string, 0, len(options))
 	for _, option := range options {
-		out = append(out, option.Label)
+		out = append(out, i18n.T(option.Label))
 	}
 
 	return out
@@ -574,7 +575,7 @@ func nodeDeviceParseEnumLabel(fieldName, selected string, options []nodeDeviceEn
 		return 0, fmt.Errorf("%s must be selected", fieldName)
 	}
 	for _, option := range options {
-		if option.Label == selected {
+		if i18n.T(option.Label) == selected {
 			return option.Value, nil
 		}
 	}
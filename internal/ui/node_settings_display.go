@@ -15,6 +15,7 @@ import (
 
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/i18n"
 	generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
 )
 
@@ -22,12 +23,12 @@ func newNodeDisplaySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 	const pageID = "device.display"
 	nodeSettingsTabLogger.Debug("building node display settings page", "page_id", pageID, "service_configured", dep.Actions.NodeSettings != nil)
 
-	controls := newNodeSettingsPageControls("Loading display settings…")
+	controls := newNodeSettingsPageControls(i18n.T("Loading display settings…"))
 	saveButton := controls.saveButton
 	cancelButton := controls.cancelButton
 	reloadButton := controls.reloadButton
 
-	nodeIDLabel := widget.NewLabel("unknown")
+	nodeIDLabel := widget.NewLabel(i18n.T("unknown"))
 	nodeIDLabel.TextStyle = fyne.TextStyle{Monospace: true}
 
 	compassNorthTopBox := widget.NewCheck("", nil)
@@ -43,18 +44,18 @@ func newNodeDisplaySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 	compassOrientationSelect := widget.NewSelect(nil, nil)
 
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeIDLabel),
-		widget.NewFormItem("Always point north", compassNorthTopBox),
-		widget.NewFormItem("Use 12-hour time format", use12HClockBox),
-		widget.NewFormItem("Bold heading", headingBoldBox),
-		widget.NewFormItem("Display units", unitsSelect),
-		widget.NewFormItem("Screen on duration", screenOnSecsSelect),
-		widget.NewFormItem("Carousel duration", carouselSecsSelect),
-		widget.NewFormItem("Wake on tap or motion", wakeOnTapOrMotionBox),
-		widget.NewFormItem("Flip screen", flipScreenBox),
-		widget.NewFormItem("Display mode", displayModeSelect),
-		widget.NewFormItem("OLED type", oledTypeSelect),
-		widget.NewFormItem("Compass orientation", compassOrientationSelect),
+		widget.NewFormItem(i18n.T("Node ID"), nodeIDLabel),
+		widget.NewFormItem(i18n.T("Always point north"), compassNorthTopBox),
+		widget.NewFormItem(i18n.T("Use 12-hour time format"), use12HClockBox),
+		widget.NewFormItem(i18n.T("Bold heading"), headingBoldBox),
+		widget.NewFormItem(i18n.T("Display units"), unitsSelect),
+		widget.NewFormItem(i18n.T("Screen on duration"), screenOnSecsSelect),
+		widget.NewFormItem(i18n.T("Carousel duration"), carouselSecsSelect),
+		widget.NewFormItem(i18n.T("Wake on tap or motion"), wakeOnTapOrMotionBox),
+		widget.NewFormItem(i18n.T("Flip screen"), flipScreenBox),
+		widget.NewFormItem(i18n.T("Display mode"), displayModeSelect),
+		widget.NewFormItem(i18n.T("OLED type"), oledTypeSelect),
+		widget.NewFormItem(i18n.T("Compass orientation"), compassOrientationSelect),
 	)
 
 	var (
@@ -222,25 +223,25 @@ func newNodeDisplaySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node display settings reload failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Reload failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Reload failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node display settings reload unavailable: service is not configured", "page_id", pageID)
-			controls.SetStatus("Reload is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Reload is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node display settings reload blocked: device is disconnected", "page_id", pageID, "node_id", target.NodeID)
-			controls.SetStatus("Reload from device is unavailable while disconnected.", 0, 2)
+			controls.SetStatus(i18n.T("Reload from device is unavailable while disconnected."), 0, 2)
 
 			return
 		}
 
 		nodeSettingsTabLogger.Info("reloading node display settings from device", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Reloading display settings from device…", 1, 2)
+		controls.SetStatus(i18n.T("Reloading display settings from device…"), 1, 2)
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), nodeSettingsOpTimeout)
 			defer cancel()
@@ -248,14 +249,14 @@ func newNodeDisplaySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 			fyne.Do(func() {
 				if err != nil {
 					nodeSettingsTabLogger.Warn("reloading node display settings from device failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Reload failed: "+err.Error(), 0, 2)
+					controls.SetStatus(i18n.Tf("Reload failed: %s", err), 0, 2)
 					updateButtons()
 
 					return
 				}
 				applyLoadedSettings(loaded)
 				nodeSettingsTabLogger.Info("reloaded node display settings from device", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Reloaded display settings from device.", 2, 2)
+				controls.SetStatus(i18n.T("Reloaded display settings from device."), 2, 2)
 			})
 		}()
 	}
@@ -279,7 +280,7 @@ func newNodeDisplaySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 		dirty = false
 		mu.Unlock()
 		applyForm(settings)
-		controls.SetStatus("Local edits reverted.", 1, 1)
+		controls.SetStatus(i18n.T("Local edits reverted."), 1, 1)
 		updateButtons()
 	}
 
@@ -287,13 +288,13 @@ func newNodeDisplaySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 		nodeSettingsTabLogger.Info("node display settings save requested", "page_id", pageID)
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node display settings save unavailable: service is not configured")
-			controls.SetStatus("Save is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node display settings save blocked: device is disconnected", "page_id", pageID)
-			controls.SetStatus("Save is unavailable while disconnected.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable while disconnected."), 0, 1)
 			updateButtons()
 
 			return
@@ -301,13 +302,13 @@ func newNodeDisplaySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node display settings save failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Save failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Save failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if saveGate != nil && !saveGate.TryAcquire(pageID) {
 			nodeSettingsTabLogger.Info("node display settings save blocked: another page save is active", "page_id", pageID, "active_page", saveGate.ActivePage())
-			controls.SetStatus("Another settings save is in progress on a different page.", 0, 1)
+			controls.SetStatus(i18n.T("Another settings save is in progress on a different page."), 0, 1)
 			updateButtons()
 
 			return
@@ -319,7 +320,7 @@ func newNodeDisplaySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 				saveGate.Release(pageID)
 			}
 			nodeSettingsTabLogger.Warn("node display settings save failed: invalid form values", "page_id", pageID, "error", err)
-			controls.SetStatus("Save failed: "+err.Error(), 0, 1)
+			controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 1)
 			updateButtons()
 
 			return
@@ -329,7 +330,7 @@ func newNodeDisplaySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 		saving = true
 		mu.Unlock()
 		nodeSettingsTabLogger.Info("saving node display settings", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Saving display settings…", 1, 3)
+		controls.SetStatus(i18n.T("Saving display settings…"), 1, 3)
 		updateButtons()
 
 		go func(settings app.NodeDisplaySettings) {
@@ -344,7 +345,7 @@ func newNodeDisplaySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 				}
 				if err != nil {
 					nodeSettingsTabLogger.Warn("saving node display settings failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Save failed: "+err.Error(), 0, 3)
+					controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 3)
 					mu.Unlock()
 					updateButtons()
 
@@ -358,7 +359,7 @@ func newNodeDisplaySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 
 				applyForm(applied)
 				nodeSettingsTabLogger.Info("saved node display settings", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Saved display settings.", 3, 3)
+				controls.SetStatus(i18n.T("Saved display settings."), 3, 3)
 				updateButtons()
 			})
 		}(next)
@@ -380,9 +381,9 @@ func newNodeDisplaySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 	}
 	applyLoadedSettings(initial)
 	if dep.Actions.NodeSettings == nil {
-		controls.SetStatus("Display settings are unavailable: node settings service is not configured.", 0, 1)
+		controls.SetStatus(i18n.T("Display settings are unavailable: node settings service is not configured."), 0, 1)
 	} else {
-		controls.SetStatus("Display settings will load when this tab is opened.", 0, 1)
+		controls.SetStatus(i18n.T("Display settings will load when this tab is opened."), 0, 1)
 	}
 
 	if dep.Data.Bus != nil {
@@ -400,7 +401,7 @@ func newNodeDisplaySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 	updateButtons()
 
 	content := container.NewVBox(
-		widget.NewLabel("Display settings are loaded from and saved to the connected local node."),
+		widget.NewLabel(i18n.T("Display settings are loaded from and saved to the connected local node.")),
 		form,
 	)
 
@@ -513,7 +514,7 @@ func cloneNodeDisplaySettings(settings app.NodeDisplaySettings) app.NodeDisplayS
 
 func nodeDisplayScreenOnCustomLabel(value uint32) string {
 	if value == math.MaxInt32 {
-		return "Always on"
+		return i18n.T("Always on")
 	}
 
 	return nodeSettingsCustomSecondsLabel(value)
@@ -530,7 +531,7 @@ func nodeDisplayScreenOnLabel(value uint32) string {
 
 func nodeDisplayParseScreenOnSelectLabel(selected string) (uint32, error) {
 	selected = strings.TrimSpace(selected)
-	if strings.EqualFold(selected, "Always on") {
+	if strings.EqualFold(selected, i18n.T("Always on")) {
 		return math.MaxInt32, nil
 	}
 
@@ -539,7 +540,7 @@ func nodeDisplayParseScreenOnSelectLabel(selected string) (uint32, error) {
 
 func nodeDisplayCarouselCustomLabel(value uint32) string {
 	if value == 0 {
-		return "Unset"
+		return i18n.T("Unset")
 	}
 
 	return nodeSettingsCustomSecondsLabel(value)
@@ -556,7 +557,7 @@ func nodeDisplayCarouselLabel(value uint32) string {
 
 func nodeDisplayParseCarouselSelectLabel(selected string) (uint32, error) {
 	selected = strings.TrimSpace(selected)
-	if strings.EqualFold(selected, "Unset") {
+	if strings.EqualFold(selected, i18n.T("Unset")) {
 		return 0, nil
 	}
 
@@ -576,7 +577,7 @@ func nodeDisplaySetEnumSelect(selectWidget *widget.Select, options []nodeDisplay
 func nodeDisplayEnumLabel(value int32, options []nodeDisplayEnumOption) string {
 	for _, option := range options {
 		if option.Value == value {
-			return option.Label
+			return i18n.T(option.Label)
 		}
 	}
 
@@ -586,7 +587,7 @@ func nodeDisplayEnumLabel(value int32, options []nodeDisplayEnumOption) string {
 func nodeDisplayEnumOptionsLabels(options []nodeDisplayEnumOption) []string {
 	out := make([]string, 0, len(options))
 	for _, option := range options {
-		out = append(out, option.Label)
+		out = append(out, i18n.T(option.Label))
 	}
 
 	return out
@@ -602,7 +603,7 @@ func nodeDisplayParseEnumLabel(fieldName, selected string, options []nodeDisplay
 		return 0, fmt.Errorf("%s must be selected", fieldName)
 	}
 	for _, option := range options {
-		if option.Label == selected {
+		if i18n.T(option.Label) == selected {
 			return option.Value, nil
 		}
 	}
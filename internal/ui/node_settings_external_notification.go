@@ -11,11 +11,12 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodeExternalNotificationSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) (fyne.CanvasObject, func()) {
 	return newManagedNodeSettingsPage(
-		dep, saveGate, "module.external_notification", "Loading external notification settings…", "External notification settings loaded.",
+		dep, saveGate, "module.external_notification", i18n.T("Loading external notification settings…"), i18n.T("External notification settings loaded."),
 		func(ctx context.Context, target app.NodeSettingsTarget) (app.NodeExternalNotificationSettings, error) {
 			return dep.Actions.NodeSettings.LoadExternalNotificationSettings(ctx, target)
 		},
@@ -34,7 +35,7 @@ func newNodeExternalNotificationSettingsPage(dep RuntimeDependencies, saveGate *
 }
 
 func buildNodeExternalNotificationSettingsForm(onChanged func()) nodeManagedSettingsForm[app.NodeExternalNotificationSettings] {
-	nodeID := widget.NewLabel("unknown")
+	nodeID := widget.NewLabel(i18n.T("unknown"))
 	nodeID.TextStyle = fyne.TextStyle{Monospace: true}
 	enabled := newSettingsCheck(onChanged)
 	outputMS := widget.NewSelect(nil, nil)
@@ -58,33 +59,33 @@ func buildNodeExternalNotificationSettingsForm(onChanged func()) nodeManagedSett
 	useI2S := newSettingsCheck(onChanged)
 
 	configForm := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeID),
-		widget.NewFormItem("External notification enabled", enabled),
+		widget.NewFormItem(i18n.T("Node ID"), nodeID),
+		widget.NewFormItem(i18n.T("External notification enabled"), enabled),
 	)
 	messageForm := widget.NewForm(
-		widget.NewFormItem("Alert message LED", alertMessageLED),
-		widget.NewFormItem("Alert message buzzer", alertMessageBuzzer),
-		widget.NewFormItem("Alert message vibra", alertMessageVibra),
+		widget.NewFormItem(i18n.T("Alert message LED"), alertMessageLED),
+		widget.NewFormItem(i18n.T("Alert message buzzer"), alertMessageBuzzer),
+		widget.NewFormItem(i18n.T("Alert message vibra"), alertMessageVibra),
 	)
 	bellForm := widget.NewForm(
-		widget.NewFormItem("Alert bell LED", alertBellLED),
-		widget.NewFormItem("Alert bell buzzer", alertBellBuzzer),
-		widget.NewFormItem("Alert bell vibra", alertBellVibra),
+		widget.NewFormItem(i18n.T("Alert bell LED"), alertBellLED),
+		widget.NewFormItem(i18n.T("Alert bell buzzer"), alertBellBuzzer),
+		widget.NewFormItem(i18n.T("Alert bell vibra"), alertBellVibra),
 	)
 	activeHighItem := widget.NewForm(
-		widget.NewFormItem("Output LED active high", outputActiveHigh),
+		widget.NewFormItem(i18n.T("Output LED active high"), outputActiveHigh),
 	)
 	pwmItem := widget.NewForm(
-		widget.NewFormItem("Use PWM buzzer", usePWMBuzzer),
+		widget.NewFormItem(i18n.T("Use PWM buzzer"), usePWMBuzzer),
 	)
 	advancedForm := widget.NewForm(
-		widget.NewFormItem("Output LED GPIO", outputGPIO),
-		widget.NewFormItem("Output buzzer GPIO", outputBuzzerGPIO),
-		widget.NewFormItem("Output vibra GPIO", outputVibraGPIO),
-		widget.NewFormItem("Output duration milliseconds", outputMS),
-		widget.NewFormItem("Nag timeout seconds", nagTimeout),
-		widget.NewFormItem("Ringtone", ringtone),
-		widget.NewFormItem("Use I2S as buzzer", useI2S),
+		widget.NewFormItem(i18n.T("Output LED GPIO"), outputGPIO),
+		widget.NewFormItem(i18n.T("Output buzzer GPIO"), outputBuzzerGPIO),
+		widget.NewFormItem(i18n.T("Output vibra GPIO"), outputVibraGPIO),
+		widget.NewFormItem(i18n.T("Output duration milliseconds"), outputMS),
+		widget.NewFormItem(i18n.T("Nag timeout seconds"), nagTimeout),
+		widget.NewFormItem(i18n.T("Ringtone"), ringtone),
+		widget.NewFormItem(i18n.T("Use I2S as buzzer"), useI2S),
 	)
 	updateAdvancedVisibility := func() {
 		if strings.TrimSpace(outputGPIO.Text) != "" && strings.TrimSpace(outputGPIO.Text) != "0" {
@@ -107,10 +108,10 @@ func buildNodeExternalNotificationSettingsForm(onChanged func()) nodeManagedSett
 		onChanged()
 	}
 	content := container.NewVBox(
-		widget.NewCard("External notification config", "", configForm),
-		widget.NewCard("Notifications on message receipt", "", messageForm),
-		widget.NewCard("Notifications on alert bell receipt", "", bellForm),
-		widget.NewCard("Advanced", "", container.NewVBox(
+		widget.NewCard(i18n.T("External notification config"), "", configForm),
+		widget.NewCard(i18n.T("Notifications on message receipt"), "", messageForm),
+		widget.NewCard(i18n.T("Notifications on alert bell receipt"), "", bellForm),
+		widget.NewCard(i18n.T("Advanced"), "", container.NewVBox(
 			advancedForm,
 			activeHighItem,
 			pwmItem,
@@ -14,25 +14,26 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 	generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
 )
 
 const nodeSettingsProfileFileExt = ".cfg"
 
 func newNodeImportExportPage(dep RuntimeDependencies) fyne.CanvasObject {
-	status := widget.NewLabel("Import and export node settings using Android-compatible Meshtastic profile files.")
+	status := widget.NewLabel(i18n.T("Import and export node settings using Android-compatible Meshtastic profile files."))
 	status.Wrapping = fyne.TextWrapWord
-	exportButton := widget.NewButton("Export profile…", nil)
-	importButton := widget.NewButton("Import profile…", nil)
-	keepExistingChannels := widget.NewCheck("Keep existing channels", nil)
+	exportButton := widget.NewButton(i18n.T("Export profile…"), nil)
+	importButton := widget.NewButton(i18n.T("Import profile…"), nil)
+	keepExistingChannels := widget.NewCheck(i18n.T("Keep existing channels"), nil)
 	keepExistingChannels.SetChecked(false)
-	channelHelp := widget.NewLabel("When enabled, channel settings from the profile are ignored.")
+	channelHelp := widget.NewLabel(i18n.T("When enabled, channel settings from the profile are ignored."))
 	channelHelp.Wrapping = fyne.TextWrapWord
 
 	if dep.Actions.NodeSettings == nil {
 		exportButton.Disable()
 		importButton.Disable()
-		status.SetText("Node settings service is unavailable.")
+		status.SetText(i18n.T("Node settings service is unavailable."))
 	}
 
 	exportButton.OnTapped = func() {
@@ -67,7 +68,7 @@ func newNodeImportExportPage(dep RuntimeDependencies) fyne.CanvasObject {
 				profile, exportErr := dep.Actions.NodeSettings.ExportProfile(ctx, target)
 				if exportErr != nil {
 					fyne.Do(func() {
-						status.SetText(fmt.Sprintf("Export failed: %v", exportErr))
+						status.SetText(i18n.Tf("Export failed: %s", exportErr))
 						showErrorModal(dep, exportErr)
 					})
 
@@ -76,7 +77,7 @@ func newNodeImportExportPage(dep RuntimeDependencies) fyne.CanvasObject {
 				raw, exportErr := app.EncodeDeviceProfile(profile)
 				if exportErr != nil {
 					fyne.Do(func() {
-						status.SetText(fmt.Sprintf("Export failed: %v", exportErr))
+						status.SetText(i18n.Tf("Export failed: %s", exportErr))
 						showErrorModal(dep, exportErr)
 					})
 
@@ -84,14 +85,14 @@ func newNodeImportExportPage(dep RuntimeDependencies) fyne.CanvasObject {
 				}
 				if _, exportErr = writer.Write(raw); exportErr != nil {
 					fyne.Do(func() {
-						status.SetText(fmt.Sprintf("Export failed: %v", exportErr))
+						status.SetText(i18n.Tf("Export failed: %s", exportErr))
 						showErrorModal(dep, exportErr)
 					})
 
 					return
 				}
 				fyne.Do(func() {
-					status.SetText(fmt.Sprintf("Exported profile to %s.", writer.URI().Name()))
+					status.SetText(i18n.Tf("Exported profile to %s.", writer.URI().Name()))
 				})
 			}()
 		}, window)
@@ -142,7 +143,7 @@ func newNodeImportExportPage(dep RuntimeDependencies) fyne.CanvasObject {
 				summary := buildDeviceProfileImportSummary(profile, keepChannels)
 				fyne.Do(func() {
 					dialog.ShowConfirm(
-						"Import node settings profile",
+						i18n.T("Import node settings profile"),
 						summary,
 						func(ok bool) {
 							if !ok {
@@ -156,12 +157,12 @@ func newNodeImportExportPage(dep RuntimeDependencies) fyne.CanvasObject {
 								})
 								fyne.Do(func() {
 									if importErr != nil {
-										status.SetText(fmt.Sprintf("Import failed: %v", importErr))
+										status.SetText(i18n.Tf("Import failed: %s", importErr))
 										showErrorModal(dep, importErr)
 
 										return
 									}
-									status.SetText(fmt.Sprintf("Imported profile from %s.", reader.URI().Name()))
+									status.SetText(i18n.Tf("Imported profile from %s.", reader.URI().Name()))
 								})
 							}()
 						},
@@ -175,7 +176,7 @@ func newNodeImportExportPage(dep RuntimeDependencies) fyne.CanvasObject {
 	}
 
 	return container.NewVBox(
-		widget.NewLabel("Node settings profile"),
+		widget.NewLabel(i18n.T("Node settings profile")),
 		status,
 		keepExistingChannels,
 		channelHelp,
@@ -230,7 +231,7 @@ func buildDeviceProfileSummary(profile *generated.DeviceProfile) string {
 
 func buildDeviceProfileImportSummary(profile *generated.DeviceProfile, keepExistingChannels bool) string {
 	if profile == nil {
-		return "The selected file does not contain a Meshtastic device profile."
+		return i18n.T("The selected file does not contain a Meshtastic device profile.")
 	}
 	configCount := 0
 	if cfg := profile.GetConfig(); cfg != nil {
@@ -305,16 +306,16 @@ func buildDeviceProfileImportSummary(profile *generated.DeviceProfile, keepExist
 		}
 	}
 
-	channelSummary := "Channels: not included"
+	channelSummary := i18n.T("Channels: not included")
 	if strings.TrimSpace(profile.GetChannelUrl()) != "" {
 		if keepExistingChannels {
-			channelSummary = "Channels: keep existing (profile channels will be ignored)"
+			channelSummary = i18n.T("Channels: keep existing (profile channels will be ignored)")
 		} else {
-			channelSummary = "Channels: replace from profile\n\nWarning: replacing channels can disrupt mesh communication and remote administration."
+			channelSummary = i18n.T("Channels: replace from profile\n\nWarning: replacing channels can disrupt mesh communication and remote administration.")
 		}
 	}
 
-	return fmt.Sprintf(
+	return i18n.Tf(
 		"Import profile for \"%s\" / \"%s\"?\n\nConfig sections: %d\nModule sections: %d\nFixed position: %t\nRingtone: %t\nCanned messages: %t\n%s",
 		orUnknown(profile.GetLongName()),
 		orUnknown(profile.GetShortName()),
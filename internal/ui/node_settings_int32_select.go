@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 const nodeSettingsCustomInt32LabelPrefix = "Custom ("
@@ -45,7 +47,7 @@ func nodeSettingsParseInt32SelectLabel(
 		return 0, fmt.Errorf("%s must be selected", fieldName)
 	}
 	for _, option := range options {
-		if option.Label == selected {
+		if i18n.T(option.Label) == selected {
 			return option.Value, nil
 		}
 	}
@@ -65,7 +67,7 @@ func nodeSettingsParseInt32SelectLabel(
 func nodeSettingsInt32OptionLabel(value int32, options []nodeSettingsInt32Option) string {
 	for _, option := range options {
 		if option.Value == value {
-			return option.Label
+			return i18n.T(option.Label)
 		}
 	}
 
@@ -75,7 +77,7 @@ func nodeSettingsInt32OptionLabel(value int32, options []nodeSettingsInt32Option
 func nodeSettingsInt32OptionLabels(options []nodeSettingsInt32Option) []string {
 	out := make([]string, 0, len(options))
 	for _, option := range options {
-		out = append(out, option.Label)
+		out = append(out, i18n.T(option.Label))
 	}
 
 	return out
@@ -15,6 +15,7 @@ import (
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/bus"
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 	generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
 )
 
@@ -22,12 +23,12 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 	const pageID = "radio.lora"
 	nodeSettingsTabLogger.Debug("building node LoRa settings page", "page_id", pageID, "service_configured", dep.Actions.NodeSettings != nil)
 
-	controls := newNodeSettingsPageControls("Loading LoRa settings…")
+	controls := newNodeSettingsPageControls(i18n.T("Loading LoRa settings…"))
 	saveButton := controls.saveButton
 	cancelButton := controls.cancelButton
 	reloadButton := controls.reloadButton
 
-	nodeIDLabel := widget.NewLabel("unknown")
+	nodeIDLabel := widget.NewLabel(i18n.T("unknown"))
 	nodeIDLabel.TextStyle = fyne.TextStyle{Monospace: true}
 
 	regionSelect := widget.NewSelect(nil, nil)
@@ -47,15 +48,15 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 	txPowerEntry := widget.NewEntry()
 	paFanDisabledBox := widget.NewCheck("", nil)
 
-	modemPresetItem := widget.NewFormItem("Modem preset", modemPresetSelect)
-	bandwidthItem := widget.NewFormItem("Bandwidth", bandwidthEntry)
-	spreadFactorItem := widget.NewFormItem("Spread factor", spreadFactorEntry)
-	codingRateItem := widget.NewFormItem("Coding rate", codingRateEntry)
+	modemPresetItem := widget.NewFormItem(i18n.T("Modem preset"), modemPresetSelect)
+	bandwidthItem := widget.NewFormItem(i18n.T("Bandwidth"), bandwidthEntry)
+	spreadFactorItem := widget.NewFormItem(i18n.T("Spread factor"), spreadFactorEntry)
+	codingRateItem := widget.NewFormItem(i18n.T("Coding rate"), codingRateEntry)
 
 	basicForm := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeIDLabel),
-		widget.NewFormItem("Region frequency plan", regionSelect),
-		widget.NewFormItem("Use modem preset", useModemPresetBox),
+		widget.NewFormItem(i18n.T("Node ID"), nodeIDLabel),
+		widget.NewFormItem(i18n.T("Region frequency plan"), regionSelect),
+		widget.NewFormItem(i18n.T("Use modem preset"), useModemPresetBox),
 	)
 	presetForm := widget.NewForm(
 		modemPresetItem,
@@ -66,18 +67,18 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		codingRateItem,
 	)
 	advancedForm := widget.NewForm(
-		widget.NewFormItem("Ignore MQTT", ignoreMqttBox),
-		widget.NewFormItem("OK to MQTT", okToMqttBox),
-		widget.NewFormItem("TX enabled", txEnabledBox),
-		widget.NewFormItem("Override duty cycle", overrideDutyCycleBox),
-		widget.NewFormItem("Hop limit", hopLimitSelect),
-		widget.NewFormItem("Frequency slot", frequencySlotEntry),
-		widget.NewFormItem("SX126X RX boosted gain", sx126xRxBoostedGainBox),
-		widget.NewFormItem("Override frequency (MHz)", overrideFrequencyEntry),
-		widget.NewFormItem("TX power (dBm)", txPowerEntry),
+		widget.NewFormItem(i18n.T("Ignore MQTT"), ignoreMqttBox),
+		widget.NewFormItem(i18n.T("OK to MQTT"), okToMqttBox),
+		widget.NewFormItem(i18n.T("TX enabled"), txEnabledBox),
+		widget.NewFormItem(i18n.T("Override duty cycle"), overrideDutyCycleBox),
+		widget.NewFormItem(i18n.T("Hop limit"), hopLimitSelect),
+		widget.NewFormItem(i18n.T("Frequency slot"), frequencySlotEntry),
+		widget.NewFormItem(i18n.T("SX126X RX boosted gain"), sx126xRxBoostedGainBox),
+		widget.NewFormItem(i18n.T("Override frequency (MHz)"), overrideFrequencyEntry),
+		widget.NewFormItem(i18n.T("TX power (dBm)"), txPowerEntry),
 	)
 	paFanForm := widget.NewForm(
-		widget.NewFormItem("PA fan disabled", paFanDisabledBox),
+		widget.NewFormItem(i18n.T("PA fan disabled"), paFanDisabledBox),
 	)
 	formContent := container.NewVBox(
 		basicForm,
@@ -475,7 +476,7 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		if !ok {
 			if reportFailure {
 				nodeSettingsTabLogger.Warn("node LoRa settings reload failed: local node ID is unknown", "page_id", pageID)
-				controls.SetStatus("Reload failed: local node ID is not known yet.", 0, 1)
+				controls.SetStatus(i18n.T("Reload failed: local node ID is not known yet."), 0, 1)
 			}
 
 			return app.NodeSettingsTarget{}, false
@@ -483,7 +484,7 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		if dep.Actions.NodeSettings == nil {
 			if reportFailure {
 				nodeSettingsTabLogger.Warn("node LoRa settings reload unavailable: service is not configured", "page_id", pageID)
-				controls.SetStatus("Reload is unavailable: node settings service is not configured.", 0, 1)
+				controls.SetStatus(i18n.T("Reload is unavailable: node settings service is not configured."), 0, 1)
 			}
 
 			return app.NodeSettingsTarget{}, false
@@ -491,7 +492,7 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		if !isConnected() {
 			if reportFailure {
 				nodeSettingsTabLogger.Info("node LoRa settings reload blocked: device is disconnected", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Reload from device is unavailable while disconnected.", 0, 2)
+				controls.SetStatus(i18n.T("Reload from device is unavailable while disconnected."), 0, 2)
 			}
 
 			return app.NodeSettingsTarget{}, false
@@ -502,7 +503,7 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 
 	startReloadFromDevice := func(target app.NodeSettingsTarget) {
 		nodeSettingsTabLogger.Info("reloading node LoRa settings from device", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Reloading LoRa settings from device…", 1, 2)
+		controls.SetStatus(i18n.T("Reloading LoRa settings from device…"), 1, 2)
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), nodeSettingsOpTimeout)
 			defer cancel()
@@ -510,14 +511,14 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 			fyne.Do(func() {
 				if err != nil {
 					nodeSettingsTabLogger.Warn("reloading node LoRa settings from device failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Reload failed: "+err.Error(), 0, 2)
+					controls.SetStatus(i18n.Tf("Reload failed: %s", err), 0, 2)
 					updateButtons()
 
 					return
 				}
 				applyLoadedSettings(loaded)
 				nodeSettingsTabLogger.Info("reloaded node LoRa settings from device", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Reloaded LoRa settings from device.", 2, 2)
+				controls.SetStatus(i18n.T("Reloaded LoRa settings from device."), 2, 2)
 			})
 		}()
 	}
@@ -601,7 +602,7 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		dirty = false
 		mu.Unlock()
 		applyForm(settings)
-		controls.SetStatus("Local edits reverted.", 1, 1)
+		controls.SetStatus(i18n.T("Local edits reverted."), 1, 1)
 		updateButtons()
 	}
 
@@ -609,13 +610,13 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		nodeSettingsTabLogger.Info("node LoRa settings save requested", "page_id", pageID)
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node LoRa settings save unavailable: service is not configured")
-			controls.SetStatus("Save is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node LoRa settings save blocked: device is disconnected", "page_id", pageID)
-			controls.SetStatus("Save is unavailable while disconnected.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable while disconnected."), 0, 1)
 			updateButtons()
 
 			return
@@ -623,13 +624,13 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node LoRa settings save failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Save failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Save failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if saveGate != nil && !saveGate.TryAcquire(pageID) {
 			nodeSettingsTabLogger.Info("node LoRa settings save blocked: another page save is active", "page_id", pageID, "active_page", saveGate.ActivePage())
-			controls.SetStatus("Another settings save is in progress on a different page.", 0, 1)
+			controls.SetStatus(i18n.T("Another settings save is in progress on a different page."), 0, 1)
 			updateButtons()
 
 			return
@@ -641,7 +642,7 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 				saveGate.Release(pageID)
 			}
 			nodeSettingsTabLogger.Warn("node LoRa settings save failed: invalid form values", "page_id", pageID, "error", err)
-			controls.SetStatus("Save failed: "+err.Error(), 0, 1)
+			controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 1)
 			updateButtons()
 
 			return
@@ -651,7 +652,7 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		saving = true
 		mu.Unlock()
 		nodeSettingsTabLogger.Info("saving node LoRa settings", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Saving LoRa settings…", 1, 3)
+		controls.SetStatus(i18n.T("Saving LoRa settings…"), 1, 3)
 		updateButtons()
 
 		go func(settings app.NodeLoRaSettings) {
@@ -666,7 +667,7 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 				}
 				if err != nil {
 					nodeSettingsTabLogger.Warn("saving node LoRa settings failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Save failed: "+err.Error(), 0, 3)
+					controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 3)
 					mu.Unlock()
 					updateButtons()
 
@@ -680,7 +681,7 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 
 				applyForm(applied)
 				nodeSettingsTabLogger.Info("saved node LoRa settings", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Saved LoRa settings.", 3, 3)
+				controls.SetStatus(i18n.T("Saved LoRa settings."), 3, 3)
 				updateButtons()
 			})
 		}(next)
@@ -697,9 +698,9 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 	}
 	applyLoadedSettings(initial)
 	if dep.Actions.NodeSettings == nil {
-		controls.SetStatus("LoRa settings are unavailable: node settings service is not configured.", 0, 1)
+		controls.SetStatus(i18n.T("LoRa settings are unavailable: node settings service is not configured."), 0, 1)
 	} else {
-		controls.SetStatus("LoRa settings will load when this tab is opened.", 0, 1)
+		controls.SetStatus(i18n.T("LoRa settings will load when this tab is opened."), 0, 1)
 	}
 
 	if dep.Data.Bus != nil {
@@ -748,7 +749,7 @@ func newNodeLoRaSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 	updateButtons()
 
 	content := container.NewVBox(
-		widget.NewLabel("LoRa settings are loaded from and saved to the connected local node."),
+		widget.NewLabel(i18n.T("LoRa settings are loaded from and saved to the connected local node.")),
 		formContent,
 	)
 
@@ -1002,7 +1003,7 @@ func nodeLoRaSetEnumSelect(selectWidget *widget.Select, options []nodeLoRaEnumOp
 func nodeLoRaEnumLabel(value int32, options []nodeLoRaEnumOption) string {
 	for _, option := range options {
 		if option.Value == value {
-			return option.Label
+			return i18n.T(option.Label)
 		}
 	}
 
@@ -1012,7 +1013,7 @@ func nodeLoRaEnumLabel(value int32, options []nodeLoRaEnumOption) string {
 func nodeLoRaEnumOptionsLabels(options []nodeLoRaEnumOption) []string {
 	out := make([]string, 0, len(options))
 	for _, option := range options {
-		out = append(out, option.Label)
+		out = append(out, i18n.T(option.Label))
 	}
 
 	return out
@@ -1028,7 +1029,7 @@ func nodeLoRaParseEnumLabel(fieldName, selected string, options []nodeLoRaEnumOp
 		return 0, fmt.Errorf("%s must be selected", fieldName)
 	}
 	for _, option := range options {
-		if option.Label == selected {
+		if i18n.T(option.Label) == selected {
 			return option.Value, nil
 		}
 	}
@@ -10,23 +10,24 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodeMaintenancePage(dep RuntimeDependencies) fyne.CanvasObject {
-	status := widget.NewLabel("Run node maintenance actions.")
+	status := widget.NewLabel(i18n.T("Run node maintenance actions."))
 	status.Wrapping = fyne.TextWrapWord
-	preserveFavorites := widget.NewCheck("Preserve favorites when resetting node DB", nil)
-	rebootButton := widget.NewButton("Reboot", nil)
-	shutdownButton := widget.NewButton("Shutdown", nil)
-	factoryResetButton := widget.NewButton("Factory reset", nil)
-	resetNodeDBButton := widget.NewButton("Reset node DB", nil)
+	preserveFavorites := widget.NewCheck(i18n.T("Preserve favorites when resetting node DB"), nil)
+	rebootButton := widget.NewButton(i18n.T("Reboot"), nil)
+	shutdownButton := widget.NewButton(i18n.T("Shutdown"), nil)
+	factoryResetButton := widget.NewButton(i18n.T("Factory reset"), nil)
+	resetNodeDBButton := widget.NewButton(i18n.T("Reset node DB"), nil)
 
 	if dep.Actions.NodeSettings == nil {
 		rebootButton.Disable()
 		shutdownButton.Disable()
 		factoryResetButton.Disable()
 		resetNodeDBButton.Disable()
-		status.SetText("Node settings service is unavailable.")
+		status.SetText(i18n.T("Node settings service is unavailable."))
 	}
 
 	runAction := func(title, message string, action func(context.Context, app.NodeSettingsTarget) error) {
@@ -52,29 +53,29 @@ func newNodeMaintenancePage(dep RuntimeDependencies) fyne.CanvasObject {
 				err := action(ctx, target)
 				fyne.Do(func() {
 					if err != nil {
-						status.SetText(fmt.Sprintf("%s failed: %v", title, err))
+						status.SetText(i18n.Tf("%s failed: %s", title, err))
 						showErrorModal(dep, err)
 
 						return
 					}
-					status.SetText(title + " command sent.")
+					status.SetText(i18n.Tf("%s command sent.", title))
 				})
 			}()
 		}, window)
 	}
 
 	rebootButton.OnTapped = func() {
-		runAction("Reboot node", "Send a reboot command to the connected node?", func(ctx context.Context, target app.NodeSettingsTarget) error {
+		runAction(i18n.T("Reboot node"), i18n.T("Send a reboot command to the connected node?"), func(ctx context.Context, target app.NodeSettingsTarget) error {
 			return dep.Actions.NodeSettings.RebootNode(ctx, target)
 		})
 	}
 	shutdownButton.OnTapped = func() {
-		runAction("Shutdown node", "Send a shutdown command to the connected node?", func(ctx context.Context, target app.NodeSettingsTarget) error {
+		runAction(i18n.T("Shutdown node"), i18n.T("Send a shutdown command to the connected node?"), func(ctx context.Context, target app.NodeSettingsTarget) error {
 			return dep.Actions.NodeSettings.ShutdownNode(ctx, target)
 		})
 	}
 	factoryResetButton.OnTapped = func() {
-		runAction("Factory reset node", "Factory reset will erase node configuration on the device. Continue?", func(ctx context.Context, target app.NodeSettingsTarget) error {
+		runAction(i18n.T("Factory reset node"), i18n.T("Factory reset will erase node configuration on the device. Continue?"), func(ctx context.Context, target app.NodeSettingsTarget) error {
 			return dep.Actions.NodeSettings.FactoryResetNode(ctx, target)
 		})
 	}
@@ -91,7 +92,7 @@ func newNodeMaintenancePage(dep RuntimeDependencies) fyne.CanvasObject {
 
 			return
 		}
-		dialog.ShowConfirm("Reset node DB", "Reset the node database on the connected device?", func(ok bool) {
+		dialog.ShowConfirm(i18n.T("Reset node DB"), i18n.T("Reset the node database on the connected device?"), func(ok bool) {
 			if !ok {
 				return
 			}
@@ -102,12 +103,12 @@ func newNodeMaintenancePage(dep RuntimeDependencies) fyne.CanvasObject {
 				err := dep.Actions.NodeSettings.ResetNodeDB(ctx, target, keepFavorites)
 				fyne.Do(func() {
 					if err != nil {
-						status.SetText(fmt.Sprintf("Reset node DB failed: %v", err))
+						status.SetText(i18n.Tf("Reset node DB failed: %s", err))
 						showErrorModal(dep, err)
 
 						return
 					}
-					status.SetText("Reset node DB command sent.")
+					status.SetText(i18n.T("Reset node DB command sent."))
 				})
 			}()
 		}, window)
@@ -118,5 +119,109 @@ func newNodeMaintenancePage(dep RuntimeDependencies) fyne.CanvasObject {
 		preserveFavorites,
 		container.NewGridWithColumns(2, rebootButton, shutdownButton),
 		container.NewGridWithColumns(2, factoryResetButton, resetNodeDBButton),
+		widget.NewSeparator(),
+		newEmergencyModeControls(dep),
 	)
 }
+
+func newEmergencyModeControls(dep RuntimeDependencies) fyne.CanvasObject {
+	status := widget.NewLabel(emergencyModeStatusText(dep.Actions.EmergencyMode))
+	status.Wrapping = fyne.TextWrapWord
+	toggleButton := widget.NewButton(emergencyModeButtonText(dep.Actions.EmergencyMode), nil)
+	toggleButton.Importance = widget.DangerImportance
+	if dep.Actions.EmergencyMode == nil {
+		toggleButton.Disable()
+	}
+
+	refresh := func() {
+		status.SetText(emergencyModeStatusText(dep.Actions.EmergencyMode))
+		toggleButton.SetText(emergencyModeButtonText(dep.Actions.EmergencyMode))
+	}
+	run := func(action func(context.Context) error) {
+		toggleButton.Disable()
+		status.SetText(i18n.T("Applying emergency mode changes…"))
+		go func() {
+			// Each step is a separate begin/commit edit transaction on the device.
+			ctx, cancel := context.WithTimeout(context.Background(), 4*nodeSettingsOpTimeout)
+			defer cancel()
+			err := action(ctx)
+			fyne.Do(func() {
+				toggleButton.Enable()
+				refresh()
+				if err != nil {
+					status.SetText(i18n.Tf("Emergency mode change failed: %s", err))
+					showErrorModal(dep, err)
+				}
+			})
+		}()
+	}
+
+	toggleButton.OnTapped = func() {
+		emergency := dep.Actions.EmergencyMode
+		if emergency == nil {
+			return
+		}
+		window := currentRuntimeWindow(dep)
+		if window == nil {
+			showErrorModal(dep, fmt.Errorf("window is unavailable"))
+
+			return
+		}
+		if emergency.Active() {
+			dialog.ShowConfirm(
+				i18n.T("Restore normal mode"),
+				i18n.T("Restore node position, display, buzzer and app notification settings saved before emergency mode?"),
+				func(ok bool) {
+					if ok {
+						run(emergency.Deactivate)
+					}
+				},
+				window,
+			)
+
+			return
+		}
+		target, ok := localNodeSettingsTarget(dep)
+		if !ok {
+			showErrorModal(dep, fmt.Errorf("local node is unavailable"))
+
+			return
+		}
+		dialog.ShowConfirm(
+			i18n.T("Activate emergency mode"),
+			i18n.Tf(
+				"Set position beacon to every %d seconds, keep the node screen always on, enable the device buzzer and all message notifications? Current settings are saved and restored when emergency mode is turned off.",
+				app.EmergencyPositionBroadcastSecs,
+			),
+			func(ok bool) {
+				if ok {
+					run(func(ctx context.Context) error {
+						return emergency.Activate(ctx, target)
+					})
+				}
+			},
+			window,
+		)
+	}
+
+	return container.NewVBox(widget.NewLabelWithStyle(i18n.T("Emergency mode"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}), status, toggleButton)
+}
+
+func emergencyModeStatusText(emergency EmergencyModeAction) string {
+	switch {
+	case emergency == nil:
+		return i18n.T("Emergency mode is unavailable.")
+	case emergency.Active():
+		return i18n.T("Emergency mode is active.")
+	default:
+		return i18n.T("Emergency mode is off.")
+	}
+}
+
+func emergencyModeButtonText(emergency EmergencyModeAction) string {
+	if emergency != nil && emergency.Active() {
+		return i18n.T("Restore normal mode")
+	}
+
+	return i18n.T("Activate emergency mode")
+}
@@ -12,6 +12,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 	generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
 )
 
@@ -71,6 +72,19 @@ var (
 		{Label: nodeSettingsSecondsKnownLabel(48*60*60, ""), Value: 48 * 60 * 60},
 		{Label: nodeSettingsSecondsKnownLabel(72*60*60, ""), Value: 72 * 60 * 60},
 	}
+	// Firmware clamps NeighborInfo broadcasts to at least 4 hours.
+	nodeSettingsNeighborInfoIntervalOptions = []nodeSettingsUint32Option{
+		{Label: "Unset", Value: 0},
+		{Label: nodeSettingsSecondsKnownLabel(4*60*60, ""), Value: 4 * 60 * 60},
+		{Label: nodeSettingsSecondsKnownLabel(5*60*60, ""), Value: 5 * 60 * 60},
+		{Label: nodeSettingsSecondsKnownLabel(6*60*60, ""), Value: 6 * 60 * 60},
+		{Label: nodeSettingsSecondsKnownLabel(12*60*60, ""), Value: 12 * 60 * 60},
+		{Label: nodeSettingsSecondsKnownLabel(18*60*60, ""), Value: 18 * 60 * 60},
+		{Label: nodeSettingsSecondsKnownLabel(24*60*60, ""), Value: 24 * 60 * 60},
+		{Label: nodeSettingsSecondsKnownLabel(36*60*60, ""), Value: 36 * 60 * 60},
+		{Label: nodeSettingsSecondsKnownLabel(48*60*60, ""), Value: 48 * 60 * 60},
+		{Label: nodeSettingsSecondsKnownLabel(72*60*60, ""), Value: 72 * 60 * 60},
+	}
 	nodeSettingsPaxcounterIntervalOptions = []nodeSettingsUint32Option{
 		{Label: nodeSettingsSecondsKnownLabel(15*60, ""), Value: 15 * 60},
 		{Label: nodeSettingsSecondsKnownLabel(30*60, ""), Value: 30 * 60},
@@ -273,13 +287,13 @@ func newManagedNodeSettingsPage[T any](
 	reloadFromDevice := func() {
 		target, ok := localNodeSettingsTarget(dep)
 		if !ok {
-			controls.SetStatus("Local node is unavailable.", 0, 1)
+			controls.SetStatus(i18n.T("Local node is unavailable."), 0, 1)
 			updateButtons()
 
 			return
 		}
 		if dep.Actions.NodeSettings == nil {
-			controls.SetStatus("Node settings service is unavailable.", 0, 1)
+			controls.SetStatus(i18n.T("Node settings service is unavailable."), 0, 1)
 			updateButtons()
 
 			return
@@ -301,7 +315,7 @@ func newManagedNodeSettingsPage[T any](
 				saving = false
 				mu.Unlock()
 				if err != nil {
-					controls.SetStatus(fmt.Sprintf("Load failed: %v", err), 0, 1)
+					controls.SetStatus(i18n.Tf("Load failed: %s", err), 0, 1)
 					updateButtons()
 					showErrorModal(dep, err)
 
@@ -363,7 +377,7 @@ func newManagedNodeSettingsPage[T any](
 		saving = true
 		mu.Unlock()
 		updateButtons()
-		controls.SetStatus("Saving settings…", 0, 1)
+		controls.SetStatus(i18n.T("Saving settings…"), 0, 1)
 
 		go func(payload T) {
 			ctx, cancel := context.WithTimeout(context.Background(), nodeSettingsOpTimeout)
@@ -378,21 +392,21 @@ func newManagedNodeSettingsPage[T any](
 					saveGate.Release(pageID)
 				}
 				if err != nil {
-					controls.SetStatus(fmt.Sprintf("Save failed: %v", err), 0, 1)
+					controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 1)
 					updateButtons()
 					showErrorModal(dep, err)
 
 					return
 				}
 				applyLoadedSettings(payload)
-				controls.SetStatus("Settings saved.", 1, 1)
+				controls.SetStatus(i18n.T("Settings saved."), 1, 1)
 			})
 		}(next)
 	}
 
 	onTabOpened := func() {
 		if dep.Actions.NodeSettings == nil {
-			controls.SetStatus("Node settings service is unavailable.", 0, 1)
+			controls.SetStatus(i18n.T("Node settings service is unavailable."), 0, 1)
 			updateButtons()
 
 			return
@@ -462,24 +476,6 @@ func parseOptionalInt32(raw string) (int32, error) {
 	return int32(parsed), nil
 }
 
-func parseUint32List(raw string) ([]uint32, error) {
-	trimmed := strings.TrimSpace(raw)
-	if trimmed == "" {
-		return nil, nil
-	}
-	parts := strings.Split(trimmed, ",")
-	out := make([]uint32, 0, len(parts))
-	for _, part := range parts {
-		value, err := parseOptionalUint32(part)
-		if err != nil {
-			return nil, err
-		}
-		out = append(out, value)
-	}
-
-	return out, nil
-}
-
 func nodeSettingsCustomMillisecondsLabel(milliseconds uint32) string {
 	return fmt.Sprintf("Custom (%d ms)", milliseconds)
 }
@@ -491,7 +487,7 @@ func nodeSettingsParseMillisecondsSelectLabel(
 ) (uint32, error) {
 	selected = strings.TrimSpace(selected)
 	for _, option := range options {
-		if option.Label == selected {
+		if nodeSettingsUint32OptionText(option) == selected {
 			return option.Value, nil
 		}
 	}
@@ -515,7 +511,7 @@ func fieldParseError(field string, err error) error {
 func cloneNodeRemoteHardwareSettings(in app.NodeRemoteHardwareSettings) app.NodeRemoteHardwareSettings {
 	out := in
 	if len(in.AvailablePins) > 0 {
-		out.AvailablePins = append([]uint32(nil), in.AvailablePins...)
+		out.AvailablePins = append([]app.NodeRemoteHardwarePin(nil), in.AvailablePins...)
 	}
 
 	return out
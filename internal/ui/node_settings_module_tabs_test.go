@@ -1,11 +1,15 @@
 package ui
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
 	"fyne.io/fyne/v2/container"
 	fynetest "fyne.io/fyne/v2/test"
+
+	"github.com/skobkin/meshgo/internal/app"
+	generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
 )
 
 func TestNodeTabModuleConfigurationIncludesNewTabsInAndroidOrder(t *testing.T) {
@@ -143,3 +147,160 @@ func TestNodeTabNewModuleSettingsExposeExpectedSelectControls(t *testing.T) {
 		mustFindSelectWithOption(t, tab, tc.option)
 	}
 }
+
+func TestNodeTelemetrySettingsFormRoundTrip(t *testing.T) {
+	want := app.NodeTelemetrySettings{
+		NodeID:                        "!00000001",
+		DeviceUpdateInterval:          30 * 60,
+		EnvironmentUpdateInterval:     60 * 60,
+		EnvironmentMeasurementEnabled: true,
+		EnvironmentScreenEnabled:      true,
+		EnvironmentDisplayFahrenheit:  true,
+		AirQualityEnabled:             true,
+		AirQualityInterval:            2 * 60 * 60,
+		PowerMeasurementEnabled:       true,
+		PowerUpdateInterval:           900,
+		PowerScreenEnabled:            true,
+		HealthMeasurementEnabled:      true,
+		HealthUpdateInterval:          6 * 60 * 60,
+		HealthScreenEnabled:           true,
+		DeviceTelemetryEnabled:        true,
+		AirQualityScreenEnabled:       true,
+	}
+
+	form := buildNodeTelemetrySettingsForm(func() {})
+	form.set(want)
+
+	got, err := form.read(app.NodeTelemetrySettings{}, app.NodeSettingsTarget{NodeID: " !00000001 "})
+	if err != nil {
+		t.Fatalf("read telemetry form: %v", err)
+	}
+	if got != want {
+		t.Fatalf("unexpected telemetry settings after round trip:\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestNodeStoreForwardSettingsFormRoundTrip(t *testing.T) {
+	want := app.NodeStoreForwardSettings{
+		NodeID:              "!00000001",
+		Enabled:             true,
+		Heartbeat:           true,
+		Records:             300,
+		HistoryReturnMax:    25,
+		HistoryReturnWindow: 240,
+		IsServer:            true,
+	}
+
+	form := buildNodeStoreForwardSettingsForm(func() {})
+	form.set(want)
+
+	got, err := form.read(app.NodeStoreForwardSettings{}, app.NodeSettingsTarget{NodeID: "!00000001"})
+	if err != nil {
+		t.Fatalf("read store & forward form: %v", err)
+	}
+	if got != want {
+		t.Fatalf("unexpected store & forward settings after round trip:\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestNodeNeighborInfoSettingsFormRoundTrip(t *testing.T) {
+	want := app.NodeNeighborInfoSettings{
+		NodeID:             "!00000001",
+		Enabled:            true,
+		UpdateIntervalSecs: 12 * 60 * 60,
+		TransmitOverLoRa:   true,
+	}
+
+	form := buildNodeNeighborInfoSettingsForm(func() {})
+	form.set(want)
+
+	got, err := form.read(app.NodeNeighborInfoSettings{}, app.NodeSettingsTarget{NodeID: "!00000001"})
+	if err != nil {
+		t.Fatalf("read neighbor info form: %v", err)
+	}
+	if got != want {
+		t.Fatalf("unexpected neighbor info settings after round trip:\n got: %+v\nwant: %+v", got, want)
+	}
+
+	// Values below the firmware minimum read back from older configs are kept as custom.
+	legacy := app.NodeNeighborInfoSettings{NodeID: "!00000001", UpdateIntervalSecs: 900}
+	form.set(legacy)
+	got, err = form.read(app.NodeNeighborInfoSettings{}, app.NodeSettingsTarget{NodeID: "!00000001"})
+	if err != nil {
+		t.Fatalf("read neighbor info form with custom interval: %v", err)
+	}
+	if got.UpdateIntervalSecs != 900 {
+		t.Fatalf("expected custom interval to be preserved, got %d", got.UpdateIntervalSecs)
+	}
+}
+
+func TestNodePaxcounterSettingsFormRoundTrip(t *testing.T) {
+	want := app.NodePaxcounterSettings{
+		NodeID:             "!00000001",
+		Enabled:            true,
+		UpdateIntervalSecs: 900,
+		WifiThreshold:      -70,
+		BLEThreshold:       -80,
+	}
+
+	form := buildNodePaxcounterSettingsForm(func() {})
+	form.set(want)
+
+	got, err := form.read(app.NodePaxcounterSettings{}, app.NodeSettingsTarget{NodeID: "!00000001"})
+	if err != nil {
+		t.Fatalf("read paxcounter form: %v", err)
+	}
+	if got != want {
+		t.Fatalf("unexpected paxcounter settings after round trip:\n got: %+v\nwant: %+v", got, want)
+	}
+
+	form.set(app.NodePaxcounterSettings{NodeID: "!00000001", UpdateIntervalSecs: 900, WifiThreshold: 10})
+	if _, err := form.read(app.NodePaxcounterSettings{}, app.NodeSettingsTarget{NodeID: "!00000001"}); err == nil {
+		t.Fatalf("expected positive RSSI threshold to be rejected")
+	}
+}
+
+func TestNodeRemoteHardwareSettingsFormRoundTrip(t *testing.T) {
+	want := app.NodeRemoteHardwareSettings{
+		NodeID:                  "!00000001",
+		Enabled:                 true,
+		AllowUndefinedPinAccess: true,
+		AvailablePins: []app.NodeRemoteHardwarePin{
+			{GPIOPin: 4},
+			{GPIOPin: 12, Name: "Gate", Type: int32(generated.RemoteHardwarePinType_DIGITAL_WRITE)},
+			{GPIOPin: 13, Type: int32(generated.RemoteHardwarePinType_DIGITAL_READ)},
+			{GPIOPin: 14, Name: "Mailbox"},
+		},
+	}
+
+	form := buildNodeRemoteHardwareSettingsForm(func() {})
+	form.set(want)
+
+	got, err := form.read(app.NodeRemoteHardwareSettings{}, app.NodeSettingsTarget{NodeID: "!00000001"})
+	if err != nil {
+		t.Fatalf("read remote hardware form: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected remote hardware settings after round trip:\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestParseNodeRemoteHardwarePinsRejectsInvalidLines(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{name: "non-numeric GPIO", raw: "gate"},
+		{name: "unknown type", raw: "12, Gate, toggle"},
+		{name: "too many fields", raw: "12, Gate, write, extra"},
+		{name: "name too long", raw: "12, Very long pin name"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := parseNodeRemoteHardwarePins(tc.raw); err == nil {
+				t.Fatalf("expected parse error for %q", tc.raw)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 const (
@@ -48,12 +49,12 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 	const pageID = "module.mqtt"
 	nodeSettingsTabLogger.Debug("building node MQTT settings page", "page_id", pageID, "service_configured", dep.Actions.NodeSettings != nil)
 
-	controls := newNodeSettingsPageControls("Loading MQTT settings…")
+	controls := newNodeSettingsPageControls(i18n.T("Loading MQTT settings…"))
 	saveButton := controls.saveButton
 	cancelButton := controls.cancelButton
 	reloadButton := controls.reloadButton
 
-	nodeIDLabel := widget.NewLabel("unknown")
+	nodeIDLabel := widget.NewLabel(i18n.T("unknown"))
 	nodeIDLabel.TextStyle = fyne.TextStyle{Monospace: true}
 
 	enabledBox := widget.NewCheck("", nil)
@@ -70,32 +71,32 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 	rootEntry.SetPlaceHolder("msh")
 	proxyToClientEnabledBox := widget.NewCheck("", nil)
 
-	mapReportingEnabledBox := widget.NewCheck("Enabled", nil)
-	mapReportShouldReportLocationBox := widget.NewCheck("Consent to share location", nil)
+	mapReportingEnabledBox := widget.NewCheck(i18n.T("Enabled"), nil)
+	mapReportShouldReportLocationBox := widget.NewCheck(i18n.T("Consent to share location"), nil)
 	mapReportPositionPrecisionSelect := widget.NewSelect(nil, nil)
 	mapReportPublishIntervalSecsSelect := widget.NewSelect(nil, nil)
 
 	mqttForm := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeIDLabel),
-		widget.NewFormItem("MQTT enabled", enabledBox),
-		widget.NewFormItem("Address", addressEntry),
-		widget.NewFormItem("Username", usernameEntry),
-		widget.NewFormItem("Password", passwordEntry),
-		widget.NewFormItem("Encryption enabled", encryptionEnabledBox),
-		widget.NewFormItem("JSON output enabled (legacy, read-only)", jsonEnabledBox),
-		widget.NewFormItem("TLS enabled", tlsEnabledBox),
-		widget.NewFormItem("Root topic", rootEntry),
-		widget.NewFormItem("Proxy to client enabled", proxyToClientEnabledBox),
+		widget.NewFormItem(i18n.T("Node ID"), nodeIDLabel),
+		widget.NewFormItem(i18n.T("MQTT enabled"), enabledBox),
+		widget.NewFormItem(i18n.T("Address"), addressEntry),
+		widget.NewFormItem(i18n.T("Username"), usernameEntry),
+		widget.NewFormItem(i18n.T("Password"), passwordEntry),
+		widget.NewFormItem(i18n.T("Encryption enabled"), encryptionEnabledBox),
+		widget.NewFormItem(i18n.T("JSON output enabled (legacy, read-only)"), jsonEnabledBox),
+		widget.NewFormItem(i18n.T("TLS enabled"), tlsEnabledBox),
+		widget.NewFormItem(i18n.T("Root topic"), rootEntry),
+		widget.NewFormItem(i18n.T("Proxy to client enabled"), proxyToClientEnabledBox),
 	)
 	mapReportingForm := widget.NewForm(
-		widget.NewFormItem("Map reporting", container.NewGridWithColumns(2, mapReportingEnabledBox, mapReportShouldReportLocationBox)),
+		widget.NewFormItem(i18n.T("Map reporting"), container.NewGridWithColumns(2, mapReportingEnabledBox, mapReportShouldReportLocationBox)),
 	)
 	mapReportingDetailsForm := widget.NewForm(
-		widget.NewFormItem("Position precision", mapReportPositionPrecisionSelect),
-		widget.NewFormItem("Publish interval", mapReportPublishIntervalSecsSelect),
+		widget.NewFormItem(i18n.T("Position precision"), mapReportPositionPrecisionSelect),
+		widget.NewFormItem(i18n.T("Publish interval"), mapReportPublishIntervalSecsSelect),
 	)
 	mapReportingContent := container.NewVBox(
-		widget.NewLabel("Map reporting"),
+		widget.NewLabel(i18n.T("Map reporting")),
 		mapReportingForm,
 		mapReportingDetailsForm,
 	)
@@ -292,7 +293,7 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		parsedMapPrecision, err := nodeMQTTParseMapPrecisionLabel("position precision", selectedMapPrecision)
 		if err == nil {
 			mapReportPositionPrecision = parsedMapPrecision
-		} else if selectedMapPrecision != "" && selectedMapPrecision != nodeMQTTMapPrecisionCustomText {
+		} else if selectedMapPrecision != "" && selectedMapPrecision != i18n.T(nodeMQTTMapPrecisionCustomText) {
 			return app.NodeMQTTSettings{}, err
 		}
 		mapReportPublishIntervalSecs := next.MapReportPublishIntervalSecs
@@ -340,25 +341,25 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node MQTT settings reload failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Reload failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Reload failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node MQTT settings reload unavailable: service is not configured", "page_id", pageID)
-			controls.SetStatus("Reload is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Reload is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node MQTT settings reload blocked: device is disconnected", "page_id", pageID, "node_id", target.NodeID)
-			controls.SetStatus("Reload from device is unavailable while disconnected.", 0, 2)
+			controls.SetStatus(i18n.T("Reload from device is unavailable while disconnected."), 0, 2)
 
 			return
 		}
 
 		nodeSettingsTabLogger.Info("reloading node MQTT settings from device", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Reloading MQTT settings from device…", 1, 2)
+		controls.SetStatus(i18n.T("Reloading MQTT settings from device…"), 1, 2)
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), nodeSettingsOpTimeout)
 			defer cancel()
@@ -366,7 +367,7 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 			fyne.Do(func() {
 				if err != nil {
 					nodeSettingsTabLogger.Warn("reloading node MQTT settings from device failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Reload failed: "+err.Error(), 0, 2)
+					controls.SetStatus(i18n.Tf("Reload failed: %s", err), 0, 2)
 					updateButtons()
 
 					return
@@ -379,7 +380,7 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 				}
 				applyLoadedSettings(loaded)
 				nodeSettingsTabLogger.Info("reloaded node MQTT settings from device", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Reloaded MQTT settings from device.", 2, 2)
+				controls.SetStatus(i18n.T("Reloaded MQTT settings from device."), 2, 2)
 			})
 		}()
 	}
@@ -417,7 +418,7 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		dirty = false
 		mu.Unlock()
 		applyForm(settings)
-		controls.SetStatus("Local edits reverted.", 1, 1)
+		controls.SetStatus(i18n.T("Local edits reverted."), 1, 1)
 		updateButtons()
 	}
 
@@ -425,13 +426,13 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		nodeSettingsTabLogger.Info("node MQTT settings save requested", "page_id", pageID)
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node MQTT settings save unavailable: service is not configured")
-			controls.SetStatus("Save is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node MQTT settings save blocked: device is disconnected", "page_id", pageID)
-			controls.SetStatus("Save is unavailable while disconnected.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable while disconnected."), 0, 1)
 			updateButtons()
 
 			return
@@ -439,13 +440,13 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node MQTT settings save failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Save failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Save failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if saveGate != nil && !saveGate.TryAcquire(pageID) {
 			nodeSettingsTabLogger.Info("node MQTT settings save blocked: another page save is active", "page_id", pageID, "active_page", saveGate.ActivePage())
-			controls.SetStatus("Another settings save is in progress on a different page.", 0, 1)
+			controls.SetStatus(i18n.T("Another settings save is in progress on a different page."), 0, 1)
 			updateButtons()
 
 			return
@@ -457,7 +458,7 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 				saveGate.Release(pageID)
 			}
 			nodeSettingsTabLogger.Warn("node MQTT settings save failed: invalid form values", "page_id", pageID, "error", err)
-			controls.SetStatus("Save failed: "+err.Error(), 0, 1)
+			controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 1)
 			updateButtons()
 
 			return
@@ -467,7 +468,7 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		saving = true
 		mu.Unlock()
 		nodeSettingsTabLogger.Info("saving node MQTT settings", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Saving MQTT settings…", 1, 3)
+		controls.SetStatus(i18n.T("Saving MQTT settings…"), 1, 3)
 		updateButtons()
 
 		go func(settings app.NodeMQTTSettings) {
@@ -482,7 +483,7 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 				}
 				if err != nil {
 					nodeSettingsTabLogger.Warn("saving node MQTT settings failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Save failed: "+err.Error(), 0, 3)
+					controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 3)
 					mu.Unlock()
 					updateButtons()
 
@@ -496,7 +497,7 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 
 				applyForm(applied)
 				nodeSettingsTabLogger.Info("saved node MQTT settings", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Saved MQTT settings.", 3, 3)
+				controls.SetStatus(i18n.T("Saved MQTT settings."), 3, 3)
 				updateButtons()
 			})
 		}(next)
@@ -516,9 +517,9 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 	}
 	applyLoadedSettings(initial)
 	if dep.Actions.NodeSettings == nil {
-		controls.SetStatus("MQTT settings are unavailable: node settings service is not configured.", 0, 1)
+		controls.SetStatus(i18n.T("MQTT settings are unavailable: node settings service is not configured."), 0, 1)
 	} else {
-		controls.SetStatus("MQTT settings will load when this tab is opened.", 0, 1)
+		controls.SetStatus(i18n.T("MQTT settings will load when this tab is opened."), 0, 1)
 	}
 
 	if dep.Data.Bus != nil {
@@ -536,7 +537,7 @@ func newNodeMQTTSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 	updateButtons()
 
 	content := container.NewVBox(
-		widget.NewLabel("MQTT module settings are loaded from and saved to the connected local node."),
+		widget.NewLabel(i18n.T("MQTT module settings are loaded from and saved to the connected local node.")),
 		mqttForm,
 		mapReportingContent,
 	)
@@ -665,7 +666,7 @@ func nodeMQTTMapPrecisionKnownLabel(bits uint32) string {
 }
 
 func nodeMQTTMapPrecisionCustomLabel(_ uint32) string {
-	return nodeMQTTMapPrecisionCustomText
+	return i18n.T(nodeMQTTMapPrecisionCustomText)
 }
 
 func nodeMQTTParseMapPrecisionLabel(fieldName, selected string) (uint32, error) {
@@ -674,7 +675,7 @@ func nodeMQTTParseMapPrecisionLabel(fieldName, selected string) (uint32, error)
 		return 0, fmt.Errorf("%s must be selected", fieldName)
 	}
 	for _, option := range nodeMQTTMapPrecisionOptions {
-		if option.Label == selected {
+		if nodeSettingsUint32OptionText(option) == selected {
 			return option.Value, nil
 		}
 	}
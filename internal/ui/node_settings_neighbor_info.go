@@ -9,11 +9,12 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodeNeighborInfoSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) (fyne.CanvasObject, func()) {
 	return newManagedNodeSettingsPage(
-		dep, saveGate, "module.neighbor_info", "Loading neighbor info settings…", "Neighbor info settings loaded.",
+		dep, saveGate, "module.neighbor_info", i18n.T("Loading neighbor info settings…"), i18n.T("Neighbor info settings loaded."),
 		func(ctx context.Context, target app.NodeSettingsTarget) (app.NodeNeighborInfoSettings, error) {
 			return dep.Actions.NodeSettings.LoadNeighborInfoSettings(ctx, target)
 		},
@@ -29,17 +30,17 @@ func newNodeNeighborInfoSettingsPage(dep RuntimeDependencies, saveGate *nodeSett
 }
 
 func buildNodeNeighborInfoSettingsForm(onChanged func()) nodeManagedSettingsForm[app.NodeNeighborInfoSettings] {
-	nodeID := widget.NewLabel("unknown")
+	nodeID := widget.NewLabel(i18n.T("unknown"))
 	nodeID.TextStyle = fyne.TextStyle{Monospace: true}
 	enabled := newSettingsCheck(onChanged)
 	updateInterval := widget.NewSelect(nil, nil)
 	updateInterval.OnChanged = func(string) { onChanged() }
 	transmitOverLoRa := newSettingsCheck(onChanged)
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeID),
-		widget.NewFormItem("Enabled", enabled),
-		widget.NewFormItem("Update interval secs", updateInterval),
-		widget.NewFormItem("Transmit over LoRa", transmitOverLoRa),
+		widget.NewFormItem(i18n.T("Node ID"), nodeID),
+		widget.NewFormItem(i18n.T("Enabled"), enabled),
+		widget.NewFormItem(i18n.T("Update interval secs"), updateInterval),
+		widget.NewFormItem(i18n.T("Transmit over LoRa"), transmitOverLoRa),
 	)
 
 	return nodeManagedSettingsForm[app.NodeNeighborInfoSettings]{
@@ -47,14 +48,14 @@ func buildNodeNeighborInfoSettingsForm(onChanged func()) nodeManagedSettingsForm
 		set: func(v app.NodeNeighborInfoSettings) {
 			nodeID.SetText(orUnknown(v.NodeID))
 			enabled.SetChecked(v.Enabled)
-			nodeSettingsSetUint32Select(updateInterval, nodeSettingsDetectionMinimumIntervalOptions, v.UpdateIntervalSecs, nodeSettingsCustomSecondsLabel)
+			nodeSettingsSetUint32Select(updateInterval, nodeSettingsNeighborInfoIntervalOptions, v.UpdateIntervalSecs, nodeSettingsCustomSecondsLabel)
 			transmitOverLoRa.SetChecked(v.TransmitOverLoRa)
 		},
 		read: func(base app.NodeNeighborInfoSettings, target app.NodeSettingsTarget) (app.NodeNeighborInfoSettings, error) {
 			base.NodeID = strings.TrimSpace(target.NodeID)
 			var err error
 			base.Enabled = enabled.Checked
-			base.UpdateIntervalSecs, err = nodeSettingsParseUint32SelectLabel("update interval secs", updateInterval.Selected, nodeSettingsDetectionMinimumIntervalOptions)
+			base.UpdateIntervalSecs, err = nodeSettingsParseUint32SelectLabel("update interval secs", updateInterval.Selected, nodeSettingsNeighborInfoIntervalOptions)
 			if err != nil {
 				return app.NodeNeighborInfoSettings{}, fieldParseError("update interval secs", err)
 			}
@@ -11,6 +11,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 	generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
 )
 
@@ -24,8 +25,8 @@ func newNodeNetworkSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 		dep,
 		saveGate,
 		"device.network",
-		"Loading network settings…",
-		"Network settings loaded.",
+		i18n.T("Loading network settings…"),
+		i18n.T("Network settings loaded."),
 		func(ctx context.Context, target app.NodeSettingsTarget) (app.NodeNetworkSettings, error) {
 			return dep.Actions.NodeSettings.LoadNetworkSettings(ctx, target)
 		},
@@ -43,7 +44,7 @@ func newNodeNetworkSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsS
 }
 
 func buildNodeNetworkSettingsForm(onChanged func()) nodeManagedSettingsForm[app.NodeNetworkSettings] {
-	nodeID := widget.NewLabel("unknown")
+	nodeID := widget.NewLabel(i18n.T("unknown"))
 	nodeID.TextStyle = fyne.TextStyle{Monospace: true}
 	wifiEnabled := newSettingsCheck(onChanged)
 	wifiSSID := widget.NewEntry()
@@ -68,20 +69,20 @@ func buildNodeNetworkSettingsForm(onChanged func()) nodeManagedSettingsForm[app.
 	ipv6Enabled := newSettingsCheck(onChanged)
 
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeID),
-		widget.NewFormItem("WiFi enabled", wifiEnabled),
-		widget.NewFormItem("WiFi SSID", wifiSSID),
-		widget.NewFormItem("WiFi password", wifiPSK),
-		widget.NewFormItem("NTP server", ntpServer),
-		widget.NewFormItem("Ethernet enabled", ethernetEnabled),
-		widget.NewFormItem("Address mode", addressMode),
-		widget.NewFormItem("IPv4 address", ipv4Address),
-		widget.NewFormItem("IPv4 gateway", ipv4Gateway),
-		widget.NewFormItem("IPv4 subnet", ipv4Subnet),
-		widget.NewFormItem("IPv4 DNS", ipv4DNS),
-		widget.NewFormItem("Rsyslog server", rsyslogServer),
-		widget.NewFormItem("UDP broadcast enabled", udpBroadcastEnabled),
-		widget.NewFormItem("IPv6 enabled", ipv6Enabled),
+		widget.NewFormItem(i18n.T("Node ID"), nodeID),
+		widget.NewFormItem(i18n.T("WiFi enabled"), wifiEnabled),
+		widget.NewFormItem(i18n.T("WiFi SSID"), wifiSSID),
+		widget.NewFormItem(i18n.T("WiFi password"), wifiPSK),
+		widget.NewFormItem(i18n.T("NTP server"), ntpServer),
+		widget.NewFormItem(i18n.T("Ethernet enabled"), ethernetEnabled),
+		widget.NewFormItem(i18n.T("Address mode"), addressMode),
+		widget.NewFormItem(i18n.T("IPv4 address"), ipv4Address),
+		widget.NewFormItem(i18n.T("IPv4 gateway"), ipv4Gateway),
+		widget.NewFormItem(i18n.T("IPv4 subnet"), ipv4Subnet),
+		widget.NewFormItem(i18n.T("IPv4 DNS"), ipv4DNS),
+		widget.NewFormItem(i18n.T("Rsyslog server"), rsyslogServer),
+		widget.NewFormItem(i18n.T("UDP broadcast enabled"), udpBroadcastEnabled),
+		widget.NewFormItem(i18n.T("IPv6 enabled"), ipv6Enabled),
 	)
 
 	return nodeManagedSettingsForm[app.NodeNetworkSettings]{
@@ -10,11 +10,12 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodePaxcounterSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) (fyne.CanvasObject, func()) {
 	return newManagedNodeSettingsPage(
-		dep, saveGate, "module.paxcounter", "Loading paxcounter settings…", "Paxcounter settings loaded.",
+		dep, saveGate, "module.paxcounter", i18n.T("Loading paxcounter settings…"), i18n.T("Paxcounter settings loaded."),
 		func(ctx context.Context, target app.NodeSettingsTarget) (app.NodePaxcounterSettings, error) {
 			return dep.Actions.NodeSettings.LoadPaxcounterSettings(ctx, target)
 		},
@@ -30,7 +31,7 @@ func newNodePaxcounterSettingsPage(dep RuntimeDependencies, saveGate *nodeSettin
 }
 
 func buildNodePaxcounterSettingsForm(onChanged func()) nodeManagedSettingsForm[app.NodePaxcounterSettings] {
-	nodeID := widget.NewLabel("unknown")
+	nodeID := widget.NewLabel(i18n.T("unknown"))
 	nodeID.TextStyle = fyne.TextStyle{Monospace: true}
 	enabled := newSettingsCheck(onChanged)
 	updateInterval := widget.NewSelect(nil, nil)
@@ -38,11 +39,11 @@ func buildNodePaxcounterSettingsForm(onChanged func()) nodeManagedSettingsForm[a
 	wifiThreshold := newNumberEntry(onChanged)
 	bleThreshold := newNumberEntry(onChanged)
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeID),
-		widget.NewFormItem("Enabled", enabled),
-		widget.NewFormItem("Update interval secs", updateInterval),
-		widget.NewFormItem("WiFi threshold", wifiThreshold),
-		widget.NewFormItem("BLE threshold", bleThreshold),
+		widget.NewFormItem(i18n.T("Node ID"), nodeID),
+		widget.NewFormItem(i18n.T("Enabled"), enabled),
+		widget.NewFormItem(i18n.T("Update interval secs"), updateInterval),
+		widget.NewFormItem(i18n.T("WiFi RSSI threshold (dBm, 0 = firmware default)"), wifiThreshold),
+		widget.NewFormItem(i18n.T("BLE RSSI threshold (dBm, 0 = firmware default)"), bleThreshold),
 	)
 
 	return nodeManagedSettingsForm[app.NodePaxcounterSettings]{
@@ -62,11 +63,11 @@ func buildNodePaxcounterSettingsForm(onChanged func()) nodeManagedSettingsForm[a
 			if err != nil {
 				return app.NodePaxcounterSettings{}, fieldParseError("update interval secs", err)
 			}
-			base.WifiThreshold, err = parseOptionalInt32(wifiThreshold.Text)
+			base.WifiThreshold, err = parsePaxcounterRSSIThreshold(wifiThreshold.Text)
 			if err != nil {
 				return app.NodePaxcounterSettings{}, fieldParseError("WiFi threshold", err)
 			}
-			base.BLEThreshold, err = parseOptionalInt32(bleThreshold.Text)
+			base.BLEThreshold, err = parsePaxcounterRSSIThreshold(bleThreshold.Text)
 			if err != nil {
 				return app.NodePaxcounterSettings{}, fieldParseError("BLE threshold", err)
 			}
@@ -76,3 +77,16 @@ func buildNodePaxcounterSettingsForm(onChanged func()) nodeManagedSettingsForm[a
 		setSaving: disableWidgets(enabled, updateInterval, wifiThreshold, bleThreshold),
 	}
 }
+
+// parsePaxcounterRSSIThreshold parses an RSSI threshold in dBm. Zero keeps the firmware default.
+func parsePaxcounterRSSIThreshold(raw string) (int32, error) {
+	value, err := parseOptionalInt32(raw)
+	if err != nil {
+		return 0, err
+	}
+	if value > 0 {
+		return 0, fmt.Errorf("RSSI threshold must be negative dBm or 0")
+	}
+
+	return value, nil
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"context"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
@@ -14,6 +15,8 @@ import (
 
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/geo"
+	"github.com/skobkin/meshgo/internal/i18n"
 	generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
 )
 
@@ -67,12 +70,12 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 	const pageID = "device.position"
 	nodeSettingsTabLogger.Debug("building node position settings page", "page_id", pageID, "service_configured", dep.Actions.NodeSettings != nil)
 
-	controls := newNodeSettingsPageControls("Loading position settings…")
+	controls := newNodeSettingsPageControls(i18n.T("Loading position settings…"))
 	saveButton := controls.saveButton
 	cancelButton := controls.cancelButton
 	reloadButton := controls.reloadButton
 
-	nodeIDLabel := widget.NewLabel("unknown")
+	nodeIDLabel := widget.NewLabel(i18n.T("unknown"))
 	nodeIDLabel.TextStyle = fyne.TextStyle{Monospace: true}
 
 	positionBroadcastSecsSelect := widget.NewSelect(nil, nil)
@@ -82,6 +85,26 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 	fixedPositionBox := widget.NewCheck("", nil)
 	fixedLatitudeEntry := widget.NewEntry()
 	fixedLongitudeEntry := widget.NewEntry()
+	fixedCoordinatesEntry := widget.NewEntry()
+	fixedCoordinatesEntry.SetPlaceHolder(i18n.T("DD, DMS, MGRS or grid square"))
+	fixedCoordinatesHint := widget.NewLabel("")
+	fixedCoordinatesHint.Wrapping = fyne.TextWrapWord
+	fixedCoordinatesHint.Hide()
+	applyFixedCoordinates := func() {
+		lat, lon, err := geo.ParseCoordinates(fixedCoordinatesEntry.Text)
+		if err != nil {
+			fixedCoordinatesHint.SetText(err.Error())
+			fixedCoordinatesHint.Show()
+
+			return
+		}
+		fixedCoordinatesHint.Hide()
+		fixedLatitudeEntry.SetText(formatNodePositionCoordinate(lat))
+		fixedLongitudeEntry.SetText(formatNodePositionCoordinate(lon))
+		fixedCoordinatesEntry.SetText("")
+	}
+	fixedCoordinatesEntry.OnSubmitted = func(_ string) { applyFixedCoordinates() }
+	fixedCoordinatesButton := widget.NewButton(i18n.T("Fill"), applyFixedCoordinates)
 	fixedAltitudeEntry := widget.NewEntry()
 	gpsModeSelect := widget.NewSelect(nil, nil)
 	gpsUpdateIntervalSelect := widget.NewSelect(nil, nil)
@@ -89,16 +112,16 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 	txGPIOEntry := widget.NewEntry()
 	gpsEnGPIOEntry := widget.NewEntry()
 
-	flagAltitudeBox := widget.NewCheck("Altitude", nil)
-	flagAltitudeMSLBox := widget.NewCheck("Altitude MSL", nil)
-	flagGeoidalSeparationBox := widget.NewCheck("Geoidal separation", nil)
-	flagDOPBox := widget.NewCheck("DOP", nil)
-	flagHVDOPBox := widget.NewCheck("HVDOP", nil)
-	flagSatInViewBox := widget.NewCheck("Satellites in view", nil)
-	flagSeqNoBox := widget.NewCheck("Sequence number", nil)
-	flagTimestampBox := widget.NewCheck("Timestamp", nil)
-	flagHeadingBox := widget.NewCheck("Heading", nil)
-	flagSpeedBox := widget.NewCheck("Speed", nil)
+	flagAltitudeBox := widget.NewCheck(i18n.T("Altitude"), nil)
+	flagAltitudeMSLBox := widget.NewCheck(i18n.T("Altitude MSL"), nil)
+	flagGeoidalSeparationBox := widget.NewCheck(i18n.T("Geoidal separation"), nil)
+	flagDOPBox := widget.NewCheck(i18n.T("DOP"), nil)
+	flagHVDOPBox := widget.NewCheck(i18n.T("HVDOP"), nil)
+	flagSatInViewBox := widget.NewCheck(i18n.T("Satellites in view"), nil)
+	flagSeqNoBox := widget.NewCheck(i18n.T("Sequence number"), nil)
+	flagTimestampBox := widget.NewCheck(i18n.T("Timestamp"), nil)
+	flagHeadingBox := widget.NewCheck(i18n.T("Heading"), nil)
+	flagSpeedBox := widget.NewCheck(i18n.T("Speed"), nil)
 
 	positionFlags := container.NewGridWithColumns(
 		5,
@@ -115,21 +138,25 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 	)
 
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeIDLabel),
-		widget.NewFormItem("Position broadcast interval", positionBroadcastSecsSelect),
-		widget.NewFormItem("Smart position enabled", smartPositionEnabledBox),
-		widget.NewFormItem("Smart minimum interval", minimumIntervalSelect),
-		widget.NewFormItem("Smart minimum distance (meters)", minimumDistanceEntry),
-		widget.NewFormItem("Use fixed position", fixedPositionBox),
-		widget.NewFormItem("Fixed latitude", fixedLatitudeEntry),
-		widget.NewFormItem("Fixed longitude", fixedLongitudeEntry),
-		widget.NewFormItem("Fixed altitude (meters)", fixedAltitudeEntry),
-		widget.NewFormItem("GPS mode (physical hardware)", gpsModeSelect),
-		widget.NewFormItem("GPS update interval", gpsUpdateIntervalSelect),
-		widget.NewFormItem("Position flags", positionFlags),
-		widget.NewFormItem("GPS RX GPIO", rxGPIOEntry),
-		widget.NewFormItem("GPS TX GPIO", txGPIOEntry),
-		widget.NewFormItem("GPS EN GPIO", gpsEnGPIOEntry),
+		widget.NewFormItem(i18n.T("Node ID"), nodeIDLabel),
+		widget.NewFormItem(i18n.T("Position broadcast interval"), positionBroadcastSecsSelect),
+		widget.NewFormItem(i18n.T("Smart position enabled"), smartPositionEnabledBox),
+		widget.NewFormItem(i18n.T("Smart minimum interval"), minimumIntervalSelect),
+		widget.NewFormItem(i18n.T("Smart minimum distance (meters)"), minimumDistanceEntry),
+		widget.NewFormItem(i18n.T("Use fixed position"), fixedPositionBox),
+		widget.NewFormItem(i18n.T("Fixed coordinates"), container.NewVBox(
+			container.NewBorder(nil, nil, nil, fixedCoordinatesButton, fixedCoordinatesEntry),
+			fixedCoordinatesHint,
+		)),
+		widget.NewFormItem(i18n.T("Fixed latitude"), fixedLatitudeEntry),
+		widget.NewFormItem(i18n.T("Fixed longitude"), fixedLongitudeEntry),
+		widget.NewFormItem(i18n.T("Fixed altitude (meters)"), fixedAltitudeEntry),
+		widget.NewFormItem(i18n.T("GPS mode (physical hardware)"), gpsModeSelect),
+		widget.NewFormItem(i18n.T("GPS update interval"), gpsUpdateIntervalSelect),
+		widget.NewFormItem(i18n.T("Position flags"), positionFlags),
+		widget.NewFormItem(i18n.T("GPS RX GPIO"), rxGPIOEntry),
+		widget.NewFormItem(i18n.T("GPS TX GPIO"), txGPIOEntry),
+		widget.NewFormItem(i18n.T("GPS EN GPIO"), gpsEnGPIOEntry),
 	)
 
 	var (
@@ -245,10 +272,14 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		}
 
 		if !isSaving && fixedPositionBox.Checked {
+			fixedCoordinatesEntry.Enable()
+			fixedCoordinatesButton.Enable()
 			fixedLatitudeEntry.Enable()
 			fixedLongitudeEntry.Enable()
 			fixedAltitudeEntry.Enable()
 		} else {
+			fixedCoordinatesEntry.Disable()
+			fixedCoordinatesButton.Disable()
 			fixedLatitudeEntry.Disable()
 			fixedLongitudeEntry.Disable()
 			fixedAltitudeEntry.Disable()
@@ -412,25 +443,25 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node position settings reload failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Reload failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Reload failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node position settings reload unavailable: service is not configured", "page_id", pageID)
-			controls.SetStatus("Reload is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Reload is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node position settings reload blocked: device is disconnected", "page_id", pageID, "node_id", target.NodeID)
-			controls.SetStatus("Reload from device is unavailable while disconnected.", 0, 2)
+			controls.SetStatus(i18n.T("Reload from device is unavailable while disconnected."), 0, 2)
 
 			return
 		}
 
 		nodeSettingsTabLogger.Info("reloading node position settings from device", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Reloading position settings from device…", 1, 2)
+		controls.SetStatus(i18n.T("Reloading position settings from device…"), 1, 2)
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), nodeSettingsOpTimeout)
 			defer cancel()
@@ -438,14 +469,14 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 			fyne.Do(func() {
 				if err != nil {
 					nodeSettingsTabLogger.Warn("reloading node position settings from device failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Reload failed: "+err.Error(), 0, 2)
+					controls.SetStatus(i18n.Tf("Reload failed: %s", err), 0, 2)
 					updateButtons()
 
 					return
 				}
 				applyLoadedSettings(loaded)
 				nodeSettingsTabLogger.Info("reloaded node position settings from device", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Reloaded position settings from device.", 2, 2)
+				controls.SetStatus(i18n.T("Reloaded position settings from device."), 2, 2)
 			})
 		}()
 	}
@@ -481,7 +512,7 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		dirty = false
 		mu.Unlock()
 		applyForm(settings)
-		controls.SetStatus("Local edits reverted.", 1, 1)
+		controls.SetStatus(i18n.T("Local edits reverted."), 1, 1)
 		updateButtons()
 	}
 
@@ -489,13 +520,13 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		nodeSettingsTabLogger.Info("node position settings save requested", "page_id", pageID)
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node position settings save unavailable: service is not configured")
-			controls.SetStatus("Save is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node position settings save blocked: device is disconnected", "page_id", pageID)
-			controls.SetStatus("Save is unavailable while disconnected.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable while disconnected."), 0, 1)
 			updateButtons()
 
 			return
@@ -503,13 +534,13 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node position settings save failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Save failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Save failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if saveGate != nil && !saveGate.TryAcquire(pageID) {
 			nodeSettingsTabLogger.Info("node position settings save blocked: another page save is active", "page_id", pageID, "active_page", saveGate.ActivePage())
-			controls.SetStatus("Another settings save is in progress on a different page.", 0, 1)
+			controls.SetStatus(i18n.T("Another settings save is in progress on a different page."), 0, 1)
 			updateButtons()
 
 			return
@@ -521,7 +552,7 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 				saveGate.Release(pageID)
 			}
 			nodeSettingsTabLogger.Warn("node position settings save failed: invalid form values", "page_id", pageID, "error", err)
-			controls.SetStatus("Save failed: "+err.Error(), 0, 1)
+			controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 1)
 			updateButtons()
 
 			return
@@ -531,7 +562,7 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		saving = true
 		mu.Unlock()
 		nodeSettingsTabLogger.Info("saving node position settings", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Saving position settings…", 1, 3)
+		controls.SetStatus(i18n.T("Saving position settings…"), 1, 3)
 		updateButtons()
 
 		go func(settings app.NodePositionSettings) {
@@ -546,7 +577,7 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 				}
 				if err != nil {
 					nodeSettingsTabLogger.Warn("saving node position settings failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Save failed: "+err.Error(), 0, 3)
+					controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 3)
 					mu.Unlock()
 					updateButtons()
 
@@ -560,7 +591,7 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 
 				applyForm(applied)
 				nodeSettingsTabLogger.Info("saved node position settings", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Saved position settings.", 3, 3)
+				controls.SetStatus(i18n.T("Saved position settings."), 3, 3)
 				updateButtons()
 			})
 		}(next)
@@ -579,9 +610,9 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 	}
 	applyLoadedSettings(initial)
 	if dep.Actions.NodeSettings == nil {
-		controls.SetStatus("Position settings are unavailable: node settings service is not configured.", 0, 1)
+		controls.SetStatus(i18n.T("Position settings are unavailable: node settings service is not configured."), 0, 1)
 	} else {
-		controls.SetStatus("Position settings will load when this tab is opened.", 0, 1)
+		controls.SetStatus(i18n.T("Position settings will load when this tab is opened."), 0, 1)
 	}
 
 	if dep.Data.Bus != nil {
@@ -599,7 +630,7 @@ func newNodePositionSettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 	updateButtons()
 
 	content := container.NewVBox(
-		widget.NewLabel("Position settings are loaded from and saved to the connected local node."),
+		widget.NewLabel(i18n.T("Position settings are loaded from and saved to the connected local node.")),
 		form,
 	)
 
@@ -834,12 +865,9 @@ func parseNodePositionLatitudeField(fieldName, raw string) (float64, error) {
 	if raw == "" {
 		return 0, fmt.Errorf("%s is required", fieldName)
 	}
-	value, err := strconv.ParseFloat(raw, 64)
+	value, err := geo.ParseLatitude(raw)
 	if err != nil {
-		return 0, fmt.Errorf("%s must be a number", fieldName)
-	}
-	if value < -90 || value > 90 {
-		return 0, fmt.Errorf("%s must be between -90 and 90", fieldName)
+		return 0, fmt.Errorf("%s: %w", fieldName, err)
 	}
 
 	return value, nil
@@ -850,17 +878,19 @@ func parseNodePositionLongitudeField(fieldName, raw string) (float64, error) {
 	if raw == "" {
 		return 0, fmt.Errorf("%s is required", fieldName)
 	}
-	value, err := strconv.ParseFloat(raw, 64)
+	value, err := geo.ParseLongitude(raw)
 	if err != nil {
-		return 0, fmt.Errorf("%s must be a number", fieldName)
-	}
-	if value < -180 || value > 180 {
-		return 0, fmt.Errorf("%s must be between -180 and 180", fieldName)
+		return 0, fmt.Errorf("%s: %w", fieldName, err)
 	}
 
 	return value, nil
 }
 
+// formatNodePositionCoordinate rounds to the 1e-7 degree resolution the firmware stores.
+func formatNodePositionCoordinate(value float64) string {
+	return strconv.FormatFloat(math.Round(value*1e7)/1e7, 'f', -1, 64)
+}
+
 func nodePositionSetBroadcastIntervalSelect(selectWidget *widget.Select, value uint32) {
 	nodeSettingsSetUint32Select(selectWidget, nodePositionBroadcastIntervalOptions, value, nodeSettingsCustomSecondsLabel)
 }
@@ -959,7 +989,7 @@ func nodePositionSetEnumSelect(selectWidget *widget.Select, options []nodePositi
 func nodePositionEnumLabel(value int32, options []nodePositionEnumOption) string {
 	for _, option := range options {
 		if option.Value == value {
-			return option.Label
+			return i18n.T(option.Label)
 		}
 	}
 
@@ -969,7 +999,7 @@ func nodePositionEnumLabel(value int32, options []nodePositionEnumOption) string
 func nodePositionEnumOptionsLabels(options []nodePositionEnumOption) []string {
 	out := make([]string, 0, len(options))
 	for _, option := range options {
-		out = append(out, option.Label)
+		out = append(out, i18n.T(option.Label))
 	}
 
 	return out
@@ -985,7 +1015,7 @@ func nodePositionParseEnumLabel(fieldName, selected string, options []nodePositi
 		return 0, fmt.Errorf("%s must be selected", fieldName)
 	}
 	for _, option := range options {
-		if option.Label == selected {
+		if i18n.T(option.Label) == selected {
 			return option.Value, nil
 		}
 	}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseNodePositionCoordinateFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		parse   func(fieldName, raw string) (float64, error)
+		raw     string
+		want    float64
+		wantErr bool
+	}{
+		{name: "decimal latitude", parse: parseNodePositionLatitudeField, raw: " 50.450333 ", want: 50.450333},
+		{name: "dms latitude", parse: parseNodePositionLatitudeField, raw: `50°27'01.2"N`, want: 50.450333},
+		{name: "dms western longitude", parse: parseNodePositionLongitudeField, raw: `77°02'06.7"W`, want: -77.035194},
+		{name: "empty latitude", parse: parseNodePositionLatitudeField, raw: "", wantErr: true},
+		{name: "latitude out of range", parse: parseNodePositionLatitudeField, raw: "91", wantErr: true},
+		{name: "longitude given as latitude", parse: parseNodePositionLatitudeField, raw: `30°31'24"E`, wantErr: true},
+		{name: "not a number", parse: parseNodePositionLongitudeField, raw: "east", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.parse("fixed coordinate", tc.raw)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if math.Abs(got-tc.want) > 1e-6 {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatNodePositionCoordinateRoundsToFirmwareResolution(t *testing.T) {
+	if got := formatNodePositionCoordinate(38.889512345678); got != "38.8895123" {
+		t.Fatalf("unexpected coordinate text: %q", got)
+	}
+}
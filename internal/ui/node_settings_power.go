@@ -15,18 +15,19 @@ import (
 
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodePowerSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) (fyne.CanvasObject, func()) {
 	const pageID = "device.power"
 	nodeSettingsTabLogger.Debug("building node power settings page", "page_id", pageID, "service_configured", dep.Actions.NodeSettings != nil)
 
-	controls := newNodeSettingsPageControls("Loading power settings…")
+	controls := newNodeSettingsPageControls(i18n.T("Loading power settings…"))
 	saveButton := controls.saveButton
 	cancelButton := controls.cancelButton
 	reloadButton := controls.reloadButton
 
-	nodeIDLabel := widget.NewLabel("unknown")
+	nodeIDLabel := widget.NewLabel(i18n.T("unknown"))
 	nodeIDLabel.TextStyle = fyne.TextStyle{Monospace: true}
 
 	powerSavingBox := widget.NewCheck("", nil)
@@ -39,15 +40,15 @@ func newNodePowerSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSav
 	deviceBatteryINAAddressEntry := widget.NewEntry()
 
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeIDLabel),
-		widget.NewFormItem("Enable power saving mode", powerSavingBox),
-		widget.NewFormItem("Shutdown on power loss", shutdownOnPowerLossSelect),
-		widget.NewFormItem("ADC multiplier override", adcMultiplierOverrideBox),
-		widget.NewFormItem("ADC multiplier override ratio", adcMultiplierOverrideEntry),
-		widget.NewFormItem("Wait for Bluetooth duration", waitBluetoothSecsSelect),
-		widget.NewFormItem("Super deep sleep duration", superDeepSleepSecsSelect),
-		widget.NewFormItem("Minimum wake time", minWakeSecsSelect),
-		widget.NewFormItem("Battery INA 2xx I2C address", deviceBatteryINAAddressEntry),
+		widget.NewFormItem(i18n.T("Node ID"), nodeIDLabel),
+		widget.NewFormItem(i18n.T("Enable power saving mode"), powerSavingBox),
+		widget.NewFormItem(i18n.T("Shutdown on power loss"), shutdownOnPowerLossSelect),
+		widget.NewFormItem(i18n.T("ADC multiplier override"), adcMultiplierOverrideBox),
+		widget.NewFormItem(i18n.T("ADC multiplier override ratio"), adcMultiplierOverrideEntry),
+		widget.NewFormItem(i18n.T("Wait for Bluetooth duration"), waitBluetoothSecsSelect),
+		widget.NewFormItem(i18n.T("Super deep sleep duration"), superDeepSleepSecsSelect),
+		widget.NewFormItem(i18n.T("Minimum wake time"), minWakeSecsSelect),
+		widget.NewFormItem(i18n.T("Battery INA 2xx I2C address"), deviceBatteryINAAddressEntry),
 	)
 
 	var (
@@ -248,25 +249,25 @@ func newNodePowerSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSav
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node power settings reload failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Reload failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Reload failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node power settings reload unavailable: service is not configured", "page_id", pageID)
-			controls.SetStatus("Reload is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Reload is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node power settings reload blocked: device is disconnected", "page_id", pageID, "node_id", target.NodeID)
-			controls.SetStatus("Reload from device is unavailable while disconnected.", 0, 2)
+			controls.SetStatus(i18n.T("Reload from device is unavailable while disconnected."), 0, 2)
 
 			return
 		}
 
 		nodeSettingsTabLogger.Info("reloading node power settings from device", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Reloading power settings from device…", 1, 2)
+		controls.SetStatus(i18n.T("Reloading power settings from device…"), 1, 2)
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), nodeSettingsOpTimeout)
 			defer cancel()
@@ -274,14 +275,14 @@ func newNodePowerSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSav
 			fyne.Do(func() {
 				if err != nil {
 					nodeSettingsTabLogger.Warn("reloading node power settings from device failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Reload failed: "+err.Error(), 0, 2)
+					controls.SetStatus(i18n.Tf("Reload failed: %s", err), 0, 2)
 					updateButtons()
 
 					return
 				}
 				applyLoadedSettings(loaded)
 				nodeSettingsTabLogger.Info("reloaded node power settings from device", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Reloaded power settings from device.", 2, 2)
+				controls.SetStatus(i18n.T("Reloaded power settings from device."), 2, 2)
 			})
 		}()
 	}
@@ -311,7 +312,7 @@ func newNodePowerSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSav
 		dirty = false
 		mu.Unlock()
 		applyForm(settings)
-		controls.SetStatus("Local edits reverted.", 1, 1)
+		controls.SetStatus(i18n.T("Local edits reverted."), 1, 1)
 		updateButtons()
 	}
 
@@ -319,13 +320,13 @@ func newNodePowerSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSav
 		nodeSettingsTabLogger.Info("node power settings save requested", "page_id", pageID)
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node power settings save unavailable: service is not configured")
-			controls.SetStatus("Save is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node power settings save blocked: device is disconnected", "page_id", pageID)
-			controls.SetStatus("Save is unavailable while disconnected.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable while disconnected."), 0, 1)
 			updateButtons()
 
 			return
@@ -333,13 +334,13 @@ func newNodePowerSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSav
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node power settings save failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Save failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Save failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if saveGate != nil && !saveGate.TryAcquire(pageID) {
 			nodeSettingsTabLogger.Info("node power settings save blocked: another page save is active", "page_id", pageID, "active_page", saveGate.ActivePage())
-			controls.SetStatus("Another settings save is in progress on a different page.", 0, 1)
+			controls.SetStatus(i18n.T("Another settings save is in progress on a different page."), 0, 1)
 			updateButtons()
 
 			return
@@ -351,7 +352,7 @@ func newNodePowerSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSav
 				saveGate.Release(pageID)
 			}
 			nodeSettingsTabLogger.Warn("node power settings save failed: invalid form values", "page_id", pageID, "error", err)
-			controls.SetStatus("Save failed: "+err.Error(), 0, 1)
+			controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 1)
 			updateButtons()
 
 			return
@@ -361,7 +362,7 @@ func newNodePowerSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSav
 		saving = true
 		mu.Unlock()
 		nodeSettingsTabLogger.Info("saving node power settings", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Saving power settings…", 1, 3)
+		controls.SetStatus(i18n.T("Saving power settings…"), 1, 3)
 		updateButtons()
 
 		go func(settings app.NodePowerSettings) {
@@ -376,7 +377,7 @@ func newNodePowerSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSav
 				}
 				if err != nil {
 					nodeSettingsTabLogger.Warn("saving node power settings failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Save failed: "+err.Error(), 0, 3)
+					controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 3)
 					mu.Unlock()
 					updateButtons()
 
@@ -390,7 +391,7 @@ func newNodePowerSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSav
 
 				applyForm(applied)
 				nodeSettingsTabLogger.Info("saved node power settings", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Saved power settings.", 3, 3)
+				controls.SetStatus(i18n.T("Saved power settings."), 3, 3)
 				updateButtons()
 			})
 		}(next)
@@ -409,9 +410,9 @@ func newNodePowerSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSav
 	}
 	applyLoadedSettings(initial)
 	if dep.Actions.NodeSettings == nil {
-		controls.SetStatus("Power settings are unavailable: node settings service is not configured.", 0, 1)
+		controls.SetStatus(i18n.T("Power settings are unavailable: node settings service is not configured."), 0, 1)
 	} else {
-		controls.SetStatus("Power settings will load when this tab is opened.", 0, 1)
+		controls.SetStatus(i18n.T("Power settings will load when this tab is opened."), 0, 1)
 	}
 
 	if dep.Data.Bus != nil {
@@ -429,7 +430,7 @@ func newNodePowerSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSav
 	updateButtons()
 
 	content := container.NewVBox(
-		widget.NewLabel("Power settings are loaded from and saved to the connected local node."),
+		widget.NewLabel(i18n.T("Power settings are loaded from and saved to the connected local node.")),
 		form,
 	)
 
@@ -542,10 +543,10 @@ var nodePowerAllIntervalOptions = []nodeSettingsUint32Option{
 
 func nodePowerAllIntervalCustomLabel(value uint32) string {
 	if value == math.MaxInt32 {
-		return "Always on"
+		return i18n.T("Always on")
 	}
 	if value == 0 {
-		return "Unset"
+		return i18n.T("Unset")
 	}
 
 	return nodeSettingsCustomSecondsLabel(value)
@@ -557,10 +558,10 @@ func nodePowerAllIntervalLabel(value uint32) string {
 
 func nodePowerParseAllIntervalSelectLabel(fieldName string, selected string) (uint32, error) {
 	selected = strings.TrimSpace(selected)
-	if strings.EqualFold(selected, "Always on") {
+	if strings.EqualFold(selected, i18n.T("Always on")) {
 		return math.MaxInt32, nil
 	}
-	if strings.EqualFold(selected, "Unset") {
+	if strings.EqualFold(selected, i18n.T("Unset")) {
 		return 0, nil
 	}
 
@@ -12,6 +12,7 @@ import (
 
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 const (
@@ -31,12 +32,12 @@ func newNodeRangeTestSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 	const pageID = "module.range_test"
 	nodeSettingsTabLogger.Debug("building node range test settings page", "page_id", pageID, "service_configured", dep.Actions.NodeSettings != nil)
 
-	controls := newNodeSettingsPageControls("Loading range test settings…")
+	controls := newNodeSettingsPageControls(i18n.T("Loading range test settings…"))
 	saveButton := controls.saveButton
 	cancelButton := controls.cancelButton
 	reloadButton := controls.reloadButton
 
-	nodeIDLabel := widget.NewLabel("unknown")
+	nodeIDLabel := widget.NewLabel(i18n.T("unknown"))
 	nodeIDLabel.TextStyle = fyne.TextStyle{Monospace: true}
 
 	enabledBox := widget.NewCheck("", nil)
@@ -44,10 +45,10 @@ func newNodeRangeTestSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 	saveCSVBox := widget.NewCheck("", nil)
 
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeIDLabel),
-		widget.NewFormItem("Range test enabled", enabledBox),
-		widget.NewFormItem("Sender message interval", senderIntervalSelect),
-		widget.NewFormItem("Save CSV in storage (ESP32 only)", saveCSVBox),
+		widget.NewFormItem(i18n.T("Node ID"), nodeIDLabel),
+		widget.NewFormItem(i18n.T("Range test enabled"), enabledBox),
+		widget.NewFormItem(i18n.T("Sender message interval"), senderIntervalSelect),
+		widget.NewFormItem(i18n.T("Save CSV in storage (ESP32 only)"), saveCSVBox),
 	)
 
 	var (
@@ -161,25 +162,25 @@ func newNodeRangeTestSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node range test settings reload failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Reload failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Reload failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node range test settings reload unavailable: service is not configured", "page_id", pageID)
-			controls.SetStatus("Reload is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Reload is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node range test settings reload blocked: device is disconnected", "page_id", pageID, "node_id", target.NodeID)
-			controls.SetStatus("Reload from device is unavailable while disconnected.", 0, 2)
+			controls.SetStatus(i18n.T("Reload from device is unavailable while disconnected."), 0, 2)
 
 			return
 		}
 
 		nodeSettingsTabLogger.Info("reloading node range test settings from device", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Reloading range test settings from device…", 1, 2)
+		controls.SetStatus(i18n.T("Reloading range test settings from device…"), 1, 2)
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), nodeSettingsOpTimeout)
 			defer cancel()
@@ -187,14 +188,14 @@ func newNodeRangeTestSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 			fyne.Do(func() {
 				if err != nil {
 					nodeSettingsTabLogger.Warn("reloading node range test settings from device failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Reload failed: "+err.Error(), 0, 2)
+					controls.SetStatus(i18n.Tf("Reload failed: %s", err), 0, 2)
 					updateButtons()
 
 					return
 				}
 				applyLoadedSettings(loaded)
 				nodeSettingsTabLogger.Info("reloaded node range test settings from device", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Reloaded range test settings from device.", 2, 2)
+				controls.SetStatus(i18n.T("Reloaded range test settings from device."), 2, 2)
 			})
 		}()
 	}
@@ -210,7 +211,7 @@ func newNodeRangeTestSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 		dirty = false
 		mu.Unlock()
 		applyForm(settings)
-		controls.SetStatus("Local edits reverted.", 1, 1)
+		controls.SetStatus(i18n.T("Local edits reverted."), 1, 1)
 		updateButtons()
 	}
 
@@ -218,13 +219,13 @@ func newNodeRangeTestSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 		nodeSettingsTabLogger.Info("node range test settings save requested", "page_id", pageID)
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node range test settings save unavailable: service is not configured")
-			controls.SetStatus("Save is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node range test settings save blocked: device is disconnected", "page_id", pageID)
-			controls.SetStatus("Save is unavailable while disconnected.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable while disconnected."), 0, 1)
 			updateButtons()
 
 			return
@@ -232,13 +233,13 @@ func newNodeRangeTestSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node range test settings save failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Save failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Save failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if saveGate != nil && !saveGate.TryAcquire(pageID) {
 			nodeSettingsTabLogger.Info("node range test settings save blocked: another page save is active", "page_id", pageID, "active_page", saveGate.ActivePage())
-			controls.SetStatus("Another settings save is in progress on a different page.", 0, 1)
+			controls.SetStatus(i18n.T("Another settings save is in progress on a different page."), 0, 1)
 			updateButtons()
 
 			return
@@ -250,7 +251,7 @@ func newNodeRangeTestSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 				saveGate.Release(pageID)
 			}
 			nodeSettingsTabLogger.Warn("node range test settings save failed: invalid form values", "page_id", pageID, "error", err)
-			controls.SetStatus("Save failed: "+err.Error(), 0, 1)
+			controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 1)
 			updateButtons()
 
 			return
@@ -260,7 +261,7 @@ func newNodeRangeTestSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 		saving = true
 		mu.Unlock()
 		nodeSettingsTabLogger.Info("saving node range test settings", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Saving range test settings…", 1, 3)
+		controls.SetStatus(i18n.T("Saving range test settings…"), 1, 3)
 		updateButtons()
 
 		go func(settings app.NodeRangeTestSettings) {
@@ -275,7 +276,7 @@ func newNodeRangeTestSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 				}
 				if err != nil {
 					nodeSettingsTabLogger.Warn("saving node range test settings failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Save failed: "+err.Error(), 0, 3)
+					controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 3)
 					mu.Unlock()
 					updateButtons()
 
@@ -289,7 +290,7 @@ func newNodeRangeTestSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 
 				applyForm(applied)
 				nodeSettingsTabLogger.Info("saved node range test settings", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Saved range test settings.", 3, 3)
+				controls.SetStatus(i18n.T("Saved range test settings."), 3, 3)
 				updateButtons()
 			})
 		}(next)
@@ -306,9 +307,9 @@ func newNodeRangeTestSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 	}
 	applyLoadedSettings(initial)
 	if dep.Actions.NodeSettings == nil {
-		controls.SetStatus("Range test settings are unavailable: node settings service is not configured.", 0, 1)
+		controls.SetStatus(i18n.T("Range test settings are unavailable: node settings service is not configured."), 0, 1)
 	} else {
-		controls.SetStatus("Range test settings will load when this tab is opened.", 0, 1)
+		controls.SetStatus(i18n.T("Range test settings will load when this tab is opened."), 0, 1)
 	}
 
 	if dep.Data.Bus != nil {
@@ -326,7 +327,7 @@ func newNodeRangeTestSettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 	updateButtons()
 
 	content := container.NewVBox(
-		widget.NewLabel("Range test module settings are loaded from and saved to the connected local node."),
+		widget.NewLabel(i18n.T("Range test module settings are loaded from and saved to the connected local node.")),
 		form,
 	)
 
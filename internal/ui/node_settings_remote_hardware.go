@@ -10,11 +10,22 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
+	generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
 )
 
+// Firmware stores pin names in a fixed-size buffer.
+const nodeRemoteHardwarePinNameMaxLen = 14
+
+var nodeRemoteHardwarePinTypeLabels = map[int32]string{
+	int32(generated.RemoteHardwarePinType_UNKNOWN):       "",
+	int32(generated.RemoteHardwarePinType_DIGITAL_READ):  "read",
+	int32(generated.RemoteHardwarePinType_DIGITAL_WRITE): "write",
+}
+
 func newNodeRemoteHardwareSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) (fyne.CanvasObject, func()) {
 	return newManagedNodeSettingsPage(
-		dep, saveGate, "module.remote_hardware", "Loading remote hardware settings…", "Remote hardware settings loaded.",
+		dep, saveGate, "module.remote_hardware", i18n.T("Loading remote hardware settings…"), i18n.T("Remote hardware settings loaded."),
 		func(ctx context.Context, target app.NodeSettingsTarget) (app.NodeRemoteHardwareSettings, error) {
 			return dep.Actions.NodeSettings.LoadRemoteHardwareSettings(ctx, target)
 		},
@@ -23,29 +34,26 @@ func newNodeRemoteHardwareSettingsPage(dep RuntimeDependencies, saveGate *nodeSe
 		},
 		cloneNodeRemoteHardwareSettings,
 		func(v app.NodeRemoteHardwareSettings) string {
-			parts := make([]string, 0, len(v.AvailablePins))
-			for _, pin := range v.AvailablePins {
-				parts = append(parts, strconv.FormatUint(uint64(pin), 10))
-			}
-
-			return fmt.Sprintf("%s|%t|%t|%s", v.NodeID, v.Enabled, v.AllowUndefinedPinAccess, strings.Join(parts, ","))
+			return fmt.Sprintf("%s|%t|%t|%s", v.NodeID, v.Enabled, v.AllowUndefinedPinAccess, formatNodeRemoteHardwarePins(v.AvailablePins))
 		},
 		buildNodeRemoteHardwareSettingsForm,
 	)
 }
 
 func buildNodeRemoteHardwareSettingsForm(onChanged func()) nodeManagedSettingsForm[app.NodeRemoteHardwareSettings] {
-	nodeID := widget.NewLabel("unknown")
+	nodeID := widget.NewLabel(i18n.T("unknown"))
 	nodeID.TextStyle = fyne.TextStyle{Monospace: true}
 	enabled := newSettingsCheck(onChanged)
 	allowUndefined := newSettingsCheck(onChanged)
-	availablePins := widget.NewEntry()
+	availablePins := widget.NewMultiLineEntry()
+	availablePins.SetMinRowsVisible(4)
+	availablePins.SetPlaceHolder("12, Gate, write\n13, Door sensor, read")
 	availablePins.OnChanged = func(string) { onChanged() }
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeID),
-		widget.NewFormItem("Enabled", enabled),
-		widget.NewFormItem("Allow undefined pin access", allowUndefined),
-		widget.NewFormItem("Available pins", availablePins),
+		widget.NewFormItem(i18n.T("Node ID"), nodeID),
+		widget.NewFormItem(i18n.T("Enabled"), enabled),
+		widget.NewFormItem(i18n.T("Allow undefined pin access"), allowUndefined),
+		widget.NewFormItem(i18n.T("Available pins (GPIO, name, read/write; one per line)"), availablePins),
 	)
 
 	return nodeManagedSettingsForm[app.NodeRemoteHardwareSettings]{
@@ -54,17 +62,13 @@ func buildNodeRemoteHardwareSettingsForm(onChanged func()) nodeManagedSettingsFo
 			nodeID.SetText(orUnknown(v.NodeID))
 			enabled.SetChecked(v.Enabled)
 			allowUndefined.SetChecked(v.AllowUndefinedPinAccess)
-			parts := make([]string, 0, len(v.AvailablePins))
-			for _, pin := range v.AvailablePins {
-				parts = append(parts, strconv.FormatUint(uint64(pin), 10))
-			}
-			availablePins.SetText(strings.Join(parts, ", "))
+			availablePins.SetText(formatNodeRemoteHardwarePins(v.AvailablePins))
 		},
 		read: func(base app.NodeRemoteHardwareSettings, target app.NodeSettingsTarget) (app.NodeRemoteHardwareSettings, error) {
 			base.NodeID = strings.TrimSpace(target.NodeID)
 			base.Enabled = enabled.Checked
 			base.AllowUndefinedPinAccess = allowUndefined.Checked
-			pins, err := parseUint32List(availablePins.Text)
+			pins, err := parseNodeRemoteHardwarePins(availablePins.Text)
 			if err != nil {
 				return app.NodeRemoteHardwareSettings{}, fieldParseError("available pins", err)
 			}
@@ -75,3 +79,68 @@ func buildNodeRemoteHardwareSettingsForm(onChanged func()) nodeManagedSettingsFo
 		setSaving: disableWidgets(enabled, allowUndefined, availablePins),
 	}
 }
+
+func formatNodeRemoteHardwarePins(pins []app.NodeRemoteHardwarePin) string {
+	lines := make([]string, 0, len(pins))
+	for _, pin := range pins {
+		parts := []string{strconv.FormatUint(uint64(pin.GPIOPin), 10)}
+		name := strings.TrimSpace(pin.Name)
+		typeLabel := nodeRemoteHardwarePinTypeLabels[pin.Type]
+		if name != "" || typeLabel != "" {
+			parts = append(parts, name)
+		}
+		if typeLabel != "" {
+			parts = append(parts, typeLabel)
+		}
+		lines = append(lines, strings.Join(parts, ", "))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// parseNodeRemoteHardwarePins parses "GPIO[, name[, read|write]]" lines.
+func parseNodeRemoteHardwarePins(raw string) ([]app.NodeRemoteHardwarePin, error) {
+	var out []app.NodeRemoteHardwarePin
+	for i, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		parts := strings.Split(line, ",")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("line %d: expected GPIO, name and type", i+1)
+		}
+		gpio, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid GPIO number %q", i+1, strings.TrimSpace(parts[0]))
+		}
+		pin := app.NodeRemoteHardwarePin{GPIOPin: uint32(gpio)}
+		if len(parts) > 1 {
+			pin.Name = strings.TrimSpace(parts[1])
+			if len(pin.Name) > nodeRemoteHardwarePinNameMaxLen {
+				return nil, fmt.Errorf("line %d: name must be at most %d bytes", i+1, nodeRemoteHardwarePinNameMaxLen)
+			}
+		}
+		if len(parts) > 2 {
+			pinType, ok := parseNodeRemoteHardwarePinType(parts[2])
+			if !ok {
+				return nil, fmt.Errorf("line %d: type must be read or write", i+1)
+			}
+			pin.Type = pinType
+		}
+		out = append(out, pin)
+	}
+
+	return out, nil
+}
+
+func parseNodeRemoteHardwarePinType(raw string) (int32, bool) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	for value, label := range nodeRemoteHardwarePinTypeLabels {
+		if label == raw {
+			return value, true
+		}
+	}
+
+	return 0, false
+}
@@ -13,18 +13,19 @@ import (
 
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) (fyne.CanvasObject, func()) {
 	const pageID = "radio.security"
 	nodeSettingsTabLogger.Debug("building node security settings page", "page_id", pageID, "service_configured", dep.Actions.NodeSettings != nil)
 
-	controls := newNodeSettingsPageControls("Loading security settings…")
+	controls := newNodeSettingsPageControls(i18n.T("Loading security settings…"))
 	saveButton := controls.saveButton
 	cancelButton := controls.cancelButton
 	reloadButton := controls.reloadButton
 
-	nodeIDLabel := widget.NewLabel("unknown")
+	nodeIDLabel := widget.NewLabel(i18n.T("unknown"))
 	nodeIDLabel.TextStyle = fyne.TextStyle{Monospace: true}
 
 	publicKeyEntry := widget.NewEntry()
@@ -32,8 +33,8 @@ func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 	privateKeyEntry := widget.NewEntry()
 	privateKeyEntry.Password = true
 	privateKeyEntry.Disable()
-	publicKeyCopyButton := widget.NewButton("Copy", nil)
-	privateKeyCopyButton := widget.NewButton("Copy", nil)
+	publicKeyCopyButton := widget.NewButton(i18n.T("Copy"), nil)
+	privateKeyCopyButton := widget.NewButton(i18n.T("Copy"), nil)
 	adminKeysEntry := widget.NewMultiLineEntry()
 	adminKeysEntry.SetMinRowsVisible(3)
 
@@ -46,14 +47,14 @@ func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 	privateKeyField := container.NewBorder(nil, nil, nil, privateKeyCopyButton, privateKeyEntry)
 
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeIDLabel),
-		widget.NewFormItem("Public key (read-only)", publicKeyField),
-		widget.NewFormItem("Private key (read-only)", privateKeyField),
-		widget.NewFormItem("Admin keys (base64, one key per line)", adminKeysEntry),
-		widget.NewFormItem("Managed mode", managedBox),
-		widget.NewFormItem("Serial console over Stream API", serialEnabledBox),
-		widget.NewFormItem("Debug log over API", debugLogAPIBox),
-		widget.NewFormItem("Legacy admin channel", adminChannelBox),
+		widget.NewFormItem(i18n.T("Node ID"), nodeIDLabel),
+		widget.NewFormItem(i18n.T("Public key (read-only)"), publicKeyField),
+		widget.NewFormItem(i18n.T("Private key (read-only)"), privateKeyField),
+		widget.NewFormItem(i18n.T("Admin keys (base64, one key per line)"), adminKeysEntry),
+		widget.NewFormItem(i18n.T("Managed mode"), managedBox),
+		widget.NewFormItem(i18n.T("Serial console over Stream API"), serialEnabledBox),
+		widget.NewFormItem(i18n.T("Debug log over API"), debugLogAPIBox),
+		widget.NewFormItem(i18n.T("Legacy admin channel"), adminChannelBox),
 	)
 
 	var (
@@ -201,25 +202,25 @@ func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node security settings reload failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Reload failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Reload failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node security settings reload unavailable: service is not configured", "page_id", pageID)
-			controls.SetStatus("Reload is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Reload is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node security settings reload blocked: device is disconnected", "page_id", pageID, "node_id", target.NodeID)
-			controls.SetStatus("Reload from device is unavailable while disconnected.", 0, 2)
+			controls.SetStatus(i18n.T("Reload from device is unavailable while disconnected."), 0, 2)
 
 			return
 		}
 
 		nodeSettingsTabLogger.Info("reloading node security settings from device", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Reloading security settings from device…", 1, 2)
+		controls.SetStatus(i18n.T("Reloading security settings from device…"), 1, 2)
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), nodeSettingsOpTimeout)
 			defer cancel()
@@ -227,14 +228,14 @@ func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 			fyne.Do(func() {
 				if err != nil {
 					nodeSettingsTabLogger.Warn("reloading node security settings from device failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Reload failed: "+err.Error(), 0, 2)
+					controls.SetStatus(i18n.Tf("Reload failed: %s", err), 0, 2)
 					updateButtons()
 
 					return
 				}
 				applyLoadedSettings(loaded)
 				nodeSettingsTabLogger.Info("reloaded node security settings from device", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Reloaded security settings from device.", 2, 2)
+				controls.SetStatus(i18n.T("Reloaded security settings from device."), 2, 2)
 			})
 		}()
 	}
@@ -246,19 +247,19 @@ func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 	adminChannelBox.OnChanged = func(_ bool) { markDirty() }
 	publicKeyCopyButton.OnTapped = func() {
 		if err := copyTextToClipboard(publicKeyEntry.Text); err != nil {
-			controls.SetStatus("Copy failed: "+err.Error(), 0, 1)
+			controls.SetStatus(i18n.Tf("Copy failed: %s", err), 0, 1)
 
 			return
 		}
-		controls.SetStatus("Public key copied.", 1, 1)
+		controls.SetStatus(i18n.T("Public key copied."), 1, 1)
 	}
 	privateKeyCopyButton.OnTapped = func() {
 		if err := copyTextToClipboard(privateKeyEntry.Text); err != nil {
-			controls.SetStatus("Copy failed: "+err.Error(), 0, 1)
+			controls.SetStatus(i18n.Tf("Copy failed: %s", err), 0, 1)
 
 			return
 		}
-		controls.SetStatus("Private key copied.", 1, 1)
+		controls.SetStatus(i18n.T("Private key copied."), 1, 1)
 	}
 
 	cancelButton.OnTapped = func() {
@@ -268,7 +269,7 @@ func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		dirty = false
 		mu.Unlock()
 		applyForm(settings)
-		controls.SetStatus("Local edits reverted.", 1, 1)
+		controls.SetStatus(i18n.T("Local edits reverted."), 1, 1)
 		updateButtons()
 	}
 
@@ -276,13 +277,13 @@ func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		nodeSettingsTabLogger.Info("node security settings save requested", "page_id", pageID)
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node security settings save unavailable: service is not configured")
-			controls.SetStatus("Save is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node security settings save blocked: device is disconnected", "page_id", pageID)
-			controls.SetStatus("Save is unavailable while disconnected.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable while disconnected."), 0, 1)
 			updateButtons()
 
 			return
@@ -290,13 +291,13 @@ func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node security settings save failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Save failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Save failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if saveGate != nil && !saveGate.TryAcquire(pageID) {
 			nodeSettingsTabLogger.Info("node security settings save blocked: another page save is active", "page_id", pageID, "active_page", saveGate.ActivePage())
-			controls.SetStatus("Another settings save is in progress on a different page.", 0, 1)
+			controls.SetStatus(i18n.T("Another settings save is in progress on a different page."), 0, 1)
 			updateButtons()
 
 			return
@@ -308,7 +309,7 @@ func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 				saveGate.Release(pageID)
 			}
 			nodeSettingsTabLogger.Warn("node security settings save failed: invalid form values", "page_id", pageID, "error", err)
-			controls.SetStatus("Save failed: "+err.Error(), 0, 1)
+			controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 1)
 			updateButtons()
 
 			return
@@ -318,7 +319,7 @@ func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 		saving = true
 		mu.Unlock()
 		nodeSettingsTabLogger.Info("saving node security settings", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Saving security settings…", 1, 3)
+		controls.SetStatus(i18n.T("Saving security settings…"), 1, 3)
 		updateButtons()
 
 		go func(settings app.NodeSecuritySettings) {
@@ -333,7 +334,7 @@ func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 				}
 				if err != nil {
 					nodeSettingsTabLogger.Warn("saving node security settings failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Save failed: "+err.Error(), 0, 3)
+					controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 3)
 					mu.Unlock()
 					updateButtons()
 
@@ -347,7 +348,7 @@ func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 
 				applyForm(applied)
 				nodeSettingsTabLogger.Info("saved node security settings", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Saved security settings.", 3, 3)
+				controls.SetStatus(i18n.T("Saved security settings."), 3, 3)
 				updateButtons()
 			})
 		}(next)
@@ -364,9 +365,9 @@ func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 	}
 	applyLoadedSettings(initial)
 	if dep.Actions.NodeSettings == nil {
-		controls.SetStatus("Security settings are unavailable: node settings service is not configured.", 0, 1)
+		controls.SetStatus(i18n.T("Security settings are unavailable: node settings service is not configured."), 0, 1)
 	} else {
-		controls.SetStatus("Security settings will load when this tab is opened.", 0, 1)
+		controls.SetStatus(i18n.T("Security settings will load when this tab is opened."), 0, 1)
 	}
 
 	if dep.Data.Bus != nil {
@@ -383,11 +384,11 @@ func newNodeSecuritySettingsPage(dep RuntimeDependencies, saveGate *nodeSettings
 	}
 	updateButtons()
 
-	adminKeysHint := widget.NewLabel("Use base64-encoded admin public keys, one key per line. Up to 3 keys are supported.")
+	adminKeysHint := widget.NewLabel(i18n.T("Use base64-encoded admin public keys, one key per line. Up to 3 keys are supported."))
 	adminKeysHint.Wrapping = fyne.TextWrapWord
 
 	content := container.NewVBox(
-		widget.NewLabel("Security settings are loaded from and saved to the connected local node."),
+		widget.NewLabel(i18n.T("Security settings are loaded from and saved to the connected local node.")),
 		adminKeysHint,
 		form,
 	)
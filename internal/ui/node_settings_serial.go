@@ -10,11 +10,12 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodeSerialSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) (fyne.CanvasObject, func()) {
 	return newManagedNodeSettingsPage(
-		dep, saveGate, "module.serial", "Loading serial settings…", "Serial settings loaded.",
+		dep, saveGate, "module.serial", i18n.T("Loading serial settings…"), i18n.T("Serial settings loaded."),
 		func(ctx context.Context, target app.NodeSettingsTarget) (app.NodeSerialSettings, error) {
 			return dep.Actions.NodeSettings.LoadSerialSettings(ctx, target)
 		},
@@ -30,7 +31,7 @@ func newNodeSerialSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSa
 }
 
 func buildNodeSerialSettingsForm(onChanged func()) nodeManagedSettingsForm[app.NodeSerialSettings] {
-	nodeID := widget.NewLabel("unknown")
+	nodeID := widget.NewLabel(i18n.T("unknown"))
 	nodeID.TextStyle = fyne.TextStyle{Monospace: true}
 	enabled := newSettingsCheck(onChanged)
 	echoEnabled := newSettingsCheck(onChanged)
@@ -43,15 +44,15 @@ func buildNodeSerialSettingsForm(onChanged func()) nodeManagedSettingsForm[app.N
 	mode.OnChanged = func(string) { onChanged() }
 	overrideConsole := newSettingsCheck(onChanged)
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeID),
-		widget.NewFormItem("Enabled", enabled),
-		widget.NewFormItem("Echo enabled", echoEnabled),
-		widget.NewFormItem("RX GPIO", rxGPIO),
-		widget.NewFormItem("TX GPIO", txGPIO),
-		widget.NewFormItem("Baud", baud),
-		widget.NewFormItem("Timeout", timeout),
-		widget.NewFormItem("Mode", mode),
-		widget.NewFormItem("Override console serial port", overrideConsole),
+		widget.NewFormItem(i18n.T("Node ID"), nodeID),
+		widget.NewFormItem(i18n.T("Enabled"), enabled),
+		widget.NewFormItem(i18n.T("Echo enabled"), echoEnabled),
+		widget.NewFormItem(i18n.T("RX GPIO"), rxGPIO),
+		widget.NewFormItem(i18n.T("TX GPIO"), txGPIO),
+		widget.NewFormItem(i18n.T("Baud"), baud),
+		widget.NewFormItem(i18n.T("Timeout"), timeout),
+		widget.NewFormItem(i18n.T("Mode"), mode),
+		widget.NewFormItem(i18n.T("Override console serial port"), overrideConsole),
 	)
 
 	return nodeManagedSettingsForm[app.NodeSerialSettings]{
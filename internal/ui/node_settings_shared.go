@@ -11,6 +11,7 @@ import (
 
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 	"github.com/skobkin/meshgo/internal/radio/busmsg"
 )
 
@@ -124,7 +125,7 @@ func copyTextToClipboard(value string) error {
 func orUnknown(v string) string {
 	v = strings.TrimSpace(v)
 	if v == "" {
-		return "unknown"
+		return i18n.T("unknown")
 	}
 
 	return v
@@ -8,11 +8,12 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodeStatusMessageSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) (fyne.CanvasObject, func()) {
 	return newManagedNodeSettingsPage(
-		dep, saveGate, "module.status_message", "Loading status message settings…", "Status message settings loaded.",
+		dep, saveGate, "module.status_message", i18n.T("Loading status message settings…"), i18n.T("Status message settings loaded."),
 		func(ctx context.Context, target app.NodeSettingsTarget) (app.NodeStatusMessageSettings, error) {
 			return dep.Actions.NodeSettings.LoadStatusMessageSettings(ctx, target)
 		},
@@ -26,13 +27,13 @@ func newNodeStatusMessageSettingsPage(dep RuntimeDependencies, saveGate *nodeSet
 }
 
 func buildNodeStatusMessageSettingsForm(onChanged func()) nodeManagedSettingsForm[app.NodeStatusMessageSettings] {
-	nodeID := widget.NewLabel("unknown")
+	nodeID := widget.NewLabel(i18n.T("unknown"))
 	nodeID.TextStyle = fyne.TextStyle{Monospace: true}
 	status := widget.NewMultiLineEntry()
 	status.OnChanged = func(string) { onChanged() }
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeID),
-		widget.NewFormItem("Status", status),
+		widget.NewFormItem(i18n.T("Node ID"), nodeID),
+		widget.NewFormItem(i18n.T("Status"), status),
 	)
 
 	return nodeManagedSettingsForm[app.NodeStatusMessageSettings]{
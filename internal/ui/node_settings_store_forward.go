@@ -10,11 +10,12 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodeStoreForwardSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) (fyne.CanvasObject, func()) {
 	return newManagedNodeSettingsPage(
-		dep, saveGate, "module.store_forward", "Loading Store & Forward settings…", "Store & Forward settings loaded.",
+		dep, saveGate, "module.store_forward", i18n.T("Loading Store & Forward settings…"), i18n.T("Store & Forward settings loaded."),
 		func(ctx context.Context, target app.NodeSettingsTarget) (app.NodeStoreForwardSettings, error) {
 			return dep.Actions.NodeSettings.LoadStoreForwardSettings(ctx, target)
 		},
@@ -30,7 +31,7 @@ func newNodeStoreForwardSettingsPage(dep RuntimeDependencies, saveGate *nodeSett
 }
 
 func buildNodeStoreForwardSettingsForm(onChanged func()) nodeManagedSettingsForm[app.NodeStoreForwardSettings] {
-	nodeID := widget.NewLabel("unknown")
+	nodeID := widget.NewLabel(i18n.T("unknown"))
 	nodeID.TextStyle = fyne.TextStyle{Monospace: true}
 	enabled := newSettingsCheck(onChanged)
 	heartbeat := newSettingsCheck(onChanged)
@@ -39,13 +40,13 @@ func buildNodeStoreForwardSettingsForm(onChanged func()) nodeManagedSettingsForm
 	historyReturnWindow := newNumberEntry(onChanged)
 	isServer := newSettingsCheck(onChanged)
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeID),
-		widget.NewFormItem("Enabled", enabled),
-		widget.NewFormItem("Heartbeat", heartbeat),
-		widget.NewFormItem("Records", records),
-		widget.NewFormItem("History return max", historyReturnMax),
-		widget.NewFormItem("History return window", historyReturnWindow),
-		widget.NewFormItem("Server mode", isServer),
+		widget.NewFormItem(i18n.T("Node ID"), nodeID),
+		widget.NewFormItem(i18n.T("Enabled"), enabled),
+		widget.NewFormItem(i18n.T("Heartbeat"), heartbeat),
+		widget.NewFormItem(i18n.T("Records (0 = firmware default)"), records),
+		widget.NewFormItem(i18n.T("History return max"), historyReturnMax),
+		widget.NewFormItem(i18n.T("History return window (minutes)"), historyReturnWindow),
+		widget.NewFormItem(i18n.T("Server mode"), isServer),
 	)
 
 	return nodeManagedSettingsForm[app.NodeStoreForwardSettings]{
@@ -9,11 +9,12 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodeTelemetrySettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) (fyne.CanvasObject, func()) {
 	return newManagedNodeSettingsPage(
-		dep, saveGate, "module.telemetry", "Loading telemetry settings…", "Telemetry settings loaded.",
+		dep, saveGate, "module.telemetry", i18n.T("Loading telemetry settings…"), i18n.T("Telemetry settings loaded."),
 		func(ctx context.Context, target app.NodeSettingsTarget) (app.NodeTelemetrySettings, error) {
 			return dep.Actions.NodeSettings.LoadTelemetrySettings(ctx, target)
 		},
@@ -33,7 +34,7 @@ func newNodeTelemetrySettingsPage(dep RuntimeDependencies, saveGate *nodeSetting
 }
 
 func buildNodeTelemetrySettingsForm(onChanged func()) nodeManagedSettingsForm[app.NodeTelemetrySettings] {
-	nodeID := widget.NewLabel("unknown")
+	nodeID := widget.NewLabel(i18n.T("unknown"))
 	nodeID.TextStyle = fyne.TextStyle{Monospace: true}
 	deviceUpdateInterval := widget.NewSelect(nil, nil)
 	deviceUpdateInterval.OnChanged = func(string) { onChanged() }
@@ -56,22 +57,22 @@ func buildNodeTelemetrySettingsForm(onChanged func()) nodeManagedSettingsForm[ap
 	deviceTelemetry := newSettingsCheck(onChanged)
 	airQualityScreen := newSettingsCheck(onChanged)
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeID),
-		widget.NewFormItem("Device update interval", deviceUpdateInterval),
-		widget.NewFormItem("Environment update interval", environmentUpdateInterval),
-		widget.NewFormItem("Environment measurement enabled", environmentMeasurement),
-		widget.NewFormItem("Environment screen enabled", environmentScreen),
-		widget.NewFormItem("Display Fahrenheit", displayFahrenheit),
-		widget.NewFormItem("Air quality enabled", airQualityEnabled),
-		widget.NewFormItem("Air quality interval", airQualityInterval),
-		widget.NewFormItem("Power measurement enabled", powerMeasurement),
-		widget.NewFormItem("Power update interval", powerUpdateInterval),
-		widget.NewFormItem("Power screen enabled", powerScreen),
-		widget.NewFormItem("Health measurement enabled", healthMeasurement),
-		widget.NewFormItem("Health update interval", healthUpdateInterval),
-		widget.NewFormItem("Health screen enabled", healthScreen),
-		widget.NewFormItem("Device telemetry enabled", deviceTelemetry),
-		widget.NewFormItem("Air quality screen enabled", airQualityScreen),
+		widget.NewFormItem(i18n.T("Node ID"), nodeID),
+		widget.NewFormItem(i18n.T("Device update interval"), deviceUpdateInterval),
+		widget.NewFormItem(i18n.T("Environment update interval"), environmentUpdateInterval),
+		widget.NewFormItem(i18n.T("Environment measurement enabled"), environmentMeasurement),
+		widget.NewFormItem(i18n.T("Environment screen enabled"), environmentScreen),
+		widget.NewFormItem(i18n.T("Display Fahrenheit"), displayFahrenheit),
+		widget.NewFormItem(i18n.T("Air quality enabled"), airQualityEnabled),
+		widget.NewFormItem(i18n.T("Air quality interval"), airQualityInterval),
+		widget.NewFormItem(i18n.T("Power measurement enabled"), powerMeasurement),
+		widget.NewFormItem(i18n.T("Power update interval"), powerUpdateInterval),
+		widget.NewFormItem(i18n.T("Power screen enabled"), powerScreen),
+		widget.NewFormItem(i18n.T("Health measurement enabled"), healthMeasurement),
+		widget.NewFormItem(i18n.T("Health update interval"), healthUpdateInterval),
+		widget.NewFormItem(i18n.T("Health screen enabled"), healthScreen),
+		widget.NewFormItem(i18n.T("Device telemetry enabled"), deviceTelemetry),
+		widget.NewFormItem(i18n.T("Air quality screen enabled"), airQualityScreen),
 	)
 
 	return nodeManagedSettingsForm[app.NodeTelemetrySettings]{
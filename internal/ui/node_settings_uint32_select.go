@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 const (
@@ -19,34 +21,50 @@ type nodeSettingsUint32Option struct {
 }
 
 func nodeSettingsSecondsKnownLabel(seconds uint32, zeroLabel string) string {
-	if seconds == 0 {
-		if zeroLabel != "" {
-			return zeroLabel
-		}
+	if seconds == 0 && zeroLabel != "" {
+		return zeroLabel
+	}
+
+	return nodeSettingsSecondsText(seconds, fmt.Sprintf)
+}
 
-		return "0 seconds"
+// nodeSettingsSecondsText spells out seconds with format, which is fmt.Sprintf for the
+// option tables and i18n.Tf when the label is shown.
+func nodeSettingsSecondsText(seconds uint32, format func(string, ...any) string) string {
+	if seconds == 0 {
+		return format("0 seconds")
 	}
 	if seconds%3600 == 0 {
 		hours := seconds / 3600
 		if hours == 1 {
-			return "1 hour"
+			return format("1 hour")
 		}
 
-		return fmt.Sprintf("%d hours", hours)
+		return format("%d hours", hours)
 	}
 	if seconds%60 == 0 {
 		minutes := seconds / 60
 		if minutes == 1 {
-			return "1 minute"
+			return format("1 minute")
 		}
 
-		return fmt.Sprintf("%d minutes", minutes)
+		return format("%d minutes", minutes)
 	}
 	if seconds == 1 {
-		return "1 second"
+		return format("1 second")
+	}
+
+	return format("%d seconds", seconds)
+}
+
+// nodeSettingsUint32OptionText returns the option label in the current language. The
+// option tables are built at start-up in English, so labels are translated when shown.
+func nodeSettingsUint32OptionText(option nodeSettingsUint32Option) string {
+	if option.Label == nodeSettingsSecondsText(option.Value, fmt.Sprintf) {
+		return nodeSettingsSecondsText(option.Value, i18n.Tf)
 	}
 
-	return fmt.Sprintf("%d seconds", seconds)
+	return i18n.T(option.Label)
 }
 
 func nodeSettingsCustomSecondsLabel(seconds uint32) string {
@@ -79,7 +97,7 @@ func nodeSettingsParseUint32SelectLabel(
 		return 0, fmt.Errorf("%s must be selected", fieldName)
 	}
 	for _, option := range options {
-		if option.Label == selected {
+		if nodeSettingsUint32OptionText(option) == selected {
 			return option.Value, nil
 		}
 	}
@@ -99,7 +117,7 @@ func nodeSettingsParseUint32SelectLabel(
 func nodeSettingsUint32OptionLabel(value uint32, options []nodeSettingsUint32Option) string {
 	for _, option := range options {
 		if option.Value == value {
-			return option.Label
+			return nodeSettingsUint32OptionText(option)
 		}
 	}
 
@@ -122,7 +140,7 @@ func nodeSettingsSelectCurrentLabel(
 func nodeSettingsUint32OptionLabels(options []nodeSettingsUint32Option) []string {
 	out := make([]string, 0, len(options))
 	for _, option := range options {
-		out = append(out, option.Label)
+		out = append(out, nodeSettingsUint32OptionText(option))
 	}
 
 	return out
@@ -12,18 +12,19 @@ import (
 
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodeUserSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSaveGate) fyne.CanvasObject {
 	const pageID = "device.user"
 	nodeSettingsTabLogger.Debug("building node user settings page", "page_id", pageID, "service_configured", dep.Actions.NodeSettings != nil)
 
-	controls := newNodeSettingsPageControls("Loading local node user settings…")
+	controls := newNodeSettingsPageControls(i18n.T("Loading local node user settings…"))
 	saveButton := controls.saveButton
 	cancelButton := controls.cancelButton
 	reloadButton := controls.reloadButton
 
-	nodeIDLabel := widget.NewLabel("unknown")
+	nodeIDLabel := widget.NewLabel(i18n.T("unknown"))
 	nodeIDLabel.TextStyle = fyne.TextStyle{Monospace: true}
 
 	longNameEntry := widget.NewEntry()
@@ -32,11 +33,11 @@ func newNodeUserSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 	unmessageableBox := widget.NewCheck("", nil)
 
 	form := widget.NewForm(
-		widget.NewFormItem("Node ID", nodeIDLabel),
-		widget.NewFormItem("Long Name", longNameEntry),
-		widget.NewFormItem("Short Name", shortNameEntry),
-		widget.NewFormItem("Licensed amateur radio (HAM)", hamLicensedBox),
-		widget.NewFormItem("Unmessageable", unmessageableBox),
+		widget.NewFormItem(i18n.T("Node ID"), nodeIDLabel),
+		widget.NewFormItem(i18n.T("Long Name"), longNameEntry),
+		widget.NewFormItem(i18n.T("Short Name"), shortNameEntry),
+		widget.NewFormItem(i18n.T("Licensed amateur radio (HAM)"), hamLicensedBox),
+		widget.NewFormItem(i18n.T("Unmessageable"), unmessageableBox),
 	)
 
 	var (
@@ -113,7 +114,7 @@ func newNodeUserSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Debug("local node settings are unavailable: local node ID is unknown")
-			controls.SetStatus("Local node ID is not available yet.", 0, 1)
+			controls.SetStatus(i18n.T("Local node ID is not available yet."), 0, 1)
 
 			return
 		}
@@ -138,7 +139,7 @@ func newNodeUserSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		if shouldApply {
 			applyForm(next)
 			nodeSettingsTabLogger.Debug("loaded node user settings from local store", "node_id", next.NodeID)
-			controls.SetStatus("Loaded local node user settings.", 1, 1)
+			controls.SetStatus(i18n.T("Loaded local node user settings."), 1, 1)
 		}
 		updateButtons()
 	}
@@ -168,7 +169,7 @@ func newNodeUserSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		dirty = false
 		mu.Unlock()
 		applyForm(settings)
-		controls.SetStatus("Local edits reverted.", 1, 1)
+		controls.SetStatus(i18n.T("Local edits reverted."), 1, 1)
 		updateButtons()
 	}
 
@@ -176,13 +177,13 @@ func newNodeUserSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		nodeSettingsTabLogger.Info("node user settings save requested", "page_id", pageID)
 		if dep.Actions.NodeSettings == nil {
 			nodeSettingsTabLogger.Warn("node user settings save unavailable: service is not configured")
-			controls.SetStatus("Save is unavailable: node settings service is not configured.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable: node settings service is not configured."), 0, 1)
 
 			return
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node user settings save blocked: device is disconnected", "page_id", pageID)
-			controls.SetStatus("Save is unavailable while disconnected.", 0, 1)
+			controls.SetStatus(i18n.T("Save is unavailable while disconnected."), 0, 1)
 			updateButtons()
 
 			return
@@ -190,13 +191,13 @@ func newNodeUserSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node user settings save failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Save failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Save failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
 		if saveGate != nil && !saveGate.TryAcquire(pageID) {
 			nodeSettingsTabLogger.Info("node user settings save blocked: another page save is active", "page_id", pageID, "active_page", saveGate.ActivePage())
-			controls.SetStatus("Another settings save is in progress on a different page.", 0, 1)
+			controls.SetStatus(i18n.T("Another settings save is in progress on a different page."), 0, 1)
 			updateButtons()
 
 			return
@@ -208,7 +209,7 @@ func newNodeUserSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		next := current
 		mu.Unlock()
 		nodeSettingsTabLogger.Info("saving node user settings", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Saving user settings…", 1, 3)
+		controls.SetStatus(i18n.T("Saving user settings…"), 1, 3)
 		updateButtons()
 
 		go func(settings app.NodeUserSettings) {
@@ -223,13 +224,13 @@ func newNodeUserSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 				}
 				if err != nil {
 					nodeSettingsTabLogger.Warn("saving node user settings failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Save failed: "+err.Error(), 0, 3)
+					controls.SetStatus(i18n.Tf("Save failed: %s", err), 0, 3)
 				} else {
 					nodeSettingsTabLogger.Info("saved node user settings", "page_id", pageID, "node_id", target.NodeID)
 					baseline = settings
 					current = settings
 					dirty = false
-					controls.SetStatus("Saved user settings.", 3, 3)
+					controls.SetStatus(i18n.T("Saved user settings."), 3, 3)
 				}
 				mu.Unlock()
 				updateButtons()
@@ -242,7 +243,7 @@ func newNodeUserSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		target, ok := localTarget()
 		if !ok {
 			nodeSettingsTabLogger.Warn("node user settings reload failed: local node ID is unknown", "page_id", pageID)
-			controls.SetStatus("Reload failed: local node ID is not known yet.", 0, 1)
+			controls.SetStatus(i18n.T("Reload failed: local node ID is not known yet."), 0, 1)
 
 			return
 		}
@@ -254,13 +255,13 @@ func newNodeUserSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 		}
 		if !isConnected() {
 			nodeSettingsTabLogger.Info("node user settings reload blocked: device is disconnected", "page_id", pageID, "node_id", target.NodeID)
-			controls.SetStatus("Reload from device is unavailable while disconnected.", 0, 2)
+			controls.SetStatus(i18n.T("Reload from device is unavailable while disconnected."), 0, 2)
 
 			return
 		}
 
 		nodeSettingsTabLogger.Info("reloading node user settings from device", "page_id", pageID, "node_id", target.NodeID)
-		controls.SetStatus("Reloading user settings from device…", 1, 2)
+		controls.SetStatus(i18n.T("Reloading user settings from device…"), 1, 2)
 		go func() {
 			ctx, cancel := context.WithTimeout(context.Background(), nodeSettingsOpTimeout)
 			defer cancel()
@@ -268,7 +269,7 @@ func newNodeUserSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 			fyne.Do(func() {
 				if err != nil {
 					nodeSettingsTabLogger.Warn("reloading node user settings from device failed", "page_id", pageID, "node_id", target.NodeID, "error", err)
-					controls.SetStatus("Reload failed: "+err.Error(), 0, 2)
+					controls.SetStatus(i18n.Tf("Reload failed: %s", err), 0, 2)
 					updateButtons()
 
 					return
@@ -282,7 +283,7 @@ func newNodeUserSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 				mu.Unlock()
 				applyForm(settings)
 				nodeSettingsTabLogger.Info("reloaded node user settings from device", "page_id", pageID, "node_id", target.NodeID)
-				controls.SetStatus("Reloaded user settings from device.", 2, 2)
+				controls.SetStatus(i18n.T("Reloaded user settings from device."), 2, 2)
 				updateButtons()
 			})
 		}()
@@ -315,7 +316,7 @@ func newNodeUserSettingsPage(dep RuntimeDependencies, saveGate *nodeSettingsSave
 	updateButtons()
 
 	content := container.NewVBox(
-		widget.NewLabel("User settings can be edited and saved per page. Only one settings save can run at a time."),
+		widget.NewLabel(i18n.T("User settings can be edited and saved per page. Only one settings save can run at a time.")),
 		form,
 	)
 
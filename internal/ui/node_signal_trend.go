@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+const (
+	// nodeSignalTrendSamples is how many recent SNR readings a node row draws.
+	nodeSignalTrendSamples = 20
+	// nodeSignalTrendMaxAge reloads a node even when it wasn't heard again, because the
+	// history is written after the node update that triggered the row refresh.
+	nodeSignalTrendMaxAge = time.Minute
+)
+
+var nodeSignalTrendMinSize = fyne.NewSize(48, 14)
+
+// nodeSignalTrendCache keeps the recent SNR readings of nodes for the list rows, so
+// scrolling the list doesn't query the signal history for every row.
+type nodeSignalTrendCache struct {
+	load func(nodeID string) []domain.NodeSignalHistoryEntry
+	now  func() time.Time
+
+	mu    sync.Mutex
+	items map[string]nodeSignalTrend
+}
+
+type nodeSignalTrend struct {
+	heardAt  time.Time
+	loadedAt time.Time
+	points   []signalSeriesPoint
+}
+
+func newNodeSignalTrendCache(load func(nodeID string) []domain.NodeSignalHistoryEntry) *nodeSignalTrendCache {
+	return &nodeSignalTrendCache{
+		load:  load,
+		now:   time.Now,
+		items: make(map[string]nodeSignalTrend),
+	}
+}
+
+// SNR returns the recent SNR readings of node, oldest first. The readings are reloaded
+// once the node was heard again or the cached ones got old.
+func (c *nodeSignalTrendCache) SNR(node domain.Node) []signalSeriesPoint {
+	if c == nil || c.load == nil || node.NodeID == "" {
+		return nil
+	}
+	now := c.now()
+
+	c.mu.Lock()
+	cached, ok := c.items[node.NodeID]
+	c.mu.Unlock()
+	if ok && cached.heardAt.Equal(node.LastHeardAt) && now.Sub(cached.loadedAt) < nodeSignalTrendMaxAge {
+		return cached.points
+	}
+
+	_, points := signalHistorySeries(c.load(node.NodeID))
+	if len(points) > nodeSignalTrendSamples {
+		points = points[len(points)-nodeSignalTrendSamples:]
+	}
+	c.mu.Lock()
+	c.items[node.NodeID] = nodeSignalTrend{heardAt: node.LastHeardAt, loadedAt: now, points: points}
+	c.mu.Unlock()
+
+	return points
+}
+
+// nodeSignalTrendSource loads the latest signal readings of a node for the list rows.
+func nodeSignalTrendSource(dep RuntimeDependencies) func(domain.Node) []signalSeriesPoint {
+	if dep.Actions.NodeOverview == nil {
+		return nil
+	}
+	cache := newNodeSignalTrendCache(func(nodeID string) []domain.NodeSignalHistoryEntry {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		items, err := dep.Actions.NodeOverview.ListSignalHistory(ctx, nodeID, nodeSignalTrendSamples)
+		if err != nil {
+			nodesLogger.Debug("signal trend load failed", "node_id", nodeID, "error", err)
+
+			return nil
+		}
+
+		return items
+	})
+
+	return cache.SNR
+}
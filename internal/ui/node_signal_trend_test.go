@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestNodeSignalTrendCacheReloadsWhenNodeHeardAgain(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	now := base
+	loads := 0
+	cache := newNodeSignalTrendCache(func(nodeID string) []domain.NodeSignalHistoryEntry {
+		loads++
+		entries := make([]domain.NodeSignalHistoryEntry, 0, 25)
+		for i := range 25 {
+			snr := float64(i)
+			entries = append(entries, domain.NodeSignalHistoryEntry{
+				NodeID:     nodeID,
+				ObservedAt: base.Add(time.Duration(i) * time.Minute),
+				SNR:        &snr,
+			})
+		}
+		entries = append(entries, domain.NodeSignalHistoryEntry{NodeID: nodeID, ObservedAt: base.Add(time.Hour)})
+
+		return entries
+	})
+	cache.now = func() time.Time { return now }
+	node := domain.Node{NodeID: "!00000001", LastHeardAt: base}
+
+	points := cache.SNR(node)
+	if len(points) != nodeSignalTrendSamples {
+		t.Fatalf("unexpected point count: expected %d, got %d", nodeSignalTrendSamples, len(points))
+	}
+	if points[0].Value != 5 || points[len(points)-1].Value != 24 {
+		t.Fatalf("expected the latest readings, got %v…%v", points[0].Value, points[len(points)-1].Value)
+	}
+
+	cache.SNR(node)
+	if loads != 1 {
+		t.Fatalf("expected cached readings to be reused, got %d loads", loads)
+	}
+
+	node.LastHeardAt = base.Add(time.Second)
+	cache.SNR(node)
+	if loads != 2 {
+		t.Fatalf("expected a reload after the node was heard again, got %d loads", loads)
+	}
+
+	now = now.Add(nodeSignalTrendMaxAge)
+	cache.SNR(node)
+	if loads != 3 {
+		t.Fatalf("expected a reload of old readings, got %d loads", loads)
+	}
+
+	if points := cache.SNR(domain.Node{}); points != nil {
+		t.Fatalf("expected no readings without node ID, got %v", points)
+	}
+}
@@ -3,6 +3,8 @@ package ui
 import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func newNodeTab(dep RuntimeDependencies) fyne.CanvasObject {
@@ -13,51 +15,51 @@ func newNodeTabWithOnShow(dep RuntimeDependencies) fyne.CanvasObject {
 	nodeSettingsTabLogger.Info("building node settings tab")
 	saveGate := &nodeSettingsSaveGate{}
 	loraPage, onLoRaTabOpened := newNodeLoRaSettingsPage(dep, saveGate)
-	loraTab := container.NewTabItem("LoRa", loraPage)
+	loraTab := container.NewTabItem(i18n.T("LoRa"), loraPage)
 	channelsPage, onChannelsTabOpened := newNodeChannelsSettingsPage(dep, saveGate)
-	channelsTab := container.NewTabItem("Channels", channelsPage)
+	channelsTab := container.NewTabItem(i18n.T("Channels"), channelsPage)
 	securityPage, onSecurityTabOpened := newNodeSecuritySettingsPage(dep, saveGate)
-	securityTab := container.NewTabItem("Security", securityPage)
+	securityTab := container.NewTabItem(i18n.T("Security"), securityPage)
 	devicePage, onDeviceTabOpened := newNodeDeviceSettingsPage(dep, saveGate)
-	deviceTab := container.NewTabItem("Device", devicePage)
+	deviceTab := container.NewTabItem(i18n.T("Device"), devicePage)
 	positionPage, onPositionTabOpened := newNodePositionSettingsPage(dep, saveGate)
-	positionTab := container.NewTabItem("Position", positionPage)
+	positionTab := container.NewTabItem(i18n.T("Position"), positionPage)
 	powerPage, onPowerTabOpened := newNodePowerSettingsPage(dep, saveGate)
-	powerTab := container.NewTabItem("Power", powerPage)
+	powerTab := container.NewTabItem(i18n.T("Power"), powerPage)
 	displayPage, onDisplayTabOpened := newNodeDisplaySettingsPage(dep, saveGate)
-	displayTab := container.NewTabItem("Display", displayPage)
+	displayTab := container.NewTabItem(i18n.T("Display"), displayPage)
 	bluetoothPage, onBluetoothTabOpened := newNodeBluetoothSettingsPage(dep, saveGate)
-	bluetoothTab := container.NewTabItem("Bluetooth", bluetoothPage)
+	bluetoothTab := container.NewTabItem(i18n.T("Bluetooth"), bluetoothPage)
 	networkPage, onNetworkTabOpened := newNodeNetworkSettingsPage(dep, saveGate)
-	networkTab := container.NewTabItem("Network", networkPage)
+	networkTab := container.NewTabItem(i18n.T("Network"), networkPage)
 	mqttPage, onMQTTTabOpened := newNodeMQTTSettingsPage(dep, saveGate)
-	mqttTab := container.NewTabItem("MQTT", mqttPage)
+	mqttTab := container.NewTabItem(i18n.T("MQTT"), mqttPage)
 	serialPage, onSerialTabOpened := newNodeSerialSettingsPage(dep, saveGate)
-	serialTab := container.NewTabItem("Serial", serialPage)
+	serialTab := container.NewTabItem(i18n.T("Serial"), serialPage)
 	externalNotificationPage, onExternalNotificationTabOpened := newNodeExternalNotificationSettingsPage(dep, saveGate)
-	externalNotificationTab := container.NewTabItem("External notification", externalNotificationPage)
+	externalNotificationTab := container.NewTabItem(i18n.T("External notification"), externalNotificationPage)
 	storeForwardPage, onStoreForwardTabOpened := newNodeStoreForwardSettingsPage(dep, saveGate)
-	storeForwardTab := container.NewTabItem("Store & Forward", storeForwardPage)
+	storeForwardTab := container.NewTabItem(i18n.T("Store & Forward"), storeForwardPage)
 	rangeTestPage, onRangeTestTabOpened := newNodeRangeTestSettingsPage(dep, saveGate)
-	rangeTestTab := container.NewTabItem("Range test", rangeTestPage)
+	rangeTestTab := container.NewTabItem(i18n.T("Range test"), rangeTestPage)
 	telemetryPage, onTelemetryTabOpened := newNodeTelemetrySettingsPage(dep, saveGate)
-	telemetryTab := container.NewTabItem("Telemetry", telemetryPage)
+	telemetryTab := container.NewTabItem(i18n.T("Telemetry"), telemetryPage)
 	cannedMessagePage, onCannedMessageTabOpened := newNodeCannedMessageSettingsPage(dep, saveGate)
-	cannedMessageTab := container.NewTabItem("Canned Message", cannedMessagePage)
+	cannedMessageTab := container.NewTabItem(i18n.T("Canned Message"), cannedMessagePage)
 	audioPage, onAudioTabOpened := newNodeAudioSettingsPage(dep, saveGate)
-	audioTab := container.NewTabItem("Audio", audioPage)
+	audioTab := container.NewTabItem(i18n.T("Audio"), audioPage)
 	remoteHardwarePage, onRemoteHardwareTabOpened := newNodeRemoteHardwareSettingsPage(dep, saveGate)
-	remoteHardwareTab := container.NewTabItem("Remote Hardware", remoteHardwarePage)
+	remoteHardwareTab := container.NewTabItem(i18n.T("Remote Hardware"), remoteHardwarePage)
 	neighborInfoPage, onNeighborInfoTabOpened := newNodeNeighborInfoSettingsPage(dep, saveGate)
-	neighborInfoTab := container.NewTabItem("Neighbor Info", neighborInfoPage)
+	neighborInfoTab := container.NewTabItem(i18n.T("Neighbor Info"), neighborInfoPage)
 	ambientLightingPage, onAmbientLightingTabOpened := newNodeAmbientLightingSettingsPage(dep, saveGate)
-	ambientLightingTab := container.NewTabItem("Ambient Lighting", ambientLightingPage)
+	ambientLightingTab := container.NewTabItem(i18n.T("Ambient Lighting"), ambientLightingPage)
 	detectionSensorPage, onDetectionSensorTabOpened := newNodeDetectionSensorSettingsPage(dep, saveGate)
-	detectionSensorTab := container.NewTabItem("Detection Sensor", detectionSensorPage)
+	detectionSensorTab := container.NewTabItem(i18n.T("Detection Sensor"), detectionSensorPage)
 	paxcounterPage, onPaxcounterTabOpened := newNodePaxcounterSettingsPage(dep, saveGate)
-	paxcounterTab := container.NewTabItem("Paxcounter", paxcounterPage)
+	paxcounterTab := container.NewTabItem(i18n.T("Paxcounter"), paxcounterPage)
 	statusMessagePage, onStatusMessageTabOpened := newNodeStatusMessageSettingsPage(dep, saveGate)
-	statusMessageTab := container.NewTabItem("Status Message", statusMessagePage)
+	statusMessageTab := container.NewTabItem(i18n.T("Status Message"), statusMessagePage)
 
 	radioTabs := container.NewAppTabs(
 		loraTab,
@@ -84,7 +86,7 @@ func newNodeTabWithOnShow(dep RuntimeDependencies) fyne.CanvasObject {
 	radioTabs.OnSelected = func(_ *container.TabItem) { openSelectedRadioTab() }
 
 	deviceTabs := container.NewAppTabs(
-		container.NewTabItem("User", newNodeUserSettingsPage(dep, saveGate)),
+		container.NewTabItem(i18n.T("User"), newNodeUserSettingsPage(dep, saveGate)),
 		deviceTab,
 		positionTab,
 		powerTab,
@@ -205,12 +207,12 @@ func newNodeTabWithOnShow(dep RuntimeDependencies) fyne.CanvasObject {
 	importExportTab := newNodeImportExportPage(dep)
 	maintenanceTab := newNodeMaintenancePage(dep)
 
-	overviewTopTab := container.NewTabItem("Node overview", newNodeOverviewSettingsPage(dep))
-	radioConfigTab := container.NewTabItem("Radio configuration", radioTabs)
-	deviceConfigTab := container.NewTabItem("Device configuration", deviceTabs)
-	moduleConfigTab := container.NewTabItem("Module configuration", moduleTabs)
-	importExportTopTab := container.NewTabItem("Import/Export", importExportTab)
-	maintenanceTopTab := container.NewTabItem("Maintenance", maintenanceTab)
+	overviewTopTab := container.NewTabItem(i18n.T("Node overview"), newNodeOverviewSettingsPage(dep))
+	radioConfigTab := container.NewTabItem(i18n.T("Radio configuration"), radioTabs)
+	deviceConfigTab := container.NewTabItem(i18n.T("Device configuration"), deviceTabs)
+	moduleConfigTab := container.NewTabItem(i18n.T("Module configuration"), moduleTabs)
+	importExportTopTab := container.NewTabItem(i18n.T("Import/Export"), importExportTab)
+	maintenanceTopTab := container.NewTabItem(i18n.T("Maintenance"), maintenanceTab)
 	topTabs := container.NewAppTabs(
 		overviewTopTab,
 		radioConfigTab,
@@ -3,7 +3,9 @@ package ui
 import (
 	"fmt"
 	"image/color"
+	"log/slog"
 	"math"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -15,10 +17,15 @@ import (
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/skobkin/meshgo/internal/config"
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/geo"
+	"github.com/skobkin/meshgo/internal/i18n"
 	"github.com/skobkin/meshgo/internal/resources"
 )
 
+var nodesLogger = slog.With("component", "ui.nodes")
+
 // NodeRowRenderer defines create/update callbacks for nodes list row widgets.
 type NodeRowRenderer struct {
 	Create func() fyne.CanvasObject
@@ -28,32 +35,64 @@ type NodeRowRenderer struct {
 // NodesTabActions contains optional callbacks for node row interactions.
 type NodesTabActions struct {
 	OnNodeSecondaryTapped func(node domain.Node, position fyne.Position)
-}
-
-const nodeFilterDebounce = 500 * time.Millisecond
+	// OnMessageTag sends a direct message to the nodes shown by a tag filter.
+	OnMessageTag func(tag string, nodes []domain.Node)
+	// ListConfig returns the saved columns and sorting of the list. With no columns the
+	// list uses the row renderer of the tab.
+	ListConfig func() config.NodeListConfig
+	// OnListConfigChanged saves the columns and sorting picked in the list.
+	OnListConfigChanged func(prefs config.NodeListConfig) error
+	// shortcuts is filled in with the search and list navigation handlers of the tab.
+	shortcuts *listShortcutTarget
+}
+
+const (
+	nodeFilterDebounce = 500 * time.Millisecond
+	// nodeGridFilterPrefix switches the node filter to Maidenhead grid matching, e.g. "grid:KO50".
+	nodeGridFilterPrefix = "grid:"
+	// nodeTagFilterPrefix switches the node filter to local tag matching, e.g. "tag:solar".
+	nodeTagFilterPrefix   = "tag:"
+	nodeFilterPlaceholder = "Filter nodes, grid:KO50 or tag:solar"
+)
 
 func DefaultNodeRowRenderer() NodeRowRenderer {
+	return newNodeRowRenderer(nil, nil)
+}
+
+// newNodeRowRenderer builds the default rows. When signalTrend is set, rows draw the
+// recent SNR readings of the node next to its signal quality. When localNode is set,
+// rows show how far the node is once both positions are known.
+func newNodeRowRenderer(signalTrend func(node domain.Node) []signalSeriesPoint, localNode func() *domain.Node) NodeRowRenderer {
 	return NodeRowRenderer{
 		Create: func() fyne.CanvasObject {
 			nameLabel := widget.NewLabel("name")
 			nameLabel.TextStyle = fyne.TextStyle{Bold: true}
 			favoriteIcon := widget.NewIcon(nil)
 			favoriteIcon.Hide()
+			batteryIcon := widget.NewIcon(nil)
+			batteryIcon.Hide()
+			line1Charge := widget.NewLabel("")
+			line1Charge.Hide()
+			line1Distance := widget.NewLabel("")
+			line1Distance.Hide()
 			line1Right := widget.NewLabel("seen")
-			line1RightBox := container.NewHBox(favoriteIcon, line1Right)
+			line1RightBox := container.NewHBox(favoriteIcon, batteryIcon, line1Charge, line1Distance, line1Right)
+			line2ModelIcon := widget.NewIcon(nil)
 			line2Model := widget.NewLabel("model")
 			line2Role := widget.NewLabel("role")
 			line2Role.Alignment = fyne.TextAlignCenter
 			line2Signal := canvas.NewText("", signalColorGood)
 			line2Signal.TextStyle = fyne.TextStyle{Monospace: true}
 			line2Signal.Hide()
+			line2Trend := newSparkline(nil, theme.Color(theme.ColorNamePrimary), nodeSignalTrendMinSize).(*fyne.Container)
+			line2Trend.Hide()
 			line2Right := widget.NewLabel("id")
 			line2Right.TextStyle = fyne.TextStyle{Monospace: true}
-			line2RightBox := container.NewHBox(line2Signal, line2Right)
+			line2RightBox := container.NewHBox(line2Trend, line2Signal, line2Right)
 
 			return container.NewVBox(
 				container.NewHBox(nameLabel, layout.NewSpacer(), line1RightBox),
-				container.NewBorder(nil, nil, line2Model, line2RightBox, line2Role),
+				container.NewBorder(nil, nil, container.NewHBox(line2ModelIcon, line2Model), line2RightBox, line2Role),
 			)
 		},
 		Update: func(obj fyne.CanvasObject, node domain.Node) {
@@ -62,19 +101,40 @@ func DefaultNodeRowRenderer() NodeRowRenderer {
 				return
 			}
 			labels.name.SetText(nodeDisplayName(node))
-			labels.seen.SetText(nodeLine1Right(node, time.Now()))
-			if node.IsFavorite != nil && *node.IsFavorite {
-				iconResource := resources.UIIconResource(resources.UIIconFavorite, currentThemeVariant())
-				if iconResource == nil {
-					iconResource = resources.UIIconResource(resources.UIIconFavorite, theme.VariantDark)
+			if battery := nodeBattery(node, currentDisplayConfig().LowBatteryThreshold()); battery.Visible {
+				labels.battery.SetResource(themedUIIconResource(battery.Icon))
+				labels.battery.Show()
+				labels.charge.Importance = widget.MediumImportance
+				if battery.Low {
+					labels.charge.Importance = widget.DangerImportance
 				}
-				labels.favorite.SetResource(iconResource)
+				labels.charge.SetText(battery.Text)
+				labels.charge.Show()
+			} else {
+				labels.battery.Hide()
+				labels.charge.Hide()
+			}
+			var local *domain.Node
+			if localNode != nil {
+				local = localNode()
+			}
+			if km, ok := nodeDistanceKm(local, node); ok {
+				labels.distance.SetText(formatDistanceKm(km))
+				labels.distance.Show()
+			} else {
+				labels.distance.Hide()
+			}
+			setNodeLastHeardLabel(labels.seen, node, time.Now())
+			if node.IsFavorite != nil && *node.IsFavorite {
+				labels.favorite.SetResource(themedUIIconResource(resources.UIIconFavorite))
 				labels.favorite.Show()
 			} else {
 				labels.favorite.SetResource(nil)
 				labels.favorite.Hide()
 			}
-			labels.model.SetText(nodeLine2Model(node))
+			model := describeHardwareModel(node.BoardModel)
+			labels.modelIcon.SetResource(hardwareModelIconResource(model))
+			labels.model.SetText(model.Name)
 			labels.role.SetText(nodeLine2Role(node))
 			signal := nodeLine2Signal(node)
 			labels.signal.Text = signal.Text
@@ -85,19 +145,34 @@ func DefaultNodeRowRenderer() NodeRowRenderer {
 				labels.signal.Hide()
 			}
 			labels.signal.Refresh()
+			var trend []signalSeriesPoint
+			if signalTrend != nil {
+				trend = signalTrend(node)
+			}
+			if len(trend) > 1 {
+				setSparklinePoints(labels.trend, trend, theme.Color(theme.ColorNamePrimary))
+				labels.trend.Show()
+			} else {
+				labels.trend.Hide()
+			}
 			labels.id.SetText(node.NodeID)
 		},
 	}
 }
 
 type nodeRowLabels struct {
-	name     *widget.Label
-	favorite *widget.Icon
-	seen     *widget.Label
-	model    *widget.Label
-	role     *widget.Label
-	signal   *canvas.Text
-	id       *widget.Label
+	name      *widget.Label
+	favorite  *widget.Icon
+	battery   *widget.Icon
+	charge    *widget.Label
+	distance  *widget.Label
+	seen      *widget.Label
+	model     *widget.Label
+	modelIcon *widget.Icon
+	role      *widget.Label
+	signal    *canvas.Text
+	trend     *fyne.Container
+	id        *widget.Label
 }
 
 type nodeRowItem struct {
@@ -256,14 +331,26 @@ func extractNodeRowLabels(obj fyne.CanvasObject) (nodeRowLabels, bool) {
 		return nodeRowLabels{}, false
 	}
 	line1RightBox, ok := line1.Objects[2].(*fyne.Container)
-	if !ok || len(line1RightBox.Objects) < 2 {
+	if !ok || len(line1RightBox.Objects) < 5 {
 		return nodeRowLabels{}, false
 	}
 	favorite, ok := line1RightBox.Objects[0].(*widget.Icon)
 	if !ok {
 		return nodeRowLabels{}, false
 	}
-	seen, ok := line1RightBox.Objects[1].(*widget.Label)
+	battery, ok := line1RightBox.Objects[1].(*widget.Icon)
+	if !ok {
+		return nodeRowLabels{}, false
+	}
+	charge, ok := line1RightBox.Objects[2].(*widget.Label)
+	if !ok {
+		return nodeRowLabels{}, false
+	}
+	distance, ok := line1RightBox.Objects[3].(*widget.Label)
+	if !ok {
+		return nodeRowLabels{}, false
+	}
+	seen, ok := line1RightBox.Objects[4].(*widget.Label)
 	if !ok {
 		return nodeRowLabels{}, false
 	}
@@ -271,31 +358,48 @@ func extractNodeRowLabels(obj fyne.CanvasObject) (nodeRowLabels, bool) {
 	if !ok {
 		return nodeRowLabels{}, false
 	}
-	model, ok := line2.Objects[1].(*widget.Label)
+	modelBox, ok := line2.Objects[1].(*fyne.Container)
+	if !ok || len(modelBox.Objects) < 2 {
+		return nodeRowLabels{}, false
+	}
+	modelIcon, ok := modelBox.Objects[0].(*widget.Icon)
+	if !ok {
+		return nodeRowLabels{}, false
+	}
+	model, ok := modelBox.Objects[1].(*widget.Label)
 	if !ok {
 		return nodeRowLabels{}, false
 	}
 	rightBox, ok := line2.Objects[2].(*fyne.Container)
-	if !ok || len(rightBox.Objects) < 2 {
+	if !ok || len(rightBox.Objects) < 3 {
+		return nodeRowLabels{}, false
+	}
+	trend, ok := rightBox.Objects[0].(*fyne.Container)
+	if !ok {
 		return nodeRowLabels{}, false
 	}
-	signal, ok := rightBox.Objects[0].(*canvas.Text)
+	signal, ok := rightBox.Objects[1].(*canvas.Text)
 	if !ok {
 		return nodeRowLabels{}, false
 	}
-	id, ok := rightBox.Objects[1].(*widget.Label)
+	id, ok := rightBox.Objects[2].(*widget.Label)
 	if !ok {
 		return nodeRowLabels{}, false
 	}
 
 	return nodeRowLabels{
-		name:     name,
-		favorite: favorite,
-		seen:     seen,
-		model:    model,
-		role:     role,
-		signal:   signal,
-		id:       id,
+		name:      name,
+		favorite:  favorite,
+		battery:   battery,
+		charge:    charge,
+		distance:  distance,
+		seen:      seen,
+		model:     model,
+		modelIcon: modelIcon,
+		role:      role,
+		signal:    signal,
+		trend:     trend,
+		id:        id,
 	}, true
 }
 
@@ -305,24 +409,6 @@ type nodeSignalView struct {
 	Visible bool
 }
 
-func nodeLine1Right(node domain.Node, now time.Time) string {
-	parts := []string{}
-	if charge := nodeCharge(node); charge != "" {
-		parts = append(parts, charge)
-	}
-	parts = append(parts, formatSeenAgo(node.LastHeardAt, now))
-
-	return strings.Join(parts, " | ")
-}
-
-func nodeLine2Model(node domain.Node) string {
-	if v := strings.TrimSpace(node.BoardModel); v != "" {
-		return v
-	}
-
-	return "Unknown device"
-}
-
 func nodeLine2Role(node domain.Node) string {
 	if v := strings.TrimSpace(node.Role); v != "" {
 		return v
@@ -352,11 +438,11 @@ func nodeLine2Signal(node domain.Node) nodeSignalView {
 func signalLevelLabel(quality domain.SignalQuality) string {
 	switch quality {
 	case domain.SignalGood:
-		return "Good"
+		return i18n.T("Good")
 	case domain.SignalFair:
-		return "Fair"
+		return i18n.T("Fair")
 	case domain.SignalBad:
-		return "Bad"
+		return i18n.T("Bad")
 	default:
 		return ""
 	}
@@ -376,6 +462,9 @@ func nodeDisplayName(node domain.Node) string {
 	default:
 		base = node.NodeID
 	}
+	if alias := strings.TrimSpace(node.Alias); alias != "" {
+		base = fmt.Sprintf("%s (%s)", alias, base)
+	}
 	if node.IsUnmessageable != nil && *node.IsUnmessageable {
 		return base + " {INFRA}"
 	}
@@ -383,46 +472,78 @@ func nodeDisplayName(node domain.Node) string {
 	return base
 }
 
-func nodeCharge(node domain.Node) string {
+// themedUIIconResource returns icon for the current theme, falling back to the dark one.
+func themedUIIconResource(icon resources.UIIcon) fyne.Resource {
+	if res := resources.UIIconResource(icon, currentThemeVariant()); res != nil {
+		return res
+	}
+
+	return resources.UIIconResource(icon, theme.VariantDark)
+}
+
+// nodeBatteryView is how the battery of a node is shown in its row.
+type nodeBatteryView struct {
+	Icon    resources.UIIcon
+	Text    string
+	Low     bool
+	Visible bool
+}
+
+// nodeBattery describes the battery of node from its device metrics. Levels above 100
+// mean the node runs on external power. lowThreshold is the level at or below which the
+// battery is highlighted.
+func nodeBattery(node domain.Node, lowThreshold int) nodeBatteryView {
 	if node.BatteryLevel == nil {
-		return ""
+		return nodeBatteryView{}
+	}
+	level := *node.BatteryLevel
+	if level > 100 {
+		return nodeBatteryView{Icon: resources.UIIconPowerPlug, Text: "ext", Visible: true}
 	}
-	v := *node.BatteryLevel
-	if v > 100 {
-		return "Charge: ext"
+	view := nodeBatteryView{Text: fmt.Sprintf("%d%%", level), Visible: true}
+	switch {
+	case int(level) <= lowThreshold:
+		view.Icon = resources.UIIconBatteryAlert
+		view.Low = true
+	case level < 35:
+		view.Icon = resources.UIIconBatteryLow
+	case level < 70:
+		view.Icon = resources.UIIconBatteryHalf
+	default:
+		view.Icon = resources.UIIconBatteryFull
 	}
 
-	return fmt.Sprintf("Charge: %d%%", v)
+	return view
 }
 
 func formatSeenAgo(lastSeen time.Time, now time.Time) string {
 	if lastSeen.IsZero() {
-		return "seen: ?"
+		return i18n.T("seen: ?")
 	}
 	d := now.Sub(lastSeen)
-	if d < 0 {
-		d = 0
+	if d < time.Minute {
+		return i18n.T("just now")
 	}
 
 	if d < time.Hour {
 		minutes := maxRounded(d.Minutes())
 
-		return fmt.Sprintf("%d min", minutes)
+		return i18n.Tf("%d min", minutes)
 	}
 	if d < 24*time.Hour {
 		hours := maxRounded(d.Hours())
 		if hours == 1 {
-			return "1 hour"
+			return i18n.T("1 hour")
 		}
 
-		return fmt.Sprintf("%d hours", hours)
+		return i18n.Tf("%d hours", hours)
 	}
 	days := maxRounded(d.Hours() / 24)
 	if days == 1 {
-		return "1 day"
+		return i18n.T("1 day")
 	}
 
-	return fmt.Sprintf("%d days", days)
+	return i18n.Tf("%d days", days)
 }
 
 func maxRounded(v float64) int {
@@ -445,77 +566,222 @@ func newNodesTabWithActions(
 	actions NodesTabActions,
 ) fyne.CanvasObject {
 	if store == nil {
-		title := widget.NewLabel("Nodes (0)")
+		title := widget.NewLabel(i18n.T("Nodes (0)"))
 		filterEntry := widget.NewEntry()
-		filterEntry.SetPlaceHolder("Filter nodes")
+		filterEntry.SetPlaceHolder(i18n.T(nodeFilterPlaceholder))
 		filterEntry.Disable()
 		filterSize := fyne.NewSize(260, filterEntry.MinSize().Height)
 		filterWidget := container.NewGridWrap(filterSize, filterEntry)
 		header := container.NewHBox(title, layout.NewSpacer(), filterWidget)
-		placeholder := widget.NewLabel("Nodes are unavailable")
+		placeholder := widget.NewLabel(i18n.T("Nodes are unavailable"))
 		placeholder.Wrapping = fyne.TextWrapWord
 
 		return container.NewBorder(header, nil, nil, nil, container.NewCenter(placeholder))
 	}
 
+	listPrefs := normalizedNodeListPrefs(actions.ListConfig)
 	allNodes := store.SnapshotSorted()
 	appliedFilter := ""
-	nodes := displayNodes(allNodes, appliedFilter, localNodeIDValue(localNodeID))
+	quickFilters := nodeQuickFilters{}
+	localNode := storeLocalNode(store, localNodeID)
+	visibleNodes := func() []domain.Node {
+		localID := localNodeIDValue(localNodeID)
+		sorted := sortNodesForList(allNodes, listPrefs, localNode())
+
+		return applyNodeQuickFilters(displayNodes(sorted, appliedFilter, localID), quickFilters, localID, time.Now())
+	}
+	nodes := visibleNodes()
+	rows := nodeListRows(nodes, listPrefs, localNodeIDValue(localNodeID))
 	title := widget.NewLabel(nodeCountLabelText(len(allNodes), len(nodes), appliedFilter))
 
-	list := widget.NewList(
-		func() int { return len(nodes) },
-		func() fyne.CanvasObject {
-			return newNodeRowItem(renderer.Create())
-		},
-		func(id widget.ListItemID, obj fyne.CanvasObject) {
-			if id < 0 || id >= len(nodes) {
-				return
-			}
-			row, ok := obj.(*nodeRowItem)
-			if !ok {
-				return
-			}
-			node := nodes[id]
-			localID := localNodeIDValue(localNodeID)
-			renderer.Update(row.content, node)
-			if shouldHideFavoriteIcon(node, localID) {
-				if labels, ok := extractNodeRowLabels(row.content); ok {
-					labels.favorite.SetResource(nil)
-					labels.favorite.Hide()
+	selectedIndex := -1
+	var toggleGroup func(group config.NodeHopGroup)
+	// Section headers are lower than node rows, so grouped lists size every row.
+	var headerHeight, rowHeight float32
+	newList := func(renderer NodeRowRenderer) *widget.List {
+		list := widget.NewList(
+			func() int { return len(rows) },
+			func() fyne.CanvasObject {
+				header := newNodeListGroupHeader()
+				header.Hide()
+
+				return container.NewStack(header, newNodeRowItem(renderer.Create()))
+			},
+			func(id widget.ListItemID, obj fyne.CanvasObject) {
+				if id < 0 || id >= len(rows) {
+					return
 				}
-			}
-			if isLocalNode(node, localID) {
-				row.SetBackground(NodeRowBackground{
-					ThemeColorName: theme.ColorNameSelection,
-					Alpha:          0.24,
-				})
-			} else {
-				row.ClearBackground()
-			}
-			if actions.OnNodeSecondaryTapped == nil {
-				row.onSecondary = nil
+				cell, ok := obj.(*fyne.Container)
+				if !ok || len(cell.Objects) != 2 {
+					return
+				}
+				header, headerOK := cell.Objects[0].(*widget.Button)
+				row, rowOK := cell.Objects[1].(*nodeRowItem)
+				if !headerOK || !rowOK {
+					return
+				}
+				entry := rows[id]
+				if entry.header {
+					row.Hide()
+					header.Show()
+					updateNodeListGroupHeader(header, entry, slices.Contains(listPrefs.CollapsedGroups, entry.group), func() {
+						toggleGroup(entry.group)
+					})
+
+					return
+				}
+				header.Hide()
+				row.Show()
+				node := entry.node
+				localID := localNodeIDValue(localNodeID)
+				renderer.Update(row.content, node)
+				if shouldHideFavoriteIcon(node, localID) {
+					if labels, ok := extractNodeRowLabels(row.content); ok {
+						labels.favorite.SetResource(nil)
+						labels.favorite.Hide()
+					}
+				}
+				if isLocalNode(node, localID) {
+					row.SetBackground(NodeRowBackground{
+						ThemeColorName: theme.ColorNameSelection,
+						Alpha:          0.24,
+					})
+				} else {
+					row.ClearBackground()
+				}
+				if actions.OnNodeSecondaryTapped == nil {
+					row.onSecondary = nil
+
+					return
+				}
+				row.onSecondary = func(position fyne.Position) {
+					actions.OnNodeSecondaryTapped(node, position)
+				}
+			},
+		)
+		list.OnSelected = func(id widget.ListItemID) {
+			if id >= 0 && id < len(rows) && rows[id].header {
+				list.Unselect(id)
 
 				return
 			}
-			row.onSecondary = func(position fyne.Position) {
-				actions.OnNodeSecondaryTapped(node, position)
-			}
-		},
-	)
+			selectedIndex = id
+		}
+		list.OnUnselected = func(widget.ListItemID) {
+			selectedIndex = -1
+		}
+		if listPrefs.GroupByHops {
+			headerHeight, rowHeight = nodeListRowHeights(renderer)
+		}
+
+		return list
+	}
+	listRenderer := func() NodeRowRenderer {
+		if len(listPrefs.Columns) == 0 {
+			return renderer
+		}
 
-	filterEntry := widget.NewEntry()
-	filterEntry.SetPlaceHolder("Filter nodes")
+		return newNodeColumnsRowRenderer(listPrefs.Columns, localNode)
+	}
+	list := newList(listRenderer())
+	listBox := container.NewStack()
+	refreshRows := func() {
+		nodes = visibleNodes()
+		rows = nodeListRows(nodes, listPrefs, localNodeIDValue(localNodeID))
+		if !listPrefs.GroupByHops {
+			return
+		}
+		for i, row := range rows {
+			if row.header {
+				list.SetItemHeight(i, headerHeight)
+			} else {
+				list.SetItemHeight(i, rowHeight)
+			}
+		}
+	}
+	var setListPrefs func(prefs config.NodeListConfig)
+	rebuildList := func() {
+		selectedIndex = -1
+		list = newList(listRenderer())
+		body := fyne.CanvasObject(list)
+		if len(listPrefs.Columns) > 0 {
+			body = container.NewBorder(newNodeColumnsHeader(listPrefs, setListPrefs), nil, nil, nil, list)
+		}
+		listBox.Objects = []fyne.CanvasObject{body}
+		listBox.Refresh()
+		refreshRows()
+	}
+	saveListPrefs := func(prefs config.NodeListConfig) {
+		if actions.OnListConfigChanged == nil {
+			return
+		}
+		if err := actions.OnListConfigChanged(prefs); err != nil {
+			nodesLogger.Warn("save node list preferences failed", "error", err)
+		}
+	}
+	setListPrefs = func(prefs config.NodeListConfig) {
+		listPrefs = prefs
+		rebuildList()
+		saveListPrefs(prefs)
+	}
+	toggleGroup = func(group config.NodeHopGroup) {
+		listPrefs = nodeListWithGroupCollapsed(listPrefs, group, !slices.Contains(listPrefs.CollapsedGroups, group))
+		list.UnselectAll()
+		refreshRows()
+		list.Refresh()
+		saveListPrefs(listPrefs)
+	}
+	rebuildList()
+	var viewButton *widget.Button
+	viewButton = widget.NewButtonWithIcon("", theme.ListIcon(), func() {
+		menu := newNodeListViewMenu(listPrefs, setListPrefs)
+		widget.ShowPopUpMenuAtRelativePosition(menu, canvasForObject(viewButton), fyne.NewPos(0, viewButton.Size().Height), viewButton)
+	})
+	viewButton.Importance = widget.LowImportance
+
+	filterEntry := newShortcutEntry()
+	filterEntry.SetPlaceHolder(i18n.T(nodeFilterPlaceholder))
 	filterSize := fyne.NewSize(260, filterEntry.MinSize().Height)
 	filterWidget := container.NewGridWrap(filterSize, filterEntry)
 	var filterDebounceSeq uint64
+	if actions.shortcuts != nil {
+		actions.shortcuts.focusSearch = func() {
+			focusEntry(filterEntry)
+		}
+		actions.shortcuts.selectRelative = func(delta int) {
+			if index := nextNodeListIndex(rows, selectedIndex, delta); index >= 0 {
+				list.Select(index)
+				list.ScrollTo(index)
+			}
+		}
+	}
+
+	messageTagButton := widget.NewButtonWithIcon(i18n.T("Message all"), theme.MailSendIcon(), func() {
+		if tag, ok := nodeTagFilter(appliedFilter); ok && actions.OnMessageTag != nil {
+			actions.OnMessageTag(tag, nodes)
+		}
+	})
+	messageTagButton.Hide()
+	refreshMessageTagButton := func() {
+		if _, ok := nodeTagFilter(appliedFilter); ok && actions.OnMessageTag != nil && len(nodes) > 0 {
+			messageTagButton.Show()
+
+			return
+		}
+		messageTagButton.Hide()
+	}
 
 	applyFilter := func(value string) {
 		appliedFilter = value
-		nodes = displayNodes(allNodes, appliedFilter, localNodeIDValue(localNodeID))
+		refreshRows()
 		title.SetText(nodeCountLabelText(len(allNodes), len(nodes), appliedFilter))
+		refreshMessageTagButton()
+		list.UnselectAll()
 		list.Refresh()
 	}
+	filterChips := newNodeFilterChips(&quickFilters, func() {
+		applyFilter(appliedFilter)
+	})
 
 	filterEntry.OnChanged = func(text string) {
 		seq := atomic.AddUint64(&filterDebounceSeq, 1)
@@ -535,20 +801,34 @@ func newNodesTabWithActions(
 		applyFilter(text)
 	}
 
+	refreshNodes := func() {
+		allNodes = store.SnapshotSorted()
+		refreshRows()
+		title.SetText(nodeCountLabelText(len(allNodes), len(nodes), appliedFilter))
+		refreshMessageTagButton()
+		list.Refresh()
+	}
 	go func() {
 		for range store.Changes() {
-			fyne.Do(func() {
-				allNodes = store.SnapshotSorted()
-				nodes = displayNodes(allNodes, appliedFilter, localNodeIDValue(localNodeID))
-				title.SetText(nodeCountLabelText(len(allNodes), len(nodes), appliedFilter))
-				list.Refresh()
-			})
+			fyne.Do(refreshNodes)
+		}
+	}()
+	// Last heard times, freshness colors and the "recently heard" filter change with
+	// time alone, so the list is redrawn and sorted again even without node updates.
+	go func() {
+		ticker := time.NewTicker(nodeLastHeardTick)
+		defer ticker.Stop()
+		for range ticker.C {
+			fyne.Do(refreshNodes)
 		}
 	}()
 
-	header := container.NewHBox(title, layout.NewSpacer(), filterWidget)
+	header := container.NewVBox(
+		container.NewHBox(title, layout.NewSpacer(), messageTagButton, filterWidget, viewButton),
+		filterChips,
+	)
 
-	return container.NewBorder(header, nil, nil, nil, list)
+	return container.NewBorder(header, nil, nil, nil, listBox)
 }
 
 func displayNodes(nodes []domain.Node, rawFilter, localNodeID string) []domain.Node {
@@ -586,6 +866,22 @@ func localNodeFirst(nodes []domain.Node, localNodeID string) []domain.Node {
 	return out
 }
 
+// storeLocalNode looks the local node up in the store, returning nil while it is unknown.
+func storeLocalNode(store *domain.NodeStore, localNodeID func() string) func() *domain.Node {
+	return func() *domain.Node {
+		localID := localNodeIDValue(localNodeID)
+		if localID == "" || store == nil {
+			return nil
+		}
+		node, ok := store.Get(localID)
+		if !ok {
+			return nil
+		}
+
+		return &node
+	}
+}
+
 func localNodeIDValue(provider func() string) string {
 	if provider == nil {
 		return ""
@@ -607,11 +903,11 @@ func shouldHideFavoriteIcon(node domain.Node, localNodeID string) bool {
 }
 
 func nodeCountLabelText(total int, visible int, rawFilter string) string {
-	if strings.TrimSpace(rawFilter) == "" {
-		return fmt.Sprintf("Nodes (%d)", total)
+	if strings.TrimSpace(rawFilter) == "" && visible == total {
+		return i18n.Tf("Nodes (%d)", total)
 	}
 
-	return fmt.Sprintf("Nodes (%d/%d)", visible, total)
+	return i18n.Tf("Nodes (%d/%d)", visible, total)
 }
 
 func filterNodes(nodes []domain.Node, rawFilter string) []domain.Node {
@@ -623,15 +919,64 @@ func filterNodes(nodes []domain.Node, rawFilter string) []domain.Node {
 		return out
 	}
 
+	if gridPrefix, ok := strings.CutPrefix(needle, nodeGridFilterPrefix); ok {
+		return filterNodesByGrid(nodes, gridPrefix)
+	}
+	if tag, ok := strings.CutPrefix(needle, nodeTagFilterPrefix); ok {
+		return filterNodesByTag(nodes, tag)
+	}
+
 	out := make([]domain.Node, 0, len(nodes))
 	for _, node := range nodes {
 		nodeID := strings.ToLower(strings.TrimSpace(node.NodeID))
 		shortName := strings.ToLower(strings.TrimSpace(node.ShortName))
 		longName := strings.ToLower(strings.TrimSpace(node.LongName))
-		if strings.Contains(nodeID, needle) || strings.Contains(shortName, needle) || strings.Contains(longName, needle) {
+		alias := strings.ToLower(strings.TrimSpace(node.Alias))
+		if strings.Contains(nodeID, needle) || strings.Contains(shortName, needle) || strings.Contains(longName, needle) || strings.Contains(alias, needle) || domain.HasTag(node.Tags, needle) {
+			out = append(out, node)
+		}
+	}
+
+	return out
+}
+
+// filterNodesByGrid keeps nodes whose Maidenhead locator starts with prefix, e.g. "ko50".
+func filterNodesByGrid(nodes []domain.Node, prefix string) []domain.Node {
+	prefix = strings.ToUpper(strings.TrimSpace(prefix))
+	out := make([]domain.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if !nodeHasPosition(node) {
+			continue
+		}
+		if strings.HasPrefix(strings.ToUpper(geo.Maidenhead(*node.Latitude, *node.Longitude, 4)), prefix) {
+			out = append(out, node)
+		}
+	}
+
+	return out
+}
+
+// filterNodesByTag keeps nodes with the local tag. An empty tag keeps every tagged node.
+func filterNodesByTag(nodes []domain.Node, tag string) []domain.Node {
+	tag = strings.TrimSpace(tag)
+	out := make([]domain.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if (tag == "" && len(node.Tags) > 0) || domain.HasTag(node.Tags, tag) {
 			out = append(out, node)
 		}
 	}
 
 	return out
 }
+
+// nodeTagFilter returns the tag of a "tag:" filter. It reports false for other filters
+// and for a bare prefix.
+func nodeTagFilter(rawFilter string) (string, bool) {
+	value := strings.TrimSpace(rawFilter)
+	if len(value) < len(nodeTagFilterPrefix) || !strings.EqualFold(value[:len(nodeTagFilterPrefix)], nodeTagFilterPrefix) {
+		return "", false
+	}
+	tag := strings.Join(strings.Fields(value[len(nodeTagFilterPrefix):]), " ")
+
+	return tag, tag != ""
+}
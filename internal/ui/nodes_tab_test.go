@@ -12,6 +12,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/resources"
 )
 
 func TestNewNodesTabNilStoreShowsPlaceholder(t *testing.T) {
@@ -66,6 +67,11 @@ func TestNodeDisplayName(t *testing.T) {
 			},
 			want: "[ABCD] Alpha Bravo {INFRA}",
 		},
+		{
+			name: "local alias",
+			node: domain.Node{NodeID: "!abcd1234", ShortName: "ABCD", LongName: "Alpha Bravo", Alias: "Base camp"},
+			want: "Base camp ([ABCD] Alpha Bravo)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -75,17 +81,23 @@ func TestNodeDisplayName(t *testing.T) {
 	}
 }
 
-func TestNodeCharge(t *testing.T) {
-	level := uint32(75)
-	if got := nodeCharge(domain.Node{BatteryLevel: &level}); got != "Charge: 75%" {
-		t.Fatalf("unexpected charge line: %q", got)
-	}
-	external := uint32(101)
-	if got := nodeCharge(domain.Node{BatteryLevel: &external}); got != "Charge: ext" {
-		t.Fatalf("unexpected external power line: %q", got)
+func TestNodeBattery(t *testing.T) {
+	level := func(v uint32) *uint32 { return &v }
+	tests := []struct {
+		node domain.Node
+		want nodeBatteryView
+	}{
+		{node: domain.Node{BatteryLevel: level(75)}, want: nodeBatteryView{Icon: resources.UIIconBatteryFull, Text: "75%", Visible: true}},
+		{node: domain.Node{BatteryLevel: level(50)}, want: nodeBatteryView{Icon: resources.UIIconBatteryHalf, Text: "50%", Visible: true}},
+		{node: domain.Node{BatteryLevel: level(30)}, want: nodeBatteryView{Icon: resources.UIIconBatteryLow, Text: "30%", Visible: true}},
+		{node: domain.Node{BatteryLevel: level(20)}, want: nodeBatteryView{Icon: resources.UIIconBatteryAlert, Text: "20%", Low: true, Visible: true}},
+		{node: domain.Node{BatteryLevel: level(101)}, want: nodeBatteryView{Icon: resources.UIIconPowerPlug, Text: "ext", Visible: true}},
+		{node: domain.Node{}, want: nodeBatteryView{}},
 	}
-	if got := nodeCharge(domain.Node{}); got != "" {
-		t.Fatalf("unexpected empty charge line: %q", got)
+	for _, tt := range tests {
+		if got := nodeBattery(tt.node, 20); got != tt.want {
+			t.Fatalf("unexpected battery view: expected %+v, got %+v", tt.want, got)
+		}
 	}
 }
 
@@ -102,6 +114,33 @@ func TestFormatSeenAgo(t *testing.T) {
 	}
 }
 
+func TestNodeFreshnessAt(t *testing.T) {
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		lastHeard time.Time
+		want      nodeFreshness
+	}{
+		{name: "never heard", want: nodeFreshnessUnknown},
+		{name: "heard a minute ago", lastHeard: now.Add(-time.Minute), want: nodeFreshnessFresh},
+		{name: "heard an hour ago", lastHeard: now.Add(-time.Hour), want: nodeFreshnessRecent},
+		{name: "heard yesterday", lastHeard: now.Add(-24 * time.Hour), want: nodeFreshnessStale},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nodeFreshnessAt(tt.lastHeard, now); got != tt.want {
+				t.Fatalf("unexpected freshness: expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	label := widget.NewLabel("")
+	setNodeLastHeardLabel(label, domain.Node{LastHeardAt: now.Add(-20 * time.Second)}, now)
+	if label.Text != "just now" || label.Importance != widget.SuccessImportance {
+		t.Fatalf("unexpected fresh label: expected %q with %v, got %q with %v", "just now", widget.SuccessImportance, label.Text, label.Importance)
+	}
+}
+
 func TestDefaultNodeRowRenderer_UsesMonospaceIDAndCenteredRole(t *testing.T) {
 	renderer := DefaultNodeRowRenderer()
 	obj := renderer.Create()
@@ -110,7 +149,7 @@ func TestDefaultNodeRowRenderer_UsesMonospaceIDAndCenteredRole(t *testing.T) {
 	isFavorite := true
 	renderer.Update(obj, domain.Node{
 		NodeID:     "!abcd1234",
-		BoardModel: "T-Echo",
+		BoardModel: "T_ECHO",
 		Role:       "CLIENT",
 		IsFavorite: &isFavorite,
 		RSSI:       &rssi,
@@ -132,9 +171,12 @@ func TestDefaultNodeRowRenderer_UsesMonospaceIDAndCenteredRole(t *testing.T) {
 	favorite := row.favorite
 	signal := row.signal
 
-	if model.Text != "T-Echo" {
+	if model.Text != "LilyGO T-Echo" {
 		t.Fatalf("unexpected model text: %q", model.Text)
 	}
+	if row.modelIcon.Resource == nil {
+		t.Fatalf("model icon resource should be set")
+	}
 	if role.Text != "CLIENT" {
 		t.Fatalf("unexpected role text: %q", role.Text)
 	}
@@ -191,7 +233,7 @@ func TestFilterNodes(t *testing.T) {
 		{NodeID: "!00000001", ShortName: "ABCD", LongName: "Alpha Bravo"},
 		{NodeID: "!000000a2", ShortName: "EFGH", LongName: "Echo Foxtrot"},
 		{NodeID: "!00000003", ShortName: "", LongName: "Golf Hotel"},
-		{NodeID: "!00000004", ShortName: "IJKL", LongName: ""},
+		{NodeID: "!00000004", ShortName: "IJKL", LongName: "", Alias: "Base Camp", Tags: []string{"Solar"}},
 	}
 
 	t.Run("empty filter keeps all", func(t *testing.T) {
@@ -236,6 +278,20 @@ func TestFilterNodes(t *testing.T) {
 		}
 	})
 
+	t.Run("matches local alias", func(t *testing.T) {
+		filtered := filterNodes(nodes, "camp")
+		if len(filtered) != 1 || filtered[0].NodeID != "!00000004" {
+			t.Fatalf("unexpected filtered result: %+v", filtered)
+		}
+	})
+
+	t.Run("matches local tag", func(t *testing.T) {
+		filtered := filterNodes(nodes, "solar")
+		if len(filtered) != 1 || filtered[0].NodeID != "!00000004" {
+			t.Fatalf("unexpected filtered result: %+v", filtered)
+		}
+	})
+
 	t.Run("no match returns empty", func(t *testing.T) {
 		filtered := filterNodes(nodes, "zzz")
 		if len(filtered) != 0 {
@@ -244,6 +300,105 @@ func TestFilterNodes(t *testing.T) {
 	})
 }
 
+func TestFilterNodesByGridPrefix(t *testing.T) {
+	kyivLat, kyivLon := 50.450333, 30.523333
+	dcLat, dcLon := 38.8895, -77.0352
+	nodes := []domain.Node{
+		{NodeID: "!00000001", LongName: "Kyiv", Latitude: &kyivLat, Longitude: &kyivLon},
+		{NodeID: "!00000002", LongName: "Washington", Latitude: &dcLat, Longitude: &dcLon},
+		{NodeID: "!00000003", LongName: "Grid without position"},
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{filter: "grid:KO", want: []string{"!00000001"}},
+		{filter: "GRID: ko50gk", want: []string{"!00000001"}},
+		{filter: "grid:fm18", want: []string{"!00000002"}},
+		{filter: "grid:", want: []string{"!00000001", "!00000002"}},
+		{filter: "grid:AA", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			filtered := filterNodes(nodes, tt.filter)
+			if len(filtered) != len(tt.want) {
+				t.Fatalf("expected %v, got %+v", tt.want, filtered)
+			}
+			for i, nodeID := range tt.want {
+				if filtered[i].NodeID != nodeID {
+					t.Fatalf("expected %v, got %+v", tt.want, filtered)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterNodesByTagPrefix(t *testing.T) {
+	nodes := []domain.Node{
+		{NodeID: "!00000001", LongName: "Ridge", Tags: []string{"solar", "Router"}},
+		{NodeID: "!00000002", LongName: "Home", Tags: []string{"friends"}},
+		{NodeID: "!00000003", LongName: "Untagged"},
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{filter: "tag:solar", want: []string{"!00000001"}},
+		{filter: "TAG: router", want: []string{"!00000001"}},
+		{filter: "tag:", want: []string{"!00000001", "!00000002"}},
+		{filter: "tag:sol", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			filtered := filterNodes(nodes, tt.filter)
+			if len(filtered) != len(tt.want) {
+				t.Fatalf("expected %v, got %+v", tt.want, filtered)
+			}
+			for i, nodeID := range tt.want {
+				if filtered[i].NodeID != nodeID {
+					t.Fatalf("expected %v, got %+v", tt.want, filtered)
+				}
+			}
+		})
+	}
+}
+
+func TestNodeTagFilter(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+		ok     bool
+	}{
+		{filter: " Tag:Base   camp ", want: "Base camp", ok: true},
+		{filter: "tag:", ok: false},
+		{filter: "solar", ok: false},
+		{filter: "grid:KO50", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := nodeTagFilter(tt.filter)
+		if got != tt.want || ok != tt.ok {
+			t.Fatalf("nodeTagFilter(%q): expected (%q, %v), got (%q, %v)", tt.filter, tt.want, tt.ok, got, ok)
+		}
+	}
+}
+
+func TestTagMessageRecipients(t *testing.T) {
+	infra := true
+	nodes := []domain.Node{
+		{NodeID: "!00000001", Tags: []string{"friends"}},
+		{NodeID: "!00000002", Tags: []string{"Friends"}},
+		{NodeID: "!00000003", Tags: []string{"friends"}, IsUnmessageable: &infra},
+		{NodeID: "!00000004", Tags: []string{"solar"}},
+	}
+
+	got := tagMessageRecipients(nodes, "friends", "!00000002")
+	if len(got) != 1 || got[0] != domain.ChatKeyForDM("!00000001") {
+		t.Fatalf("expected only the remote messageable node, got %v", got)
+	}
+}
+
 func TestNodeCountLabelText(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -255,6 +410,7 @@ func TestNodeCountLabelText(t *testing.T) {
 		{name: "no filter shows total", total: 52, visible: 52, filter: "", expected: "Nodes (52)"},
 		{name: "whitespace filter counts as empty", total: 52, visible: 52, filter: "  ", expected: "Nodes (52)"},
 		{name: "active filter shows visible over total", total: 52, visible: 7, filter: "abc", expected: "Nodes (7/52)"},
+		{name: "hidden nodes show visible over total", total: 52, visible: 50, filter: "", expected: "Nodes (50/52)"},
 	}
 
 	for _, tt := range tests {
@@ -362,3 +518,61 @@ func TestNodeRowItemTappedSecondaryCallsCallback(t *testing.T) {
 		t.Fatalf("expected secondary tap callback")
 	}
 }
+
+func TestNodeRowRenderer_ShowsSignalTrend(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	trend := []signalSeriesPoint{{At: base, Value: -3}, {At: base.Add(time.Minute), Value: 4.5}, {At: base.Add(2 * time.Minute), Value: -1}}
+	renderer := newNodeRowRenderer(func(node domain.Node) []signalSeriesPoint {
+		if node.NodeID == "!abcd1234" {
+			return trend
+		}
+
+		return nil
+	}, nil)
+	obj := renderer.Create()
+
+	renderer.Update(obj, domain.Node{NodeID: "!abcd1234"})
+	row, ok := extractNodeRowLabels(obj)
+	if !ok {
+		t.Fatalf("failed to parse row labels")
+	}
+	if !row.trend.Visible() {
+		t.Fatalf("signal trend should be visible")
+	}
+	if len(row.trend.Objects) != len(trend)-1 {
+		t.Fatalf("unexpected trend segments: expected %d, got %d", len(trend)-1, len(row.trend.Objects))
+	}
+
+	renderer.Update(obj, domain.Node{NodeID: "!00000001"})
+	if row.trend.Visible() {
+		t.Fatalf("signal trend should be hidden without readings")
+	}
+}
+
+func TestNodeRowRenderer_ShowsDistanceFromLocalNode(t *testing.T) {
+	coord := func(v float64) *float64 { return &v }
+	local := domain.Node{NodeID: "!local", Latitude: coord(52.52), Longitude: coord(13.405)}
+	renderer := newNodeRowRenderer(nil, func() *domain.Node { return &local })
+	obj := renderer.Create()
+	row, ok := extractNodeRowLabels(obj)
+	if !ok {
+		t.Fatalf("failed to parse row labels")
+	}
+
+	renderer.Update(obj, domain.Node{NodeID: "!near", Latitude: coord(52.5245), Longitude: coord(13.405)})
+	if !row.distance.Visible() || row.distance.Text != "500 m" {
+		t.Fatalf("unexpected distance label: expected visible %q, got %q (visible %v)", "500 m", row.distance.Text, row.distance.Visible())
+	}
+	renderer.Update(obj, domain.Node{NodeID: "!far", Latitude: coord(48.137), Longitude: coord(11.575)})
+	if row.distance.Text != "504.3 km" {
+		t.Fatalf("unexpected distance label: expected %q, got %q", "504.3 km", row.distance.Text)
+	}
+	renderer.Update(obj, domain.Node{NodeID: "!nopos"})
+	if row.distance.Visible() {
+		t.Fatalf("distance should be hidden without a position")
+	}
+	renderer.Update(obj, local)
+	if row.distance.Visible() {
+		t.Fatalf("distance should be hidden for the local node")
+	}
+}
@@ -0,0 +1,215 @@
+package ui
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/i18n"
+	"github.com/skobkin/meshgo/internal/notifications"
+)
+
+// notificationCenter lists the recent notifications, including the ones not shown on
+// the desktop, and opens the chat or node they are about.
+type notificationCenter struct {
+	window   fyne.Window
+	history  *notifications.History
+	button   *widget.Button
+	openChat func(chatKey string)
+	openNode func(nodeID string)
+	// chatColor returns the accent color of a chat, or nil when it has none.
+	chatColor func(chatKey string) color.Color
+}
+
+func newNotificationCenter(
+	window fyne.Window,
+	history *notifications.History,
+	openChat func(chatKey string),
+	openNode func(nodeID string),
+	chatColor func(chatKey string) color.Color,
+) *notificationCenter {
+	center := &notificationCenter{
+		window:    window,
+		history:   history,
+		openChat:  openChat,
+		openNode:  openNode,
+		chatColor: chatColor,
+	}
+	center.button = widget.NewButtonWithIcon("", theme.MailComposeIcon(), center.Show)
+	center.button.Importance = widget.LowImportance
+	center.refreshButton()
+
+	return center
+}
+
+func (c *notificationCenter) Button() fyne.CanvasObject {
+	return c.button
+}
+
+// Start keeps the unread counter of the button up to date until the returned stop is called.
+func (c *notificationCenter) Start() func() {
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-c.history.Changes():
+				fyne.Do(c.refreshButton)
+			}
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+func (c *notificationCenter) refreshButton() {
+	c.button.SetText(notificationCenterButtonText(c.history.Unread()))
+	if c.history.Unread() > 0 {
+		c.button.Importance = widget.HighImportance
+	} else {
+		c.button.Importance = widget.LowImportance
+	}
+	c.button.Refresh()
+}
+
+func notificationCenterButtonText(unread int) string {
+	if unread <= 0 {
+		return ""
+	}
+	if unread > 99 {
+		return "99+"
+	}
+
+	return strconv.Itoa(unread)
+}
+
+// Show opens the notification list and marks the notifications as read.
+func (c *notificationCenter) Show() {
+	if c.window == nil {
+		return
+	}
+	entries := c.history.Entries()
+	c.history.MarkRead()
+
+	var panel dialog.Dialog
+	jump := func(entry notifications.HistoryEntry) {
+		panel.Hide()
+		c.open(entry.Payload)
+	}
+	list := container.NewVBox()
+	now := time.Now()
+	for _, entry := range entries {
+		list.Add(c.newEntryRow(entry, now, jump))
+	}
+	if len(entries) == 0 {
+		empty := widget.NewLabel(i18n.T("No notifications yet"))
+		empty.Importance = widget.LowImportance
+		list.Add(empty)
+	}
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(520, 380))
+
+	clearButton := widget.NewButton(i18n.T("Clear all"), nil)
+	closeButton := widget.NewButton(i18n.T("Close"), nil)
+	if len(entries) == 0 {
+		clearButton.Disable()
+	}
+	content := container.NewBorder(
+		nil,
+		container.NewHBox(clearButton, layout.NewSpacer(), closeButton),
+		nil,
+		nil,
+		scroll,
+	)
+	panel = dialog.NewCustomWithoutButtons(i18n.T("Notifications"), content, c.window)
+	clearButton.OnTapped = func() {
+		c.history.Clear()
+		panel.Hide()
+	}
+	closeButton.OnTapped = panel.Hide
+	panel.Show()
+}
+
+func (c *notificationCenter) newEntryRow(
+	entry notifications.HistoryEntry,
+	now time.Time,
+	jump func(notifications.HistoryEntry),
+) fyne.CanvasObject {
+	title := widget.NewLabelWithStyle(strings.TrimSpace(entry.Payload.Title), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	title.Truncation = fyne.TextTruncateEllipsis
+	at := widget.NewLabel(currentDisplayFormatter().MessageTime(entry.At, now))
+	at.Importance = widget.LowImportance
+	body := widget.NewLabel(strings.TrimSpace(entry.Payload.Content))
+	body.Wrapping = fyne.TextWrapWord
+
+	var action fyne.CanvasObject
+	if label := notificationJumpLabel(entry.Payload); label != "" && c.canOpen(entry.Payload) {
+		action = widget.NewButton(label, func() { jump(entry) })
+	}
+
+	row := container.NewVBox(
+		container.NewBorder(nil, nil, nil, container.NewHBox(at), title),
+		container.NewBorder(nil, nil, nil, action, body),
+	)
+	if accent := c.entryColor(entry.Payload); accent != nil {
+		bar := canvas.NewRectangle(accent)
+		bar.SetMinSize(fyne.NewSize(4, 0))
+		row = container.NewBorder(nil, nil, bar, nil, row)
+	}
+
+	return container.NewVBox(row, widget.NewSeparator())
+}
+
+// entryColor returns the accent color of the chat payload is about, if any.
+func (c *notificationCenter) entryColor(payload notifications.Payload) color.Color {
+	chatKey := strings.TrimSpace(payload.ChatKey)
+	if chatKey == "" || c.chatColor == nil {
+		return nil
+	}
+
+	return c.chatColor(chatKey)
+}
+
+// notificationJumpLabel names the action opening what payload is about, or is empty
+// when it is not about a chat or a node.
+func notificationJumpLabel(payload notifications.Payload) string {
+	switch {
+	case strings.TrimSpace(payload.ChatKey) != "":
+		return i18n.T("Open chat")
+	case strings.TrimSpace(payload.NodeID) != "":
+		return i18n.T("Show node")
+	default:
+		return ""
+	}
+}
+
+func (c *notificationCenter) canOpen(payload notifications.Payload) bool {
+	if strings.TrimSpace(payload.ChatKey) != "" {
+		return c.openChat != nil
+	}
+
+	return c.openNode != nil
+}
+
+func (c *notificationCenter) open(payload notifications.Payload) {
+	if chatKey := strings.TrimSpace(payload.ChatKey); chatKey != "" {
+		if c.openChat != nil {
+			c.openChat(chatKey)
+		}
+
+		return
+	}
+	if nodeID := strings.TrimSpace(payload.NodeID); nodeID != "" && c.openNode != nil {
+		c.openNode(nodeID)
+	}
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/notifications"
+)
+
+func TestNotificationCenterButtonText(t *testing.T) {
+	tests := []struct {
+		unread int
+		want   string
+	}{
+		{unread: 0, want: ""},
+		{unread: 7, want: "7"},
+		{unread: 120, want: "99+"},
+	}
+	for _, tt := range tests {
+		if got := notificationCenterButtonText(tt.unread); got != tt.want {
+			t.Fatalf("unexpected button text for %d unread: expected %q, got %q", tt.unread, tt.want, got)
+		}
+	}
+}
+
+func TestNotificationCenterOpensSource(t *testing.T) {
+	var openedChat, openedNode string
+	center := newNotificationCenter(
+		nil,
+		notifications.NewHistory(0),
+		func(chatKey string) { openedChat = chatKey },
+		func(nodeID string) { openedNode = nodeID },
+		nil,
+	)
+
+	chatPayload := notifications.Payload{Title: "#Primary", ChatKey: "channel:0"}
+	if got := notificationJumpLabel(chatPayload); got != "Open chat" {
+		t.Fatalf("unexpected chat jump label: expected %q, got %q", "Open chat", got)
+	}
+	center.open(chatPayload)
+	if openedChat != "channel:0" {
+		t.Fatalf("unexpected opened chat: expected %q, got %q", "channel:0", openedChat)
+	}
+
+	nodePayload := notifications.Payload{Title: "Low battery: Base", NodeID: "!00000001"}
+	if got := notificationJumpLabel(nodePayload); got != "Show node" {
+		t.Fatalf("unexpected node jump label: expected %q, got %q", "Show node", got)
+	}
+	center.open(nodePayload)
+	if openedNode != "!00000001" {
+		t.Fatalf("unexpected opened node: expected %q, got %q", "!00000001", openedNode)
+	}
+
+	if got := notificationJumpLabel(notifications.Payload{Title: "Serial - connected"}); got != "" {
+		t.Fatalf("expected no jump label for a connection notification, got %q", got)
+	}
+}
@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"sync"
+	"time"
+)
+
+// notificationMute is the global mute of desktop notifications and message sounds. The
+// tray menu, the status bar and the settings tab all change it, so each listens for the
+// others' changes. A timed mute ends on its own.
+type notificationMute struct {
+	save func(muted bool, until time.Time) error
+	now  func() time.Time
+
+	mu        sync.Mutex
+	muted     bool
+	until     time.Time
+	expiry    *time.Timer
+	listeners []func(muted bool)
+}
+
+func newNotificationMute(dep RuntimeDependencies) *notificationMute {
+	prefs := dep.Data.Config.UI.Notifications
+	if dep.Data.CurrentConfig != nil {
+		prefs = dep.Data.CurrentConfig().UI.Notifications
+	}
+	m := &notificationMute{save: dep.Actions.OnSetNotificationsMuted}
+	if prefs.MutedAt(m.currentTime()) {
+		m.muted, m.until = true, prefs.MutedUntil
+		m.scheduleExpiry()
+	}
+
+	return m
+}
+
+// Muted reports whether notifications are muted.
+func (m *notificationMute) Muted() bool {
+	if m == nil {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.muted && (m.until.IsZero() || m.currentTime().Before(m.until))
+}
+
+// Until returns when a timed mute ends. It is zero when notifications are not muted or
+// are muted until unmuted.
+func (m *notificationMute) Until() time.Time {
+	if m == nil {
+		return time.Time{}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.until
+}
+
+// Set mutes notifications until unmuted, or unmutes them.
+func (m *notificationMute) Set(muted bool) error {
+	return m.SetUntil(muted, time.Time{})
+}
+
+// MuteFor mutes notifications for d.
+func (m *notificationMute) MuteFor(d time.Duration) error {
+	return m.SetUntil(true, m.currentTime().Add(d))
+}
+
+// SetUntil saves the mute and notifies the listeners. A non-zero until ends the mute on
+// its own. The mute is kept unchanged when it can't be saved.
+func (m *notificationMute) SetUntil(muted bool, until time.Time) error {
+	if m == nil {
+		return nil
+	}
+	if !muted {
+		until = time.Time{}
+	}
+	m.mu.Lock()
+	if m.muted == muted && m.until.Equal(until) {
+		m.mu.Unlock()
+
+		return nil
+	}
+	if m.save != nil {
+		if err := m.save(muted, until); err != nil {
+			m.mu.Unlock()
+
+			return err
+		}
+	}
+	m.muted, m.until = muted, until
+	m.scheduleExpiry()
+	listeners := append([]func(bool){}, m.listeners...)
+	m.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(muted)
+	}
+
+	return nil
+}
+
+// OnChange registers a listener called after the mute changes.
+func (m *notificationMute) OnChange(listener func(muted bool)) {
+	if m == nil || listener == nil {
+		return
+	}
+	m.mu.Lock()
+	m.listeners = append(m.listeners, listener)
+	m.mu.Unlock()
+}
+
+// scheduleExpiry restarts the timer that ends a timed mute. It must be called with mu
+// held.
+func (m *notificationMute) scheduleExpiry() {
+	if m.expiry != nil {
+		m.expiry.Stop()
+		m.expiry = nil
+	}
+	if !m.muted || m.until.IsZero() {
+		return
+	}
+	m.expiry = time.AfterFunc(m.until.Sub(m.currentTime()), m.expire)
+}
+
+// expire ends a timed mute that ran out.
+func (m *notificationMute) expire() {
+	m.mu.Lock()
+	if !m.muted || m.until.IsZero() {
+		m.mu.Unlock()
+
+		return
+	}
+	if m.currentTime().Before(m.until) {
+		// The clock was set back since the timer started.
+		m.scheduleExpiry()
+		m.mu.Unlock()
+
+		return
+	}
+	// Notifications are no longer muted either way, so a failed save only leaves an
+	// expired mute in the config.
+	if m.save != nil {
+		if err := m.save(false, time.Time{}); err != nil {
+			appLogger.Warn("failed to save the end of the notifications mute", "error", err)
+		}
+	}
+	m.muted, m.until, m.expiry = false, time.Time{}, nil
+	listeners := append([]func(bool){}, m.listeners...)
+	m.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(false)
+	}
+}
+
+func (m *notificationMute) currentTime() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+
+	return time.Now()
+}
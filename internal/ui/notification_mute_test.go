@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/config"
+)
+
+func TestNotificationMuteTimedMuteEndsOnItsOwn(t *testing.T) {
+	type saveCall struct {
+		muted bool
+		until time.Time
+	}
+	saves := make(chan saveCall, 4)
+	mute := &notificationMute{save: func(muted bool, until time.Time) error {
+		saves <- saveCall{muted: muted, until: until}
+
+		return nil
+	}}
+	changes := make(chan bool, 4)
+	mute.OnChange(func(muted bool) { changes <- muted })
+
+	if err := mute.MuteFor(20 * time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !mute.Muted() || mute.Until().IsZero() {
+		t.Fatalf("expected a timed mute, got muted %v until %v", mute.Muted(), mute.Until())
+	}
+	if got := <-saves; !got.muted || got.until.IsZero() {
+		t.Fatalf("expected the timed mute to be saved, got %+v", got)
+	}
+	if got := <-changes; !got {
+		t.Fatalf("expected listeners to hear about the mute")
+	}
+
+	select {
+	case got := <-changes:
+		if got {
+			t.Fatalf("expected listeners to hear about the end of the mute")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected the mute to end on its own")
+	}
+	if got := <-saves; got.muted || !got.until.IsZero() {
+		t.Fatalf("expected the end of the mute to be saved, got %+v", got)
+	}
+	if mute.Muted() || !mute.Until().IsZero() {
+		t.Fatalf("expected notifications to be unmuted")
+	}
+}
+
+func TestNewNotificationMuteIgnoresExpiredMute(t *testing.T) {
+	cfg := config.Default()
+	cfg.UI.Notifications.Muted = true
+	cfg.UI.Notifications.MutedUntil = time.Now().Add(-time.Minute)
+	mute := newNotificationMute(RuntimeDependencies{Data: DataDependencies{Config: cfg}})
+	if mute.Muted() {
+		t.Fatalf("expected an expired mute to be off")
+	}
+
+	cfg.UI.Notifications.MutedUntil = time.Now().Add(time.Hour)
+	mute = newNotificationMute(RuntimeDependencies{Data: DataDependencies{Config: cfg}})
+	t.Cleanup(func() { _ = mute.Set(false) })
+	if !mute.Muted() || !mute.Until().Equal(cfg.UI.Notifications.MutedUntil) {
+		t.Fatalf("expected the saved timed mute to be restored, got until %v", mute.Until())
+	}
+}
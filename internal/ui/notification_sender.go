@@ -5,7 +5,9 @@ import (
 
 	"fyne.io/fyne/v2"
 
+	"github.com/skobkin/meshgo/internal/config"
 	"github.com/skobkin/meshgo/internal/notifications"
+	"github.com/skobkin/meshgo/internal/platform"
 )
 
 // FyneNotificationSender bridges app notifications to native Fyne notifications.
@@ -32,3 +34,62 @@ func (s *FyneNotificationSender) Send(notification notifications.Payload) {
 		s.app.SendNotification(fyne.NewNotification(title, content))
 	})
 }
+
+// DesktopNotificationSender shows notifications through a desktop notifier that reports
+// clicks, and falls back to another sender when the notifier fails.
+type DesktopNotificationSender struct {
+	notifier platform.DesktopNotifier
+	fallback notifications.Sender
+	onClick  func(notifications.Payload)
+}
+
+func NewDesktopNotificationSender(
+	notifier platform.DesktopNotifier,
+	fallback notifications.Sender,
+	onClick func(notifications.Payload),
+) *DesktopNotificationSender {
+	return &DesktopNotificationSender{notifier: notifier, fallback: fallback, onClick: onClick}
+}
+
+func (s *DesktopNotificationSender) Send(notification notifications.Payload) {
+	title := strings.TrimSpace(notification.Title)
+	content := strings.TrimSpace(notification.Content)
+	if title == "" && content == "" {
+		return
+	}
+
+	var onClick func()
+	if s.onClick != nil {
+		onClick = func() { s.onClick(notification) }
+	}
+	if err := s.notifier.Notify(title, content, strings.TrimSpace(notification.GroupKey), onClick); err != nil {
+		appLogger.Warn("desktop notification failed, using fallback", "error", err)
+		if s.fallback != nil {
+			s.fallback.Send(notification)
+		}
+	}
+}
+
+// notificationClickHandler brings the window back for a clicked notification and, unless
+// the user only wants the window shown, opens the chat the notification is about.
+func notificationClickHandler(
+	window fyne.Window,
+	currentConfig func() config.AppConfig,
+	openChat func(chatKey string),
+) func(notifications.Payload) {
+	return func(notification notifications.Payload) {
+		action := config.NotificationClickOpenChat
+		if currentConfig != nil {
+			action = currentConfig().UI.Notifications.ClickAction
+		}
+		chatKey := strings.TrimSpace(notification.ChatKey)
+		appLogger.Debug("notification clicked", "chat_key", chatKey, "click_action", action)
+		fyne.Do(func() {
+			window.Show()
+			window.RequestFocus()
+			if action == config.NotificationClickOpenChat && chatKey != "" && openChat != nil {
+				openChat(chatKey)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	fynetest "fyne.io/fyne/v2/test"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/notifications"
+)
+
+type desktopNotifierStub struct {
+	err     error
+	titles  []string
+	groups  []string
+	onClick []func()
+}
+
+func (n *desktopNotifierStub) Notify(title, _, group string, onClick func()) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.titles = append(n.titles, title)
+	n.groups = append(n.groups, group)
+	n.onClick = append(n.onClick, onClick)
+
+	return nil
+}
+
+func (n *desktopNotifierStub) Close() error {
+	return nil
+}
+
+type payloadRecorder struct {
+	sent []notifications.Payload
+}
+
+func (r *payloadRecorder) Send(payload notifications.Payload) {
+	r.sent = append(r.sent, payload)
+}
+
+func TestDesktopNotificationSenderReportsClickedPayload(t *testing.T) {
+	notifier := &desktopNotifierStub{}
+	fallback := &payloadRecorder{}
+	var clicked []notifications.Payload
+	sender := NewDesktopNotificationSender(notifier, fallback, func(payload notifications.Payload) {
+		clicked = append(clicked, payload)
+	})
+
+	sender.Send(notifications.Payload{Title: "#General", Content: "Alice: hi", ChatKey: "channel:0", GroupKey: "chat:channel:0"})
+	sender.Send(notifications.Payload{Title: " ", Content: " "})
+
+	if len(notifier.titles) != 1 || len(fallback.sent) != 0 {
+		t.Fatalf("expected one desktop notification and no fallback, got %v and %+v", notifier.titles, fallback.sent)
+	}
+	if notifier.groups[0] != "chat:channel:0" {
+		t.Fatalf("expected the notification group, got %q", notifier.groups[0])
+	}
+	notifier.onClick[0]()
+	if len(clicked) != 1 || clicked[0].ChatKey != "channel:0" {
+		t.Fatalf("expected click on the channel notification, got %+v", clicked)
+	}
+}
+
+func TestDesktopNotificationSenderFallsBackWhenNotifierFails(t *testing.T) {
+	fallback := &payloadRecorder{}
+	sender := NewDesktopNotificationSender(&desktopNotifierStub{err: errors.New("bus gone")}, fallback, nil)
+
+	sender.Send(notifications.Payload{Title: "#General", Content: "Alice: hi"})
+
+	if len(fallback.sent) != 1 || fallback.sent[0].Title != "#General" {
+		t.Fatalf("expected fallback notification, got %+v", fallback.sent)
+	}
+}
+
+func TestNotificationClickHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   config.NotificationClickAction
+		chatKey  string
+		wantOpen string
+	}{
+		{name: "opens chat", action: config.NotificationClickOpenChat, chatKey: "dm:!0000002a", wantOpen: "dm:!0000002a"},
+		{name: "only shows window", action: config.NotificationClickShowWindow, chatKey: "dm:!0000002a"},
+		{name: "notification without chat", action: config.NotificationClickOpenChat},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app := fynetest.NewTempApp(t)
+			window := app.NewWindow("meshgo")
+			cfg := config.Default()
+			cfg.UI.Notifications.ClickAction = tc.action
+			var mu sync.Mutex
+			opened := ""
+			handle := notificationClickHandler(window, func() config.AppConfig { return cfg }, func(chatKey string) {
+				mu.Lock()
+				opened = chatKey
+				mu.Unlock()
+			})
+
+			handle(notifications.Payload{Title: "@Alice", ChatKey: tc.chatKey})
+
+			// Runs after the handler's queued UI work.
+			fyne.DoAndWait(func() {})
+			mu.Lock()
+			defer mu.Unlock()
+			if opened != tc.wantOpen {
+				t.Fatalf("expected opened chat %q, got %q", tc.wantOpen, opened)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+)
+
+// visibleAppTabs returns the tab containers shown in root, from the outermost to the
+// innermost one, e.g. the node settings groups and the pages of the selected group.
+func visibleAppTabs(root fyne.CanvasObject) []*container.AppTabs {
+	var chain []*container.AppTabs
+	for tabs := firstAppTabs(root); tabs != nil; {
+		chain = append(chain, tabs)
+		selected := tabs.Selected()
+		if selected == nil {
+			break
+		}
+		tabs = firstAppTabs(selected.Content)
+	}
+
+	return chain
+}
+
+func firstAppTabs(object fyne.CanvasObject) *container.AppTabs {
+	if object == nil || !object.Visible() {
+		return nil
+	}
+	switch object := object.(type) {
+	case *container.AppTabs:
+		return object
+	case *container.Scroll:
+		return firstAppTabs(object.Content)
+	case *fyne.Container:
+		for _, child := range object.Objects {
+			if tabs := firstAppTabs(child); tabs != nil {
+				return tabs
+			}
+		}
+	}
+
+	return nil
+}
+
+// selectRelativePage moves delta pages through the tab containers of root, as if the
+// nested tabs were one list of pages: past the last page of a group it opens the first
+// page of the next group. The outermost tabs wrap around. It reports whether root has
+// pages.
+func selectRelativePage(root fyne.CanvasObject, delta int) bool {
+	chain := visibleAppTabs(root)
+	for i := len(chain) - 1; i >= 0; i-- {
+		tabs := chain[i]
+		count := len(tabs.Items)
+		if count == 0 {
+			continue
+		}
+		next := tabs.SelectedIndex() + delta
+		if i > 0 && (next < 0 || next >= count) {
+			continue
+		}
+		tabs.SelectIndex((next%count + count) % count)
+		if i < len(chain)-1 {
+			for _, inner := range visibleAppTabs(tabs.Selected().Content) {
+				if delta > 0 {
+					inner.SelectIndex(0)
+				} else {
+					inner.SelectIndex(len(inner.Items) - 1)
+				}
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
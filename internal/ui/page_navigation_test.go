@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/container"
+	fynetest "fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestSelectRelativePageWalksNestedTabs(t *testing.T) {
+	app := fynetest.NewApp()
+	t.Cleanup(app.Quit)
+
+	radio := container.NewAppTabs(
+		container.NewTabItem("LoRa", widget.NewLabel("lora")),
+		container.NewTabItem("Channels", widget.NewLabel("channels")),
+	)
+	device := container.NewAppTabs(
+		container.NewTabItem("User", widget.NewLabel("user")),
+		container.NewTabItem("Power", widget.NewLabel("power")),
+	)
+	top := container.NewAppTabs(
+		container.NewTabItem("Overview", widget.NewLabel("overview")),
+		container.NewTabItem("Radio", radio),
+		container.NewTabItem("Device", device),
+	)
+	root := container.NewBorder(nil, widget.NewButton("Save", nil), nil, nil, top)
+	fynetest.NewTempWindow(t, root)
+
+	page := func() string {
+		chain := visibleAppTabs(root)
+
+		return chain[len(chain)-1].Selected().Text
+	}
+	want := []string{"LoRa", "Channels", "User", "Power", "Overview", "LoRa"}
+	for _, expected := range want {
+		if !selectRelativePage(root, 1) {
+			t.Fatalf("expected root to have pages")
+		}
+		if got := page(); got != expected {
+			t.Fatalf("unexpected next page: expected %q, got %q", expected, got)
+		}
+	}
+
+	selectRelativePage(root, -1)
+	if got := page(); got != "Overview" {
+		t.Fatalf("unexpected previous page: expected %q, got %q", "Overview", got)
+	}
+	selectRelativePage(root, -1)
+	if got := page(); got != "Power" {
+		t.Fatalf("expected going back to open the last page of the previous group, got %q", got)
+	}
+
+	if selectRelativePage(widget.NewLabel("no pages"), 1) {
+		t.Fatalf("expected no pages outside of tabs")
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/skobkin/meshgo/internal/config"
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func handleNodePositionLogAction(window fyne.Window, dep RuntimeDependencies, node domain.Node) {
@@ -37,9 +38,9 @@ func handleNodePositionLogAction(window fyne.Window, dep RuntimeDependencies, no
 }
 
 func showPositionLogModal(window fyne.Window, dep RuntimeDependencies, node domain.Node) {
-	loading := widget.NewLabel("Loading position history...")
+	loading := widget.NewLabel(i18n.T("Loading position history..."))
 	body := container.NewStack(loading)
-	closeButton := widget.NewButton("Close", nil)
+	closeButton := widget.NewButton(i18n.T("Close"), nil)
 	content := container.NewBorder(nil, closeButton, nil, nil, body)
 	modal := widget.NewModalPopUp(content, window.Canvas())
 	closeButton.OnTapped = modal.Hide
@@ -87,13 +88,13 @@ func newPositionLogTable(
 	onOpenMapLink func(target *url.URL),
 ) fyne.CanvasObject {
 	headers := []string{
-		"Latitude",
-		"Longitude",
-		"Altitude",
-		"Precision",
-		"Channel",
-		"Update",
-		"Observed at",
+		i18n.T("Latitude"),
+		i18n.T("Longitude"),
+		i18n.T("Altitude"),
+		i18n.T("Precision"),
+		i18n.T("Channel"),
+		i18n.T("Update"),
+		i18n.T("Observed at"),
 		// "From packet",
 	}
 	rows := make([]positionLogRowView, 0, len(items))
@@ -102,7 +103,7 @@ func newPositionLogTable(
 	}
 	if len(rows) == 0 {
 		rows = append(rows, positionLogRowView{
-			Values: []string{"No position history yet", "", "", "", "", "", "", ""},
+			Values: []string{i18n.T("No position history yet"), "", "", "", "", "", "", ""},
 		})
 	}
 
@@ -171,7 +172,7 @@ func newPositionLogTable(
 
 	return container.NewBorder(
 		container.NewVBox(
-			widget.NewLabelWithStyle("Position log", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			widget.NewLabelWithStyle(i18n.T("Position log"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 			widget.NewSeparator(),
 		),
 		nil,
@@ -208,8 +209,8 @@ func positionLogLatitudeURL(item domain.NodePositionHistoryEntry, mapLinkProvide
 
 func positionLogRow(item domain.NodePositionHistoryEntry) []string {
 	return []string{
-		formatFloat64(item.Latitude, "%.6f"),
-		formatFloat64(item.Longitude, "%.6f"),
+		formatLatitude(item.Latitude),
+		formatLongitude(item.Longitude),
 		formatInt32(item.Altitude, "%d m"),
 		formatPositionPrecision(item.Precision),
 		formatUint32(item.Channel, "%d"),
@@ -221,7 +222,7 @@ func positionLogRow(item domain.NodePositionHistoryEntry) []string {
 
 func formatInt32(value *int32, format string) string {
 	if value == nil {
-		return "unknown"
+		return i18n.T("unknown")
 	}
 
 	return fmt.Sprintf(format, *value)
@@ -229,7 +230,7 @@ func formatInt32(value *int32, format string) string {
 
 func formatPositionPrecision(value *uint32) string {
 	if value == nil {
-		return "unknown"
+		return i18n.T("unknown")
 	}
 
 	return nodeChannelPositionPrecisionLabel(*value)
@@ -79,20 +79,20 @@ func bindPresentationListeners(
 		func(status busmsg.ConnectionStatus) {
 			callbackGate.Do(func() {
 				if connStatusPresenter != nil {
-					connStatusPresenter.Set(status, fyApp.Settings().ThemeVariant())
+					connStatusPresenter.Set(status, appThemeVariant(fyApp))
 				}
 			})
 		},
 		func() {
 			callbackGate.Do(func() {
 				if connStatusPresenter != nil {
-					connStatusPresenter.Refresh(fyApp.Settings().ThemeVariant())
+					connStatusPresenter.Refresh(appThemeVariant(fyApp))
 				}
 			})
 		},
 	)
 	if status, ok := currentConnStatus(dep); ok && connStatusPresenter != nil {
-		connStatusPresenter.Set(status, fyApp.Settings().ThemeVariant())
+		connStatusPresenter.Set(status, appThemeVariant(fyApp))
 	}
 
 	appLogger.Debug("starting update snapshot listener")
@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// quickConnectTransports is the order the recent endpoints are grouped in.
+var quickConnectTransports = []config.TransportType{
+	config.TransportIP,
+	config.TransportSerial,
+	config.TransportBluetooth,
+}
+
+// quickConnect lists the recently connected endpoints and switches the connection to
+// one of them in one click.
+type quickConnect struct {
+	window        fyne.Window
+	currentConfig func() config.AppConfig
+	save          func(cfg config.AppConfig) error
+	clearDB       func() error
+	reconnect     func()
+	button        *widget.Button
+	// onSaved is called after the connection was switched to conn.
+	onSaved func(conn config.ConnectionConfig)
+}
+
+func newQuickConnect(window fyne.Window, dep RuntimeDependencies, onSaved func(conn config.ConnectionConfig)) *quickConnect {
+	q := &quickConnect{
+		window:        window,
+		currentConfig: dep.Data.CurrentConfig,
+		save:          dep.Actions.OnSave,
+		clearDB:       dep.Actions.OnClearDB,
+		reconnect:     dep.Actions.OnReconnect,
+		onSaved:       onSaved,
+	}
+	q.button = widget.NewButtonWithIcon("", theme.LoginIcon(), q.Show)
+	q.button.Importance = widget.LowImportance
+
+	return q
+}
+
+func (q *quickConnect) Button() fyne.CanvasObject {
+	return q.button
+}
+
+// Show opens the list of recent endpoints.
+func (q *quickConnect) Show() {
+	if q.window == nil || q.currentConfig == nil {
+		return
+	}
+	cfg := q.currentConfig()
+
+	var panel dialog.Dialog
+	pick := func(conn config.ConnectionConfig) {
+		panel.Hide()
+		q.connect(conn)
+	}
+	list := container.NewVBox()
+	for _, transport := range quickConnectTransports {
+		recent := cfg.RecentConnectionsFor(transport)
+		if len(recent) == 0 {
+			continue
+		}
+		list.Add(widget.NewLabelWithStyle(transportOptionFromType(transport), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		for _, conn := range recent {
+			list.Add(newQuickConnectRow(conn, cfg.Connection, pick))
+		}
+	}
+	if len(cfg.RecentConnections) == 0 {
+		empty := widget.NewLabel(i18n.T("No recent connections yet"))
+		empty.Importance = widget.LowImportance
+		list.Add(empty)
+	}
+	scroll := container.NewVScroll(list)
+	scroll.SetMinSize(fyne.NewSize(420, 280))
+
+	closeButton := widget.NewButton(i18n.T("Close"), nil)
+	content := container.NewBorder(nil, container.NewHBox(layout.NewSpacer(), closeButton), nil, nil, scroll)
+	panel = dialog.NewCustomWithoutButtons(i18n.T("Quick connect"), content, q.window)
+	closeButton.OnTapped = panel.Hide
+	panel.Show()
+}
+
+func newQuickConnectRow(
+	conn config.ConnectionConfig,
+	current config.ConnectionConfig,
+	pick func(config.ConnectionConfig),
+) fyne.CanvasObject {
+	button := widget.NewButtonWithIcon(quickConnectEndpointText(conn), theme.LoginIcon(), func() { pick(conn) })
+	button.Alignment = widget.ButtonAlignLeading
+	if quickConnectSameEndpoint(conn, current) {
+		button.Importance = widget.HighImportance
+	}
+
+	return button
+}
+
+// quickConnectEndpointText describes the endpoint of conn.
+func quickConnectEndpointText(conn config.ConnectionConfig) string {
+	switch conn.Transport {
+	case config.TransportSerial:
+		return fmt.Sprintf("%s @ %d", conn.SerialPort, conn.SerialBaud)
+	case config.TransportBluetooth:
+		if adapter := strings.TrimSpace(conn.BluetoothAdapter); adapter != "" {
+			return fmt.Sprintf("%s (%s)", conn.BluetoothAddress, adapter)
+		}
+
+		return conn.BluetoothAddress
+	default:
+		return conn.Host
+	}
+}
+
+// quickConnectSameEndpoint reports whether a and b connect to the same endpoint the
+// same way, ignoring the fields their transport doesn't use.
+func quickConnectSameEndpoint(a, b config.ConnectionConfig) bool {
+	if a.Transport != b.Transport {
+		return false
+	}
+	switch a.Transport {
+	case config.TransportSerial:
+		return a.SerialPort == b.SerialPort && a.SerialBaud == b.SerialBaud
+	case config.TransportBluetooth:
+		return strings.EqualFold(a.BluetoothAddress, b.BluetoothAddress) && a.BluetoothAdapter == b.BluetoothAdapter
+	default:
+		return a.Host == b.Host
+	}
+}
+
+// quickConnectConfig is cfg switched to the endpoint of conn.
+func quickConnectConfig(cfg config.AppConfig, conn config.ConnectionConfig) config.AppConfig {
+	next := cfg.Connection
+	next.Transport = conn.Transport
+	switch conn.Transport {
+	case config.TransportSerial:
+		next.SerialPort = conn.SerialPort
+		next.SerialBaud = conn.SerialBaud
+	case config.TransportBluetooth:
+		next.BluetoothAddress = conn.BluetoothAddress
+		next.BluetoothAdapter = conn.BluetoothAdapter
+		next.BluetoothTestingEnabled = true
+	default:
+		next.Host = conn.Host
+	}
+	cfg.Connection = next
+
+	return cfg
+}
+
+func (q *quickConnect) connect(conn config.ConnectionConfig) {
+	cfg := q.currentConfig()
+	if quickConnectSameEndpoint(conn, cfg.Connection) {
+		settingsLogger.Info("quick connect to the current endpoint", "transport", conn.Transport)
+		if q.reconnect != nil {
+			q.reconnect()
+		}
+
+		return
+	}
+	next := quickConnectConfig(cfg, conn)
+	if next.Connection.Transport == cfg.Connection.Transport {
+		q.apply(next, false)
+
+		return
+	}
+	dialog.ShowConfirm(
+		i18n.T("Switch transport?"),
+		i18n.T("Changing transport will clear the local database before reconnecting. Continue?"),
+		func(ok bool) {
+			if !ok {
+				settingsLogger.Info("quick connect transport switch canceled by user")
+
+				return
+			}
+			q.apply(next, true)
+		},
+		q.window,
+	)
+}
+
+func (q *quickConnect) apply(cfg config.AppConfig, clearDatabase bool) {
+	settingsLogger.Info("quick connect", "transport", cfg.Connection.Transport, "clear_database", clearDatabase)
+	if q.save == nil {
+		return
+	}
+	if clearDatabase {
+		if q.clearDB == nil {
+			dialog.ShowError(errors.New(i18n.T("Save failed: database clear is not available")), q.window)
+
+			return
+		}
+		if err := q.clearDB(); err != nil {
+			dialog.ShowError(fmt.Errorf("%s", i18n.Tf("Save failed: database clear failed: %s", err)), q.window)
+
+			return
+		}
+	}
+	if err := q.save(cfg); err != nil && !quickConnectSavedWithWarning(err) {
+		settingsLogger.Warn("quick connect failed", "error", err)
+		dialog.ShowError(err, q.window)
+
+		return
+	}
+	if q.onSaved != nil {
+		q.onSaved(cfg.Connection)
+	}
+}
+
+// quickConnectSavedWithWarning reports whether err only warns about autostart, which
+// means the config was saved.
+func quickConnectSavedWithWarning(err error) bool {
+	var devWarning *app.AutostartDevBuildSkipWarning
+	var syncWarning *app.AutostartSyncWarning
+
+	return errors.As(err, &devWarning) || errors.As(err, &syncWarning)
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/skobkin/meshgo/internal/config"
+)
+
+func TestQuickConnectConfig(t *testing.T) {
+	cfg := config.Default()
+	cfg.Connection.Host = "10.0.0.1"
+	cfg.Connection.SerialPort = "COM1"
+
+	next := quickConnectConfig(cfg, config.ConnectionConfig{Transport: config.TransportSerial, SerialPort: "COM3", SerialBaud: 9600})
+	if next.Connection.Transport != config.TransportSerial || next.Connection.SerialPort != "COM3" || next.Connection.SerialBaud != 9600 {
+		t.Fatalf("unexpected serial connection: %+v", next.Connection)
+	}
+	if next.Connection.Host != "10.0.0.1" {
+		t.Fatalf("expected the IP host to be kept for later, got %q", next.Connection.Host)
+	}
+
+	next = quickConnectConfig(cfg, config.ConnectionConfig{Transport: config.TransportBluetooth, BluetoothAddress: "AA:BB:CC:DD:EE:FF"})
+	if !next.Connection.BluetoothTestingEnabled {
+		t.Fatalf("expected a Bluetooth endpoint to enable Bluetooth testing")
+	}
+}
+
+func TestQuickConnectConnect(t *testing.T) {
+	current := config.Default()
+	current.Connection.Host = "10.0.0.1"
+
+	var saved []config.AppConfig
+	var synced []config.ConnectionConfig
+	reconnects := 0
+	q := &quickConnect{
+		currentConfig: func() config.AppConfig { return current },
+		save: func(cfg config.AppConfig) error {
+			saved = append(saved, cfg)
+
+			return nil
+		},
+		reconnect: func() { reconnects++ },
+		onSaved:   func(conn config.ConnectionConfig) { synced = append(synced, conn) },
+	}
+
+	q.connect(config.ConnectionConfig{Transport: config.TransportIP, Host: "10.0.0.1"})
+	if reconnects != 1 || len(saved) != 0 {
+		t.Fatalf("expected the current endpoint to reconnect without saving, got %d reconnects and %d saves", reconnects, len(saved))
+	}
+
+	q.connect(config.ConnectionConfig{Transport: config.TransportIP, Host: "10.0.0.2"})
+	if len(saved) != 1 || saved[0].Connection.Host != "10.0.0.2" {
+		t.Fatalf("expected the picked endpoint to be saved, got %+v", saved)
+	}
+	if len(synced) != 1 || synced[0].Host != "10.0.0.2" {
+		t.Fatalf("expected the settings tab to be synced, got %+v", synced)
+	}
+}
+
+func TestQuickConnectEndpointText(t *testing.T) {
+	tests := []struct {
+		conn config.ConnectionConfig
+		want string
+	}{
+		{config.ConnectionConfig{Transport: config.TransportIP, Host: "meshtastic.local"}, "meshtastic.local"},
+		{config.ConnectionConfig{Transport: config.TransportSerial, SerialPort: "/dev/ttyUSB0", SerialBaud: 115200}, "/dev/ttyUSB0 @ 115200"},
+		{config.ConnectionConfig{Transport: config.TransportBluetooth, BluetoothAddress: "AA:BB", BluetoothAdapter: "hci1"}, "AA:BB (hci1)"},
+	}
+	for _, tt := range tests {
+		if got := quickConnectEndpointText(tt.conn); got != tt.want {
+			t.Fatalf("unexpected endpoint text: expected %q, got %q", tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// quickSwitcherMatches returns the chats whose title or key contains the query. Chats
+// with a title starting with the query come first; otherwise the chat list order is kept.
+func quickSwitcherMatches(chats []domain.Chat, query string, titleOf func(domain.Chat) string) []domain.Chat {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return append([]domain.Chat(nil), chats...)
+	}
+
+	type match struct {
+		chat   domain.Chat
+		prefix bool
+	}
+	matches := make([]match, 0, len(chats))
+	for _, chat := range chats {
+		title := strings.ToLower(titleOf(chat))
+		if !strings.Contains(title, query) && !strings.Contains(strings.ToLower(chat.Key), query) {
+			continue
+		}
+		matches = append(matches, match{chat: chat, prefix: strings.HasPrefix(title, query)})
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].prefix && !matches[j].prefix
+	})
+
+	out := make([]domain.Chat, 0, len(matches))
+	for _, item := range matches {
+		out = append(out, item.chat)
+	}
+
+	return out
+}
+
+// showQuickChatSwitcher shows a filterable chat list. Up and Down move the selection,
+// Enter opens the selected chat and Esc closes the switcher.
+func showQuickChatSwitcher(window fyne.Window, chats []domain.Chat, nodeNameByID func(string) string, open func(chatKey string)) {
+	if window == nil || open == nil {
+		return
+	}
+	titleOf := func(chat domain.Chat) string {
+		return chatDisplayTitle(chat, nodeNameByID)
+	}
+	matches := quickSwitcherMatches(chats, "", titleOf)
+	selected := 0
+
+	var modal *widget.PopUp
+	release := func() {}
+	closeSwitcher := func() {
+		release()
+		modal.Hide()
+	}
+	openSelected := func() {
+		if selected < 0 || selected >= len(matches) {
+			return
+		}
+		chatKey := matches[selected].Key
+		closeSwitcher()
+		open(chatKey)
+	}
+
+	list := widget.NewList(
+		func() int { return len(matches) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+
+			return label
+		},
+		func(id widget.ListItemID, object fyne.CanvasObject) {
+			label, ok := object.(*widget.Label)
+			if !ok || id < 0 || id >= len(matches) {
+				return
+			}
+			label.SetText(titleOf(matches[id]))
+		},
+	)
+	selectIndex := func(index int) {
+		if index < 0 {
+			selected = -1
+			list.UnselectAll()
+
+			return
+		}
+		selected = index
+		list.Select(index)
+		list.ScrollTo(index)
+	}
+
+	query := newShortcutEntry()
+	query.SetPlaceHolder(i18n.T("Go to chat"))
+	query.OnChanged = func(text string) {
+		matches = quickSwitcherMatches(chats, text, titleOf)
+		list.Refresh()
+		selectIndex(relativeListIndex(-1, 1, len(matches)))
+	}
+	query.OnSubmitted = func(string) {
+		openSelected()
+	}
+	query.onTypedKey = func(event *fyne.KeyEvent) bool {
+		switch event.Name {
+		case fyne.KeyDown:
+			selectIndex(relativeListIndex(selected, 1, len(matches)))
+		case fyne.KeyUp:
+			selectIndex(relativeListIndex(selected, -1, len(matches)))
+		case fyne.KeyEscape:
+			closeSwitcher()
+		default:
+			return false
+		}
+
+		return true
+	}
+	// Tapping a chat opens it right away.
+	list.OnSelected = func(id widget.ListItemID) {
+		if id == selected {
+			return
+		}
+		selected = id
+		openSelected()
+	}
+
+	content := container.NewBorder(query, nil, nil, nil, list)
+	modal = widget.NewModalPopUp(content, window.Canvas())
+	release = shortcutsForWindow(window).Handle(shortcutHideWindow, func() bool {
+		closeSwitcher()
+
+		return true
+	})
+	modal.Resize(fyne.NewSize(420, 360))
+	modal.Show()
+	selectIndex(relativeListIndex(-1, 1, len(matches)))
+	window.Canvas().Focus(query)
+}
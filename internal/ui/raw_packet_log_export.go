@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// rawPacketLogExportFunc writes the raw packet log and returns how many frames it wrote.
+type rawPacketLogExportFunc func(ctx context.Context, w io.Writer) (int, error)
+
+func rawPacketLogSizeOptionLabels() []string {
+	return []string{"1 MB", "4 MB", "8 MB", "16 MB", "32 MB", "64 MB"}
+}
+
+func rawPacketLogSizeLabel(sizeMB int) string {
+	return fmt.Sprintf("%d MB", sizeMB)
+}
+
+func parseRawPacketLogSizeLabel(label string) (int, error) {
+	var sizeMB int
+	if _, err := fmt.Sscanf(label, "%d MB", &sizeMB); err != nil || sizeMB <= 0 {
+		return 0, fmt.Errorf("invalid raw packet log size %q", label)
+	}
+
+	return sizeMB, nil
+}
+
+func rawPacketLogExportFileName(now time.Time) string {
+	return "meshgo-raw-packets-" + now.Format("20060102-150405") + ".jsonl"
+}
+
+// showRawPacketLogExportDialog asks for a file and writes the raw packet log to it.
+func showRawPacketLogExportDialog(window fyne.Window, export rawPacketLogExportFunc) {
+	if window == nil || export == nil {
+		return
+	}
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			settingsLogger.Warn("raw packet log export file selection failed", "error", err)
+			dialog.ShowError(err, window)
+
+			return
+		}
+		if writer == nil {
+			return
+		}
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+			count, err := export(ctx, writer)
+			if closeErr := writer.Close(); err == nil && closeErr != nil {
+				err = fmt.Errorf("close export file: %w", closeErr)
+			}
+			fyne.Do(func() {
+				if err != nil {
+					settingsLogger.Warn("raw packet log export failed", "error", err)
+					dialog.ShowError(err, window)
+
+					return
+				}
+				dialog.ShowInformation(
+					i18n.T("Export complete"),
+					i18n.Tf("Exported %d frames to %s.", count, writer.URI().Name()),
+					window,
+				)
+			})
+		}()
+	}, window)
+	saveDialog.SetFileName(rawPacketLogExportFileName(time.Now()))
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{".jsonl"}))
+	saveDialog.Show()
+}
@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRawPacketLogSizeLabelRoundTrip(t *testing.T) {
+	for _, label := range rawPacketLogSizeOptionLabels() {
+		sizeMB, err := parseRawPacketLogSizeLabel(label)
+		if err != nil {
+			t.Fatalf("parse %q: %v", label, err)
+		}
+		if got := rawPacketLogSizeLabel(sizeMB); got != label {
+			t.Fatalf("round trip: expected %q, got %q", label, got)
+		}
+	}
+	for _, label := range []string{"", "0 MB", "big"} {
+		if _, err := parseRawPacketLogSizeLabel(label); err == nil {
+			t.Fatalf("expected %q to be rejected", label)
+		}
+	}
+}
+
+func TestRawPacketLogExportFileName(t *testing.T) {
+	got := rawPacketLogExportFileName(time.Date(2026, 3, 1, 12, 30, 5, 0, time.UTC))
+	if got != "meshgo-raw-packets-20260301-123005.jsonl" {
+		t.Fatalf("unexpected file name: %q", got)
+	}
+}
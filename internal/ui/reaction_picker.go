@@ -3,7 +3,6 @@ package ui
 import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/widget"
 )
 
@@ -52,27 +51,26 @@ func newReactionPickerContent(onPick func(emoji string)) fyne.CanvasObject {
 
 // showReactionPicker shows a small pop-up anchored to the given canvas
 // position. The onPick callback is invoked with the chosen emoji when
-// the user clicks one of the buttons; the pop-up is hidden and the Esc
-// shortcut removed at that point. Esc and click-outside (Fyne's default
-// behaviour for widget.PopUp) dismiss the pop-up without invoking
-// onPick.
+// the user clicks one of the buttons; the pop-up is hidden at that point.
+// Esc and click-outside (Fyne's default behaviour for widget.PopUp)
+// dismiss the pop-up without invoking onPick.
 //
-// The picker re-registers its Esc shortcut on each invocation and
-// removes it on dismiss, so a new picker cleanly replaces the previous
-// one and there is no handler leak.
+// Esc goes through the canvas shortcut registry: the picker's handler sits
+// on top of the hide-window handler while the pop-up is shown and removes
+// itself once the pop-up is gone, so there is no handler leak.
 func showReactionPicker(fyneCanvas fyne.Canvas, anchor fyne.Position, onPick func(emoji string)) {
 	if fyneCanvas == nil {
 		return
 	}
 
 	var popup *widget.PopUp
-	escShortcut := &desktop.CustomShortcut{KeyName: fyne.KeyEscape}
+	release := func() {}
 
 	dismiss := func() {
 		if popup != nil {
 			popup.Hide()
 		}
-		fyneCanvas.RemoveShortcut(escShortcut)
+		release()
 	}
 
 	popup = widget.NewPopUp(newReactionPickerContent(func(emoji string) {
@@ -82,8 +80,16 @@ func showReactionPicker(fyneCanvas fyne.Canvas, anchor fyne.Position, onPick fun
 		}
 	}), fyneCanvas)
 
-	fyneCanvas.AddShortcut(escShortcut, func(fyne.Shortcut) {
+	release = shortcutsForCanvas(fyneCanvas).Handle(shortcutHideWindow, func() bool {
+		if !popup.Visible() {
+			// Dismissed by a click outside.
+			release()
+
+			return false
+		}
 		dismiss()
+
+		return true
 	})
 
 	popup.ShowAtPosition(anchor)
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+func showRecentlyDeletedDialog(window fyne.Window, dep RuntimeDependencies) {
+	if window == nil || dep.Actions.ListRecentlyDeleted == nil {
+		return
+	}
+
+	rows := container.NewVBox()
+	var reload func()
+	reload = func() {
+		items, err := dep.Actions.ListRecentlyDeleted()
+		if err != nil {
+			settingsLogger.Warn("list recently deleted items failed", "error", err)
+			rows.Objects = []fyne.CanvasObject{widget.NewLabel(i18n.Tf("Failed to load deleted items: %s", err))}
+			rows.Refresh()
+
+			return
+		}
+		rows.Objects = recentlyDeletedRows(items, func(item domain.DeletedItem) {
+			if dep.Actions.OnRestoreDeleted == nil {
+				return
+			}
+			if err := dep.Actions.OnRestoreDeleted(item); err != nil {
+				settingsLogger.Warn("restore deleted item failed", "kind", item.Kind, "key", item.Key, "error", err)
+				dialog.ShowError(err, window)
+
+				return
+			}
+			reload()
+		})
+		rows.Refresh()
+	}
+	reload()
+
+	scroll := container.NewVScroll(rows)
+	scroll.SetMinSize(fyne.NewSize(460, 280))
+	dialog.ShowCustom(i18n.T("Recently deleted"), i18n.T("Close"), scroll, window)
+}
+
+func recentlyDeletedRows(items []domain.DeletedItem, onRestore func(domain.DeletedItem)) []fyne.CanvasObject {
+	if len(items) == 0 {
+		return []fyne.CanvasObject{widget.NewLabel(i18n.T("Nothing was deleted recently."))}
+	}
+
+	out := make([]fyne.CanvasObject, 0, len(items))
+	for _, item := range items {
+		restoreButton := widget.NewButton(i18n.T("Restore"), func() {
+			onRestore(item)
+		})
+		out = append(out, container.NewHBox(
+			widget.NewLabel(recentlyDeletedItemLabel(item)),
+			layout.NewSpacer(),
+			restoreButton,
+		))
+	}
+
+	return out
+}
+
+func recentlyDeletedItemLabel(item domain.DeletedItem) string {
+	kind := i18n.T("Chat")
+	if item.Kind == domain.DeletedItemNode {
+		kind = i18n.T("Node")
+	}
+	title := strings.TrimSpace(item.Title)
+	if title == "" || title == item.Key {
+		title = item.Key
+	} else {
+		title = fmt.Sprintf("%s (%s)", title, item.Key)
+	}
+
+	return i18n.Tf("%s: %s, deleted %s", kind, title, currentDisplayFormatter().DateTime(item.DeletedAt))
+}
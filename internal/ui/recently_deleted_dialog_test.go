@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestRecentlyDeletedItemLabel(t *testing.T) {
+	deletedAt := time.Date(2026, 2, 23, 10, 30, 0, 0, time.Local)
+	tests := []struct {
+		name string
+		item domain.DeletedItem
+		want string
+	}{
+		{
+			name: "chat with title",
+			item: domain.DeletedItem{Kind: domain.DeletedItemChat, Key: "dm:!00000001", Title: "Alice", DeletedAt: deletedAt},
+			want: "Chat: Alice (dm:!00000001), deleted 2026-02-23 10:30",
+		},
+		{
+			name: "node without name",
+			item: domain.DeletedItem{Kind: domain.DeletedItemNode, Key: "!00000002", DeletedAt: deletedAt},
+			want: "Node: !00000002, deleted 2026-02-23 10:30",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := recentlyDeletedItemLabel(tc.item); got != tc.want {
+				t.Fatalf("expected %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRecentlyDeletedRows_RestoreButtonPassesItem(t *testing.T) {
+	if raceDetectorEnabled {
+		t.Skip("skipping Fyne UI test under race detector")
+	}
+
+	items := []domain.DeletedItem{
+		{Kind: domain.DeletedItemChat, Key: "dm:!00000001"},
+		{Kind: domain.DeletedItemNode, Key: "!00000002"},
+	}
+	var restored []string
+	rows := recentlyDeletedRows(items, func(item domain.DeletedItem) {
+		restored = append(restored, item.Key)
+	})
+	if len(rows) != 2 {
+		t.Fatalf("expected two rows, got %d", len(rows))
+	}
+	row := rows[1].(*fyne.Container)
+	row.Objects[2].(*widget.Button).OnTapped()
+	if len(restored) != 1 || restored[0] != "!00000002" {
+		t.Fatalf("expected second item to be restored, got %v", restored)
+	}
+
+	empty := recentlyDeletedRows(nil, nil)
+	if len(empty) != 1 {
+		t.Fatalf("expected placeholder row for empty list, got %d", len(empty))
+	}
+}
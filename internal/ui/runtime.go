@@ -4,6 +4,9 @@ import (
 	"sync"
 
 	"fyne.io/fyne/v2"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 type uiRuntime struct {
@@ -14,6 +17,8 @@ type uiRuntime struct {
 	stopUIListeners     func()
 	stopUpdateSnapshots func()
 	onQuit              func()
+	afterShow           func()
+	beforeHide          func()
 
 	shutdownOnce sync.Once
 }
@@ -36,19 +41,78 @@ func newUIRuntime(
 	}
 }
 
-func (r *uiRuntime) BindCloseIntercept() {
+// BindWindowState restores the window placement once the window is first shown and
+// saves it before the window is hidden to the tray or the app quits.
+func (r *uiRuntime) BindWindowState(afterShow, beforeHide func()) {
+	r.afterShow = afterShow
+	r.beforeHide = beforeHide
+}
+
+// BindCloseIntercept makes the close button hide the window to the tray or quit, as
+// chosen. Until there is a choice, closing asks for it.
+func (r *uiRuntime) BindCloseIntercept(choice *closeButtonChoice) {
 	if r.window == nil {
 		return
 	}
 	r.window.SetCloseIntercept(func() {
-		appLogger.Debug("main window close intercepted: hiding to tray")
-		r.window.Hide()
+		switch action := choice.Action(); action {
+		case config.CloseButtonQuit:
+			appLogger.Debug("main window close intercepted: quitting")
+			r.Quit()
+		case config.CloseButtonHideToTray:
+			appLogger.Debug("main window close intercepted: hiding to tray")
+			r.hide()
+		default:
+			appLogger.Debug("main window close intercepted: asking what the close button does")
+			showCloseButtonPrompt(r.window, func(action config.CloseButtonAction) {
+				if err := choice.Set(action); err != nil {
+					appLogger.Warn("failed to save close button action", "action", action, "error", err)
+				}
+				if action == config.CloseButtonQuit {
+					r.Quit()
+
+					return
+				}
+				r.hide()
+			})
+		}
 	})
 }
 
+// BindMainMenu adds the main menu with the explicit way out, as the close button may
+// only hide the window.
+func (r *uiRuntime) BindMainMenu() {
+	if r.window == nil {
+		return
+	}
+	setMenu := func() {
+		quit := fyne.NewMenuItem(i18n.T("Quit"), r.Quit)
+		quit.IsQuit = true
+		r.window.SetMainMenu(fyne.NewMainMenu(fyne.NewMenu(i18n.T("File"),
+			fyne.NewMenuItem(i18n.T("Hide to the tray"), r.hide),
+			fyne.NewMenuItemSeparator(),
+			quit,
+		)))
+	}
+	setMenu()
+	i18n.OnChange(func(string) {
+		fyne.Do(setMenu)
+	})
+}
+
+func (r *uiRuntime) hide() {
+	if r.beforeHide != nil {
+		r.beforeHide()
+	}
+	r.window.Hide()
+}
+
 func (r *uiRuntime) Quit() {
 	r.shutdownOnce.Do(func() {
 		appLogger.Info("quitting UI runtime")
+		if r.beforeHide != nil {
+			r.beforeHide()
+		}
 		r.stop()
 		if r.fyApp != nil {
 			r.fyApp.Quit()
@@ -59,6 +123,9 @@ func (r *uiRuntime) Quit() {
 func (r *uiRuntime) Run(startHidden bool) {
 	if r.window != nil {
 		r.window.Show()
+		if r.afterShow != nil {
+			r.afterShow()
+		}
 		if startHidden {
 			appLogger.Info("launch option start_hidden is enabled: hiding main window")
 			r.window.Hide()
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	fynetest "fyne.io/fyne/v2/test"
+
+	"github.com/skobkin/meshgo/internal/config"
 )
 
 func TestUIRuntimeQuitStopsOnceAndQuitsApp(t *testing.T) {
@@ -42,21 +44,79 @@ func TestUIRuntimeQuitStopsOnceAndQuitsApp(t *testing.T) {
 	}
 }
 
-func TestUIRuntimeBindCloseInterceptHidesWindow(t *testing.T) {
+func TestUIRuntimeBindCloseInterceptFollowsChoice(t *testing.T) {
+	tests := []struct {
+		action    config.CloseButtonAction
+		wantHides int
+		wantQuits int
+	}{
+		{action: config.CloseButtonHideToTray, wantHides: 1},
+		{action: config.CloseButtonQuit, wantQuits: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.action), func(t *testing.T) {
+			base := fynetest.NewApp()
+			t.Cleanup(base.Quit)
+			app := &appRunQuitSpy{App: base}
+
+			window := &windowSpy{Window: base.NewWindow("runtime")}
+			runtime := newUIRuntime(app, window, nil, nil, nil, nil)
+
+			runtime.BindCloseIntercept(&closeButtonChoice{action: tc.action})
+			if window.closeIntercept == nil {
+				t.Fatalf("expected close intercept to be set")
+			}
+
+			window.closeIntercept()
+			if window.hideCalls != tc.wantHides {
+				t.Fatalf("expected %d hides, got %d", tc.wantHides, window.hideCalls)
+			}
+			if app.quitCalls != tc.wantQuits {
+				t.Fatalf("expected %d quits, got %d", tc.wantQuits, app.quitCalls)
+			}
+		})
+	}
+}
+
+func TestUIRuntimeBindCloseInterceptAsksFirst(t *testing.T) {
 	base := fynetest.NewApp()
 	t.Cleanup(base.Quit)
+	app := &appRunQuitSpy{App: base}
 
-	window := &windowSpy{Window: base.NewWindow("runtime")}
-	runtime := newUIRuntime(base, window, nil, nil, nil, nil)
+	var saved []config.CloseButtonAction
+	choice := &closeButtonChoice{
+		action: config.CloseButtonAsk,
+		save: func(action config.CloseButtonAction) error {
+			saved = append(saved, action)
 
-	runtime.BindCloseIntercept()
-	if window.closeIntercept == nil {
-		t.Fatalf("expected close intercept to be set")
+			return nil
+		},
 	}
+	window := &windowSpy{Window: base.NewWindow("runtime")}
+	runtime := newUIRuntime(app, window, nil, nil, nil, nil)
+	runtime.BindCloseIntercept(choice)
 
 	window.closeIntercept()
+	prompt := window.Canvas().Overlays().Top()
+	if prompt == nil {
+		t.Fatalf("expected the first close to ask")
+	}
+	if window.hideCalls != 0 || app.quitCalls != 0 {
+		t.Fatalf("expected the window to stay until answered, hides=%d quits=%d", window.hideCalls, app.quitCalls)
+	}
+
+	fynetest.Tap(mustFindButtonByText(t, prompt, "Keep running in the tray"))
 	if window.hideCalls != 1 {
-		t.Fatalf("expected intercept to hide window once, got %d", window.hideCalls)
+		t.Fatalf("expected the window to be hidden once, got %d", window.hideCalls)
+	}
+	if len(saved) != 1 || saved[0] != config.CloseButtonHideToTray {
+		t.Fatalf("expected the answer to be saved, got %v", saved)
+	}
+
+	window.closeIntercept()
+	if window.hideCalls != 2 || window.Canvas().Overlays().Top() != nil {
+		t.Fatalf("expected the next close to hide without asking, hides=%d", window.hideCalls)
 	}
 }
 
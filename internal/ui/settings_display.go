@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+const (
+	themeModeOptionSystem       = "System"
+	themeModeOptionDark         = "Dark"
+	themeModeOptionLight        = "Light"
+	themeModeOptionHighContrast = "High contrast"
+
+	accentColorOptionSystem = "System"
+
+	trayIconOptionAuto  = "Match app theme"
+	trayIconOptionDark  = "Dark tray panel"
+	trayIconOptionLight = "Light tray panel"
+
+	languageOptionSystem = "System"
+)
+
+// displaySettingsForm edits the language, the theme and the UI scale for the monitor the
+// window is on.
+type displaySettingsForm struct {
+	content fyne.CanvasObject
+	set     func(ui config.UIConfig)
+	read    func(ui config.UIConfig) config.UIConfig
+}
+
+// lowBatteryPercentOptions are the thresholds offered for the low battery highlight and
+// alerts.
+var lowBatteryPercentOptions = []int{10, 15, 20, 25, 30, 40, 50}
+
+func lowBatteryPercentLabels() []string {
+	labels := make([]string, 0, len(lowBatteryPercentOptions))
+	for _, percent := range lowBatteryPercentOptions {
+		labels = append(labels, fmt.Sprintf("%d%%", percent))
+	}
+
+	return labels
+}
+
+func parseLowBatteryPercentLabel(label string) int {
+	for _, percent := range lowBatteryPercentOptions {
+		if fmt.Sprintf("%d%%", percent) == label {
+			return percent
+		}
+	}
+
+	return config.DefaultLowBatteryPercent
+}
+
+func uiScaleLabel(scale float64) string {
+	return fmt.Sprintf("%d%%", int(math.Round(scale*100)))
+}
+
+func themeModeLabel(mode config.ThemeMode) string {
+	switch mode {
+	case config.ThemeModeDark:
+		return i18n.T(themeModeOptionDark)
+	case config.ThemeModeLight:
+		return i18n.T(themeModeOptionLight)
+	case config.ThemeModeHighContrast:
+		return i18n.T(themeModeOptionHighContrast)
+	default:
+		return i18n.T(themeModeOptionSystem)
+	}
+}
+
+func parseThemeModeLabel(label string) config.ThemeMode {
+	switch label {
+	case i18n.T(themeModeOptionDark):
+		return config.ThemeModeDark
+	case i18n.T(themeModeOptionLight):
+		return config.ThemeModeLight
+	case i18n.T(themeModeOptionHighContrast):
+		return config.ThemeModeHighContrast
+	default:
+		return config.ThemeModeSystem
+	}
+}
+
+func accentColorOptions() []string {
+	options := []string{i18n.T(accentColorOptionSystem)}
+	for _, accent := range config.AccentColors {
+		options = append(options, accentColorLabel(accent))
+	}
+
+	return options
+}
+
+func accentColorLabel(accent string) string {
+	if accent == "" {
+		return i18n.T(accentColorOptionSystem)
+	}
+
+	return i18n.T(strings.ToUpper(accent[:1]) + accent[1:])
+}
+
+func parseAccentColorLabel(label string) string {
+	for _, accent := range config.AccentColors {
+		if accentColorLabel(accent) == label {
+			return accent
+		}
+	}
+
+	return ""
+}
+
+func trayIconLabel(style config.TrayIconStyle) string {
+	switch style {
+	case config.TrayIconStyleDark:
+		return i18n.T(trayIconOptionDark)
+	case config.TrayIconStyleLight:
+		return i18n.T(trayIconOptionLight)
+	default:
+		return i18n.T(trayIconOptionAuto)
+	}
+}
+
+func parseTrayIconLabel(label string) config.TrayIconStyle {
+	switch label {
+	case i18n.T(trayIconOptionDark):
+		return config.TrayIconStyleDark
+	case i18n.T(trayIconOptionLight):
+		return config.TrayIconStyleLight
+	default:
+		return config.TrayIconStyleAuto
+	}
+}
+
+// languageOptions lists languages by their native names, after the option following the
+// system locale.
+func languageOptions() []string {
+	options := []string{i18n.T(languageOptionSystem)}
+	for _, language := range i18n.Languages() {
+		options = append(options, language.Name)
+	}
+
+	return options
+}
+
+func languageLabel(code string) string {
+	for _, language := range i18n.Languages() {
+		if language.Code == code {
+			return language.Name
+		}
+	}
+
+	return i18n.T(languageOptionSystem)
+}
+
+func parseLanguageLabel(label string) string {
+	for _, language := range i18n.Languages() {
+		if language.Name == label {
+			return language.Code
+		}
+	}
+
+	return ""
+}
+
+func newDisplaySettingsForm(current config.UIConfig, monitorKey func() string) displaySettingsForm {
+	scaleLabel := widget.NewLabel("")
+	scaleSlider := widget.NewSlider(config.MinUIScale*100, config.MaxUIScale*100)
+	scaleSlider.Step = config.UIScaleStep * 100
+	scaleSlider.OnChanged = func(value float64) {
+		scaleLabel.SetText(uiScaleLabel(value / 100))
+	}
+	languageSelect := widget.NewSelect(languageOptions(), nil)
+	themeSelect := widget.NewSelect([]string{
+		i18n.T(themeModeOptionSystem),
+		i18n.T(themeModeOptionDark),
+		i18n.T(themeModeOptionLight),
+		i18n.T(themeModeOptionHighContrast),
+	}, nil)
+	accentSelect := widget.NewSelect(accentColorOptions(), nil)
+	trayIconSelect := widget.NewSelect([]string{
+		i18n.T(trayIconOptionAuto),
+		i18n.T(trayIconOptionDark),
+		i18n.T(trayIconOptionLight),
+	}, nil)
+	lowBatterySelect := widget.NewSelect(lowBatteryPercentLabels(), nil)
+	set := func(ui config.UIConfig) {
+		display := ui.Display
+		lowBatterySelect.SetSelected(fmt.Sprintf("%d%%", display.LowBatteryThreshold()))
+		languageSelect.SetSelected(languageLabel(ui.Language))
+		themeSelect.SetSelected(themeModeLabel(display.Theme))
+		accentSelect.SetSelected(accentColorLabel(display.AccentColor))
+		trayIconSelect.SetSelected(trayIconLabel(display.TrayIcon))
+		scaleSlider.SetValue(display.ScaleFor(monitorKey()) * 100)
+		scaleLabel.SetText(uiScaleLabel(scaleSlider.Value / 100))
+	}
+	set(current)
+	resetButton := widget.NewButton(i18n.T("Reset"), func() {
+		scaleSlider.SetValue(100)
+	})
+
+	help := widget.NewLabel(i18n.T("The scale is remembered for each monitor density, so docking and undocking a laptop switches between saved scales. Use \"Move window to screen\" in the tray menu if the window is lost after a monitor is disconnected.") +
+		"\n" + i18n.T("A new language applies to screens opened after saving; restart the app to translate the rest."))
+	help.Wrapping = fyne.TextWrapWord
+
+	return displaySettingsForm{
+		content: container.NewVBox(
+			widget.NewForm(
+				widget.NewFormItem(i18n.T("Language"), languageSelect),
+				widget.NewFormItem(i18n.T("Theme"), themeSelect),
+				widget.NewFormItem(i18n.T("Accent color"), accentSelect),
+				widget.NewFormItem(i18n.T("Tray icon"), trayIconSelect),
+				widget.NewFormItem(i18n.T("Low battery below"), lowBatterySelect),
+				widget.NewFormItem(i18n.T("UI scale"), container.NewBorder(nil, nil, nil, container.NewHBox(scaleLabel, resetButton), scaleSlider)),
+			),
+			help,
+		),
+		set: set,
+		read: func(ui config.UIConfig) config.UIConfig {
+			ui.Language = parseLanguageLabel(languageSelect.Selected)
+			ui.Display.SetScale(monitorKey(), scaleSlider.Value/100)
+			ui.Display.Theme = parseThemeModeLabel(themeSelect.Selected)
+			ui.Display.AccentColor = parseAccentColorLabel(accentSelect.Selected)
+			ui.Display.TrayIcon = parseTrayIconLabel(trayIconSelect.Selected)
+			ui.Display.LowBatteryPercent = parseLowBatteryPercentLabel(lowBatterySelect.Selected)
+
+			return ui
+		},
+	}
+}
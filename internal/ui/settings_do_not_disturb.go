@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// doNotDisturbSettingsForm edits the daily do-not-disturb schedule.
+type doNotDisturbSettingsForm struct {
+	content fyne.CanvasObject
+	set     func(prefs config.DoNotDisturbConfig)
+	read    func() (config.DoNotDisturbConfig, error)
+}
+
+func newDoNotDisturbSettingsForm(current config.DoNotDisturbConfig) doNotDisturbSettingsForm {
+	start := widget.NewEntry()
+	start.SetPlaceHolder(config.DefaultDoNotDisturbStart)
+	end := widget.NewEntry()
+	end.SetPlaceHolder(config.DefaultDoNotDisturbEnd)
+	allowBells := widget.NewCheck(i18n.T("Let messages with an alert bell through"), nil)
+	enabled := widget.NewCheck(i18n.T("Do not disturb on a schedule"), func(on bool) {
+		for _, field := range []fyne.Disableable{start, end, allowBells} {
+			if on {
+				field.Enable()
+			} else {
+				field.Disable()
+			}
+		}
+	})
+	help := widget.NewLabel(i18n.T("Notifications and message sounds are held back during these hours. Notifications are still listed in the notification center."))
+	help.Wrapping = fyne.TextWrapWord
+
+	set := func(prefs config.DoNotDisturbConfig) {
+		start.SetText(prefs.Start)
+		end.SetText(prefs.End)
+		allowBells.SetChecked(prefs.AllowBells)
+		enabled.SetChecked(prefs.Enabled)
+		enabled.OnChanged(prefs.Enabled)
+	}
+	set(current)
+
+	return doNotDisturbSettingsForm{
+		content: container.NewVBox(
+			enabled,
+			widget.NewForm(
+				widget.NewFormItem(i18n.T("From"), start),
+				widget.NewFormItem(i18n.T("Until"), end),
+			),
+			allowBells,
+			help,
+		),
+		set: set,
+		read: func() (config.DoNotDisturbConfig, error) {
+			prefs := config.DoNotDisturbConfig{
+				Enabled:    enabled.Checked,
+				Start:      strings.TrimSpace(start.Text),
+				End:        strings.TrimSpace(end.Text),
+				AllowBells: allowBells.Checked,
+			}
+			for _, value := range []string{prefs.Start, prefs.End} {
+				if _, err := config.ParseClockMinutes(value); err != nil {
+					return config.DoNotDisturbConfig{}, fmt.Errorf("%s: %w", i18n.T("do not disturb"), err)
+				}
+			}
+
+			return prefs, nil
+		},
+	}
+}
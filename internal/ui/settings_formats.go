@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+type formatOption[T ~string] struct {
+	Value T
+	// Label is the English label; it is translated when shown.
+	Label string
+}
+
+var timeFormatOptions = []formatOption[config.TimeFormat]{
+	{Value: config.TimeFormatAuto, Label: "System locale"},
+	{Value: config.TimeFormat24h, Label: "24-hour (15:04)"},
+	{Value: config.TimeFormat12h, Label: "12-hour (3:04 PM)"},
+}
+
+var dateFormatOptions = []formatOption[config.DateFormat]{
+	{Value: config.DateFormatAuto, Label: "System locale"},
+	{Value: config.DateFormatISO, Label: "Year-month-day (2006-01-31)"},
+	{Value: config.DateFormatDMY, Label: "Day/month/year (31/01/2006)"},
+	{Value: config.DateFormatMDY, Label: "Month/day/year (01/31/2006)"},
+}
+
+var decimalSeparatorOptions = []formatOption[config.DecimalSeparator]{
+	{Value: config.DecimalSeparatorAuto, Label: "System locale"},
+	{Value: config.DecimalSeparatorPoint, Label: "Point (3.14)"},
+	{Value: config.DecimalSeparatorComma, Label: "Comma (3,14)"},
+}
+
+var temperatureUnitOptions = []formatOption[config.TemperatureUnit]{
+	{Value: config.TemperatureUnitAuto, Label: "System locale"},
+	{Value: config.TemperatureUnitCelsius, Label: "Celsius"},
+	{Value: config.TemperatureUnitFahrenheit, Label: "Fahrenheit"},
+}
+
+var coordinateFormatOptions = []formatOption[config.CoordinateFormat]{
+	{Value: config.CoordinateFormatDecimal, Label: "Decimal degrees (50.450333)"},
+	{Value: config.CoordinateFormatDMS, Label: "Degrees, minutes, seconds (50°27'01.2\"N)"},
+	{Value: config.CoordinateFormatMGRS, Label: "MGRS (36U UA 24178 91633)"},
+	{Value: config.CoordinateFormatMaidenhead, Label: "Maidenhead grid square (KO50gk)"},
+}
+
+var messageTimestampOptions = []formatOption[config.MessageTimestamp]{
+	{Value: config.MessageTimestampAbsolute, Label: "Clock time (15:04)"},
+	{Value: config.MessageTimestampRelative, Label: "Time ago (5 min ago)"},
+}
+
+func formatOptionLabels[T ~string](options []formatOption[T]) []string {
+	labels := make([]string, 0, len(options))
+	for _, option := range options {
+		labels = append(labels, i18n.T(option.Label))
+	}
+
+	return labels
+}
+
+func formatOptionLabel[T ~string](options []formatOption[T], value T) string {
+	for _, option := range options {
+		if option.Value == value {
+			return i18n.T(option.Label)
+		}
+	}
+
+	return i18n.T(options[0].Label)
+}
+
+func parseFormatOptionLabel[T ~string](options []formatOption[T], label string) T {
+	for _, option := range options {
+		if i18n.T(option.Label) == label {
+			return option.Value
+		}
+	}
+
+	return options[0].Value
+}
+
+// formatsSettingsForm edits display format preferences in the app settings tab.
+type formatsSettingsForm struct {
+	content fyne.CanvasObject
+	set     func(formats config.FormatsConfig)
+	read    func() config.FormatsConfig
+}
+
+func newFormatsSettingsForm(current config.FormatsConfig) formatsSettingsForm {
+	timeSelect := widget.NewSelect(formatOptionLabels(timeFormatOptions), nil)
+	dateSelect := widget.NewSelect(formatOptionLabels(dateFormatOptions), nil)
+	decimalSelect := widget.NewSelect(formatOptionLabels(decimalSeparatorOptions), nil)
+	temperatureSelect := widget.NewSelect(formatOptionLabels(temperatureUnitOptions), nil)
+	coordinateSelect := widget.NewSelect(formatOptionLabels(coordinateFormatOptions), nil)
+	messageTimestampSelect := widget.NewSelect(formatOptionLabels(messageTimestampOptions), nil)
+	daySeparatorsCheck := widget.NewCheck(i18n.T("Show dates between days in chats"), nil)
+
+	set := func(formats config.FormatsConfig) {
+		timeSelect.SetSelected(formatOptionLabel(timeFormatOptions, formats.TimeFormat))
+		dateSelect.SetSelected(formatOptionLabel(dateFormatOptions, formats.DateFormat))
+		decimalSelect.SetSelected(formatOptionLabel(decimalSeparatorOptions, formats.DecimalSeparator))
+		temperatureSelect.SetSelected(formatOptionLabel(temperatureUnitOptions, formats.TemperatureUnit))
+		coordinateSelect.SetSelected(formatOptionLabel(coordinateFormatOptions, formats.CoordinateFormat))
+		messageTimestampSelect.SetSelected(formatOptionLabel(messageTimestampOptions, formats.MessageTimestamp))
+		daySeparatorsCheck.SetChecked(formats.ChatDaySeparators)
+	}
+	set(current)
+
+	help := widget.NewLabel(i18n.T("System locale follows LC_ALL, LC_TIME or LANG. Changes apply to views opened or redrawn after saving."))
+	help.Wrapping = fyne.TextWrapWord
+
+	return formatsSettingsForm{
+		content: container.NewVBox(
+			widget.NewForm(
+				widget.NewFormItem(i18n.T("Time"), timeSelect),
+				widget.NewFormItem(i18n.T("Date"), dateSelect),
+				widget.NewFormItem(i18n.T("Decimal separator"), decimalSelect),
+				widget.NewFormItem(i18n.T("Temperature"), temperatureSelect),
+				widget.NewFormItem(i18n.T("Coordinates"), coordinateSelect),
+				widget.NewFormItem(i18n.T("Message time"), messageTimestampSelect),
+				widget.NewFormItem("", daySeparatorsCheck),
+			),
+			help,
+		),
+		set: set,
+		read: func() config.FormatsConfig {
+			return config.FormatsConfig{
+				TimeFormat:        parseFormatOptionLabel(timeFormatOptions, timeSelect.Selected),
+				DateFormat:        parseFormatOptionLabel(dateFormatOptions, dateSelect.Selected),
+				DecimalSeparator:  parseFormatOptionLabel(decimalSeparatorOptions, decimalSelect.Selected),
+				TemperatureUnit:   parseFormatOptionLabel(temperatureUnitOptions, temperatureSelect.Selected),
+				CoordinateFormat:  parseFormatOptionLabel(coordinateFormatOptions, coordinateSelect.Selected),
+				MessageTimestamp:  parseFormatOptionLabel(messageTimestampOptions, messageTimestampSelect.Selected),
+				ChatDaySeparators: daySeparatorsCheck.Checked,
+			}
+		},
+	}
+}
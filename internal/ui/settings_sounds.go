@@ -0,0 +1,252 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/i18n"
+	"github.com/skobkin/meshgo/internal/sounds"
+)
+
+const soundOptionChooseFile = "Choose file…"
+
+// soundSettingsForm edits the sound effects of sent messages and of each kind of
+// received message.
+type soundSettingsForm struct {
+	content fyne.CanvasObject
+	set     func(prefs config.SoundsConfig)
+	read    func() config.SoundsConfig
+}
+
+// soundEventControls edit the sound of one event.
+type soundEventControls struct {
+	enabled     *widget.Check
+	soundSelect *widget.Select
+	volume      *widget.Slider
+	volumeLabel *widget.Label
+	test        *widget.Button
+
+	sound  string
+	custom string
+}
+
+func bundledSoundLabel(sound string) string {
+	return i18n.T(strings.ToUpper(sound[:1]) + sound[1:])
+}
+
+// soundLabel names a bundled sound or the file name of a custom one.
+func soundLabel(sound string) string {
+	if config.IsBundledSound(sound) {
+		return bundledSoundLabel(sound)
+	}
+
+	return filepath.Base(sound)
+}
+
+func (c *soundEventControls) options() []string {
+	options := make([]string, 0, len(config.BundledSounds)+2)
+	for _, sound := range config.BundledSounds {
+		options = append(options, bundledSoundLabel(sound))
+	}
+	if c.custom != "" {
+		options = append(options, soundLabel(c.custom))
+	}
+
+	return append(options, i18n.T(soundOptionChooseFile))
+}
+
+func (c *soundEventControls) set(event config.SoundEventConfig) {
+	c.sound = event.Sound
+	if !config.IsBundledSound(event.Sound) {
+		c.custom = event.Sound
+	}
+	c.enabled.SetChecked(event.Enabled)
+	c.soundSelect.SetOptions(c.options())
+	c.soundSelect.SetSelected(soundLabel(c.sound))
+	c.volume.SetValue(float64(event.Volume))
+}
+
+func (c *soundEventControls) read() config.SoundEventConfig {
+	return config.SoundEventConfig{Enabled: c.enabled.Checked, Sound: c.sound, Volume: int(c.volume.Value)}
+}
+
+func newSoundEventControls(
+	label string,
+	current config.SoundEventConfig,
+	preview func(config.SoundEventConfig) error,
+	window func() fyne.Window,
+) *soundEventControls {
+	controls := &soundEventControls{
+		enabled:     widget.NewCheck(label, nil),
+		volumeLabel: widget.NewLabel(""),
+	}
+	controls.volume = widget.NewSlider(1, 100)
+	controls.volume.Step = 1
+	controls.volume.OnChanged = func(value float64) {
+		controls.volumeLabel.SetText(fmt.Sprintf("%d%%", int(value)))
+	}
+	controls.soundSelect = widget.NewSelect(nil, nil)
+	controls.soundSelect.OnChanged = func(selected string) {
+		if selected != i18n.T(soundOptionChooseFile) {
+			for _, sound := range config.BundledSounds {
+				if bundledSoundLabel(sound) == selected {
+					controls.sound = sound
+				}
+			}
+			if controls.custom != "" && selected == soundLabel(controls.custom) {
+				controls.sound = controls.custom
+			}
+
+			return
+		}
+		// Keep the current sound shown until a file is picked.
+		controls.soundSelect.SetSelected(soundLabel(controls.sound))
+		chooseSoundFile(window(), func(path string) {
+			controls.custom = path
+			controls.sound = path
+			controls.soundSelect.SetOptions(controls.options())
+			controls.soundSelect.SetSelected(soundLabel(path))
+		})
+	}
+	controls.test = widget.NewButton(i18n.T("Test"), func() {
+		if err := preview(controls.read()); err != nil {
+			settingsLogger.Warn("sound preview failed", "sound", controls.sound, "error", err)
+			if current := window(); current != nil {
+				dialog.ShowError(err, current)
+			}
+		}
+	})
+	if preview == nil {
+		controls.test.Hide()
+	}
+	controls.set(current)
+
+	return controls
+}
+
+// chooseSoundFile asks for a WAV file and calls onPick once it can be played.
+func chooseSoundFile(window fyne.Window, onPick func(path string)) {
+	if window == nil {
+		return
+	}
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			settingsLogger.Warn("sound file selection failed", "error", err)
+			dialog.ShowError(err, window)
+
+			return
+		}
+		if reader == nil {
+			return
+		}
+		path := reader.URI().Path()
+		_ = reader.Close()
+		if err := sounds.Validate(path); err != nil {
+			settingsLogger.Warn("sound file rejected", "path", path, "error", err)
+			dialog.ShowError(err, window)
+
+			return
+		}
+		onPick(path)
+	}, window)
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".wav"}))
+	openDialog.Show()
+}
+
+func newSoundSettingsForm(
+	current config.SoundsConfig,
+	preview func(config.SoundEventConfig) error,
+	window func() fyne.Window,
+) soundSettingsForm {
+	enabled := widget.NewCheck(i18n.T("Play sounds for chat messages"), nil)
+	send := newSoundEventControls(i18n.T("Sent message"), current.Send, preview, window)
+	receive := newSoundEventControls(i18n.T("Channel message"), current.Receive, preview, window)
+	direct := newSoundEventControls(i18n.T("Direct message"), current.Direct, preview, window)
+	alert := newSoundEventControls(i18n.T("Alert message (with a bell)"), current.Alert, preview, window)
+	events := []*soundEventControls{send, receive, direct, alert}
+
+	updateEnabled := func() {
+		for _, event := range events {
+			widgets := []fyne.Disableable{event.soundSelect, event.volume}
+			if enabled.Checked {
+				event.enabled.Enable()
+			} else {
+				event.enabled.Disable()
+			}
+			for _, item := range widgets {
+				if enabled.Checked && event.enabled.Checked {
+					item.Enable()
+				} else {
+					item.Disable()
+				}
+			}
+		}
+	}
+	enabled.OnChanged = func(bool) { updateEnabled() }
+	for _, event := range events {
+		event.enabled.OnChanged = func(bool) { updateEnabled() }
+	}
+	set := func(prefs config.SoundsConfig) {
+		enabled.SetChecked(prefs.Enabled)
+		send.set(prefs.Send)
+		receive.set(prefs.Receive)
+		direct.set(prefs.Direct)
+		alert.set(prefs.Alert)
+		updateEnabled()
+	}
+	set(current)
+
+	eventRow := func(event *soundEventControls) fyne.CanvasObject {
+		volume := container.NewBorder(nil, nil, nil, event.volumeLabel, event.volume)
+
+		return container.NewVBox(
+			event.enabled,
+			widget.NewForm(
+				widget.NewFormItem(i18n.T("Sound"), container.NewBorder(nil, nil, nil, event.test, event.soundSelect)),
+				widget.NewFormItem(i18n.T("Volume"), volume),
+			),
+		)
+	}
+	help := widget.NewLabel(i18n.T("Uncheck a message kind to keep it silent. Custom sounds must be 16-bit PCM WAV files. Sounds are not played while notifications are muted."))
+	help.Wrapping = fyne.TextWrapWord
+
+	return soundSettingsForm{
+		content: container.NewVBox(enabled, eventRow(send), eventRow(receive), eventRow(direct), eventRow(alert), help),
+		set:     set,
+		read: func() config.SoundsConfig {
+			return config.SoundsConfig{
+				Enabled: enabled.Checked,
+				Send:    send.read(),
+				Receive: receive.read(),
+				Direct:  direct.read(),
+				Alert:   alert.read(),
+			}
+		},
+	}
+}
+
+// newNotificationMuteCheck shows the global mute, which is also in the tray menu. It
+// applies right away instead of on save.
+func newNotificationMuteCheck(mute *notificationMute) *widget.Check {
+	check := widget.NewCheck(i18n.T("Mute notifications and sounds"), nil)
+	check.SetChecked(mute.Muted())
+	check.OnChanged = func(muted bool) {
+		if err := mute.Set(muted); err != nil {
+			settingsLogger.Warn("failed to change notifications mute", "error", err)
+			check.SetChecked(mute.Muted())
+		}
+	}
+	mute.OnChange(func(muted bool) {
+		fyne.Do(func() { check.SetChecked(muted) })
+	})
+
+	return check
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/i18n"
 	"github.com/skobkin/meshgo/internal/resources"
 )
 
@@ -27,12 +29,39 @@ const (
 	transportOptionBluetooth = "Bluetooth LE (unstable)"
 	autostartOptionNormal    = "Normal window"
 	autostartOptionTray      = "Background tray"
+
+	notificationGroupingOptionChat   = "Per chat"
+	notificationGroupingOptionSender = "Per sender"
+	notificationGroupingOptionGlobal = "All messages together"
+
+	notificationClickOptionOpenChat   = "Open the chat"
+	notificationClickOptionShowWindow = "Only show the window"
 )
 
 var defaultSerialBaudOptions = []string{"9600", "19200", "38400", "57600", "115200", "230400", "460800", "921600"}
-var settingsLogger = slog.With("component", "ui.settings")
+
+// settingsLogComponent names the settings log lines shown in error toast details.
+const settingsLogComponent = "ui.settings"
+
+var settingsLogger = slog.With("component", settingsLogComponent)
 
 func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne.CanvasObject {
+	tab, _ := newSettingsTabWithConnectionSync(dep, connStatusLabel, newNotificationMute(dep), newCloseButtonChoice(dep), nil)
+
+	return tab
+}
+
+// newSettingsTabWithConnectionSync also returns a function that shows a connection
+// saved outside of the settings tab, so a later settings save doesn't revert it. mute is
+// shared with the tray menu, closeButton with the main window, and reportError shows
+// failed saves as error toasts.
+func newSettingsTabWithConnectionSync(
+	dep RuntimeDependencies,
+	connStatusLabel *widget.Label,
+	mute *notificationMute,
+	closeButton *closeButtonChoice,
+	reportError reportErrorFunc,
+) (fyne.CanvasObject, func(conn config.ConnectionConfig)) {
 	current := dep.Data.Config
 	current.FillMissingDefaults()
 	settingsLogger.Debug(
@@ -44,10 +73,14 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 		"autostart_mode", current.UI.Autostart.Mode,
 		"compact_cyrillic_encoding", current.UI.Messaging.CompactCyrillicEncoding,
 		"notify_when_focused", current.UI.Notifications.NotifyWhenFocused,
+		"notify_message_grouping", current.UI.Notifications.MessageGrouping,
+		"notify_click_action", current.UI.Notifications.ClickAction,
 		"notify_incoming_message", current.UI.Notifications.Events.IncomingMessage,
 		"notify_node_discovered", current.UI.Notifications.Events.NodeDiscovered,
 		"notify_connection_status", current.UI.Notifications.Events.ConnectionStatus,
 		"notify_update_available", current.UI.Notifications.Events.UpdateAvailable,
+		"notify_low_battery", current.UI.Notifications.Events.LowBattery,
+		"taskbar_flash_enabled", current.UI.TaskbarFlash.Enabled,
 		"map_show_precision_circles", current.UI.MapDisplay.ShowPrecisionCircles,
 		"map_show_precision_circles_only_on_hover", current.UI.MapDisplay.ShowPrecisionCirclesOnlyOnHover,
 	)
@@ -63,11 +96,28 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 
 	hostEntry := widget.NewEntry()
 	hostEntry.SetText(current.Connection.Host)
-	hostEntry.SetPlaceHolder("IP address or hostname")
+	hostEntry.SetPlaceHolder(i18n.T("IP address or hostname"))
 
 	logToFile := widget.NewCheck("", nil)
 	logToFile.SetChecked(current.Logging.LogToFile)
 
+	mutedNodeEventsEntry := widget.NewMultiLineEntry()
+	mutedNodeEventsEntry.SetMinRowsVisible(3)
+	mutedNodeEventsEntry.SetPlaceHolder("!1234abcd: position, telemetry")
+	mutedNodeEventsEntry.SetText(config.FormatMutedNodeEvents(current.Logging.MutedNodeEvents))
+
+	supportUploadURLEntry := widget.NewEntry()
+	supportUploadURLEntry.SetPlaceHolder("https://support.example.org/upload")
+	supportUploadURLEntry.SetText(current.Logging.SupportUploadURL)
+
+	rawPacketLogEnabled := widget.NewCheck(i18n.T("Store raw radio frames in the database"), nil)
+	rawPacketLogEnabled.SetChecked(current.Logging.RawPacketLog.Enabled)
+	rawPacketLogSizeSelect := widget.NewSelect(uniqueValues(append(
+		rawPacketLogSizeOptionLabels(),
+		rawPacketLogSizeLabel(current.Logging.RawPacketLog.MaxSizeMB),
+	)), nil)
+	rawPacketLogSizeSelect.SetSelected(rawPacketLogSizeLabel(current.Logging.RawPacketLog.MaxSizeMB))
+
 	levelSelect := widget.NewSelect([]string{"debug", "info", "warn", "error"}, nil)
 	levelSelect.SetSelected(strings.ToLower(current.Logging.Level))
 	if levelSelect.Selected == "" {
@@ -77,13 +127,13 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 	autostartEnabled := widget.NewCheck("", nil)
 	autostartEnabled.SetChecked(current.UI.Autostart.Enabled)
 
-	compactCyrillicEncoding := widget.NewCheck("Compact encoding for Cyrillic", nil)
+	compactCyrillicEncoding := widget.NewCheck(i18n.T("Compact encoding for Cyrillic"), nil)
 	compactCyrillicEncoding.SetChecked(current.UI.Messaging.CompactCyrillicEncoding)
 
-	autostartModeSelect := widget.NewSelect([]string{autostartOptionNormal, autostartOptionTray}, nil)
+	autostartModeSelect := widget.NewSelect([]string{i18n.T(autostartOptionNormal), i18n.T(autostartOptionTray)}, nil)
 	autostartModeSelect.SetSelected(autostartOptionFromMode(current.UI.Autostart.Mode))
 	if autostartModeSelect.Selected == "" {
-		autostartModeSelect.SetSelected(autostartOptionNormal)
+		autostartModeSelect.SetSelected(i18n.T(autostartOptionNormal))
 	}
 	setAutostartModeEnabled := func(enabled bool) {
 		if enabled {
@@ -98,29 +148,70 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 	}
 	setAutostartModeEnabled(autostartEnabled.Checked)
 
-	notifyWhenFocused := widget.NewCheck("Notify when app is focused", nil)
+	notifyWhenFocused := widget.NewCheck(i18n.T("Notify when app is focused"), nil)
 	notifyWhenFocused.SetChecked(current.UI.Notifications.NotifyWhenFocused)
-	notifyIncomingMessage := widget.NewCheck("Incoming chat messages", nil)
+	notifyIncomingMessage := widget.NewCheck(i18n.T("Incoming chat messages"), nil)
 	notifyIncomingMessage.SetChecked(current.UI.Notifications.Events.IncomingMessage)
-	notifyNodeDiscovered := widget.NewCheck("New node discovered", nil)
+	notifyNodeDiscovered := widget.NewCheck(i18n.T("New node discovered"), nil)
 	notifyNodeDiscovered.SetChecked(current.UI.Notifications.Events.NodeDiscovered)
-	notifyConnectionStatus := widget.NewCheck("Connection status changes", nil)
+	notifyConnectionStatus := widget.NewCheck(i18n.T("Connection status changes"), nil)
 	notifyConnectionStatus.SetChecked(current.UI.Notifications.Events.ConnectionStatus)
-	notifyUpdateAvailable := widget.NewCheck("Update available", nil)
+	notifyUpdateAvailable := widget.NewCheck(i18n.T("Update available"), nil)
 	notifyUpdateAvailable.SetChecked(current.UI.Notifications.Events.UpdateAvailable)
-	mapShowPrecisionCircles := widget.NewCheck("Show precision circles", nil)
+	notifyLowBattery := widget.NewCheck(i18n.T("Low battery on local or favorite nodes"), nil)
+	notifyLowBattery.SetChecked(current.UI.Notifications.Events.LowBattery)
+	notifyNodePresence := widget.NewCheck(i18n.T("Favorite nodes going silent or heard again"), nil)
+	notifyNodePresence.SetChecked(current.UI.Notifications.Events.NodePresence)
+	notifyLowBatterySelect := widget.NewSelect(lowBatteryPercentLabels(), nil)
+	notifyLowBatterySelect.SetSelected(fmt.Sprintf("%d%%", current.UI.Notifications.LowBatteryThreshold()))
+	setNotifyLowBatteryEnabled := func(enabled bool) {
+		if enabled {
+			notifyLowBatterySelect.Enable()
+
+			return
+		}
+		notifyLowBatterySelect.Disable()
+	}
+	notifyLowBattery.OnChanged = setNotifyLowBatteryEnabled
+	setNotifyLowBatteryEnabled(notifyLowBattery.Checked)
+	doNotDisturbForm := newDoNotDisturbSettingsForm(current.UI.Notifications.DoNotDisturb)
+	taskbarFlashEnabled := widget.NewCheck(i18n.T("Flash the taskbar on new messages while the window is unfocused"), nil)
+	taskbarFlashEnabled.SetChecked(current.UI.TaskbarFlash.Enabled)
+	notifyMessageGroupingSelect := widget.NewSelect([]string{
+		i18n.T(notificationGroupingOptionChat),
+		i18n.T(notificationGroupingOptionSender),
+		i18n.T(notificationGroupingOptionGlobal),
+	}, nil)
+	notifyMessageGroupingSelect.SetSelected(notificationGroupingOptionFromMode(current.UI.Notifications.MessageGrouping))
+	notifyClickActionSelect := widget.NewSelect([]string{
+		i18n.T(notificationClickOptionOpenChat),
+		i18n.T(notificationClickOptionShowWindow),
+	}, nil)
+	notifyClickActionSelect.SetSelected(notificationClickOptionFromAction(current.UI.Notifications.ClickAction))
+	mapShowPrecisionCircles := widget.NewCheck(i18n.T("Show precision circles"), nil)
 	mapShowPrecisionCircles.SetChecked(current.UI.MapDisplay.ShowPrecisionCircles)
-	mapShowPrecisionCirclesOnlyOnHover := widget.NewCheck("Only on hover", nil)
+	mapShowPrecisionCirclesOnlyOnHover := widget.NewCheck(i18n.T("Only on hover"), nil)
 	mapShowPrecisionCirclesOnlyOnHover.SetChecked(current.UI.MapDisplay.ShowPrecisionCirclesOnlyOnHover)
 	mapLinkProviderSelect := widget.NewSelect(mapLinkProviderLabels(), nil)
 	mapLinkProviderSelect.SetSelected(mapLinkProviderLabel(current.UI.MapDisplay.MapLinkProvider))
+	mapTileCacheSizeSelect := widget.NewSelect(uniqueValues(append(
+		mapTileCacheSizeOptionLabels(),
+		mapTileCacheSizeLabel(current.UI.MapDisplay.TileCacheSizeMB),
+	)), nil)
+	mapTileCacheSizeSelect.SetSelected(mapTileCacheSizeLabel(current.UI.MapDisplay.TileCacheSizeMB))
+	formatsForm := newFormatsSettingsForm(current.UI.Formats)
+	displayForm := newDisplaySettingsForm(current.UI, currentMonitorKey)
 	historyLimitOptions := historyLimitOptionLabels()
 	historyPositionLimitSelect := widget.NewSelect(historyLimitOptions, nil)
 	historyTelemetryLimitSelect := widget.NewSelect(historyLimitOptions, nil)
 	historyIdentityLimitSelect := widget.NewSelect(historyLimitOptions, nil)
+	historySignalLimitSelect := widget.NewSelect(historyLimitOptions, nil)
 	historyPositionLimitSelect.SetSelected(historyLimitLabel(current.Persistence.HistoryLimits.Position, config.DefaultPositionHistoryLimit))
 	historyTelemetryLimitSelect.SetSelected(historyLimitLabel(current.Persistence.HistoryLimits.Telemetry, config.DefaultTelemetryHistoryLimit))
 	historyIdentityLimitSelect.SetSelected(historyLimitLabel(current.Persistence.HistoryLimits.Identity, config.DefaultIdentityHistoryLimit))
+	historySignalLimitSelect.SetSelected(historyLimitLabel(current.Persistence.HistoryLimits.Signal, config.DefaultSignalHistoryLimit))
+	encryptDatabase := widget.NewCheck(i18n.T("Encrypt database at rest"), nil)
+	encryptDatabase.SetChecked(current.Persistence.EncryptDatabase)
 	setMapHoverOnlyEnabled := func(enabled bool) {
 		if enabled {
 			mapShowPrecisionCirclesOnlyOnHover.Enable()
@@ -136,11 +227,11 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 	setMapHoverOnlyEnabled(mapShowPrecisionCircles.Checked)
 
 	status := widget.NewLabel("")
-	bluetoothTestingEnabledCheck := widget.NewCheck("Enable Bluetooth LE testing transport", nil)
+	bluetoothTestingEnabledCheck := widget.NewCheck(i18n.T("Enable Bluetooth LE testing transport"), nil)
 	bluetoothTestingEnabledCheck.SetChecked(current.Connection.BluetoothTestingEnabled)
 
 	serialPortSelect := widget.NewSelect(nil, nil)
-	serialPortSelect.PlaceHolder = "Select serial port"
+	serialPortSelect.PlaceHolder = i18n.T("Select serial port")
 	serialPortSelect.SetSelected(current.Connection.SerialPort)
 
 	serialBaudSelect := widget.NewSelect(uniqueValues(append(defaultSerialBaudOptions, strconv.Itoa(current.Connection.SerialBaud))), nil)
@@ -154,7 +245,7 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 	bluetoothAdapterEntry.SetText(current.Connection.BluetoothAdapter)
 	bluetoothAdapterEntry.SetPlaceHolder("hci0 (optional)")
 
-	bluetoothPairingHint := widget.NewLabel("Pair the node in OS Bluetooth settings before connecting.")
+	bluetoothPairingHint := widget.NewLabel(i18n.T("Pair the node in OS Bluetooth settings before connecting."))
 	bluetoothPairingHint.Wrapping = fyne.TextWrapWord
 
 	bluetoothScanner := dep.Platform.BluetoothScanner
@@ -171,6 +262,8 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 	if currentWindowFn == nil {
 		currentWindowFn = currentWindow
 	}
+	notificationsMuted := newNotificationMuteCheck(mute)
+	soundsForm := newSoundSettingsForm(current.UI.Sounds, messageSoundPreview(dep), currentWindowFn)
 	runOnUI := dep.UIHooks.RunOnUI
 	if runOnUI == nil {
 		runOnUI = fyne.Do
@@ -194,15 +287,15 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 		showInfoDialogFn = dialog.ShowInformation
 	}
 
-	scanBluetoothButton := widget.NewButton("Scan", nil)
-	openBluetoothSettingsButton := widget.NewButton("Open Bluetooth Settings", func() {
+	scanBluetoothButton := widget.NewButton(i18n.T("Scan"), nil)
+	openBluetoothSettingsButton := widget.NewButton(i18n.T("Open Bluetooth Settings"), func() {
 		settingsLogger.Info(
 			"opening Bluetooth settings from UI",
 			"adapter", strings.TrimSpace(bluetoothAdapterEntry.Text),
 		)
 		if err := openBluetoothSettingsFn(); err != nil {
 			settingsLogger.Warn("open Bluetooth settings failed", "error", err)
-			status.SetText("Failed to open Bluetooth settings: " + err.Error())
+			status.SetText(i18n.Tf("Failed to open Bluetooth settings: %s", err))
 
 			return
 		}
@@ -217,20 +310,20 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 		window := currentWindowFn()
 		if window == nil {
 			settingsLogger.Warn("Bluetooth scan failed: active window unavailable")
-			status.SetText("Bluetooth scan failed: active window is unavailable")
+			status.SetText(i18n.T("Bluetooth scan failed: active window is unavailable"))
 
 			return
 		}
 
 		scanBluetoothButton.Disable()
 		openBluetoothSettingsButton.Disable()
-		status.SetText("Scanning...")
+		status.SetText(i18n.T("Scanning..."))
 		progressBar := widget.NewProgressBarInfinite()
 		progressBar.Start()
 		progress := dialog.NewCustomWithoutButtons(
-			"Bluetooth scan",
+			i18n.T("Bluetooth scan"),
 			container.NewVBox(
-				widget.NewLabel("Scanning for nearby devices..."),
+				widget.NewLabel(i18n.T("Scanning for nearby devices...")),
 				progressBar,
 			),
 			window,
@@ -247,22 +340,22 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 
 				if err != nil {
 					settingsLogger.Warn("Bluetooth scan failed", "adapter", adapterID, "error", err)
-					status.SetText("Bluetooth scan failed: " + err.Error())
+					status.SetText(i18n.Tf("Bluetooth scan failed: %s", err))
 					showErrorDialogFn(err, window)
 
 					return
 				}
 				settingsLogger.Info("Bluetooth scan finished", "adapter", adapterID, "devices_found", len(devices))
 				if len(devices) == 0 {
-					status.SetText("No Bluetooth devices found")
-					showInfoDialogFn("Bluetooth scan", "No Bluetooth devices found", window)
+					status.SetText(i18n.T("No Bluetooth devices found"))
+					showInfoDialogFn(i18n.T("Bluetooth scan"), i18n.T("No Bluetooth devices found"), window)
 
 					return
 				}
 
 				showScanDialogFn(window, devices, func(device DiscoveredBluetoothDevice) {
 					bluetoothAddressEntry.SetText(device.Address)
-					status.SetText("Selected: " + device.Address)
+					status.SetText(i18n.Tf("Selected: %s", device.Address))
 				})
 			})
 		})
@@ -274,7 +367,7 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 		ports, err := serial.GetPortsList()
 		if err != nil {
 			settingsLogger.Warn("refreshing serial ports failed", "error", err)
-			status.SetText("Failed to list serial ports: " + err.Error())
+			status.SetText(i18n.Tf("Failed to list serial ports: %s", err))
 
 			return
 		}
@@ -297,7 +390,7 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 
 		if len(ports) == 0 {
 			settingsLogger.Info("serial ports refresh completed: no ports detected")
-			status.SetText("No serial ports detected")
+			status.SetText(i18n.T("No serial ports detected"))
 
 			return
 		}
@@ -305,16 +398,16 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 		status.SetText("")
 	}
 
-	refreshPortsButton := widget.NewButton("Refresh", refreshPorts)
+	refreshPortsButton := widget.NewButton(i18n.T("Refresh"), refreshPorts)
 	serialPortRow := container.NewBorder(nil, nil, nil, refreshPortsButton, serialPortSelect)
 
-	transportLabel := widget.NewLabel("Transport")
+	transportLabel := widget.NewLabel(i18n.T("Transport"))
 	bluetoothTestingEnabledLabel := widget.NewLabel("")
-	ipHostLabel := widget.NewLabel("IP Host")
-	serialPortLabel := widget.NewLabel("Serial Port")
-	serialBaudLabel := widget.NewLabel("Serial Baud")
-	bluetoothAddressLabel := widget.NewLabel("Bluetooth Address")
-	bluetoothAdapterLabel := widget.NewLabel("Bluetooth Adapter")
+	ipHostLabel := widget.NewLabel(i18n.T("IP Host"))
+	serialPortLabel := widget.NewLabel(i18n.T("Serial Port"))
+	serialBaudLabel := widget.NewLabel(i18n.T("Serial Baud"))
+	bluetoothAddressLabel := widget.NewLabel(i18n.T("Bluetooth Address"))
+	bluetoothAdapterLabel := widget.NewLabel(i18n.T("Bluetooth Adapter"))
 	bluetoothActionsLabel := widget.NewLabel("")
 	bluetoothHintLabel := widget.NewLabel("")
 
@@ -351,6 +444,9 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 	}
 	applySavedConfigState := func(next config.AppConfig, statusText string) {
 		current = next
+		setDisplayFormats(current.UI.Formats)
+		setDisplayScaleConfig(current.UI.Display)
+		applyUILanguage(current.UI.Language)
 		showBluetoothTestingToggle = current.Connection.BluetoothTestingEnabled
 		setBluetoothTestingToggleVisible(showBluetoothTestingToggle)
 		status.SetText(statusText)
@@ -365,7 +461,7 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 			refreshPorts()
 		}
 		if next == config.TransportBluetooth {
-			status.SetText("Pair the node in OS Bluetooth settings before connecting.")
+			status.SetText(i18n.T("Pair the node in OS Bluetooth settings before connecting."))
 
 			return
 		}
@@ -380,7 +476,7 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 			refreshPorts()
 		}
 		if selected == config.TransportBluetooth {
-			status.SetText("Pair the node in OS Bluetooth settings before connecting.")
+			status.SetText(i18n.T("Pair the node in OS Bluetooth settings before connecting."))
 
 			return
 		}
@@ -394,30 +490,48 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 		refreshPorts()
 	}
 
+	applyConnectionToForm := func(conn config.ConnectionConfig) config.TransportType {
+		showBluetoothTestingToggle = conn.BluetoothTestingEnabled
+		setBluetoothTestingToggleVisible(showBluetoothTestingToggle)
+		bluetoothTestingEnabledCheck.SetChecked(conn.BluetoothTestingEnabled)
+		selected := selectTransport(conn.Transport, conn.BluetoothTestingEnabled)
+		hostEntry.SetText(conn.Host)
+		serialPortSelect.SetSelected(conn.SerialPort)
+		serialBaudSelect.SetOptions(uniqueValues(append(defaultSerialBaudOptions, strconv.Itoa(conn.SerialBaud))))
+		serialBaudSelect.SetSelected(strconv.Itoa(conn.SerialBaud))
+		bluetoothAddressEntry.SetText(conn.BluetoothAddress)
+		bluetoothAdapterEntry.SetText(conn.BluetoothAdapter)
+		setTransportFields(selected, conn.BluetoothTestingEnabled)
+		if selected == config.TransportSerial {
+			refreshPorts()
+		}
+
+		return selected
+	}
+
 	applyConfigToForm := func(next config.AppConfig) {
 		next.FillMissingDefaults()
 
-		showBluetoothTestingToggle = next.Connection.BluetoothTestingEnabled
-		setBluetoothTestingToggleVisible(showBluetoothTestingToggle)
-		bluetoothTestingEnabledCheck.SetChecked(next.Connection.BluetoothTestingEnabled)
-		selected := selectTransport(next.Connection.Transport, next.Connection.BluetoothTestingEnabled)
-		hostEntry.SetText(next.Connection.Host)
-		serialPortSelect.SetSelected(next.Connection.SerialPort)
-		serialBaudSelect.SetOptions(uniqueValues(append(defaultSerialBaudOptions, strconv.Itoa(next.Connection.SerialBaud))))
-		serialBaudSelect.SetSelected(strconv.Itoa(next.Connection.SerialBaud))
-		bluetoothAddressEntry.SetText(next.Connection.BluetoothAddress)
-		bluetoothAdapterEntry.SetText(next.Connection.BluetoothAdapter)
+		selected := applyConnectionToForm(next.Connection)
 
 		levelSelect.SetSelected(strings.ToLower(next.Logging.Level))
 		if strings.TrimSpace(levelSelect.Selected) == "" {
 			levelSelect.SetSelected("info")
 		}
 		logToFile.SetChecked(next.Logging.LogToFile)
+		mutedNodeEventsEntry.SetText(config.FormatMutedNodeEvents(next.Logging.MutedNodeEvents))
+		supportUploadURLEntry.SetText(next.Logging.SupportUploadURL)
+		rawPacketLogEnabled.SetChecked(next.Logging.RawPacketLog.Enabled)
+		rawPacketLogSizeSelect.SetOptions(uniqueValues(append(
+			rawPacketLogSizeOptionLabels(),
+			rawPacketLogSizeLabel(next.Logging.RawPacketLog.MaxSizeMB),
+		)))
+		rawPacketLogSizeSelect.SetSelected(rawPacketLogSizeLabel(next.Logging.RawPacketLog.MaxSizeMB))
 
 		autostartEnabled.SetChecked(next.UI.Autostart.Enabled)
 		autostartModeSelect.SetSelected(autostartOptionFromMode(next.UI.Autostart.Mode))
 		if strings.TrimSpace(autostartModeSelect.Selected) == "" {
-			autostartModeSelect.SetSelected(autostartOptionNormal)
+			autostartModeSelect.SetSelected(i18n.T(autostartOptionNormal))
 		}
 		setAutostartModeEnabled(autostartEnabled.Checked)
 		compactCyrillicEncoding.SetChecked(next.UI.Messaging.CompactCyrillicEncoding)
@@ -427,27 +541,40 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 		notifyNodeDiscovered.SetChecked(next.UI.Notifications.Events.NodeDiscovered)
 		notifyConnectionStatus.SetChecked(next.UI.Notifications.Events.ConnectionStatus)
 		notifyUpdateAvailable.SetChecked(next.UI.Notifications.Events.UpdateAvailable)
+		notifyLowBattery.SetChecked(next.UI.Notifications.Events.LowBattery)
+		notifyLowBatterySelect.SetSelected(fmt.Sprintf("%d%%", next.UI.Notifications.LowBatteryThreshold()))
+		notifyNodePresence.SetChecked(next.UI.Notifications.Events.NodePresence)
+		doNotDisturbForm.set(next.UI.Notifications.DoNotDisturb)
+		taskbarFlashEnabled.SetChecked(next.UI.TaskbarFlash.Enabled)
+		notifyMessageGroupingSelect.SetSelected(notificationGroupingOptionFromMode(next.UI.Notifications.MessageGrouping))
+		notifyClickActionSelect.SetSelected(notificationClickOptionFromAction(next.UI.Notifications.ClickAction))
+		soundsForm.set(next.UI.Sounds)
 		mapShowPrecisionCircles.SetChecked(next.UI.MapDisplay.ShowPrecisionCircles)
 		mapShowPrecisionCirclesOnlyOnHover.SetChecked(next.UI.MapDisplay.ShowPrecisionCirclesOnlyOnHover)
 		mapLinkProviderSelect.SetSelected(mapLinkProviderLabel(next.UI.MapDisplay.MapLinkProvider))
+		mapTileCacheSizeSelect.SetOptions(uniqueValues(append(
+			mapTileCacheSizeOptionLabels(),
+			mapTileCacheSizeLabel(next.UI.MapDisplay.TileCacheSizeMB),
+		)))
+		mapTileCacheSizeSelect.SetSelected(mapTileCacheSizeLabel(next.UI.MapDisplay.TileCacheSizeMB))
+		formatsForm.set(next.UI.Formats)
+		displayForm.set(next.UI)
 		historyPositionLimitSelect.SetSelected(historyLimitLabel(next.Persistence.HistoryLimits.Position, config.DefaultPositionHistoryLimit))
 		historyTelemetryLimitSelect.SetSelected(historyLimitLabel(next.Persistence.HistoryLimits.Telemetry, config.DefaultTelemetryHistoryLimit))
 		historyIdentityLimitSelect.SetSelected(historyLimitLabel(next.Persistence.HistoryLimits.Identity, config.DefaultIdentityHistoryLimit))
+		historySignalLimitSelect.SetSelected(historyLimitLabel(next.Persistence.HistoryLimits.Signal, config.DefaultSignalHistoryLimit))
+		encryptDatabase.SetChecked(next.Persistence.EncryptDatabase)
 		setMapHoverOnlyEnabled(next.UI.MapDisplay.ShowPrecisionCircles)
 
-		setTransportFields(selected, next.Connection.BluetoothTestingEnabled)
-		if selected == config.TransportSerial {
-			refreshPorts()
-		}
 		if selected == config.TransportBluetooth {
-			status.SetText("Pair the node in OS Bluetooth settings before connecting.")
+			status.SetText(i18n.T("Pair the node in OS Bluetooth settings before connecting."))
 
 			return
 		}
 		status.SetText("")
 	}
 
-	saveButton := widget.NewButton("Save", func() {
+	saveButton := widget.NewButton(i18n.T("Save"), func() {
 		transport := transportTypeFromOption(transportSelect.Selected)
 		transport = normalizeTransportForOptions(transport, bluetoothTestingEnabledCheck.Checked)
 		settingsLogger.Info(
@@ -456,14 +583,19 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 			"bluetooth_testing_enabled", bluetoothTestingEnabledCheck.Checked,
 			"log_level", strings.TrimSpace(levelSelect.Selected),
 			"log_to_file", logToFile.Checked,
+			"raw_packet_log", rawPacketLogEnabled.Checked,
 			"autostart_enabled", autostartEnabled.Checked,
 			"autostart_mode", autostartModeFromOption(autostartModeSelect.Selected),
 			"compact_cyrillic_encoding", compactCyrillicEncoding.Checked,
 			"notify_when_focused", notifyWhenFocused.Checked,
+			"notify_message_grouping", notificationGroupingFromOption(notifyMessageGroupingSelect.Selected),
+			"notify_click_action", notificationClickActionFromOption(notifyClickActionSelect.Selected),
 			"notify_incoming_message", notifyIncomingMessage.Checked,
 			"notify_node_discovered", notifyNodeDiscovered.Checked,
 			"notify_connection_status", notifyConnectionStatus.Checked,
 			"notify_update_available", notifyUpdateAvailable.Checked,
+			"notify_low_battery", notifyLowBattery.Checked,
+			"taskbar_flash_enabled", taskbarFlashEnabled.Checked,
 			"map_show_precision_circles", mapShowPrecisionCircles.Checked,
 			"map_show_precision_circles_only_on_hover", mapShowPrecisionCirclesOnlyOnHover.Checked,
 		)
@@ -474,26 +606,58 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 			baud, err = parseSerialBaud(serialBaudSelect.Selected)
 			if err != nil {
 				settingsLogger.Warn("settings save failed: invalid serial baud", "value", strings.TrimSpace(serialBaudSelect.Selected), "error", err)
-				status.SetText("Save failed: " + err.Error())
+				status.SetText(i18n.Tf("Save failed: %s", err))
 
 				return
 			}
 		}
 		positionHistoryLimit, err := parseHistoryLimitLabel(historyPositionLimitSelect.Selected)
 		if err != nil {
-			status.SetText("Save failed: " + err.Error())
+			status.SetText(i18n.Tf("Save failed: %s", err))
 
 			return
 		}
 		telemetryHistoryLimit, err := parseHistoryLimitLabel(historyTelemetryLimitSelect.Selected)
 		if err != nil {
-			status.SetText("Save failed: " + err.Error())
+			status.SetText(i18n.Tf("Save failed: %s", err))
 
 			return
 		}
 		identityHistoryLimit, err := parseHistoryLimitLabel(historyIdentityLimitSelect.Selected)
 		if err != nil {
-			status.SetText("Save failed: " + err.Error())
+			status.SetText(i18n.Tf("Save failed: %s", err))
+
+			return
+		}
+		signalHistoryLimit, err := parseHistoryLimitLabel(historySignalLimitSelect.Selected)
+		if err != nil {
+			status.SetText(i18n.Tf("Save failed: %s", err))
+
+			return
+		}
+		rawPacketLogSizeMB, err := parseRawPacketLogSizeLabel(rawPacketLogSizeSelect.Selected)
+		if err != nil {
+			status.SetText(i18n.Tf("Save failed: %s", err))
+
+			return
+		}
+		mapTileCacheSizeMB, err := parseMapTileCacheSizeLabel(mapTileCacheSizeSelect.Selected)
+		if err != nil {
+			status.SetText(i18n.Tf("Save failed: %s", err))
+
+			return
+		}
+		doNotDisturb, err := doNotDisturbForm.read()
+		if err != nil {
+			settingsLogger.Warn("settings save failed: invalid do not disturb schedule", "error", err)
+			status.SetText(i18n.Tf("Save failed: %s", err))
+
+			return
+		}
+		mutedNodeEvents, err := config.ParseMutedNodeEvents(mutedNodeEventsEntry.Text)
+		if err != nil {
+			settingsLogger.Warn("settings save failed: invalid muted node events", "error", err)
+			status.SetText(i18n.Tf("Save failed: %s", err))
 
 			return
 		}
@@ -508,6 +672,10 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 		cfg.Connection.BluetoothTestingEnabled = bluetoothTestingEnabledCheck.Checked
 		cfg.Logging.Level = levelSelect.Selected
 		cfg.Logging.LogToFile = logToFile.Checked
+		cfg.Logging.MutedNodeEvents = mutedNodeEvents
+		cfg.Logging.SupportUploadURL = strings.TrimSpace(supportUploadURLEntry.Text)
+		cfg.Logging.RawPacketLog.Enabled = rawPacketLogEnabled.Checked
+		cfg.Logging.RawPacketLog.MaxSizeMB = rawPacketLogSizeMB
 		cfg.UI.Autostart.Enabled = autostartEnabled.Checked
 		cfg.UI.Autostart.Mode = autostartModeFromOption(autostartModeSelect.Selected)
 		cfg.UI.Messaging.CompactCyrillicEncoding = compactCyrillicEncoding.Checked
@@ -516,25 +684,38 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 		cfg.UI.Notifications.Events.NodeDiscovered = notifyNodeDiscovered.Checked
 		cfg.UI.Notifications.Events.ConnectionStatus = notifyConnectionStatus.Checked
 		cfg.UI.Notifications.Events.UpdateAvailable = notifyUpdateAvailable.Checked
+		cfg.UI.Notifications.Events.LowBattery = notifyLowBattery.Checked
+		cfg.UI.Notifications.LowBatteryPercent = parseLowBatteryPercentLabel(notifyLowBatterySelect.Selected)
+		cfg.UI.Notifications.Events.NodePresence = notifyNodePresence.Checked
+		cfg.UI.Notifications.DoNotDisturb = doNotDisturb
+		cfg.UI.Notifications.MessageGrouping = notificationGroupingFromOption(notifyMessageGroupingSelect.Selected)
+		cfg.UI.Notifications.ClickAction = notificationClickActionFromOption(notifyClickActionSelect.Selected)
+		cfg.UI.TaskbarFlash.Enabled = taskbarFlashEnabled.Checked
+		cfg.UI.Sounds = soundsForm.read()
 		cfg.UI.MapDisplay.ShowPrecisionCircles = mapShowPrecisionCircles.Checked
 		cfg.UI.MapDisplay.ShowPrecisionCirclesOnlyOnHover = mapShowPrecisionCirclesOnlyOnHover.Checked
 		cfg.UI.MapDisplay.MapLinkProvider = parseMapLinkProviderLabel(mapLinkProviderSelect.Selected)
+		cfg.UI.MapDisplay.TileCacheSizeMB = mapTileCacheSizeMB
+		cfg.UI.Formats = formatsForm.read()
+		cfg.UI = displayForm.read(cfg.UI)
 		cfg.Persistence.HistoryLimits.Position = intPtr(positionHistoryLimit)
 		cfg.Persistence.HistoryLimits.Telemetry = intPtr(telemetryHistoryLimit)
 		cfg.Persistence.HistoryLimits.Identity = intPtr(identityHistoryLimit)
+		cfg.Persistence.HistoryLimits.Signal = intPtr(signalHistoryLimit)
+		cfg.Persistence.EncryptDatabase = encryptDatabase.Checked
 
 		saveConfig := func(clearDatabase bool) {
 			settingsLogger.Info("applying settings", "clear_database", clearDatabase, "transport", cfg.Connection.Transport)
 			if clearDatabase {
 				if dep.Actions.OnClearDB == nil {
 					settingsLogger.Warn("settings save failed: database clear action unavailable")
-					status.SetText("Save failed: database clear is not available")
+					status.SetText(i18n.T("Save failed: database clear is not available"))
 
 					return
 				}
 				if err := dep.Actions.OnClearDB(); err != nil {
 					settingsLogger.Warn("settings save failed: database clear failed", "error", err)
-					status.SetText("Save failed: database clear failed: " + err.Error())
+					status.SetText(i18n.Tf("Save failed: database clear failed: %s", err))
 
 					return
 				}
@@ -544,7 +725,7 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 				var devWarning *app.AutostartDevBuildSkipWarning
 				if errors.As(err, &devWarning) {
 					settingsLogger.Info("settings saved with dev-build autostart skip", "autostart_enabled", devWarning.Enabled)
-					applySavedConfigState(cfg, "Saved")
+					applySavedConfigState(cfg, i18n.T("Saved"))
 					if dep.Actions.OnMapDisplayConfigChanged != nil {
 						dep.Actions.OnMapDisplayConfigChanged(cfg.UI.MapDisplay)
 					}
@@ -555,8 +736,8 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 							settingsLogger.Warn("autostart dev-build info dialog skipped: active window unavailable")
 						} else {
 							showInfoDialogFn(
-								"Autostart in dev build",
-								"Autostart entry was not rewritten because dev builds do not support autorun sync. Other settings were saved.",
+								i18n.T("Autostart in dev build"),
+								i18n.T("Autostart entry was not rewritten because dev builds do not support autorun sync. Other settings were saved."),
 								window,
 							)
 						}
@@ -568,7 +749,7 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 				var warning *app.AutostartSyncWarning
 				if errors.As(err, &warning) {
 					settingsLogger.Info("settings saved with warning", "warning", warning.Error())
-					applySavedConfigState(cfg, "Saved with warning: "+warning.Error())
+					applySavedConfigState(cfg, i18n.Tf("Saved with warning: %s", warning))
 					if dep.Actions.OnMapDisplayConfigChanged != nil {
 						dep.Actions.OnMapDisplayConfigChanged(cfg.UI.MapDisplay)
 					}
@@ -576,12 +757,13 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 					return
 				}
 				settingsLogger.Warn("settings save failed", "error", err)
-				status.SetText("Save failed: " + err.Error())
+				status.SetText(i18n.Tf("Save failed: %s", err))
+				reportError.report(i18n.T("Settings not saved"), err, settingsLogComponent)
 
 				return
 			}
 			settingsLogger.Info("settings saved successfully", "transport", cfg.Connection.Transport)
-			applySavedConfigState(cfg, "Saved")
+			applySavedConfigState(cfg, i18n.T("Saved"))
 			if dep.Actions.OnMapDisplayConfigChanged != nil {
 				dep.Actions.OnMapDisplayConfigChanged(cfg.UI.MapDisplay)
 			}
@@ -596,17 +778,17 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 			window := currentWindow()
 			if window == nil {
 				settingsLogger.Warn("settings save failed: active window unavailable for transport confirmation")
-				status.SetText("Save failed: active window is unavailable")
+				status.SetText(i18n.T("Save failed: active window is unavailable"))
 
 				return
 			}
 			dialog.ShowConfirm(
-				"Switch transport?",
-				"Changing transport will clear the local database before reconnecting. Continue?",
+				i18n.T("Switch transport?"),
+				i18n.T("Changing transport will clear the local database before reconnecting. Continue?"),
 				func(ok bool) {
 					if !ok {
 						settingsLogger.Info("transport switch canceled by user")
-						status.SetText("Save canceled")
+						status.SetText(i18n.T("Save canceled"))
 
 						return
 					}
@@ -623,68 +805,232 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 	})
 	saveButton.Importance = widget.HighImportance
 
-	revertButton := widget.NewButton("Revert", func() {
+	revertButton := widget.NewButton(i18n.T("Revert"), func() {
 		settingsLogger.Info("settings revert requested")
 		applyConfigToForm(current)
-		status.SetText("Unsaved changes reverted")
+		status.SetText(i18n.T("Unsaved changes reverted"))
 	})
 
-	clearDBButton := widget.NewButton("Clear database", func() {
+	clearDBButton := widget.NewButton(i18n.T("Clear database"), func() {
 		settingsLogger.Info("clear database requested from settings UI")
 		if dep.Actions.OnClearDB == nil {
 			settingsLogger.Warn("clear database unavailable: action is not configured")
-			status.SetText("Database clear is not available")
+			status.SetText(i18n.T("Database clear is not available"))
 
 			return
 		}
 		if err := dep.Actions.OnClearDB(); err != nil {
 			settingsLogger.Warn("database clear failed", "error", err)
-			status.SetText("Database clear failed: " + err.Error())
+			status.SetText(i18n.Tf("Database clear failed: %s", err))
 
 			return
 		}
 		settingsLogger.Info("database cleared from settings UI")
-		status.SetText("Database cleared")
+		status.SetText(i18n.T("Database cleared"))
 	})
 	if dep.Actions.OnClearDB == nil {
 		clearDBButton.Disable()
 	}
 
-	clearCacheButton := widget.NewButton("Clear cache", func() {
+	clearCacheButton := widget.NewButton(i18n.T("Clear cache"), func() {
 		settingsLogger.Info("clear cache requested from settings UI")
 		if dep.Actions.OnClearCache == nil {
 			settingsLogger.Warn("clear cache unavailable: action is not configured")
-			status.SetText("Cache clear is not available")
+			status.SetText(i18n.T("Cache clear is not available"))
 
 			return
 		}
 		if err := dep.Actions.OnClearCache(); err != nil {
 			settingsLogger.Warn("cache clear failed", "error", err)
-			status.SetText("Cache clear failed: " + err.Error())
+			status.SetText(i18n.Tf("Cache clear failed: %s", err))
 
 			return
 		}
 		settingsLogger.Info("cache cleared from settings UI")
-		status.SetText("Cache cleared")
+		status.SetText(i18n.T("Cache cleared"))
 	})
 	if dep.Actions.OnClearCache == nil {
 		clearCacheButton.Disable()
 	}
 
+	maintenanceStatusLabel := widget.NewLabel("")
+	maintenanceStatusLabel.Wrapping = fyne.TextWrapWord
+	refreshMaintenanceStatus := func() {
+		if dep.Data.DatabaseMaintenanceStatus == nil {
+			maintenanceStatusLabel.SetText(i18n.T("Database maintenance is not available"))
+
+			return
+		}
+		maintenanceStatusLabel.SetText(databaseMaintenanceStatusText(dep.Data.DatabaseMaintenanceStatus()))
+	}
+	refreshMaintenanceStatus()
+	if dep.Data.DatabaseMaintenanceStatus != nil {
+		go func() {
+			ticker := time.NewTicker(time.Minute)
+			defer ticker.Stop()
+			for range ticker.C {
+				runOnUI(refreshMaintenanceStatus)
+			}
+		}()
+	}
+	runMaintenanceButton := widget.NewButton(i18n.T("Run maintenance now"), nil)
+	runMaintenanceButton.OnTapped = func() {
+		settingsLogger.Info("database maintenance requested from settings UI")
+		runMaintenanceButton.Disable()
+		status.SetText(i18n.T("Running database maintenance..."))
+		runAsync(func() {
+			_, err := dep.Actions.RunDatabaseMaintenance(context.Background())
+			runOnUI(func() {
+				runMaintenanceButton.Enable()
+				refreshMaintenanceStatus()
+				if err != nil {
+					status.SetText(i18n.Tf("Database maintenance failed: %s", err))
+
+					return
+				}
+				status.SetText(i18n.T("Database maintenance finished"))
+			})
+		})
+	}
+	if dep.Actions.RunDatabaseMaintenance == nil {
+		runMaintenanceButton.Disable()
+	}
+
+	recentlyDeletedButton := widget.NewButton(i18n.T("Recently deleted…"), func() {
+		window := currentWindowFn()
+		if window == nil {
+			settingsLogger.Warn("recently deleted dialog skipped: active window unavailable")
+			status.SetText(i18n.T("Recently deleted items are not available: active window is unavailable"))
+
+			return
+		}
+		showRecentlyDeletedDialog(window, dep)
+	})
+	if dep.Actions.ListRecentlyDeleted == nil {
+		recentlyDeletedButton.Disable()
+	}
+
+	importHistoryButton := widget.NewButton(i18n.T("Import history…"), func() {
+		window := currentWindowFn()
+		if window == nil {
+			settingsLogger.Warn("history import dialog skipped: active window unavailable")
+			status.SetText(i18n.T("History import is not available: active window is unavailable"))
+
+			return
+		}
+		showHistoryImportDialog(window, dep.Actions.ImportHistory)
+	})
+	if dep.Actions.ImportHistory == nil {
+		importHistoryButton.Disable()
+	}
+
+	backupAppDataButton := widget.NewButton(i18n.T("Backup app data…"), func() {
+		window := currentWindowFn()
+		if window == nil {
+			settingsLogger.Warn("app data backup dialog skipped: active window unavailable")
+			status.SetText(i18n.T("App data backup is not available: active window is unavailable"))
+
+			return
+		}
+		showAppDataBackupDialog(window, dep.Actions.BackupAppData)
+	})
+	if dep.Actions.BackupAppData == nil {
+		backupAppDataButton.Disable()
+	}
+
+	restoreAppDataButton := widget.NewButton(i18n.T("Restore app data…"), func() {
+		window := currentWindowFn()
+		if window == nil {
+			settingsLogger.Warn("app data restore dialog skipped: active window unavailable")
+			status.SetText(i18n.T("App data restore is not available: active window is unavailable"))
+
+			return
+		}
+		showAppDataRestoreDialog(window, dep.Actions.RestoreAppData)
+	})
+	if dep.Actions.RestoreAppData == nil {
+		restoreAppDataButton.Disable()
+	}
+
+	uploadDiagnosticsButton := widget.NewButton(i18n.T("Upload diagnostics…"), func() {
+		endpoint := strings.TrimSpace(current.Logging.SupportUploadURL)
+		if endpoint == "" {
+			status.SetText(i18n.T("Set and save a support upload URL first"))
+
+			return
+		}
+		window := currentWindowFn()
+		if window == nil {
+			settingsLogger.Warn("diagnostics upload skipped: active window unavailable")
+			status.SetText(i18n.T("Diagnostics upload is not available: active window is unavailable"))
+
+			return
+		}
+		dialog.ShowConfirm(
+			i18n.T("Upload diagnostics?"),
+			i18n.Tf("A bundle with the app version, settings without connection addresses and the log file will be sent to:\n%s", endpoint),
+			func(ok bool) {
+				if !ok {
+					return
+				}
+				settingsLogger.Info("diagnostics upload confirmed by user", "endpoint", endpoint)
+				status.SetText(i18n.T("Uploading diagnostics..."))
+				runAsync(func() {
+					upload, err := dep.Actions.UploadDiagnostics(context.Background())
+					runOnUI(func() {
+						if err != nil {
+							settingsLogger.Warn("diagnostics upload failed", "error", err)
+							status.SetText(i18n.Tf("Diagnostics upload failed: %s", err))
+
+							return
+						}
+						status.SetText(i18n.Tf("Uploaded %s (%d KB)", upload.Name, (upload.Size+1023)/1024))
+					})
+				})
+			},
+			window,
+		)
+	})
+	if dep.Actions.UploadDiagnostics == nil {
+		uploadDiagnosticsButton.Disable()
+	}
+
+	exportRawPacketLogButton := widget.NewButton(i18n.T("Export raw packet log…"), func() {
+		window := currentWindowFn()
+		if window == nil {
+			settingsLogger.Warn("raw packet log export skipped: active window unavailable")
+			status.SetText(i18n.T("Raw packet log export is not available: active window is unavailable"))
+
+			return
+		}
+		showRawPacketLogExportDialog(window, dep.Actions.ExportRawPacketLog)
+	})
+	if dep.Actions.ExportRawPacketLog == nil {
+		exportRawPacketLogButton.Disable()
+	}
+
 	loggingForm := widget.NewForm(
-		widget.NewFormItem("Log Level", levelSelect),
-		widget.NewFormItem("Log to file", logToFile),
+		widget.NewFormItem(i18n.T("Log Level"), levelSelect),
+		widget.NewFormItem(i18n.T("Log to file"), logToFile),
+		widget.NewFormItem(i18n.T("Muted node events"), mutedNodeEventsEntry),
+		widget.NewFormItem(i18n.T("Support upload URL"), supportUploadURLEntry),
+		widget.NewFormItem(i18n.T("Raw packet log"), rawPacketLogEnabled),
+		widget.NewFormItem(i18n.T("Raw packet log size"), rawPacketLogSizeSelect),
+	)
+	mutedNodeEventsHelp := widget.NewLabel(
+		i18n.T("One node per line: node ID, colon, then any of core, position, telemetry. Muted events are left out of the event log."),
 	)
+	mutedNodeEventsHelp.Wrapping = fyne.TextWrapWord
 	startupForm := widget.NewForm(
-		widget.NewFormItem("Run on system startup", autostartEnabled),
-		widget.NewFormItem("Startup mode", autostartModeSelect),
+		widget.NewFormItem(i18n.T("Run on system startup"), autostartEnabled),
+		widget.NewFormItem(i18n.T("Startup mode"), autostartModeSelect),
 	)
 	compactCyrillicEncodingHelp := widget.NewLabel(
-		"Replaces safe Cyrillic homoglyphs with ASCII before sending to reduce UTF-8 message size. Disabled by default.",
+		i18n.T("Replaces safe Cyrillic homoglyphs with ASCII before sending to reduce UTF-8 message size. Disabled by default."),
 	)
 	compactCyrillicEncodingHelp.Wrapping = fyne.TextWrapWord
 	compactCyrillicEncodingWarning := widget.NewLabel(
-		"Warning: this intentionally creates mixed-script text, which can make copy/paste, search, exact comparison, moderation, and debugging confusing.",
+		i18n.T("Warning: this intentionally creates mixed-script text, which can make copy/paste, search, exact comparison, moderation, and debugging confusing."),
 	)
 	compactCyrillicEncodingWarning.Wrapping = fyne.TextWrapWord
 	messagingContent := container.NewVBox(
@@ -692,80 +1038,140 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 		compactCyrillicEncodingHelp,
 		compactCyrillicEncodingWarning,
 	)
+	taskbarFlashHelp := widget.NewLabel(
+		i18n.T("Works separately from notifications. Direct messages flash by default; change it for any chat from its menu in the chat list."),
+	)
+	taskbarFlashHelp.Wrapping = fyne.TextWrapWord
+	notifyNodePresenceHelp := widget.NewLabel(
+		i18n.T("Set when a favorite node alerts from its menu in the node list."),
+	)
+	notifyNodePresenceHelp.Wrapping = fyne.TextWrapWord
 	notificationsContent := container.NewVBox(
+		notificationsMuted,
+		widget.NewSeparator(),
 		notifyWhenFocused,
 		notifyIncomingMessage,
 		notifyNodeDiscovered,
 		notifyConnectionStatus,
 		notifyUpdateAvailable,
+		notifyLowBattery,
+		notifyNodePresence,
+		notifyNodePresenceHelp,
+		widget.NewForm(
+			widget.NewFormItem(i18n.T("Group message notifications"), notifyMessageGroupingSelect),
+			widget.NewFormItem(i18n.T("When a notification is clicked"), notifyClickActionSelect),
+			widget.NewFormItem(i18n.T("Low battery alert below"), notifyLowBatterySelect),
+		),
+		widget.NewSeparator(),
+		doNotDisturbForm.content,
+		widget.NewSeparator(),
+		taskbarFlashEnabled,
+		taskbarFlashHelp,
+	)
+	mapForm := widget.NewForm(
+		widget.NewFormItem(i18n.T("Open map links in"), mapLinkProviderSelect),
+		widget.NewFormItem(i18n.T("Tile cache size"), mapTileCacheSizeSelect),
 	)
-	mapForm := widget.NewForm(widget.NewFormItem("Open map links in", mapLinkProviderSelect))
+	mapTileCacheHelp := widget.NewLabel(i18n.T("Map tiles are kept on disk, so seen areas stay available offline. Use Offline on the map to download an area in advance."))
+	mapTileCacheHelp.Wrapping = fyne.TextWrapWord
 	mapContent := container.NewVBox(
 		mapShowPrecisionCircles,
 		container.NewPadded(mapShowPrecisionCirclesOnlyOnHover),
 		mapForm,
+		mapTileCacheHelp,
 	)
 	historyForm := widget.NewForm(
-		widget.NewFormItem("Position history rows", historyPositionLimitSelect),
-		widget.NewFormItem("Telemetry history rows", historyTelemetryLimitSelect),
-		widget.NewFormItem("Identity history rows", historyIdentityLimitSelect),
+		widget.NewFormItem(i18n.T("Position history rows"), historyPositionLimitSelect),
+		widget.NewFormItem(i18n.T("Telemetry history rows"), historyTelemetryLimitSelect),
+		widget.NewFormItem(i18n.T("Identity history rows"), historyIdentityLimitSelect),
+		widget.NewFormItem(i18n.T("Signal history rows"), historySignalLimitSelect),
 	)
-	historyHelp := widget.NewLabel("Limits are per node and per table. Unlimited means history is not capped.")
+	historyHelp := widget.NewLabel(i18n.T("Limits are per node and per table. Unlimited means history is not capped."))
 	historyHelp.Wrapping = fyne.TextWrapWord
-	historyContent := container.NewVBox(historyForm, historyHelp)
+	encryptDatabaseHelp := widget.NewLabel(i18n.T("The encryption key is kept in the OS keyring. The database is converted on the next start. While it is encrypted, no other meshgo process can open it at the same time."))
+	encryptDatabaseHelp.Wrapping = fyne.TextWrapWord
+	historyContent := container.NewVBox(historyForm, historyHelp, encryptDatabase, encryptDatabaseHelp)
 
-	connectionBlock := widget.NewCard("Connection", "", container.NewVBox(
+	connectionBlock := widget.NewCard(i18n.T("Connection"), "", container.NewVBox(
 		connStatusLabel,
 		connectionFields,
 	))
-	startupBlock := widget.NewCard("Startup", "", startupForm)
-	messagingBlock := widget.NewCard("Messaging", "", messagingContent)
-	notificationsBlock := widget.NewCard("Notifications", "", notificationsContent)
-	mapBlock := widget.NewCard("Map", "", mapContent)
-	historyBlock := widget.NewCard("History", "", historyContent)
-	loggingBlock := widget.NewCard("Logging", "", loggingForm)
-	maintenanceBlock := widget.NewCard("Maintenance", "", container.NewGridWithColumns(2,
-		clearDBButton,
-		clearCacheButton,
+	startupBlock := widget.NewCard(i18n.T("Startup"), "", startupForm)
+	windowBlock := widget.NewCard(i18n.T("Window"), "", widget.NewForm(
+		widget.NewFormItem(i18n.T("Close button"), newCloseButtonSelect(closeButton)),
+	))
+	messagingBlock := widget.NewCard(i18n.T("Messaging"), "", messagingContent)
+	notificationsBlock := widget.NewCard(i18n.T("Notifications"), "", notificationsContent)
+	soundsBlock := widget.NewCard(i18n.T("Sounds"), "", soundsForm.content)
+	mapBlock := widget.NewCard(i18n.T("Map"), "", mapContent)
+	formatsBlock := widget.NewCard(i18n.T("Formats"), "", formatsForm.content)
+	displayBlock := widget.NewCard(i18n.T("Display"), "", displayForm.content)
+	historyBlock := widget.NewCard(i18n.T("History"), "", historyContent)
+	supportUploadHelp := widget.NewLabel(i18n.T("Diagnostics bundles are only sent when you press Upload diagnostics and confirm."))
+	supportUploadHelp.Wrapping = fyne.TextWrapWord
+	rawPacketLogHelp := widget.NewLabel(i18n.T("Keeps every frame exchanged with the radio for protocol debugging. The oldest frames are dropped once the log reaches its size."))
+	rawPacketLogHelp.Wrapping = fyne.TextWrapWord
+	loggingBlock := widget.NewCard(i18n.T("Logging"), "", container.NewVBox(
+		loggingForm,
+		mutedNodeEventsHelp,
+		supportUploadHelp,
+		rawPacketLogHelp,
+		container.NewHBox(uploadDiagnosticsButton, exportRawPacketLogButton),
+	))
+	maintenanceBlock := widget.NewCard(i18n.T("Maintenance"), "", container.NewVBox(
+		container.NewGridWithColumns(2,
+			clearDBButton,
+			clearCacheButton,
+			recentlyDeletedButton,
+			importHistoryButton,
+			backupAppDataButton,
+			restoreAppDataButton,
+		),
+		maintenanceStatusLabel,
+		container.NewHBox(runMaintenanceButton),
 	))
 
 	logo := newLinkImage(resources.LogoTextResource(), fyne.NewSize(220, 80), func() {
 		settingsLogger.Debug("opening source URL from settings logo", "url", app.SourceURL)
 		if err := openExternalURL(app.SourceURL); err != nil {
 			settingsLogger.Warn("open source URL failed", "url", app.SourceURL, "error", err)
-			status.SetText("Failed to open source website: " + err.Error())
+			status.SetText(i18n.Tf("Failed to open source website: %s", err))
 		}
 	})
 
-	sourceLink := newSafeHyperlink("Source", app.SourceURL, status)
+	sourceLink := newSafeHyperlink(i18n.T("Source"), app.SourceURL, status)
 	meshtasticLink := newSafeHyperlink("Meshtastic", app.MeshtasticURL, status)
 	poweredByRow := container.NewHBox(
-		widget.NewLabel("Powered by "),
+		widget.NewLabel(i18n.T("Powered by ")),
 		meshtasticLink,
 	)
+	shortcutsButton := widget.NewButton(i18n.T("Keyboard shortcuts"), func() {
+		showShortcutCheatSheet(currentWindowFn())
+	})
 	versionBlock := widget.NewCard("", "", container.NewVBox(
 		container.NewHBox(logo, layout.NewSpacer()),
-		widget.NewLabel("Version: "+app.BuildVersionWithDate()),
+		widget.NewLabel(i18n.Tf("Version: %s", app.BuildVersionWithDate())),
 		sourceLink,
 		poweredByRow,
+		container.NewHBox(shortcutsButton),
 	))
 
-	generalTab := newSettingsSubTabPage(startupBlock, messagingBlock)
+	generalTab := newSettingsSubTabPage(startupBlock, windowBlock, messagingBlock, displayBlock, formatsBlock)
 	connectionTab := newSettingsSubTabPage(connectionBlock)
 	mapTab := newSettingsSubTabPage(mapBlock)
 	historyTab := newSettingsSubTabPage(historyBlock)
-	notificationsTab := newSettingsSubTabPage(notificationsBlock)
+	notificationsTab := newSettingsSubTabPage(notificationsBlock, soundsBlock)
 	maintenanceTab := newSettingsSubTabPage(loggingBlock, maintenanceBlock)
 	aboutTab := newSettingsSubTabPage(versionBlock)
 
 	subTabs := container.NewAppTabs(
-		container.NewTabItem("General", generalTab),
-		container.NewTabItem("Connection", connectionTab),
-		container.NewTabItem("Map", mapTab),
-		container.NewTabItem("History", historyTab),
-		container.NewTabItem("Notifications", notificationsTab),
-		container.NewTabItem("Maintenance", maintenanceTab),
-		container.NewTabItem("About", aboutTab),
+		container.NewTabItem(i18n.T("General"), generalTab),
+		container.NewTabItem(i18n.T("Connection"), connectionTab),
+		container.NewTabItem(i18n.T("Map"), mapTab),
+		container.NewTabItem(i18n.T("History"), historyTab),
+		container.NewTabItem(i18n.T("Notifications"), notificationsTab),
+		container.NewTabItem(i18n.T("Maintenance"), maintenanceTab),
+		container.NewTabItem(i18n.T("About"), aboutTab),
 	)
 	subTabs.SetTabLocation(container.TabLocationTop)
 
@@ -776,7 +1182,16 @@ func newSettingsTab(dep RuntimeDependencies, connStatusLabel *widget.Label) fyne
 		container.NewPadded(buttonRow),
 	)
 
-	return container.NewBorder(nil, bottomBar, nil, nil, subTabs)
+	syncConnection := func(conn config.ConnectionConfig) {
+		if conn == current.Connection {
+			return
+		}
+		settingsLogger.Debug("connection changed outside settings", "transport", conn.Transport)
+		current.Connection = conn
+		applyConnectionToForm(conn)
+	}
+
+	return container.NewBorder(nil, bottomBar, nil, nil, subTabs), syncConnection
 }
 
 func newSettingsSubTabPage(content ...fyne.CanvasObject) fyne.CanvasObject {
@@ -786,12 +1201,12 @@ func newSettingsSubTabPage(content ...fyne.CanvasObject) fyne.CanvasObject {
 }
 
 func historyLimitOptionLabels() []string {
-	return []string{"10", "50", "100", "250", "500", "1000", "Unlimited"}
+	return []string{"10", "50", "100", "250", "500", "1000", i18n.T("Unlimited")}
 }
 
 func parseHistoryLimitLabel(label string) (int, error) {
 	trimmed := strings.TrimSpace(label)
-	if strings.EqualFold(trimmed, "unlimited") {
+	if strings.EqualFold(trimmed, "unlimited") || trimmed == i18n.T("Unlimited") {
 		return 0, nil
 	}
 	value, err := strconv.Atoi(trimmed)
@@ -811,7 +1226,7 @@ func historyLimitLabel(limit *int, fallback int) string {
 		return strconv.Itoa(fallback)
 	}
 	if *limit == 0 {
-		return "Unlimited"
+		return i18n.T("Unlimited")
 	}
 
 	return strconv.Itoa(*limit)
@@ -869,9 +1284,9 @@ func showBluetoothScanDialog(window fyne.Window, devices []DiscoveredBluetoothDe
 
 	dialogContent := container.NewBorder(nil, nil, nil, nil, list)
 	scanDialog := dialog.NewCustomConfirm(
-		"Bluetooth devices",
-		"Select",
-		"Cancel",
+		i18n.T("Bluetooth devices"),
+		i18n.T("Select"),
+		i18n.T("Cancel"),
 		dialogContent,
 		func(ok bool) {
 			if !ok {
@@ -938,11 +1353,11 @@ func currentWindow() fyne.Window {
 
 func transportOptionsForBluetoothTesting(bluetoothTestingEnabled bool) []string {
 	options := []string{
-		transportOptionIP,
-		transportOptionSerial,
+		i18n.T(transportOptionIP),
+		i18n.T(transportOptionSerial),
 	}
 	if bluetoothTestingEnabled {
-		options = append(options, transportOptionBluetooth)
+		options = append(options, i18n.T(transportOptionBluetooth))
 	}
 
 	return options
@@ -964,23 +1379,23 @@ func normalizeTransportForOptions(transport config.TransportType, bluetoothTesti
 func transportOptionFromType(transport config.TransportType) string {
 	switch transport {
 	case config.TransportIP:
-		return transportOptionIP
+		return i18n.T(transportOptionIP)
 	case config.TransportSerial:
-		return transportOptionSerial
+		return i18n.T(transportOptionSerial)
 	case config.TransportBluetooth:
-		return transportOptionBluetooth
+		return i18n.T(transportOptionBluetooth)
 	default:
-		return transportOptionIP
+		return i18n.T(transportOptionIP)
 	}
 }
 
 func transportTypeFromOption(value string) config.TransportType {
 	switch strings.TrimSpace(value) {
-	case transportOptionIP:
+	case i18n.T(transportOptionIP):
 		return config.TransportIP
-	case transportOptionSerial:
+	case i18n.T(transportOptionSerial):
 		return config.TransportSerial
-	case transportOptionBluetooth:
+	case i18n.T(transportOptionBluetooth):
 		return config.TransportBluetooth
 	default:
 		return config.TransportIP
@@ -990,21 +1405,59 @@ func transportTypeFromOption(value string) config.TransportType {
 func autostartOptionFromMode(mode config.AutostartMode) string {
 	switch mode {
 	case config.AutostartModeBackground:
-		return autostartOptionTray
+		return i18n.T(autostartOptionTray)
 	default:
-		return autostartOptionNormal
+		return i18n.T(autostartOptionNormal)
 	}
 }
 
 func autostartModeFromOption(value string) config.AutostartMode {
 	switch strings.TrimSpace(value) {
-	case autostartOptionTray:
+	case i18n.T(autostartOptionTray):
 		return config.AutostartModeBackground
 	default:
 		return config.AutostartModeNormal
 	}
 }
 
+func notificationGroupingOptionFromMode(grouping config.NotificationGrouping) string {
+	switch grouping {
+	case config.NotificationGroupingSender:
+		return i18n.T(notificationGroupingOptionSender)
+	case config.NotificationGroupingGlobal:
+		return i18n.T(notificationGroupingOptionGlobal)
+	default:
+		return i18n.T(notificationGroupingOptionChat)
+	}
+}
+
+func notificationGroupingFromOption(value string) config.NotificationGrouping {
+	switch strings.TrimSpace(value) {
+	case i18n.T(notificationGroupingOptionSender):
+		return config.NotificationGroupingSender
+	case i18n.T(notificationGroupingOptionGlobal):
+		return config.NotificationGroupingGlobal
+	default:
+		return config.NotificationGroupingChat
+	}
+}
+
+func notificationClickOptionFromAction(action config.NotificationClickAction) string {
+	if action == config.NotificationClickShowWindow {
+		return i18n.T(notificationClickOptionShowWindow)
+	}
+
+	return i18n.T(notificationClickOptionOpenChat)
+}
+
+func notificationClickActionFromOption(value string) config.NotificationClickAction {
+	if strings.TrimSpace(value) == i18n.T(notificationClickOptionShowWindow) {
+		return config.NotificationClickShowWindow
+	}
+
+	return config.NotificationClickOpenChat
+}
+
 func parseSerialBaud(value string) (int, error) {
 	baud, err := strconv.Atoi(strings.TrimSpace(value))
 	if err != nil {
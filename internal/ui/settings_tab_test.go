@@ -495,11 +495,15 @@ func TestSettingsTabSavePersistsNotificationSettings(t *testing.T) {
 	nodeCheckbox := mustFindCheckByText(t, tab, "New node discovered")
 	connCheckbox := mustFindCheckByText(t, tab, "Connection status changes")
 	updateCheckbox := mustFindCheckByText(t, tab, "Update available")
+	taskbarFlashCheckbox := mustFindCheckByText(t, tab, "Flash the taskbar on new messages while the window is unfocused")
+	fynetest.Tap(taskbarFlashCheckbox)
 	fynetest.Tap(focusedCheckbox)
 	fynetest.Tap(incomingCheckbox)
 	fynetest.Tap(nodeCheckbox)
 	fynetest.Tap(connCheckbox)
 	fynetest.Tap(updateCheckbox)
+	mustFindSelectWithOption(t, tab, notificationGroupingOptionSender).SetSelected(notificationGroupingOptionSender)
+	mustFindSelectWithOption(t, tab, notificationClickOptionShowWindow).SetSelected(notificationClickOptionShowWindow)
 
 	saveButton := mustFindButtonByText(t, tab, "Save")
 	fynetest.Tap(saveButton)
@@ -519,6 +523,63 @@ func TestSettingsTabSavePersistsNotificationSettings(t *testing.T) {
 	if saved.UI.Notifications.Events.UpdateAvailable {
 		t.Fatalf("expected update notifications to be saved as disabled")
 	}
+	if saved.UI.Notifications.MessageGrouping != config.NotificationGroupingSender {
+		t.Fatalf("expected sender message grouping to be saved, got %q", saved.UI.Notifications.MessageGrouping)
+	}
+	if saved.UI.Notifications.ClickAction != config.NotificationClickShowWindow {
+		t.Fatalf("expected show window click action to be saved, got %q", saved.UI.Notifications.ClickAction)
+	}
+	if !saved.UI.TaskbarFlash.Enabled {
+		t.Fatalf("expected taskbar flashing to be saved as enabled")
+	}
+}
+
+func TestSettingsTabSavePersistsSoundSettings(t *testing.T) {
+	var (
+		saved config.AppConfig
+		muted []bool
+	)
+	dep := RuntimeDependencies{
+		Data: DataDependencies{Config: config.Default()},
+		Actions: ActionDependencies{
+			OnSave: func(next config.AppConfig) error {
+				saved = next
+
+				return nil
+			},
+			OnSetNotificationsMuted: func(value bool, _ time.Time) error {
+				muted = append(muted, value)
+
+				return nil
+			},
+		},
+	}
+
+	tab := newSettingsTab(dep, widget.NewLabel(""))
+	_ = fynetest.NewTempWindow(t, tab)
+	mustSelectAppTabByText(t, tab, "Notifications")
+
+	fynetest.Tap(mustFindCheckByText(t, tab, "Mute notifications and sounds"))
+	if len(muted) != 1 || !muted[0] {
+		t.Fatalf("expected the mute to apply right away, got %v", muted)
+	}
+	fynetest.Tap(mustFindCheckByText(t, tab, "Play sounds for chat messages"))
+	fynetest.Tap(mustFindCheckByText(t, tab, "Sent message"))
+	mustFindSelectWithOption(t, tab, "Bell").SetSelected("Bell")
+	fynetest.Tap(mustFindButtonByText(t, tab, "Save"))
+
+	if !saved.UI.Sounds.Enabled {
+		t.Fatalf("expected sounds to be saved as enabled")
+	}
+	if saved.UI.Sounds.Send.Enabled || saved.UI.Sounds.Send.Sound != config.SoundBell {
+		t.Fatalf("expected the send sound to be saved as a disabled bell, got %+v", saved.UI.Sounds.Send)
+	}
+	if saved.UI.Sounds.Receive.Sound != config.SoundChime || saved.UI.Sounds.Receive.Volume != config.DefaultReceiveSoundVolume {
+		t.Fatalf("expected the receive sound to keep its defaults, got %+v", saved.UI.Sounds.Receive)
+	}
+	if saved.UI.Sounds.Alert.Sound != config.SoundBell || !saved.UI.Sounds.Alert.Enabled {
+		t.Fatalf("expected the alert sound to keep its defaults, got %+v", saved.UI.Sounds.Alert)
+	}
 }
 
 func TestSettingsTabRevertRestoresLastSavedSettings(t *testing.T) {
@@ -822,6 +883,9 @@ func mustFindEntryByPlaceholder(t *testing.T, root fyne.CanvasObject, placeholde
 	var found *widget.Entry
 	walkCanvasObjects(root, func(object fyne.CanvasObject) bool {
 		entry, ok := object.(*widget.Entry)
+		if wrapped, isWrapped := object.(*shortcutEntry); isWrapped {
+			entry, ok = &wrapped.Entry, true
+		}
 		if !ok {
 			return false
 		}
@@ -988,6 +1052,10 @@ func walkCanvasObjects(root fyne.CanvasObject, visit func(fyne.CanvasObject) boo
 		if walkCanvasObjects(object.Content, visit) {
 			return true
 		}
+	case *chatsTabContent:
+		if walkCanvasObjects(object.content, visit) {
+			return true
+		}
 	case *widget.Form:
 		for _, item := range object.Items {
 			if item == nil {
@@ -1,7 +1,6 @@
 package ui
 
 import (
-	"fmt"
 	"image"
 	"strings"
 
@@ -12,6 +11,8 @@ import (
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/widget"
 	qrcode "github.com/skip2/go-qrcode"
+
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 const (
@@ -23,6 +24,10 @@ const (
 type qrShareModalPayload struct {
 	Title string
 	URL   string
+	// Hint is shown above the QR code when set.
+	Hint string
+	// Actions are extra buttons shown before "Copy URL".
+	Actions []fyne.CanvasObject
 }
 
 func showInfoModal(dep RuntimeDependencies, title, message string) {
@@ -87,31 +92,33 @@ func showQRCodeShareModal(window fyne.Window, payload qrShareModalPayload) {
 
 	qrBox := buildQRCodeContent(qrImage, qrErr)
 	copyStatus := widget.NewLabel("")
-	closeButton := widget.NewButton("Close", nil)
-
-	content := container.NewBorder(
-		nil,
-		container.NewHBox(
-			copyStatus,
-			layout.NewSpacer(),
-			widget.NewButton("Copy URL", func() {
-				if err := copyTextToClipboard(urlText); err != nil {
-					copyStatus.SetText("Copy failed: " + err.Error())
-
-					return
-				}
-				copyStatus.SetText("URL copied to clipboard.")
-			}),
-			closeButton,
-		),
-		nil,
-		nil,
-		container.NewVBox(
-			qrBox,
-			widget.NewLabel("Shareable URL"),
-			urlEntry,
-		),
-	)
+	closeButton := widget.NewButton(i18n.T("Close"), nil)
+
+	body := container.NewVBox()
+	if hint := strings.TrimSpace(payload.Hint); hint != "" {
+		hintLabel := widget.NewLabel(hint)
+		hintLabel.Wrapping = fyne.TextWrapWord
+		body.Add(hintLabel)
+	}
+	body.Add(qrBox)
+	body.Add(widget.NewLabel(i18n.T("Shareable URL")))
+	body.Add(urlEntry)
+
+	buttons := container.NewHBox(copyStatus, layout.NewSpacer())
+	for _, action := range payload.Actions {
+		buttons.Add(action)
+	}
+	buttons.Add(widget.NewButton(i18n.T("Copy URL"), func() {
+		if err := copyTextToClipboard(urlText); err != nil {
+			copyStatus.SetText(i18n.Tf("Copy failed: %s", err.Error()))
+
+			return
+		}
+		copyStatus.SetText(i18n.T("URL copied to clipboard."))
+	}))
+	buttons.Add(closeButton)
+
+	content := container.NewBorder(nil, buttons, nil, nil, body)
 
 	modal := dialog.NewCustomWithoutButtons(strings.TrimSpace(payload.Title), content, window)
 	closeButton.OnTapped = modal.Hide
@@ -128,15 +135,15 @@ func buildQRCodeContent(qrImage image.Image, qrErr error) fyne.CanvasObject {
 		return container.NewCenter(img)
 	}
 
-	message := "QR code is unavailable."
+	message := i18n.T("QR code is unavailable.")
 	if qrErr != nil {
-		message = fmt.Sprintf("QR code generation failed: %v", qrErr)
+		message = i18n.Tf("QR code generation failed: %s", qrErr)
 	}
 
 	label := widget.NewLabel(message)
 	label.Wrapping = fyne.TextWrapWord
 
-	return container.NewCenter(container.NewVBox(widget.NewLabel("QR code"), label))
+	return container.NewCenter(container.NewVBox(widget.NewLabel(i18n.T("QR code")), label))
 }
 
 func generateQRCodeImage(content string) (image.Image, error) {
@@ -0,0 +1,575 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// shortcutAction names a keyboard shortcut. Names are used as keys of the
+// ui.shortcuts config overrides.
+type shortcutAction string
+
+const (
+	shortcutQuickSwitcher shortcutAction = "quick_switcher"
+	shortcutSearch        shortcutAction = "search"
+	shortcutSend          shortcutAction = "send"
+	shortcutReply         shortcutAction = "reply"
+	shortcutNextItem      shortcutAction = "next_item"
+	shortcutPreviousItem  shortcutAction = "previous_item"
+	shortcutHideWindow    shortcutAction = "hide_window"
+	shortcutCheatSheet    shortcutAction = "show_shortcuts"
+	shortcutShowChats     shortcutAction = "show_chats"
+	shortcutShowNodes     shortcutAction = "show_nodes"
+	shortcutShowMap       shortcutAction = "show_map"
+	shortcutShowNode      shortcutAction = "show_node_settings"
+	shortcutShowApp       shortcutAction = "show_app_settings"
+	shortcutNextPage      shortcutAction = "next_page"
+	shortcutPreviousPage  shortcutAction = "previous_page"
+)
+
+// shortcutDefinition is a shortcut with its default key combination and the
+// description shown in the cheat sheet.
+type shortcutDefinition struct {
+	Action      shortcutAction
+	Default     string
+	Description string
+}
+
+var shortcutDefinitions = []shortcutDefinition{
+	{Action: shortcutQuickSwitcher, Default: "Ctrl+K", Description: "Switch to a chat"},
+	{Action: shortcutSearch, Default: "Ctrl+F", Description: "Search in the current tab"},
+	{Action: shortcutSend, Default: "Ctrl+Enter", Description: "Send the message"},
+	{Action: shortcutReply, Default: "Ctrl+R", Description: "Reply to the hovered or latest message"},
+	{Action: shortcutNextItem, Default: "Alt+Down", Description: "Next chat or node"},
+	{Action: shortcutPreviousItem, Default: "Alt+Up", Description: "Previous chat or node"},
+	{Action: shortcutHideWindow, Default: "Esc", Description: "Close the pop-up or hide the window to the tray"},
+	{Action: shortcutCheatSheet, Default: "Ctrl+/", Description: "Show keyboard shortcuts"},
+	{Action: shortcutShowChats, Default: "Ctrl+1", Description: "Open chats"},
+	{Action: shortcutShowNodes, Default: "Ctrl+2", Description: "Open nodes"},
+	{Action: shortcutShowMap, Default: "Ctrl+3", Description: "Open the map"},
+	{Action: shortcutShowNode, Default: "Ctrl+4", Description: "Open node settings"},
+	{Action: shortcutShowApp, Default: "Ctrl+5", Description: "Open app settings"},
+	{Action: shortcutNextPage, Default: "Ctrl+PageDown", Description: "Next settings page"},
+	{Action: shortcutPreviousPage, Default: "Ctrl+PageUp", Description: "Previous settings page"},
+}
+
+// sidebarTabShortcuts maps the tab shortcuts to the sidebar tabs they open.
+var sidebarTabShortcuts = map[shortcutAction]string{
+	shortcutShowChats: "Chats",
+	shortcutShowNodes: "Nodes",
+	shortcutShowMap:   "Map",
+	shortcutShowNode:  "Node",
+	shortcutShowApp:   "App",
+}
+
+// shortcutKey is a key with its modifiers. Modifier is zero for plain keys like Esc.
+type shortcutKey struct {
+	Key      fyne.KeyName
+	Modifier fyne.KeyModifier
+}
+
+var shortcutModifierNames = []struct {
+	Modifier fyne.KeyModifier
+	Name     string
+}{
+	{Modifier: fyne.KeyModifierControl, Name: "Ctrl"},
+	{Modifier: fyne.KeyModifierAlt, Name: "Alt"},
+	{Modifier: fyne.KeyModifierShift, Name: "Shift"},
+	{Modifier: fyne.KeyModifierSuper, Name: "Super"},
+}
+
+var shortcutKeyAliases = map[string]fyne.KeyName{
+	"enter":    fyne.KeyReturn,
+	"return":   fyne.KeyReturn,
+	"esc":      fyne.KeyEscape,
+	"escape":   fyne.KeyEscape,
+	"up":       fyne.KeyUp,
+	"down":     fyne.KeyDown,
+	"left":     fyne.KeyLeft,
+	"right":    fyne.KeyRight,
+	"pageup":   fyne.KeyPageUp,
+	"pagedown": fyne.KeyPageDown,
+	"home":     fyne.KeyHome,
+	"end":      fyne.KeyEnd,
+	"space":    fyne.KeySpace,
+	"tab":      fyne.KeyTab,
+	"delete":   fyne.KeyDelete,
+}
+
+// parseShortcutKey parses combinations like "Ctrl+K", "alt+up" or "F1".
+func parseShortcutKey(text string) (shortcutKey, error) {
+	parts := strings.Split(strings.TrimSpace(text), "+")
+	var out shortcutKey
+	for _, part := range parts[:len(parts)-1] {
+		switch strings.ToLower(strings.TrimSpace(part)) {
+		case "ctrl", "control":
+			out.Modifier |= fyne.KeyModifierControl
+		case "alt", "option":
+			out.Modifier |= fyne.KeyModifierAlt
+		case "shift":
+			out.Modifier |= fyne.KeyModifierShift
+		case "super", "cmd", "meta", "win":
+			out.Modifier |= fyne.KeyModifierSuper
+		default:
+			return shortcutKey{}, fmt.Errorf("unknown modifier %q in %q", part, text)
+		}
+	}
+	key := strings.TrimSpace(parts[len(parts)-1])
+	switch {
+	case key == "":
+		return shortcutKey{}, fmt.Errorf("missing key in %q", text)
+	case shortcutKeyAliases[strings.ToLower(key)] != "":
+		out.Key = shortcutKeyAliases[strings.ToLower(key)]
+	case len([]rune(key)) == 1, isFunctionKeyName(key):
+		out.Key = fyne.KeyName(strings.ToUpper(key))
+	default:
+		return shortcutKey{}, fmt.Errorf("unknown key %q in %q", key, text)
+	}
+	if out.Modifier == fyne.KeyModifierShift {
+		// Fyne types Shift+key as text, it never reaches shortcut handlers.
+		return shortcutKey{}, fmt.Errorf("shift alone is not a shortcut modifier in %q", text)
+	}
+
+	return out, nil
+}
+
+// isFunctionKeyName matches F1 to F12.
+func isFunctionKeyName(key string) bool {
+	if len(key) < 2 || (key[0] != 'F' && key[0] != 'f') {
+		return false
+	}
+	number, err := strconv.Atoi(key[1:])
+
+	return err == nil && number >= 1 && number <= 12
+}
+
+func (k shortcutKey) String() string {
+	parts := make([]string, 0, len(shortcutModifierNames)+1)
+	for _, modifier := range shortcutModifierNames {
+		if k.Modifier&modifier.Modifier != 0 {
+			parts = append(parts, modifier.Name)
+		}
+	}
+	switch k.Key {
+	case fyne.KeyReturn:
+		parts = append(parts, "Enter")
+	case fyne.KeyEscape:
+		parts = append(parts, "Esc")
+	case fyne.KeyPageUp:
+		parts = append(parts, "PageUp")
+	case fyne.KeyPageDown:
+		parts = append(parts, "PageDown")
+	default:
+		parts = append(parts, string(k.Key))
+	}
+
+	return strings.Join(parts, "+")
+}
+
+// shortcutKeymap binds actions to keys. Disabled actions are missing.
+type shortcutKeymap map[shortcutAction]shortcutKey
+
+// newShortcutKeymap applies config overrides on top of the defaults. An empty or "none"
+// override disables the action; a key taken by an override is released by its default
+// action. Invalid overrides keep the default and are returned as errors.
+func newShortcutKeymap(overrides map[string]string) (shortcutKeymap, []error) {
+	keymap := make(shortcutKeymap, len(shortcutDefinitions))
+	for _, definition := range shortcutDefinitions {
+		key, err := parseShortcutKey(definition.Default)
+		if err != nil {
+			panic(fmt.Sprintf("invalid default shortcut for %s: %v", definition.Action, err))
+		}
+		keymap[definition.Action] = key
+	}
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var errs []error
+	for _, name := range names {
+		action := shortcutAction(name)
+		if !isShortcutAction(action) {
+			errs = append(errs, fmt.Errorf("unknown shortcut action %q", name))
+
+			continue
+		}
+		value := strings.TrimSpace(overrides[name])
+		if value == "" || strings.EqualFold(value, "none") {
+			delete(keymap, action)
+
+			continue
+		}
+		key, err := parseShortcutKey(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("shortcut %s: %w", name, err))
+
+			continue
+		}
+		for other, otherKey := range keymap {
+			if otherKey == key && other != action {
+				delete(keymap, other)
+			}
+		}
+		keymap[action] = key
+	}
+
+	return keymap, errs
+}
+
+func isShortcutAction(action shortcutAction) bool {
+	for _, definition := range shortcutDefinitions {
+		if definition.Action == action {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m shortcutKeymap) actionFor(key shortcutKey) (shortcutAction, bool) {
+	for action, bound := range m {
+		if bound == key {
+			return action, true
+		}
+	}
+
+	return "", false
+}
+
+var shortcutState = struct {
+	mu         sync.Mutex
+	keymap     shortcutKeymap
+	registries map[fyne.Canvas]*shortcutRegistry
+}{
+	registries: map[fyne.Canvas]*shortcutRegistry{},
+}
+
+// setShortcutOverrides sets the keymap used by canvases registered afterwards.
+func setShortcutOverrides(overrides map[string]string) {
+	keymap, errs := newShortcutKeymap(overrides)
+	for _, err := range errs {
+		appLogger.Warn("ignoring shortcut override", "error", err)
+	}
+	shortcutState.mu.Lock()
+	shortcutState.keymap = keymap
+	shortcutState.mu.Unlock()
+}
+
+func currentShortcutKeymap() shortcutKeymap {
+	shortcutState.mu.Lock()
+	defer shortcutState.mu.Unlock()
+	if shortcutState.keymap == nil {
+		shortcutState.keymap, _ = newShortcutKeymap(nil)
+	}
+
+	return shortcutState.keymap
+}
+
+// shortcutRegistry dispatches the shortcuts of one canvas to handlers. Handlers of an
+// action form a stack: the newest one runs first and the next one only runs when it
+// declines by returning false, so pop-ups can take over Esc while they are shown.
+type shortcutRegistry struct {
+	keymap   shortcutKeymap
+	mu       sync.Mutex
+	handlers map[shortcutAction][]*shortcutHandler
+}
+
+type shortcutHandler struct {
+	run func() bool
+}
+
+// shortcutsForCanvas returns the registry of the canvas, installing it on first use.
+func shortcutsForCanvas(fyneCanvas fyne.Canvas) *shortcutRegistry {
+	if fyneCanvas == nil {
+		return nil
+	}
+	shortcutState.mu.Lock()
+	registry, ok := shortcutState.registries[fyneCanvas]
+	shortcutState.mu.Unlock()
+	if ok {
+		return registry
+	}
+
+	registry = &shortcutRegistry{keymap: currentShortcutKeymap(), handlers: map[shortcutAction][]*shortcutHandler{}}
+	registry.install(fyneCanvas)
+	shortcutState.mu.Lock()
+	shortcutState.registries[fyneCanvas] = registry
+	shortcutState.mu.Unlock()
+
+	return registry
+}
+
+func shortcutsForWindow(window fyne.Window) *shortcutRegistry {
+	if window == nil {
+		return nil
+	}
+
+	return shortcutsForCanvas(window.Canvas())
+}
+
+func (r *shortcutRegistry) install(fyneCanvas fyne.Canvas) {
+	plainKeys := false
+	for action, key := range r.keymap {
+		if key.Modifier == 0 {
+			plainKeys = true
+
+			continue
+		}
+		fyneCanvas.AddShortcut(&desktop.CustomShortcut{KeyName: key.Key, Modifier: key.Modifier}, func(fyne.Shortcut) {
+			r.trigger(action)
+		})
+	}
+	if plainKeys {
+		// Plain keys reach the canvas only while no widget has the focus; focused entries
+		// forward them through shortcutEntry.
+		fyneCanvas.SetOnTypedKey(func(event *fyne.KeyEvent) {
+			r.TypedKey(event)
+		})
+	}
+}
+
+// Handle adds a handler on top of the action stack. The returned func removes it.
+func (r *shortcutRegistry) Handle(action shortcutAction, run func() bool) func() {
+	if r == nil || run == nil {
+		return func() {}
+	}
+	handler := &shortcutHandler{run: run}
+	r.mu.Lock()
+	r.handlers[action] = append(r.handlers[action], handler)
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		handlers := r.handlers[action]
+		for i, item := range handlers {
+			if item == handler {
+				r.handlers[action] = append(handlers[:i:i], handlers[i+1:]...)
+
+				return
+			}
+		}
+	}
+}
+
+// trigger runs the handlers of the action from the newest and reports whether one
+// handled it.
+func (r *shortcutRegistry) trigger(action shortcutAction) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	handlers := append([]*shortcutHandler(nil), r.handlers[action]...)
+	r.mu.Unlock()
+	for i := len(handlers) - 1; i >= 0; i-- {
+		if handlers[i].run() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TypedShortcut runs the action bound to the shortcut and reports whether it was handled.
+func (r *shortcutRegistry) TypedShortcut(shortcut fyne.Shortcut) bool {
+	custom, ok := shortcut.(*desktop.CustomShortcut)
+	if r == nil || !ok {
+		return false
+	}
+	action, ok := r.keymap.actionFor(shortcutKey{Key: custom.KeyName, Modifier: custom.Modifier})
+	if !ok {
+		return false
+	}
+
+	return r.trigger(action)
+}
+
+// TypedKey runs the action bound to the plain key and reports whether it was handled.
+func (r *shortcutRegistry) TypedKey(event *fyne.KeyEvent) bool {
+	if r == nil || event == nil {
+		return false
+	}
+	action, ok := r.keymap.actionFor(shortcutKey{Key: event.Name})
+	if !ok {
+		return false
+	}
+
+	return r.trigger(action)
+}
+
+// shortcutEntry is an entry that passes app shortcuts on to the registry of its canvas
+// before handling keys itself, so shortcuts also work while typing.
+type shortcutEntry struct {
+	widget.Entry
+	// onTypedKey handles keys before the entry does. It returns true when the key is used.
+	onTypedKey func(event *fyne.KeyEvent) bool
+}
+
+func newShortcutEntry() *shortcutEntry {
+	entry := &shortcutEntry{}
+	entry.ExtendBaseWidget(entry)
+
+	return entry
+}
+
+func (e *shortcutEntry) TypedShortcut(shortcut fyne.Shortcut) {
+	if shortcutsForCanvas(canvasForObject(e)).TypedShortcut(shortcut) {
+		return
+	}
+	e.Entry.TypedShortcut(shortcut)
+}
+
+func (e *shortcutEntry) TypedKey(event *fyne.KeyEvent) {
+	if e.onTypedKey != nil && e.onTypedKey(event) {
+		return
+	}
+	if shortcutsForCanvas(canvasForObject(e)).TypedKey(event) {
+		return
+	}
+	e.Entry.TypedKey(event)
+}
+
+// listShortcutTarget is filled in by tabs that support the search and list navigation
+// shortcuts. Nil funcs are skipped.
+type listShortcutTarget struct {
+	focusSearch    func()
+	selectRelative func(delta int)
+}
+
+// relativeListIndex moves current by delta within a list of length items. Without a
+// current item it starts from the first or the last one.
+func relativeListIndex(current, delta, length int) int {
+	if length <= 0 {
+		return -1
+	}
+	if current < 0 || current >= length {
+		if delta < 0 {
+			return length - 1
+		}
+
+		return 0
+	}
+
+	return min(max(current+delta, 0), length-1)
+}
+
+func shortcutCheatSheetRows(keymap shortcutKeymap) [][2]string {
+	rows := make([][2]string, 0, len(shortcutDefinitions))
+	for _, definition := range shortcutDefinitions {
+		key, ok := keymap[definition.Action]
+		if !ok {
+			continue
+		}
+		rows = append(rows, [2]string{key.String(), i18n.T(definition.Description)})
+	}
+
+	return rows
+}
+
+func showShortcutCheatSheet(window fyne.Window) {
+	if window == nil {
+		return
+	}
+	form := widget.NewForm()
+	for _, row := range shortcutCheatSheetRows(currentShortcutKeymap()) {
+		form.Append(row[0], widget.NewLabel(row[1]))
+	}
+	help := widget.NewLabel(i18n.T("Shortcuts can be changed in the \"shortcuts\" section of the config file."))
+	help.Wrapping = fyne.TextWrapWord
+	dialog.ShowCustom(i18n.T("Keyboard shortcuts"), i18n.T("Close"), container.NewVBox(form, help), window)
+}
+
+// bindMainViewShortcuts registers the window-wide shortcuts. Search, list and page
+// navigation go to the shown tab.
+func bindMainViewShortcuts(
+	window fyne.Window,
+	sidebar sidebarLayout,
+	tabContent map[string]fyne.CanvasObject,
+	targets map[string]*listShortcutTarget,
+	showSwitcher func(),
+) {
+	shortcuts := shortcutsForWindow(window)
+	if shortcuts == nil {
+		return
+	}
+	activeTarget := func() *listShortcutTarget {
+		return targets[sidebar.ActiveTab()]
+	}
+	for action, tab := range sidebarTabShortcuts {
+		shortcuts.Handle(action, func() bool {
+			if tabContent[tab] == nil || window.Canvas().Overlays().Top() != nil {
+				return false
+			}
+			sidebar.SwitchTab(tab)
+			// The next Tab press starts from the first control of the opened tab.
+			window.Canvas().Unfocus()
+
+			return true
+		})
+	}
+	for action, delta := range map[shortcutAction]int{shortcutNextPage: 1, shortcutPreviousPage: -1} {
+		shortcuts.Handle(action, func() bool {
+			if window.Canvas().Overlays().Top() != nil {
+				return false
+			}
+
+			return selectRelativePage(tabContent[sidebar.ActiveTab()], delta)
+		})
+	}
+	shortcuts.Handle(shortcutSearch, func() bool {
+		target := activeTarget()
+		if target == nil || target.focusSearch == nil {
+			return false
+		}
+		target.focusSearch()
+
+		return true
+	})
+	for action, delta := range map[shortcutAction]int{shortcutNextItem: 1, shortcutPreviousItem: -1} {
+		shortcuts.Handle(action, func() bool {
+			target := activeTarget()
+			if target == nil || target.selectRelative == nil {
+				return false
+			}
+			target.selectRelative(delta)
+
+			return true
+		})
+	}
+	shortcuts.Handle(shortcutQuickSwitcher, func() bool {
+		if showSwitcher == nil || window.Canvas().Overlays().Top() != nil {
+			return false
+		}
+		showSwitcher()
+
+		return true
+	})
+	shortcuts.Handle(shortcutCheatSheet, func() bool {
+		showShortcutCheatSheet(window)
+
+		return true
+	})
+	shortcuts.Handle(shortcutHideWindow, func() bool {
+		// Dialogs and pop-ups without their own handler stay open.
+		if window.Canvas().Overlays().Top() != nil {
+			return false
+		}
+		appLogger.Debug("hide window shortcut invoked: hiding to tray")
+		window.Hide()
+
+		return true
+	})
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestParseShortcutKey(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    shortcutKey
+		label   string
+		wantErr bool
+	}{
+		{in: "Ctrl+K", want: shortcutKey{Key: fyne.KeyK, Modifier: fyne.KeyModifierControl}, label: "Ctrl+K"},
+		{in: " ctrl + enter ", want: shortcutKey{Key: fyne.KeyReturn, Modifier: fyne.KeyModifierControl}, label: "Ctrl+Enter"},
+		{in: "Alt+Up", want: shortcutKey{Key: fyne.KeyUp, Modifier: fyne.KeyModifierAlt}, label: "Alt+Up"},
+		{in: "Ctrl+Shift+/", want: shortcutKey{Key: fyne.KeySlash, Modifier: fyne.KeyModifierControl | fyne.KeyModifierShift}, label: "Ctrl+Shift+/"},
+		{in: "esc", want: shortcutKey{Key: fyne.KeyEscape}, label: "Esc"},
+		{in: "f5", want: shortcutKey{Key: fyne.KeyF5}, label: "F5"},
+		{in: "Hyper+K", wantErr: true},
+		{in: "Ctrl+", wantErr: true},
+		{in: "Ctrl+Foo", wantErr: true},
+		{in: "Shift+K", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseShortcutKey(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Fatalf("%q: expected error, got %v", tt.in, got)
+			}
+
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Fatalf("%q: expected %v, got %v", tt.in, tt.want, got)
+		}
+		if got.String() != tt.label {
+			t.Fatalf("%q: expected label %q, got %q", tt.in, tt.label, got.String())
+		}
+	}
+}
+
+func TestNewShortcutKeymapAppliesOverrides(t *testing.T) {
+	keymap, errs := newShortcutKeymap(map[string]string{
+		"search":         "Ctrl+K",
+		"send":           "none",
+		"show_shortcuts": "F1",
+		"reply":          "Ctrl+Nope",
+		"unknown":        "Ctrl+U",
+	})
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if got := keymap[shortcutSearch]; got != (shortcutKey{Key: fyne.KeyK, Modifier: fyne.KeyModifierControl}) {
+		t.Fatalf("expected search on Ctrl+K, got %v", got)
+	}
+	if _, ok := keymap[shortcutQuickSwitcher]; ok {
+		t.Fatalf("expected quick switcher to lose Ctrl+K to the override")
+	}
+	if _, ok := keymap[shortcutSend]; ok {
+		t.Fatalf("expected send to be disabled")
+	}
+	if got := keymap[shortcutCheatSheet]; got != (shortcutKey{Key: fyne.KeyF1}) {
+		t.Fatalf("expected cheat sheet on F1, got %v", got)
+	}
+	if got := keymap[shortcutReply]; got != (shortcutKey{Key: fyne.KeyR, Modifier: fyne.KeyModifierControl}) {
+		t.Fatalf("expected invalid reply override to keep Ctrl+R, got %v", got)
+	}
+}
+
+func TestShortcutRegistryRunsNewestHandlerFirst(t *testing.T) {
+	keymap, _ := newShortcutKeymap(nil)
+	registry := &shortcutRegistry{keymap: keymap, handlers: map[shortcutAction][]*shortcutHandler{}}
+	var calls []string
+	registry.Handle(shortcutHideWindow, func() bool {
+		calls = append(calls, "window")
+
+		return true
+	})
+	releasePopup := registry.Handle(shortcutHideWindow, func() bool {
+		calls = append(calls, "popup")
+
+		return true
+	})
+	registry.Handle(shortcutHideWindow, func() bool {
+		calls = append(calls, "declined")
+
+		return false
+	})
+
+	if !registry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape}) {
+		t.Fatalf("expected Esc to be handled")
+	}
+	releasePopup()
+	if !registry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape}) {
+		t.Fatalf("expected Esc to be handled")
+	}
+	if want := []string{"declined", "popup", "declined", "window"}; !slices.Equal(calls, want) {
+		t.Fatalf("expected calls %v, got %v", want, calls)
+	}
+
+	if registry.TypedShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyF, Modifier: fyne.KeyModifierControl}) {
+		t.Fatalf("expected search without handlers to be unhandled")
+	}
+	if registry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyA}) {
+		t.Fatalf("expected unbound key to be unhandled")
+	}
+	var nilRegistry *shortcutRegistry
+	if nilRegistry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape}) {
+		t.Fatalf("expected nil registry to handle nothing")
+	}
+}
+
+func TestRelativeListIndex(t *testing.T) {
+	tests := []struct {
+		current, delta, length, want int
+	}{
+		{current: -1, delta: 1, length: 3, want: 0},
+		{current: -1, delta: -1, length: 3, want: 2},
+		{current: 0, delta: 1, length: 3, want: 1},
+		{current: 2, delta: 1, length: 3, want: 2},
+		{current: 0, delta: -1, length: 3, want: 0},
+		{current: 5, delta: 1, length: 3, want: 0},
+		{current: 0, delta: 1, length: 0, want: -1},
+	}
+
+	for _, tt := range tests {
+		if got := relativeListIndex(tt.current, tt.delta, tt.length); got != tt.want {
+			t.Fatalf("relativeListIndex(%d, %d, %d): expected %d, got %d", tt.current, tt.delta, tt.length, tt.want, got)
+		}
+	}
+}
+
+func TestQuickSwitcherMatches(t *testing.T) {
+	chats := []domain.Chat{
+		{Key: "channel:0", Title: "LongFast"},
+		{Key: "dm:!1234abcd", Title: "Alice"},
+		{Key: "channel:1", Title: "Ops Fast"},
+	}
+	titleOf := func(chat domain.Chat) string { return chat.Title }
+	keys := func(items []domain.Chat) []string {
+		out := make([]string, 0, len(items))
+		for _, item := range items {
+			out = append(out, item.Key)
+		}
+
+		return out
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{query: "", want: []string{"channel:0", "dm:!1234abcd", "channel:1"}},
+		{query: "fast", want: []string{"channel:0", "channel:1"}},
+		{query: "ops", want: []string{"channel:1"}},
+		{query: "1234", want: []string{"dm:!1234abcd"}},
+		{query: "zzz", want: []string{}},
+	}
+	for _, tt := range tests {
+		if got := keys(quickSwitcherMatches(chats, tt.query, titleOf)); !slices.Equal(got, tt.want) {
+			t.Fatalf("%q: expected %v, got %v", tt.query, tt.want, got)
+		}
+	}
+
+	// A title starting with the query goes before other matches.
+	if got, want := keys(quickSwitcherMatches(chats, "a", titleOf)), []string{"dm:!1234abcd", "channel:0", "channel:1"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
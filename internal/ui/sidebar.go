@@ -17,6 +17,7 @@ type sidebarLayout struct {
 	rightStack *fyne.Container
 	applyTheme func(fyne.ThemeVariant)
 	switchTab  func(name string)
+	activeTab  func() string
 }
 
 func buildSidebarLayout(
@@ -106,6 +107,9 @@ func buildSidebarLayout(
 		rightStack: rightStack,
 		applyTheme: applyTheme,
 		switchTab:  switchTab,
+		activeTab: func() string {
+			return active
+		},
 	}
 }
 
@@ -114,3 +118,12 @@ func (s sidebarLayout) SwitchTab(name string) {
 		s.switchTab(name)
 	}
 }
+
+// ActiveTab returns the name of the shown tab.
+func (s sidebarLayout) ActiveTab() string {
+	if s.activeTab == nil {
+		return ""
+	}
+
+	return s.activeTab()
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+var signalSparklineMinSize = fyne.NewSize(240, 36)
+
+// signalSeriesPoint is one reading of a single signal metric.
+type signalSeriesPoint struct {
+	At    time.Time
+	Value float64
+}
+
+// signalHistorySeries splits readings into RSSI and SNR series, skipping missing values.
+func signalHistorySeries(entries []domain.NodeSignalHistoryEntry) (rssi, snr []signalSeriesPoint) {
+	for _, entry := range entries {
+		if entry.RSSI != nil {
+			rssi = append(rssi, signalSeriesPoint{At: entry.ObservedAt, Value: float64(*entry.RSSI)})
+		}
+		if entry.SNR != nil {
+			snr = append(snr, signalSeriesPoint{At: entry.ObservedAt, Value: *entry.SNR})
+		}
+	}
+
+	return rssi, snr
+}
+
+// sparklinePositions maps points onto size: time runs left to right and higher values
+// are drawn higher. A flat series is drawn through the middle.
+func sparklinePositions(points []signalSeriesPoint, size fyne.Size) []fyne.Position {
+	if len(points) == 0 {
+		return nil
+	}
+	first, last := points[0].At, points[len(points)-1].At
+	minValue, maxValue := points[0].Value, points[0].Value
+	for _, point := range points[1:] {
+		minValue = min(minValue, point.Value)
+		maxValue = max(maxValue, point.Value)
+	}
+	span := last.Sub(first)
+
+	out := make([]fyne.Position, 0, len(points))
+	for i, point := range points {
+		var x float32
+		switch {
+		case span > 0:
+			x = float32(point.At.Sub(first)) / float32(span) * size.Width
+		case len(points) > 1:
+			x = float32(i) / float32(len(points)-1) * size.Width
+		}
+		y := size.Height / 2
+		if maxValue > minValue {
+			y = float32((maxValue-point.Value)/(maxValue-minValue)) * size.Height
+		}
+		out = append(out, fyne.NewPos(x, y))
+	}
+
+	return out
+}
+
+func signalSeriesSummary(points []signalSeriesPoint, unit string) string {
+	if len(points) == 0 {
+		return ""
+	}
+	minValue, maxValue := points[0].Value, points[0].Value
+	for _, point := range points[1:] {
+		minValue = min(minValue, point.Value)
+		maxValue = max(maxValue, point.Value)
+	}
+	formatter := currentDisplayFormatter()
+
+	return i18n.Tf(
+		"now %s, range %s…%s %s",
+		formatter.Number("%.1f", points[len(points)-1].Value),
+		formatter.Number("%.1f", minValue),
+		formatter.Number("%.1f", maxValue),
+		unit,
+	)
+}
+
+// sparklineLayout places one line per pair of neighbouring points.
+type sparklineLayout struct {
+	points  []signalSeriesPoint
+	minSize fyne.Size
+}
+
+func (l *sparklineLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	positions := sparklinePositions(l.points, size)
+	for i, object := range objects {
+		line, ok := object.(*canvas.Line)
+		if !ok || i+1 >= len(positions) {
+			continue
+		}
+		line.Position1 = positions[i]
+		line.Position2 = positions[i+1]
+		line.Refresh()
+	}
+}
+
+func (l *sparklineLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	return l.minSize
+}
+
+func newSignalSparkline(points []signalSeriesPoint, stroke color.Color) fyne.CanvasObject {
+	return newSparkline(points, stroke, signalSparklineMinSize)
+}
+
+func newSparkline(points []signalSeriesPoint, stroke color.Color, minSize fyne.Size) fyne.CanvasObject {
+	lines := make([]fyne.CanvasObject, 0, max(len(points)-1, 0))
+	for range max(len(points)-1, 0) {
+		line := canvas.NewLine(stroke)
+		line.StrokeWidth = 2
+		lines = append(lines, line)
+	}
+
+	return container.New(&sparklineLayout{points: points, minSize: minSize}, lines...)
+}
+
+// setSparklinePoints redraws a sparkline built by newSparkline with other points.
+func setSparklinePoints(sparkline *fyne.Container, points []signalSeriesPoint, stroke color.Color) {
+	sparklineLayout, ok := sparkline.Layout.(*sparklineLayout)
+	if !ok {
+		return
+	}
+	sparklineLayout.points = points
+	sparkline.Objects = newSparkline(points, stroke, sparklineLayout.minSize).(*fyne.Container).Objects
+	sparkline.Refresh()
+}
+
+// newSignalHistoryRows renders RSSI and SNR sparklines. It returns nil when neither
+// metric has enough readings to show a trend.
+func newSignalHistoryRows(entries []domain.NodeSignalHistoryEntry) fyne.CanvasObject {
+	rssi, snr := signalHistorySeries(entries)
+	stroke := theme.Color(theme.ColorNamePrimary)
+	rows := make([]*widget.FormItem, 0, 2)
+	if len(rssi) > 1 {
+		rows = append(rows, widget.NewFormItem(i18n.T("RSSI"), container.NewVBox(
+			newSignalSparkline(rssi, stroke),
+			widget.NewLabel(signalSeriesSummary(rssi, "dBm")),
+		)))
+	}
+	if len(snr) > 1 {
+		rows = append(rows, widget.NewFormItem(i18n.T("SNR"), container.NewVBox(
+			newSignalSparkline(snr, stroke),
+			widget.NewLabel(signalSeriesSummary(snr, "dB")),
+		)))
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	return widget.NewForm(rows...)
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	fynetest "fyne.io/fyne/v2/test"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestSignalHistorySeriesSkipsMissingValues(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rssi := -95
+	snr := 4.5
+	gotRSSI, gotSNR := signalHistorySeries([]domain.NodeSignalHistoryEntry{
+		{RSSI: &rssi, ObservedAt: at},
+		{SNR: &snr, ObservedAt: at.Add(time.Minute)},
+	})
+	if len(gotRSSI) != 1 || gotRSSI[0].Value != -95 || !gotRSSI[0].At.Equal(at) {
+		t.Fatalf("unexpected rssi series: %+v", gotRSSI)
+	}
+	if len(gotSNR) != 1 || gotSNR[0].Value != 4.5 {
+		t.Fatalf("unexpected snr series: %+v", gotSNR)
+	}
+}
+
+func TestSparklinePositions(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	size := fyne.NewSize(100, 40)
+	tests := []struct {
+		name   string
+		points []signalSeriesPoint
+		want   []fyne.Position
+	}{
+		{name: "empty"},
+		{
+			name: "scales time and value",
+			points: []signalSeriesPoint{
+				{At: at, Value: -90},
+				{At: at.Add(3 * time.Minute), Value: -110},
+				{At: at.Add(4 * time.Minute), Value: -100},
+			},
+			want: []fyne.Position{fyne.NewPos(0, 0), fyne.NewPos(75, 40), fyne.NewPos(100, 20)},
+		},
+		{
+			name:   "flat series at same time",
+			points: []signalSeriesPoint{{At: at, Value: 5}, {At: at, Value: 5}},
+			want:   []fyne.Position{fyne.NewPos(0, 20), fyne.NewPos(100, 20)},
+		},
+	}
+
+	for _, tt := range tests {
+		got := sparklinePositions(tt.points, size)
+		if len(got) != len(tt.want) {
+			t.Fatalf("%s: expected %d positions, got %d", tt.name, len(tt.want), len(got))
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Fatalf("%s: position %d: expected %v, got %v", tt.name, i, tt.want[i], got[i])
+			}
+		}
+	}
+}
+
+func TestNewSignalHistoryRowsNeedsTwoReadings(t *testing.T) {
+	_ = fynetest.NewTempApp(t)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	rssi := -95
+	if rows := newSignalHistoryRows([]domain.NodeSignalHistoryEntry{{RSSI: &rssi, ObservedAt: at}}); rows != nil {
+		t.Fatalf("expected no rows for a single reading")
+	}
+	if rows := newSignalHistoryRows([]domain.NodeSignalHistoryEntry{
+		{RSSI: &rssi, ObservedAt: at},
+		{RSSI: &rssi, ObservedAt: at.Add(time.Minute)},
+	}); rows == nil {
+		t.Fatalf("expected rssi rows for two readings")
+	}
+}
@@ -0,0 +1,261 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	generated "github.com/skobkin/meshgo/internal/radio/meshtasticpb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	smokeSimulatorNodeNum     uint32 = 0x0000abcd
+	smokeSimulatorNodeID             = "!0000abcd"
+	smokeSimulatorChannelName        = "SmokeNet"
+	smokeBroadcastNodeNum     uint32 = 0xffffffff
+)
+
+var errSmokeSimulatorClosed = errors.New("simulator transport closed")
+
+// smokeSimulatorTransport is an in-memory transport that answers like a connected
+// Meshtastic node: it replays a config handshake on want_config, acknowledges
+// outgoing packets with routing replies and serves telemetry module config over admin.
+type smokeSimulatorTransport struct {
+	inbound chan []byte
+
+	mu          sync.Mutex
+	closed      chan struct{}
+	nextID      uint32
+	telemetry   *generated.ModuleConfig_TelemetryConfig
+	sentTexts   []string
+	adminWrites []string
+}
+
+func newSmokeSimulatorTransport() *smokeSimulatorTransport {
+	return &smokeSimulatorTransport{
+		inbound: make(chan []byte, 64),
+		closed:  make(chan struct{}),
+		nextID:  1000,
+		telemetry: &generated.ModuleConfig_TelemetryConfig{
+			DeviceUpdateInterval:      30 * 60,
+			EnvironmentUpdateInterval: 30 * 60,
+			AirQualityInterval:        30 * 60,
+			PowerUpdateInterval:       30 * 60,
+			HealthUpdateInterval:      30 * 60,
+			DeviceTelemetryEnabled:    true,
+		},
+	}
+}
+
+func (s *smokeSimulatorTransport) Name() string {
+	return "simulator"
+}
+
+func (s *smokeSimulatorTransport) Connect(context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.closed:
+		s.closed = make(chan struct{})
+	default:
+	}
+
+	return nil
+}
+
+func (s *smokeSimulatorTransport) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	select {
+	case <-s.closed:
+	default:
+		close(s.closed)
+	}
+
+	return nil
+}
+
+func (s *smokeSimulatorTransport) ReadFrame(ctx context.Context) ([]byte, error) {
+	s.mu.Lock()
+	closed := s.closed
+	s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-closed:
+		return nil, errSmokeSimulatorClosed
+	case payload := <-s.inbound:
+		return payload, nil
+	}
+}
+
+func (s *smokeSimulatorTransport) WriteFrame(_ context.Context, payload []byte) error {
+	var wire generated.ToRadio
+	if err := proto.Unmarshal(payload, &wire); err != nil {
+		return fmt.Errorf("decode toradio: %w", err)
+	}
+
+	if configID := wire.GetWantConfigId(); configID != 0 {
+		s.replayConfig(configID)
+
+		return nil
+	}
+	packet := wire.GetPacket()
+	if packet == nil || packet.GetDecoded() == nil {
+		return nil
+	}
+
+	switch packet.GetDecoded().GetPortnum() {
+	case generated.PortNum_TEXT_MESSAGE_APP:
+		s.mu.Lock()
+		s.sentTexts = append(s.sentTexts, string(packet.GetDecoded().GetPayload()))
+		s.mu.Unlock()
+		s.ack(packet)
+	case generated.PortNum_ADMIN_APP:
+		var admin generated.AdminMessage
+		if err := proto.Unmarshal(packet.GetDecoded().GetPayload(), &admin); err != nil {
+			return fmt.Errorf("decode admin payload: %w", err)
+		}
+		s.handleAdmin(packet, &admin)
+	}
+
+	return nil
+}
+
+// DeliverChannelText injects an incoming broadcast text packet as if it was heard on the mesh.
+func (s *smokeSimulatorTransport) DeliverChannelText(from uint32, text string) {
+	s.push(&generated.FromRadio{PayloadVariant: &generated.FromRadio_Packet{Packet: &generated.MeshPacket{
+		From: from,
+		To:   smokeBroadcastNodeNum,
+		Id:   s.allocID(),
+		PayloadVariant: &generated.MeshPacket_Decoded{Decoded: &generated.Data{
+			Portnum: generated.PortNum_TEXT_MESSAGE_APP,
+			Payload: []byte(text),
+		}},
+	}}})
+}
+
+func (s *smokeSimulatorTransport) SentTexts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.sentTexts...)
+}
+
+func (s *smokeSimulatorTransport) AdminWrites() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.adminWrites...)
+}
+
+func (s *smokeSimulatorTransport) Telemetry() *generated.ModuleConfig_TelemetryConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return proto.Clone(s.telemetry).(*generated.ModuleConfig_TelemetryConfig)
+}
+
+func (s *smokeSimulatorTransport) replayConfig(configID uint32) {
+	s.push(&generated.FromRadio{PayloadVariant: &generated.FromRadio_MyInfo{MyInfo: &generated.MyNodeInfo{
+		MyNodeNum: smokeSimulatorNodeNum,
+	}}})
+	s.push(&generated.FromRadio{PayloadVariant: &generated.FromRadio_NodeInfo{NodeInfo: &generated.NodeInfo{
+		Num: smokeSimulatorNodeNum,
+		User: &generated.User{
+			Id:        smokeSimulatorNodeID,
+			LongName:  "Smoke Node",
+			ShortName: "SMK",
+		},
+	}}})
+	s.push(&generated.FromRadio{PayloadVariant: &generated.FromRadio_Channel{Channel: &generated.Channel{
+		Index:    0,
+		Role:     generated.Channel_PRIMARY,
+		Settings: &generated.ChannelSettings{Name: smokeSimulatorChannelName},
+	}}})
+	s.push(&generated.FromRadio{PayloadVariant: &generated.FromRadio_ConfigCompleteId{ConfigCompleteId: configID}})
+}
+
+func (s *smokeSimulatorTransport) handleAdmin(packet *generated.MeshPacket, admin *generated.AdminMessage) {
+	switch variant := admin.GetPayloadVariant().(type) {
+	case *generated.AdminMessage_GetModuleConfigRequest:
+		if variant.GetModuleConfigRequest != generated.AdminMessage_TELEMETRY_CONFIG {
+			// Unsupported reads stay unanswered, like a node running older firmware.
+			return
+		}
+		s.reply(packet, generated.PortNum_ADMIN_APP, &generated.AdminMessage{PayloadVariant: &generated.AdminMessage_GetModuleConfigResponse{
+			GetModuleConfigResponse: &generated.ModuleConfig{PayloadVariant: &generated.ModuleConfig_Telemetry{
+				Telemetry: s.Telemetry(),
+			}},
+		}})
+
+		return
+	case *generated.AdminMessage_BeginEditSettings:
+		s.recordAdminWrite("begin_edit_settings")
+	case *generated.AdminMessage_CommitEditSettings:
+		s.recordAdminWrite("commit_edit_settings")
+	case *generated.AdminMessage_SetModuleConfig:
+		if telemetry := variant.SetModuleConfig.GetTelemetry(); telemetry != nil {
+			s.mu.Lock()
+			s.telemetry = proto.Clone(telemetry).(*generated.ModuleConfig_TelemetryConfig)
+			s.mu.Unlock()
+		}
+		s.recordAdminWrite("set_module_config")
+	default:
+		s.recordAdminWrite(fmt.Sprintf("%T", variant))
+	}
+	s.ack(packet)
+}
+
+func (s *smokeSimulatorTransport) recordAdminWrite(action string) {
+	s.mu.Lock()
+	s.adminWrites = append(s.adminWrites, action)
+	s.mu.Unlock()
+}
+
+func (s *smokeSimulatorTransport) ack(packet *generated.MeshPacket) {
+	if !packet.GetWantAck() {
+		return
+	}
+	s.reply(packet, generated.PortNum_ROUTING_APP, &generated.Routing{
+		Variant: &generated.Routing_ErrorReason{ErrorReason: generated.Routing_NONE},
+	})
+}
+
+func (s *smokeSimulatorTransport) reply(request *generated.MeshPacket, port generated.PortNum, message proto.Message) {
+	payload, err := proto.Marshal(message)
+	if err != nil {
+		panic(fmt.Sprintf("marshal simulator reply: %v", err))
+	}
+	s.push(&generated.FromRadio{PayloadVariant: &generated.FromRadio_Packet{Packet: &generated.MeshPacket{
+		From: smokeSimulatorNodeNum,
+		To:   smokeSimulatorNodeNum,
+		Id:   s.allocID(),
+		PayloadVariant: &generated.MeshPacket_Decoded{Decoded: &generated.Data{
+			Portnum:   port,
+			Payload:   payload,
+			RequestId: request.GetId(),
+		}},
+	}}})
+}
+
+func (s *smokeSimulatorTransport) push(frame *generated.FromRadio) {
+	payload, err := proto.Marshal(frame)
+	if err != nil {
+		panic(fmt.Sprintf("marshal simulator frame: %v", err))
+	}
+	s.inbound <- payload
+}
+
+func (s *smokeSimulatorTransport) allocID() uint32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+
+	return s.nextID
+}
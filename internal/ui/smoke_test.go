@@ -0,0 +1,239 @@
+package ui
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	fynetest "fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+
+	meshapp "github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/radio"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+// uiSmokeHarness wires the real bus, radio service and stores against the
+// simulator transport so UI tests exercise the same plumbing as the app.
+type uiSmokeHarness struct {
+	sim       *smokeSimulatorTransport
+	bus       *bus.PubSubBus
+	radio     *radio.Service
+	chatStore *domain.ChatStore
+	nodeStore *domain.NodeStore
+
+	connMu    sync.Mutex
+	conn      busmsg.ConnectionStatus
+	connKnown bool
+}
+
+func newUISmokeHarness(t *testing.T) *uiSmokeHarness {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	messageBus := bus.New(logger)
+	t.Cleanup(func() {
+		cancel()
+		messageBus.Close()
+	})
+
+	codec, err := radio.NewMeshtasticCodec()
+	if err != nil {
+		t.Fatalf("create codec: %v", err)
+	}
+	h := &uiSmokeHarness{
+		sim:       newSmokeSimulatorTransport(),
+		bus:       messageBus,
+		chatStore: domain.NewChatStore(),
+		nodeStore: domain.NewNodeStore(),
+	}
+	h.chatStore.Start(ctx, messageBus)
+	h.nodeStore.Start(ctx, messageBus)
+
+	connSub := messageBus.Subscribe(bus.TopicConnStatus)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case raw, ok := <-connSub:
+				if !ok {
+					return
+				}
+				status, ok := raw.(busmsg.ConnectionStatus)
+				if !ok {
+					continue
+				}
+				h.connMu.Lock()
+				h.conn = status
+				h.connKnown = true
+				h.connMu.Unlock()
+			}
+		}
+	}()
+
+	h.radio = radio.NewService(logger, messageBus, h.sim, codec)
+	h.radio.Start(ctx)
+
+	return h
+}
+
+func (h *uiSmokeHarness) CurrentConnStatus() (busmsg.ConnectionStatus, bool) {
+	h.connMu.Lock()
+	defer h.connMu.Unlock()
+
+	return h.conn, h.connKnown
+}
+
+func (h *uiSmokeHarness) waitConnected(t *testing.T) {
+	t.Helper()
+
+	waitForCondition(t, func() bool {
+		status, known := h.CurrentConnStatus()
+
+		return known &&
+			status.State == busmsg.ConnectionStateConnected &&
+			h.radio.LocalNodeID() == smokeSimulatorNodeID &&
+			hasChat(h.chatStore.ChatListSorted(), domain.ChatKeyForChannel(0))
+	})
+}
+
+func (h *uiSmokeHarness) runtimeDependencies() RuntimeDependencies {
+	return RuntimeDependencies{
+		Data: DataDependencies{
+			ChatStore:         h.chatStore,
+			NodeStore:         h.nodeStore,
+			LocalNodeID:       h.radio.LocalNodeID,
+			CurrentConnStatus: h.CurrentConnStatus,
+		},
+		Actions: ActionDependencies{
+			Sender: h.radio,
+			NodeSettings: meshapp.NewNodeSettingsService(
+				h.bus,
+				h.radio,
+				h.CurrentConnStatus,
+				slog.New(slog.NewTextHandler(io.Discard, nil)),
+			),
+		},
+	}
+}
+
+func TestUISmokeConnectPopulatesLocalNodeAndChannels(t *testing.T) {
+	h := newUISmokeHarness(t)
+	h.waitConnected(t)
+
+	chats := h.chatStore.ChatListSorted()
+	if idx := chatIndexByKey(chats, domain.ChatKeyForChannel(0)); idx < 0 || chats[idx].Title != smokeSimulatorChannelName {
+		t.Fatalf("expected primary channel chat %q, got %+v", smokeSimulatorChannelName, chats)
+	}
+	waitForCondition(t, func() bool {
+		node, ok := h.nodeStore.Get(smokeSimulatorNodeID)
+
+		return ok && node.LongName == "Smoke Node"
+	})
+}
+
+func TestUISmokeChatRendersIncomingAndSendsOutgoing(t *testing.T) {
+	if raceDetectorEnabled {
+		t.Skip("Fyne GUI interaction tests are not stable under the race detector")
+	}
+
+	h := newUISmokeHarness(t)
+	h.waitConnected(t)
+	dep := h.runtimeDependencies()
+
+	tab := newChatsTab(
+		nil,
+		h.chatStore,
+		dep.Actions.Sender,
+		nil,
+		nil,
+		dep.Data.LocalNodeID,
+		nil,
+		domain.ChatKeyForChannel(0),
+		nil,
+		nil,
+		nil,
+		nil,
+		func() bool { return false },
+		chatAnnotationActions{},
+		chatTaskbarFlashActions{},
+		nil,
+		nil,
+		chatReferenceSource{},
+		nil,
+		chatPinActions{},
+		chatHistoryActions{},
+		chatListPrefsActions{},
+		chatColorActions{},
+		nil,
+	)
+	window := fynetest.NewTempWindow(t, tab)
+	window.Resize(fyne.NewSize(900, 700))
+
+	h.sim.DeliverChannelText(0x00001234, "hello from the mesh")
+	waitForCondition(t, func() bool { return smokeRichTextContains(tab, "hello from the mesh") })
+
+	entry := mustFindEntryByPlaceholder(t, tab, "Type message")
+	entry.SetText("hello back")
+	fynetest.Tap(mustFindButtonByText(t, tab, "Send"))
+
+	waitForCondition(t, func() bool {
+		sent := h.sim.SentTexts()
+
+		return len(sent) == 1 && sent[0] == "hello back"
+	})
+	waitForCondition(t, func() bool { return smokeRichTextContains(tab, "hello back") })
+}
+
+func TestUISmokeTelemetrySettingsLoadAndSave(t *testing.T) {
+	if raceDetectorEnabled {
+		t.Skip("Fyne GUI interaction tests are not stable under the race detector")
+	}
+
+	h := newUISmokeHarness(t)
+	h.waitConnected(t)
+	dep := h.runtimeDependencies()
+
+	page, onOpened := newNodeTelemetrySettingsPage(dep, &nodeSettingsSaveGate{})
+	_ = fynetest.NewTempWindow(t, page)
+	fyne.DoAndWait(onOpened)
+	waitForCondition(t, func() bool { return findLabelByPrefix(page, "Telemetry settings loaded.") != nil })
+
+	deviceInterval := mustFindSelectWithOption(t, page, "1 hour")
+	if deviceInterval.Selected != "30 minutes" {
+		t.Fatalf("expected loaded device update interval, got %q", deviceInterval.Selected)
+	}
+	deviceInterval.SetSelected("1 hour")
+	fynetest.Tap(mustFindButtonByText(t, page, "Save"))
+
+	waitForCondition(t, func() bool { return findLabelByPrefix(page, "Settings saved.") != nil })
+	if got := h.sim.Telemetry().GetDeviceUpdateInterval(); got != 60*60 {
+		t.Fatalf("expected simulator to store device update interval 3600, got %d", got)
+	}
+	want := []string{"begin_edit_settings", "set_module_config", "commit_edit_settings"}
+	if got := h.sim.AdminWrites(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected admin write sequence: got %v want %v", got, want)
+	}
+}
+
+func smokeRichTextContains(root fyne.CanvasObject, text string) bool {
+	for _, object := range fynetest.LaidOutObjects(root) {
+		richText, ok := object.(*widget.RichText)
+		if !ok {
+			continue
+		}
+		if strings.Contains(richTextSegmentsText(richText.Segments), text) {
+			return true
+		}
+	}
+
+	return false
+}
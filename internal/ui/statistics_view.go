@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+const (
+	statisticsDays      = 30
+	statisticsNodeLimit = 15
+	statisticsChatLimit = 10
+)
+
+var statisticsBarChartMinSize = fyne.NewSize(480, 90)
+
+func showStatisticsModal(window fyne.Window, dep RuntimeDependencies) {
+	if window == nil {
+		return
+	}
+	if dep.Actions.LoadStatistics == nil {
+		showErrorModal(dep, fmt.Errorf("statistics are unavailable: database is not initialized"))
+
+		return
+	}
+
+	loading := widget.NewLabel(i18n.T("Loading statistics..."))
+	body := container.NewStack(loading)
+	closeButton := widget.NewButton(i18n.T("Close"), nil)
+	content := container.NewBorder(nil, closeButton, nil, nil, body)
+	modal := widget.NewModalPopUp(content, window.Canvas())
+	closeButton.OnTapped = modal.Hide
+	modal.Resize(fyne.NewSize(640, 640))
+	modal.Show()
+
+	go func() {
+		now := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stats, err := dep.Actions.LoadStatistics(ctx, now.AddDate(0, 0, -(statisticsDays-1)), statisticsNodeLimit)
+		fyne.Do(func() {
+			if err != nil {
+				modal.Hide()
+				showErrorModal(dep, err)
+
+				return
+			}
+			var chats []domain.Chat
+			if dep.Data.ChatStore != nil {
+				chats = dep.Data.ChatStore.ChatListSorted()
+			}
+			body.Objects = []fyne.CanvasObject{newStatisticsContent(stats, now, chats, resolveNodeDisplayName(dep.Data.NodeStore))}
+			body.Refresh()
+		})
+	}()
+}
+
+func newStatisticsContent(stats domain.MeshStatistics, now time.Time, chats []domain.Chat, nodeNameByID func(string) string) fyne.CanvasObject {
+	stroke := theme.Color(theme.ColorNamePrimary)
+	daily := domain.DailyMessageTotals(stats.Daily, now, statisticsDays)
+	dailyValues := make([]int, 0, len(daily))
+	for _, day := range daily {
+		dailyValues = append(dailyValues, day.Total())
+	}
+	hourValues := make([]int, 0, len(stats.Hours))
+	for _, hour := range stats.Hours {
+		hourValues = append(hourValues, hour.Packets+hour.Messages)
+	}
+
+	sections := container.NewVBox(
+		widget.NewLabelWithStyle(i18n.Tf("Messages per day, last %d days", statisticsDays), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		newStatisticsBarChart(dailyValues, stroke),
+		widget.NewLabel(statisticsDailySummary(daily)),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle(i18n.T("Activity by hour of day"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		newStatisticsBarChart(hourValues, stroke),
+		widget.NewLabel(statisticsHoursSummary(stats.Hours)),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle(i18n.T("Busiest chats"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(strings.Join(statisticsChatLines(domain.ChatMessageTotals(stats.Daily), chats, nodeNameByID), "\n")),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle(i18n.T("Packets per node"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabel(strings.Join(statisticsNodeLines(stats.Nodes, nodeNameByID), "\n")),
+	)
+
+	return container.NewVScroll(sections)
+}
+
+func statisticsDailySummary(daily []domain.DailyMessageTotal) string {
+	total, incoming, outgoing := 0, 0, 0
+	busiest := -1
+	for i, day := range daily {
+		total += day.Total()
+		incoming += day.Incoming
+		outgoing += day.Outgoing
+		if day.Total() > 0 && (busiest < 0 || day.Total() > daily[busiest].Total()) {
+			busiest = i
+		}
+	}
+	if busiest < 0 {
+		return i18n.T("No messages yet")
+	}
+
+	return i18n.Tf(
+		"%d messages (%d received, %d sent), busiest day %s with %d",
+		total, incoming, outgoing, daily[busiest].Day.Format("Jan 2"), daily[busiest].Total(),
+	)
+}
+
+func statisticsHoursSummary(hours [24]domain.HourlyActivity) string {
+	busiest := -1
+	for hour, activity := range hours {
+		value := activity.Packets + activity.Messages
+		if value > 0 && (busiest < 0 || value > hours[busiest].Packets+hours[busiest].Messages) {
+			busiest = hour
+		}
+	}
+	if busiest < 0 {
+		return i18n.T("No activity yet")
+	}
+
+	return i18n.Tf(
+		"Busiest hour %02d:00-%02d:00 with %d packets and %d messages",
+		busiest, (busiest+1)%24, hours[busiest].Packets, hours[busiest].Messages,
+	)
+}
+
+func statisticsChatLines(totals []domain.ChatMessageTotal, chats []domain.Chat, nodeNameByID func(string) string) []string {
+	if len(totals) == 0 {
+		return []string{i18n.T("No messages yet")}
+	}
+	byKey := make(map[string]domain.Chat, len(chats))
+	for _, chat := range chats {
+		byKey[chat.Key] = chat
+	}
+	lines := make([]string, 0, min(len(totals), statisticsChatLimit))
+	for _, total := range totals[:min(len(totals), statisticsChatLimit)] {
+		chat, ok := byKey[total.ChatKey]
+		if !ok {
+			chat = domain.Chat{Key: total.ChatKey, Type: domain.ChatTypeForKey(total.ChatKey)}
+		}
+		lines = append(lines, i18n.Tf(
+			"%s: %d (%d received, %d sent)",
+			chatDisplayTitle(chat, nodeNameByID), total.Total(), total.Incoming, total.Outgoing,
+		))
+	}
+
+	return lines
+}
+
+func statisticsNodeLines(nodes []domain.NodePacketCount, nodeNameByID func(string) string) []string {
+	if len(nodes) == 0 {
+		return []string{i18n.T("No packets yet")}
+	}
+	lines := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		lines = append(lines, i18n.Tf(
+			"%s: %d packets, last %s",
+			displaySender(node.NodeID, nodeNameByID), node.Packets, telemetryLogTime(node.LastPacketAt),
+		))
+	}
+
+	return lines
+}
+
+// statisticsBarRect is the position and size of one bar.
+type statisticsBarRect struct {
+	Pos  fyne.Position
+	Size fyne.Size
+}
+
+// statisticsBarRects lays values out as bars of equal width scaled to the largest value.
+func statisticsBarRects(values []int, size fyne.Size) []statisticsBarRect {
+	if len(values) == 0 {
+		return nil
+	}
+	peak := 0
+	for _, value := range values {
+		peak = max(peak, value)
+	}
+	slot := size.Width / float32(len(values))
+	gap := min(slot*0.2, 2)
+
+	out := make([]statisticsBarRect, 0, len(values))
+	for i, value := range values {
+		var height float32
+		if peak > 0 {
+			height = float32(value) / float32(peak) * size.Height
+		}
+		out = append(out, statisticsBarRect{
+			Pos:  fyne.NewPos(float32(i)*slot, size.Height-height),
+			Size: fyne.NewSize(slot-gap, height),
+		})
+	}
+
+	return out
+}
+
+type statisticsBarChartLayout struct {
+	values []int
+}
+
+func (l *statisticsBarChartLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	rects := statisticsBarRects(l.values, size)
+	for i, object := range objects {
+		if i >= len(rects) {
+			break
+		}
+		object.Move(rects[i].Pos)
+		object.Resize(rects[i].Size)
+	}
+}
+
+func (l *statisticsBarChartLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	return statisticsBarChartMinSize
+}
+
+func newStatisticsBarChart(values []int, fill color.Color) fyne.CanvasObject {
+	bars := make([]fyne.CanvasObject, 0, len(values))
+	for range values {
+		bars = append(bars, canvas.NewRectangle(fill))
+	}
+
+	return container.New(&statisticsBarChartLayout{values: values}, bars...)
+}
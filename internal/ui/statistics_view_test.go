@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestStatisticsBarRects(t *testing.T) {
+	size := fyne.NewSize(40, 20)
+	tests := []struct {
+		name   string
+		values []int
+		want   []statisticsBarRect
+	}{
+		{name: "empty"},
+		{
+			name:   "scales to peak",
+			values: []int{2, 0, 4, 1},
+			want: []statisticsBarRect{
+				{Pos: fyne.NewPos(0, 10), Size: fyne.NewSize(8, 10)},
+				{Pos: fyne.NewPos(10, 20), Size: fyne.NewSize(8, 0)},
+				{Pos: fyne.NewPos(20, 0), Size: fyne.NewSize(8, 20)},
+				{Pos: fyne.NewPos(30, 15), Size: fyne.NewSize(8, 5)},
+			},
+		},
+		{
+			name:   "all zero",
+			values: []int{0, 0},
+			want: []statisticsBarRect{
+				{Pos: fyne.NewPos(0, 20), Size: fyne.NewSize(18, 0)},
+				{Pos: fyne.NewPos(20, 20), Size: fyne.NewSize(18, 0)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got := statisticsBarRects(tt.values, size)
+		if len(got) != len(tt.want) {
+			t.Fatalf("%s: expected %d bars, got %d", tt.name, len(tt.want), len(got))
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Fatalf("%s: bar %d: expected %+v, got %+v", tt.name, i, tt.want[i], got[i])
+			}
+		}
+	}
+}
+
+func TestStatisticsSummaries(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	daily := []domain.DailyMessageTotal{
+		{Day: day.AddDate(0, 0, -1), Incoming: 1},
+		{Day: day, Incoming: 2, Outgoing: 1},
+	}
+	if got, want := statisticsDailySummary(daily), "4 messages (3 received, 1 sent), busiest day Mar 2 with 3"; got != want {
+		t.Fatalf("daily summary: expected %q, got %q", want, got)
+	}
+	if got := statisticsDailySummary(nil); got != "No messages yet" {
+		t.Fatalf("empty daily summary: expected %q, got %q", "No messages yet", got)
+	}
+
+	var hours [24]domain.HourlyActivity
+	hours[23] = domain.HourlyActivity{Packets: 5, Messages: 1}
+	hours[8] = domain.HourlyActivity{Packets: 2}
+	if got, want := statisticsHoursSummary(hours), "Busiest hour 23:00-00:00 with 5 packets and 1 messages"; got != want {
+		t.Fatalf("hours summary: expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver"
+
+	meshapp "github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/bus"
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/platform"
+)
+
+var taskbarFlashLogger = slog.With("component", "ui.taskbar_flash")
+
+// taskbarFlashMinInterval keeps a busy chat from flashing the taskbar again and again
+// while the user is away.
+const taskbarFlashMinInterval = 30 * time.Second
+
+// taskbarFlasher highlights the taskbar entry when a message in a chat that has flashing
+// enabled arrives while the window is unfocused. Flashing stops when the user returns.
+type taskbarFlasher struct {
+	attention     platform.TaskbarAttention
+	window        fyne.Window
+	foreground    func() bool
+	currentConfig func() config.AppConfig
+	minInterval   time.Duration
+	now           func() time.Time
+
+	mu        sync.Mutex
+	flashing  bool
+	lastFlash time.Time
+}
+
+func newTaskbarFlasher(
+	attention platform.TaskbarAttention,
+	window fyne.Window,
+	foreground func() bool,
+	currentConfig func() config.AppConfig,
+) *taskbarFlasher {
+	return &taskbarFlasher{
+		attention:     attention,
+		window:        window,
+		foreground:    foreground,
+		currentConfig: currentConfig,
+		minInterval:   taskbarFlashMinInterval,
+		now:           time.Now,
+	}
+}
+
+// newTaskbarFlasherFor returns nil when the platform cannot highlight the taskbar.
+func newTaskbarFlasherFor(
+	dep RuntimeDependencies,
+	window fyne.Window,
+	attention *userAttention,
+	currentConfig func() config.AppConfig,
+) *taskbarFlasher {
+	if dep.Platform.NewTaskbarAttention == nil {
+		return nil
+	}
+	taskbar, err := dep.Platform.NewTaskbarAttention()
+	if err != nil {
+		taskbarFlashLogger.Info("taskbar flashing is unavailable", "error", err)
+
+		return nil
+	}
+
+	return newTaskbarFlasher(taskbar, window, attention.Foreground, currentConfig)
+}
+
+// Start flashes for incoming messages published on messageBus until ctx is done.
+func (f *taskbarFlasher) Start(ctx context.Context, messageBus bus.MessageBus) {
+	if f == nil || messageBus == nil {
+		return
+	}
+	sub := messageBus.Subscribe(bus.TopicTextMessage)
+	go func() {
+		defer messageBus.Unsubscribe(sub, bus.TopicTextMessage)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case raw, ok := <-sub:
+				if !ok {
+					return
+				}
+				msg, ok := raw.(domain.ChatMessage)
+				if !ok {
+					continue
+				}
+				f.handleMessage(msg)
+			}
+		}
+	}()
+}
+
+func (f *taskbarFlasher) handleMessage(msg domain.ChatMessage) {
+	if !f.shouldFlash(msg) {
+		return
+	}
+	f.mu.Lock()
+	now := f.now()
+	if !f.lastFlash.IsZero() && now.Sub(f.lastFlash) < f.minInterval {
+		f.mu.Unlock()
+
+		return
+	}
+	f.lastFlash = now
+	f.flashing = true
+	f.mu.Unlock()
+
+	fyne.Do(func() {
+		if err := f.attention.Request(nativeWindowHandle(f.window)); err != nil {
+			taskbarFlashLogger.Debug("flash taskbar failed", "chat_key", msg.ChatKey, "error", err)
+		}
+	})
+}
+
+func (f *taskbarFlasher) shouldFlash(msg domain.ChatMessage) bool {
+	if msg.Direction != domain.MessageDirectionIn || isReactionMessage(msg) {
+		return false
+	}
+	if f.foreground != nil && f.foreground() {
+		return false
+	}
+	if f.currentConfig == nil {
+		return false
+	}
+	prefs := f.currentConfig().UI.TaskbarFlash
+	if !prefs.Enabled {
+		return false
+	}
+
+	return prefs.FlashesChat(msg.ChatKey, meshapp.TaskbarFlashChatDefault(msg.ChatKey))
+}
+
+// Cancel stops flashing once the user is back, so the next message after they leave
+// again flashes right away. It may run on any goroutine.
+func (f *taskbarFlasher) Cancel() {
+	f.mu.Lock()
+	wasFlashing := f.flashing
+	f.flashing = false
+	f.lastFlash = time.Time{}
+	f.mu.Unlock()
+	if !wasFlashing {
+		return
+	}
+
+	fyne.Do(func() {
+		if err := f.attention.Cancel(nativeWindowHandle(f.window)); err != nil {
+			taskbarFlashLogger.Debug("stop taskbar flash failed", "error", err)
+		}
+	})
+}
+
+// nativeWindowHandle returns the window system handle of window, or 0 when the driver
+// does not expose one. It must run on the UI goroutine.
+func nativeWindowHandle(window fyne.Window) uintptr {
+	native, ok := window.(driver.NativeWindow)
+	if !ok {
+		return 0
+	}
+	var handle uintptr
+	native.RunNative(func(context any) {
+		switch ctx := context.(type) {
+		case driver.WindowsWindowContext:
+			handle = ctx.HWND
+		case driver.X11WindowContext:
+			handle = ctx.WindowHandle
+		}
+	})
+
+	return handle
+}
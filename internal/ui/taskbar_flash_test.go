@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	fynetest "fyne.io/fyne/v2/test"
+
+	"github.com/skobkin/meshgo/internal/config"
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+type taskbarAttentionStub struct {
+	mu       sync.Mutex
+	requests int
+	cancels  int
+}
+
+func (a *taskbarAttentionStub) Request(uintptr) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.requests++
+
+	return nil
+}
+
+func (a *taskbarAttentionStub) Cancel(uintptr) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.cancels++
+
+	return nil
+}
+
+func (a *taskbarAttentionStub) counts() (requests, cancels int) {
+	// Flash calls are queued onto the UI goroutine.
+	fyne.DoAndWait(func() {})
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.requests, a.cancels
+}
+
+func newTestTaskbarFlasher(t *testing.T, foreground bool, prefs config.TaskbarFlashConfig) (*taskbarFlasher, *taskbarAttentionStub) {
+	t.Helper()
+	app := fynetest.NewTempApp(t)
+	stub := &taskbarAttentionStub{}
+	flasher := newTaskbarFlasher(
+		stub,
+		app.NewWindow("test"),
+		func() bool { return foreground },
+		func() config.AppConfig {
+			cfg := config.Default()
+			cfg.UI.TaskbarFlash = prefs
+
+			return cfg
+		},
+	)
+
+	return flasher, stub
+}
+
+func TestTaskbarFlasherFlashesOnlyForEnabledChats(t *testing.T) {
+	incomingDM := domain.ChatMessage{ChatKey: "dm:!0000002a", Direction: domain.MessageDirectionIn, Body: "hi"}
+	incomingChannel := domain.ChatMessage{ChatKey: "channel:0", Direction: domain.MessageDirectionIn, Body: "hi"}
+
+	tests := []struct {
+		name       string
+		foreground bool
+		prefs      config.TaskbarFlashConfig
+		message    domain.ChatMessage
+		want       int
+	}{
+		{name: "disabled", prefs: config.TaskbarFlashConfig{}, message: incomingDM, want: 0},
+		{name: "direct message", prefs: config.TaskbarFlashConfig{Enabled: true}, message: incomingDM, want: 1},
+		{name: "window focused", foreground: true, prefs: config.TaskbarFlashConfig{Enabled: true}, message: incomingDM, want: 0},
+		{name: "channel by default", prefs: config.TaskbarFlashConfig{Enabled: true}, message: incomingChannel, want: 0},
+		{
+			name:    "channel enabled for the chat",
+			prefs:   config.TaskbarFlashConfig{Enabled: true, Chats: map[string]bool{"channel:0": true}},
+			message: incomingChannel,
+			want:    1,
+		},
+		{
+			name:    "direct message disabled for the chat",
+			prefs:   config.TaskbarFlashConfig{Enabled: true, Chats: map[string]bool{"dm:!0000002a": false}},
+			message: incomingDM,
+			want:    0,
+		},
+		{
+			name:    "outgoing message",
+			prefs:   config.TaskbarFlashConfig{Enabled: true},
+			message: domain.ChatMessage{ChatKey: "dm:!0000002a", Direction: domain.MessageDirectionOut, Body: "hi"},
+			want:    0,
+		},
+		{
+			name:    "reaction",
+			prefs:   config.TaskbarFlashConfig{Enabled: true},
+			message: domain.ChatMessage{ChatKey: "dm:!0000002a", Direction: domain.MessageDirectionIn, ReplyToDeviceMessageID: "1", Emoji: 1, Body: "👍"},
+			want:    0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			flasher, stub := newTestTaskbarFlasher(t, tc.foreground, tc.prefs)
+			flasher.handleMessage(tc.message)
+			if requests, _ := stub.counts(); requests != tc.want {
+				t.Fatalf("expected %d flash requests, got %d", tc.want, requests)
+			}
+		})
+	}
+}
+
+func TestTaskbarFlasherThrottlesUntilUserReturns(t *testing.T) {
+	flasher, stub := newTestTaskbarFlasher(t, false, config.TaskbarFlashConfig{Enabled: true})
+	now := time.Unix(1_700_000_000, 0)
+	flasher.now = func() time.Time { return now }
+	msg := domain.ChatMessage{ChatKey: "dm:!0000002a", Direction: domain.MessageDirectionIn, Body: "hi"}
+
+	flasher.handleMessage(msg)
+	now = now.Add(time.Second)
+	flasher.handleMessage(msg)
+	if requests, _ := stub.counts(); requests != 1 {
+		t.Fatalf("expected a burst to flash once, got %d requests", requests)
+	}
+
+	now = now.Add(taskbarFlashMinInterval)
+	flasher.handleMessage(msg)
+	if requests, _ := stub.counts(); requests != 2 {
+		t.Fatalf("expected another flash after the interval, got %d requests", requests)
+	}
+
+	flasher.Cancel()
+	flasher.Cancel()
+	if _, cancels := stub.counts(); cancels != 1 {
+		t.Fatalf("expected one cancel for the running flash, got %d", cancels)
+	}
+	now = now.Add(time.Second)
+	flasher.handleMessage(msg)
+	if requests, _ := stub.counts(); requests != 3 {
+		t.Fatalf("expected the next message after a return to flash at once, got %d requests", requests)
+	}
+}
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"slices"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// telemetryChartHistoryLimit caps the history rows loaded for the telemetry log and charts.
+const telemetryChartHistoryLimit = 5000
+
+var telemetryChartMinSize = fyne.NewSize(420, 90)
+
+type telemetryChartRange struct {
+	Label string
+	// Window is how far back the chart goes; zero shows the whole stored history.
+	Window time.Duration
+}
+
+var telemetryChartRanges = []telemetryChartRange{
+	{Label: "6 hours", Window: 6 * time.Hour},
+	{Label: "24 hours", Window: 24 * time.Hour},
+	{Label: "7 days", Window: 7 * 24 * time.Hour},
+	{Label: "30 days", Window: 30 * 24 * time.Hour},
+	{Label: "All", Window: 0},
+}
+
+const telemetryChartDefaultRange = "24 hours"
+
+// telemetryChartRangeByLabel returns the range shown under the given translated label.
+func telemetryChartRangeByLabel(label string) telemetryChartRange {
+	for _, item := range telemetryChartRanges {
+		if i18n.T(item.Label) == label {
+			return item
+		}
+	}
+
+	return telemetryChartRanges[len(telemetryChartRanges)-1]
+}
+
+// telemetryChartMetric is one charted telemetry value.
+type telemetryChartMetric struct {
+	Title string
+	Unit  string
+	Value func(entry domain.NodeTelemetryHistoryEntry) (float64, bool)
+}
+
+func telemetryChartMetrics(formatter displayFormatter) []telemetryChartMetric {
+	temperatureUnit := "C"
+	if formatter.fahrenheit {
+		temperatureUnit = "F"
+	}
+
+	return []telemetryChartMetric{
+		{Title: "Battery", Unit: "%", Value: func(entry domain.NodeTelemetryHistoryEntry) (float64, bool) {
+			if entry.BatteryLevel == nil {
+				return 0, false
+			}
+
+			return float64(*entry.BatteryLevel), true
+		}},
+		{Title: "Voltage", Unit: "V", Value: func(entry domain.NodeTelemetryHistoryEntry) (float64, bool) {
+			return telemetryChartFloat(entry.Voltage)
+		}},
+		{Title: "Channel utilization", Unit: "%", Value: func(entry domain.NodeTelemetryHistoryEntry) (float64, bool) {
+			return telemetryChartFloat(entry.ChannelUtilization)
+		}},
+		{Title: "TX air utilization", Unit: "%", Value: func(entry domain.NodeTelemetryHistoryEntry) (float64, bool) {
+			return telemetryChartFloat(entry.AirUtilTx)
+		}},
+		{Title: "Temperature", Unit: temperatureUnit, Value: func(entry domain.NodeTelemetryHistoryEntry) (float64, bool) {
+			if entry.Temperature == nil {
+				return 0, false
+			}
+			if formatter.fahrenheit {
+				return *entry.Temperature*9/5 + 32, true
+			}
+
+			return *entry.Temperature, true
+		}},
+	}
+}
+
+func telemetryChartFloat(value *float64) (float64, bool) {
+	if value == nil {
+		return 0, false
+	}
+
+	return *value, true
+}
+
+// telemetryChartSeries returns the metric readings observed since the given time,
+// oldest first. A zero since keeps all readings.
+func telemetryChartSeries(
+	entries []domain.NodeTelemetryHistoryEntry,
+	metric telemetryChartMetric,
+	since time.Time,
+) []signalSeriesPoint {
+	points := make([]signalSeriesPoint, 0, len(entries))
+	for _, entry := range entries {
+		if entry.ObservedAt.IsZero() || (!since.IsZero() && entry.ObservedAt.Before(since)) {
+			continue
+		}
+		value, ok := metric.Value(entry)
+		if !ok {
+			continue
+		}
+		points = append(points, signalSeriesPoint{At: entry.ObservedAt, Value: value})
+	}
+	slices.SortStableFunc(points, func(a, b signalSeriesPoint) int {
+		return a.At.Compare(b.At)
+	})
+
+	return points
+}
+
+// newTelemetryCharts renders a chart per metric with enough readings in the range.
+func newTelemetryCharts(entries []domain.NodeTelemetryHistoryEntry, chartRange telemetryChartRange, now time.Time) fyne.CanvasObject {
+	var since time.Time
+	if chartRange.Window > 0 {
+		since = now.Add(-chartRange.Window)
+	}
+	stroke := theme.Color(theme.ColorNamePrimary)
+	rows := make([]*widget.FormItem, 0, 5)
+	for _, metric := range telemetryChartMetrics(currentDisplayFormatter()) {
+		points := telemetryChartSeries(entries, metric, since)
+		if len(points) < 2 {
+			continue
+		}
+		rows = append(rows, widget.NewFormItem(i18n.T(metric.Title), container.NewVBox(
+			newSparkline(points, stroke, telemetryChartMinSize),
+			widget.NewLabel(signalSeriesSummary(points, metric.Unit)),
+		)))
+	}
+	if len(rows) == 0 {
+		return widget.NewLabel(i18n.T("Not enough telemetry in this range"))
+	}
+
+	return widget.NewForm(rows...)
+}
+
+// newTelemetryChartsView shows the charts with a time range selector.
+func newTelemetryChartsView(entries []domain.NodeTelemetryHistoryEntry) fyne.CanvasObject {
+	charts := container.NewStack()
+	labels := make([]string, 0, len(telemetryChartRanges))
+	for _, item := range telemetryChartRanges {
+		labels = append(labels, i18n.T(item.Label))
+	}
+	rangeSelect := widget.NewSelect(labels, func(label string) {
+		charts.Objects = []fyne.CanvasObject{newTelemetryCharts(entries, telemetryChartRangeByLabel(label), time.Now())}
+		charts.Refresh()
+	})
+	rangeSelect.SetSelected(i18n.T(telemetryChartDefaultRange))
+
+	return container.NewBorder(
+		container.NewHBox(widget.NewLabel(i18n.T("Range")), rangeSelect),
+		nil,
+		nil,
+		nil,
+		container.NewVScroll(charts),
+	)
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+)
+
+func TestTelemetryChartSeries_FiltersRangeAndSortsOldestFirst(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	battery := func(value uint32) *uint32 { return &value }
+	entries := []domain.NodeTelemetryHistoryEntry{
+		{ObservedAt: now.Add(-time.Hour), BatteryLevel: battery(80)},
+		{ObservedAt: now.Add(-2 * time.Hour)},
+		{ObservedAt: now.Add(-3 * time.Hour), BatteryLevel: battery(90)},
+		{ObservedAt: now.Add(-48 * time.Hour), BatteryLevel: battery(100)},
+	}
+	metric := telemetryChartMetrics(displayFormatter{})[0]
+
+	points := telemetryChartSeries(entries, metric, now.Add(-24*time.Hour))
+	if len(points) != 2 {
+		t.Fatalf("unexpected point count: expected 2, got %d", len(points))
+	}
+	if points[0].Value != 90 || points[1].Value != 80 {
+		t.Fatalf("expected points oldest first, got %v", points)
+	}
+
+	if got := len(telemetryChartSeries(entries, metric, time.Time{})); got != 3 {
+		t.Fatalf("unexpected point count without range: expected 3, got %d", got)
+	}
+}
+
+func TestTelemetryChartMetrics_TemperatureFollowsUnit(t *testing.T) {
+	celsius := 20.0
+	entry := domain.NodeTelemetryHistoryEntry{Temperature: &celsius}
+	tests := []struct {
+		name      string
+		formatter displayFormatter
+		unit      string
+		value     float64
+	}{
+		{name: "celsius", formatter: displayFormatter{}, unit: "C", value: 20},
+		{name: "fahrenheit", formatter: displayFormatter{fahrenheit: true}, unit: "F", value: 68},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := telemetryChartMetrics(tt.formatter)
+			metric := metrics[len(metrics)-1]
+			if metric.Unit != tt.unit {
+				t.Fatalf("unexpected unit: expected %q, got %q", tt.unit, metric.Unit)
+			}
+			if got, ok := metric.Value(entry); !ok || got != tt.value {
+				t.Fatalf("unexpected value: expected %v, got %v (ok=%v)", tt.value, got, ok)
+			}
+		})
+	}
+}
+
+func TestTelemetryChartRangeByLabel(t *testing.T) {
+	if got := telemetryChartRangeByLabel("7 days").Window; got != 7*24*time.Hour {
+		t.Fatalf("unexpected window: expected %v, got %v", 7*24*time.Hour, got)
+	}
+	if got := telemetryChartRangeByLabel("unknown").Window; got != 0 {
+		t.Fatalf("expected unknown label to show the whole history, got %v", got)
+	}
+}
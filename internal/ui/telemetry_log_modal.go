@@ -11,6 +11,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 )
 
 func handleNodeTelemetryLogAction(window fyne.Window, dep RuntimeDependencies, node domain.Node) {
@@ -34,9 +35,9 @@ func handleNodeTelemetryLogAction(window fyne.Window, dep RuntimeDependencies, n
 }
 
 func showTelemetryLogModal(window fyne.Window, dep RuntimeDependencies, node domain.Node) {
-	loading := widget.NewLabel("Loading telemetry history...")
+	loading := widget.NewLabel(i18n.T("Loading telemetry history..."))
 	body := container.NewStack(loading)
-	closeButton := widget.NewButton("Close", nil)
+	closeButton := widget.NewButton(i18n.T("Close"), nil)
 	content := container.NewBorder(nil, closeButton, nil, nil, body)
 	modal := widget.NewModalPopUp(content, window.Canvas())
 	closeButton.OnTapped = modal.Hide
@@ -46,7 +47,7 @@ func showTelemetryLogModal(window fyne.Window, dep RuntimeDependencies, node dom
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		rows, err := dep.Actions.NodeOverview.ListTelemetryHistory(ctx, strings.TrimSpace(node.NodeID), 0)
+		rows, err := dep.Actions.NodeOverview.ListTelemetryHistory(ctx, strings.TrimSpace(node.NodeID), telemetryChartHistoryLimit)
 		fyne.Do(func() {
 			if err != nil {
 				modal.Hide()
@@ -54,7 +55,10 @@ func showTelemetryLogModal(window fyne.Window, dep RuntimeDependencies, node dom
 
 				return
 			}
-			body.Objects = []fyne.CanvasObject{newTelemetryLogTable(rows)}
+			body.Objects = []fyne.CanvasObject{container.NewAppTabs(
+				container.NewTabItem(i18n.T("Charts"), newTelemetryChartsView(rows)),
+				container.NewTabItem(i18n.T("Log"), newTelemetryLogTable(rows)),
+			)}
 			body.Refresh()
 		})
 	}()
@@ -62,27 +66,27 @@ func showTelemetryLogModal(window fyne.Window, dep RuntimeDependencies, node dom
 
 func newTelemetryLogTable(items []domain.NodeTelemetryHistoryEntry) fyne.CanvasObject {
 	headers := []string{
-		"Battery",
-		"Voltage",
-		"Uptime",
-		"Channel util",
-		"TX air util",
-		"Temperature",
-		"Humidity",
-		"Pressure",
-		"Soil T",
-		"Soil M",
-		"Gas R",
-		"AQI",
-		"Dew point",
-		"Light",
-		"UV light",
-		"Radiation",
-		"Power V",
-		"Power A",
-		"Channel",
-		"Update",
-		"Observed at",
+		i18n.T("Battery"),
+		i18n.T("Voltage"),
+		i18n.T("Uptime"),
+		i18n.T("Channel util"),
+		i18n.T("TX air util"),
+		i18n.T("Temperature"),
+		i18n.T("Humidity"),
+		i18n.T("Pressure"),
+		i18n.T("Soil T"),
+		i18n.T("Soil M"),
+		i18n.T("Gas R"),
+		i18n.T("AQI"),
+		i18n.T("Dew point"),
+		i18n.T("Light"),
+		i18n.T("UV light"),
+		i18n.T("Radiation"),
+		i18n.T("Power V"),
+		i18n.T("Power A"),
+		i18n.T("Channel"),
+		i18n.T("Update"),
+		i18n.T("Observed at"),
 		// "From packet",
 	}
 	rows := make([][]string, 0, len(items))
@@ -91,7 +95,7 @@ func newTelemetryLogTable(items []domain.NodeTelemetryHistoryEntry) fyne.CanvasO
 	}
 	if len(rows) == 0 {
 		rows = append(rows, []string{
-			"No telemetry history yet",
+			i18n.T("No telemetry history yet"),
 			"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "",
 		})
 	}
@@ -130,7 +134,7 @@ func newTelemetryLogTable(items []domain.NodeTelemetryHistoryEntry) fyne.CanvasO
 
 	return container.NewBorder(
 		container.NewVBox(
-			widget.NewLabelWithStyle("Telemetry log", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			widget.NewLabelWithStyle(i18n.T("Telemetry log"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 			widget.NewSeparator(),
 		),
 		nil,
@@ -147,10 +151,10 @@ func telemetryLogRow(item domain.NodeTelemetryHistoryEntry) []string {
 		overviewUptime(item.UptimeSeconds),
 		formatFloat64(item.ChannelUtilization, "%.2f%%"),
 		formatFloat64(item.AirUtilTx, "%.2f%%"),
-		formatFloat64(item.Temperature, "%.1f C"),
+		formatTemperature(item.Temperature),
 		formatFloat64(item.Humidity, "%.1f%%"),
 		formatFloat64(item.Pressure, "%.1f hPa"),
-		formatFloat64(item.SoilTemperature, "%.1f C"),
+		formatTemperature(item.SoilTemperature),
 		formatUint32(item.SoilMoisture, "%d%%"),
 		formatFloat64(item.GasResistance, "%.2f MOhm"),
 		formatFloat64(item.AirQualityIndex, "%.1f"),
@@ -169,16 +173,16 @@ func telemetryLogRow(item domain.NodeTelemetryHistoryEntry) []string {
 
 func telemetryLogTime(value time.Time) string {
 	if value.IsZero() {
-		return "unknown"
+		return i18n.T("unknown")
 	}
 
-	return value.Local().Format(time.DateTime)
+	return currentDisplayFormatter().DateTimeSeconds(value)
 }
 
 func telemetryLogUpdateType(value domain.NodeUpdateType) string {
 	value = domain.NodeUpdateType(strings.TrimSpace(string(value)))
 	if value == "" {
-		return "unknown"
+		return i18n.T("unknown")
 	}
 
 	return string(value)
@@ -186,7 +190,7 @@ func telemetryLogUpdateType(value domain.NodeUpdateType) string {
 
 func formatUint32(value *uint32, format string) string {
 	if value == nil {
-		return "unknown"
+		return i18n.T("unknown")
 	}
 
 	return fmt.Sprintf(format, *value)
@@ -194,17 +198,41 @@ func formatUint32(value *uint32, format string) string {
 
 func formatFloat64(value *float64, format string) string {
 	if value == nil {
-		return "unknown"
+		return i18n.T("unknown")
 	}
 
-	return fmt.Sprintf(format, *value)
+	return currentDisplayFormatter().Number(format, *value)
+}
+
+func formatTemperature(celsius *float64) string {
+	if celsius == nil {
+		return i18n.T("unknown")
+	}
+
+	return currentDisplayFormatter().Temperature(*celsius)
+}
+
+func formatLatitude(value *float64) string {
+	if value == nil {
+		return i18n.T("unknown")
+	}
+
+	return currentDisplayFormatter().Latitude(*value)
+}
+
+func formatLongitude(value *float64) string {
+	if value == nil {
+		return i18n.T("unknown")
+	}
+
+	return currentDisplayFormatter().Longitude(*value)
 }
 
 func formatDewPoint(temperature, humidity *float64) string {
 	dewPoint, ok := calculateDewPointCelsius(temperature, humidity)
 	if !ok {
-		return "unknown"
+		return i18n.T("unknown")
 	}
 
-	return fmt.Sprintf("%.1f C", dewPoint)
+	return currentDisplayFormatter().Temperature(dewPoint)
 }
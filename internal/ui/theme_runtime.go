@@ -48,14 +48,14 @@ func (r *themeRuntime) SetTrayIconSetter(setter func(fyne.ThemeVariant)) {
 func (r *themeRuntime) BindSettings() {
 	r.fyApp.Settings().AddListener(func(_ fyne.Settings) {
 		appLogger.Debug("theme settings changed")
-		r.Apply(r.fyApp.Settings().ThemeVariant())
+		r.Apply(appThemeVariant(r.fyApp))
 	})
 }
 
 func (r *themeRuntime) Apply(variant fyne.ThemeVariant) {
 	appLogger.Debug("applying theme resources", "theme", variant)
 	r.fyApp.SetIcon(resources.AppIconResource(variant))
-	r.setTrayIcon(variant)
+	r.setTrayIcon(trayIconVariant(currentDisplayConfig().TrayIcon, variant))
 	r.sidebar.applyTheme(variant)
 	if r.updateIndicator != nil {
 		r.updateIndicator.ApplyTheme(variant)
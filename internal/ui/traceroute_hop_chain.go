@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+)
+
+// tracerouteHopDotSize is the diameter of the node dots of a hop chain.
+const tracerouteHopDotSize = float32(12)
+
+// tracerouteHop is a node of a traced route with the SNR of the link it was reached
+// over. The first node of a route has no link.
+type tracerouteHop struct {
+	Label  string
+	SNR    float64
+	HasSNR bool
+	IsLink bool
+}
+
+// tracerouteHops pairs the route nodes with the SNR values of the links between them.
+// Signals that do not match the route are treated as unknown.
+func tracerouteHops(nodeIDs []string, signals []int32, nodeStore *domain.NodeStore) []tracerouteHop {
+	hops := make([]tracerouteHop, 0, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		hop := tracerouteHop{Label: tracerouteNodeDisplay(nodeID, nodeStore), IsLink: i > 0}
+		if i > 0 && len(signals) == len(nodeIDs)-1 && signals[i-1] != tracerouteUnknownSNR {
+			hop.SNR = float64(signals[i-1]) / 4
+			hop.HasSNR = true
+		}
+		hops = append(hops, hop)
+	}
+
+	return hops
+}
+
+func tracerouteHopSNRText(hop tracerouteHop) string {
+	if !hop.HasSNR {
+		return "SNR ?"
+	}
+
+	return currentDisplayFormatter().Number("SNR %.2f dB", hop.SNR)
+}
+
+func tracerouteSNRImportance(hop tracerouteHop) widget.Importance {
+	switch {
+	case !hop.HasSNR:
+		return widget.LowImportance
+	case hop.SNR >= float64(domain.SNRGood):
+		return widget.SuccessImportance
+	case hop.SNR >= float64(domain.SNRFair):
+		return widget.WarningImportance
+	default:
+		return widget.DangerImportance
+	}
+}
+
+// setTracerouteHopChain draws hops into chain as a vertical chain of node dots joined
+// by links labelled with their SNR.
+func setTracerouteHopChain(chain *fyne.Container, hops []tracerouteHop) {
+	objects := make([]fyne.CanvasObject, 0, len(hops)*2)
+	if len(hops) == 0 {
+		waiting := widget.NewLabel(i18n.T("Waiting for route data..."))
+		waiting.Importance = widget.LowImportance
+		objects = append(objects, waiting)
+	}
+	for i, hop := range hops {
+		if hop.IsLink {
+			objects = append(objects, newTracerouteLinkRow(hop))
+		}
+		objects = append(objects, newTracerouteNodeRow(hop, i == 0 || i == len(hops)-1))
+	}
+	chain.Objects = objects
+	chain.Refresh()
+}
+
+func newTracerouteNodeRow(hop tracerouteHop, endpoint bool) fyne.CanvasObject {
+	dotColor := theme.Color(theme.ColorNameDisabled)
+	if endpoint {
+		dotColor = theme.Color(theme.ColorNamePrimary)
+	}
+	dot := canvas.NewCircle(dotColor)
+	label := widget.NewLabelWithStyle(hop.Label, fyne.TextAlignLeading, fyne.TextStyle{Bold: endpoint})
+	label.Truncation = fyne.TextTruncateEllipsis
+
+	return container.NewBorder(
+		nil,
+		nil,
+		container.NewCenter(container.NewGridWrap(fyne.NewSquareSize(tracerouteHopDotSize), dot)),
+		nil,
+		label,
+	)
+}
+
+func newTracerouteLinkRow(hop tracerouteHop) fyne.CanvasObject {
+	line := canvas.NewRectangle(theme.Color(theme.ColorNameDisabled))
+	line.SetMinSize(fyne.NewSize(2, theme.TextSize()*1.5))
+	snr := widget.NewLabel("↓ " + tracerouteHopSNRText(hop))
+	snr.Importance = tracerouteSNRImportance(hop)
+
+	return container.NewBorder(
+		nil,
+		nil,
+		container.NewGridWrap(fyne.NewSize(tracerouteHopDotSize, theme.TextSize()*1.5), container.NewCenter(line)),
+		nil,
+		snr,
+	)
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestTracerouteHops(t *testing.T) {
+	hops := tracerouteHops([]string{"!00000001", "!00000002", "!00000003"}, []int32{22, tracerouteUnknownSNR}, nil)
+	if len(hops) != 3 {
+		t.Fatalf("unexpected hop count: expected 3, got %d", len(hops))
+	}
+	if hops[0].IsLink || hops[0].HasSNR {
+		t.Fatalf("expected the first hop to have no link, got %+v", hops[0])
+	}
+	if !hops[1].IsLink || !hops[1].HasSNR || hops[1].SNR != 5.5 {
+		t.Fatalf("expected the second hop to have 5.5 dB SNR, got %+v", hops[1])
+	}
+	if !hops[2].IsLink || hops[2].HasSNR {
+		t.Fatalf("expected the last hop to have an unknown SNR, got %+v", hops[2])
+	}
+	if hops[2].Label != "!00000003" {
+		t.Fatalf("unexpected label: expected %q, got %q", "!00000003", hops[2].Label)
+	}
+}
+
+func TestTracerouteHopsMismatchedSignals(t *testing.T) {
+	hops := tracerouteHops([]string{"!00000001", "!00000002"}, []int32{1, 2, 3}, nil)
+	if hops[1].HasSNR {
+		t.Fatalf("expected mismatched signals to be unknown, got %+v", hops[1])
+	}
+}
+
+func TestTracerouteSNRImportance(t *testing.T) {
+	tests := []struct {
+		hop  tracerouteHop
+		want widget.Importance
+	}{
+		{hop: tracerouteHop{}, want: widget.LowImportance},
+		{hop: tracerouteHop{SNR: 5, HasSNR: true}, want: widget.SuccessImportance},
+		{hop: tracerouteHop{SNR: -10, HasSNR: true}, want: widget.WarningImportance},
+		{hop: tracerouteHop{SNR: -20, HasSNR: true}, want: widget.DangerImportance},
+	}
+
+	for _, tc := range tests {
+		if got := tracerouteSNRImportance(tc.hop); got != tc.want {
+			t.Fatalf("unexpected importance for %+v: expected %v, got %v", tc.hop, tc.want, got)
+		}
+	}
+}
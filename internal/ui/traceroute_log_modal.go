@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+func handleNodeTracerouteLogAction(window fyne.Window, dep RuntimeDependencies, node domain.Node) {
+	if dep.Actions.NodeOverview == nil {
+		showErrorModal(dep, fmt.Errorf("node overview actions are unavailable"))
+
+		return
+	}
+	nodeID := strings.TrimSpace(node.NodeID)
+	if nodeID == "" {
+		return
+	}
+	if window == nil {
+		window = currentRuntimeWindow(dep)
+	}
+	if window == nil {
+		return
+	}
+
+	showTracerouteLogModal(window, dep, node)
+}
+
+func showTracerouteLogModal(window fyne.Window, dep RuntimeDependencies, node domain.Node) {
+	loading := widget.NewLabel(i18n.T("Loading traceroute history..."))
+	body := container.NewStack(loading)
+	closeButton := widget.NewButton(i18n.T("Close"), nil)
+	content := container.NewBorder(nil, closeButton, nil, nil, body)
+	modal := widget.NewModalPopUp(content, window.Canvas())
+	closeButton.OnTapped = modal.Hide
+	modal.Resize(fyne.NewSize(1040, 560))
+	modal.Show()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		rows, err := dep.Actions.NodeOverview.ListTracerouteHistory(ctx, strings.TrimSpace(node.NodeID), 0)
+		fyne.Do(func() {
+			if err != nil {
+				modal.Hide()
+				showErrorModal(dep, fmt.Errorf("load traceroute history: %w", err))
+
+				return
+			}
+			body.Objects = []fyne.CanvasObject{newTracerouteLogTable(rows, resolveNodeDisplayName(dep.Data.NodeStore))}
+			body.Refresh()
+		})
+	}()
+}
+
+// newTracerouteLogTable lists traceroutes newest first, as the repository returns them.
+func newTracerouteLogTable(items []domain.TracerouteRecord, nodeNameByID func(string) string) fyne.CanvasObject {
+	headers := []string{
+		i18n.T("Started at"),
+		i18n.T("Status"),
+		i18n.T("Hops"),
+		i18n.T("Route toward"),
+		i18n.T("Route back"),
+		i18n.T("Route"),
+		i18n.T("Duration"),
+	}
+	rows := make([][]string, 0, len(items))
+	for i, item := range items {
+		rows = append(rows, tracerouteLogRow(item, previousCompletedTraceroute(items[i+1:]), nodeNameByID))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, []string{
+			i18n.T("No traceroutes yet"),
+			"", "", "", "", "", "",
+		})
+	}
+
+	table := widget.NewTable(
+		func() (int, int) {
+			return len(rows) + 1, len(headers)
+		},
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Wrapping = fyne.TextWrapWord
+
+			return label
+		},
+		func(id widget.TableCellID, object fyne.CanvasObject) {
+			label, ok := object.(*widget.Label)
+			if !ok {
+				return
+			}
+			if id.Row == 0 {
+				label.SetText(headers[id.Col])
+				label.TextStyle = fyne.TextStyle{Bold: true}
+
+				return
+			}
+			label.SetText(rows[id.Row-1][id.Col])
+			label.TextStyle = fyne.TextStyle{}
+		},
+	)
+	table.SetColumnWidth(0, 170)
+	table.SetColumnWidth(1, 100)
+	table.SetColumnWidth(2, 60)
+	table.SetColumnWidth(3, 280)
+	table.SetColumnWidth(4, 280)
+	table.SetColumnWidth(5, 90)
+	table.SetColumnWidth(6, 90)
+	for row := range rows {
+		table.SetRowHeight(row+1, tracerouteLogRowHeight(rows[row]))
+	}
+
+	return container.NewBorder(
+		container.NewVBox(
+			widget.NewLabelWithStyle(i18n.T("Traceroute log"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			widget.NewSeparator(),
+		),
+		nil,
+		nil,
+		nil,
+		container.NewVScroll(table),
+	)
+}
+
+func tracerouteLogRow(item, previous domain.TracerouteRecord, nodeNameByID func(string) string) []string {
+	return []string{
+		telemetryLogTime(item.StartedAt),
+		tracerouteStatusText(busmsg.TracerouteUpdate{Status: item.Status}),
+		tracerouteLogHops(item.ForwardRoute),
+		tracerouteLogRoute(item.ForwardRoute, item.ForwardSNR, nodeNameByID),
+		tracerouteLogRoute(item.ReturnRoute, item.ReturnSNR, nodeNameByID),
+		tracerouteLogRouteChange(item, previous),
+		tracerouteLogDuration(item.DurationMS),
+	}
+}
+
+// previousCompletedTraceroute returns the newest completed traceroute of older items.
+func previousCompletedTraceroute(older []domain.TracerouteRecord) domain.TracerouteRecord {
+	for _, item := range older {
+		if item.Status == busmsg.TracerouteStatusCompleted && len(item.ForwardRoute) > 0 {
+			return item
+		}
+	}
+
+	return domain.TracerouteRecord{}
+}
+
+// tracerouteLogRouteChange compares the forward route with the previous completed run.
+func tracerouteLogRouteChange(item, previous domain.TracerouteRecord) string {
+	if item.Status != busmsg.TracerouteStatusCompleted || len(item.ForwardRoute) == 0 || len(previous.ForwardRoute) == 0 {
+		return ""
+	}
+	if slices.Equal(item.ForwardRoute, previous.ForwardRoute) {
+		return i18n.T("Same")
+	}
+
+	return i18n.T("Changed")
+}
+
+func tracerouteLogHops(route []string) string {
+	if len(route) < 2 {
+		return ""
+	}
+
+	return fmt.Sprintf("%d", len(route)-1)
+}
+
+// tracerouteLogRoute renders a route on one line. The SNR of each hop follows the
+// node that received it.
+func tracerouteLogRoute(nodeIDs []string, signals []int32, nodeNameByID func(string) string) string {
+	if len(nodeIDs) == 0 {
+		return ""
+	}
+	known := len(signals) == len(nodeIDs)-1
+
+	var b strings.Builder
+	b.WriteString(displaySender(nodeIDs[0], nodeNameByID))
+	for i := 1; i < len(nodeIDs); i++ {
+		signal := int32(tracerouteUnknownSNR)
+		if known {
+			signal = signals[i-1]
+		}
+		b.WriteString(" → ")
+		b.WriteString(displaySender(nodeIDs[i], nodeNameByID))
+		b.WriteString(" (" + tracerouteLogSNR(signal) + ")")
+	}
+
+	return b.String()
+}
+
+func tracerouteLogSNR(raw int32) string {
+	if raw == tracerouteUnknownSNR {
+		return "?"
+	}
+
+	return fmt.Sprintf("%.2f dB", float64(raw)/4)
+}
+
+func tracerouteLogDuration(durationMS int64) string {
+	if durationMS <= 0 {
+		return ""
+	}
+
+	return (time.Duration(durationMS) * time.Millisecond).Round(100 * time.Millisecond).String()
+}
+
+// tracerouteLogRowHeight grows rows with long routes so wrapped text stays readable.
+func tracerouteLogRowHeight(row []string) float32 {
+	const charsPerLine = 36
+	lines := 1
+	for _, value := range row[3:5] {
+		if n := (len([]rune(value)) + charsPerLine - 1) / charsPerLine; n > lines {
+			lines = n
+		}
+	}
+
+	return float32(lines) * 24
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+)
+
+func TestTracerouteLogRoute(t *testing.T) {
+	names := func(nodeID string) string {
+		if nodeID == "!00000010" {
+			return "Relay"
+		}
+
+		return ""
+	}
+	tests := []struct {
+		name    string
+		route   []string
+		signals []int32
+		want    string
+	}{
+		{name: "empty", want: ""},
+		{
+			name:    "with signals",
+			route:   []string{"!00000001", "!00000010", "!00000042"},
+			signals: []int32{25, -8},
+			want:    "!00000001 → Relay (6.25 dB) → !00000042 (-2.00 dB)",
+		},
+		{
+			name:    "missing signals",
+			route:   []string{"!00000001", "!00000042"},
+			signals: nil,
+			want:    "!00000001 → !00000042 (?)",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tracerouteLogRoute(tt.route, tt.signals, names); got != tt.want {
+			t.Fatalf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestTracerouteLogRowComparesWithPreviousCompletedRun(t *testing.T) {
+	started := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	items := []domain.TracerouteRecord{
+		{Status: busmsg.TracerouteStatusCompleted, StartedAt: started.Add(2 * time.Hour), ForwardRoute: []string{"!1", "!3", "!9"}, DurationMS: 2340},
+		{Status: busmsg.TracerouteStatusTimedOut, StartedAt: started.Add(time.Hour)},
+		{Status: busmsg.TracerouteStatusCompleted, StartedAt: started, ForwardRoute: []string{"!1", "!2", "!9"}},
+		{Status: busmsg.TracerouteStatusCompleted, StartedAt: started.Add(-time.Hour), ForwardRoute: []string{"!1", "!2", "!9"}},
+	}
+
+	newest := tracerouteLogRow(items[0], previousCompletedTraceroute(items[1:]), nil)
+	if newest[1] != "Complete" || newest[2] != "2" || newest[5] != "Changed" || newest[6] != "2.3s" {
+		t.Fatalf("unexpected newest row: %q", newest)
+	}
+	timedOut := tracerouteLogRow(items[1], previousCompletedTraceroute(items[2:]), nil)
+	if timedOut[1] != "Timed out" || timedOut[2] != "" || timedOut[5] != "" || timedOut[6] != "" {
+		t.Fatalf("unexpected timed out row: %q", timedOut)
+	}
+	same := tracerouteLogRow(items[2], previousCompletedTraceroute(items[3:]), nil)
+	if same[5] != "Same" {
+		t.Fatalf("expected unchanged route, got %q", same[5])
+	}
+	oldest := tracerouteLogRow(items[3], previousCompletedTraceroute(nil), nil)
+	if oldest[5] != "" {
+		t.Fatalf("expected no comparison for oldest run, got %q", oldest[5])
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/skobkin/meshgo/internal/app"
 	"github.com/skobkin/meshgo/internal/bus"
 	"github.com/skobkin/meshgo/internal/domain"
+	"github.com/skobkin/meshgo/internal/i18n"
 	"github.com/skobkin/meshgo/internal/radio/busmsg"
 )
 
@@ -25,6 +26,7 @@ func showTracerouteModal(
 	nodeStore *domain.NodeStore,
 	targetNode domain.Node,
 	initial busmsg.TracerouteUpdate,
+	restart func() (busmsg.TracerouteUpdate, error),
 ) {
 	if window == nil {
 		return
@@ -44,18 +46,14 @@ func showTracerouteModal(
 	errorLabel.Wrapping = fyne.TextWrapWord
 	errorLabel.Hide()
 
-	forwardHeader := widget.NewLabelWithStyle("Route traced toward destination:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
-	forwardPath := widget.NewLabel("")
-	forwardPath.TextStyle = fyne.TextStyle{Monospace: true}
-	forwardPath.Wrapping = fyne.TextWrapWord
+	forwardHeader := widget.NewLabelWithStyle(i18n.T("Route traced toward destination:"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	forwardPath := container.NewVBox()
 
-	returnHeader := widget.NewLabelWithStyle("Route traced back to us:", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
-	returnPath := widget.NewLabel("")
-	returnPath.TextStyle = fyne.TextStyle{Monospace: true}
-	returnPath.Wrapping = fyne.TextWrapWord
+	returnHeader := widget.NewLabelWithStyle(i18n.T("Route traced back to us:"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	returnPath := container.NewVBox()
 
 	var copyText string
-	copyButton := widget.NewButton("Copy", func() {
+	copyButton := widget.NewButton(i18n.T("Copy"), func() {
 		currentApp := fyne.CurrentApp()
 		if currentApp == nil || currentApp.Clipboard() == nil {
 			return
@@ -64,18 +62,21 @@ func showTracerouteModal(
 	})
 	copyButton.Disable()
 
+	rerunButton := widget.NewButton(i18n.T("Run again"), nil)
+	rerunButton.Disable()
+
 	titleRow := container.NewBorder(nil, nil, title, elapsed, status)
-	forwardRow := container.NewHBox(forwardHeader, layout.NewSpacer(), copyButton)
+	routes := container.NewGridWithColumns(
+		2,
+		container.NewVBox(forwardHeader, forwardPath),
+		container.NewVBox(returnHeader, returnPath),
+	)
 
 	content := container.NewVBox(
 		titleRow,
 		progress,
 		errorLabel,
-		forwardRow,
-		forwardPath,
-		layout.NewSpacer(),
-		returnHeader,
-		returnPath,
+		routes,
 	)
 	scroll := container.NewVScroll(content)
 	scroll.SetMinSize(fyne.NewSize(680, 420))
@@ -85,6 +86,7 @@ func showTracerouteModal(
 	var stopOnce sync.Once
 	var sub bus.Subscription
 	current := initial
+	requestID := initial.RequestID
 
 	stop := func() {
 		stopOnce.Do(func() {
@@ -98,13 +100,13 @@ func showTracerouteModal(
 		})
 	}
 
-	closeButton := widget.NewButton("Close", stop)
-	content.Add(closeButton)
+	closeButton := widget.NewButton(i18n.T("Close"), stop)
+	content.Add(container.NewHBox(copyButton, rerunButton, layout.NewSpacer(), closeButton))
 
 	refresh := func(now time.Time) {
 		status.SetText(tracerouteStatusText(current))
 		elapsedDuration := tracerouteElapsedDuration(current, now)
-		elapsed.SetText(fmt.Sprintf("Elapsed: %.1f s", float64(elapsedDuration.Milliseconds())/1000))
+		elapsed.SetText(i18n.Tf("Elapsed: %.1f s", float64(elapsedDuration.Milliseconds())/1000))
 
 		progress.SetValue(tracerouteProgressValue(current, elapsedDuration))
 
@@ -116,17 +118,38 @@ func showTracerouteModal(
 			errorLabel.Hide()
 		}
 
-		forwardText := formatTraceroutePath(current.ForwardRoute, current.ForwardSNR, nodeStore)
-		returnText := formatTraceroutePath(current.ReturnRoute, current.ReturnSNR, nodeStore)
-		forwardPath.SetText(forwardText)
-		returnPath.SetText(returnText)
+		setTracerouteHopChain(forwardPath, tracerouteHops(current.ForwardRoute, current.ForwardSNR, nodeStore))
+		setTracerouteHopChain(returnPath, tracerouteHops(current.ReturnRoute, current.ReturnSNR, nodeStore))
 
-		copyText = formatTracerouteResults(forwardText, returnText)
+		copyText = formatTracerouteResults(
+			formatTraceroutePath(current.ForwardRoute, current.ForwardSNR, nodeStore),
+			formatTraceroutePath(current.ReturnRoute, current.ReturnSNR, nodeStore),
+		)
 		if isTracerouteCopyAvailable(current.Status) {
 			copyButton.Enable()
 		} else {
 			copyButton.Disable()
 		}
+		if restart != nil && !isTracerouteRunning(current.Status) {
+			rerunButton.Enable()
+		} else {
+			rerunButton.Disable()
+		}
+	}
+
+	// Running again follows the new request in the same dialog.
+	rerunButton.OnTapped = func() {
+		rerunButton.Disable()
+		update, err := restart()
+		if err != nil {
+			tracerouteShowErrorDialog(err, window)
+			refresh(time.Now())
+
+			return
+		}
+		current = update
+		requestID = update.RequestID
+		refresh(time.Now())
 	}
 
 	refresh(time.Now())
@@ -143,10 +166,13 @@ func showTracerouteModal(
 						return
 					}
 					update, ok := raw.(busmsg.TracerouteUpdate)
-					if !ok || update.RequestID != initial.RequestID {
+					if !ok {
 						continue
 					}
 					fyne.Do(func() {
+						if update.RequestID != requestID {
+							return
+						}
 						current = update
 						refresh(time.Now())
 					})
@@ -182,17 +208,17 @@ func showTracerouteModal(
 func tracerouteStatusText(update busmsg.TracerouteUpdate) string {
 	switch update.Status {
 	case busmsg.TracerouteStatusStarted:
-		return "Started"
+		return i18n.T("Started")
 	case busmsg.TracerouteStatusProgress:
-		return "Waiting"
+		return i18n.T("Waiting")
 	case busmsg.TracerouteStatusCompleted:
-		return "Complete"
+		return i18n.T("Complete")
 	case busmsg.TracerouteStatusFailed:
-		return "Failed"
+		return i18n.T("Failed")
 	case busmsg.TracerouteStatusTimedOut:
-		return "Timed out"
+		return i18n.T("Timed out")
 	default:
-		return "Update"
+		return i18n.T("Update")
 	}
 }
 
@@ -235,10 +261,10 @@ func isTracerouteCopyAvailable(status busmsg.TracerouteStatus) bool {
 
 func formatTracerouteResults(forwardPath, returnPath string) string {
 	lines := []string{
-		"Route traced toward destination:",
+		i18n.T("Route traced toward destination:"),
 		forwardPath,
 		"",
-		"Route traced back to us:",
+		i18n.T("Route traced back to us:"),
 		returnPath,
 	}
 
@@ -247,7 +273,7 @@ func formatTracerouteResults(forwardPath, returnPath string) string {
 
 func formatTraceroutePath(nodeIDs []string, signals []int32, nodeStore *domain.NodeStore) string {
 	if len(nodeIDs) == 0 {
-		return "Waiting for route data..."
+		return i18n.T("Waiting for route data...")
 	}
 	effectiveSignals := signals
 	if len(signals) != len(nodeIDs)-1 {
@@ -278,7 +304,7 @@ func tracerouteHopSignalLabel(raw int32) string {
 func tracerouteNodeDisplay(nodeID string, nodeStore *domain.NodeStore) string {
 	id := strings.TrimSpace(nodeID)
 	if id == "" {
-		return "unknown"
+		return i18n.T("unknown")
 	}
 	if nodeStore == nil {
 		return id
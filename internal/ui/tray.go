@@ -1,35 +1,172 @@
 package ui
 
 import (
+	"sync"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/systray"
 
+	"github.com/skobkin/meshgo/internal/i18n"
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
 	"github.com/skobkin/meshgo/internal/resources"
 )
 
-func configureSystemTray(fyApp fyne.App, window fyne.Window, initialVariant fyne.ThemeVariant, quit func()) func(fyne.ThemeVariant) {
-	setTrayIcon := func(_ fyne.ThemeVariant) {}
+// trayIcon shows the connection state and the unread count on the tray icon and in its
+// tooltip.
+type trayIcon struct {
+	set        func(fyne.Resource)
+	setTooltip func(string)
+
+	mu      sync.Mutex
+	variant fyne.ThemeVariant
+	state   resources.TrayState
+	unread  int
+	shown   fyne.Resource
+	tooltip string
+}
+
+func newTrayIcon(set func(fyne.Resource), setTooltip func(string), variant fyne.ThemeVariant) *trayIcon {
+	icon := &trayIcon{set: set, setTooltip: setTooltip, variant: variant, state: resources.TrayStateDisconnected}
+	icon.apply()
+
+	return icon
+}
+
+// SetVariant switches the icon to the variant for the theme.
+func (i *trayIcon) SetVariant(variant fyne.ThemeVariant) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.variant = variant
+	i.apply()
+}
+
+func (i *trayIcon) SetConnectionStatus(status busmsg.ConnectionStatus) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.state = trayStateForStatus(status)
+	i.apply()
+}
+
+// SetUnread shows the total unread count.
+func (i *trayIcon) SetUnread(count int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.unread = max(count, 0)
+	i.apply()
+}
+
+// Retranslate sets the tooltip again in the current language.
+func (i *trayIcon) Retranslate() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.tooltip = ""
+	i.apply()
+}
 
+// apply sets the icon and the tooltip when they changed, since some trays flicker on
+// every update.
+func (i *trayIcon) apply() {
+	if i.set != nil {
+		if icon := resources.TrayStatusIconResource(i.variant, i.state, i.unread); icon != i.shown {
+			i.shown = icon
+			i.set(icon)
+		}
+	}
+	if i.setTooltip != nil {
+		if tooltip := trayTooltip(i.state, i.unread); tooltip != i.tooltip {
+			i.tooltip = tooltip
+			i.setTooltip(tooltip)
+		}
+	}
+}
+
+func trayTooltip(state resources.TrayState, unread int) string {
+	var tooltip string
+	switch state {
+	case resources.TrayStateConnected:
+		tooltip = i18n.T("meshgo: connected")
+	case resources.TrayStateConnecting:
+		tooltip = i18n.T("meshgo: connecting")
+	default:
+		tooltip = i18n.T("meshgo: disconnected")
+	}
+	if unread > 0 {
+		tooltip += ", " + i18n.Tf("%d unread", unread)
+	}
+
+	return tooltip
+}
+
+func trayStateForStatus(status busmsg.ConnectionStatus) resources.TrayState {
+	switch status.State {
+	case busmsg.ConnectionStateConnected:
+		return resources.TrayStateConnected
+	case busmsg.ConnectionStateConnecting, busmsg.ConnectionStateReconnecting:
+		return resources.TrayStateConnecting
+	default:
+		return resources.TrayStateDisconnected
+	}
+}
+
+func configureSystemTray(
+	fyApp fyne.App,
+	window fyne.Window,
+	initialVariant fyne.ThemeVariant,
+	quickConnect func(),
+	mute *notificationMute,
+	quit func(),
+) *trayIcon {
 	desk, ok := fyApp.(desktop.App)
 	if !ok {
-		return setTrayIcon
+		return newTrayIcon(nil, nil, initialVariant)
 	}
 
-	setTrayIcon = func(variant fyne.ThemeVariant) {
-		desk.SetSystemTrayIcon(resources.TrayIconResource(variant))
+	// Not every tray shows tooltips, and the tooltip may be lost when set before the
+	// tray starts, so it only repeats what the icon already shows.
+	icon := newTrayIcon(desk.SetSystemTrayIcon, systray.SetTooltip, initialVariant)
+	setTrayMenu := func() {
+		items := []*fyne.MenuItem{
+			fyne.NewMenuItem(i18n.T("Show"), func() {
+				appLogger.Debug("system tray show action invoked")
+				window.Show()
+				window.RequestFocus()
+			}),
+		}
+		if quickConnect != nil {
+			items = append(items, fyne.NewMenuItem(i18n.T("Quick connect…"), func() {
+				appLogger.Debug("system tray quick connect action invoked")
+				window.Show()
+				window.RequestFocus()
+				quickConnect()
+			}))
+		}
+		if mute != nil {
+			muteItem := fyne.NewMenuItem(doNotDisturbTrayLabel(mute), nil)
+			muteItem.ChildMenu = fyne.NewMenu("", doNotDisturbMenuItems(mute)...)
+			muteItem.Checked = mute.Muted()
+			items = append(items, muteItem)
+		}
+		items = append(items,
+			fyne.NewMenuItem(i18n.T("Move window to screen"), func() {
+				appLogger.Debug("system tray recover window action invoked")
+				recoverWindow(window)
+			}),
+			fyne.NewMenuItem(i18n.T("Quit"), func() {
+				appLogger.Debug("system tray quit action invoked")
+				quit()
+			}),
+		)
+		desk.SetSystemTrayMenu(fyne.NewMenu("meshgo", items...))
 	}
-	setTrayIcon(initialVariant)
-	desk.SetSystemTrayMenu(fyne.NewMenu("meshgo",
-		fyne.NewMenuItem("Show", func() {
-			appLogger.Debug("system tray show action invoked")
-			window.Show()
-			window.RequestFocus()
-		}),
-		fyne.NewMenuItem("Quit", func() {
-			appLogger.Debug("system tray quit action invoked")
-			quit()
-		}),
-	))
-
-	return setTrayIcon
+	setTrayMenu()
+	i18n.OnChange(func(string) {
+		fyne.Do(setTrayMenu)
+		icon.Retranslate()
+	})
+	mute.OnChange(func(bool) {
+		fyne.Do(setTrayMenu)
+	})
+
+	return icon
 }
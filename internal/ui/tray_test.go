@@ -1,10 +1,16 @@
 package ui
 
 import (
+	"strings"
 	"testing"
+	"time"
 
+	"fyne.io/fyne/v2"
 	fynetest "fyne.io/fyne/v2/test"
 	"fyne.io/fyne/v2/theme"
+
+	"github.com/skobkin/meshgo/internal/radio/busmsg"
+	"github.com/skobkin/meshgo/internal/resources"
 )
 
 func TestConfigureSystemTrayDesktopApp(t *testing.T) {
@@ -13,13 +19,15 @@ func TestConfigureSystemTrayDesktopApp(t *testing.T) {
 
 	app := &trayAppSpy{App: base}
 	window := &windowSpy{Window: base.NewWindow("tray")}
-	var quitCalls int
+	var quickConnectCalls, quitCalls int
 
-	setTrayIcon := configureSystemTray(app, window, theme.VariantLight, func() {
+	tray := configureSystemTray(app, window, theme.VariantLight, func() {
+		quickConnectCalls++
+	}, nil, func() {
 		quitCalls++
 	})
-	if setTrayIcon == nil {
-		t.Fatalf("expected tray icon setter")
+	if tray == nil {
+		t.Fatalf("expected tray icon")
 	}
 	if app.trayIcon == nil {
 		t.Fatalf("expected initial tray icon to be set")
@@ -27,11 +35,11 @@ func TestConfigureSystemTrayDesktopApp(t *testing.T) {
 	if app.trayMenu == nil {
 		t.Fatalf("expected tray menu to be configured")
 	}
-	if len(app.trayMenu.Items) != 2 {
-		t.Fatalf("expected two tray menu items, got %d", len(app.trayMenu.Items))
+	if len(app.trayMenu.Items) != 4 {
+		t.Fatalf("expected four tray menu items, got %d", len(app.trayMenu.Items))
 	}
 
-	setTrayIcon(theme.VariantDark)
+	tray.SetVariant(theme.VariantDark)
 	if app.trayIcon == nil {
 		t.Fatalf("expected tray icon after theme change")
 	}
@@ -45,6 +53,14 @@ func TestConfigureSystemTrayDesktopApp(t *testing.T) {
 	}
 
 	app.trayMenu.Items[1].Action()
+	if quickConnectCalls != 1 {
+		t.Fatalf("expected quick connect action callback once, got %d", quickConnectCalls)
+	}
+	if window.showCalls != 2 {
+		t.Fatalf("expected quick connect action to show window, got %d show calls", window.showCalls)
+	}
+
+	app.trayMenu.Items[3].Action()
 	if quitCalls != 1 {
 		t.Fatalf("expected quit action callback once, got %d", quitCalls)
 	}
@@ -56,10 +72,102 @@ func TestConfigureSystemTrayNonDesktopAppReturnsNoopSetter(t *testing.T) {
 
 	app := &basicAppWrapper{App: base}
 	window := base.NewWindow("tray")
-	setTrayIcon := configureSystemTray(app, window, theme.VariantLight, nil)
-	if setTrayIcon == nil {
-		t.Fatalf("expected non-nil setter for non-desktop app")
+	tray := configureSystemTray(app, window, theme.VariantLight, nil, nil, nil)
+	if tray == nil {
+		t.Fatalf("expected non-nil tray icon for non-desktop app")
 	}
 
-	setTrayIcon(theme.VariantDark)
+	tray.SetVariant(theme.VariantDark)
+	tray.SetConnectionStatus(busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnected})
+}
+
+func TestTrayIconShowsConnectionStateAndUnread(t *testing.T) {
+	var (
+		shown    []fyne.Resource
+		tooltips []string
+	)
+	tray := newTrayIcon(
+		func(icon fyne.Resource) { shown = append(shown, icon) },
+		func(tooltip string) { tooltips = append(tooltips, tooltip) },
+		theme.VariantDark,
+	)
+
+	tray.SetConnectionStatus(busmsg.ConnectionStatus{State: busmsg.ConnectionStateReconnecting})
+	tray.SetConnectionStatus(busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnecting})
+	tray.SetConnectionStatus(busmsg.ConnectionStatus{State: busmsg.ConnectionStateConnected})
+	tray.SetUnread(3)
+	tray.SetUnread(3)
+	tray.SetUnread(12)
+
+	want := []fyne.Resource{
+		resources.TrayStatusIconResource(theme.VariantDark, resources.TrayStateDisconnected, 0),
+		resources.TrayStatusIconResource(theme.VariantDark, resources.TrayStateConnecting, 0),
+		resources.TrayStatusIconResource(theme.VariantDark, resources.TrayStateConnected, 0),
+		resources.TrayStatusIconResource(theme.VariantDark, resources.TrayStateConnected, 3),
+		resources.TrayStatusIconResource(theme.VariantDark, resources.TrayStateConnected, 12),
+	}
+	if len(shown) != len(want) {
+		t.Fatalf("expected %d icon updates, got %d", len(want), len(shown))
+	}
+	for i := range want {
+		if shown[i] != want[i] {
+			t.Fatalf("update %d: expected %s, got %s", i, want[i].Name(), shown[i].Name())
+		}
+	}
+	wantTooltips := []string{
+		"meshgo: disconnected",
+		"meshgo: connecting",
+		"meshgo: connected",
+		"meshgo: connected, 3 unread",
+		"meshgo: connected, 12 unread",
+	}
+	if strings.Join(tooltips, "|") != strings.Join(wantTooltips, "|") {
+		t.Fatalf("expected tooltips %q, got %q", wantTooltips, tooltips)
+	}
+}
+
+func TestConfigureSystemTrayDoNotDisturbFollowsToggle(t *testing.T) {
+	base := fynetest.NewApp()
+	t.Cleanup(base.Quit)
+
+	app := &trayAppSpy{App: base}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local)
+	var saved []time.Time
+	mute := &notificationMute{
+		save: func(muted bool, until time.Time) error {
+			if muted {
+				saved = append(saved, until)
+			}
+
+			return nil
+		},
+		now: func() time.Time { return now },
+	}
+	configureSystemTray(app, base.NewWindow("tray"), theme.VariantLight, nil, mute, func() {})
+	if len(app.trayMenu.Items) != 4 {
+		t.Fatalf("expected four tray menu items, got %d", len(app.trayMenu.Items))
+	}
+	dnd := app.trayMenu.Items[1]
+	if dnd.Checked || dnd.Label != "Do not disturb" || len(dnd.ChildMenu.Items) != 4 {
+		t.Fatalf("expected an unchecked do not disturb menu, got %q with %d items", dnd.Label, len(dnd.ChildMenu.Items))
+	}
+
+	dnd.ChildMenu.Items[1].Action()
+	until := now.Add(time.Hour)
+	if len(saved) != 1 || !saved[0].Equal(until) {
+		t.Fatalf("expected a one hour mute to be saved, got %v", saved)
+	}
+	dnd = app.trayMenu.Items[1]
+	if want := "Do not disturb until " + currentDisplayFormatter().Time(until); !dnd.Checked || dnd.Label != want {
+		t.Fatalf("expected the rebuilt menu to show %q, got %q", want, dnd.Label)
+	}
+	turnOff := dnd.ChildMenu.Items[len(dnd.ChildMenu.Items)-1]
+	if turnOff.Label != "Turn off do not disturb" {
+		t.Fatalf("expected the last item to turn do not disturb off, got %q", turnOff.Label)
+	}
+
+	turnOff.Action()
+	if mute.Muted() || app.trayMenu.Items[1].Checked {
+		t.Fatalf("expected do not disturb to be off")
+	}
 }
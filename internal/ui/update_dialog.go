@@ -15,6 +15,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	meshapp "github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/i18n"
 	"github.com/skobkin/meshgo/internal/resources"
 	"github.com/skobkin/meshgo/internal/ui/widgets"
 )
@@ -36,17 +37,17 @@ func showUpdateDialog(
 
 	currentVersion := strings.TrimSpace(snapshot.CurrentVersion)
 	if currentVersion == "" {
-		currentVersion = "unknown"
+		currentVersion = i18n.T("unknown")
 	}
 
 	latestVersion := strings.TrimSpace(snapshot.Latest.Version)
 	if latestVersion == "" {
-		latestVersion = "unknown"
+		latestVersion = i18n.T("unknown")
 	}
 
 	currentLabel := newUpdateVersionText(currentVersion, variant)
 	latestLabel := newUpdateVersionText(latestVersion, variant)
-	dialogTitle := widget.NewLabelWithStyle("Update", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	dialogTitle := widget.NewLabelWithStyle(i18n.T("Update"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
 	var updateDialog *widget.PopUp
 	dialogCloseButton := widget.NewButton("X", func() {
 		updateDialogLogger.Debug("closing update dialog")
@@ -71,7 +72,7 @@ func showUpdateDialog(
 	changelogScroll.SetMinSize(fyne.NewSize(0, 320))
 
 	downloadURL := strings.TrimSpace(snapshot.Latest.HTMLURL)
-	downloadButton := widget.NewButton("Download", func() {
+	downloadButton := widget.NewButton(i18n.T("Download"), func() {
 		updateDialogLogger.Info(
 			"download button clicked",
 			"url", downloadURL,
@@ -128,18 +129,18 @@ func newUpdateVersionText(version string, variant fyne.ThemeVariant) *canvas.Tex
 
 func buildUpdateChangelogText(releases []meshapp.ReleaseInfo) string {
 	if len(releases) == 0 {
-		return "No release notes available."
+		return i18n.T("No release notes available.")
 	}
 
 	sections := make([]string, 0, len(releases))
 	for _, release := range releases {
 		version := strings.TrimSpace(release.Version)
 		if version == "" {
-			version = "unknown"
+			version = i18n.T("unknown")
 		}
 		body := strings.TrimSpace(release.Body)
 		if body == "" {
-			body = "No changelog provided."
+			body = i18n.T("No changelog provided.")
 		} else {
 			body = stripLeadingCommitHashesFromMarkdown(body)
 		}
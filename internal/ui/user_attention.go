@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/platform"
+)
+
+var attentionLogger = slog.With("component", "ui.attention")
+
+const (
+	// userIdleThreshold is how long without keyboard or mouse input makes the user away
+	// even though the window is still focused.
+	userIdleThreshold = 2 * time.Minute
+	// userIdlePollInterval bounds how late a return from idle is noticed.
+	userIdlePollInterval = 5 * time.Second
+)
+
+// userAttention tracks whether the user is looking at the app: its window is in the
+// foreground and the system is not idle.
+type userAttention struct {
+	idleTime      func() (time.Duration, error)
+	idleThreshold time.Duration
+	pollInterval  time.Duration
+
+	foreground atomic.Bool
+	idle       atomic.Bool
+
+	mu         sync.Mutex
+	onRegained []func()
+}
+
+func newUserAttention(foreground bool, idleTime func() (time.Duration, error)) *userAttention {
+	a := &userAttention{
+		idleTime:      idleTime,
+		idleThreshold: userIdleThreshold,
+		pollInterval:  userIdlePollInterval,
+	}
+	a.foreground.Store(foreground)
+
+	return a
+}
+
+// Foreground reports whether the app window is in the foreground.
+func (a *userAttention) Foreground() bool {
+	return a.foreground.Load()
+}
+
+// Active reports whether the user is looking at the app right now.
+func (a *userAttention) Active() bool {
+	return a.foreground.Load() && !a.idle.Load()
+}
+
+// OnRegained registers fn to run whenever the user returns to the app. It may run on
+// any goroutine.
+func (a *userAttention) OnRegained(fn func()) {
+	if fn == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.onRegained = append(a.onRegained, fn)
+}
+
+func (a *userAttention) SetForeground(foreground bool) {
+	wasActive := a.Active()
+	a.foreground.Store(foreground)
+	if foreground {
+		// Focusing the window takes input, so an idle state seen earlier is stale.
+		a.idle.Store(false)
+	}
+	a.notifyIfRegained(wasActive)
+}
+
+func (a *userAttention) setIdle(idle bool) {
+	wasActive := a.Active()
+	a.idle.Store(idle)
+	a.notifyIfRegained(wasActive)
+}
+
+func (a *userAttention) notifyIfRegained(wasActive bool) {
+	if wasActive || !a.Active() {
+		return
+	}
+	a.mu.Lock()
+	listeners := append([]func(){}, a.onRegained...)
+	a.mu.Unlock()
+	for _, fn := range listeners {
+		fn()
+	}
+}
+
+// Start polls the system idle time while the window is in the foreground until ctx is
+// done. Without idle time support the user counts as present whenever the window is.
+func (a *userAttention) Start(ctx context.Context) {
+	if a.idleTime == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(a.pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !a.pollIdle() {
+					return
+				}
+			}
+		}
+	}()
+}
+
+// pollIdle refreshes the idle state and reports whether polling should go on.
+func (a *userAttention) pollIdle() bool {
+	if !a.foreground.Load() {
+		return true
+	}
+	idleFor, err := a.idleTime()
+	if err != nil {
+		a.setIdle(false)
+		if errors.Is(err, platform.ErrIdleTimeUnsupported) {
+			attentionLogger.Info("system idle time is unavailable, only window focus is tracked", "error", err)
+
+			return false
+		}
+		attentionLogger.Debug("read system idle time failed", "error", err)
+
+		return true
+	}
+	a.setIdle(idleFor >= a.idleThreshold)
+
+	return true
+}
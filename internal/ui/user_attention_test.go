@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/skobkin/meshgo/internal/platform"
+)
+
+func TestUserAttentionActive(t *testing.T) {
+	tests := []struct {
+		name       string
+		foreground bool
+		idleFor    time.Duration
+		idleErr    error
+		want       bool
+	}{
+		{name: "focused and present", foreground: true, idleFor: time.Second, want: true},
+		{name: "focused but idle", foreground: true, idleFor: userIdleThreshold, want: false},
+		{name: "in background", foreground: false, idleFor: time.Second, want: false},
+		{name: "idle time unsupported", foreground: true, idleErr: platform.ErrIdleTimeUnsupported, want: true},
+		{name: "idle time read failed", foreground: true, idleErr: errors.New("bus timeout"), want: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			attention := newUserAttention(tc.foreground, func() (time.Duration, error) {
+				return tc.idleFor, tc.idleErr
+			})
+			attention.pollIdle()
+			if got := attention.Active(); got != tc.want {
+				t.Fatalf("expected active=%v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestUserAttentionPollStopsWhenIdleTimeUnsupported(t *testing.T) {
+	attention := newUserAttention(true, func() (time.Duration, error) {
+		return 0, fmt.Errorf("%w: no session bus", platform.ErrIdleTimeUnsupported)
+	})
+	if attention.pollIdle() {
+		t.Fatalf("expected polling to stop without idle time support")
+	}
+}
+
+func TestUserAttentionOnRegainedRunsWhenUserReturns(t *testing.T) {
+	idleFor := time.Duration(0)
+	attention := newUserAttention(true, func() (time.Duration, error) {
+		return idleFor, nil
+	})
+	regained := 0
+	attention.OnRegained(func() { regained++ })
+
+	attention.pollIdle()
+	if regained != 0 {
+		t.Fatalf("expected no notification while the user stays active, got %d", regained)
+	}
+
+	idleFor = userIdleThreshold + time.Second
+	attention.pollIdle()
+	idleFor = time.Second
+	attention.pollIdle()
+	if regained != 1 {
+		t.Fatalf("expected one notification after returning from idle, got %d", regained)
+	}
+
+	attention.SetForeground(false)
+	attention.SetForeground(true)
+	if regained != 2 {
+		t.Fatalf("expected one notification after refocusing the window, got %d", regained)
+	}
+	attention.SetForeground(true)
+	if regained != 2 {
+		t.Fatalf("expected no notification when the window stays focused, got %d", regained)
+	}
+}
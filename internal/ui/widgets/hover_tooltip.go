@@ -79,6 +79,15 @@ func (w *TooltipWidget) setLabelBadge(text, tooltip string, tooltipContent fyne.
 	w.label.SetText(text)
 }
 
+// SetImportance sets the importance of the label, e.g. to draw it in the error colour.
+func (w *TooltipWidget) SetImportance(importance widget.Importance) {
+	if w.label == nil || w.label.Importance == importance {
+		return
+	}
+	w.label.Importance = importance
+	w.label.Refresh()
+}
+
 func (w *TooltipWidget) MouseIn(*desktop.MouseEvent) {
 	if w.manager == nil {
 		return
@@ -10,7 +10,10 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
-// IconNavButton is a navigation button with an icon and optional text label.
+var _ fyne.Focusable = (*IconNavButton)(nil)
+
+// IconNavButton is a navigation button with an icon and optional text label. It can be
+// focused with Tab and pressed with Space or Enter.
 type IconNavButton struct {
 	widget.DisableableWidget
 
@@ -20,6 +23,7 @@ type IconNavButton struct {
 	onTap    func()
 	selected bool
 	hovered  bool
+	focused  bool
 }
 
 const defaultIconNavButtonSize float32 = 48
@@ -109,6 +113,25 @@ func (b *IconNavButton) MouseOut() {
 	b.Refresh()
 }
 
+func (b *IconNavButton) FocusGained() {
+	b.focused = true
+	b.Refresh()
+}
+
+func (b *IconNavButton) FocusLost() {
+	b.focused = false
+	b.Refresh()
+}
+
+func (b *IconNavButton) TypedRune(_ rune) {}
+
+func (b *IconNavButton) TypedKey(event *fyne.KeyEvent) {
+	switch event.Name {
+	case fyne.KeySpace, fyne.KeyReturn, fyne.KeyEnter:
+		b.Tapped(nil)
+	}
+}
+
 func (b *IconNavButton) CreateRenderer() fyne.WidgetRenderer {
 	bg := canvas.NewRectangle(color.Transparent)
 	bg.CornerRadius = b.Theme().Size(theme.SizeNameInputRadius)
@@ -225,6 +248,12 @@ func (r *iconNavButtonRenderer) Refresh() {
 		r.background.FillColor = color.Transparent
 	}
 	r.background.CornerRadius = th.Size(theme.SizeNameInputRadius)
+	if r.button.focused {
+		r.background.StrokeColor = th.Color(theme.ColorNameFocus, v)
+		r.background.StrokeWidth = th.Size(theme.SizeNameInputBorder)
+	} else {
+		r.background.StrokeWidth = 0
+	}
 	r.background.Refresh()
 
 	icon := r.button.icon
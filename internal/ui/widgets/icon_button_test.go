@@ -3,6 +3,7 @@ package widgets
 import (
 	"testing"
 
+	"fyne.io/fyne/v2"
 	fynetest "fyne.io/fyne/v2/test"
 )
 
@@ -23,3 +24,23 @@ func TestIconNavButtonMinSizeWithText(t *testing.T) {
 		t.Fatalf("expected text button width not to shrink: base=%v withText=%v", base, withText)
 	}
 }
+
+func TestIconNavButtonKeyboardActivation(t *testing.T) {
+	app := fynetest.NewApp()
+	t.Cleanup(app.Quit)
+
+	taps := 0
+	button := NewIconNavButton(nil, func() { taps++ })
+	for _, key := range []fyne.KeyName{fyne.KeySpace, fyne.KeyReturn, fyne.KeyEnter, fyne.KeyTab} {
+		button.TypedKey(&fyne.KeyEvent{Name: key})
+	}
+	if taps != 3 {
+		t.Fatalf("expected Space, Return and Enter to press the button: expected 3, got %d", taps)
+	}
+
+	button.Disable()
+	button.TypedKey(&fyne.KeyEvent{Name: fyne.KeySpace})
+	if taps != 3 {
+		t.Fatalf("expected a disabled button to ignore keys, got %d taps", taps)
+	}
+}
@@ -4,7 +4,9 @@ import "fyne.io/fyne/v2"
 
 const chatRowWidthRatio float32 = 0.8
 
-// ChatRowLayout is a custom layout for aligning chat message rows.
+// ChatRowLayout is a custom layout for aligning chat message rows. The first object is
+// the message bubble; an optional visible second object is a full width header shown
+// above it, such as a day separator.
 type ChatRowLayout struct {
 	alignRight bool
 }
@@ -23,14 +25,21 @@ func (l *ChatRowLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
 		return
 	}
 
+	top := float32(0)
+	if header := chatRowHeader(objects); header != nil {
+		top = header.MinSize().Height
+		header.Move(fyne.NewPos(0, 0))
+		header.Resize(fyne.NewSize(size.Width, top))
+	}
+
 	row := objects[0]
-	rowSize := fyne.NewSize(chatRowWidth(size.Width, row.MinSize().Width), size.Height)
+	rowSize := fyne.NewSize(chatRowWidth(size.Width, row.MinSize().Width), size.Height-top)
 	x := float32(0)
 	if l.alignRight {
 		x = size.Width - rowSize.Width
 	}
 
-	row.Move(fyne.NewPos(x, 0))
+	row.Move(fyne.NewPos(x, top))
 	row.Resize(rowSize)
 }
 
@@ -39,7 +48,21 @@ func (l *ChatRowLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
 		return fyne.Size{}
 	}
 
-	return objects[0].MinSize()
+	size := objects[0].MinSize()
+	if header := chatRowHeader(objects); header != nil {
+		headerSize := header.MinSize()
+		size = fyne.NewSize(max(size.Width, headerSize.Width), size.Height+headerSize.Height)
+	}
+
+	return size
+}
+
+func chatRowHeader(objects []fyne.CanvasObject) fyne.CanvasObject {
+	if len(objects) < 2 || !objects[1].Visible() {
+		return nil
+	}
+
+	return objects[1]
 }
 
 func chatRowWidth(totalWidth, minWidth float32) float32 {
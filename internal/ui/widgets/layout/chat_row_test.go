@@ -34,3 +34,35 @@ func TestChatRowLayoutAlignRight(t *testing.T) {
 		t.Fatalf("expected right-aligned widget to not be at x=0")
 	}
 }
+
+func TestChatRowLayoutHeader(t *testing.T) {
+	app := fynetest.NewApp()
+	t.Cleanup(app.Quit)
+
+	layout := NewChatRowLayout(true)
+	bubble := widget.NewLabel("message")
+	header := widget.NewLabel("Today")
+	objects := []fyne.CanvasObject{bubble, header}
+
+	min := layout.MinSize(objects)
+	if want := bubble.MinSize().Height + header.MinSize().Height; min.Height != want {
+		t.Fatalf("expected min height %v, got %v", want, min.Height)
+	}
+	size := fyne.NewSize(300, min.Height)
+	layout.Layout(objects, size)
+	if header.Position().X != 0 || header.Size().Width != size.Width {
+		t.Fatalf("expected full width header, got position %v size %v", header.Position(), header.Size())
+	}
+	if bubble.Position().Y != header.MinSize().Height {
+		t.Fatalf("expected bubble below the header at %v, got %v", header.MinSize().Height, bubble.Position().Y)
+	}
+
+	header.Hide()
+	if got := layout.MinSize(objects); got != bubble.MinSize() {
+		t.Fatalf("expected hidden header to be ignored, got %v", got)
+	}
+	layout.Layout(objects, size)
+	if bubble.Position().Y != 0 {
+		t.Fatalf("expected bubble at the top without header, got %v", bubble.Position().Y)
+	}
+}
@@ -448,6 +448,37 @@ func (t *MapTileCacheTransport) evictIfNeededLocked() {
 	mapTileCacheLogger.Debug("map tile cache eviction completed", "remaining_bytes", totalSize, "max_bytes", t.MaxBytes)
 }
 
+// SetMaxBytes changes the cache size cap and evicts the oldest tiles right away when
+// the cache is over the new cap.
+func (t *MapTileCacheTransport) SetMaxBytes(maxBytes int64) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMapTileCacheSizeBytes
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.MaxBytes == maxBytes {
+		return
+	}
+	mapTileCacheLogger.Info("changing map tile cache size cap", "from_bytes", t.MaxBytes, "to_bytes", maxBytes)
+	t.MaxBytes = maxBytes
+	if t.CacheDir != "" {
+		t.evictIfNeededLocked()
+	}
+}
+
+// SetMapTileClientMaxBytes changes the cache size cap of a client built by NewMapTileHTTPClient.
+func SetMapTileClientMaxBytes(client *http.Client, maxBytes int64) {
+	if client == nil {
+		return
+	}
+	transport, ok := client.Transport.(*MapTileCacheTransport)
+	if !ok || transport == nil {
+		return
+	}
+	transport.SetMaxBytes(maxBytes)
+}
+
 // SetMapTileClientAsyncCachedCallback sets a callback for when async tile caching completes.
 func SetMapTileClientAsyncCachedCallback(client *http.Client, callback func()) {
 	if client == nil {
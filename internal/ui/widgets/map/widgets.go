@@ -31,6 +31,8 @@ type MapMarkerWidget struct {
 	manager       *widgets.HoverTooltipManager
 	hovered       bool
 	onHoverChange func(hovered bool)
+	onTap         func(*fyne.PointEvent)
+	onSecondary   func(*fyne.PointEvent)
 }
 
 var _ desktop.Hoverable = (*MapMarkerWidget)(nil)
@@ -74,11 +76,21 @@ func (m *MapMarkerWidget) MouseOut() {
 	}
 }
 
-func (m *MapMarkerWidget) Tapped(*fyne.PointEvent) {
+func (m *MapMarkerWidget) Tapped(event *fyne.PointEvent) {
+	if m.onTap != nil {
+		m.onTap(event)
+
+		return
+	}
 	m.showTooltip()
 }
 
-func (m *MapMarkerWidget) TappedSecondary(*fyne.PointEvent) {
+func (m *MapMarkerWidget) TappedSecondary(event *fyne.PointEvent) {
+	if m.onSecondary != nil {
+		m.onSecondary(event)
+
+		return
+	}
 	m.showTooltip()
 }
 
@@ -98,6 +110,22 @@ func (m *MapMarkerWidget) SetHoverChangeHandler(handler func(hovered bool)) {
 	m.onHoverChange = handler
 }
 
+// SetTapHandler replaces the tooltip shown on a tap with handler.
+func (m *MapMarkerWidget) SetTapHandler(handler func(*fyne.PointEvent)) {
+	if m == nil {
+		return
+	}
+	m.onTap = handler
+}
+
+// SetSecondaryTapHandler replaces the tooltip shown on a secondary tap with handler.
+func (m *MapMarkerWidget) SetSecondaryTapHandler(handler func(*fyne.PointEvent)) {
+	if m == nil {
+		return
+	}
+	m.onSecondary = handler
+}
+
 func (m *MapMarkerWidget) SetHovered(hovered bool) {
 	if m == nil || m.hovered == hovered {
 		return
@@ -134,13 +162,17 @@ func (m *MapMarkerWidget) SetHovered(hovered bool) {
 type MapInteractionLayer struct {
 	widget.BaseWidget
 
-	onScroll func(*fyne.ScrollEvent)
-	onDrag   func(fyne.Position, fyne.Delta)
-	bg       *canvas.Rectangle
+	onScroll    func(*fyne.ScrollEvent)
+	onDrag      func(fyne.Position, fyne.Delta)
+	onTap       func(*fyne.PointEvent)
+	onSecondary func(*fyne.PointEvent)
+	bg          *canvas.Rectangle
 }
 
 var _ fyne.Scrollable = (*MapInteractionLayer)(nil)
 var _ fyne.Draggable = (*MapInteractionLayer)(nil)
+var _ fyne.Tappable = (*MapInteractionLayer)(nil)
+var _ fyne.SecondaryTappable = (*MapInteractionLayer)(nil)
 
 // NewMapInteractionLayer creates a new interaction layer with the specified scroll and drag handlers.
 func NewMapInteractionLayer(onScroll func(*fyne.ScrollEvent), onDrag func(fyne.Position, fyne.Delta)) *MapInteractionLayer {
@@ -173,6 +205,38 @@ func (l *MapInteractionLayer) Dragged(event *fyne.DragEvent) {
 
 func (l *MapInteractionLayer) DragEnd() {}
 
+// SetTapHandler sets the handler of taps on the map.
+func (l *MapInteractionLayer) SetTapHandler(handler func(*fyne.PointEvent)) {
+	if l == nil {
+		return
+	}
+	l.onTap = handler
+}
+
+func (l *MapInteractionLayer) Tapped(event *fyne.PointEvent) {
+	if l == nil || l.onTap == nil || event == nil {
+		return
+	}
+
+	l.onTap(event)
+}
+
+// SetSecondaryTapHandler sets the handler of secondary taps on the map.
+func (l *MapInteractionLayer) SetSecondaryTapHandler(handler func(*fyne.PointEvent)) {
+	if l == nil {
+		return
+	}
+	l.onSecondary = handler
+}
+
+func (l *MapInteractionLayer) TappedSecondary(event *fyne.PointEvent) {
+	if l == nil || l.onSecondary == nil || event == nil {
+		return
+	}
+
+	l.onSecondary(event)
+}
+
 func (l *MapInteractionLayer) MinSize() fyne.Size {
 	return fyne.NewSize(1, 1)
 }
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"log/slog"
+	"strings"
+
+	"fyne.io/fyne/v2"
+
+	meshapp "github.com/skobkin/meshgo/internal/app"
+	"github.com/skobkin/meshgo/internal/platform"
+)
+
+var windowStateLogger = slog.With("component", "ui.window_state")
+
+// defaultWindowSize is the size of the main window on the first launch.
+var defaultWindowSize = fyne.NewSize(1000, 700)
+
+// minRestoredWindowSize keeps a broken saved size from restoring an unusable window.
+var minRestoredWindowSize = fyne.NewSize(480, 320)
+
+// windowStateKeeper saves the main window geometry and selected tab next to the config
+// and restores them on launch. The position and maximized state are only kept where
+// the platform can read and move windows.
+type windowStateKeeper struct {
+	path      string
+	window    fyne.Window
+	placement platform.WindowPlacement
+	state     meshapp.WindowState
+}
+
+func newWindowStateKeeper(dep RuntimeDependencies, window fyne.Window) *windowStateKeeper {
+	keeper := &windowStateKeeper{path: strings.TrimSpace(dep.Data.Paths.WindowStateFile), window: window}
+	if keeper.path != "" {
+		state, err := meshapp.LoadWindowState(keeper.path)
+		if err != nil {
+			windowStateLogger.Warn("load window state failed", "path", keeper.path, "error", err)
+		}
+		keeper.state = state
+	}
+	if dep.Platform.NewWindowPlacement != nil {
+		placement, err := dep.Platform.NewWindowPlacement()
+		if err != nil {
+			windowStateLogger.Info("window position will not be restored", "error", err)
+		} else {
+			keeper.placement = placement
+		}
+	}
+
+	return keeper
+}
+
+// RestoreSize resizes the window to the saved size, or to the default one.
+func (k *windowStateKeeper) RestoreSize() {
+	k.window.Resize(restoredWindowSize(k.state))
+}
+
+// RestoreTab switches to the tab selected when the app was last closed.
+func (k *windowStateKeeper) RestoreTab(switchTab func(name string)) {
+	if tab := strings.TrimSpace(k.state.SelectedTab); tab != "" && switchTab != nil {
+		switchTab(tab)
+	}
+}
+
+// RestorePlacement moves the window back to its saved position and maximizes it again.
+// It must run on the UI goroutine after the window is shown.
+func (k *windowStateKeeper) RestorePlacement() {
+	if k.placement == nil || k.state.Bounds == nil {
+		return
+	}
+	if err := k.placement.Set(nativeWindowHandle(k.window), *k.state.Bounds, k.state.Maximized); err != nil {
+		windowStateLogger.Debug("restore window placement failed", "error", err)
+	}
+}
+
+// Save records the current geometry and activeTab. It must run on the UI goroutine.
+func (k *windowStateKeeper) Save(activeTab string) {
+	if k.path == "" {
+		return
+	}
+	maximized := false
+	if k.placement != nil {
+		bounds, isMaximized, err := k.placement.Get(nativeWindowHandle(k.window))
+		if err != nil {
+			windowStateLogger.Debug("read window placement failed", "error", err)
+		} else {
+			k.state.Bounds = &bounds
+			maximized = isMaximized
+		}
+	}
+	k.state.Maximized = maximized
+	// A maximized window has the size of the screen; keep the size it is restored to.
+	if size := k.window.Canvas().Size(); !maximized && size.Width > 0 && size.Height > 0 {
+		k.state.Width = size.Width
+		k.state.Height = size.Height
+	}
+	if tab := strings.TrimSpace(activeTab); tab != "" {
+		k.state.SelectedTab = tab
+	}
+	if err := meshapp.SaveWindowState(k.path, k.state); err != nil {
+		windowStateLogger.Warn("save window state failed", "path", k.path, "error", err)
+	}
+}
+
+func restoredWindowSize(state meshapp.WindowState) fyne.Size {
+	if state.Width <= 0 || state.Height <= 0 {
+		return defaultWindowSize
+	}
+
+	return fyne.NewSize(
+		max(state.Width, minRestoredWindowSize.Width),
+		max(state.Height, minRestoredWindowSize.Height),
+	)
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	fynetest "fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+
+	meshapp "github.com/skobkin/meshgo/internal/app"
+)
+
+func TestRestoredWindowSize(t *testing.T) {
+	tests := []struct {
+		name  string
+		state meshapp.WindowState
+		want  fyne.Size
+	}{
+		{name: "first launch", state: meshapp.WindowState{}, want: defaultWindowSize},
+		{name: "saved size", state: meshapp.WindowState{Width: 1280, Height: 900}, want: fyne.NewSize(1280, 900)},
+		{name: "too small", state: meshapp.WindowState{Width: 100, Height: 50}, want: minRestoredWindowSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := restoredWindowSize(tt.state); got != tt.want {
+				t.Fatalf("unexpected window size: expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWindowStateKeeperSavesAndRestores(t *testing.T) {
+	path := filepath.Join(t.TempDir(), meshapp.WindowStateFilename)
+	dep := RuntimeDependencies{Data: DataDependencies{Paths: meshapp.Paths{WindowStateFile: path}}}
+	window := fynetest.NewTempWindow(t, widget.NewLabel("content"))
+	window.Resize(fyne.NewSize(900, 640))
+
+	newWindowStateKeeper(dep, window).Save("Map")
+
+	restored := newWindowStateKeeper(dep, window)
+	if got := restored.state.SelectedTab; got != "Map" {
+		t.Fatalf("unexpected selected tab: expected %q, got %q", "Map", got)
+	}
+	var switchedTo string
+	restored.RestoreTab(func(name string) { switchedTo = name })
+	if switchedTo != "Map" {
+		t.Fatalf("unexpected restored tab: expected %q, got %q", "Map", switchedTo)
+	}
+	if got := restoredWindowSize(restored.state); got != window.Canvas().Size() {
+		t.Fatalf("unexpected restored size: expected %v, got %v", window.Canvas().Size(), got)
+	}
+}